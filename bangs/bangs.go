@@ -23,6 +23,7 @@ type Bang struct {
 	FavIcon   string            `json:"favicon"`
 	Triggers  []string          `json:"triggers"`
 	Regions   map[string]string `json:"regions"`
+	Disabled  bool              `json:"disabled,omitempty"`
 	Functions []string          `json:"-"`
 	Funcs     []fn              `json:"-"`
 }
@@ -79,19 +80,21 @@ func (b *Bangs) Suggest(term string, size int) (Results, error) {
 		return res, err
 	}
 
-	// fill in the rest of the suggestion
-	for i, s := range res.Suggestions {
+	// fill in the rest of the suggestion. The index can have
+	// triggers of !bangs that have since been disabled or edited.
+	suggestions := res.Suggestions[:0]
+	for _, s := range res.Suggestions {
 		for _, bng := range b.Bangs {
-			for _, trigger := range bng.Triggers {
-				if trigger == s.Trigger {
-					s.Name = bng.Name
-					s.FavIcon = bng.FavIcon
-					res.Suggestions[i] = s
-				}
+			if trigger(s.Trigger, bng.Triggers) {
+				s.Name = bng.Name
+				s.FavIcon = bng.FavIcon
+				suggestions = append(suggestions, s)
+				break
 			}
 		}
 	}
 
+	res.Suggestions = suggestions
 	return res, err
 }
 
@@ -149,6 +152,8 @@ func trigger(k string, triggers []string) bool {
 // Is a workaround since I couldn't find a way to map a function type in a config file.
 func (b *Bangs) CreateFunctions() error {
 	for i, bng := range b.Bangs {
+		b.Bangs[i].Funcs = nil
+
 		for _, f := range bng.Functions {
 			var ff fn
 
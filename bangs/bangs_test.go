@@ -204,6 +204,50 @@ func TestDetect(t *testing.T) {
 				ok:  true,
 			},
 		},
+		{
+			q: "golang slice !g", r: "US", l: language.English,
+			want: data{
+				b: Bang{
+					Name:     "Google",
+					FavIcon:  "https://www.google.com/favicon.ico",
+					Triggers: []string{"g", "google"},
+					Regions: map[string]string{
+						"ru":      "https://www.google.ru/search?hl={{{lang}}}&q={{{term}}}",
+						"default": "https://encrypted.google.com/search?hl={{{lang}}}&q={{{term}}}",
+						"ca":      "https://www.google.ca/search?q={{{term}}}",
+						"fr":      "https://www.google.fr/search?hl={{{lang}}}&q={{{term}}}",
+					},
+				},
+				loc: "https://encrypted.google.com/search?hl=en&q=golang+slice",
+				ok:  true,
+			},
+		},
+		{
+			q: "golang !g slice", r: "US", l: language.English,
+			want: data{
+				b: Bang{
+					Name:     "Google",
+					FavIcon:  "https://www.google.com/favicon.ico",
+					Triggers: []string{"g", "google"},
+					Regions: map[string]string{
+						"ru":      "https://www.google.ru/search?hl={{{lang}}}&q={{{term}}}",
+						"default": "https://encrypted.google.com/search?hl={{{lang}}}&q={{{term}}}",
+						"ca":      "https://www.google.ca/search?q={{{term}}}",
+						"fr":      "https://www.google.fr/search?hl={{{lang}}}&q={{{term}}}",
+					},
+				},
+				loc: "https://encrypted.google.com/search?hl=en&q=golang+slice",
+				ok:  true,
+			},
+		},
+		{
+			q: "!! golang slice", r: "US", l: language.English,
+			want: data{
+				b:   Bang{},
+				loc: "",
+				ok:  false,
+			},
+		},
 		{
 			q: "nonexistent! some query", r: "US", l: language.French,
 			want: data{
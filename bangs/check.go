@@ -0,0 +1,149 @@
+package bangs
+
+import (
+	"net/http"
+	"net/url"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/jivesearch/jivesearch/log"
+)
+
+// Check is what we found when we requested one of a !bang's urls
+type Check struct {
+	Region     string    `json:"region"`
+	URL        string    `json:"url"`
+	Status     int       `json:"status,omitempty"`
+	Final      string    `json:"final,omitempty"` // where we ended up if it redirected to another site
+	Dead       bool      `json:"dead"`
+	Redirected bool      `json:"redirected"`
+	Err        string    `json:"error,omitempty"`
+	Checked    time.Time `json:"checked"`
+}
+
+// Checker periodically requests the url of each !bang to find those that are dead or
+// now redirect to another site. The urls are requested with a sample query.
+type Checker struct {
+	Client    *http.Client
+	UserAgent string
+	Interval  time.Duration
+	Delay     time.Duration // between requests so we don't hammer anyone
+	mu        sync.Mutex
+	checks    map[string][]Check // by !bang name
+}
+
+// checkTerm is the query we send with each !bang
+const checkTerm = "test"
+
+// Run checks the !bangs every Interval. It doesn't return.
+func (c *Checker) Run(bangs func() ([]Bang, error)) {
+	for {
+		bngs, err := bangs()
+		if err != nil {
+			log.Info.Println(err)
+		}
+
+		for _, b := range bngs {
+			checks := c.Check(b)
+
+			c.mu.Lock()
+			if c.checks == nil {
+				c.checks = make(map[string][]Check)
+			}
+			c.checks[b.Name] = checks
+			c.mu.Unlock()
+		}
+
+		time.Sleep(c.Interval)
+	}
+}
+
+// Checks are the latest checks of a !bang's urls
+func (c *Checker) Checks(name string) []Check {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return c.checks[name]
+}
+
+// Check requests each of the !bang's urls
+func (c *Checker) Check(b Bang) []Check {
+	regions := []string{}
+	for region := range b.Regions {
+		regions = append(regions, region)
+	}
+	sort.Strings(regions)
+
+	checks := []Check{}
+	for i, region := range regions {
+		if i > 0 {
+			time.Sleep(c.Delay)
+		}
+
+		u := strings.Replace(b.Regions[region], "{{{term}}}", checkTerm, -1)
+		u = strings.Replace(u, "{{{lang}}}", "en", -1)
+		checks = append(checks, c.check(region, u))
+	}
+
+	return checks
+}
+
+func (c *Checker) check(region, u string) Check {
+	chk := Check{
+		Region:  region,
+		URL:     u,
+		Checked: time.Now(),
+	}
+
+	resp, err := c.request("HEAD", u)
+	if err == nil && (resp.StatusCode == http.StatusMethodNotAllowed || resp.StatusCode == http.StatusNotImplemented) {
+		resp, err = c.request("GET", u) // not every site answers a HEAD
+	}
+
+	if err != nil {
+		chk.Dead, chk.Err = true, err.Error()
+		return chk
+	}
+
+	chk.Status = resp.StatusCode
+	chk.Dead = resp.StatusCode == http.StatusNotFound || resp.StatusCode == http.StatusGone || resp.StatusCode >= 500
+
+	if final := resp.Request.URL; !sameSite(final.Host, u) {
+		chk.Redirected, chk.Final = true, final.String()
+	}
+
+	return chk
+}
+
+func (c *Checker) request(method, u string) (*http.Response, error) {
+	req, err := http.NewRequest(method, u, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Set("User-Agent", c.UserAgent)
+
+	resp, err := c.Client.Do(req) // follows redirects
+	if err != nil {
+		return nil, err
+	}
+
+	resp.Body.Close()
+	return resp, nil
+}
+
+// sameSite is true if the host is that of the url, give or take a "www."
+func sameSite(host, u string) bool {
+	pu, err := url.Parse(u)
+	if err != nil {
+		return false
+	}
+
+	trim := func(h string) string {
+		return strings.TrimPrefix(strings.ToLower(h), "www.")
+	}
+
+	return trim(host) == trim(pu.Host)
+}
@@ -0,0 +1,79 @@
+package bangs
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestCheck(t *testing.T) {
+	moved := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	defer moved.Close()
+
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/ok":
+			if r.URL.Query().Get("q") != checkTerm {
+				t.Errorf("got query %q; want %q", r.URL.Query().Get("q"), checkTerm)
+			}
+		case "/head":
+			if r.Method == "HEAD" {
+				w.WriteHeader(http.StatusMethodNotAllowed)
+			}
+		case "/moved":
+			http.Redirect(w, r, moved.URL+"/elsewhere", http.StatusMovedPermanently)
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer ts.Close()
+
+	c := &Checker{Client: ts.Client()}
+
+	b := Bang{
+		Name: "Test",
+		Regions: map[string]string{
+			"default": ts.URL + "/ok?q={{{term}}}",
+			"de":      ts.URL + "/head?q={{{term}}}",
+			"fr":      ts.URL + "/gone?q={{{term}}}",
+			"uk":      ts.URL + "/moved?q={{{term}}}",
+			"us":      "http://127.0.0.1:0/?q={{{term}}}",
+		},
+	}
+
+	checks := c.Check(b)
+	if len(checks) != 5 {
+		t.Fatalf("got %d checks; want 5", len(checks))
+	}
+
+	for _, c := range []struct {
+		region     string
+		status     int
+		dead       bool
+		redirected bool
+	}{
+		{"de", http.StatusOK, false, false},
+		{"default", http.StatusOK, false, false},
+		{"fr", http.StatusNotFound, true, false},
+		{"uk", http.StatusOK, false, true},
+		{"us", 0, true, false},
+	} {
+		t.Run(c.region, func(t *testing.T) {
+			var got Check
+			for _, chk := range checks {
+				if chk.Region == c.region {
+					got = chk
+				}
+			}
+
+			if got.Status != c.status || got.Dead != c.dead || got.Redirected != c.redirected {
+				t.Fatalf("got %+v; want status %d, dead %v and redirected %v", got, c.status, c.dead, c.redirected)
+			}
+
+			if c.redirected && !strings.HasSuffix(got.Final, "/elsewhere") {
+				t.Fatalf("got final url %q; want the site we were redirected to", got.Final)
+			}
+		})
+	}
+}
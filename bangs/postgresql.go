@@ -0,0 +1,93 @@
+package bangs
+
+import (
+	"database/sql"
+	"encoding/json"
+	"strings"
+)
+
+// PostgreSQL stores the edited !bangs in PostgreSQL
+type PostgreSQL struct {
+	*sql.DB
+}
+
+const bangsTable = "bangs"
+
+// Setup creates our table if it doesn't exist
+func (p *PostgreSQL) Setup() error {
+	_, err := p.DB.Exec(`
+		CREATE TABLE IF NOT EXISTS ` + bangsTable + ` (
+			name text PRIMARY KEY,
+			favicon text NOT NULL,
+			triggers text NOT NULL,
+			regions text NOT NULL,
+			functions text NOT NULL,
+			disabled boolean NOT NULL DEFAULT false
+		);
+	`)
+
+	return err
+}
+
+// List returns the edited !bangs by name
+func (p *PostgreSQL) List() ([]Bang, error) {
+	rows, err := p.DB.Query(`
+		SELECT name, favicon, triggers, regions, functions, disabled
+		FROM ` + bangsTable + ` ORDER BY name`,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	defer rows.Close()
+
+	bngs := []Bang{}
+	for rows.Next() {
+		var b Bang
+		var triggers, regions, functions string
+		if err := rows.Scan(&b.Name, &b.FavIcon, &triggers, &regions, &functions, &b.Disabled); err != nil {
+			return nil, err
+		}
+
+		if err := json.Unmarshal([]byte(regions), &b.Regions); err != nil {
+			return nil, err
+		}
+
+		b.Triggers, b.Functions = split(triggers), split(functions)
+		bngs = append(bngs, b)
+	}
+
+	return bngs, rows.Err()
+}
+
+// Save adds or replaces a !bang
+func (p *PostgreSQL) Save(b Bang) error {
+	regions, err := json.Marshal(b.Regions)
+	if err != nil {
+		return err
+	}
+
+	_, err = p.DB.Exec(`
+		INSERT INTO `+bangsTable+` (name, favicon, triggers, regions, functions, disabled)
+		VALUES ($1, $2, $3, $4, $5, $6)
+		ON CONFLICT (name) DO UPDATE SET
+			favicon = EXCLUDED.favicon, triggers = EXCLUDED.triggers, regions = EXCLUDED.regions,
+			functions = EXCLUDED.functions, disabled = EXCLUDED.disabled`,
+		b.Name, b.FavIcon, strings.Join(b.Triggers, ","), string(regions), strings.Join(b.Functions, ","), b.Disabled,
+	)
+
+	return err
+}
+
+// Delete removes a !bang
+func (p *PostgreSQL) Delete(name string) error {
+	_, err := p.DB.Exec(`DELETE FROM `+bangsTable+` WHERE lower(name) = lower($1)`, name)
+	return err
+}
+
+func split(s string) []string {
+	if s == "" {
+		return nil
+	}
+	return strings.Split(s, ",")
+}
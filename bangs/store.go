@@ -0,0 +1,167 @@
+package bangs
+
+import (
+	"fmt"
+	"net/url"
+	"regexp"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// Store persists the !bangs an admin has added, edited or disabled.
+// A !bang in the store replaces the one of the same name in our config file.
+type Store interface {
+	Setup() error
+	List() ([]Bang, error)
+	Save(b Bang) error
+	Delete(name string) error // reverts to our config file
+}
+
+// Merge applies the edits to the !bangs of our config file. Disabled !bangs are kept.
+func Merge(bngs, edits []Bang) []Bang {
+	edited := map[string]Bang{}
+	for _, e := range edits {
+		edited[strings.ToLower(e.Name)] = e
+	}
+
+	merged := []Bang{}
+	for _, b := range bngs {
+		k := strings.ToLower(b.Name)
+		if e, ok := edited[k]; ok {
+			b = e
+			delete(edited, k)
+		}
+		merged = append(merged, b)
+	}
+
+	added := []Bang{}
+	for _, e := range edited {
+		added = append(added, e)
+	}
+
+	sort.Slice(added, func(i, j int) bool { return added[i].Name < added[j].Name })
+	return append(merged, added...)
+}
+
+// Edit returns a copy of our !bangs with the edits in the store and without those disabled.
+// The copy shares our Suggester.
+func (b *Bangs) Edit(s Store) (*Bangs, error) {
+	if s == nil {
+		return b, nil
+	}
+
+	edits, err := s.List()
+	if err != nil {
+		return nil, err
+	}
+
+	nb := &Bangs{
+		Suggester: b.Suggester,
+	}
+
+	for _, bng := range Merge(b.Bangs, edits) {
+		if !bng.Disabled {
+			nb.Bangs = append(nb.Bangs, bng)
+		}
+	}
+
+	err = nb.CreateFunctions()
+	return nb, err
+}
+
+var validTrigger = regexp.MustCompile(`^[a-z0-9][a-z0-9._-]*$`)
+
+// Validate checks a !bang before it is saved. Its triggers can't be those of another !bang that is enabled.
+func Validate(b Bang, bngs []Bang) error {
+	if strings.TrimSpace(b.Name) == "" {
+		return fmt.Errorf("a !bang needs a name")
+	}
+
+	if len(b.Triggers) == 0 {
+		return fmt.Errorf("%q needs a trigger", b.Name)
+	}
+
+	for _, t := range b.Triggers {
+		if !validTrigger.MatchString(t) {
+			return fmt.Errorf("invalid trigger %q", t)
+		}
+
+		for _, other := range bngs {
+			if !other.Disabled && !strings.EqualFold(other.Name, b.Name) && trigger(t, other.Triggers) {
+				return fmt.Errorf("%q is already the trigger of %q", t, other.Name)
+			}
+		}
+	}
+
+	if _, ok := b.Regions[def]; !ok {
+		return fmt.Errorf("%q needs a default region", b.Name)
+	}
+
+	for region, u := range b.Regions {
+		if !strings.Contains(u, "{{{term}}}") {
+			return fmt.Errorf("the %v url of %q needs {{{term}}}", region, b.Name)
+		}
+
+		pu, err := url.Parse(u)
+		if err != nil || (pu.Scheme != "http" && pu.Scheme != "https") || pu.Host == "" {
+			return fmt.Errorf("invalid %v url for %q", region, b.Name)
+		}
+	}
+
+	for _, f := range b.Functions {
+		if f != "wikipediaCanonical" {
+			return fmt.Errorf("unknown function string %v", f)
+		}
+	}
+
+	return nil
+}
+
+// SimpleStore is an in-memory Store. Edits are lost on restart so it is only suitable for testing.
+type SimpleStore struct {
+	mu    sync.Mutex
+	bangs map[string]Bang // by lowercase name
+}
+
+// Setup initializes the store
+func (s *SimpleStore) Setup() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.bangs = make(map[string]Bang)
+	return nil
+}
+
+// List returns the edited !bangs by name
+func (s *SimpleStore) List() ([]Bang, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	bngs := []Bang{}
+	for _, b := range s.bangs {
+		bngs = append(bngs, b)
+	}
+
+	sort.Slice(bngs, func(i, j int) bool { return bngs[i].Name < bngs[j].Name })
+	return bngs, nil
+}
+
+// Save adds or replaces a !bang
+func (s *SimpleStore) Save(b Bang) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	b.Funcs = nil
+	s.bangs[strings.ToLower(b.Name)] = b
+	return nil
+}
+
+// Delete removes a !bang
+func (s *SimpleStore) Delete(name string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	delete(s.bangs, strings.ToLower(name))
+	return nil
+}
@@ -0,0 +1,148 @@
+package bangs
+
+import (
+	"reflect"
+	"testing"
+
+	"golang.org/x/text/language"
+	sqlmock "gopkg.in/DATA-DOG/go-sqlmock.v2"
+)
+
+var amazon = Bang{
+	Name:     "Amazon",
+	Triggers: []string{"a", "amazon"},
+	Regions:  map[string]string{def: "https://www.amazon.com/s?k={{{term}}}"},
+}
+
+var wikipedia = Bang{
+	Name:      "Wikipedia",
+	Triggers:  []string{"w"},
+	Regions:   map[string]string{def: "https://{{{lang}}}.wikipedia.org/wiki/{{{term}}}"},
+	Functions: []string{"wikipediaCanonical"},
+}
+
+func TestEdit(t *testing.T) {
+	b := &Bangs{Bangs: []Bang{amazon, wikipedia}}
+	if err := b.CreateFunctions(); err != nil {
+		t.Fatal(err)
+	}
+
+	s := &SimpleStore{}
+	if err := s.Setup(); err != nil {
+		t.Fatal(err)
+	}
+
+	amazonDE := amazon
+	amazonDE.Regions = map[string]string{def: amazon.Regions[def], "de": "https://www.amazon.de/s?k={{{term}}}"}
+
+	disabled := wikipedia
+	disabled.Disabled = true
+
+	ddg := Bang{Name: "DuckDuckGo", Triggers: []string{"ddg"}, Regions: map[string]string{def: "https://duckduckgo.com/?q={{{term}}}"}}
+
+	for _, e := range []Bang{amazonDE, disabled, ddg} {
+		if err := s.Save(e); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	nb, err := b.Edit(s)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, c := range []struct {
+		q    string
+		want string
+	}{
+		{"!a shoes", "https://www.amazon.de/s?k=shoes"},
+		{"!ddg jive", "https://duckduckgo.com/?q=jive"},
+		{"!w jive", ""},
+	} {
+		t.Run(c.q, func(t *testing.T) {
+			_, got, _ := nb.Detect(c.q, language.MustParseRegion("DE"), language.German)
+			if got != c.want {
+				t.Fatalf("got %q; want %q", got, c.want)
+			}
+		})
+	}
+
+	if _, got, _ := b.Detect("!w jive talkin", language.MustParseRegion("US"), language.English); got != "https://en.wikipedia.org/wiki/Jive_Talkin" {
+		t.Fatalf("the !bangs of our config file changed: got %q", got)
+	}
+
+	if err := s.Delete("amazon"); err != nil {
+		t.Fatal(err)
+	}
+
+	edits, _ := s.List()
+	want := []Bang{amazon, disabled, ddg}
+	if got := Merge([]Bang{amazon, wikipedia}, edits); !reflect.DeepEqual(got, want) {
+		t.Fatalf("got %+v; want %+v", got, want)
+	}
+}
+
+func TestValidate(t *testing.T) {
+	for _, c := range []struct {
+		name string
+		b    Bang
+		ok   bool
+	}{
+		{"valid", Bang{Name: "DuckDuckGo", Triggers: []string{"ddg"}, Regions: map[string]string{def: "https://duckduckgo.com/?q={{{term}}}"}}, true},
+		{"edit", Bang{Name: "amazon", Triggers: []string{"a"}, Regions: amazon.Regions}, true},
+		{"no name", Bang{Triggers: []string{"ddg"}, Regions: map[string]string{def: "https://duckduckgo.com/?q={{{term}}}"}}, false},
+		{"no trigger", Bang{Name: "DuckDuckGo", Regions: map[string]string{def: "https://duckduckgo.com/?q={{{term}}}"}}, false},
+		{"taken", Bang{Name: "Other", Triggers: []string{"amazon"}, Regions: map[string]string{def: "https://other.com/?q={{{term}}}"}}, false},
+		{"bad trigger", Bang{Name: "Other", Triggers: []string{"o o"}, Regions: map[string]string{def: "https://other.com/?q={{{term}}}"}}, false},
+		{"no default", Bang{Name: "Other", Triggers: []string{"o"}, Regions: map[string]string{"de": "https://other.de/?q={{{term}}}"}}, false},
+		{"no term", Bang{Name: "Other", Triggers: []string{"o"}, Regions: map[string]string{def: "https://other.com/"}}, false},
+		{"bad url", Bang{Name: "Other", Triggers: []string{"o"}, Regions: map[string]string{def: "javascript:{{{term}}}"}}, false},
+	} {
+		t.Run(c.name, func(t *testing.T) {
+			if err := Validate(c.b, []Bang{amazon, wikipedia}); (err == nil) != c.ok {
+				t.Fatalf("got %v; want ok %v", err, c.ok)
+			}
+		})
+	}
+}
+
+func TestPostgreSQL(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+
+	p := &PostgreSQL{DB: db}
+
+	mock.ExpectExec("INSERT INTO bangs (.+) ON CONFLICT").
+		WithArgs("Wikipedia", "", "w", `{"default":"https://{{{lang}}}.wikipedia.org/wiki/{{{term}}}"}`, "wikipediaCanonical", false).
+		WillReturnResult(sqlmock.NewResult(1, 1))
+
+	if err := p.Save(wikipedia); err != nil {
+		t.Fatal(err)
+	}
+
+	cols := []string{"name", "favicon", "triggers", "regions", "functions", "disabled"}
+	mock.ExpectQuery("SELECT (.+) FROM bangs").
+		WillReturnRows(sqlmock.NewRows(cols).AddRow("Wikipedia", "", "w", `{"default":"https://{{{lang}}}.wikipedia.org/wiki/{{{term}}}"}`, "wikipediaCanonical", false))
+
+	got, err := p.List()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if want := []Bang{wikipedia}; !reflect.DeepEqual(got, want) {
+		t.Fatalf("got %+v; want %+v", got, want)
+	}
+
+	mock.ExpectExec("DELETE FROM bangs").WithArgs("wikipedia").WillReturnResult(sqlmock.NewResult(0, 1))
+
+	if err := p.Delete("wikipedia"); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Fatal(err)
+	}
+}
@@ -0,0 +1,59 @@
+package bangs
+
+import (
+	"sort"
+	"sync"
+	"time"
+)
+
+// Usage counts the redirects of each !bang since we started.
+// Only the name of the !bang is counted, nothing about the user or their query.
+type Usage struct {
+	mu     sync.Mutex
+	counts map[string]int64
+	Since  time.Time
+}
+
+// NewUsage starts counting
+func NewUsage(since time.Time) *Usage {
+	return &Usage{
+		counts: make(map[string]int64),
+		Since:  since,
+	}
+}
+
+// Count counts a redirect
+func (u *Usage) Count(name string) {
+	u.mu.Lock()
+	defer u.mu.Unlock()
+
+	u.counts[name]++
+}
+
+// Redirects is the number of redirects of a !bang
+func (u *Usage) Redirects(name string) int64 {
+	u.mu.Lock()
+	defer u.mu.Unlock()
+
+	return u.counts[name]
+}
+
+// Top returns the names of the !bangs by their redirects, most first
+func (u *Usage) Top() []string {
+	u.mu.Lock()
+	defer u.mu.Unlock()
+
+	names := []string{}
+	for name := range u.counts {
+		names = append(names, name)
+	}
+
+	sort.Slice(names, func(i, j int) bool {
+		if u.counts[names[i]] == u.counts[names[j]] {
+			return names[i] < names[j]
+		}
+		return u.counts[names[i]] > u.counts[names[j]]
+	})
+
+	return names
+}
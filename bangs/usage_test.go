@@ -0,0 +1,28 @@
+package bangs
+
+import (
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestUsage(t *testing.T) {
+	u := NewUsage(time.Now())
+
+	for _, name := range []string{"Google", "Amazon", "Google", "Wikipedia", "Google", "Amazon"} {
+		u.Count(name)
+	}
+
+	if got := u.Redirects("Google"); got != 3 {
+		t.Fatalf("got %d redirects; want 3", got)
+	}
+
+	if got := u.Redirects("GitHub"); got != 0 {
+		t.Fatalf("got %d redirects; want 0", got)
+	}
+
+	want := []string{"Google", "Amazon", "Wikipedia"}
+	if got := u.Top(); !reflect.DeepEqual(got, want) {
+		t.Fatalf("got %v; want %v", got, want)
+	}
+}
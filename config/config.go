@@ -3,6 +3,7 @@ package config
 
 import (
 	"fmt"
+	"strings"
 	"time"
 
 	"github.com/spf13/cobra"
@@ -27,6 +28,25 @@ func SetDefaults(cfg Provider) {
 	cfg.SetTypeByDefaultValue(true)
 
 	cfg.SetDefault("hmac.secret", "")
+	// rotated keys as JSON, e.g. [{"id": "2019a", "secret": "...", "not_before": "2019-06-01T00:00:00Z"}].
+	// The newest key in effect signs. The one it replaced is accepted for hmac.grace unless it has a "not_after".
+	cfg.SetDefault("hmac.keys", "")
+	cfg.SetDefault("hmac.grace", 30*24*time.Hour)
+
+	// autocomplete over a WebSocket answers the keystrokes within debounce of each other once.
+	// We hang up once the user stops typing for idle or after max_messages keystrokes.
+	cfg.SetDefault("autocomplete.debounce", 50*time.Millisecond)
+	cfg.SetDefault("autocomplete.idle", time.Minute)
+	cfg.SetDefault("autocomplete.max_messages", 1000)
+
+	// how often we remove queries with personal information (emails, phone numbers, etc) from autocomplete
+	cfg.SetDefault("autocomplete.scrub.interval", 24*time.Hour)
+
+	// image thumbnails are fetched from our proxy in batches
+	cfg.SetDefault("images.batch.concurrency", 10)
+	cfg.SetDefault("images.batch.timeout", 2*time.Second)
+	cfg.SetDefault("admin.token", "") // the admin endpoints are closed unless this is set
+	cfg.SetDefault("config.file", "") // optional. Reloaded on SIGHUP, /admin/reload or when it changes
 
 	// Brand
 	cfg.SetDefault("brand.name", "Jive Search")
@@ -67,10 +87,23 @@ func SetDefaults(cfg Provider) {
 	port := 8000
 	cfg.SetDefault("server.host", fmt.Sprintf("http://127.0.0.1:%d", port))
 
+	// logging. The format is text or json and the level is info or debug.
+	cfg.SetDefault("log.format", "text")
+	cfg.SetDefault("log.level", "info")
+
 	// Frontend Cache
 	cfg.SetDefault("cache.instant", 1*time.Second)
 	cfg.SetDefault("cache.search", 1*time.Second)
 
+	// readiness checks of /readyz. An image url, if set, checks that the image proxy can fetch images.
+	cfg.SetDefault("health.timeout", 2*time.Second)
+	cfg.SetDefault("health.image", "")
+
+	// on SIGTERM we fail /readyz for shutdown.delay so load balancers stop sending
+	// us requests, then wait up to shutdown.timeout for in-flight requests to finish
+	cfg.SetDefault("shutdown.delay", 0*time.Second)
+	cfg.SetDefault("shutdown.timeout", 15*time.Second)
+
 	// languages are in the order of preference
 	// empty slice = all languages
 	// Note: the crawler and frontend packages (for now) don't support language config yet.
@@ -81,6 +114,8 @@ func SetDefaults(cfg Provider) {
 	cfg.SetDefault("elasticsearch.url", "http://127.0.0.1:9200")
 	cfg.SetDefault("elasticsearch.search.index", "test-search")
 	cfg.SetDefault("elasticsearch.search.type", "document")
+	cfg.SetDefault("elasticsearch.search.plugins", []string{}) // e.g. "analysis-kuromoji analysis-nori analysis-smartcn"
+	cfg.SetDefault("elasticsearch.search.compounds", "")       // a word list that splits German compounds
 
 	cfg.SetDefault("elasticsearch.bangs.index", "test-bangs")
 	cfg.SetDefault("elasticsearch.bangs.type", "bang")
@@ -94,6 +129,13 @@ func SetDefaults(cfg Provider) {
 	cfg.SetDefault("elasticsearch.robots.index", "test-robots")
 	cfg.SetDefault("elasticsearch.robots.type", "robots")
 
+	// bulk NDJSON ingestion of pre-crawled or internal documents at /api/v1/documents (opt-in).
+	// Requests need the admin token.
+	cfg.SetDefault("documents.enabled", false)
+	cfg.SetDefault("documents.max_bytes", 10<<20)
+	cfg.SetDefault("documents.max", 1000)  // documents per request
+	cfg.SetDefault("documents.batch", 100) // documents per bulk request to elasticsearch. We stop at the request timeout.
+
 	// PostgreSQL
 	// Note: there is a security concern if postgres password is stored in env variable
 	// but setting it as an env var w/in systemd nullifies this.
@@ -130,17 +172,143 @@ func SetDefaults(cfg Provider) {
 	cfg.SetDefault("crawler.truncate.keywords", 25)
 	cfg.SetDefault("crawler.truncate.description", 250)
 
-	// image nsfw scoring and metadata
+	// index audit settings, e.g. JIVESEARCH_CRAWLER_AUDIT_PURGE=true
+	cfg.SetDefault("crawler.audit.noindex", false) // refetch pages to check for noindex
+	cfg.SetDefault("crawler.audit.purge", false)   // remove non-compliant documents
+	cfg.SetDefault("crawler.audit.delay", time.Second)
+
+	// extra blocklists of adult domains for SafeSearch, e.g. a hosts file
+	cfg.SetDefault("crawler.adult.domains", []string{})
+
+	// image nsfw scoring and metadata: "open_nsfw" (our classifier.py at nsfw.host), "sightengine" or "" to only read the dimensions & EXIF
+	cfg.SetDefault("nsfw.provider", "open_nsfw")
 	cfg.SetDefault("nsfw.host", "http://127.0.0.1:8080")
 	cfg.SetDefault("nsfw.workers", 10)
 	cfg.SetDefault("nsfw.since", now().AddDate(0, -1, 0))
 
+	// Security headers. The CSP's {nonce} is replaced for each response so only our own inline scripts run.
+	// Proxied pages keep their base64 images so need data: and maps load their tiles and workers from MapBox.
+	cfg.SetDefault("security.csp", strings.Join([]string{
+		"default-src 'self'",
+		"script-src 'self' 'nonce-{nonce}' https://buttons.github.io",
+		"style-src 'self' 'unsafe-inline'",
+		"img-src 'self' data: blob: https://*.mapbox.com",
+		"connect-src 'self' https://*.mapbox.com https://api.github.com",
+		"worker-src 'self' blob:",
+		"child-src 'self' blob:",
+		"frame-ancestors 'self'",
+		"object-src 'none'",
+		"base-uri 'self'",
+	}, "; "))
+	cfg.SetDefault("security.csp_report_only", false)
+	cfg.SetDefault("security.hsts", 2*365*24*time.Hour)
+	cfg.SetDefault("security.frame", "SAMEORIGIN") // our proxy frames its own pages
+	cfg.SetDefault("security.referrer", "origin")  // don't send the search query when clicking on a link
+
 	// Tor
 	cfg.SetDefault("onion", "jivexx2rbi6llz37jq37n4uqff4kdipqbqd24c437c56om6uxbzhtdid.onion")
+	cfg.SetDefault("tor.mode", false) // no third-party fetches, referrers or client IPs. Rate limits are shared by all users.
 
 	// ProPublica API
 	cfg.SetDefault("propublica.key", "my_key")
 
+	// JSON API. Keys are issued via /admin/apikeys.
+	cfg.SetDefault("api.keys.required", false) // require a key for API requests not from our own pages
+
+	// CORS lets browser-based clients call the API from other sites, e.g. JIVESEARCH_CORS_ORIGINS="https://example.com".
+	// "*" allows any origin but not with credentials.
+	cfg.SetDefault("cors.origins", []string{})
+	cfg.SetDefault("cors.methods", []string{"GET", "POST"})
+	cfg.SetDefault("cors.headers", []string{"Content-Type", "X-API-Key"})
+	cfg.SetDefault("cors.max_age", 10*time.Minute)
+	cfg.SetDefault("cors.credentials", false)
+
+	// anonymized query log, summarized at /admin/analytics (opt-in).
+	// The salt keeps the user identifiers from being matched to an IP. A random one is used if empty.
+	cfg.SetDefault("analytics.enabled", false)
+	cfg.SetDefault("analytics.salt", "")
+
+	// the queries trending in our analytics are fetched ahead of our users in each locale.
+	// 0 is off. It should be shorter than cache.search so the results don't expire in between.
+	cfg.SetDefault("warm.interval", time.Duration(0))
+	cfg.SetDefault("warm.period", 24*time.Hour)
+	cfg.SetDefault("warm.top", 50)
+	cfg.SetDefault("warm.locales", []string{"en-US", "en-GB", "de-DE", "fr-FR", "es-ES", "ja-JP"})
+
+	// click feedback blended into the ranking of our own index (opt-in). Only a hash of the query,
+	// the result and its position are kept. Keep the salt the same across restarts or the statistics start over.
+	cfg.SetDefault("clicks.enabled", false)
+	cfg.SetDefault("clicks.salt", "")
+	cfg.SetDefault("clicks.weight", .2)       // 0 to 1, how much the click-through rate counts
+	cfg.SetDefault("clicks.impressions", 100) // queries shown fewer times aren't reranked
+
+	// search history that users can turn on for themselves (opt-in). It is sealed with a key
+	// only their browser has and puts their past searches first in autocomplete.
+	cfg.SetDefault("history.enabled", false)
+
+	// the url of each !bang is requested this often to find those that are dead or moved. 0 disables it.
+	cfg.SetDefault("bangs.check.interval", 24*time.Hour)
+	cfg.SetDefault("bangs.check.delay", time.Second) // between requests
+	cfg.SetDefault("bangs.check.timeout", 10*time.Second)
+
+	// results that api key holders bookmark at /api/v1/saved (opt-in). Kept in PostgreSQL or, with
+	// saved.store = "bolt", in the file at saved.path.
+	cfg.SetDefault("saved.enabled", false)
+	cfg.SetDefault("saved.store", "postgresql")
+	cfg.SetDefault("saved.path", "saved.db")
+
+	// local mirrors of malware and phishing blocklists, reread by /admin/reload. Results on them
+	// are flagged with a warning or, with threat.filter, removed.
+	cfg.SetDefault("threat.malware", []string{})
+	cfg.SetDefault("threat.phishing", []string{})
+	cfg.SetDefault("threat.filter", false)
+
+	// query intent is classified with heuristics, and also with a trained model if this is the path to one
+	cfg.SetDefault("intent.model", "")
+
+	// blend other verticals into the web results. A vertical is blended when
+	// the query's intent for it (0 to 1) times its weight clears the threshold.
+	cfg.SetDefault("blend.threshold", .5)
+	cfg.SetDefault("blend.images.weight", 1)
+	cfg.SetDefault("blend.videos.weight", 0) // requires youtube.key
+	cfg.SetDefault("blend.news.weight", 0)   // requires newsapi.key
+	cfg.SetDefault("youtube.key", "key")
+	cfg.SetDefault("newsapi.key", "key")
+
+	// A/B experiments as JSON, e.g. [{"name": "ranking", "variants": [{"name": "control", "weight": 90},
+	// {"name": "yandex", "weight": 10, "params": {"search": "yandex"}}]}]
+	cfg.SetDefault("experiments", "")
+
+	// other brands served by this deployment as JSON by hostname, e.g. {"search.example.org": {"name": "Example Search",
+	// "tagline": "...", "logo": "<svg>...</svg>", "bangs": ["g", "w"], "verticals": ["images", "maps"], "ranking": "yandex"}}.
+	// Whatever a tenant leaves out is the same as ours. A ranking is one of the search providers.
+	cfg.SetDefault("tenants", "")
+
+	// rate limits per IP (requests per second & burst). A rate of 0 disables the limit.
+	cfg.SetDefault("ratelimit.search.rate", 1)
+	cfg.SetDefault("ratelimit.search.burst", 30)
+	cfg.SetDefault("ratelimit.autocomplete.rate", 5)
+	cfg.SetDefault("ratelimit.autocomplete.burst", 50)
+	cfg.SetDefault("ratelimit.image.rate", 20)
+	cfg.SetDefault("ratelimit.image.burst", 300)
+	cfg.SetDefault("ratelimit.api.rate", 2)
+	cfg.SetDefault("ratelimit.api.burst", 60)
+	cfg.SetDefault("ratelimit.click.rate", 1)
+	cfg.SetDefault("ratelimit.click.burst", 20)
+	cfg.SetDefault("ratelimit.multiplier", 10) // API keys get 10x the per-IP limits
+	// IPs or CIDRs of our reverse proxies, e.g. "127.0.0.1 10.0.0.0/8". We limit by the
+	// right-most X-Forwarded-For hop they didn't add and otherwise ignore the header.
+	cfg.SetDefault("ratelimit.trusted_proxies", []string{})
+
+	// load shedding. Searches over our concurrency limit only get cached results. The limit
+	// grows while searches are faster than the latency and shrinks when they aren't.
+	cfg.SetDefault("shed.latency", "1s") // 0 never sheds
+	cfg.SetDefault("shed.min", 10)
+	cfg.SetDefault("shed.max", 500)
+	cfg.SetDefault("shed.goroutines", 50000)
+	cfg.SetDefault("shed.number", 10)
+	cfg.SetDefault("shed.retry_after", "10s")
+
 	// useragent for fetching api's, images, etc.
 	cfg.SetDefault("useragent", "https://github.com/jivesearch/jivesearch")
 
@@ -154,15 +322,38 @@ func SetDefaults(cfg Provider) {
 	cfg.SetDefault("fedex.meter", "meter")
 
 	// Maps
+	cfg.SetDefault("maps.provider", "mapbox") // "mapbox" or "osm" (Nominatim, OSRM & Overpass)
 	cfg.SetDefault("mapbox.key", "key")
+	cfg.SetDefault("nominatim.url", "https://nominatim.openstreetmap.org")
+	cfg.SetDefault("osrm.url", "https://router.project-osrm.org")
+	cfg.SetDefault("overpass.url", "https://overpass-api.de/api/interpreter")
+
+	// Shopping
+	cfg.SetDefault("shopping.provider", "ebay") // "ebay", "amazon" or "feed"
+	cfg.SetDefault("ebay.token", "token")
+	cfg.SetDefault("amazon.access_key", "key")
+	cfg.SetDefault("amazon.secret_key", "secret")
+	cfg.SetDefault("amazon.partner_tag", "tag")
+	cfg.SetDefault("shopping.feeds", []string{}) // urls of product feeds in the Google merchant format
+
+	// Scholar
+	cfg.SetDefault("scholar.provider", "crossref") // "crossref", "semanticscholar" or "arxiv"
+	cfg.SetDefault("crossref.mailto", "")
+	cfg.SetDefault("semanticscholar.key", "")
 
 	// MaxMind geolocation DB
 	cfg.SetDefault("maxmind.database", "/usr/share/GeoIP/GeoLite2-City.mmdb")
+	cfg.SetDefault("maxmind.asn.database", "/usr/share/GeoIP/GeoLite2-ASN.mmdb")
+	cfg.SetDefault("geolocation.region", false) // detect the user's region from their IP (opt-in)
 
 	// Search Providers
 	cfg.SetDefault("yandex.key", "key")
 	cfg.SetDefault("yandex.user", "user")
 
+	// Send searches as form posts to /search so queries aren't in urls, referrers or access logs.
+	// Users can override it with ?post=true or false.
+	cfg.SetDefault("search.post", false)
+
 	// UPS package tracking API settings
 	cfg.SetDefault("ups.user", "user")
 	cfg.SetDefault("ups.password", "password")
@@ -181,12 +372,83 @@ func SetDefaults(cfg Provider) {
 	// Pixabay images API
 	cfg.SetDefault("pixabay.key", "key")
 
+	// Sightengine image moderation API
+	cfg.SetDefault("sightengine.user", "user")
+	cfg.SetDefault("sightengine.secret", "secret")
+
+	// Movies & TV shows, from "tmdb" or "omdb"
+	cfg.SetDefault("media.provider", "tmdb")
+	cfg.SetDefault("media.rate", 2)
+	cfg.SetDefault("media.burst", 20)
+	cfg.SetDefault("media.ttl", 24*time.Hour)
+	cfg.SetDefault("tmdb.key", "key")
+	cfg.SetDefault("omdb.key", "key")
+
+	// GitHub & GitLab repositories. Tokens are optional but raise the API's rate limits.
+	// The rate & burst apply to each provider separately.
+	cfg.SetDefault("repo.rate", 1)
+	cfg.SetDefault("repo.burst", 30)
+	cfg.SetDefault("repo.ttl", time.Hour)
+	cfg.SetDefault("github.token", "")
+	cfg.SetDefault("gitlab.token", "")
+
+	// the latest versions of packages on PyPI, npm, crates.io and the Go module proxy.
+	// The rate & burst are shared by all of the registries.
+	cfg.SetDefault("packages.rate", 2)
+	cfg.SetDefault("packages.burst", 30)
+	cfg.SetDefault("packages.ttl", time.Hour)
+
+	// DNS & WHOIS lookups are cached and rate limited for everyone together
+	cfg.SetDefault("dns.rate", 5)
+	cfg.SetDefault("dns.burst", 20)
+	cfg.SetDefault("dns.ttl", 10*time.Minute)
+	cfg.SetDefault("whois.rate", 1)
+	cfg.SetDefault("whois.burst", 10)
+	cfg.SetDefault("whois.ttl", 24*time.Hour)
+
+	// instant answers to turn off, e.g. JIVESEARCH_INSTANT_DISABLED="coin random"
+	cfg.SetDefault("instant.disabled", []string{})
+
+	// how long the solutions of slow or external instant answers are cached, by answer,
+	// e.g. JIVESEARCH_INSTANT_TTL_WIKIPEDIA=1h. Answers without a ttl are solved every time.
+	cfg.SetDefault("instant.cache", 10000) // solutions
+	cfg.SetDefault("instant.ttl.congress", 24*time.Hour)
+	cfg.SetDefault("instant.ttl.currency", time.Minute)
+	cfg.SetDefault("instant.ttl.discography", 24*time.Hour)
+	cfg.SetDefault("instant.ttl.gdp", 24*time.Hour)
+	cfg.SetDefault("instant.ttl.media", 6*time.Hour)
+	cfg.SetDefault("instant.ttl.population", 24*time.Hour)
+	cfg.SetDefault("instant.ttl.stackoverflow", time.Hour)
+	cfg.SetDefault("instant.ttl.stock_quote", time.Minute)
+	cfg.SetDefault("instant.ttl.wikipedia", 6*time.Hour)
+
 	// Timezone database location
 	cfg.SetDefault("timezone.database", "/usr/share/timezone/timezone") // suffix is automatically added
 
 	// wikipedia settings
 	truncate := 250
-	cfg.SetDefault("wikipedia.truncate", truncate) // chars
+	cfg.SetDefault("wikipedia.truncate", truncate)                         // chars
+	cfg.SetDefault("wikipedia.checkpoint", "wikipedia_updater.checkpoint") // last change applied by the updater
+
+	// musicbrainz importer settings
+	cfg.SetDefault("musicbrainz.dir", "musicbrainz")
+	cfg.SetDefault("musicbrainz.delete", true) // delete each dump file once imported
+
+	// snapshot settings. Elasticsearch writes to its own repository ("fs" or "s3").
+	// The location is a path in path.repo for "fs" or the bucket for "s3".
+	cfg.SetDefault("snapshot.dir", "snapshots") // our PostgreSQL tables and manifests
+	cfg.SetDefault("snapshot.repository", "jivesearch")
+	cfg.SetDefault("snapshot.type", "fs")
+	cfg.SetDefault("snapshot.location", "/usr/share/elasticsearch/snapshots")
+	cfg.SetDefault("snapshot.tables", []string{"%wikipedia", "%wikiquote", "%wiktionary", "wikidata", "wikidata_aliases"})
+
+	// relevance evaluation settings. Queries are "id<tab>query" and the qrels are TREC's.
+	cfg.SetDefault("releval.queries", "queries.tsv")
+	cfg.SetDefault("releval.qrels", "qrels.txt")
+	cfg.SetDefault("releval.depth", 10) // how many results are scored
+	cfg.SetDefault("releval.lang", "en")
+	cfg.SetDefault("releval.region", "US")
+	cfg.SetDefault("releval.safe", "off")
 
 	// command flags
 	cmd := cobra.Command{}
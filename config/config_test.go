@@ -28,6 +28,16 @@ func TestSetDefaults(t *testing.T) {
 		value interface{}
 	}{
 		{"hmac.secret", ""},
+		{"hmac.keys", ""},
+		{"hmac.grace", 720 * time.Hour},
+		{"autocomplete.debounce", 50 * time.Millisecond},
+		{"autocomplete.idle", time.Minute},
+		{"autocomplete.max_messages", 1000},
+		{"autocomplete.scrub.interval", 24 * time.Hour},
+		{"images.batch.concurrency", 10},
+		{"images.batch.timeout", 2 * time.Second},
+		{"admin.token", ""},
+		{"config.file", ""},
 
 		// Brand
 		{"brand.name", "Jive Search"},
@@ -68,10 +78,24 @@ func TestSetDefaults(t *testing.T) {
 		// Server
 		{"server.host", fmt.Sprintf("http://127.0.0.1:%d", port)},
 
+		// Logging
+		{"log.format", "text"},
+		{"log.level", "info"},
+
+		// Health
+		{"health.timeout", 2 * time.Second},
+		{"health.image", ""},
+
+		// Shutdown
+		{"shutdown.delay", 0 * time.Second},
+		{"shutdown.timeout", 15 * time.Second},
+
 		// Elasticsearch
 		{"elasticsearch.url", "http://127.0.0.1:9200"},
 		{"elasticsearch.search.index", "test-search"},
 		{"elasticsearch.search.type", "document"},
+		{"elasticsearch.search.plugins", []string{}},
+		{"elasticsearch.search.compounds", ""},
 		{"elasticsearch.bangs.index", "test-bangs"},
 		{"elasticsearch.bangs.type", "bang"},
 		{"elasticsearch.image.index", "test-images"},
@@ -80,6 +104,10 @@ func TestSetDefaults(t *testing.T) {
 		{"elasticsearch.query.type", "query"},
 		{"elasticsearch.robots.index", "test-robots"},
 		{"elasticsearch.robots.type", "robots"},
+		{"documents.enabled", false},
+		{"documents.max_bytes", 10 << 20},
+		{"documents.max", 1000},
+		{"documents.batch", 100},
 
 		// PostgreSQL
 		{"postgresql.host", "localhost"},
@@ -110,16 +138,100 @@ func TestSetDefaults(t *testing.T) {
 		{"crawler.truncate.title", 100},
 		{"crawler.truncate.keywords", 25},
 		{"crawler.truncate.description", 250},
+		{"crawler.audit.noindex", false},
+		{"crawler.audit.purge", false},
+		{"crawler.audit.delay", time.Second},
+		{"crawler.adult.domains", []string{}},
+
+		// JSON API
+		{"api.keys.required", false},
+		{"cors.origins", []string{}},
+		{"cors.methods", []string{"GET", "POST"}},
+		{"cors.headers", []string{"Content-Type", "X-API-Key"}},
+		{"cors.max_age", 10 * time.Minute},
+		{"cors.credentials", false},
+
+		// anonymized query log
+		{"analytics.enabled", false},
+		{"analytics.salt", ""},
+		{"warm.interval", time.Duration(0)},
+		{"warm.period", 24 * time.Hour},
+		{"warm.top", 50},
+		{"warm.locales", []string{"en-US", "en-GB", "de-DE", "fr-FR", "es-ES", "ja-JP"}},
+		{"clicks.enabled", false},
+		{"clicks.salt", ""},
+		{"clicks.weight", .2},
+		{"clicks.impressions", 100},
+		{"history.enabled", false},
+		{"bangs.check.interval", 24 * time.Hour},
+		{"bangs.check.delay", time.Second},
+		{"bangs.check.timeout", 10 * time.Second},
+		{"saved.enabled", false},
+		{"saved.store", "postgresql"},
+		{"saved.path", "saved.db"},
+
+		// threat blocklists
+		{"threat.malware", []string{}},
+		{"threat.phishing", []string{}},
+		{"threat.filter", false},
+
+		// query intent
+		{"intent.model", ""},
+
+		// blending
+		{"blend.threshold", .5},
+		{"blend.images.weight", 1},
+		{"blend.videos.weight", 0},
+		{"blend.news.weight", 0},
+		{"youtube.key", "key"},
+		{"newsapi.key", "key"},
+
+		// A/B experiments
+		{"experiments", ""},
+
+		// other brands
+		{"tenants", ""},
+
+		// rate limits
+		{"ratelimit.search.rate", 1},
+		{"ratelimit.search.burst", 30},
+		{"ratelimit.autocomplete.rate", 5},
+		{"ratelimit.autocomplete.burst", 50},
+		{"ratelimit.image.rate", 20},
+		{"ratelimit.image.burst", 300},
+		{"ratelimit.api.rate", 2},
+		{"ratelimit.api.burst", 60},
+		{"ratelimit.click.rate", 1},
+		{"ratelimit.click.burst", 20},
+		{"ratelimit.multiplier", 10},
+		{"ratelimit.trusted_proxies", []string{}},
+
+		// load shedding
+		{"shed.latency", "1s"},
+		{"shed.min", 10},
+		{"shed.max", 500},
+		{"shed.goroutines", 50000},
+		{"shed.number", 10},
+		{"shed.retry_after", "10s"},
 
 		// useragent for fetching api's, images, etc.
 		{"useragent", "https://github.com/jivesearch/jivesearch"},
 
 		// image nsfw scoring and metadata
+		{"nsfw.provider", "open_nsfw"},
 		{"nsfw.workers", 10},
 		{"nsfw.since", time.Date(2018, 01, 06, 20, 34, 58, 651387237, time.UTC)},
 
+		// Security headers
+		{"security.csp", "default-src 'self'; script-src 'self' 'nonce-{nonce}' https://buttons.github.io; style-src 'self' 'unsafe-inline'; img-src 'self' data: blob: https://*.mapbox.com; connect-src 'self' https://*.mapbox.com https://api.github.com; worker-src 'self' blob:; child-src 'self' blob:; frame-ancestors 'self'; object-src 'none'; base-uri 'self'"},
+		{"security.csp_report_only", false},
+		{"security.hsts", 17520 * time.Hour},
+		{"security.frame", "SAMEORIGIN"},
+		{"security.referrer", "origin"},
+
 		// Tor
 		{"onion", "jivexx2rbi6llz37jq37n4uqff4kdipqbqd24c437c56om6uxbzhtdid.onion"},
+		{"tor.mode", false},
 
 		// ProPublica API
 		{"propublica.key", "my_key"},
@@ -134,14 +246,34 @@ func TestSetDefaults(t *testing.T) {
 		{"fedex.meter", "meter"},
 
 		// Maps
+		{"maps.provider", "mapbox"},
 		{"mapbox.key", "key"},
+		{"nominatim.url", "https://nominatim.openstreetmap.org"},
+		{"osrm.url", "https://router.project-osrm.org"},
+		{"overpass.url", "https://overpass-api.de/api/interpreter"},
+
+		// Shopping
+		{"shopping.provider", "ebay"},
+		{"ebay.token", "token"},
+		{"amazon.access_key", "key"},
+		{"amazon.secret_key", "secret"},
+		{"amazon.partner_tag", "tag"},
+		{"shopping.feeds", []string{}},
+
+		// Scholar
+		{"scholar.provider", "crossref"},
+		{"crossref.mailto", ""},
+		{"semanticscholar.key", ""},
 
 		// MaxMind geolocation DB
 		{"maxmind.database", "/usr/share/GeoIP/GeoLite2-City.mmdb"},
+		{"maxmind.asn.database", "/usr/share/GeoIP/GeoLite2-ASN.mmdb"},
+		{"geolocation.region", false},
 
 		// Search Providers
 		{"yandex.key", "key"},
 		{"yandex.user", "user"},
+		{"search.post", false},
 
 		// UPS package tracking API settings
 		{"ups.user", "user"},
@@ -161,11 +293,73 @@ func TestSetDefaults(t *testing.T) {
 		// Pixabay images API
 		{"pixabay.key", "key"},
 
+		// Sightengine image moderation API
+		{"sightengine.user", "user"},
+		{"sightengine.secret", "secret"},
+
+		// Movies & TV shows
+		{"media.provider", "tmdb"},
+		{"media.rate", 2},
+		{"media.burst", 20},
+		{"media.ttl", 24 * time.Hour},
+		{"tmdb.key", "key"},
+		{"omdb.key", "key"},
+
+		// GitHub & GitLab repositories
+		{"repo.rate", 1},
+		{"repo.burst", 30},
+		{"repo.ttl", time.Hour},
+		{"packages.rate", 2},
+		{"packages.burst", 30},
+		{"packages.ttl", time.Hour},
+		{"github.token", ""},
+		{"gitlab.token", ""},
+
+		// DNS & WHOIS lookups
+		{"dns.rate", 5},
+		{"dns.burst", 20},
+		{"dns.ttl", 10 * time.Minute},
+		{"whois.rate", 1},
+		{"whois.burst", 10},
+		{"whois.ttl", 24 * time.Hour},
+
+		{"instant.disabled", []string{}},
+		{"instant.cache", 10000},
+		{"instant.ttl.congress", 24 * time.Hour},
+		{"instant.ttl.currency", time.Minute},
+		{"instant.ttl.discography", 24 * time.Hour},
+		{"instant.ttl.gdp", 24 * time.Hour},
+		{"instant.ttl.media", 6 * time.Hour},
+		{"instant.ttl.population", 24 * time.Hour},
+		{"instant.ttl.stackoverflow", time.Hour},
+		{"instant.ttl.stock_quote", time.Minute},
+		{"instant.ttl.wikipedia", 6 * time.Hour},
+
 		// Timezone database location
 		{"timezone.database", "/usr/share/timezone/timezone"},
 
 		// wikipedia settings
 		{"wikipedia.truncate", 250},
+		{"wikipedia.checkpoint", "wikipedia_updater.checkpoint"},
+
+		// musicbrainz importer settings
+		{"musicbrainz.dir", "musicbrainz"},
+		{"musicbrainz.delete", true},
+
+		// snapshot settings
+		{"snapshot.dir", "snapshots"},
+		{"snapshot.repository", "jivesearch"},
+		{"snapshot.type", "fs"},
+		{"snapshot.location", "/usr/share/elasticsearch/snapshots"},
+		{"snapshot.tables", []string{"%wikipedia", "%wikiquote", "%wiktionary", "wikidata", "wikidata_aliases"}},
+
+		// relevance evaluation settings
+		{"releval.queries", "queries.tsv"},
+		{"releval.qrels", "qrels.txt"},
+		{"releval.depth", 10},
+		{"releval.lang", "en"},
+		{"releval.region", "US"},
+		{"releval.safe", "off"},
 	}
 
 	for _, v := range values {
@@ -40,8 +40,8 @@ type about struct {
 
 func (f *Frontend) aboutHandler(w http.ResponseWriter, r *http.Request) *response {
 	abt := about{
-		Brand:   f.Brand,
-		Context: &Context{},
+		Brand:   f.tenant(r).Brand,
+		Context: &Context{Nonce: nonce(r)},
 		Onion:   f.Onion,
 	}
 
@@ -53,6 +53,10 @@ func (f *Frontend) aboutHandler(w http.ResponseWriter, r *http.Request) *respons
 		data:     abt,
 	}
 
+	if f.Tor {
+		return resp
+	}
+
 	/*
 		For more detail on additions, deletions and commits: https://api.github.com/repos/jivesearch/jivesearch/stats/contributors
 		The below is sorted by # of contributions (in descending order).
@@ -0,0 +1,61 @@
+package frontend
+
+import (
+	"crypto/subtle"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/jivesearch/jivesearch/instant"
+)
+
+// adminInstantHandler lists the instant answers and, for a POST, enables or disables one.
+// e.g. curl -H "Authorization: Bearer $TOKEN" -d "name=coin&enabled=false" /admin/instant
+func (f *Frontend) adminInstantHandler(w http.ResponseWriter, r *http.Request) *response {
+	resp := &response{
+		status:   http.StatusOK,
+		template: "json",
+	}
+
+	if !f.isAdmin(r) {
+		resp.status = http.StatusForbidden
+		resp.err = fmt.Errorf("invalid admin token from %v", r.RemoteAddr)
+		return resp
+	}
+
+	if r.Method == http.MethodPost {
+		enabled, err := strconv.ParseBool(r.FormValue("enabled"))
+		if err != nil {
+			resp.status, resp.err = http.StatusBadRequest, err
+			return resp
+		}
+
+		name := r.FormValue("name")
+		switch enabled {
+		case true:
+			err = instant.Enable(name)
+		default:
+			err = instant.Disable(name)
+		}
+
+		if err != nil {
+			resp.status, resp.err = http.StatusBadRequest, err
+			return resp
+		}
+	}
+
+	resp.data = instant.Registrations()
+	return resp
+}
+
+// isAdmin checks the bearer token against our admin token.
+// The admin endpoints are closed if no token is configured.
+func (f *Frontend) isAdmin(r *http.Request) bool {
+	if f.AdminToken == "" {
+		return false
+	}
+
+	token := strings.TrimPrefix(r.Header.Get("Authorization"), "Bearer ")
+	return subtle.ConstantTimeCompare([]byte(token), []byte(f.AdminToken)) == 1
+}
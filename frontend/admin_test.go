@@ -0,0 +1,50 @@
+package frontend
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/jivesearch/jivesearch/instant"
+)
+
+func TestAdminInstantHandler(t *testing.T) {
+	for _, c := range []struct {
+		name    string
+		token   string
+		form    url.Values
+		status  int
+		enabled bool
+	}{
+		{"no token configured", "", url.Values{"name": {"coin"}, "enabled": {"false"}}, http.StatusForbidden, true},
+		{"wrong token", "wrong", url.Values{"name": {"coin"}, "enabled": {"false"}}, http.StatusForbidden, true},
+		{"disable", "secret", url.Values{"name": {"coin"}, "enabled": {"false"}}, http.StatusOK, false},
+		{"unknown", "secret", url.Values{"name": {"nope"}, "enabled": {"false"}}, http.StatusBadRequest, false},
+		{"bad value", "secret", url.Values{"name": {"coin"}, "enabled": {"maybe"}}, http.StatusBadRequest, false},
+		{"enable", "secret", url.Values{"name": {"coin"}, "enabled": {"true"}}, http.StatusOK, true},
+	} {
+		t.Run(c.name, func(t *testing.T) {
+			f := &Frontend{}
+			if c.token != "" {
+				f.AdminToken = "secret"
+			}
+
+			req := httptest.NewRequest("POST", "/admin/instant", strings.NewReader(c.form.Encode()))
+			req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+			req.Header.Set("Authorization", "Bearer "+c.token)
+
+			rsp := f.adminInstantHandler(httptest.NewRecorder(), req)
+			if rsp.status != c.status {
+				t.Fatalf("got status %d; want %d", rsp.status, c.status)
+			}
+
+			for _, r := range instant.Registrations() {
+				if r.Name == "coin" && r.Enabled != c.enabled {
+					t.Fatalf("got enabled %v; want %v", r.Enabled, c.enabled)
+				}
+			}
+		})
+	}
+}
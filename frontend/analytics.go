@@ -0,0 +1,127 @@
+package frontend
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/jivesearch/jivesearch/frontend/analytics"
+	"github.com/jivesearch/jivesearch/instant"
+	"github.com/jivesearch/jivesearch/log"
+)
+
+// Analytics is our opt-in, anonymized query log
+type Analytics struct {
+	analytics.Store
+	analytics.Anonymizer
+}
+
+// maxAnalyticsPeriod keeps the admin from loading too many events at once
+const maxAnalyticsPeriod = 31 * 24 * time.Hour
+
+// logQuery records a query. Only the first page is counted so infinite scrolling doesn't inflate the volume.
+func (f *Frontend) logQuery(r *http.Request, d data, bang string, noResults bool, start time.Time) {
+	if f.Analytics.Store == nil || d.Context.Page > 1 || doNotTrack(r) {
+		return
+	}
+
+	vertical := d.Context.T
+	switch {
+	case bang != "":
+		vertical = "bang"
+	case vertical == "":
+		vertical = "web"
+	}
+
+	t := now()
+
+	e := &analytics.Event{
+		Time:        t,
+		User:        f.Analytics.User(instant.IPAddress(r).String(), r.UserAgent(), t),
+		Query:       d.Context.Q,
+		Vertical:    vertical,
+		Bang:        bang,
+		NoResults:   noResults,
+		Latency:     time.Since(start),
+		Experiments: d.Context.Experiments.String(),
+	}
+
+	if err := f.Analytics.Insert(e); err != nil {
+		log.Info.Println(err)
+	}
+}
+
+// noResults is true if the vertical the user asked for came back empty
+func noResults(d data) bool {
+	switch d.Context.T {
+	case "images":
+		return d.Images == nil || len(d.Images.Images) == 0
+	case "local":
+		return d.Local == nil || len(d.Local.Places) == 0
+	case "scholar":
+		return d.Scholar == nil || len(d.Scholar.Papers) == 0
+	case "shopping":
+		return d.Shopping == nil || len(d.Shopping.Products) == 0
+	case "maps": // results are loaded by the browser
+		return false
+	}
+
+	return d.Search == nil || len(d.Search.Documents) == 0
+}
+
+// adminAnalyticsHandler summarizes the query log.
+// The period defaults to the last 24 hours and can be set with the since & until params (RFC 3339).
+// e.g. curl -H "Authorization: Bearer $TOKEN" "/admin/analytics?since=2018-02-06T00:00:00Z"
+func (f *Frontend) adminAnalyticsHandler(w http.ResponseWriter, r *http.Request) *response {
+	resp := &response{
+		status:   http.StatusOK,
+		template: "json",
+	}
+
+	if !f.isAdmin(r) {
+		resp.status = http.StatusForbidden
+		resp.err = fmt.Errorf("invalid admin token from %v", r.RemoteAddr)
+		return resp
+	}
+
+	if f.Analytics.Store == nil {
+		resp.status = http.StatusInternalServerError
+		resp.err = fmt.Errorf("analytics are disabled")
+		return resp
+	}
+
+	until := now().Truncate(time.Hour).Add(time.Hour)
+	since := until.Add(-24 * time.Hour)
+
+	for _, p := range []struct {
+		param string
+		t     *time.Time
+	}{
+		{"since", &since},
+		{"until", &until},
+	} {
+		if s := strings.TrimSpace(r.FormValue(p.param)); s != "" {
+			t, err := time.Parse(time.RFC3339, s)
+			if err != nil {
+				resp.status, resp.err = http.StatusBadRequest, err
+				return resp
+			}
+			*p.t = t.UTC()
+		}
+	}
+
+	if !since.Before(until) || until.Sub(since) > maxAnalyticsPeriod {
+		resp.status, resp.err = http.StatusBadRequest, fmt.Errorf("invalid period %v to %v", since, until)
+		return resp
+	}
+
+	events, err := f.Analytics.Events(since, until)
+	if err != nil {
+		resp.status, resp.err = http.StatusInternalServerError, err
+		return resp
+	}
+
+	resp.data = analytics.Summarize(events, since, until)
+	return resp
+}
@@ -0,0 +1,210 @@
+// Package analytics keeps an anonymized log of queries for aggregate reporting.
+// IP addresses are never stored. Users are identified by a salted hash that changes
+// daily and is truncated so it can't be reversed or followed from one day to the next.
+package analytics
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"math"
+	"sort"
+	"strings"
+	"time"
+)
+
+// Store outlines the methods to persist our query log
+type Store interface {
+	Setup() error
+	Insert(e *Event) error
+	// Events returns the events in [since, until), oldest first
+	Events(since, until time.Time) ([]*Event, error)
+}
+
+// Event is a single query
+type Event struct {
+	Time        time.Time     `json:"time"`
+	User        string        `json:"user"`
+	Query       string        `json:"query"`
+	Vertical    string        `json:"vertical"`
+	Bang        string        `json:"bang,omitempty"`
+	NoResults   bool          `json:"no_results"`
+	Latency     time.Duration `json:"latency"`
+	Experiments string        `json:"experiments,omitempty"` // the user's variants, e.g. "ranking:control,results:compact"
+}
+
+// Anonymizer creates the user identifiers
+type Anonymizer struct {
+	Salt string
+}
+
+// userLen is the number of bytes of the hash we keep.
+// Collisions are expected and only make a user harder to single out.
+const userLen = 4
+
+// User identifies a user for the day t without storing their IP.
+func (a Anonymizer) User(ip, userAgent string, t time.Time) string {
+	h := hmac.New(sha256.New, []byte(a.Salt))
+	h.Write([]byte(t.UTC().Format("2006-01-02") + "|" + ip + "|" + userAgent))
+	return hex.EncodeToString(h.Sum(nil)[:userLen])
+}
+
+// Summary is the aggregate of the events for a period
+type Summary struct {
+	Since       time.Time      `json:"since"`
+	Until       time.Time      `json:"until"`
+	Queries     int            `json:"queries"`
+	Users       int            `json:"users"`
+	Hourly      []Hour         `json:"hourly"`
+	Verticals   map[string]int `json:"verticals"`
+	Bangs       map[string]int `json:"bangs"`
+	ZeroResults float64        `json:"zero_results"` // rate, excluding !bangs
+	Latency     Latency        `json:"latency"`
+	Variants    map[string]Arm `json:"variants"` // by "experiment:variant"
+}
+
+// Arm compares the variants of our experiments
+type Arm struct {
+	Queries     int     `json:"queries"`
+	ZeroResults float64 `json:"zero_results"`
+}
+
+// Hour is the number of queries in the hour starting at Time
+type Hour struct {
+	Time    time.Time `json:"time"`
+	Queries int       `json:"queries"`
+}
+
+// Latency percentiles in milliseconds
+type Latency struct {
+	P50 float64 `json:"p50"`
+	P90 float64 `json:"p90"`
+	P99 float64 `json:"p99"`
+}
+
+// Summarize aggregates the events. Every hour in the period is included, even those without queries.
+func Summarize(events []*Event, since, until time.Time) *Summary {
+	s := &Summary{
+		Since:     since,
+		Until:     until,
+		Queries:   len(events),
+		Hourly:    []Hour{},
+		Verticals: map[string]int{},
+		Bangs:     map[string]int{},
+		Variants:  map[string]Arm{},
+	}
+
+	hours := map[time.Time]int{}
+	users := map[string]bool{}
+	latencies := []time.Duration{}
+	searches, zero := 0, 0
+	arms := map[string][2]int{} // searches & zero results
+
+	for _, e := range events {
+		hours[e.Time.UTC().Truncate(time.Hour)]++
+		users[e.User] = true
+		latencies = append(latencies, e.Latency)
+
+		if e.Bang != "" {
+			s.Bangs[e.Bang]++
+			continue
+		}
+
+		s.Verticals[e.Vertical]++
+		searches++
+		if e.NoResults {
+			zero++
+		}
+
+		for _, v := range strings.Split(e.Experiments, ",") {
+			if v == "" {
+				continue
+			}
+
+			a := arms[v]
+			a[0]++
+			if e.NoResults {
+				a[1]++
+			}
+			arms[v] = a
+		}
+	}
+
+	for v, a := range arms {
+		s.Variants[v] = Arm{Queries: a[0], ZeroResults: float64(a[1]) / float64(a[0])}
+	}
+
+	for h := since.UTC().Truncate(time.Hour); h.Before(until); h = h.Add(time.Hour) {
+		s.Hourly = append(s.Hourly, Hour{Time: h, Queries: hours[h]})
+	}
+
+	s.Users = len(users)
+
+	if searches > 0 {
+		s.ZeroResults = float64(zero) / float64(searches)
+	}
+
+	sort.Slice(latencies, func(i, j int) bool { return latencies[i] < latencies[j] })
+	s.Latency = Latency{
+		P50: percentile(latencies, 50),
+		P90: percentile(latencies, 90),
+		P99: percentile(latencies, 99),
+	}
+
+	return s
+}
+
+// Trending are the n web queries searched by the most users, most first.
+// A query only one user searched isn't trending, however often they searched it.
+func Trending(events []*Event, n int) []string {
+	users := map[string]map[string]bool{}
+	for _, e := range events {
+		if e.Vertical != "web" || e.Bang != "" || e.NoResults {
+			continue
+		}
+
+		q := strings.ToLower(strings.Join(strings.Fields(e.Query), " "))
+		if q == "" {
+			continue
+		}
+
+		if users[q] == nil {
+			users[q] = map[string]bool{}
+		}
+		users[q][e.User] = true
+	}
+
+	queries := []string{}
+	for q, u := range users {
+		if len(u) > 1 {
+			queries = append(queries, q)
+		}
+	}
+
+	sort.Slice(queries, func(i, j int) bool {
+		if len(users[queries[i]]) == len(users[queries[j]]) {
+			return queries[i] < queries[j]
+		}
+		return len(users[queries[i]]) > len(users[queries[j]])
+	})
+
+	if len(queries) > n {
+		queries = queries[:n]
+	}
+
+	return queries
+}
+
+// percentile uses the nearest-rank method on sorted durations
+func percentile(sorted []time.Duration, p float64) float64 {
+	if len(sorted) == 0 {
+		return 0
+	}
+
+	i := int(math.Ceil(p/100*float64(len(sorted)))) - 1
+	if i < 0 {
+		i = 0
+	}
+
+	return float64(sorted[i]) / float64(time.Millisecond)
+}
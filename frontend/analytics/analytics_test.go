@@ -0,0 +1,105 @@
+package analytics
+
+import (
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestUser(t *testing.T) {
+	a := Anonymizer{Salt: "my_salt"}
+	day := time.Date(2018, 02, 06, 11, 0, 0, 0, time.UTC)
+
+	u := a.User("203.0.113.1", "Firefox", day)
+	if len(u) != userLen*2 {
+		t.Fatalf("got %q; want %d hex characters", u, userLen*2)
+	}
+
+	for _, c := range []struct {
+		name string
+		got  string
+		same bool
+	}{
+		{"later that day", a.User("203.0.113.1", "Firefox", day.Add(12*time.Hour)), true},
+		{"next day", a.User("203.0.113.1", "Firefox", day.Add(24*time.Hour)), false},
+		{"different ip", a.User("203.0.113.2", "Firefox", day), false},
+		{"different salt", Anonymizer{Salt: "other"}.User("203.0.113.1", "Firefox", day), false},
+	} {
+		t.Run(c.name, func(t *testing.T) {
+			if (c.got == u) != c.same {
+				t.Fatalf("got %q vs %q; want same %v", c.got, u, c.same)
+			}
+		})
+	}
+}
+
+func TestSummarize(t *testing.T) {
+	since := time.Date(2018, 02, 06, 10, 0, 0, 0, time.UTC)
+	until := since.Add(3 * time.Hour)
+
+	events := []*Event{
+		{Time: since.Add(5 * time.Minute), User: "a", Vertical: "web", Latency: 100 * time.Millisecond, Experiments: "ranking:control"},
+		{Time: since.Add(10 * time.Minute), User: "a", Vertical: "images", Latency: 300 * time.Millisecond, Experiments: "ranking:control"},
+		{Time: since.Add(15 * time.Minute), User: "b", Vertical: "web", NoResults: true, Latency: 200 * time.Millisecond, Experiments: "ranking:yandex,results:compact"},
+		{Time: since.Add(2*time.Hour + 5*time.Minute), User: "c", Bang: "Google", Latency: time.Millisecond},
+	}
+
+	got := Summarize(events, since, until)
+
+	want := &Summary{
+		Since:   since,
+		Until:   until,
+		Queries: 4,
+		Users:   3,
+		Hourly: []Hour{
+			{Time: since, Queries: 3},
+			{Time: since.Add(time.Hour), Queries: 0},
+			{Time: since.Add(2 * time.Hour), Queries: 1},
+		},
+		Verticals:   map[string]int{"web": 2, "images": 1},
+		Bangs:       map[string]int{"Google": 1},
+		ZeroResults: 1.0 / 3,
+		Latency:     Latency{P50: 100, P90: 300, P99: 300},
+		Variants: map[string]Arm{
+			"ranking:control": {Queries: 2},
+			"ranking:yandex":  {Queries: 1, ZeroResults: 1},
+			"results:compact": {Queries: 1, ZeroResults: 1},
+		},
+	}
+
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("got %+v; want %+v", got, want)
+	}
+}
+
+func TestTrending(t *testing.T) {
+	events := []*Event{
+		{User: "a", Query: "jive search", Vertical: "web"},
+		{User: "b", Query: "Jive  Search", Vertical: "web"},
+		{User: "c", Query: "jive search", Vertical: "web"},
+		{User: "a", Query: "weather", Vertical: "web"},
+		{User: "b", Query: "weather", Vertical: "web"},
+		{User: "a", Query: "golang", Vertical: "web"},
+		{User: "b", Query: "golang", Vertical: "web"},
+		{User: "d", Query: "just me", Vertical: "web"},
+		{User: "d", Query: "just me", Vertical: "web"},
+		{User: "a", Query: "kittens", Vertical: "images"},
+		{User: "b", Query: "kittens", Vertical: "images"},
+		{User: "a", Query: "g news", Vertical: "web", Bang: "Google"},
+		{User: "b", Query: "g news", Vertical: "web", Bang: "Google"},
+		{User: "a", Query: "asdfgh", Vertical: "web", NoResults: true},
+		{User: "b", Query: "asdfgh", Vertical: "web", NoResults: true},
+	}
+
+	for _, c := range []struct {
+		n    int
+		want []string
+	}{
+		{10, []string{"jive search", "golang", "weather"}},
+		{2, []string{"jive search", "golang"}},
+	} {
+		if got := Trending(events, c.n); !reflect.DeepEqual(got, c.want) {
+			t.Fatalf("got %+v; want %+v", got, c.want)
+		}
+	}
+}
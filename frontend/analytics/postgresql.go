@@ -0,0 +1,68 @@
+package analytics
+
+import (
+	"database/sql"
+	"time"
+)
+
+// PostgreSQL stores our query log in PostgreSQL
+type PostgreSQL struct {
+	*sql.DB
+}
+
+const eventsTable = "analytics"
+
+// eventsQuery is the events in a time range. Its columns are scanned, in order, by Events.
+const eventsQuery = `SELECT time, usr, query, vertical, bang, no_results, latency, experiments FROM ` + eventsTable + ` WHERE time >= $1 AND time < $2 ORDER BY time`
+
+// Setup creates our table if it doesn't exist
+func (p *PostgreSQL) Setup() error {
+	_, err := p.DB.Exec(`
+		CREATE TABLE IF NOT EXISTS ` + eventsTable + ` (
+			time timestamptz NOT NULL,
+			usr text NOT NULL,
+			query text NOT NULL,
+			vertical text NOT NULL,
+			bang text NOT NULL DEFAULT '',
+			no_results boolean NOT NULL DEFAULT false,
+			latency bigint NOT NULL
+		);
+		ALTER TABLE ` + eventsTable + ` ADD COLUMN IF NOT EXISTS experiments text NOT NULL DEFAULT '';
+		CREATE INDEX IF NOT EXISTS ` + eventsTable + `_time_idx ON ` + eventsTable + ` (time);
+	`)
+
+	return err
+}
+
+// Insert adds an event
+func (p *PostgreSQL) Insert(e *Event) error {
+	_, err := p.DB.Exec(
+		`INSERT INTO `+eventsTable+` (time, usr, query, vertical, bang, no_results, latency, experiments) VALUES ($1, $2, $3, $4, $5, $6, $7, $8)`,
+		e.Time, e.User, e.Query, e.Vertical, e.Bang, e.NoResults, int64(e.Latency), e.Experiments,
+	)
+
+	return err
+}
+
+// Events returns the events in [since, until), oldest first
+func (p *PostgreSQL) Events(since, until time.Time) ([]*Event, error) {
+	rows, err := p.DB.Query(eventsQuery, since, until)
+	if err != nil {
+		return nil, err
+	}
+
+	defer rows.Close()
+
+	events := []*Event{}
+	for rows.Next() {
+		e := &Event{}
+		var latency int64
+		if err := rows.Scan(&e.Time, &e.User, &e.Query, &e.Vertical, &e.Bang, &e.NoResults, &latency, &e.Experiments); err != nil {
+			return nil, err
+		}
+		e.Latency = time.Duration(latency)
+		events = append(events, e)
+	}
+
+	return events, rows.Err()
+}
@@ -0,0 +1,85 @@
+package analytics
+
+import (
+	"reflect"
+	"regexp"
+	"strings"
+	"testing"
+	"time"
+
+	sqlmock "gopkg.in/DATA-DOG/go-sqlmock.v2"
+)
+
+func TestPostgreSQL(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+
+	p := &PostgreSQL{DB: db}
+	since := time.Date(2018, 02, 06, 10, 0, 0, 0, time.UTC)
+	until := since.Add(time.Hour)
+
+	e := &Event{
+		Time:        since.Add(time.Minute),
+		User:        "1a2b3c4d",
+		Query:       "jive search",
+		Vertical:    "web",
+		Latency:     150 * time.Millisecond,
+		Experiments: "ranking:control",
+	}
+
+	mock.ExpectExec("INSERT INTO analytics").
+		WithArgs(e.Time, e.User, e.Query, e.Vertical, "", false, int64(e.Latency), e.Experiments).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+
+	if err := p.Insert(e); err != nil {
+		t.Fatal(err)
+	}
+
+	// the rows have the columns our query selects, so a column that isn't scanned (or is scanned but not selected) fails
+	cols := selected(t, eventsQuery)
+	if want := []string{"time", "usr", "query", "vertical", "bang", "no_results", "latency", "experiments"}; !reflect.DeepEqual(cols, want) {
+		t.Fatalf("got columns %v; want %v", cols, want)
+	}
+
+	mock.ExpectQuery(regexp.QuoteMeta(eventsQuery)).WithArgs(since, until).
+		WillReturnRows(sqlmock.NewRows(cols).
+			AddRow(e.Time, e.User, e.Query, e.Vertical, "", false, int64(e.Latency), e.Experiments).
+			AddRow(e.Time.Add(time.Minute), "9f8e7d6c", "!g jive", "bang", "Google", false, int64(time.Millisecond), ""),
+		)
+
+	got, err := p.Events(since, until)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := []*Event{
+		e,
+		{Time: e.Time.Add(time.Minute), User: "9f8e7d6c", Query: "!g jive", Vertical: "bang", Bang: "Google", Latency: time.Millisecond},
+	}
+
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("got %+v; want %+v", got, want)
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Fatal(err)
+	}
+}
+
+// selected are the columns of a SELECT statement
+func selected(t *testing.T, query string) []string {
+	m := regexp.MustCompile(`^SELECT (.+?) FROM `).FindStringSubmatch(query)
+	if m == nil {
+		t.Fatalf("%q isn't a SELECT", query)
+	}
+
+	cols := strings.Split(m[1], ",")
+	for i, c := range cols {
+		cols[i] = strings.TrimSpace(c)
+	}
+
+	return cols
+}
@@ -0,0 +1,49 @@
+package analytics
+
+import (
+	"sync"
+	"time"
+)
+
+// Simple is an in-memory Store. Events are lost on restart so it is only suitable for testing.
+type Simple struct {
+	mu     sync.Mutex
+	events []*Event
+}
+
+// Setup initializes the store
+func (s *Simple) Setup() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.events = []*Event{}
+	return nil
+}
+
+// Insert adds an event
+func (s *Simple) Insert(e *Event) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	ee := *e
+	s.events = append(s.events, &ee)
+	return nil
+}
+
+// Events returns the events in [since, until), oldest first
+func (s *Simple) Events(since, until time.Time) ([]*Event, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	events := []*Event{}
+	for _, e := range s.events {
+		if e.Time.Before(since) || !e.Time.Before(until) {
+			continue
+		}
+
+		ee := *e
+		events = append(events, &ee)
+	}
+
+	return events, nil
+}
@@ -0,0 +1,37 @@
+package analytics
+
+import (
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestSimple(t *testing.T) {
+	s := &Simple{}
+	if err := s.Setup(); err != nil {
+		t.Fatal(err)
+	}
+
+	since := time.Date(2018, 02, 06, 10, 0, 0, 0, time.UTC)
+	events := []*Event{
+		{Time: since.Add(-time.Minute), User: "a", Query: "too early", Vertical: "web"},
+		{Time: since, User: "a", Query: "first", Vertical: "web"},
+		{Time: since.Add(30 * time.Minute), User: "b", Query: "second", Vertical: "images"},
+		{Time: since.Add(time.Hour), User: "b", Query: "too late", Vertical: "web"},
+	}
+
+	for _, e := range events {
+		if err := s.Insert(e); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	got, err := s.Events(since, since.Add(time.Hour))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !reflect.DeepEqual(got, events[1:3]) {
+		t.Fatalf("got %+v; want %+v", got, events[1:3])
+	}
+}
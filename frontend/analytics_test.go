@@ -0,0 +1,143 @@
+package frontend
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/jivesearch/jivesearch/frontend/analytics"
+	"github.com/jivesearch/jivesearch/search"
+	"github.com/jivesearch/jivesearch/search/document"
+)
+
+func TestLogQuery(t *testing.T) {
+	store := &analytics.Simple{}
+	if err := store.Setup(); err != nil {
+		t.Fatal(err)
+	}
+
+	f := &Frontend{
+		Analytics: Analytics{
+			Store:      store,
+			Anonymizer: analytics.Anonymizer{Salt: "my_salt"},
+		},
+	}
+
+	now = func() time.Time {
+		return time.Date(2018, 02, 06, 11, 30, 0, 0, time.UTC)
+	}
+
+	req := httptest.NewRequest("GET", "/?q=jive+search", nil)
+	req.RemoteAddr = "203.0.113.1:1234"
+
+	web := data{
+		Context: &Context{Q: "jive search", Page: 1},
+		Results: Results{Search: &search.Results{Documents: []*document.Document{{ID: "https://www.example.com"}}}},
+	}
+	images := data{
+		Context: &Context{Q: "jive search", T: "images", Page: 1},
+	}
+	page2 := data{
+		Context: &Context{Q: "jive search", Page: 2},
+	}
+
+	f.logQuery(req, web, "", noResults(web), time.Now())
+	f.logQuery(req, images, "", noResults(images), time.Now())
+	f.logQuery(req, page2, "", noResults(page2), time.Now())
+	f.logQuery(req, web, "Google", false, time.Now())
+
+	dnt := httptest.NewRequest("GET", "/?q=jive+search", nil)
+	dnt.Header.Set("Sec-GPC", "1")
+	f.logQuery(dnt, web, "", noResults(web), time.Now())
+
+	events, err := store.Events(now().Add(-time.Hour), now().Add(time.Hour))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(events) != 3 {
+		t.Fatalf("got %d events; want 3", len(events))
+	}
+
+	for i, want := range []struct {
+		vertical  string
+		bang      string
+		noResults bool
+	}{
+		{"web", "", false},
+		{"images", "", true},
+		{"bang", "Google", false},
+	} {
+		e := events[i]
+		if e.Vertical != want.vertical || e.Bang != want.bang || e.NoResults != want.noResults {
+			t.Fatalf("got %+v; want %+v", e, want)
+		}
+
+		if e.User == "" || strings.Contains(e.User, "203.0.113.1") {
+			t.Fatalf("got user %q; want an anonymized identifier", e.User)
+		}
+	}
+}
+
+func TestAdminAnalyticsHandler(t *testing.T) {
+	store := &analytics.Simple{}
+	if err := store.Setup(); err != nil {
+		t.Fatal(err)
+	}
+
+	f := &Frontend{
+		AdminToken: "secret",
+		Analytics: Analytics{
+			Store: store,
+		},
+	}
+
+	now = func() time.Time {
+		return time.Date(2018, 02, 06, 11, 30, 0, 0, time.UTC)
+	}
+
+	for _, e := range []*analytics.Event{
+		{Time: now().Add(-time.Hour), User: "a", Vertical: "web"},
+		{Time: now().Add(-48 * time.Hour), User: "b", Vertical: "web"},
+	} {
+		if err := store.Insert(e); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	for _, c := range []struct {
+		name    string
+		token   string
+		query   string
+		status  int
+		queries int
+	}{
+		{"wrong token", "wrong", "", http.StatusForbidden, 0},
+		{"last day", "secret", "", http.StatusOK, 1},
+		{"since", "secret", "since=2018-02-01T00:00:00Z", http.StatusOK, 2},
+		{"bad since", "secret", "since=yesterday", http.StatusBadRequest, 0},
+		{"backwards", "secret", "since=2018-02-06T00:00:00Z&until=2018-02-05T00:00:00Z", http.StatusBadRequest, 0},
+		{"too long", "secret", "since=2017-02-06T00:00:00Z", http.StatusBadRequest, 0},
+	} {
+		t.Run(c.name, func(t *testing.T) {
+			req := httptest.NewRequest("GET", "/admin/analytics?"+c.query, nil)
+			req.Header.Set("Authorization", "Bearer "+c.token)
+
+			rsp := f.adminAnalyticsHandler(httptest.NewRecorder(), req)
+			if rsp.status != c.status {
+				t.Fatalf("got status %d; want %d (%v)", rsp.status, c.status, rsp.err)
+			}
+
+			if c.status != http.StatusOK {
+				return
+			}
+
+			s := rsp.data.(*analytics.Summary)
+			if s.Queries != c.queries {
+				t.Fatalf("got %d queries; want %d", s.Queries, c.queries)
+			}
+		})
+	}
+}
@@ -5,13 +5,19 @@ import (
 	"encoding/json"
 	"fmt"
 	"net/http"
+	"sync"
 	"time"
 
+	"github.com/jivesearch/jivesearch/bangs"
 	"github.com/jivesearch/jivesearch/instant"
 	"github.com/jivesearch/jivesearch/instant/breach"
 	"github.com/jivesearch/jivesearch/instant/congress"
 	"github.com/jivesearch/jivesearch/instant/discography"
+	"github.com/jivesearch/jivesearch/instant/media"
+	"github.com/jivesearch/jivesearch/instant/packages"
 	"github.com/jivesearch/jivesearch/instant/parcel"
+	"github.com/jivesearch/jivesearch/instant/reference"
+	"github.com/jivesearch/jivesearch/instant/repo"
 	"github.com/jivesearch/jivesearch/instant/shortener"
 	"github.com/jivesearch/jivesearch/instant/status"
 	"github.com/jivesearch/jivesearch/instant/stock"
@@ -19,6 +25,8 @@ import (
 	"github.com/jivesearch/jivesearch/instant/whois"
 	"github.com/jivesearch/jivesearch/instant/wikipedia"
 	"github.com/jivesearch/jivesearch/log"
+	"github.com/jivesearch/jivesearch/search"
+	"github.com/jivesearch/jivesearch/search/intent"
 	"golang.org/x/text/language"
 )
 
@@ -62,8 +70,8 @@ func (f *Frontend) answerHandler(w http.ResponseWriter, r *http.Request) *respon
 	}
 
 	a.HTML = buf.String()
-	a.CSS = answerCSS(f.Brand.Host, d.Instant)
-	a.JavaScript = answerJS(f.Brand.Host, d.Instant)
+	a.CSS = answerCSS(d.Brand.Host, d.Instant)
+	a.JavaScript = answerJS(d.Brand.Host, d.Instant)
 	resp.data = a
 
 	return resp
@@ -71,12 +79,9 @@ func (f *Frontend) answerHandler(w http.ResponseWriter, r *http.Request) *respon
 
 func (f *Frontend) getAnswer(r *http.Request, dd data, ic chan instant.Data) {
 	lang, _, _ := f.Wikipedia.Matcher.Match(dd.Context.Preferred...)
-	key := cacheKey("instant", lang, f.detectRegion(lang, r), r.URL)
+	key := cacheKey("instant", lang, f.detectRegion(lang, r), formURL(r))
 
-	v, err := f.Cache.Get(key)
-	if err != nil {
-		log.Info.Println(err)
-	}
+	v := f.cacheGet(r.Context(), "instant", key)
 
 	if v != nil {
 		ir := &Instant{
@@ -99,17 +104,23 @@ func (f *Frontend) getAnswer(r *http.Request, dd data, ic chan instant.Data) {
 
 	var d = f.Cache.Instant
 
-	res := f.DetectInstantAnswer(r, lang, onlyMaps)
+	res := f.DetectInstantAnswer(r, lang, onlyMaps, dd.Context.Intent)
 
 	var cache bool
 
 	switch res.Type {
-	case instant.WikidataClockType, instant.CoinTossType, instant.LocalWeatherType, instant.RandomType, instant.UserAgentType: // only local weather
+	case instant.WikidataClockType, instant.CoinTossType, instant.LocalWeatherType, instant.RandomType, instant.UserAgentType, instant.MyIPType: // only local weather
+		cache = false
+	case instant.DiceType, instant.PasswordType, instant.UUIDType: // a cached password would be anything but random
+		cache = false
+	case instant.DateMathType, instant.HolidayType: // "today" changes daily
 		cache = false
 	case instant.CurrencyType, instant.StockQuoteType, instant.FedExType, instant.UPSType, instant.USPSType:
 		d = 1 * time.Minute
 		cache = true
-	case instant.WikipediaType: // I can't figure out how to cache this without errors...
+	case instant.BangsType: // our !bangs can be edited without a restart
+		cache = false
+	case instant.WikipediaType: // doesn't survive a round trip through json. The AnswerCache keeps it instead.
 		cache = false
 	default:
 		cache = true
@@ -120,99 +131,78 @@ func (f *Frontend) getAnswer(r *http.Request, dd data, ic chan instant.Data) {
 			d = f.Cache.Instant
 		}
 
-		if err := f.Cache.Put(key, res, d); err != nil {
-			log.Info.Println(err)
-		}
+		f.cachePut(r.Context(), key, res, d)
 	}
 
 	ic <- res
 }
 
-// DetectInstantAnswer triggers the instant answers
-func (f *Frontend) DetectInstantAnswer(r *http.Request, lang language.Tag, onlyMaps bool) instant.Data {
-	var answers []instant.Answerer
-
+// DetectInstantAnswer triggers the instant answers, trying those that serve the query's intent first
+func (f *Frontend) DetectInstantAnswer(r *http.Request, lang language.Tag, onlyMaps bool, scores intent.Scores) instant.Data {
 	// select all answers by default, unless user chooses maps
-	switch onlyMaps {
-	case true:
-		answers = []instant.Answerer{
-			&instant.Maps{LocationFetcher: f.Instant.LocationFetcher},
-			&instant.Wikipedia{
-				Fetcher: f.Instant.WikipediaFetcher,
-			},
-		}
-	default:
-		answers = []instant.Answerer{
-			&instant.BirthStone{},
-			&instant.Breach{
-				Fetcher: f.Instant.BreachFetcher,
-			},
-			&instant.Calculator{},
-			&instant.CamelCase{},
-			&instant.Characters{},
-			&instant.Coin{},
-			&instant.Congress{
-				Fetcher: f.Instant.CongressFetcher,
-			},
-			&instant.CountryCode{},
-			&instant.Currency{
-				CryptoFetcher: f.Instant.CryptoFetcher,
-				FXFetcher:     f.Instant.FXFetcher,
-			},
-			&instant.Discography{Fetcher: f.Instant.DiscographyFetcher},
-			&instant.DigitalStorage{},
-			&instant.FedEx{Fetcher: f.Instant.FedExFetcher},
-			&instant.Frequency{},
-			&instant.GDP{GDPFetcher: f.Instant.GDPFetcher},
-			&instant.Hash{},
-			&instant.Speed{}, // trigger "miles per hour" b/f "miles"
-			&instant.Length{},
-			&instant.Maps{LocationFetcher: f.Instant.LocationFetcher},
-			&instant.Minify{},
-			&instant.MortgageCalculator{},
-			&instant.Population{PopulationFetcher: f.Instant.PopulationFetcher},
-			&instant.Potus{},
-			&instant.Power{},
-			&instant.Prime{},
-			&instant.Random{},
-			&instant.Reverse{},
-			&instant.Shortener{Service: f.Instant.LinkShortener},
-			&instant.Stats{},
-			&instant.Status{Fetcher: f.Instant.StatusFetcher},
-			&instant.StockQuote{Fetcher: f.Instant.StockQuoteFetcher},
-			&instant.Temperature{},
-			&instant.USPS{Fetcher: f.Instant.USPSFetcher},
-			&instant.UPS{Fetcher: f.Instant.UPSFetcher},
-			&instant.URLDecode{},
-			&instant.URLEncode{},
-			&instant.UserAgent{},
-			&instant.StackOverflow{Fetcher: f.Instant.StackOverflowFetcher},
-			&instant.Weather{Fetcher: f.Instant.WeatherFetcher, LocationFetcher: f.Instant.LocationFetcher},
-			&instant.WHOIS{Fetcher: f.Instant.WHOISFetcher},
-			&instant.Wikipedia{
-				LocationFetcher:  f.Instant.LocationFetcher,
-				NutritionFetcher: f.Instant.NutritionFetcher,
-				TimeZoneFetcher:  f.Instant.TimeZoneFetcher,
-				Fetcher:          f.Instant.WikipediaFetcher,
-			}, // always keep this last so that Wikipedia Box will trigger if none other
+	answers := f.Instant.Answerers(onlyMaps, scores)
+
+	fallback := f.wikipediaFallback(r)
+	region := f.detectRegion(lang, r)
+	for _, ia := range answers {
+		switch a := ia.(type) {
+		case *instant.Wikipedia:
+			a.Fallback = fallback
+		case *instant.DateMath:
+			a.Region = region
+		case *instant.Holiday:
+			a.Region = region
+		case *instant.Bangs:
+			if f.Bangs != nil {
+				a.List = f.Bangs.Bangs
+			}
 		}
 	}
 
-	// Necessary to use goroutines??? setSolution called only when triggered.
-	// Also, the order of some answers matters, like Wikipedia, which is a catch-all
+	// Every triggered answer is solved so they can be ranked against each other.
+	// The answers are kept in the order they were tried, which breaks ties in confidence.
+	triggered := []instant.Answerer{}
 	for _, ia := range answers {
-		if triggered := f.Instant.Trigger(ia, r, lang); triggered {
-			sol := f.Instant.Solve(ia, r)
-			if sol.Err != nil {
-				log.Debug.Println(sol.Err)
-				continue
-			}
+		if f.Instant.Trigger(ia, r, lang) {
+			triggered = append(triggered, ia)
+		}
+	}
 
-			return sol
+	solutions := make([]instant.Data, len(triggered))
+
+	var wg sync.WaitGroup
+	for i, ia := range triggered {
+		wg.Add(1)
+		go func(i int, ia instant.Answerer) {
+			defer wg.Done()
+			solutions[i] = f.Instant.SolveCached(ia, r, region)
+		}(i, ia)
+	}
+	wg.Wait()
+
+	solved := []instant.Data{}
+	for _, sol := range solutions {
+		if sol.Err != nil {
+			log.Debug.Println(sol.Err)
+			continue
 		}
+		solved = append(solved, sol)
 	}
 
-	return instant.Data{}
+	return instant.Rank(solved)
+}
+
+// wikipediaFallback is the user's preferred languages that Wikipedia supports
+func (f *Frontend) wikipediaFallback(r *http.Request) []language.Tag {
+	langs := []language.Tag{}
+
+	for _, p := range f.detectLanguage(r) {
+		if lang, _, c := f.Wikipedia.Matcher.Match(p); c != language.No {
+			langs = append(langs, lang)
+		}
+	}
+
+	return langs
 }
 
 // UnmarshalJSON unmarshals an instant answer to the correct data structure
@@ -233,6 +223,20 @@ func (d *Instant) UnmarshalJSON(b []byte) error {
 	d.Data = raw.Data
 	d.Solution = raw.Solution
 
+	for i, sec := range raw.Secondary {
+		b, err := json.Marshal(sec)
+		if err != nil {
+			return err
+		}
+
+		s := &Instant{}
+		if err := json.Unmarshal(b, s); err != nil {
+			return err
+		}
+
+		d.Secondary[i] = s.Data
+	}
+
 	s := detectType(raw.Type)
 	if s == nil { // a string
 		return nil
@@ -247,30 +251,78 @@ func detectType(t instant.Type) interface{} {
 	var v interface{}
 
 	switch t {
+	case instant.BangsType:
+		v = &[]bangs.Bang{}
 	case instant.BreachType:
 		v = &breach.Response{}
+	case instant.BMIType:
+		v = &instant.BMIResponse{}
+	case instant.CalculatorType:
+		v = &instant.CalculatorResponse{}
+	case instant.ColorType:
+		v = &instant.ColorResponse{}
 	case instant.CongressType:
 		v = &congress.Response{}
 	case instant.CountryCodeType:
 		v = &instant.CountryCodeResponse{}
+	case instant.CronType:
+		v = &instant.CronResponse{}
+	case instant.DateMathType:
+		v = &instant.DateMathResponse{}
+	case instant.DiceType:
+		v = &instant.DiceResponse{}
 	case instant.DiscographyType:
 		v = &[]discography.Album{}
+	case instant.DNSType:
+		v = &instant.DNSResponse{}
 	case instant.CurrencyType:
 		v = &instant.CurrencyResponse{}
+	case instant.ElementType:
+		v = &instant.ElementResponse{}
 	case instant.FedExType, instant.UPSType, instant.USPSType:
 		v = &parcel.Response{}
 	case instant.GDPType:
 		v = &instant.GDPResponse{}
 	case instant.HashType:
 		v = &instant.HashResponse{}
+	case instant.HTTPStatusType:
+		v = &reference.Status{}
+	case instant.HolidayType:
+		v = &instant.HolidayResponse{}
+	case instant.HolidaysType:
+		v = &instant.HolidaysResponse{}
+	case instant.IPType:
+		v = &instant.IPResponse{}
+	case instant.MediaType:
+		v = &media.Title{}
+	case instant.MIMEType:
+		v = &reference.MIMEType{}
+	case instant.MortageCalculatorType:
+		v = &instant.MortgageResponse{}
+	case instant.OperatorsType:
+		v = &[]search.Operator{}
+	case instant.PackageType:
+		v = &packages.Package{}
+	case instant.PercentageType:
+		v = &instant.PercentageResponse{}
 	case instant.PopulationType:
 		v = &instant.PopulationResponse{}
+	case instant.PortType:
+		v = &instant.PortResponse{}
+	case instant.RegexType:
+		v = &instant.RegexResponse{}
+	case instant.RepositoryType:
+		v = &repo.Repository{}
+	case instant.SongwriterType:
+		v = &discography.Song{}
 	case instant.StackOverflowType:
 		v = &instant.StackOverflowAnswer{}
 	case instant.StatusType:
 		v = &status.Response{}
 	case instant.StockQuoteType:
 		v = &stock.Quote{}
+	case instant.TipType:
+		v = &instant.TipResponse{}
 	case instant.URLShortenerType:
 		v = &shortener.Response{}
 	case instant.LocalWeatherType, instant.WeatherType:
@@ -279,6 +331,8 @@ func detectType(t instant.Type) interface{} {
 		v = &whois.Response{}
 	case instant.WikipediaType:
 		v = []*wikipedia.Item{}
+	case instant.WordsType:
+		v = &instant.WordsResponse{}
 	case instant.WikidataAgeType:
 		v = &instant.Age{
 			Birthday: &instant.Birthday{},
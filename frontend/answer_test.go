@@ -1,22 +1,29 @@
 package frontend
 
 import (
+	"encoding/json"
 	"net/http"
 	"net/http/httptest"
 	"reflect"
 	"testing"
 	"time"
 
+	"github.com/jivesearch/jivesearch/bangs"
 	"github.com/jivesearch/jivesearch/instant"
 	"github.com/jivesearch/jivesearch/instant/breach"
 	"github.com/jivesearch/jivesearch/instant/discography"
+	"github.com/jivesearch/jivesearch/instant/media"
+	"github.com/jivesearch/jivesearch/instant/packages"
 	"github.com/jivesearch/jivesearch/instant/parcel"
+	"github.com/jivesearch/jivesearch/instant/reference"
+	"github.com/jivesearch/jivesearch/instant/repo"
 	"github.com/jivesearch/jivesearch/instant/shortener"
 	"github.com/jivesearch/jivesearch/instant/status"
 	"github.com/jivesearch/jivesearch/instant/stock"
 	"github.com/jivesearch/jivesearch/instant/weather"
 	"github.com/jivesearch/jivesearch/instant/whois"
 	"github.com/jivesearch/jivesearch/instant/wikipedia"
+	"github.com/jivesearch/jivesearch/search"
 	"golang.org/x/text/language"
 )
 
@@ -36,8 +43,9 @@ func TestAnswerHandler(t *testing.T) {
 				status:   http.StatusOK,
 				template: "jsonp",
 				data: &AnswerResponse{
-					HTML: `<div id=answer class=pure-u-1><div style=margin:15px;margin-bottom:5px>Garnet</div><div class=pure-u-1 style=margin-top:5px><div class=pure-u-1 style=margin-top:7px><div id=source class=pure-u-22-24 style=float:left;text-align:left;padding:15px><em>Source</em><br>Jive Search
-<span style=float:right;text-align:right><a href=#open-widget onclick="document.getElementById('open-widget').style.display='block'">Get Widget</a></span></div></div></div></div>`,
+					HTML: `<div id=answer class=pure-u-1><div style=margin:15px;margin-bottom:5px>Garnet</div><div class=pure-u-1 style=margin-top:5px><div class=pure-u-1 style=margin-top:7px><div id=source class=pure-u-22-24 style=padding:15px><em>Source</em><br>Jive Search
+<span class=get_widget><a class=open_widget href=#open-widget>Get Widget</a></span>
+<span class=share_answer><a href=http://anything.com/answer/birthstone/january-birthstone>Share</a></span></div></div></div></div>`,
 					CSS:        []string{},
 					JavaScript: []string{},
 				},
@@ -49,7 +57,7 @@ func TestAnswerHandler(t *testing.T) {
 				status:   http.StatusOK,
 				template: "jsonp",
 				data: &AnswerResponse{
-					HTML: `<div id=answer class=pure-u-1 style=width:323px;height:500px;padding:25px;padding-bottom:0><noscript><div id=answer class=pure-u-1><div style=margin:15px;margin-bottom:5px>4</div></div></noscript><div id=calculator style=display:none><div id=result tabindex=4>4</div><div id=main><div id=first-row><button id=clear class=del-bg>C</button>
+					HTML: `<div id=answer class=pure-u-1 style=width:323px;height:500px;padding:25px;padding-bottom:0><noscript><div id=answer class=pure-u-1><div style=margin:15px;margin-bottom:5px>2 &#43; 2 = 4</div></div></noscript><div id=calculator style=display:none><div id=expression>2 &#43; 2 =</div><div id=result tabindex=4>4</div><div id=main><div id=first-row><button id=clear class=del-bg>C</button>
 <button class="btn-style operator opera-bg fall-back" value=%>%</button>
 <button class="btn-style opera-bg align operator" value=/>/</button></div><div class=rows><button class="btn-style num-bg number first-child" value=7>7</button>
 <button class="btn-style num-bg number" value=8>8</button>
@@ -62,8 +70,9 @@ func TestAnswerHandler(t *testing.T) {
 <button class="btn-style num-bg number" value=3>3</button>
 <button class="btn-style opera-bg operator" value=+>+</button></div><div class=rows><button id=zero class="num-bg zero" value=0>0</button>
 <button class="btn-style num-bg period fall-back" value=.>.</button>
-<button id=eqn-bg class="eqn align" value="=">=</button></div></div></div><div class=pure-u-1 style=margin-top:5px><div class=pure-u-1 style=margin-top:7px><div id=source class=pure-u-22-24 style=float:left;text-align:left;padding:15px><em>Source</em><br>Jive Search
-<span style=float:right;text-align:right><a href=#open-widget onclick="document.getElementById('open-widget').style.display='block'">Get Widget</a></span></div></div></div></div>`,
+<button id=eqn-bg class="eqn align" value="=">=</button></div></div></div><div class=pure-u-1 style=margin-top:5px><div class=pure-u-1 style=margin-top:7px><div id=source class=pure-u-22-24 style=padding:15px><em>Source</em><br>Jive Search
+<span class=get_widget><a class=open_widget href=#open-widget>Get Widget</a></span>
+<span class=share_answer><a href=http://anything.com/answer/calculator/2&#43;2>Share</a></span></div></div></div></div>`,
 					CSS:        []string{"http://anything.com/static/instant/calculator/calculator.css"},
 					JavaScript: []string{"http://anything.com/static/instant/calculator/calculator.js"},
 				},
@@ -136,22 +145,47 @@ func TestDetectType(t *testing.T) {
 		name instant.Type
 		want interface{}
 	}{
+		{instant.BangsType, &[]bangs.Bang{}},
 		{instant.BirthStoneType, nil},
 		{instant.BreachType, &breach.Response{}},
+		{instant.BMIType, &instant.BMIResponse{}},
+		{instant.CalculatorType, &instant.CalculatorResponse{}},
+		{instant.ColorType, &instant.ColorResponse{}},
 		{instant.CountryCodeType, &instant.CountryCodeResponse{}},
+		{instant.CronType, &instant.CronResponse{}},
 		{instant.CurrencyType, &instant.CurrencyResponse{}},
+		{instant.DateMathType, &instant.DateMathResponse{}},
+		{instant.DiceType, &instant.DiceResponse{}},
 		{instant.DiscographyType, &[]discography.Album{}},
+		{instant.DNSType, &instant.DNSResponse{}},
+		{instant.ElementType, &instant.ElementResponse{}},
 		{instant.FedExType, &parcel.Response{}},
 		{instant.GDPType, &instant.GDPResponse{}},
 		{instant.HashType, &instant.HashResponse{}},
+		{instant.HTTPStatusType, &reference.Status{}},
+		{instant.HolidayType, &instant.HolidayResponse{}},
+		{instant.HolidaysType, &instant.HolidaysResponse{}},
+		{instant.IPType, &instant.IPResponse{}},
+		{instant.MediaType, &media.Title{}},
+		{instant.MIMEType, &reference.MIMEType{}},
+		{instant.MortageCalculatorType, &instant.MortgageResponse{}},
+		{instant.OperatorsType, &[]search.Operator{}},
+		{instant.PackageType, &packages.Package{}},
+		{instant.PercentageType, &instant.PercentageResponse{}},
 		{instant.PopulationType, &instant.PopulationResponse{}},
+		{instant.PortType, &instant.PortResponse{}},
+		{instant.RegexType, &instant.RegexResponse{}},
+		{instant.RepositoryType, &repo.Repository{}},
+		{instant.SongwriterType, &discography.Song{}},
 		{instant.StackOverflowType, &instant.StackOverflowAnswer{}},
 		{instant.StatusType, &status.Response{}},
 		{instant.StockQuoteType, &stock.Quote{}},
+		{instant.TipType, &instant.TipResponse{}},
 		{instant.URLShortenerType, &shortener.Response{}},
 		{instant.WeatherType, &weather.Weather{}},
 		{instant.WHOISType, &whois.Response{}},
 		{instant.WikipediaType, []*wikipedia.Item{}},
+		{instant.WordsType, &instant.WordsResponse{}},
 		{
 			"wikidata age", &instant.Age{
 				Birthday: &instant.Birthday{},
@@ -174,3 +208,66 @@ func TestDetectType(t *testing.T) {
 		})
 	}
 }
+
+func TestWikipediaFallback(t *testing.T) {
+	for _, c := range []struct {
+		name   string
+		l      string
+		header string
+		want   []language.Tag
+	}{
+		{"none", "", "", []language.Tag{}},
+		{"header", "", "fr,de;q=0.8,ja;q=0.5", []language.Tag{language.French, language.German}},
+		{"l param", "de", "fr", []language.Tag{language.German, language.French}},
+	} {
+		t.Run(c.name, func(t *testing.T) {
+			f := &Frontend{
+				Wikipedia: Wikipedia{
+					Matcher: language.NewMatcher([]language.Tag{language.English, language.French, language.German}),
+				},
+			}
+
+			req, err := http.NewRequest("GET", "/?l="+c.l, nil)
+			if err != nil {
+				t.Fatal(err)
+			}
+			req.Header.Set("Accept-Language", c.header)
+
+			got := f.wikipediaFallback(req)
+			if !reflect.DeepEqual(got, c.want) {
+				t.Fatalf("got %+v; want %+v", got, c.want)
+			}
+		})
+	}
+}
+
+func TestInstantUnmarshalSecondary(t *testing.T) {
+	want := instant.Data{
+		Type:       instant.StockQuoteType,
+		Triggered:  true,
+		Solution:   &stock.Quote{Ticker: "AAPL"},
+		Confidence: .7,
+		Secondary: []instant.Data{
+			{
+				Type:       instant.HashType,
+				Triggered:  true,
+				Solution:   &instant.HashResponse{Original: "apple"},
+				Confidence: .6,
+			},
+		},
+	}
+
+	b, err := json.Marshal(want)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	got := &Instant{}
+	if err := json.Unmarshal(b, got); err != nil {
+		t.Fatal(err)
+	}
+
+	if !reflect.DeepEqual(got.Data, want) {
+		t.Fatalf("got %+v; want %+v", got.Data, want)
+	}
+}
@@ -0,0 +1,92 @@
+// Package apikey issues and verifies keys for our JSON API
+package apikey
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"strings"
+	"time"
+)
+
+// ErrNotFound indicates an unknown key
+var ErrNotFound = errors.New("api key not found")
+
+// Store outlines the methods to persist our keys.
+// Only the hash of a key is ever stored.
+type Store interface {
+	Setup() error
+	Insert(k *Key) error
+	Get(hash string) (*Key, error)
+	List() ([]*Key, error)
+	Revoke(id string) error
+	// Increment counts a request against the key's usage for the day and returns the new total
+	Increment(id string, day time.Time) (int, error)
+}
+
+// Key is an API key.
+// Quota is the max requests per day (UTC) and Rate & Burst override the default
+// rate limits. Zero values mean unlimited or default, respectively.
+// Sites are the hosts the key can search with our site search widget.
+type Key struct {
+	ID      string    `json:"id"`
+	Hash    string    `json:"-"`
+	Name    string    `json:"name"`
+	Quota   int       `json:"quota"`
+	Rate    float64   `json:"rate"`
+	Burst   int       `json:"burst"`
+	Sites   []string  `json:"sites,omitempty"`
+	Created time.Time `json:"created"`
+	Revoked bool      `json:"revoked"`
+}
+
+// New creates a key. The secret is returned only once as we just store its hash.
+// Secrets look like "<id>.<random>" so a key can be identified in logs without revealing it.
+func New(name string, quota int, rate float64, burst int) (string, *Key, error) {
+	b := make([]byte, 36)
+	if _, err := rand.Read(b); err != nil {
+		return "", nil, err
+	}
+
+	id := hex.EncodeToString(b[:4])
+	secret := id + "." + base64.RawURLEncoding.EncodeToString(b[4:])
+
+	k := &Key{
+		ID:      id,
+		Hash:    Hash(secret),
+		Name:    name,
+		Quota:   quota,
+		Rate:    rate,
+		Burst:   burst,
+		Created: now(),
+	}
+
+	return secret, k, nil
+}
+
+// Allows is true if the key can search a host. A site's subdomains are included.
+func (k *Key) Allows(host string) bool {
+	host = strings.ToLower(host)
+	for _, s := range k.Sites {
+		if host == s || strings.HasSuffix(host, "."+s) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// Hash is the sha256 of the secret. Our secrets are random so a plain hash suffices.
+func Hash(secret string) string {
+	h := sha256.Sum256([]byte(strings.TrimSpace(secret)))
+	return hex.EncodeToString(h[:])
+}
+
+// Day truncates t to the UTC day that quotas are counted by
+func Day(t time.Time) time.Time {
+	return t.UTC().Truncate(24 * time.Hour)
+}
+
+var now = func() time.Time { return time.Now().UTC() }
@@ -0,0 +1,67 @@
+package apikey
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestNew(t *testing.T) {
+	now = func() time.Time {
+		return time.Date(2018, 02, 06, 11, 0, 0, 0, time.UTC)
+	}
+
+	secret, k, err := New("acme", 1000, 5, 50)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !strings.HasPrefix(secret, k.ID+".") {
+		t.Fatalf("secret %q should start with its id %q", secret, k.ID)
+	}
+
+	if k.Hash != Hash(secret) || k.Hash == secret {
+		t.Fatalf("got hash %q; want the hash of the secret", k.Hash)
+	}
+
+	want := Key{ID: k.ID, Hash: k.Hash, Name: "acme", Quota: 1000, Rate: 5, Burst: 50, Created: now()}
+	if !reflect.DeepEqual(*k, want) {
+		t.Fatalf("got %+v; want %+v", k, want)
+	}
+
+	other, _, err := New("acme", 1000, 5, 50)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if other == secret {
+		t.Fatal("expected unique secrets")
+	}
+}
+
+func TestAllows(t *testing.T) {
+	k := &Key{Sites: []string{"example.com", "blog.example.org"}}
+
+	for host, want := range map[string]bool{
+		"example.com":      true,
+		"Docs.Example.com": true,
+		"badexample.com":   false,
+		"example.org":      false,
+		"blog.example.org": true,
+		"":                 false,
+	} {
+		if got := k.Allows(host); got != want {
+			t.Fatalf("got %v for %q; want %v", got, host, want)
+		}
+	}
+}
+
+func TestDay(t *testing.T) {
+	got := Day(time.Date(2018, 02, 06, 23, 59, 0, 0, time.FixedZone("EST", -5*60*60)))
+	want := time.Date(2018, 02, 07, 0, 0, 0, 0, time.UTC)
+
+	if !got.Equal(want) {
+		t.Fatalf("got %v; want %v", got, want)
+	}
+}
@@ -0,0 +1,133 @@
+package apikey
+
+import (
+	"database/sql"
+	"strings"
+	"time"
+)
+
+// PostgreSQL stores our keys in PostgreSQL
+type PostgreSQL struct {
+	*sql.DB
+}
+
+const keysTable = "apikeys"
+const usageTable = "apikey_usage"
+
+// Setup creates our tables if they don't exist
+func (p *PostgreSQL) Setup() error {
+	_, err := p.DB.Exec(`
+		CREATE TABLE IF NOT EXISTS ` + keysTable + ` (
+			id text PRIMARY KEY,
+			hash text NOT NULL UNIQUE,
+			name text NOT NULL,
+			quota integer NOT NULL DEFAULT 0,
+			rate double precision NOT NULL DEFAULT 0,
+			burst integer NOT NULL DEFAULT 0,
+			sites text NOT NULL DEFAULT '',
+			created timestamptz NOT NULL,
+			revoked boolean NOT NULL DEFAULT false
+		);
+		ALTER TABLE ` + keysTable + ` ADD COLUMN IF NOT EXISTS sites text NOT NULL DEFAULT '';
+		CREATE TABLE IF NOT EXISTS ` + usageTable + ` (
+			id text NOT NULL REFERENCES ` + keysTable + ` (id),
+			day date NOT NULL,
+			count integer NOT NULL,
+			PRIMARY KEY (id, day)
+		);
+	`)
+
+	return err
+}
+
+// Insert adds a key
+func (p *PostgreSQL) Insert(k *Key) error {
+	_, err := p.DB.Exec(
+		`INSERT INTO `+keysTable+` (id, hash, name, quota, rate, burst, sites, created, revoked) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)`,
+		k.ID, k.Hash, k.Name, k.Quota, k.Rate, k.Burst, strings.Join(k.Sites, ","), k.Created, k.Revoked,
+	)
+
+	return err
+}
+
+// Get retrieves a key by its hash
+func (p *PostgreSQL) Get(hash string) (*Key, error) {
+	k := &Key{}
+	var sites string
+
+	err := p.DB.QueryRow(
+		`SELECT id, hash, name, quota, rate, burst, sites, created, revoked FROM `+keysTable+` WHERE hash = $1`, hash,
+	).Scan(&k.ID, &k.Hash, &k.Name, &k.Quota, &k.Rate, &k.Burst, &sites, &k.Created, &k.Revoked)
+
+	if err == sql.ErrNoRows {
+		return nil, ErrNotFound
+	}
+
+	k.Sites = split(sites)
+	return k, err
+}
+
+// List returns all keys, oldest first
+func (p *PostgreSQL) List() ([]*Key, error) {
+	rows, err := p.DB.Query(`SELECT id, hash, name, quota, rate, burst, sites, created, revoked FROM ` + keysTable + ` ORDER BY created, id`)
+	if err != nil {
+		return nil, err
+	}
+
+	defer rows.Close()
+
+	keys := []*Key{}
+	for rows.Next() {
+		k := &Key{}
+		var sites string
+		if err := rows.Scan(&k.ID, &k.Hash, &k.Name, &k.Quota, &k.Rate, &k.Burst, &sites, &k.Created, &k.Revoked); err != nil {
+			return nil, err
+		}
+		k.Sites = split(sites)
+		keys = append(keys, k)
+	}
+
+	return keys, rows.Err()
+}
+
+// Revoke disables a key
+func (p *PostgreSQL) Revoke(id string) error {
+	res, err := p.DB.Exec(`UPDATE `+keysTable+` SET revoked = true WHERE id = $1`, id)
+	if err != nil {
+		return err
+	}
+
+	n, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if n == 0 {
+		return ErrNotFound
+	}
+
+	return nil
+}
+
+// Increment counts a request against the key's usage for the day
+func (p *PostgreSQL) Increment(id string, day time.Time) (int, error) {
+	var count int
+
+	err := p.DB.QueryRow(`
+		INSERT INTO `+usageTable+` (id, day, count) VALUES ($1, $2, 1)
+		ON CONFLICT (id, day) DO UPDATE SET count = `+usageTable+`.count + 1
+		RETURNING count`,
+		id, Day(day).Format("2006-01-02"),
+	).Scan(&count)
+
+	return count, err
+}
+
+// split the comma-separated sites of a key
+func split(sites string) []string {
+	if sites == "" {
+		return nil
+	}
+
+	return strings.Split(sites, ",")
+}
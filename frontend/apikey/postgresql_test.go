@@ -0,0 +1,66 @@
+package apikey
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	sqlmock "gopkg.in/DATA-DOG/go-sqlmock.v2"
+)
+
+func TestPostgreSQL(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+
+	p := &PostgreSQL{DB: db}
+	created := time.Date(2018, 02, 06, 11, 0, 0, 0, time.UTC)
+	cols := []string{"id", "hash", "name", "quota", "rate", "burst", "sites", "created", "revoked"}
+
+	mock.ExpectQuery("SELECT (.+) FROM apikeys WHERE hash").WithArgs("abc").
+		WillReturnRows(sqlmock.NewRows(cols).AddRow("1a2b", "abc", "acme", 1000, 5.0, 50, "example.com,example.org", created, false))
+
+	got, err := p.Get("abc")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := &Key{
+		ID: "1a2b", Hash: "abc", Name: "acme", Quota: 1000, Rate: 5, Burst: 50,
+		Sites: []string{"example.com", "example.org"}, Created: created,
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("got %+v; want %+v", got, want)
+	}
+
+	mock.ExpectQuery("SELECT (.+) FROM apikeys WHERE hash").WithArgs("wrong").
+		WillReturnRows(sqlmock.NewRows(cols))
+
+	if _, err := p.Get("wrong"); err != ErrNotFound {
+		t.Fatalf("got %v; want %v", err, ErrNotFound)
+	}
+
+	mock.ExpectExec("UPDATE apikeys SET revoked").WithArgs("nope").WillReturnResult(sqlmock.NewResult(0, 0))
+
+	if err := p.Revoke("nope"); err != ErrNotFound {
+		t.Fatalf("got %v; want %v", err, ErrNotFound)
+	}
+
+	mock.ExpectQuery("INSERT INTO apikey_usage").WithArgs("1a2b", "2018-02-06").
+		WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(7))
+
+	n, err := p.Increment("1a2b", created)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if n != 7 {
+		t.Fatalf("got usage %d; want 7", n)
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Fatal(err)
+	}
+}
@@ -0,0 +1,94 @@
+package apikey
+
+import (
+	"sort"
+	"sync"
+	"time"
+)
+
+// Simple is an in-memory Store. Keys are lost on restart so it is only suitable for testing.
+type Simple struct {
+	mu    sync.Mutex
+	keys  map[string]*Key // by hash
+	usage map[string]int  // by id & day
+}
+
+// Setup initializes the store
+func (s *Simple) Setup() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.keys = make(map[string]*Key)
+	s.usage = make(map[string]int)
+	return nil
+}
+
+// Insert adds a key
+func (s *Simple) Insert(k *Key) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	kk := *k
+	s.keys[k.Hash] = &kk
+	return nil
+}
+
+// Get retrieves a key by its hash
+func (s *Simple) Get(hash string) (*Key, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	k, ok := s.keys[hash]
+	if !ok {
+		return nil, ErrNotFound
+	}
+
+	kk := *k
+	return &kk, nil
+}
+
+// List returns all keys, oldest first
+func (s *Simple) List() ([]*Key, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	keys := []*Key{}
+	for _, k := range s.keys {
+		kk := *k
+		keys = append(keys, &kk)
+	}
+
+	sort.Slice(keys, func(i, j int) bool {
+		if keys[i].Created.Equal(keys[j].Created) {
+			return keys[i].ID < keys[j].ID
+		}
+		return keys[i].Created.Before(keys[j].Created)
+	})
+
+	return keys, nil
+}
+
+// Revoke disables a key
+func (s *Simple) Revoke(id string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for _, k := range s.keys {
+		if k.ID == id {
+			k.Revoked = true
+			return nil
+		}
+	}
+
+	return ErrNotFound
+}
+
+// Increment counts a request against the key's usage for the day
+func (s *Simple) Increment(id string, day time.Time) (int, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	u := id + "::" + Day(day).Format("2006-01-02")
+	s.usage[u]++
+	return s.usage[u], nil
+}
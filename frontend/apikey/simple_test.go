@@ -0,0 +1,68 @@
+package apikey
+
+import (
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestSimple(t *testing.T) {
+	s := &Simple{}
+	if err := s.Setup(); err != nil {
+		t.Fatal(err)
+	}
+
+	secret, k, err := New("acme", 2, 0, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err := s.Insert(k); err != nil {
+		t.Fatal(err)
+	}
+
+	got, err := s.Get(Hash(secret))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !reflect.DeepEqual(got, k) {
+		t.Fatalf("got %+v; want %+v", got, k)
+	}
+
+	if _, err := s.Get(Hash("wrong")); err != ErrNotFound {
+		t.Fatalf("got %v; want %v", err, ErrNotFound)
+	}
+
+	day := time.Date(2018, 02, 06, 11, 0, 0, 0, time.UTC)
+	for i, want := range []int{1, 2, 3} {
+		n, err := s.Increment(k.ID, day.Add(time.Duration(i)*time.Hour))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if n != want {
+			t.Fatalf("got usage %d; want %d", n, want)
+		}
+	}
+
+	if n, _ := s.Increment(k.ID, day.Add(24*time.Hour)); n != 1 {
+		t.Fatalf("got usage %d on a new day; want 1", n)
+	}
+
+	if err := s.Revoke(k.ID); err != nil {
+		t.Fatal(err)
+	}
+
+	keys, err := s.List()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(keys) != 1 || !keys[0].Revoked {
+		t.Fatalf("got %+v; want 1 revoked key", keys)
+	}
+
+	if err := s.Revoke("nope"); err != ErrNotFound {
+		t.Fatalf("got %v; want %v", err, ErrNotFound)
+	}
+}
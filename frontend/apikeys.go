@@ -0,0 +1,207 @@
+package frontend
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/jivesearch/jivesearch/frontend/apikey"
+	"github.com/jivesearch/jivesearch/log"
+)
+
+// APIKeys guard our JSON API
+type APIKeys struct {
+	apikey.Store
+	Required bool // refuse keyless API requests that don't come from our own pages
+}
+
+type contextKey string
+
+const apiKeyContext contextKey = "apikey"
+
+// apiAccess verifies the API key, if any, and counts the request against its daily quota.
+// The key is passed along in the request's context for rate limiting.
+func (f *Frontend) apiAccess(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if f.APIKeys.Store == nil || !isAPIRequest(r) {
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		secret := apiKey(r)
+		if secret == "" {
+			if f.APIKeys.Required && !sameOrigin(r) {
+				http.Error(w, "API key required", http.StatusUnauthorized)
+				return
+			}
+
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		k, err := f.APIKeys.Get(apikey.Hash(secret))
+		switch {
+		case err == apikey.ErrNotFound, err == nil && k.Revoked:
+			http.Error(w, http.StatusText(http.StatusForbidden), http.StatusForbidden)
+			return
+		case err != nil:
+			log.Info.Println(err)
+			http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+			return
+		}
+
+		if retry, ok := f.quota(k); !ok {
+			w.Header().Set("Retry-After", strconv.Itoa(int(math.Ceil(retry.Seconds()))))
+			http.Error(w, errQuota.Error(), http.StatusTooManyRequests)
+			return
+		}
+
+		next.ServeHTTP(w, r.WithContext(context.WithValue(r.Context(), apiKeyContext, k)))
+	})
+}
+
+var errQuota = fmt.Errorf("daily quota exceeded")
+
+// quota counts a request against the key's daily quota.
+// Once over it, retry is how long until the quota resets.
+func (f *Frontend) quota(k *apikey.Key) (retry time.Duration, ok bool) {
+	t := now()
+
+	n, err := f.APIKeys.Increment(k.ID, t)
+	if err != nil {
+		log.Info.Println(err)
+	}
+
+	if k.Quota > 0 && n > k.Quota {
+		return apikey.Day(t).Add(24 * time.Hour).Sub(t), false
+	}
+
+	return 0, true
+}
+
+// isAPIRequest is true for the /api/ endpoints and json search results.
+// Bulk exports count against a key's quota just like json.
+func isAPIRequest(r *http.Request) bool {
+	switch r.FormValue("o") { // posted searches too
+	case "json", "jsonp", "csv", "jsonl":
+		return true
+	}
+
+	return strings.HasPrefix(r.URL.Path, "/api/")
+}
+
+// sameOrigin is true for requests from our own pages, like infinite scrolling.
+func sameOrigin(r *http.Request) bool {
+	if r.Header.Get("Sec-Fetch-Site") == "same-origin" {
+		return true
+	}
+
+	for _, h := range []string{"Origin", "Referer"} {
+		if u, err := url.Parse(r.Header.Get(h)); err == nil && u.Host != "" {
+			return u.Host == r.Host
+		}
+	}
+
+	return false
+}
+
+// adminAPIKeysHandler lists the API keys, issues a new key for a POST and revokes one for a DELETE.
+// The secret of a new key is only shown in the response to the POST.
+// e.g. curl -H "Authorization: Bearer $TOKEN" -d "name=acme&quota=10000&sites=acme.com,acme.org" /admin/apikeys
+func (f *Frontend) adminAPIKeysHandler(w http.ResponseWriter, r *http.Request) *response {
+	resp := &response{
+		status:   http.StatusOK,
+		template: "json",
+	}
+
+	if !f.isAdmin(r) {
+		resp.status = http.StatusForbidden
+		resp.err = fmt.Errorf("invalid admin token from %v", r.RemoteAddr)
+		return resp
+	}
+
+	if f.APIKeys.Store == nil {
+		resp.status = http.StatusInternalServerError
+		resp.err = fmt.Errorf("no api key store")
+		return resp
+	}
+
+	switch r.Method {
+	case http.MethodPost:
+		name := strings.TrimSpace(r.FormValue("name"))
+		if name == "" {
+			resp.status, resp.err = http.StatusBadRequest, fmt.Errorf("missing name")
+			return resp
+		}
+
+		var quota, burst int
+		var rate float64
+		var err error
+
+		for _, p := range []struct {
+			param string
+			parse func(s string) error
+		}{
+			{"quota", func(s string) error { quota, err = strconv.Atoi(s); return err }},
+			{"rate", func(s string) error { rate, err = strconv.ParseFloat(s, 64); return err }},
+			{"burst", func(s string) error { burst, err = strconv.Atoi(s); return err }},
+		} {
+			if s := strings.TrimSpace(r.FormValue(p.param)); s != "" {
+				if err := p.parse(s); err != nil {
+					resp.status, resp.err = http.StatusBadRequest, err
+					return resp
+				}
+			}
+		}
+
+		secret, k, err := apikey.New(name, quota, rate, burst)
+		if err != nil {
+			resp.status, resp.err = http.StatusInternalServerError, err
+			return resp
+		}
+
+		// the sites the key can search with our site search widget
+		for _, s := range strings.Split(r.FormValue("sites"), ",") {
+			if strings.TrimSpace(s) == "" {
+				continue
+			}
+
+			host := site(s)
+			if host == "" {
+				resp.status, resp.err = http.StatusBadRequest, fmt.Errorf("invalid site %q", s)
+				return resp
+			}
+			k.Sites = append(k.Sites, host)
+		}
+
+		if err := f.APIKeys.Insert(k); err != nil {
+			resp.status, resp.err = http.StatusInternalServerError, err
+			return resp
+		}
+
+		resp.data = struct {
+			Secret string `json:"key"`
+			*apikey.Key
+		}{secret, k}
+		return resp
+	case http.MethodDelete:
+		if err := f.APIKeys.Revoke(r.FormValue("id")); err != nil {
+			resp.status, resp.err = http.StatusBadRequest, err
+			return resp
+		}
+	}
+
+	keys, err := f.APIKeys.List()
+	if err != nil {
+		resp.status, resp.err = http.StatusInternalServerError, err
+		return resp
+	}
+
+	resp.data = keys
+	return resp
+}
@@ -0,0 +1,168 @@
+package frontend
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/jivesearch/jivesearch/frontend/apikey"
+)
+
+func TestAPIAccess(t *testing.T) {
+	now = func() time.Time {
+		return time.Date(2018, 02, 06, 23, 0, 0, 0, time.UTC)
+	}
+
+	store := &apikey.Simple{}
+	if err := store.Setup(); err != nil {
+		t.Fatal(err)
+	}
+
+	secret, k, err := apikey.New("acme", 1, 0, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err := store.Insert(k); err != nil {
+		t.Fatal(err)
+	}
+
+	revoked, rk, err := apikey.New("revoked", 0, 0, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	rk.Revoked = true
+	if err := store.Insert(rk); err != nil {
+		t.Fatal(err)
+	}
+
+	for _, c := range []struct {
+		name       string
+		url        string
+		header     http.Header
+		status     int
+		retryAfter string
+		key        *apikey.Key
+	}{
+		{"html search", "/?q=test", nil, http.StatusOK, "", nil},
+		{"keyless", "/?q=test&o=json", nil, http.StatusUnauthorized, "", nil},
+		{"keyless export", "/?q=test&o=csv", nil, http.StatusUnauthorized, "", nil},
+		{"keyless from our pages", "/?q=test&o=json", http.Header{"Referer": {"http://example.com/?q=test"}}, http.StatusOK, "", nil},
+		{"keyless from another site", "/api/v1/images?q=test", http.Header{"Referer": {"http://other.com/"}}, http.StatusUnauthorized, "", nil},
+		{"fetch metadata", "/api/v1/images?q=test", http.Header{"Sec-Fetch-Site": {"same-origin"}}, http.StatusOK, "", nil},
+		{"keyless graphql", "/api/v1/graphql?query={search(q:\"test\"){title}}", nil, http.StatusUnauthorized, "", nil},
+		{"unknown key", "/api/v1/instant?q=test&api_key=wrong", nil, http.StatusForbidden, "", nil},
+		{"revoked", "/api/v1/instant?q=test&api_key=" + revoked, nil, http.StatusForbidden, "", nil},
+		{"valid key", "/api/v1/instant?q=test", http.Header{"X-Api-Key": {secret}}, http.StatusOK, "", k},
+		{"over quota", "/api/v1/instant?q=test", http.Header{"X-Api-Key": {secret}}, http.StatusTooManyRequests, "3600", nil},
+	} {
+		t.Run(c.name, func(t *testing.T) {
+			f := &Frontend{
+				APIKeys: APIKeys{
+					Store:    store,
+					Required: true,
+				},
+			}
+
+			var got *apikey.Key
+			h := f.apiAccess(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				got, _ = r.Context().Value(apiKeyContext).(*apikey.Key)
+			}))
+
+			r := httptest.NewRequest("GET", c.url, nil)
+			for k, v := range c.header {
+				r.Header[k] = v
+			}
+
+			w := httptest.NewRecorder()
+			h.ServeHTTP(w, r)
+
+			if w.Code != c.status {
+				t.Fatalf("got %d; want %d", w.Code, c.status)
+			}
+
+			if ra := w.Header().Get("Retry-After"); ra != c.retryAfter {
+				t.Fatalf("got Retry-After %q; want %q", ra, c.retryAfter)
+			}
+
+			if !reflect.DeepEqual(got, c.key) {
+				t.Fatalf("got key %+v; want %+v", got, c.key)
+			}
+		})
+	}
+}
+
+func TestAdminAPIKeysHandler(t *testing.T) {
+	store := &apikey.Simple{}
+	if err := store.Setup(); err != nil {
+		t.Fatal(err)
+	}
+
+	f := &Frontend{
+		AdminToken: "secret",
+		APIKeys: APIKeys{
+			Store: store,
+		},
+	}
+
+	for _, c := range []struct {
+		name   string
+		method string
+		token  string
+		form   url.Values
+		status int
+	}{
+		{"wrong token", "POST", "wrong", url.Values{"name": {"acme"}}, http.StatusForbidden},
+		{"missing name", "POST", "secret", url.Values{}, http.StatusBadRequest},
+		{"bad quota", "POST", "secret", url.Values{"name": {"acme"}, "quota": {"lots"}}, http.StatusBadRequest},
+		{"bad site", "POST", "secret", url.Values{"name": {"acme"}, "sites": {"acme.com,not a site"}}, http.StatusBadRequest},
+		{
+			"issue", "POST", "secret",
+			url.Values{"name": {"acme"}, "quota": {"1000"}, "rate": {"2.5"}, "sites": {"Acme.com, https://blog.acme.org/"}},
+			http.StatusOK,
+		},
+		{"unknown", "DELETE", "secret", url.Values{"id": {"nope"}}, http.StatusBadRequest},
+	} {
+		t.Run(c.name, func(t *testing.T) {
+			req := httptest.NewRequest(c.method, "/admin/apikeys?"+c.form.Encode(), strings.NewReader(c.form.Encode()))
+			req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+			req.Header.Set("Authorization", "Bearer "+c.token)
+
+			rsp := f.adminAPIKeysHandler(httptest.NewRecorder(), req)
+			if rsp.status != c.status {
+				t.Fatalf("got status %d; want %d (%v)", rsp.status, c.status, rsp.err)
+			}
+		})
+	}
+
+	keys, err := store.List()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(keys) != 1 || keys[0].Name != "acme" || keys[0].Quota != 1000 || keys[0].Rate != 2.5 ||
+		!reflect.DeepEqual(keys[0].Sites, []string{"acme.com", "blog.acme.org"}) {
+		t.Fatalf("got %+v; want the key we issued", keys)
+	}
+
+	// revoke it and make sure the hash isn't exposed
+	req := httptest.NewRequest("DELETE", "/admin/apikeys?id="+keys[0].ID, nil)
+	req.Header.Set("Authorization", "Bearer secret")
+
+	rsp := f.adminAPIKeysHandler(httptest.NewRecorder(), req)
+
+	j, err := json.Marshal(rsp.data)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if strings.Contains(string(j), keys[0].Hash) || !strings.Contains(string(j), `"revoked":true`) {
+		t.Fatalf("got %s; want the revoked key without its hash", j)
+	}
+}
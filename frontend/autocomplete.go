@@ -0,0 +1,219 @@
+package frontend
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/gorilla/websocket"
+	"github.com/jivesearch/jivesearch/frontend/history"
+	"github.com/jivesearch/jivesearch/log"
+)
+
+// Autocomplete keeps a WebSocket open while the user types so each keystroke
+// doesn't cost a request. Keystrokes within Debounce of each other are answered once.
+type Autocomplete struct {
+	Debounce    time.Duration
+	Idle        time.Duration // we hang up on users who stop typing
+	MaxMessages int           // and after this many keystrokes. The browser opens another connection.
+}
+
+// keystroke is what the user has typed so far. The ID is echoed back so
+// the browser can match our suggestions to what they were typed for.
+type keystroke struct {
+	ID  int64  `json:"id"`
+	Q   string `json:"q"`
+	Key string `json:"key,omitempty"` // opens the user's search history
+}
+
+type suggestions struct {
+	ID          int64       `json:"id"`
+	Q           string      `json:"q"`
+	Suggestions interface{} `json:"suggestions"`
+}
+
+var upgrader = websocket.Upgrader{
+	ReadBufferSize:  1024,
+	WriteBufferSize: 4096,
+}
+
+// autocompleteSocketHandler pushes suggestions for the latest keystroke after the user pauses.
+// Each keystroke takes a token from the user's autocomplete rate limit, the same as a request would.
+func (f *Frontend) autocompleteSocketHandler(w http.ResponseWriter, r *http.Request) {
+	u := upgrader
+	u.CheckOrigin = f.checkOrigin
+
+	conn, err := u.Upgrade(w, r, nil)
+	if err != nil {
+		log.Debug.Println(err) // Upgrade has responded
+		return
+	}
+
+	defer conn.Close()
+
+	conn.SetReadLimit(4096) // the query input is at most 2048 characters
+
+	idle := f.Autocomplete.Idle
+	if idle <= 0 {
+		idle = time.Minute
+	}
+
+	max := f.Autocomplete.MaxMessages
+	if max <= 0 {
+		max = 1000
+	}
+
+	keystrokes := make(chan keystroke, 1)
+	go func() {
+		defer close(keystrokes)
+
+		for i := 0; ; i++ {
+			if i == max {
+				hangUp(conn, websocket.ClosePolicyViolation, "too many messages")
+				return
+			}
+
+			if err := conn.SetReadDeadline(time.Now().Add(idle)); err != nil {
+				return
+			}
+
+			var k keystroke
+			if err := conn.ReadJSON(&k); err != nil {
+				if !websocket.IsCloseError(err, websocket.CloseNormalClosure, websocket.CloseGoingAway) {
+					log.Debug.Println(err)
+				}
+				return
+			}
+
+			if retry, ok := f.take("autocomplete", r); !ok {
+				hangUp(conn, websocket.CloseTryAgainLater, fmt.Sprintf("too many requests, retry in %v", retry.Round(time.Second)))
+				return
+			}
+
+			// only the latest keystroke matters
+			select {
+			case <-keystrokes:
+			default:
+			}
+			keystrokes <- k
+		}
+	}()
+
+	var latest keystroke
+	var pending, opened bool
+	var h *history.History
+	timer := time.NewTimer(time.Hour)
+	timer.Stop()
+
+	for {
+		select {
+		case k, ok := <-keystrokes:
+			if !ok {
+				return
+			}
+
+			latest, pending = k, true
+
+			if !opened && k.Key != "" { // once per connection
+				h, opened = f.userHistory(k.Key), true
+			}
+
+			if !timer.Stop() {
+				select {
+				case <-timer.C:
+				default:
+				}
+			}
+			timer.Reset(f.Autocomplete.Debounce)
+		case <-timer.C:
+			if !pending {
+				continue
+			}
+			pending = false
+
+			q := strings.TrimSpace(latest.Q)
+
+			res, err := f.suggestions(q)
+			if err != nil {
+				log.Info.Println(err)
+				continue
+			}
+
+			if err := conn.WriteJSON(suggestions{latest.ID, latest.Q, withHistory(res, q, h)}); err != nil {
+				log.Debug.Println(err)
+				return
+			}
+		}
+	}
+}
+
+// hangUp tells the browser why we are closing the connection. It falls back to requests.
+func hangUp(conn *websocket.Conn, code int, reason string) {
+	msg := websocket.FormatCloseMessage(code, reason)
+	if err := conn.WriteControl(websocket.CloseMessage, msg, time.Now().Add(time.Second)); err != nil {
+		log.Debug.Println(err)
+	}
+}
+
+// checkOrigin only lets the pages of our hosts and our tenants' open a WebSocket.
+// Browsers always send an Origin so a client without one isn't a page on another site.
+// Without a host of our own, e.g. in development, the Origin has to match the Host header.
+func (f *Frontend) checkOrigin(r *http.Request) bool {
+	origin := r.Header.Get("Origin")
+	if origin == "" {
+		return true
+	}
+
+	u, err := url.Parse(origin)
+	if err != nil || u.Host == "" {
+		return false
+	}
+
+	hosts := f.hosts()
+	if len(hosts) == 0 {
+		return hostname(u.Host) == hostname(r.Host)
+	}
+
+	return hosts[hostname(u.Host)]
+}
+
+// hosts are the hostnames we and our tenants serve, e.g. "jivesearch.com" and our onion address
+func (f *Frontend) hosts() map[string]bool {
+	hosts := map[string]bool{}
+
+	add := func(s string) {
+		if u, err := url.Parse(s); err == nil && u.Host != "" {
+			s = u.Host
+		}
+		if h := hostname(s); h != "" {
+			hosts[h] = true
+		}
+	}
+
+	add(f.Brand.Host)
+	add(f.Onion)
+
+	for host, t := range f.Tenants {
+		add(host)
+		add(t.Host)
+	}
+
+	return hosts
+}
+
+// Timeout is http.TimeoutHandler for everything but our WebSockets, which stay open
+// and need the connection that the TimeoutHandler hides.
+func Timeout(h http.Handler, dt time.Duration, msg string) http.Handler {
+	th := http.TimeoutHandler(h, dt, msg)
+
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if websocket.IsWebSocketUpgrade(r) {
+			h.ServeHTTP(w, r)
+			return
+		}
+
+		th.ServeHTTP(w, r)
+	})
+}
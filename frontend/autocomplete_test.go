@@ -0,0 +1,182 @@
+package frontend
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gorilla/websocket"
+	"github.com/jivesearch/jivesearch/frontend/cache"
+)
+
+func TestAutocompleteSocketHandler(t *testing.T) {
+	f := &Frontend{
+		Suggest: &mockSuggester{},
+	}
+	f.Autocomplete.Debounce = 50 * time.Millisecond
+
+	ts := httptest.NewServer(http.HandlerFunc(f.autocompleteSocketHandler))
+	defer ts.Close()
+
+	conn, _, err := websocket.DefaultDialer.Dial("ws"+strings.TrimPrefix(ts.URL, "http"), nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer conn.Close()
+
+	// typed faster than the debounce so only the last is answered
+	for i, q := range []string{"x", "xy", "r"} {
+		if err := conn.WriteJSON(keystroke{ID: int64(i + 1), Q: q}); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	var got struct {
+		ID          int64
+		Q           string
+		Suggestions struct {
+			Suggestions []string
+		}
+	}
+
+	if err := conn.ReadJSON(&got); err != nil {
+		t.Fatal(err)
+	}
+
+	if got.ID != 3 || got.Q != "r" || len(got.Suggestions.Suggestions) == 0 {
+		t.Fatalf("got %+v; want the suggestions for the last keystroke", got)
+	}
+
+	if err := conn.SetReadDeadline(time.Now().Add(150 * time.Millisecond)); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := conn.ReadJSON(&got); err == nil {
+		t.Fatalf("got %+v; want nothing for the earlier keystrokes", got)
+	}
+}
+
+func TestAutocompleteSocketLimits(t *testing.T) {
+	for _, c := range []struct {
+		name   string
+		limits map[string]Limit
+		max    int
+		code   int
+	}{
+		{"rate limited", map[string]Limit{"autocomplete": {Rate: .01, Burst: 2}}, 0, websocket.CloseTryAgainLater},
+		{"too many messages", nil, 2, websocket.ClosePolicyViolation},
+	} {
+		t.Run(c.name, func(t *testing.T) {
+			f := &Frontend{
+				Suggest:   &mockSuggester{},
+				RateLimit: RateLimit{Limits: c.limits},
+			}
+			f.Cache.Cacher = &cache.Simple{M: map[string]cache.Value{}}
+			f.Autocomplete.Debounce = time.Second // so we hang up before answering
+			f.Autocomplete.MaxMessages = c.max
+
+			ts := httptest.NewServer(http.HandlerFunc(f.autocompleteSocketHandler))
+			defer ts.Close()
+
+			conn, _, err := websocket.DefaultDialer.Dial("ws"+strings.TrimPrefix(ts.URL, "http"), http.Header{"X-Forwarded-For": {"1.2.3.4"}})
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer conn.Close()
+
+			for i, q := range []string{"j", "ji", "jiv"} {
+				if err := conn.WriteJSON(keystroke{ID: int64(i + 1), Q: q}); err != nil {
+					t.Fatal(err)
+				}
+			}
+
+			var got suggestions
+			err = conn.ReadJSON(&got)
+			if !websocket.IsCloseError(err, c.code) {
+				t.Fatalf("got %+v and err %v; want to be closed with %d", got, err, c.code)
+			}
+		})
+	}
+}
+
+func TestCheckOrigin(t *testing.T) {
+	ours := &Frontend{
+		Brand: Brand{Host: "https://jivesearch.com"},
+		Onion: "jivexx.onion",
+		Tenants: Tenants{
+			"search.example.org": {},
+			"find.example.net":   {Brand: Brand{Host: "https://www.example.net"}},
+		},
+	}
+
+	for _, c := range []struct {
+		name   string
+		f      *Frontend
+		origin string
+		want   bool
+	}{
+		{"not a browser", ours, "", true},
+		{"ours", ours, "https://jivesearch.com", true},
+		{"onion", ours, "http://jivexx.onion", true},
+		{"tenant", ours, "https://search.example.org", true},
+		{"tenant's own host", ours, "https://www.example.net", true},
+		{"other site", ours, "https://evil.example.com", false},
+		{"suffix", ours, "https://notjivesearch.com", false},
+		{"null", ours, "null", false},
+		{"unconfigured same host", &Frontend{}, "http://localhost:8000", true},
+		{"unconfigured other site", &Frontend{}, "https://evil.example.com", false},
+	} {
+		t.Run(c.name, func(t *testing.T) {
+			r := httptest.NewRequest("GET", "http://localhost:8000/autocomplete/socket", nil)
+			if c.origin != "" {
+				r.Header.Set("Origin", c.origin)
+			}
+
+			if got := c.f.checkOrigin(r); got != c.want {
+				t.Fatalf("got %v; want %v", got, c.want)
+			}
+		})
+	}
+}
+
+func TestTimeout(t *testing.T) {
+	h := Timeout(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, ok := w.(http.Hijacker)
+		w.Header().Set("X-Hijacker", map[bool]string{true: "yes", false: "no"}[ok])
+	}), time.Second, "too slow")
+
+	for _, c := range []struct {
+		name    string
+		headers map[string]string
+		want    string
+	}{
+		{"request", map[string]string{}, "no"},
+		{"websocket", map[string]string{"Connection": "Upgrade", "Upgrade": "websocket"}, "yes"},
+	} {
+		t.Run(c.name, func(t *testing.T) {
+			ts := httptest.NewServer(h)
+			defer ts.Close()
+
+			req, err := http.NewRequest("GET", ts.URL, nil)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			for k, v := range c.headers {
+				req.Header.Set(k, v)
+			}
+
+			resp, err := http.DefaultClient.Do(req)
+			if err != nil {
+				t.Fatal(err)
+			}
+			resp.Body.Close()
+
+			if got := resp.Header.Get("X-Hijacker"); got != c.want {
+				t.Fatalf("got hijacker %q; want %q", got, c.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,310 @@
+package frontend
+
+import (
+	"fmt"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/jivesearch/jivesearch/bangs"
+	"golang.org/x/text/language"
+)
+
+// BangEdits are the !bangs an admin has added, edited or disabled at /admin/bangs
+type BangEdits struct {
+	bangs.Store
+	Config *bangs.Bangs // as loaded from our config file
+}
+
+// BangStats are how often each !bang is used and whether its urls still work
+type BangStats struct {
+	*bangs.Usage
+	*bangs.Checker // optional
+}
+
+// BangStat is a !bang's redirects since we started and the latest checks of its urls
+type BangStat struct {
+	Name       string        `json:"name"`
+	Triggers   []string      `json:"triggers"`
+	Redirects  int64         `json:"redirects"`
+	Dead       bool          `json:"dead"`
+	Redirected bool          `json:"redirected"`
+	Checks     []bangs.Check `json:"checks,omitempty"`
+}
+
+// BangStatsReport is the statistics of each !bang, the most used first
+type BangStatsReport struct {
+	Since time.Time  `json:"since"`
+	Bangs []BangStat `json:"bangs"`
+}
+
+// EditBangs applies the admin's edits to the !bangs of our config file
+func (f *Frontend) EditBangs() error {
+	if f.BangEdits.Config == nil {
+		return nil
+	}
+
+	b, err := f.BangEdits.Config.Edit(f.BangEdits.Store)
+	if err != nil {
+		return err
+	}
+
+	f.Bangs = b
+	return nil
+}
+
+// adminBangsHandler lists our !bangs, adds or edits one for a POST and reverts one to our config file for a DELETE.
+// A POST only changes what it has: triggers are comma separated and each region is "region:url" (an empty url removes it).
+// Our frontend reloads so the change is live right away, though new triggers are only suggested after a restart.
+// e.g. curl -H "Authorization: Bearer $TOKEN" -d "name=Amazon&region=de:https://www.amazon.de/s?k={{{term}}}" /admin/bangs
+// or curl -H "Authorization: Bearer $TOKEN" -d "name=Amazon&disabled=true" /admin/bangs
+func (f *Frontend) adminBangsHandler(w http.ResponseWriter, r *http.Request) *response {
+	resp := &response{
+		status:   http.StatusOK,
+		template: "json",
+	}
+
+	if !f.isAdmin(r) {
+		resp.status = http.StatusForbidden
+		resp.err = fmt.Errorf("invalid admin token from %v", r.RemoteAddr)
+		return resp
+	}
+
+	if f.BangEdits.Store == nil || f.BangEdits.Config == nil {
+		resp.status = http.StatusInternalServerError
+		resp.err = fmt.Errorf("no !bangs store")
+		return resp
+	}
+
+	edits, err := f.BangEdits.List()
+	if err != nil {
+		resp.status, resp.err = http.StatusInternalServerError, err
+		return resp
+	}
+
+	all := bangs.Merge(f.BangEdits.Config.Bangs, edits)
+
+	switch r.Method {
+	case http.MethodPost, http.MethodDelete:
+		name := strings.TrimSpace(r.FormValue("name"))
+		if name == "" {
+			resp.status, resp.err = http.StatusBadRequest, fmt.Errorf("missing name")
+			return resp
+		}
+
+		if r.Method == http.MethodDelete {
+			err = f.BangEdits.Delete(name)
+		} else {
+			var b bangs.Bang
+			if b, err = editBang(r, name, all); err != nil {
+				resp.status, resp.err = http.StatusBadRequest, err
+				return resp
+			}
+
+			err = f.BangEdits.Save(b)
+		}
+
+		if err != nil {
+			resp.status, resp.err = http.StatusInternalServerError, err
+			return resp
+		}
+
+		if f.Reload != nil {
+			if err := f.Reload(); err != nil {
+				resp.status, resp.err = http.StatusInternalServerError, err
+				return resp
+			}
+		}
+
+		if edits, err = f.BangEdits.List(); err != nil {
+			resp.status, resp.err = http.StatusInternalServerError, err
+			return resp
+		}
+
+		all = bangs.Merge(f.BangEdits.Config.Bangs, edits)
+	}
+
+	resp.data = all
+	return resp
+}
+
+// editBang applies the posted changes to the !bang of that name, or a new one
+func editBang(r *http.Request, name string, all []bangs.Bang) (bangs.Bang, error) {
+	b := bangs.Bang{Name: name}
+	for _, bng := range all {
+		if strings.EqualFold(bng.Name, name) {
+			b = bng
+			break
+		}
+	}
+
+	// copy what we change so our config's !bangs are untouched
+	regions := map[string]string{}
+	for k, v := range b.Regions {
+		regions[k] = v
+	}
+	b.Regions = regions
+
+	if _, ok := r.PostForm["favicon"]; ok {
+		b.FavIcon = strings.TrimSpace(r.PostFormValue("favicon"))
+	}
+
+	if _, ok := r.PostForm["triggers"]; ok {
+		b.Triggers = nil
+		for _, t := range strings.Split(r.PostFormValue("triggers"), ",") {
+			if t = strings.ToLower(strings.Trim(strings.TrimSpace(t), "!")); t != "" {
+				b.Triggers = append(b.Triggers, t)
+			}
+		}
+	}
+
+	for _, reg := range r.PostForm["region"] {
+		kv := strings.SplitN(reg, ":", 2)
+		if len(kv) != 2 {
+			return b, fmt.Errorf("invalid region %q", reg)
+		}
+
+		k, u := strings.ToLower(strings.TrimSpace(kv[0])), strings.TrimSpace(kv[1])
+		if u == "" {
+			delete(b.Regions, k)
+			continue
+		}
+		b.Regions[k] = u
+	}
+
+	if _, ok := r.PostForm["disabled"]; ok {
+		disabled, err := strconv.ParseBool(r.PostFormValue("disabled"))
+		if err != nil {
+			return b, err
+		}
+		b.Disabled = disabled
+	}
+
+	return b, bangs.Validate(b, all)
+}
+
+// adminBangsUIHandler is our page to list, add, edit, disable and test !bangs at /admin/bangs/ui.
+// The page has nothing of ours in it. It asks for the admin token and sends it to /admin/bangs as a
+// bearer token, so the api above is what checks it. We don't show the page if there is no admin token.
+func (f *Frontend) adminBangsUIHandler(w http.ResponseWriter, r *http.Request) *response {
+	if f.AdminToken == "" {
+		return &response{
+			status: http.StatusNotFound,
+			err:    fmt.Errorf("no admin token for %v", r.URL.Path),
+		}
+	}
+
+	return &response{
+		status:   http.StatusOK,
+		template: "admin_bangs",
+		data:     f.Brand,
+	}
+}
+
+// BangTest is the !bang a query triggers and where it sends the user
+type BangTest struct {
+	Bang *bangs.Bang `json:"bang"`
+	URL  string      `json:"url"`
+}
+
+// adminBangsTestHandler shows where a query would be sent, as it would be for a user in the region and language.
+// e.g. curl -H "Authorization: Bearer $TOKEN" "/admin/bangs/test?q=!a+shoes&region=fr&l=fr"
+func (f *Frontend) adminBangsTestHandler(w http.ResponseWriter, r *http.Request) *response {
+	resp := &response{
+		status:   http.StatusOK,
+		template: "json",
+	}
+
+	if !f.isAdmin(r) {
+		resp.status = http.StatusForbidden
+		resp.err = fmt.Errorf("invalid admin token from %v", r.RemoteAddr)
+		return resp
+	}
+
+	var region language.Region
+	if reg := strings.TrimSpace(r.FormValue("region")); reg != "" {
+		var err error
+		if region, err = language.ParseRegion(reg); err != nil {
+			resp.status, resp.err = http.StatusBadRequest, err
+			return resp
+		}
+	}
+
+	lang := language.English
+	if l := strings.TrimSpace(r.FormValue("l")); l != "" {
+		var err error
+		if lang, err = language.Parse(l); err != nil {
+			resp.status, resp.err = http.StatusBadRequest, err
+			return resp
+		}
+	}
+
+	t := &BangTest{}
+	if bng, u, ok := f.Bangs.Detect(r.FormValue("q"), region, lang); ok {
+		t.Bang, t.URL = &bng, u
+	}
+
+	resp.data = t
+	return resp
+}
+
+// adminBangsStatsHandler reports the redirects of each !bang and flags those that are dead or that
+// redirect to another site. flagged=true leaves out the !bangs that are fine.
+// e.g. curl -H "Authorization: Bearer $TOKEN" "/admin/bangs/stats?flagged=true"
+func (f *Frontend) adminBangsStatsHandler(w http.ResponseWriter, r *http.Request) *response {
+	resp := &response{
+		status:   http.StatusOK,
+		template: "json",
+	}
+
+	if !f.isAdmin(r) {
+		resp.status = http.StatusForbidden
+		resp.err = fmt.Errorf("invalid admin token from %v", r.RemoteAddr)
+		return resp
+	}
+
+	if f.BangStats.Usage == nil {
+		resp.status = http.StatusInternalServerError
+		resp.err = fmt.Errorf("!bang usage isn't counted")
+		return resp
+	}
+
+	flagged := r.FormValue("flagged") == "true"
+
+	report := &BangStatsReport{
+		Since: f.BangStats.Since,
+		Bangs: []BangStat{},
+	}
+
+	for _, b := range f.Bangs.Bangs {
+		st := BangStat{
+			Name:      b.Name,
+			Triggers:  b.Triggers,
+			Redirects: f.BangStats.Redirects(b.Name),
+		}
+
+		if f.BangStats.Checker != nil {
+			st.Checks = f.BangStats.Checks(b.Name)
+		}
+
+		for _, c := range st.Checks {
+			st.Dead = st.Dead || c.Dead
+			st.Redirected = st.Redirected || c.Redirected
+		}
+
+		if flagged && !st.Dead && !st.Redirected {
+			continue
+		}
+
+		report.Bangs = append(report.Bangs, st)
+	}
+
+	sort.SliceStable(report.Bangs, func(i, j int) bool {
+		return report.Bangs[i].Redirects > report.Bangs[j].Redirects
+	})
+
+	resp.data = report
+	return resp
+}
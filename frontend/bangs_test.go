@@ -0,0 +1,181 @@
+package frontend
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/jivesearch/jivesearch/bangs"
+)
+
+func TestAdminBangsHandler(t *testing.T) {
+	store := &bangs.SimpleStore{}
+	if err := store.Setup(); err != nil {
+		t.Fatal(err)
+	}
+
+	f := &Frontend{
+		AdminToken: "secret",
+	}
+	f.BangEdits.Store = store
+	f.BangEdits.Config = &bangs.Bangs{
+		Bangs: []bangs.Bang{
+			{Name: "Amazon", Triggers: []string{"a"}, Regions: map[string]string{"default": "https://www.amazon.com/s?k={{{term}}}"}},
+			{Name: "Google", Triggers: []string{"g"}, Regions: map[string]string{"default": "https://www.google.com/search?q={{{term}}}"}},
+		},
+	}
+
+	reloads := 0
+	f.Reload = func() error {
+		reloads++
+		return f.EditBangs()
+	}
+
+	for _, c := range []struct {
+		name    string
+		token   string
+		method  string
+		form    url.Values
+		status  int
+		q       string
+		want    string
+		reloads int
+	}{
+		{"wrong token", "wrong", "GET", nil, http.StatusForbidden, "", "", 0},
+		{
+			"region", "secret", "POST", url.Values{"name": {"amazon"}, "region": {"de:https://www.amazon.de/s?k={{{term}}}"}},
+			http.StatusOK, "!a shoes", "https://www.amazon.de/s?k=shoes", 1,
+		},
+		{"disable", "secret", "POST", url.Values{"name": {"Google"}, "disabled": {"true"}}, http.StatusOK, "!g jive", "", 2},
+		{
+			"add", "secret", "POST", url.Values{"name": {"DuckDuckGo"}, "triggers": {"!ddg, duck"}, "region": {"default:https://duckduckgo.com/?q={{{term}}}"}},
+			http.StatusOK, "!duck jive", "https://duckduckgo.com/?q=jive", 3,
+		},
+		{"taken trigger", "secret", "POST", url.Values{"name": {"Other"}, "triggers": {"a"}}, http.StatusBadRequest, "", "", 3},
+		{"missing name", "secret", "POST", url.Values{"disabled": {"true"}}, http.StatusBadRequest, "", "", 3},
+		{"revert", "secret", "DELETE", url.Values{"name": {"Amazon"}}, http.StatusOK, "!a shoes", "https://www.amazon.com/s?k=shoes", 4},
+	} {
+		t.Run(c.name, func(t *testing.T) {
+			req := httptest.NewRequest(c.method, "/admin/bangs", strings.NewReader(c.form.Encode()))
+			req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+			if c.method == "DELETE" { // the body of a DELETE isn't parsed
+				req = httptest.NewRequest(c.method, "/admin/bangs?"+c.form.Encode(), nil)
+			}
+			req.Header.Set("Authorization", "Bearer "+c.token)
+
+			rsp := f.adminBangsHandler(httptest.NewRecorder(), req)
+			if rsp.status != c.status {
+				t.Fatalf("got status %d; want %d (%v)", rsp.status, c.status, rsp.err)
+			}
+
+			if reloads != c.reloads {
+				t.Fatalf("got %d reloads; want %d", reloads, c.reloads)
+			}
+
+			if c.q == "" {
+				return
+			}
+
+			req = httptest.NewRequest("GET", "/admin/bangs/test?region=de&q="+url.QueryEscape(c.q), nil)
+			req.Header.Set("Authorization", "Bearer "+c.token)
+
+			rsp = f.adminBangsTestHandler(httptest.NewRecorder(), req)
+			if rsp.status != http.StatusOK {
+				t.Fatalf("got status %d; want %d (%v)", rsp.status, http.StatusOK, rsp.err)
+			}
+
+			if got := rsp.data.(*BangTest).URL; got != c.want {
+				t.Fatalf("got %q; want %q", got, c.want)
+			}
+		})
+	}
+
+	if got := len(f.BangEdits.Config.Bangs[0].Regions); got != 1 {
+		t.Fatalf("the !bangs of our config file were changed: got %d regions", got)
+	}
+}
+
+func TestAdminBangsUIHandler(t *testing.T) {
+	ParseTemplates()
+
+	for _, c := range []struct {
+		name   string
+		token  string
+		status int
+	}{
+		{"closed", "", http.StatusNotFound},
+		{"open", "secret", http.StatusOK},
+	} {
+		t.Run(c.name, func(t *testing.T) {
+			f := &Frontend{AdminToken: c.token, Brand: Brand{Name: "Jive Search"}}
+
+			w := httptest.NewRecorder()
+			appHandler(f.adminBangsUIHandler).ServeHTTP(w, httptest.NewRequest("GET", "/admin/bangs/ui", nil))
+
+			if w.Code != c.status {
+				t.Fatalf("got status %d; want %d", w.Code, c.status)
+			}
+
+			if c.status != http.StatusOK {
+				return
+			}
+
+			body := w.Body.String()
+			for _, want := range []string{"<title>!bangs - Jive Search</title>", `src="/static/admin_bangs.js"`, "{{{term}}}"} {
+				if !strings.Contains(body, want) {
+					t.Fatalf("got %q; want %q in it", body, want)
+				}
+			}
+
+			if strings.Contains(body, c.token) {
+				t.Fatal("our admin token is on the page")
+			}
+		})
+	}
+}
+
+func TestAdminBangsStatsHandler(t *testing.T) {
+	f := &Frontend{
+		AdminToken: "secret",
+		Bangs: &bangs.Bangs{
+			Bangs: []bangs.Bang{
+				{Name: "Amazon", Triggers: []string{"a"}},
+				{Name: "Google", Triggers: []string{"g"}},
+			},
+		},
+	}
+	f.BangStats.Usage = bangs.NewUsage(time.Now())
+	f.BangStats.Count("Google")
+
+	for _, c := range []struct {
+		name string
+		url  string
+		want []string
+	}{
+		{"all", "/admin/bangs/stats", []string{"Google", "Amazon"}},
+		{"flagged", "/admin/bangs/stats?flagged=true", []string{}},
+	} {
+		t.Run(c.name, func(t *testing.T) {
+			req := httptest.NewRequest("GET", c.url, nil)
+			req.Header.Set("Authorization", "Bearer secret")
+
+			rsp := f.adminBangsStatsHandler(httptest.NewRecorder(), req)
+			if rsp.status != http.StatusOK {
+				t.Fatalf("got status %d; want %d (%v)", rsp.status, http.StatusOK, rsp.err)
+			}
+
+			got := []string{}
+			for _, st := range rsp.data.(*BangStatsReport).Bangs {
+				got = append(got, st.Name)
+			}
+
+			if !reflect.DeepEqual(got, c.want) {
+				t.Fatalf("got %v; want %v", got, c.want)
+			}
+		})
+	}
+}
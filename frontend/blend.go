@@ -0,0 +1,138 @@
+package frontend
+
+import (
+	"context"
+	"encoding/json"
+	"net/url"
+	"sync"
+
+	"github.com/jivesearch/jivesearch/log"
+	"github.com/jivesearch/jivesearch/search/blend"
+	img "github.com/jivesearch/jivesearch/search/image"
+	"github.com/jivesearch/jivesearch/search/news"
+	"github.com/jivesearch/jivesearch/search/video"
+	"golang.org/x/text/language"
+)
+
+// Blend holds the other verticals mixed into the web results
+type Blend struct {
+	Images *img.Results   `json:"images,omitempty"`
+	Videos *video.Results `json:"videos,omitempty"`
+	News   *news.Results  `json:"news,omitempty"`
+}
+
+// how many of each vertical to show inline
+const (
+	blendImages = 8
+	blendVideos = 4
+	blendNews   = 3
+)
+
+// blend fetches the verticals the query's intent warrants. It is nil if there are none.
+func (f *Frontend) blend(ctx context.Context, d data, lang language.Tag, region language.Region) *Blend {
+	verticals := f.Blender.Verticals(d.Context.Intent)
+	if len(verticals) == 0 {
+		return nil
+	}
+
+	b := &Blend{}
+	var wg sync.WaitGroup
+
+	for _, v := range verticals {
+		u := &url.URL{Path: "/", RawQuery: url.Values{"q": {d.Context.Q}, "safe": {safe(d.Context.Safe)}}.Encode()}
+		key := cacheKey("blend:"+string(v), lang, region, u)
+
+		switch {
+		case v == blend.Images && f.Images.Fetcher != nil:
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				ir := &img.Results{}
+				if !f.blendCached(ctx, key, ir) {
+					var err error
+					if ir, err = f.Images.Fetch(d.Context.Q, d.Context.Safe, img.Filter{}, blendImages, 0); err != nil {
+						log.Infow(ctx, "fetch failed", log.Fields{"fetcher": "images", "error": err})
+						return
+					}
+					f.blendCache(ctx, key, ir)
+				}
+				b.Images = ir
+			}()
+		case v == blend.Videos && f.Videos != nil:
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				vr := &video.Results{}
+				if !f.blendCached(ctx, key, vr) {
+					var err error
+					if vr, err = f.Videos.Fetch(d.Context.Q, d.Context.Safe, lang, blendVideos); err != nil {
+						log.Infow(ctx, "fetch failed", log.Fields{"fetcher": "videos", "error": err})
+						return
+					}
+					f.blendCache(ctx, key, vr)
+				}
+				b.Videos = vr
+			}()
+		case v == blend.News && f.News != nil:
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				nr := &news.Results{}
+				if !f.blendCached(ctx, key, nr) {
+					var err error
+					if nr, err = f.News.Fetch(d.Context.Q, lang, blendNews); err != nil {
+						log.Infow(ctx, "fetch failed", log.Fields{"fetcher": "news", "error": err})
+						return
+					}
+					f.blendCache(ctx, key, nr)
+				}
+				b.News = nr
+			}()
+		}
+	}
+
+	wg.Wait()
+
+	if b.Images != nil && len(b.Images.Images) == 0 {
+		b.Images = nil
+	}
+	if b.Videos != nil && len(b.Videos.Videos) == 0 {
+		b.Videos = nil
+	}
+	if b.News != nil && len(b.News.Articles) == 0 {
+		b.News = nil
+	}
+
+	if b.Images == nil && b.Videos == nil && b.News == nil {
+		return nil
+	}
+
+	return b
+}
+
+// blendCached unmarshals the cached results into res, if there are any
+func (f *Frontend) blendCached(ctx context.Context, key string, res interface{}) bool {
+	v := f.cacheGet(ctx, "blend", key)
+
+	if v == nil {
+		return false
+	}
+
+	if err := json.Unmarshal(v.([]byte), res); err != nil {
+		log.Info.Println(err)
+		return false
+	}
+
+	return true
+}
+
+func (f *Frontend) blendCache(ctx context.Context, key string, res interface{}) {
+	f.cachePut(ctx, key, res, f.Cache.Search)
+}
+
+func safe(s bool) string {
+	if s {
+		return "t"
+	}
+	return "f"
+}
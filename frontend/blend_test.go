@@ -0,0 +1,90 @@
+package frontend
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	"github.com/jivesearch/jivesearch/search/blend"
+	img "github.com/jivesearch/jivesearch/search/image"
+	"github.com/jivesearch/jivesearch/search/news"
+	"github.com/jivesearch/jivesearch/search/video"
+	"golang.org/x/text/language"
+)
+
+func TestBlend(t *testing.T) {
+	f := &Frontend{
+		Blender: blend.Blender{
+			Weights:   map[blend.Vertical]float64{blend.Images: 1, blend.Videos: 1, blend.News: 1},
+			Threshold: .5,
+		},
+		News:   &mockNews{},
+		Videos: &mockVideos{},
+	}
+	f.Images.Fetcher = &mockBlendImages{}
+	f.Cache.Cacher = &mockCacher{}
+
+	for _, c := range []struct {
+		q    string
+		want *Blend
+	}{
+		{"jimi hendrix", nil},
+		{"jimi hendrix pictures", &Blend{Images: mockBlendImageResults}},
+		{"jimi hendrix videos", &Blend{Videos: mockVideoResults}},
+		{"jimi hendrix news videos", &Blend{Videos: mockVideoResults, News: mockNewsResults}},
+		{"obscure news", nil}, // no articles
+	} {
+		t.Run(c.q, func(t *testing.T) {
+			d := data{
+				Context: &Context{Q: c.q, Safe: true, Intent: f.Intent.Classify(c.q)},
+			}
+
+			got := f.blend(context.Background(), d, language.English, language.MustParseRegion("US"))
+			if !reflect.DeepEqual(got, c.want) {
+				t.Fatalf("got %+v; want %+v", got, c.want)
+			}
+		})
+	}
+}
+
+type mockBlendImages struct {
+	mockImages
+}
+
+func (m *mockBlendImages) Fetch(q string, safe bool, f img.Filter, number int, offset int) (*img.Results, error) {
+	return mockBlendImageResults, nil
+}
+
+var mockBlendImageResults = &img.Results{
+	Count:  1,
+	Images: []*img.Image{{ID: "https://example.com/hendrix.jpg"}},
+}
+
+type mockVideos struct{}
+
+func (m *mockVideos) Fetch(q string, safe bool, lang language.Tag, number int) (*video.Results, error) {
+	return mockVideoResults, nil
+}
+
+type mockNews struct{}
+
+func (m *mockNews) Fetch(q string, lang language.Tag, number int) (*news.Results, error) {
+	if q == "obscure news" {
+		return &news.Results{Provider: news.NewsAPIProvider}, nil
+	}
+	return mockNewsResults, nil
+}
+
+var mockVideoResults = &video.Results{
+	Provider: video.YouTubeProvider,
+	Videos: []*video.Video{
+		{ID: "cJunCsrhJjg", URL: "https://www.youtube.com/watch?v=cJunCsrhJjg", Title: "Purple Haze"},
+	},
+}
+
+var mockNewsResults = &news.Results{
+	Provider: news.NewsAPIProvider,
+	Articles: []*news.Article{
+		{URL: "https://news.example.com/hendrix", Title: "Hendrix guitar sold", Source: "Example News"},
+	},
+}
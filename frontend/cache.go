@@ -0,0 +1,156 @@
+package frontend
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/jivesearch/jivesearch/frontend/cache"
+	"github.com/jivesearch/jivesearch/log"
+	"golang.org/x/text/language"
+)
+
+// cacheKey is where an item is cached for a request, e.g. ::search::en-US::US::/?q=reverse+%22this%22.
+// The language and region might be different than what is passed as the l & r params.
+func cacheKey(item string, lang language.Tag, region language.Region, u *url.URL) string {
+	return fmt.Sprintf("::%v::%v::%v::%v", item, lang.String(), region.String(), canonical(item, u))
+}
+
+// irrelevant params don't change what we fetch, only how it is shown or where the user came from.
+// The domain preferences are applied after we get the results from our cache.
+var irrelevant = map[string]bool{
+	"api_key":  true, // a secret, which we never want in a cache key or our logs
+	"ban":      true,
+	"callback": true,
+	"lucky":    true,
+	"o":        true,
+	"pin":      true,
+	"post":     true,
+	"ref":      true,
+	"sink":     true,
+	"theme":    true,
+}
+
+// caseless items are the same for any case of the query. Instant answers are not, e.g. "reverse Hello".
+var caseless = map[string]bool{
+	"images":     true,
+	"images_api": true,
+	"local":      true,
+	"related":    true,
+	"scholar":    true,
+	"search":     true,
+	"shopping":   true,
+}
+
+// canonical is the url with sorted params, without the irrelevant or empty ones and
+// with the query trimmed so that e.g. "?q=Foo&n=25" and "?n=25&q=foo+" share a key
+func canonical(item string, u *url.URL) string {
+	fold := caseless[item] || strings.HasPrefix(item, "search:") || strings.HasPrefix(item, "blend:")
+
+	params := url.Values{}
+	for k, vs := range u.Query() {
+		if irrelevant[k] || strings.HasPrefix(k, "utm_") {
+			continue
+		}
+
+		for _, v := range vs {
+			v = strings.TrimSpace(v)
+			if k == "q" && fold {
+				v = strings.ToLower(strings.Join(strings.Fields(v), " "))
+			}
+
+			if v == "" { // "&l=" is the same as no l
+				continue
+			}
+
+			params.Add(k, v)
+		}
+	}
+
+	p := u.Path
+	if p == "" {
+		p = "/"
+	}
+
+	if len(params) == 0 {
+		return p
+	}
+
+	return p + "?" + params.Encode()
+}
+
+// formURL is the request's url with its form, so a POSTed search isn't cached under the bare path
+func formURL(r *http.Request) *url.URL {
+	if err := r.ParseForm(); err != nil {
+		return r.URL
+	}
+
+	u := *r.URL
+	u.RawQuery = r.Form.Encode()
+	return &u
+}
+
+// cacheGet gets an item from our cache and counts if it was there.
+// It logs with the request id in ctx, as does cachePut.
+func (f *Frontend) cacheGet(ctx context.Context, item, key string) interface{} {
+	strt := time.Now()
+	v, err := f.Cache.Get(key)
+	if err != nil {
+		log.Infow(ctx, "cache get failed", log.Fields{"key": key, "error": err})
+	}
+
+	log.Debugw(ctx, "cache get", log.Fields{"key": key, "hit": v != nil, "latency": time.Since(strt)})
+
+	if f.Cache.Stats != nil {
+		f.Cache.Stats.Count(item, v != nil)
+	}
+
+	return v
+}
+
+// cachePut caches a value for ttl
+func (f *Frontend) cachePut(ctx context.Context, key string, v interface{}, ttl time.Duration) {
+	strt := time.Now()
+	if err := f.Cache.Put(key, v, ttl); err != nil {
+		log.Infow(ctx, "cache put failed", log.Fields{"key": key, "error": err})
+		return
+	}
+
+	log.Debugw(ctx, "cache put", log.Fields{"key": key, "latency": time.Since(strt)})
+}
+
+// CacheReport is the hit rate of each item in our cache since we started
+type CacheReport struct {
+	Since time.Time              `json:"since"`
+	Items map[string]cache.Count `json:"items"`
+}
+
+// adminCacheHandler reports how often each item is found in our cache.
+// e.g. curl -H "Authorization: Bearer $TOKEN" /admin/cache
+func (f *Frontend) adminCacheHandler(w http.ResponseWriter, r *http.Request) *response {
+	resp := &response{
+		status:   http.StatusOK,
+		template: "json",
+	}
+
+	if !f.isAdmin(r) {
+		resp.status = http.StatusForbidden
+		resp.err = fmt.Errorf("invalid admin token from %v", r.RemoteAddr)
+		return resp
+	}
+
+	if f.Cache.Stats == nil {
+		resp.status = http.StatusInternalServerError
+		resp.err = fmt.Errorf("our cache isn't counted")
+		return resp
+	}
+
+	resp.data = &CacheReport{
+		Since: f.Cache.Stats.Since,
+		Items: f.Cache.Stats.Counts(),
+	}
+	return resp
+}
@@ -0,0 +1,31 @@
+package cache
+
+import (
+	"math"
+	"time"
+)
+
+// Limiter is a cache that can rate limit with token buckets.
+// Each bucket holds up to burst tokens and is refilled at rate tokens per second.
+type Limiter interface {
+	// Take removes a token from the key's bucket. If the bucket
+	// is empty it returns false and how long until a token is available.
+	Take(key string, rate float64, burst int) (bool, time.Duration, error)
+}
+
+type bucket struct {
+	tokens float64
+	last   time.Time
+}
+
+func (b *bucket) take(t time.Time, rate float64, burst int) (bool, time.Duration) {
+	b.tokens = math.Min(float64(burst), b.tokens+t.Sub(b.last).Seconds()*rate)
+	b.last = t
+
+	if b.tokens >= 1 {
+		b.tokens--
+		return true, 0
+	}
+
+	return false, time.Duration((1 - b.tokens) / rate * float64(time.Second))
+}
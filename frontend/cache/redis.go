@@ -2,6 +2,7 @@ package cache
 
 import (
 	"encoding/json"
+	"fmt"
 	"time"
 
 	"github.com/garyburd/redigo/redis"
@@ -11,7 +12,7 @@ const (
 	prefix = "jivesearch"
 )
 
-// Redis implements the Cacher interface
+// Redis implements the Cacher and Limiter interfaces
 type Redis struct {
 	RedisPool *redis.Pool
 }
@@ -59,3 +60,53 @@ func (r *Redis) Put(key string, value interface{}, ttl time.Duration) error {
 func seconds(ttl time.Duration) int {
 	return int(ttl / time.Second)
 }
+
+// takeScript is the token bucket from bucket.take() but atomic across instances
+var takeScript = redis.NewScript(1, `
+local rate = tonumber(ARGV[1])
+local burst = tonumber(ARGV[2])
+local now = tonumber(ARGV[3])
+
+local b = redis.call("HMGET", KEYS[1], "tokens", "last")
+local tokens = tonumber(b[1]) or burst
+local last = tonumber(b[2]) or now
+
+tokens = math.min(burst, tokens + (now - last) / 1000 * rate)
+
+local allowed, retry = 0, 0
+if tokens >= 1 then
+	tokens = tokens - 1
+	allowed = 1
+else
+	retry = math.ceil((1 - tokens) / rate * 1000)
+end
+
+redis.call("HMSET", KEYS[1], "tokens", tostring(tokens), "last", now)
+redis.call("PEXPIRE", KEYS[1], math.ceil(burst / rate * 1000))
+return {allowed, retry}
+`)
+
+// Take removes a token from the key's bucket
+func (r *Redis) Take(key string, rate float64, burst int) (bool, time.Duration, error) {
+	c := r.RedisPool.Get()
+	defer c.Close()
+
+	ms := now().UnixNano() / int64(time.Millisecond)
+
+	res, err := redis.Int64s(takeScript.Do(c, r.prefixKey(key), rate, burst, ms))
+	if err != nil {
+		return false, 0, err
+	}
+
+	if len(res) != 2 {
+		return false, 0, fmt.Errorf("unexpected rate limit reply %v", res)
+	}
+
+	return res[0] == 1, time.Duration(res[1]) * time.Millisecond, nil
+}
+
+// Ping checks that we can reach redis
+func (r *Redis) Ping() error {
+	_, err := r.do("PING")
+	return err
+}
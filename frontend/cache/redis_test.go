@@ -85,3 +85,58 @@ func TestPut(t *testing.T) {
 		})
 	}
 }
+
+func TestTake(t *testing.T) {
+	for _, c := range []struct {
+		name    string
+		reply   []interface{}
+		allowed bool
+		retry   time.Duration
+	}{
+		{"allowed", []interface{}{int64(1), int64(0)}, true, 0},
+		{"limited", []interface{}{int64(0), int64(1500)}, false, 1500 * time.Millisecond},
+	} {
+		t.Run(c.name, func(t *testing.T) {
+			now = func() time.Time {
+				return time.Date(2018, 02, 06, 11, 0, 0, 0, time.UTC)
+			}
+
+			r := &Redis{}
+			conn := redigomock.NewConn()
+			conn.Command("EVALSHA", takeScript.Hash(), 1, r.prefixKey("ratelimit::1.2.3.4"), 2.5, 10, int64(1517914800000)).Expect(c.reply)
+
+			r.RedisPool = &redis.Pool{
+				Dial: func() (redis.Conn, error) {
+					return conn, nil
+				},
+			}
+			defer r.RedisPool.Close()
+
+			allowed, retry, err := r.Take("ratelimit::1.2.3.4", 2.5, 10)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if allowed != c.allowed || retry != c.retry {
+				t.Fatalf("got %v, %v; want %v, %v", allowed, retry, c.allowed, c.retry)
+			}
+		})
+	}
+}
+
+func TestPing(t *testing.T) {
+	r := &Redis{}
+	conn := redigomock.NewConn()
+	conn.Command("PING").Expect("PONG")
+
+	r.RedisPool = &redis.Pool{
+		Dial: func() (redis.Conn, error) {
+			return conn, nil
+		},
+	}
+	defer r.RedisPool.Close()
+
+	if err := r.Ping(); err != nil {
+		t.Fatal(err)
+	}
+}
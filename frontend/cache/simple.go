@@ -2,12 +2,15 @@ package cache
 
 import (
 	"encoding/json"
+	"sync"
 	"time"
 )
 
-// Simple implements the Cacher interface
+// Simple implements the Cacher and Limiter interfaces
 type Simple struct {
-	M map[string]Value
+	M       map[string]Value
+	mu      sync.Mutex
+	buckets map[string]*bucket
 }
 
 // Value is a value with an expiration
@@ -44,3 +47,29 @@ func (s *Simple) Put(key string, val interface{}, ttl time.Duration) error {
 	s.M[key] = v
 	return nil
 }
+
+// Take removes a token from the key's bucket
+func (s *Simple) Take(key string, rate float64, burst int) (bool, time.Duration, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.buckets == nil {
+		s.buckets = make(map[string]*bucket)
+	}
+
+	t := now()
+
+	b, ok := s.buckets[key]
+	if !ok {
+		b = &bucket{tokens: float64(burst), last: t}
+		s.buckets[key] = b
+	}
+
+	allowed, retry := b.take(t, rate, burst)
+	return allowed, retry, nil
+}
+
+// Ping always succeeds as we are in memory
+func (s *Simple) Ping() error {
+	return nil
+}
@@ -48,3 +48,42 @@ func TestSimpleCache(t *testing.T) {
 		})
 	}
 }
+
+func TestSimpleTake(t *testing.T) {
+	s := &Simple{
+		M: make(map[string]Value),
+	}
+
+	start := time.Date(2018, 02, 06, 11, 0, 0, 0, time.UTC)
+
+	for _, c := range []struct {
+		name    string
+		elapsed time.Duration
+		allowed bool
+		retry   time.Duration
+	}{
+		{"first", 0, true, 0},
+		{"second", 0, true, 0},
+		{"empty", 0, false, 500 * time.Millisecond},
+		{"still empty", 250 * time.Millisecond, false, 250 * time.Millisecond},
+		{"refilled", 500 * time.Millisecond, true, 0},
+		{"never more than burst", time.Hour, true, 0},
+		{"burst", time.Hour, true, 0},
+		{"empty again", time.Hour, false, 500 * time.Millisecond},
+	} {
+		t.Run(c.name, func(t *testing.T) {
+			now = func() time.Time {
+				return start.Add(c.elapsed)
+			}
+
+			allowed, retry, err := s.Take("key", 2, 2)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if allowed != c.allowed || retry != c.retry {
+				t.Fatalf("got %v, %v; want %v, %v", allowed, retry, c.allowed, c.retry)
+			}
+		})
+	}
+}
@@ -0,0 +1,71 @@
+package cache
+
+import (
+	"sync"
+	"time"
+)
+
+// Stats counts the hits and misses of each kind of item since we started
+type Stats struct {
+	mu     sync.Mutex
+	counts map[string]*Count
+	Since  time.Time
+}
+
+// Count is the hits and misses of one kind of item
+type Count struct {
+	Hits    int64   `json:"hits"`
+	Misses  int64   `json:"misses"`
+	HitRate float64 `json:"hit_rate"`
+}
+
+// NewStats starts counting
+func NewStats(since time.Time) *Stats {
+	return &Stats{
+		counts: make(map[string]*Count),
+		Since:  since,
+	}
+}
+
+// Count counts a lookup of an item
+func (s *Stats) Count(item string, hit bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	c, ok := s.counts[item]
+	if !ok {
+		c = &Count{}
+		s.counts[item] = c
+	}
+
+	switch hit {
+	case true:
+		c.Hits++
+	default:
+		c.Misses++
+	}
+}
+
+// Counts are the hits, misses and hit rate of each item. "total" is all of them together.
+func (s *Stats) Counts() map[string]Count {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	counts := make(map[string]Count, len(s.counts)+1)
+	total := Count{}
+	for item, c := range s.counts {
+		counts[item] = c.rate()
+		total.Hits += c.Hits
+		total.Misses += c.Misses
+	}
+
+	counts["total"] = total.rate()
+	return counts
+}
+
+func (c Count) rate() Count {
+	if n := c.Hits + c.Misses; n > 0 {
+		c.HitRate = float64(c.Hits) / float64(n)
+	}
+	return c
+}
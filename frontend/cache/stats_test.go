@@ -0,0 +1,29 @@
+package cache
+
+import (
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestStats(t *testing.T) {
+	s := NewStats(time.Now())
+
+	if got, want := s.Counts(), map[string]Count{"total": {}}; !reflect.DeepEqual(got, want) {
+		t.Fatalf("got %+v; want %+v", got, want)
+	}
+
+	s.Count("search", true)
+	s.Count("search", false)
+	s.Count("images", false)
+
+	want := map[string]Count{
+		"search": {Hits: 1, Misses: 1, HitRate: 0.5},
+		"images": {Hits: 0, Misses: 1, HitRate: 0},
+		"total":  {Hits: 1, Misses: 2, HitRate: 1.0 / 3},
+	}
+
+	if got := s.Counts(); !reflect.DeepEqual(got, want) {
+		t.Fatalf("got %+v; want %+v", got, want)
+	}
+}
@@ -0,0 +1,162 @@
+package frontend
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/jivesearch/jivesearch/frontend/cache"
+	"github.com/jivesearch/jivesearch/log"
+	"golang.org/x/text/language"
+)
+
+func TestCacheKey(t *testing.T) {
+	for _, c := range []struct {
+		name string
+		item string
+		urls []string
+		want string
+	}{
+		{
+			"sorted", "search",
+			[]string{"/?q=foo&n=25", "/?n=25&q=foo"},
+			"::search::en-US::US::/?n=25&q=foo",
+		},
+		{
+			"whitespace & case", "search",
+			[]string{"/?q=Foo++Bar", "/?q=+foo+bar+", "/?q=FOO%20BAR"},
+			"::search::en-US::US::/?q=foo+bar",
+		},
+		{
+			"irrelevant & empty", "search",
+			[]string{"/?q=foo", "/?q=foo&o=json&theme=dark&ref=opensearch", "/?q=foo&l=&utm_source=newsletter", "/?q=foo&ban=example.com"},
+			"::search::en-US::US::/?q=foo",
+		},
+		{
+			"api key", "search",
+			[]string{"/?q=foo&o=json&api_key=s3cr3t", "/?api_key=other&q=foo"},
+			"::search::en-US::US::/?q=foo",
+		},
+		{
+			"experiment", "search:yandex",
+			[]string{"/?q=Foo", "/?q=foo"},
+			"::search:yandex::en-US::US::/?q=foo",
+		},
+		{
+			"instant keeps case", "instant",
+			[]string{"/?q=reverse+Hello+", "/?o=json&q=reverse+Hello"},
+			"::instant::en-US::US::/?q=reverse+Hello",
+		},
+		{
+			"path", "instant",
+			[]string{"/answer?q=2%2B2"},
+			"::instant::en-US::US::/answer?q=2%2B2",
+		},
+	} {
+		t.Run(c.name, func(t *testing.T) {
+			for _, raw := range c.urls {
+				u, err := url.Parse(raw)
+				if err != nil {
+					t.Fatal(err)
+				}
+
+				if got := cacheKey(c.item, language.MustParse("en-US"), language.MustParseRegion("US"), u); got != c.want {
+					t.Fatalf("%v: got %q; want %q", raw, got, c.want)
+				}
+			}
+		})
+	}
+}
+
+func TestFormURL(t *testing.T) {
+	req := httptest.NewRequest("POST", "/search?l=en", strings.NewReader("q=jive+search"))
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+
+	if got, want := formURL(req).String(), "/search?l=en&q=jive+search"; got != want {
+		t.Fatalf("got %q; want %q", got, want)
+	}
+}
+
+func TestAdminCacheHandler(t *testing.T) {
+	f := &Frontend{AdminToken: "secret"}
+	f.Cache.Cacher = &cache.Simple{M: map[string]cache.Value{}}
+
+	req := httptest.NewRequest("GET", "/admin/cache", nil)
+	req.Header.Set("Authorization", "Bearer secret")
+
+	if rsp := f.adminCacheHandler(httptest.NewRecorder(), req); rsp.status != http.StatusInternalServerError {
+		t.Fatalf("got status %d; want %d", rsp.status, http.StatusInternalServerError)
+	}
+
+	since := time.Date(2018, 02, 06, 11, 30, 0, 0, time.UTC)
+	f.Cache.Stats = cache.NewStats(since)
+
+	key := "::search::en::US::/?q=jive"
+	if err := f.Cache.Put(key, "results", time.Minute); err != nil {
+		t.Fatal(err)
+	}
+
+	for _, k := range []string{key, key, key, "::search::en::US::/?q=other"} {
+		f.cacheGet(context.Background(), "search", k)
+	}
+
+	f.cacheGet(context.Background(), "instant", "::instant::en::US::/?q=jive")
+
+	rsp := f.adminCacheHandler(httptest.NewRecorder(), req)
+	if rsp.status != http.StatusOK {
+		t.Fatalf("got status %d; want %d (%v)", rsp.status, http.StatusOK, rsp.err)
+	}
+
+	want := &CacheReport{
+		Since: since,
+		Items: map[string]cache.Count{
+			"search":  {Hits: 3, Misses: 1, HitRate: 0.75},
+			"instant": {Hits: 0, Misses: 1, HitRate: 0},
+			"total":   {Hits: 3, Misses: 2, HitRate: 0.6},
+		},
+	}
+
+	if !reflect.DeepEqual(rsp.data, want) {
+		t.Fatalf("got %+v; want %+v", rsp.data, want)
+	}
+
+	req.Header.Set("Authorization", "Bearer wrong")
+	if rsp := f.adminCacheHandler(httptest.NewRecorder(), req); rsp.status != http.StatusForbidden {
+		t.Fatalf("got status %d; want %d", rsp.status, http.StatusForbidden)
+	}
+}
+
+func TestCacheLogsRequestID(t *testing.T) {
+	buf := &bytes.Buffer{}
+	log.SetLevel(log.DebugLevel)
+	log.Debug.SetOutput(buf)
+	defer log.SetLevel(log.InfoLevel)
+
+	f := &Frontend{}
+	f.Cache.Cacher = &cache.Simple{M: map[string]cache.Value{}}
+
+	ctx := log.NewContext(context.Background(), "f3a9-22c1")
+	key := "::search::en::US::/?q=jive"
+
+	f.cachePut(ctx, key, "results", time.Minute)
+	if v := f.cacheGet(ctx, "search", key); v == nil {
+		t.Fatal("got nothing from our cache")
+	}
+
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	if len(lines) != 2 {
+		t.Fatalf("got %d log lines; want 2: %q", len(lines), buf.String())
+	}
+
+	for i, msg := range []string{"cache put", "cache get"} {
+		if !strings.Contains(lines[i], msg+" ") || !strings.Contains(lines[i], "request_id=f3a9-22c1") {
+			t.Fatalf("got %q; want %q with our request id", lines[i], msg)
+		}
+	}
+}
@@ -0,0 +1,75 @@
+package frontend
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"github.com/jivesearch/jivesearch/log"
+	"github.com/jivesearch/jivesearch/search/click"
+)
+
+// Clicks is our opt-in click feedback for reranking. Only the hashed query,
+// the result's URL and its position are kept. Browsers that send Do Not Track or GPC aren't counted.
+type Clicks struct {
+	click.Store
+	click.Hasher
+}
+
+func (f *Frontend) tracking(r *http.Request) bool {
+	return f.Clicks.Store != nil && !doNotTrack(r)
+}
+
+// countImpression counts the first page of web results being shown
+func (f *Frontend) countImpression(r *http.Request, d data) {
+	if !f.tracking(r) || d.Context.T != "" || d.Context.Page > 1 || noResults(d) {
+		return
+	}
+
+	if err := f.Clicks.Impression(f.Clicks.Hash(d.Context.Q)); err != nil {
+		log.Info.Println(err)
+	}
+}
+
+// clickHandler counts a click on a result. Our search.js sends it as a beacon
+// with the query (q), the result's url (u) and its position (p) starting at 1.
+func (f *Frontend) clickHandler(w http.ResponseWriter, r *http.Request) *response {
+	resp := &response{
+		status: http.StatusNoContent,
+	}
+
+	if f.Clicks.Store == nil {
+		resp.status, resp.err = http.StatusBadRequest, fmt.Errorf("click tracking is disabled")
+		return resp
+	}
+
+	if !f.tracking(r) {
+		return resp
+	}
+
+	q := strings.TrimSpace(r.FormValue("q"))
+	if q == "" {
+		resp.status, resp.err = http.StatusBadRequest, fmt.Errorf("no query")
+		return resp
+	}
+
+	u, err := url.Parse(strings.TrimSpace(r.FormValue("u")))
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		resp.status, resp.err = http.StatusBadRequest, fmt.Errorf("invalid url %q", r.FormValue("u"))
+		return resp
+	}
+
+	p, err := strconv.Atoi(strings.TrimSpace(r.FormValue("p")))
+	if err != nil || p < 1 || p > click.MaxPosition {
+		resp.status, resp.err = http.StatusBadRequest, fmt.Errorf("invalid position %q", r.FormValue("p"))
+		return resp
+	}
+
+	if err := f.Clicks.Click(f.Clicks.Hash(q), u.String(), p); err != nil {
+		resp.status, resp.err = http.StatusInternalServerError, err
+	}
+
+	return resp
+}
@@ -0,0 +1,105 @@
+package frontend
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/jivesearch/jivesearch/search"
+	"github.com/jivesearch/jivesearch/search/click"
+	"github.com/jivesearch/jivesearch/search/document"
+)
+
+func TestClickHandler(t *testing.T) {
+	store := &click.Simple{}
+	if err := store.Setup(); err != nil {
+		t.Fatal(err)
+	}
+
+	f := &Frontend{
+		Clicks: Clicks{
+			Store:  store,
+			Hasher: click.Hasher{Salt: "my_salt"},
+		},
+	}
+
+	for _, c := range []struct {
+		name   string
+		form   url.Values
+		header string // asks not to be tracked
+		status int
+	}{
+		{"click", url.Values{"q": {"Jive Search"}, "u": {"https://www.example.com"}, "p": {"2"}}, "", http.StatusNoContent},
+		{"do not track", url.Values{"q": {"jive search"}, "u": {"https://www.example.com"}, "p": {"2"}}, "DNT", http.StatusNoContent},
+		{"global privacy control", url.Values{"q": {"jive search"}, "u": {"https://www.example.com"}, "p": {"3"}}, "Sec-GPC", http.StatusNoContent},
+		{"no query", url.Values{"u": {"https://www.example.com"}, "p": {"2"}}, "", http.StatusBadRequest},
+		{"bad url", url.Values{"q": {"jive search"}, "u": {"javascript:alert(1)"}, "p": {"2"}}, "", http.StatusBadRequest},
+		{"bad position", url.Values{"q": {"jive search"}, "u": {"https://www.example.com"}, "p": {"0"}}, "", http.StatusBadRequest},
+	} {
+		t.Run(c.name, func(t *testing.T) {
+			req := httptest.NewRequest("POST", "/click", strings.NewReader(c.form.Encode()))
+			req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+			if c.header != "" {
+				req.Header.Set(c.header, "1")
+			}
+
+			rsp := f.clickHandler(httptest.NewRecorder(), req)
+			if rsp.status != c.status {
+				t.Fatalf("got status %d; want %d (%v)", rsp.status, c.status, rsp.err)
+			}
+		})
+	}
+
+	st, err := store.Stats(f.Clicks.Hash("jive search"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if s, ok := st.URLs["https://www.example.com"]; !ok || s.Clicks != 1 || len(st.URLs) != 1 {
+		t.Fatalf("got %+v; want one click on https://www.example.com", st.URLs)
+	}
+
+	// disabled
+	f.Clicks.Store = nil
+	req := httptest.NewRequest("POST", "/click?q=jive&u=https://www.example.com&p=1", nil)
+	if rsp := f.clickHandler(httptest.NewRecorder(), req); rsp.status != http.StatusBadRequest {
+		t.Fatalf("got status %d; want %d", rsp.status, http.StatusBadRequest)
+	}
+}
+
+func TestCountImpression(t *testing.T) {
+	store := &click.Simple{}
+	if err := store.Setup(); err != nil {
+		t.Fatal(err)
+	}
+
+	f := &Frontend{
+		Clicks: Clicks{
+			Store:  store,
+			Hasher: click.Hasher{Salt: "my_salt"},
+		},
+	}
+
+	results := Results{Search: &search.Results{Documents: []*document.Document{{ID: "https://www.example.com"}}}}
+	req := httptest.NewRequest("GET", "/?q=jive+search", nil)
+
+	for _, d := range []data{
+		{Context: &Context{Q: "jive search", Page: 1}, Results: results},
+		{Context: &Context{Q: "jive search", Page: 2}, Results: results},
+		{Context: &Context{Q: "jive search", T: "images", Page: 1}, Results: results},
+		{Context: &Context{Q: "jive search", Page: 1}, Results: Results{Search: &search.Results{}}},
+	} {
+		f.countImpression(req, d)
+	}
+
+	st, err := store.Stats(f.Clicks.Hash("jive search"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if st.Impressions != 1 {
+		t.Fatalf("got %d impressions; want 1", st.Impressions)
+	}
+}
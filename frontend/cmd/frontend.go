@@ -2,18 +2,30 @@
 package main
 
 import (
+	"context"
+	"crypto/rand"
 	"database/sql"
+	"encoding/hex"
+	"encoding/json"
 	"fmt"
+	"io"
+	"net"
 	"net/http"
 	"os"
+	"os/signal"
 	"path"
 	"strconv"
 	"strings"
+	"syscall"
 
 	"github.com/jivesearch/jivesearch/instant/breach"
 	"github.com/jivesearch/jivesearch/instant/congress"
+	"github.com/jivesearch/jivesearch/instant/dns"
+	"github.com/jivesearch/jivesearch/instant/media"
 	"github.com/jivesearch/jivesearch/instant/nutrition"
+	"github.com/jivesearch/jivesearch/instant/repo"
 	"github.com/jivesearch/jivesearch/instant/status"
+	"github.com/jivesearch/jivesearch/instant/throttle"
 	"github.com/jivesearch/jivesearch/instant/whois"
 
 	"github.com/jivesearch/jivesearch/instant/econ/gdp"
@@ -26,6 +38,7 @@ import (
 
 	tzz "github.com/evanoberholster/timezoneLookup"
 	"github.com/jivesearch/jivesearch/instant/location"
+	"github.com/jivesearch/jivesearch/instant/maps"
 	"github.com/jivesearch/jivesearch/instant/weather"
 
 	"github.com/abursavich/nett"
@@ -33,53 +46,94 @@ import (
 	"github.com/jivesearch/jivesearch/bangs"
 	"github.com/jivesearch/jivesearch/config"
 	"github.com/jivesearch/jivesearch/frontend"
+	"github.com/jivesearch/jivesearch/frontend/analytics"
+	"github.com/jivesearch/jivesearch/frontend/apikey"
 	"github.com/jivesearch/jivesearch/frontend/cache"
+	"github.com/jivesearch/jivesearch/frontend/history"
+	"github.com/jivesearch/jivesearch/frontend/saved"
 	"github.com/jivesearch/jivesearch/instant"
 	"github.com/jivesearch/jivesearch/instant/discography/musicbrainz"
+	"github.com/jivesearch/jivesearch/instant/packages"
 	"github.com/jivesearch/jivesearch/instant/parcel"
 	"github.com/jivesearch/jivesearch/instant/stackoverflow"
 	"github.com/jivesearch/jivesearch/instant/stock"
 	"github.com/jivesearch/jivesearch/instant/timezone"
 	"github.com/jivesearch/jivesearch/instant/wikipedia"
+	"github.com/jivesearch/jivesearch/instant/words"
 	"github.com/jivesearch/jivesearch/log"
 	"github.com/jivesearch/jivesearch/search"
+	"github.com/jivesearch/jivesearch/search/blend"
+	"github.com/jivesearch/jivesearch/search/click"
 	"github.com/jivesearch/jivesearch/search/document"
+	"github.com/jivesearch/jivesearch/search/domains"
 	img "github.com/jivesearch/jivesearch/search/image"
+	"github.com/jivesearch/jivesearch/search/intent"
+	"github.com/jivesearch/jivesearch/search/local"
+	"github.com/jivesearch/jivesearch/search/news"
 	"github.com/jivesearch/jivesearch/search/provider"
+	"github.com/jivesearch/jivesearch/search/scholar"
+	"github.com/jivesearch/jivesearch/search/shopping"
+	"github.com/jivesearch/jivesearch/search/threat"
+	"github.com/jivesearch/jivesearch/search/video"
+	"github.com/jivesearch/jivesearch/singleflight"
 	"github.com/jivesearch/jivesearch/suggest"
 	"github.com/olivere/elastic"
 	"github.com/spf13/viper"
+	bolt "go.etcd.io/bbolt"
 	"golang.org/x/text/language"
 )
 
 var (
-	f *frontend.Frontend
+	f  *frontend.Frontend
+	rl *frontend.Reloader
 )
 
+// throttles outlive a reload so reloading doesn't refill them.
+// Each provider has its own so one can't use up another's limit or cache.
+var throttles struct {
+	github, gitlab, packages, whois, media *throttle.Throttle
+}
+
 func setup(v *viper.Viper) *http.Server {
 	v.SetEnvPrefix("jivesearch")
 	v.AutomaticEnv()
 	v.SetEnvKeyReplacer(strings.NewReplacer(".", "_"))
 	config.SetDefaults(v)
 
+	if err := readConfig(v); err != nil {
+		panic(err)
+	}
+
+	if err := logging(v); err != nil {
+		panic(err)
+	}
+
 	frontend.ParseTemplates()
-	f = &frontend.Frontend{
-		Brand: frontend.Brand{
-			Name:      v.GetString("brand.name"),
-			Host:      v.GetString("server.host"),
-			TagLine:   v.GetString("brand.tagline"),
-			Logo:      v.GetString("brand.logo"),
-			SmallLogo: v.GetString("brand.small_logo"),
+	f = &frontend.Frontend{}
+
+	// our router is built once main has configured our frontend
+	rl = &frontend.Reloader{
+		Load: func() (http.Handler, error) {
+			return f.Router(), nil
 		},
-		Onion: v.GetString("onion"),
 	}
 
-	router := f.Router(v)
-
 	return &http.Server{
 		Addr:    ":" + strconv.Itoa(v.GetInt("frontend.port")),
-		Handler: http.TimeoutHandler(router, 5*time.Second, "Sorry, we took too long to get back to you"),
+		Handler: frontend.Timeout(rl, 5*time.Second, "Sorry, we took too long to get back to you"),
+	}
+}
+
+// readConfig reads the optional config file, e.g. JIVESEARCH_CONFIG_FILE=/etc/jivesearch.toml.
+// Environment variables still take precedence over the file.
+func readConfig(v *viper.Viper) error {
+	file := v.GetString("config.file")
+	if file == "" {
+		return nil
 	}
+
+	v.SetConfigFile(file)
+	return v.ReadInConfig()
 }
 
 func main() {
@@ -100,40 +154,36 @@ func main() {
 		Timeout: 3 * time.Second,
 	}
 
-	switch v.GetString("search.provider") {
-	case "yandex":
-		f.Search = &provider.Yandex{
-			Client: httpClient,
-			Key:    v.GetString("yandex.key"),
-			User:   v.GetString("yandex.user"),
-		}
-	default:
-		f.Search = &search.ElasticSearch{
-			ElasticSearch: &document.ElasticSearch{
-				Client: esClient(v, client),
-				Index:  v.GetString("elasticsearch.search.index"),
-				Type:   v.GetString("elasticsearch.search.type"),
-			},
-		}
+	// boosts, freshness, etc are reloaded when the ranking file changes
+	vr := viper.New()
+	vr.SetConfigType("toml")
+	vr.AddConfigPath("../search")
+	vr.SetConfigName("ranking")
+
+	ranker, err := search.NewRanker(vr)
+	if err != nil {
+		panic(err)
 	}
 
-	switch v.GetString("images.provider") {
-	case "pixabay":
-		f.Images.Fetcher = &img.Pixabay{
-			HTTPClient: httpClient,
-			Key:        v.GetString("pixabay.key"),
-		}
-	default:
-		f.Images.Fetcher = &img.ElasticSearch{
-			Client:        esClient(v, client),
-			Index:         v.GetString("elasticsearch.images.index"),
-			Type:          v.GetString("elasticsearch.images.type"),
-			NSFWThreshold: .80,
-		}
+	// queries without results are retried with looser matching
+	es := &search.ElasticSearch{
+		ElasticSearch: &document.ElasticSearch{
+			Client: esClient(v, client),
+			Index:  v.GetString("elasticsearch.search.index"),
+			Type:   v.GetString("elasticsearch.search.type"),
+		},
+		Ranker: ranker,
+	}
+
+	// ranking experiments can switch providers with the "search" param
+	f.Experiments.Searchers = map[string]search.Fetcher{
+		"elasticsearch": &search.Relaxer{
+			Fetcher: es,
+			Speller: es,
+		},
 	}
 
 	f.Images.Client = httpClient
-	f.MapBoxKey = v.GetString("mapbox.key")
 
 	// load naughty list
 	cwd, err := os.Getwd()
@@ -165,8 +215,7 @@ func main() {
 		panic(err)
 	}
 
-	f.Cache.Instant = v.GetDuration("cache.instant")
-	f.Cache.Search = v.GetDuration("cache.search")
+	f.BangEdits.Config = f.Bangs
 
 	// The database needs to be setup beforehand.
 	db, err := sql.Open("postgres",
@@ -192,20 +241,21 @@ func main() {
 
 	f.Instant = &instant.Instant{
 		QueryVar: "q",
+		Cache:    instant.NewAnswerCache(v.GetInt("instant.cache")),
 		BreachFetcher: &breach.Pwned{
 			HTTPClient: httpClient,
 			UserAgent:  v.GetString("useragent"),
 		},
-		CongressFetcher: &congress.ProPublica{
-			Key:        v.GetString("propublica.key"),
-			HTTPClient: httpClient,
-		},
-		FedExFetcher: &parcel.FedEx{
-			HTTPClient: httpClient,
-			Account:    v.GetString("fedex.account"),
-			Password:   v.GetString("fedex.password"),
-			Key:        v.GetString("fedex.key"),
-			Meter:      v.GetString("fedex.meter"),
+		DNSFetcher: &dns.Limited{
+			Fetcher: &dns.Resolver{
+				Resolver: net.DefaultResolver,
+				Timeout:  5 * time.Second,
+			},
+			Throttle: &throttle.Throttle{
+				Rate:  v.GetFloat64("dns.rate"),
+				Burst: v.GetInt("dns.burst"),
+				TTL:   v.GetDuration("dns.ttl"),
+			},
 		},
 		Currency: instant.Currency{
 			CryptoFetcher: &currency.CryptoCompare{
@@ -220,50 +270,82 @@ func main() {
 		LinkShortener: &shortener.IsGd{
 			HTTPClient: httpClient,
 		},
-		NutritionFetcher: &nutrition.USDA{
-			HTTPClient: httpClient,
-			Key:        v.GetString("usda.key"),
-		},
 		PopulationFetcher: &population.WorldBank{
 			HTTPClient: httpClient,
 		},
-		StackOverflowFetcher: &stackoverflow.API{
-			HTTPClient: httpClient,
-			Key:        v.GetString("stackoverflow.key"),
-		},
 		StatusFetcher: &status.IsItUp{
 			HTTPClient: httpClient,
 		},
 		StockQuoteFetcher: &stock.IEX{
 			HTTPClient: httpClient,
 		},
-		UPSFetcher: &parcel.UPS{
-			HTTPClient: httpClient,
-			User:       v.GetString("ups.user"),
-			Password:   v.GetString("ups.password"),
-			Key:        v.GetString("ups.key"),
-		},
-		USPSFetcher: &parcel.USPS{
-			HTTPClient: httpClient,
-			User:       v.GetString("usps.user"),
-			Password:   v.GetString("usps.password"),
-		},
-		WeatherFetcher: &weather.OpenWeatherMap{
-			HTTPClient: httpClient,
-			Key:        v.GetString("openweathermap.key"),
-		},
-		WHOISFetcher: &whois.JiveData{ // until there are multiple whois fetchers Jive Data will be the default
-			HTTPClient: httpClient,
-			Key:        v.GetString("jivedata.key"),
-		},
+	}
+
+	// brand, cache TTLs, API keys, etc can be reloaded without a restart
+	if err := configure(f, v, httpClient); err != nil {
+		panic(err)
 	}
 
 	f.ProxyClient = httpClient
 
+	// many users searching for the same thing at once share one fetch until it's cached
+	f.Coalesce = &singleflight.Group{}
+
+	proxies, err := frontend.ParseNetworks(v.GetStringSlice("ratelimit.trusted_proxies"))
+	if err != nil {
+		panic(err)
+	}
+
+	f.RateLimit = frontend.RateLimit{
+		Limits:         map[string]frontend.Limit{},
+		Multiplier:     v.GetFloat64("ratelimit.multiplier"),
+		TrustedProxies: proxies,
+	}
+
+	for _, route := range []string{"search", "autocomplete", "image", "api", "click"} {
+		f.RateLimit.Limits[route] = frontend.Limit{
+			Rate:  v.GetFloat64(fmt.Sprintf("ratelimit.%v.rate", route)),
+			Burst: v.GetInt(fmt.Sprintf("ratelimit.%v.burst", route)),
+		}
+	}
+
+	// under load we shed instant answers, results and finally anything that isn't cached
+	f.Shed = &frontend.Shedder{
+		Latency:    v.GetDuration("shed.latency"),
+		Min:        v.GetInt("shed.min"),
+		Max:        v.GetInt("shed.max"),
+		Goroutines: v.GetInt("shed.goroutines"),
+		Number:     v.GetInt("shed.number"),
+		RetryAfter: v.GetDuration("shed.retry_after"),
+	}
+
+	if m := v.GetString("intent.model"); m != "" {
+		fl, err := os.Open(m)
+		if err != nil {
+			panic(err)
+		}
+
+		f.Intent.Model, err = intent.LoadModel(fl)
+		if err != nil {
+			panic(err)
+		}
+		fl.Close()
+	}
+
+	f.Blender = blend.Blender{
+		Weights:   map[blend.Vertical]float64{},
+		Threshold: v.GetFloat64("blend.threshold"),
+	}
+
+	for _, vertical := range []blend.Vertical{blend.Images, blend.Videos, blend.News} {
+		f.Blender.Weights[vertical] = v.GetFloat64(fmt.Sprintf("blend.%v.weight", vertical))
+	}
+
+	f.APIKeys.Required = v.GetBool("api.keys.required")
+
 	// use Jive Data when debuggin to make setup easier
 	switch debug {
 	case true:
-		log.Debug.SetOutput(os.Stdout)
 		f.Cache.Cacher = &cache.Simple{
 			M: make(map[string]cache.Value),
 		}
@@ -272,6 +354,28 @@ func main() {
 
 		f.Suggest = &suggest.Simple{}
 
+		f.APIKeys.Store = &apikey.Simple{}
+
+		f.Domains = &domains.Simple{}
+
+		f.BangEdits.Store = &bangs.SimpleStore{}
+
+		if v.GetBool("analytics.enabled") {
+			f.Analytics.Store = &analytics.Simple{}
+		}
+
+		if v.GetBool("clicks.enabled") {
+			f.Clicks.Store = &click.Simple{}
+		}
+
+		if v.GetBool("history.enabled") {
+			f.History.Store = &history.Simple{}
+		}
+
+		if v.GetBool("saved.enabled") {
+			f.Saved.Store = &saved.Simple{}
+		}
+
 		f.Instant.DiscographyFetcher = &musicbrainz.JiveData{
 			HTTPClient: httpClient,
 			Key:        v.GetString("jivedata.key"),
@@ -325,14 +429,21 @@ func main() {
 			Type:   v.GetString("elasticsearch.query.type"),
 		}
 
-		f.Instant.DiscographyFetcher = &musicbrainz.PostgreSQL{
+		mb := &musicbrainz.PostgreSQL{
 			DB: db,
 		}
 
-		f.Instant.LocationFetcher = &location.MaxMind{
-			DB: v.GetString("maxmind.database"),
+		f.Instant.DiscographyFetcher = mb
+		f.Instant.SongwriterFetcher = mb
+
+		mm := &location.MaxMind{
+			DB:    v.GetString("maxmind.database"),
+			ASNDB: v.GetString("maxmind.asn.database"),
 		}
 
+		f.Instant.LocationFetcher = mm
+		f.Instant.ASNFetcher = mm
+
 		// timezone
 		tz, err := tzz.LoadTimezones(tzz.Config{
 			DatabaseType: "memory",
@@ -353,6 +464,206 @@ func main() {
 		f.Instant.WikipediaFetcher = &wikipedia.PostgreSQL{
 			DB: db,
 		}
+
+		f.APIKeys.Store = &apikey.PostgreSQL{
+			DB: db,
+		}
+
+		f.Domains = &domains.PostgreSQL{
+			DB: db,
+		}
+
+		f.BangEdits.Store = &bangs.PostgreSQL{
+			DB: db,
+		}
+
+		if v.GetBool("analytics.enabled") {
+			f.Analytics.Store = &analytics.PostgreSQL{
+				DB: db,
+			}
+		}
+
+		if v.GetBool("clicks.enabled") {
+			f.Clicks.Store = &click.PostgreSQL{
+				DB: db,
+			}
+		}
+
+		if v.GetBool("history.enabled") {
+			f.History.Store = &history.PostgreSQL{
+				DB: db,
+			}
+		}
+
+		if v.GetBool("saved.enabled") {
+			switch store := v.GetString("saved.store"); store {
+			case "bolt":
+				bdb, err := bolt.Open(v.GetString("saved.path"), 0600, &bolt.Options{Timeout: time.Second})
+				if err != nil {
+					panic(err)
+				}
+
+				defer bdb.Close()
+
+				f.Saved.Store = &saved.Bolt{
+					DB: bdb,
+				}
+			case "postgresql":
+				f.Saved.Store = &saved.PostgreSQL{
+					DB: db,
+				}
+			default:
+				panic(fmt.Sprintf("unknown saved.store %q", store))
+			}
+		}
+	}
+
+	if err := f.APIKeys.Setup(); err != nil {
+		panic(err)
+	}
+
+	if err := f.Domains.Setup(); err != nil {
+		panic(err)
+	}
+
+	if err := f.BangEdits.Setup(); err != nil {
+		panic(err)
+	}
+
+	if err := f.EditBangs(); err != nil {
+		panic(err)
+	}
+
+	// !bang redirects are counted, and their urls checked, for as long as we run. A reload keeps both.
+	f.BangStats.Usage = bangs.NewUsage(time.Now())
+
+	// so is how often we find what we need in our cache
+	f.Cache.Stats = cache.NewStats(time.Now())
+
+	if interval := v.GetDuration("bangs.check.interval"); interval > 0 && !v.GetBool("tor.mode") {
+		f.BangStats.Checker = &bangs.Checker{
+			Client: &http.Client{
+				Transport: httpClient.Transport,
+				Timeout:   v.GetDuration("bangs.check.timeout"),
+			},
+			UserAgent: v.GetString("useragent"),
+			Interval:  interval,
+			Delay:     v.GetDuration("bangs.check.delay"),
+		}
+
+		edits := f.BangEdits
+		go f.BangStats.Run(func() ([]bangs.Bang, error) {
+			b, err := edits.Config.Edit(edits.Store)
+			if err != nil {
+				return nil, err
+			}
+			return b.Bangs, nil
+		})
+	}
+
+	if f.Analytics.Store != nil {
+		if err := f.Analytics.Setup(); err != nil {
+			panic(err)
+		}
+
+		f.Analytics.Salt = v.GetString("analytics.salt")
+		if f.Analytics.Salt == "" {
+			b := make([]byte, 32)
+			if _, err := rand.Read(b); err != nil {
+				panic(err)
+			}
+			f.Analytics.Salt = hex.EncodeToString(b)
+		}
+
+		if interval := v.GetDuration("warm.interval"); interval > 0 {
+			w := &frontend.Warmer{
+				Interval: interval,
+				Period:   v.GetDuration("warm.period"),
+				Top:      v.GetInt("warm.top"),
+			}
+
+			for _, l := range v.GetStringSlice("warm.locales") {
+				locale, err := language.Parse(l)
+				if err != nil {
+					panic(err)
+				}
+				w.Locales = append(w.Locales, locale)
+			}
+
+			go f.Warm(w)
+		}
+	}
+
+	if f.Clicks.Store != nil {
+		if err := f.Clicks.Setup(); err != nil {
+			panic(err)
+		}
+
+		f.Clicks.Salt = v.GetString("clicks.salt")
+		if f.Clicks.Salt == "" {
+			log.Info.Println("clicks.salt isn't set so click statistics won't carry over a restart")
+			b := make([]byte, 32)
+			if _, err := rand.Read(b); err != nil {
+				panic(err)
+			}
+			f.Clicks.Salt = hex.EncodeToString(b)
+		}
+
+		// results from our own index are reordered by how often they are clicked
+		relaxer := f.Experiments.Searchers["elasticsearch"]
+		rr := &click.Reranker{
+			Fetcher:        relaxer,
+			Store:          f.Clicks.Store,
+			Hasher:         f.Clicks.Hasher,
+			Weight:         v.GetFloat64("clicks.weight"),
+			MinImpressions: v.GetInt64("clicks.impressions"),
+		}
+
+		f.Experiments.Searchers["elasticsearch"] = rr
+		if f.Search == relaxer {
+			f.Search = rr
+		}
+	}
+
+	if f.History.Store != nil {
+		if err := f.History.Setup(); err != nil {
+			panic(err)
+		}
+	}
+
+	if f.Saved.Store != nil {
+		if err := f.Saved.Setup(); err != nil {
+			panic(err)
+		}
+	}
+
+	// operators push documents straight into our index at /api/v1/documents (opt-in)
+	if v.GetBool("documents.enabled") {
+		idx := &document.ElasticSearch{
+			Client:    es.Client,
+			Index:     es.Index,
+			Type:      es.Type,
+			BM25:      ranker.Ranking().BM25,
+			Plugins:   v.GetStringSlice("elasticsearch.search.plugins"),
+			Compounds: v.GetString("elasticsearch.search.compounds"),
+		}
+
+		// the language indices might not exist yet if we haven't crawled
+		if err := idx.Setup(); err != nil {
+			panic(err)
+		}
+
+		f.Ingest = frontend.Ingest{
+			Indexer:      idx,
+			MaxBytes:     v.GetInt64("documents.max_bytes"),
+			MaxDocuments: v.GetInt("documents.max"),
+			Batch:        v.GetInt("documents.batch"),
+		}
+	}
+
+	// looking up the user's region by IP is opt-in
+	if v.GetBool("geolocation.region") {
+		f.RegionFetcher = f.Instant.LocationFetcher
 	}
 
 	// setup !bangs suggester
@@ -383,6 +694,14 @@ func main() {
 		}
 	}
 
+	if interval := v.GetDuration("autocomplete.scrub.interval"); interval > 0 {
+		scrubber := &suggest.Scrubber{
+			Suggester: f.Suggest,
+			Interval:  interval,
+		}
+		go scrubber.Run()
+	}
+
 	// wikipedia setup
 	if err := f.Instant.WikipediaFetcher.Setup(); err != nil {
 		log.Info.Println(err)
@@ -396,12 +715,544 @@ func main() {
 
 	f.Wikipedia.Matcher = language.NewMatcher(supported)
 
+	// word lists for anagrams, scrabble scores, etc
+	f.Instant.WordLists, err = words.Load(path.Join(cwd, "../instant/words"), supported)
+	if err != nil {
+		panic(err)
+	}
+
+	for _, lang := range supported {
+		if base, _ := lang.Base(); f.Instant.WordLists[base.String()] == nil {
+			log.Info.Printf("no word list for language %q\n", lang)
+		}
+	}
+
 	// see notes on customizing languages in search/document/document.go
 	f.Document.Languages = document.Languages(supported)
 	f.Document.Matcher = language.NewMatcher(f.Document.Languages)
 
-	log.Info.Printf("Listening at http://127.0.0.1%v", s.Addr)
-	log.Info.Fatal(s.ListenAndServe())
+	if err := rl.Reload(); err != nil {
+		panic(err)
+	}
+
+	// a reload rereads the config file and swaps in a copy of our frontend with the new settings
+	f.Reload = rl.Reload
+	rl.Load = func() (http.Handler, error) {
+		if err := readConfig(v); err != nil {
+			return nil, err
+		}
+
+		nf := *f
+		if err := configure(&nf, v, httpClient); err != nil {
+			return nil, err
+		}
+
+		if err := nf.EditBangs(); err != nil {
+			return nil, err
+		}
+
+		f = &nf
+		return f.Router(), nil
+	}
+
+	rl.Notify(syscall.SIGHUP)
+
+	if file := v.GetString("config.file"); file != "" {
+		if err := rl.Watch(file); err != nil {
+			panic(err)
+		}
+	}
+
+	go func() {
+		log.Info.Printf("Listening at http://127.0.0.1%v", s.Addr)
+		if err := s.ListenAndServe(); err != http.ErrServerClosed {
+			log.Info.Fatal(err)
+		}
+	}()
+
+	// returning from main, rather than exiting, closes our redis pool and database
+	stop := make(chan os.Signal, 1)
+	signal.Notify(stop, syscall.SIGINT, syscall.SIGTERM)
+	log.Info.Printf("shutting down on %v\n", <-stop)
+
+	frontend.Drain()
+	time.Sleep(v.GetDuration("shutdown.delay"))
+
+	ctx, cancel := context.WithTimeout(context.Background(), v.GetDuration("shutdown.timeout"))
+	defer cancel()
+
+	if err := frontend.Shutdown(ctx, s); err != nil {
+		log.Info.Printf("unable to finish in-flight requests: %v\n", err)
+	}
+}
+
+// configure sets everything that can change without a restart: our brand,
+// cache TTLs, API keys, log level and which instant answers are on. It is also how we
+// switch providers that need a key. On a reload it gets a copy of the running
+// frontend so nothing it changes is shared with requests being served.
+func configure(f *frontend.Frontend, v *viper.Viper, httpClient *http.Client) error {
+	f.AdminToken = v.GetString("admin.token")
+	f.Brand = frontend.Brand{
+		Name:      v.GetString("brand.name"),
+		Host:      v.GetString("server.host"),
+		TagLine:   v.GetString("brand.tagline"),
+		Logo:      v.GetString("brand.logo"),
+		SmallLogo: v.GetString("brand.small_logo"),
+	}
+	f.Onion = v.GetString("onion")
+	f.POST = v.GetBool("search.post")
+	f.Security = frontend.Security{
+		CSP:        v.GetString("security.csp"),
+		ReportOnly: v.GetBool("security.csp_report_only"),
+		HSTS:       v.GetDuration("security.hsts"),
+		Frame:      v.GetString("security.frame"),
+		Referrer:   v.GetString("security.referrer"),
+	}
+	f.Autocomplete = frontend.Autocomplete{
+		Debounce:    v.GetDuration("autocomplete.debounce"),
+		Idle:        v.GetDuration("autocomplete.idle"),
+		MaxMessages: v.GetInt("autocomplete.max_messages"),
+	}
+	f.CORS = frontend.CORS{
+		Origins:     v.GetStringSlice("cors.origins"),
+		Methods:     v.GetStringSlice("cors.methods"),
+		Headers:     v.GetStringSlice("cors.headers"),
+		MaxAge:      v.GetDuration("cors.max_age"),
+		Credentials: v.GetBool("cors.credentials"),
+	}
+
+	for _, o := range f.CORS.Origins {
+		if o == "*" && f.CORS.Credentials {
+			return fmt.Errorf("cors credentials can't be allowed for any origin")
+		}
+	}
+
+	f.Tor = v.GetBool("tor.mode")
+	if f.Tor {
+		if p := v.GetString("search.provider"); p == "yandex" {
+			return fmt.Errorf("tor mode can't use the %v search provider", p)
+		}
+		if p := v.GetString("images.provider"); p == "pixabay" {
+			return fmt.Errorf("tor mode can't use the %v images provider", p)
+		}
+	}
+
+	f.Cache.Instant = v.GetDuration("cache.instant")
+	f.Cache.Search = v.GetDuration("cache.search")
+
+	f.Health = frontend.Health{
+		Timeout:  v.GetDuration("health.timeout"),
+		ImageURL: v.GetString("health.image"),
+	}
+
+	searchers := map[string]search.Fetcher{}
+	for k, s := range f.Experiments.Searchers {
+		searchers[k] = s
+	}
+
+	searchers["yandex"] = &search.Relaxer{
+		Fetcher: &provider.Yandex{
+			Client: httpClient,
+			Key:    v.GetString("yandex.key"),
+			User:   v.GetString("yandex.user"),
+		},
+	}
+
+	f.Experiments.Searchers = searchers
+
+	switch v.GetString("search.provider") {
+	case "yandex":
+		f.Search = f.Experiments.Searchers["yandex"]
+	default:
+		f.Search = f.Experiments.Searchers["elasticsearch"]
+	}
+
+	f.HMAC = frontend.HMAC{Secret: v.GetString("hmac.secret"), Grace: v.GetDuration("hmac.grace")}
+	if keys := v.GetString("hmac.keys"); keys != "" {
+		if err := json.Unmarshal([]byte(keys), &f.HMAC.Keys); err != nil {
+			return err
+		}
+	}
+
+	for _, k := range f.HMAC.Keys {
+		if k.ID == "" || strings.ContainsAny(k.ID, "./,") || k.Secret == "" {
+			return fmt.Errorf("hmac keys need an id without '.', '/' or ',' and a secret: %q", k.ID)
+		}
+	}
+
+	feed, err := threats(v)
+	if err != nil {
+		return err
+	}
+	f.Threats = frontend.Threats{Feed: feed, Filter: v.GetBool("threat.filter")}
+
+	f.Thumbnails.Concurrency = v.GetInt("images.batch.concurrency")
+	f.Thumbnails.Timeout = v.GetDuration("images.batch.timeout")
+
+	f.Experiments.Tests = nil
+	if exp := v.GetString("experiments"); exp != "" {
+		if err := json.Unmarshal([]byte(exp), &f.Experiments.Tests); err != nil {
+			return err
+		}
+	}
+
+	f.Tenants = nil
+	if tenants := v.GetString("tenants"); tenants != "" {
+		if err := json.Unmarshal([]byte(tenants), &f.Tenants); err != nil {
+			return err
+		}
+	}
+
+	rankings := []string{}
+	for k := range f.Experiments.Searchers {
+		rankings = append(rankings, k)
+	}
+
+	if err := f.Tenants.Validate(rankings); err != nil {
+		return err
+	}
+
+	switch v.GetString("images.provider") {
+	case "pixabay":
+		f.Images.Fetcher = &img.Pixabay{
+			HTTPClient: httpClient,
+			Key:        v.GetString("pixabay.key"),
+		}
+	default:
+		if _, ok := f.Images.Fetcher.(*img.ElasticSearch); !ok {
+			f.Images.Fetcher = &img.ElasticSearch{
+				Client:        esClient(v, nil),
+				Index:         v.GetString("elasticsearch.images.index"),
+				Type:          v.GetString("elasticsearch.images.type"),
+				NSFWThreshold: .80,
+			}
+		}
+	}
+
+	f.MapBoxKey = v.GetString("mapbox.key")
+
+	switch v.GetString("maps.provider") {
+	case "osm":
+		f.Maps.Geocoder = &maps.Nominatim{
+			HTTPClient: httpClient,
+			URL:        v.GetString("nominatim.url"),
+			UserAgent:  v.GetString("useragent"),
+		}
+		f.Maps.Router = &maps.OSRM{
+			HTTPClient: httpClient,
+			URL:        v.GetString("osrm.url"),
+		}
+		f.Local = &local.Overpass{
+			HTTPClient: httpClient,
+			URL:        v.GetString("overpass.url"),
+			UserAgent:  v.GetString("useragent"),
+		}
+	default:
+		mb := &maps.MapBox{
+			HTTPClient: httpClient,
+			Key:        v.GetString("mapbox.key"),
+		}
+		f.Maps.Geocoder = mb
+		f.Maps.Router = mb
+		f.Local = &local.MapBox{
+			HTTPClient: httpClient,
+			Key:        v.GetString("mapbox.key"),
+		}
+	}
+
+	switch v.GetString("scholar.provider") {
+	case "arxiv":
+		f.Scholar = &scholar.ArXiv{
+			HTTPClient: httpClient,
+			UserAgent:  v.GetString("useragent"),
+		}
+	case "semanticscholar":
+		f.Scholar = &scholar.SemanticScholar{
+			HTTPClient: httpClient,
+			Key:        v.GetString("semanticscholar.key"),
+		}
+	default:
+		f.Scholar = &scholar.Crossref{
+			HTTPClient: httpClient,
+			Mailto:     v.GetString("crossref.mailto"),
+			UserAgent:  v.GetString("useragent"),
+		}
+	}
+
+	switch v.GetString("shopping.provider") {
+	case "amazon":
+		f.Shopping = &shopping.Amazon{
+			HTTPClient: httpClient,
+			AccessKey:  v.GetString("amazon.access_key"),
+			SecretKey:  v.GetString("amazon.secret_key"),
+			PartnerTag: v.GetString("amazon.partner_tag"),
+		}
+	case "feed":
+		f.Shopping = &shopping.Feed{
+			HTTPClient: httpClient,
+			URLs:       v.GetStringSlice("shopping.feeds"),
+			UserAgent:  v.GetString("useragent"),
+		}
+	default:
+		f.Shopping = &shopping.EBay{
+			HTTPClient: httpClient,
+			Token:      v.GetString("ebay.token"),
+		}
+	}
+
+	f.Videos = &video.YouTube{
+		HTTPClient: httpClient,
+		Key:        v.GetString("youtube.key"),
+	}
+
+	f.News = &news.NewsAPI{
+		HTTPClient: httpClient,
+		Key:        v.GetString("newsapi.key"),
+	}
+
+	if throttles.github == nil {
+		throttles.github = &throttle.Throttle{
+			Rate:  v.GetFloat64("repo.rate"),
+			Burst: v.GetInt("repo.burst"),
+			TTL:   v.GetDuration("repo.ttl"),
+		}
+		throttles.gitlab = &throttle.Throttle{
+			Rate:  v.GetFloat64("repo.rate"),
+			Burst: v.GetInt("repo.burst"),
+			TTL:   v.GetDuration("repo.ttl"),
+		}
+		throttles.packages = &throttle.Throttle{
+			Rate:  v.GetFloat64("packages.rate"),
+			Burst: v.GetInt("packages.burst"),
+			TTL:   v.GetDuration("packages.ttl"),
+		}
+		throttles.whois = &throttle.Throttle{
+			Rate:  v.GetFloat64("whois.rate"),
+			Burst: v.GetInt("whois.burst"),
+			TTL:   v.GetDuration("whois.ttl"),
+		}
+		throttles.media = &throttle.Throttle{
+			Rate:  v.GetFloat64("media.rate"),
+			Burst: v.GetInt("media.burst"),
+			TTL:   v.GetDuration("media.ttl"),
+		}
+	}
+
+	in := instant.Instant{}
+	if f.Instant != nil {
+		in = *f.Instant
+	}
+
+	in.CongressFetcher = &congress.ProPublica{
+		Key:        v.GetString("propublica.key"),
+		HTTPClient: httpClient,
+	}
+	in.FedExFetcher = &parcel.FedEx{
+		HTTPClient: httpClient,
+		Account:    v.GetString("fedex.account"),
+		Password:   v.GetString("fedex.password"),
+		Key:        v.GetString("fedex.key"),
+		Meter:      v.GetString("fedex.meter"),
+	}
+	in.GitHubFetcher = &repo.Limited{
+		Fetcher: &repo.GitHub{
+			HTTPClient: httpClient,
+			Token:      v.GetString("github.token"),
+		},
+		Throttle: throttles.github,
+	}
+	in.GitLabFetcher = &repo.Limited{
+		Fetcher: &repo.GitLab{
+			HTTPClient: httpClient,
+			Token:      v.GetString("gitlab.token"),
+		},
+		Throttle: throttles.gitlab,
+	}
+	in.PackageFetcher = &packages.Limited{
+		Fetcher: &packages.Registries{
+			HTTPClient: httpClient,
+			UserAgent:  v.GetString("useragent"),
+		},
+		Throttle: throttles.packages,
+	}
+	in.NutritionFetcher = &nutrition.USDA{
+		HTTPClient: httpClient,
+		Key:        v.GetString("usda.key"),
+	}
+	in.StackOverflowFetcher = &stackoverflow.API{
+		HTTPClient: httpClient,
+		Key:        v.GetString("stackoverflow.key"),
+	}
+	in.UPSFetcher = &parcel.UPS{
+		HTTPClient: httpClient,
+		User:       v.GetString("ups.user"),
+		Password:   v.GetString("ups.password"),
+		Key:        v.GetString("ups.key"),
+	}
+	in.USPSFetcher = &parcel.USPS{
+		HTTPClient: httpClient,
+		User:       v.GetString("usps.user"),
+		Password:   v.GetString("usps.password"),
+	}
+	in.WeatherFetcher = &weather.OpenWeatherMap{
+		HTTPClient: httpClient,
+		Key:        v.GetString("openweathermap.key"),
+	}
+	in.WHOISFetcher = &whois.Limited{
+		Fetcher: &whois.JiveData{ // until there are multiple whois fetchers Jive Data will be the default
+			HTTPClient: httpClient,
+			Key:        v.GetString("jivedata.key"),
+		},
+		Throttle: throttles.whois,
+	}
+
+	switch v.GetString("media.provider") {
+	case "omdb":
+		in.MediaFetcher = &media.Limited{
+			Fetcher: &media.OMDb{
+				HTTPClient: httpClient,
+				Key:        v.GetString("omdb.key"),
+			},
+			Throttle: throttles.media,
+		}
+	default:
+		in.MediaFetcher = &media.Limited{
+			Fetcher: &media.TMDB{
+				HTTPClient: httpClient,
+				Key:        v.GetString("tmdb.key"),
+			},
+			Throttle: throttles.media,
+		}
+	}
+
+	// tor mode never calls out to a third party on behalf of the user
+	if f.Tor {
+		f.Local = nil
+		f.News = nil
+		f.Scholar = nil
+		f.Shopping = nil
+		f.Videos = nil
+		in.NutritionFetcher = nil
+	}
+
+	f.Instant = &in
+
+	if err := toggle(v); err != nil {
+		return err
+	}
+
+	return logging(v)
+}
+
+// threats loads our mirrors of the malware and phishing blocklists. Without any there is no feed.
+func threats(v *viper.Viper) (*threat.Feed, error) {
+	var feed *threat.Feed
+	for _, kind := range []threat.Kind{threat.Malware, threat.Phishing} {
+		files := v.GetStringSlice("threat." + string(kind))
+		if len(files) == 0 {
+			continue
+		}
+
+		rs := []io.Reader{}
+		for _, name := range files {
+			file, err := os.Open(name)
+			if err != nil {
+				return nil, err
+			}
+			defer file.Close()
+
+			rs = append(rs, file)
+		}
+
+		if feed == nil {
+			feed = threat.New()
+		}
+
+		if err := feed.Load(kind, rs...); err != nil {
+			return nil, err
+		}
+	}
+
+	return feed, nil
+}
+
+// logging sets the format and level of our logs, e.g. JIVESEARCH_LOG_LEVEL=debug.
+// Debug mode always logs at the debug level.
+func logging(v *viper.Viper) error {
+	format, err := log.ParseFormat(v.GetString("log.format"))
+	if err != nil {
+		return err
+	}
+
+	level, err := log.ParseLevel(v.GetString("log.level"))
+	if err != nil {
+		return err
+	}
+
+	if v.GetBool("debug") {
+		level = log.DebugLevel
+	}
+
+	log.SetFormat(format)
+	log.SetLevel(level)
+	return nil
+}
+
+// toggle turns on every instant answer except the ones disabled in our config
+// and sets how long their solutions are cached.
+// Answers that call a third party are off in tor mode.
+// Answers switched on or off through /admin/instant are reset.
+func toggle(v *viper.Viper) error {
+	disabled := map[string]bool{}
+	for _, name := range v.GetStringSlice("instant.disabled") {
+		disabled[name] = true
+	}
+
+	// songwriters need a local musicbrainz database
+	if v.GetBool("debug") {
+		disabled["songwriter"] = true
+	}
+
+	regs := instant.Registrations()
+
+	if v.GetBool("tor.mode") {
+		for _, r := range regs {
+			if r.External {
+				disabled[r.Name] = true
+			}
+		}
+	}
+
+	known := map[string]bool{}
+	for _, r := range regs {
+		known[r.Name] = true
+	}
+
+	for name := range disabled {
+		if !known[name] {
+			return fmt.Errorf("unknown instant answer %q", name)
+		}
+	}
+
+	for _, r := range regs {
+		if err := instant.SetTTL(r.Name, v.GetDuration("instant.ttl."+r.Name)); err != nil {
+			return err
+		}
+
+		var err error
+		switch disabled[r.Name] {
+		case true:
+			err = instant.Disable(r.Name)
+		default:
+			err = instant.Enable(r.Name)
+		}
+
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
 }
 
 func esClient(v *viper.Viper, client *elastic.Client) *elastic.Client {
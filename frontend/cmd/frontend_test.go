@@ -1,11 +1,21 @@
 package main
 
 import (
+	"net/http"
 	"reflect"
 	"testing"
+	"time"
 
+	"github.com/jivesearch/jivesearch/config"
 	"github.com/jivesearch/jivesearch/frontend"
+	"github.com/jivesearch/jivesearch/instant"
+	"github.com/jivesearch/jivesearch/instant/media"
+	"github.com/jivesearch/jivesearch/instant/repo"
 	"github.com/jivesearch/jivesearch/instant/wikipedia"
+	"github.com/jivesearch/jivesearch/log"
+	"github.com/jivesearch/jivesearch/search"
+	img "github.com/jivesearch/jivesearch/search/image"
+	"github.com/jivesearch/jivesearch/search/provider"
 	"github.com/spf13/viper"
 	"golang.org/x/text/language"
 )
@@ -72,3 +82,226 @@ func TestLanguages(t *testing.T) {
 		})
 	}
 }
+
+func TestConfigure(t *testing.T) {
+	v := viper.New()
+	config.SetDefaults(v)
+	v.Set("brand.name", "My Search")
+	v.Set("cache.search", "1m")
+	v.Set("search.provider", "yandex")
+	v.Set("yandex.key", "abc")
+	v.Set("tmdb.key", "def")
+	v.Set("images.provider", "pixabay") // our own index needs Elasticsearch
+
+	es := &search.Relaxer{}
+	old := &frontend.Frontend{}
+	old.Experiments.Searchers = map[string]search.Fetcher{"elasticsearch": es}
+	old.Instant = &instant.Instant{QueryVar: "q"}
+
+	nf := *old
+	if err := configure(&nf, v, http.DefaultClient); err != nil {
+		t.Fatal(err)
+	}
+
+	if nf.Brand.Name != "My Search" || nf.Cache.Search != time.Minute {
+		t.Fatalf("got brand %q and search cache %v", nf.Brand.Name, nf.Cache.Search)
+	}
+
+	if y := nf.Search.(*search.Relaxer).Fetcher.(*provider.Yandex); y.Key != "abc" {
+		t.Fatalf("got yandex key %q; want %q", y.Key, "abc")
+	}
+
+	if m := nf.Instant.MediaFetcher.(*media.Limited); m.Fetcher.(*media.TMDB).Key != "def" || m.Throttle != throttles.media {
+		t.Fatalf("got media fetcher %+v", m)
+	}
+
+	github, gitlab := nf.Instant.GitHubFetcher.(*repo.Limited), nf.Instant.GitLabFetcher.(*repo.Limited)
+	if github.Throttle != throttles.github || gitlab.Throttle != throttles.gitlab || github.Throttle == gitlab.Throttle {
+		t.Fatalf("got github throttle %p and gitlab throttle %p", github.Throttle, gitlab.Throttle)
+	}
+
+	if nf.Instant.QueryVar != "q" || nf.Experiments.Searchers["elasticsearch"] != es {
+		t.Fatal("expected the settings that can't be reloaded to be kept")
+	}
+
+	// the running frontend is untouched
+	if old.Search != nil || len(old.Experiments.Searchers) != 1 || old.Instant.MediaFetcher != nil {
+		t.Fatalf("the old frontend was changed: %+v", old)
+	}
+
+	// a reload switches back to our own index and keeps the throttles
+	media := throttles.media
+	v.Set("search.provider", "elasticsearch")
+	if err := configure(&nf, v, http.DefaultClient); err != nil {
+		t.Fatal(err)
+	}
+
+	if nf.Search != es || throttles.media != media {
+		t.Fatalf("got search %+v", nf.Search)
+	}
+
+	if nf.Instant.GitHubFetcher.(*repo.Limited).Throttle != github.Throttle || nf.Instant.GitLabFetcher.(*repo.Limited).Throttle != gitlab.Throttle {
+		t.Fatal("expected the github and gitlab throttles to outlive a reload")
+	}
+}
+
+func TestToggle(t *testing.T) {
+	defer instant.Enable("coin")
+
+	v := viper.New()
+	v.Set("instant.disabled", []string{"coin"})
+
+	if err := toggle(v); err != nil {
+		t.Fatal(err)
+	}
+
+	if !disabled("coin") {
+		t.Fatal("expected coin to be disabled")
+	}
+
+	v.Set("instant.disabled", []string{"coin", "nope"})
+	if err := toggle(v); err == nil {
+		t.Fatal("expected an error for an unknown answer")
+	}
+
+	v.Set("instant.disabled", []string{})
+	if err := toggle(v); err != nil {
+		t.Fatal(err)
+	}
+
+	if disabled("coin") {
+		t.Fatal("expected coin to be enabled again")
+	}
+}
+
+func TestTorMode(t *testing.T) {
+	defer toggle(viper.New())
+
+	v := viper.New()
+	config.SetDefaults(v)
+	v.Set("tor.mode", true)
+	v.Set("images.provider", "pixabay")
+
+	f := &frontend.Frontend{Instant: &instant.Instant{}}
+	if err := configure(f, v, http.DefaultClient); err == nil {
+		t.Fatal("expected an error for a third-party images provider")
+	}
+
+	v.Set("images.provider", "elasticsearch")
+	f.Images.Fetcher = &img.ElasticSearch{}
+	if err := configure(f, v, http.DefaultClient); err != nil {
+		t.Fatal(err)
+	}
+
+	if !f.Tor || f.Local != nil || f.News != nil || f.Scholar != nil || f.Shopping != nil || f.Videos != nil || f.Instant.NutritionFetcher != nil {
+		t.Fatalf("got a frontend that fetches from third parties %+v", f)
+	}
+
+	if !disabled("weather") || disabled("coin") {
+		t.Fatal("expected only answers that call a third party to be disabled")
+	}
+}
+
+func disabled(name string) bool {
+	for _, r := range instant.Registrations() {
+		if r.Name == name {
+			return !r.Enabled
+		}
+	}
+	return false
+}
+
+func TestHMACKeys(t *testing.T) {
+	for _, c := range []struct {
+		name  string
+		keys  string
+		valid bool
+	}{
+		{"none", "", true},
+		{"rotated", `[{"id": "2019a", "secret": "abc"}, {"id": "2019b", "secret": "def", "not_before": "2019-06-01T00:00:00Z"}]`, true},
+		{"no id", `[{"secret": "abc"}]`, false},
+		{"id with a dot", `[{"id": "2019.a", "secret": "abc"}]`, false},
+		{"no secret", `[{"id": "2019a"}]`, false},
+		{"not json", `2019a`, false},
+	} {
+		t.Run(c.name, func(t *testing.T) {
+			v := viper.New()
+			config.SetDefaults(v)
+			v.Set("hmac.keys", c.keys)
+
+			f := &frontend.Frontend{Instant: &instant.Instant{}}
+			f.Images.Fetcher = &img.ElasticSearch{}
+			err := configure(f, v, http.DefaultClient)
+			if (err == nil) != c.valid {
+				t.Fatalf("got error %v; want valid %v", err, c.valid)
+			}
+
+			if c.valid && f.HMAC.Grace != 30*24*time.Hour {
+				t.Fatalf("got grace %v", f.HMAC.Grace)
+			}
+		})
+	}
+}
+
+func TestTenants(t *testing.T) {
+	for _, c := range []struct {
+		name    string
+		tenants string
+		valid   bool
+	}{
+		{"none", "", true},
+		{"branded", `{"search.example.org": {"name": "Example Search", "small_logo": "<svg></svg>", "bangs": ["g"], "verticals": ["images"], "ranking": "yandex"}}`, true},
+		{"unknown ranking", `{"search.example.org": {"ranking": "google"}}`, false},
+		{"unknown vertical", `{"search.example.org": {"verticals": ["news"]}}`, false},
+		{"port", `{"search.example.org:8000": {"name": "Example Search"}}`, false},
+		{"not json", `search.example.org`, false},
+	} {
+		t.Run(c.name, func(t *testing.T) {
+			v := viper.New()
+			config.SetDefaults(v)
+			v.Set("tenants", c.tenants)
+
+			f := &frontend.Frontend{Instant: &instant.Instant{}}
+			f.Images.Fetcher = &img.ElasticSearch{}
+			err := configure(f, v, http.DefaultClient)
+			if (err == nil) != c.valid {
+				t.Fatalf("got error %v; want valid %v", err, c.valid)
+			}
+
+			if c.name == "branded" && f.Tenants["search.example.org"].SmallLogo != "<svg></svg>" {
+				t.Fatalf("got tenants %+v", f.Tenants)
+			}
+		})
+	}
+}
+
+func TestLogging(t *testing.T) {
+	defer log.SetLevel(log.InfoLevel)
+
+	for _, c := range []struct {
+		name   string
+		format string
+		level  string
+		debug  bool
+		err    bool
+	}{
+		{"default", "text", "info", false, false},
+		{"json", "json", "debug", false, false},
+		{"debug mode", "text", "info", true, false},
+		{"unknown format", "xml", "info", false, true},
+		{"unknown level", "text", "verbose", false, true},
+	} {
+		t.Run(c.name, func(t *testing.T) {
+			defer log.SetFormat(log.Text)
+
+			v := viper.New()
+			v.Set("log.format", c.format)
+			v.Set("log.level", c.level)
+			v.Set("debug", c.debug)
+
+			if err := logging(v); (err != nil) != c.err {
+				t.Fatalf("got err %v; want an error %v", err, c.err)
+			}
+		})
+	}
+}
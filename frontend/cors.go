@@ -0,0 +1,81 @@
+package frontend
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// CORS lets browsers call our JSON API from other sites. It is off without any Origins.
+type CORS struct {
+	Origins     []string      // e.g. https://example.com. "*" allows any origin.
+	Methods     []string      // those allowed by a preflight
+	Headers     []string      // the request headers a client may send, e.g. X-API-Key
+	MaxAge      time.Duration // how long a browser may cache a preflight
+	Credentials bool          // let browsers send cookies along
+}
+
+// allowed is true if a page from the origin can call our API
+func (c CORS) allowed(origin string) bool {
+	for _, o := range c.Origins {
+		if o == "*" || strings.EqualFold(o, origin) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// cors sets the CORS headers of API requests from allowed origins and answers their preflights.
+// Our own pages are same-origin so don't need them.
+func (f *Frontend) cors(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		c := f.CORS
+		origin := r.Header.Get("Origin")
+
+		if origin == "" || !isAPIRequest(r) || !c.allowed(origin) {
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		h := w.Header()
+		h.Add("Vary", "Origin")
+
+		// a wildcard can't be used with credentials
+		if c.allowed("*") && !c.Credentials {
+			h.Set("Access-Control-Allow-Origin", "*")
+		} else {
+			h.Set("Access-Control-Allow-Origin", origin)
+		}
+
+		if c.Credentials {
+			h.Set("Access-Control-Allow-Credentials", "true")
+		}
+
+		if r.Method != http.MethodOptions || r.Header.Get("Access-Control-Request-Method") == "" {
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		if len(c.Methods) > 0 {
+			h.Set("Access-Control-Allow-Methods", strings.Join(c.Methods, ", "))
+		}
+
+		if len(c.Headers) > 0 {
+			h.Set("Access-Control-Allow-Headers", strings.Join(c.Headers, ", "))
+		}
+
+		if c.MaxAge > 0 {
+			h.Set("Access-Control-Max-Age", strconv.Itoa(int(c.MaxAge.Seconds())))
+		}
+
+		w.WriteHeader(http.StatusNoContent)
+	})
+}
+
+// preflightHandler answers the preflights that cors doesn't, i.e. from origins that aren't allowed.
+// Without the CORS headers the browser won't make the request.
+func preflightHandler(w http.ResponseWriter, r *http.Request) {
+	w.WriteHeader(http.StatusNoContent)
+}
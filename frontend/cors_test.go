@@ -0,0 +1,100 @@
+package frontend
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestCORS(t *testing.T) {
+	specific := CORS{
+		Origins:     []string{"https://example.com"},
+		Methods:     []string{"GET", "POST"},
+		Headers:     []string{"Content-Type", "X-API-Key"},
+		MaxAge:      10 * time.Minute,
+		Credentials: true,
+	}
+
+	for _, c := range []struct {
+		name   string
+		cors   CORS
+		method string
+		u      string
+		origin string
+		status int
+		want   map[string]string
+	}{
+		{"off", CORS{}, "GET", "/api/v1/images?q=cats", "https://example.com", http.StatusOK, map[string]string{}},
+		{"same origin", specific, "GET", "/api/v1/images?q=cats", "", http.StatusOK, map[string]string{}},
+		{"not the api", specific, "GET", "/?q=cats", "https://example.com", http.StatusOK, map[string]string{}},
+		{"not allowed", specific, "GET", "/api/v1/images?q=cats", "https://other.com", http.StatusOK, map[string]string{}},
+		{
+			"allowed", specific, "GET", "/?q=cats&o=json", "https://example.com", http.StatusOK,
+			map[string]string{
+				"Access-Control-Allow-Origin":      "https://example.com",
+				"Access-Control-Allow-Credentials": "true",
+				"Vary":                             "Origin",
+			},
+		},
+		{
+			"any", CORS{Origins: []string{"*"}}, "GET", "/api/v1/images?q=cats", "https://other.com", http.StatusOK,
+			map[string]string{
+				"Access-Control-Allow-Origin": "*",
+				"Vary":                        "Origin",
+			},
+		},
+		{
+			"any with credentials", CORS{Origins: []string{"*"}, Credentials: true}, "GET", "/api/v1/images?q=cats", "https://other.com", http.StatusOK,
+			map[string]string{
+				"Access-Control-Allow-Origin":      "https://other.com",
+				"Access-Control-Allow-Credentials": "true",
+				"Vary":                             "Origin",
+			},
+		},
+		{
+			"preflight", specific, "OPTIONS", "/api/v1/images?q=cats", "https://example.com", http.StatusNoContent,
+			map[string]string{
+				"Access-Control-Allow-Origin":      "https://example.com",
+				"Access-Control-Allow-Credentials": "true",
+				"Access-Control-Allow-Methods":     "GET, POST",
+				"Access-Control-Allow-Headers":     "Content-Type, X-API-Key",
+				"Access-Control-Max-Age":           "600",
+				"Vary":                             "Origin",
+			},
+		},
+	} {
+		t.Run(c.name, func(t *testing.T) {
+			f := &Frontend{CORS: c.cors}
+
+			next := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(http.StatusOK)
+			})
+
+			r := httptest.NewRequest(c.method, c.u, nil)
+			if c.origin != "" {
+				r.Header.Set("Origin", c.origin)
+			}
+			if c.method == "OPTIONS" {
+				r.Header.Set("Access-Control-Request-Method", "GET")
+			}
+
+			w := httptest.NewRecorder()
+			f.cors(next).ServeHTTP(w, r)
+
+			if w.Code != c.status {
+				t.Fatalf("got status %d; want %d", w.Code, c.status)
+			}
+
+			got := map[string]string{}
+			for k := range w.Header() {
+				got[k] = w.Header().Get(k)
+			}
+
+			if !reflect.DeepEqual(got, c.want) {
+				t.Fatalf("got %+v; want %+v", got, c.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,13 @@
+package frontend
+
+import (
+	"net/http"
+)
+
+// doNotTrack is true if the browser sends Do Not Track or Global Privacy Control.
+// We honor either by not logging the query, not adding it to autocomplete,
+// not counting clicks and not setting cookies.
+// https://www.w3.org/TR/tracking-dnt/ https://globalprivacycontrol.github.io/gpc-spec/
+func doNotTrack(r *http.Request) bool {
+	return r.Header.Get("DNT") == "1" || r.Header.Get("Sec-GPC") == "1"
+}
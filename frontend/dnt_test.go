@@ -0,0 +1,31 @@
+package frontend
+
+import (
+	"net/http/httptest"
+	"testing"
+)
+
+func TestDoNotTrack(t *testing.T) {
+	for _, c := range []struct {
+		name   string
+		header string
+		value  string
+		want   bool
+	}{
+		{"none", "", "", false},
+		{"dnt", "DNT", "1", true},
+		{"dnt off", "DNT", "0", false},
+		{"gpc", "Sec-GPC", "1", true},
+	} {
+		t.Run(c.name, func(t *testing.T) {
+			req := httptest.NewRequest("GET", "/?q=jive", nil)
+			if c.header != "" {
+				req.Header.Set(c.header, c.value)
+			}
+
+			if got := doNotTrack(req); got != c.want {
+				t.Fatalf("got %v; want %v", got, c.want)
+			}
+		})
+	}
+}
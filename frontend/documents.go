@@ -0,0 +1,224 @@
+package frontend
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/jivesearch/jivesearch/search/document"
+	"golang.org/x/text/language"
+)
+
+const (
+	maxIngestLine        = 1 << 20 // the longest line of a bulk request
+	maxIngestTitle       = 512
+	maxIngestDescription = 10000
+	ingestBatch          = 100 // documents per request to our index
+)
+
+var (
+	errIngestDisabled = fmt.Errorf("document ingestion is disabled")
+	errIngestTitle    = fmt.Errorf("missing title")
+)
+
+// Ingest pushes pre-crawled or internal documents, such as intranet pages
+// and docs sites, straight into our index
+type Ingest struct {
+	Indexer      // optional
+	MaxBytes     int64
+	MaxDocuments int // per request
+	Batch        int // documents we send our index at once
+}
+
+// Indexer adds documents to our index, replacing any with the same url.
+// errs[i] is why docs[i] wasn't indexed. err is why none were, e.g. ctx is done.
+type Indexer interface {
+	BulkUpsert(ctx context.Context, docs []*document.Document) (errs []error, err error)
+}
+
+// Ingested is how many documents of a bulk request were indexed and why the others weren't
+type Ingested struct {
+	Indexed int           `json:"indexed"`
+	Errors  []IngestError `json:"errors,omitempty"`
+}
+
+// IngestError is why a line of a bulk request was rejected
+type IngestError struct {
+	Line  int    `json:"line"`
+	URL   string `json:"url,omitempty"`
+	Error string `json:"error"`
+}
+
+// ingestDocument is a line of a bulk request.
+// The language picks the index & analyzer and defaults to our first supported language.
+type ingestDocument struct {
+	URL         string `json:"url"`
+	Title       string `json:"title"`
+	Description string `json:"description"`
+	Keywords    string `json:"keywords"`
+	Language    string `json:"language"`
+	Date        string `json:"date"` // e.g. 2019-03-27 or 2019-03-27T15:04:05Z
+}
+
+// documentsHandler indexes the newline-delimited JSON documents in the body of a POST. A bad line
+// doesn't reject the others: each is listed in the response with its line number and why.
+// We index in batches and stop once the request times out, listing the lines we didn't get to.
+// e.g. curl -H "Authorization: Bearer $TOKEN" -H "Content-Type: application/x-ndjson" --data-binary @docs.ndjson /api/v1/documents
+func (f *Frontend) documentsHandler(w http.ResponseWriter, r *http.Request) *response {
+	resp := &response{
+		status:   http.StatusOK,
+		template: "json",
+	}
+
+	if !f.isAdmin(r) {
+		resp.status = http.StatusForbidden
+		resp.err = fmt.Errorf("invalid admin token from %v", r.RemoteAddr)
+		return resp
+	}
+
+	if f.Ingest.Indexer == nil {
+		resp.status, resp.template, resp.err = http.StatusNotFound, "", errIngestDisabled
+		return resp
+	}
+
+	if f.Ingest.MaxBytes > 0 {
+		r.Body = http.MaxBytesReader(w, r.Body, f.Ingest.MaxBytes)
+	}
+
+	type valid struct {
+		line int
+		doc  *document.Document
+	}
+
+	ingested := &Ingested{}
+	docs := []valid{}
+	n := 0
+
+	scanner := bufio.NewScanner(r.Body)
+	scanner.Buffer(make([]byte, 64*1024), maxIngestLine)
+
+	for line := 1; scanner.Scan(); line++ {
+		b := scanner.Bytes()
+		if strings.TrimSpace(string(b)) == "" {
+			continue
+		}
+
+		if n++; f.Ingest.MaxDocuments > 0 && n > f.Ingest.MaxDocuments {
+			resp.status = http.StatusRequestEntityTooLarge
+			resp.err = fmt.Errorf("more than %d documents", f.Ingest.MaxDocuments)
+			return resp
+		}
+
+		in := &ingestDocument{}
+		if err := json.Unmarshal(b, in); err != nil {
+			ingested.Errors = append(ingested.Errors, IngestError{Line: line, Error: err.Error()})
+			continue
+		}
+
+		doc, err := f.ingestDocument(in)
+		if err != nil {
+			ingested.Errors = append(ingested.Errors, IngestError{Line: line, URL: in.URL, Error: err.Error()})
+			continue
+		}
+
+		docs = append(docs, valid{line, doc})
+	}
+
+	if err := scanner.Err(); err != nil {
+		resp.status, resp.err = http.StatusBadRequest, err
+		if err == bufio.ErrTooLong || strings.Contains(err.Error(), "request body too large") {
+			resp.status = http.StatusRequestEntityTooLarge
+		}
+		return resp
+	}
+
+	batch := f.Ingest.Batch
+	if batch <= 0 {
+		batch = ingestBatch
+	}
+
+	for len(docs) > 0 {
+		size := batch
+		if size > len(docs) {
+			size = len(docs)
+		}
+
+		b := make([]*document.Document, size)
+		for i, v := range docs[:size] {
+			b[i] = v.doc
+		}
+
+		var errs []error
+		err := r.Context().Err() // we're out of time for the rest
+		if err == nil {
+			errs, err = f.Ingest.BulkUpsert(r.Context(), b)
+		}
+
+		for i, v := range docs[:size] {
+			switch {
+			case err != nil:
+				ingested.Errors = append(ingested.Errors, IngestError{Line: v.line, URL: v.doc.ID, Error: err.Error()})
+			case errs[i] != nil:
+				ingested.Errors = append(ingested.Errors, IngestError{Line: v.line, URL: v.doc.ID, Error: errs[i].Error()})
+			default:
+				ingested.Indexed++
+			}
+		}
+
+		docs = docs[size:]
+	}
+
+	resp.data = ingested
+	return resp
+}
+
+// ingestDocument validates a line of a bulk request and makes it a document like one we crawled
+func (f *Frontend) ingestDocument(in *ingestDocument) (*document.Document, error) {
+	doc, err := document.New(in.URL)
+	if err != nil {
+		return nil, fmt.Errorf("invalid url: %v", err)
+	}
+
+	in.Title = strings.TrimSpace(in.Title)
+	switch {
+	case in.Title == "":
+		return nil, errIngestTitle
+	case len(in.Title) > maxIngestTitle:
+		return nil, fmt.Errorf("title is longer than %d bytes", maxIngestTitle)
+	case len(in.Description) > maxIngestDescription:
+		return nil, fmt.Errorf("description is longer than %d bytes", maxIngestDescription)
+	}
+
+	doc.Language = language.English
+	if len(f.Document.Languages) > 0 {
+		doc.Language = f.Document.Languages[0]
+	}
+
+	if in.Language != "" {
+		if doc.Language, err = language.Parse(in.Language); err != nil {
+			return nil, fmt.Errorf("invalid language: %v", err)
+		}
+	}
+
+	if in.Date != "" {
+		if _, err := time.Parse(time.RFC3339, in.Date); err != nil {
+			if _, err := time.Parse("2006-01-02", in.Date); err != nil {
+				return nil, fmt.Errorf("invalid date %q", in.Date)
+			}
+		}
+		doc.Date = in.Date
+	}
+
+	doc.SetStatusCode(http.StatusOK).SetCrawled(now()).SetLang()
+	doc.Title = in.Title
+	doc.Description = strings.TrimSpace(in.Description)
+	doc.Keywords = strings.TrimSpace(in.Keywords)
+	doc.Canonical = true
+	doc.Index = true
+
+	return doc, nil
+}
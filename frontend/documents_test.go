@@ -0,0 +1,183 @@
+package frontend
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/jivesearch/jivesearch/search/document"
+	"golang.org/x/text/language"
+)
+
+type mockIndexer struct {
+	docs    []*document.Document
+	batches []int
+	cancel  context.CancelFunc // e.g. the request times out after our first batch
+}
+
+func (m *mockIndexer) BulkUpsert(ctx context.Context, docs []*document.Document) ([]error, error) {
+	m.batches = append(m.batches, len(docs))
+
+	errs := make([]error, len(docs))
+	for i, doc := range docs {
+		if doc.Host == "unindexable.example.com" {
+			errs[i] = fmt.Errorf("analyzer should not be blank")
+			continue
+		}
+
+		m.docs = append(m.docs, doc)
+	}
+
+	if m.cancel != nil {
+		m.cancel()
+	}
+
+	return errs, nil
+}
+
+func TestDocumentsHandler(t *testing.T) {
+	now = func() time.Time {
+		return time.Date(2019, 03, 27, 0, 0, 0, 0, time.UTC)
+	}
+
+	body := strings.Join([]string{
+		`{"url":"https://intranet.example.com/handbook#vacation","title":" Employee Handbook ","description":"Our policies.","language":"de","date":"2019-03-01"}`,
+		``,
+		`{"url":"ftp://example.com/file","title":"FTP"}`,
+		`{"url":"https://example.com/no-title"}`,
+		`not json`,
+		`{"url":"https://example.com/bad-date","title":"Bad Date","date":"March 1st"}`,
+		`{"url":"https://unindexable.example.com/","title":"Unindexable"}`,
+		`{"url":"https://docs.example.com/api","title":"API","keywords":"rest, json"}`,
+	}, "\n")
+
+	idx := &mockIndexer{}
+	f := &Frontend{AdminToken: "secret"}
+	f.Document.Languages = []language.Tag{language.French}
+
+	for _, c := range []struct {
+		name   string
+		token  string
+		ingest Ingest
+		body   string
+		status int
+	}{
+		{"forbidden", "wrong", Ingest{Indexer: idx}, body, http.StatusForbidden},
+		{"disabled", "secret", Ingest{}, body, http.StatusNotFound},
+		{"too many", "secret", Ingest{Indexer: idx, MaxDocuments: 2}, body, http.StatusRequestEntityTooLarge},
+		{"too big", "secret", Ingest{Indexer: idx, MaxBytes: 100}, body, http.StatusRequestEntityTooLarge},
+		{"ok", "secret", Ingest{Indexer: idx, MaxBytes: 1 << 20, MaxDocuments: 10}, body, http.StatusOK},
+	} {
+		t.Run(c.name, func(t *testing.T) {
+			f.Ingest = c.ingest
+
+			req := httptest.NewRequest("POST", "/api/v1/documents", strings.NewReader(c.body))
+			req.Header.Set("Authorization", "Bearer "+c.token)
+			req.Header.Set("Content-Type", "application/x-ndjson")
+
+			rsp := f.documentsHandler(httptest.NewRecorder(), req)
+			if rsp.status != c.status {
+				t.Fatalf("got status %d; want %d (%v)", rsp.status, c.status, rsp.err)
+			}
+		})
+	}
+
+	if len(idx.docs) != 2 {
+		t.Fatalf("got %d documents; want 2", len(idx.docs))
+	}
+
+	handbook := idx.docs[0]
+	if handbook.ID != "https://intranet.example.com/handbook" || handbook.Title != "Employee Handbook" ||
+		handbook.Language != language.German || handbook.Date != "2019-03-01" || handbook.Crawled != "20190327" ||
+		!handbook.Index || handbook.StatusCode != http.StatusOK {
+		t.Fatalf("got %+v", handbook)
+	}
+
+	if api := idx.docs[1]; api.Language != language.French || api.Keywords != "rest, json" || api.Domain != "example.com" {
+		t.Fatalf("got %+v", api)
+	}
+
+	want := []int{3, 4, 5, 6, 7}
+	f.Ingest = Ingest{Indexer: &mockIndexer{}}
+	req := httptest.NewRequest("POST", "/api/v1/documents", strings.NewReader(body))
+	req.Header.Set("Authorization", "Bearer secret")
+
+	ingested := f.documentsHandler(httptest.NewRecorder(), req).data.(*Ingested)
+	if ingested.Indexed != 2 {
+		t.Fatalf("got %d indexed; want 2", ingested.Indexed)
+	}
+
+	got := []int{}
+	for _, e := range ingested.Errors {
+		got = append(got, e.Line)
+	}
+
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("got errors on lines %v; want %v (%+v)", got, want, ingested.Errors)
+	}
+}
+
+func TestDocumentsHandlerBatches(t *testing.T) {
+	lines := []string{}
+	for i := 1; i <= 5; i++ {
+		lines = append(lines, fmt.Sprintf(`{"url":"https://example.com/%d","title":"Page %d"}`, i, i))
+	}
+	body := strings.Join(lines, "\n")
+
+	for _, c := range []struct {
+		name    string
+		cancel  bool
+		batches []int
+		indexed int
+		errors  []int
+	}{
+		{"batches", false, []int{2, 2, 1}, 5, []int{}},
+		{"timed out", true, []int{2}, 2, []int{3, 4, 5}},
+	} {
+		t.Run(c.name, func(t *testing.T) {
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+
+			idx := &mockIndexer{}
+			if c.cancel {
+				idx.cancel = cancel
+			}
+
+			f := &Frontend{AdminToken: "secret", Ingest: Ingest{Indexer: idx, Batch: 2}}
+
+			req := httptest.NewRequest("POST", "/api/v1/documents", strings.NewReader(body)).WithContext(ctx)
+			req.Header.Set("Authorization", "Bearer secret")
+
+			rsp := f.documentsHandler(httptest.NewRecorder(), req)
+			if rsp.status != http.StatusOK {
+				t.Fatalf("got status %d; want %d (%v)", rsp.status, http.StatusOK, rsp.err)
+			}
+
+			if !reflect.DeepEqual(idx.batches, c.batches) {
+				t.Fatalf("got batches %v; want %v", idx.batches, c.batches)
+			}
+
+			ingested := rsp.data.(*Ingested)
+			if ingested.Indexed != c.indexed {
+				t.Fatalf("got %d indexed; want %d", ingested.Indexed, c.indexed)
+			}
+
+			got := []int{}
+			for _, e := range ingested.Errors {
+				if e.Error != context.Canceled.Error() {
+					t.Fatalf("got error %q; want %q", e.Error, context.Canceled)
+				}
+				got = append(got, e.Line)
+			}
+
+			if !reflect.DeepEqual(got, c.errors) {
+				t.Fatalf("got errors on lines %v; want %v", got, c.errors)
+			}
+		})
+	}
+}
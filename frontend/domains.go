@@ -0,0 +1,68 @@
+package frontend
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/jivesearch/jivesearch/search"
+)
+
+// adminDomainsHandler lists the instance's domain preferences, sets one for a POST and removes one for a DELETE.
+// The preference is "ban", "sink" or "pin" and a domain includes its subdomains.
+// e.g. curl -H "Authorization: Bearer $TOKEN" -d "domain=example.com&preference=ban" /admin/domains
+// or curl -X DELETE -H "Authorization: Bearer $TOKEN" "/admin/domains?domain=example.com"
+func (f *Frontend) adminDomainsHandler(w http.ResponseWriter, r *http.Request) *response {
+	resp := &response{
+		status:   http.StatusOK,
+		template: "json",
+	}
+
+	if !f.isAdmin(r) {
+		resp.status = http.StatusForbidden
+		resp.err = fmt.Errorf("invalid admin token from %v", r.RemoteAddr)
+		return resp
+	}
+
+	if f.Domains == nil {
+		resp.status = http.StatusInternalServerError
+		resp.err = fmt.Errorf("no domain preferences store")
+		return resp
+	}
+
+	switch r.Method {
+	case http.MethodPost, http.MethodDelete:
+		domain := search.NormalizeDomain(r.FormValue("domain"))
+		if domain == "" {
+			resp.status, resp.err = http.StatusBadRequest, fmt.Errorf("missing domain")
+			return resp
+		}
+
+		if r.Method == http.MethodDelete {
+			if err := f.Domains.Delete(domain); err != nil {
+				resp.status, resp.err = http.StatusInternalServerError, err
+				return resp
+			}
+			break
+		}
+
+		p, err := search.ParsePreference(r.FormValue("preference"))
+		if err != nil {
+			resp.status, resp.err = http.StatusBadRequest, err
+			return resp
+		}
+
+		if err := f.Domains.Set(domain, p); err != nil {
+			resp.status, resp.err = http.StatusInternalServerError, err
+			return resp
+		}
+	}
+
+	prefs, err := f.Domains.List()
+	if err != nil {
+		resp.status, resp.err = http.StatusInternalServerError, err
+		return resp
+	}
+
+	resp.data = prefs
+	return resp
+}
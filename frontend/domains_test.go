@@ -0,0 +1,80 @@
+package frontend
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/jivesearch/jivesearch/search"
+	"github.com/jivesearch/jivesearch/search/domains"
+)
+
+func TestAdminDomainsHandler(t *testing.T) {
+	store := &domains.Simple{}
+	if err := store.Setup(); err != nil {
+		t.Fatal(err)
+	}
+
+	f := &Frontend{
+		AdminToken: "secret",
+		Domains:    store,
+	}
+
+	for _, c := range []struct {
+		name   string
+		token  string
+		method string
+		form   url.Values
+		status int
+		want   search.Preferences
+	}{
+		{"wrong token", "wrong", "GET", nil, http.StatusForbidden, nil},
+		{"ban", "secret", "POST", url.Values{"domain": {"*.Example.com"}, "preference": {"ban"}}, http.StatusOK, search.Preferences{"example.com": search.Ban}},
+		{"pin", "secret", "POST", url.Values{"domain": {"example.org"}, "preference": {"pin"}}, http.StatusOK, search.Preferences{"example.com": search.Ban, "example.org": search.Pin}},
+		{"bad preference", "secret", "POST", url.Values{"domain": {"example.org"}, "preference": {"hide"}}, http.StatusBadRequest, nil},
+		{"missing domain", "secret", "POST", url.Values{"preference": {"sink"}}, http.StatusBadRequest, nil},
+		{"delete", "secret", "DELETE", url.Values{"domain": {"example.com"}}, http.StatusOK, search.Preferences{"example.org": search.Pin}},
+		{"list", "secret", "GET", nil, http.StatusOK, search.Preferences{"example.org": search.Pin}},
+	} {
+		t.Run(c.name, func(t *testing.T) {
+			req := httptest.NewRequest(c.method, "/admin/domains", strings.NewReader(c.form.Encode()))
+			req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+			if c.method == "DELETE" { // the body of a DELETE isn't parsed
+				req = httptest.NewRequest(c.method, "/admin/domains?"+c.form.Encode(), nil)
+			}
+			req.Header.Set("Authorization", "Bearer "+c.token)
+
+			rsp := f.adminDomainsHandler(httptest.NewRecorder(), req)
+			if rsp.status != c.status {
+				t.Fatalf("got status %d; want %d (%v)", rsp.status, c.status, rsp.err)
+			}
+
+			if c.status != http.StatusOK {
+				return
+			}
+
+			if got := rsp.data.(search.Preferences); !reflect.DeepEqual(got, c.want) {
+				t.Fatalf("got %+v; want %+v", got, c.want)
+			}
+		})
+	}
+}
+
+func TestSetPreferences(t *testing.T) {
+	req := httptest.NewRequest("GET", "/?q=jive&ban=spam.com,both.com&sink=content.com,both.com&pin=docs.org", nil)
+
+	got := (&Context{}).setPreferences(req).Preferences
+	want := search.Preferences{
+		"spam.com":    search.Ban,
+		"both.com":    search.Ban,
+		"content.com": search.Sink,
+		"docs.org":    search.Pin,
+	}
+
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("got %+v; want %+v", got, want)
+	}
+}
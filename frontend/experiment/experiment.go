@@ -0,0 +1,120 @@
+// Package experiment buckets users into the variants of A/B tests
+package experiment
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"hash/fnv"
+	"sort"
+	"strings"
+)
+
+// Experiment splits users between its variants by weight
+type Experiment struct {
+	Name     string    `json:"name"`
+	Variants []Variant `json:"variants"`
+}
+
+// Variant is one arm of an experiment.
+// Params tweak the ranking or UI, e.g. {"search": "yandex"}.
+type Variant struct {
+	Name   string            `json:"name"`
+	Weight int               `json:"weight"`
+	Params map[string]string `json:"params"`
+}
+
+// Assign picks a variant for a user. The same id always gets the same variant.
+// Each experiment hashes the id with its own name so the buckets of different experiments are independent.
+func (e Experiment) Assign(id string) (Variant, bool) {
+	total := 0
+	for _, v := range e.Variants {
+		if v.Weight > 0 {
+			total += v.Weight
+		}
+	}
+
+	if total == 0 {
+		return Variant{}, false
+	}
+
+	h := fnv.New32a()
+	h.Write([]byte(e.Name + ":" + id))
+	n := int(h.Sum32() % uint32(total))
+
+	for _, v := range e.Variants {
+		if v.Weight <= 0 {
+			continue
+		}
+
+		if n < v.Weight {
+			return v, true
+		}
+		n -= v.Weight
+	}
+
+	return Variant{}, false // unreachable
+}
+
+// Assignments are a user's variants by experiment name
+type Assignments map[string]Variant
+
+// Assign buckets a user into every experiment
+func Assign(experiments []Experiment, id string) Assignments {
+	a := Assignments{}
+	for _, e := range experiments {
+		if v, ok := e.Assign(id); ok {
+			a[e.Name] = v
+		}
+	}
+
+	return a
+}
+
+// Is reports whether the user is in a variant.
+// For conditional rendering: {{if .Context.Experiments.Is "results" "compact"}}
+func (a Assignments) Is(experiment, variant string) bool {
+	v, ok := a[experiment]
+	return ok && v.Name == variant
+}
+
+// Param is the value of a param set by any of the user's variants.
+// If experiments overlap the first one by name wins.
+func (a Assignments) Param(key string) string {
+	for _, name := range a.names() {
+		if p, ok := a[name].Params[key]; ok {
+			return p
+		}
+	}
+
+	return ""
+}
+
+// String stamps the assignments in our logs & metrics, e.g. "ranking:yandex,results:compact"
+func (a Assignments) String() string {
+	s := []string{}
+	for _, name := range a.names() {
+		s = append(s, name+":"+a[name].Name)
+	}
+
+	return strings.Join(s, ",")
+}
+
+func (a Assignments) names() []string {
+	names := []string{}
+	for name := range a {
+		names = append(names, name)
+	}
+
+	sort.Strings(names)
+	return names
+}
+
+// NewID creates an anonymous id for a user. It is random so it tells us nothing about them.
+func NewID() (string, error) {
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+
+	return hex.EncodeToString(b), nil
+}
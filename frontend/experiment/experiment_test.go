@@ -0,0 +1,104 @@
+package experiment
+
+import (
+	"fmt"
+	"math"
+	"testing"
+)
+
+func TestAssign(t *testing.T) {
+	ranking := Experiment{
+		Name: "ranking",
+		Variants: []Variant{
+			{Name: "control", Weight: 75},
+			{Name: "yandex", Weight: 25, Params: map[string]string{"search": "yandex"}},
+			{Name: "paused", Weight: 0},
+		},
+	}
+
+	results := Experiment{
+		Name: "results",
+		Variants: []Variant{
+			{Name: "default", Weight: 1},
+			{Name: "compact", Weight: 1},
+		},
+	}
+
+	disabled := Experiment{
+		Name:     "disabled",
+		Variants: []Variant{{Name: "a"}},
+	}
+
+	counts := map[string]int{}
+	n := 10000
+
+	for i := 0; i < n; i++ {
+		id := fmt.Sprintf("user%d", i)
+
+		a := Assign([]Experiment{ranking, results, disabled}, id)
+		if len(a) != 2 {
+			t.Fatalf("got %d assignments; want 2", len(a))
+		}
+
+		if again := Assign([]Experiment{ranking, results, disabled}, id); again.String() != a.String() {
+			t.Fatalf("got %q then %q; want the same variants", a, again)
+		}
+
+		counts[a.String()]++
+	}
+
+	// each combination should get its share, regardless of the other experiment
+	for k, share := range map[string]float64{
+		"ranking:control,results:default": .375,
+		"ranking:control,results:compact": .375,
+		"ranking:yandex,results:default":  .125,
+		"ranking:yandex,results:compact":  .125,
+	} {
+		got := float64(counts[k]) / float64(n)
+		if math.Abs(got-share) > .02 {
+			t.Fatalf("%v got %v of users; want %v", k, got, share)
+		}
+	}
+}
+
+func TestAssignments(t *testing.T) {
+	a := Assignments{
+		"ranking": {Name: "yandex", Params: map[string]string{"search": "yandex"}},
+		"results": {Name: "compact", Params: map[string]string{"layout": "compact", "search": "ignored"}},
+	}
+
+	if !a.Is("ranking", "yandex") || a.Is("ranking", "control") || a.Is("missing", "yandex") {
+		t.Fatal("wrong variant")
+	}
+
+	for key, want := range map[string]string{"search": "yandex", "layout": "compact", "missing": ""} {
+		if got := a.Param(key); got != want {
+			t.Fatalf("got %q for %q; want %q", got, key, want)
+		}
+	}
+
+	if got, want := a.String(), "ranking:yandex,results:compact"; got != want {
+		t.Fatalf("got %q; want %q", got, want)
+	}
+
+	var none Assignments
+	if none.Is("ranking", "yandex") || none.Param("search") != "" || none.String() != "" {
+		t.Fatal("nil assignments should be empty")
+	}
+}
+
+func TestNewID(t *testing.T) {
+	a, err := NewID()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	b, err := NewID()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(a) != 32 || a == b {
+		t.Fatalf("got %q and %q; want 2 different random ids", a, b)
+	}
+}
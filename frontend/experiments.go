@@ -0,0 +1,78 @@
+package frontend
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/jivesearch/jivesearch/frontend/experiment"
+	"github.com/jivesearch/jivesearch/log"
+	"github.com/jivesearch/jivesearch/search"
+)
+
+// Experiments are our A/B tests of ranking and UI changes
+type Experiments struct {
+	Tests     []experiment.Experiment
+	Searchers map[string]search.Fetcher // ranking variants pick one with the "search" param
+}
+
+const experimentCookie = "ab"
+
+// experimentID makes sure the user has an anonymous id to bucket them by.
+// No cookie is set unless we are running an experiment, nor for browsers that ask not to be tracked.
+func (f *Frontend) experimentID(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if len(f.Experiments.Tests) == 0 || doNotTrack(r) {
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		if _, err := r.Cookie(experimentCookie); err == http.ErrNoCookie {
+			id, err := experiment.NewID()
+			if err != nil {
+				log.Info.Println(err)
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			c := &http.Cookie{
+				Name:     experimentCookie,
+				Value:    id,
+				Path:     "/",
+				Expires:  now().Add(90 * 24 * time.Hour),
+				HttpOnly: true,
+				SameSite: http.SameSiteLaxMode,
+			}
+
+			http.SetCookie(w, c)
+			r.AddCookie(c)
+		}
+
+		next.ServeHTTP(w, r)
+	})
+}
+
+// assign buckets the user into our experiments
+func (f *Frontend) assign(r *http.Request) experiment.Assignments {
+	if len(f.Experiments.Tests) == 0 {
+		return nil
+	}
+
+	c, err := r.Cookie(experimentCookie)
+	if err != nil || c.Value == "" {
+		return nil
+	}
+
+	return experiment.Assign(f.Experiments.Tests, c.Value)
+}
+
+// searcher is the search backend for the user's ranking variant, if any,
+// or else the ranking of the tenant they're searching
+func (f *Frontend) searcher(a experiment.Assignments, ranking string) (string, search.Fetcher) {
+	for _, name := range []string{a.Param("search"), ranking} {
+		if s, ok := f.Experiments.Searchers[name]; ok {
+			return name, s
+		}
+	}
+
+	return "", f.Search
+}
@@ -0,0 +1,101 @@
+package frontend
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/jivesearch/jivesearch/frontend/experiment"
+	"github.com/jivesearch/jivesearch/search"
+)
+
+func TestExperimentID(t *testing.T) {
+	tests := []experiment.Experiment{
+		{
+			Name: "ranking",
+			Variants: []experiment.Variant{
+				{Name: "control", Weight: 1},
+				{Name: "other", Weight: 1},
+			},
+		},
+	}
+
+	for _, c := range []struct {
+		name   string
+		tests  []experiment.Experiment
+		cookie string
+		dnt    bool
+		set    bool
+	}{
+		{"no experiments", nil, "", false, false},
+		{"new user", tests, "", false, true},
+		{"returning user", tests, "abc123", false, false},
+		{"do not track", tests, "", true, false},
+	} {
+		t.Run(c.name, func(t *testing.T) {
+			f := &Frontend{}
+			f.Experiments.Tests = c.tests
+
+			var got experiment.Assignments
+			h := f.experimentID(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				got = f.assign(r)
+			}))
+
+			req := httptest.NewRequest("GET", "/?q=jive", nil)
+			if c.cookie != "" {
+				req.AddCookie(&http.Cookie{Name: experimentCookie, Value: c.cookie})
+			}
+			if c.dnt {
+				req.Header.Set("DNT", "1")
+			}
+
+			rr := httptest.NewRecorder()
+			h.ServeHTTP(rr, req)
+
+			if set := rr.Header().Get("Set-Cookie") != ""; set != c.set {
+				t.Fatalf("got cookie set %v; want %v", set, c.set)
+			}
+
+			want := len(c.tests)
+			if c.dnt {
+				want = 0 // no id to bucket them by
+			}
+
+			if len(got) != want {
+				t.Fatalf("got %d assignments; want %d", len(got), want)
+			}
+		})
+	}
+}
+
+func TestSearcher(t *testing.T) {
+	def := &mockSearch{}
+	other := &search.Relaxer{Fetcher: def}
+
+	f := &Frontend{Search: def}
+	third := &search.Relaxer{Fetcher: other}
+	f.Experiments.Searchers = map[string]search.Fetcher{"other": other, "default": third}
+
+	for _, c := range []struct {
+		name        string
+		assignments experiment.Assignments
+		ranking     string
+		want        string
+		fetcher     search.Fetcher
+	}{
+		{"none", nil, "", "", def},
+		{"control", experiment.Assignments{"ranking": {Name: "control"}}, "", "", def},
+		{"variant", experiment.Assignments{"ranking": {Name: "b", Params: map[string]string{"search": "other"}}}, "", "other", other},
+		{"unknown", experiment.Assignments{"ranking": {Name: "c", Params: map[string]string{"search": "missing"}}}, "", "", def},
+		{"tenant", nil, "other", "other", other},
+		{"variant over tenant", experiment.Assignments{"ranking": {Name: "control", Params: map[string]string{"search": "default"}}}, "other", "default", third},
+		{"unknown tenant ranking", nil, "missing", "", def},
+	} {
+		t.Run(c.name, func(t *testing.T) {
+			name, fetcher := f.searcher(c.assignments, c.ranking)
+			if name != c.want || fetcher != c.fetcher {
+				t.Fatalf("got %q %p; want %q %p", name, fetcher, c.want, c.fetcher)
+			}
+		})
+	}
+}
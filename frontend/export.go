@@ -0,0 +1,157 @@
+package frontend
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+)
+
+// export is a table of results for bulk downloads as csv or json lines
+type export struct {
+	header []string
+	rows   []exportRow
+}
+
+// exportRow is a result. Its record has a value for each column of the header.
+type exportRow interface {
+	record() []string
+}
+
+type webRow struct {
+	Rank        int    `json:"rank"`
+	URL         string `json:"url"`
+	Title       string `json:"title"`
+	Description string `json:"description"`
+	Domain      string `json:"domain"`
+	Crawled     string `json:"crawled,omitempty"`
+}
+
+var webHeader = []string{"rank", "url", "title", "description", "domain", "crawled"}
+
+func (w webRow) record() []string {
+	return []string{strconv.Itoa(w.Rank), w.URL, w.Title, w.Description, w.Domain, w.Crawled}
+}
+
+type imageRow struct {
+	Rank    int     `json:"rank"`
+	URL     string  `json:"url"`
+	Domain  string  `json:"domain"`
+	Alt     string  `json:"alt"`
+	Width   int     `json:"width,omitempty"`
+	Height  int     `json:"height,omitempty"`
+	MIME    string  `json:"mime,omitempty"`
+	NSFW    float64 `json:"nsfw_score"`
+	Crawled string  `json:"crawled,omitempty"`
+}
+
+var imageHeader = []string{"rank", "url", "domain", "alt", "width", "height", "mime", "nsfw_score", "crawled"}
+
+func (i imageRow) record() []string {
+	return []string{
+		strconv.Itoa(i.Rank), i.URL, i.Domain, i.Alt, strconv.Itoa(i.Width), strconv.Itoa(i.Height),
+		i.MIME, strconv.FormatFloat(i.NSFW, 'f', -1, 64), i.Crawled,
+	}
+}
+
+// newExport exports the web (or files) or image results. Ranks continue across pages.
+// We leave out the base64 of images as exports are for the data, not the pictures.
+func newExport(d data) (*export, error) {
+	offset := d.Context.Offset()
+
+	switch d.Context.T {
+	case "", "files":
+		e := &export{header: webHeader}
+		if d.Search == nil {
+			return e, nil
+		}
+
+		for i, doc := range d.Search.Documents {
+			e.rows = append(e.rows, webRow{
+				Rank:        offset + i + 1,
+				URL:         doc.ID,
+				Title:       doc.Title,
+				Description: doc.Description,
+				Domain:      doc.Domain,
+				Crawled:     doc.Crawled,
+			})
+		}
+
+		return e, nil
+	case "images":
+		e := &export{header: imageHeader}
+		if d.Images == nil {
+			return e, nil
+		}
+
+		for i, im := range d.Images.Images {
+			e.rows = append(e.rows, imageRow{
+				Rank:    offset + i + 1,
+				URL:     im.ID,
+				Domain:  im.Domain,
+				Alt:     im.Alt,
+				Width:   im.Width,
+				Height:  im.Height,
+				MIME:    im.MIME,
+				NSFW:    im.NSFW,
+				Crawled: im.Crawled,
+			})
+		}
+
+		return e, nil
+	}
+
+	return nil, fmt.Errorf("%q results can't be exported", d.Context.T)
+}
+
+// exporting is true for bulk downloads, which don't need the images themselves
+func exporting(r *http.Request) bool {
+	switch r.FormValue("o") {
+	case "csv", "jsonl":
+		return true
+	}
+	return false
+}
+
+func (e *export) writeCSV(w io.Writer) error {
+	cw := csv.NewWriter(w)
+	if err := cw.Write(e.header); err != nil {
+		return err
+	}
+
+	for _, r := range e.rows {
+		record := r.record()
+		for i, v := range record {
+			record[i] = csvCell(v)
+		}
+
+		if err := cw.Write(record); err != nil {
+			return err
+		}
+	}
+
+	cw.Flush()
+	return cw.Error()
+}
+
+// csvCell stops a spreadsheet from running a title or description as a formula, e.g. =HYPERLINK(...),
+// by prefixing it with a quote. JSON lines are left as is.
+func csvCell(v string) string {
+	if v != "" && strings.ContainsRune("=+-@\t\r", rune(v[0])) {
+		return "'" + v
+	}
+	return v
+}
+
+func (e *export) writeJSONL(w io.Writer) error {
+	enc := json.NewEncoder(w)
+	for _, r := range e.rows {
+		if err := enc.Encode(r); err != nil {
+			return err
+		}
+	}
+	return nil
+}
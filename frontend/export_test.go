@@ -0,0 +1,128 @@
+package frontend
+
+import (
+	"bytes"
+	"reflect"
+	"testing"
+
+	"github.com/jivesearch/jivesearch/search"
+	"github.com/jivesearch/jivesearch/search/document"
+	img "github.com/jivesearch/jivesearch/search/image"
+)
+
+func TestExport(t *testing.T) {
+	type want struct {
+		csv   string
+		jsonl string
+		err   bool
+	}
+
+	for _, c := range []struct {
+		name string
+		d    data
+		want
+	}{
+		{
+			name: "web",
+			d: data{
+				Context: &Context{Q: "jimi hendrix", Page: 2, Number: 25},
+				Results: Results{
+					Search: &search.Results{
+						Documents: []*document.Document{
+							{
+								ID:      "https://www.example.com/jimi",
+								Domain:  "example.com",
+								Crawled: "20181001",
+								Content: document.Content{Title: "Jimi Hendrix", Description: "Guitarist, \"Purple Haze\""},
+							},
+						},
+					},
+				},
+			},
+			want: want{
+				csv: "rank,url,title,description,domain,crawled\n" +
+					"26,https://www.example.com/jimi,Jimi Hendrix,\"Guitarist, \"\"Purple Haze\"\"\",example.com,20181001\n",
+				jsonl: `{"rank":26,"url":"https://www.example.com/jimi","title":"Jimi Hendrix","description":"Guitarist, \"Purple Haze\"","domain":"example.com","crawled":"20181001"}` + "\n",
+			},
+		},
+		{
+			name: "images",
+			d: data{
+				Context: &Context{Q: "guitar", T: "images", Page: 1, Number: 25},
+				Results: Results{
+					Images: &img.Results{
+						Images: []*img.Image{
+							{ID: "https://example.com/guitar.jpg", Domain: "example.com", Alt: "a guitar", Width: 640, Height: 480, NSFW: 0.01, Base64: "skipped"},
+						},
+					},
+				},
+			},
+			want: want{
+				csv: "rank,url,domain,alt,width,height,mime,nsfw_score,crawled\n" +
+					"1,https://example.com/guitar.jpg,example.com,a guitar,640,480,,0.01,\n",
+				jsonl: `{"rank":1,"url":"https://example.com/guitar.jpg","domain":"example.com","alt":"a guitar","width":640,"height":480,"nsfw_score":0.01}` + "\n",
+			},
+		},
+		{
+			name: "formulas",
+			d: data{
+				Context: &Context{Q: "spreadsheet", Page: 1, Number: 25},
+				Results: Results{
+					Search: &search.Results{
+						Documents: []*document.Document{
+							{
+								ID:      "https://www.example.com/",
+								Domain:  "example.com",
+								Content: document.Content{Title: `=HYPERLINK("https://evil.example.com","click me")`, Description: "@SUM(1+1)"},
+							},
+						},
+					},
+				},
+			},
+			want: want{
+				csv: "rank,url,title,description,domain,crawled\n" +
+					"1,https://www.example.com/,\"'=HYPERLINK(\"\"https://evil.example.com\"\",\"\"click me\"\")\",'@SUM(1+1),example.com,\n",
+				jsonl: `{"rank":1,"url":"https://www.example.com/","title":"=HYPERLINK(\"https://evil.example.com\",\"click me\")","description":"@SUM(1+1)","domain":"example.com"}` + "\n",
+			},
+		},
+		{
+			name: "no results",
+			d:    data{Context: &Context{Q: "nothing", Page: 1, Number: 25}},
+			want: want{csv: "rank,url,title,description,domain,crawled\n"},
+		},
+		{
+			name: "maps",
+			d:    data{Context: &Context{Q: "paris", T: "maps", Page: 1, Number: 25}},
+			want: want{err: true},
+		},
+	} {
+		t.Run(c.name, func(t *testing.T) {
+			e, err := newExport(c.d)
+			if (err != nil) != c.want.err {
+				t.Fatalf("got err %v; want an error %v", err, c.want.err)
+			}
+
+			if err != nil {
+				return
+			}
+
+			buf := &bytes.Buffer{}
+			if err := e.writeCSV(buf); err != nil {
+				t.Fatal(err)
+			}
+
+			if got := buf.String(); got != c.want.csv {
+				t.Fatalf("got %q; want %q", got, c.want.csv)
+			}
+
+			buf.Reset()
+			if err := e.writeJSONL(buf); err != nil {
+				t.Fatal(err)
+			}
+
+			if got := buf.String(); !reflect.DeepEqual(got, c.want.jsonl) {
+				t.Fatalf("got %q; want %q", got, c.want.jsonl)
+			}
+		})
+	}
+}
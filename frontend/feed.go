@@ -0,0 +1,169 @@
+package frontend
+
+import (
+	"encoding/xml"
+	"fmt"
+	"net/url"
+	"time"
+)
+
+// feedItem is a result in an RSS or Atom feed
+type feedItem struct {
+	Title     string
+	Link      string
+	Snippet   string
+	Published time.Time // zero if we don't know when it was published
+}
+
+// rss is an RSS 2.0 feed of a search
+type rss struct {
+	XMLName xml.Name   `xml:"rss"`
+	Version string     `xml:"version,attr"`
+	Channel rssChannel `xml:"channel"`
+}
+
+type rssChannel struct {
+	Title       string    `xml:"title"`
+	Link        string    `xml:"link"`
+	Description string    `xml:"description"`
+	Items       []rssItem `xml:"item"`
+}
+
+type rssItem struct {
+	Title       string `xml:"title"`
+	Link        string `xml:"link"`
+	Description string `xml:"description,omitempty"`
+	GUID        string `xml:"guid"`
+	PubDate     string `xml:"pubDate,omitempty"`
+}
+
+// atom is an Atom feed of a search
+type atom struct {
+	XMLName xml.Name    `xml:"http://www.w3.org/2005/Atom feed"`
+	ID      string      `xml:"id"`
+	Title   string      `xml:"title"`
+	Updated string      `xml:"updated"`
+	Link    []atomLink  `xml:"link"`
+	Entries []atomEntry `xml:"entry"`
+}
+
+type atomLink struct {
+	Href string `xml:"href,attr"`
+	Rel  string `xml:"rel,attr,omitempty"`
+}
+
+type atomEntry struct {
+	ID      string   `xml:"id"`
+	Title   string   `xml:"title"`
+	Link    atomLink `xml:"link"`
+	Summary string   `xml:"summary,omitempty"`
+	Updated string   `xml:"updated"`
+}
+
+// feedItems are the news articles followed by the web results.
+// Web results are dated by when we crawled them.
+func feedItems(d data) []feedItem {
+	items := []feedItem{}
+
+	if d.Blend != nil && d.Blend.News != nil {
+		for _, a := range d.Blend.News.Articles {
+			items = append(items, feedItem{
+				Title:     a.Title,
+				Link:      a.URL,
+				Snippet:   a.Description,
+				Published: a.Published,
+			})
+		}
+	}
+
+	if d.Search != nil {
+		for _, doc := range d.Search.Documents {
+			item := feedItem{
+				Title:   doc.Title,
+				Link:    doc.ID,
+				Snippet: doc.Description,
+			}
+
+			if t, err := time.Parse("20060102", doc.Crawled); err == nil {
+				item.Published = t
+			}
+
+			items = append(items, item)
+		}
+	}
+
+	return items
+}
+
+func feedTitle(d data) string {
+	name := d.Brand.Name
+	if name == "" {
+		name = "Jive Search"
+	}
+	return fmt.Sprintf("%v - %v", d.Context.Q, name)
+}
+
+// feedLink is the html page of the search
+func feedLink(d data) string {
+	return fmt.Sprintf("%v/?q=%v", d.Brand.Host, url.QueryEscape(d.Context.Q))
+}
+
+func newRSS(d data) *rss {
+	feed := &rss{
+		Version: "2.0",
+		Channel: rssChannel{
+			Title:       feedTitle(d),
+			Link:        feedLink(d),
+			Description: fmt.Sprintf("Search results for %v", d.Context.Q),
+		},
+	}
+
+	for _, item := range feedItems(d) {
+		i := rssItem{
+			Title:       item.Title,
+			Link:        item.Link,
+			Description: item.Snippet,
+			GUID:        item.Link,
+		}
+
+		if !item.Published.IsZero() {
+			i.PubDate = item.Published.Format(time.RFC1123Z)
+		}
+
+		feed.Channel.Items = append(feed.Channel.Items, i)
+	}
+
+	return feed
+}
+
+// newAtom requires an updated time for every entry so undated results get the time of the search
+func newAtom(d data, updated time.Time) *atom {
+	link := feedLink(d)
+
+	feed := &atom{
+		ID:      link,
+		Title:   feedTitle(d),
+		Updated: updated.Format(time.RFC3339),
+		Link: []atomLink{
+			{Href: link},
+			{Href: link + "&o=atom", Rel: "self"},
+		},
+	}
+
+	for _, item := range feedItems(d) {
+		published := item.Published
+		if published.IsZero() {
+			published = updated
+		}
+
+		feed.Entries = append(feed.Entries, atomEntry{
+			ID:      item.Link,
+			Title:   item.Title,
+			Link:    atomLink{Href: item.Link},
+			Summary: item.Snippet,
+			Updated: published.Format(time.RFC3339),
+		})
+	}
+
+	return feed
+}
@@ -0,0 +1,102 @@
+package frontend
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/jivesearch/jivesearch/search"
+	"github.com/jivesearch/jivesearch/search/document"
+	"github.com/jivesearch/jivesearch/search/news"
+)
+
+func TestFeeds(t *testing.T) {
+	published := time.Date(2018, 10, 16, 9, 30, 0, 0, time.UTC)
+	updated := time.Date(2018, 10, 17, 0, 0, 0, 0, time.UTC)
+
+	d := data{
+		Brand:   Brand{Name: "Jive Search", Host: "https://jivesearch.com"},
+		Context: &Context{Q: "mars rover"},
+		Results: Results{
+			Blend: &Blend{
+				News: &news.Results{
+					Articles: []*news.Article{
+						{
+							URL:         "https://news.example.com/rover",
+							Title:       "Rover wakes up",
+							Description: "The rover is back & talking.",
+							Published:   published,
+						},
+					},
+				},
+			},
+			Search: &search.Results{
+				Documents: []*document.Document{
+					{
+						ID:      "https://www.example.com/mars",
+						Crawled: "20181001",
+						Content: document.Content{Title: "Mars", Description: "The red planet"},
+					},
+					{
+						ID:      "https://undated.example.com/",
+						Content: document.Content{Title: "Undated"},
+					},
+				},
+			},
+		},
+	}
+
+	for _, c := range []struct {
+		template string
+		data     interface{}
+		mime     string
+		want     []string
+	}{
+		{
+			template: "rss",
+			data:     newRSS(d),
+			mime:     "application/rss+xml; charset=utf-8",
+			want: []string{
+				`<?xml version="1.0" encoding="UTF-8"?>`,
+				`<rss version="2.0"><channel><title>mars rover - Jive Search</title><link>https://jivesearch.com/?q=mars+rover</link>`,
+				`<item><title>Rover wakes up</title><link>https://news.example.com/rover</link><description>The rover is back &amp; talking.</description><guid>https://news.example.com/rover</guid><pubDate>Tue, 16 Oct 2018 09:30:00 +0000</pubDate></item>`,
+				`<item><title>Mars</title><link>https://www.example.com/mars</link><description>The red planet</description><guid>https://www.example.com/mars</guid><pubDate>Mon, 01 Oct 2018 00:00:00 +0000</pubDate></item>`,
+				`<item><title>Undated</title><link>https://undated.example.com/</link><guid>https://undated.example.com/</guid></item>`,
+			},
+		},
+		{
+			template: "atom",
+			data:     newAtom(d, updated),
+			mime:     "application/atom+xml; charset=utf-8",
+			want: []string{
+				`<feed xmlns="http://www.w3.org/2005/Atom"><id>https://jivesearch.com/?q=mars+rover</id><title>mars rover - Jive Search</title><updated>2018-10-17T00:00:00Z</updated>`,
+				`<link href="https://jivesearch.com/?q=mars+rover&amp;o=atom" rel="self"></link>`,
+				`<entry><id>https://news.example.com/rover</id><title>Rover wakes up</title><link href="https://news.example.com/rover"></link><summary>The rover is back &amp; talking.</summary><updated>2018-10-16T09:30:00Z</updated></entry>`,
+				`<entry><id>https://undated.example.com/</id><title>Undated</title><link href="https://undated.example.com/"></link><updated>2018-10-17T00:00:00Z</updated></entry>`,
+			},
+		},
+	} {
+		t.Run(c.template, func(t *testing.T) {
+			h := appHandler(func(w http.ResponseWriter, r *http.Request) *response {
+				return &response{status: http.StatusOK, template: c.template, data: c.data}
+			})
+
+			w := httptest.NewRecorder()
+			h.ServeHTTP(w, httptest.NewRequest("GET", "/?"+url.Values{"q": {"mars rover"}, "o": {c.template}}.Encode(), nil))
+
+			if got := w.Header().Get("Content-Type"); got != c.mime {
+				t.Fatalf("got %q; want %q", got, c.mime)
+			}
+
+			body := w.Body.String()
+			for _, want := range c.want {
+				if !strings.Contains(body, want) {
+					t.Fatalf("got %v; want it to contain %v", body, want)
+				}
+			}
+		})
+	}
+}
@@ -1,14 +1,10 @@
 package frontend
 
 import (
-	"crypto/hmac"
-	"crypto/sha256"
-	"encoding/base64"
 	"encoding/json"
 	"fmt"
 	"html/template"
 	"math"
-	"os"
 	"reflect"
 	"sort"
 	"strconv"
@@ -16,16 +12,21 @@ import (
 	"time"
 
 	"github.com/PuerkitoBio/goquery"
+	"github.com/jivesearch/jivesearch/frontend/highlight"
 	"github.com/jivesearch/jivesearch/instant/breach"
 	"github.com/jivesearch/jivesearch/instant/congress"
 	"github.com/jivesearch/jivesearch/instant/whois"
 	"github.com/jivesearch/jivesearch/search"
 	"github.com/jivesearch/jivesearch/search/image"
+	"github.com/jivesearch/jivesearch/search/shopping"
 
 	humanize "github.com/dustin/go-humanize"
 	"github.com/jivesearch/jivesearch/instant"
 	"github.com/jivesearch/jivesearch/instant/currency"
 	"github.com/jivesearch/jivesearch/instant/econ"
+	"github.com/jivesearch/jivesearch/instant/media"
+	"github.com/jivesearch/jivesearch/instant/packages"
+	"github.com/jivesearch/jivesearch/instant/repo"
 	"github.com/jivesearch/jivesearch/instant/shortener"
 	"github.com/jivesearch/jivesearch/instant/stock"
 	"github.com/jivesearch/jivesearch/instant/weather"
@@ -39,18 +40,26 @@ var funcMap = template.FuncMap{
 	"AnswerCSS":            answerCSS,
 	"AnswerJS":             answerJS,
 	"Commafy":              commafy,
+	"Currencies":           currencies,
+	"Highlight":            highlight.Code,
+	"HighlightDigest":      highlight.Digest,
+	"HighlightRegex":       highlight.Regex,
 	"HMACKey":              hmacKey,
+	"Host":                 search.Host,
 	"ImagesProvider":       imagesProvider,
 	"Join":                 join,
 	"JSONMarshal":          jsonMarshal,
 	"Now":                  now,
 	"Percent":              percent,
+	"Permalink":            permalink,
 	"PlusOne":              plusOne,
 	"SafeHTML":             safeHTML,
 	"Source":               source,
+	"Snippet":              snippet,
 	"SortWHOISNameServers": sortWHOISNameServers,
 	"StripHTML":            stripHTML,
 	"Subtract":             subtract,
+	"Thumbnail":            thumbnail,
 	"Title":                title,
 	"Truncate":             truncate,
 	"WeatherCode":          weatherCode,
@@ -110,6 +119,10 @@ func answerCSS(host string, a instant.Data) []string {
 		files = []string{
 			addStaticPrefix(host, "population/population.css"),
 		}
+	case "stackoverflow":
+		files = []string{
+			addStaticPrefix(host, "stackoverflow/stackoverflow.css"),
+		}
 	case "stock quote":
 		files = []string{
 			addStaticPrefix(host, "stock_quotes/stock_quotes.css"),
@@ -140,6 +153,10 @@ func answerJS(host string, a instant.Data) []string {
 		files = []string{
 			addStaticPrefix(host, "calculator/calculator.js"),
 		}
+	case "bmi", "mortgage calculator", "percentage", "tip":
+		files = []string{
+			addStaticPrefix(host, "calculator/widget.js"),
+		}
 	case "currency":
 		files = []string{
 			addStaticPrefix(host, "d3.v4.min.js"),
@@ -160,10 +177,6 @@ func answerJS(host string, a instant.Data) []string {
 			addStaticPrefix(host, "maps/mapbox.js"),
 			addStaticPrefix(host, "maps/mapbox_directions.js"),
 		}
-	case "mortgage calculator":
-		files = []string{
-			addStaticPrefix(host, "mortgage_calculator/mortgage_calculator.js"),
-		}
 	case "wikidata nutrition":
 		files = []string{
 			addStaticPrefix(host, "nutrition/nutrition.js"),
@@ -199,6 +212,11 @@ func answerJS(host string, a instant.Data) []string {
 	return files
 }
 
+// currencies are the currencies shopping results can be shown in
+func currencies() []string {
+	return shopping.Currencies
+}
+
 func commafy(v interface{}) string {
 	switch v := v.(type) {
 	case int:
@@ -219,23 +237,9 @@ func commafy(v interface{}) string {
 	}
 }
 
-var hmacSecret = func() string {
-	return os.Getenv("hmac_secret")
-}
-
 // hmacKey generates an hmac key for our reverse image proxy
 func hmacKey(u string) string {
-	secret := hmacSecret()
-	if secret == "" {
-		log.Info.Println(`hmac secret for image proxy is blank. Please set the "hmac_secret" env variable`)
-	}
-
-	h := hmac.New(sha256.New, []byte(secret))
-	if _, err := h.Write([]byte(u)); err != nil {
-		log.Info.Println(err)
-	}
-
-	return base64.URLEncoding.EncodeToString(h.Sum(nil))
+	return keyring().sign(u, now())
 }
 
 func imagesProvider(p image.Provider) string {
@@ -287,6 +291,16 @@ func safeHTML(value string) template.HTML {
 	return template.HTML(value)
 }
 
+// snippet highlights a result's description if it is code, such as a man page's usage or a gist
+func snippet(s string) template.HTML {
+	lang := highlight.Detect(s)
+	if lang == "" {
+		return template.HTML(template.HTMLEscapeString(s))
+	}
+
+	return template.HTML(`<code class="hl">` + string(highlight.Code(s, lang)) + `</code>`)
+}
+
 func sortWHOISNameServers(servers []whois.NameServer) []whois.NameServer {
 	sort.Slice(servers, func(i, j int) bool { return servers[i].Name < servers[j].Name })
 	return servers
@@ -326,7 +340,7 @@ func source(answer instant.Data) string {
 		default:
 			log.Debug.Printf("unknown congress provider %v\n", c.Provider)
 		}
-	case "discography":
+	case "discography", "songwriter":
 		img = fmt.Sprintf(`<img width="12" height="12" alt="musicbrainz" src="%v"/>`, proxyFavIcon("https://musicbrainz.org/favicon.ico"))
 		f = fmt.Sprintf(`%v <a href="https://musicbrainz.org/">MusicBrainz</a>`, img)
 	case "fedex":
@@ -372,12 +386,57 @@ func source(answer instant.Data) string {
 		}
 
 		f = makeSource(provider)
+	case "media":
+		m := answer.Solution.(*media.Title)
+		switch m.Provider {
+		case media.TMDBProvider:
+			img = fmt.Sprintf(`<img width="12" height="12" alt="%v" src="%v"/>`, m.Provider, proxyFavIcon("https://www.themoviedb.org/favicon.ico"))
+			f = fmt.Sprintf(`%v <a href="https://www.themoviedb.org/">%v</a>. This product uses the TMDB API but is not endorsed or certified by TMDB.`, img, m.Provider) // MUST include their notice
+		case media.OMDbProvider:
+			img = fmt.Sprintf(`<img width="12" height="12" alt="%v" src="%v"/>`, m.Provider, proxyFavIcon("https://www.omdbapi.com/favicon.ico"))
+			f = fmt.Sprintf(`%v <a href="https://www.omdbapi.com/">%v</a>`, img, m.Provider)
+		default:
+			log.Debug.Printf("unknown media provider %v\n", m.Provider)
+		}
+	case "package":
+		p := answer.Solution.(*packages.Package)
+		switch p.Registry {
+		case packages.PyPI:
+			img = fmt.Sprintf(`<img width="12" height="12" alt="%v" src="%v"/>`, p.Registry, proxyFavIcon("https://pypi.org/favicon.ico"))
+			f = fmt.Sprintf(`%v <a href="https://pypi.org/">%v</a>`, img, p.Registry)
+		case packages.NPM:
+			img = fmt.Sprintf(`<img width="12" height="12" alt="%v" src="%v"/>`, p.Registry, proxyFavIcon("https://www.npmjs.com/favicon.ico"))
+			f = fmt.Sprintf(`%v <a href="https://www.npmjs.com/">%v</a>`, img, p.Registry)
+		case packages.Crates:
+			img = fmt.Sprintf(`<img width="12" height="12" alt="%v" src="%v"/>`, p.Registry, proxyFavIcon("https://crates.io/favicon.ico"))
+			f = fmt.Sprintf(`%v <a href="https://crates.io/">%v</a>`, img, p.Registry)
+		case packages.Go:
+			img = fmt.Sprintf(`<img width="12" height="12" alt="%v" src="%v"/>`, p.Registry, proxyFavIcon("https://pkg.go.dev/favicon.ico"))
+			f = fmt.Sprintf(`%v <a href="https://pkg.go.dev/">pkg.go.dev</a>`, img)
+		default:
+			log.Debug.Printf("unknown package registry %v\n", p.Registry)
+		}
+	case "repository":
+		rp := answer.Solution.(*repo.Repository)
+		switch rp.Provider {
+		case repo.GitHubProvider:
+			img = fmt.Sprintf(`<img width="12" height="12" alt="%v" src="%v"/>`, rp.Provider, proxyFavIcon("https://github.com/favicon.ico"))
+			f = fmt.Sprintf(`%v <a href="https://github.com/">%v</a>`, img, rp.Provider)
+		case repo.GitLabProvider:
+			img = fmt.Sprintf(`<img width="12" height="12" alt="%v" src="%v"/>`, rp.Provider, proxyFavIcon("https://gitlab.com/favicon.ico"))
+			f = fmt.Sprintf(`%v <a href="https://gitlab.com/">%v</a>`, img, rp.Provider)
+		default:
+			log.Debug.Printf("unknown repository provider %v\n", rp.Provider)
+		}
 	case "stackoverflow":
-		// TODO: I wasn't able to get both the User's display name and link to their profile or id.
-		// Can select one or the other but not both in their filter.
-		user := answer.Solution.(*instant.StackOverflowAnswer).Answer.User
+		// Stack Overflow asks that answers link back to the author & mention the license
+		a := answer.Solution.(*instant.StackOverflowAnswer).Answer
+		user := template.HTMLEscapeString(a.User)
+		if a.UserLink != "" {
+			user = fmt.Sprintf(`<a href="%v">%v</a>`, template.HTMLEscapeString(a.UserLink), user)
+		}
 		img = fmt.Sprintf(`<img width="12" height="12" alt="stackoverflow" src="%v"/>`, proxyFavIcon("https://cdn.sstatic.net/Sites/stackoverflow/img/favicon.ico"))
-		f = fmt.Sprintf(`%v via %v <a href="https://stackoverflow.com/">Stack Overflow</a>`, user, img)
+		f = fmt.Sprintf(`%v via %v <a href="https://stackoverflow.com/">Stack Overflow</a>, licensed under <a href="https://stackoverflow.com/help/licensing">CC BY-SA</a>`, user, img)
 	case "status":
 		img = fmt.Sprintf(`<img width="12" height="12" alt="isitup?" src="%v"/>`, proxyFavIcon("https://isitup.org/favicon.ico"))
 		f = fmt.Sprintf(`%v <a href="https://isitup.org/">Is It Up?</a>`, img)
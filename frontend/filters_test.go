@@ -15,6 +15,8 @@ import (
 	"github.com/jivesearch/jivesearch/instant/econ"
 	"github.com/jivesearch/jivesearch/instant/econ/gdp"
 	"github.com/jivesearch/jivesearch/instant/econ/population"
+	"github.com/jivesearch/jivesearch/instant/media"
+	"github.com/jivesearch/jivesearch/instant/repo"
 
 	"github.com/jivesearch/jivesearch/instant/currency"
 	"github.com/jivesearch/jivesearch/instant/shortener"
@@ -76,6 +78,12 @@ func TestAnswerCSS(t *testing.T) {
 				"http://127.0.0.1:8000/static/instant/calculator/calculator.css",
 			},
 		},
+		{
+			name: "stackoverflow",
+			want: []string{
+				"http://127.0.0.1:8000/static/instant/stackoverflow/stackoverflow.css",
+			},
+		},
 		{
 			name: "whois",
 			want: []string{},
@@ -114,6 +122,22 @@ func TestAnswerJS(t *testing.T) {
 				"http://127.0.0.1:8000/static/instant/calculator/calculator.js",
 			},
 		},
+		{
+			name: "mortgage calculator",
+			want: []string{
+				"http://127.0.0.1:8000/static/instant/calculator/widget.js",
+			},
+		},
+		{
+			name: "stackoverflow",
+			want: []string{},
+		},
+		{
+			name: "tip",
+			want: []string{
+				"http://127.0.0.1:8000/static/instant/calculator/widget.js",
+			},
+		},
 		{
 			name: "whois",
 			want: []string{},
@@ -210,7 +234,8 @@ func TestHMACKey(t *testing.T) {
 		},
 	} {
 		t.Run(tt.name, func(t *testing.T) {
-			hmacSecret = func() string { return tt.args.secret }
+			defer signing.Store(HMAC{}.keyring())
+			signing.Store(HMAC{Secret: tt.args.secret}.keyring())
 
 			got := hmacKey(tt.args.u)
 
@@ -353,6 +378,30 @@ func TestSafeHTML(t *testing.T) {
 	}
 }
 
+func TestSnippet(t *testing.T) {
+	for _, tt := range []struct {
+		arg  string
+		want template.HTML
+	}{
+		{
+			arg:  "Compare <b>apples</b> & oranges",
+			want: "Compare &lt;b&gt;apples&lt;/b&gt; &amp; oranges",
+		},
+		{
+			arg:  "SELECT name FROM users WHERE id = 1",
+			want: `<code class="hl"><span class="hl-keyword">SELECT</span> name <span class="hl-keyword">FROM</span> users <span class="hl-keyword">WHERE</span> id = <span class="hl-number">1</span></code>`,
+		},
+	} {
+		t.Run(tt.arg, func(t *testing.T) {
+			got := snippet(tt.arg)
+
+			if got != tt.want {
+				t.Fatalf("got %q; want %q", got, tt.want)
+			}
+		})
+	}
+}
+
 func TestSortWHOISNameServers(t *testing.T) {
 	for _, tt := range []struct {
 		name string
@@ -486,6 +535,15 @@ func TestSource(t *testing.T) {
 			},
 			want: `<img width="12" height="12" alt="musicbrainz" src="/image/32x,sv4p1VZOkfT_gjscSjDjuToOCXgNXhcOxdBDjhYmwmsk=/https://musicbrainz.org/favicon.ico"/> <a href="https://musicbrainz.org/">MusicBrainz</a>`,
 		},
+		{
+			name: "songwriter",
+			args: args{
+				instant.Data{
+					Type: "songwriter",
+				},
+			},
+			want: `<img width="12" height="12" alt="musicbrainz" src="/image/32x,sv4p1VZOkfT_gjscSjDjuToOCXgNXhcOxdBDjhYmwmsk=/https://musicbrainz.org/favicon.ico"/> <a href="https://musicbrainz.org/">MusicBrainz</a>`,
+		},
 		{
 			name: "currency",
 			args: args{
@@ -538,6 +596,54 @@ func TestSource(t *testing.T) {
 			},
 			want: `<img width="12" height="12" alt="The World Bank" src="/image/32x,sr79IepQNuB0JCCgfeNKd5TpbGm4JSKlr9E4pUtiw9Ig=/https://www.worldbank.org/content/dam/wbr-redesign/logos/wbg-favicon.png"/> <a href="https://www.worldbank.org/">The World Bank</a>`,
 		},
+		{
+			name: "media tmdb",
+			args: args{
+				instant.Data{
+					Type: "media",
+					Solution: &media.Title{
+						Provider: media.TMDBProvider,
+					},
+				},
+			},
+			want: `<img width="12" height="12" alt="The Movie Database" src="/image/32x,sez01yuhJ8GgY2Nx9Fabv0Wp6H-g2afUkOtzK8H3qM3c=/https://www.themoviedb.org/favicon.ico"/> <a href="https://www.themoviedb.org/">The Movie Database</a>. This product uses the TMDB API but is not endorsed or certified by TMDB.`,
+		},
+		{
+			name: "media omdb",
+			args: args{
+				instant.Data{
+					Type: "media",
+					Solution: &media.Title{
+						Provider: media.OMDbProvider,
+					},
+				},
+			},
+			want: `<img width="12" height="12" alt="OMDb" src="/image/32x,sLmDBdV6oMVOcGiuI8LMxTHJCJh6b47Lka9CeIauQy8s=/https://www.omdbapi.com/favicon.ico"/> <a href="https://www.omdbapi.com/">OMDb</a>`,
+		},
+		{
+			name: "repository github",
+			args: args{
+				instant.Data{
+					Type: "repository",
+					Solution: &repo.Repository{
+						Provider: repo.GitHubProvider,
+					},
+				},
+			},
+			want: `<img width="12" height="12" alt="GitHub" src="/image/32x,stnNTL-BiRf_CwEuaKJfpgC1xRR8is9PqSW-qLgt3J-s=/https://github.com/favicon.ico"/> <a href="https://github.com/">GitHub</a>`,
+		},
+		{
+			name: "repository gitlab",
+			args: args{
+				instant.Data{
+					Type: "repository",
+					Solution: &repo.Repository{
+						Provider: repo.GitLabProvider,
+					},
+				},
+			},
+			want: `<img width="12" height="12" alt="GitLab" src="/image/32x,sqz4GHl8t6_dBX_VKqkfm6ij2twG24mj9anJ5ECa0NBI=/https://gitlab.com/favicon.ico"/> <a href="https://gitlab.com/">GitLab</a>`,
+		},
 		{
 			name: "stackoverflow",
 			args: args{
@@ -550,7 +656,22 @@ func TestSource(t *testing.T) {
 					},
 				},
 			},
-			want: `bob via <img width="12" height="12" alt="stackoverflow" src="/image/32x,sT0tRYsDTt0J1npxPJ5N9YAHsrK7jWT0WcvRrCA0vRW8=/https://cdn.sstatic.net/Sites/stackoverflow/img/favicon.ico"/> <a href="https://stackoverflow.com/">Stack Overflow</a>`,
+			want: `bob via <img width="12" height="12" alt="stackoverflow" src="/image/32x,sT0tRYsDTt0J1npxPJ5N9YAHsrK7jWT0WcvRrCA0vRW8=/https://cdn.sstatic.net/Sites/stackoverflow/img/favicon.ico"/> <a href="https://stackoverflow.com/">Stack Overflow</a>, licensed under <a href="https://stackoverflow.com/help/licensing">CC BY-SA</a>`,
+		},
+		{
+			name: "stackoverflow with profile",
+			args: args{
+				instant.Data{
+					Type: "stackoverflow",
+					Solution: &instant.StackOverflowAnswer{
+						Answer: instant.SOAnswer{
+							User:     "<b>NikiC</b>",
+							UserLink: "https://stackoverflow.com/users/385378/nikic",
+						},
+					},
+				},
+			},
+			want: `<a href="https://stackoverflow.com/users/385378/nikic">&lt;b&gt;NikiC&lt;/b&gt;</a> via <img width="12" height="12" alt="stackoverflow" src="/image/32x,sT0tRYsDTt0J1npxPJ5N9YAHsrK7jWT0WcvRrCA0vRW8=/https://cdn.sstatic.net/Sites/stackoverflow/img/favicon.ico"/> <a href="https://stackoverflow.com/">Stack Overflow</a>, licensed under <a href="https://stackoverflow.com/help/licensing">CC BY-SA</a>`,
 		},
 		{
 			name: "stock quote",
@@ -4,6 +4,7 @@ package frontend
 import (
 	"context"
 	"encoding/json"
+	"encoding/xml"
 	"fmt"
 	"html/template"
 	"net/http"
@@ -13,9 +14,21 @@ import (
 	"github.com/jivesearch/jivesearch/bangs"
 	"github.com/jivesearch/jivesearch/frontend/cache"
 	"github.com/jivesearch/jivesearch/instant"
+	"github.com/jivesearch/jivesearch/instant/location"
+	"github.com/jivesearch/jivesearch/instant/maps"
 	"github.com/jivesearch/jivesearch/log"
 	"github.com/jivesearch/jivesearch/search"
+	"github.com/jivesearch/jivesearch/search/blend"
+	"github.com/jivesearch/jivesearch/search/domains"
 	img "github.com/jivesearch/jivesearch/search/image"
+	"github.com/jivesearch/jivesearch/search/intent"
+	"github.com/jivesearch/jivesearch/search/local"
+	"github.com/jivesearch/jivesearch/search/news"
+	"github.com/jivesearch/jivesearch/search/scholar"
+	"github.com/jivesearch/jivesearch/search/shopping"
+	"github.com/jivesearch/jivesearch/search/threat"
+	"github.com/jivesearch/jivesearch/search/video"
+	"github.com/jivesearch/jivesearch/singleflight"
 	"github.com/jivesearch/jivesearch/suggest"
 	"github.com/oxtoacart/bpool"
 	"golang.org/x/text/language"
@@ -23,35 +36,73 @@ import (
 
 // Frontend holds settings for branding, cache, search backend, etc.
 type Frontend struct {
+	AdminToken   string
+	Analytics    Analytics // optional
+	APIKeys      APIKeys
+	Autocomplete Autocomplete
+	BangEdits    BangEdits // the !bangs an admin has changed
+	BangStats    BangStats
 	Brand
+	Blender  blend.Blender
+	Clicks   Clicks              // optional
+	CORS     CORS                // for browsers calling our API from other sites
+	Coalesce *singleflight.Group // optional. Identical fetches in flight at once share one call.
 	Document
+	Domains domains.Store // optional. The domains banned, sunk or pinned for everyone
 	*bangs.Bangs
-	Cache struct {
+	Health  Health
+	History History // optional. Only for users who opt in
+	Cache   struct {
 		cache.Cacher
 		Instant time.Duration
 		Search  time.Duration
+		Stats   *cache.Stats // optional
 	}
-	Images struct {
+	Experiments Experiments
+	HMAC        HMAC // hmac.secret and the rotated keys for the urls of our proxies
+	Images      struct {
 		img.Fetcher
 		*http.Client
 	}
+	Ingest Ingest // documents pushed into our index by operators
 	*instant.Instant
-	MapBoxKey   string
+	Thumbnails Thumbnails
+	Intent     intent.Classifier
+	Local      local.Fetcher
+	MapBoxKey  string
+	Maps       struct {
+		maps.Geocoder
+		maps.Router
+	}
+	News        news.Fetcher // optional. Blended into the web results
 	Onion       string
+	POST        bool // searches are sent as form posts by default
 	ProxyClient *http.Client
-	Suggest     suggest.Suggester
-	Search      search.Fetcher
+	RateLimit
+	RegionFetcher location.Fetcher // optional. Detects the user's region from their IP
+	Scholar       scholar.Fetcher  // optional. Papers for t=scholar
+	Reload        func() error     // optional. Rereads our configuration for /admin/reload
+	Saved         Saved            // optional. Results bookmarked with an api key
+	Suggest       suggest.Suggester
+	Search        search.Fetcher
+	Security      Security
+	Shed          *Shedder         // optional. Skips parts of our searches when we are overloaded
+	Shopping      shopping.Fetcher // optional. Products for sale for t=shopping
+	Threats       Threats          // optional. Results on malware and phishing blocklists
+	Tenants       Tenants          // optional. Other brands served from here by hostname
+	Tor           bool             // only our own index and instant answers. Nothing is fetched from third parties.
+	Videos        video.Fetcher    // optional. Blended into the web results
 	Wikipedia
 	GitHub
 }
 
 // Brand allows for customization of the name and tagline
 type Brand struct {
-	Name      string
-	Host      string
-	TagLine   string
-	Logo      string
-	SmallLogo string
+	Name      string `json:"name"`
+	Host      string `json:"host"`
+	TagLine   string `json:"tagline"`
+	Logo      string `json:"logo"`
+	SmallLogo string `json:"small_logo"`
 }
 
 // Document has the languages we support
@@ -60,6 +111,12 @@ type Document struct {
 	language.Matcher
 }
 
+// Threats warns of the results on our malware and phishing blocklists
+type Threats struct {
+	*threat.Feed
+	Filter bool // remove them instead
+}
+
 // Wikipedia holds our settings for wikipedia/wikidata
 // Note: language matcher here may be different than that for
 // document due to available languages Wikipedia supports
@@ -123,6 +180,29 @@ func (fn appHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 
 				fmt.Fprintf(w, "jivesearchcallback(%s)", buf)
 				return // return here as we're done!
+			case "rss", "atom":
+				w.Header().Set("Content-Type", fmt.Sprintf("application/%v+xml; charset=utf-8", rsp.template))
+				buf.WriteString(xml.Header)
+				if err := xml.NewEncoder(buf).Encode(rsp.data); err != nil {
+					rsp.status, rsp.err = http.StatusInternalServerError, err
+					errHandler(w, rsp)
+					return
+				}
+			case "csv":
+				w.Header().Set("Content-Type", "text/csv; charset=utf-8")
+				w.Header().Set("Content-Disposition", `attachment; filename="results.csv"`)
+				if err := rsp.data.(*export).writeCSV(buf); err != nil {
+					rsp.status, rsp.err = http.StatusInternalServerError, err
+					errHandler(w, rsp)
+					return
+				}
+			case "jsonl":
+				w.Header().Set("Content-Type", "application/x-ndjson")
+				if err := rsp.data.(*export).writeJSONL(buf); err != nil {
+					rsp.status, rsp.err = http.StatusInternalServerError, err
+					errHandler(w, rsp)
+					return
+				}
 			case "proxy_css":
 				w.Header().Set("Content-Type", "text/css; charset=utf-8")
 
@@ -168,6 +248,8 @@ func (fn appHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 				rsp.status, rsp.err = http.StatusInternalServerError, err
 				errHandler(w, rsp)
 			}
+		case http.StatusNoContent:
+			w.WriteHeader(rsp.status)
 		case http.StatusFound:
 			switch rsp.data.(type) {
 			case map[string][]string: // POST request
@@ -191,7 +273,7 @@ func (fn appHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 			default: // !bang
 				http.Redirect(w, r, rsp.redirect, http.StatusFound)
 			}
-		case http.StatusBadRequest, http.StatusInternalServerError:
+		case http.StatusBadRequest, http.StatusUnauthorized, http.StatusForbidden, http.StatusNotFound, http.StatusInternalServerError:
 			errHandler(w, rsp)
 		default:
 			log.Info.Printf("Unknown status %d\n", rsp.status)
@@ -201,7 +283,7 @@ func (fn appHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 
 func errHandler(w http.ResponseWriter, rsp *response) {
 	switch rsp.status {
-	case http.StatusBadRequest:
+	case http.StatusBadRequest, http.StatusUnauthorized, http.StatusForbidden, http.StatusNotFound:
 		log.Debug.Println(rsp.err)
 	case http.StatusInternalServerError:
 		log.Info.Println(rsp.err)
@@ -211,12 +293,29 @@ func errHandler(w http.ResponseWriter, rsp *response) {
 }
 
 func (f *Frontend) autocompleteHandler(w http.ResponseWriter, r *http.Request) *response {
+	q := strings.TrimSpace(r.FormValue("q"))
+
+	res, err := f.suggestions(q)
+	if err != nil {
+		return &response{
+			status: http.StatusInternalServerError,
+			err:    err,
+		}
+	}
+
+	return &response{
+		status:   http.StatusOK,
+		template: "json",
+		data:     withHistory(res, q, f.userHistory(r.Header.Get(historyKeyHeader))),
+	}
+}
+
+// suggestions are the !bangs or queries that complete what the user is typing
+func (f *Frontend) suggestions(q string) (interface{}, error) {
 	var proxyFavIcon = func(u string) string {
 		return fmt.Sprintf("/image/32x,s%v/%v", hmacKey(u), u)
 	}
 
-	q := strings.TrimSpace(r.FormValue("q"))
-
 	if q == "!" {
 		bngs := []bangs.Suggestion{}
 		triggers := []string{"g", "a", "b", "reddit", "w"}
@@ -236,21 +335,14 @@ func (f *Frontend) autocompleteHandler(w http.ResponseWriter, r *http.Request) *
 		}
 
 		// give a default set of !bang suggestions
-		return &response{
-			status:   http.StatusOK,
-			template: "json",
-			data: bangs.Results{
-				Suggestions: bngs,
-			},
-		}
+		return bangs.Results{
+			Suggestions: bngs,
+		}, nil
 
 	} else if len(q) > 1 && !strings.HasPrefix(q, " ") && strings.HasPrefix(q, "!") {
 		res, err := f.Bangs.Suggest(q, 10)
 		if err != nil {
-			return &response{
-				status: http.StatusInternalServerError,
-				err:    err,
-			}
+			return nil, err
 		}
 
 		if len(res.Suggestions) > 0 {
@@ -260,27 +352,11 @@ func (f *Frontend) autocompleteHandler(w http.ResponseWriter, r *http.Request) *
 				res.Suggestions[i] = b
 			}
 
-			return &response{
-				status:   http.StatusOK,
-				template: "json",
-				data:     res,
-			}
-		}
-	}
-
-	res, err := f.Suggest.Completion(q, 10)
-	if err != nil {
-		return &response{
-			status: http.StatusInternalServerError,
-			err:    err,
+			return res, nil
 		}
 	}
 
-	return &response{
-		status:   http.StatusOK,
-		template: "json",
-		data:     res,
-	}
+	return f.Suggest.Completion(q, 10)
 }
 
 // ParseTemplates parses our html templates.
@@ -295,6 +371,12 @@ var ParseTemplates = func() {
 				"templates/about.html",
 			),
 	)
+	templates["admin_bangs"] = template.Must(
+		template.New("admin_bangs.html").
+			ParseFiles(
+				"templates/admin_bangs.html",
+			),
+	)
 
 	var err error
 	t := template.New("tmp")
@@ -310,6 +392,13 @@ var ParseTemplates = func() {
 				"templates/answer.html",
 			),
 	)
+	templates["lite"] = template.Must(
+		template.New("lite.html").
+			Funcs(funcMap).
+			ParseFiles(
+				"templates/lite.html",
+			),
+	)
 	templates["maps"] = template.Must(
 		template.New("maps.html").
 			Funcs(funcMap).
@@ -323,6 +412,17 @@ var ParseTemplates = func() {
 				"templates/opensearch.xml",
 			),
 	)
+	templates["permalink"] = template.Must(
+		template.New("base.html").
+			Funcs(funcMap).
+			ParseFiles(
+				"templates/base.html",
+				"templates/answer.html",
+				"templates/search_form.html",
+				"templates/permalink.html",
+				"templates/wikipedia.html",
+			),
+	)
 	templates["proxy_header"] = template.Must(
 		template.New("base.html").
 			Funcs(funcMap).
@@ -347,6 +447,7 @@ var ParseTemplates = func() {
 			ParseFiles(
 				"templates/base.html",
 				"templates/answer.html",
+				"templates/knowledge.html",
 				"templates/search_form.html",
 				"templates/search.html",
 				"templates/wikipedia.html",
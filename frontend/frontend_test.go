@@ -216,6 +216,10 @@ func (ms *mockSuggester) Completion(q string, size int) (suggest.Results, error)
 	return s, nil
 }
 
+func (ms *mockSuggester) Scrub(remove func(q string) bool) (int, error) {
+	return 0, nil
+}
+
 func (ms *mockSuggester) IndexExists() (bool, error) {
 	return ms.ex, nil
 }
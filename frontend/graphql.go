@@ -0,0 +1,259 @@
+package frontend
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"mime"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/jivesearch/jivesearch/frontend/apikey"
+	"github.com/jivesearch/jivesearch/frontend/graphql"
+	"github.com/jivesearch/jivesearch/instant"
+	"github.com/jivesearch/jivesearch/search"
+)
+
+// maxGraphQLBody is as large as a query we'll read
+const maxGraphQLBody = 1 << 20
+
+// maxGraphQLFields is how many searches, answers and suggestions one query can ask for.
+// Each is as much work as a request to our other endpoints.
+const maxGraphQLFields = 10
+
+// graphqlHandler answers a GraphQL query of our searches, instant answers and suggestions
+// in one round trip, with only the fields asked for, e.g.
+// { search(q: "jive") { documents { url: id title } } instant(q: "jive") { type } }.
+// Fields with the same arguments are fetched once and the rest are fetched together.
+// On top of the query itself, each search counts against the API key's quota and
+// the search rate limit like a json search of its own.
+func (f *Frontend) graphqlHandler(w http.ResponseWriter, r *http.Request) *response {
+	req, err := graphqlRequest(w, r)
+	if err != nil {
+		return &response{
+			status: http.StatusBadRequest,
+			err:    err,
+		}
+	}
+
+	// when overloaded the searches only come from our cache
+	shed, release := f.Shed.acquire()
+	defer release()
+
+	return &response{
+		status:   http.StatusOK,
+		template: "json",
+		data:     f.schema(r, shed).Execute(req),
+	}
+}
+
+// graphqlRequest reads a query from the params of a GET or the body of a POST.
+// A POST is json unless it is sent as application/graphql.
+func graphqlRequest(w http.ResponseWriter, r *http.Request) (*graphql.Request, error) {
+	req := &graphql.Request{}
+
+	if r.Method == http.MethodPost {
+		r.Body = http.MaxBytesReader(w, r.Body, maxGraphQLBody)
+
+		ct, _, _ := mime.ParseMediaType(r.Header.Get("Content-Type"))
+		switch ct {
+		case "application/graphql":
+			b, err := ioutil.ReadAll(r.Body)
+			if err != nil {
+				return nil, err
+			}
+			req.Query = string(b)
+		default:
+			if err := json.NewDecoder(r.Body).Decode(req); err != nil {
+				return nil, err
+			}
+		}
+	} else {
+		req.Query = r.FormValue("query")
+		req.OperationName = r.FormValue("operationName")
+		if v := r.FormValue("variables"); v != "" {
+			if err := json.Unmarshal([]byte(v), &req.Variables); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	if strings.TrimSpace(req.Query) == "" {
+		return nil, errMissingQuery
+	}
+
+	return req, nil
+}
+
+// schema maps the root fields onto the params of our other endpoints so they share our cache.
+// The loaders last for this request only.
+func (f *Frontend) schema(r *http.Request, shed Shed) graphql.Schema {
+	searches := &graphql.Loader{Fetch: batch(func(key string) (interface{}, error) {
+		sr := subrequest(r, "/", key)
+		d, err := f.getData(sr)
+		if err != nil {
+			return nil, err
+		}
+
+		d.Context.Shed = shed
+		if shed >= ShedResults && d.Context.Number > f.Shed.Number {
+			d.Context.Number = f.Shed.Number
+		}
+
+		res := f.searchResults(sr, d, d.Context.lang, d.Context.Region)
+		if res == nil {
+			return nil, errOverloaded
+		}
+		res.Syntax = search.Validate(d.Context.Q)
+
+		return res, nil
+	})}
+
+	answers := &graphql.Loader{Fetch: batch(func(key string) (interface{}, error) {
+		if shed >= ShedInstant {
+			return nil, errOverloaded
+		}
+
+		sr := subrequest(r, "/api/v1/instant", key)
+		d, err := f.getData(sr)
+		if err != nil {
+			return nil, err
+		}
+
+		ic := make(chan instant.Data)
+		go f.getAnswer(sr, d, ic)
+		return <-ic, nil
+	})}
+
+	suggestions := &graphql.Loader{Fetch: batch(f.suggestions)}
+
+	fields := 0
+	limit := func(resolve graphql.Resolver) graphql.Resolver {
+		return func(args map[string]interface{}) (graphql.Thunk, error) {
+			if fields++; fields > maxGraphQLFields {
+				return nil, fmt.Errorf("no more than %d fields per query", maxGraphQLFields)
+			}
+			return resolve(args)
+		}
+	}
+
+	return graphql.Schema{
+		"search": limit(func(args map[string]interface{}) (graphql.Thunk, error) {
+			v, err := params(args, map[string]string{
+				"q": "q", "page": "p", "number": "n", "filter": "f", "language": "l", "region": "r",
+			})
+			if err != nil {
+				return nil, err
+			}
+			if err := f.charge(r, "search"); err != nil {
+				return nil, err
+			}
+			return searches.Load(v.Encode()), nil
+		}),
+		"instant": limit(func(args map[string]interface{}) (graphql.Thunk, error) {
+			v, err := params(args, map[string]string{
+				"q": "q", "language": "l", "region": "r",
+			})
+			if err != nil {
+				return nil, err
+			}
+			return answers.Load(v.Encode()), nil
+		}),
+		"suggestions": limit(func(args map[string]interface{}) (graphql.Thunk, error) {
+			v, err := params(args, map[string]string{"q": "q"})
+			if err != nil {
+				return nil, err
+			}
+			return suggestions.Load(v.Get("q")), nil
+		}),
+	}
+}
+
+// charge counts a field against the API key's daily quota, if any, and the rate limit of its route
+func (f *Frontend) charge(r *http.Request, route string) error {
+	if k, ok := r.Context().Value(apiKeyContext).(*apikey.Key); ok {
+		if retry, ok := f.quota(k); !ok {
+			return fmt.Errorf("%v, retry in %v", errQuota, retry.Round(time.Second))
+		}
+	}
+
+	if retry, ok := f.take(route, r); !ok {
+		return fmt.Errorf("%v, retry in %v", strings.ToLower(http.StatusText(http.StatusTooManyRequests)), retry.Round(time.Second))
+	}
+
+	return nil
+}
+
+// params are the form values of a field's arguments. Every field needs a query.
+func params(args map[string]interface{}, names map[string]string) (url.Values, error) {
+	v := url.Values{}
+
+	for arg := range args {
+		if _, ok := names[arg]; !ok {
+			return nil, fmt.Errorf("unknown argument %q", arg)
+		}
+	}
+
+	for arg, param := range names {
+		var s string
+		switch arg {
+		case "page", "number":
+			n, err := graphql.Int(args, arg)
+			if err != nil {
+				return nil, err
+			}
+			if n != 0 {
+				s = strconv.Itoa(n)
+			}
+		default:
+			var err error
+			if s, err = graphql.String(args, arg); err != nil {
+				return nil, err
+			}
+		}
+
+		if s = strings.TrimSpace(s); s != "" {
+			v.Set(param, s)
+		}
+	}
+
+	if v.Get("q") == "" {
+		return nil, errMissingQuery
+	}
+
+	return v, nil
+}
+
+// subrequest is the GET to one of our endpoints that a field stands for.
+// It keeps the headers and address of the request for the language and region.
+func subrequest(r *http.Request, path, rawQuery string) *http.Request {
+	sr := r.WithContext(r.Context())
+	sr.Method = http.MethodGet
+	sr.URL = &url.URL{Path: path, RawQuery: rawQuery}
+	sr.Body, sr.ContentLength = http.NoBody, 0
+	sr.Form, sr.PostForm = nil, nil
+	return sr
+}
+
+// batch fetches the keys of a batch at the same time
+func batch(fetch func(key string) (interface{}, error)) func(keys []string) ([]interface{}, []error) {
+	return func(keys []string) ([]interface{}, []error) {
+		values, errs := make([]interface{}, len(keys)), make([]error, len(keys))
+
+		var wg sync.WaitGroup
+		for i, k := range keys {
+			wg.Add(1)
+			go func(i int, k string) {
+				defer wg.Done()
+				values[i], errs[i] = fetch(k)
+			}(i, k)
+		}
+		wg.Wait()
+
+		return values, errs
+	}
+}
@@ -0,0 +1,559 @@
+// Package graphql answers GraphQL queries against a schema of root fields.
+// Only queries are supported. The fields below the root are selected from whatever
+// the root resolvers return by their json names, so we don't declare their types.
+package graphql
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"math"
+	"reflect"
+	"strings"
+	"sync"
+)
+
+// Thunk returns the value of a field once it is needed.
+// Thunks from a Loader fetch the keys of all the fields in one batch.
+type Thunk func() (interface{}, error)
+
+// Resolver resolves a root field from its arguments
+type Resolver func(args map[string]interface{}) (Thunk, error)
+
+// Schema is the resolvers of the root Query type by field name
+type Schema map[string]Resolver
+
+// Request is a query and its variables
+type Request struct {
+	Query         string                 `json:"query"`
+	OperationName string                 `json:"operationName"`
+	Variables     map[string]interface{} `json:"variables"`
+}
+
+// Response is the result of a query. A query that couldn't run has no data.
+type Response struct {
+	Data   Object   `json:"data,omitempty"`
+	Errors []*Error `json:"errors,omitempty"`
+}
+
+// Error is what went wrong with a query or one of its fields
+type Error struct {
+	Message   string        `json:"message"`
+	Locations []Location    `json:"locations,omitempty"`
+	Path      []interface{} `json:"path,omitempty"`
+}
+
+func (e *Error) Error() string {
+	return e.Message
+}
+
+// Location is a line and column of a query
+type Location struct {
+	Line   int `json:"line"`
+	Column int `json:"column"`
+}
+
+// Object is a selection of fields in the order they were asked for
+type Object []Member
+
+// Member is a field of an Object
+type Member struct {
+	Key   string
+	Value interface{}
+}
+
+// MarshalJSON keeps the fields in order
+func (o Object) MarshalJSON() ([]byte, error) {
+	if o == nil {
+		return []byte("null"), nil
+	}
+
+	var buf bytes.Buffer
+	buf.WriteByte('{')
+	for i, m := range o {
+		if i > 0 {
+			buf.WriteByte(',')
+		}
+
+		k, err := json.Marshal(m.Key)
+		if err != nil {
+			return nil, err
+		}
+		buf.Write(k)
+		buf.WriteByte(':')
+
+		v, err := json.Marshal(m.Value)
+		if err != nil {
+			return nil, err
+		}
+		buf.Write(v)
+	}
+	buf.WriteByte('}')
+
+	return buf.Bytes(), nil
+}
+
+// Execute runs a query. All the root fields are resolved before any of their thunks
+// are called, so that a Loader gets all its keys at once, and then the thunks are called together.
+func (s Schema) Execute(req *Request) *Response {
+	doc, err := Parse(req.Query)
+	if err != nil {
+		return failed(err)
+	}
+
+	op, err := operation(doc, req.OperationName)
+	if err != nil {
+		return failed(err)
+	}
+
+	vars, err := variables(op, req.Variables)
+	if err != nil {
+		return failed(err)
+	}
+
+	if err := validate(op.Selections, vars); err != nil {
+		return failed(err)
+	}
+
+	fields, err := merge(included(op.Selections, vars))
+	if err != nil {
+		return failed(err)
+	}
+
+	for _, f := range fields {
+		if _, ok := s[f.Name]; !ok && f.Name != "__typename" {
+			return failed(unknownField(f, "Query"))
+		}
+	}
+
+	// each field has its own executor so its errors come in the order the fields were asked for
+	data := make(Object, len(fields))
+	thunks := make([]Thunk, len(fields))
+	executors := make([]*executor, len(fields))
+
+	for i, f := range fields {
+		data[i].Key, executors[i] = f.Key(), &executor{vars: vars}
+		if f.Name == "__typename" {
+			data[i].Value = "Query"
+			continue
+		}
+
+		thunk, err := s[f.Name](arguments(f.Arguments, vars))
+		if err != nil {
+			executors[i].fail(f, []interface{}{f.Key()}, err)
+			continue
+		}
+		thunks[i] = thunk
+	}
+
+	var wg sync.WaitGroup
+	for i, thunk := range thunks {
+		if thunk == nil {
+			continue
+		}
+
+		wg.Add(1)
+		go func(i int, thunk Thunk) {
+			defer wg.Done()
+
+			e, f, path := executors[i], fields[i], []interface{}{fields[i].Key()}
+			v, err := thunk()
+			if err != nil {
+				e.fail(f, path, err)
+				return
+			}
+			data[i].Value = e.complete(reflect.ValueOf(v), f, path)
+		}(i, thunk)
+	}
+	wg.Wait()
+
+	resp := &Response{Data: data}
+	for _, e := range executors {
+		resp.Errors = append(resp.Errors, e.errors...)
+	}
+
+	return resp
+}
+
+func failed(err error) *Response {
+	e, ok := err.(*Error)
+	if !ok {
+		e = &Error{Message: err.Error()}
+		if se, ok := err.(*SyntaxError); ok {
+			e.Message = "Syntax Error: " + se.Message
+			e.Locations = []Location{{Line: se.Line, Column: se.Column}}
+		}
+	}
+	return &Response{Errors: []*Error{e}}
+}
+
+func fieldError(f *Field, msg string) *Error {
+	return &Error{Message: msg, Locations: []Location{{Line: f.line, Column: f.column}}}
+}
+
+func unknownField(f *Field, typ string) *Error {
+	return fieldError(f, fmt.Sprintf("Cannot query field %q on type %q", f.Name, typ))
+}
+
+// operation picks the operation to run. The name is optional if there is only one.
+func operation(doc *Document, name string) (*Operation, error) {
+	if name == "" {
+		if len(doc.Operations) > 1 {
+			return nil, &Error{Message: "Must provide operation name if query contains multiple operations"}
+		}
+		return doc.Operations[0], nil
+	}
+
+	for _, op := range doc.Operations {
+		if op.Name == name {
+			return op, nil
+		}
+	}
+
+	return nil, &Error{Message: fmt.Sprintf("Unknown operation named %q", name)}
+}
+
+// variables fills in the defaults of the variables that weren't given
+func variables(op *Operation, given map[string]interface{}) (map[string]interface{}, error) {
+	vars := map[string]interface{}{}
+
+	for _, v := range op.Variables {
+		val, ok := given[v.Name]
+		if !ok && v.Default != nil {
+			val = v.Default.value(nil)
+		}
+
+		if val == nil && v.Required {
+			return nil, &Error{Message: fmt.Sprintf("Variable \"$%v\" of required type \"%v!\" was not provided", v.Name, v.Type)}
+		}
+
+		vars[v.Name] = val
+	}
+
+	return vars, nil
+}
+
+// validate checks the directives and variables of every field before anything is resolved
+func validate(fields []*Field, vars map[string]interface{}) error {
+	for _, f := range fields {
+		for _, d := range f.Directives {
+			if d.Name != "skip" && d.Name != "include" {
+				return fieldError(f, fmt.Sprintf("Unknown directive \"@%v\"", d.Name))
+			}
+
+			if _, ok := d.Arguments["if"]; !ok {
+				return fieldError(f, fmt.Sprintf("Directive \"@%v\" argument \"if\" is required", d.Name))
+			}
+
+			if err := declared(f, d.Arguments, vars); err != nil {
+				return err
+			}
+		}
+
+		if err := declared(f, f.Arguments, vars); err != nil {
+			return err
+		}
+
+		if err := validate(f.Selections, vars); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func declared(f *Field, args map[string]Value, vars map[string]interface{}) error {
+	var check func(v Value) error
+	check = func(v Value) error {
+		switch v := v.(type) {
+		case variable:
+			if _, ok := vars[string(v)]; !ok {
+				return fieldError(f, fmt.Sprintf("Variable \"$%v\" is not defined", v))
+			}
+		case list:
+			for _, vv := range v {
+				if err := check(vv); err != nil {
+					return err
+				}
+			}
+		case object:
+			for _, vv := range v {
+				if err := check(vv); err != nil {
+					return err
+				}
+			}
+		}
+		return nil
+	}
+
+	for _, v := range args {
+		if err := check(v); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// included drops the fields skipped by @skip or @include
+func included(fields []*Field, vars map[string]interface{}) []*Field {
+	inc := []*Field{}
+
+Fields:
+	for _, f := range fields {
+		for _, d := range f.Directives {
+			b, _ := d.Arguments["if"].value(vars).(bool)
+			if (d.Name == "skip") == b {
+				continue Fields
+			}
+		}
+		inc = append(inc, f)
+	}
+
+	return inc
+}
+
+// merge drops the fields asked for twice. Different fields can't share a key.
+func merge(fields []*Field) ([]*Field, error) {
+	merged := []*Field{}
+
+Fields:
+	for _, f := range fields {
+		for _, g := range merged {
+			if g.Key() != f.Key() {
+				continue
+			}
+			if g.Name != f.Name {
+				return nil, fieldError(f, fmt.Sprintf("Fields %q conflict because %v and %v are different fields", f.Key(), g.Name, f.Name))
+			}
+			continue Fields
+		}
+		merged = append(merged, f)
+	}
+
+	return merged, nil
+}
+
+func arguments(args map[string]Value, vars map[string]interface{}) map[string]interface{} {
+	m := make(map[string]interface{}, len(args))
+	for k, v := range args {
+		m[k] = v.value(vars)
+	}
+	return m
+}
+
+// executor completes a root field and collects the errors of its subfields
+type executor struct {
+	vars   map[string]interface{}
+	errors []*Error
+}
+
+func (e *executor) fail(f *Field, path []interface{}, err error) {
+	fe := fieldError(f, err.Error())
+	fe.Path = append([]interface{}{}, path...)
+	e.errors = append(e.errors, fe)
+}
+
+// complete selects the subfields of a value. A field without subfields is the whole value.
+func (e *executor) complete(v reflect.Value, f *Field, path []interface{}) interface{} {
+	for v.IsValid() && (v.Kind() == reflect.Ptr || v.Kind() == reflect.Interface) {
+		if v.IsNil() {
+			return nil
+		}
+		v = v.Elem()
+	}
+
+	if !v.IsValid() {
+		return nil
+	}
+
+	if len(f.Selections) == 0 {
+		return v.Interface()
+	}
+
+	switch v.Kind() {
+	case reflect.Slice, reflect.Array:
+		if v.Kind() == reflect.Slice && v.IsNil() {
+			return nil
+		}
+
+		l := make([]interface{}, v.Len())
+		for i := range l {
+			l[i] = e.complete(v.Index(i), f, append(path, i))
+		}
+		return l
+	case reflect.Struct:
+		return e.selectFields(v, f, path, structFields(v.Type()))
+	case reflect.Map:
+		if v.Type().Key().Kind() != reflect.String {
+			break
+		}
+		return e.selectFields(v, f, path, nil)
+	}
+
+	e.fail(f, path, fmt.Errorf("Field %q must not have a selection since type %q has no subfields", f.Name, typeName(v.Type())))
+	return nil
+}
+
+// selectFields picks the subfields of a struct, or of a map if there are no fields to look them up in
+func (e *executor) selectFields(v reflect.Value, f *Field, path []interface{}, fields map[string][]int) interface{} {
+	sel, err := merge(included(f.Selections, e.vars))
+	if err != nil {
+		e.fail(f, path, err)
+		return nil
+	}
+
+	o := make(Object, 0, len(sel))
+	for _, s := range sel {
+		p := append(path[:len(path):len(path)], s.Key())
+		if s.Name == "__typename" {
+			o = append(o, Member{Key: s.Key(), Value: typeName(v.Type())})
+			continue
+		}
+
+		if len(s.Arguments) > 0 {
+			e.fail(s, p, fmt.Errorf("Field %q takes no arguments", s.Name))
+			o = append(o, Member{Key: s.Key()})
+			continue
+		}
+
+		var fv reflect.Value
+		if v.Kind() == reflect.Map {
+			fv = v.MapIndex(reflect.ValueOf(s.Name).Convert(v.Type().Key()))
+		} else {
+			idx, ok := fields[s.Name]
+			if !ok {
+				e.fail(s, p, unknownField(s, typeName(v.Type())))
+				o = append(o, Member{Key: s.Key()})
+				continue
+			}
+
+			if fv, ok = fieldByIndex(v, idx); !ok {
+				o = append(o, Member{Key: s.Key()})
+				continue
+			}
+		}
+
+		o = append(o, Member{Key: s.Key(), Value: e.complete(fv, s, p)})
+	}
+
+	return o
+}
+
+var (
+	cacheMu sync.RWMutex
+	cache   = map[reflect.Type]map[string][]int{}
+)
+
+// structFields are the fields of a struct by their json names, including those of embedded structs
+func structFields(t reflect.Type) map[string][]int {
+	cacheMu.RLock()
+	fields, ok := cache[t]
+	cacheMu.RUnlock()
+	if ok {
+		return fields
+	}
+
+	fields = map[string][]int{}
+	depth := map[string]int{}
+
+	var walk func(t reflect.Type, index []int)
+	walk = func(t reflect.Type, index []int) {
+		for i := 0; i < t.NumField(); i++ {
+			sf := t.Field(i)
+			idx := append(index[:len(index):len(index)], i)
+
+			tag := sf.Tag.Get("json")
+			if tag == "-" {
+				continue
+			}
+			name := strings.Split(tag, ",")[0]
+
+			if sf.Anonymous && name == "" {
+				ft := sf.Type
+				if ft.Kind() == reflect.Ptr {
+					ft = ft.Elem()
+				}
+				if ft.Kind() == reflect.Struct {
+					walk(ft, idx)
+					continue
+				}
+			}
+
+			if sf.PkgPath != "" { // unexported
+				continue
+			}
+
+			if name == "" {
+				name = sf.Name
+			}
+
+			// like encoding/json the shallowest field wins
+			if d, ok := depth[name]; ok && d <= len(idx) {
+				continue
+			}
+			fields[name], depth[name] = idx, len(idx)
+		}
+	}
+	walk(t, nil)
+
+	cacheMu.Lock()
+	cache[t] = fields
+	cacheMu.Unlock()
+
+	return fields
+}
+
+// fieldByIndex is false if the field is in an embedded struct pointer that is nil
+func fieldByIndex(v reflect.Value, index []int) (reflect.Value, bool) {
+	for i, x := range index {
+		if i > 0 && v.Kind() == reflect.Ptr {
+			if v.IsNil() {
+				return reflect.Value{}, false
+			}
+			v = v.Elem()
+		}
+		v = v.Field(x)
+	}
+	return v, true
+}
+
+func typeName(t reflect.Type) string {
+	if t.Name() != "" {
+		return t.Name()
+	}
+	return t.String()
+}
+
+// String is a string argument. A missing or null argument is "".
+func String(args map[string]interface{}, name string) (string, error) {
+	switch v := args[name].(type) {
+	case nil:
+		return "", nil
+	case string:
+		return v, nil
+	default:
+		return "", fmt.Errorf("Argument %q must be a String", name)
+	}
+}
+
+// Int is an integer argument. A missing or null argument is 0.
+// Variables decoded from json are float64 so they're fine if they're whole.
+func Int(args map[string]interface{}, name string) (int, error) {
+	switch v := args[name].(type) {
+	case nil:
+		return 0, nil
+	case int64:
+		return int(v), nil
+	case float64:
+		if v == math.Trunc(v) {
+			return int(v), nil
+		}
+	case json.Number:
+		if n, err := v.Int64(); err == nil {
+			return int(n), nil
+		}
+	}
+
+	return 0, fmt.Errorf("Argument %q must be an Int", name)
+}
@@ -0,0 +1,189 @@
+package graphql
+
+import (
+	"encoding/json"
+	"fmt"
+	"testing"
+)
+
+type mockBase struct {
+	ID string `json:"id"`
+}
+
+type mockDocument struct {
+	mockBase
+	Title   string            `json:"title,omitempty"`
+	Tags    []string          `json:"tags"`
+	Meta    map[string]string `json:"meta"`
+	Secret  string            `json:"-"`
+	NoTag   int
+	private string
+}
+
+type mockResults struct {
+	Count     int64           `json:"count"`
+	Documents []*mockDocument `json:"documents"`
+	Extra     interface{}     `json:"extra"`
+	None      *mockDocument   `json:"none"`
+}
+
+var mockSchema = Schema{
+	"search": func(args map[string]interface{}) (Thunk, error) {
+		q, err := String(args, "q")
+		if err != nil {
+			return nil, err
+		}
+
+		n, err := Int(args, "number")
+		if err != nil {
+			return nil, err
+		}
+
+		return func() (interface{}, error) {
+			if q == "fail" {
+				return nil, fmt.Errorf("search failed")
+			}
+
+			res := &mockResults{Count: int64(n), Extra: mockDocument{Title: "extra"}}
+			for i := 0; i < n; i++ {
+				res.Documents = append(res.Documents, &mockDocument{
+					mockBase: mockBase{ID: fmt.Sprintf("https://example.com/%v/%d", q, i)},
+					Title:    fmt.Sprintf("%v %d", q, i),
+					Tags:     []string{q},
+					Meta:     map[string]string{"lang": "en"},
+					Secret:   "shh",
+					NoTag:    i,
+				})
+			}
+			return res, nil
+		}, nil
+	},
+	"echo": func(args map[string]interface{}) (Thunk, error) {
+		return func() (interface{}, error) { return args["v"], nil }, nil
+	},
+}
+
+func TestExecute(t *testing.T) {
+	for _, c := range []struct {
+		name string
+		req  *Request
+		want string
+	}{
+		{
+			"selection",
+			&Request{Query: `{ search(q: "jive", number: 2) { count documents { url: id title NoTag } } }`},
+			`{"data":{"search":{"count":2,"documents":[` +
+				`{"url":"https://example.com/jive/0","title":"jive 0","NoTag":0},` +
+				`{"url":"https://example.com/jive/1","title":"jive 1","NoTag":1}]}}}`,
+		},
+		{
+			"whole values",
+			&Request{Query: `{ search(q: "jive", number: 1) { documents { tags meta } extra none { id } } }`},
+			`{"data":{"search":{"documents":[{"tags":["jive"],"meta":{"lang":"en"}}],"extra":{"id":"","title":"extra","tags":null,"meta":null,"NoTag":0},"none":null}}}`,
+		},
+		{
+			"maps and interfaces",
+			&Request{Query: `{ search(q: "jive", number: 1) { documents { meta { lang missing } } extra { title } } }`},
+			`{"data":{"search":{"documents":[{"meta":{"lang":"en","missing":null}}],"extra":{"title":"extra"}}}}`,
+		},
+		{
+			"variables and defaults",
+			&Request{
+				Query:     `query($q: String!, $n: Int = 1) { search(q: $q, number: $n) { count } }`,
+				Variables: map[string]interface{}{"q": "jive"},
+			},
+			`{"data":{"search":{"count":1}}}`,
+		},
+		{
+			"json numbers",
+			&Request{
+				Query:     `query($n: Int) { search(q: "jive", number: $n) { count } }`,
+				Variables: map[string]interface{}{"n": 3.0},
+			},
+			`{"data":{"search":{"count":3}}}`,
+		},
+		{
+			"directives",
+			&Request{
+				Query:     `query($yes: Boolean) { a: echo(v: 1) @skip(if: $yes) b: echo(v: 2) @include(if: $yes) c: echo(v: 3) @include(if: false) }`,
+				Variables: map[string]interface{}{"yes": true},
+			},
+			`{"data":{"b":2}}`,
+		},
+		{
+			"typename",
+			&Request{Query: `{ __typename search(q: "jive", number: 1) { __typename documents { __typename } } }`},
+			`{"data":{"__typename":"Query","search":{"__typename":"mockResults","documents":[{"__typename":"mockDocument"}]}}}`,
+		},
+		{
+			"the same field twice",
+			&Request{Query: `{ echo(v: "x") echo(v: "x") }`},
+			`{"data":{"echo":"x"}}`,
+		},
+		{
+			"operation name",
+			&Request{Query: `query A { a: echo(v: "a") } query B { b: echo(v: "b") }`, OperationName: "B"},
+			`{"data":{"b":"b"}}`,
+		},
+		{
+			"field errors",
+			&Request{Query: `{ a: search(q: "fail") { count } b: search(q: 1) { count } c: search(q: "jive", number: 1) { documents { Secret title { x } } } }`},
+			`{"data":{"a":null,"b":null,"c":{"documents":[{"Secret":null,"title":null}]}},"errors":[` +
+				`{"message":"search failed","locations":[{"line":1,"column":3}],"path":["a"]},` +
+				`{"message":"Argument \"q\" must be a String","locations":[{"line":1,"column":34}],"path":["b"]},` +
+				`{"message":"Cannot query field \"Secret\" on type \"mockDocument\"","locations":[{"line":1,"column":106}],"path":["c","documents",0,"Secret"]},` +
+				`{"message":"Field \"title\" must not have a selection since type \"string\" has no subfields","locations":[{"line":1,"column":113}],"path":["c","documents",0,"title"]}]}`,
+		},
+		{
+			"syntax error",
+			&Request{Query: `{ search(q: "jive" }`},
+			`{"errors":[{"message":"Syntax Error: unexpected \"}\"","locations":[{"line":1,"column":20}]}]}`,
+		},
+		{
+			"unknown field",
+			&Request{Query: `{ images }`},
+			`{"errors":[{"message":"Cannot query field \"images\" on type \"Query\"","locations":[{"line":1,"column":3}]}]}`,
+		},
+		{
+			"conflicting fields",
+			&Request{Query: `{ x: search(q: "jive") x: echo(v: 1) }`},
+			`{"errors":[{"message":"Fields \"x\" conflict because search and echo are different fields","locations":[{"line":1,"column":24}]}]}`,
+		},
+		{
+			"missing variable",
+			&Request{Query: `query($q: String!) { search(q: $q) { count } }`},
+			`{"errors":[{"message":"Variable \"$q\" of required type \"String!\" was not provided"}]}`,
+		},
+		{
+			"undefined variable",
+			&Request{Query: `{ search(q: $q) { count } }`},
+			`{"errors":[{"message":"Variable \"$q\" is not defined","locations":[{"line":1,"column":3}]}]}`,
+		},
+		{
+			"unknown directive",
+			&Request{Query: `{ echo(v: 1) @defer }`},
+			`{"errors":[{"message":"Unknown directive \"@defer\"","locations":[{"line":1,"column":3}]}]}`,
+		},
+		{
+			"operation name required",
+			&Request{Query: `query A { echo } query B { echo }`},
+			`{"errors":[{"message":"Must provide operation name if query contains multiple operations"}]}`,
+		},
+		{
+			"unknown operation",
+			&Request{Query: `query A { echo }`, OperationName: "B"},
+			`{"errors":[{"message":"Unknown operation named \"B\""}]}`,
+		},
+	} {
+		t.Run(c.name, func(t *testing.T) {
+			b, err := json.Marshal(mockSchema.Execute(c.req))
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if string(b) != c.want {
+				t.Fatalf("got %s; want %s", b, c.want)
+			}
+		})
+	}
+}
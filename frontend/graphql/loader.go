@@ -0,0 +1,74 @@
+package graphql
+
+import (
+	"fmt"
+	"sync"
+)
+
+// Loader batches the keys of the fields that load them and fetches each key once.
+// A Loader lasts as long as the request, so two fields with the same arguments share a result.
+type Loader struct {
+	// Fetch returns a value or error for each key, in order
+	Fetch func(keys []string) ([]interface{}, []error)
+
+	mu      sync.Mutex
+	results map[string]*result
+	pending []*result
+}
+
+type result struct {
+	key   string
+	value interface{}
+	err   error
+	done  chan struct{}
+}
+
+// Load queues a key. It is fetched, along with every other queued key, when the first of their thunks is called.
+func (l *Loader) Load(key string) Thunk {
+	l.mu.Lock()
+	if l.results == nil {
+		l.results = map[string]*result{}
+	}
+
+	r, ok := l.results[key]
+	if !ok {
+		r = &result{key: key, done: make(chan struct{})}
+		l.results[key] = r
+		l.pending = append(l.pending, r)
+	}
+	l.mu.Unlock()
+
+	return func() (interface{}, error) {
+		l.dispatch()
+		<-r.done
+		return r.value, r.err
+	}
+}
+
+// dispatch fetches the queued keys. The keys of a batch that is already being fetched aren't queued anymore.
+func (l *Loader) dispatch() {
+	l.mu.Lock()
+	batch := l.pending
+	l.pending = nil
+	l.mu.Unlock()
+
+	if len(batch) == 0 {
+		return
+	}
+
+	keys := make([]string, len(batch))
+	for i, r := range batch {
+		keys[i] = r.key
+	}
+
+	values, errs := l.Fetch(keys)
+	for i, r := range batch {
+		switch {
+		case len(values) != len(keys) || len(errs) != len(keys):
+			r.err = fmt.Errorf("fetched %d values and %d errors for %d keys", len(values), len(errs), len(keys))
+		default:
+			r.value, r.err = values[i], errs[i]
+		}
+		close(r.done)
+	}
+}
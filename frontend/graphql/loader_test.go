@@ -0,0 +1,79 @@
+package graphql
+
+import (
+	"fmt"
+	"reflect"
+	"sync"
+	"testing"
+)
+
+func TestLoader(t *testing.T) {
+	var mu sync.Mutex
+	batches := [][]string{}
+
+	l := &Loader{
+		Fetch: func(keys []string) ([]interface{}, []error) {
+			mu.Lock()
+			batches = append(batches, keys)
+			mu.Unlock()
+
+			values, errs := make([]interface{}, len(keys)), make([]error, len(keys))
+			for i, k := range keys {
+				if k == "bad" {
+					errs[i] = fmt.Errorf("no %v", k)
+					continue
+				}
+				values[i] = k + "!"
+			}
+			return values, errs
+		},
+	}
+
+	thunks := []Thunk{l.Load("a"), l.Load("b"), l.Load("a"), l.Load("bad")}
+	got := make([]interface{}, len(thunks))
+	errs := make([]error, len(thunks))
+
+	var wg sync.WaitGroup
+	for i, thunk := range thunks {
+		wg.Add(1)
+		go func(i int, thunk Thunk) {
+			defer wg.Done()
+			got[i], errs[i] = thunk()
+		}(i, thunk)
+	}
+	wg.Wait()
+
+	if want := []interface{}{"a!", "b!", "a!", nil}; !reflect.DeepEqual(got, want) {
+		t.Fatalf("got %v; want %v", got, want)
+	}
+
+	if errs[3] == nil || errs[3].Error() != "no bad" {
+		t.Fatalf("got %v; want the error of the bad key", errs[3])
+	}
+
+	// loaded keys are remembered and new ones are another batch
+	if v, _ := l.Load("b")(); v != "b!" {
+		t.Fatalf("got %v; want b!", v)
+	}
+
+	if v, _ := l.Load("c")(); v != "c!" {
+		t.Fatalf("got %v; want c!", v)
+	}
+
+	if want := [][]string{{"a", "b", "bad"}, {"c"}}; !reflect.DeepEqual(batches, want) {
+		t.Fatalf("got batches %v; want %v", batches, want)
+	}
+}
+
+func TestLoaderMismatch(t *testing.T) {
+	l := &Loader{
+		Fetch: func(keys []string) ([]interface{}, []error) {
+			return nil, nil
+		},
+	}
+
+	_, err := l.Load("a")()
+	if err == nil || err.Error() != "fetched 0 values and 0 errors for 1 keys" {
+		t.Fatalf("got %v; want a mismatch", err)
+	}
+}
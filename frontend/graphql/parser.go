@@ -0,0 +1,647 @@
+package graphql
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+)
+
+// Document is a parsed query. We only take queries: no mutations, subscriptions or fragments.
+type Document struct {
+	Operations []*Operation
+}
+
+// Operation is a named or anonymous query
+type Operation struct {
+	Name       string
+	Variables  []*Variable
+	Selections []*Field
+}
+
+// Variable is a variable the operation declares, e.g. "$q: String! = "jive""
+type Variable struct {
+	Name     string
+	Type     string
+	Required bool
+	Default  Value
+}
+
+// Field is a field of a selection set along with its arguments and subfields
+type Field struct {
+	Alias      string
+	Name       string
+	Arguments  map[string]Value
+	Directives []*Directive
+	Selections []*Field
+	line       int
+	column     int
+}
+
+// Key is where the field goes in the response
+func (f *Field) Key() string {
+	if f.Alias != "" {
+		return f.Alias
+	}
+	return f.Name
+}
+
+// Directive is an @skip or @include on a field
+type Directive struct {
+	Name      string
+	Arguments map[string]Value
+}
+
+// Value is an argument's value before the variables are filled in
+type Value interface {
+	value(vars map[string]interface{}) interface{}
+}
+
+type literal struct{ v interface{} }
+
+func (l literal) value(map[string]interface{}) interface{} { return l.v }
+
+type variable string
+
+func (v variable) value(vars map[string]interface{}) interface{} { return vars[string(v)] }
+
+type list []Value
+
+func (l list) value(vars map[string]interface{}) interface{} {
+	vs := make([]interface{}, len(l))
+	for i, v := range l {
+		vs[i] = v.value(vars)
+	}
+	return vs
+}
+
+type object map[string]Value
+
+func (o object) value(vars map[string]interface{}) interface{} {
+	m := make(map[string]interface{}, len(o))
+	for k, v := range o {
+		m[k] = v.value(vars)
+	}
+	return m
+}
+
+// SyntaxError is a query we couldn't parse
+type SyntaxError struct {
+	Message string
+	Line    int
+	Column  int
+}
+
+func (e *SyntaxError) Error() string {
+	return fmt.Sprintf("syntax error at %d:%d: %v", e.Line, e.Column, e.Message)
+}
+
+type tokenKind int
+
+const (
+	eof tokenKind = iota
+	punctuator
+	name
+	intValue
+	floatValue
+	stringValue
+)
+
+type token struct {
+	kind   tokenKind
+	value  string
+	line   int
+	column int
+}
+
+// lexer splits a query into tokens. Commas, whitespace and comments don't count.
+type lexer struct {
+	src    string
+	pos    int
+	line   int
+	column int
+}
+
+func (l *lexer) errorf(format string, a ...interface{}) error {
+	return &SyntaxError{Message: fmt.Sprintf(format, a...), Line: l.line, Column: l.column}
+}
+
+func (l *lexer) advance(n int) {
+	for _, r := range l.src[l.pos : l.pos+n] {
+		if r == '\n' {
+			l.line++
+			l.column = 1
+			continue
+		}
+		l.column++
+	}
+	l.pos += n
+}
+
+func (l *lexer) next() (token, error) {
+	for l.pos < len(l.src) {
+		c := l.src[l.pos]
+		if c == '#' {
+			end := strings.IndexByte(l.src[l.pos:], '\n')
+			if end < 0 {
+				end = len(l.src) - l.pos
+			}
+			l.advance(end)
+			continue
+		}
+		if c != ' ' && c != '\t' && c != '\n' && c != '\r' && c != ',' {
+			break
+		}
+		l.advance(1)
+	}
+
+	t := token{line: l.line, column: l.column}
+	if l.pos >= len(l.src) {
+		return t, nil
+	}
+
+	c := l.src[l.pos]
+	switch {
+	case strings.HasPrefix(l.src[l.pos:], "..."):
+		t.kind, t.value = punctuator, "..."
+		l.advance(3)
+	case strings.IndexByte("!$():=@[]{}|", c) >= 0:
+		t.kind, t.value = punctuator, string(c)
+		l.advance(1)
+	case c == '_' || isLetter(c):
+		end := l.pos + 1
+		for end < len(l.src) && (l.src[end] == '_' || isLetter(l.src[end]) || isDigit(l.src[end])) {
+			end++
+		}
+		t.kind, t.value = name, l.src[l.pos:end]
+		l.advance(end - l.pos)
+	case c == '-' || isDigit(c):
+		return l.number(t)
+	case c == '"':
+		return l.string(t)
+	default:
+		r, _ := utf8.DecodeRuneInString(l.src[l.pos:])
+		return t, l.errorf("unexpected character %q", r)
+	}
+
+	return t, nil
+}
+
+func (l *lexer) number(t token) (token, error) {
+	end := l.pos
+	if l.src[end] == '-' {
+		end++
+	}
+
+	digits := func() {
+		for end < len(l.src) && isDigit(l.src[end]) {
+			end++
+		}
+	}
+
+	digits()
+	t.kind = intValue
+	if end < len(l.src) && l.src[end] == '.' {
+		end++
+		digits()
+		t.kind = floatValue
+	}
+	if end < len(l.src) && (l.src[end] == 'e' || l.src[end] == 'E') {
+		end++
+		if end < len(l.src) && (l.src[end] == '+' || l.src[end] == '-') {
+			end++
+		}
+		digits()
+		t.kind = floatValue
+	}
+
+	t.value = l.src[l.pos:end]
+	if _, err := strconv.ParseFloat(t.value, 64); err != nil {
+		return t, l.errorf("invalid number %q", t.value)
+	}
+
+	l.advance(end - l.pos)
+	return t, nil
+}
+
+// string reads a quoted string. Block strings aren't supported.
+func (l *lexer) string(t token) (token, error) {
+	if strings.HasPrefix(l.src[l.pos:], `"""`) {
+		return t, l.errorf("block strings aren't supported")
+	}
+
+	end := l.pos + 1
+	for ; end < len(l.src); end++ {
+		switch l.src[end] {
+		case '\\':
+			end++
+			continue
+		case '\n':
+			return t, l.errorf("unterminated string")
+		case '"':
+			s, err := strconv.Unquote(l.src[l.pos : end+1])
+			if err != nil {
+				return t, l.errorf("invalid string %v", l.src[l.pos:end+1])
+			}
+			t.kind, t.value = stringValue, s
+			l.advance(end + 1 - l.pos)
+			return t, nil
+		}
+	}
+
+	return t, l.errorf("unterminated string")
+}
+
+func isLetter(c byte) bool {
+	return (c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z')
+}
+
+func isDigit(c byte) bool {
+	return c >= '0' && c <= '9'
+}
+
+type parser struct {
+	lexer
+	tok   token
+	depth int
+}
+
+// maxDepth is how deeply selections, lists, objects and list types can nest, counted together.
+// We parse them recursively so a deeper query could exhaust our stack.
+const maxDepth = 32
+
+// enter goes a level deeper. Each enter that succeeds needs a leave.
+func (p *parser) enter() error {
+	if p.depth++; p.depth > maxDepth {
+		p.depth--
+		return &SyntaxError{Message: fmt.Sprintf("nested deeper than %d levels", maxDepth), Line: p.tok.line, Column: p.tok.column}
+	}
+	return nil
+}
+
+func (p *parser) leave() {
+	p.depth--
+}
+
+// Parse parses a query document
+func Parse(query string) (*Document, error) {
+	p := &parser{lexer: lexer{src: query, line: 1, column: 1}}
+	if err := p.read(); err != nil {
+		return nil, err
+	}
+
+	doc := &Document{}
+	for p.tok.kind != eof {
+		op, err := p.operation()
+		if err != nil {
+			return nil, err
+		}
+		doc.Operations = append(doc.Operations, op)
+	}
+
+	if len(doc.Operations) == 0 {
+		return nil, p.unexpected()
+	}
+
+	return doc, nil
+}
+
+func (p *parser) read() error {
+	t, err := p.lexer.next()
+	if err != nil {
+		return err
+	}
+	p.tok = t
+	return nil
+}
+
+func (p *parser) unexpected() error {
+	if p.tok.kind == eof {
+		return &SyntaxError{Message: "unexpected end of query", Line: p.tok.line, Column: p.tok.column}
+	}
+	return &SyntaxError{Message: fmt.Sprintf("unexpected %q", p.tok.value), Line: p.tok.line, Column: p.tok.column}
+}
+
+func (p *parser) peek(punct string) bool {
+	return p.tok.kind == punctuator && p.tok.value == punct
+}
+
+func (p *parser) expect(punct string) error {
+	if !p.peek(punct) {
+		return p.unexpected()
+	}
+	return p.read()
+}
+
+func (p *parser) name() (string, error) {
+	if p.tok.kind != name {
+		return "", p.unexpected()
+	}
+	n := p.tok.value
+	return n, p.read()
+}
+
+func (p *parser) operation() (*Operation, error) {
+	op := &Operation{}
+
+	if p.tok.kind == name {
+		switch p.tok.value {
+		case "query":
+		case "mutation", "subscription":
+			return nil, &SyntaxError{Message: p.tok.value + "s aren't supported", Line: p.tok.line, Column: p.tok.column}
+		case "fragment":
+			return nil, &SyntaxError{Message: "fragments aren't supported", Line: p.tok.line, Column: p.tok.column}
+		default:
+			return nil, p.unexpected()
+		}
+
+		if err := p.read(); err != nil {
+			return nil, err
+		}
+
+		if p.tok.kind == name {
+			op.Name = p.tok.value
+			if err := p.read(); err != nil {
+				return nil, err
+			}
+		}
+
+		if p.peek("(") {
+			vars, err := p.variables()
+			if err != nil {
+				return nil, err
+			}
+			op.Variables = vars
+		}
+	}
+
+	sel, err := p.selections()
+	if err != nil {
+		return nil, err
+	}
+	op.Selections = sel
+
+	return op, nil
+}
+
+func (p *parser) variables() ([]*Variable, error) {
+	if err := p.expect("("); err != nil {
+		return nil, err
+	}
+
+	vars := []*Variable{}
+	for !p.peek(")") {
+		if err := p.expect("$"); err != nil {
+			return nil, err
+		}
+
+		v := &Variable{}
+		var err error
+		if v.Name, err = p.name(); err != nil {
+			return nil, err
+		}
+
+		if err := p.expect(":"); err != nil {
+			return nil, err
+		}
+
+		if v.Type, err = p.typ(); err != nil {
+			return nil, err
+		}
+
+		if p.peek("!") {
+			v.Required = true
+			if err := p.read(); err != nil {
+				return nil, err
+			}
+		}
+
+		if p.peek("=") {
+			if err := p.read(); err != nil {
+				return nil, err
+			}
+			if v.Default, err = p.value(true); err != nil {
+				return nil, err
+			}
+		}
+
+		vars = append(vars, v)
+	}
+
+	return vars, p.read()
+}
+
+// typ is the name of a variable's type, e.g. "String" or "[String!]"
+func (p *parser) typ() (string, error) {
+	if !p.peek("[") {
+		return p.name()
+	}
+
+	if err := p.enter(); err != nil {
+		return "", err
+	}
+	defer p.leave()
+
+	if err := p.read(); err != nil {
+		return "", err
+	}
+
+	t, err := p.typ()
+	if err != nil {
+		return "", err
+	}
+
+	if p.peek("!") {
+		t += "!"
+		if err := p.read(); err != nil {
+			return "", err
+		}
+	}
+
+	return "[" + t + "]", p.expect("]")
+}
+
+func (p *parser) selections() ([]*Field, error) {
+	if err := p.enter(); err != nil {
+		return nil, err
+	}
+	defer p.leave()
+
+	if err := p.expect("{"); err != nil {
+		return nil, err
+	}
+
+	fields := []*Field{}
+	for !p.peek("}") {
+		if p.peek("...") {
+			return nil, &SyntaxError{Message: "fragments aren't supported", Line: p.tok.line, Column: p.tok.column}
+		}
+
+		f, err := p.field()
+		if err != nil {
+			return nil, err
+		}
+		fields = append(fields, f)
+	}
+
+	return fields, p.read()
+}
+
+func (p *parser) field() (*Field, error) {
+	f := &Field{line: p.tok.line, column: p.tok.column}
+
+	n, err := p.name()
+	if err != nil {
+		return nil, err
+	}
+	f.Name = n
+
+	if p.peek(":") {
+		if err := p.read(); err != nil {
+			return nil, err
+		}
+		if f.Name, err = p.name(); err != nil {
+			return nil, err
+		}
+		f.Alias = n
+	}
+
+	if p.peek("(") {
+		if f.Arguments, err = p.arguments(); err != nil {
+			return nil, err
+		}
+	}
+
+	for p.peek("@") {
+		if err := p.read(); err != nil {
+			return nil, err
+		}
+
+		d := &Directive{}
+		if d.Name, err = p.name(); err != nil {
+			return nil, err
+		}
+
+		if p.peek("(") {
+			if d.Arguments, err = p.arguments(); err != nil {
+				return nil, err
+			}
+		}
+
+		f.Directives = append(f.Directives, d)
+	}
+
+	if p.peek("{") {
+		if f.Selections, err = p.selections(); err != nil {
+			return nil, err
+		}
+	}
+
+	return f, nil
+}
+
+func (p *parser) arguments() (map[string]Value, error) {
+	if err := p.expect("("); err != nil {
+		return nil, err
+	}
+
+	args := map[string]Value{}
+	for !p.peek(")") {
+		n, err := p.name()
+		if err != nil {
+			return nil, err
+		}
+
+		if err := p.expect(":"); err != nil {
+			return nil, err
+		}
+
+		if args[n], err = p.value(false); err != nil {
+			return nil, err
+		}
+	}
+
+	return args, p.read()
+}
+
+// value reads a value. Constant values, like the defaults of variables, can't hold variables.
+func (p *parser) value(constant bool) (Value, error) {
+	t := p.tok
+
+	switch t.kind {
+	case intValue:
+		n, err := strconv.ParseInt(t.value, 10, 64)
+		if err != nil {
+			return nil, &SyntaxError{Message: fmt.Sprintf("invalid int %v", t.value), Line: t.line, Column: t.column}
+		}
+		return literal{n}, p.read()
+	case floatValue:
+		n, _ := strconv.ParseFloat(t.value, 64)
+		return literal{n}, p.read()
+	case stringValue:
+		return literal{t.value}, p.read()
+	case name:
+		var v interface{} = t.value // an enum
+		switch t.value {
+		case "true":
+			v = true
+		case "false":
+			v = false
+		case "null":
+			v = nil
+		}
+		return literal{v}, p.read()
+	}
+
+	switch {
+	case p.peek("$") && !constant:
+		if err := p.read(); err != nil {
+			return nil, err
+		}
+		n, err := p.name()
+		return variable(n), err
+	case p.peek("["):
+		if err := p.enter(); err != nil {
+			return nil, err
+		}
+		defer p.leave()
+
+		if err := p.read(); err != nil {
+			return nil, err
+		}
+		l := list{}
+		for !p.peek("]") {
+			v, err := p.value(constant)
+			if err != nil {
+				return nil, err
+			}
+			l = append(l, v)
+		}
+		return l, p.read()
+	case p.peek("{"):
+		if err := p.enter(); err != nil {
+			return nil, err
+		}
+		defer p.leave()
+
+		if err := p.read(); err != nil {
+			return nil, err
+		}
+		o := object{}
+		for !p.peek("}") {
+			n, err := p.name()
+			if err != nil {
+				return nil, err
+			}
+			if err := p.expect(":"); err != nil {
+				return nil, err
+			}
+			if o[n], err = p.value(constant); err != nil {
+				return nil, err
+			}
+		}
+		return o, p.read()
+	}
+
+	return nil, p.unexpected()
+}
@@ -0,0 +1,129 @@
+package graphql
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestParse(t *testing.T) {
+	doc, err := Parse(`
+		# the search page
+		query Page($q: String! = "jive", $n: [Int!]) {
+			results: search(q: $q, number: 10, ratio: -1.5e2, safe: true, filter: STRICT, tags: ["a" "b"], where: {lang: null}) {
+				documents @include(if: true) { title, id }
+			}
+		}
+		query Other { suggestions(q: "café \"au lait\"") }
+	`)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(doc.Operations) != 2 {
+		t.Fatalf("got %d operations; want 2", len(doc.Operations))
+	}
+
+	page := doc.Operations[0]
+	if page.Name != "Page" {
+		t.Fatalf("got %q; want Page", page.Name)
+	}
+
+	if len(page.Variables) != 2 {
+		t.Fatalf("got %d variables; want 2", len(page.Variables))
+	}
+
+	q, n := page.Variables[0], page.Variables[1]
+	if q.Name != "q" || q.Type != "String" || !q.Required || q.Default.value(nil) != "jive" {
+		t.Fatalf("got %+v; want a required String with a default", q)
+	}
+	if n.Name != "n" || n.Type != "[Int!]" || n.Required || n.Default != nil {
+		t.Fatalf("got %+v; want an optional [Int!]", n)
+	}
+
+	search := page.Selections[0]
+	if search.Alias != "results" || search.Name != "search" || search.Key() != "results" {
+		t.Fatalf("got %+v; want search aliased to results", search)
+	}
+
+	args := arguments(search.Arguments, map[string]interface{}{"q": "jimi"})
+	want := map[string]interface{}{
+		"q":      "jimi",
+		"number": int64(10),
+		"ratio":  -150.0,
+		"safe":   true,
+		"filter": "STRICT",
+		"tags":   []interface{}{"a", "b"},
+		"where":  map[string]interface{}{"lang": nil},
+	}
+	if !reflect.DeepEqual(args, want) {
+		t.Fatalf("got %+v; want %+v", args, want)
+	}
+
+	docs := search.Selections[0]
+	if docs.Name != "documents" || len(docs.Directives) != 1 || docs.Directives[0].Name != "include" {
+		t.Fatalf("got %+v; want documents with @include", docs)
+	}
+	if len(docs.Selections) != 2 || docs.Selections[0].Name != "title" || docs.Selections[1].Name != "id" {
+		t.Fatalf("got %+v; want title and id", docs.Selections)
+	}
+
+	other := doc.Operations[1].Selections[0]
+	if got := other.Arguments["q"].value(nil); got != `café "au lait"` {
+		t.Fatalf("got %q; want the unescaped string", got)
+	}
+}
+
+func TestParseErrors(t *testing.T) {
+	for _, c := range []struct {
+		name  string
+		query string
+		want  *SyntaxError
+	}{
+		{"empty", "", &SyntaxError{Message: "unexpected end of query", Line: 1, Column: 1}},
+		{"unclosed", "{ search(q: \"jive\") {\n  title", &SyntaxError{Message: "unexpected end of query", Line: 2, Column: 8}},
+		{"mutation", "mutation { save }", &SyntaxError{Message: "mutations aren't supported", Line: 1, Column: 1}},
+		{"fragment spread", "{ search { ...Docs } }", &SyntaxError{Message: "fragments aren't supported", Line: 1, Column: 12}},
+		{"fragment", "fragment Docs on Results { title }", &SyntaxError{Message: "fragments aren't supported", Line: 1, Column: 1}},
+		{"unterminated string", `{ search(q: "jive) }`, &SyntaxError{Message: "unterminated string", Line: 1, Column: 13}},
+		{"block string", `{ search(q: """jive""") }`, &SyntaxError{Message: "block strings aren't supported", Line: 1, Column: 13}},
+		{"bad character", "{ search(q: 'jive') }", &SyntaxError{Message: "unexpected character '\\''", Line: 1, Column: 13}},
+		{"variable in a default", "query($a: Int = $b) { search }", &SyntaxError{Message: `unexpected "$"`, Line: 1, Column: 17}},
+		{"missing colon", "{ search(q \"jive\") }", &SyntaxError{Message: `unexpected "jive"`, Line: 1, Column: 12}},
+	} {
+		t.Run(c.name, func(t *testing.T) {
+			_, err := Parse(c.query)
+			if !reflect.DeepEqual(err, c.want) {
+				t.Fatalf("got %v; want %v", err, c.want)
+			}
+		})
+	}
+}
+
+func TestParseDepth(t *testing.T) {
+	for _, c := range []struct {
+		name  string
+		query string
+		want  *SyntaxError
+	}{
+		{"lists", "{ search(q: " + strings.Repeat("[", 1<<20), &SyntaxError{Message: "nested deeper than 32 levels", Line: 1, Column: 44}},
+		{"objects", "{ search(q: " + strings.Repeat("{a:", 1<<18), &SyntaxError{Message: "nested deeper than 32 levels", Line: 1, Column: 106}},
+		{"selections", strings.Repeat("{ a ", 1<<18), &SyntaxError{Message: "nested deeper than 32 levels", Line: 1, Column: 129}},
+		{"types", "query($a: " + strings.Repeat("[", 1<<20), &SyntaxError{Message: "nested deeper than 32 levels", Line: 1, Column: 43}},
+		{"as deep as we go", "{ search(q: " + strings.Repeat("[", 31) + strings.Repeat("]", 31) + ") { title } }", nil},
+	} {
+		t.Run(c.name, func(t *testing.T) {
+			_, err := Parse(c.query)
+			if c.want == nil {
+				if err != nil {
+					t.Fatal(err)
+				}
+				return
+			}
+
+			if !reflect.DeepEqual(err, c.want) {
+				t.Fatalf("got %v; want %v", err, c.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,282 @@
+package frontend
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/jivesearch/jivesearch/frontend/apikey"
+	"github.com/jivesearch/jivesearch/frontend/cache"
+	"github.com/jivesearch/jivesearch/instant"
+	"github.com/jivesearch/jivesearch/search"
+	"github.com/jivesearch/jivesearch/search/document"
+	"github.com/jivesearch/jivesearch/singleflight"
+	"golang.org/x/text/language"
+)
+
+// mockCountSearch counts its fetches by query
+type mockCountSearch struct {
+	sync.Mutex
+	fetches map[string]int
+}
+
+func (s *mockCountSearch) Fetch(q string, f search.Filter, lang language.Tag, region language.Region, number int, offset int) (*search.Results, error) {
+	s.Lock()
+	s.fetches[q]++
+	s.Unlock()
+
+	return &search.Results{
+		Documents: []*document.Document{
+			{ID: "https://example.com/" + strings.Replace(q, " ", "-", -1), Content: document.Content{Title: q, Description: "about " + q}},
+		},
+	}, nil
+}
+
+func TestGraphQLHandler(t *testing.T) {
+	bngs, err := bangsFromConfig()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	matcher := language.NewMatcher([]language.Tag{language.English})
+
+	for _, c := range []struct {
+		name        string
+		method      string
+		contentType string
+		target      string
+		body        string
+		status      int
+		want        string
+		fetches     map[string]int
+	}{
+		{
+			name:        "combined",
+			method:      "POST",
+			contentType: "application/json",
+			target:      "/api/v1/graphql",
+			body: `{"query": "query Combined($q: String!) {` +
+				`search(q: $q) { documents { url: id title } } ` +
+				`again: search(q: $q) { documents { title } } ` +
+				`other: search(q: \"jimi hendrix\", number: 10) { documents { title } } ` +
+				`instant(q: \"reverse hello\") { type answer } ` +
+				`suggestions(q: \"r\") { suggestions }` +
+				`}", "variables": {"q": "jive"}}`,
+			status: http.StatusOK,
+			want: `{"data":{` +
+				`"search":{"documents":[{"url":"https://example.com/jive","title":"jive"}]},` +
+				`"again":{"documents":[{"title":"jive"}]},` +
+				`"other":{"documents":[{"title":"jimi hendrix"}]},` +
+				`"instant":{"type":"reverse","answer":"olleh"},` +
+				`"suggestions":{"suggestions":["radiohead","rage against the machine","red hot chili peppers","r.e.m.","rolling stones","rollins band","rusted root"]}` +
+				`}}`,
+			fetches: map[string]int{"jive": 1, "jimi hendrix": 1},
+		},
+		{
+			name:   "get",
+			method: "GET",
+			target: "/api/v1/graphql?" + url.Values{
+				"query":     {`query($q: String) { search(q: $q) { documents { title } } }`},
+				"variables": {`{"q": "jive"}`},
+			}.Encode(),
+			status:  http.StatusOK,
+			want:    `{"data":{"search":{"documents":[{"title":"jive"}]}}}`,
+			fetches: map[string]int{"jive": 1},
+		},
+		{
+			name:        "application/graphql",
+			method:      "POST",
+			contentType: "application/graphql",
+			target:      "/api/v1/graphql",
+			body:        `{ instant(q: "reverse hello") { type } }`,
+			status:      http.StatusOK,
+			want:        `{"data":{"instant":{"type":"reverse"}}}`,
+			fetches:     map[string]int{},
+		},
+		{
+			name:        "field errors",
+			method:      "POST",
+			contentType: "application/graphql",
+			target:      "/api/v1/graphql",
+			body:        `{ search(q: "") { documents { title } } instant(q: "reverse hello") { type nope } }`,
+			status:      http.StatusOK,
+			want: `{"data":{"search":null,"instant":{"type":"reverse","nope":null}},"errors":[` +
+				`{"message":"missing query","locations":[{"line":1,"column":3}],"path":["search"]},` +
+				`{"message":"Cannot query field \"nope\" on type \"Data\"","locations":[{"line":1,"column":76}],"path":["instant","nope"]}]}`,
+			fetches: map[string]int{},
+		},
+		{
+			name:        "unknown field",
+			method:      "POST",
+			contentType: "application/graphql",
+			target:      "/api/v1/graphql",
+			body:        `{ images(q: "jive") { images } }`,
+			status:      http.StatusOK,
+			want:        `{"errors":[{"message":"Cannot query field \"images\" on type \"Query\"","locations":[{"line":1,"column":3}]}]}`,
+			fetches:     map[string]int{},
+		},
+		{
+			name:    "missing query",
+			method:  "GET",
+			target:  "/api/v1/graphql",
+			status:  http.StatusBadRequest,
+			want:    "Bad Request\n",
+			fetches: map[string]int{},
+		},
+	} {
+		t.Run(c.name, func(t *testing.T) {
+			s := &mockCountSearch{fetches: map[string]int{}}
+
+			f := &Frontend{
+				Bangs:    bngs,
+				Coalesce: &singleflight.Group{},
+				Document: Document{
+					Matcher: matcher,
+				},
+				Instant: &instant.Instant{
+					QueryVar:             "q",
+					BreachFetcher:        &mockBreachFetcher{},
+					WikipediaFetcher:     &mockWikipediaFetcher{},
+					StackOverflowFetcher: &mockStackOverflowFetcher{},
+				},
+				Search:  s,
+				Suggest: &mockSuggester{},
+				Wikipedia: Wikipedia{
+					Matcher: matcher,
+				},
+			}
+
+			f.Cache.Cacher = &mockCacher{}
+			f.Cache.Instant = 10 * time.Second
+
+			r := httptest.NewRequest(c.method, c.target, strings.NewReader(c.body))
+			r.Header.Set("Accept-Language", "en-US")
+			if c.contentType != "" {
+				r.Header.Set("Content-Type", c.contentType)
+			}
+
+			w := httptest.NewRecorder()
+			appHandler(f.graphqlHandler).ServeHTTP(w, r)
+
+			if w.Code != c.status {
+				t.Fatalf("got status %d; want %d", w.Code, c.status)
+			}
+
+			if got := strings.TrimSpace(w.Body.String()); got != strings.TrimSpace(c.want) {
+				t.Fatalf("got %s; want %s", got, c.want)
+			}
+
+			if len(s.fetches) != len(c.fetches) {
+				t.Fatalf("got fetches %v; want %v", s.fetches, c.fetches)
+			}
+			for q, n := range c.fetches {
+				if s.fetches[q] != n {
+					t.Fatalf("got %d fetches of %q; want %d", s.fetches[q], q, n)
+				}
+			}
+		})
+	}
+}
+
+func TestGraphQLAPIAccess(t *testing.T) {
+	defer func(n func() time.Time) { now = n }(now)
+	now = func() time.Time {
+		return time.Date(2018, 02, 06, 23, 0, 0, 0, time.UTC)
+	}
+
+	bngs, err := bangsFromConfig()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	matcher := language.NewMatcher([]language.Tag{language.English})
+	query := `{"query": "{ a: search(q: \"jive\") { documents { title } } b: search(q: \"bebop\") { documents { title } } }"}`
+
+	for _, c := range []struct {
+		name   string
+		quota  int
+		limits map[string]Limit
+		key    bool
+		status int
+		want   string
+	}{
+		{
+			name:   "keyless",
+			status: http.StatusUnauthorized,
+			want:   "API key required",
+		},
+		{
+			name:   "quota",
+			quota:  2, // the query and one search
+			key:    true,
+			status: http.StatusOK,
+			want: `{"data":{"a":{"documents":[{"title":"jive"}]},"b":null},"errors":[` +
+				`{"message":"daily quota exceeded, retry in 1h0m0s","locations":[{"line":1,"column":48}],"path":["b"]}]}`,
+		},
+		{
+			name:   "rate limit",
+			limits: map[string]Limit{"search": {Rate: .01, Burst: 1}},
+			key:    true,
+			status: http.StatusOK,
+			want: `{"data":{"a":{"documents":[{"title":"jive"}]},"b":null},"errors":[` +
+				`{"message":"too many requests, retry in 1m40s","locations":[{"line":1,"column":48}],"path":["b"]}]}`,
+		},
+	} {
+		t.Run(c.name, func(t *testing.T) {
+			store := &apikey.Simple{}
+			if err := store.Setup(); err != nil {
+				t.Fatal(err)
+			}
+
+			secret, k, err := apikey.New("acme", c.quota, 0, 0)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if err := store.Insert(k); err != nil {
+				t.Fatal(err)
+			}
+
+			f := &Frontend{
+				APIKeys: APIKeys{
+					Store:    store,
+					Required: true,
+				},
+				Bangs:    bngs,
+				Coalesce: &singleflight.Group{},
+				Document: Document{
+					Matcher: matcher,
+				},
+				Instant:   &instant.Instant{QueryVar: "q"},
+				RateLimit: RateLimit{Limits: c.limits},
+				Search:    &mockCountSearch{fetches: map[string]int{}},
+				Wikipedia: Wikipedia{
+					Matcher: matcher,
+				},
+			}
+			f.Cache.Cacher = &cache.Simple{M: make(map[string]cache.Value)}
+
+			r := httptest.NewRequest("POST", "/api/v1/graphql", strings.NewReader(query))
+			r.Header.Set("Accept-Language", "en-US")
+			r.Header.Set("Content-Type", "application/json")
+			if c.key {
+				r.Header.Set("X-API-Key", secret)
+			}
+
+			w := httptest.NewRecorder()
+			f.Router().ServeHTTP(w, r)
+
+			if w.Code != c.status {
+				t.Fatalf("got status %d; want %d", w.Code, c.status)
+			}
+
+			if got := strings.TrimSpace(w.Body.String()); got != c.want {
+				t.Fatalf("got %s; want %s", got, c.want)
+			}
+		})
+	}
+}
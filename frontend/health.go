@@ -0,0 +1,187 @@
+package frontend
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sort"
+	"sync"
+	"time"
+)
+
+// Pinger is a dependency that can tell us if it is reachable
+type Pinger interface {
+	Ping() error
+}
+
+// PingerFunc lets an ordinary function be used as a Pinger
+type PingerFunc func() error
+
+// Ping calls fn()
+func (fn PingerFunc) Ping() error {
+	return fn()
+}
+
+// Health holds the settings for our readiness checks
+type Health struct {
+	Timeout  time.Duration // how long to wait on each dependency
+	ImageURL string        // optional. An image the image proxy should be able to fetch.
+}
+
+// DependencyStatus is the result of checking a dependency
+type DependencyStatus struct {
+	Name    string  `json:"name"`
+	OK      bool    `json:"ok"`
+	Latency float64 `json:"latency_ms"`
+	Error   string  `json:"error,omitempty"`
+}
+
+type healthResponse struct {
+	Status       string             `json:"status"`
+	Dependencies []DependencyStatus `json:"dependencies,omitempty"`
+}
+
+// healthzHandler is our liveness check. It only tells us the process is serving
+// so a slow or broken dependency doesn't get the instance restarted.
+func (f *Frontend) healthzHandler(w http.ResponseWriter, r *http.Request) {
+	writeHealth(w, http.StatusOK, &healthResponse{Status: "ok"})
+}
+
+// readyzHandler is our readiness check. It returns a 503 if any
+// dependency is unreachable so we are taken out of rotation.
+func (f *Frontend) readyzHandler(w http.ResponseWriter, r *http.Request) {
+	if Draining() {
+		writeHealth(w, http.StatusServiceUnavailable, &healthResponse{Status: "shutting down"})
+		return
+	}
+
+	resp := &healthResponse{
+		Status:       "ok",
+		Dependencies: f.checkDependencies(),
+	}
+
+	status := http.StatusOK
+	for _, d := range resp.Dependencies {
+		if !d.OK {
+			status, resp.Status = http.StatusServiceUnavailable, "unavailable"
+		}
+	}
+
+	writeHealth(w, status, resp)
+}
+
+func writeHealth(w http.ResponseWriter, status int, resp *healthResponse) {
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Cache-Control", "no-store")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(resp)
+}
+
+// dependencies are the things we need to serve a search.
+// The search backend and cache are only checked if they know how to ping.
+func (f *Frontend) dependencies() map[string]Pinger {
+	deps := map[string]Pinger{}
+
+	if p, ok := f.Search.(Pinger); ok {
+		deps["search"] = p
+	}
+
+	if p, ok := f.Cache.Cacher.(Pinger); ok {
+		deps["cache"] = p
+	}
+
+	if f.Suggest != nil {
+		deps["suggest"] = PingerFunc(func() error {
+			exists, err := f.Suggest.IndexExists()
+			if err == nil && !exists {
+				err = fmt.Errorf("index doesn't exist")
+			}
+			return err
+		})
+	}
+
+	if f.Health.ImageURL != "" && !f.Tor {
+		deps["image_proxy"] = PingerFunc(f.pingImageProxy)
+	}
+
+	return deps
+}
+
+// pingImageProxy makes sure the image proxy can reach the outside world
+func (f *Frontend) pingImageProxy() error {
+	client := f.ProxyClient
+	if client == nil {
+		client = http.DefaultClient
+	}
+
+	resp, err := client.Head(f.Health.ImageURL)
+	if err != nil {
+		return err
+	}
+	resp.Body.Close()
+
+	if resp.StatusCode >= 400 {
+		return fmt.Errorf("%v returned %d", f.Health.ImageURL, resp.StatusCode)
+	}
+
+	return nil
+}
+
+// checkDependencies pings each dependency at the same time, giving up on any that take longer than the timeout
+func (f *Frontend) checkDependencies() []DependencyStatus {
+	deps := f.dependencies()
+
+	timeout := f.Health.Timeout
+	if timeout <= 0 {
+		timeout = 2 * time.Second
+	}
+
+	statuses := make([]DependencyStatus, 0, len(deps))
+	var mu sync.Mutex
+	var wg sync.WaitGroup
+
+	for name, p := range deps {
+		wg.Add(1)
+		go func(name string, p Pinger) {
+			defer wg.Done()
+
+			start := time.Now()
+			err := ping(p, timeout)
+
+			s := DependencyStatus{
+				Name:    name,
+				OK:      err == nil,
+				Latency: float64(time.Since(start)) / float64(time.Millisecond),
+			}
+			if err != nil {
+				s.Error = err.Error()
+			}
+
+			mu.Lock()
+			statuses = append(statuses, s)
+			mu.Unlock()
+		}(name, p)
+	}
+
+	wg.Wait()
+
+	sort.Slice(statuses, func(i, j int) bool {
+		return statuses[i].Name < statuses[j].Name
+	})
+
+	return statuses
+}
+
+func ping(p Pinger, timeout time.Duration) error {
+	ch := make(chan error, 1)
+	go func() {
+		ch <- p.Ping()
+	}()
+
+	select {
+	case err := <-ch:
+		return err
+	case <-time.After(timeout):
+		return fmt.Errorf("timed out after %v", timeout)
+	}
+}
@@ -0,0 +1,166 @@
+package frontend
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/jivesearch/jivesearch/frontend/cache"
+)
+
+type mockPingSearch struct {
+	mockSearch
+	err error
+}
+
+func (m *mockPingSearch) Ping() error { return m.err }
+
+func TestHealthzHandler(t *testing.T) {
+	f := &Frontend{
+		Search: &mockPingSearch{err: fmt.Errorf("down")},
+	}
+
+	w := httptest.NewRecorder()
+	f.healthzHandler(w, httptest.NewRequest("GET", "/healthz", nil))
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("got %d; want %d", w.Code, http.StatusOK)
+	}
+
+	want := "{\"status\":\"ok\"}\n"
+	if got := w.Body.String(); got != want {
+		t.Fatalf("got %q; want %q", got, want)
+	}
+}
+
+func TestReadyzHandler(t *testing.T) {
+	img := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/missing.png" {
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer img.Close()
+
+	type want struct {
+		status int
+		deps   map[string]string
+	}
+
+	for _, c := range []struct {
+		name    string
+		search  error
+		suggest bool
+		image   string
+		slow    bool
+		want
+	}{
+		{
+			name:    "ready",
+			suggest: true,
+			image:   img.URL + "/logo.png",
+			want: want{
+				status: http.StatusOK,
+				deps:   map[string]string{"cache": "", "image_proxy": "", "search": "", "suggest": ""},
+			},
+		},
+		{
+			name:    "search down",
+			search:  fmt.Errorf("connection refused"),
+			suggest: true,
+			want: want{
+				status: http.StatusServiceUnavailable,
+				deps:   map[string]string{"cache": "", "search": "connection refused", "suggest": ""},
+			},
+		},
+		{
+			name:    "missing suggest index",
+			suggest: false,
+			want: want{
+				status: http.StatusServiceUnavailable,
+				deps:   map[string]string{"cache": "", "search": "", "suggest": "index doesn't exist"},
+			},
+		},
+		{
+			name:    "image proxy",
+			suggest: true,
+			image:   img.URL + "/missing.png",
+			want: want{
+				status: http.StatusServiceUnavailable,
+				deps: map[string]string{
+					"cache": "", "search": "", "suggest": "",
+					"image_proxy": img.URL + "/missing.png returned 404",
+				},
+			},
+		},
+		{
+			name:    "timeout",
+			suggest: true,
+			slow:    true,
+			want: want{
+				status: http.StatusServiceUnavailable,
+				deps:   map[string]string{"cache": "", "search": "timed out after 10ms", "suggest": ""},
+			},
+		},
+	} {
+		t.Run(c.name, func(t *testing.T) {
+			s := &mockPingSearch{err: c.search}
+			f := &Frontend{
+				Health: Health{
+					Timeout:  10 * time.Millisecond,
+					ImageURL: c.image,
+				},
+				ProxyClient: &http.Client{},
+				Suggest:     &mockSuggester{ex: c.suggest},
+				Search:      s,
+			}
+			f.Cache.Cacher = &cache.Simple{}
+
+			if c.slow {
+				done := make(chan struct{})
+				defer close(done)
+				f.Search = &slowSearch{done: done}
+			}
+
+			w := httptest.NewRecorder()
+			f.readyzHandler(w, httptest.NewRequest("GET", "/readyz", nil))
+
+			if w.Code != c.want.status {
+				t.Fatalf("got %d; want %d", w.Code, c.want.status)
+			}
+
+			resp := &healthResponse{}
+			if err := json.NewDecoder(w.Body).Decode(resp); err != nil {
+				t.Fatal(err)
+			}
+
+			got := map[string]string{}
+			for i, d := range resp.Dependencies {
+				if i > 0 && resp.Dependencies[i-1].Name > d.Name {
+					t.Fatalf("dependencies aren't sorted: %+v", resp.Dependencies)
+				}
+				if d.OK != (d.Error == "") {
+					t.Fatalf("%v is ok %v with error %q", d.Name, d.OK, d.Error)
+				}
+				got[d.Name] = d.Error
+			}
+
+			if !reflect.DeepEqual(got, c.want.deps) {
+				t.Fatalf("got %+v; want %+v", got, c.want.deps)
+			}
+		})
+	}
+}
+
+type slowSearch struct {
+	mockSearch
+	done chan struct{}
+}
+
+func (s *slowSearch) Ping() error {
+	<-s.done
+	return nil
+}
@@ -0,0 +1,93 @@
+package highlight
+
+import (
+	"regexp"
+)
+
+// signals are telltale bits of each language
+var signals = map[string][]*regexp.Regexp{
+	"go": {
+		regexp.MustCompile(`\bfunc\s+(?:\([^)]*\)\s*)?\w*\(`),
+		regexp.MustCompile(`\w\s*:=`),
+		regexp.MustCompile(`(?m)^\s*package\s+\w+\s*$`),
+		regexp.MustCompile(`\bfmt\.\w+\(`),
+		regexp.MustCompile(`\bif\s+err\s*!=\s*nil\b`),
+	},
+	"python": {
+		regexp.MustCompile(`(?m)^\s*def\s+\w+\(.*\)\s*:`),
+		regexp.MustCompile(`(?m)^\s*(?:from\s+[\w.]+\s+)?import\s+[\w.]+\s*$`),
+		regexp.MustCompile(`\bself\.\w+`),
+		regexp.MustCompile(`\bprint\(`),
+		regexp.MustCompile(`\belif\b`),
+	},
+	"javascript": {
+		regexp.MustCompile(`\bfunction\s*\w*\s*\(`),
+		regexp.MustCompile(`\b(?:const|let|var)\s+\w+\s*=`),
+		regexp.MustCompile(`=>`),
+		regexp.MustCompile(`\bconsole\.log\(`),
+		regexp.MustCompile(`\bdocument\.\w+|\$\(`),
+	},
+	"java": {
+		regexp.MustCompile(`\bpublic\s+static\s+void\b`),
+		regexp.MustCompile(`\bSystem\.out\.\w+`),
+		regexp.MustCompile(`\b(?:public|private|protected)\s+(?:class|[\w<>\[\]]+\s+\w+\s*\()`),
+		regexp.MustCompile(`\bnew\s+[A-Z]\w*(?:<[^>]*>)?\(`),
+	},
+	"c": {
+		regexp.MustCompile(`(?m)^\s*#\s*include\s*[<"]`),
+		regexp.MustCompile(`\bprintf\(`),
+		regexp.MustCompile(`\bint\s+main\s*\(`),
+		regexp.MustCompile(`\bstd::`),
+	},
+	"php": {
+		regexp.MustCompile(`<\?php`),
+		regexp.MustCompile(`\$\w+\s*=`),
+		regexp.MustCompile(`\$\w+->\w+`),
+		regexp.MustCompile(`\becho\s+\$`),
+	},
+	"ruby": {
+		regexp.MustCompile(`(?m)^\s*end\s*$`),
+		regexp.MustCompile(`\bputs\b`),
+		regexp.MustCompile(`(?m)^\s*def\s+\w+[^:]*$`),
+		regexp.MustCompile(`\.each\s+do\b`),
+	},
+	"bash": {
+		regexp.MustCompile(`(?m)^\s*\$\s+\w`),
+		regexp.MustCompile(`(?m)^\s*(?:sudo|apt-get|apt|yum|brew|cd|ls|grep|chmod|export)\s`),
+		regexp.MustCompile(`\becho\s+["$]`),
+		regexp.MustCompile(`(?m)^#!/bin/(?:ba)?sh`),
+		regexp.MustCompile(`\s--?[a-z][\w-]*`),
+	},
+	"sql": {
+		regexp.MustCompile(`(?i)\bselect\b[\s\S]+?\bfrom\b`),
+		regexp.MustCompile(`(?i)\binsert\s+into\b`),
+		regexp.MustCompile(`(?i)\bcreate\s+table\b`),
+		regexp.MustCompile(`(?i)\bwhere\s+\w+\s*(?:=|<|>|like|in)`),
+		regexp.MustCompile(`(?i)\bupdate\s+\w+\s+set\b`),
+	},
+}
+
+// minSignals is how many signals a language needs so prose that mentions a keyword isn't taken for code
+const minSignals = 2
+
+// Detect guesses the language of code. It is empty if the text doesn't look like code.
+func Detect(code string) string {
+	var best string
+	most := minSignals - 1
+
+	// sorted so a tie always goes the same way
+	for _, language := range []string{"bash", "c", "go", "java", "javascript", "php", "python", "ruby", "sql"} {
+		n := 0
+		for _, re := range signals[language] {
+			if re.MatchString(code) {
+				n++
+			}
+		}
+
+		if n > most {
+			best, most = language, n
+		}
+	}
+
+	return best
+}
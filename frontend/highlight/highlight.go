@@ -0,0 +1,261 @@
+// Package highlight marks up code as HTML on our server. The markup is only spans with
+// classes so it is styled by our stylesheets and needs no scripts or inline styles.
+package highlight
+
+import (
+	"html/template"
+	"regexp"
+	"strings"
+)
+
+// Token classes of the markup
+const (
+	Comment  = "hl-comment"
+	String   = "hl-string"
+	Number   = "hl-number"
+	Keyword  = "hl-keyword"
+	Operator = "hl-operator"
+)
+
+type lang struct {
+	comments []string // regular expressions for the comments
+	keywords []string
+	fold     bool // keywords are case insensitive
+	token    *regexp.Regexp
+	words    map[string]bool
+}
+
+const (
+	slashComments = `//[^\n]*|/\*[\s\S]*?\*/`
+	hashComments  = `#[^\n]*`
+	dashComments  = `--[^\n]*`
+	strs          = "\"(?:\\\\.|[^\"\\\\\\n])*\"|'(?:\\\\.|[^'\\\\\\n])*'|`[^`]*`"
+	numbers       = `\b(?:0x[0-9a-fA-F]+|\d+(?:\.\d+)?(?:[eE][+-]?\d+)?)\b`
+)
+
+var langs = map[string]*lang{
+	"go": {
+		comments: []string{slashComments},
+		keywords: []string{"break", "case", "chan", "const", "continue", "default", "defer", "else", "fallthrough", "false",
+			"for", "func", "go", "goto", "if", "import", "interface", "map", "nil", "package", "range", "return",
+			"select", "struct", "switch", "true", "type", "var"},
+	},
+	"python": {
+		comments: []string{hashComments},
+		keywords: []string{"and", "as", "assert", "async", "await", "break", "class", "continue", "def", "del", "elif",
+			"else", "except", "False", "finally", "for", "from", "global", "if", "import", "in", "is", "lambda", "None",
+			"nonlocal", "not", "or", "pass", "raise", "return", "self", "True", "try", "while", "with", "yield"},
+	},
+	"javascript": {
+		comments: []string{slashComments},
+		keywords: []string{"async", "await", "break", "case", "catch", "class", "const", "continue", "default", "delete",
+			"do", "else", "export", "extends", "false", "finally", "for", "function", "if", "import", "in", "instanceof",
+			"let", "new", "null", "of", "return", "super", "switch", "this", "throw", "true", "try", "typeof",
+			"undefined", "var", "void", "while", "yield"},
+	},
+	"java": {
+		comments: []string{slashComments},
+		keywords: []string{"abstract", "boolean", "break", "case", "catch", "char", "class", "continue", "default", "do",
+			"double", "else", "extends", "false", "final", "finally", "float", "for", "if", "implements", "import",
+			"instanceof", "int", "interface", "long", "new", "null", "package", "private", "protected", "public",
+			"return", "static", "super", "switch", "this", "throw", "throws", "true", "try", "void", "while"},
+	},
+	"c": {
+		comments: []string{slashComments, `#\s*(?:include|define|ifn?def|endif|pragma)[^\n]*`},
+		keywords: []string{"auto", "bool", "break", "case", "char", "class", "const", "continue", "default", "delete",
+			"do", "double", "else", "enum", "extern", "false", "float", "for", "if", "int", "long", "namespace",
+			"new", "nullptr", "private", "public", "return", "short", "signed", "sizeof", "static", "std", "struct",
+			"switch", "template", "this", "true", "typedef", "union", "unsigned", "using", "void", "while"},
+	},
+	"php": {
+		comments: []string{slashComments, hashComments},
+		keywords: []string{"array", "as", "break", "case", "class", "const", "continue", "echo", "else", "elseif",
+			"false", "foreach", "for", "function", "if", "isset", "new", "null", "private", "public", "return",
+			"static", "switch", "this", "true", "use", "while"},
+	},
+	"ruby": {
+		comments: []string{hashComments},
+		keywords: []string{"begin", "class", "def", "do", "else", "elsif", "end", "ensure", "false", "if", "in",
+			"module", "next", "nil", "puts", "require", "rescue", "return", "self", "then", "true", "unless",
+			"until", "when", "while", "yield"},
+	},
+	"bash": {
+		comments: []string{hashComments},
+		keywords: []string{"case", "do", "done", "echo", "elif", "else", "esac", "exit", "export", "fi", "for",
+			"function", "if", "in", "local", "return", "sudo", "then", "while"},
+	},
+	"sql": {
+		comments: []string{dashComments, slashComments},
+		keywords: []string{"alter", "and", "as", "asc", "by", "create", "delete", "desc", "distinct", "drop", "from",
+			"group", "having", "in", "index", "inner", "insert", "into", "is", "join", "key", "left", "like", "limit",
+			"not", "null", "on", "or", "order", "primary", "right", "select", "set", "table", "union", "update",
+			"values", "where"},
+		fold: true,
+	},
+}
+
+// aliases are other names for our languages, such as the tags of Stack Overflow
+var aliases = map[string]string{
+	"golang":        "go",
+	"py":            "python",
+	"js":            "javascript",
+	"typescript":    "javascript",
+	"node.js":       "javascript",
+	"reactjs":       "javascript",
+	"angular":       "javascript",
+	"angularjs":     "javascript",
+	"jquery":        "javascript",
+	"vue.js":        "javascript",
+	"json":          "javascript",
+	"c++":           "c",
+	"c#":            "c",
+	"objective-c":   "c",
+	"swift":         "c",
+	"scala":         "java",
+	"android":       "java",
+	"ruby-on-rails": "ruby",
+	"perl":          "ruby",
+	"sh":            "bash",
+	"shell":         "bash",
+	"linux":         "bash",
+	"mysql":         "sql",
+	"postgresql":    "sql",
+	"sqlite":        "sql",
+	"oracle":        "sql",
+}
+
+// generic is for the languages we don't know: C-style comments and the keywords of them all
+var generic = &lang{comments: []string{slashComments}}
+
+func init() {
+	all := map[string]bool{}
+	for _, l := range langs {
+		for _, k := range l.keywords {
+			all[strings.ToLower(k)] = true
+		}
+	}
+
+	for k := range all {
+		generic.keywords = append(generic.keywords, k)
+	}
+	generic.fold = true
+
+	for _, l := range append([]*lang{generic}, values()...) {
+		l.compile()
+	}
+}
+
+func values() []*lang {
+	ls := []*lang{}
+	for _, l := range langs {
+		ls = append(ls, l)
+	}
+	return ls
+}
+
+func (l *lang) compile() {
+	l.token = regexp.MustCompile(`(` + strings.Join(l.comments, "|") + `)|(` + strs + `)|(` + numbers + `)|(\b[A-Za-z_]\w*\b)`)
+	l.words = map[string]bool{}
+	for _, k := range l.keywords {
+		if l.fold {
+			k = strings.ToLower(k)
+		}
+		l.words[k] = true
+	}
+}
+
+// Code marks up the comments, strings, numbers and keywords of code. An empty language is detected.
+func Code(code, language string) template.HTML {
+	if language == "" {
+		language = Detect(code)
+	}
+
+	l := find(language)
+
+	var b strings.Builder
+	last := 0
+	for _, m := range l.token.FindAllStringSubmatchIndex(code, -1) {
+		var class string
+		switch {
+		case m[2] >= 0:
+			class = Comment
+		case m[4] >= 0:
+			class = String
+		case m[6] >= 0:
+			class = Number
+		default:
+			w := code[m[0]:m[1]]
+			if l.fold {
+				w = strings.ToLower(w)
+			}
+			if !l.words[w] {
+				continue
+			}
+			class = Keyword
+		}
+
+		b.WriteString(template.HTMLEscapeString(code[last:m[0]]))
+		span(&b, class, code[m[0]:m[1]])
+		last = m[1]
+	}
+
+	b.WriteString(template.HTMLEscapeString(code[last:]))
+	return template.HTML(b.String())
+}
+
+func find(language string) *lang {
+	language = strings.ToLower(language)
+	if a, ok := aliases[language]; ok {
+		language = a
+	}
+
+	if l, ok := langs[language]; ok {
+		return l
+	}
+
+	return generic
+}
+
+func span(b *strings.Builder, class, s string) {
+	b.WriteString(`<span class="` + class + `">`)
+	b.WriteString(template.HTMLEscapeString(s))
+	b.WriteString(`</span>`)
+}
+
+var regexToken = regexp.MustCompile(`(\\.)|(\[(?:\\.|[^\]\\])*\])|(\(\?(?:[:=!]|P?<\w+>)|[()])|([*+?]\??|\{\d+(?:,\d*)?\}\??)|([\^$|.])`)
+
+// Regex marks up the escapes, character classes, groups, quantifiers and anchors of a regular expression
+func Regex(pattern string) template.HTML {
+	classes := []string{String, Number, Keyword, Operator, Operator}
+
+	var b strings.Builder
+	last := 0
+	for _, m := range regexToken.FindAllStringSubmatchIndex(pattern, -1) {
+		b.WriteString(template.HTMLEscapeString(pattern[last:m[0]]))
+		for i, class := range classes {
+			if m[2*(i+1)] >= 0 {
+				span(&b, class, pattern[m[0]:m[1]])
+				break
+			}
+		}
+		last = m[1]
+	}
+
+	b.WriteString(template.HTMLEscapeString(pattern[last:]))
+	return template.HTML(b.String())
+}
+
+// Digest marks up a hex digest in blocks of 8 so it is easier to compare by eye.
+// The blocks are spaced by our stylesheet so the digest still copies as one word.
+func Digest(hex string) template.HTML {
+	var b strings.Builder
+	for i := 0; i < len(hex); i += 8 {
+		end := i + 8
+		if end > len(hex) {
+			end = len(hex)
+		}
+		span(&b, Number, hex[i:end])
+	}
+
+	return template.HTML(b.String())
+}
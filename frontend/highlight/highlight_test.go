@@ -0,0 +1,92 @@
+package highlight
+
+import (
+	"html/template"
+	"testing"
+)
+
+func TestCode(t *testing.T) {
+	for _, c := range []struct {
+		name     string
+		code     string
+		language string
+		want     template.HTML
+	}{
+		{
+			"go", `x := "hi" // greet`, "go",
+			`x := <span class="hl-string">&#34;hi&#34;</span> <span class="hl-comment">// greet</span>`,
+		},
+		{
+			"python", "def f(n):\n    return n * 2 # double", "python",
+			`<span class="hl-keyword">def</span> f(n):` + "\n" + `    <span class="hl-keyword">return</span> n * <span class="hl-number">2</span> <span class="hl-comment"># double</span>`,
+		},
+		{
+			"alias", "SELECT * FROM t -- all", "postgresql",
+			`<span class="hl-keyword">SELECT</span> * <span class="hl-keyword">FROM</span> t <span class="hl-comment">-- all</span>`,
+		},
+		{
+			"escaped", `if a < b && c > 0x1F { }`, "go",
+			`<span class="hl-keyword">if</span> a &lt; b &amp;&amp; c &gt; <span class="hl-number">0x1F</span> { }`,
+		},
+		{
+			"markup in a string", `x = "</code><script>"`, "javascript",
+			`x = <span class="hl-string">&#34;&lt;/code&gt;&lt;script&gt;&#34;</span>`,
+		},
+		{
+			"unknown language", "return nil; // done", "cobol",
+			`<span class="hl-keyword">return</span> <span class="hl-keyword">nil</span>; <span class="hl-comment">// done</span>`,
+		},
+		{
+			"detected", "import os\nprint(os.getcwd())", "",
+			`<span class="hl-keyword">import</span> os` + "\n" + `print(os.getcwd())`,
+		},
+	} {
+		t.Run(c.name, func(t *testing.T) {
+			got := Code(c.code, c.language)
+			if got != c.want {
+				t.Fatalf("got %q; want %q", got, c.want)
+			}
+		})
+	}
+}
+
+func TestDetect(t *testing.T) {
+	for _, c := range []struct {
+		code string
+		want string
+	}{
+		{"package main\n\nfunc main() {\n\tx := 1\n\tfmt.Println(x)\n}", "go"},
+		{"import os\n\ndef cwd():\n    print(os.getcwd())", "python"},
+		{"const add = (a, b) => a + b;\nconsole.log(add(1, 2));", "javascript"},
+		{"public static void main(String[] args) {\n    System.out.println(\"hi\");\n}", "java"},
+		{"#include <stdio.h>\nint main() {\n    printf(\"hi\");\n}", "c"},
+		{"<?php\n$name = 'bob';\necho $name;", "php"},
+		{"[1, 2].each do |n|\n  puts n\nend", "ruby"},
+		{"$ sudo apt-get install git\n$ git --version", "bash"},
+		{"SELECT name FROM users WHERE id = 1", "sql"},
+		{"Select the best option from the list of plans where you live.", ""},
+		{"The function of the heart is to pump blood. Print this page for later.", ""},
+	} {
+		t.Run(c.want, func(t *testing.T) {
+			if got := Detect(c.code); got != c.want {
+				t.Fatalf("got %q; want %q", got, c.want)
+			}
+		})
+	}
+}
+
+func TestRegex(t *testing.T) {
+	got := Regex(`^(\d+)[a-z]*?$`)
+	want := template.HTML(`<span class="hl-operator">^</span><span class="hl-keyword">(</span><span class="hl-string">\d</span><span class="hl-operator">+</span><span class="hl-keyword">)</span><span class="hl-number">[a-z]</span><span class="hl-operator">*?</span><span class="hl-operator">$</span>`)
+	if got != want {
+		t.Fatalf("got %q; want %q", got, want)
+	}
+}
+
+func TestDigest(t *testing.T) {
+	got := Digest("5d41402abc4b2a76b9719d911017c592")
+	want := template.HTML(`<span class="hl-number">5d41402a</span><span class="hl-number">bc4b2a76</span><span class="hl-number">b9719d91</span><span class="hl-number">1017c592</span>`)
+	if got != want {
+		t.Fatalf("got %q; want %q", got, want)
+	}
+}
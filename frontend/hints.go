@@ -0,0 +1,36 @@
+package frontend
+
+// Hints describe the results on a page for keyboard navigation and screen readers.
+// They are embedded in the search page for our JavaScript and sent in our json responses
+// so results loaded by infinite scroll carry on the numbering.
+type Hints struct {
+	Count    int64             `json:"count"`
+	First    int               `json:"first"` // the position of the first result on the page, 0 without results
+	Last     int               `json:"last"`
+	Announce string            `json:"announce"` // read by screen readers when results arrive
+	Keys     map[string]string `json:"keys"`
+}
+
+// keys are the shortcuts for moving between results
+var keys = map[string]string{
+	"j": "next",
+	"k": "previous",
+	"/": "search",
+}
+
+func newHints(d data) *Hints {
+	h := &Hints{
+		Keys: keys,
+	}
+
+	if d.Search == nil || len(d.Search.Documents) == 0 {
+		h.Announce = d.Context.Tr("No results for") + " " + d.Context.Q
+		return h
+	}
+
+	h.Count = d.Search.Count
+	h.First = d.Context.Offset() + 1
+	h.Last = d.Context.Offset() + len(d.Search.Documents)
+	h.Announce = d.Context.Tr("Results %d to %d of %d", h.First, h.Last, h.Count)
+	return h
+}
@@ -0,0 +1,82 @@
+package frontend
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/jivesearch/jivesearch/search"
+	"github.com/jivesearch/jivesearch/search/document"
+	"golang.org/x/text/language"
+)
+
+func TestNewHints(t *testing.T) {
+	docs := []*document.Document{{ID: "https://example.com"}, {ID: "https://example.org"}}
+
+	for _, c := range []struct {
+		name    string
+		context *Context
+		results *search.Results
+		want    *Hints
+	}{
+		{
+			"first page", &Context{Q: "jive", Number: 25, Page: 1},
+			&search.Results{Count: 1234, Documents: docs},
+			&Hints{Count: 1234, First: 1, Last: 2, Announce: "Results 1 to 2 of 1,234", Keys: keys},
+		},
+		{
+			"third page", &Context{Q: "jive", Number: 25, Page: 3},
+			&search.Results{Count: 1234, Documents: docs},
+			&Hints{Count: 1234, First: 51, Last: 52, Announce: "Results 51 to 52 of 1,234", Keys: keys},
+		},
+		{
+			"translated", &Context{Q: "jive", Number: 25, Page: 1, Preferred: []language.Tag{language.German}},
+			&search.Results{Count: 1234, Documents: docs},
+			&Hints{Count: 1234, First: 1, Last: 2, Announce: "Ergebnisse 1 bis 2 von 1.234", Keys: keys},
+		},
+		{
+			"empty", &Context{Q: "jive", Number: 25, Page: 1},
+			&search.Results{},
+			&Hints{Announce: "No results for jive", Keys: keys},
+		},
+	} {
+		t.Run(c.name, func(t *testing.T) {
+			got := newHints(data{Context: c.context, Results: Results{Search: c.results}})
+			if !reflect.DeepEqual(got, c.want) {
+				t.Fatalf("got %+v; want %+v", got, c.want)
+			}
+		})
+	}
+}
+
+func TestHintsTemplate(t *testing.T) {
+	ParseTemplates()
+
+	d := data{
+		Brand:   Brand{Name: "Jive Search"},
+		Context: &Context{Q: "jive", Number: 25, Page: 2},
+		Results: Results{
+			Search: &search.Results{
+				Count:     30,
+				Documents: []*document.Document{{ID: "https://example.com"}},
+			},
+		},
+	}
+	d.Hints = newHints(d)
+
+	var b strings.Builder
+	if err := templates["search"].Execute(&b, d); err != nil {
+		t.Fatal(err)
+	}
+
+	for _, want := range []string{
+		`<script type="application/json" id="hints">{"count":30,"first":26,"last":26,`,
+		`role="status" aria-live="polite">Results 26 to 26 of 30</div>`,
+		`role="list" aria-label="Search results"`,
+		`role="listitem" data-index="26" aria-posinset="26" aria-setsize="30"`,
+	} {
+		if !strings.Contains(b.String(), want) {
+			t.Fatalf("want %q in our page", want)
+		}
+	}
+}
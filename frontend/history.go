@@ -0,0 +1,153 @@
+package frontend
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+
+	"github.com/jivesearch/jivesearch/frontend/history"
+	"github.com/jivesearch/jivesearch/log"
+	"github.com/jivesearch/jivesearch/suggest"
+)
+
+// History syncs the search history of users who opt in. It is kept in their browser
+// and sealed with a key that never leaves it except in the header of our own requests.
+type History struct {
+	history.Store
+}
+
+const historyKeyHeader = "X-History-Key"
+
+// maxHistoryBody is plenty for history.Max queries
+const maxHistoryBody = 64 << 10
+
+// historyHandler gets (GET), merges the user's queries into (POST) or forgets (DELETE) their history
+func (f *Frontend) historyHandler(w http.ResponseWriter, r *http.Request) *response {
+	resp := &response{
+		status:   http.StatusOK,
+		template: "json",
+	}
+
+	if f.History.Store == nil {
+		resp.status, resp.template, resp.err = http.StatusNotFound, "", fmt.Errorf("search history is disabled")
+		return resp
+	}
+
+	w.Header().Set("Cache-Control", "no-store")
+
+	k, err := history.ParseKey(r.Header.Get(historyKeyHeader))
+	if err != nil {
+		resp.status, resp.template, resp.err = http.StatusBadRequest, "", err
+		return resp
+	}
+
+	if r.Method == http.MethodDelete {
+		if err := f.History.Delete(k.ID()); err != nil {
+			resp.status, resp.template, resp.err = http.StatusInternalServerError, "", err
+			return resp
+		}
+
+		resp.status, resp.template = http.StatusNoContent, ""
+		return resp
+	}
+
+	h, err := f.history(k)
+	if err != nil {
+		resp.status, resp.template, resp.err = http.StatusInternalServerError, "", err
+		if err == history.ErrInvalidKey {
+			resp.status = http.StatusBadRequest
+		}
+		return resp
+	}
+
+	if r.Method == http.MethodPost {
+		recent := &history.History{}
+		if err := json.NewDecoder(io.LimitReader(r.Body, maxHistoryBody)).Decode(recent); err != nil {
+			resp.status, resp.template, resp.err = http.StatusBadRequest, "", err
+			return resp
+		}
+
+		h.Merge(recent.Queries...)
+
+		sealed, err := k.Seal(h)
+		if err == nil {
+			err = f.History.Put(k.ID(), sealed, now())
+		}
+
+		if err != nil {
+			resp.status, resp.template, resp.err = http.StatusInternalServerError, "", err
+			return resp
+		}
+	}
+
+	resp.data = h
+	return resp
+}
+
+// history opens the user's history, which is empty if they haven't synced yet
+func (f *Frontend) history(k *history.Key) (*history.History, error) {
+	sealed, err := f.History.Get(k.ID())
+	switch err {
+	case nil:
+		return k.Open(sealed)
+	case history.ErrNotFound:
+		return &history.History{Queries: []history.Query{}}, nil
+	default:
+		return nil, err
+	}
+}
+
+// userHistory is the history of the user making the request, if they opted in
+func (f *Frontend) userHistory(key string) *history.History {
+	if f.History.Store == nil || strings.TrimSpace(key) == "" {
+		return nil
+	}
+
+	k, err := history.ParseKey(key)
+	if err != nil {
+		return nil
+	}
+
+	h, err := f.history(k)
+	if err != nil {
+		log.Debug.Println(err)
+		return nil
+	}
+
+	return h
+}
+
+// withHistory puts the user's past queries that complete q ahead of everyone else's.
+// !bang suggestions are left alone.
+func withHistory(res interface{}, q string, h *history.History) interface{} {
+	sr, ok := res.(suggest.Results)
+	if !ok || h == nil {
+		return res
+	}
+
+	const size = 10
+
+	suggestions := h.Complete(q, size)
+	for _, s := range sr.Suggestions {
+		if len(suggestions) == size {
+			break
+		}
+
+		dup := false
+		for _, p := range suggestions {
+			if strings.EqualFold(p, s) {
+				dup = true
+				break
+			}
+		}
+
+		if !dup {
+			suggestions = append(suggestions, s)
+		}
+	}
+
+	sr.Suggestions = suggestions
+	return sr
+}
@@ -0,0 +1,172 @@
+// Package history keeps the opt-in search history of our users.
+// It is sealed with a key that only the user's browser holds, so we can
+// only read it while handling a request that carries the key.
+package history
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"sort"
+	"strings"
+	"time"
+)
+
+// ErrNotFound indicates we have no history for a key
+var ErrNotFound = errors.New("history not found")
+
+// ErrInvalidKey indicates a key that isn't 32 bytes of url-safe base64
+var ErrInvalidKey = errors.New("invalid history key")
+
+// Max is the number of queries we keep
+const Max = 100
+
+// Store outlines the methods to persist sealed histories
+type Store interface {
+	Setup() error
+	Get(id string) ([]byte, error)
+	Put(id string, sealed []byte, updated time.Time) error
+	Delete(id string) error
+}
+
+// Query is a search the user made
+type Query struct {
+	Q    string    `json:"q"`
+	Time time.Time `json:"time"`
+}
+
+// History is a user's queries, most recent first
+type History struct {
+	Queries []Query `json:"queries"`
+}
+
+// Merge adds queries, e.g. those the browser recorded since it last synced.
+// A query searched more than once keeps its latest time.
+func (h *History) Merge(queries ...Query) {
+	latest := map[string]Query{}
+	for _, q := range append(h.Queries, queries...) {
+		q.Q = strings.TrimSpace(q.Q)
+		if q.Q == "" {
+			continue
+		}
+
+		k := strings.ToLower(q.Q)
+		if l, ok := latest[k]; !ok || q.Time.After(l.Time) {
+			latest[k] = q
+		}
+	}
+
+	h.Queries = h.Queries[:0]
+	for _, q := range latest {
+		h.Queries = append(h.Queries, q)
+	}
+
+	sort.Slice(h.Queries, func(i, j int) bool {
+		if h.Queries[i].Time.Equal(h.Queries[j].Time) {
+			return h.Queries[i].Q < h.Queries[j].Q
+		}
+		return h.Queries[i].Time.After(h.Queries[j].Time)
+	})
+
+	if len(h.Queries) > Max {
+		h.Queries = h.Queries[:Max]
+	}
+}
+
+// Complete returns up to n past queries that start with the prefix, most recent first
+func (h *History) Complete(prefix string, n int) []string {
+	prefix = strings.ToLower(strings.TrimSpace(prefix))
+	res := []string{}
+
+	if prefix == "" {
+		return res
+	}
+
+	for _, q := range h.Queries {
+		if len(res) == n {
+			break
+		}
+
+		if l := strings.ToLower(q.Q); strings.HasPrefix(l, prefix) && l != prefix {
+			res = append(res, q.Q)
+		}
+	}
+
+	return res
+}
+
+// Key seals a user's history
+type Key struct {
+	secret []byte
+}
+
+// ParseKey parses the key sent by the user's browser
+func ParseKey(s string) (*Key, error) {
+	b, err := base64.RawURLEncoding.DecodeString(strings.TrimRight(strings.TrimSpace(s), "="))
+	if err != nil || len(b) != 32 {
+		return nil, ErrInvalidKey
+	}
+
+	return &Key{secret: b}, nil
+}
+
+// ID is what the history is stored under. The key can't be derived from it.
+func (k *Key) ID() string {
+	h := sha256.Sum256(append([]byte("history:"), k.secret...))
+	return hex.EncodeToString(h[:])
+}
+
+// Seal encrypts a history with AES-256-GCM
+func (k *Key) Seal(h *History) ([]byte, error) {
+	b, err := json.Marshal(h)
+	if err != nil {
+		return nil, err
+	}
+
+	gcm, err := k.gcm()
+	if err != nil {
+		return nil, err
+	}
+
+	nonce := make([]byte, gcm.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		return nil, err
+	}
+
+	return gcm.Seal(nonce, nonce, b, nil), nil
+}
+
+// Open decrypts a history sealed with the same key
+func (k *Key) Open(sealed []byte) (*History, error) {
+	gcm, err := k.gcm()
+	if err != nil {
+		return nil, err
+	}
+
+	if len(sealed) < gcm.NonceSize() {
+		return nil, ErrInvalidKey
+	}
+
+	b, err := gcm.Open(nil, sealed[:gcm.NonceSize()], sealed[gcm.NonceSize():], nil)
+	if err != nil {
+		return nil, ErrInvalidKey
+	}
+
+	h := &History{}
+	err = json.Unmarshal(b, h)
+	return h, err
+}
+
+func (k *Key) gcm() (cipher.AEAD, error) {
+	block, err := aes.NewCipher(k.secret)
+	if err != nil {
+		return nil, err
+	}
+
+	return cipher.NewGCM(block)
+}
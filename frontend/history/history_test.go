@@ -0,0 +1,131 @@
+package history
+
+import (
+	"bytes"
+	"encoding/base64"
+	"fmt"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestMerge(t *testing.T) {
+	day := time.Date(2018, 02, 06, 11, 0, 0, 0, time.UTC)
+
+	h := &History{
+		Queries: []Query{
+			{"jive talkin", day.Add(2 * time.Hour)},
+			{"bee gees", day},
+		},
+	}
+
+	h.Merge(
+		Query{"Bee Gees", day.Add(3 * time.Hour)},
+		Query{"  ", day.Add(4 * time.Hour)},
+		Query{"stayin alive", day.Add(time.Hour)},
+	)
+
+	want := []Query{
+		{"Bee Gees", day.Add(3 * time.Hour)},
+		{"jive talkin", day.Add(2 * time.Hour)},
+		{"stayin alive", day.Add(time.Hour)},
+	}
+
+	if !reflect.DeepEqual(h.Queries, want) {
+		t.Fatalf("got %+v; want %+v", h.Queries, want)
+	}
+
+	for i := 0; i < 2*Max; i++ {
+		h.Merge(Query{fmt.Sprintf("query %d", i), day.Add(time.Duration(i) * time.Minute)})
+	}
+
+	if len(h.Queries) != Max {
+		t.Fatalf("got %d queries; want %d", len(h.Queries), Max)
+	}
+}
+
+func TestComplete(t *testing.T) {
+	h := &History{
+		Queries: []Query{
+			{Q: "jive talkin"},
+			{Q: "Jive Records"},
+			{Q: "jive"},
+			{Q: "bee gees"},
+		},
+	}
+
+	for _, c := range []struct {
+		prefix string
+		n      int
+		want   []string
+	}{
+		{"jive", 10, []string{"jive talkin", "Jive Records"}},
+		{"JIVE", 1, []string{"jive talkin"}},
+		{"", 10, []string{}},
+		{"abba", 10, []string{}},
+	} {
+		t.Run(c.prefix, func(t *testing.T) {
+			if got := h.Complete(c.prefix, c.n); !reflect.DeepEqual(got, c.want) {
+				t.Fatalf("got %q; want %q", got, c.want)
+			}
+		})
+	}
+}
+
+func TestKey(t *testing.T) {
+	secret := base64.RawURLEncoding.EncodeToString(bytes.Repeat([]byte{7}, 32))
+
+	for _, c := range []struct {
+		name string
+		key  string
+		err  error
+	}{
+		{"valid", secret, nil},
+		{"padded", secret + "=", nil},
+		{"short", base64.RawURLEncoding.EncodeToString([]byte("short")), ErrInvalidKey},
+		{"not base64", "not a key!", ErrInvalidKey},
+		{"empty", "", ErrInvalidKey},
+	} {
+		t.Run(c.name, func(t *testing.T) {
+			if _, err := ParseKey(c.key); err != c.err {
+				t.Fatalf("got %v; want %v", err, c.err)
+			}
+		})
+	}
+
+	k, err := ParseKey(secret)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if id := k.ID(); len(id) != 64 || id == secret {
+		t.Fatalf("got id %q; want a hash of the key", id)
+	}
+
+	h := &History{
+		Queries: []Query{{"jive talkin", time.Date(2018, 02, 06, 11, 0, 0, 0, time.UTC)}},
+	}
+
+	sealed, err := k.Seal(h)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if bytes.Contains(sealed, []byte("jive")) {
+		t.Fatalf("%q isn't sealed", sealed)
+	}
+
+	got, err := k.Open(sealed)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !reflect.DeepEqual(got, h) {
+		t.Fatalf("got %+v; want %+v", got, h)
+	}
+
+	other, _ := ParseKey(base64.RawURLEncoding.EncodeToString(bytes.Repeat([]byte{8}, 32)))
+	if _, err := other.Open(sealed); err != ErrInvalidKey {
+		t.Fatalf("opened with another key: got %v; want %v", err, ErrInvalidKey)
+	}
+}
@@ -0,0 +1,55 @@
+package history
+
+import (
+	"database/sql"
+	"time"
+)
+
+// PostgreSQL stores sealed histories in PostgreSQL
+type PostgreSQL struct {
+	*sql.DB
+}
+
+const historyTable = "history"
+
+// Setup creates our table if it doesn't exist
+func (p *PostgreSQL) Setup() error {
+	_, err := p.DB.Exec(`
+		CREATE TABLE IF NOT EXISTS ` + historyTable + ` (
+			id text PRIMARY KEY,
+			sealed bytea NOT NULL,
+			updated timestamptz NOT NULL
+		);
+	`)
+
+	return err
+}
+
+// Get retrieves a sealed history
+func (p *PostgreSQL) Get(id string) ([]byte, error) {
+	var b []byte
+
+	err := p.DB.QueryRow(`SELECT sealed FROM `+historyTable+` WHERE id = $1`, id).Scan(&b)
+	if err == sql.ErrNoRows {
+		return nil, ErrNotFound
+	}
+
+	return b, err
+}
+
+// Put stores a sealed history, replacing any before it
+func (p *PostgreSQL) Put(id string, sealed []byte, updated time.Time) error {
+	_, err := p.DB.Exec(`
+		INSERT INTO `+historyTable+` (id, sealed, updated) VALUES ($1, $2, $3)
+		ON CONFLICT (id) DO UPDATE SET sealed = EXCLUDED.sealed, updated = EXCLUDED.updated`,
+		id, sealed, updated,
+	)
+
+	return err
+}
+
+// Delete forgets a history
+func (p *PostgreSQL) Delete(id string) error {
+	_, err := p.DB.Exec(`DELETE FROM `+historyTable+` WHERE id = $1`, id)
+	return err
+}
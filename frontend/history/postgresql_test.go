@@ -0,0 +1,61 @@
+package history
+
+import (
+	"testing"
+	"time"
+
+	sqlmock "gopkg.in/DATA-DOG/go-sqlmock.v2"
+)
+
+func TestPostgreSQL(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+
+	p := &PostgreSQL{DB: db}
+	updated := time.Date(2018, 02, 06, 11, 0, 0, 0, time.UTC)
+
+	mock.ExpectExec("CREATE TABLE IF NOT EXISTS history").WillReturnResult(sqlmock.NewResult(0, 0))
+
+	if err := p.Setup(); err != nil {
+		t.Fatal(err)
+	}
+
+	mock.ExpectExec("INSERT INTO history (.+) ON CONFLICT").WithArgs("abc", []byte("sealed"), updated).
+		WillReturnResult(sqlmock.NewResult(1, 1))
+
+	if err := p.Put("abc", []byte("sealed"), updated); err != nil {
+		t.Fatal(err)
+	}
+
+	mock.ExpectQuery("SELECT sealed FROM history WHERE id").WithArgs("abc").
+		WillReturnRows(sqlmock.NewRows([]string{"sealed"}).AddRow([]byte("sealed")))
+
+	got, err := p.Get("abc")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if string(got) != "sealed" {
+		t.Fatalf("got %q; want %q", got, "sealed")
+	}
+
+	mock.ExpectQuery("SELECT sealed FROM history WHERE id").WithArgs("wrong").
+		WillReturnRows(sqlmock.NewRows([]string{"sealed"}))
+
+	if _, err := p.Get("wrong"); err != ErrNotFound {
+		t.Fatalf("got %v; want %v", err, ErrNotFound)
+	}
+
+	mock.ExpectExec("DELETE FROM history WHERE id").WithArgs("abc").WillReturnResult(sqlmock.NewResult(0, 1))
+
+	if err := p.Delete("abc"); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Fatal(err)
+	}
+}
@@ -0,0 +1,52 @@
+package history
+
+import (
+	"sync"
+	"time"
+)
+
+// Simple is an in-memory Store. Histories are lost on restart so it is only suitable for testing.
+type Simple struct {
+	mu        sync.Mutex
+	histories map[string][]byte
+}
+
+// Setup initializes the store
+func (s *Simple) Setup() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.histories = make(map[string][]byte)
+	return nil
+}
+
+// Get retrieves a sealed history
+func (s *Simple) Get(id string) ([]byte, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	b, ok := s.histories[id]
+	if !ok {
+		return nil, ErrNotFound
+	}
+
+	return append([]byte{}, b...), nil
+}
+
+// Put stores a sealed history, replacing any before it
+func (s *Simple) Put(id string, sealed []byte, updated time.Time) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.histories[id] = append([]byte{}, sealed...)
+	return nil
+}
+
+// Delete forgets a history
+func (s *Simple) Delete(id string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	delete(s.histories, id)
+	return nil
+}
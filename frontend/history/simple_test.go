@@ -0,0 +1,38 @@
+package history
+
+import (
+	"testing"
+	"time"
+)
+
+func TestSimple(t *testing.T) {
+	s := &Simple{}
+	if err := s.Setup(); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := s.Get("abc"); err != ErrNotFound {
+		t.Fatalf("got %v; want %v", err, ErrNotFound)
+	}
+
+	if err := s.Put("abc", []byte("sealed"), time.Now()); err != nil {
+		t.Fatal(err)
+	}
+
+	got, err := s.Get("abc")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if string(got) != "sealed" {
+		t.Fatalf("got %q; want %q", got, "sealed")
+	}
+
+	if err := s.Delete("abc"); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := s.Get("abc"); err != ErrNotFound {
+		t.Fatalf("got %v after deleting; want %v", err, ErrNotFound)
+	}
+}
@@ -0,0 +1,113 @@
+package frontend
+
+import (
+	"bytes"
+	"encoding/base64"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/jivesearch/jivesearch/bangs"
+	"github.com/jivesearch/jivesearch/frontend/history"
+	"github.com/jivesearch/jivesearch/suggest"
+)
+
+func TestHistoryHandler(t *testing.T) {
+	key := base64.RawURLEncoding.EncodeToString(bytes.Repeat([]byte{7}, 32))
+
+	s := &history.Simple{}
+	if err := s.Setup(); err != nil {
+		t.Fatal(err)
+	}
+
+	f := &Frontend{}
+	f.History.Store = s
+
+	for _, c := range []struct {
+		name   string
+		method string
+		key    string
+		body   string
+		status int
+		want   []string
+	}{
+		{"bad key", "GET", "abc", "", http.StatusBadRequest, nil},
+		{"nothing synced", "GET", key, "", http.StatusOK, []string{}},
+		{"sync", "POST", key, `{"queries":[{"q":"jive talkin","time":"2018-02-06T11:00:00Z"}]}`, http.StatusOK, []string{"jive talkin"}},
+		{
+			"merge", "POST", key, `{"queries":[{"q":"bee gees","time":"2018-02-06T12:00:00Z"}]}`,
+			http.StatusOK, []string{"bee gees", "jive talkin"},
+		},
+		{"bad body", "POST", key, `{"queries":`, http.StatusBadRequest, nil},
+		{"get", "GET", key, "", http.StatusOK, []string{"bee gees", "jive talkin"}},
+		{"delete", "DELETE", key, "", http.StatusNoContent, nil},
+		{"deleted", "GET", key, "", http.StatusOK, []string{}},
+	} {
+		t.Run(c.name, func(t *testing.T) {
+			r := httptest.NewRequest(c.method, "/history", strings.NewReader(c.body))
+			r.Header.Set(historyKeyHeader, c.key)
+
+			resp := f.historyHandler(httptest.NewRecorder(), r)
+			if resp.status != c.status {
+				t.Fatalf("got status %d; want %d (%v)", resp.status, c.status, resp.err)
+			}
+
+			if c.want == nil {
+				return
+			}
+
+			got := []string{}
+			for _, q := range resp.data.(*history.History).Queries {
+				got = append(got, q.Q)
+			}
+
+			if !reflect.DeepEqual(got, c.want) {
+				t.Fatalf("got %q; want %q", got, c.want)
+			}
+		})
+	}
+
+	f.History.Store = nil
+	r := httptest.NewRequest("GET", "/history", nil)
+	r.Header.Set(historyKeyHeader, key)
+
+	if resp := f.historyHandler(httptest.NewRecorder(), r); resp.status != http.StatusNotFound {
+		t.Fatalf("got status %d when disabled; want %d", resp.status, http.StatusNotFound)
+	}
+}
+
+func TestWithHistory(t *testing.T) {
+	h := &history.History{
+		Queries: []history.Query{
+			{Q: "radiohead live"},
+			{Q: "rush"},
+		},
+	}
+
+	for _, c := range []struct {
+		name string
+		res  interface{}
+		h    *history.History
+		want interface{}
+	}{
+		{
+			"boosted", suggest.Results{Suggestions: []string{"Rush", "red hot chili peppers"}}, h,
+			suggest.Results{Suggestions: []string{"radiohead live", "rush", "red hot chili peppers"}},
+		},
+		{
+			"no history", suggest.Results{Suggestions: []string{"rush"}}, nil,
+			suggest.Results{Suggestions: []string{"rush"}},
+		},
+		{
+			"bangs", bangs.Results{}, h, bangs.Results{},
+		},
+	} {
+		t.Run(c.name, func(t *testing.T) {
+			if got := withHistory(c.res, "r", c.h); !reflect.DeepEqual(got, c.want) {
+				t.Fatalf("got %+v; want %+v", got, c.want)
+			}
+		})
+	}
+}
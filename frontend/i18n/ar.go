@@ -0,0 +1,109 @@
+package i18n
+
+var arabic = map[string]string{
+	"Search":                          "بحث",
+	"All":                             "الكل",
+	"Images":                          "صور",
+	"Local":                           "محلي",
+	"Maps":                            "خرائط",
+	"Shopping":                        "تسوق",
+	"Scholar":                         "الأبحاث العلمية",
+	"Files":                           "ملفات",
+	"SafeSearch":                      "البحث الآمن",
+	"On":                              "مفعّل",
+	"Off":                             "متوقف",
+	"Strict":                          "صارم",
+	"Moderate":                        "معتدل",
+	"Turn on SafeSearch":              "تفعيل البحث الآمن",
+	"Size":                            "الحجم",
+	"Aspect ratio":                    "نسبة العرض إلى الارتفاع",
+	"Color":                           "اللون",
+	"Type":                            "النوع",
+	"License":                         "الترخيص",
+	"Date":                            "التاريخ",
+	"Any time":                        "أي وقت",
+	"Past day":                        "آخر يوم",
+	"Past week":                       "آخر أسبوع",
+	"Past month":                      "آخر شهر",
+	"Past year":                       "آخر سنة",
+	"Any size":                        "أي حجم",
+	"Large":                           "كبير",
+	"Medium":                          "متوسط",
+	"Small":                           "صغير",
+	"Icon":                            "رمز",
+	"Any aspect":                      "أي نسبة",
+	"Tall":                            "طولي",
+	"Square":                          "مربع",
+	"Wide":                            "عريض",
+	"Panoramic":                       "بانورامي",
+	"Any color":                       "أي لون",
+	"Red":                             "أحمر",
+	"Orange":                          "برتقالي",
+	"Yellow":                          "أصفر",
+	"Green":                           "أخضر",
+	"Teal":                            "أزرق مخضر",
+	"Blue":                            "أزرق",
+	"Purple":                          "بنفسجي",
+	"Pink":                            "وردي",
+	"White":                           "أبيض",
+	"Gray":                            "رمادي",
+	"Black":                           "أسود",
+	"Brown":                           "بني",
+	"Any type":                        "أي نوع",
+	"Photo":                           "صورة فوتوغرافية",
+	"Clip art":                        "قصاصات فنية",
+	"Animated":                        "متحركة",
+	"Transparent":                     "شفافة",
+	"Any license":                     "أي ترخيص",
+	"Public domain":                   "ملكية عامة",
+	"Creative Commons":                "المشاع الإبداعي",
+	"All rights reserved":             "جميع الحقوق محفوظة",
+	"Did you mean":                    "هل تقصد",
+	"No results for":                  "لا توجد نتائج عن",
+	"Showing results for %v instead.": "يتم عرض نتائج %v بدلاً من ذلك.",
+	"Suggestions:":                    "اقتراحات:",
+	"Please check your spelling.":     "تحقق من الإملاء.",
+	"Try a more general query.":       "جرّب عبارة بحث أعم.",
+	"No places found for":             "لم يتم العثور على أماكن عن",
+	`Try adding a location, e.g. "%v near boston".`: `جرّب إضافة موقع، مثل "%v near boston".`,
+	"Directions":            "الاتجاهات",
+	"Data from %v":          "البيانات من %v",
+	"Sort":                  "ترتيب",
+	"Best match":            "الأفضل تطابقًا",
+	"Lowest price":          "الأقل سعرًا",
+	"Highest price":         "الأعلى سعرًا",
+	"Min price":             "أدنى سعر",
+	"Max price":             "أعلى سعر",
+	"Any currency":          "أي عملة",
+	"No products found for": "لم يتم العثور على منتجات لـ",
+	"Cited by %d":           "استشهد به %d",
+	"No papers found for":   "لم يتم العثور على أوراق بحثية لـ",
+	"%d results":            "%d نتيجة",
+	"People also ask":       "أسئلة ذات صلة",
+	"Related searches":      "عمليات بحث ذات صلة",
+	"Cached":                "نسخة مخبأة",
+	"View a copy of this page through our proxy": "عرض نسخة من هذه الصفحة عبر الوكيل الخاص بنا",
+	"More results from %v":                       "مزيد من النتائج من %v",
+	"More results for %v":                        "المزيد من النتائج عن %v",
+	"Share":                                      "مشاركة",
+	"Previous":                                   "السابق",
+	"Next":                                       "التالي",
+	"Close":                                      "إغلاق",
+	"How we protect your privacy":                "كيف نحمي خصوصيتك",
+	"Theme:":                                     "المظهر:",
+	"Search history:":                            "سجل البحث:",
+	"Auto":                                       "تلقائي",
+	"Light":                                      "فاتح",
+	"Dark":                                       "داكن",
+	"Night":                                      "ليلي",
+	"Protect your privacy!":                      "احمِ خصوصيتك!",
+	"Search results":                             "نتائج البحث",
+	"Results %d to %d of %d":                     "النتائج من %d إلى %d من أصل %d",
+	"Full version":                               "النسخة الكاملة",
+	"Results from %v only":                       "نتائج من %v فقط",
+	"Search the whole web":                       "البحث في الويب بالكامل",
+	"More from this site":                        "المزيد من هذا الموقع",
+	"Warning: this site may install harmful software":                         "تحذير: قد يثبت هذا الموقع برامج ضارة",
+	"Warning: this site may try to steal your personal information":           "تحذير: قد يحاول هذا الموقع سرقة معلوماتك الشخصية",
+	"Do Not Track is on: your searches aren't logged and no cookies are set.": "ميزة عدم التتبع مفعّلة: لا نسجل عمليات بحثك ولا نضع ملفات تعريف الارتباط.",
+}
@@ -0,0 +1,109 @@
+package i18n
+
+var german = map[string]string{
+	"Search":                          "Suchen",
+	"All":                             "Alle",
+	"Images":                          "Bilder",
+	"Local":                           "Lokal",
+	"Maps":                            "Karten",
+	"Shopping":                        "Shopping",
+	"Scholar":                         "Wissenschaft",
+	"Files":                           "Dateien",
+	"SafeSearch":                      "SafeSearch",
+	"On":                              "An",
+	"Off":                             "Aus",
+	"Strict":                          "Streng",
+	"Moderate":                        "Moderat",
+	"Turn on SafeSearch":              "SafeSearch aktivieren",
+	"Size":                            "Größe",
+	"Aspect ratio":                    "Seitenverhältnis",
+	"Color":                           "Farbe",
+	"Type":                            "Typ",
+	"License":                         "Lizenz",
+	"Date":                            "Datum",
+	"Any time":                        "Beliebige Zeit",
+	"Past day":                        "Letzter Tag",
+	"Past week":                       "Letzte Woche",
+	"Past month":                      "Letzter Monat",
+	"Past year":                       "Letztes Jahr",
+	"Any size":                        "Beliebige Größe",
+	"Large":                           "Groß",
+	"Medium":                          "Mittel",
+	"Small":                           "Klein",
+	"Icon":                            "Symbol",
+	"Any aspect":                      "Beliebiges Format",
+	"Tall":                            "Hochformat",
+	"Square":                          "Quadratisch",
+	"Wide":                            "Querformat",
+	"Panoramic":                       "Panorama",
+	"Any color":                       "Beliebige Farbe",
+	"Red":                             "Rot",
+	"Orange":                          "Orange",
+	"Yellow":                          "Gelb",
+	"Green":                           "Grün",
+	"Teal":                            "Blaugrün",
+	"Blue":                            "Blau",
+	"Purple":                          "Lila",
+	"Pink":                            "Rosa",
+	"White":                           "Weiß",
+	"Gray":                            "Grau",
+	"Black":                           "Schwarz",
+	"Brown":                           "Braun",
+	"Any type":                        "Beliebiger Typ",
+	"Photo":                           "Foto",
+	"Clip art":                        "Clipart",
+	"Animated":                        "Animiert",
+	"Transparent":                     "Transparent",
+	"Any license":                     "Beliebige Lizenz",
+	"Public domain":                   "Gemeinfrei",
+	"Creative Commons":                "Creative Commons",
+	"All rights reserved":             "Alle Rechte vorbehalten",
+	"Did you mean":                    "Meinten Sie",
+	"No results for":                  "Keine Ergebnisse für",
+	"Showing results for %v instead.": "Stattdessen werden Ergebnisse für %v angezeigt.",
+	"Suggestions:":                    "Vorschläge:",
+	"Please check your spelling.":     "Überprüfen Sie die Schreibweise.",
+	"Try a more general query.":       "Versuchen Sie eine allgemeinere Suche.",
+	"No places found for":             "Keine Orte gefunden für",
+	`Try adding a location, e.g. "%v near boston".`: `Fügen Sie einen Ort hinzu, z. B. „%v in der Nähe von boston“.`,
+	"Directions":            "Route",
+	"Data from %v":          "Daten von %v",
+	"Sort":                  "Sortieren",
+	"Best match":            "Beste Treffer",
+	"Lowest price":          "Niedrigster Preis",
+	"Highest price":         "Höchster Preis",
+	"Min price":             "Mindestpreis",
+	"Max price":             "Höchstpreis",
+	"Any currency":          "Alle Währungen",
+	"No products found for": "Keine Produkte gefunden für",
+	"Cited by %d":           "Zitiert von: %d",
+	"No papers found for":   "Keine Artikel gefunden für",
+	"%d results":            "%d Ergebnisse",
+	"People also ask":       "Ähnliche Fragen",
+	"Related searches":      "Ähnliche Suchanfragen",
+	"Cached":                "Im Cache",
+	"View a copy of this page through our proxy": "Eine Kopie dieser Seite über unseren Proxy ansehen",
+	"More results from %v":                       "Weitere Ergebnisse von %v",
+	"More results for %v":                        "Weitere Ergebnisse für %v",
+	"Share":                                      "Teilen",
+	"Previous":                                   "Zurück",
+	"Next":                                       "Weiter",
+	"Close":                                      "Schließen",
+	"How we protect your privacy":                "Wie wir Ihre Privatsphäre schützen",
+	"Theme:":                                     "Design:",
+	"Search history:":                            "Suchverlauf:",
+	"Auto":                                       "Automatisch",
+	"Light":                                      "Hell",
+	"Dark":                                       "Dunkel",
+	"Night":                                      "Nacht",
+	"Protect your privacy!":                      "Schützen Sie Ihre Privatsphäre!",
+	"Search results":                             "Suchergebnisse",
+	"Results %d to %d of %d":                     "Ergebnisse %d bis %d von %d",
+	"Full version":                               "Vollversion",
+	"Results from %v only":                       "Nur Ergebnisse von %v",
+	"Search the whole web":                       "Im ganzen Web suchen",
+	"More from this site":                        "Mehr von dieser Website",
+	"Warning: this site may install harmful software":                         "Warnung: Diese Website installiert möglicherweise schädliche Software",
+	"Warning: this site may try to steal your personal information":           "Warnung: Diese Website versucht möglicherweise, Ihre persönlichen Daten zu stehlen",
+	"Do Not Track is on: your searches aren't logged and no cookies are set.": "„Do Not Track“ ist aktiv: Ihre Suchanfragen werden nicht protokolliert und es werden keine Cookies gesetzt.",
+}
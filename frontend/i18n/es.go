@@ -0,0 +1,109 @@
+package i18n
+
+var spanish = map[string]string{
+	"Search":                          "Buscar",
+	"All":                             "Todo",
+	"Images":                          "Imágenes",
+	"Local":                           "Local",
+	"Maps":                            "Mapas",
+	"Shopping":                        "Compras",
+	"Scholar":                         "Académico",
+	"Files":                           "Archivos",
+	"SafeSearch":                      "Búsqueda segura",
+	"On":                              "Activada",
+	"Off":                             "Desactivada",
+	"Strict":                          "Estricta",
+	"Moderate":                        "Moderada",
+	"Turn on SafeSearch":              "Activar la búsqueda segura",
+	"Size":                            "Tamaño",
+	"Aspect ratio":                    "Relación de aspecto",
+	"Color":                           "Color",
+	"Type":                            "Tipo",
+	"License":                         "Licencia",
+	"Date":                            "Fecha",
+	"Any time":                        "Cualquier fecha",
+	"Past day":                        "Último día",
+	"Past week":                       "Última semana",
+	"Past month":                      "Último mes",
+	"Past year":                       "Último año",
+	"Any size":                        "Cualquier tamaño",
+	"Large":                           "Grande",
+	"Medium":                          "Mediano",
+	"Small":                           "Pequeño",
+	"Icon":                            "Icono",
+	"Any aspect":                      "Cualquier proporción",
+	"Tall":                            "Vertical",
+	"Square":                          "Cuadrada",
+	"Wide":                            "Horizontal",
+	"Panoramic":                       "Panorámica",
+	"Any color":                       "Cualquier color",
+	"Red":                             "Rojo",
+	"Orange":                          "Naranja",
+	"Yellow":                          "Amarillo",
+	"Green":                           "Verde",
+	"Teal":                            "Verde azulado",
+	"Blue":                            "Azul",
+	"Purple":                          "Morado",
+	"Pink":                            "Rosa",
+	"White":                           "Blanco",
+	"Gray":                            "Gris",
+	"Black":                           "Negro",
+	"Brown":                           "Marrón",
+	"Any type":                        "Cualquier tipo",
+	"Photo":                           "Foto",
+	"Clip art":                        "Imágenes prediseñadas",
+	"Animated":                        "Animada",
+	"Transparent":                     "Transparente",
+	"Any license":                     "Cualquier licencia",
+	"Public domain":                   "Dominio público",
+	"Creative Commons":                "Creative Commons",
+	"All rights reserved":             "Todos los derechos reservados",
+	"Did you mean":                    "Quizás quisiste decir",
+	"No results for":                  "No hay resultados para",
+	"Showing results for %v instead.": "Mostrando resultados para %v.",
+	"Suggestions:":                    "Sugerencias:",
+	"Please check your spelling.":     "Comprueba la ortografía.",
+	"Try a more general query.":       "Prueba con una búsqueda más general.",
+	"No places found for":             "No se encontraron lugares para",
+	`Try adding a location, e.g. "%v near boston".`: `Prueba a añadir una ubicación, p. ej. "%v cerca de boston".`,
+	"Directions":            "Cómo llegar",
+	"Data from %v":          "Datos de %v",
+	"Sort":                  "Ordenar",
+	"Best match":            "Más relevantes",
+	"Lowest price":          "Precio más bajo",
+	"Highest price":         "Precio más alto",
+	"Min price":             "Precio mínimo",
+	"Max price":             "Precio máximo",
+	"Any currency":          "Cualquier moneda",
+	"No products found for": "No se encontraron productos para",
+	"Cited by %d":           "Citado por %d",
+	"No papers found for":   "No se encontraron artículos para",
+	"%d results":            "%d resultados",
+	"People also ask":       "Otras preguntas de los usuarios",
+	"Related searches":      "Búsquedas relacionadas",
+	"Cached":                "En caché",
+	"View a copy of this page through our proxy": "Ver una copia de esta página a través de nuestro proxy",
+	"More results from %v":                       "Más resultados de %v",
+	"More results for %v":                        "Más resultados de %v",
+	"Share":                                      "Compartir",
+	"Previous":                                   "Anterior",
+	"Next":                                       "Siguiente",
+	"Close":                                      "Cerrar",
+	"How we protect your privacy":                "Cómo protegemos tu privacidad",
+	"Theme:":                                     "Tema:",
+	"Search history:":                            "Historial de búsqueda:",
+	"Auto":                                       "Automático",
+	"Light":                                      "Claro",
+	"Dark":                                       "Oscuro",
+	"Night":                                      "Noche",
+	"Protect your privacy!":                      "¡Protege tu privacidad!",
+	"Search results":                             "Resultados de búsqueda",
+	"Results %d to %d of %d":                     "Resultados %d a %d de %d",
+	"Full version":                               "Versión completa",
+	"Results from %v only":                       "Resultados solo de %v",
+	"Search the whole web":                       "Buscar en toda la web",
+	"More from this site":                        "Más de este sitio",
+	"Warning: this site may install harmful software":                         "Advertencia: este sitio puede instalar software dañino",
+	"Warning: this site may try to steal your personal information":           "Advertencia: este sitio puede intentar robar tu información personal",
+	"Do Not Track is on: your searches aren't logged and no cookies are set.": "«No rastrear» está activado: no registramos tus búsquedas ni guardamos cookies.",
+}
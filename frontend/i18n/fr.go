@@ -0,0 +1,109 @@
+package i18n
+
+var french = map[string]string{
+	"Search":                          "Rechercher",
+	"All":                             "Tous",
+	"Images":                          "Images",
+	"Local":                           "Local",
+	"Maps":                            "Cartes",
+	"Shopping":                        "Shopping",
+	"Scholar":                         "Scholar",
+	"Files":                           "Fichiers",
+	"SafeSearch":                      "Recherche sécurisée",
+	"On":                              "Activée",
+	"Off":                             "Désactivée",
+	"Strict":                          "Stricte",
+	"Moderate":                        "Modérée",
+	"Turn on SafeSearch":              "Activer la recherche sécurisée",
+	"Size":                            "Taille",
+	"Aspect ratio":                    "Format",
+	"Color":                           "Couleur",
+	"Type":                            "Type",
+	"License":                         "Licence",
+	"Date":                            "Date",
+	"Any time":                        "Toutes les dates",
+	"Past day":                        "Dernier jour",
+	"Past week":                       "Dernière semaine",
+	"Past month":                      "Dernier mois",
+	"Past year":                       "Dernière année",
+	"Any size":                        "Toutes les tailles",
+	"Large":                           "Grande",
+	"Medium":                          "Moyenne",
+	"Small":                           "Petite",
+	"Icon":                            "Icône",
+	"Any aspect":                      "Tous les formats",
+	"Tall":                            "Portrait",
+	"Square":                          "Carré",
+	"Wide":                            "Paysage",
+	"Panoramic":                       "Panoramique",
+	"Any color":                       "Toutes les couleurs",
+	"Red":                             "Rouge",
+	"Orange":                          "Orange",
+	"Yellow":                          "Jaune",
+	"Green":                           "Vert",
+	"Teal":                            "Bleu canard",
+	"Blue":                            "Bleu",
+	"Purple":                          "Violet",
+	"Pink":                            "Rose",
+	"White":                           "Blanc",
+	"Gray":                            "Gris",
+	"Black":                           "Noir",
+	"Brown":                           "Marron",
+	"Any type":                        "Tous les types",
+	"Photo":                           "Photo",
+	"Clip art":                        "Clipart",
+	"Animated":                        "Animée",
+	"Transparent":                     "Transparente",
+	"Any license":                     "Toutes les licences",
+	"Public domain":                   "Domaine public",
+	"Creative Commons":                "Creative Commons",
+	"All rights reserved":             "Tous droits réservés",
+	"Did you mean":                    "Vouliez-vous dire",
+	"No results for":                  "Aucun résultat pour",
+	"Showing results for %v instead.": "Résultats affichés pour %v.",
+	"Suggestions:":                    "Suggestions :",
+	"Please check your spelling.":     "Vérifiez l'orthographe.",
+	"Try a more general query.":       "Essayez une recherche plus générale.",
+	"No places found for":             "Aucun lieu trouvé pour",
+	`Try adding a location, e.g. "%v near boston".`: `Essayez d'ajouter un lieu, par ex. « %v près de boston ».`,
+	"Directions":            "Itinéraire",
+	"Data from %v":          "Données de %v",
+	"Sort":                  "Trier",
+	"Best match":            "Pertinence",
+	"Lowest price":          "Prix le plus bas",
+	"Highest price":         "Prix le plus élevé",
+	"Min price":             "Prix minimum",
+	"Max price":             "Prix maximum",
+	"Any currency":          "Toutes les devises",
+	"No products found for": "Aucun produit trouvé pour",
+	"Cited by %d":           "Cité %d fois",
+	"No papers found for":   "Aucun article trouvé pour",
+	"%d results":            "%d résultats",
+	"People also ask":       "Autres questions posées",
+	"Related searches":      "Recherches associées",
+	"Cached":                "En cache",
+	"View a copy of this page through our proxy": "Voir une copie de cette page via notre proxy",
+	"More results from %v":                       "Plus de résultats de %v",
+	"More results for %v":                        "Plus de résultats pour %v",
+	"Share":                                      "Partager",
+	"Previous":                                   "Précédent",
+	"Next":                                       "Suivant",
+	"Close":                                      "Fermer",
+	"How we protect your privacy":                "Comment nous protégeons votre vie privée",
+	"Theme:":                                     "Thème :",
+	"Search history:":                            "Historique de recherche :",
+	"Auto":                                       "Automatique",
+	"Light":                                      "Clair",
+	"Dark":                                       "Sombre",
+	"Night":                                      "Nuit",
+	"Protect your privacy!":                      "Protégez votre vie privée !",
+	"Search results":                             "Résultats de recherche",
+	"Results %d to %d of %d":                     "Résultats %d à %d sur %d",
+	"Full version":                               "Version complète",
+	"Results from %v only":                       "Résultats de %v uniquement",
+	"Search the whole web":                       "Rechercher sur tout le web",
+	"More from this site":                        "Plus de ce site",
+	"Warning: this site may install harmful software":                         "Attention : ce site peut installer des logiciels malveillants",
+	"Warning: this site may try to steal your personal information":           "Attention : ce site peut tenter de voler vos informations personnelles",
+	"Do Not Track is on: your searches aren't logged and no cookies are set.": "« Ne pas suivre » est activé : vos recherches ne sont pas enregistrées et aucun cookie n'est déposé.",
+}
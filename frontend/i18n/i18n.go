@@ -0,0 +1,97 @@
+// Package i18n translates the text of our pages.
+// Messages are keyed by their English text, which is also what is shown when we don't have a translation.
+package i18n
+
+import (
+	"golang.org/x/text/language"
+	"golang.org/x/text/message"
+	"golang.org/x/text/message/catalog"
+)
+
+// messages are our translations for each language other than English
+var messages = map[language.Tag]map[string]string{
+	language.Arabic:            arabic,
+	language.German:            german,
+	language.Spanish:           spanish,
+	language.French:            french,
+	language.Italian:           italian,
+	language.Japanese:          japanese,
+	language.Korean:            korean,
+	language.Portuguese:        portuguese,
+	language.Russian:           russian,
+	language.SimplifiedChinese: chinese,
+}
+
+var (
+	supported = []language.Tag{language.English} // the first is what the matcher falls back to
+	printers  = map[language.Tag]*message.Printer{}
+	matcher   language.Matcher
+)
+
+func init() {
+	b := catalog.NewBuilder(catalog.Fallback(language.English))
+
+	for t, m := range messages {
+		supported = append(supported, t)
+		for key, msg := range m {
+			if err := b.SetString(t, key, msg); err != nil {
+				panic(err)
+			}
+		}
+	}
+
+	for _, t := range supported {
+		printers[t] = message.NewPrinter(t, message.Catalog(b))
+	}
+
+	matcher = language.NewMatcher(supported)
+}
+
+// Languages are those we have translations for
+func Languages() []language.Tag {
+	return supported
+}
+
+// Printer translates messages into the user's languages
+type Printer struct {
+	tags []language.Tag // those we support, most preferred first and always ending in English
+}
+
+// New returns a Printer for the user's preferred languages.
+// A message missing from the first of them is looked up in the next and so on, then English.
+func New(preferred ...language.Tag) *Printer {
+	p := &Printer{}
+	seen := map[language.Tag]bool{}
+
+	add := func(t language.Tag) {
+		_, i, c := matcher.Match(t)
+		if c == language.No || seen[supported[i]] {
+			return
+		}
+		seen[supported[i]] = true
+		p.tags = append(p.tags, supported[i])
+	}
+
+	for _, t := range preferred {
+		add(t)
+	}
+	add(language.English)
+
+	return p
+}
+
+// Language of the page, which is that of our most preferred catalog
+func (p *Printer) Language() language.Tag {
+	return p.tags[0]
+}
+
+// Sprintf translates a message and formats it with the conventions of its language, e.g. "1.234" in German.
+func (p *Printer) Sprintf(key string, a ...interface{}) string {
+	for _, t := range p.tags {
+		if _, ok := messages[t][key]; ok || t == language.English {
+			return printers[t].Sprintf(key, a...)
+		}
+	}
+
+	return printers[language.English].Sprintf(key, a...)
+}
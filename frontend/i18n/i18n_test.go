@@ -0,0 +1,99 @@
+package i18n
+
+import (
+	"reflect"
+	"regexp"
+	"testing"
+
+	"golang.org/x/text/language"
+)
+
+func TestCatalogs(t *testing.T) {
+	keys := map[string]bool{}
+	for _, m := range messages {
+		for k := range m {
+			keys[k] = true
+		}
+	}
+
+	verbs := regexp.MustCompile(`%[vdsq]`)
+
+	for tag, m := range messages {
+		for k := range keys {
+			msg, ok := m[k]
+			if !ok {
+				t.Errorf("%v is missing %q", tag, k)
+				continue
+			}
+
+			if got, want := verbs.FindAllString(msg, -1), verbs.FindAllString(k, -1); !reflect.DeepEqual(got, want) {
+				t.Errorf("%v %q has verbs %v; want %v", tag, msg, got, want)
+			}
+		}
+	}
+}
+
+func TestLanguages(t *testing.T) {
+	if got := len(Languages()); got < 11 {
+		t.Fatalf("got %d languages; want English and at least 10 others", got)
+	}
+}
+
+func TestNew(t *testing.T) {
+	for _, c := range []struct {
+		name      string
+		preferred []language.Tag
+		want      []language.Tag
+	}{
+		{"none", nil, []language.Tag{language.English}},
+		{"unsupported", []language.Tag{language.Swedish}, []language.Tag{language.English}},
+		{"regional", []language.Tag{language.MustParse("fr-CA")}, []language.Tag{language.French, language.English}},
+		{
+			"chain",
+			[]language.Tag{language.MustParse("de-AT"), language.Swedish, language.MustParse("en-GB"), language.Spanish},
+			[]language.Tag{language.German, language.English, language.Spanish},
+		},
+		{"arabic", []language.Tag{language.MustParse("ar-EG")}, []language.Tag{language.Arabic, language.English}},
+	} {
+		t.Run(c.name, func(t *testing.T) {
+			p := New(c.preferred...)
+
+			if !reflect.DeepEqual(p.tags, c.want) {
+				t.Fatalf("got %v; want %v", p.tags, c.want)
+			}
+
+			if p.Language() != c.want[0] {
+				t.Fatalf("got language %v; want %v", p.Language(), c.want[0])
+			}
+		})
+	}
+}
+
+func TestSprintf(t *testing.T) {
+	// pretend French is missing a message so we can see it come from the next language
+	next := messages[language.French]["Next"]
+	delete(messages[language.French], "Next")
+	defer func() { messages[language.French]["Next"] = next }()
+
+	for _, c := range []struct {
+		name      string
+		preferred []language.Tag
+		key       string
+		args      []interface{}
+		want      string
+	}{
+		{"english", nil, "Next", nil, "Next"},
+		{"translated", []language.Tag{language.French}, "Previous", nil, "Précédent"},
+		{"number", []language.Tag{language.German}, "%d results", []interface{}{1234567}, "1.234.567 Ergebnisse"},
+		{"english number", []language.Tag{language.Swedish}, "%d results", []interface{}{1234567}, "1,234,567 results"},
+		{"next preferred", []language.Tag{language.French, language.German}, "Next", nil, "Weiter"},
+		{"english last", []language.Tag{language.French}, "Next", nil, "Next"},
+		{"untranslated", []language.Tag{language.French}, "Not translated", nil, "Not translated"},
+	} {
+		t.Run(c.name, func(t *testing.T) {
+			if got := New(c.preferred...).Sprintf(c.key, c.args...); got != c.want {
+				t.Fatalf("got %q; want %q", got, c.want)
+			}
+		})
+	}
+}
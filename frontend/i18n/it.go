@@ -0,0 +1,109 @@
+package i18n
+
+var italian = map[string]string{
+	"Search":                          "Cerca",
+	"All":                             "Tutti",
+	"Images":                          "Immagini",
+	"Local":                           "Locale",
+	"Maps":                            "Mappe",
+	"Shopping":                        "Shopping",
+	"Scholar":                         "Scholar",
+	"Files":                           "File",
+	"SafeSearch":                      "SafeSearch",
+	"On":                              "Attiva",
+	"Off":                             "Disattivata",
+	"Strict":                          "Rigida",
+	"Moderate":                        "Moderata",
+	"Turn on SafeSearch":              "Attiva SafeSearch",
+	"Size":                            "Dimensioni",
+	"Aspect ratio":                    "Proporzioni",
+	"Color":                           "Colore",
+	"Type":                            "Tipo",
+	"License":                         "Licenza",
+	"Date":                            "Data",
+	"Any time":                        "Qualsiasi data",
+	"Past day":                        "Ultimo giorno",
+	"Past week":                       "Ultima settimana",
+	"Past month":                      "Ultimo mese",
+	"Past year":                       "Ultimo anno",
+	"Any size":                        "Qualsiasi dimensione",
+	"Large":                           "Grandi",
+	"Medium":                          "Medie",
+	"Small":                           "Piccole",
+	"Icon":                            "Icona",
+	"Any aspect":                      "Qualsiasi proporzione",
+	"Tall":                            "Verticale",
+	"Square":                          "Quadrata",
+	"Wide":                            "Orizzontale",
+	"Panoramic":                       "Panoramica",
+	"Any color":                       "Qualsiasi colore",
+	"Red":                             "Rosso",
+	"Orange":                          "Arancione",
+	"Yellow":                          "Giallo",
+	"Green":                           "Verde",
+	"Teal":                            "Verde acqua",
+	"Blue":                            "Blu",
+	"Purple":                          "Viola",
+	"Pink":                            "Rosa",
+	"White":                           "Bianco",
+	"Gray":                            "Grigio",
+	"Black":                           "Nero",
+	"Brown":                           "Marrone",
+	"Any type":                        "Qualsiasi tipo",
+	"Photo":                           "Foto",
+	"Clip art":                        "Clip art",
+	"Animated":                        "Animata",
+	"Transparent":                     "Trasparente",
+	"Any license":                     "Qualsiasi licenza",
+	"Public domain":                   "Pubblico dominio",
+	"Creative Commons":                "Creative Commons",
+	"All rights reserved":             "Tutti i diritti riservati",
+	"Did you mean":                    "Forse cercavi",
+	"No results for":                  "Nessun risultato per",
+	"Showing results for %v instead.": "Sono mostrati i risultati per %v.",
+	"Suggestions:":                    "Suggerimenti:",
+	"Please check your spelling.":     "Controlla l'ortografia.",
+	"Try a more general query.":       "Prova una ricerca più generica.",
+	"No places found for":             "Nessun luogo trovato per",
+	`Try adding a location, e.g. "%v near boston".`: `Prova ad aggiungere un luogo, ad es. "%v vicino a boston".`,
+	"Directions":            "Indicazioni",
+	"Data from %v":          "Dati di %v",
+	"Sort":                  "Ordina",
+	"Best match":            "Più pertinenti",
+	"Lowest price":          "Prezzo più basso",
+	"Highest price":         "Prezzo più alto",
+	"Min price":             "Prezzo minimo",
+	"Max price":             "Prezzo massimo",
+	"Any currency":          "Qualsiasi valuta",
+	"No products found for": "Nessun prodotto trovato per",
+	"Cited by %d":           "Citato da %d",
+	"No papers found for":   "Nessun articolo trovato per",
+	"%d results":            "%d risultati",
+	"People also ask":       "Altre domande",
+	"Related searches":      "Ricerche correlate",
+	"Cached":                "Copia cache",
+	"View a copy of this page through our proxy": "Visualizza una copia di questa pagina tramite il nostro proxy",
+	"More results from %v":                       "Altri risultati da %v",
+	"More results for %v":                        "Altri risultati per %v",
+	"Share":                                      "Condividi",
+	"Previous":                                   "Precedente",
+	"Next":                                       "Successiva",
+	"Close":                                      "Chiudi",
+	"How we protect your privacy":                "Come proteggiamo la tua privacy",
+	"Theme:":                                     "Tema:",
+	"Search history:":                            "Cronologia delle ricerche:",
+	"Auto":                                       "Automatico",
+	"Light":                                      "Chiaro",
+	"Dark":                                       "Scuro",
+	"Night":                                      "Notte",
+	"Protect your privacy!":                      "Proteggi la tua privacy!",
+	"Search results":                             "Risultati di ricerca",
+	"Results %d to %d of %d":                     "Risultati da %d a %d di %d",
+	"Full version":                               "Versione completa",
+	"Results from %v only":                       "Solo risultati da %v",
+	"Search the whole web":                       "Cerca in tutto il web",
+	"More from this site":                        "Altro da questo sito",
+	"Warning: this site may install harmful software":                         "Attenzione: questo sito potrebbe installare software dannoso",
+	"Warning: this site may try to steal your personal information":           "Attenzione: questo sito potrebbe tentare di rubare i tuoi dati personali",
+	"Do Not Track is on: your searches aren't logged and no cookies are set.": "«Do Not Track» è attivo: le tue ricerche non vengono registrate e non impostiamo cookie.",
+}
@@ -0,0 +1,109 @@
+package i18n
+
+var japanese = map[string]string{
+	"Search":                          "検索",
+	"All":                             "すべて",
+	"Images":                          "画像",
+	"Local":                           "周辺",
+	"Maps":                            "地図",
+	"Shopping":                        "ショッピング",
+	"Scholar":                         "論文",
+	"Files":                           "ファイル",
+	"SafeSearch":                      "セーフサーチ",
+	"On":                              "オン",
+	"Off":                             "オフ",
+	"Strict":                          "厳しい",
+	"Moderate":                        "中程度",
+	"Turn on SafeSearch":              "セーフサーチをオンにする",
+	"Size":                            "サイズ",
+	"Aspect ratio":                    "縦横比",
+	"Color":                           "色",
+	"Type":                            "種類",
+	"License":                         "ライセンス",
+	"Date":                            "日付",
+	"Any time":                        "期間指定なし",
+	"Past day":                        "1 日以内",
+	"Past week":                       "1 週間以内",
+	"Past month":                      "1 か月以内",
+	"Past year":                       "1 年以内",
+	"Any size":                        "すべてのサイズ",
+	"Large":                           "大",
+	"Medium":                          "中",
+	"Small":                           "小",
+	"Icon":                            "アイコン",
+	"Any aspect":                      "すべての縦横比",
+	"Tall":                            "縦長",
+	"Square":                          "正方形",
+	"Wide":                            "横長",
+	"Panoramic":                       "パノラマ",
+	"Any color":                       "すべての色",
+	"Red":                             "赤",
+	"Orange":                          "オレンジ",
+	"Yellow":                          "黄",
+	"Green":                           "緑",
+	"Teal":                            "青緑",
+	"Blue":                            "青",
+	"Purple":                          "紫",
+	"Pink":                            "ピンク",
+	"White":                           "白",
+	"Gray":                            "グレー",
+	"Black":                           "黒",
+	"Brown":                           "茶",
+	"Any type":                        "すべての種類",
+	"Photo":                           "写真",
+	"Clip art":                        "クリップアート",
+	"Animated":                        "アニメーション",
+	"Transparent":                     "透過",
+	"Any license":                     "すべてのライセンス",
+	"Public domain":                   "パブリックドメイン",
+	"Creative Commons":                "クリエイティブ・コモンズ",
+	"All rights reserved":             "全著作権所有",
+	"Did you mean":                    "もしかして:",
+	"No results for":                  "検索結果がありません:",
+	"Showing results for %v instead.": "%v の検索結果を表示しています。",
+	"Suggestions:":                    "ヒント:",
+	"Please check your spelling.":     "キーワードに誤字・脱字がないか確認してください。",
+	"Try a more general query.":       "より一般的なキーワードで検索してください。",
+	"No places found for":             "場所が見つかりません:",
+	`Try adding a location, e.g. "%v near boston".`: `場所を追加してください (例: 「%v near boston」)。`,
+	"Directions":            "経路",
+	"Data from %v":          "データ提供: %v",
+	"Sort":                  "並べ替え",
+	"Best match":            "関連度順",
+	"Lowest price":          "価格の安い順",
+	"Highest price":         "価格の高い順",
+	"Min price":             "最低価格",
+	"Max price":             "最高価格",
+	"Any currency":          "すべての通貨",
+	"No products found for": "商品が見つかりませんでした:",
+	"Cited by %d":           "被引用数: %d",
+	"No papers found for":   "論文が見つかりませんでした:",
+	"%d results":            "%d 件",
+	"People also ask":       "他の人はこちらも質問",
+	"Related searches":      "関連する検索",
+	"Cached":                "キャッシュ",
+	"View a copy of this page through our proxy": "プロキシ経由でこのページのコピーを表示",
+	"More results from %v":                       "%v からの他の結果",
+	"More results for %v":                        "%v の検索結果をもっと見る",
+	"Share":                                      "共有",
+	"Previous":                                   "前へ",
+	"Next":                                       "次へ",
+	"Close":                                      "閉じる",
+	"How we protect your privacy":                "プライバシー保護の取り組み",
+	"Theme:":                                     "テーマ:",
+	"Search history:":                            "検索履歴:",
+	"Auto":                                       "自動",
+	"Light":                                      "ライト",
+	"Dark":                                       "ダーク",
+	"Night":                                      "ナイト",
+	"Protect your privacy!":                      "プライバシーを守りましょう！",
+	"Search results":                             "検索結果",
+	"Results %d to %d of %d":                     "%d～%d 件目 (全 %d 件)",
+	"Full version":                               "通常版",
+	"Results from %v only":                       "%v の結果のみ",
+	"Search the whole web":                       "ウェブ全体を検索",
+	"More from this site":                        "このサイトの他の結果",
+	"Warning: this site may install harmful software":                         "警告: このサイトは有害なソフトウェアをインストールする可能性があります",
+	"Warning: this site may try to steal your personal information":           "警告: このサイトは個人情報を盗もうとする可能性があります",
+	"Do Not Track is on: your searches aren't logged and no cookies are set.": "トラッキング拒否が有効です：検索は記録されず、Cookie も設定されません。",
+}
@@ -0,0 +1,109 @@
+package i18n
+
+var korean = map[string]string{
+	"Search":                          "검색",
+	"All":                             "전체",
+	"Images":                          "이미지",
+	"Local":                           "주변",
+	"Maps":                            "지도",
+	"Shopping":                        "쇼핑",
+	"Scholar":                         "학술자료",
+	"Files":                           "파일",
+	"SafeSearch":                      "세이프서치",
+	"On":                              "사용",
+	"Off":                             "사용 안함",
+	"Strict":                          "엄격",
+	"Moderate":                        "보통",
+	"Turn on SafeSearch":              "세이프서치 사용",
+	"Size":                            "크기",
+	"Aspect ratio":                    "가로세로 비율",
+	"Color":                           "색상",
+	"Type":                            "유형",
+	"License":                         "라이선스",
+	"Date":                            "날짜",
+	"Any time":                        "모든 날짜",
+	"Past day":                        "지난 1일",
+	"Past week":                       "지난 1주",
+	"Past month":                      "지난 1개월",
+	"Past year":                       "지난 1년",
+	"Any size":                        "모든 크기",
+	"Large":                           "크게",
+	"Medium":                          "중간",
+	"Small":                           "작게",
+	"Icon":                            "아이콘",
+	"Any aspect":                      "모든 비율",
+	"Tall":                            "세로",
+	"Square":                          "정사각형",
+	"Wide":                            "가로",
+	"Panoramic":                       "파노라마",
+	"Any color":                       "모든 색상",
+	"Red":                             "빨간색",
+	"Orange":                          "주황색",
+	"Yellow":                          "노란색",
+	"Green":                           "녹색",
+	"Teal":                            "청록색",
+	"Blue":                            "파란색",
+	"Purple":                          "보라색",
+	"Pink":                            "분홍색",
+	"White":                           "흰색",
+	"Gray":                            "회색",
+	"Black":                           "검은색",
+	"Brown":                           "갈색",
+	"Any type":                        "모든 유형",
+	"Photo":                           "사진",
+	"Clip art":                        "클립아트",
+	"Animated":                        "애니메이션",
+	"Transparent":                     "투명",
+	"Any license":                     "모든 라이선스",
+	"Public domain":                   "퍼블릭 도메인",
+	"Creative Commons":                "크리에이티브 커먼즈",
+	"All rights reserved":             "모든 권리 보유",
+	"Did you mean":                    "다음을 찾으셨나요:",
+	"No results for":                  "검색결과 없음:",
+	"Showing results for %v instead.": "%v에 대한 검색결과를 표시합니다.",
+	"Suggestions:":                    "도움말:",
+	"Please check your spelling.":     "맞춤법을 확인하세요.",
+	"Try a more general query.":       "더 일반적인 검색어를 사용해 보세요.",
+	"No places found for":             "장소를 찾을 수 없음:",
+	`Try adding a location, e.g. "%v near boston".`: `위치를 추가해 보세요. 예: "%v near boston"`,
+	"Directions":            "길찾기",
+	"Data from %v":          "데이터 제공: %v",
+	"Sort":                  "정렬",
+	"Best match":            "관련성순",
+	"Lowest price":          "낮은 가격순",
+	"Highest price":         "높은 가격순",
+	"Min price":             "최저 가격",
+	"Max price":             "최고 가격",
+	"Any currency":          "모든 통화",
+	"No products found for": "다음에 대한 상품을 찾을 수 없습니다:",
+	"Cited by %d":           "%d회 인용",
+	"No papers found for":   "다음에 대한 논문을 찾을 수 없습니다:",
+	"%d results":            "검색결과 %d개",
+	"People also ask":       "다른 사람들이 함께 찾은 질문",
+	"Related searches":      "관련 검색어",
+	"Cached":                "저장된 페이지",
+	"View a copy of this page through our proxy": "프록시를 통해 이 페이지의 사본 보기",
+	"More results from %v":                       "%v의 검색결과 더보기",
+	"More results for %v":                        "%v에 대한 결과 더보기",
+	"Share":                                      "공유",
+	"Previous":                                   "이전",
+	"Next":                                       "다음",
+	"Close":                                      "닫기",
+	"How we protect your privacy":                "개인정보 보호 방법",
+	"Theme:":                                     "테마:",
+	"Search history:":                            "검색 기록:",
+	"Auto":                                       "자동",
+	"Light":                                      "밝게",
+	"Dark":                                       "어둡게",
+	"Night":                                      "야간",
+	"Protect your privacy!":                      "개인정보를 보호하세요!",
+	"Search results":                             "검색결과",
+	"Results %d to %d of %d":                     "검색결과 %d~%d번째 (총 %d개)",
+	"Full version":                               "전체 버전",
+	"Results from %v only":                       "%v의 결과만",
+	"Search the whole web":                       "웹 전체 검색",
+	"More from this site":                        "이 사이트에서 더보기",
+	"Warning: this site may install harmful software":                         "경고: 이 사이트는 유해한 소프트웨어를 설치할 수 있습니다",
+	"Warning: this site may try to steal your personal information":           "경고: 이 사이트는 개인 정보를 훔치려 할 수 있습니다",
+	"Do Not Track is on: your searches aren't logged and no cookies are set.": "추적 안 함이 켜져 있습니다: 검색 기록을 남기지 않고 쿠키도 설정하지 않습니다.",
+}
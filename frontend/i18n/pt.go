@@ -0,0 +1,109 @@
+package i18n
+
+var portuguese = map[string]string{
+	"Search":                          "Pesquisar",
+	"All":                             "Todos",
+	"Images":                          "Imagens",
+	"Local":                           "Local",
+	"Maps":                            "Mapas",
+	"Shopping":                        "Compras",
+	"Scholar":                         "Acadêmico",
+	"Files":                           "Arquivos",
+	"SafeSearch":                      "Pesquisa segura",
+	"On":                              "Ativada",
+	"Off":                             "Desativada",
+	"Strict":                          "Rigorosa",
+	"Moderate":                        "Moderada",
+	"Turn on SafeSearch":              "Ativar a pesquisa segura",
+	"Size":                            "Tamanho",
+	"Aspect ratio":                    "Proporção",
+	"Color":                           "Cor",
+	"Type":                            "Tipo",
+	"License":                         "Licença",
+	"Date":                            "Data",
+	"Any time":                        "Qualquer data",
+	"Past day":                        "Último dia",
+	"Past week":                       "Última semana",
+	"Past month":                      "Último mês",
+	"Past year":                       "Último ano",
+	"Any size":                        "Qualquer tamanho",
+	"Large":                           "Grande",
+	"Medium":                          "Médio",
+	"Small":                           "Pequeno",
+	"Icon":                            "Ícone",
+	"Any aspect":                      "Qualquer proporção",
+	"Tall":                            "Vertical",
+	"Square":                          "Quadrada",
+	"Wide":                            "Horizontal",
+	"Panoramic":                       "Panorâmica",
+	"Any color":                       "Qualquer cor",
+	"Red":                             "Vermelho",
+	"Orange":                          "Laranja",
+	"Yellow":                          "Amarelo",
+	"Green":                           "Verde",
+	"Teal":                            "Azul-petróleo",
+	"Blue":                            "Azul",
+	"Purple":                          "Roxo",
+	"Pink":                            "Rosa",
+	"White":                           "Branco",
+	"Gray":                            "Cinza",
+	"Black":                           "Preto",
+	"Brown":                           "Marrom",
+	"Any type":                        "Qualquer tipo",
+	"Photo":                           "Foto",
+	"Clip art":                        "Clip-art",
+	"Animated":                        "Animada",
+	"Transparent":                     "Transparente",
+	"Any license":                     "Qualquer licença",
+	"Public domain":                   "Domínio público",
+	"Creative Commons":                "Creative Commons",
+	"All rights reserved":             "Todos os direitos reservados",
+	"Did you mean":                    "Você quis dizer",
+	"No results for":                  "Nenhum resultado para",
+	"Showing results for %v instead.": "Mostrando resultados para %v.",
+	"Suggestions:":                    "Sugestões:",
+	"Please check your spelling.":     "Verifique a ortografia.",
+	"Try a more general query.":       "Tente uma pesquisa mais geral.",
+	"No places found for":             "Nenhum lugar encontrado para",
+	`Try adding a location, e.g. "%v near boston".`: `Tente adicionar um local, por ex. "%v perto de boston".`,
+	"Directions":            "Rotas",
+	"Data from %v":          "Dados de %v",
+	"Sort":                  "Ordenar",
+	"Best match":            "Mais relevantes",
+	"Lowest price":          "Menor preço",
+	"Highest price":         "Maior preço",
+	"Min price":             "Preço mínimo",
+	"Max price":             "Preço máximo",
+	"Any currency":          "Qualquer moeda",
+	"No products found for": "Nenhum produto encontrado para",
+	"Cited by %d":           "Citado por %d",
+	"No papers found for":   "Nenhum artigo encontrado para",
+	"%d results":            "%d resultados",
+	"People also ask":       "As pessoas também perguntam",
+	"Related searches":      "Pesquisas relacionadas",
+	"Cached":                "Em cache",
+	"View a copy of this page through our proxy": "Ver uma cópia desta página através do nosso proxy",
+	"More results from %v":                       "Mais resultados de %v",
+	"More results for %v":                        "Mais resultados para %v",
+	"Share":                                      "Compartilhar",
+	"Previous":                                   "Anterior",
+	"Next":                                       "Próxima",
+	"Close":                                      "Fechar",
+	"How we protect your privacy":                "Como protegemos a sua privacidade",
+	"Theme:":                                     "Tema:",
+	"Search history:":                            "Histórico de pesquisa:",
+	"Auto":                                       "Automático",
+	"Light":                                      "Claro",
+	"Dark":                                       "Escuro",
+	"Night":                                      "Noite",
+	"Protect your privacy!":                      "Proteja a sua privacidade!",
+	"Search results":                             "Resultados da pesquisa",
+	"Results %d to %d of %d":                     "Resultados %d a %d de %d",
+	"Full version":                               "Versão completa",
+	"Results from %v only":                       "Apenas resultados de %v",
+	"Search the whole web":                       "Pesquisar em toda a web",
+	"More from this site":                        "Mais deste site",
+	"Warning: this site may install harmful software":                         "Aviso: este site pode instalar software nocivo",
+	"Warning: this site may try to steal your personal information":           "Aviso: este site pode tentar roubar suas informações pessoais",
+	"Do Not Track is on: your searches aren't logged and no cookies are set.": "«Não rastrear» está ativado: as suas pesquisas não são registadas e não são definidos cookies.",
+}
@@ -0,0 +1,109 @@
+package i18n
+
+var russian = map[string]string{
+	"Search":                          "Найти",
+	"All":                             "Все",
+	"Images":                          "Картинки",
+	"Local":                           "Рядом",
+	"Maps":                            "Карты",
+	"Shopping":                        "Покупки",
+	"Scholar":                         "Академия",
+	"Files":                           "Файлы",
+	"SafeSearch":                      "Безопасный поиск",
+	"On":                              "Вкл.",
+	"Off":                             "Выкл.",
+	"Strict":                          "Строгий",
+	"Moderate":                        "Умеренный",
+	"Turn on SafeSearch":              "Включить безопасный поиск",
+	"Size":                            "Размер",
+	"Aspect ratio":                    "Соотношение сторон",
+	"Color":                           "Цвет",
+	"Type":                            "Тип",
+	"License":                         "Лицензия",
+	"Date":                            "Дата",
+	"Any time":                        "За всё время",
+	"Past day":                        "За сутки",
+	"Past week":                       "За неделю",
+	"Past month":                      "За месяц",
+	"Past year":                       "За год",
+	"Any size":                        "Любой размер",
+	"Large":                           "Большие",
+	"Medium":                          "Средние",
+	"Small":                           "Маленькие",
+	"Icon":                            "Значки",
+	"Any aspect":                      "Любая ориентация",
+	"Tall":                            "Вертикальные",
+	"Square":                          "Квадратные",
+	"Wide":                            "Горизонтальные",
+	"Panoramic":                       "Панорамные",
+	"Any color":                       "Любой цвет",
+	"Red":                             "Красный",
+	"Orange":                          "Оранжевый",
+	"Yellow":                          "Жёлтый",
+	"Green":                           "Зелёный",
+	"Teal":                            "Бирюзовый",
+	"Blue":                            "Синий",
+	"Purple":                          "Фиолетовый",
+	"Pink":                            "Розовый",
+	"White":                           "Белый",
+	"Gray":                            "Серый",
+	"Black":                           "Чёрный",
+	"Brown":                           "Коричневый",
+	"Any type":                        "Любой тип",
+	"Photo":                           "Фото",
+	"Clip art":                        "Клипарт",
+	"Animated":                        "Анимированные",
+	"Transparent":                     "Прозрачные",
+	"Any license":                     "Любая лицензия",
+	"Public domain":                   "Общественное достояние",
+	"Creative Commons":                "Creative Commons",
+	"All rights reserved":             "Все права защищены",
+	"Did you mean":                    "Возможно, вы имели в виду",
+	"No results for":                  "Нет результатов по запросу",
+	"Showing results for %v instead.": "Показаны результаты по запросу %v.",
+	"Suggestions:":                    "Рекомендации:",
+	"Please check your spelling.":     "Проверьте правописание.",
+	"Try a more general query.":       "Попробуйте более общий запрос.",
+	"No places found for":             "Не найдено мест по запросу",
+	`Try adding a location, e.g. "%v near boston".`: `Попробуйте добавить место, например «%v рядом с boston».`,
+	"Directions":            "Маршрут",
+	"Data from %v":          "Данные: %v",
+	"Sort":                  "Сортировка",
+	"Best match":            "По релевантности",
+	"Lowest price":          "Сначала дешевле",
+	"Highest price":         "Сначала дороже",
+	"Min price":             "Мин. цена",
+	"Max price":             "Макс. цена",
+	"Any currency":          "Любая валюта",
+	"No products found for": "Не найдено товаров по запросу",
+	"Cited by %d":           "Цитируется: %d",
+	"No papers found for":   "Не найдено статей по запросу",
+	"%d results":            "Результатов: %d",
+	"People also ask":       "Похожие вопросы",
+	"Related searches":      "Похожие запросы",
+	"Cached":                "Сохранённая копия",
+	"View a copy of this page through our proxy": "Открыть копию страницы через наш прокси",
+	"More results from %v":                       "Ещё результаты с %v",
+	"More results for %v":                        "Другие результаты по запросу %v",
+	"Share":                                      "Поделиться",
+	"Previous":                                   "Назад",
+	"Next":                                       "Далее",
+	"Close":                                      "Закрыть",
+	"How we protect your privacy":                "Как мы защищаем вашу конфиденциальность",
+	"Theme:":                                     "Тема:",
+	"Search history:":                            "История поиска:",
+	"Auto":                                       "Автоматически",
+	"Light":                                      "Светлая",
+	"Dark":                                       "Тёмная",
+	"Night":                                      "Ночная",
+	"Protect your privacy!":                      "Защитите свою конфиденциальность!",
+	"Search results":                             "Результаты поиска",
+	"Results %d to %d of %d":                     "Результаты с %d по %d из %d",
+	"Full version":                               "Полная версия",
+	"Results from %v only":                       "Только результаты с %v",
+	"Search the whole web":                       "Искать по всему интернету",
+	"More from this site":                        "Ещё с этого сайта",
+	"Warning: this site may install harmful software":                         "Внимание: этот сайт может установить вредоносное ПО",
+	"Warning: this site may try to steal your personal information":           "Внимание: этот сайт может пытаться украсть ваши личные данные",
+	"Do Not Track is on: your searches aren't logged and no cookies are set.": "Включён режим «Не отслеживать»: ваши запросы не записываются, а файлы cookie не сохраняются.",
+}
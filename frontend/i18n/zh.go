@@ -0,0 +1,109 @@
+package i18n
+
+var chinese = map[string]string{
+	"Search":                          "搜索",
+	"All":                             "全部",
+	"Images":                          "图片",
+	"Local":                           "本地",
+	"Maps":                            "地图",
+	"Shopping":                        "购物",
+	"Scholar":                         "学术",
+	"Files":                           "文件",
+	"SafeSearch":                      "安全搜索",
+	"On":                              "开",
+	"Off":                             "关",
+	"Strict":                          "严格",
+	"Moderate":                        "中等",
+	"Turn on SafeSearch":              "开启安全搜索",
+	"Size":                            "尺寸",
+	"Aspect ratio":                    "宽高比",
+	"Color":                           "颜色",
+	"Type":                            "类型",
+	"License":                         "许可",
+	"Date":                            "日期",
+	"Any time":                        "时间不限",
+	"Past day":                        "过去一天",
+	"Past week":                       "过去一周",
+	"Past month":                      "过去一个月",
+	"Past year":                       "过去一年",
+	"Any size":                        "任意尺寸",
+	"Large":                           "大",
+	"Medium":                          "中",
+	"Small":                           "小",
+	"Icon":                            "图标",
+	"Any aspect":                      "任意宽高比",
+	"Tall":                            "竖图",
+	"Square":                          "正方形",
+	"Wide":                            "横图",
+	"Panoramic":                       "全景",
+	"Any color":                       "任意颜色",
+	"Red":                             "红色",
+	"Orange":                          "橙色",
+	"Yellow":                          "黄色",
+	"Green":                           "绿色",
+	"Teal":                            "青色",
+	"Blue":                            "蓝色",
+	"Purple":                          "紫色",
+	"Pink":                            "粉色",
+	"White":                           "白色",
+	"Gray":                            "灰色",
+	"Black":                           "黑色",
+	"Brown":                           "棕色",
+	"Any type":                        "任意类型",
+	"Photo":                           "照片",
+	"Clip art":                        "剪贴画",
+	"Animated":                        "动图",
+	"Transparent":                     "透明",
+	"Any license":                     "任意许可",
+	"Public domain":                   "公有领域",
+	"Creative Commons":                "知识共享",
+	"All rights reserved":             "保留所有权利",
+	"Did you mean":                    "您是不是要找",
+	"No results for":                  "找不到结果：",
+	"Showing results for %v instead.": "已显示 %v 的搜索结果。",
+	"Suggestions:":                    "建议：",
+	"Please check your spelling.":     "请检查拼写。",
+	"Try a more general query.":       "请尝试更宽泛的搜索词。",
+	"No places found for":             "找不到地点：",
+	`Try adding a location, e.g. "%v near boston".`: `请尝试添加地点，例如“%v near boston”。`,
+	"Directions":            "路线",
+	"Data from %v":          "数据来自 %v",
+	"Sort":                  "排序",
+	"Best match":            "最佳匹配",
+	"Lowest price":          "价格最低",
+	"Highest price":         "价格最高",
+	"Min price":             "最低价格",
+	"Max price":             "最高价格",
+	"Any currency":          "任何货币",
+	"No products found for": "未找到相关商品：",
+	"Cited by %d":           "被引用次数：%d",
+	"No papers found for":   "未找到相关论文：",
+	"%d results":            "%d 条结果",
+	"People also ask":       "相关问题",
+	"Related searches":      "相关搜索",
+	"Cached":                "网页快照",
+	"View a copy of this page through our proxy": "通过我们的代理查看此网页的副本",
+	"More results from %v":                       "来自 %v 的更多结果",
+	"More results for %v":                        "更多关于 %v 的结果",
+	"Share":                                      "分享",
+	"Previous":                                   "上一页",
+	"Next":                                       "下一页",
+	"Close":                                      "关闭",
+	"How we protect your privacy":                "我们如何保护您的隐私",
+	"Theme:":                                     "主题：",
+	"Search history:":                            "搜索历史：",
+	"Auto":                                       "自动",
+	"Light":                                      "浅色",
+	"Dark":                                       "深色",
+	"Night":                                      "夜间",
+	"Protect your privacy!":                      "保护您的隐私！",
+	"Search results":                             "搜索结果",
+	"Results %d to %d of %d":                     "第 %d 至 %d 条结果，共 %d 条",
+	"Full version":                               "完整版",
+	"Results from %v only":                       "仅显示来自 %v 的结果",
+	"Search the whole web":                       "搜索整个网络",
+	"More from this site":                        "来自此网站的更多结果",
+	"Warning: this site may install harmful software":                         "警告：此网站可能会安装有害软件",
+	"Warning: this site may try to steal your personal information":           "警告：此网站可能会试图窃取您的个人信息",
+	"Do Not Track is on: your searches aren't logged and no cookies are set.": "“请勿跟踪”已开启：我们不会记录您的搜索，也不会设置 Cookie。",
+}
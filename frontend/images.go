@@ -0,0 +1,93 @@
+package frontend
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/jivesearch/jivesearch/log"
+	img "github.com/jivesearch/jivesearch/search/image"
+)
+
+var errMissingQuery = fmt.Errorf("missing query")
+
+// imagesHandler is a lightweight image search API for infinite scrolling.
+// Rather than a page number it accepts the cursor from the previous response.
+func (f *Frontend) imagesHandler(w http.ResponseWriter, r *http.Request) *response {
+	d, err := f.getData(r)
+	if err != nil {
+		return &response{
+			status: http.StatusBadRequest,
+			err:    err,
+		}
+	}
+
+	if d.Context.Q == "" {
+		return &response{
+			status: http.StatusBadRequest,
+			err:    errMissingQuery,
+		}
+	}
+
+	key := cacheKey("images_api", d.Context.lang, d.Context.Region, formURL(r))
+
+	v := f.cacheGet(r.Context(), "images_api", key)
+
+	if v != nil {
+		ir := &img.Results{}
+		if err := json.Unmarshal(v.([]byte), &ir); err != nil {
+			log.Info.Println(err)
+		}
+
+		f.inlineAPI(r, ir)
+
+		return &response{
+			status:   http.StatusOK,
+			template: "json",
+			data:     ir,
+		}
+	}
+
+	var ir *img.Results
+
+	switch cursor := strings.TrimSpace(r.FormValue("cursor")); cursor {
+	case "":
+		ir, err = f.Images.Fetch(d.Context.Q, d.Context.Safe, d.Context.ImageFilter, d.Context.Number, 0)
+	default:
+		ir, err = f.Images.FetchAfter(d.Context.Q, d.Context.Safe, d.Context.ImageFilter, d.Context.Number, cursor)
+	}
+
+	switch err {
+	case nil:
+	case img.ErrInvalidCursor:
+		return &response{
+			status: http.StatusBadRequest,
+			err:    err,
+		}
+	default:
+		return &response{
+			status: http.StatusInternalServerError,
+			err:    err,
+		}
+	}
+
+	for _, im := range ir.Images {
+		im.Thumbnail = thumbnail(im.ID)
+	}
+
+	f.cachePut(r.Context(), key, ir, f.Cache.Search)
+
+	f.inlineAPI(r, ir) // after caching so the cache doesn't fill up with images
+
+	return &response{
+		status:   http.StatusOK,
+		template: "json",
+		data:     ir,
+	}
+}
+
+// thumbnail is the signed image proxy link for a resized image
+func thumbnail(u string) string {
+	return fmt.Sprintf("/image/225x,s%v/%v", hmacKey(u), strings.Replace(u, "://", ":/", 1))
+}
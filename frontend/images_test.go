@@ -0,0 +1,93 @@
+package frontend
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+
+	"github.com/jivesearch/jivesearch/bangs"
+	img "github.com/jivesearch/jivesearch/search/image"
+	"golang.org/x/text/language"
+)
+
+func TestImagesHandler(t *testing.T) {
+	for _, c := range []struct {
+		name   string
+		query  string
+		cursor string
+		want   *response
+	}{
+		{
+			name: "empty query",
+			want: &response{
+				status: http.StatusBadRequest,
+				err:    errMissingQuery,
+			},
+		},
+		{
+			name:  "first page",
+			query: "cats",
+			want: &response{
+				status:   http.StatusOK,
+				template: "json",
+				data:     mockImageResults,
+			},
+		},
+		{
+			name:   "next page",
+			query:  "cats",
+			cursor: "next",
+			want: &response{
+				status:   http.StatusOK,
+				template: "json",
+				data:     mockImageResults,
+			},
+		},
+		{
+			name:   "bad cursor",
+			query:  "cats",
+			cursor: "bogus",
+			want: &response{
+				status: http.StatusBadRequest,
+				err:    img.ErrInvalidCursor,
+			},
+		},
+	} {
+		t.Run(c.name, func(t *testing.T) {
+			f := &Frontend{
+				Bangs: &bangs.Bangs{},
+				Document: Document{
+					Matcher: language.NewMatcher([]language.Tag{language.English}),
+				},
+			}
+			f.Images.Fetcher = &mockImages{}
+			f.Cache.Cacher = &mockCacher{}
+
+			req, err := http.NewRequest("GET", "/api/v1/images", nil)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			q := req.URL.Query()
+			q.Add("q", c.query)
+			q.Add("cursor", c.cursor)
+			req.URL.RawQuery = q.Encode()
+
+			got := f.imagesHandler(httptest.NewRecorder(), req)
+
+			if !reflect.DeepEqual(got, c.want) {
+				t.Fatalf("got %+v; want %+v", got, c.want)
+			}
+		})
+	}
+}
+
+func TestThumbnail(t *testing.T) {
+	got := thumbnail("https://example.com/cat.jpg")
+	want := "/image/225x,s" + hmacKey("https://example.com/cat.jpg") + "/https:/example.com/cat.jpg"
+
+	if got != want {
+		t.Fatalf("got %q; want %q", got, want)
+	}
+}
@@ -0,0 +1,228 @@
+package frontend
+
+import (
+	"encoding/json"
+	"fmt"
+	"html"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/jivesearch/jivesearch/instant"
+	"github.com/jivesearch/jivesearch/instant/wikipedia"
+	"github.com/jivesearch/jivesearch/log"
+	"golang.org/x/text/language"
+)
+
+// DuckDuckGo mimics DuckDuckGo's Instant Answer API so their clients can point to us instead.
+// https://duckduckgo.com/api
+type DuckDuckGo struct {
+	Abstract         string            `json:"Abstract"`
+	AbstractText     string            `json:"AbstractText"`
+	AbstractSource   string            `json:"AbstractSource"`
+	AbstractURL      string            `json:"AbstractURL"`
+	Image            string            `json:"Image"`
+	Heading          string            `json:"Heading"`
+	Answer           string            `json:"Answer"`
+	AnswerType       string            `json:"AnswerType"`
+	Definition       string            `json:"Definition"`
+	DefinitionSource string            `json:"DefinitionSource"`
+	DefinitionURL    string            `json:"DefinitionURL"`
+	Entity           string            `json:"Entity"`
+	Infobox          interface{}       `json:"Infobox"` // an empty string when there isn't one
+	Redirect         string            `json:"Redirect"`
+	RelatedTopics    []DuckDuckGoTopic `json:"RelatedTopics"`
+	Results          []DuckDuckGoTopic `json:"Results"`
+	Type             string            `json:"Type"` // A (article), D (disambiguation), C (category), N (name), E (exclusive) or nothing
+}
+
+// DuckDuckGoTopic is a related topic or external link
+type DuckDuckGoTopic struct {
+	FirstURL string         `json:"FirstURL"`
+	Icon     DuckDuckGoIcon `json:"Icon"`
+	Result   string         `json:"Result"`
+	Text     string         `json:"Text"`
+}
+
+// DuckDuckGoIcon is the icon of a topic
+type DuckDuckGoIcon struct {
+	Height string `json:"Height"`
+	URL    string `json:"URL"`
+	Width  string `json:"Width"`
+}
+
+// DuckDuckGoInfobox holds the facts about an entity
+type DuckDuckGoInfobox struct {
+	Content []DuckDuckGoInfo `json:"content"`
+}
+
+// DuckDuckGoInfo is a single fact in the infobox
+type DuckDuckGoInfo struct {
+	DataType string `json:"data_type"`
+	Label    string `json:"label"`
+	Value    string `json:"value"`
+}
+
+// instantHandler runs only the instant answers, skipping search and images.
+// The format=ddg param returns DuckDuckGo's format rather than our own.
+func (f *Frontend) instantHandler(w http.ResponseWriter, r *http.Request) *response {
+	d, err := f.getData(r)
+	if err != nil {
+		return &response{
+			status: http.StatusBadRequest,
+			err:    err,
+		}
+	}
+
+	if d.Context.Q == "" {
+		return &response{
+			status: http.StatusBadRequest,
+			err:    errMissingQuery,
+		}
+	}
+
+	ic := make(chan instant.Data)
+	go f.getAnswer(r, d, ic)
+	sol := <-ic
+
+	resp := &response{
+		status:   http.StatusOK,
+		template: "json",
+		data:     sol,
+	}
+
+	if r.FormValue("format") == "ddg" {
+		lang, _, _ := f.Wikipedia.Matcher.Match(d.Context.Preferred...)
+		resp.data = f.duckDuckGo(sol, lang, d.Brand.Host)
+	}
+
+	return resp
+}
+
+// duckDuckGo converts our answers to DuckDuckGo's format.
+// The best answer goes first and the secondary answers fill in whatever it leaves blank.
+// Our images and related searches link to host.
+func (f *Frontend) duckDuckGo(sol instant.Data, lang language.Tag, host string) *DuckDuckGo {
+	ddg := &DuckDuckGo{
+		Infobox:       "",
+		RelatedTopics: []DuckDuckGoTopic{},
+		Results:       []DuckDuckGoTopic{},
+	}
+
+	for _, a := range append([]instant.Data{sol}, sol.Secondary...) {
+		if !a.Triggered {
+			continue
+		}
+
+		switch a.Type {
+		case instant.WikipediaType:
+			items, _ := a.Solution.([]*wikipedia.Item)
+			if ddg.Heading != "" || len(items) == 0 || items[0].Wikipedia.Title == "" {
+				continue
+			}
+
+			f.ddgAbstract(ddg, items[0], lang, host)
+		case instant.WiktionaryType:
+			var w wikipedia.Wiktionary
+			switch s := a.Solution.(type) {
+			case wikipedia.Wiktionary:
+				w = s
+			case *wikipedia.Wiktionary:
+				w = *s
+			}
+
+			if ddg.Definition != "" || len(w.Definitions) == 0 {
+				continue
+			}
+
+			ddg.Definition = w.Definitions[0].Meaning
+			ddg.DefinitionSource = "Wiktionary"
+			ddg.DefinitionURL = fmt.Sprintf("https://%v.wiktionary.org/wiki/%v", base(w.Language, lang), wikiPath(w.Title))
+		default:
+			if ddg.Answer != "" {
+				continue
+			}
+
+			ddg.Answer = answerText(a.Solution)
+			ddg.AnswerType = string(a.Type)
+		}
+	}
+
+	switch {
+	case ddg.Heading != "":
+		ddg.Type = "A"
+	case ddg.Answer != "" || ddg.Definition != "":
+		ddg.Type = "E"
+	}
+
+	return ddg
+}
+
+// ddgAbstract fills in the abstract, infobox and related topics from a Wikipedia article
+func (f *Frontend) ddgAbstract(ddg *DuckDuckGo, item *wikipedia.Item, lang language.Tag, host string) {
+	ddg.Heading = item.Wikipedia.Title
+	ddg.Abstract = item.Wikipedia.Text
+	ddg.AbstractText = item.Wikipedia.Text
+	ddg.AbstractSource = "Wikipedia"
+	ddg.AbstractURL = fmt.Sprintf("https://%v.wikipedia.org/wiki/%v", base(item.Wikipedia.Language, lang), wikiPath(item.Wikipedia.Title))
+
+	p := wikipedia.NewPanel(item, lang)
+	if p == nil {
+		return
+	}
+
+	ddg.Entity = p.Description
+
+	if p.Image != "" {
+		ddg.Image = fmt.Sprintf("%v/image/250x,s%v/%v", host, hmacKey(p.Image), p.Image)
+	}
+
+	if len(p.Facts) > 0 {
+		ib := DuckDuckGoInfobox{}
+		for _, fact := range p.Facts {
+			ib.Content = append(ib.Content, DuckDuckGoInfo{DataType: "string", Label: fact.Label, Value: fact.Value})
+		}
+		ddg.Infobox = ib
+	}
+
+	for _, e := range p.Related {
+		u := fmt.Sprintf("%v/?q=%v", host, url.QueryEscape(e.Label))
+		ddg.RelatedTopics = append(ddg.RelatedTopics, DuckDuckGoTopic{
+			FirstURL: u,
+			Result:   fmt.Sprintf(`<a href="%v">%v</a> (%v)`, html.EscapeString(u), html.EscapeString(e.Label), e.Relation),
+			Text:     fmt.Sprintf("%v (%v)", e.Label, e.Relation),
+		})
+	}
+}
+
+// answerText flattens a solution into the single string DuckDuckGo's clients expect
+func answerText(s interface{}) string {
+	switch v := s.(type) {
+	case string:
+		return v
+	case fmt.Stringer:
+		return v.String()
+	}
+
+	b, err := json.Marshal(s)
+	if err != nil {
+		log.Debug.Println(err)
+		return ""
+	}
+
+	return string(b)
+}
+
+// base is the language of a Wiki or else the user's language
+func base(l string, lang language.Tag) string {
+	if l != "" {
+		return l
+	}
+
+	b, _ := lang.Base()
+	return b.String()
+}
+
+func wikiPath(title string) string {
+	return url.PathEscape(strings.Replace(title, " ", "_", -1))
+}
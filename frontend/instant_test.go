@@ -0,0 +1,129 @@
+package frontend
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/jivesearch/jivesearch/instant"
+	"github.com/jivesearch/jivesearch/instant/wikipedia"
+	"golang.org/x/text/language"
+)
+
+func TestInstantHandler(t *testing.T) {
+	bngs, err := bangsFromConfig()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	eiffel := []*wikipedia.Item{
+		{
+			Wikipedia: wikipedia.Wikipedia{
+				Language: "en",
+				Title:    "Eiffel Tower",
+			},
+			Wikidata: &wikipedia.Wikidata{
+				ID: "Q243",
+				Descriptions: wikipedia.Descriptions{
+					"en": {Text: "tower located on the Champ de Mars in Paris, France", Language: "en"},
+				},
+				Claims: &wikipedia.Claims{
+					Country: []wikipedia.Country{
+						{Item: []wikipedia.Wikidata{{ID: "Q142", Labels: wikipedia.Labels{"en": {Text: "France", Language: "en"}}}}},
+					},
+				},
+			},
+		},
+	}
+
+	for _, c := range []struct {
+		name  string
+		query string
+		want  *response
+	}{
+		{
+			"missing query", "",
+			&response{
+				status: http.StatusBadRequest,
+				err:    errMissingQuery,
+			},
+		},
+		{
+			"native", "q=reverse+hello",
+			&response{
+				status:   http.StatusOK,
+				template: "json",
+				data: instant.Data{
+					Type:       instant.ReverseType,
+					Triggered:  true,
+					Solution:   "olleh",
+					Confidence: 1,
+					Secondary: []instant.Data{
+						{
+							Type:       instant.WikipediaType,
+							Triggered:  true,
+							Solution:   eiffel,
+							Confidence: .6,
+						},
+					},
+				},
+			},
+		},
+		{
+			"ddg", "q=reverse+hello&format=ddg",
+			&response{
+				status:   http.StatusOK,
+				template: "json",
+				data: &DuckDuckGo{
+					AbstractSource: "Wikipedia",
+					AbstractURL:    "https://en.wikipedia.org/wiki/Eiffel_Tower",
+					Heading:        "Eiffel Tower",
+					Answer:         "olleh",
+					AnswerType:     "reverse",
+					Entity:         "tower located on the Champ de Mars in Paris, France",
+					Infobox: DuckDuckGoInfobox{
+						Content: []DuckDuckGoInfo{
+							{DataType: "string", Label: "Country", Value: "France"},
+						},
+					},
+					RelatedTopics: []DuckDuckGoTopic{},
+					Results:       []DuckDuckGoTopic{},
+					Type:          "A",
+				},
+			},
+		},
+	} {
+		t.Run(c.name, func(t *testing.T) {
+			matcher := language.NewMatcher([]language.Tag{language.English})
+
+			f := &Frontend{
+				Bangs: bngs,
+				Document: Document{
+					Matcher: matcher,
+				},
+				Instant: &instant.Instant{
+					QueryVar:         "q",
+					WikipediaFetcher: &mockKnowledgeFetcher{},
+				},
+				Wikipedia: Wikipedia{
+					Matcher: matcher,
+				},
+			}
+			f.Cache.Cacher = &mockCacher{}
+			f.Cache.Instant = 10 * time.Second
+
+			req, err := http.NewRequest("GET", "/api/v1/instant?"+c.query, nil)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			got := f.instantHandler(httptest.NewRecorder(), req)
+
+			if !reflect.DeepEqual(got, c.want) {
+				t.Fatalf("got %+v; want %+v", got, c.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,49 @@
+package frontend
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/jivesearch/jivesearch/instant/wikipedia"
+	"github.com/jivesearch/jivesearch/log"
+)
+
+// knowledgePanel assembles an infobox for entity queries like "Marie Curie".
+// The query must name the entity itself, otherwise "marie curie radium"
+// would show Marie Curie's panel. Nil means no panel.
+func (f *Frontend) knowledgePanel(r *http.Request, d data, kc chan *wikipedia.Panel) {
+	lang, _, _ := f.Wikipedia.Matcher.Match(d.Context.Preferred...)
+	key := cacheKey("knowledge", lang, d.Context.Region, formURL(r))
+
+	v := f.cacheGet(r.Context(), "knowledge", key)
+
+	if v != nil {
+		p := &wikipedia.Panel{}
+		if err := json.Unmarshal(v.([]byte), &p); err != nil {
+			log.Info.Println(err)
+		}
+
+		kc <- p
+		return
+	}
+
+	items, err := f.Instant.WikipediaFetcher.Fetch(d.Context.Q, lang)
+	if err != nil {
+		log.Infow(r.Context(), "fetch failed", log.Fields{"fetcher": "wikipedia", "error": err})
+		kc <- nil
+		return
+	}
+
+	var p *wikipedia.Panel
+
+	for _, item := range items {
+		if pp := wikipedia.NewPanel(item, lang); pp != nil && pp.Matches(d.Context.Q) {
+			p = pp
+			break
+		}
+	}
+
+	f.cachePut(r.Context(), key, p, f.Cache.Instant)
+
+	kc <- p
+}
@@ -0,0 +1,94 @@
+package frontend
+
+import (
+	"net/http"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/jivesearch/jivesearch/instant"
+	"github.com/jivesearch/jivesearch/instant/wikipedia"
+	"golang.org/x/text/language"
+)
+
+func TestKnowledgePanel(t *testing.T) {
+	for _, c := range []struct {
+		q    string
+		want *wikipedia.Panel
+	}{
+		{"eiffel tower", &wikipedia.Panel{
+			ID:          "Q243",
+			Title:       "Eiffel Tower",
+			Description: "tower located on the Champ de Mars in Paris, France",
+			Language:    "en",
+			Facts: []wikipedia.Fact{
+				{Label: "Country", Value: "France"},
+			},
+		}},
+		{"eiffel tower height", nil},
+	} {
+		t.Run(c.q, func(t *testing.T) {
+			matcher := language.NewMatcher([]language.Tag{language.English})
+
+			f := &Frontend{
+				Instant: &instant.Instant{
+					WikipediaFetcher: &mockKnowledgeFetcher{},
+				},
+				Wikipedia: Wikipedia{
+					Matcher: matcher,
+				},
+			}
+			f.Cache.Cacher = &mockCacher{}
+			f.Cache.Instant = 10 * time.Second
+
+			req, err := http.NewRequest("GET", "/", nil)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			d := data{
+				Context: &Context{
+					Q:         c.q,
+					Preferred: []language.Tag{language.English},
+					Region:    language.MustParseRegion("US"),
+				},
+			}
+
+			kc := make(chan *wikipedia.Panel)
+			go f.knowledgePanel(req, d, kc)
+			got := <-kc
+
+			if !reflect.DeepEqual(got, c.want) {
+				t.Fatalf("got %+v; want %+v", got, c.want)
+			}
+		})
+	}
+}
+
+type mockKnowledgeFetcher struct{}
+
+func (mf *mockKnowledgeFetcher) Fetch(query string, lang language.Tag) ([]*wikipedia.Item, error) {
+	return []*wikipedia.Item{
+		{
+			Wikipedia: wikipedia.Wikipedia{
+				Language: "en",
+				Title:    "Eiffel Tower",
+			},
+			Wikidata: &wikipedia.Wikidata{
+				ID: "Q243",
+				Descriptions: wikipedia.Descriptions{
+					"en": {Text: "tower located on the Champ de Mars in Paris, France", Language: "en"},
+				},
+				Claims: &wikipedia.Claims{
+					Country: []wikipedia.Country{
+						{Item: []wikipedia.Wikidata{{ID: "Q142", Labels: wikipedia.Labels{"en": {Text: "France", Language: "en"}}}}},
+					},
+				},
+			},
+		},
+	}, nil
+}
+
+func (mf *mockKnowledgeFetcher) Setup() error {
+	return nil
+}
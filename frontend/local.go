@@ -0,0 +1,77 @@
+package frontend
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+
+	"github.com/jivesearch/jivesearch/instant"
+	"github.com/jivesearch/jivesearch/instant/maps"
+	"github.com/jivesearch/jivesearch/log"
+	"github.com/jivesearch/jivesearch/search/local"
+	"golang.org/x/text/language"
+)
+
+// maxLocal is the most places we'll show
+const maxLocal = 10
+
+// localResults finds places near the location in the query ("pizza near boston")
+// or, failing that, near the user's IP address.
+func (f *Frontend) localResults(r *http.Request, d data, lang language.Tag, region language.Region) *local.Results {
+	if f.Local == nil {
+		return &local.Results{}
+	}
+
+	what, where := local.ParseQuery(d.Context.Q)
+
+	var near maps.Coordinate
+	var err error
+
+	switch where {
+	case "":
+		city, err := f.Instant.LocationFetcher.Fetch(instant.IPAddress(r))
+		if err != nil {
+			log.Infow(r.Context(), "fetch failed", log.Fields{"fetcher": "location", "error": err})
+			return &local.Results{}
+		}
+
+		near = maps.Coordinate{Latitude: city.Location.Latitude, Longitude: city.Location.Longitude}
+	default:
+		if near, err = f.coordinate(r.Context(), where, lang, region); err != nil {
+			log.Info.Println(err)
+			return &local.Results{}
+		}
+	}
+
+	u := &url.URL{
+		Path: "/",
+		RawQuery: url.Values{
+			"q":    {what},
+			"near": {fmt.Sprintf("%v,%v", near.Latitude, near.Longitude)},
+		}.Encode(),
+	}
+	key := cacheKey("local", lang, region, u)
+
+	v := f.cacheGet(r.Context(), "local", key)
+
+	if v != nil {
+		lr := &local.Results{}
+		if err := json.Unmarshal(v.([]byte), lr); err != nil {
+			log.Info.Println(err)
+		}
+		lr.Location = where
+		return lr
+	}
+
+	lr, err := f.Local.Fetch(what, near, lang, maxLocal)
+	if err != nil {
+		log.Infow(r.Context(), "fetch failed", log.Fields{"fetcher": "local", "error": err})
+		return &local.Results{}
+	}
+
+	f.cachePut(r.Context(), key, lr, f.Cache.Search)
+
+	lr.Location = where
+	return lr
+}
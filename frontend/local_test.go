@@ -0,0 +1,92 @@
+package frontend
+
+import (
+	"net"
+	"net/http"
+	"reflect"
+	"testing"
+
+	"github.com/jivesearch/jivesearch/instant"
+	"github.com/jivesearch/jivesearch/instant/location"
+	"github.com/jivesearch/jivesearch/instant/maps"
+	"github.com/jivesearch/jivesearch/search/local"
+	"golang.org/x/text/language"
+)
+
+func TestLocalResults(t *testing.T) {
+	for _, c := range []struct {
+		q    string
+		want *local.Results
+	}{
+		{
+			"pizza",
+			&local.Results{
+				Provider: local.OverpassProvider,
+				Near:     maps.Coordinate{Latitude: 42.3601, Longitude: -71.0589},
+				Places: []*local.Place{
+					{Marker: "A", Name: "pizza place", Coordinate: maps.Coordinate{Latitude: 42.3601, Longitude: -71.0589}},
+				},
+			},
+		},
+		{
+			"pizza near paris",
+			&local.Results{
+				Provider: local.OverpassProvider,
+				Location: "paris",
+				Near:     maps.Coordinate{Latitude: 48.8566, Longitude: 2.3522},
+				Places: []*local.Place{
+					{Marker: "A", Name: "pizza place", Coordinate: maps.Coordinate{Latitude: 48.8566, Longitude: 2.3522}},
+				},
+			},
+		},
+		{
+			"pizza near atlantis",
+			&local.Results{},
+		},
+	} {
+		t.Run(c.q, func(t *testing.T) {
+			f := mapsFrontend(t)
+			f.Instant = &instant.Instant{
+				LocationFetcher: &mockLocationFetcher{},
+			}
+			f.Local = &mockLocalFetcher{}
+
+			r, err := http.NewRequest("GET", "/?t=local&q="+c.q, nil)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			d := data{
+				Context: &Context{Q: c.q},
+			}
+
+			got := f.localResults(r, d, language.English, language.MustParseRegion("US"))
+
+			if !reflect.DeepEqual(got, c.want) {
+				t.Fatalf("got %+v; want %+v", got, c.want)
+			}
+		})
+	}
+}
+
+type mockLocalFetcher struct{}
+
+func (l *mockLocalFetcher) Fetch(q string, near maps.Coordinate, lang language.Tag, number int) (*local.Results, error) {
+	return &local.Results{
+		Provider: local.OverpassProvider,
+		Near:     near,
+		Places: []*local.Place{
+			{Marker: "A", Name: q + " place", Coordinate: near},
+		},
+	}, nil
+}
+
+type mockLocationFetcher struct{}
+
+func (l *mockLocationFetcher) Fetch(ip net.IP) (*location.City, error) {
+	c := &location.City{}
+	c.Location.Latitude = 42.3601
+	c.Location.Longitude = -71.0589
+	c.Country.IsoCode = "DE"
+	return c, nil
+}
@@ -0,0 +1,170 @@
+package frontend
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/jivesearch/jivesearch/instant/maps"
+	"github.com/jivesearch/jivesearch/log"
+	"golang.org/x/text/language"
+)
+
+var errMissingDirections = fmt.Errorf("missing from or to")
+
+// geocodeHandler proxies geocoding requests so our provider key is never sent to the browser
+func (f *Frontend) geocodeHandler(w http.ResponseWriter, r *http.Request) *response {
+	d, err := f.getData(r)
+	if err != nil {
+		return &response{
+			status: http.StatusBadRequest,
+			err:    err,
+		}
+	}
+
+	if d.Context.Q == "" {
+		return &response{
+			status: http.StatusBadRequest,
+			err:    errMissingQuery,
+		}
+	}
+
+	g, err := f.geocode(r.Context(), d.Context.Q, d.Context.lang, d.Context.Region)
+	if err != nil {
+		return &response{
+			status: http.StatusInternalServerError,
+			err:    err,
+		}
+	}
+
+	return &response{
+		status:   http.StatusOK,
+		template: "json",
+		data:     g,
+	}
+}
+
+// directionsHandler proxies directions requests.
+// The from and to params are either "lat,long" or a place to geocode.
+func (f *Frontend) directionsHandler(w http.ResponseWriter, r *http.Request) *response {
+	d, err := f.getData(r)
+	if err != nil {
+		return &response{
+			status: http.StatusBadRequest,
+			err:    err,
+		}
+	}
+
+	from, to := strings.TrimSpace(r.FormValue("from")), strings.TrimSpace(r.FormValue("to"))
+	if from == "" || to == "" {
+		return &response{
+			status: http.StatusBadRequest,
+			err:    errMissingDirections,
+		}
+	}
+
+	profile, err := maps.ParseProfile(r.FormValue("profile"))
+	if err != nil {
+		return &response{
+			status: http.StatusBadRequest,
+			err:    err,
+		}
+	}
+
+	var coords [2]maps.Coordinate
+	for i, s := range []string{from, to} {
+		if coords[i], err = f.coordinate(r.Context(), s, d.Context.lang, d.Context.Region); err != nil {
+			return &response{
+				status: http.StatusBadRequest,
+				err:    err,
+			}
+		}
+	}
+
+	u := &url.URL{
+		Path: "/",
+		RawQuery: url.Values{
+			"from":    {fmt.Sprintf("%v,%v", coords[0].Latitude, coords[0].Longitude)},
+			"to":      {fmt.Sprintf("%v,%v", coords[1].Latitude, coords[1].Longitude)},
+			"profile": {string(profile)},
+		}.Encode(),
+	}
+	key := cacheKey("directions", language.Und, language.Region{}, u)
+
+	v := f.cacheGet(r.Context(), "directions", key)
+
+	if v != nil {
+		dir := &maps.Directions{}
+		if err := json.Unmarshal(v.([]byte), dir); err != nil {
+			log.Info.Println(err)
+		}
+
+		return &response{
+			status:   http.StatusOK,
+			template: "json",
+			data:     dir,
+		}
+	}
+
+	dir, err := f.Maps.Directions(profile, coords[0], coords[1])
+	if err != nil {
+		return &response{
+			status: http.StatusInternalServerError,
+			err:    err,
+		}
+	}
+
+	f.cachePut(r.Context(), key, dir, f.Cache.Search)
+
+	return &response{
+		status:   http.StatusOK,
+		template: "json",
+		data:     dir,
+	}
+}
+
+// coordinate parses a "lat,long" pair, falling back to the best geocoded match
+func (f *Frontend) coordinate(ctx context.Context, s string, lang language.Tag, region language.Region) (maps.Coordinate, error) {
+	if c, err := maps.ParseCoordinate(s); err == nil {
+		return c, nil
+	}
+
+	g, err := f.geocode(ctx, s, lang, region)
+	if err != nil {
+		return maps.Coordinate{}, err
+	}
+
+	if len(g.Places) == 0 {
+		return maps.Coordinate{}, fmt.Errorf("unable to find %q", s)
+	}
+
+	return g.Places[0].Coordinate, nil
+}
+
+// geocode is cached by query, language and region. Places don't move much.
+func (f *Frontend) geocode(ctx context.Context, q string, lang language.Tag, region language.Region) (*maps.Geocoding, error) {
+	u := &url.URL{Path: "/", RawQuery: url.Values{"q": {q}}.Encode()}
+	key := cacheKey("geocode", lang, region, u)
+
+	v := f.cacheGet(ctx, "geocode", key)
+
+	if v != nil {
+		g := &maps.Geocoding{}
+		if err := json.Unmarshal(v.([]byte), g); err != nil {
+			log.Info.Println(err)
+		}
+		return g, nil
+	}
+
+	g, err := f.Maps.Geocode(q, lang)
+	if err != nil {
+		return nil, err
+	}
+
+	f.cachePut(ctx, key, g, f.Cache.Search)
+
+	return g, nil
+}
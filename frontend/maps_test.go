@@ -0,0 +1,173 @@
+package frontend
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+
+	"github.com/jivesearch/jivesearch/instant/maps"
+	"golang.org/x/text/language"
+)
+
+func TestGeocodeHandler(t *testing.T) {
+	for _, c := range []struct {
+		name  string
+		query string
+		want  *response
+	}{
+		{
+			"missing query", "",
+			&response{
+				status: http.StatusBadRequest,
+				err:    errMissingQuery,
+			},
+		},
+		{
+			"paris", "q=paris",
+			&response{
+				status:   http.StatusOK,
+				template: "json",
+				data:     mockParis,
+			},
+		},
+	} {
+		t.Run(c.name, func(t *testing.T) {
+			f := mapsFrontend(t)
+
+			req, err := http.NewRequest("GET", "/maps/geocode?"+c.query, nil)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			got := f.geocodeHandler(httptest.NewRecorder(), req)
+
+			if !reflect.DeepEqual(got, c.want) {
+				t.Fatalf("got %+v; want %+v", got, c.want)
+			}
+		})
+	}
+}
+
+func TestDirectionsHandler(t *testing.T) {
+	for _, c := range []struct {
+		name  string
+		query string
+		want  *response
+	}{
+		{
+			"missing to", "from=paris",
+			&response{
+				status: http.StatusBadRequest,
+				err:    errMissingDirections,
+			},
+		},
+		{
+			"bad profile", "from=paris&to=48.8,2.3&profile=flying",
+			&response{
+				status: http.StatusBadRequest,
+				err:    fmt.Errorf(`unknown profile "flying"`),
+			},
+		},
+		{
+			"unknown place", "from=atlantis&to=paris",
+			&response{
+				status: http.StatusBadRequest,
+				err:    fmt.Errorf(`unable to find "atlantis"`),
+			},
+		},
+		{
+			"place and coordinate", "from=paris&to=45.76,4.84&profile=cycling",
+			&response{
+				status:   http.StatusOK,
+				template: "json",
+				data: &maps.Directions{
+					Profile: maps.Cycling,
+					Routes: []maps.Route{
+						{
+							Distance: 100,
+							Duration: 10,
+							Geometry: []maps.Coordinate{{Latitude: 48.8566, Longitude: 2.3522}, {Latitude: 45.76, Longitude: 4.84}},
+							Steps:    []maps.Step{},
+						},
+					},
+					Provider: maps.OSRMProvider,
+				},
+			},
+		},
+	} {
+		t.Run(c.name, func(t *testing.T) {
+			f := mapsFrontend(t)
+
+			req, err := http.NewRequest("GET", "/maps/directions?"+c.query, nil)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			got := f.directionsHandler(httptest.NewRecorder(), req)
+
+			if !reflect.DeepEqual(got, c.want) {
+				t.Fatalf("got %+v; want %+v", got, c.want)
+			}
+		})
+	}
+}
+
+func mapsFrontend(t *testing.T) *Frontend {
+	bngs, err := bangsFromConfig()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	f := &Frontend{
+		Bangs: bngs,
+		Document: Document{
+			Matcher: language.NewMatcher([]language.Tag{language.English}),
+		},
+	}
+	f.Cache.Cacher = &mockCacher{}
+	f.Maps.Geocoder = &mockGeocoder{}
+	f.Maps.Router = &mockRouter{}
+	return f
+}
+
+var mockParis = &maps.Geocoding{
+	Places: []maps.Place{
+		{
+			Name:       "Paris, France",
+			Coordinate: maps.Coordinate{Latitude: 48.8566, Longitude: 2.3522},
+		},
+	},
+	Provider: maps.NominatimProvider,
+}
+
+type mockGeocoder struct{}
+
+func (g *mockGeocoder) Geocode(q string, lang language.Tag) (*maps.Geocoding, error) {
+	if q == "paris" {
+		return mockParis, nil
+	}
+
+	return &maps.Geocoding{
+		Places:   []maps.Place{},
+		Provider: maps.NominatimProvider,
+	}, nil
+}
+
+type mockRouter struct{}
+
+func (r *mockRouter) Directions(profile maps.Profile, from, to maps.Coordinate) (*maps.Directions, error) {
+	return &maps.Directions{
+		Profile: profile,
+		Routes: []maps.Route{
+			{
+				Distance: 100,
+				Duration: 10,
+				Geometry: []maps.Coordinate{from, to},
+				Steps:    []maps.Step{},
+			},
+		},
+		Provider: maps.OSRMProvider,
+	}, nil
+}
@@ -6,7 +6,7 @@ func (f *Frontend) openSearchHandler(w http.ResponseWriter, r *http.Request) *re
 	resp := &response{
 		status: http.StatusOK,
 		data: data{
-			Brand: f.Brand,
+			Brand: f.tenant(r).Brand,
 		},
 		template: "opensearch",
 		err:      nil,
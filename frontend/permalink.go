@@ -0,0 +1,125 @@
+package frontend
+
+import (
+	"bytes"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/PuerkitoBio/goquery"
+	"github.com/gorilla/mux"
+	"github.com/jivesearch/jivesearch/instant"
+)
+
+// unshareable answers are random or about whoever asks, like their ip address or local weather,
+// so a link to them would show something else to everyone who opens it. Maps need the scripts of our search page.
+var unshareable = map[instant.Type]bool{
+	instant.CoinTossType:     true,
+	instant.DiceType:         true,
+	instant.LocalWeatherType: true,
+	instant.MapsType:         true,
+	instant.MyIPType:         true,
+	instant.PasswordType:     true,
+	instant.RandomType:       true,
+	instant.UserAgentType:    true,
+	instant.UUIDType:         true,
+}
+
+// maxDescription is as long as the og:description gets. Longer ones are cut off when shared anyway.
+const maxDescription = 200
+
+// answerPage is an instant answer on a page of its own
+type answerPage struct {
+	data
+	URL         string
+	Title       string
+	Description string
+}
+
+// permalink links to a page with just the answer to a query, e.g. "/answer/stock-quote/aapl-quote".
+// The slug is the query with dashes for spaces. A query with dashes of its own wouldn't be the same
+// once we turn them back into spaces so it gets no link.
+func permalink(d instant.Data, q string) string {
+	q = strings.ToLower(strings.Join(strings.Fields(q), " "))
+	if !d.Triggered || unshareable[d.Type] || q == "" || strings.Contains(q, "-") {
+		return ""
+	}
+
+	return fmt.Sprintf("/answer/%v/%v", slug(string(d.Type)), url.PathEscape(slug(q)))
+}
+
+func slug(s string) string {
+	return strings.Replace(s, " ", "-", -1)
+}
+
+func unslug(s string) string {
+	return strings.Replace(s, "-", " ", -1)
+}
+
+// permalinkHandler answers the query in the slug again and shows it with the OpenGraph tags
+// sites use for the preview of a link. A query that no longer triggers an answer of the type
+// in the link is not found.
+func (f *Frontend) permalinkHandler(w http.ResponseWriter, r *http.Request) *response {
+	vars := mux.Vars(r)
+	typ, q := instant.Type(unslug(vars["type"])), unslug(vars["slug"])
+
+	if unshareable[typ] || strings.TrimSpace(q) == "" {
+		return &response{status: http.StatusNotFound, err: fmt.Errorf("no permalink for %q", r.URL.Path)}
+	}
+
+	// the answerers read the query from the form. We share our cache with the answer widget.
+	v := r.URL.Query()
+	v.Set("q", q)
+	u := url.URL{Path: "/answer", RawQuery: v.Encode()}
+
+	r = r.WithContext(r.Context())
+	r.URL, r.Form = &u, nil
+
+	d, err := f.getData(r)
+	if err != nil {
+		return &response{status: http.StatusBadRequest, err: err}
+	}
+
+	ic := make(chan instant.Data)
+	go f.getAnswer(r, d, ic)
+	d.Instant = <-ic
+
+	link := permalink(d.Instant, d.Context.Q)
+	if d.Instant.Type != typ || link == "" {
+		return &response{status: http.StatusNotFound, err: fmt.Errorf("%q isn't a %v answer", q, typ)}
+	}
+
+	p := answerPage{
+		data:  d,
+		URL:   d.Brand.Host + link,
+		Title: fmt.Sprintf("%v - %v", d.Context.Q, d.Brand.Name),
+	}
+
+	var buf bytes.Buffer
+	if err := templates["answer"].Execute(&buf, d); err != nil {
+		return &response{status: http.StatusInternalServerError, err: err}
+	}
+
+	if p.Description, err = describe(buf.String()); err != nil {
+		return &response{status: http.StatusInternalServerError, err: err}
+	}
+
+	return &response{
+		status:   http.StatusOK,
+		template: "permalink",
+		data:     p,
+	}
+}
+
+// describe is the text of a rendered answer, without its scripts or where it came from
+func describe(html string) (string, error) {
+	doc, err := goquery.NewDocumentFromReader(strings.NewReader(html))
+	if err != nil {
+		return "", err
+	}
+
+	doc.Find("script, style, #source").Remove()
+
+	return truncate(strings.Join(strings.Fields(doc.Text()), " "), maxDescription, true), nil
+}
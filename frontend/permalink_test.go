@@ -0,0 +1,111 @@
+package frontend
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gorilla/mux"
+	"github.com/jivesearch/jivesearch/instant"
+	"golang.org/x/text/language"
+)
+
+func TestPermalink(t *testing.T) {
+	for _, c := range []struct {
+		name string
+		d    instant.Data
+		q    string
+		want string
+	}{
+		{"weather", instant.Data{Type: instant.WeatherType, Triggered: true}, "Weather  Paris", "/answer/weather/weather-paris"},
+		{"type with a space", instant.Data{Type: instant.StockQuoteType, Triggered: true}, "aapl quote", "/answer/stock-quote/aapl-quote"},
+		{"escaped", instant.Data{Type: instant.CalculatorType, Triggered: true}, "1/2 + 3", "/answer/calculator/1%2F2-+-3"},
+		{"dash", instant.Data{Type: instant.CalculatorType, Triggered: true}, "5-3", ""},
+		{"random", instant.Data{Type: instant.PasswordType, Triggered: true}, "password", ""},
+		{"not triggered", instant.Data{}, "weather paris", ""},
+	} {
+		t.Run(c.name, func(t *testing.T) {
+			if got := permalink(c.d, c.q); got != c.want {
+				t.Fatalf("got %q; want %q", got, c.want)
+			}
+		})
+	}
+}
+
+func TestPermalinkHandler(t *testing.T) {
+	ParseTemplates()
+
+	bngs, err := bangsFromConfig()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	matcher := language.NewMatcher([]language.Tag{language.English})
+
+	f := &Frontend{
+		Brand: Brand{
+			Name: "Jive Search",
+			Host: "https://jivesearch.com",
+		},
+		Bangs: bngs,
+		Document: Document{
+			Matcher: matcher,
+		},
+		Instant: &instant.Instant{
+			BreachFetcher:        &mockBreachFetcher{},
+			WikipediaFetcher:     &mockWikipediaFetcher{},
+			StackOverflowFetcher: &mockStackOverflowFetcher{},
+		},
+		Wikipedia: Wikipedia{
+			Matcher: matcher,
+		},
+	}
+
+	f.Cache.Cacher = &mockCacher{}
+	f.Cache.Instant = 10 * time.Second
+
+	for _, c := range []struct {
+		name   string
+		typ    string
+		slug   string
+		status int
+		want   []string
+	}{
+		{
+			"birthstone", "birthstone", "january-birthstone", http.StatusOK,
+			[]string{
+				`<title>january birthstone - Jive Search</title>`,
+				`<link rel="canonical" href="https://jivesearch.com/answer/birthstone/january-birthstone">`,
+				`<meta property="og:title" content="january birthstone - Jive Search">`,
+				`<meta property="og:description" content="Garnet">`,
+				`<a href="/?q=january%20birthstone">More results for january birthstone</a>`,
+			},
+		},
+		{"another type", "calculator", "january-birthstone", http.StatusNotFound, nil},
+		{"unshareable", "user-agent", "user-agent", http.StatusNotFound, nil},
+	} {
+		t.Run(c.name, func(t *testing.T) {
+			r, err := http.NewRequest("GET", "/answer/"+c.typ+"/"+c.slug, nil)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			r = mux.SetURLVars(r, map[string]string{"type": c.typ, "slug": c.slug})
+
+			w := httptest.NewRecorder()
+			appHandler(f.permalinkHandler).ServeHTTP(w, r)
+
+			if w.Code != c.status {
+				t.Fatalf("got status %d; want %d", w.Code, c.status)
+			}
+
+			for _, want := range c.want {
+				if !strings.Contains(w.Body.String(), want) {
+					t.Fatalf("got %s; want it to contain %s", w.Body.String(), want)
+				}
+			}
+		})
+	}
+}
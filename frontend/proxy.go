@@ -1,9 +1,6 @@
 package frontend
 
 import (
-	"crypto/hmac"
-	"crypto/sha256"
-	"encoding/base64"
 	"fmt"
 	"io/ioutil"
 	"net/http"
@@ -13,6 +10,7 @@ import (
 
 	"github.com/PuerkitoBio/goquery"
 	"github.com/jivesearch/jivesearch/log"
+	"golang.org/x/net/html"
 )
 
 type proxyResponse struct {
@@ -27,8 +25,9 @@ func (f *Frontend) proxyHeaderHandler(w http.ResponseWriter, r *http.Request) *r
 		status:   http.StatusOK,
 		template: "proxy_header",
 		data: proxyResponse{
-			Brand: f.Brand,
-			URL:   r.FormValue("q"),
+			Brand:   f.tenant(r).Brand,
+			Context: Context{Nonce: nonce(r)},
+			URL:     r.FormValue("q"),
 		},
 		err: nil,
 	}
@@ -36,15 +35,23 @@ func (f *Frontend) proxyHeaderHandler(w http.ResponseWriter, r *http.Request) *r
 	return resp
 }
 
+// proxyHandler serves a sanitized, read-only copy of a page: scripts and
+// trackers are removed and links, images & stylesheets go through our proxy.
+// The "u" param is the url and "key" its HMAC signature. The "q" param is
+// the url for links created before "u".
 func (f *Frontend) proxyHandler(w http.ResponseWriter, r *http.Request) *response {
-	u := r.FormValue("q")
+	u := r.FormValue("u")
+	if u == "" {
+		u = r.FormValue("q")
+	}
 
 	resp := &response{
 		status:   http.StatusOK,
 		template: "proxy",
 		data: proxyResponse{
-			Brand: f.Brand,
-			URL:   u,
+			Brand:   f.tenant(r).Brand,
+			Context: Context{Nonce: nonce(r)},
+			URL:     u,
 		},
 		err: nil,
 	}
@@ -63,7 +70,7 @@ func (f *Frontend) proxyHandler(w http.ResponseWriter, r *http.Request) *respons
 			return resp
 		}
 
-		if !validSignature([]byte(hmacSecret()), uu, signature) {
+		if !f.validSignature(uu, signature) {
 			return resp
 		}
 
@@ -93,7 +100,7 @@ func (f *Frontend) proxyHandler(w http.ResponseWriter, r *http.Request) *respons
 		return resp
 	}
 
-	if !validSignature([]byte(hmacSecret()), base, signature) {
+	if !f.validSignature(base, signature) {
 		return resp
 	}
 
@@ -111,12 +118,7 @@ func (f *Frontend) proxyHandler(w http.ResponseWriter, r *http.Request) *respons
 		return resp
 	}
 
-	// TODO: remove all comments...no need for them
-
-	// remove all javascript
-	doc.Find("script").Each(func(i int, s *goquery.Selection) {
-		s.Remove()
-	})
+	sanitize(doc)
 
 	// disable all forms
 	doc.Find("form").Each(func(i int, s *goquery.Selection) {
@@ -129,8 +131,9 @@ func (f *Frontend) proxyHandler(w http.ResponseWriter, r *http.Request) *respons
 		for _, href := range []string{"href"} {
 			if lnk, ok := s.Attr(href); ok {
 				u, err := createProxyLink(base, lnk, false)
-				if err != nil {
+				if err != nil { // we won't link straight to the page
 					log.Debug.Println(err)
+					s.RemoveAttr(href)
 					return
 				}
 
@@ -146,6 +149,7 @@ func (f *Frontend) proxyHandler(w http.ResponseWriter, r *http.Request) *respons
 			u, err := createProxyLink(base, lnk, true)
 			if err != nil {
 				log.Debug.Println(err)
+				s.RemoveAttr("src")
 				return
 			}
 
@@ -163,7 +167,13 @@ func (f *Frontend) proxyHandler(w http.ResponseWriter, r *http.Request) *respons
 
 				matches := reSrcSet.FindAllStringSubmatch(lnk, -1)
 				if len(matches) == 0 {
-					u := createProxyImage(base, lnk)
+					u, err := createProxyImage(base, lnk)
+					if err != nil {
+						log.Debug.Println(err)
+						s.RemoveAttr(src)
+						continue
+					}
+
 					s.SetAttr(src, u)
 					continue
 				}
@@ -176,11 +186,20 @@ func (f *Frontend) proxyHandler(w http.ResponseWriter, r *http.Request) *respons
 						continue
 					}
 
-					u := createProxyImage(base, m[1])
+					u, err := createProxyImage(base, m[1])
+					if err != nil {
+						log.Debug.Println(err)
+						continue
+					}
 
 					lnks = append(lnks, fmt.Sprintf("%v %v", u, m[2]))
 				}
 
+				if len(lnks) == 0 {
+					s.RemoveAttr(src)
+					continue
+				}
+
 				s.SetAttr(src, strings.Join(lnks, " "))
 			}
 		}
@@ -207,6 +226,7 @@ func (f *Frontend) proxyHandler(w http.ResponseWriter, r *http.Request) *respons
 				u, err := createProxyCSSLink(base, lnk)
 				if err != nil {
 					log.Debug.Println(err)
+					s.RemoveAttr("href")
 					return
 				}
 
@@ -227,15 +247,244 @@ func (f *Frontend) proxyHandler(w http.ResponseWriter, r *http.Request) *respons
 		resp.data = string(h)
 	default:
 		resp.data = proxyResponse{
-			Brand: f.Brand,
-			HTML:  h,
-			URL:   u,
+			Brand:   f.tenant(r).Brand,
+			Context: Context{Nonce: nonce(r)},
+			HTML:    h,
+			URL:     u,
 		}
 	}
 
 	return resp
 }
 
+// trackers are hosts whose images & iframes only exist to track the user
+var trackers = []string{
+	"doubleclick.net",
+	"facebook.com/tr",
+	"google-analytics.com",
+	"googlesyndication.com",
+	"googletagmanager.com",
+	"pixel.wp.com",
+	"quantserve.com",
+	"scorecardresearch.com",
+	"stats.wp.com",
+}
+
+// sanitize keeps only the elements, attributes and urls we know can't run code
+// or phone home when rendering the page. Other elements are unwrapped, keeping
+// their text, except for those that only run or embed something.
+func sanitize(doc *goquery.Document) {
+	// tracking pixels and known trackers
+	doc.Find("img, iframe").Each(func(i int, s *goquery.Selection) {
+		w, _ := s.Attr("width")
+		h, _ := s.Attr("height")
+		if (w == "0" || w == "1") && (h == "0" || h == "1") {
+			s.Remove()
+			return
+		}
+
+		src, _ := s.Attr("src")
+		for _, t := range trackers {
+			if strings.Contains(strings.ToLower(src), t) {
+				s.Remove()
+				return
+			}
+		}
+	})
+
+	for _, n := range doc.Nodes {
+		sanitizeNode(n)
+	}
+}
+
+// elements are the html elements a proxied page keeps
+var elements = map[string]bool{
+	"html": true, "head": true, "body": true, "title": true, "meta": true, "link": true, "style": true,
+	"a": true, "abbr": true, "address": true, "article": true, "aside": true, "b": true, "bdi": true, "bdo": true,
+	"big": true, "blockquote": true, "br": true, "button": true, "caption": true, "center": true, "cite": true,
+	"code": true, "col": true, "colgroup": true, "dd": true, "del": true, "details": true, "dfn": true, "div": true,
+	"dl": true, "dt": true, "em": true, "fieldset": true, "figcaption": true, "figure": true, "font": true,
+	"footer": true, "form": true, "h1": true, "h2": true, "h3": true, "h4": true, "h5": true, "h6": true,
+	"header": true, "hgroup": true, "hr": true, "i": true, "iframe": true, "img": true, "input": true, "ins": true,
+	"kbd": true, "label": true, "legend": true, "li": true, "main": true, "mark": true, "nav": true, "ol": true,
+	"optgroup": true, "option": true, "p": true, "pre": true, "q": true, "rp": true, "rt": true, "ruby": true,
+	"s": true, "samp": true, "section": true, "select": true, "small": true, "span": true, "strike": true,
+	"strong": true, "sub": true, "summary": true, "sup": true, "table": true, "tbody": true, "td": true,
+	"textarea": true, "tfoot": true, "th": true, "thead": true, "time": true, "tr": true, "tt": true, "u": true,
+	"ul": true, "var": true, "wbr": true,
+}
+
+// svgElements are the svg elements that only draw, for a page's icons
+var svgElements = map[string]bool{
+	"svg": true, "g": true, "path": true, "circle": true, "ellipse": true, "line": true, "polyline": true,
+	"polygon": true, "rect": true, "title": true, "desc": true, "defs": true, "symbol": true,
+	"lineargradient": true, "radialgradient": true, "stop": true, "clippath": true, "mask": true,
+}
+
+// embedded elements only run or embed something so are removed along with their content
+var embedded = map[string]bool{
+	"script": true, "noscript": true, "object": true, "embed": true, "applet": true, "param": true,
+	"base": true, "frame": true, "frameset": true, "noframes": true, "template": true, "canvas": true,
+	"audio": true, "video": true, "source": true, "track": true, "portal": true,
+}
+
+// attributes are the attributes the elements keep, besides data-* and aria-*
+var attributes = map[string]bool{
+	"id": true, "class": true, "style": true, "title": true, "lang": true, "dir": true, "hidden": true,
+	"tabindex": true, "role": true, "alt": true, "width": true, "height": true, "align": true, "valign": true,
+	"border": true, "cellpadding": true, "cellspacing": true, "colspan": true, "rowspan": true, "headers": true,
+	"scope": true, "span": true, "summary": true, "bgcolor": true, "color": true, "face": true, "size": true,
+	"nowrap": true, "href": true, "hreflang": true, "name": true, "rel": true, "target": true, "type": true,
+	"media": true, "charset": true, "content": true, "src": true, "srcset": true, "sizes": true, "loading": true,
+	"frameborder": true, "scrolling": true, "method": true, "value": true, "placeholder": true, "checked": true,
+	"selected": true, "disabled": true, "readonly": true, "multiple": true, "maxlength": true, "rows": true,
+	"cols": true, "wrap": true, "label": true, "for": true, "start": true, "reversed": true, "datetime": true,
+	"open": true, "cite": true, "background": true,
+	// svg
+	"viewbox": true, "d": true, "fill": true, "fill-rule": true, "fill-opacity": true, "stroke": true,
+	"stroke-width": true, "stroke-linecap": true, "stroke-linejoin": true, "stroke-dasharray": true,
+	"stroke-opacity": true, "clip-rule": true, "clip-path": true, "opacity": true, "points": true, "cx": true,
+	"cy": true, "r": true, "rx": true, "ry": true, "x": true, "y": true, "x1": true, "y1": true, "x2": true,
+	"y2": true, "transform": true, "xmlns": true, "version": true, "preserveaspectratio": true, "offset": true,
+	"stop-color": true, "stop-opacity": true, "gradientunits": true, "gradienttransform": true, "focusable": true,
+}
+
+// urlAttributes hold a url, which is only kept if it is safe
+var urlAttributes = map[string]bool{
+	"href": true, "src": true, "cite": true, "background": true,
+}
+
+type disposition int
+
+const (
+	keep disposition = iota
+	unwrap
+	drop
+)
+
+// dispose decides what becomes of an element
+func dispose(n *html.Node) disposition {
+	tag := strings.ToLower(n.Data)
+
+	switch n.Namespace {
+	case "":
+	case "svg":
+		if svgElements[tag] {
+			return keep
+		}
+		return drop
+	default: // e.g. math
+		return drop
+	}
+
+	switch {
+	case embedded[tag]:
+		return drop
+	case tag == "link":
+		// stylesheets are proxied but everything else a <link> can fetch is not
+		for _, a := range n.Attr {
+			if strings.ToLower(a.Key) == "rel" && strings.ToLower(strings.TrimSpace(a.Val)) == "stylesheet" {
+				return keep
+			}
+		}
+		return drop
+	case tag == "meta":
+		// e.g. a refresh to another page
+		for _, a := range n.Attr {
+			if strings.ToLower(a.Key) == "http-equiv" {
+				return drop
+			}
+		}
+		return keep
+	case elements[tag]:
+		return keep
+	}
+
+	return unwrap
+}
+
+// sanitizeNode sanitizes the children of a node. Comments go too as old IE runs the ones that look like <!--[if IE]>.
+func sanitizeNode(n *html.Node) {
+	for c := n.FirstChild; c != nil; {
+		next := c.NextSibling
+
+		switch c.Type {
+		case html.CommentNode:
+			n.RemoveChild(c)
+		case html.ElementNode:
+			switch dispose(c) {
+			case keep:
+				c.Attr = safeAttributes(c)
+				sanitizeNode(c)
+			case unwrap:
+				sanitizeNode(c)
+				for gc := c.FirstChild; gc != nil; gc = c.FirstChild {
+					c.RemoveChild(gc)
+					n.InsertBefore(gc, c)
+				}
+				n.RemoveChild(c)
+			default:
+				n.RemoveChild(c)
+			}
+		}
+
+		c = next
+	}
+}
+
+// safeAttributes are the attributes we keep, without the urls that aren't safe.
+// Namespaced attributes, like xlink:href, aren't kept.
+func safeAttributes(n *html.Node) []html.Attribute {
+	attrs := []html.Attribute{}
+
+	for _, a := range n.Attr {
+		key := strings.ToLower(a.Key)
+
+		switch {
+		case a.Namespace != "":
+			continue
+		case strings.HasPrefix(key, "data-"), strings.HasPrefix(key, "aria-"):
+		case !attributes[key]:
+			continue
+		case urlAttributes[key] && !safeURL(strings.ToLower(n.Data), a.Val):
+			continue
+		}
+
+		attrs = append(attrs, a)
+	}
+
+	return attrs
+}
+
+// safeURL is true for http, https and relative urls, mailto: links and data: images.
+// Browsers ignore whitespace and control characters in a scheme, e.g. "java\tscript:", so we do too.
+func safeURL(tag, u string) bool {
+	u = strings.ToLower(strings.Map(func(r rune) rune {
+		if r <= ' ' {
+			return -1
+		}
+		return r
+	}, u))
+
+	if tag == "img" && strings.HasPrefix(u, "data:image/") {
+		return true
+	}
+
+	uu, err := url.Parse(u)
+	if err != nil {
+		return false
+	}
+
+	switch uu.Scheme {
+	case "", "http", "https":
+		return true
+	case "mailto":
+		return tag == "a"
+	}
+
+	return false
+}
+
 func isBase64(s string) bool {
 	return strings.HasPrefix(strings.ToLower(s), "data:")
 }
@@ -273,7 +522,8 @@ func replaceCSS(base *url.URL, s string) string {
 
 		u, err := url.Parse(m)
 		if err != nil {
-			log.Info.Println(err)
+			log.Debug.Println(err)
+			return []byte(`url("")`)
 		}
 
 		u = base.ResolveReference(u)
@@ -286,22 +536,24 @@ func replaceCSS(base *url.URL, s string) string {
 	return string(ss)
 }
 
-func createProxyImage(base *url.URL, lnk string) string {
+// createProxyImage, createProxyCSSLink and createProxyLink return an error for a url
+// the page got wrong, e.g. "http://[::1", and the caller drops the attribute.
+func createProxyImage(base *url.URL, lnk string) (string, error) {
 	u, err := url.Parse(lnk)
 	if err != nil {
-		panic(err)
+		return "", err
 	}
 
 	u = base.ResolveReference(u)
 	key := hmacKey(u.String())
 	l := fmt.Sprintf("/image/,s%v/%v", key, u.String())
-	return l
+	return l, nil
 }
 
 func createProxyCSSLink(base *url.URL, lnk string) (*url.URL, error) {
 	u, err := url.Parse(lnk)
 	if err != nil {
-		panic(err)
+		return nil, err
 	}
 
 	u = base.ResolveReference(u)
@@ -313,7 +565,7 @@ func createProxyCSSLink(base *url.URL, lnk string) (*url.URL, error) {
 
 	q := uu.Query()
 	q.Add("key", hmacKey(u.String()))
-	q.Add("q", u.String())
+	q.Add("u", u.String())
 	q.Add("css", "true")
 	uu.RawQuery = q.Encode()
 	return uu, err
@@ -322,7 +574,7 @@ func createProxyCSSLink(base *url.URL, lnk string) (*url.URL, error) {
 func createProxyLink(base *url.URL, lnk string, iframe bool) (*url.URL, error) {
 	u, err := url.Parse(lnk)
 	if err != nil {
-		panic(err)
+		return nil, err
 	}
 
 	u = base.ResolveReference(u)
@@ -334,7 +586,7 @@ func createProxyLink(base *url.URL, lnk string, iframe bool) (*url.URL, error) {
 
 	q := uu.Query()
 	q.Add("key", hmacKey(u.String()))
-	q.Add("q", u.String())
+	q.Add("u", u.String())
 	if iframe {
 		q.Add("iframe", "true")
 	}
@@ -343,25 +595,8 @@ func createProxyLink(base *url.URL, lnk string, iframe bool) (*url.URL, error) {
 }
 
 // validSignature returns whether the request signature is valid.
-func validSignature(key []byte, u *url.URL, signature string) bool {
-	if m := len(signature) % 4; m != 0 { // add padding if missing
-		signature += strings.Repeat("=", 4-m)
-	}
-
-	got, err := base64.URLEncoding.DecodeString(signature)
-	if err != nil {
-		log.Debug.Printf("error base64 decoding signature %q\n", signature)
-		return false
-	}
-
-	mac := hmac.New(sha256.New, key)
-	if _, err := mac.Write([]byte(u.String())); err != nil {
-		log.Debug.Println(err)
-		return false
-	}
-	want := mac.Sum(nil)
-
-	return hmac.Equal(got, want)
+func (f *Frontend) validSignature(u *url.URL, signature string) bool {
+	return f.HMAC.keyring().valid(u.String(), signature, now())
 }
 
 func (f *Frontend) get(u *url.URL) (*http.Response, error) {
@@ -69,6 +69,7 @@ func TestProxyHandler(t *testing.T) {
 	defer httpmock.DeactivateAndReset()
 
 	type args struct {
+		param  string
 		css    string
 		q      string
 		key    string
@@ -84,6 +85,7 @@ func TestProxyHandler(t *testing.T) {
 		{
 			"basic",
 			args{
+				param:  "u",
 				css:    "",
 				q:      "https://example.com",
 				key:    "jfsdijf89sd",
@@ -110,7 +112,7 @@ func TestProxyHandler(t *testing.T) {
 			},
 			`<html>
 				<head>
-				<link rel=stylesheet href="/proxy?css=true&amp;key=3jUnkmdp2GQ0a9mmkFWYaTq6pg9rxGdVlic5t4fvfKc%3D&amp;q=https%3A%2F%2Fexample.com%2Fmystyle.css">
+				<link rel=stylesheet href="/proxy?css=true&amp;key=3jUnkmdp2GQ0a9mmkFWYaTq6pg9rxGdVlic5t4fvfKc%3D&amp;u=https%3A%2F%2Fexample.com%2Fmystyle.css">
 				<style>
 					.body {margin:0}
 					#mydiv {background: lightblue url("/image/,sUKGG_QlTynjPRkhces2ykv26GkZbya3NOhrjgMZCWXY=/https://example.com/img_tree.gif") no-repeat fixed center}
@@ -118,15 +120,72 @@ func TestProxyHandler(t *testing.T) {
 				</head>
 				<body>
 					<form id=form disabled action=javascript:void(0);></form>
-					<a href="/proxy?key=_Zbla8JTucVtfb7n-QIGsrKozkTGaGsuKlxppnXb6xM%3D&amp;q=https%3A%2F%2Fwww.example.com" target=_top>A link</a>
-					<a href="/proxy?key=j_gIsLDElFG1Qnp3TAYn1KD5dwvJ0gB_KqvUjXvM64g%3D&amp;q=https%3A%2F%2Fexample.com%2Frelative%2Flink" target=_top>A relative link</a>
-					<iframe src="/proxy?iframe=true&amp;key=QtzD41Rkf5VUsmVPv9kSn4VHfUqf2jMljGktkjYVOVc%3D&amp;q=https%3A%2F%2Fexample.com%2Fiframe%2Fstuff"></iframe>
+					<a href="/proxy?key=_Zbla8JTucVtfb7n-QIGsrKozkTGaGsuKlxppnXb6xM%3D&amp;u=https%3A%2F%2Fwww.example.com" target=_top>A link</a>
+					<a href="/proxy?key=j_gIsLDElFG1Qnp3TAYn1KD5dwvJ0gB_KqvUjXvM64g%3D&amp;u=https%3A%2F%2Fexample.com%2Frelative%2Flink" target=_top>A relative link</a>
+					<iframe src="/proxy?iframe=true&amp;key=QtzD41Rkf5VUsmVPv9kSn4VHfUqf2jMljGktkjYVOVc%3D&amp;u=https%3A%2F%2Fexample.com%2Fiframe%2Fstuff"></iframe>
 					<img src="/image/,sypKZuwtHssDFg_bLaExLhx4rYNnbr0KkzPeekQYRlGA=/https://example.com/nice.jpg" alt="nice image">
 					<img src=data:image/png;base64 alt="Red dot">
 					<div style='background-image:url("/image/,s1aMOcTAkBGs07NYeV9NjCCrDMIAQ7vtELioY-qfeDpo=/https://example.com/paper.gif")'>Cool div you got there. Would be a shame if we proxied the url.</div>
 				</body>
 			</html>`,
 		},
+		{
+			"sanitized",
+			args{
+				param:  "q", // links from before the "u" param
+				css:    "",
+				q:      "https://example.com/tracked",
+				key:    "jfsdijf89sd",
+				secret: "my_secret",
+				resp: `<html>
+								<head>
+									<meta http-equiv="refresh" content="0; url=https://evil.com">
+									<base href="https://evil.com/">
+									<link rel="preconnect" href="https://tracker.com">
+									<script src="https://tracker.com/t.js"></script>
+								</head>
+								<body onload="track()">
+									<noscript><img src="https://tracker.com/noscript.gif"></noscript>
+									<img src="https://www.facebook.com/tr?id=1&ev=PageView" alt="">
+									<img src="pixel.gif" width="1" height="1">
+									<object data="movie.swf"></object>
+									<a href="javascript:track()">Bad</a>
+									<p onclick="track()" ping="https://tracker.com">Hello</p>
+								</body>
+							</html>`,
+			},
+			`<html>
+				<head></head>
+				<body>
+					<a>Bad</a>
+					<p>Hello</p>
+				</body>
+			</html>`,
+		},
+		{
+			"unparseable urls",
+			args{
+				param:  "u",
+				css:    "",
+				q:      "https://example.com/broken",
+				key:    "jfsdijf89sd",
+				secret: "my_secret",
+				resp: `<html>
+								<head></head>
+								<body>
+									<img srcset="http://[::1 2x" alt="broken srcset">
+									<div style="background-image: url('http://[::1');">Broken background</div>
+								</body>
+							</html>`,
+			},
+			`<html>
+				<head></head>
+				<body>
+					<img alt="broken srcset">
+					<div style='background-image:url("")'>Broken background</div>
+				</body>
+			</html>`,
+		},
 		/*
 			{
 				"css",
@@ -150,10 +209,12 @@ func TestProxyHandler(t *testing.T) {
 
 			f := &Frontend{
 				Brand:       Brand{},
+				HMAC:        HMAC{Secret: c.args.secret},
 				ProxyClient: &http.Client{},
 			}
 
-			hmacSecret = func() string { return c.args.secret }
+			defer signing.Store(HMAC{}.keyring())
+			signing.Store(f.HMAC.keyring())
 			k := hmacKey(c.q)
 
 			responder := httpmock.NewStringResponder(200, c.resp)
@@ -174,7 +235,7 @@ func TestProxyHandler(t *testing.T) {
 
 			q := req.URL.Query()
 			q.Add("css", c.css)
-			q.Add("q", c.q)
+			q.Add(c.param, c.q)
 			q.Add("key", k)
 			req.URL.RawQuery = q.Encode()
 
@@ -237,6 +298,51 @@ func TestProxyHandler(t *testing.T) {
 	httpmock.Reset()
 }
 
+func TestSanitize(t *testing.T) {
+	for _, c := range []struct {
+		name string
+		html string
+		want string
+	}{
+		{"srcdoc", `<iframe srcdoc="<script>alert(1)</script>"></iframe>`, `<iframe></iframe>`},
+		{"formaction", `<form><button formaction="javascript:alert(1)">Go</button></form>`, `<form><button>Go</button></form>`},
+		{"xlink:href", `<svg><a xlink:href="javascript:alert(1)"><text>Go</text></a><use xlink:href="data:image/svg+xml;base64,PHN2Zz4="></use></svg>`, `<svg></svg>`},
+		{"xlink on a kept element", `<svg><path xlink:href="javascript:alert(1)" d="M0 0"></path></svg>`, `<svg><path d="M0 0"></path></svg>`},
+		{"data: iframe", `<iframe src="data:text/html;base64,PHNjcmlwdD5hbGVydCgxKTwvc2NyaXB0Pg=="></iframe>`, `<iframe></iframe>`},
+		{"data: link", `<a href="data:text/html,<script>alert(1)</script>">Go</a>`, `<a>Go</a>`},
+		{"vbscript: link", `<a href="vbscript:msgbox(1)">Go</a>`, `<a>Go</a>`},
+		{"vbscript: iframe", `<iframe src="VBScript:msgbox(1)"></iframe>`, `<iframe></iframe>`},
+		{"obfuscated scheme", "<a href=\"java\tscript:alert(1)\">Go</a><a href=\" &#14; javascript:alert(1)\">Go</a>", `<a>Go</a><a>Go</a>`},
+		{"object", `<object data="data:text/html,<script>alert(1)</script>"><p>Fallback</p></object>`, ``},
+		{"embed", `<embed src="data:image/svg+xml,<svg onload=alert(1)>">`, ``},
+		{"math", `<math><mtext><a href="javascript:alert(1)">Go</a></mtext></math>`, ``},
+		{"animate", `<svg><animate attributeName="href" to="javascript:alert(1)"></animate><circle r="1"></circle></svg>`, `<svg><circle r="1"></circle></svg>`},
+		{"unknown element", `<marquee onstart="alert(1)">Hello <b>world</b></marquee>`, `Hello <b>world</b>`},
+		{"comment", `<p>Hello<!--[if IE]><script>alert(1)</script><![endif]--></p>`, `<p>Hello</p>`},
+		{"kept", `<a href="https://example.com/" class="link" data-id="1">Go</a><img src="data:image/png;base64,iVBORw0KGgo=" alt="dot"><a href="mailto:jive@example.com">Mail</a>`, `<a href="https://example.com/" class="link" data-id="1">Go</a><img src="data:image/png;base64,iVBORw0KGgo=" alt="dot"/><a href="mailto:jive@example.com">Mail</a>`},
+		{"mailto: iframe", `<iframe src="mailto:jive@example.com"></iframe>`, `<iframe></iframe>`},
+		{"unparseable url", `<a href="http://[::1">Go</a><img src="http://[::1" alt="x">`, `<a>Go</a><img alt="x"/>`},
+	} {
+		t.Run(c.name, func(t *testing.T) {
+			doc, err := goquery.NewDocumentFromReader(strings.NewReader(c.html))
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			sanitize(doc)
+
+			got, err := doc.Find("body").Html()
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if got != c.want {
+				t.Fatalf("got %s; want %s", got, c.want)
+			}
+		})
+	}
+}
+
 func htmlMinify(s string) (string, error) {
 	m := minify.New()
 	m.AddFunc("text/html", html.Minify)
@@ -0,0 +1,124 @@
+package frontend
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/jivesearch/jivesearch/log"
+	"github.com/jivesearch/jivesearch/suggest"
+)
+
+// Question is a question related to the query ("People also ask").
+// Its instant answer is loaded from /answer when the user expands it.
+type Question struct {
+	Question string `json:"question"`
+	Source   string `json:"source"`
+}
+
+// sources of related questions
+const (
+	queryLogSource  = "queries"
+	wikipediaSource = "wikipedia"
+)
+
+// maxQuestions is the most related questions we'll show
+const maxQuestions = 5
+
+// questionPrefixes are how questions in our query log usually begin
+var questionPrefixes = []string{
+	"what is", "who is", "how to", "how does", "why is", "where is",
+}
+
+// headingQuestions turns common Wikipedia section headings into questions
+var headingQuestions = map[string]string{
+	"awards":         "What awards did %v win?",
+	"career":         "What is %v known for?",
+	"construction":   "How was %v built?",
+	"death":          "How did %v die?",
+	"demographics":   "What is the population of %v?",
+	"design":         "How was %v designed?",
+	"early life":     "Where did %v grow up?",
+	"education":      "Where did %v go to school?",
+	"etymology":      "Where does the name %v come from?",
+	"geography":      "Where is %v located?",
+	"history":        "What is the history of %v?",
+	"honours":        "What awards did %v win?",
+	"legacy":         "What is the legacy of %v?",
+	"symptoms":       "What are the symptoms of %v?",
+	"treatment":      "How is %v treated?",
+	"uses":           "What is %v used for?",
+	"climate":        "What is the climate like in %v?",
+	"economy":        "What is the economy of %v based on?",
+	"discography":    "What albums did %v release?",
+	"filmography":    "What movies was %v in?",
+	"causes":         "What causes %v?",
+	"classification": "How is %v classified?",
+}
+
+// relatedQuestions mines the query log and Wikipedia section headings for
+// questions related to the query.
+func (f *Frontend) relatedQuestions(r *http.Request, d data, qc chan []Question) {
+	lang, _, _ := f.Wikipedia.Matcher.Match(d.Context.Preferred...)
+	key := cacheKey("questions", lang, d.Context.Region, formURL(r))
+
+	v := f.cacheGet(r.Context(), "questions", key)
+
+	if v != nil {
+		var qs []Question
+		if err := json.Unmarshal(v.([]byte), &qs); err != nil {
+			log.Info.Println(err)
+		}
+
+		qc <- qs
+		return
+	}
+
+	q := strings.ToLower(d.Context.Q)
+	seen := map[string]bool{q: true}
+	var qs []Question
+
+	add := func(question, source string) {
+		k := strings.ToLower(strings.TrimRight(question, "?"))
+		if len(qs) >= maxQuestions || seen[k] || suggest.Naughty(question) {
+			return
+		}
+
+		seen[k] = true
+		qs = append(qs, Question{Question: question, Source: source})
+	}
+
+	for _, p := range questionPrefixes {
+		res, err := f.Suggest.Completion(p+" "+q, maxQuestions)
+		if err != nil {
+			log.Infow(r.Context(), "fetch failed", log.Fields{"fetcher": "suggest", "error": err})
+			break
+		}
+
+		for _, s := range res.Suggestions {
+			add(s, queryLogSource)
+		}
+	}
+
+	items, err := f.Instant.WikipediaFetcher.Fetch(d.Context.Q, lang)
+	if err != nil {
+		log.Infow(r.Context(), "fetch failed", log.Fields{"fetcher": "wikipedia", "error": err})
+	}
+
+	for _, item := range items {
+		if !strings.EqualFold(item.Wikipedia.Title, d.Context.Q) {
+			continue
+		}
+
+		for _, h := range item.Wikipedia.Headings {
+			if tmpl, ok := headingQuestions[strings.ToLower(strings.TrimSpace(h))]; ok {
+				add(fmt.Sprintf(tmpl, item.Wikipedia.Title), wikipediaSource)
+			}
+		}
+	}
+
+	f.cachePut(r.Context(), key, qs, f.Cache.Instant)
+
+	qc <- qs
+}
@@ -0,0 +1,99 @@
+package frontend
+
+import (
+	"net/http"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/jivesearch/jivesearch/instant"
+	"github.com/jivesearch/jivesearch/instant/wikipedia"
+	"github.com/jivesearch/jivesearch/suggest"
+	"golang.org/x/text/language"
+)
+
+func TestRelatedQuestions(t *testing.T) {
+	for _, c := range []struct {
+		q    string
+		want []Question
+	}{
+		{"marie curie", []Question{
+			{Question: "who is marie curie married to", Source: queryLogSource},
+			{Question: "why is marie curie important", Source: queryLogSource},
+			{Question: "Where did Marie Curie grow up?", Source: wikipediaSource},
+			{Question: "How did Marie Curie die?", Source: wikipediaSource},
+		}},
+		{"something else", nil},
+	} {
+		t.Run(c.q, func(t *testing.T) {
+			matcher := language.NewMatcher([]language.Tag{language.English})
+
+			f := &Frontend{
+				Instant: &instant.Instant{
+					WikipediaFetcher: &mockQuestionsFetcher{},
+				},
+				Suggest: &mockQuestionsSuggester{},
+				Wikipedia: Wikipedia{
+					Matcher: matcher,
+				},
+			}
+			f.Cache.Cacher = &mockCacher{}
+			f.Cache.Instant = 10 * time.Second
+
+			req, err := http.NewRequest("GET", "/", nil)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			d := data{
+				Context: &Context{
+					Q:         c.q,
+					Preferred: []language.Tag{language.English},
+					Region:    language.MustParseRegion("US"),
+				},
+			}
+
+			qc := make(chan []Question)
+			go f.relatedQuestions(req, d, qc)
+			got := <-qc
+
+			if !reflect.DeepEqual(got, c.want) {
+				t.Fatalf("got %+v; want %+v", got, c.want)
+			}
+		})
+	}
+}
+
+type mockQuestionsSuggester struct {
+	mockSuggester
+}
+
+func (ms *mockQuestionsSuggester) Completion(q string, size int) (suggest.Results, error) {
+	s := suggest.Results{}
+
+	switch q {
+	case "who is marie curie":
+		s.Suggestions = []string{"who is marie curie married to"}
+	case "why is marie curie":
+		s.Suggestions = []string{"why is marie curie important", "who is marie curie married to"} // dupe
+	}
+
+	return s, nil
+}
+
+type mockQuestionsFetcher struct{}
+
+func (mf *mockQuestionsFetcher) Fetch(query string, lang language.Tag) ([]*wikipedia.Item, error) {
+	return []*wikipedia.Item{
+		{
+			Wikipedia: wikipedia.Wikipedia{
+				Title:    "Marie Curie",
+				Headings: []string{"Early life", "Nobel Prizes", "Death"},
+			},
+		},
+	}, nil
+}
+
+func (mf *mockQuestionsFetcher) Setup() error {
+	return nil
+}
@@ -0,0 +1,174 @@
+package frontend
+
+import (
+	"fmt"
+	"math"
+	"net"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/jivesearch/jivesearch/frontend/apikey"
+	"github.com/jivesearch/jivesearch/frontend/cache"
+	"github.com/jivesearch/jivesearch/log"
+)
+
+// Limit is a token bucket: Burst requests at once, refilled at Rate requests per second
+type Limit struct {
+	Rate  float64
+	Burst int
+}
+
+// RateLimit holds our per-route limits.
+// Requests with a valid API key are limited by key rather than by IP.
+// In tor mode everyone without a key shares one limit per route.
+type RateLimit struct {
+	Limits         map[string]Limit // by route name. Routes without a limit aren't limited.
+	Multiplier     float64          // API keys get Multiplier times the per-IP limit unless the key has its own
+	TrustedProxies []*net.IPNet     // our reverse proxies. We only believe X-Forwarded-For from them.
+}
+
+// ParseNetworks parses IP addresses and CIDR blocks, e.g. 10.0.0.1 or 10.0.0.0/8
+func ParseNetworks(addrs []string) ([]*net.IPNet, error) {
+	nets := []*net.IPNet{}
+	for _, a := range addrs {
+		a = strings.TrimSpace(a)
+		if a == "" {
+			continue
+		}
+
+		if !strings.Contains(a, "/") {
+			ip := net.ParseIP(a)
+			if ip == nil {
+				return nil, fmt.Errorf("invalid ip address %q", a)
+			}
+			bits := 8 * net.IPv6len
+			if ip.To4() != nil {
+				ip, bits = ip.To4(), 8*net.IPv4len
+			}
+			nets = append(nets, &net.IPNet{IP: ip, Mask: net.CIDRMask(bits, bits)})
+			continue
+		}
+
+		_, n, err := net.ParseCIDR(a)
+		if err != nil {
+			return nil, err
+		}
+		nets = append(nets, n)
+	}
+
+	return nets, nil
+}
+
+// client is the IP address we limit a request by. Anyone can send an X-Forwarded-For header
+// so we go by the remote address unless it is one of our proxies. Then the client is the
+// right-most hop our proxies didn't add themselves.
+func (rl RateLimit) client(r *http.Request) net.IP {
+	host, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		host = r.RemoteAddr
+	}
+
+	ip := net.ParseIP(host)
+	if ip == nil || !rl.trusted(ip) {
+		return ip
+	}
+
+	hops := strings.Split(r.Header.Get("X-Forwarded-For"), ",")
+	if strings.TrimSpace(r.Header.Get("X-Forwarded-For")) == "" {
+		hops = []string{r.Header.Get("X-Real-IP")}
+	}
+
+	for i := len(hops) - 1; i >= 0; i-- {
+		hop := net.ParseIP(strings.TrimSpace(hops[i]))
+		if hop == nil { // we can't tell who sent anything left of this
+			return ip
+		}
+
+		ip = hop
+		if !rl.trusted(ip) {
+			return ip
+		}
+	}
+
+	return ip
+}
+
+func (rl RateLimit) trusted(ip net.IP) bool {
+	for _, n := range rl.TrustedProxies {
+		if n.Contains(ip) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// rateLimit returns 429 with a Retry-After header once a client exhausts
+// the route's limit. The buckets live in our cache so they are shared across
+// instances when using Redis. If the cache isn't available we let the request through.
+func (f *Frontend) rateLimit(route string, next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if retry, ok := f.take(route, r); !ok {
+			w.Header().Set("Retry-After", strconv.Itoa(int(math.Ceil(retry.Seconds()))))
+			http.Error(w, http.StatusText(http.StatusTooManyRequests), http.StatusTooManyRequests)
+			return
+		}
+
+		next.ServeHTTP(w, r)
+	})
+}
+
+// take takes a token from the client's bucket for the route.
+// Without one, retry is how long until the next token.
+func (f *Frontend) take(route string, r *http.Request) (retry time.Duration, ok bool) {
+	limit, ok := f.RateLimit.Limits[route]
+	limiter, isLimiter := f.Cache.Cacher.(cache.Limiter)
+	if !ok || !isLimiter || limit.Rate <= 0 || limit.Burst <= 0 {
+		return 0, true
+	}
+
+	var id string
+
+	switch k, ok := r.Context().Value(apiKeyContext).(*apikey.Key); {
+	case ok:
+		id = "key:" + k.ID
+		switch {
+		case k.Rate > 0:
+			limit = Limit{Rate: k.Rate, Burst: k.Burst}
+			if limit.Burst <= 0 {
+				limit.Burst = int(math.Ceil(k.Rate))
+			}
+		case f.RateLimit.Multiplier > 0:
+			limit.Rate *= f.RateLimit.Multiplier
+			limit.Burst = int(float64(limit.Burst) * f.RateLimit.Multiplier)
+		}
+	case f.Tor: // we don't know who anyone is so tor users share one bucket per route
+		id = "tor"
+	default:
+		// loopback isn't exempt: a reverse proxy on our host would otherwise lift the limit for everyone
+		ip := f.RateLimit.client(r)
+		if ip == nil {
+			return 0, true
+		}
+		id = "ip:" + ip.String()
+	}
+
+	allowed, retry, err := limiter.Take(fmt.Sprintf("ratelimit::%v::%v", route, id), limit.Rate, limit.Burst)
+	if err != nil {
+		log.Info.Println(err)
+		return 0, true
+	}
+
+	return retry, allowed
+}
+
+// apiKey is sent in the X-API-Key header or the api_key param
+func apiKey(r *http.Request) string {
+	if key := strings.TrimSpace(r.Header.Get("X-API-Key")); key != "" {
+		return key
+	}
+
+	return strings.TrimSpace(r.URL.Query().Get("api_key"))
+}
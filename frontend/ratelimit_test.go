@@ -0,0 +1,168 @@
+package frontend
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/jivesearch/jivesearch/frontend/apikey"
+	"github.com/jivesearch/jivesearch/frontend/cache"
+)
+
+func TestRateLimit(t *testing.T) {
+	type request struct {
+		remoteAddr string
+		forwarded  string // X-Forwarded-For
+		apiKey     string
+		status     int
+		retryAfter string
+	}
+
+	for _, c := range []struct {
+		name     string
+		proxies  []string
+		cacher   cache.Cacher
+		requests []request
+	}{
+		{
+			"per ip", nil, &cache.Simple{M: make(map[string]cache.Value)},
+			[]request{
+				{"1.2.3.4:1234", "", "", http.StatusOK, ""},
+				{"1.2.3.4:1234", "", "", http.StatusOK, ""},
+				{"1.2.3.4:1234", "", "", http.StatusTooManyRequests, "100"},
+				{"5.6.7.8:1234", "", "", http.StatusOK, ""},
+			},
+		},
+		{
+			"api key", nil, &cache.Simple{M: make(map[string]cache.Value)},
+			[]request{
+				{"1.2.3.4:1234", "", "", http.StatusOK, ""},
+				{"1.2.3.4:1234", "", "", http.StatusOK, ""},
+				{"1.2.3.4:1234", "", "", http.StatusTooManyRequests, "100"},
+				{"1.2.3.4:1234", "", "secret", http.StatusOK, ""},
+				{"1.2.3.4:1234", "", "secret", http.StatusOK, ""},
+				{"1.2.3.4:1234", "", "secret", http.StatusOK, ""},
+				{"1.2.3.4:1234", "", "secret", http.StatusOK, ""},
+				{"1.2.3.4:1234", "", "secret", http.StatusTooManyRequests, "50"},
+				{"1.2.3.4:1234", "", "unknown", http.StatusTooManyRequests, "100"},
+				{"1.2.3.4:1234", "", "own limit", http.StatusOK, ""},
+				{"1.2.3.4:1234", "", "own limit", http.StatusTooManyRequests, "10"},
+			},
+		},
+		{
+			"loopback", nil, &cache.Simple{M: make(map[string]cache.Value)},
+			[]request{
+				{"127.0.0.1:1234", "", "", http.StatusOK, ""},
+				{"127.0.0.1:1234", "", "", http.StatusOK, ""},
+				{"127.0.0.1:1234", "", "", http.StatusTooManyRequests, "100"},
+			},
+		},
+		{
+			"spoofed X-Forwarded-For", nil, &cache.Simple{M: make(map[string]cache.Value)},
+			[]request{
+				{"1.2.3.4:1234", "", "", http.StatusOK, ""},
+				{"1.2.3.4:1234", "5.6.7.8", "", http.StatusOK, ""},
+				{"1.2.3.4:1234", "9.10.11.12", "", http.StatusTooManyRequests, "100"},
+			},
+		},
+		{
+			"trusted proxy", []string{"10.0.0.0/8", "127.0.0.1"}, &cache.Simple{M: make(map[string]cache.Value)},
+			[]request{
+				{"127.0.0.1:1234", "1.2.3.4, 10.0.0.2", "", http.StatusOK, ""},
+				{"127.0.0.1:1234", "5.6.7.8, 1.2.3.4", "", http.StatusOK, ""}, // the client spoofed 5.6.7.8
+				{"127.0.0.1:1234", "1.2.3.4", "", http.StatusTooManyRequests, "100"},
+				{"127.0.0.1:1234", "9.10.11.12", "", http.StatusOK, ""},
+				{"127.0.0.1:1234", "", "", http.StatusOK, ""}, // the proxy didn't say who it is for
+				{"127.0.0.1:1234", "", "", http.StatusOK, ""},
+				{"127.0.0.1:1234", "", "", http.StatusTooManyRequests, "100"},
+				{"10.0.0.2:1234", "9.10.11.12", "", http.StatusOK, ""},
+				{"10.0.0.2:1234", "9.10.11.12", "", http.StatusTooManyRequests, "100"},
+			},
+		},
+		{
+			"cache can't limit", nil, &mockCacher{},
+			[]request{
+				{"1.2.3.4:1234", "", "", http.StatusOK, ""},
+				{"1.2.3.4:1234", "", "", http.StatusOK, ""},
+				{"1.2.3.4:1234", "", "", http.StatusOK, ""},
+			},
+		},
+	} {
+		t.Run(c.name, func(t *testing.T) {
+			proxies, err := ParseNetworks(c.proxies)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			f := &Frontend{
+				RateLimit: RateLimit{
+					Limits: map[string]Limit{
+						"search": {Rate: .01, Burst: 2},
+					},
+					Multiplier:     2,
+					TrustedProxies: proxies,
+				},
+			}
+			f.Cache.Cacher = c.cacher
+
+			h := f.rateLimit("search", http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+
+			for i, req := range c.requests {
+				r := httptest.NewRequest("GET", "/?q=test", nil)
+				r.RemoteAddr = req.remoteAddr
+				if req.forwarded != "" {
+					r.Header.Set("X-Forwarded-For", req.forwarded)
+				}
+				switch req.apiKey { // set by apiAccess for valid keys
+				case "secret":
+					r = r.WithContext(context.WithValue(r.Context(), apiKeyContext, &apikey.Key{ID: "1a2b"}))
+				case "own limit":
+					r = r.WithContext(context.WithValue(r.Context(), apiKeyContext, &apikey.Key{ID: "3c4d", Rate: .1}))
+				}
+
+				w := httptest.NewRecorder()
+				h.ServeHTTP(w, r)
+
+				if w.Code != req.status {
+					t.Fatalf("request %d: got %d; want %d", i, w.Code, req.status)
+				}
+
+				if got := w.Header().Get("Retry-After"); got != req.retryAfter {
+					t.Fatalf("request %d: got Retry-After %q; want %q", i, got, req.retryAfter)
+				}
+			}
+		})
+	}
+}
+
+func TestParseNetworks(t *testing.T) {
+	for _, c := range []struct {
+		addrs []string
+		want  []string
+		err   bool
+	}{
+		{[]string{"127.0.0.1", " 10.0.0.0/8", ""}, []string{"127.0.0.1/32", "10.0.0.0/8"}, false},
+		{[]string{"::1"}, []string{"::1/128"}, false},
+		{[]string{"localhost"}, nil, true},
+		{[]string{"10.0.0.0/33"}, nil, true},
+	} {
+		t.Run(strings.Join(c.addrs, ","), func(t *testing.T) {
+			nets, err := ParseNetworks(c.addrs)
+			if (err != nil) != c.err {
+				t.Fatalf("got err %v; want err %v", err, c.err)
+			}
+
+			got := []string{}
+			for _, n := range nets {
+				got = append(got, n.String())
+			}
+
+			if !c.err && !reflect.DeepEqual(got, c.want) {
+				t.Fatalf("got %v; want %v", got, c.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,45 @@
+package frontend
+
+import (
+	"context"
+	"encoding/json"
+	"net/url"
+
+	"github.com/jivesearch/jivesearch/log"
+	"github.com/jivesearch/jivesearch/suggest"
+	"golang.org/x/text/language"
+)
+
+// maxRelated is the most related searches we'll show
+const maxRelated = 8
+
+// relatedSearches finds queries from our query log that are related to the query.
+// They don't change from page to page so are cached by query, language and region only.
+func (f *Frontend) relatedSearches(ctx context.Context, d data, lang language.Tag, region language.Region) []string {
+	u := &url.URL{Path: "/", RawQuery: url.Values{"q": {d.Context.Q}}.Encode()}
+	key := cacheKey("related", lang, region, u)
+
+	v := f.cacheGet(ctx, "related", key)
+
+	if v != nil {
+		var related []string
+		if err := json.Unmarshal(v.([]byte), &related); err != nil {
+			log.Info.Println(err)
+		}
+		return related
+	}
+
+	related, err := suggest.Related(f.Suggest, d.Context.Q, maxRelated)
+	if err != nil {
+		log.Infow(ctx, "fetch failed", log.Fields{"fetcher": "related", "error": err})
+		return nil
+	}
+
+	if len(related) == 0 {
+		related = nil
+	}
+
+	f.cachePut(ctx, key, related, f.Cache.Search)
+
+	return related
+}
@@ -0,0 +1,58 @@
+package frontend
+
+import (
+	"context"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/jivesearch/jivesearch/suggest"
+	"golang.org/x/text/language"
+)
+
+func TestRelatedSearches(t *testing.T) {
+	for _, c := range []struct {
+		q    string
+		want []string
+	}{
+		{"marie curie", []string{"marie curie death", "marie curie quotes", "pierre and marie curie"}},
+		{"something else", nil},
+	} {
+		t.Run(c.q, func(t *testing.T) {
+			f := &Frontend{
+				Suggest: &mockRelatedSuggester{},
+			}
+			f.Cache.Cacher = &mockCacher{}
+			f.Cache.Search = 10 * time.Second
+
+			d := data{
+				Context: &Context{
+					Q: c.q,
+				},
+			}
+
+			got := f.relatedSearches(context.Background(), d, language.English, language.MustParseRegion("US"))
+
+			if !reflect.DeepEqual(got, c.want) {
+				t.Fatalf("got %q; want %q", got, c.want)
+			}
+		})
+	}
+}
+
+type mockRelatedSuggester struct {
+	mockSuggester
+}
+
+func (ms *mockRelatedSuggester) Completion(q string, size int) (suggest.Results, error) {
+	s := suggest.Results{}
+
+	switch q {
+	case "marie curie ":
+		s.Suggestions = []string{"marie curie death", "marie curie quotes"}
+	case "curie":
+		s.Suggestions = []string{"pierre and marie curie", "marie curie death"}
+	}
+
+	return s, nil
+}
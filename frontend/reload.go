@@ -0,0 +1,129 @@
+package frontend
+
+import (
+	"fmt"
+	"net/http"
+	"os"
+	"os/signal"
+	"path/filepath"
+	"sync"
+	"sync/atomic"
+
+	"github.com/fsnotify/fsnotify"
+	"github.com/jivesearch/jivesearch/log"
+)
+
+// Reloader serves requests with the most recently loaded handler. A reload builds
+// a new handler and swaps it in atomically so requests already being served
+// finish with the configuration they started with.
+type Reloader struct {
+	Load    func() (http.Handler, error)
+	mu      sync.Mutex
+	handler atomic.Value
+}
+
+// ServeHTTP passes the request to the current handler
+func (rl *Reloader) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	rl.handler.Load().(http.Handler).ServeHTTP(w, r)
+}
+
+// Reload loads a new handler and swaps it in. The current handler
+// keeps serving if the new one can't be loaded.
+func (rl *Reloader) Reload() error {
+	rl.mu.Lock()
+	defer rl.mu.Unlock()
+
+	h, err := rl.Load()
+	if err != nil {
+		return err
+	}
+
+	rl.handler.Store(h)
+	return nil
+}
+
+// Notify reloads every time one of the signals is received, e.g. syscall.SIGHUP
+func (rl *Reloader) Notify(sig ...os.Signal) {
+	ch := make(chan os.Signal, 1)
+	signal.Notify(ch, sig...)
+
+	go func() {
+		for s := range ch {
+			if err := rl.Reload(); err != nil {
+				log.Info.Printf("unable to reload on %v: %v\n", s, err)
+				continue
+			}
+			log.Info.Printf("reloaded on %v\n", s)
+		}
+	}()
+}
+
+// adminReloadHandler rereads our configuration without a restart.
+// e.g. curl -X POST -H "Authorization: Bearer $TOKEN" /admin/reload
+func (f *Frontend) adminReloadHandler(w http.ResponseWriter, r *http.Request) *response {
+	resp := &response{
+		status:   http.StatusOK,
+		template: "json",
+	}
+
+	if !f.isAdmin(r) {
+		resp.status = http.StatusForbidden
+		resp.err = fmt.Errorf("invalid admin token from %v", r.RemoteAddr)
+		return resp
+	}
+
+	if f.Reload == nil {
+		resp.status, resp.err = http.StatusNotImplemented, fmt.Errorf("reloading isn't setup")
+		return resp
+	}
+
+	if err := f.Reload(); err != nil {
+		resp.status, resp.err = http.StatusInternalServerError, err
+		return resp
+	}
+
+	resp.data = map[string]bool{"reloaded": true}
+	return resp
+}
+
+// Watch reloads whenever the file is written. We watch its directory
+// as many editors replace a file rather than write to it.
+func (rl *Reloader) Watch(file string) error {
+	w, err := fsnotify.NewWatcher()
+	if err != nil {
+		return err
+	}
+
+	if err := w.Add(filepath.Dir(file)); err != nil {
+		w.Close()
+		return err
+	}
+
+	go func() {
+		for {
+			select {
+			case e, ok := <-w.Events:
+				if !ok {
+					return
+				}
+
+				if filepath.Clean(e.Name) != filepath.Clean(file) || e.Op&(fsnotify.Write|fsnotify.Create) == 0 {
+					continue
+				}
+
+				if err := rl.Reload(); err != nil {
+					log.Info.Printf("unable to reload %v: %v\n", file, err)
+					continue
+				}
+				log.Info.Printf("reloaded %v\n", file)
+			case err, ok := <-w.Errors:
+				if !ok {
+					return
+				}
+				log.Info.Println(err)
+			}
+		}
+	}()
+
+	return nil
+}
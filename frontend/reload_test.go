@@ -0,0 +1,126 @@
+package frontend
+
+import (
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestReloader(t *testing.T) {
+	version := 0
+	var fail bool
+
+	rl := &Reloader{
+		Load: func() (http.Handler, error) {
+			if fail {
+				return nil, fmt.Errorf("bad config")
+			}
+
+			version++
+			v := version
+			return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				fmt.Fprint(w, v)
+			}), nil
+		},
+	}
+
+	served := func() string {
+		w := httptest.NewRecorder()
+		rl.ServeHTTP(w, httptest.NewRequest("GET", "/", nil))
+		return w.Body.String()
+	}
+
+	for _, c := range []struct {
+		name string
+		fail bool
+		want string
+	}{
+		{"first", false, "1"},
+		{"reloaded", false, "2"},
+		{"bad config keeps serving", true, "2"},
+	} {
+		t.Run(c.name, func(t *testing.T) {
+			fail = c.fail
+			if err := rl.Reload(); (err != nil) != c.fail {
+				t.Fatalf("got err %v; want an error %v", err, c.fail)
+			}
+
+			if got := served(); got != c.want {
+				t.Fatalf("got %q; want %q", got, c.want)
+			}
+		})
+	}
+}
+
+func TestReloaderWatch(t *testing.T) {
+	dir, err := ioutil.TempDir("", "reload")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	file := filepath.Join(dir, "jivesearch.toml")
+	if err := ioutil.WriteFile(file, []byte(`[brand]\nname = "Jive Search"`), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	reloaded := make(chan bool, 10)
+	rl := &Reloader{
+		Load: func() (http.Handler, error) {
+			reloaded <- true
+			return http.NotFoundHandler(), nil
+		},
+	}
+
+	if err := rl.Watch(file); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := ioutil.WriteFile(filepath.Join(dir, "other.toml"), []byte("ignored"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := ioutil.WriteFile(file, []byte(`[brand]\nname = "My Search"`), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	select {
+	case <-reloaded:
+	case <-time.After(5 * time.Second):
+		t.Fatal("expected a reload after the file changed")
+	}
+}
+
+func TestAdminReloadHandler(t *testing.T) {
+	for _, c := range []struct {
+		name   string
+		token  string
+		reload func() error
+		status int
+	}{
+		{"wrong token", "wrong", func() error { return nil }, http.StatusForbidden},
+		{"not setup", "secret", nil, http.StatusNotImplemented},
+		{"bad config", "secret", func() error { return fmt.Errorf("bad config") }, http.StatusInternalServerError},
+		{"reloaded", "secret", func() error { return nil }, http.StatusOK},
+	} {
+		t.Run(c.name, func(t *testing.T) {
+			f := &Frontend{
+				AdminToken: "secret",
+				Reload:     c.reload,
+			}
+
+			req := httptest.NewRequest("POST", "/admin/reload", nil)
+			req.Header.Set("Authorization", "Bearer "+c.token)
+
+			rsp := f.adminReloadHandler(httptest.NewRecorder(), req)
+			if rsp.status != c.status {
+				t.Fatalf("got status %d; want %d", rsp.status, c.status)
+			}
+		})
+	}
+}
@@ -0,0 +1,44 @@
+package frontend
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/jivesearch/jivesearch/log"
+)
+
+func TestRequestID(t *testing.T) {
+	for _, c := range []struct {
+		name   string
+		header string
+		keep   bool
+	}{
+		{"new", "", false},
+		{"from load balancer", "f3a9-22c1", true},
+		{"invalid", "<script>", false},
+	} {
+		t.Run(c.name, func(t *testing.T) {
+			var got string
+			h := requestID(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				got = log.RequestID(r.Context())
+			}))
+
+			r := httptest.NewRequest("GET", "/", nil)
+			if c.header != "" {
+				r.Header.Set(requestIDHeader, c.header)
+			}
+
+			w := httptest.NewRecorder()
+			h.ServeHTTP(w, r)
+
+			if got == "" || got != w.Header().Get(requestIDHeader) {
+				t.Fatalf("got request id %q with header %q", got, w.Header().Get(requestIDHeader))
+			}
+
+			if (got == c.header) != c.keep {
+				t.Fatalf("got %q; want the header kept %v", got, c.keep)
+			}
+		})
+	}
+}
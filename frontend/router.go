@@ -2,30 +2,112 @@ package frontend
 
 import (
 	"net/http"
-	"os"
 	"time"
 
 	"github.com/gorilla/mux"
-	"github.com/jivesearch/jivesearch/config"
+	"github.com/jivesearch/jivesearch/log"
 	"willnorris.com/go/imageproxy"
 )
 
 // Router sets up the routes & handlers
-func (f *Frontend) Router(cfg config.Provider) *mux.Router {
+func (f *Frontend) Router() *mux.Router {
 	router := mux.NewRouter().StrictSlash(true)
+	router.Use(requestID, f.secure, f.cors, f.private, rememberTheme)
+
+	// browsers check with us before calling our API from other sites
+	router.NewRoute().Name("preflight").Methods("OPTIONS").MatcherFunc(func(r *http.Request, rm *mux.RouteMatch) bool {
+		return isAPIRequest(r)
+	}).HandlerFunc(preflightHandler)
 
 	router.NewRoute().Name("search").Methods("GET").Path("/").Handler(
-		f.middleware(appHandler(f.searchHandler)),
+		f.apiAccess(f.rateLimit("search", f.experimentID(f.middleware(appHandler(f.searchHandler))))),
+	)
+	router.NewRoute().Name("search_post").Methods("POST").Path("/search").Handler(
+		f.apiAccess(f.rateLimit("search", f.experimentID(f.middleware(appHandler(f.searchHandler))))),
+	)
+	router.NewRoute().Name("lite").Methods("GET", "POST").Path("/lite").Handler(
+		f.rateLimit("search", f.experimentID(f.middleware(appHandler(f.liteHandler)))),
+	)
+	router.NewRoute().Name("click").Methods("POST").Path("/click").Handler(
+		f.rateLimit("click", f.middleware(appHandler(f.clickHandler))),
 	)
 	router.NewRoute().Name("answer").Methods("GET").Path("/answer").Handler(
 		f.middleware(appHandler(f.answerHandler)),
 	)
+	router.NewRoute().Name("permalink").Methods("GET").Path("/answer/{type}/{slug:.+}").Handler(
+		f.middleware(appHandler(f.permalinkHandler)),
+	)
 	router.NewRoute().Name("about").Methods("GET").Path("/about").Handler(
 		f.middleware(appHandler(f.aboutHandler)),
 	)
+	router.NewRoute().Name("images_api").Methods("GET", "POST").Path("/api/v1/images").Handler(
+		f.apiAccess(f.rateLimit("api", f.middleware(appHandler(f.imagesHandler)))),
+	)
+	router.NewRoute().Name("images_batch").Methods("GET", "POST").Path("/api/v1/images/batch").Handler(
+		f.offline(f.rateLimit("image", f.middleware(appHandler(f.thumbnailsHandler)))),
+	)
+	router.NewRoute().Name("saved_api").Methods("GET", "POST", "DELETE").Path("/api/v1/saved").Handler(
+		f.apiAccess(f.rateLimit("api", f.middleware(appHandler(f.savedHandler)))),
+	)
+	router.NewRoute().Name("sitesearch_api").Methods("GET").Path("/api/v1/sitesearch").Handler(
+		f.apiAccess(f.rateLimit("api", f.middleware(appHandler(f.siteSearchHandler)))),
+	)
+	router.NewRoute().Name("instant_api").Methods("GET").Path("/api/v1/instant").Handler(
+		f.apiAccess(f.rateLimit("api", f.middleware(appHandler(f.instantHandler)))),
+	)
+	router.NewRoute().Name("graphql").Methods("GET", "POST").Path("/api/v1/graphql").Handler(
+		f.apiAccess(f.rateLimit("api", f.middleware(appHandler(f.graphqlHandler)))),
+	)
+	router.NewRoute().Name("documents_api").Methods("POST").Path("/api/v1/documents").Handler(
+		f.middleware(appHandler(f.documentsHandler)),
+	)
 	router.NewRoute().Name("autocomplete").Methods("GET").Path("/autocomplete").Handler(
-		f.middleware(appHandler(f.autocompleteHandler)),
+		f.rateLimit("autocomplete", f.middleware(appHandler(f.autocompleteHandler))),
+	)
+	router.NewRoute().Name("autocomplete_socket").Methods("GET").Path("/autocomplete/socket").Handler(
+		f.rateLimit("autocomplete", http.HandlerFunc(f.autocompleteSocketHandler)),
+	)
+	router.NewRoute().Name("history").Methods("GET", "POST", "DELETE").Path("/history").Handler(
+		f.rateLimit("search", f.middleware(appHandler(f.historyHandler))),
+	)
+	router.NewRoute().Name("maps_geocode").Methods("GET").Path("/maps/geocode").Handler(
+		f.offline(f.middleware(appHandler(f.geocodeHandler))),
 	)
+	router.NewRoute().Name("maps_directions").Methods("GET").Path("/maps/directions").Handler(
+		f.offline(f.middleware(appHandler(f.directionsHandler))),
+	)
+	router.NewRoute().Name("admin_analytics").Methods("GET").Path("/admin/analytics").Handler(
+		f.middleware(appHandler(f.adminAnalyticsHandler)),
+	)
+	router.NewRoute().Name("admin_apikeys").Methods("GET", "POST", "DELETE").Path("/admin/apikeys").Handler(
+		f.middleware(appHandler(f.adminAPIKeysHandler)),
+	)
+	router.NewRoute().Name("admin_bangs").Methods("GET", "POST", "DELETE").Path("/admin/bangs").Handler(
+		f.middleware(appHandler(f.adminBangsHandler)),
+	)
+	router.NewRoute().Name("admin_bangs_ui").Methods("GET").Path("/admin/bangs/ui").Handler(
+		f.middleware(appHandler(f.adminBangsUIHandler)),
+	)
+	router.NewRoute().Name("admin_bangs_stats").Methods("GET").Path("/admin/bangs/stats").Handler(
+		f.middleware(appHandler(f.adminBangsStatsHandler)),
+	)
+	router.NewRoute().Name("admin_bangs_test").Methods("GET").Path("/admin/bangs/test").Handler(
+		f.middleware(appHandler(f.adminBangsTestHandler)),
+	)
+	router.NewRoute().Name("admin_cache").Methods("GET").Path("/admin/cache").Handler(
+		f.middleware(appHandler(f.adminCacheHandler)),
+	)
+	router.NewRoute().Name("admin_domains").Methods("GET", "POST", "DELETE").Path("/admin/domains").Handler(
+		f.middleware(appHandler(f.adminDomainsHandler)),
+	)
+	router.NewRoute().Name("admin_instant").Methods("GET", "POST").Path("/admin/instant").Handler(
+		f.middleware(appHandler(f.adminInstantHandler)),
+	)
+	router.NewRoute().Name("admin_reload").Methods("POST").Path("/admin/reload").Handler(
+		f.middleware(appHandler(f.adminReloadHandler)),
+	)
+	router.NewRoute().Name("healthz").Methods("GET").Path("/healthz").HandlerFunc(f.healthzHandler)
+	router.NewRoute().Name("readyz").Methods("GET").Path("/readyz").HandlerFunc(f.readyzHandler)
 	router.NewRoute().Name("favicon").Methods("GET").Path("/favicon.ico").Handler(
 		http.FileServer(http.Dir("static")),
 	)
@@ -33,10 +115,17 @@ func (f *Frontend) Router(cfg config.Provider) *mux.Router {
 		f.middleware(appHandler(f.openSearchHandler)),
 	)
 	router.NewRoute().Name("proxy").Methods("GET").Path("/proxy").Handler(
-		f.middleware(appHandler(f.proxyHandler)),
+		f.offline(f.middleware(appHandler(f.proxyHandler))),
 	)
 	router.NewRoute().Name("proxy_header").Methods("GET").Path("/proxy_header").Handler(
-		f.middleware(appHandler(f.proxyHeaderHandler)),
+		f.offline(f.middleware(appHandler(f.proxyHeaderHandler))),
+	)
+
+	// the site search widget that site owners embed
+	router.NewRoute().Name("widget").Methods("GET").Path("/widget.js").Handler(
+		corsHeaders(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			http.ServeFile(w, r, "static/sitesearch.js")
+		})),
 	)
 
 	// How do we exclude viewing the entire static directory of /static path?
@@ -44,16 +133,19 @@ func (f *Frontend) Router(cfg config.Provider) *mux.Router {
 		corsHeaders(http.StripPrefix("/static/", http.FileServer(http.Dir("static")))),
 	)
 
-	// make hmac key available to our templates
-	key := cfg.GetString("hmac.secret")
-	os.Setenv("hmac_secret", key)
+	// make our hmac keys available to our templates
+	if f.HMAC.Secret == "" && len(f.HMAC.Keys) == 0 {
+		log.Info.Println(`hmac secret for image proxy is blank. Please set the "hmac.secret" config`)
+	}
+	signing.Store(f.HMAC.keyring())
 
 	p := imageproxy.NewProxy(nil, nil)
 	p.Verbose = false // otherwise logs the image fetched
-	//p.UserAgent = cfg.GetString("useragent") // not implemented yet: https://github.com/willnorris/imageproxy/pull/83
-	p.SignatureKey = []byte(key)
 	p.Timeout = 2 * time.Second
-	router.NewRoute().Name("image").Methods("GET").PathPrefix("/image/").Handler(http.StripPrefix("/image", p))
+	f.Thumbnails.Proxy = http.StripPrefix("/image", f.signed(p))
+	router.NewRoute().Name("image").Methods("GET").PathPrefix("/image/").Handler(
+		f.offline(f.rateLimit("image", f.Thumbnails.Proxy)),
+	)
 
 	/* To generate new HMAC secret...
 	// DON'T RUN IN PLAYGROUND! Will get same secret each time ;)
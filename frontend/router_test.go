@@ -5,7 +5,6 @@ import (
 	"testing"
 
 	"github.com/gorilla/mux"
-	"github.com/spf13/pflag"
 )
 
 func TestRouter(t *testing.T) {
@@ -21,11 +20,21 @@ func TestRouter(t *testing.T) {
 			method: "GET",
 			url:    "https://www.example.com/?q=search+term",
 		},
+		{
+			name:   "search_post",
+			method: "POST",
+			url:    "https://www.example.com/search",
+		},
 		{
 			name:   "answer",
 			method: "GET",
 			url:    "https://www.example.com/answer/?q=search+term",
 		},
+		{
+			name:   "permalink",
+			method: "GET",
+			url:    "https://www.example.com/answer/stock-quote/aapl-quote",
+		},
 		{
 			name:   "about",
 			method: "GET",
@@ -36,6 +45,131 @@ func TestRouter(t *testing.T) {
 			method: "GET",
 			url:    "http://127.0.0.1/autocomplete",
 		},
+		{
+			name:   "autocomplete_socket",
+			method: "GET",
+			url:    "http://127.0.0.1/autocomplete/socket",
+		},
+		{
+			name:   "history",
+			method: "POST",
+			url:    "http://127.0.0.1/history",
+		},
+		{
+			name:   "images_api",
+			method: "GET",
+			url:    "http://localhost/api/v1/images?q=cats&cursor=abc",
+		},
+		{
+			name:   "saved_api",
+			method: "DELETE",
+			url:    "http://localhost/api/v1/saved?id=abc",
+		},
+		{
+			name:   "preflight",
+			method: "OPTIONS",
+			url:    "http://localhost/api/v1/images?q=cats",
+		},
+		{
+			name:   "sitesearch_api",
+			method: "GET",
+			url:    "http://localhost/api/v1/sitesearch?q=jive&site=example.com",
+		},
+		{
+			name:   "documents_api",
+			method: "POST",
+			url:    "http://localhost/api/v1/documents",
+		},
+		{
+			name:   "widget",
+			method: "GET",
+			url:    "http://localhost/widget.js",
+		},
+		{
+			name:   "images_batch",
+			method: "POST",
+			url:    "http://localhost/api/v1/images/batch",
+		},
+		{
+			name:   "admin_analytics",
+			method: "GET",
+			url:    "http://localhost/admin/analytics",
+		},
+		{
+			name:   "admin_apikeys",
+			method: "POST",
+			url:    "http://localhost/admin/apikeys",
+		},
+		{
+			name:   "admin_instant",
+			method: "POST",
+			url:    "http://localhost/admin/instant",
+		},
+		{
+			name:   "admin_bangs",
+			method: "POST",
+			url:    "http://localhost/admin/bangs",
+		},
+		{
+			name:   "admin_bangs_ui",
+			method: "GET",
+			url:    "http://localhost/admin/bangs/ui",
+		},
+		{
+			name:   "admin_bangs_stats",
+			method: "GET",
+			url:    "http://localhost/admin/bangs/stats",
+		},
+		{
+			name:   "admin_bangs_test",
+			method: "GET",
+			url:    "http://localhost/admin/bangs/test?q=!g+jive",
+		},
+		{
+			name:   "admin_cache",
+			method: "GET",
+			url:    "http://localhost/admin/cache",
+		},
+		{
+			name:   "admin_reload",
+			method: "POST",
+			url:    "http://localhost/admin/reload",
+		},
+		{
+			name:   "maps_geocode",
+			method: "GET",
+			url:    "http://localhost/maps/geocode?q=paris",
+		},
+		{
+			name:   "maps_directions",
+			method: "GET",
+			url:    "http://localhost/maps/directions?from=paris&to=lyon",
+		},
+		{
+			name:   "instant_api",
+			method: "GET",
+			url:    "http://localhost/api/v1/instant?q=reverse+this",
+		},
+		{
+			name:   "graphql",
+			method: "POST",
+			url:    "http://localhost/api/v1/graphql",
+		},
+		{
+			name:   "lite",
+			method: "POST",
+			url:    "http://localhost/lite",
+		},
+		{
+			name:   "healthz",
+			method: "GET",
+			url:    "http://localhost/healthz",
+		},
+		{
+			name:   "readyz",
+			method: "GET",
+			url:    "http://localhost/readyz",
+		},
 		{
 			name:   "favicon",
 			method: "GET",
@@ -63,13 +197,8 @@ func TestRouter(t *testing.T) {
 		},
 	} {
 		t.Run(c.name, func(t *testing.T) {
-			cfg := &mockProvider{
-				m: make(map[string]interface{}),
-			}
-			cfg.SetDefault("hmac.secret", "very secret")
-
 			f := &Frontend{}
-			router := f.Router(cfg)
+			router := f.Router()
 
 			expected, err := http.NewRequest(
 				c.method,
@@ -88,27 +217,3 @@ func TestRouter(t *testing.T) {
 		})
 	}
 }
-
-type mockProvider struct {
-	m map[string]interface{}
-}
-
-func (p *mockProvider) SetDefault(key string, value interface{}) {
-	p.m[key] = value
-}
-func (p *mockProvider) SetTypeByDefaultValue(bool) {}
-func (p *mockProvider) BindPFlag(key string, flg *pflag.Flag) error {
-	return nil
-}
-func (p *mockProvider) Get(key string) interface{} {
-	return p.m[key]
-}
-func (p *mockProvider) GetString(key string) string {
-	return p.m[key].(string)
-}
-func (p *mockProvider) GetInt(key string) int {
-	return p.m[key].(int)
-}
-func (p *mockProvider) GetStringSlice(key string) []string {
-	return p.m[key].([]string)
-}
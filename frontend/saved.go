@@ -0,0 +1,171 @@
+package frontend
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/jivesearch/jivesearch/frontend/apikey"
+	"github.com/jivesearch/jivesearch/frontend/saved"
+)
+
+// Saved lets api key holders bookmark results
+type Saved struct {
+	saved.Store
+}
+
+// SavedItems are the user's collections and the items that match their search
+type SavedItems struct {
+	Collections []saved.Collection `json:"collections"`
+	Items       []*saved.Item      `json:"items"`
+}
+
+type savedRow struct {
+	Collection  string `json:"collection"`
+	URL         string `json:"url"`
+	Title       string `json:"title"`
+	Description string `json:"description"`
+	Note        string `json:"note"`
+	Saved       string `json:"saved"`
+}
+
+var savedHeader = []string{"collection", "url", "title", "description", "note", "saved"}
+
+func (s savedRow) record() []string {
+	return []string{s.Collection, s.URL, s.Title, s.Description, s.Note, s.Saved}
+}
+
+var errSavedKey = errors.New("saving results requires an api key")
+
+// maxSavedText keeps a title, description or note from filling up our store
+const maxSavedText = 1000
+
+// savedHandler lists (GET), saves (POST) or removes (DELETE) the results saved with an api key.
+// GET takes an optional collection and q to search the items, and o=csv or o=jsonl exports them.
+// e.g. curl -H "X-API-Key: $KEY" -d "url=https://example.com&title=Example&collection=reading" /api/v1/saved
+func (f *Frontend) savedHandler(w http.ResponseWriter, r *http.Request) *response {
+	resp := &response{
+		status:   http.StatusOK,
+		template: "json",
+	}
+
+	if f.Saved.Store == nil {
+		resp.status, resp.template, resp.err = http.StatusNotFound, "", fmt.Errorf("saved results are disabled")
+		return resp
+	}
+
+	k, _ := r.Context().Value(apiKeyContext).(*apikey.Key)
+	if k == nil {
+		resp.status, resp.template, resp.err = http.StatusUnauthorized, "", errSavedKey
+		return resp
+	}
+
+	items, err := f.Saved.List(k.ID)
+	if err != nil {
+		resp.status, resp.template, resp.err = http.StatusInternalServerError, "", err
+		return resp
+	}
+
+	switch r.Method {
+	case http.MethodPost:
+		item, err := savedItem(r)
+		if err != nil {
+			resp.status, resp.template, resp.err = http.StatusBadRequest, "", err
+			return resp
+		}
+
+		item.Owner, item.Saved = k.ID, now()
+
+		exists := false
+		for _, i := range items {
+			if i.ID == item.ID {
+				exists = true
+				break
+			}
+		}
+
+		if !exists && len(items) >= saved.Max {
+			resp.status, resp.template, resp.err = http.StatusForbidden, "", fmt.Errorf("%v has saved %d items", k.ID, len(items))
+			return resp
+		}
+
+		if err := f.Saved.Save(item); err != nil {
+			resp.status, resp.template, resp.err = http.StatusInternalServerError, "", err
+			return resp
+		}
+
+		resp.data = item
+		return resp
+	case http.MethodDelete:
+		switch err := f.Saved.Delete(k.ID, strings.TrimSpace(r.FormValue("id"))); err {
+		case nil:
+			resp.status, resp.template = http.StatusNoContent, ""
+		case saved.ErrNotFound:
+			resp.status, resp.template, resp.err = http.StatusNotFound, "", err
+		default:
+			resp.status, resp.template, resp.err = http.StatusInternalServerError, "", err
+		}
+
+		return resp
+	}
+
+	matches := saved.Filter(items, strings.TrimSpace(r.FormValue("collection")), r.FormValue("q"))
+
+	switch o := r.FormValue("o"); o {
+	case "csv", "jsonl":
+		e := &export{header: savedHeader}
+		for _, item := range matches {
+			e.rows = append(e.rows, savedRow{
+				Collection:  item.Collection,
+				URL:         item.URL,
+				Title:       item.Title,
+				Description: item.Description,
+				Note:        item.Note,
+				Saved:       item.Saved.UTC().Format(time.RFC3339),
+			})
+		}
+
+		resp.template, resp.data = o, e
+		return resp
+	}
+
+	resp.data = &SavedItems{
+		Collections: saved.Collections(items),
+		Items:       matches,
+	}
+
+	return resp
+}
+
+// savedItem is the result the user posted
+func savedItem(r *http.Request) (*saved.Item, error) {
+	u, err := url.Parse(strings.TrimSpace(r.FormValue("url")))
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return nil, fmt.Errorf("invalid url %q", r.FormValue("url"))
+	}
+
+	collection := strings.TrimSpace(r.FormValue("collection"))
+	if collection == "" {
+		collection = saved.DefaultCollection
+	}
+
+	item := &saved.Item{
+		ID:          saved.ID(collection, u.String()),
+		Collection:  collection,
+		URL:         u.String(),
+		Title:       strings.TrimSpace(r.FormValue("title")),
+		Description: strings.TrimSpace(r.FormValue("description")),
+		Note:        strings.TrimSpace(r.FormValue("note")),
+	}
+
+	for _, s := range []string{item.Collection, item.Title, item.Description, item.Note} {
+		if len(s) > maxSavedText {
+			return nil, fmt.Errorf("%q is longer than %d bytes", s[:50], maxSavedText)
+		}
+	}
+
+	return item, nil
+}
@@ -0,0 +1,77 @@
+package saved
+
+import (
+	"encoding/json"
+
+	bolt "go.etcd.io/bbolt"
+)
+
+// Bolt stores saved items in a bolt file, for when we don't have PostgreSQL.
+// Each owner has a bucket of their items by id.
+type Bolt struct {
+	*bolt.DB
+}
+
+var savedBucket = []byte("saved")
+
+// Setup creates our bucket if it doesn't exist
+func (b *Bolt) Setup() error {
+	return b.DB.Update(func(tx *bolt.Tx) error {
+		_, err := tx.CreateBucketIfNotExists(savedBucket)
+		return err
+	})
+}
+
+// Save adds or updates an item
+func (b *Bolt) Save(item *Item) error {
+	v, err := json.Marshal(item)
+	if err != nil {
+		return err
+	}
+
+	return b.DB.Update(func(tx *bolt.Tx) error {
+		owner, err := tx.Bucket(savedBucket).CreateBucketIfNotExists([]byte(item.Owner))
+		if err != nil {
+			return err
+		}
+
+		return owner.Put([]byte(item.ID), v)
+	})
+}
+
+// List returns an owner's items
+func (b *Bolt) List(owner string) ([]*Item, error) {
+	items := []*Item{}
+
+	err := b.DB.View(func(tx *bolt.Tx) error {
+		bkt := tx.Bucket(savedBucket).Bucket([]byte(owner))
+		if bkt == nil {
+			return nil
+		}
+
+		return bkt.ForEach(func(k, v []byte) error {
+			item := &Item{}
+			if err := json.Unmarshal(v, item); err != nil {
+				return err
+			}
+
+			item.Owner = owner // not in the json
+			items = append(items, item)
+			return nil
+		})
+	})
+
+	return items, err
+}
+
+// Delete removes an item
+func (b *Bolt) Delete(owner, id string) error {
+	return b.DB.Update(func(tx *bolt.Tx) error {
+		bkt := tx.Bucket(savedBucket).Bucket([]byte(owner))
+		if bkt == nil || bkt.Get([]byte(id)) == nil {
+			return ErrNotFound
+		}
+
+		return bkt.Delete([]byte(id))
+	})
+}
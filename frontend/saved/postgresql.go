@@ -0,0 +1,88 @@
+package saved
+
+import (
+	"database/sql"
+)
+
+// PostgreSQL stores saved items in PostgreSQL
+type PostgreSQL struct {
+	*sql.DB
+}
+
+const savedTable = "saved"
+
+// Setup creates our table if it doesn't exist
+func (p *PostgreSQL) Setup() error {
+	_, err := p.DB.Exec(`
+		CREATE TABLE IF NOT EXISTS ` + savedTable + ` (
+			owner text NOT NULL,
+			id text NOT NULL,
+			collection text NOT NULL,
+			url text NOT NULL,
+			title text NOT NULL,
+			description text NOT NULL,
+			note text NOT NULL,
+			saved timestamptz NOT NULL,
+			PRIMARY KEY (owner, id)
+		);
+	`)
+
+	return err
+}
+
+// Save adds or updates an item
+func (p *PostgreSQL) Save(item *Item) error {
+	_, err := p.DB.Exec(`
+		INSERT INTO `+savedTable+` (owner, id, collection, url, title, description, note, saved)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+		ON CONFLICT (owner, id) DO UPDATE SET
+			title = EXCLUDED.title, description = EXCLUDED.description, note = EXCLUDED.note, saved = EXCLUDED.saved`,
+		item.Owner, item.ID, item.Collection, item.URL, item.Title, item.Description, item.Note, item.Saved,
+	)
+
+	return err
+}
+
+// List returns an owner's items
+func (p *PostgreSQL) List(owner string) ([]*Item, error) {
+	rows, err := p.DB.Query(`
+		SELECT owner, id, collection, url, title, description, note, saved
+		FROM `+savedTable+` WHERE owner = $1`, owner,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	items := []*Item{}
+	for rows.Next() {
+		item := &Item{}
+		if err := rows.Scan(
+			&item.Owner, &item.ID, &item.Collection, &item.URL, &item.Title, &item.Description, &item.Note, &item.Saved,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, item)
+	}
+
+	return items, rows.Err()
+}
+
+// Delete removes an item
+func (p *PostgreSQL) Delete(owner, id string) error {
+	res, err := p.DB.Exec(`DELETE FROM `+savedTable+` WHERE owner = $1 AND id = $2`, owner, id)
+	if err != nil {
+		return err
+	}
+
+	n, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if n == 0 {
+		return ErrNotFound
+	}
+
+	return nil
+}
@@ -0,0 +1,54 @@
+package saved
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	sqlmock "gopkg.in/DATA-DOG/go-sqlmock.v2"
+)
+
+func TestPostgreSQL(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+
+	p := &PostgreSQL{DB: db}
+	saved := time.Date(2018, 02, 06, 11, 0, 0, 0, time.UTC)
+	item := &Item{
+		ID: "1a2b", Owner: "abc", Collection: "music", URL: "https://jive.com", Title: "Jive Talkin", Saved: saved,
+	}
+
+	mock.ExpectExec("INSERT INTO saved (.+) ON CONFLICT").
+		WithArgs("abc", "1a2b", "music", "https://jive.com", "Jive Talkin", "", "", saved).
+		WillReturnResult(sqlmock.NewResult(1, 1))
+
+	if err := p.Save(item); err != nil {
+		t.Fatal(err)
+	}
+
+	cols := []string{"owner", "id", "collection", "url", "title", "description", "note", "saved"}
+	mock.ExpectQuery("SELECT (.+) FROM saved WHERE owner").WithArgs("abc").
+		WillReturnRows(sqlmock.NewRows(cols).AddRow("abc", "1a2b", "music", "https://jive.com", "Jive Talkin", "", "", saved))
+
+	got, err := p.List("abc")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if want := []*Item{item}; !reflect.DeepEqual(got, want) {
+		t.Fatalf("got %+v; want %+v", got, want)
+	}
+
+	mock.ExpectExec("DELETE FROM saved WHERE owner").WithArgs("abc", "nope").WillReturnResult(sqlmock.NewResult(0, 0))
+
+	if err := p.Delete("abc", "nope"); err != ErrNotFound {
+		t.Fatalf("got %v; want %v", err, ErrNotFound)
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Fatal(err)
+	}
+}
@@ -0,0 +1,99 @@
+// Package saved keeps the results our API users bookmark, in named collections
+package saved
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"sort"
+	"strings"
+	"time"
+)
+
+// ErrNotFound indicates an unknown item
+var ErrNotFound = errors.New("saved item not found")
+
+// Max is the number of items an owner can save
+const Max = 1000
+
+// DefaultCollection is where items go if the user doesn't name a collection
+const DefaultCollection = "Saved"
+
+// Store outlines the methods to persist saved items.
+// The owner is the id of the api key the items were saved with.
+type Store interface {
+	Setup() error
+	Save(item *Item) error // replaces an item with the same id
+	List(owner string) ([]*Item, error)
+	Delete(owner, id string) error
+}
+
+// Item is a saved result
+type Item struct {
+	ID          string    `json:"id"`
+	Owner       string    `json:"-"`
+	Collection  string    `json:"collection"`
+	URL         string    `json:"url"`
+	Title       string    `json:"title"`
+	Description string    `json:"description,omitempty"`
+	Note        string    `json:"note,omitempty"`
+	Saved       time.Time `json:"saved"`
+}
+
+// ID identifies a url in a collection so saving it twice updates it rather than adding a copy
+func ID(collection, u string) string {
+	h := sha256.Sum256([]byte(strings.ToLower(collection) + "\x00" + u))
+	return hex.EncodeToString(h[:8])
+}
+
+// Collection is a name the user saves items under
+type Collection struct {
+	Name  string `json:"name"`
+	Count int    `json:"count"`
+}
+
+// Collections lists the collections of the items by name
+func Collections(items []*Item) []Collection {
+	counts := map[string]int{}
+	for _, item := range items {
+		counts[item.Collection]++
+	}
+
+	c := []Collection{}
+	for name, n := range counts {
+		c = append(c, Collection{name, n})
+	}
+
+	sort.Slice(c, func(i, j int) bool { return c[i].Name < c[j].Name })
+	return c
+}
+
+// Filter returns the items in a collection (all of them if empty) whose url,
+// title, description or note has every word of q, most recently saved first.
+func Filter(items []*Item, collection, q string) []*Item {
+	words := strings.Fields(strings.ToLower(q))
+	res := []*Item{}
+
+	for _, item := range items {
+		if collection != "" && !strings.EqualFold(item.Collection, collection) {
+			continue
+		}
+
+		text := strings.ToLower(strings.Join([]string{item.URL, item.Title, item.Description, item.Note}, " "))
+
+		match := true
+		for _, w := range words {
+			if !strings.Contains(text, w) {
+				match = false
+				break
+			}
+		}
+
+		if match {
+			res = append(res, item)
+		}
+	}
+
+	sort.SliceStable(res, func(i, j int) bool { return res[i].Saved.After(res[j].Saved) })
+	return res
+}
@@ -0,0 +1,104 @@
+package saved
+
+import (
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestID(t *testing.T) {
+	if ID("Reading", "https://example.com") != ID("reading", "https://example.com") {
+		t.Fatal("want the same id regardless of the collection's case")
+	}
+
+	if ID("reading", "https://example.com") == ID("work", "https://example.com") {
+		t.Fatal("want a different id in another collection")
+	}
+}
+
+func TestFilter(t *testing.T) {
+	day := time.Date(2018, 02, 06, 11, 0, 0, 0, time.UTC)
+
+	items := []*Item{
+		{ID: "1", Collection: "music", URL: "https://beegees.com", Title: "Bee Gees", Saved: day},
+		{ID: "2", Collection: "music", URL: "https://jive.com", Title: "Jive Talkin", Note: "disco", Saved: day.Add(time.Hour)},
+		{ID: "3", Collection: "reading", URL: "https://example.com/disco", Title: "A History of Disco", Saved: day.Add(2 * time.Hour)},
+	}
+
+	for _, c := range []struct {
+		name       string
+		collection string
+		q          string
+		want       []string
+	}{
+		{"all", "", "", []string{"3", "2", "1"}},
+		{"collection", "Music", "", []string{"2", "1"}},
+		{"search", "", "disco", []string{"3", "2"}},
+		{"every word", "", "disco history", []string{"3"}},
+		{"search a collection", "music", "DISCO", []string{"2"}},
+		{"nothing", "", "abba", []string{}},
+	} {
+		t.Run(c.name, func(t *testing.T) {
+			got := []string{}
+			for _, item := range Filter(items, c.collection, c.q) {
+				got = append(got, item.ID)
+			}
+
+			if !reflect.DeepEqual(got, c.want) {
+				t.Fatalf("got %v; want %v", got, c.want)
+			}
+		})
+	}
+
+	want := []Collection{{"music", 2}, {"reading", 1}}
+	if got := Collections(items); !reflect.DeepEqual(got, want) {
+		t.Fatalf("got %+v; want %+v", got, want)
+	}
+}
+
+// testStore saves, lists and deletes items the same for every store
+func testStore(t *testing.T, s Store) {
+	if err := s.Setup(); err != nil {
+		t.Fatal(err)
+	}
+
+	saved := time.Date(2018, 02, 06, 11, 0, 0, 0, time.UTC)
+	item := &Item{
+		ID: ID("music", "https://jive.com"), Owner: "abc", Collection: "music",
+		URL: "https://jive.com", Title: "Jive", Saved: saved,
+	}
+
+	if err := s.Save(item); err != nil {
+		t.Fatal(err)
+	}
+
+	item.Title = "Jive Talkin"
+	if err := s.Save(item); err != nil {
+		t.Fatal(err)
+	}
+
+	got, err := s.List("abc")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(got) != 1 || !reflect.DeepEqual(got[0], item) {
+		t.Fatalf("got %+v; want %+v", got, item)
+	}
+
+	if got, _ := s.List("other"); len(got) != 0 {
+		t.Fatalf("got %+v for another owner; want nothing", got)
+	}
+
+	if err := s.Delete("other", item.ID); err != ErrNotFound {
+		t.Fatalf("got %v deleting another owner's item; want %v", err, ErrNotFound)
+	}
+
+	if err := s.Delete("abc", item.ID); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := s.Delete("abc", item.ID); err != ErrNotFound {
+		t.Fatalf("got %v; want %v", err, ErrNotFound)
+	}
+}
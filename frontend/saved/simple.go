@@ -0,0 +1,60 @@
+package saved
+
+import (
+	"sync"
+)
+
+// Simple is an in-memory Store. Items are lost on restart so it is only suitable for testing.
+type Simple struct {
+	mu    sync.Mutex
+	items map[string]map[string]Item // by owner & id
+}
+
+// Setup initializes the store
+func (s *Simple) Setup() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.items = make(map[string]map[string]Item)
+	return nil
+}
+
+// Save adds or updates an item
+func (s *Simple) Save(item *Item) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.items[item.Owner] == nil {
+		s.items[item.Owner] = make(map[string]Item)
+	}
+
+	s.items[item.Owner][item.ID] = *item
+	return nil
+}
+
+// List returns an owner's items
+func (s *Simple) List(owner string) ([]*Item, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	items := []*Item{}
+	for _, item := range s.items[owner] {
+		item := item
+		items = append(items, &item)
+	}
+
+	return items, nil
+}
+
+// Delete removes an item
+func (s *Simple) Delete(owner, id string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, ok := s.items[owner][id]; !ok {
+		return ErrNotFound
+	}
+
+	delete(s.items[owner], id)
+	return nil
+}
@@ -0,0 +1,9 @@
+package saved
+
+import (
+	"testing"
+)
+
+func TestSimple(t *testing.T) {
+	testStore(t, &Simple{})
+}
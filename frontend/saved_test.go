@@ -0,0 +1,83 @@
+package frontend
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/jivesearch/jivesearch/frontend/apikey"
+	"github.com/jivesearch/jivesearch/frontend/saved"
+)
+
+func TestSavedHandler(t *testing.T) {
+	s := &saved.Simple{}
+	if err := s.Setup(); err != nil {
+		t.Fatal(err)
+	}
+
+	f := &Frontend{}
+	f.Saved.Store = s
+
+	key := &apikey.Key{ID: "abc"}
+	id := saved.ID("music", "https://jive.com")
+
+	for _, c := range []struct {
+		name   string
+		method string
+		key    *apikey.Key
+		params url.Values
+		status int
+		want   string
+	}{
+		{"no key", "GET", nil, url.Values{}, http.StatusUnauthorized, ""},
+		{"bad url", "POST", key, url.Values{"url": {"ftp://jive.com"}}, http.StatusBadRequest, ""},
+		{
+			"save", "POST", key, url.Values{"url": {"https://jive.com"}, "title": {"Jive Talkin"}, "collection": {"music"}},
+			http.StatusOK, `"collection":"music"`,
+		},
+		{"default collection", "POST", key, url.Values{"url": {"https://beegees.com"}}, http.StatusOK, `"collection":"Saved"`},
+		{"list", "GET", key, url.Values{}, http.StatusOK, `{"name":"Saved","count":1}`},
+		{"search", "GET", key, url.Values{"q": {"talkin"}}, http.StatusOK, `"url":"https://jive.com"`},
+		{"csv", "GET", key, url.Values{"o": {"csv"}, "collection": {"music"}}, http.StatusOK, "music,https://jive.com,Jive Talkin"},
+		{"jsonl", "GET", key, url.Values{"o": {"jsonl"}, "q": {"beegees"}}, http.StatusOK, `"url":"https://beegees.com"`},
+		{"another key", "DELETE", &apikey.Key{ID: "other"}, url.Values{"id": {id}}, http.StatusNotFound, ""},
+		{"delete", "DELETE", key, url.Values{"id": {id}}, http.StatusNoContent, ""},
+	} {
+		t.Run(c.name, func(t *testing.T) {
+			var r *http.Request
+			switch c.method {
+			case "POST":
+				r = httptest.NewRequest(c.method, "/api/v1/saved", strings.NewReader(c.params.Encode()))
+				r.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+			default:
+				r = httptest.NewRequest(c.method, "/api/v1/saved?"+c.params.Encode(), nil)
+			}
+
+			if c.key != nil {
+				r = r.WithContext(context.WithValue(r.Context(), apiKeyContext, c.key))
+			}
+
+			rr := httptest.NewRecorder()
+			appHandler(f.savedHandler).ServeHTTP(rr, r)
+
+			if rr.Code != c.status {
+				t.Fatalf("got status %d; want %d (%v)", rr.Code, c.status, rr.Body.String())
+			}
+
+			if !strings.Contains(rr.Body.String(), c.want) {
+				t.Fatalf("got %q; want it to contain %q", rr.Body.String(), c.want)
+			}
+		})
+	}
+
+	f.Saved.Store = nil
+	r := httptest.NewRequest("GET", "/api/v1/saved", nil)
+	r = r.WithContext(context.WithValue(r.Context(), apiKeyContext, key))
+
+	if resp := f.savedHandler(httptest.NewRecorder(), r); resp.status != http.StatusNotFound {
+		t.Fatalf("got status %d when disabled; want %d", resp.status, http.StatusNotFound)
+	}
+}
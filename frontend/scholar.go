@@ -0,0 +1,52 @@
+package frontend
+
+import (
+	"context"
+	"encoding/json"
+	"net/url"
+
+	"github.com/jivesearch/jivesearch/log"
+	"github.com/jivesearch/jivesearch/search/scholar"
+	"golang.org/x/text/language"
+)
+
+// maxScholar is the most papers we'll show
+const maxScholar = 25
+
+// scholarResults finds academic papers. Like images and local, the results
+// are the same whatever the user's language or region.
+func (f *Frontend) scholarResults(ctx context.Context, d data, lang language.Tag, region language.Region) *scholar.Results {
+	if f.Scholar == nil {
+		return &scholar.Results{}
+	}
+
+	u := &url.URL{
+		Path:     "/",
+		RawQuery: url.Values{"q": {d.Context.Q}}.Encode(),
+	}
+	key := cacheKey("scholar", lang, region, u)
+
+	v := f.cacheGet(ctx, "scholar", key)
+
+	if v != nil {
+		sr := &scholar.Results{}
+		if err := json.Unmarshal(v.([]byte), sr); err != nil {
+			log.Info.Println(err)
+		}
+		return sr
+	}
+
+	if d.Context.Shed == ShedUncached {
+		return nil
+	}
+
+	sr, err := f.Scholar.Fetch(d.Context.Q, maxScholar)
+	if err != nil {
+		log.Infow(ctx, "fetch failed", log.Fields{"fetcher": "scholar", "error": err})
+		return &scholar.Results{}
+	}
+
+	f.cachePut(ctx, key, sr, f.Cache.Search)
+
+	return sr
+}
@@ -0,0 +1,66 @@
+package frontend
+
+import (
+	"net/http/httptest"
+	"reflect"
+	"testing"
+
+	"github.com/jivesearch/jivesearch/instant"
+	"github.com/jivesearch/jivesearch/search/scholar"
+)
+
+func TestScholarResults(t *testing.T) {
+	paper := func() *scholar.Paper {
+		return &scholar.Paper{
+			ID:        "https://doi.org/10.1038/nature14539",
+			Title:     "Deep learning",
+			Authors:   []string{"Yann LeCun"},
+			Venue:     "Nature",
+			Year:      2015,
+			Citations: 50000,
+			DOI:       "10.1038/nature14539",
+		}
+	}
+
+	cited := paper()
+	cited.BibTeX = cited.Cite()
+
+	for _, c := range []struct {
+		name string
+		u    string
+		want *scholar.Paper
+	}{
+		{"html", "/?l=en&q=deep+learning&t=scholar", paper()},
+		{"json", "/?l=en&o=json&q=deep+learning&t=scholar", cited},
+	} {
+		t.Run(c.name, func(t *testing.T) {
+			f := mapsFrontend(t)
+			f.Instant = &instant.Instant{}
+			f.Scholar = &mockScholarFetcher{paper: paper()}
+			f.Search = &mockSearch{}
+			f.Suggest = &mockSuggester{}
+
+			r := httptest.NewRequest("GET", c.u, nil)
+			r.Header.Set("Accept-Language", "en")
+
+			resp := f.searchHandler(httptest.NewRecorder(), r)
+			got := resp.data.(data).Scholar
+
+			want := &scholar.Results{Provider: scholar.CrossrefProvider, Papers: []*scholar.Paper{c.want}}
+			if !reflect.DeepEqual(got, want) {
+				t.Fatalf("got %+v; want %+v", got, want)
+			}
+		})
+	}
+}
+
+type mockScholarFetcher struct {
+	paper *scholar.Paper
+}
+
+func (m *mockScholarFetcher) Fetch(q string, number int) (*scholar.Results, error) {
+	return &scholar.Results{
+		Provider: scholar.CrossrefProvider,
+		Papers:   []*scholar.Paper{m.paper},
+	}, nil
+}
@@ -1,48 +1,81 @@
 package frontend
 
 import (
-	"encoding/base64"
 	"encoding/json"
 	"fmt"
-	"io/ioutil"
+	"math"
 	"net/http"
 	"net/url"
 	"strconv"
 	"strings"
-	"sync"
 	"time"
 
 	"github.com/jivesearch/jivesearch/bangs"
+	"github.com/jivesearch/jivesearch/frontend/experiment"
 	"github.com/jivesearch/jivesearch/instant"
+	"github.com/jivesearch/jivesearch/instant/wikipedia"
 	"github.com/jivesearch/jivesearch/log"
 	"github.com/jivesearch/jivesearch/search"
 	img "github.com/jivesearch/jivesearch/search/image"
+	"github.com/jivesearch/jivesearch/search/intent"
+	"github.com/jivesearch/jivesearch/search/local"
+	"github.com/jivesearch/jivesearch/search/scholar"
+	"github.com/jivesearch/jivesearch/search/shopping"
 	"github.com/jivesearch/jivesearch/suggest"
-	"github.com/pkg/errors"
 	"golang.org/x/text/language"
 )
 
 // Context holds a user's request context so we can pass it to our template's form.
 // Query, Language, and Region are the RAW query string variables.
 type Context struct {
-	Q            string        `json:"query"`
-	L            string        `json:"-"`
-	D            string        `json:"-"`
-	F            search.Filter `json:"-"`
-	lang         language.Tag
-	POST         bool            `json:"-"`
-	R            string          `json:"-"`
-	S            string          `json:"-"`
-	N            string          `json:"-"`
-	T            string          `json:"-"`
-	Ref          string          `json:"-"`
-	Safe         bool            `json:"-"`
-	DefaultBangs []DefaultBang   `json:"-"`
-	Preferred    []language.Tag  `json:"-"`
-	Region       language.Region `json:"-"`
-	Number       int             `json:"-"`
-	Page         int             `json:"-"`
-	Theme        string          `json:"-"`
+	Q              string            `json:"query"`
+	Site           string            `json:"-"` // web results are limited to this host
+	L              string            `json:"-"`
+	D              string            `json:"-"`
+	F              search.Filter     `json:"-"`
+	ImageFilter    img.Filter        `json:"-"`
+	ShoppingFilter shopping.Filter   `json:"-"`
+	FileFilter     search.FileFilter `json:"-"`
+	lang           language.Tag
+	POST           bool                   `json:"-"`
+	R              string                 `json:"-"`
+	S              string                 `json:"-"`
+	N              string                 `json:"-"`
+	T              string                 `json:"-"`
+	Ref            string                 `json:"-"`
+	Safe           bool                   `json:"-"`
+	Clicks         bool                   `json:"-"` // report which results are clicked
+	History        bool                   `json:"-"` // users may opt in to keeping their search history
+	DefaultBangs   []DefaultBang          `json:"-"`
+	Experiments    experiment.Assignments `json:"-"`
+	Intent         intent.Scores          `json:"-"`
+	Preferred      []language.Tag         `json:"-"`
+	RTL            bool                   `json:"-"` // the user's language is written right to left
+	Region         language.Region        `json:"-"`
+	Number         int                    `json:"-"`
+	Page           int                    `json:"-"`
+	Cursor         string                 `json:"-"` // where the page before ended, for pages too deep for an offset
+	Theme          string                 `json:"-"`
+	Ban            string                 `json:"-"` // comma-separated domains the user doesn't want to see
+	Sink           string                 `json:"-"` // ...wants to see last
+	Pin            string                 `json:"-"` // ...wants to see first
+	Preferences    search.Preferences     `json:"-"`
+	Lite           bool                   `json:"-"` // the JavaScript-free page at /lite
+	Nonce          string                 `json:"-"` // lets our inline scripts run under our Content-Security-Policy
+	DNT            bool                   `json:"-"` // the browser sent Do Not Track or Global Privacy Control
+	Shed           Shed                   `json:"-"` // what we skip as we are overloaded
+	Verticals      []string               `json:"-"` // the tabs of the tenant's search page. Empty is all of them.
+	Ranking        string                 `json:"-"` // the tenant's searcher
+}
+
+// Vertical is true if the search page has a tab for the vertical
+func (c *Context) Vertical(v string) bool {
+	return len(c.Verticals) == 0 || !contains(Verticals, v) || contains(c.Verticals, v)
+}
+
+// Offset is the number of results before the current page
+func (c *Context) Offset() int {
+	return c.Page*c.Number - c.Number
 }
 
 // DefaultBang is the user's preffered !bang
@@ -53,10 +86,17 @@ type DefaultBang struct {
 
 // Results is the results from search, instant, wikipedia, etc
 type Results struct {
-	Alternative string          `json:"-"`
-	Images      *img.Results    `json:"images,omitempty"`
-	Instant     instant.Data    `json:"-"`
-	Search      *search.Results `json:"search,omitempty"`
+	Alternative string            `json:"-"`
+	Blend       *Blend            `json:"blend,omitempty"`
+	Hints       *Hints            `json:"hints,omitempty"`
+	Images      *img.Results      `json:"images,omitempty"`
+	Instant     instant.Data      `json:"-"`
+	Knowledge   *wikipedia.Panel  `json:"knowledge,omitempty"`
+	Local       *local.Results    `json:"local,omitempty"`
+	Questions   []Question        `json:"questions,omitempty"`
+	Scholar     *scholar.Results  `json:"scholar,omitempty"`
+	Search      *search.Results   `json:"search,omitempty"`
+	Shopping    *shopping.Results `json:"shopping,omitempty"`
 }
 
 // Instant is a wrapper to facilitate custom unmarshalling
@@ -64,12 +104,18 @@ type Instant struct {
 	instant.Data
 }
 
-func (c *Context) setTheme(r *http.Request) *Context {
-	th := strings.ToLower(r.FormValue("theme"))
-	if _, ok := themes[th]; ok {
-		c.Theme = th
+func (c *Context) setPreferences(r *http.Request) *Context {
+	c.Ban = strings.TrimSpace(r.FormValue("ban"))
+	c.Sink = strings.TrimSpace(r.FormValue("sink"))
+	c.Pin = strings.TrimSpace(r.FormValue("pin"))
+
+	if c.Ban == "" && c.Sink == "" && c.Pin == "" {
+		return c
 	}
 
+	// a domain in more than one list is banned rather than sunk, and sunk rather than pinned
+	c.Preferences = search.Preferences{}.Add(c.Pin, search.Pin).Add(c.Sink, search.Sink).Add(c.Ban, search.Ban)
+
 	return c
 }
 
@@ -81,22 +127,17 @@ type data struct {
 }
 
 func (f *Frontend) defaultBangs(r *http.Request) []DefaultBang {
-	var bngs []DefaultBang
-
-	for _, db := range strings.Split(strings.TrimSpace(r.FormValue("b")), ",") {
-		for _, b := range f.Bangs.Bangs {
-			for _, t := range b.Triggers {
-				if t == db {
-					bngs = append(bngs, DefaultBang{db, b})
-				}
-			}
-		}
+	if bngs := f.triggered(strings.Split(strings.TrimSpace(r.FormValue("b")), ",")); len(bngs) > 0 {
+		return bngs
 	}
 
-	if len(bngs) > 0 {
+	// a tenant's before ours
+	if bngs := f.triggered(f.tenant(r).DefaultBangs); len(bngs) > 0 {
 		return bngs
 	}
 
+	var bngs []DefaultBang
+
 	// defaults if no valid params passed
 	for _, b := range []struct {
 		trigger string
@@ -117,6 +158,23 @@ func (f *Frontend) defaultBangs(r *http.Request) []DefaultBang {
 	return bngs
 }
 
+// triggered are the !bangs with the triggers
+func (f *Frontend) triggered(triggers []string) []DefaultBang {
+	var bngs []DefaultBang
+
+	for _, db := range triggers {
+		for _, b := range f.Bangs.Bangs {
+			for _, t := range b.Triggers {
+				if t == db {
+					bngs = append(bngs, DefaultBang{db, b})
+				}
+			}
+		}
+	}
+
+	return bngs
+}
+
 // Detect the user's preferred language(s).
 // The "l" param takes precedence over the "Accept-Language" header.
 func (f *Frontend) detectLanguage(r *http.Request) []language.Tag {
@@ -137,19 +195,59 @@ func (f *Frontend) detectLanguage(r *http.Request) []language.Tag {
 	return preferred
 }
 
+// rtl matches the languages written right to left. English is the fallback for all others.
+var rtl = language.NewMatcher([]language.Tag{language.English, language.Arabic, language.Hebrew, language.Persian})
+
+// rightToLeft is true if the user's most preferred language is written right to left
+func rightToLeft(preferred []language.Tag) bool {
+	if len(preferred) == 0 {
+		return false
+	}
+
+	_, i, c := rtl.Match(preferred[0])
+	return i > 0 && c >= language.High
+}
+
 // Detect the user's region. "r" param takes precedence over the language's region (if any).
+// If the language doesn't specify a region we fall back to their IP address, if enabled.
 func (f *Frontend) detectRegion(lang language.Tag, r *http.Request) language.Region {
 	reg, err := language.ParseRegion(strings.TrimSpace(r.FormValue("r")))
 	if err != nil {
-		reg, _ = lang.Region()
+		var conf language.Confidence
+		reg, conf = lang.Region()
+		if conf != language.Exact && f.RegionFetcher != nil && !f.Tor {
+			if ipReg, err := f.ipRegion(r); err == nil {
+				reg = ipReg
+			} else {
+				log.Debug.Println(err)
+			}
+		}
 	}
 
 	return reg.Canonicalize()
 }
 
+// ipRegion is the country of the user's IP address.
+// The IP address isn't logged or stored.
+func (f *Frontend) ipRegion(r *http.Request) (language.Region, error) {
+	city, err := f.RegionFetcher.Fetch(instant.IPAddress(r))
+	if err != nil {
+		return language.Region{}, err
+	}
+
+	return language.ParseRegion(city.Country.IsoCode)
+}
+
 var errIsNaughty = fmt.Errorf("naughty word")
 
+var errIsPII = fmt.Errorf("personal information")
+
 func (f *Frontend) addQuery(q string) error {
+	// e.g. emails & phone numbers. We never suggest them to anyone else.
+	if suggest.PII(q) {
+		return errIsPII
+	}
+
 	exists, err := f.Suggest.Exists(q)
 	if err != nil {
 		return err
@@ -175,13 +273,17 @@ func (f *Frontend) getData(r *http.Request) (data, error) {
 		return data{}, err
 	}
 
+	t := f.tenant(r)
 	d := data{
-		Brand:     f.Brand,
-		MapBoxKey: f.MapBoxKey,
+		Brand:     t.Brand,
+		MapBoxKey: t.MapBoxKey,
 		Context: &Context{
-			Q:    strings.TrimSpace(r.FormValue("q")),
-			F:    search.Moderate,
-			Safe: true,
+			Q:         strings.TrimSpace(r.FormValue("q")),
+			F:         search.Moderate,
+			Safe:      true,
+			Nonce:     nonce(r),
+			Verticals: t.Verticals,
+			Ranking:   t.Ranking,
 		},
 	}
 
@@ -190,6 +292,13 @@ func (f *Frontend) getData(r *http.Request) (data, error) {
 	}
 
 	d.Context.setTheme(r)
+	d.Context.setPreferences(r)
+	d.Context.POST = f.post(r)
+	d.Context.Preferred = f.detectLanguage(r) // the start page is translated too
+	d.Context.RTL = rightToLeft(d.Context.Preferred)
+	d.Context.Site = site(r.FormValue("site")) // a site's search box starts empty
+	d.Context.History = f.History.Store != nil
+	d.Context.DNT = doNotTrack(r)
 
 	// Note: We can combine Safe with F. They are only separate for now
 	// because image filter is a boolean but that can be changed to off, moderate and strict.
@@ -209,13 +318,36 @@ func (f *Frontend) getData(r *http.Request) (data, error) {
 	d.Context.D = strings.TrimSpace(r.FormValue("d"))
 	d.Context.L = strings.TrimSpace(r.FormValue("l"))
 	d.Context.N = strings.TrimSpace(r.FormValue("n"))
-	d.Context.POST = strings.ToLower(strings.TrimSpace(r.FormValue("post"))) == "true"
 	d.Context.R = strings.TrimSpace(r.FormValue("r"))
 	d.Context.S = strings.TrimSpace(r.FormValue("s"))
 	d.Context.Ref = strings.TrimSpace(r.FormValue("ref"))
 	d.Context.T = strings.TrimSpace(r.FormValue("t"))
+	if !d.Context.Vertical(d.Context.T) { // a tab the tenant turned off is a web search
+		d.Context.T = ""
+	}
+	d.Context.Cursor = strings.TrimSpace(r.FormValue("cursor"))
+	d.Context.ImageFilter = img.NewFilter(
+		strings.TrimSpace(r.FormValue("size")),
+		strings.TrimSpace(r.FormValue("aspect")),
+		strings.TrimSpace(r.FormValue("color")),
+		strings.TrimSpace(r.FormValue("kind")),
+		strings.TrimSpace(r.FormValue("license")),
+	)
+	d.Context.ShoppingFilter = shopping.NewFilter(
+		strings.TrimSpace(r.FormValue("sort")),
+		strings.TrimSpace(r.FormValue("min")),
+		strings.TrimSpace(r.FormValue("max")),
+		strings.TrimSpace(r.FormValue("currency")),
+	)
+	d.Context.FileFilter = search.NewFileFilter(
+		strings.TrimSpace(r.FormValue("filetype")),
+		strings.TrimSpace(r.FormValue("filesize")),
+		strings.TrimSpace(r.FormValue("filedate")),
+	)
 	d.Context.DefaultBangs = f.defaultBangs(r)
-	d.Context.Preferred = f.detectLanguage(r)
+	d.Context.Experiments = f.assign(r)
+	d.Context.Clicks = f.tracking(r)
+	d.Context.Intent = f.Intent.Classify(d.Context.Q)
 	d.Results = Results{
 		Search: &search.Results{},
 	}
@@ -237,7 +369,93 @@ func (f *Frontend) getData(r *http.Request) (data, error) {
 	return d, nil
 }
 
+// post is true if the search form, pagination and tabs should send form posts to /search,
+// keeping the query out of our urls, referrers and access logs. A "post" param overrides our default.
+func (f *Frontend) post(r *http.Request) bool {
+	if r.Method == http.MethodPost {
+		return true
+	}
+
+	if p := strings.TrimSpace(r.FormValue("post")); p != "" {
+		return strings.ToLower(p) == "true"
+	}
+
+	return f.POST
+}
+
+// feelingLucky is true when they want the first result, e.g. "!! example", "example !!",
+// "golang !! slice", "! example", "example !" or "\example" but NOT "example ! now".
+// A "!" on its own lists our !bangs instead. The query is returned without the "!", "!!" or "\".
+func feelingLucky(q string) (string, bool) {
+	fields := strings.Fields(q)
+	if len(fields) == 0 || (len(fields) == 1 && fields[0] == "!") {
+		return q, false
+	}
+
+	lucky := false
+	rest := []string{}
+	for i, f := range fields {
+		switch {
+		case f == "!!":
+			lucky = true
+			continue
+		case f == "!" && (i == 0 || i == len(fields)-1):
+			lucky = true
+			continue
+		case i == 0 && strings.HasPrefix(f, `\`):
+			lucky = true
+			if f = strings.TrimPrefix(f, `\`); f == "" {
+				continue
+			}
+		}
+
+		rest = append(rest, f)
+	}
+
+	if !lucky {
+		return q, false
+	}
+
+	return strings.Join(rest, " "), true
+}
+
+// luckyResult is where to send them for the first result. We don't send them anywhere for images
+// or maps, if there are no results, if we had to loosen an ambiguous query to find any or
+// if the first result is on a threat blocklist.
+func (f *Frontend) luckyResult(r *http.Request, d data, q string) (string, bool) {
+	if d.Context.T != "" || strings.TrimSpace(q) == "" {
+		return "", false
+	}
+
+	ctx := *d.Context
+	ctx.Q = q
+	d.Context = &ctx
+
+	res := f.searchResults(r, d, d.Context.lang, d.Context.Region)
+	if res == nil || res.Err != nil || res.Relaxed != "" || len(res.Documents) == 0 || res.Documents[0].Threat != "" {
+		return "", false
+	}
+
+	u, err := url.Parse(res.Documents[0].ID)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return "", false
+	}
+
+	return u.String(), true
+}
+
 func (f *Frontend) searchHandler(w http.ResponseWriter, r *http.Request) *response {
+	return f.search(w, r, false)
+}
+
+// liteHandler serves a minimal page of web results that works without JavaScript.
+// We skip what it can't show (instant answers, the knowledge panel,
+// blended verticals and related questions) so there is less to fetch.
+func (f *Frontend) liteHandler(w http.ResponseWriter, r *http.Request) *response {
+	return f.search(w, r, true)
+}
+
+func (f *Frontend) search(w http.ResponseWriter, r *http.Request, lite bool) *response {
 	d, err := f.getData(r)
 
 	resp := &response{
@@ -247,6 +465,11 @@ func (f *Frontend) searchHandler(w http.ResponseWriter, r *http.Request) *respon
 		err:      err,
 	}
 
+	if lite {
+		d.Context.Lite, d.Context.T = true, ""
+		resp.template = "lite"
+	}
+
 	// render start page if no query
 	if d.Context.Q == "" {
 		return resp
@@ -272,23 +495,39 @@ func (f *Frontend) searchHandler(w http.ResponseWriter, r *http.Request) *respon
 		}
 	*/
 
+	strt := time.Now() // we already have total response time in nginx...we want the breakdown
+
 	// is it a !bang? Redirect them
 	if bng, loc, ok := f.Bangs.Detect(d.Context.Q, d.Context.Region, d.Context.lang); ok {
 		log.Info.Printf("!bang (%v)", bng.Name)
+		if f.BangStats.Usage != nil {
+			f.BangStats.Count(bng.Name)
+		}
+		f.logQuery(r, d, bng.Name, false, strt)
 		return &response{
 			status:   302,
 			redirect: loc,
 		}
 	}
 
-	// Do they just want the first result?
-	// "! example", "example !" or "\example" but NOT "example ! now"
-	fields := strings.Fields(d.Context.Q)
-	if fields[0] == "!" || fields[len(fields)-1] == "!" || strings.HasPrefix(fields[0], `\`) {
-		docs := f.searchResults(d, d.Context.lang, d.Context.Region, r.URL)
-		for _, doc := range docs.Documents {
-			loc := doc.ID
+	// when overloaded we skip the parts of a search we can do without
+	shed, release := f.Shed.acquire()
+	defer release()
+
+	d.Context.Shed = shed
+	if shed >= ShedResults && d.Context.Number > f.Shed.Number {
+		d.Context.Number = f.Shed.Number
+	}
+
+	// Do they just want the first result? e.g. "!! example" or "/search?q=example&lucky=1"
+	q, lucky := feelingLucky(d.Context.Q)
+	if l, err := strconv.ParseBool(r.FormValue("lucky")); err == nil && l {
+		lucky = true
+	}
 
+	if lucky {
+		if loc, ok := f.luckyResult(r, d, q); ok {
+			f.logQuery(r, d, "", false, strt)
 			return &response{
 				status:   302,
 				redirect: loc,
@@ -297,34 +536,69 @@ func (f *Frontend) searchHandler(w http.ResponseWriter, r *http.Request) *respon
 	}
 
 	channels := 1
-	imageCH := make(chan *img.Results)
-	sc := make(chan *search.Results)
+	// buffered so the goroutines below can finish even if we stop listening after a timeout
+	imageCH := make(chan *img.Results, 1)
+	localCH := make(chan *local.Results, 1)
+	scholarCH := make(chan *scholar.Results, 1)
+	shoppingCH := make(chan *shopping.Results, 1)
+	sc := make(chan *search.Results, 1)
 	var ac chan error
+	var bc chan *Blend
 	var ic chan instant.Data
-
-	strt := time.Now() // we already have total response time in nginx...we want the breakdown
+	var kc chan *wikipedia.Panel
+	var qc chan []Question
+	missed := make(chan struct{}, 1) // what they want isn't cached and we are shedding
 
 	if d.Context.Page == 1 && (d.Context.T == "" || d.Context.T == "maps") {
+		if !d.Context.DNT {
+			channels++
+			ac = make(chan error, 1)
+			go func(q string, ch chan error, done func()) {
+				defer done()
+				ch <- f.addQuery(q)
+			}(d.Context.Q, ac, track())
+		}
+
+		if !d.Context.Lite && shed < ShedInstant {
+			channels++
+			ic = make(chan instant.Data, 1)
+			go func(d data, done func()) {
+				defer done()
+				f.getAnswer(r, d, ic)
+			}(d, track())
+		}
+	}
+
+	if d.Context.Page == 1 && d.Context.T == "" && !d.Context.Lite && shed < ShedUncached {
 		channels++
-		ac = make(chan error)
-		go func(q string, ch chan error) {
-			ch <- f.addQuery(q)
-		}(d.Context.Q, ac)
+		bc = make(chan *Blend, 1)
+		go func(d data, lang language.Tag, region language.Region, done func()) {
+			defer done()
+			bc <- f.blend(r.Context(), d, lang, region)
+		}(d, d.Context.lang, d.Context.Region, track())
 
 		channels++
-		ic = make(chan instant.Data)
-		go f.getAnswer(r, d, ic)
+		kc = make(chan *wikipedia.Panel, 1)
+		go func(d data, done func()) {
+			defer done()
+			f.knowledgePanel(r, d, kc)
+		}(d, track())
+
+		channels++
+		qc = make(chan []Question, 1)
+		go func(d data, done func()) {
+			defer done()
+			f.relatedQuestions(r, d, qc)
+		}(d, track())
 	}
 
-	go func(d data, lang language.Tag, region language.Region) {
+	go func(d data, lang language.Tag, region language.Region, done func()) {
+		defer done()
 		switch d.Context.T {
 		case "images":
-			key := cacheKey("images", lang, region, r.URL)
+			key := cacheKey("images", lang, region, formURL(r))
 
-			v, err := f.Cache.Get(key)
-			if err != nil {
-				log.Info.Println(err)
-			}
+			v := f.cacheGet(r.Context(), "images", key)
 
 			if v != nil {
 				ir := &img.Results{}
@@ -336,75 +610,130 @@ func (f *Frontend) searchHandler(w http.ResponseWriter, r *http.Request) *respon
 				return
 			}
 
-			num := 100
-			offset := d.Context.Page*num - num
-			ir, err := f.Images.Fetch(d.Context.Q, d.Context.Safe, num, offset) // .8 is Yahoo's open_nsfw cutoff for nsfw
-			if err != nil {
-				log.Info.Println(err)
+			if d.Context.Shed == ShedUncached {
+				missed <- struct{}{}
+				imageCH <- nil
+				return
 			}
 
-			if err := f.Cache.Put(key, ir, f.Cache.Search); err != nil {
-				log.Info.Println(err)
+			v, _, shared := f.Coalesce.Do(key, func() (interface{}, error) {
+				num := 100
+				offset := d.Context.Page*num - num
+				ir, err := f.Images.Fetch(d.Context.Q, d.Context.Safe, d.Context.ImageFilter, num, offset) // .8 is Yahoo's open_nsfw cutoff for nsfw
+				if err != nil {
+					log.Infow(r.Context(), "fetch failed", log.Fields{"fetcher": "images", "error": err})
+				}
+
+				f.cachePut(r.Context(), key, ir, f.Cache.Search)
+
+				return ir, nil
+			})
+
+			ir := v.(*img.Results)
+			if shared && ir != nil { // we add the thumbnails to the images for this user
+				ir = ir.Copy()
 			}
 
 			imageCH <- ir
+		case "local":
+			if d.Context.Shed == ShedUncached {
+				missed <- struct{}{}
+				localCH <- &local.Results{}
+				return
+			}
+			localCH <- f.localResults(r, d, lang, region)
+		case "scholar":
+			sr := f.scholarResults(r.Context(), d, lang, region)
+			if sr == nil {
+				missed <- struct{}{}
+				scholarCH <- &scholar.Results{}
+				return
+			}
+			scholarCH <- sr
+		case "shopping":
+			sr := f.shoppingResults(r.Context(), d, lang, region)
+			if sr == nil {
+				missed <- struct{}{}
+				shoppingCH <- &shopping.Results{}
+				return
+			}
+			shoppingCH <- sr
 		case "maps":
 			resp.template = "maps"
 			channels--
 		default:
-			sc <- f.searchResults(d, lang, region, r.URL)
+			sr := f.searchResults(r, d, lang, region)
+			if sr == nil {
+				missed <- struct{}{}
+				sc <- &search.Results{}
+				return
+			}
+			sr.Syntax = search.Validate(d.Context.Q)
+			if d.Context.Shed < ShedUncached {
+				if related := f.relatedSearches(r.Context(), d, lang, region); related != nil {
+					sr.Related = related
+				}
+			}
+			sc <- sr
 		}
 
-	}(d, d.Context.lang, d.Context.Region)
+	}(d, d.Context.lang, d.Context.Region, track())
 
 	stats := struct {
 		autocomplete time.Duration
+		blend        time.Duration
 		images       time.Duration
 		instant      time.Duration
+		knowledge    time.Duration
+		local        time.Duration
+		questions    time.Duration
+		scholar      time.Duration
 		search       time.Duration
+		shopping     time.Duration
 	}{}
 
 	for i := 0; i < channels; i++ {
 		select {
 		case d.Images = <-imageCH:
-			if d.Images != nil {
-				// fetch the image & convert to base64 for smoother user experience
-				tmp := make(chan *img.Image, len(d.Images.Images))
-
-				go func() {
-					for im := range tmp {
-						for i, o := range d.Images.Images {
-							if im.ID == o.ID {
-								d.Images.Images[i] = im
-							}
-						}
-					}
-				}()
-
-				var wg sync.WaitGroup
-
+			if d.Images != nil && !f.Tor { // in tor mode our image proxy is offline
+				// our pages lazy load the thumbnails. Only api users who ask get them inlined.
 				for _, im := range d.Images.Images {
-					wg.Add(1)
-					go func(im *img.Image) {
-						var err error
-						im, err = f.fetchImage(im)
-						if err != nil {
-							log.Debug.Println(err)
-						}
-						tmp <- im
-						wg.Done()
-					}(im)
+					im.Thumbnail = thumbnail(im.ID)
 				}
 
-				wg.Wait()
+				if r.FormValue("o") == "json" && d.Context.Shed < ShedThumbnails {
+					f.inlineAPI(r, d.Images)
+				}
 			}
 
 			stats.images = time.Since(strt).Round(time.Millisecond)
+		case d.Blend = <-bc:
+			stats.blend = time.Since(strt).Round(time.Millisecond)
 		case d.Instant = <-ic:
 			if d.Instant.Err != nil {
-				log.Info.Println(d.Instant.Err)
+				log.Infow(r.Context(), "instant answer failed", log.Fields{"error": d.Instant.Err, "type": d.Instant.Type})
 			}
 			stats.instant = time.Since(strt).Round(time.Microsecond)
+		case d.Knowledge = <-kc:
+			stats.knowledge = time.Since(strt).Round(time.Millisecond)
+		case d.Local = <-localCH:
+			stats.local = time.Since(strt).Round(time.Millisecond)
+		case d.Scholar = <-scholarCH:
+			if r.FormValue("o") == "json" { // api users can cite each paper
+				for _, p := range d.Scholar.Papers {
+					p.BibTeX = p.Cite()
+				}
+			}
+			stats.scholar = time.Since(strt).Round(time.Millisecond)
+		case d.Shopping = <-shoppingCH:
+			for _, p := range d.Shopping.Products {
+				if p.Image != "" && !f.Tor { // in tor mode our image proxy is offline
+					p.Thumbnail = thumbnail(p.Image)
+				}
+			}
+			stats.shopping = time.Since(strt).Round(time.Millisecond)
+		case d.Questions = <-qc:
+			stats.questions = time.Since(strt).Round(time.Millisecond)
 		case d.Search = <-sc:
 			for _, doc := range d.Search.Documents {
 				// Truncate Title/Description here so the preserve-worded
@@ -417,7 +746,7 @@ func (f *Frontend) searchHandler(w http.ResponseWriter, r *http.Request) *respon
 		case err := <-ac:
 			switch err {
 			case nil:
-			case errIsNaughty:
+			case errIsNaughty, errIsPII:
 				log.Debug.Println(err)
 			default:
 				log.Info.Println(err)
@@ -426,87 +755,165 @@ func (f *Frontend) searchHandler(w http.ResponseWriter, r *http.Request) *respon
 		case <-r.Context().Done():
 			// TODO: add info on which items took too long...
 			// Perhaps change status code of response so it isn't cached by nginx
-			log.Info.Println(errors.Wrapf(r.Context().Err(), "timeout on retrieving results"))
+			log.Infow(r.Context(), "timeout on retrieving results", log.Fields{"error": r.Context().Err()})
+		}
+	}
+
+	log.Infow(r.Context(), "search", log.Fields{
+		"autocomplete": stats.autocomplete,
+		"blend":        stats.blend,
+		"images":       stats.images,
+		"instant":      stats.instant,
+		"instant_type": d.Instant.Type,
+		"knowledge":    stats.knowledge,
+		"local":        stats.local,
+		"questions":    stats.questions,
+		"scholar":      stats.scholar,
+		"search":       stats.search,
+		"shopping":     stats.shopping,
+		"intent":       d.Context.Intent.Top(),
+		"experiments":  d.Context.Experiments.String(),
+		"vertical":     d.Context.T,
+	})
+
+	select {
+	case <-missed:
+		log.Infow(r.Context(), "shed uncached search", log.Fields{"vertical": d.Context.T})
+		w.Header().Set("Retry-After", strconv.Itoa(int(math.Ceil(f.Shed.RetryAfter.Seconds()))))
+		return &response{
+			status: http.StatusServiceUnavailable,
+			err:    errOverloaded,
 		}
+	default:
 	}
 
-	log.Info.Printf("ac:%v, images: %v, instant (%v):%v, search:%v\n", stats.autocomplete, stats.images, d.Instant.Type, stats.instant, stats.search)
+	f.logQuery(r, d, "", noResults(d), strt)
+	f.countImpression(r, d)
 
-	if r.FormValue("o") == "json" {
-		resp.template = r.FormValue("o")
+	// the knowledge panel supersedes the generic Wikipedia box
+	if d.Knowledge != nil && d.Instant.Type == instant.WikipediaType {
+		d.Instant = instant.Data{}
 	}
 
+	d.Hints = newHints(d)
 	resp.data = d
+
+	// feeds let users subscribe to a query
+	switch o := r.FormValue("o"); o {
+	case "json":
+		resp.template = o
+	case "rss":
+		resp.template, resp.data = o, newRSS(d)
+	case "atom":
+		resp.template, resp.data = o, newAtom(d, now())
+	case "csv", "jsonl":
+		e, err := newExport(d)
+		if err != nil {
+			resp.status, resp.err = http.StatusBadRequest, err
+			return resp
+		}
+		resp.template, resp.data = o, e
+	}
+
 	return resp
 }
 
-func (f *Frontend) searchResults(d data, lang language.Tag, region language.Region, u *url.URL) *search.Results {
-	key := cacheKey("search", lang, region, u)
-
-	v, err := f.Cache.Get(key)
-	if err != nil {
-		log.Info.Println(err)
+// searchResults are nil if we only serve cached results and they aren't cached
+func (f *Frontend) searchResults(r *http.Request, d data, lang language.Tag, region language.Region) *search.Results {
+	item := "search"
+	name, searcher := f.searcher(d.Context.Experiments, d.Context.Ranking)
+	if name != "" { // ranking variants get their own cache
+		item += ":" + name
 	}
 
+	key := cacheKey(item, lang, region, formURL(r))
+
+	v := f.cacheGet(r.Context(), item, key)
 	if v != nil {
 		sr := &search.Results{}
 		if err := json.Unmarshal(v.([]byte), &sr); err != nil {
-			log.Info.Println(err)
+			log.Infow(r.Context(), "cached results are invalid", log.Fields{"key": key, "error": err})
 		}
-		return sr
+		return f.arrange(sr, d)
 	}
 
-	offset := d.Context.Page*d.Context.Number - d.Context.Number
-	sr, err := f.Search.Fetch(d.Context.Q, d.Context.F, lang, region, d.Context.Number, offset)
-	if err != nil {
-		log.Info.Println(err)
-		return &search.Results{}
+	if d.Context.Shed == ShedUncached {
+		return nil
 	}
 
-	if sr.Err != nil {
-		log.Info.Println(sr.Err)
-	}
+	// concurrent searches for the same page share one fetch. We shed by shortening the page, so that's in the key.
+	v, err, shared := f.Coalesce.Do(fmt.Sprintf("%v::%d", key, d.Context.Number), func() (interface{}, error) {
+		strt := time.Now()
+		sr, err := fetch(searcher, d, lang, region)
+		f.Shed.observe(time.Since(strt))
+		if err != nil {
+			return nil, err
+		}
 
-	sr = sr.AddPagination(d.Context.Number, d.Context.Page) // move this to javascript??? (Wouldn't be available in API....)
+		if sr.Err != nil {
+			log.Infow(r.Context(), "search failed", log.Fields{"searcher": name, "error": sr.Err})
+		}
 
-	if err := f.Cache.Put(key, sr, f.Cache.Search); err != nil {
-		log.Info.Println(err)
-	}
+		log.Debugw(r.Context(), "search fetched", log.Fields{"searcher": name, "hits": sr.Count, "latency": time.Since(strt)})
 
-	return sr
-}
+		sr = sr.Detrack().AddPagination(d.Context.Number, d.Context.Page) // move this to javascript??? (Wouldn't be available in API....)
+		if sr.Cursor != "" || d.Context.Cursor != "" {
+			sr = sr.Shallow(d.Context.Number, d.Context.Page)
+		}
 
-// fetchImage fetches and converts an image to Base64
-func (f *Frontend) fetchImage(i *img.Image) (*img.Image, error) {
-	var err error
+		// a shortened page would be served to everyone asking for the full one
+		if d.Context.Shed < ShedResults {
+			f.cachePut(r.Context(), key, sr, f.Cache.Search)
+		}
 
-	// go through image proxy to resize and cache the image
-	key := hmacKey(i.ID)
-	u := fmt.Sprintf("%v/image/225x,s%v/%v", f.Host, key, strings.Replace(i.ID, "://", ":/", 1))
+		return sr, nil
+	})
 
-	resp, err := f.Images.Client.Get(u)
 	if err != nil {
-		return i, err
+		log.Infow(r.Context(), "search failed", log.Fields{"searcher": name, "error": err})
+		return &search.Results{}
 	}
 
-	defer resp.Body.Close()
-
-	bdy, err := ioutil.ReadAll(resp.Body)
-	if err != nil {
-		return i, err
+	sr := v.(*search.Results)
+	if shared { // arrange changes the documents for this user
+		sr = sr.Copy()
 	}
 
-	i.Base64 = base64.StdEncoding.EncodeToString(bdy)
-	return i, err
+	return f.arrange(sr, d)
 }
 
-func cacheKey(item string, lang language.Tag, region language.Region, u *url.URL) string {
-	// language and region might be different than what is pass as l & r params
-	// ::search::en-US::US::/?q=reverse+%22this%22
-	// ::instant::en-US::US::/?q=reverse+%22this%22
-	return fmt.Sprintf("::%v::%v::%v::%v", item, lang.String(), region.String(), u.String())
+// fetch continues from the cursor of the page before, if we have one, rather than an offset.
+// A cursor we can't use falls back to the offset. The files vertical pages with an offset only.
+func fetch(searcher search.Fetcher, d data, lang language.Tag, region language.Region) (*search.Results, error) {
+	if d.Context.T == "files" {
+		ff, ok := searcher.(search.FileFetcher)
+		if !ok {
+			return &search.Results{}, search.ErrNoFiles
+		}
+		return ff.FetchFiles(d.Context.Query(), d.Context.F, d.Context.FileFilter, lang, region, d.Context.Number, d.Context.Offset())
+	}
+
+	if af, ok := searcher.(search.AfterFetcher); ok && d.Context.Cursor != "" {
+		sr, err := af.FetchAfter(d.Context.Query(), d.Context.F, lang, region, d.Context.Number, d.Context.Cursor)
+		if err != search.ErrInvalidCursor {
+			return sr, err
+		}
+	}
+
+	return searcher.Fetch(d.Context.Query(), d.Context.F, lang, region, d.Context.Number, d.Context.Offset())
 }
 
-var themes = map[string]bool{
-	"night": true,
+// arrange applies the domain preferences, flags or removes the results on our threat blocklists and then
+// collapses the results from hosts with too many of them. It runs after caching so changes to the
+// preferences and blocklists take effect right away.
+func (f *Frontend) arrange(sr *search.Results, d data) *search.Results {
+	var instance search.Preferences
+	if f.Domains != nil {
+		var err error
+		if instance, err = f.Domains.List(); err != nil {
+			log.Info.Println(err)
+		}
+	}
+
+	return sr.Prefer(instance, d.Context.Preferences).Warn(f.Threats.Feed, f.Threats.Filter).Collapse(d.Context.Query(), search.PerHost)
 }
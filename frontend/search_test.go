@@ -5,6 +5,8 @@ import (
 	"net/http"
 	"net/http/httptest"
 	"reflect"
+	"strings"
+	"sync"
 	"testing"
 	"time"
 
@@ -16,6 +18,8 @@ import (
 	"github.com/jivesearch/jivesearch/search"
 	"github.com/jivesearch/jivesearch/search/document"
 	img "github.com/jivesearch/jivesearch/search/image"
+	"github.com/jivesearch/jivesearch/search/threat"
+	"github.com/jivesearch/jivesearch/singleflight"
 	"github.com/spf13/viper"
 	"golang.org/x/text/language"
 )
@@ -174,6 +178,291 @@ func TestDetectLanguage(t *testing.T) {
 	}
 }
 
+func TestRightToLeft(t *testing.T) {
+	for _, c := range []struct {
+		name      string
+		preferred []language.Tag
+		want      bool
+	}{
+		{"none", nil, false},
+		{"english", []language.Tag{language.AmericanEnglish}, false},
+		{"arabic", []language.Tag{language.MustParse("ar-EG")}, true},
+		{"hebrew", []language.Tag{language.MustParse("he-IL"), language.English}, true},
+		{"farsi", []language.Tag{language.Persian}, true},
+		{"only the first counts", []language.Tag{language.German, language.Arabic}, false},
+	} {
+		t.Run(c.name, func(t *testing.T) {
+			if got := rightToLeft(c.preferred); got != c.want {
+				t.Fatalf("got %v; want %v", got, c.want)
+			}
+		})
+	}
+}
+
+func TestFeelingLucky(t *testing.T) {
+	for _, c := range []struct {
+		q     string
+		want  string
+		lucky bool
+	}{
+		{"!! golang slice", "golang slice", true},
+		{"golang slice !!", "golang slice", true},
+		{"golang !! slice", "golang slice", true},
+		{"! golang slice", "golang slice", true},
+		{"golang slice !", "golang slice", true},
+		{`\golang slice`, "golang slice", true},
+		{`\ golang slice`, "golang slice", true},
+		{"golang ! slice", "golang ! slice", false},
+		{"golang slice", "golang slice", false},
+		{"!", "!", false},
+		{"", "", false},
+	} {
+		t.Run(c.q, func(t *testing.T) {
+			got, lucky := feelingLucky(c.q)
+			if got != c.want || lucky != c.lucky {
+				t.Fatalf("got %q, %v; want %q, %v", got, lucky, c.want, c.lucky)
+			}
+		})
+	}
+}
+
+type mockLuckySearch struct {
+	res *search.Results
+}
+
+func (s *mockLuckySearch) Fetch(q string, f search.Filter, lang language.Tag, region language.Region, page int, number int) (*search.Results, error) {
+	return s.res, nil
+}
+
+func TestLuckyResult(t *testing.T) {
+	for _, c := range []struct {
+		name    string
+		t       string
+		q       string
+		res     *search.Results
+		malware string
+		want    string
+		ok      bool
+	}{
+		{"first result", "", "example", mockSearchResults, "", "https://example.com", true},
+		{"no results", "", "example", &search.Results{}, "", "", false},
+		{"images", "images", "example", mockSearchResults, "", "", false},
+		{"empty query", "", " ", mockSearchResults, "", "", false},
+		{
+			"relaxed", "", "example", &search.Results{
+				Relaxed:   "exampl",
+				Documents: mockSearchResults.Documents,
+			}, "", "", false,
+		},
+		{
+			"not a web page", "", "example", &search.Results{
+				Documents: []*document.Document{{ID: "javascript:alert(1)"}},
+			}, "", "", false,
+		},
+		{
+			"malware", "", "example", &search.Results{
+				Documents: []*document.Document{{ID: "https://malware.example.com/"}},
+			}, "example.com", "", false,
+		},
+	} {
+		t.Run(c.name, func(t *testing.T) {
+			f := &Frontend{
+				Search: &mockLuckySearch{res: c.res},
+			}
+			f.Cache.Cacher = &mockCacher{}
+
+			if c.malware != "" {
+				f.Threats.Feed = threat.New()
+				if err := f.Threats.Load(threat.Malware, strings.NewReader(c.malware)); err != nil {
+					t.Fatal(err)
+				}
+			}
+
+			req, err := http.NewRequest("GET", "/?q=!!+example", nil)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			d := data{
+				Context: &Context{Q: "!! example", T: c.t, Number: 25, Page: 1},
+			}
+
+			got, ok := f.luckyResult(req, d, c.q)
+			if got != c.want || ok != c.ok {
+				t.Fatalf("got %q, %v; want %q, %v", got, ok, c.want, c.ok)
+			}
+
+			if d.Context.Q != "!! example" {
+				t.Fatalf("the query was changed to %q", d.Context.Q)
+			}
+		})
+	}
+}
+
+// mockSlowSearch counts its fetches, which wait until released
+type mockSlowSearch struct {
+	sync.Mutex
+	fetches int
+	release chan struct{}
+}
+
+func (s *mockSlowSearch) Fetch(q string, f search.Filter, lang language.Tag, region language.Region, page int, number int) (*search.Results, error) {
+	s.Lock()
+	s.fetches++
+	s.Unlock()
+
+	<-s.release
+	return &search.Results{Documents: []*document.Document{{ID: "https://example.com"}}}, nil
+}
+
+func TestSearchResultsCoalesce(t *testing.T) {
+	s := &mockSlowSearch{release: make(chan struct{})}
+
+	f := &Frontend{
+		Coalesce: &singleflight.Group{},
+		Search:   s,
+	}
+	f.Cache.Cacher = &mockCacher{}
+
+	results := make(chan *search.Results, 5)
+
+	var wg sync.WaitGroup
+	for i := 0; i < cap(results); i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			r := httptest.NewRequest("GET", "/?q=jimi+hendrix", nil)
+			d := data{
+				Context: &Context{Q: "jimi hendrix", Number: 25, Page: 1},
+			}
+			results <- f.searchResults(r, d, language.English, language.MustParseRegion("US"))
+		}()
+	}
+
+	time.Sleep(50 * time.Millisecond) // let them all ask for it
+	close(s.release)
+	wg.Wait()
+	close(results)
+
+	if s.fetches != 1 {
+		t.Fatalf("got %d fetches; want 1", s.fetches)
+	}
+
+	docs := map[*document.Document]bool{}
+	for sr := range results {
+		if len(sr.Documents) != 1 || sr.Documents[0].ID != "https://example.com" {
+			t.Fatalf("got %+v; want the shared results", sr.Documents)
+		}
+		docs[sr.Documents[0]] = true
+	}
+
+	if len(docs) != cap(results) {
+		t.Fatalf("got %d distinct documents; want each search to have its own copy", len(docs))
+	}
+}
+
+type mockAfterSearch struct {
+	offset int
+	cursor string
+}
+
+func (s *mockAfterSearch) Fetch(q string, f search.Filter, lang language.Tag, region language.Region, number int, offset int) (*search.Results, error) {
+	s.offset = offset
+	return &search.Results{}, nil
+}
+
+func (s *mockAfterSearch) FetchAfter(q string, f search.Filter, lang language.Tag, region language.Region, number int, cursor string) (*search.Results, error) {
+	if cursor != "valid" {
+		return &search.Results{}, search.ErrInvalidCursor
+	}
+
+	s.cursor = cursor
+	return &search.Results{}, nil
+}
+
+func TestFetch(t *testing.T) {
+	for _, c := range []struct {
+		name   string
+		cursor string
+		offset int
+		want   string
+	}{
+		{"offset", "", 50, ""},
+		{"cursor", "valid", -1, "valid"},
+		{"invalid cursor", "nope", 50, ""},
+	} {
+		t.Run(c.name, func(t *testing.T) {
+			s := &mockAfterSearch{offset: -1}
+			d := data{
+				Context: &Context{Q: "jive", Number: 25, Page: 3, Cursor: c.cursor},
+			}
+
+			if _, err := fetch(s, d, language.English, language.MustParseRegion("US")); err != nil {
+				t.Fatal(err)
+			}
+
+			if s.offset != c.offset || s.cursor != c.want {
+				t.Fatalf("got offset %d, cursor %q; want %d, %q", s.offset, s.cursor, c.offset, c.want)
+			}
+		})
+	}
+}
+
+type mockFileSearch struct {
+	mockSearch
+	files search.FileFilter
+}
+
+func (s *mockFileSearch) FetchFiles(q string, f search.Filter, files search.FileFilter, lang language.Tag, region language.Region, number int, offset int) (*search.Results, error) {
+	s.files = files
+	return &search.Results{
+		Count: 1,
+		Documents: []*document.Document{
+			{ID: "https://example.com/report.pdf", Content: document.Content{Title: "Annual Report", FileType: "pdf"}},
+		},
+		Facets: &search.Facets{
+			Type: []search.Facet{{Value: "pdf", Count: 4}, {Value: "csv", Count: 2}},
+			Size: []search.Facet{{Value: "small", Count: 6}},
+			Date: []search.Facet{{Value: "week", Count: 1}},
+		},
+	}, nil
+}
+
+func TestSearchFiles(t *testing.T) {
+	f := mapsFrontend(t)
+	f.Instant = &instant.Instant{}
+	s := &mockFileSearch{}
+	f.Search = s
+	f.Suggest = &mockSuggester{}
+	ParseTemplates()
+
+	r := httptest.NewRequest("GET", "/?l=en&q=annual+report&t=files&filetype=PDF&filesize=huge&filedate=week", nil)
+	r.Header.Set("Accept-Language", "en")
+
+	resp := f.searchHandler(httptest.NewRecorder(), r)
+
+	if want := (search.FileFilter{Type: "pdf", Date: "week"}); s.files != want {
+		t.Fatalf("got file filter %+v; want %+v", s.files, want)
+	}
+
+	if got := resp.data.(data).Search.Facets; got == nil || len(got.Type) != 2 {
+		t.Fatalf("got facets %+v; want the facets of the searcher", got)
+	}
+
+	w := httptest.NewRecorder()
+	appHandler(func(w http.ResponseWriter, r *http.Request) *response { return resp }).ServeHTTP(w, r)
+
+	for _, want := range []string{`<option value="pdf" selected>pdf (4)</option>`, `<option value="week" selected>Past week (1)</option>`} {
+		if !strings.Contains(w.Body.String(), want) {
+			t.Fatalf("the page doesn't have %s", want)
+		}
+	}
+
+	if _, err := fetch(&mockAfterSearch{}, data{Context: &Context{Q: "jive", T: "files"}}, language.English, language.MustParseRegion("US")); err != search.ErrNoFiles {
+		t.Fatalf("got err %v; want %v", err, search.ErrNoFiles)
+	}
+}
+
 func TestDetectRegion(t *testing.T) {
 	for _, c := range []struct {
 		name string
@@ -193,9 +482,18 @@ func TestDetectRegion(t *testing.T) {
 		{
 			"param overrides language's region", language.CanadianFrench, "gb", language.MustParseRegion("GB").Canonicalize(),
 		},
+		{
+			"region from ip", language.English, "", language.MustParseRegion("DE").Canonicalize(),
+		},
+		{
+			"language's region overrides ip", language.BrazilianPortuguese, "", language.MustParseRegion("BR").Canonicalize(),
+		},
 	} {
 		t.Run(c.name, func(t *testing.T) {
 			f := &Frontend{}
+			if c.lang != (language.Tag{}) {
+				f.RegionFetcher = &mockLocationFetcher{}
+			}
 
 			req, err := http.NewRequest("GET", "/", nil)
 			if err != nil {
@@ -246,8 +544,9 @@ func TestSearchHandler(t *testing.T) {
 				data: data{
 					Brand: Brand{},
 					Context: &Context{
-						F:    search.Moderate,
-						Safe: true,
+						F:         search.Moderate,
+						Safe:      true,
+						Preferred: []language.Tag{language.MustParse("en")},
 					},
 				},
 			},
@@ -273,6 +572,9 @@ func TestSearchHandler(t *testing.T) {
 						Theme:        "night",
 					},
 					Results: Results{
+						Hints: &Hints{
+							Count: 25, First: 1, Last: 2, Announce: "Results 1 to 2 of 25", Keys: keys,
+						},
 						Instant: mockInstantAnswer,
 						Search:  mockSearchResults,
 					},
@@ -299,6 +601,9 @@ func TestSearchHandler(t *testing.T) {
 						Safe:         true,
 					},
 					Results: Results{
+						Hints: &Hints{
+							Count: 25, First: 1, Last: 2, Announce: "Results 1 to 2 of 25", Keys: keys,
+						},
 						Instant: mockInstantAnswer,
 						Search:  mockSearchResults,
 					},
@@ -325,6 +630,9 @@ func TestSearchHandler(t *testing.T) {
 						Safe:         true,
 					},
 					Results: Results{
+						Hints: &Hints{
+							Count: 25, First: 1, Last: 2, Announce: "Results 1 to 2 of 25", Keys: keys,
+						},
 						Instant: mockInstantAnswer,
 						Search:  mockSearchResults,
 					},
@@ -359,6 +667,7 @@ func TestSearchHandler(t *testing.T) {
 						T:            "images",
 					},
 					Results: Results{
+						Hints:  &Hints{Announce: "No results for some query", Keys: keys},
 						Images: mockImageResults,
 						Search: &search.Results{},
 					},
@@ -428,6 +737,108 @@ func TestSearchHandler(t *testing.T) {
 	}
 }
 
+func TestLiteHandler(t *testing.T) {
+	bngs, err := bangsFromConfig()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	matcher := language.NewMatcher([]language.Tag{language.English})
+
+	f := &Frontend{
+		Document: Document{
+			Matcher: matcher,
+		},
+		Bangs: bngs,
+		Instant: &instant.Instant{
+			WikipediaFetcher:     &mockWikipediaFetcher{},
+			StackOverflowFetcher: &mockStackOverflowFetcher{},
+		},
+		Suggest: &mockSuggester{},
+		Search:  &mockSearch{},
+		Wikipedia: Wikipedia{
+			Matcher: matcher,
+		},
+	}
+
+	f.Cache.Cacher = &mockCacher{}
+	ParseTemplates()
+
+	// the vertical is ignored as we only have web results
+	r := httptest.NewRequest("POST", "/lite", strings.NewReader("q=jimi+hendrix&t=images"))
+	r.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+
+	resp := f.liteHandler(httptest.NewRecorder(), r)
+	if resp.template != "lite" {
+		t.Fatalf("got template %q; want %q", resp.template, "lite")
+	}
+
+	d := resp.data.(data)
+	if !d.Context.Lite || d.Context.T != "" {
+		t.Fatalf("got lite %v and vertical %q; want the lite web results", d.Context.Lite, d.Context.T)
+	}
+
+	if d.Instant.Type != "" || d.Knowledge != nil || d.Blend != nil || d.Questions != nil {
+		t.Fatalf("got %+v; want only web results", d.Results)
+	}
+
+	w := httptest.NewRecorder()
+	appHandler(func(w http.ResponseWriter, r *http.Request) *response { return resp }).ServeHTTP(w, r)
+
+	body := w.Body.String()
+	for _, s := range []string{"<script", "base64", "/static/"} {
+		if strings.Contains(body, s) {
+			t.Fatalf("lite page has %q", s)
+		}
+	}
+
+	for _, doc := range d.Search.Documents {
+		if !strings.Contains(body, doc.ID) {
+			t.Fatalf("lite page is missing %v", doc.ID)
+		}
+	}
+}
+
+func TestSearchSyntax(t *testing.T) {
+	bngs, err := bangsFromConfig()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	matcher := language.NewMatcher([]language.Tag{language.English})
+
+	f := &Frontend{
+		Document: Document{
+			Matcher: matcher,
+		},
+		Bangs:   bngs,
+		Instant: &instant.Instant{},
+		Suggest: &mockSuggester{},
+		Search:  &mockSearch{},
+		Wikipedia: Wikipedia{
+			Matcher: matcher,
+		},
+	}
+
+	f.Cache.Cacher = &mockCacher{}
+	ParseTemplates()
+
+	r := httptest.NewRequest("GET", "/lite?q=intitle:hendrix", nil)
+	resp := f.liteHandler(httptest.NewRecorder(), r)
+
+	want := []search.QueryError{{Term: "intitle:hendrix", Hint: "intitle: isn't an operator we support so it was searched for as a word"}}
+	if got := resp.data.(data).Search.Syntax; !reflect.DeepEqual(got, want) {
+		t.Fatalf("got %+v; want %+v", got, want)
+	}
+
+	w := httptest.NewRecorder()
+	appHandler(func(w http.ResponseWriter, r *http.Request) *response { return resp }).ServeHTTP(w, r)
+
+	if !strings.Contains(w.Body.String(), "intitle: isn&#39;t an operator we support") {
+		t.Fatalf("the hint isn't on the page")
+	}
+}
+
 type mockSearch struct{}
 
 func (s *mockSearch) Fetch(q string, f search.Filter, lang language.Tag, region language.Region, page int, number int) (*search.Results, error) {
@@ -436,7 +847,15 @@ func (s *mockSearch) Fetch(q string, f search.Filter, lang language.Tag, region
 
 type mockImages struct{}
 
-func (i *mockImages) Fetch(q string, safe bool, number int, offset int) (*img.Results, error) {
+func (i *mockImages) Fetch(q string, safe bool, f img.Filter, number int, offset int) (*img.Results, error) {
+	return mockImageResults, nil
+}
+
+func (i *mockImages) FetchAfter(q string, safe bool, f img.Filter, number int, cursor string) (*img.Results, error) {
+	if cursor != "next" {
+		return &img.Results{}, img.ErrInvalidCursor
+	}
+
 	return mockImageResults, nil
 }
 
@@ -505,7 +924,7 @@ var mockBirthstoneInstantAnswer = instant.Data{
 var mockCalculatorInstantAnswer = instant.Data{
 	Type:      "calculator",
 	Triggered: true,
-	Solution:  "4",
+	Solution:  &instant.CalculatorResponse{Expression: "2 + 2", Result: "4", Exact: true},
 	Err:       nil,
 }
 
@@ -538,3 +957,28 @@ var mockImageResults = &img.Results{
 	Pagination: []string{"1"},
 	Images:     []*img.Image{},
 }
+
+func TestPost(t *testing.T) {
+	for _, c := range []struct {
+		name     string
+		method   string
+		target   string
+		fallback bool
+		want     bool
+	}{
+		{"get", "GET", "/?q=jimi+hendrix", false, false},
+		{"get with our default", "GET", "/?q=jimi+hendrix", true, true},
+		{"get asking for posts", "GET", "/?q=jimi+hendrix&post=true", false, true},
+		{"get asking for links", "GET", "/?q=jimi+hendrix&post=false", true, false},
+		{"post", "POST", "/search", false, true},
+	} {
+		t.Run(c.name, func(t *testing.T) {
+			f := &Frontend{POST: c.fallback}
+			r := httptest.NewRequest(c.method, c.target, nil)
+
+			if got := f.post(r); got != c.want {
+				t.Fatalf("got %v; want %v", got, c.want)
+			}
+		})
+	}
+}
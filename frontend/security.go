@@ -0,0 +1,75 @@
+package frontend
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/base64"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/jivesearch/jivesearch/log"
+)
+
+// Security holds the headers that protect our pages. Any left empty aren't sent.
+type Security struct {
+	CSP        string        // Content-Security-Policy. Each response replaces {nonce} with its own nonce.
+	ReportOnly bool          // report violations of the CSP rather than block them
+	HSTS       time.Duration // max-age of Strict-Transport-Security
+	Frame      string        // X-Frame-Options
+	Referrer   string        // Referrer-Policy
+}
+
+const nonceContext contextKey = "nonce"
+
+// secure sets our security headers. The nonce in the CSP is what lets
+// the inline scripts of our templates run while any others are blocked.
+func (f *Frontend) secure(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		s := f.Security
+		h := w.Header()
+
+		h.Set("X-Content-Type-Options", "nosniff")
+
+		if s.HSTS > 0 {
+			h.Set("Strict-Transport-Security", fmt.Sprintf("max-age=%d; includeSubDomains", int(s.HSTS.Seconds())))
+		}
+
+		if s.Frame != "" {
+			h.Set("X-Frame-Options", s.Frame)
+		}
+
+		if s.Referrer != "" {
+			h.Set("Referrer-Policy", s.Referrer)
+		}
+
+		if s.CSP != "" {
+			n := newNonce()
+
+			header := "Content-Security-Policy"
+			if s.ReportOnly {
+				header += "-Report-Only"
+			}
+
+			h.Set(header, strings.Replace(s.CSP, "{nonce}", n, -1))
+			r = r.WithContext(context.WithValue(r.Context(), nonceContext, n))
+		}
+
+		next.ServeHTTP(w, r)
+	})
+}
+
+// nonce is for the inline scripts of the page we are rendering
+func nonce(r *http.Request) string {
+	n, _ := r.Context().Value(nonceContext).(string)
+	return n
+}
+
+func newNonce() string {
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		log.Info.Println(err)
+	}
+	return base64.StdEncoding.EncodeToString(b)
+}
@@ -0,0 +1,108 @@
+package frontend
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestSecure(t *testing.T) {
+	for _, c := range []struct {
+		name     string
+		security Security
+		header   string
+		want     map[string]string
+	}{
+		{
+			name: "all",
+			security: Security{
+				CSP:      "default-src 'self'; script-src 'self' 'nonce-{nonce}'",
+				HSTS:     24 * time.Hour,
+				Frame:    "SAMEORIGIN",
+				Referrer: "strict-origin",
+			},
+			header: "Content-Security-Policy",
+			want: map[string]string{
+				"Strict-Transport-Security": "max-age=86400; includeSubDomains",
+				"X-Content-Type-Options":    "nosniff",
+				"X-Frame-Options":           "SAMEORIGIN",
+				"Referrer-Policy":           "strict-origin",
+			},
+		},
+		{
+			name: "report only",
+			security: Security{
+				CSP:        "script-src 'nonce-{nonce}'",
+				ReportOnly: true,
+			},
+			header: "Content-Security-Policy-Report-Only",
+			want: map[string]string{
+				"Content-Security-Policy":   "",
+				"Strict-Transport-Security": "",
+				"X-Content-Type-Options":    "nosniff",
+			},
+		},
+		{
+			name:     "no csp",
+			security: Security{},
+			want: map[string]string{
+				"Content-Security-Policy": "",
+				"X-Content-Type-Options":  "nosniff",
+			},
+		},
+	} {
+		t.Run(c.name, func(t *testing.T) {
+			f := &Frontend{Security: c.security}
+
+			var n string
+			h := f.secure(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				n = nonce(r)
+			}))
+
+			w := httptest.NewRecorder()
+			h.ServeHTTP(w, httptest.NewRequest("GET", "/", nil))
+
+			for k, v := range c.want {
+				if got := w.Header().Get(k); got != v {
+					t.Fatalf("got %v %q; want %q", k, got, v)
+				}
+			}
+
+			if c.header == "" {
+				if n != "" {
+					t.Fatalf("got nonce %q without a policy", n)
+				}
+				return
+			}
+
+			if n == "" || !strings.Contains(w.Header().Get(c.header), "'nonce-"+n+"'") {
+				t.Fatalf("got %v %q; want nonce %q", c.header, w.Header().Get(c.header), n)
+			}
+		})
+	}
+}
+
+func TestNonceTemplates(t *testing.T) {
+	ParseTemplates()
+
+	d := proxyResponse{
+		Brand:   Brand{Name: "Jive Search"},
+		Context: Context{Nonce: "abc123"},
+	}
+
+	var b strings.Builder
+	if err := templates["proxy"].Execute(&b, d); err != nil {
+		t.Fatal(err)
+	}
+
+	body := b.String()
+	if !strings.Contains(body, `<script nonce="abc123">`) {
+		t.Fatalf("got %v; want our inline script to have the nonce", body)
+	}
+
+	if strings.Contains(body, "onclick=") {
+		t.Fatal("inline event handlers aren't allowed by our policy")
+	}
+}
@@ -0,0 +1,122 @@
+package frontend
+
+import (
+	"fmt"
+	"runtime"
+	"sync"
+	"time"
+)
+
+// Shed is how much of a search we skip to keep up when we are overloaded.
+// Each level skips what the levels before it do as well.
+type Shed int
+
+const (
+	// ShedNone serves the full search
+	ShedNone Shed = iota
+	// ShedThumbnails skips the base64 thumbnails api users ask for
+	ShedThumbnails
+	// ShedInstant skips instant answers
+	ShedInstant
+	// ShedResults serves fewer results per page
+	ShedResults
+	// ShedUncached only serves what we have cached and the knowledge panel, blend
+	// and related questions are skipped. Anything not in our cache is a 503.
+	ShedUncached
+)
+
+var errOverloaded = fmt.Errorf("overloaded")
+
+// Shedder is an adaptive concurrency limiter for our searches. Its limit grows by one
+// for each search our backend answers within Latency and shrinks by a tenth for each it doesn't.
+// The closer the searches in flight come to the limit (or our goroutines to Goroutines)
+// the more we shed.
+type Shedder struct {
+	Latency    time.Duration // the backend latency we aim for. Without it nothing is shed.
+	Min        int           // the concurrency limit never drops below Min...
+	Max        int           // ...or grows above Max
+	Goroutines int           // optional
+	Number     int           // the results per page from ShedResults
+	RetryAfter time.Duration // for the searches we can't serve
+	mu         sync.Mutex
+	limit      float64
+	inflight   int
+}
+
+// acquire counts a search in flight and returns how much of it to shed.
+// The func it returns must be called once the search is served.
+func (s *Shedder) acquire() (Shed, func()) {
+	if s == nil || s.Latency <= 0 {
+		return ShedNone, func() {}
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.inflight++
+	load := float64(s.inflight) / s.current()
+
+	if s.Goroutines > 0 {
+		if g := float64(runtime.NumGoroutine()) / float64(s.Goroutines); g > load {
+			load = g
+		}
+	}
+
+	release := func() {
+		s.mu.Lock()
+		s.inflight--
+		s.mu.Unlock()
+	}
+
+	switch {
+	case load > 1:
+		return ShedUncached, release
+	case load > .9:
+		return ShedResults, release
+	case load > .75:
+		return ShedInstant, release
+	case load > .5:
+		return ShedThumbnails, release
+	default:
+		return ShedNone, release
+	}
+}
+
+// observe adjusts the concurrency limit to how long our backend took
+func (s *Shedder) observe(latency time.Duration) {
+	if s == nil || s.Latency <= 0 {
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	limit := s.current()
+	switch {
+	case latency > s.Latency:
+		limit *= .9
+	default:
+		limit++
+	}
+
+	s.limit = limit
+	if min := float64(s.Min); s.limit < min {
+		s.limit = min
+	}
+	if max := float64(s.Max); s.Max > 0 && s.limit > max {
+		s.limit = max
+	}
+}
+
+// current is the concurrency limit, which starts at Max
+func (s *Shedder) current() float64 {
+	if s.limit == 0 {
+		s.limit = float64(s.Max)
+	}
+
+	if s.limit < 1 {
+		s.limit = 1
+	}
+
+	return s.limit
+}
@@ -0,0 +1,161 @@
+package frontend
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/jivesearch/jivesearch/instant"
+	"golang.org/x/text/language"
+)
+
+func TestShedderAcquire(t *testing.T) {
+	var s *Shedder
+	if got, release := s.acquire(); got != ShedNone {
+		t.Fatalf("got %v; want nothing shed without a Shedder", got)
+	} else {
+		release()
+	}
+
+	s = &Shedder{Latency: time.Second, Min: 1, Max: 10}
+
+	want := []Shed{
+		ShedNone, ShedNone, ShedNone, ShedNone, ShedNone,
+		ShedThumbnails, ShedThumbnails,
+		ShedInstant, ShedInstant,
+		ShedResults,
+		ShedUncached,
+	}
+
+	got := []Shed{}
+	releases := []func(){}
+	for range want {
+		shed, release := s.acquire()
+		got = append(got, shed)
+		releases = append(releases, release)
+	}
+
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("got %v; want %v", got, want)
+	}
+
+	for _, release := range releases {
+		release()
+	}
+
+	if shed, _ := s.acquire(); shed != ShedNone {
+		t.Fatalf("got %v after the searches finished; want %v", shed, ShedNone)
+	}
+}
+
+func TestShedderObserve(t *testing.T) {
+	s := &Shedder{Latency: time.Second, Min: 2, Max: 10}
+
+	for _, c := range []struct {
+		latency time.Duration
+		times   int
+		want    float64
+	}{
+		{100 * time.Millisecond, 1, 10}, // we start at Max
+		{2 * time.Second, 1, 9},
+		{2 * time.Second, 50, 2},
+		{time.Second, 3, 5},
+		{100 * time.Millisecond, 20, 10},
+	} {
+		for i := 0; i < c.times; i++ {
+			s.observe(c.latency)
+		}
+
+		if s.limit != c.want {
+			t.Fatalf("after %v x %v got a limit of %v; want %v", c.times, c.latency, s.limit, c.want)
+		}
+	}
+}
+
+func TestSearchShed(t *testing.T) {
+	bngs, err := bangsFromConfig()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	matcher := language.NewMatcher([]language.Tag{language.English})
+
+	f := &Frontend{
+		Document: Document{
+			Matcher: matcher,
+		},
+		Bangs: bngs,
+		Instant: &instant.Instant{
+			WikipediaFetcher:     &mockWikipediaFetcher{},
+			StackOverflowFetcher: &mockStackOverflowFetcher{},
+		},
+		Shed:    &Shedder{Latency: time.Second, Min: 1, Max: 1, Number: 10, RetryAfter: 5 * time.Second},
+		Suggest: &mockSuggester{},
+		Search:  &mockSearch{},
+		Wikipedia: Wikipedia{
+			Matcher: matcher,
+		},
+	}
+
+	f.Cache.Cacher = &shedCacher{key: "::search::en::US::/?l=en&q=some+query"}
+
+	// another search is using up our limit
+	_, release := f.Shed.acquire()
+	defer release()
+
+	for _, c := range []struct {
+		name   string
+		u      string
+		status int
+		retry  string
+	}{
+		{"cached", "/?l=en&o=json&q=some+query", http.StatusOK, ""},
+		{"uncached", "/?l=en&o=json&q=jimi+hendrix", http.StatusServiceUnavailable, "5"},
+		{"uncached images", "/?l=en&o=json&q=jimi+hendrix&t=images", http.StatusServiceUnavailable, "5"},
+	} {
+		t.Run(c.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			r := httptest.NewRequest("GET", c.u, nil)
+			r.Header.Set("Accept-Language", "en")
+
+			resp := f.searchHandler(w, r)
+			if resp.status != c.status {
+				t.Fatalf("got status %v; want %v", resp.status, c.status)
+			}
+
+			if got := w.Header().Get("Retry-After"); got != c.retry {
+				t.Fatalf("got Retry-After %q; want %q", got, c.retry)
+			}
+
+			if c.status != http.StatusOK {
+				return
+			}
+
+			d := resp.data.(data)
+			if d.Context.Shed != ShedUncached || d.Context.Number != 10 {
+				t.Fatalf("got shed %v and %d results; want %v and 10", d.Context.Shed, d.Context.Number, ShedUncached)
+			}
+
+			if d.Instant.Type != "" || d.Knowledge != nil || d.Blend != nil || len(d.Search.Documents) == 0 {
+				t.Fatalf("got %+v; want only our cached web results", d.Results)
+			}
+		})
+	}
+}
+
+// shedCacher only has the web results of one search
+type shedCacher struct {
+	mockCacher
+	key string
+}
+
+func (c *shedCacher) Get(key string) (interface{}, error) {
+	if key != c.key {
+		return nil, nil
+	}
+
+	return json.Marshal(mockSearchResults)
+}
@@ -0,0 +1,92 @@
+package frontend
+
+import (
+	"context"
+	"encoding/json"
+	"net/url"
+
+	"github.com/jivesearch/jivesearch/instant/currency"
+	"github.com/jivesearch/jivesearch/log"
+	"github.com/jivesearch/jivesearch/search/shopping"
+	"golang.org/x/text/language"
+)
+
+// maxShopping is the most products we'll show
+const maxShopping = 50
+
+// fxKey is where we cache the exchange rates we convert prices with
+const fxKey = "::fx::rates"
+
+// shoppingResults finds products for sale. We cache what the provider sent us and
+// convert, filter and sort it for each user.
+func (f *Frontend) shoppingResults(ctx context.Context, d data, lang language.Tag, region language.Region) *shopping.Results {
+	if f.Shopping == nil {
+		return &shopping.Results{}
+	}
+
+	u := &url.URL{
+		Path:     "/",
+		RawQuery: url.Values{"q": {d.Context.Q}}.Encode(),
+	}
+	key := cacheKey("shopping", lang, region, u)
+
+	v := f.cacheGet(ctx, "shopping", key)
+
+	sr := &shopping.Results{}
+
+	switch v {
+	case nil:
+		if d.Context.Shed == ShedUncached {
+			return nil
+		}
+
+		var err error
+		if sr, err = f.Shopping.Fetch(d.Context.Q, region, maxShopping); err != nil {
+			log.Infow(ctx, "fetch failed", log.Fields{"fetcher": "shopping", "error": err})
+			return &shopping.Results{}
+		}
+
+		f.cachePut(ctx, key, sr, f.Cache.Search)
+	default:
+		if err := json.Unmarshal(v.([]byte), sr); err != nil {
+			log.Info.Println(err)
+		}
+	}
+
+	var fx *currency.Response
+	if d.Context.ShoppingFilter.Currency != "" {
+		fx = f.rates(ctx)
+	}
+
+	return sr.Apply(d.Context.ShoppingFilter, fx)
+}
+
+// rates are the latest exchange rates, or nil if we can't get them
+func (f *Frontend) rates(ctx context.Context) *currency.Response {
+	if f.Instant == nil || f.FXFetcher == nil {
+		return nil
+	}
+
+	v := f.cacheGet(ctx, "fx", fxKey)
+
+	if v != nil {
+		fx := &currency.Response{}
+		if err := json.Unmarshal(v.([]byte), fx); err != nil {
+			log.Info.Println(err)
+			return nil
+		}
+		return fx
+	}
+
+	fx, err := f.FXFetcher.Fetch()
+	if err != nil {
+		log.Infow(ctx, "fetch failed", log.Fields{"fetcher": "fx", "error": err})
+		return nil
+	}
+
+	fx.Sort()
+
+	f.cachePut(ctx, fxKey, fx, f.Cache.Instant)
+
+	return fx
+}
@@ -0,0 +1,137 @@
+package frontend
+
+import (
+	"context"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/jivesearch/jivesearch/instant"
+	"github.com/jivesearch/jivesearch/instant/currency"
+	"github.com/jivesearch/jivesearch/search/shopping"
+	"golang.org/x/text/language"
+)
+
+func TestShoppingResults(t *testing.T) {
+	for _, c := range []struct {
+		name string
+		u    string
+		want []*shopping.Product
+	}{
+		{
+			"relevance",
+			"/?l=en&o=json&q=stratocaster&t=shopping",
+			[]*shopping.Product{
+				{
+					ID:        "https://www.ebay.com/itm/1",
+					Title:     "stratocaster 1",
+					Image:     "https://i.ebayimg.com/1.jpg",
+					Thumbnail: thumbnail("https://i.ebayimg.com/1.jpg"),
+					Price:     shopping.Price{Amount: 100, Currency: "EUR"},
+				},
+				{
+					ID:    "https://www.ebay.com/itm/2",
+					Title: "stratocaster 2",
+					Price: shopping.Price{Amount: 80, Currency: "USD"},
+				},
+			},
+		},
+		{
+			"cheapest in usd",
+			"/?currency=usd&l=en&o=json&q=stratocaster&sort=price_asc&t=shopping",
+			[]*shopping.Product{
+				{
+					ID:    "https://www.ebay.com/itm/2",
+					Title: "stratocaster 2",
+					Price: shopping.Price{Amount: 80, Currency: "USD"},
+				},
+				{
+					ID:        "https://www.ebay.com/itm/1",
+					Title:     "stratocaster 1",
+					Image:     "https://i.ebayimg.com/1.jpg",
+					Thumbnail: thumbnail("https://i.ebayimg.com/1.jpg"),
+					Price:     shopping.Price{Amount: 125, Currency: "USD"},
+					Original:  &shopping.Price{Amount: 100, Currency: "EUR"},
+				},
+			},
+		},
+		{
+			"over 100 usd",
+			"/?currency=USD&l=en&min=100&o=json&q=stratocaster&t=shopping",
+			[]*shopping.Product{
+				{
+					ID:        "https://www.ebay.com/itm/1",
+					Title:     "stratocaster 1",
+					Image:     "https://i.ebayimg.com/1.jpg",
+					Thumbnail: thumbnail("https://i.ebayimg.com/1.jpg"),
+					Price:     shopping.Price{Amount: 125, Currency: "USD"},
+					Original:  &shopping.Price{Amount: 100, Currency: "EUR"},
+				},
+			},
+		},
+	} {
+		t.Run(c.name, func(t *testing.T) {
+			f := mapsFrontend(t)
+			f.Instant = &instant.Instant{}
+			f.FXFetcher = &mockFXFetcher{}
+			f.Search = &mockSearch{}
+			f.Shopping = &mockShoppingFetcher{}
+			f.Suggest = &mockSuggester{}
+
+			r := httptest.NewRequest("GET", c.u, nil)
+			r.Header.Set("Accept-Language", "en")
+
+			resp := f.searchHandler(httptest.NewRecorder(), r)
+			got := resp.data.(data).Shopping
+
+			want := &shopping.Results{Provider: shopping.EBayProvider, Products: c.want}
+			if !reflect.DeepEqual(got, want) {
+				t.Fatalf("got %+v; want %+v", got, want)
+			}
+		})
+	}
+}
+
+func TestShoppingResultsNoFetcher(t *testing.T) {
+	f := mapsFrontend(t)
+
+	d := data{Context: &Context{Q: "stratocaster"}}
+	got := f.shoppingResults(context.Background(), d, language.English, language.MustParseRegion("US"))
+
+	if !reflect.DeepEqual(got, &shopping.Results{}) {
+		t.Fatalf("got %+v; want no products", got)
+	}
+}
+
+type mockShoppingFetcher struct{}
+
+func (m *mockShoppingFetcher) Fetch(q string, region language.Region, number int) (*shopping.Results, error) {
+	return &shopping.Results{
+		Provider: shopping.EBayProvider,
+		Products: []*shopping.Product{
+			{
+				ID:    "https://www.ebay.com/itm/1",
+				Title: q + " 1",
+				Image: "https://i.ebayimg.com/1.jpg",
+				Price: shopping.Price{Amount: 100, Currency: "EUR"},
+			},
+			{
+				ID:    "https://www.ebay.com/itm/2",
+				Title: q + " 2",
+				Price: shopping.Price{Amount: 80, Currency: "USD"},
+			},
+		},
+	}, nil
+}
+
+type mockFXFetcher struct{}
+
+func (m *mockFXFetcher) Fetch() (*currency.Response, error) {
+	r := currency.New()
+	r.ForexProvider = currency.ECBProvider
+	r.History[currency.EUR.Short] = []*currency.Rate{
+		{DateTime: time.Date(2018, 1, 31, 0, 0, 0, 0, time.UTC), Rate: 1.25},
+	}
+	return r, nil
+}
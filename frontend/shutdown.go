@@ -0,0 +1,59 @@
+package frontend
+
+import (
+	"context"
+	"net/http"
+	"sync"
+	"sync/atomic"
+)
+
+// inflight counts the goroutines a search starts. They can outlive
+// the request when the TimeoutHandler gives up on a slow backend and
+// may still be writing to the suggest index, so we wait on them too.
+var inflight sync.WaitGroup
+
+// draining is set once we start shutting down so /readyz takes us out of rotation
+var draining int32
+
+// track registers a goroutine we should wait on before exiting.
+// The func it returns must be called when the goroutine is done.
+func track() func() {
+	inflight.Add(1)
+	return inflight.Done
+}
+
+// Drain fails our readiness check so load balancers stop sending us requests.
+// Give them time to notice before calling Shutdown.
+func Drain() {
+	atomic.StoreInt32(&draining, 1)
+}
+
+// Draining is true once Drain or Shutdown has been called
+func Draining() bool {
+	return atomic.LoadInt32(&draining) == 1
+}
+
+// Shutdown stops accepting connections and waits for the requests being served
+// and the goroutines they started to finish or for ctx to be done, whichever is first.
+// Anything that needs a clean close (e.g. our cache pool or database) can be
+// closed once it returns.
+func Shutdown(ctx context.Context, s *http.Server) error {
+	Drain()
+
+	if err := s.Shutdown(ctx); err != nil {
+		return err
+	}
+
+	done := make(chan struct{})
+	go func() {
+		inflight.Wait()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
@@ -0,0 +1,97 @@
+package frontend
+
+import (
+	"context"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestShutdown(t *testing.T) {
+	for _, c := range []struct {
+		name    string
+		timeout time.Duration
+		want    error
+	}{
+		{"drained", time.Second, nil},
+		{"deadline", 20 * time.Millisecond, context.DeadlineExceeded},
+	} {
+		t.Run(c.name, func(t *testing.T) {
+			defer atomic.StoreInt32(&draining, 0)
+
+			started := make(chan struct{})
+			release := make(chan struct{})
+			finished := make(chan struct{})
+
+			// the handler returns right away but leaves work behind
+			s := &http.Server{
+				Handler: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+					done := track()
+					go func() {
+						defer done()
+						<-release
+						close(finished)
+					}()
+					close(started)
+				}),
+			}
+
+			l, err := net.Listen("tcp", "127.0.0.1:0")
+			if err != nil {
+				t.Fatal(err)
+			}
+			go s.Serve(l)
+
+			resp, err := http.Get("http://" + l.Addr().String())
+			if err != nil {
+				t.Fatal(err)
+			}
+			resp.Body.Close()
+			<-started
+
+			if c.want == nil {
+				time.AfterFunc(20*time.Millisecond, func() { close(release) })
+			}
+
+			ctx, cancel := context.WithTimeout(context.Background(), c.timeout)
+			defer cancel()
+
+			if err := Shutdown(ctx, s); err != c.want {
+				t.Fatalf("got %v; want %v", err, c.want)
+			}
+
+			if !Draining() {
+				t.Fatal("expected to be draining")
+			}
+
+			if c.want == nil {
+				select {
+				case <-finished:
+				default:
+					t.Fatal("returned before in-flight work finished")
+				}
+				return
+			}
+
+			close(release)
+			<-finished
+			inflight.Wait()
+		})
+	}
+}
+
+func TestReadyzDraining(t *testing.T) {
+	Drain()
+	defer atomic.StoreInt32(&draining, 0)
+
+	f := &Frontend{}
+	w := httptest.NewRecorder()
+	f.readyzHandler(w, httptest.NewRequest("GET", "/readyz", nil))
+
+	if w.Code != http.StatusServiceUnavailable {
+		t.Fatalf("got %d; want %d", w.Code, http.StatusServiceUnavailable)
+	}
+}
@@ -0,0 +1,175 @@
+package frontend
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"net/http"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	"github.com/jivesearch/jivesearch/log"
+	"willnorris.com/go/imageproxy"
+)
+
+// Key signs the urls of our proxies. Its id prefixes the signature, e.g. /image/225x,s2019a.{signature}/{url}.
+// The key in hmac.secret has no id so urls signed before we rotated keys still work.
+type Key struct {
+	ID        string    `json:"id"`
+	Secret    string    `json:"secret"`
+	NotBefore time.Time `json:"not_before"` // when it takes over signing
+	NotAfter  time.Time `json:"not_after"`  // when its signatures stop being accepted, e.g. because it leaked
+}
+
+// HMAC are the keys we rotate through. The newest key in effect signs urls and any
+// that hasn't expired verifies them. A key without a NotAfter expires Grace after
+// the next one takes over so cached pages outlive the rotation.
+type HMAC struct {
+	Secret string // hmac.secret
+	Keys   []Key
+	Grace  time.Duration
+}
+
+// signing is the keyring of the router we built last. It is global
+// as our templates sign urls without a Frontend.
+var signing atomic.Value
+
+// keyring is our keys plus the one in hmac.secret
+func (h HMAC) keyring() HMAC {
+	k := HMAC{Grace: h.Grace}
+
+	if h.Secret != "" || len(h.Keys) == 0 {
+		k.Keys = append(k.Keys, Key{Secret: h.Secret})
+	}
+
+	k.Keys = append(k.Keys, h.Keys...)
+	return k
+}
+
+// keyring signs urls for our templates
+func keyring() HMAC {
+	if h, ok := signing.Load().(HMAC); ok {
+		return h
+	}
+
+	return HMAC{}.keyring()
+}
+
+// signer is the newest key in effect. Ties go to the last one listed.
+func (h HMAC) signer(now time.Time) (Key, bool) {
+	var k Key
+	var found bool
+
+	for _, key := range h.Keys {
+		if key.NotBefore.After(now) || h.expired(key, now) {
+			continue
+		}
+
+		if !found || !key.NotBefore.Before(k.NotBefore) {
+			k, found = key, true
+		}
+	}
+
+	return k, found
+}
+
+// expires is when a key's signatures are no longer accepted. Zero is never.
+func (h HMAC) expires(k Key) time.Time {
+	if !k.NotAfter.IsZero() || h.Grace <= 0 {
+		return k.NotAfter
+	}
+
+	var next time.Time
+	for _, key := range h.Keys {
+		if key.NotBefore.After(k.NotBefore) && (next.IsZero() || key.NotBefore.Before(next)) {
+			next = key.NotBefore
+		}
+	}
+
+	if next.IsZero() {
+		return next
+	}
+
+	return next.Add(h.Grace)
+}
+
+func (h HMAC) expired(k Key, now time.Time) bool {
+	e := h.expires(k)
+	return !e.IsZero() && !now.Before(e)
+}
+
+// sign signs a url with the newest key
+func (h HMAC) sign(u string, now time.Time) string {
+	k, ok := h.signer(now)
+	if !ok {
+		log.Info.Println("all of our hmac keys have expired")
+	}
+
+	sig := base64.URLEncoding.EncodeToString(mac(k.Secret, u))
+	if k.ID == "" {
+		return sig
+	}
+
+	return k.ID + "." + sig
+}
+
+// valid checks a signature against the key it names
+func (h HMAC) valid(u, signature string, now time.Time) bool {
+	var id string
+	if i := strings.Index(signature, "."); i > -1 { // "." isn't in the base64 url alphabet
+		id, signature = signature[:i], signature[i+1:]
+	}
+
+	if m := len(signature) % 4; m != 0 { // add padding if missing
+		signature += strings.Repeat("=", 4-m)
+	}
+
+	got, err := base64.URLEncoding.DecodeString(signature)
+	if err != nil {
+		log.Debug.Printf("error base64 decoding signature %q\n", signature)
+		return false
+	}
+
+	for _, k := range h.Keys {
+		if k.ID != id || h.expired(k, now) {
+			continue
+		}
+
+		if hmac.Equal(got, mac(k.Secret, u)) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func mac(secret, u string) []byte {
+	h := hmac.New(sha256.New, []byte(secret))
+	if _, err := h.Write([]byte(u)); err != nil {
+		log.Info.Println(err)
+	}
+
+	return h.Sum(nil)
+}
+
+// signed verifies the signature of requests to our image proxy.
+// The proxy itself only knows of a single key.
+func (f *Frontend) signed(next http.Handler) http.Handler {
+	h := f.HMAC.keyring()
+
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		req, err := imageproxy.NewRequest(r, nil)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+
+		if !h.valid(req.URL.String(), req.Options.Signature, now()) {
+			http.Error(w, "requested URL is not allowed", http.StatusForbidden)
+			return
+		}
+
+		next.ServeHTTP(w, r)
+	})
+}
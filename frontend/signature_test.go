@@ -0,0 +1,130 @@
+package frontend
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestHMAC(t *testing.T) {
+	day := 24 * time.Hour
+	start := time.Date(2019, 6, 1, 0, 0, 0, 0, time.UTC)
+	u := "https://example.com/cat.jpg"
+
+	h := HMAC{
+		Keys: []Key{
+			{Secret: "legacy"},
+			{ID: "a", Secret: "secret a", NotBefore: start},
+			{ID: "b", Secret: "secret b", NotBefore: start.Add(30 * day)},
+			{ID: "leaked", Secret: "secret c", NotBefore: start.Add(60 * day), NotAfter: start.Add(61 * day)},
+		},
+		Grace: 7 * day,
+	}
+
+	for _, c := range []struct {
+		name   string
+		signed time.Time
+		now    time.Time
+		signer string
+		valid  bool
+	}{
+		{"legacy before rotation", start.Add(-day), start.Add(-day), "", true},
+		{"legacy in grace", start.Add(-day), start.Add(6 * day), "", true},
+		{"legacy expired", start.Add(-day), start.Add(7 * day), "", false},
+		{"a", start.Add(day), start.Add(day), "a", true},
+		{"a in grace", start.Add(29 * day), start.Add(36 * day), "a", true},
+		{"a expired", start.Add(29 * day), start.Add(37 * day), "a", false},
+		{"scheduled key signs when it takes over", start.Add(30 * day), start.Add(30 * day), "b", true},
+		{"leaked", start.Add(60 * day), start.Add(60 * day), "leaked", true},
+		{"leaked retired", start.Add(60 * day), start.Add(61 * day), "leaked", false},
+		{"back to b once leaked is retired", start.Add(62 * day), start.Add(62 * day), "b", true},
+	} {
+		t.Run(c.name, func(t *testing.T) {
+			k, _ := h.signer(c.signed)
+			if k.ID != c.signer {
+				t.Fatalf("got signer %q; want %q", k.ID, c.signer)
+			}
+
+			sig := h.sign(u, c.signed)
+			if got := h.valid(u, sig, c.now); got != c.valid {
+				t.Fatalf("got valid %v for %q; want %v", got, sig, c.valid)
+			}
+
+			if h.valid("https://example.com/dog.jpg", sig, c.signed) {
+				t.Fatal("a signature for one url is valid for another")
+			}
+		})
+	}
+}
+
+func TestKeyring(t *testing.T) {
+	h := HMAC{Keys: []Key{{ID: "a", Secret: "secret a"}}}
+
+	k := h.keyring()
+	if len(k.Keys) != 1 || k.Keys[0].ID != "a" {
+		t.Fatalf("got %+v; want a blank hmac.secret to be ignored once we have keys", k.Keys)
+	}
+
+	h.Secret = "legacy"
+	if k := h.keyring(); len(k.Keys) != 2 || k.Keys[0].Secret != "legacy" {
+		t.Fatalf("got %+v; want hmac.secret first", k.Keys)
+	}
+
+	if k := (HMAC{}).keyring(); len(k.Keys) != 1 || k.Keys[0].Secret != "" {
+		t.Fatalf("got %+v; want a blank hmac.secret without other keys", k.Keys)
+	}
+}
+
+func TestRouterKeyring(t *testing.T) {
+	defer signing.Store(HMAC{}.keyring())
+
+	f := &Frontend{HMAC: HMAC{Secret: "legacy", Keys: []Key{{ID: "a", Secret: "secret a"}}}}
+	f.Router()
+
+	u := "https://example.com/cat.jpg"
+	if got, want := hmacKey(u), f.HMAC.keyring().sign(u, now()); got != want {
+		t.Fatalf("got %q; want the url signed with the keys of the router we built last %q", got, want)
+	}
+
+	// a reload builds a router from a copy of our frontend
+	nf := *f
+	nf.HMAC = HMAC{Secret: "rotated"}
+	nf.Router()
+
+	if got, want := hmacKey(u), nf.HMAC.keyring().sign(u, now()); got != want {
+		t.Fatalf("got %q; want %q", got, want)
+	}
+}
+
+func TestSigned(t *testing.T) {
+	defer signing.Store(HMAC{}.keyring())
+
+	f := &Frontend{HMAC: HMAC{Secret: "legacy", Keys: []Key{{ID: "a", Secret: "secret a"}}}}
+	signing.Store(f.HMAC.keyring())
+
+	u := "https://example.com/cat.jpg"
+
+	for _, c := range []struct {
+		name   string
+		path   string
+		status int
+	}{
+		{"signed", "/225x,s" + hmacKey(u) + "/https:/example.com/cat.jpg", http.StatusOK},
+		{"legacy", "/225x,s" + HMAC{Keys: []Key{{Secret: "legacy"}}}.sign(u, now()) + "/https:/example.com/cat.jpg", http.StatusOK},
+		{"unknown key", "/225x,sb." + HMAC{Keys: []Key{{Secret: "secret a"}}}.sign(u, now()) + "/https:/example.com/cat.jpg", http.StatusForbidden},
+		{"unsigned", "/225x/https:/example.com/cat.jpg", http.StatusForbidden},
+		{"no url", "/225x", http.StatusBadRequest},
+	} {
+		t.Run(c.name, func(t *testing.T) {
+			h := f.signed(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+
+			w := httptest.NewRecorder()
+			h.ServeHTTP(w, httptest.NewRequest("GET", c.path, nil))
+
+			if w.Code != c.status {
+				t.Fatalf("got status %d; want %d", w.Code, c.status)
+			}
+		})
+	}
+}
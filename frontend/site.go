@@ -0,0 +1,33 @@
+package frontend
+
+import (
+	"net/url"
+	"strings"
+
+	"github.com/jivesearch/jivesearch/search"
+)
+
+// site is the host from the "site" param that web results are limited to,
+// e.g. for a search box embedded in that site. Blank if it isn't a host.
+func site(s string) string {
+	s = strings.ToLower(strings.TrimSpace(s))
+	if s == "" {
+		return ""
+	}
+
+	if !strings.Contains(s, "://") {
+		s = "http://" + s
+	}
+
+	u, err := url.Parse(s)
+	if err != nil || u.Hostname() == "" || strings.ContainsAny(u.Hostname(), " ,") {
+		return ""
+	}
+
+	return u.Hostname()
+}
+
+// Query is what we search for, which is limited to the Site if there is one
+func (c *Context) Query() string {
+	return search.Site(c.Q, c.Site)
+}
@@ -0,0 +1,45 @@
+package frontend
+
+import (
+	"testing"
+)
+
+func TestSite(t *testing.T) {
+	for _, c := range []struct {
+		name string
+		site string
+		want string
+	}{
+		{"empty", "", ""},
+		{"host", "Example.com", "example.com"},
+		{"url", "https://www.example.com/about?a=b", "www.example.com"},
+		{"port", "example.com:8080", "example.com"},
+		{"spaces", "example.com other.com", ""},
+		{"comma", "example.com,other.com", ""},
+		{"invalid", "%zz", ""},
+	} {
+		t.Run(c.name, func(t *testing.T) {
+			if got := site(c.site); got != c.want {
+				t.Fatalf("got %q; want %q", got, c.want)
+			}
+		})
+	}
+}
+
+func TestQuery(t *testing.T) {
+	for _, c := range []struct {
+		name string
+		ctx  *Context
+		want string
+	}{
+		{"no site", &Context{Q: "jive site:example.org"}, "jive site:example.org"},
+		{"site", &Context{Q: "jive", Site: "example.com"}, "jive site:example.com"},
+		{"replaces site operators", &Context{Q: "jive site:example.org", Site: "example.com"}, "jive site:example.com"},
+	} {
+		t.Run(c.name, func(t *testing.T) {
+			if got := c.ctx.Query(); got != c.want {
+				t.Fatalf("got %q; want %q", got, c.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,118 @@
+package frontend
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+
+	"github.com/jivesearch/jivesearch/frontend/apikey"
+	"github.com/jivesearch/jivesearch/search/document"
+)
+
+var (
+	errSiteSearchKey     = fmt.Errorf("site search requires an api key")
+	errSiteNotAllowed    = fmt.Errorf("the api key can't search that site")
+	errOriginNotAllowed  = fmt.Errorf("the api key can't be used from that site")
+	errSiteSearchMissing = fmt.Errorf("missing site")
+)
+
+// SiteSearch are the results of our site search API
+type SiteSearch struct {
+	Query     string               `json:"query"`
+	Site      string               `json:"site"`
+	Count     int64                `json:"count"`
+	Page      string               `json:"page"`
+	Next      string               `json:"next,omitempty"`
+	Documents []*document.Document `json:"documents"`
+}
+
+// siteSearchHandler searches a single site for the search box of /widget.js.
+// The api key, which site owners publish in their pages, can only search its own sites
+// and only be used from them. e.g. /api/v1/sitesearch?q=jive&site=example.com&api_key={key}
+func (f *Frontend) siteSearchHandler(w http.ResponseWriter, r *http.Request) *response {
+	k, _ := r.Context().Value(apiKeyContext).(*apikey.Key)
+	if k == nil {
+		return &response{
+			status: http.StatusUnauthorized,
+			err:    errSiteSearchKey,
+		}
+	}
+
+	d, err := f.getData(r)
+	if err != nil {
+		return &response{
+			status: http.StatusBadRequest,
+			err:    err,
+		}
+	}
+
+	if d.Context.Site == "" && len(k.Sites) == 1 {
+		d.Context.Site = k.Sites[0]
+	}
+
+	switch {
+	case d.Context.Site == "":
+		return &response{
+			status: http.StatusBadRequest,
+			err:    errSiteSearchMissing,
+		}
+	case !k.Allows(d.Context.Site):
+		return &response{
+			status: http.StatusForbidden,
+			err:    errSiteNotAllowed,
+		}
+	}
+
+	if o := origin(r); o != "" {
+		if !k.Allows(o) {
+			return &response{
+				status: http.StatusForbidden,
+				err:    errOriginNotAllowed,
+			}
+		}
+
+		if h := r.Header.Get("Origin"); h != "" {
+			w.Header().Set("Access-Control-Allow-Origin", h)
+			w.Header().Add("Vary", "Origin")
+		}
+	}
+
+	if d.Context.Q == "" {
+		return &response{
+			status: http.StatusBadRequest,
+			err:    errMissingQuery,
+		}
+	}
+
+	sr := f.searchResults(r, d, d.Context.lang, d.Context.Region)
+
+	resp := &response{
+		status:   http.StatusOK,
+		template: "json",
+		data: &SiteSearch{
+			Query:     d.Context.Q,
+			Site:      d.Context.Site,
+			Count:     sr.Count,
+			Page:      sr.Page,
+			Next:      sr.Next,
+			Documents: sr.Documents,
+		},
+	}
+
+	if r.FormValue("o") == "jsonp" {
+		resp.template = "jsonp"
+	}
+
+	return resp
+}
+
+// origin is the host of the page that made a request, if the browser tells us
+func origin(r *http.Request) string {
+	for _, h := range []string{"Origin", "Referer"} {
+		if u, err := url.Parse(r.Header.Get(h)); err == nil && u.Host != "" {
+			return u.Hostname()
+		}
+	}
+
+	return ""
+}
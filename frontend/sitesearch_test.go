@@ -0,0 +1,104 @@
+package frontend
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/jivesearch/jivesearch/bangs"
+	"github.com/jivesearch/jivesearch/frontend/apikey"
+	"github.com/jivesearch/jivesearch/search"
+	"github.com/jivesearch/jivesearch/search/document"
+	"golang.org/x/text/language"
+)
+
+type siteSearcher struct {
+	q string
+}
+
+func (s *siteSearcher) Fetch(q string, f search.Filter, lang language.Tag, region language.Region, number int, offset int) (*search.Results, error) {
+	s.q = q
+	return &search.Results{
+		Count: 1,
+		Documents: []*document.Document{
+			{ID: "https://example.com/about"},
+		},
+	}, nil
+}
+
+func TestSiteSearchHandler(t *testing.T) {
+	for _, c := range []struct {
+		name     string
+		u        string
+		key      *apikey.Key
+		origin   string
+		status   int
+		template string
+		cors     string
+		q        string
+	}{
+		{"no key", "/api/v1/sitesearch?q=jive&site=example.com", nil, "", http.StatusUnauthorized, "", "", ""},
+		{"missing site", "/api/v1/sitesearch?q=jive", &apikey.Key{Sites: []string{"example.com", "example.org"}}, "", http.StatusBadRequest, "", "", ""},
+		{"site not allowed", "/api/v1/sitesearch?q=jive&site=other.com", &apikey.Key{Sites: []string{"example.com"}}, "", http.StatusForbidden, "", "", ""},
+		{
+			"origin not allowed", "/api/v1/sitesearch?q=jive&site=example.com", &apikey.Key{Sites: []string{"example.com"}},
+			"https://other.com", http.StatusForbidden, "", "", "",
+		},
+		{"missing query", "/api/v1/sitesearch?site=example.com", &apikey.Key{Sites: []string{"example.com"}}, "", http.StatusBadRequest, "", "", ""},
+		{
+			"search", "/api/v1/sitesearch?q=jive+site:other.com&site=example.com", &apikey.Key{Sites: []string{"example.com"}},
+			"https://www.example.com", http.StatusOK, "json", "https://www.example.com", "jive site:example.com",
+		},
+		{"the key's only site", "/api/v1/sitesearch?q=jive", &apikey.Key{Sites: []string{"example.com"}}, "", http.StatusOK, "json", "", "jive site:example.com"},
+		{
+			"jsonp", "/api/v1/sitesearch?q=jive&site=docs.example.com&o=jsonp", &apikey.Key{Sites: []string{"example.com"}},
+			"", http.StatusOK, "jsonp", "", "jive site:docs.example.com",
+		},
+	} {
+		t.Run(c.name, func(t *testing.T) {
+			s := &siteSearcher{}
+			f := &Frontend{
+				Document: Document{
+					Matcher: language.NewMatcher([]language.Tag{language.English}),
+				},
+				Bangs:  &bangs.Bangs{},
+				Search: s,
+			}
+			f.Cache.Cacher = &mockCacher{}
+			f.Cache.Search = 10 * time.Second
+
+			r := httptest.NewRequest("GET", c.u, nil)
+			if c.origin != "" {
+				r.Header.Set("Origin", c.origin)
+			}
+			if c.key != nil {
+				r = r.WithContext(context.WithValue(r.Context(), apiKeyContext, c.key))
+			}
+
+			w := httptest.NewRecorder()
+			resp := f.siteSearchHandler(w, r)
+
+			if resp.status != c.status {
+				t.Fatalf("got status %d; want %d (%v)", resp.status, c.status, resp.err)
+			}
+
+			if resp.template != c.template {
+				t.Fatalf("got template %q; want %q", resp.template, c.template)
+			}
+
+			if got := w.Header().Get("Access-Control-Allow-Origin"); got != c.cors {
+				t.Fatalf("got Access-Control-Allow-Origin %q; want %q", got, c.cors)
+			}
+
+			if s.q != c.q {
+				t.Fatalf("searched for %q; want %q", s.q, c.q)
+			}
+
+			if resp.status == http.StatusOK && len(resp.data.(*SiteSearch).Documents) != 1 {
+				t.Fatalf("got %+v; want the results", resp.data)
+			}
+		})
+	}
+}
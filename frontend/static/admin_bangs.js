@@ -0,0 +1,189 @@
+// The !bangs admin page. Everything goes through /admin/bangs with the admin token as a bearer token,
+// which we keep for this tab only.
+var token = function(){
+  return sessionStorage.getItem("admin_token") || "";
+};
+
+var all = []; // the !bangs as of our last call
+var editing = null; // the !bang in the edit form, if we aren't adding one
+
+var call = function(method, url, data){
+  $("#error").text("");
+  return $.ajax({
+    method: method,
+    url: url,
+    data: data,
+    traditional: true, // region=a:...&region=b:... rather than region[]=
+    dataType: "json",
+    headers: {"Authorization": "Bearer " + token()}
+  }).fail(function(xhr){
+    if (xhr.status === 403){
+      signOut();
+    }
+    $("#error").text(xhr.status + " " + (xhr.responseText || xhr.statusText));
+  });
+};
+
+var signIn = function(){
+  $("#token_value").hide();
+  $("#token button[type=submit]").hide();
+  $("#sign_out").show();
+  $("#signed_in").show();
+  load();
+};
+
+var signOut = function(){
+  sessionStorage.removeItem("admin_token");
+  all = [];
+  $("#bangs").empty();
+  $("#signed_in").hide();
+  $("#sign_out").hide();
+  $("#token_value").val("").show();
+  $("#token button[type=submit]").show();
+};
+
+var load = function(){
+  call("GET", "/admin/bangs").done(loaded);
+};
+
+// render lists the !bangs that match our filter. We only ever set text so a !bang can't inject html.
+var render = function(){
+  var filter = $.trim($("#filter").val()).toLowerCase();
+  var tbody = $("#bangs").empty();
+
+  $.each(all, function(i, b){
+    var triggers = (b.triggers || []).join(", ");
+    if (filter !== "" && b.name.toLowerCase().indexOf(filter) === -1 && triggers.indexOf(filter) === -1){
+      return;
+    }
+
+    var regions = $("<td>");
+    $.each(Object.keys(b.regions || {}).sort(), function(j, k){
+      regions.append($("<div>").text(k + ": " + b.regions[k]));
+    });
+
+    var actions = $("<td>").addClass("actions").append(
+      $("<button>").addClass("pure-button").text("Edit").click(function(){ edit(b); }),
+      " ",
+      $("<button>").addClass("pure-button").text(b.disabled ? "Enable" : "Disable").click(function(){
+        save({name: b.name, disabled: !b.disabled});
+      }),
+      " ",
+      $("<button>").addClass("pure-button").text("Revert").attr("title", "Undo our edits, or remove a !bang we added").click(function(){
+        if (confirm("Revert " + b.name + " to our config file?")){
+          call("DELETE", "/admin/bangs?" + $.param({name: b.name})).done(loaded);
+        }
+      })
+    );
+
+    $("<tr>").toggleClass("disabled", !!b.disabled).append(
+      $("<td>").text(b.name + (b.disabled ? " (disabled)" : "")),
+      $("<td>").text(triggers),
+      regions,
+      actions
+    ).appendTo(tbody);
+  });
+};
+
+var loaded = function(data){
+  all = data || [];
+  render();
+};
+
+var save = function(data){
+  return call("POST", "/admin/bangs", data).done(function(data){
+    loaded(data);
+    reset();
+  });
+};
+
+var edit = function(b){
+  editing = b;
+  $("#edit_title").text("Edit " + b.name);
+  $("#edit_name").val(b.name).prop("readonly", true);
+  $("#edit_triggers").val((b.triggers || []).join(", "));
+  $("#edit_favicon").val(b.favicon || "");
+  $("#edit_regions").val($.map(Object.keys(b.regions || {}).sort(), function(k){
+    return k + ":" + b.regions[k];
+  }).join("\n"));
+  $("#edit_disabled").prop("checked", !!b.disabled);
+  $("#edit_cancel").show();
+  $("#edit_name")[0].scrollIntoView();
+};
+
+var reset = function(){
+  editing = null;
+  $("#edit_title").text("Add a !bang");
+  $("#edit")[0].reset();
+  $("#edit_name").prop("readonly", false);
+  $("#edit_cancel").hide();
+};
+
+$(document).ready(function(){
+  $("#token").submit(function(e){
+    e.preventDefault();
+    sessionStorage.setItem("admin_token", $("#token_value").val());
+    signIn();
+  });
+
+  $("#sign_out").click(signOut);
+  $("#filter").on("input", render);
+  $("#edit_cancel").click(reset);
+
+  $("#edit").submit(function(e){
+    e.preventDefault();
+
+    var regions = [], kept = {};
+    $.each($("#edit_regions").val().split("\n"), function(i, line){
+      if ($.trim(line) !== ""){
+        regions.push($.trim(line));
+        kept[$.trim(line.split(":")[0]).toLowerCase()] = true;
+      }
+    });
+
+    // a region without a url is removed
+    if (editing !== null){
+      $.each(Object.keys(editing.regions || {}), function(i, k){
+        if (!kept[k]){
+          regions.push(k + ":");
+        }
+      });
+    }
+
+    save({
+      name: $.trim($("#edit_name").val()),
+      triggers: $("#edit_triggers").val(),
+      favicon: $("#edit_favicon").val(),
+      region: regions,
+      disabled: $("#edit_disabled").prop("checked")
+    });
+  });
+
+  $("#test").submit(function(e){
+    e.preventDefault();
+
+    var result = $("#test_result").text("");
+    call("GET", "/admin/bangs/test", {
+      q: $("#test_q").val(),
+      region: $("#test_region").val(),
+      l: $("#test_l").val()
+    }).done(function(data){
+      if (!data.bang){
+        result.text("No !bang");
+        return;
+      }
+      result.text(data.bang.name + " → ");
+      if (!/^https?:\/\//i.test(data.url)){
+        result.append(document.createTextNode(data.url));
+        return;
+      }
+      result.append(
+        $("<a>").attr({href: data.url, rel: "noopener noreferrer", target: "_blank"}).text(data.url)
+      );
+    });
+  });
+
+  if (token() !== ""){
+    signIn();
+  }
+});
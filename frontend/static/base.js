@@ -1,5 +1,24 @@
+// searches sent as form posts keep their parameters in the search form rather than the url
+var posting = function(){
+  return $("#form").attr("method") === "POST";
+};
+
+var searchParams = function(){
+  if (!posting()){
+    return document.location.search;
+  }
+
+  var fields = $("#form").serializeArray();
+  for (var i = 0; i < fields.length; i++){
+    if (fields[i].name === "q"){ // what they searched for rather than what they have since typed
+      fields[i].value = $("#query").attr("data-query");
+    }
+  }
+  return '?' + $.param(fields);
+};
+
 var changeParam = function(key, value) {
-  var  urlQueryString = document.location.search,
+  var  urlQueryString = searchParams(),
        newParam = key + '=' + value,
        params = '?' + newParam;
 
@@ -21,7 +40,7 @@ var changeParam = function(key, value) {
 };
 
 var removeParam = function(key){
-  var  urlQueryString = document.location.search;
+  var  urlQueryString = searchParams();
   var removeRegex = new RegExp('([\?&])' + key + '=[^&;]+[&;]?');
   params = urlQueryString.replace(removeRegex, "$1");
   params = params.replace( /[&;]$/, "" );
@@ -29,7 +48,21 @@ var removeParam = function(key){
 };
 
 var redirect = function(params){
-  window.location.href = window.location.pathname + params;
+  if (!posting()){
+    window.location.href = window.location.pathname + params;
+    return;
+  }
+
+  var form = $("<form>", {method: "POST", action: "/search"});
+  $.each(params.replace(/^\?/, "").split("&"), function(index, pair){
+    if (pair === ""){
+      return;
+    }
+    var kv = pair.split("=");
+    var decode = function(v){ return decodeURIComponent(v.replace(/\+/g, " ")); };
+    $("<input>", {type: "hidden", name: decode(kv[0]), value: decode(kv.slice(1).join("="))}).appendTo(form);
+  });
+  form.appendTo("body").submit();
 };
 
 function isBang(item) {
@@ -44,7 +77,127 @@ function label(item){
   return label
 }
 
+// Opt-in search history. It is kept in localStorage and synced to us sealed with a key that
+// only this browser has. Autocomplete sends the key so the user's past searches come first.
+var searchHistory = {
+  key: function(){
+    try {
+      return localStorage.getItem("history_key");
+    } catch (e) { // storage is disabled
+      return null;
+    }
+  },
+  headers: function(){
+    var key = searchHistory.key();
+    return key ? {"X-History-Key": key} : {};
+  },
+  queries: function(){
+    return JSON.parse(localStorage.getItem("history") || "[]");
+  },
+  enable: function(){
+    var b = new Uint8Array(32);
+    window.crypto.getRandomValues(b);
+    var key = btoa(String.fromCharCode.apply(null, b)).replace(/\+/g, "-").replace(/\//g, "_").replace(/=+$/, "");
+    localStorage.setItem("history_key", key);
+    searchHistory.sync();
+  },
+  disable: function(){
+    $.ajax({url: "/history", method: "DELETE", headers: searchHistory.headers()});
+    localStorage.removeItem("history_key");
+    localStorage.removeItem("history");
+  },
+  add: function(q){
+    var queries = searchHistory.queries().filter(function(p){
+      return p.q.toLowerCase() !== q.toLowerCase();
+    });
+    queries.unshift({q: q, time: new Date().toISOString()});
+    localStorage.setItem("history", JSON.stringify(queries.slice(0, 100)));
+    searchHistory.sync();
+  },
+  sync: function(){
+    $.ajax({
+      url: "/history",
+      method: "POST",
+      contentType: "application/json",
+      dataType: "json",
+      headers: searchHistory.headers(),
+      data: JSON.stringify({queries: searchHistory.queries()})
+    }).done(function(data){
+      localStorage.setItem("history", JSON.stringify(data.queries));
+    });
+  }
+};
+
+var fetchSuggestions = function(q, callback){
+  $.ajax({
+    url: "/autocomplete",
+    data: {q: q}, // '{q: q}' changes it from ?term=b to ?q=b so nginx doesn't log query.
+    dataType: "json",
+    headers: searchHistory.headers()
+  }).done(function(data){
+    callback(data.suggestions);
+  });
+};
+
 $(document).ready(function() {
+  var searched = $("#query").attr("data-query");
+  if (searched && searchHistory.key()){
+    searchHistory.add(searched);
+  }
+
+  var toggle = $("#history_toggle");
+  var showHistorySetting = function(){
+    toggle.text(searchHistory.key() ? toggle.attr("data-on") : toggle.attr("data-off"));
+  };
+  showHistorySetting();
+  toggle.on("click", function(e){
+    e.preventDefault();
+    if (searchHistory.key()){
+      searchHistory.disable();
+    } else {
+      searchHistory.enable();
+    }
+    showHistorySetting();
+  });
+
+  // Autocomplete over a WebSocket while the search box has focus. The server answers the latest
+  // of the keystrokes in flight so those before it get the same suggestions and jQuery UI drops them.
+  var socket = null;
+  var keystrokes = 0;
+  var waiting = {};
+
+  function openSocket(){
+    if (!window.WebSocket || (socket && socket.readyState <= WebSocket.OPEN)){
+      return;
+    }
+
+    var scheme = window.location.protocol === "https:" ? "wss://" : "ws://";
+    socket = new WebSocket(scheme + window.location.host + "/autocomplete/socket");
+    socket.onmessage = function(e){
+      var data = JSON.parse(e.data);
+      for (var id in waiting){
+        if (id <= data.id){
+          waiting[id](data.suggestions);
+          delete waiting[id];
+        }
+      }
+    };
+    socket.onclose = function(){
+      var q = $("#query").val();
+      for (var id in waiting){ // fall back to requests
+        fetchSuggestions(q, waiting[id]);
+        delete waiting[id];
+      }
+      socket = null;
+    };
+  }
+
+  $("#query").on("focus keydown", openSocket).on("blur", function(){
+    if (socket){
+      socket.close();
+    }
+  });
+
   // autocomplete
   $(function(){
     $("#query").autocomplete({
@@ -58,9 +211,14 @@ $(document).ready(function() {
         $("ul.ui-menu").innerWidth($(this).innerWidth()); // width of input including button
       },
       source: function(request, callback){
-        $.getJSON('/autocomplete', {q: request.term}, function(data){ // '{q: request.term}' changes it from ?term=b to ?q=b so nginx doesn't log query.
-          callback(data.suggestions);
-        });
+        if (socket && socket.readyState === WebSocket.OPEN){
+          var id = ++keystrokes;
+          waiting[id] = callback;
+          socket.send(JSON.stringify({id: id, q: request.term, key: searchHistory.key() || undefined}));
+          return;
+        }
+
+        fetchSuggestions(request.term, callback);
       },
       select: function(event, ui){
         if (!isBang(ui.item)){ 
@@ -42,13 +42,27 @@ window.onload = function() {
         enterPeriod();
     }, false);
     
-    // "="
+    // "=" is worked out by our calculator rather than eval, which our Content Security Policy doesn't allow
     function enterEqual(){
-        if(result.innerHTML === output) {
-            result.innerHTML = eval(output);
-        } else {
+        if(result.innerHTML !== output) {
             result.innerHTML = "";
+            return;
         }
+
+        var expression = output;
+        $.ajax({
+            url: "/api/v1/instant",
+            data: {q: "calculate " + expression},
+            dataType: "json"
+        }).done(function(data){
+            if(data.type === "calculator" && data.answer) {
+                result.innerHTML = data.answer.result;
+            } else {
+                result.innerHTML = isFinite(expression) ? expression : "";
+            }
+        }).fail(function(){
+            result.innerHTML = "";
+        });
     }
     document.querySelector("#eqn-bg").addEventListener("click",function() {
         enterEqual();
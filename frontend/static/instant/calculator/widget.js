@@ -0,0 +1,98 @@
+// Recalculates a calculator widget (tip, bmi, mortgage, etc) whenever one of its inputs changes.
+// The formulas mirror the ones in the instant package.
+var widgetFormulas = {
+  "tip": function(v){
+    var tip = v.bill * v.percent / 100;
+    return {tip: tip, total: v.bill + tip, per_person: (v.bill + tip) / v.people};
+  },
+  "bmi-metric": function(v){
+    var m = v.height / 100;
+    return bmiResult(v.weight / (m * m));
+  },
+  "bmi-imperial": function(v){
+    return bmiResult(703 * v.weight / (v.height * v.height));
+  },
+  "mortgage": function(v){
+    var n = v.years * 12;
+    var r = v.rate / 1200;
+    var pmt = v.amount / n;
+    if (r !== 0){
+      var f = Math.pow(1 + r, n);
+      pmt = v.amount * r * f / (f - 1);
+    }
+    return {payment: pmt, total_paid: pmt * n, total_interest: pmt * n - v.amount};
+  },
+  "percent-of": function(v){
+    return {result: v.part / v.whole * 100};
+  },
+  "percent-change": function(v){
+    return {result: (v.to - v.from) / Math.abs(v.from) * 100};
+  },
+  "percent-whole": function(v){
+    return {result: v.part / (v.percent / 100)};
+  }
+};
+
+function bmiResult(bmi){
+  var category = "Obese";
+  if (bmi < 18.5){
+    category = "Underweight";
+  } else if (bmi < 25){
+    category = "Normal weight";
+  } else if (bmi < 30){
+    category = "Overweight";
+  }
+  return {bmi: bmi, bmi_text: category};
+}
+
+function formatWidgetValue(value, format){
+  var opts = {maximumFractionDigits: 2};
+  if (format === "currency"){
+    opts.minimumFractionDigits = 2;
+  }
+  var s = value.toLocaleString("en-US", opts);
+  if (format === "currency"){
+    return "$" + s;
+  } else if (format === "percent"){
+    return s + "%";
+  }
+  return s;
+}
+
+function recalculate(form){
+  var formula = widgetFormulas[form.data("formula")];
+  if (formula === undefined){
+    return;
+  }
+
+  var v = {};
+  form.find(".calc-widget-input").each(function(){
+    v[$(this).data("name")] = parseFloat($(this).val().replace(/[$,]/g, ""));
+  });
+
+  var results = formula(v);
+  form.find(".calc-widget-output").each(function(){
+    var value = results[$(this).data("name")];
+    if (isNaN(parseFloat(value)) || !isFinite(value)){
+      $(this).html("");
+      return;
+    }
+    $(this).html(formatWidgetValue(value, $(this).data("format")));
+  });
+
+  form.find(".calc-widget-text").each(function(){
+    $(this).html(results[$(this).data("name") + "_text"] || "");
+  });
+}
+
+$(document).ready(function(){
+  $(".calc-widget").each(function(){
+    var form = $(this);
+    form.find(".calc-widget-input").on("input change", function(){
+      recalculate(form);
+    });
+    form.on("submit", function(e){
+      e.preventDefault();
+    });
+  });
+});
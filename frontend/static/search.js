@@ -32,9 +32,54 @@ $(document).ready(function() {
   };
 
   $(".description").each(function(index, value){
+    if ($(value).find("code").length > 0){ // already highlighted as code on our server
+      return;
+    }
     $(value).html(highlight(value));
   });
 
+  // report which result was clicked and its position so popular results can be ranked higher
+  $(document).on('click', '#documents[data-clicks] .result .title a', function(){
+    if (!navigator.sendBeacon){
+      return;
+    }
+    var documents = $("#documents");
+    var position = documents.find(".result").index($(this).closest(".result")) + 1 + parseInt(documents.data("offset"), 10);
+    var data = new FormData();
+    data.append("q", $("#query").data("query"));
+    data.append("u", $(this).attr("href"));
+    data.append("p", position);
+    navigator.sendBeacon("/click", data);
+  });
+
+  // keyboard navigation between results. The shortcuts come from the hints of the page.
+  var hints = JSON.parse($("#hints").text() || "{}");
+  var selected = -1;
+  $(document).on('keydown', function(e){
+    if ($(e.target).is("input, textarea, select") || e.ctrlKey || e.metaKey || e.altKey){
+      return;
+    }
+
+    var documents = $("#documents .document");
+    switch ((hints.keys || {})[e.key]){
+      case "next":
+        selected = Math.min(selected + 1, documents.length - 1);
+        break;
+      case "previous":
+        selected = Math.max(selected - 1, 0);
+        break;
+      case "search":
+        e.preventDefault();
+        $("#query").focus();
+        return;
+      default:
+        return;
+    }
+
+    documents.removeClass("selected").removeAttr("aria-current");
+    documents.eq(selected).addClass("selected").attr("aria-current", "true").find(".title a").focus();
+  });
+
   // redirect to a default !bang
   $(document).on('click', '.bang_submit', function(){
     params = changeParam("q", $(this).data('location'));
@@ -43,10 +88,19 @@ $(document).ready(function() {
 
   // Traditional Pagination
   $(document).on('click', '.pagination', function(){
-    params = changeParam("p", $(this).data('page'));
+    params = withCursor(changeParam("p", $(this).data('page')), $(this).data('cursor'));
     redirect(params);
   });
 
+  // Deep pages follow the cursor of the page before them. Every other page is reached with an offset.
+  function withCursor(params, cursor){
+    params = params.replace(/([?&])cursor=[^&]*&?/, "$1").replace(/[?&]$/, "");
+    if (cursor){
+      params = params + (params ? "&" : "?") + "cursor=" + encodeURIComponent(cursor);
+    }
+    return params;
+  }
+
   // Infinite Scroll
   // ===== Scroll to Top ==== 
   $(window).scroll(function() {
@@ -60,6 +114,7 @@ $(document).ready(function() {
     $('body,html').animate({
       scrollTop : 0                       
     }, 500);
+    return false;
   });
   
   var fetching = false;
@@ -72,21 +127,47 @@ $(document).ready(function() {
         return;
       }
 
-      fetch(page, false);
+      fetch(page, false, $("#next_page").attr("data-cursor"));
     }
   }); 
 
-  function fetch(page, isretry){
-    var params = changeParam("p", page);
+  // Infinite scroll for images continues from the cursor of the last batch
+  $(window).scroll(function() {
+    var cursor = $("#image_results").attr("data-cursor");
+    if ((fetching===false) && cursor && ($(window).scrollTop() >= ($(document).height() - $(window).height() - 250))) {
+      fetching = true;
+      var params = changeParam("cursor", cursor);
+      var request = {url: "/api/v1/images" + params};
+      if (posting()){
+        request = {url: "/api/v1/images", method: "POST", data: params.substring(1)};
+      }
+      $.ajax(request).done(function(data) {
+        $("#image_results").attr("data-cursor", data.cursor || "");
+        for (var i = 0; i < data.images.length; i++) {
+          var img = $("<img>", {src: data.images[i].thumbnail, title: data.images[i].alt, loading: "lazy"});
+          $("<a>", {href: data.images[i].thumbnail}).append(img).insertBefore("#image_provider");
+        }
+        fetching = false;
+      });
+    }
+  });
+
+  function fetch(page, isretry, cursor){
+    var params = withCursor(changeParam("p", page), cursor);
     params = params + "&o=json"; // add the new param
     if (isretry === true){ // were the initial results blank and this is just a retry?
       params = params + "&isretry=true";
     }
-    var u = window.location.pathname + params;
-    $.ajax({
-      url: u,
-    }).done(function(data) {
+    var request = {url: window.location.pathname + params};
+    if (posting()){
+      request = {url: "/search", method: "POST", data: params.substring(1)};
+    }
+    $.ajax(request).done(function(data) {
       $("#next_page").attr("data-page", data.search.next);
+      $("#next_page").attr("data-cursor", data.search.cursor || "");
+      if (data.hints){
+        $("#announce").text(data.hints.announce);
+      }
       var i;
       for (i = 0; i < data.search.documents.length; i++) {
         /*
@@ -95,7 +176,8 @@ $(document).ready(function() {
         */
         var doc = data.search.documents[i];
         var desc = highlight(`<div class="description">`+doc.description+`</div>`); // bit redundant to repeat the <div tag here...
-        var h = `<div class="pure-u-1">
+        var n = data.hints ? data.hints.first + i : "";
+        var h = `<div class="document pure-u-1" role="listitem" data-index="`+n+`" aria-posinset="`+n+`">
           <div class="pure-u-22-24 pure-u-md-21-24 result">
             <div class="title"><a href="`+doc.id+`" rel="noopener">`+doc.title+`</a></div>
             <div class="url">`+doc.id.substring(0,80)+`</div>
@@ -103,6 +185,32 @@ $(document).ready(function() {
           </div>
         </div>`;
 
+        // search the rest of the result's site, unless we already are
+        var q = $("#query").attr("data-query");
+        var moreFrom = $("#documents").attr("data-more-from");
+        var host = hostname(doc.id);
+        if (moreFrom && host){
+          h = $(h);
+          var action = $("<a>", {href: "/?q=" + encodeURIComponent(q) + "&site=" + encodeURIComponent(host)}).text(moreFrom);
+          h.find(".url").append($("<span>", {class: "site_action"}).append(action));
+        }
+
+        // warn of results on our malware and phishing blocklists
+        var warning = doc.threat ? $("#documents").attr("data-" + doc.threat) : "";
+        if (warning){
+          h = $(h);
+          h.find(".url").after($("<div>", {class: "threat", role: "alert"}).text(warning));
+        }
+
+        // link to the rest of the results from a host that were collapsed
+        $.each(data.search.more || [], function(index, more){
+          if (more.after === doc.id){
+            var a = $("<a>", {href: "/?q=" + encodeURIComponent(q) + "&site=" + encodeURIComponent(more.host)}).text("More results from " + more.host);
+            h = $(h);
+            h.find(".result").append($("<div>", {class: "more_from"}).append(a));
+          }
+        });
+
         $("#documents").append(h);
       }
       fetching = false;
@@ -111,12 +219,44 @@ $(document).ready(function() {
     });
   }
 
+  function hostname(u){
+    var a = document.createElement("a");
+    a.href = u;
+    return a.hostname.toLowerCase();
+  }
+
   // Wikipedia disambiguation page link & other links w/in Wikipedia snippets
   $(document).on('click', '.wikipedia_disambiguation, .wikipedia_item', function(){
     params = changeParam("q", $(this).data('title'));
     redirect(params);
   });
   
+  // "People also ask" loads the instant answer the first time it is expanded
+  $(document).on('click', '.question_text', function(){
+    var question = $(this).closest(".question");
+    var answer = question.find(".question_answer");
+    answer.toggle();
+
+    if (question.data("loaded")){
+      return;
+    }
+    question.data("loaded", true);
+
+    $.ajax({
+      url: "/answer?q=" + encodeURIComponent(question.data("question")),
+      dataType: "JSONP",
+      jsonpCallback: "jivesearchcallback"
+    }).done(function(data){
+      $.each(data.css, function(index, val){
+        $("<link/>", {rel: "stylesheet", type: "text/css", href: val}).appendTo("head");
+      });
+      question.find(".question_instant").html(data.html);
+      $.each(data.javascript, function(index, val){
+        $.getScript(val);
+      });
+    });
+  });
+
   // redirect "did you mean?" queries
   $("#alternative").on("click", function(){  
     params = changeParam("q", $(this).attr("data-alternative")); 
@@ -140,6 +280,11 @@ $(document).ready(function() {
     redirect(params);
   });
 
+  $(".image_filter, .shopping_filter, .file_filter").on('change', function() {
+    params = changeParam($(this).attr("name"), $(this).val());
+    redirect(params);
+  });
+
   $("#all").on("click", function(){
     // we should delete the param but this works also 
     params = changeParam("t", "");
@@ -151,6 +296,26 @@ $(document).ready(function() {
     redirect(params);
   });
 
+  $("#local").on("click", function(){
+    params = changeParam("t", "local");
+    redirect(params);
+  });
+
+  $("#scholar").on("click", function(){
+    params = changeParam("t", "scholar");
+    redirect(params);
+  });
+
+  $("#shopping").on("click", function(){
+    params = changeParam("t", "shopping");
+    redirect(params);
+  });
+
+  $("#files").on("click", function(){
+    params = changeParam("t", "files");
+    redirect(params);
+  });
+
   $("#map, #maps").on("click", function(){
     params = changeParam("t", "maps");
     redirect(params);
@@ -171,6 +336,11 @@ function selectText(containerid) {
   }
 }
 
+// inline handlers aren't allowed by our Content-Security-Policy
+$(document).on('click', '.open_widget', function(){
+  document.getElementById('open-widget').style.display='block';
+});
+
 // When the user clicks anywhere outside of the widget modal, close it
 var widget_modal = document.getElementById('open-widget');
 
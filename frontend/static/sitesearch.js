@@ -0,0 +1,116 @@
+// Site search widget. Add it to your pages with:
+// <div id="jive_sitesearch"></div>
+// <script src="https://jivesearch.com/widget.js" data-key="{api key}" data-site="example.com" async></script>
+// The api key has to be issued for your site. Results are limited to it.
+(function () {
+  var script = document.currentScript;
+  if (!script) {
+    return;
+  }
+
+  var host = script.getAttribute("data-host") || new URL(script.src).origin;
+  var key = script.getAttribute("data-key") || "";
+  var site = script.getAttribute("data-site") || "";
+  var container = document.getElementById(script.getAttribute("data-container") || "jive_sitesearch");
+  if (!container) {
+    console.log("jive search: missing the #jive_sitesearch element");
+    return;
+  }
+
+  var form = document.createElement("form");
+  form.setAttribute("role", "search");
+  var input = document.createElement("input");
+  input.type = "search";
+  input.name = "q";
+  input.placeholder = script.getAttribute("data-placeholder") || "Search " + site;
+  input.setAttribute("aria-label", input.placeholder);
+  var button = document.createElement("button");
+  button.type = "submit";
+  button.textContent = "Search";
+  form.appendChild(input);
+  form.appendChild(button);
+
+  var results = document.createElement("div");
+  results.className = "jive_sitesearch_results";
+  results.setAttribute("aria-live", "polite");
+
+  var more = document.createElement("button");
+  more.type = "button";
+  more.textContent = "More results";
+  more.style.display = "none";
+
+  container.appendChild(form);
+  container.appendChild(results);
+  container.appendChild(more);
+
+  var query = "";
+  var next = "";
+
+  function fetchPage(page) {
+    var params = "?q=" + encodeURIComponent(query) + "&site=" + encodeURIComponent(site) +
+      "&api_key=" + encodeURIComponent(key);
+    if (page) {
+      params += "&p=" + encodeURIComponent(page);
+    }
+
+    fetch(host + "/api/v1/sitesearch" + params, {mode: "cors", credentials: "omit"}).then(function (resp) {
+      if (!resp.ok) {
+        throw new Error(resp.status + " " + resp.statusText);
+      }
+      return resp.json();
+    }).then(function (data) {
+      render(data, page);
+    }).catch(function (err) {
+      console.log("jive search: " + err.message);
+    });
+  }
+
+  function render(data, page) {
+    if (!page) {
+      results.textContent = "";
+    }
+
+    if (!data.documents || data.documents.length === 0) {
+      if (!page) {
+        results.textContent = "No results for " + data.query;
+      }
+      more.style.display = "none";
+      return;
+    }
+
+    data.documents.forEach(function (doc) {
+      var result = document.createElement("div");
+      result.className = "jive_sitesearch_result";
+
+      var a = document.createElement("a");
+      a.href = doc.id;
+      a.textContent = doc.title || doc.id;
+      var title = document.createElement("div");
+      title.appendChild(a);
+
+      var description = document.createElement("div");
+      description.textContent = doc.description || "";
+
+      result.appendChild(title);
+      result.appendChild(description);
+      results.appendChild(result);
+    });
+
+    next = data.next || "";
+    more.style.display = next ? "" : "none";
+  }
+
+  form.addEventListener("submit", function (e) {
+    e.preventDefault();
+    query = input.value.trim();
+    if (query !== "") {
+      fetchPage("");
+    }
+  });
+
+  more.addEventListener("click", function () {
+    if (next) {
+      fetchPage(next);
+    }
+  });
+})();
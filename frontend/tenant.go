@@ -0,0 +1,107 @@
+package frontend
+
+import (
+	"fmt"
+	"net"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+// Tenant is a branded search property served by the same deployment as ours.
+// It is picked by the Host header. Whatever it leaves empty is ours.
+type Tenant struct {
+	Brand
+	MapBoxKey    string   `json:"mapbox_key"`
+	DefaultBangs []string `json:"bangs"`     // the triggers of the !bangs under the search box, e.g. ["g", "w"]
+	Verticals    []string `json:"verticals"` // the tabs besides "All", e.g. ["images", "maps"]. Empty is all of them.
+	Ranking      string   `json:"ranking"`   // one of Experiments.Searchers
+}
+
+// Tenants are the properties by hostname
+type Tenants map[string]*Tenant
+
+// Verticals are the tabs of our search page a tenant can turn off
+var Verticals = []string{"images", "local", "scholar", "shopping", "files", "maps"}
+
+// Validate checks the rankings and verticals of the tenants. The searchers are the rankings there are.
+func (t Tenants) Validate(searchers []string) error {
+	for host, tt := range t {
+		if host != hostname(host) {
+			return fmt.Errorf("tenant %q should be a lowercase hostname without a port", host)
+		}
+
+		if tt.Ranking != "" && !contains(searchers, tt.Ranking) {
+			return fmt.Errorf("tenant %q has an unknown ranking %q", host, tt.Ranking)
+		}
+
+		for _, v := range tt.Verticals {
+			if !contains(Verticals, v) {
+				return fmt.Errorf("tenant %q has an unknown vertical %q", host, v)
+			}
+		}
+	}
+
+	return nil
+}
+
+// tenant is the property a request is for, with our brand and settings filling in what it leaves out.
+// A tenant without a host of its own links back to its hostname with our scheme.
+func (f *Frontend) tenant(r *http.Request) Tenant {
+	t := Tenant{
+		Brand:     f.Brand,
+		MapBoxKey: f.MapBoxKey,
+	}
+
+	host := hostname(r.Host)
+	tt, ok := f.Tenants[host]
+	if !ok {
+		return t
+	}
+
+	scheme := "https"
+	if u, err := url.Parse(f.Brand.Host); err == nil && u.Scheme != "" {
+		scheme = u.Scheme
+	}
+	t.Host = scheme + "://" + host
+
+	for _, s := range []struct {
+		dst *string
+		src string
+	}{
+		{&t.Name, tt.Name},
+		{&t.Host, tt.Host},
+		{&t.TagLine, tt.TagLine},
+		{&t.Logo, tt.Logo},
+		{&t.SmallLogo, tt.SmallLogo},
+		{&t.MapBoxKey, tt.MapBoxKey},
+		{&t.Ranking, tt.Ranking},
+	} {
+		if s.src != "" {
+			*s.dst = s.src
+		}
+	}
+
+	t.DefaultBangs = tt.DefaultBangs
+	t.Verticals = tt.Verticals
+
+	return t
+}
+
+// hostname is the host of a Host header without its port or a trailing dot
+func hostname(host string) string {
+	if h, _, err := net.SplitHostPort(host); err == nil {
+		host = h
+	}
+
+	return strings.TrimSuffix(strings.ToLower(host), ".")
+}
+
+func contains(list []string, s string) bool {
+	for _, l := range list {
+		if l == s {
+			return true
+		}
+	}
+	return false
+}
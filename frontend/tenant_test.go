@@ -0,0 +1,154 @@
+package frontend
+
+import (
+	"net/http/httptest"
+	"reflect"
+	"testing"
+
+	"golang.org/x/text/language"
+)
+
+func TestTenant(t *testing.T) {
+	ours := Brand{Name: "Jive Search", Host: "https://jivesearch.com", TagLine: "ours", Logo: "<svg>jive</svg>", SmallLogo: "<svg>j</svg>"}
+
+	f := &Frontend{
+		Brand:     ours,
+		MapBoxKey: "ourkey",
+		Tenants: Tenants{
+			"search.example.org": {
+				Brand:        Brand{Name: "Example Search", Logo: "<svg>example</svg>"},
+				DefaultBangs: []string{"w"},
+				Verticals:    []string{"images"},
+				Ranking:      "yandex",
+			},
+			"find.example.net": {
+				Brand:     Brand{Name: "Finder", Host: "https://www.example.net"},
+				MapBoxKey: "theirkey",
+			},
+		},
+	}
+
+	for _, c := range []struct {
+		name string
+		host string
+		want Tenant
+	}{
+		{"ours", "jivesearch.com", Tenant{Brand: ours, MapBoxKey: "ourkey"}},
+		{"unknown", "other.example.org", Tenant{Brand: ours, MapBoxKey: "ourkey"}},
+		{
+			"tenant", "Search.Example.org:8080",
+			Tenant{
+				Brand:        Brand{Name: "Example Search", Host: "https://search.example.org", TagLine: "ours", Logo: "<svg>example</svg>", SmallLogo: "<svg>j</svg>"},
+				MapBoxKey:    "ourkey",
+				DefaultBangs: []string{"w"},
+				Verticals:    []string{"images"},
+				Ranking:      "yandex",
+			},
+		},
+		{
+			"own host", "find.example.net.",
+			Tenant{
+				Brand:     Brand{Name: "Finder", Host: "https://www.example.net", TagLine: "ours", Logo: "<svg>jive</svg>", SmallLogo: "<svg>j</svg>"},
+				MapBoxKey: "theirkey",
+			},
+		},
+	} {
+		t.Run(c.name, func(t *testing.T) {
+			r := httptest.NewRequest("GET", "/", nil)
+			r.Host = c.host
+
+			if got := f.tenant(r); !reflect.DeepEqual(got, c.want) {
+				t.Fatalf("got %+v; want %+v", got, c.want)
+			}
+		})
+	}
+}
+
+func TestTenantData(t *testing.T) {
+	bngs, err := bangsFromConfig()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	f := &Frontend{
+		Bangs: bngs,
+		Brand: Brand{Name: "Jive Search"},
+		Document: Document{
+			Matcher: language.NewMatcher([]language.Tag{language.English}),
+		},
+		Tenants: Tenants{
+			"search.example.org": {
+				Brand:        Brand{Name: "Example Search"},
+				DefaultBangs: []string{"w", "nope"},
+				Verticals:    []string{"images"},
+				Ranking:      "yandex",
+			},
+		},
+	}
+
+	for _, c := range []struct {
+		name     string
+		host     string
+		target   string
+		brand    string
+		t        string
+		bangs    []string
+		ranking  string
+		shopping bool
+	}{
+		{"ours", "jivesearch.com", "/?q=jive&t=shopping", "Jive Search", "shopping", []string{"g", "b", "a", "yt"}, "", true},
+		{"tenant", "search.example.org", "/?q=jive&t=images", "Example Search", "images", []string{"w"}, "yandex", false},
+		{"tab turned off", "search.example.org", "/?q=jive&t=shopping", "Example Search", "", []string{"w"}, "yandex", false},
+		{"their own bangs", "search.example.org", "/?q=jive&b=a", "Example Search", "", []string{"a"}, "yandex", false},
+	} {
+		t.Run(c.name, func(t *testing.T) {
+			r := httptest.NewRequest("GET", c.target, nil)
+			r.Host = c.host
+
+			d, err := f.getData(r)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if d.Brand.Name != c.brand || d.Context.T != c.t || d.Context.Ranking != c.ranking {
+				t.Fatalf("got brand %q, t %q and ranking %q; want %q, %q and %q", d.Brand.Name, d.Context.T, d.Context.Ranking, c.brand, c.t, c.ranking)
+			}
+
+			triggers := []string{}
+			for _, b := range d.Context.DefaultBangs {
+				triggers = append(triggers, b.Trigger)
+			}
+
+			if !reflect.DeepEqual(triggers, c.bangs) {
+				t.Fatalf("got bangs %v; want %v", triggers, c.bangs)
+			}
+
+			if d.Context.Vertical("shopping") != c.shopping {
+				t.Fatalf("got shopping %v; want %v", d.Context.Vertical("shopping"), c.shopping)
+			}
+		})
+	}
+}
+
+func TestTenantsValidate(t *testing.T) {
+	rankings := []string{"elasticsearch", "yandex"}
+
+	for _, c := range []struct {
+		name    string
+		tenants Tenants
+		valid   bool
+	}{
+		{"none", nil, true},
+		{"valid", Tenants{"search.example.org": {Verticals: []string{"images", "maps"}, Ranking: "yandex"}}, true},
+		{"uppercase", Tenants{"Search.example.org": {}}, false},
+		{"port", Tenants{"search.example.org:80": {}}, false},
+		{"unknown ranking", Tenants{"search.example.org": {Ranking: "google"}}, false},
+		{"unknown vertical", Tenants{"search.example.org": {Verticals: []string{"news"}}}, false},
+	} {
+		t.Run(c.name, func(t *testing.T) {
+			if err := c.tenants.Validate(rankings); (err == nil) != c.valid {
+				t.Fatalf("got error %v; want valid %v", err, c.valid)
+			}
+		})
+	}
+}
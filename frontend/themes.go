@@ -0,0 +1,75 @@
+package frontend
+
+import (
+	"net/http"
+	"sort"
+	"strings"
+	"time"
+)
+
+// themes are the bundles of CSS variables in /static/themes.
+// Without one we follow the browser's light or dark preference (prefers-color-scheme).
+var themes = map[string]bool{
+	"light": true,
+	"dark":  true,
+	"night": true,
+}
+
+// autoTheme forgets the user's theme so we go back to following their browser
+const autoTheme = "auto"
+
+const themeCookie = "theme"
+
+// Themes are the choices for our theme picker
+func (c *Context) Themes() []string {
+	th := []string{}
+	for t := range themes {
+		th = append(th, t)
+	}
+
+	sort.Strings(th)
+	return append([]string{autoTheme}, th...)
+}
+
+// setTheme uses the "theme" param or, failing that, the theme they saved
+func (c *Context) setTheme(r *http.Request) *Context {
+	th := strings.ToLower(r.FormValue("theme"))
+	if th == "" {
+		if ck, err := r.Cookie(themeCookie); err == nil {
+			th = ck.Value
+		}
+	}
+
+	if themes[th] {
+		c.Theme = th
+	}
+
+	return c
+}
+
+// rememberTheme saves the theme picked with the "theme" param so it
+// doesn't have to be in every url. With Do Not Track the theme lasts only as long as the param.
+func rememberTheme(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if doNotTrack(r) {
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		switch th := strings.ToLower(r.URL.Query().Get("theme")); {
+		case th == autoTheme:
+			http.SetCookie(w, &http.Cookie{Name: themeCookie, Path: "/", MaxAge: -1})
+		case themes[th]:
+			http.SetCookie(w, &http.Cookie{
+				Name:     themeCookie,
+				Value:    th,
+				Path:     "/",
+				Expires:  now().Add(365 * 24 * time.Hour),
+				HttpOnly: true,
+				SameSite: http.SameSiteLaxMode,
+			})
+		}
+
+		next.ServeHTTP(w, r)
+	})
+}
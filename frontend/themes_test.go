@@ -0,0 +1,80 @@
+package frontend
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+)
+
+func TestSetTheme(t *testing.T) {
+	for _, c := range []struct {
+		name   string
+		target string
+		cookie string
+		want   string
+	}{
+		{"none", "/", "", ""},
+		{"param", "/?theme=Dark", "", "dark"},
+		{"saved", "/", "night", "night"},
+		{"param over saved", "/?theme=light", "night", "light"},
+		{"auto ignores saved", "/?theme=auto", "night", ""},
+		{"unknown", "/?theme=neon", "", ""},
+	} {
+		t.Run(c.name, func(t *testing.T) {
+			r := httptest.NewRequest("GET", c.target, nil)
+			if c.cookie != "" {
+				r.AddCookie(&http.Cookie{Name: themeCookie, Value: c.cookie})
+			}
+
+			if got := (&Context{}).setTheme(r).Theme; got != c.want {
+				t.Fatalf("got %q; want %q", got, c.want)
+			}
+		})
+	}
+}
+
+func TestRememberTheme(t *testing.T) {
+	for _, c := range []struct {
+		name   string
+		target string
+		dnt    bool
+		want   string
+		maxAge int
+	}{
+		{"none", "/?q=hello", false, "", 0},
+		{"save", "/?theme=dark", false, "dark", 0},
+		{"forget", "/?theme=auto", false, "", -1},
+		{"unknown", "/?theme=neon", false, "", 0},
+		{"do not track", "/?theme=dark", true, "", 0},
+	} {
+		t.Run(c.name, func(t *testing.T) {
+			req := httptest.NewRequest("GET", c.target, nil)
+			if c.dnt {
+				req.Header.Set("Sec-GPC", "1")
+			}
+
+			w := httptest.NewRecorder()
+			rememberTheme(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {})).ServeHTTP(w, req)
+
+			cookies := w.Result().Cookies()
+			if c.want == "" && c.maxAge == 0 {
+				if len(cookies) != 0 {
+					t.Fatalf("got cookies %+v; want none", cookies)
+				}
+				return
+			}
+
+			if len(cookies) != 1 || cookies[0].Name != themeCookie || cookies[0].Value != c.want || cookies[0].MaxAge != c.maxAge {
+				t.Fatalf("got cookies %+v; want theme %q", cookies, c.want)
+			}
+		})
+	}
+}
+
+func TestThemes(t *testing.T) {
+	want := []string{"auto", "dark", "light", "night"}
+	if got := (&Context{}).Themes(); !reflect.DeepEqual(got, want) {
+		t.Fatalf("got %v; want %v", got, want)
+	}
+}
@@ -0,0 +1,226 @@
+package frontend
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/jivesearch/jivesearch/log"
+	img "github.com/jivesearch/jivesearch/search/image"
+)
+
+// Thumbnail is an image resized by our proxy, ready to be inlined in a page
+type Thumbnail struct {
+	URL         string `json:"url"`
+	ContentType string `json:"content_type,omitempty"`
+	Base64      string `json:"base64,omitempty"`
+	Err         string `json:"error,omitempty"`
+}
+
+// Thumbnails are fetched from our image proxy in batches
+type Thumbnails struct {
+	Proxy       http.Handler  // our image proxy, set by our router. Without it we go through Host.
+	Concurrency int           // how many images of a batch are fetched at once
+	Timeout     time.Duration // for each image
+}
+
+const maxThumbnails = 100
+
+var (
+	errMissingImages = fmt.Errorf("missing images")
+	errTooManyImages = fmt.Errorf("too many images. The most is %d", maxThumbnails)
+	errNotThumbnail  = fmt.Errorf("not a link to our image proxy")
+	errImageTimeout  = fmt.Errorf("timed out fetching the image")
+)
+
+// thumbnailsHandler fetches and resizes many images in one round trip.
+// Each "u" param is a signed link to our image proxy, e.g. /image/225x,s{signature}/{url}.
+func (f *Frontend) thumbnailsHandler(w http.ResponseWriter, r *http.Request) *response {
+	if err := r.ParseForm(); err != nil {
+		return &response{
+			status: http.StatusBadRequest,
+			err:    err,
+		}
+	}
+
+	links := r.Form["u"]
+
+	switch {
+	case len(links) == 0:
+		return &response{
+			status: http.StatusBadRequest,
+			err:    errMissingImages,
+		}
+	case len(links) > maxThumbnails:
+		return &response{
+			status: http.StatusBadRequest,
+			err:    errTooManyImages,
+		}
+	}
+
+	return &response{
+		status:   http.StatusOK,
+		template: "json",
+		data: struct {
+			Images []Thumbnail `json:"images"`
+		}{f.thumbnails(r.Context(), links)},
+	}
+}
+
+// thumbnails fetches images from our proxy a few at a time.
+// They are in the same order as their links.
+func (f *Frontend) thumbnails(ctx context.Context, links []string) []Thumbnail {
+	n := f.Thumbnails.Concurrency
+	if n < 1 {
+		n = 1
+	}
+
+	res := make([]Thumbnail, len(links))
+	sem := make(chan struct{}, n)
+
+	var wg sync.WaitGroup
+	for i, u := range links {
+		wg.Add(1)
+		sem <- struct{}{}
+
+		go func(i int, u string) {
+			defer func() {
+				<-sem
+				wg.Done()
+			}()
+
+			res[i] = Thumbnail{URL: u}
+
+			ct, b, err := f.thumbnail(ctx, u)
+			if err != nil {
+				res[i].Err = err.Error()
+				return
+			}
+
+			res[i].ContentType = ct
+			res[i].Base64 = base64.StdEncoding.EncodeToString(b)
+		}(i, u)
+	}
+
+	wg.Wait()
+	return res
+}
+
+// thumbnail fetches an image from our proxy. We call the proxy
+// in process when we can rather than make a request to ourselves.
+func (f *Frontend) thumbnail(ctx context.Context, u string) (string, []byte, error) {
+	if !strings.HasPrefix(u, "/image/") {
+		return "", nil, errNotThumbnail
+	}
+
+	if f.Thumbnails.Timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, f.Thumbnails.Timeout)
+		defer cancel()
+	}
+
+	if f.Thumbnails.Proxy == nil {
+		return f.thumbnailFromHost(ctx, u)
+	}
+
+	req, err := http.NewRequest("GET", u, nil)
+	if err != nil {
+		return "", nil, err
+	}
+
+	// the proxy may not stop when our context is done so we don't wait for it
+	rec := &recorder{header: http.Header{}, status: http.StatusOK}
+	done := make(chan struct{})
+	go func() {
+		f.Thumbnails.Proxy.ServeHTTP(rec, req.WithContext(ctx))
+		close(done)
+	}()
+
+	select {
+	case <-done:
+	case <-ctx.Done():
+		return "", nil, errImageTimeout
+	}
+
+	if rec.status != http.StatusOK {
+		return "", nil, fmt.Errorf("image proxy returned %d: %v", rec.status, strings.TrimSpace(rec.body.String()))
+	}
+
+	return rec.header.Get("Content-Type"), rec.body.Bytes(), nil
+}
+
+func (f *Frontend) thumbnailFromHost(ctx context.Context, u string) (string, []byte, error) {
+	req, err := http.NewRequest("GET", f.Host+u, nil)
+	if err != nil {
+		return "", nil, err
+	}
+
+	resp, err := f.Images.Client.Do(req.WithContext(ctx))
+	if err != nil {
+		return "", nil, err
+	}
+
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return "", nil, fmt.Errorf("image proxy returned %d", resp.StatusCode)
+	}
+
+	b, err := ioutil.ReadAll(resp.Body)
+	return resp.Header.Get("Content-Type"), b, err
+}
+
+// inlineThumbnails is whether an api user asked for the thumbnails in the response, e.g. &base64=true
+func inlineThumbnails(r *http.Request) bool {
+	ok, _ := strconv.ParseBool(r.FormValue("base64"))
+	return ok
+}
+
+// inlineAPI inlines the thumbnails of an api response if asked
+func (f *Frontend) inlineAPI(r *http.Request, ir *img.Results) {
+	if ir != nil && inlineThumbnails(r) && !f.Tor {
+		f.inline(r.Context(), ir.Images)
+	}
+}
+
+// inline embeds the thumbnails of images as base64
+func (f *Frontend) inline(ctx context.Context, images []*img.Image) {
+	links := make([]string, len(images))
+	for i, im := range images {
+		links[i] = thumbnail(im.ID)
+	}
+
+	for i, t := range f.thumbnails(ctx, links) {
+		if t.Err != "" {
+			log.Debug.Println(t.Err)
+			continue
+		}
+		images[i].Base64 = t.Base64
+	}
+}
+
+// recorder holds the response of a handler we call in process
+type recorder struct {
+	header http.Header
+	body   bytes.Buffer
+	status int
+}
+
+func (r *recorder) Header() http.Header {
+	return r.header
+}
+
+func (r *recorder) Write(b []byte) (int, error) {
+	return r.body.Write(b)
+}
+
+func (r *recorder) WriteHeader(status int) {
+	r.status = status
+}
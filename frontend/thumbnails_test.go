@@ -0,0 +1,192 @@
+package frontend
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"reflect"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	img "github.com/jivesearch/jivesearch/search/image"
+)
+
+func TestThumbnailsHandler(t *testing.T) {
+	f := &Frontend{}
+	f.Thumbnails.Proxy = http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "image/jpeg")
+		fmt.Fprint(w, r.URL.Path)
+	})
+
+	var many []string
+	for i := 0; i <= maxThumbnails; i++ {
+		many = append(many, fmt.Sprintf("/image/225x,s/http:/example.com/%d.jpg", i))
+	}
+
+	for _, c := range []struct {
+		name   string
+		links  []string
+		status int
+		want   []Thumbnail
+	}{
+		{"missing", nil, http.StatusBadRequest, nil},
+		{"too many", many, http.StatusBadRequest, nil},
+		{
+			"batch",
+			[]string{"/image/225x,sabc/http:/example.com/cat.jpg", "https://example.com/dog.jpg"},
+			http.StatusOK,
+			[]Thumbnail{
+				{
+					URL:         "/image/225x,sabc/http:/example.com/cat.jpg",
+					ContentType: "image/jpeg",
+					Base64:      "L2ltYWdlLzIyNXgsc2FiYy9odHRwOi9leGFtcGxlLmNvbS9jYXQuanBn",
+				},
+				{URL: "https://example.com/dog.jpg", Err: errNotThumbnail.Error()},
+			},
+		},
+	} {
+		t.Run(c.name, func(t *testing.T) {
+			r := httptest.NewRequest("POST", "/api/v1/images/batch", strings.NewReader(url.Values{"u": c.links}.Encode()))
+			r.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+
+			resp := f.thumbnailsHandler(httptest.NewRecorder(), r)
+			if resp.status != c.status {
+				t.Fatalf("got status %d; want %d", resp.status, c.status)
+			}
+
+			if c.want == nil {
+				return
+			}
+
+			b, err := json.Marshal(resp.data)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			var got struct {
+				Images []Thumbnail `json:"images"`
+			}
+			if err := json.Unmarshal(b, &got); err != nil {
+				t.Fatal(err)
+			}
+
+			if !reflect.DeepEqual(got.Images, c.want) {
+				t.Fatalf("got %+v; want %+v", got.Images, c.want)
+			}
+		})
+	}
+}
+
+func TestThumbnails(t *testing.T) {
+	var running, most int32
+
+	f := &Frontend{}
+	f.Thumbnails.Concurrency = 2
+	f.Thumbnails.Timeout = 50 * time.Millisecond
+	f.Thumbnails.Proxy = http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		n := atomic.AddInt32(&running, 1)
+		defer atomic.AddInt32(&running, -1)
+
+		for {
+			m := atomic.LoadInt32(&most)
+			if n <= m || atomic.CompareAndSwapInt32(&most, m, n) {
+				break
+			}
+		}
+
+		switch {
+		case strings.HasSuffix(r.URL.Path, "slow.jpg"):
+			time.Sleep(time.Second)
+		case strings.HasSuffix(r.URL.Path, "missing.jpg"):
+			http.Error(w, "not found", http.StatusNotFound)
+			return
+		default:
+			time.Sleep(10 * time.Millisecond)
+		}
+
+		w.Header().Set("Content-Type", "image/gif")
+		fmt.Fprint(w, "img")
+	})
+
+	links := []string{
+		"/image/225x,s/http:/example.com/1.jpg",
+		"/image/225x,s/http:/example.com/slow.jpg",
+		"/image/225x,s/http:/example.com/2.jpg",
+		"/image/225x,s/http:/example.com/missing.jpg",
+		"/image/225x,s/http:/example.com/3.jpg",
+	}
+
+	got := f.thumbnails(httptest.NewRequest("GET", "/", nil).Context(), links)
+
+	want := []Thumbnail{
+		{URL: links[0], ContentType: "image/gif", Base64: "aW1n"},
+		{URL: links[1], Err: errImageTimeout.Error()},
+		{URL: links[2], ContentType: "image/gif", Base64: "aW1n"},
+		{URL: links[3], Err: "image proxy returned 404: not found"},
+		{URL: links[4], ContentType: "image/gif", Base64: "aW1n"},
+	}
+
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("got %+v; want %+v", got, want)
+	}
+
+	if most > 2 {
+		t.Fatalf("fetched %d images at once; want at most 2", most)
+	}
+}
+
+func TestThumbnailsFromHost(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "image/png")
+		fmt.Fprint(w, "img")
+	}))
+	defer ts.Close()
+
+	f := &Frontend{}
+	f.Host = ts.URL
+	f.Images.Client = ts.Client()
+
+	got := f.thumbnails(httptest.NewRequest("GET", "/", nil).Context(), []string{"/image/225x,s/http:/example.com/cat.jpg"})
+	want := []Thumbnail{
+		{URL: "/image/225x,s/http:/example.com/cat.jpg", ContentType: "image/png", Base64: "aW1n"},
+	}
+
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("got %+v; want %+v", got, want)
+	}
+}
+
+func TestInlineAPI(t *testing.T) {
+	f := &Frontend{}
+	f.Thumbnails.Proxy = http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, "img")
+	})
+
+	for _, c := range []struct {
+		name string
+		u    string
+		tor  bool
+		want string
+	}{
+		{"lazy", "/api/v1/images?q=cats", false, ""},
+		{"base64", "/api/v1/images?q=cats&base64=true", false, "aW1n"},
+		{"tor", "/api/v1/images?q=cats&base64=true", true, ""},
+	} {
+		t.Run(c.name, func(t *testing.T) {
+			f.Tor = c.tor
+			ir := &img.Results{
+				Images: []*img.Image{{ID: "https://example.com/cat.jpg"}},
+			}
+
+			f.inlineAPI(httptest.NewRequest("GET", c.u, nil), ir)
+
+			if got := ir.Images[0].Base64; got != c.want {
+				t.Fatalf("got %q; want %q", got, c.want)
+			}
+		})
+	}
+}
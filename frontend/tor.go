@@ -0,0 +1,43 @@
+package frontend
+
+import (
+	"net/http"
+)
+
+// loopback replaces the address of each visitor in tor mode.
+// Analytics and logs never see where a request came from and rate limits
+// put every visitor without an API key in one bucket per route.
+const loopback = "127.0.0.1:0"
+
+// private strips anything that identifies the user in tor mode. Links
+// followed from our pages don't send a referrer and the client IP is
+// dropped before any other handler sees the request.
+func (f *Frontend) private(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if !f.Tor {
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		w.Header().Set("Referrer-Policy", "no-referrer")
+
+		r.Header.Del("X-Forwarded-For")
+		r.Header.Del("X-Real-IP")
+		r.RemoteAddr = loopback
+
+		next.ServeHTTP(w, r)
+	})
+}
+
+// offline is for routes that fetch from third parties on behalf of the user.
+// They don't exist in tor mode.
+func (f *Frontend) offline(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if f.Tor {
+			http.NotFound(w, r)
+			return
+		}
+
+		next.ServeHTTP(w, r)
+	})
+}
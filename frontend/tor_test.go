@@ -0,0 +1,96 @@
+package frontend
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/jivesearch/jivesearch/frontend/cache"
+	"github.com/jivesearch/jivesearch/instant"
+)
+
+func TestPrivate(t *testing.T) {
+	for _, c := range []struct {
+		name     string
+		tor      bool
+		ip       string
+		referrer string
+	}{
+		{"off", false, "8.8.8.8", ""},
+		{"tor mode", true, "127.0.0.1", "no-referrer"},
+	} {
+		t.Run(c.name, func(t *testing.T) {
+			f := &Frontend{Tor: c.tor}
+
+			var got string
+			h := f.private(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				got = instant.IPAddress(r).String()
+			}))
+
+			r := httptest.NewRequest("GET", "/?q=something", nil)
+			r.RemoteAddr = "10.0.0.1:1234"
+			r.Header.Set("X-Forwarded-For", "8.8.8.8")
+
+			w := httptest.NewRecorder()
+			h.ServeHTTP(w, r)
+
+			if got != c.ip {
+				t.Fatalf("got ip %q; want %q", got, c.ip)
+			}
+
+			if p := w.Header().Get("Referrer-Policy"); p != c.referrer {
+				t.Fatalf("got referrer policy %q; want %q", p, c.referrer)
+			}
+		})
+	}
+}
+
+func TestOffline(t *testing.T) {
+	for _, c := range []struct {
+		name   string
+		tor    bool
+		status int
+	}{
+		{"off", false, http.StatusOK},
+		{"tor mode", true, http.StatusNotFound},
+	} {
+		t.Run(c.name, func(t *testing.T) {
+			f := &Frontend{Tor: c.tor}
+			h := f.offline(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+
+			w := httptest.NewRecorder()
+			h.ServeHTTP(w, httptest.NewRequest("GET", "/proxy?u=https://www.example.com", nil))
+
+			if w.Code != c.status {
+				t.Fatalf("got status %d; want %d", w.Code, c.status)
+			}
+		})
+	}
+}
+
+func TestTorRateLimit(t *testing.T) {
+	f := &Frontend{
+		Tor: true,
+		RateLimit: RateLimit{
+			Limits: map[string]Limit{
+				"search": {Rate: .01, Burst: 2},
+			},
+		},
+	}
+	f.Cache.Cacher = &cache.Simple{M: make(map[string]cache.Value)}
+
+	h := f.private(f.rateLimit("search", http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {})))
+
+	for i, want := range []int{http.StatusOK, http.StatusOK, http.StatusTooManyRequests} {
+		r := httptest.NewRequest("GET", "/?q=something", nil)
+		r.RemoteAddr = fmt.Sprintf("1.2.3.%d:1234", i) // never seen in tor mode
+
+		w := httptest.NewRecorder()
+		h.ServeHTTP(w, r)
+
+		if w.Code != want {
+			t.Fatalf("request %d: got status %d; want %d", i, w.Code, want)
+		}
+	}
+}
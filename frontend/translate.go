@@ -0,0 +1,24 @@
+package frontend
+
+import (
+	"github.com/jivesearch/jivesearch/frontend/i18n"
+)
+
+// Tr translates the text of our templates into the user's language, e.g. {{.Context.Tr "Next"}}.
+// The receivers here aren't pointers as some of our pages hold a Context rather than a *Context.
+func (c Context) Tr(key string, a ...interface{}) string {
+	return i18n.New(c.Preferred...).Sprintf(key, a...)
+}
+
+// Lang is the language our page is shown in, for the lang attribute of <html>
+func (c Context) Lang() string {
+	return i18n.New(c.Preferred...).Language().String()
+}
+
+// Dir is the direction of the text of our page
+func (c Context) Dir() string {
+	if c.RTL {
+		return "rtl"
+	}
+	return "ltr"
+}
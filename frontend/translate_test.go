@@ -0,0 +1,52 @@
+package frontend
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/jivesearch/jivesearch/search"
+	"golang.org/x/text/language"
+)
+
+func TestTranslateTemplates(t *testing.T) {
+	ParseTemplates()
+
+	for _, c := range []struct {
+		name      string
+		preferred []language.Tag
+		rtl       bool
+		want      []string
+	}{
+		{"english", nil, false, []string{`<html lang="en" dir="ltr">`, "Related searches", ">Next<"}},
+		{"german", []language.Tag{language.MustParse("de-CH"), language.French}, false, []string{`<html lang="de" dir="ltr">`, "Ähnliche Suchanfragen", ">Weiter<", "1.234 Ergebnisse"}},
+		{"arabic", []language.Tag{language.Arabic}, true, []string{`<html lang="ar" dir="rtl">`, "التالي"}},
+	} {
+		t.Run(c.name, func(t *testing.T) {
+			d := data{
+				Brand: Brand{Name: "Jive Search"},
+				Context: &Context{
+					Q:         "jive",
+					Preferred: c.preferred,
+					RTL:       c.rtl,
+				},
+				Results: Results{
+					Search: &search.Results{
+						Count:   1234,
+						Related: []string{"jive talk"},
+					},
+				},
+			}
+
+			var b strings.Builder
+			if err := templates["search"].Execute(&b, d); err != nil {
+				t.Fatal(err)
+			}
+
+			for _, w := range c.want {
+				if !strings.Contains(b.String(), w) {
+					t.Fatalf("want %q in our page", w)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,88 @@
+package frontend
+
+import (
+	"net/http"
+	"net/url"
+	"time"
+
+	"github.com/jivesearch/jivesearch/frontend/analytics"
+	"github.com/jivesearch/jivesearch/instant"
+	"github.com/jivesearch/jivesearch/log"
+	"github.com/jivesearch/jivesearch/suggest"
+	"golang.org/x/text/language"
+)
+
+// Warmer fills our cache with the web results and instant answers of the trending
+// queries before our users ask for them, so a spike in a query doesn't spike our latency
+type Warmer struct {
+	Interval time.Duration
+	Period   time.Duration  // how far back our query log is searched for trending queries
+	Top      int            // the number of trending queries
+	Locales  []language.Tag // e.g. en-US & de-DE. Each query is warmed for each of them.
+}
+
+// Warm warms our cache every Interval. It doesn't return.
+func (f *Frontend) Warm(w *Warmer) {
+	for {
+		n, err := f.warm(w)
+		if err != nil {
+			log.Info.Printf("unable to warm our cache: %v\n", err)
+		}
+
+		log.Debug.Printf("warmed our cache with %d trending queries\n", n)
+		time.Sleep(w.Interval)
+	}
+}
+
+// warm fetches the trending queries for each locale, as if a user there had searched them.
+// What is already cached is left alone.
+func (f *Frontend) warm(w *Warmer) (int, error) {
+	t := now()
+	events, err := f.Analytics.Events(t.Add(-w.Period), t)
+	if err != nil {
+		return 0, err
+	}
+
+	n := 0
+	for _, q := range analytics.Trending(events, w.Top) {
+		if suggest.PII(q) {
+			continue
+		}
+
+		for _, locale := range w.Locales {
+			if err := f.warmQuery(q, locale); err != nil {
+				return n, err
+			}
+		}
+		n++
+	}
+
+	return n, nil
+}
+
+// warmQuery builds the request a user would send so the cache keys are the same as theirs
+func (f *Frontend) warmQuery(q string, locale language.Tag) error {
+	r, err := http.NewRequest("GET", "/?"+url.Values{"q": {q}}.Encode(), nil)
+	if err != nil {
+		return err
+	}
+
+	r.Header.Set("Accept-Language", locale.String())
+	r.RemoteAddr = loopback
+
+	d, err := f.getData(r)
+	if err != nil {
+		return err
+	}
+
+	if _, _, ok := f.Bangs.Detect(d.Context.Q, d.Context.Region, d.Context.lang); ok {
+		return nil
+	}
+
+	f.searchResults(r, d, d.Context.lang, d.Context.Region)
+
+	ic := make(chan instant.Data, 1)
+	f.getAnswer(r, d, ic)
+
+	return nil
+}
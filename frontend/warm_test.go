@@ -0,0 +1,100 @@
+package frontend
+
+import (
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/jivesearch/jivesearch/frontend/analytics"
+	"github.com/jivesearch/jivesearch/frontend/cache"
+	"github.com/jivesearch/jivesearch/instant"
+	"golang.org/x/text/language"
+)
+
+func TestWarm(t *testing.T) {
+	bngs, err := bangsFromConfig()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	store := &analytics.Simple{}
+	if err := store.Setup(); err != nil {
+		t.Fatal(err)
+	}
+
+	now = func() time.Time {
+		return time.Date(2018, 02, 06, 11, 30, 0, 0, time.UTC)
+	}
+
+	for _, e := range []*analytics.Event{
+		{Time: now().Add(-time.Hour), User: "a", Query: "some query", Vertical: "web"},
+		{Time: now().Add(-time.Hour), User: "b", Query: "some query", Vertical: "web"},
+		{Time: now().Add(-time.Hour), User: "a", Query: "bob@example.com", Vertical: "web"},
+		{Time: now().Add(-time.Hour), User: "b", Query: "bob@example.com", Vertical: "web"},
+		{Time: now().Add(-48 * time.Hour), User: "a", Query: "old news", Vertical: "web"},
+		{Time: now().Add(-48 * time.Hour), User: "b", Query: "old news", Vertical: "web"},
+	} {
+		if err := store.Insert(e); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	matcher := language.NewMatcher([]language.Tag{language.English, language.French})
+
+	f := &Frontend{
+		Analytics: Analytics{Store: store},
+		Document:  Document{Matcher: matcher},
+		Bangs:     bngs,
+		Instant: &instant.Instant{
+			WikipediaFetcher:     &mockWikipediaFetcher{},
+			StackOverflowFetcher: &mockStackOverflowFetcher{},
+		},
+		Suggest:   &mockSuggester{},
+		Search:    &mockSearch{},
+		Wikipedia: Wikipedia{Matcher: matcher},
+	}
+	f.Cache.Cacher = &cache.Simple{M: map[string]cache.Value{}}
+	f.Cache.Instant = time.Hour
+	f.Cache.Search = time.Hour
+
+	w := &Warmer{
+		Period:  24 * time.Hour,
+		Top:     10,
+		Locales: []language.Tag{language.MustParse("en-US"), language.MustParse("fr-FR")},
+	}
+
+	n, err := f.warm(w)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if n != 1 {
+		t.Fatalf("got %d queries warmed; want 1", n)
+	}
+
+	// a user searching a trending query gets it from our cache
+	f.Cache.Stats = cache.NewStats(now())
+	for _, c := range []struct {
+		target string
+		locale string
+	}{
+		{"/?q=some+query", "en-US"},
+		{"/?q=Some+Query", "fr-FR"},
+		{"/?q=not+trending", "en-US"},
+	} {
+		req, err := http.NewRequest("GET", c.target, nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		req.Header.Set("Accept-Language", c.locale)
+
+		if rsp := f.searchHandler(nil, req); rsp.status != http.StatusOK {
+			t.Fatalf("got status %d; want %d", rsp.status, http.StatusOK)
+		}
+	}
+
+	want := cache.Count{Hits: 2, Misses: 1, HitRate: 2.0 / 3}
+	if got := f.Cache.Stats.Counts()["search"]; got != want {
+		t.Fatalf("got %+v; want %+v", got, want)
+	}
+}
@@ -3,7 +3,6 @@ module github.com/jivesearch/jivesearch
 go 1.12
 
 require (
-	github.com/Knetic/govaluate v3.0.0+incompatible
 	github.com/PuerkitoBio/goquery v1.5.0
 	github.com/abursavich/nett v0.0.0-20150117192851-f31118c7aeb9
 	github.com/antchfx/htmlquery v1.0.0 // indirect
@@ -13,6 +12,7 @@ require (
 	github.com/dsnet/compress v0.0.1
 	github.com/dustin/go-humanize v1.0.0
 	github.com/evanoberholster/timezoneLookup v0.0.0-20181028095704-4a3a5b71a424
+	github.com/fsnotify/fsnotify v1.4.7
 	github.com/garyburd/redigo v1.6.0
 	github.com/go-redis/redis v6.15.2+incompatible // indirect
 	github.com/gobwas/glob v0.2.3 // indirect
@@ -20,6 +20,7 @@ require (
 	github.com/gocolly/redisstorage v0.0.0-20180819125329-cc8d514304a2
 	github.com/golang/snappy v0.0.1 // indirect
 	github.com/gorilla/mux v1.7.0
+	github.com/gorilla/websocket v1.4.2
 	github.com/inconshreveable/mousetrap v1.0.0 // indirect
 	github.com/jarcoal/httpmock v1.0.1
 	github.com/kennygrant/sanitize v1.2.4 // indirect
@@ -35,6 +36,7 @@ require (
 	github.com/pariz/gountries v0.0.0-20171019111738-adb00f6513a3
 	github.com/pkg/errors v0.8.1
 	github.com/rafaeljusto/redigomock v0.0.0-20190202135759-257e089e14a1
+	github.com/rwcarlsen/goexif v0.0.0-20141222211634-41dad3aa0833
 	github.com/saintfish/chardet v0.0.0-20120816061221-3af4cd4741ca // indirect
 	github.com/spf13/afero v1.2.2
 	github.com/spf13/cobra v0.0.3
@@ -44,7 +46,8 @@ require (
 	github.com/tdewolff/parse v2.3.4+incompatible // indirect
 	github.com/temoto/robotstxt v0.0.0-20180810133444-97ee4a9ee6ea
 	github.com/vmihailenco/msgpack v4.0.4+incompatible // indirect
-	go.etcd.io/bbolt v1.3.2 // indirect
+	go.etcd.io/bbolt v1.3.2
+	golang.org/x/image v0.0.0-20171214225156-12117c17ca67
 	golang.org/x/net v0.0.0-20190328230028-74de082e2cca
 	golang.org/x/text v0.3.0
 	gopkg.in/DATA-DOG/go-sqlmock.v2 v2.0.0-20180914054222-c19298f520d0
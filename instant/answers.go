@@ -2,17 +2,22 @@
 package instant
 
 import (
+	crand "crypto/rand"
 	"errors"
+	"io"
 	"math/rand"
 	"net"
 	"net/http"
 	"regexp"
+	"sort"
 	"strings"
 	"time"
 
 	"github.com/jivesearch/jivesearch/instant/breach"
 	"github.com/jivesearch/jivesearch/instant/congress"
+	"github.com/jivesearch/jivesearch/instant/dns"
 	"github.com/jivesearch/jivesearch/instant/nutrition"
+	"github.com/jivesearch/jivesearch/instant/packages"
 	"github.com/jivesearch/jivesearch/instant/status"
 	"github.com/jivesearch/jivesearch/instant/timezone"
 	"github.com/jivesearch/jivesearch/instant/whois"
@@ -22,28 +27,38 @@ import (
 	disc "github.com/jivesearch/jivesearch/instant/discography"
 	pop "github.com/jivesearch/jivesearch/instant/econ/population"
 	"github.com/jivesearch/jivesearch/instant/location"
+	"github.com/jivesearch/jivesearch/instant/media"
 	"github.com/jivesearch/jivesearch/instant/parcel"
+	"github.com/jivesearch/jivesearch/instant/repo"
 	"github.com/jivesearch/jivesearch/instant/shortener"
 	so "github.com/jivesearch/jivesearch/instant/stackoverflow"
 	"github.com/jivesearch/jivesearch/instant/stock"
 	"github.com/jivesearch/jivesearch/instant/weather"
 	"github.com/jivesearch/jivesearch/instant/wikipedia"
+	"github.com/jivesearch/jivesearch/instant/words"
 	"golang.org/x/text/language"
 )
 
 // Instant holds config information for the instant answers
 type Instant struct {
 	QueryVar           string
+	ASNFetcher         location.ASNFetcher
 	BreachFetcher      breach.Fetcher
 	CongressFetcher    congress.Fetcher
 	DiscographyFetcher disc.Fetcher
+	DNSFetcher         dns.Fetcher
 	FedExFetcher       parcel.Fetcher
 	Currency
 	GDPFetcher           ggdp.Fetcher
+	GitHubFetcher        repo.Fetcher
+	GitLabFetcher        repo.Fetcher
 	LinkShortener        shortener.Service
 	LocationFetcher      location.Fetcher
+	MediaFetcher         media.Fetcher
 	NutritionFetcher     nutrition.Fetcher
+	PackageFetcher       packages.Fetcher
 	PopulationFetcher    pop.Fetcher
+	SongwriterFetcher    disc.SongwriterFetcher
 	StackOverflowFetcher so.Fetcher
 	StatusFetcher        status.Fetcher
 	StockQuoteFetcher    stock.Fetcher
@@ -53,6 +68,8 @@ type Instant struct {
 	WeatherFetcher       weather.Fetcher
 	WHOISFetcher         whois.Fetcher
 	WikipediaFetcher     wikipedia.Fetcher
+	WordLists            words.Lists
+	Cache                *AnswerCache // optional
 }
 
 // Answerer outlines methods for an instant answer
@@ -65,11 +82,16 @@ type Answerer interface {
 	trigger() bool
 	solve(r *http.Request) Answerer
 	solution() Data
+	setConfidence(c float64)
+	setTTL(name string, ttl time.Duration)
+	cacheKey(region language.Region) (string, time.Duration)
 	tests() []test
 }
 
 // Answer holds an instant answer when triggered
 type Answer struct {
+	name        string
+	ttl         time.Duration
 	query       string
 	userAgent   string
 	language    language.Tag
@@ -86,14 +108,24 @@ type Type string
 // For mocking....MUST use time.Date(2016, 6, 5, 3, 2, 0, 0, time.UTC) in tests
 var now = func() time.Time { return time.Now().UTC() }
 
+// randReader is the cryptographically secure source for passwords and the like. Mocked in tests.
+var randReader io.Reader = crand.Reader
+
 // Data holds the returned data of an answer
 type Data struct {
-	Type      `json:"type,omitempty"`
-	Triggered bool        `json:"triggered"`
-	Solution  interface{} `json:"answer,omitempty"`
-	Err       error       `json:"-"`
+	Type       `json:"type,omitempty"`
+	Triggered  bool        `json:"triggered"`
+	Solution   interface{} `json:"answer,omitempty"`
+	Language   string      `json:"language,omitempty"` // only set when served in a fallback language
+	Confidence float64     `json:"confidence,omitempty"`
+	Widget     *Widget     `json:"widget,omitempty"`
+	Secondary  []Data      `json:"secondary,omitempty"` // runners-up when more than one answer triggered
+	Err        error       `json:"-"`
 }
 
+// maxSecondary is the most runners-up returned alongside the best answer
+const maxSecondary = 3
+
 // Triggerer detects if the answer has been triggered
 type Triggerer interface {
 	Trigger()
@@ -118,7 +150,8 @@ func (a *Answer) setQuery(r *http.Request, qv string) {
 	a.query = strings.Join(strings.Fields(q), " ") // Replace multiple whitespace w/ single whitespace
 }
 
-func getIPAddress(r *http.Request) net.IP {
+// IPAddress is the client's public IP, preferring proxy headers over the remote address
+func IPAddress(r *http.Request) net.IP {
 	maxCidrBlocks := []string{
 		"127.0.0.1/8", "10.0.0.0/8", "172.16.0.0/12", "192.168.0.0/16",
 		"169.254.0.0/16", "::1/128", "fc00::/7", "fe80::/10",
@@ -199,6 +232,35 @@ func (a *Answer) solution() Data {
 	return a.Data
 }
 
+func (a *Answer) setConfidence(c float64) {
+	a.Confidence = c
+}
+
+// Rank picks the most confident of the solved answers, with ties going to
+// the answer tried first. The runners-up become its Secondary answers.
+func Rank(solutions []Data) Data {
+	if len(solutions) == 0 {
+		return Data{}
+	}
+
+	sorted := make([]Data, len(solutions))
+	copy(sorted, solutions)
+
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return sorted[i].Confidence > sorted[j].Confidence
+	})
+
+	best := sorted[0]
+	for _, s := range sorted[1:] {
+		if len(best.Secondary) == maxSecondary || s.Confidence <= 0 {
+			break
+		}
+		best.Secondary = append(best.Secondary, s)
+	}
+
+	return best
+}
+
 type test struct {
 	query     string
 	userAgent string
@@ -11,39 +11,53 @@ import (
 	"testing"
 	"time"
 
+	"github.com/jivesearch/jivesearch/bangs"
 	"github.com/jivesearch/jivesearch/instant/breach"
 	"github.com/jivesearch/jivesearch/instant/congress"
 	curr "github.com/jivesearch/jivesearch/instant/currency"
 	disc "github.com/jivesearch/jivesearch/instant/discography"
+	"github.com/jivesearch/jivesearch/instant/dns"
 	"github.com/jivesearch/jivesearch/instant/econ"
 	ggdp "github.com/jivesearch/jivesearch/instant/econ/gdp"
 	pop "github.com/jivesearch/jivesearch/instant/econ/population"
 	"github.com/jivesearch/jivesearch/instant/nutrition"
+	"github.com/jivesearch/jivesearch/instant/packages"
 	"github.com/jivesearch/jivesearch/instant/status"
 	"github.com/jivesearch/jivesearch/instant/whois"
 
 	"github.com/jivesearch/jivesearch/instant/location"
+	"github.com/jivesearch/jivesearch/instant/media"
 	"github.com/jivesearch/jivesearch/instant/parcel"
+	"github.com/jivesearch/jivesearch/instant/repo"
 	"github.com/jivesearch/jivesearch/instant/shortener"
 	so "github.com/jivesearch/jivesearch/instant/stackoverflow"
 	"github.com/jivesearch/jivesearch/instant/stock"
 	"github.com/jivesearch/jivesearch/instant/weather"
 	"github.com/jivesearch/jivesearch/instant/wikipedia"
+	"github.com/jivesearch/jivesearch/instant/words"
 	"golang.org/x/text/language"
 )
 
 func answers(i Instant) []Answerer {
 	return []Answerer{
+		&Bangs{List: mockBangs()},
 		&BirthStone{},
+		&BMI{},
 		&Breach{Fetcher: i.BreachFetcher},
 		&Calculator{},
 		&CamelCase{},
 		&Characters{},
 		&Coin{},
+		&Color{},
+		&Dice{},
 		&Congress{Fetcher: i.CongressFetcher},
 		&CountryCode{},
+		&DateMath{Region: language.MustParseRegion("US")},
+		&Cron{LocationFetcher: i.LocationFetcher},
 		&Discography{Fetcher: i.DiscographyFetcher},
+		&DNS{Fetcher: i.DNSFetcher},
 		&DigitalStorage{},
+		&Element{},
 		&FedEx{Fetcher: i.FedExFetcher},
 		&Frequency{},
 		&Currency{
@@ -52,30 +66,47 @@ func answers(i Instant) []Answerer {
 		},
 		&GDP{GDPFetcher: i.GDPFetcher},
 		&Hash{},
+		&HTTPStatus{},
+		&IP{DNSFetcher: i.DNSFetcher, ASNFetcher: i.ASNFetcher},
+		&Holiday{},
 		&Speed{},
 		&Length{},
 		&Maps{LocationFetcher: i.LocationFetcher},
+		&Media{Fetcher: i.MediaFetcher},
 		&Minify{},
+		&MIME{},
 		&MortgageCalculator{},
+		&MyIP{},
+		&Operators{},
+		&Package{Fetcher: i.PackageFetcher},
 		&Population{PopulationFetcher: i.PopulationFetcher},
 		&Potus{},
 		&Power{},
+		&Password{},
+		&Percentage{},
+		&Port{},
 		&Prime{},
 		&Random{},
+		&Regex{},
+		&Repository{GitHubFetcher: i.GitHubFetcher, GitLabFetcher: i.GitLabFetcher},
 		&Reverse{},
 		&Shortener{Service: i.LinkShortener},
+		&Songwriter{Fetcher: i.SongwriterFetcher},
 		&Stats{},
 		&Status{Fetcher: i.StatusFetcher},
 		&StockQuote{Fetcher: i.StockQuoteFetcher},
 		&Temperature{},
+		&Tip{},
 		&USPS{Fetcher: i.USPSFetcher},
 		&UPS{Fetcher: i.UPSFetcher},
 		&URLDecode{},
 		&URLEncode{},
 		&UserAgent{},
+		&UUID{},
 		&StackOverflow{Fetcher: i.StackOverflowFetcher},
 		&WHOIS{Fetcher: i.WHOISFetcher},
 		&Weather{Fetcher: i.WeatherFetcher, LocationFetcher: i.LocationFetcher},
+		&Words{Lists: i.WordLists},
 		&Wikipedia{
 			LocationFetcher:  i.LocationFetcher,
 			NutritionFetcher: i.NutritionFetcher,
@@ -91,6 +122,7 @@ func TestDetect(t *testing.T) {
 
 	i := Instant{
 		QueryVar:        "q",
+		ASNFetcher:      &mockASNFetcher{},
 		BreachFetcher:   &mockBreachFetcher{},
 		CongressFetcher: &mockCongressFetcher{},
 		Currency: Currency{
@@ -98,12 +130,18 @@ func TestDetect(t *testing.T) {
 			FXFetcher:     &mockFXFetcher{},
 		},
 		DiscographyFetcher:   &mockDiscographyFetcher{},
+		DNSFetcher:           &mockDNSFetcher{},
 		FedExFetcher:         &mockFedExFetcher{},
 		GDPFetcher:           &mockGDPFetcher{},
+		GitHubFetcher:        &mockGitHubFetcher{},
+		GitLabFetcher:        &mockGitLabFetcher{},
 		LinkShortener:        &mockShortener{},
 		LocationFetcher:      &mockLocationFetcher{},
+		MediaFetcher:         &mockMediaFetcher{},
 		NutritionFetcher:     &mockNutritionFetcher{},
+		PackageFetcher:       &mockPackageFetcher{},
 		PopulationFetcher:    &mockPopulationFetcher{},
+		SongwriterFetcher:    &mockSongwriterFetcher{},
 		StackOverflowFetcher: &mockStackOverflowFetcher{},
 		StatusFetcher:        &mockStatusFetcher{},
 		StockQuoteFetcher:    &mockStockQuoteFetcher{},
@@ -113,6 +151,7 @@ func TestDetect(t *testing.T) {
 		WeatherFetcher:       &mockWeatherFetcher{},
 		WHOISFetcher:         &mockWHOISFetcher{},
 		WikipediaFetcher:     &mockWikipediaFetcher{},
+		WordLists:            mockWordLists(),
 	}
 
 	for j, ia := range answers(i) {
@@ -122,6 +161,8 @@ func TestDetect(t *testing.T) {
 		cases = append(cases, ia.tests()...)
 	}
 
+	randReader = &zeroReader{}
+
 	for _, c := range cases {
 		t.Run(c.query, func(t *testing.T) {
 			ctx := fmt.Sprintf(`(query: %q, user agent: %q)`, c.query, c.userAgent)
@@ -175,7 +216,78 @@ func TestDetect(t *testing.T) {
 	}
 }
 
-func TestGetIPAddress(t *testing.T) {
+func TestWikipediaFallback(t *testing.T) {
+	for _, c := range []struct {
+		name     string
+		lang     language.Tag
+		fallback []language.Tag
+		want     string
+	}{
+		{"matched language has it", language.German, []language.Tag{language.French}, ""},
+		{"preferred language", language.Italian, []language.Tag{language.French, language.German}, "fr"},
+		{"english", language.Italian, []language.Tag{language.Spanish}, "en"},
+	} {
+		t.Run(c.name, func(t *testing.T) {
+			v := url.Values{}
+			v.Set("q", "marie curie")
+
+			r := &http.Request{
+				Form:   v,
+				Header: make(http.Header),
+			}
+
+			i := Instant{QueryVar: "q"}
+			w := &Wikipedia{
+				Fetcher:  &mockFallbackFetcher{},
+				Fallback: c.fallback,
+			}
+
+			if !i.Trigger(w, r, c.lang) {
+				t.Fatal("expected wikipedia to trigger")
+			}
+
+			got := i.Solve(w, r)
+			if got.Language != c.want {
+				t.Fatalf("got %q; want %q", got.Language, c.want)
+			}
+
+			items := got.Solution.([]*wikipedia.Item)
+			served := c.want
+			if served == "" {
+				served = c.lang.String()
+			}
+
+			if items[0].Wikipedia.Language != served {
+				t.Fatalf("got article in %q; want %q", items[0].Wikipedia.Language, served)
+			}
+		})
+	}
+}
+
+// mockFallbackFetcher only has articles in English, French and German
+type mockFallbackFetcher struct{}
+
+func (mf *mockFallbackFetcher) Fetch(query string, lang language.Tag) ([]*wikipedia.Item, error) {
+	switch lang {
+	case language.English, language.French, language.German:
+		return []*wikipedia.Item{
+			{
+				Wikipedia: wikipedia.Wikipedia{
+					Language: lang.String(),
+					Title:    "Marie Curie",
+				},
+			},
+		}, nil
+	default:
+		return []*wikipedia.Item{{}}, nil
+	}
+}
+
+func (mf *mockFallbackFetcher) Setup() error {
+	return nil
+}
+
+func TestIPAddress(t *testing.T) {
 	type args struct {
 		remoteAddr    string
 		xRealIP       string
@@ -226,7 +338,7 @@ func TestGetIPAddress(t *testing.T) {
 				Header:     h,
 			}
 
-			got := getIPAddress(r)
+			got := IPAddress(r)
 
 			if !reflect.DeepEqual(got, tt.want) {
 				t.Errorf("got %+v, want %+v", got, tt.want)
@@ -430,6 +542,100 @@ func (m *mockGDPFetcher) Fetch(country string, start time.Time, end time.Time) (
 	}, nil
 }
 
+// mock repository fetchers
+type mockGitHubFetcher struct{}
+
+func (m *mockGitHubFetcher) Fetch(path string) (*repo.Repository, error) {
+	if path != "golang/go" {
+		return nil, repo.ErrNotFound
+	}
+
+	return &repo.Repository{
+		Path:        "golang/go",
+		Description: "The Go programming language",
+		URL:         "https://github.com/golang/go",
+		Language:    "Go",
+		Stars:       118000,
+		Forks:       17000,
+		OpenIssues:  9000,
+		License:     "BSD-3-Clause",
+		LatestRelease: &repo.Release{
+			Name: "go1.21.0",
+			Tag:  "go1.21.0",
+			URL:  "https://github.com/golang/go/releases/tag/go1.21.0",
+		},
+		Provider: repo.GitHubProvider,
+	}, nil
+}
+
+type mockGitLabFetcher struct{}
+
+func (m *mockGitLabFetcher) Fetch(path string) (*repo.Repository, error) {
+	if path != "gitlab-org/gitlab-runner" {
+		return nil, repo.ErrNotFound
+	}
+
+	return &repo.Repository{
+		Path:        "gitlab-org/gitlab-runner",
+		Description: "GitLab Runner",
+		URL:         "https://gitlab.com/gitlab-org/gitlab-runner",
+		Language:    "Go",
+		Stars:       2400,
+		Forks:       4300,
+		OpenIssues:  3200,
+		License:     "mit",
+		Provider:    repo.GitLabProvider,
+	}, nil
+}
+
+// mock package fetcher
+type mockPackageFetcher struct{}
+
+func (m *mockPackageFetcher) Fetch(registry packages.Registry, name string) (*packages.Package, error) {
+	switch {
+	case name == "django" && (registry == packages.Any || registry == packages.PyPI):
+		return &packages.Package{
+			Name:        "Django",
+			Version:     "4.2.4",
+			Released:    time.Date(2023, 8, 1, 17, 30, 22, 0, time.UTC),
+			License:     "BSD-3-Clause",
+			Description: "A high-level Python web framework that encourages rapid development and clean, pragmatic design.",
+			URL:         "https://pypi.org/project/Django/",
+			Registry:    packages.PyPI,
+		}, nil
+	case name == "react" && (registry == packages.Any || registry == packages.NPM):
+		return &packages.Package{
+			Name:        "react",
+			Version:     "18.2.0",
+			Released:    time.Date(2022, 6, 14, 19, 46, 38, 0, time.UTC),
+			License:     "MIT",
+			Description: "React is a JavaScript library for building user interfaces.",
+			URL:         "https://www.npmjs.com/package/react",
+			Registry:    packages.NPM,
+		}, nil
+	case name == "serde" && (registry == packages.Any || registry == packages.Crates):
+		return &packages.Package{
+			Name:        "serde",
+			Version:     "1.0.183",
+			Released:    time.Date(2023, 8, 6, 16, 4, 31, 0, time.UTC),
+			License:     "MIT OR Apache-2.0",
+			Description: "A generic serialization/deserialization framework",
+			URL:         "https://crates.io/crates/serde",
+			Registry:    packages.Crates,
+		}, nil
+	case name == "github.com/spf13/viper" && registry == packages.Go:
+		return &packages.Package{
+			Name:     "github.com/spf13/viper",
+			Version:  "v1.16.0",
+			Released: time.Date(2023, 6, 6, 11, 0, 0, 0, time.UTC),
+			URL:      "https://pkg.go.dev/github.com/spf13/viper",
+			Registry: packages.Go,
+		}, nil
+	}
+
+	return nil, packages.ErrNotFound
+}
+
 // mock location fetcher
 type mockLocationFetcher struct{}
 
@@ -442,10 +648,73 @@ func (l *mockLocationFetcher) Fetch(ip net.IP) (*location.City, error) {
 	c.Country.Names = map[string]string{"en": "SomeCountry"}
 	c.Location.Latitude = 12
 	c.Location.Longitude = 18
+	c.Location.TimeZone = "America/Denver"
 	return c, nil
 }
 
 // mock nutrition
+type mockASNFetcher struct{}
+
+func (m *mockASNFetcher) FetchASN(ip net.IP) (*location.ASN, error) {
+	return &location.ASN{Number: 15169, Organization: "GOOGLE"}, nil
+}
+
+type mockDNSFetcher struct{}
+
+func (m *mockDNSFetcher) Lookup(domain string, t dns.Type) ([]dns.Record, error) {
+	records := map[dns.Type][]dns.Record{
+		dns.A:    {{Type: dns.A, Value: "93.184.216.34"}},
+		dns.AAAA: {{Type: dns.AAAA, Value: "2606:2800:220:1:248:1893:25c8:1946"}},
+		dns.MX:   {{Type: dns.MX, Value: "mail.example.com", Priority: 10}},
+		dns.NS:   {{Type: dns.NS, Value: "a.iana-servers.net"}, {Type: dns.NS, Value: "b.iana-servers.net"}},
+		dns.TXT:  {{Type: dns.TXT, Value: "v=spf1 -all"}},
+	}
+
+	return records[t], nil
+}
+
+func (m *mockDNSFetcher) Reverse(ip string) ([]string, error) {
+	return []string{"dns.google"}, nil
+}
+
+type mockMediaFetcher struct{}
+
+func (m *mockMediaFetcher) Fetch(title string) (*media.Title, error) {
+	switch title {
+	case "inception":
+		return &media.Title{
+			Name:     "Inception",
+			Kind:     media.Movie,
+			Year:     "2010",
+			Overview: "Cobb steals secrets from the subconscious.",
+			Poster:   "https://image.tmdb.org/t/p/w342/inception.jpg",
+			Genres:   []string{"Action", "Science Fiction"},
+			Rating:   8.4,
+			Votes:    35000,
+			Runtime:  148,
+			Cast:     []media.Role{{Actor: "Leonardo DiCaprio", Character: "Cobb"}},
+			Provider: media.TMDBProvider,
+		}, nil
+	case "breaking bad":
+		return &media.Title{
+			Name:     "Breaking Bad",
+			Kind:     media.Series,
+			Year:     "2008–2013",
+			Overview: "A chemistry teacher turns to crime.",
+			Genres:   []string{"Drama"},
+			Rating:   8.9,
+			Votes:    12000,
+			Runtime:  45,
+			Seasons:  5,
+			Episodes: 62,
+			Cast:     []media.Role{{Actor: "Bryan Cranston", Character: "Walter White"}},
+			Provider: media.TMDBProvider,
+		}, nil
+	}
+
+	return nil, media.ErrNotFound
+}
+
 type mockNutritionFetcher struct{}
 
 func (m *mockNutritionFetcher) Fetch(ndbnos []string) (*nutrition.Response, error) {
@@ -578,16 +847,27 @@ func (s *mockStackOverflowFetcher) Fetch(query string, tags []string) (so.Respon
 				Items: []so.Item{
 					{
 						Answers: []so.Answer{
+							{
+								Owner: so.Owner{
+									DisplayName: "Someone Else",
+								},
+								Score: 2000,
+								Body:  "a popular answer",
+							},
 							{
 								Owner: so.Owner{
 									DisplayName: "NikiC",
+									Link:        "https://stackoverflow.com/users/385378/nikic",
 								},
-								Score: 1273,
-								Body:  "an answer",
+								Score:      1273,
+								IsAccepted: true,
+								Body:       "<p>an answer</p><pre><code>foreach ($array as &amp;$v) {}\n</code></pre>",
 							},
 						},
-						Link:  "https://stackoverflow.com/questions/10057671/how-does-php-foreach-actually-work",
-						Title: "How does PHP &#39;foreach&#39; actually work?",
+						AcceptedAnswerID: 14854568,
+						Link:             "https://stackoverflow.com/questions/10057671/how-does-php-foreach-actually-work",
+						Tags:             []string{"php", "loops", "foreach"},
+						Title:            "How does PHP &#39;foreach&#39; actually work?",
 					},
 				},
 				QuotaMax:       300,
@@ -674,7 +954,50 @@ func (s *mockStackOverflowFetcher) Fetch(query string, tags []string) (so.Respon
 				QuotaRemaining: 197,
 			}
 		}
-
+	case "how to sort a map":
+		if reflect.DeepEqual(tags, []string{"go"}) {
+			resp = so.Response{
+				Items: []so.Item{
+					{
+						Answers: []so.Answer{
+							{
+								Owner: so.Owner{
+									DisplayName: "samol",
+								},
+								Score:      190,
+								IsAccepted: true,
+								Body:       "<pre class=\"lang-go\"><code>sort.Slice(ss, func(i, j int) bool {\n\treturn ss[i].Value &gt; ss[j].Value\n})</code></pre>",
+							},
+						},
+						Link:  "https://stackoverflow.com/questions/18695346/how-can-i-sort-a-mapstringint-by-its-values",
+						Tags:  []string{"sorting", "dictionary", "go"},
+						Title: "How can I sort a Map[string]int by its values?",
+					},
+				},
+			}
+		}
+	case "typeerror: undefined is not a function":
+		if tags == nil {
+			resp = so.Response{
+				Items: []so.Item{
+					{
+						Answers: []so.Answer{
+							{
+								Owner: so.Owner{
+									DisplayName: "Danny Zuko",
+								},
+								Score:      42,
+								IsAccepted: true,
+								Body:       "<p>you called something that isn't a function</p>",
+							},
+						},
+						Link:  "https://stackoverflow.com/questions/90210/undefined-is-not-a-function",
+						Tags:  []string{"javascript", "typeerror"},
+						Title: "What does &quot;undefined is not a function&quot; mean?",
+					},
+				},
+			}
+		}
 	default:
 	}
 
@@ -940,6 +1263,35 @@ func (f *mockWHOISFetcher) Fetch(domain string) (*whois.Response, error) {
 }
 
 // mock Wikipedia Fetcher
+func mockBangs() []bangs.Bang {
+	return []bangs.Bang{
+		{
+			Name:     "Wikipedia",
+			Triggers: []string{"w", "wikipedia"},
+			Regions:  map[string]string{"default": "https://en.wikipedia.org/wiki/{{{term}}}"},
+		},
+		{
+			Name:     "DuckDuckGo",
+			Triggers: []string{"ddg"},
+			Regions:  map[string]string{"default": "https://duckduckgo.com/?q={{{term}}}"},
+			Disabled: true,
+		},
+		{
+			Name:     "Google",
+			Triggers: []string{"g", "google"},
+			Regions:  map[string]string{"default": "https://encrypted.google.com/search?q={{{term}}}"},
+		},
+	}
+}
+
+func mockWordLists() words.Lists {
+	l, err := words.New(language.English, strings.NewReader("biology\ngeology\nenlist\ninlets\nlisten\nquixotic\nsilent\ntinsel\n"))
+	if err != nil {
+		panic(err)
+	}
+	return words.Lists{"en": l}
+}
+
 type mockWikipediaFetcher struct{}
 
 func (mf *mockWikipediaFetcher) Fetch(query string, lang language.Tag) ([]*wikipedia.Item, error) {
@@ -1096,6 +1448,21 @@ func (m *mockBreachFetcher) Fetch(account string) (*breach.Response, error) {
 	return r, nil
 }
 
+type mockSongwriterFetcher struct{}
+
+func (m *mockSongwriterFetcher) FetchSongwriters(song string) (*disc.Song, error) {
+	if song != "bohemian rhapsody" {
+		return nil, disc.ErrSongNotFound
+	}
+
+	return &disc.Song{
+		Name: "Bohemian Rhapsody",
+		Writers: []disc.Songwriter{
+			{Name: "Freddie Mercury", Roles: []string{"composer", "lyricist"}},
+		},
+	}, nil
+}
+
 type mockDiscographyFetcher struct{}
 
 func (m *mockDiscographyFetcher) Fetch(artist string) ([]disc.Album, error) {
@@ -1163,3 +1530,12 @@ func (m *mockTimeZoneFetcher) Fetch(lat, lon float64) (string, error) {
 
 	return "America/Denver", nil
 }
+
+type zeroReader struct{}
+
+func (z *zeroReader) Read(b []byte) (int, error) {
+	for i := range b {
+		b[i] = 0
+	}
+	return len(b), nil
+}
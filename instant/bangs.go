@@ -0,0 +1,103 @@
+package instant
+
+import (
+	"fmt"
+	"net/http"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/jivesearch/jivesearch/bangs"
+	"golang.org/x/text/language"
+)
+
+// BangsType is an answer Type
+const BangsType Type = "bangs"
+
+// Bangs is an instant answer that lists our !bangs
+type Bangs struct {
+	List []bangs.Bang
+	Answer
+}
+
+func (b *Bangs) setQuery(r *http.Request, qv string) Answerer {
+	b.Answer.setQuery(r, qv)
+	return b
+}
+
+func (b *Bangs) setUserAgent(r *http.Request) Answerer {
+	return b
+}
+
+func (b *Bangs) setLanguage(lang language.Tag) Answerer {
+	b.language = lang
+	return b
+}
+
+func (b *Bangs) setType() Answerer {
+	b.Type = BangsType
+	return b
+}
+
+func (b *Bangs) setRegex() Answerer {
+	b.regex = append(b.regex, regexp.MustCompile(`^(?P<trigger>!)$`))
+	return b
+}
+
+func (b *Bangs) solve(r *http.Request) Answerer {
+	list := []bangs.Bang{}
+	for _, bng := range b.List {
+		if !bng.Disabled {
+			list = append(list, bng)
+		}
+	}
+
+	if len(list) == 0 {
+		b.Err = fmt.Errorf("no !bangs")
+		return b
+	}
+
+	sort.Slice(list, func(i, j int) bool {
+		return strings.ToLower(list[i].Name) < strings.ToLower(list[j].Name)
+	})
+
+	b.Solution = list
+	return b
+}
+
+func (b *Bangs) tests() []test {
+	return []test{
+		{
+			query: "!",
+			expected: []Data{
+				{
+					Type:      BangsType,
+					Triggered: true,
+					Solution: []bangs.Bang{
+						{
+							Name:     "Google",
+							Triggers: []string{"g", "google"},
+							Regions:  map[string]string{"default": "https://encrypted.google.com/search?q={{{term}}}"},
+						},
+						{
+							Name:     "Wikipedia",
+							Triggers: []string{"w", "wikipedia"},
+							Regions:  map[string]string{"default": "https://en.wikipedia.org/wiki/{{{term}}}"},
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func init() {
+	Register(Registration{
+		Name:     "bangs",
+		Trigger:  `"!" on its own lists the !bangs`,
+		Priority: 25,
+		New: func(i *Instant) Answerer {
+			return &Bangs{}
+		},
+	})
+}
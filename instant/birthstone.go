@@ -208,3 +208,14 @@ func (b *BirthStone) tests() []test {
 
 	return tests
 }
+
+func init() {
+	Register(Registration{
+		Name:     "birthstone",
+		Trigger:  `"birthstone" and a month, e.g. "birthstone april"`,
+		Priority: 10,
+		New: func(i *Instant) Answerer {
+			return &BirthStone{}
+		},
+	})
+}
@@ -0,0 +1,217 @@
+package instant
+
+import (
+	"fmt"
+	"math"
+	"net/http"
+	"regexp"
+	"strconv"
+
+	"golang.org/x/text/language"
+)
+
+// BMIType is an answer Type
+const BMIType Type = "bmi"
+
+// BMI is an instant answer that calculates body mass index
+type BMI struct {
+	Answer
+}
+
+// BMIResponse is a body mass index and its WHO category
+type BMIResponse struct {
+	Weight   float64 `json:"weight"` // kg
+	Height   float64 `json:"height"` // cm
+	BMI      float64 `json:"bmi"`
+	Category string  `json:"category"`
+}
+
+const (
+	kgPerPound = 0.45359237
+	cmPerInch  = 2.54
+)
+
+var (
+	bmiWeight = regexp.MustCompile(`(\d+(?:\.\d+)?) ?(lbs?|pounds?|kgs?|kilograms?)\b`)
+	bmiFeet   = regexp.MustCompile(`(\d+) ?(?:'|ft|feet|foot)(?: ?(\d+(?:\.\d+)?) ?(?:"|''|in|inch|inches)?)?`)
+	bmiMetric = regexp.MustCompile(`(\d+(?:\.\d+)?) ?(cm|centimeters?|centimetres?|m|meters?|metres?)\b`)
+)
+
+func (b *BMI) setQuery(r *http.Request, qv string) Answerer {
+	b.Answer.setQuery(r, qv)
+	return b
+}
+
+func (b *BMI) setUserAgent(r *http.Request) Answerer {
+	return b
+}
+
+func (b *BMI) setLanguage(lang language.Tag) Answerer {
+	b.language = lang
+	return b
+}
+
+func (b *BMI) setType() Answerer {
+	b.Type = BMIType
+	return b
+}
+
+func (b *BMI) setRegex() Answerer {
+	t := `(?P<trigger>bmi|body mass index)`
+	b.regex = append(b.regex, regexp.MustCompile(`^`+t+`(?: calculator)?(?: for)? (?P<remainder>.+)$`))
+	b.regex = append(b.regex, regexp.MustCompile(`^(?P<remainder>.+) `+t+`$`))
+	return b
+}
+
+func (b *BMI) solve(r *http.Request) Answerer {
+	w := bmiWeight.FindStringSubmatch(b.remainder)
+	if w == nil {
+		b.Err = fmt.Errorf("no weight in %q", b.remainder)
+		return b
+	}
+
+	weight, _ := strconv.ParseFloat(w[1], 64)
+	imperial := w[2][0] == 'l' || w[2][0] == 'p'
+	if imperial {
+		weight *= kgPerPound
+	}
+
+	var height float64 // cm
+	if h := bmiFeet.FindStringSubmatch(b.remainder); h != nil {
+		ft, _ := strconv.ParseFloat(h[1], 64)
+		in, _ := strconv.ParseFloat(h[2], 64) // 0 for "6 ft"
+		height = (ft*12 + in) * cmPerInch
+	} else if h := bmiMetric.FindStringSubmatch(b.remainder); h != nil {
+		height, _ = strconv.ParseFloat(h[1], 64)
+		if h[2][0] == 'm' {
+			height *= 100
+		}
+	}
+
+	if weight <= 0 || height <= 0 {
+		b.Err = fmt.Errorf("no height in %q", b.remainder)
+		return b
+	}
+
+	m := height / 100
+	resp := &BMIResponse{
+		Weight: math.Round(weight*10) / 10,
+		Height: math.Round(height*10) / 10,
+		BMI:    math.Round(weight/(m*m)*10) / 10,
+	}
+	resp.Category = bmiCategory(resp.BMI)
+
+	b.Solution = resp
+
+	// the widget uses whichever units they asked in
+	b.Widget = &Widget{
+		Formula: "bmi-metric",
+		Inputs: []WidgetField{
+			{Name: "weight", Label: "Weight (kg)", Value: resp.Weight},
+			{Name: "height", Label: "Height (cm)", Value: resp.Height},
+		},
+		Outputs: []WidgetField{
+			{Name: "bmi", Label: "BMI", Value: resp.BMI, Text: resp.Category},
+		},
+	}
+
+	if imperial {
+		b.Widget.Formula = "bmi-imperial"
+		b.Widget.Inputs = []WidgetField{
+			{Name: "weight", Label: "Weight (lb)", Value: math.Round(weight/kgPerPound*10) / 10},
+			{Name: "height", Label: "Height (in)", Value: math.Round(height/cmPerInch*10) / 10},
+		}
+	}
+
+	return b
+}
+
+// bmiCategory is the WHO classification for adults
+func bmiCategory(bmi float64) string {
+	switch {
+	case bmi < 18.5:
+		return "Underweight"
+	case bmi < 25:
+		return "Normal weight"
+	case bmi < 30:
+		return "Overweight"
+	default:
+		return "Obese"
+	}
+}
+
+func (b *BMI) tests() []test {
+	return []test{
+		{
+			query: "bmi 180lb 5'11",
+			expected: []Data{
+				{
+					Type:      BMIType,
+					Triggered: true,
+					Solution:  &BMIResponse{Weight: 81.6, Height: 180.3, BMI: 25.1, Category: "Overweight"},
+					Widget: &Widget{
+						Formula: "bmi-imperial",
+						Inputs: []WidgetField{
+							{Name: "weight", Label: "Weight (lb)", Value: 180},
+							{Name: "height", Label: "Height (in)", Value: 71},
+						},
+						Outputs: []WidgetField{
+							{Name: "bmi", Label: "BMI", Value: 25.1, Text: "Overweight"},
+						},
+					},
+				},
+			},
+		},
+		{
+			query: "body mass index 80 kg 1.8 m",
+			expected: []Data{
+				{
+					Type:      BMIType,
+					Triggered: true,
+					Solution:  &BMIResponse{Weight: 80, Height: 180, BMI: 24.7, Category: "Normal weight"},
+					Widget: &Widget{
+						Formula: "bmi-metric",
+						Inputs: []WidgetField{
+							{Name: "weight", Label: "Weight (kg)", Value: 80},
+							{Name: "height", Label: "Height (cm)", Value: 180},
+						},
+						Outputs: []WidgetField{
+							{Name: "bmi", Label: "BMI", Value: 24.7, Text: "Normal weight"},
+						},
+					},
+				},
+			},
+		},
+		{
+			query: `5 ft 4 in 120 pounds bmi`,
+			expected: []Data{
+				{
+					Type:      BMIType,
+					Triggered: true,
+					Solution:  &BMIResponse{Weight: 54.4, Height: 162.6, BMI: 20.6, Category: "Normal weight"},
+					Widget: &Widget{
+						Formula: "bmi-imperial",
+						Inputs: []WidgetField{
+							{Name: "weight", Label: "Weight (lb)", Value: 120},
+							{Name: "height", Label: "Height (in)", Value: 64},
+						},
+						Outputs: []WidgetField{
+							{Name: "bmi", Label: "BMI", Value: 20.6, Text: "Normal weight"},
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func init() {
+	Register(Registration{
+		Name:     "bmi",
+		Trigger:  `"bmi" with a weight and height, e.g. "bmi 180lb 5'11"`,
+		Priority: 570,
+		New: func(i *Instant) Answerer {
+			return &BMI{}
+		},
+	})
+}
@@ -102,3 +102,15 @@ func (b *Breach) tests() []test {
 
 	return tests
 }
+
+func init() {
+	Register(Registration{
+		Name:     "breach",
+		Trigger:  `"breach", "pwned" or "have i been pwned" and an email address`,
+		Priority: 20,
+		External: true,
+		New: func(i *Instant) Answerer {
+			return &Breach{Fetcher: i.BreachFetcher}
+		},
+	})
+}
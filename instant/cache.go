@@ -0,0 +1,112 @@
+package instant
+
+import (
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/jivesearch/jivesearch/singleflight"
+	"golang.org/x/text/language"
+)
+
+// AnswerCache keeps solved answers in memory for the TTL of their answer.
+// We keep the solutions themselves, not their json, so that even those that
+// don't survive a round trip through json (e.g. Wikipedia's) can be cached.
+// Until an answer is cached, concurrent requests for it share one solve.
+type AnswerCache struct {
+	Max    int // the most solutions we keep
+	mu     sync.Mutex
+	m      map[string]cachedAnswer
+	flight singleflight.Group
+}
+
+type cachedAnswer struct {
+	Data
+	expires time.Time
+}
+
+// personal types are solved for the user rather than the query,
+// e.g. the time or weather where they are, or an age as of today
+var personal = map[Type]bool{
+	LocalWeatherType:  true,
+	WikidataAgeType:   true,
+	WikidataClockType: true,
+}
+
+// NewAnswerCache creates an AnswerCache that holds up to max solutions
+func NewAnswerCache(max int) *AnswerCache {
+	return &AnswerCache{
+		Max: max,
+		m:   map[string]cachedAnswer{},
+	}
+}
+
+// SolveCached solves an instant answer unless it was solved for the same query, language
+// and region within the answer's TTL. Answers without a TTL are always solved.
+func (i *Instant) SolveCached(ia Answerer, r *http.Request, region language.Region) Data {
+	key, ttl := ia.cacheKey(region)
+	if i.Cache == nil || ttl <= 0 {
+		return i.Solve(ia, r)
+	}
+
+	if d, ok := i.Cache.get(key); ok {
+		return d
+	}
+
+	v, _, _ := i.Cache.flight.Do(key, func() (interface{}, error) {
+		d := i.Solve(ia, r)
+		if d.Err == nil && !personal[d.Type] {
+			i.Cache.put(key, d, ttl)
+		}
+		return d, nil
+	})
+
+	return v.(Data)
+}
+
+func (c *AnswerCache) get(key string) (Data, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	ca, ok := c.m[key]
+	if !ok || !now().Before(ca.expires) {
+		return Data{}, false
+	}
+
+	return ca.Data, true
+}
+
+// put caches a solution. When we are full the expired solutions are dropped and,
+// if that doesn't make room, the solution isn't cached.
+func (c *AnswerCache) put(key string, d Data, ttl time.Duration) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	t := now()
+
+	if len(c.m) >= c.Max {
+		for k, ca := range c.m {
+			if !t.Before(ca.expires) {
+				delete(c.m, k)
+			}
+		}
+	}
+
+	if len(c.m) >= c.Max {
+		return
+	}
+
+	c.m[key] = cachedAnswer{d, t.Add(ttl)}
+}
+
+// cacheKey is where the solution of an answer is cached, e.g. wikipedia::en::US::jimi hendrix.
+// The query was already normalized by setQuery.
+func (a *Answer) cacheKey(region language.Region) (string, time.Duration) {
+	return fmt.Sprintf("%v::%v::%v::%v", a.name, a.language, region, a.query), a.ttl
+}
+
+// setTTL names the answer and sets how long its solutions are cached for
+func (a *Answer) setTTL(name string, ttl time.Duration) {
+	a.name, a.ttl = name, ttl
+}
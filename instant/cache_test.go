@@ -0,0 +1,156 @@
+package instant
+
+import (
+	"net/http"
+	"sync"
+	"testing"
+	"time"
+
+	"golang.org/x/text/language"
+)
+
+// counter counts how often it is solved
+type counter struct {
+	Answer
+	typ     Type
+	solved  int
+	release chan struct{} // optional. Solving waits for it to close.
+}
+
+func (c *counter) setQuery(r *http.Request, qv string) Answerer {
+	c.Answer.setQuery(r, qv)
+	return c
+}
+func (c *counter) setUserAgent(r *http.Request) Answerer  { return c }
+func (c *counter) setLanguage(lang language.Tag) Answerer { c.language = lang; return c }
+func (c *counter) setType() Answerer                      { c.Type = c.typ; return c }
+func (c *counter) setRegex() Answerer                     { return c }
+func (c *counter) tests() []test                          { return nil }
+
+func (c *counter) solve(r *http.Request) Answerer {
+	if c.release != nil {
+		<-c.release
+	}
+	c.solved++
+	c.Solution = c.solved
+	return c
+}
+
+func TestSolveCached(t *testing.T) {
+	us, gb := language.MustParseRegion("US"), language.MustParseRegion("GB")
+
+	n := now
+	defer func() { now = n }()
+	now = func() time.Time { return time.Date(2016, 6, 5, 3, 2, 0, 0, time.UTC) }
+
+	i := &Instant{
+		QueryVar: "q",
+		Cache:    NewAnswerCache(2),
+	}
+
+	solve := func(a *counter, q string, lang language.Tag, region language.Region) Data {
+		r, err := http.NewRequest("GET", "/?q="+q, nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		a.setQuery(r, i.QueryVar).setLanguage(lang)
+		return i.SolveCached(a, r, region)
+	}
+
+	a := &counter{typ: GDPType}
+	a.setTTL("gdp", time.Hour)
+
+	for _, c := range []struct {
+		name   string
+		q      string
+		lang   language.Tag
+		region language.Region
+		want   int
+	}{
+		{"solved", "gdp+of+france", language.English, us, 1},
+		{"cached", "GDP+of+France++", language.English, us, 1},
+		{"another language", "gdp+of+france", language.French, us, 2},
+		{"another region", "gdp+of+france", language.English, gb, 3}, // the cache is full
+		{"still cached", "gdp+of+france", language.English, us, 1},
+	} {
+		if got := solve(a, c.q, c.lang, c.region); got.Solution != c.want {
+			t.Fatalf("%v: got solution %v; want %v", c.name, got.Solution, c.want)
+		}
+	}
+
+	// expired solutions make way for new ones
+	now = func() time.Time { return time.Date(2016, 6, 5, 5, 2, 0, 0, time.UTC) }
+
+	if got := solve(a, "gdp+of+france", language.English, gb); got.Solution != 4 {
+		t.Fatalf("got solution %v; want 4", got.Solution)
+	}
+
+	if got := solve(a, "gdp+of+france", language.English, gb); got.Solution != 4 {
+		t.Fatalf("got solution %v; want a cached 4", got.Solution)
+	}
+
+	// without a TTL, or for answers about the user, we always solve
+	untimed := &counter{typ: GDPType}
+	untimed.setTTL("gdp", 0)
+
+	clock := &counter{typ: WikidataClockType}
+	clock.setTTL("wikipedia", time.Hour)
+
+	for _, b := range []*counter{untimed, clock} {
+		solve(b, "time", language.English, us)
+		if got := solve(b, "time", language.English, us); got.Solution != 2 {
+			t.Fatalf("%v: got solution %v; want 2", b.typ, got.Solution)
+		}
+	}
+}
+
+func TestSolveCachedCoalesces(t *testing.T) {
+	i := &Instant{
+		QueryVar: "q",
+		Cache:    NewAnswerCache(10),
+	}
+
+	release := make(chan struct{})
+	answerers := []*counter{}
+	results := make(chan Data, 5)
+
+	var wg sync.WaitGroup
+	for j := 0; j < cap(results); j++ {
+		a := &counter{typ: GDPType, release: release}
+		a.setTTL("gdp", time.Hour)
+		answerers = append(answerers, a)
+
+		r, err := http.NewRequest("GET", "/?q=gdp+of+france", nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		a.setQuery(r, i.QueryVar).setLanguage(language.English)
+
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			results <- i.SolveCached(a, r, language.MustParseRegion("US"))
+		}()
+	}
+
+	time.Sleep(10 * time.Millisecond) // let them all ask for it
+	close(release)
+	wg.Wait()
+	close(results)
+
+	var solved int
+	for _, a := range answerers {
+		solved += a.solved
+	}
+
+	if solved != 1 {
+		t.Fatalf("got %d solves; want 1", solved)
+	}
+
+	for d := range results {
+		if d.Solution != 1 {
+			t.Fatalf("got solution %v; want the shared 1", d.Solution)
+		}
+	}
+}
@@ -6,7 +6,7 @@ import (
 	"regexp"
 	"strings"
 
-	"github.com/Knetic/govaluate"
+	"github.com/jivesearch/jivesearch/instant/calculator"
 	"github.com/pkg/errors"
 	"golang.org/x/text/language"
 )
@@ -19,6 +19,13 @@ type Calculator struct {
 	Answer
 }
 
+// CalculatorResponse is the normalized expression and its result
+type CalculatorResponse struct {
+	Expression string `json:"expression"`
+	Result     string `json:"result"`
+	Exact      bool   `json:"exact"` // false if rounded, e.g. for sqrt(2)
+}
+
 func (c *Calculator) setQuery(req *http.Request, q string) Answerer {
 	c.Answer.setQuery(req, q)
 	return c
@@ -46,7 +53,7 @@ func (c *Calculator) setRegex() Answerer {
 	t := strings.Join(calculatorTriggers, "|")
 	c.regex = append(c.regex, regexp.MustCompile(fmt.Sprintf(`^(?P<trigger>%s)$`, t)))
 
-	f := `[\s0-9\.\^+\-*\/\(\)]*`
+	f := `(?:[\s0-9\.,\^+\-*\/\(\)%×÷−!]|pi|π|e|of|abs|acos|asin|atan|cbrt|cos|exp|ln|log|sin|sqrt|tan)*`
 	c.regex = append(c.regex, regexp.MustCompile(fmt.Sprintf(`^(?P<trigger>%s)?(?P<remainder>%v)$`, t, f)))
 	c.regex = append(c.regex, regexp.MustCompile(fmt.Sprintf(`^(?P<remainder>%v)(?P<trigger>%s)?$`, f, t)))
 	return c
@@ -61,33 +68,30 @@ func (c *Calculator) solve(r *http.Request) Answerer {
 		c.remainder = strings.Replace(c.remainder, t, "", -1)
 	}
 
-	if !strings.ContainsAny(c.remainder, "+-/*^") { // don't trigger fedex/ups/usps tracking numbers
+	expression, err := calculator.Parse(c.remainder)
+	if err != nil {
 		c.Triggered = false
 		c.Err = fmt.Errorf("not a mathematical formula %q", c.remainder)
 		return c
 	}
 
-	expression, err := govaluate.NewEvaluableExpression(c.remainder)
-	if err != nil {
+	if !expression.Arithmetic() { // don't trigger fedex/ups/usps tracking numbers
 		c.Triggered = false
 		c.Err = fmt.Errorf("not a mathematical formula %q", c.remainder)
 		return c
 	}
 
-	result, err := expression.Evaluate(nil)
+	result, err := expression.Evaluate()
 	if err != nil {
 		c.Triggered = false
 		c.Err = errors.Wrap(err, c.remainder)
 		return c
 	}
 
-	switch result.(type) {
-	case float64:
-		c.Solution = result
-	default:
-		c.Triggered = false
-		c.Err = errors.Wrapf(err, "not a mathematical formula %q", c.remainder)
-		return c
+	c.Solution = &CalculatorResponse{
+		Expression: expression.String(),
+		Result:     result.String(),
+		Exact:      result.Exact,
 	}
 
 	return c
@@ -110,7 +114,7 @@ func (c *Calculator) tests() []test {
 				{
 					Type:      CalculatorType,
 					Triggered: true,
-					Solution:  4.0,
+					Solution:  &CalculatorResponse{Expression: "2 + 2", Result: "4", Exact: true},
 				},
 			},
 		},
@@ -120,7 +124,7 @@ func (c *Calculator) tests() []test {
 				{
 					Type:      CalculatorType,
 					Triggered: true,
-					Solution:  18.3,
+					Solution:  &CalculatorResponse{Expression: "(2 + 2) * 3 + 6.3", Result: "18.3", Exact: true},
 				},
 			},
 		},
@@ -130,7 +134,57 @@ func (c *Calculator) tests() []test {
 				{
 					Type:      CalculatorType,
 					Triggered: true,
-					Solution:  1.875,
+					Solution:  &CalculatorResponse{Expression: "(2 + 2) * 3 / 6.4", Result: "1.875", Exact: true},
+				},
+			},
+		},
+		{
+			query: "15% of 80",
+			expected: []Data{
+				{
+					Type:      CalculatorType,
+					Triggered: true,
+					Solution:  &CalculatorResponse{Expression: "15% of 80", Result: "12", Exact: true},
+				},
+			},
+		},
+		{
+			query: "1e6 + 1",
+			expected: []Data{
+				{
+					Type:      CalculatorType,
+					Triggered: true,
+					Solution:  &CalculatorResponse{Expression: "1e6 + 1", Result: "1000001", Exact: true},
+				},
+			},
+		},
+		{
+			query: "5!",
+			expected: []Data{
+				{
+					Type:      CalculatorType,
+					Triggered: true,
+					Solution:  &CalculatorResponse{Expression: "5!", Result: "120", Exact: true},
+				},
+			},
+		},
+		{
+			query: "2 sqrt(2)",
+			expected: []Data{
+				{
+					Type:      CalculatorType,
+					Triggered: true,
+					Solution:  &CalculatorResponse{Expression: "2 * sqrt(2)", Result: "2.82842712475"},
+				},
+			},
+		},
+		{
+			query: "calculate 2^100",
+			expected: []Data{
+				{
+					Type:      CalculatorType,
+					Triggered: true,
+					Solution:  &CalculatorResponse{Expression: "2 ^ 100", Result: "1267650600228229401496703205376", Exact: true},
 				},
 			},
 		},
@@ -138,3 +192,14 @@ func (c *Calculator) tests() []test {
 
 	return tests
 }
+
+func init() {
+	Register(Registration{
+		Name:     "calculator",
+		Trigger:  `"calculator" or an arithmetic expression, e.g. "2+2"`,
+		Priority: 30,
+		New: func(i *Instant) Answerer {
+			return &Calculator{}
+		},
+	})
+}
@@ -0,0 +1,682 @@
+// Package calculator parses and evaluates arithmetic expressions
+package calculator
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"math/big"
+	"strconv"
+	"strings"
+)
+
+// Expression is a parsed arithmetic expression
+type Expression struct {
+	root node
+}
+
+// Number is the value of an expression. Arithmetic on rational numbers is exact,
+// but irrational constants and functions like sin are only accurate to a float64.
+type Number struct {
+	*big.Rat
+	Exact bool
+}
+
+// ErrDivisionByZero is returned when dividing by zero
+var ErrDivisionByZero = errors.New("division by zero")
+
+// ErrTooLarge is returned when a result would be too large to compute
+var ErrTooLarge = errors.New("result too large")
+
+// maxBits caps the size of an exact result so that "9^9^9" can't eat the server
+const maxBits = 1 << 16
+
+// maxExponent keeps scientific notation under maxBits, as 10^maxExponent is about 2^maxBits
+const maxExponent = maxBits * 3 / 10
+
+// maxFactorial is the largest n whose n! is under maxBits
+const maxFactorial = 5000
+
+// Parse parses an expression like "2(3 + 4)^2", "sqrt(16) * pi", "15% of 80", "1.5e3" or "5!"
+func Parse(s string) (*Expression, error) {
+	toks, err := tokenize(s)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(toks) == 0 {
+		return nil, errors.New("empty expression")
+	}
+
+	p := &parser{toks: toks}
+	root, err := p.expr()
+	if err != nil {
+		return nil, err
+	}
+
+	if t := p.peek(); t.kind != eof {
+		return nil, fmt.Errorf("unexpected %q", t.text)
+	}
+
+	return &Expression{root: root}, nil
+}
+
+// String is the normalized expression, e.g. "2 * (3 + 4) ^ 2"
+func (e *Expression) String() string {
+	return e.root.String()
+}
+
+// Arithmetic is true if the expression does any math, rather than being a lone number or constant
+func (e *Expression) Arithmetic() bool {
+	switch e.root.(type) {
+	case *number, *constant:
+		return false
+	case *group:
+		return (&Expression{e.root.(*group).x}).Arithmetic()
+	}
+	return true
+}
+
+// Evaluate computes the value of the expression
+func (e *Expression) Evaluate() (*Number, error) {
+	return e.root.eval()
+}
+
+// String formats the number. Exact integers are written out in full, however big.
+func (n *Number) String() string {
+	if n.Exact && n.IsInt() {
+		return n.Num().String()
+	}
+
+	f := new(big.Float).SetPrec(256).SetRat(n.Rat)
+	if !n.Exact {
+		// hide float64 noise, e.g. sin(pi) is 0 not 1.2246e-16
+		if v, _ := f.Float64(); math.Abs(v) < 1e-12 {
+			return "0"
+		}
+		return f.Text('g', 12)
+	}
+
+	return f.Text('g', 15)
+}
+
+type node interface {
+	eval() (*Number, error)
+	String() string
+}
+
+type number struct {
+	text string
+	r    *big.Rat
+}
+
+func (n *number) eval() (*Number, error) {
+	return &Number{new(big.Rat).Set(n.r), true}, nil
+}
+
+func (n *number) String() string { return n.text }
+
+var constants = map[string]float64{
+	"pi": math.Pi,
+	"π":  math.Pi,
+	"e":  math.E,
+}
+
+type constant struct {
+	name string
+}
+
+func (c *constant) eval() (*Number, error) {
+	return inexact(constants[c.name])
+}
+
+func (c *constant) String() string { return c.name }
+
+type group struct {
+	x node
+}
+
+func (g *group) eval() (*Number, error) { return g.x.eval() }
+
+func (g *group) String() string { return "(" + g.x.String() + ")" }
+
+type unary struct {
+	op string
+	x  node
+}
+
+func (u *unary) eval() (*Number, error) {
+	x, err := u.x.eval()
+	if err != nil || u.op == "+" {
+		return x, err
+	}
+
+	x.Neg(x.Rat)
+	return x, nil
+}
+
+func (u *unary) String() string { return u.op + u.x.String() }
+
+// percent is "15%", i.e. 0.15
+type percent struct {
+	x node
+}
+
+func (p *percent) eval() (*Number, error) {
+	x, err := p.x.eval()
+	if err != nil {
+		return nil, err
+	}
+
+	x.Quo(x.Rat, big.NewRat(100, 1))
+	return x, nil
+}
+
+func (p *percent) String() string { return p.x.String() + "%" }
+
+// factorial is "5!". Only whole numbers have one.
+type factorial struct {
+	x node
+}
+
+func (f *factorial) eval() (*Number, error) {
+	x, err := f.x.eval()
+	if err != nil {
+		return nil, err
+	}
+
+	if !x.Exact || !x.IsInt() || x.Sign() < 0 {
+		return nil, errors.New("factorial of a number that isn't a whole number")
+	}
+
+	if n := x.Num(); !n.IsInt64() || n.Int64() > maxFactorial {
+		return nil, ErrTooLarge
+	}
+
+	return &Number{new(big.Rat).SetInt(new(big.Int).MulRange(1, x.Num().Int64())), true}, nil
+}
+
+func (f *factorial) String() string { return f.x.String() + "!" }
+
+// percentOf is "15% of 80"
+type percentOf struct {
+	p *percent
+	x node
+}
+
+func (p *percentOf) eval() (*Number, error) {
+	return binop("*", p.p, p.x)
+}
+
+func (p *percentOf) String() string { return p.p.String() + " of " + p.x.String() }
+
+type binary struct {
+	op   string
+	l, r node
+}
+
+func (b *binary) eval() (*Number, error) {
+	// "80 + 15%" is 80 plus 15% of 80, as on a pocket calculator. The
+	// group keeps 1 + 15% from landing back here.
+	if p, ok := b.r.(*percent); ok && (b.op == "+" || b.op == "-") {
+		return binop("*", b.l, &binary{op: b.op, l: &number{"1", big.NewRat(1, 1)}, r: &group{p}})
+	}
+
+	return binop(b.op, b.l, b.r)
+}
+
+func (b *binary) String() string { return b.l.String() + " " + b.op + " " + b.r.String() }
+
+func binop(op string, l, r node) (*Number, error) {
+	x, err := l.eval()
+	if err != nil {
+		return nil, err
+	}
+
+	y, err := r.eval()
+	if err != nil {
+		return nil, err
+	}
+
+	z := &Number{new(big.Rat), x.Exact && y.Exact}
+
+	switch op {
+	case "+":
+		z.Add(x.Rat, y.Rat)
+	case "-":
+		z.Sub(x.Rat, y.Rat)
+	case "*":
+		z.Mul(x.Rat, y.Rat)
+	case "/":
+		if y.Sign() == 0 {
+			return nil, ErrDivisionByZero
+		}
+		z.Quo(x.Rat, y.Rat)
+	case "%":
+		if y.Sign() == 0 {
+			return nil, ErrDivisionByZero
+		}
+		// x - y*trunc(x/y), with the sign of x like Go's %
+		q := new(big.Rat).Quo(x.Rat, y.Rat)
+		t := new(big.Int).Quo(q.Num(), q.Denom())
+		z.Sub(x.Rat, new(big.Rat).Mul(y.Rat, new(big.Rat).SetInt(t)))
+	case "^":
+		return pow(x, y)
+	default:
+		return nil, fmt.Errorf("unknown operator %q", op)
+	}
+
+	return z, nil
+}
+
+func pow(x, y *Number) (*Number, error) {
+	if y.IsInt() && x.Exact && y.Exact {
+		n := y.Num()
+		if !n.IsInt64() || int64(x.Num().BitLen()+x.Denom().BitLen())*abs(n.Int64()) > maxBits {
+			if x.Num().CmpAbs(x.Denom()) == 0 { // 1^n and -1^n
+				return inexact(math.Pow(x.Float(), y.Float()))
+			}
+			return nil, ErrTooLarge
+		}
+
+		if x.Sign() == 0 && n.Sign() < 0 {
+			return nil, ErrDivisionByZero
+		}
+
+		e := new(big.Int).Abs(n)
+		num := new(big.Int).Exp(x.Num(), e, nil)
+		den := new(big.Int).Exp(x.Denom(), e, nil)
+		if n.Sign() < 0 {
+			num, den = den, num
+		}
+		return &Number{new(big.Rat).SetFrac(num, den), true}, nil
+	}
+
+	return inexact(math.Pow(x.Float(), y.Float()))
+}
+
+var functions = map[string]func(float64) float64{
+	"abs":  math.Abs,
+	"acos": math.Acos,
+	"asin": math.Asin,
+	"atan": math.Atan,
+	"cbrt": math.Cbrt,
+	"cos":  math.Cos,
+	"exp":  math.Exp,
+	"ln":   math.Log,
+	"log":  math.Log10,
+	"sin":  math.Sin,
+	"sqrt": math.Sqrt,
+	"tan":  math.Tan,
+}
+
+type call struct {
+	fn string
+	x  node
+}
+
+func (c *call) eval() (*Number, error) {
+	x, err := c.x.eval()
+	if err != nil {
+		return nil, err
+	}
+
+	switch c.fn {
+	case "abs":
+		x.Abs(x.Rat)
+		return x, nil
+	case "sqrt":
+		if x.Exact && x.Sign() >= 0 {
+			if r, ok := exactSqrt(x.Rat); ok {
+				return &Number{r, true}, nil
+			}
+		}
+	}
+
+	return inexact(functions[c.fn](x.Float()))
+}
+
+func (c *call) String() string {
+	if _, ok := c.x.(*group); ok {
+		return c.fn + c.x.String()
+	}
+	return c.fn + "(" + c.x.String() + ")"
+}
+
+// exactSqrt is the square root of a perfect square, e.g. sqrt(16/9) = 4/3
+func exactSqrt(r *big.Rat) (*big.Rat, bool) {
+	num, den := new(big.Int).Sqrt(r.Num()), new(big.Int).Sqrt(r.Denom())
+	if new(big.Int).Mul(num, num).Cmp(r.Num()) != 0 || new(big.Int).Mul(den, den).Cmp(r.Denom()) != 0 {
+		return nil, false
+	}
+	return new(big.Rat).SetFrac(num, den), true
+}
+
+func inexact(f float64) (*Number, error) {
+	if math.IsNaN(f) || math.IsInf(f, 0) {
+		return nil, errors.New("undefined result")
+	}
+	return &Number{new(big.Rat).SetFloat64(f), false}, nil
+}
+
+// Float is the number as a float64
+func (n *Number) Float() float64 {
+	f, _ := n.Float64()
+	return f
+}
+
+func abs(i int64) int64 {
+	if i < 0 {
+		return -i
+	}
+	return i
+}
+
+type kind int
+
+const (
+	eof kind = iota
+	num
+	ident
+	op
+)
+
+type token struct {
+	kind kind
+	text string
+}
+
+// aliases normalizes other ways of writing an operator
+var aliases = map[string]string{
+	"×": "*", "÷": "/", "−": "-", "**": "^",
+}
+
+func tokenize(s string) ([]token, error) {
+	toks := []token{}
+	rs := []rune(strings.ToLower(s))
+
+	for i := 0; i < len(rs); {
+		r := rs[i]
+		switch {
+		case r == ' ' || r == '\t':
+			i++
+		case digit(r) || r == '.':
+			j := i
+			for j < len(rs) && (digit(rs[j]) || rs[j] == '.' || thousands(rs, j)) {
+				j++
+			}
+
+			// "1.5e3" is scientific notation, not 1.5 times e times 3
+			e := j
+			j = exponent(rs, j)
+			if j < len(rs) && (digit(rs[j]) || rs[j] == '.') {
+				return nil, fmt.Errorf("invalid number %q", string(rs[i:j+1]))
+			}
+
+			text := strings.Replace(string(rs[i:j]), ",", "", -1)
+			if _, ok := new(big.Rat).SetString(text); !ok || strings.Count(text, ".") > 1 {
+				return nil, fmt.Errorf("invalid number %q", string(rs[i:j]))
+			}
+
+			if j > e {
+				if n, err := strconv.Atoi(string(rs[e+1 : j])); err != nil || abs(int64(n)) > maxExponent {
+					return nil, ErrTooLarge
+				}
+			}
+
+			toks = append(toks, token{num, text})
+			i = j
+		case r >= 'a' && r <= 'z' || r == 'π':
+			j := i
+			for j < len(rs) && (rs[j] >= 'a' && rs[j] <= 'z' || rs[j] == 'π') {
+				j++
+			}
+
+			text := string(rs[i:j])
+			if _, ok := constants[text]; !ok && functions[text] == nil && text != "of" {
+				return nil, fmt.Errorf("unknown name %q", text)
+			}
+
+			toks = append(toks, token{ident, text})
+			i = j
+		case i+1 < len(rs) && string(rs[i:i+2]) == "**":
+			toks = append(toks, token{op, "^"})
+			i += 2
+		case strings.ContainsRune("+-*/^%()×÷−!", r):
+			text := string(r)
+			if a, ok := aliases[text]; ok {
+				text = a
+			}
+
+			toks = append(toks, token{op, text})
+			i++
+		default:
+			return nil, fmt.Errorf("unexpected %q", string(r))
+		}
+	}
+
+	return toks, nil
+}
+
+// exponent is the end of the exponent of scientific notation, as in "1.5e-3", that starts at i.
+// It is i if there isn't one, as in "2e" or "2exp(1)".
+func exponent(rs []rune, i int) int {
+	if i >= len(rs) || rs[i] != 'e' {
+		return i
+	}
+
+	j := i + 1
+	if j < len(rs) && (rs[j] == '+' || rs[j] == '-') {
+		j++
+	}
+
+	if j >= len(rs) || !digit(rs[j]) {
+		return i
+	}
+
+	for j < len(rs) && digit(rs[j]) {
+		j++
+	}
+
+	return j
+}
+
+// thousands is true for a comma that separates thousands, as in "1,000,000"
+func thousands(rs []rune, i int) bool {
+	if rs[i] != ',' || i == 0 || !digit(rs[i-1]) {
+		return false
+	}
+
+	for j := i + 1; j <= i+3; j++ {
+		if j >= len(rs) || !digit(rs[j]) {
+			return false
+		}
+	}
+
+	return i+4 >= len(rs) || !digit(rs[i+4])
+}
+
+func digit(r rune) bool {
+	return r >= '0' && r <= '9'
+}
+
+// parser is a recursive descent parser. From lowest to highest precedence:
+//
+//	expr    = term {("+" | "-") term}
+//	term    = unary {("*" | "/" | "%") unary | unary}  (the last is implicit multiplication, as in "2pi")
+//	unary   = ("-" | "+") unary | power
+//	power   = postfix ["^" unary]
+//	postfix = primary ["!"] ["%" ["of" power]]
+//	primary = number | constant | function primary | "(" expr ")"
+type parser struct {
+	toks []token
+	pos  int
+}
+
+func (p *parser) peek() token {
+	if p.pos >= len(p.toks) {
+		return token{kind: eof}
+	}
+	return p.toks[p.pos]
+}
+
+func (p *parser) next() token {
+	t := p.peek()
+	p.pos++
+	return t
+}
+
+func (p *parser) expr() (node, error) {
+	l, err := p.term()
+	if err != nil {
+		return nil, err
+	}
+
+	for t := p.peek(); t.kind == op && (t.text == "+" || t.text == "-"); t = p.peek() {
+		p.next()
+		r, err := p.term()
+		if err != nil {
+			return nil, err
+		}
+		l = &binary{t.text, l, r}
+	}
+
+	return l, nil
+}
+
+func (p *parser) term() (node, error) {
+	l, err := p.unary()
+	if err != nil {
+		return nil, err
+	}
+
+	for {
+		t := p.peek()
+		o := ""
+
+		switch {
+		case t.kind == op && (t.text == "*" || t.text == "/" || t.text == "%"):
+			p.next()
+			o = t.text
+		case p.operand(t):
+			o = "*"
+		default:
+			return l, nil
+		}
+
+		r, err := p.unary()
+		if err != nil {
+			return nil, err
+		}
+		l = &binary{o, l, r}
+	}
+}
+
+func (p *parser) unary() (node, error) {
+	if t := p.peek(); t.kind == op && (t.text == "-" || t.text == "+") {
+		p.next()
+		x, err := p.unary()
+		if err != nil {
+			return nil, err
+		}
+		return &unary{t.text, x}, nil
+	}
+
+	return p.power()
+}
+
+func (p *parser) power() (node, error) {
+	x, err := p.postfix()
+	if err != nil {
+		return nil, err
+	}
+
+	if t := p.peek(); t.kind == op && t.text == "^" {
+		p.next()
+		y, err := p.unary() // right associative, so 2^3^2 is 2^9
+		if err != nil {
+			return nil, err
+		}
+		return &binary{"^", x, y}, nil
+	}
+
+	return x, nil
+}
+
+func (p *parser) postfix() (node, error) {
+	x, err := p.primary()
+	if err != nil {
+		return nil, err
+	}
+
+	if t := p.peek(); t.kind == op && t.text == "!" {
+		p.next()
+		x = &factorial{x}
+	}
+
+	if t := p.peek(); t.kind != op || t.text != "%" {
+		return x, nil
+	}
+
+	// "10 % 3" is a remainder, left for term
+	if p.pos+1 < len(p.toks) && p.operand(p.toks[p.pos+1]) {
+		return x, nil
+	}
+
+	p.next()
+	pc := &percent{x}
+
+	if t := p.peek(); t.kind == ident && t.text == "of" {
+		p.next()
+		y, err := p.power()
+		if err != nil {
+			return nil, err
+		}
+		return &percentOf{pc, y}, nil
+	}
+
+	return pc, nil
+}
+
+func (p *parser) primary() (node, error) {
+	t := p.next()
+
+	switch t.kind {
+	case num:
+		r, _ := new(big.Rat).SetString(t.text)
+		return &number{t.text, r}, nil
+	case ident:
+		if _, ok := constants[t.text]; ok {
+			return &constant{t.text}, nil
+		}
+
+		if _, ok := functions[t.text]; ok {
+			x, err := p.primary()
+			if err != nil {
+				return nil, err
+			}
+			return &call{t.text, x}, nil
+		}
+	case op:
+		if t.text == "(" {
+			x, err := p.expr()
+			if err != nil {
+				return nil, err
+			}
+
+			if c := p.next(); c.kind != op || c.text != ")" {
+				return nil, errors.New("missing )")
+			}
+			return &group{x}, nil
+		}
+	case eof:
+		return nil, errors.New("unexpected end of expression")
+	}
+
+	return nil, fmt.Errorf("unexpected %q", t.text)
+}
+
+// operand is true if the token can start an operand, for implicit multiplication
+func (p *parser) operand(t token) bool {
+	return t.kind == num || (t.kind == ident && t.text != "of") || (t.kind == op && t.text == "(")
+}
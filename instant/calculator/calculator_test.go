@@ -0,0 +1,156 @@
+package calculator
+
+import (
+	"testing"
+)
+
+func TestEvaluate(t *testing.T) {
+	for _, c := range []struct {
+		expr       string
+		normalized string
+		want       string
+		exact      bool
+	}{
+		{"2+2", "2 + 2", "4", true},
+		{"(2+2)*3+6.3", "(2 + 2) * 3 + 6.3", "18.3", true},
+		{"(2+2)*3/6.4", "(2 + 2) * 3 / 6.4", "1.875", true},
+		{"0.1+0.2", "0.1 + 0.2", "0.3", true},
+		{"1/3", "1 / 3", "0.333333333333333", true},
+		{"2 + 3 * 4", "2 + 3 * 4", "14", true},
+		{"2^3^2", "2 ^ 3 ^ 2", "512", true},
+		{"2**10", "2 ^ 10", "1024", true},
+		{"-2^2", "-2 ^ 2", "-4", true},
+		{"2^-2", "2 ^ -2", "0.25", true},
+		{"2^100", "2 ^ 100", "1267650600228229401496703205376", true},
+		{"99999999999999999999 * 99999999999999999999", "99999999999999999999 * 99999999999999999999", "9999999999999999999800000000000000000001", true},
+		{"1,000,000 × 3", "1000000 * 3", "3000000", true},
+		{"10 ÷ 4", "10 / 4", "2.5", true},
+		{"10 % 3", "10 % 3", "1", true},
+		{"15% of 80", "15% of 80", "12", true},
+		{"15%", "15%", "0.15", true},
+		{"80 + 15%", "80 + 15%", "92", true},
+		{"80 - 25%", "80 - 25%", "60", true},
+		{"50% of 10 + 1", "50% of 10 + 1", "6", true},
+		{"sqrt(16)", "sqrt(16)", "4", true},
+		{"sqrt 16/9", "sqrt(16) / 9", "0.444444444444444", true},
+		{"sqrt(2)", "sqrt(2)", "1.41421356237", false},
+		{"2pi", "2 * pi", "6.28318530718", false},
+		{"2(3+4)", "2 * (3 + 4)", "14", true},
+		{"sin(pi)", "sin(pi)", "0", false},
+		{"cos(0)", "cos(0)", "1", false},
+		{"log(1000)", "log(1000)", "3", false},
+		{"ln(e)", "ln(e)", "1", false},
+		{"abs(-3.5)", "abs(-3.5)", "3.5", true},
+		{"2 ^ 0.5", "2 ^ 0.5", "1.41421356237", false},
+		{"1e6", "1e6", "1000000", true},
+		{"1E6", "1e6", "1000000", true},
+		{"2e3 + 1", "2e3 + 1", "2001", true},
+		{"1.5e-3", "1.5e-3", "0.0015", true},
+		{"6.02e+23 / 2", "6.02e+23 / 2", "301000000000000000000000", true},
+		{"1e400 / 1e399", "1e400 / 1e399", "10", true},
+		{"2e", "2 * e", "5.43656365692", false},
+		{"2e+1", "2e+1", "20", true},
+		{"2e + 1", "2 * e + 1", "6.43656365692", false},
+		{"exp(1)", "exp(1)", "2.71828182846", false},
+		{"5!", "5!", "120", true},
+		{"0!", "0!", "1", true},
+		{"2^3!", "2 ^ 3!", "64", true},
+		{"-3!", "-3!", "-6", true},
+		{"(1+2)! * 2", "(1 + 2)! * 2", "12", true},
+	} {
+		t.Run(c.expr, func(t *testing.T) {
+			e, err := Parse(c.expr)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if got := e.String(); got != c.normalized {
+				t.Fatalf("got %q; want %q", got, c.normalized)
+			}
+
+			n, err := e.Evaluate()
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if got := n.String(); got != c.want {
+				t.Fatalf("got %q; want %q", got, c.want)
+			}
+
+			if n.Exact != c.exact {
+				t.Fatalf("got exact %v; want %v", n.Exact, c.exact)
+			}
+		})
+	}
+}
+
+func TestErrors(t *testing.T) {
+	for _, c := range []struct {
+		expr  string
+		parse bool // fails to parse rather than to evaluate
+	}{
+		{"", true},
+		{"2 +", true},
+		{"(2 + 3", true},
+		{"2 + 3)", true},
+		{"1.2.3", true},
+		{"sine 30", true},
+		{"hello", true},
+		{"1 / 0", false},
+		{"10 % 0", false},
+		{"sqrt(-1)", false},
+		{"log(0)", false},
+		{"9^9^9", false},
+		{"0^-1", false},
+		{"1e3.5", true},
+		{"1e99999", true},
+		{"5!!", true},
+		{"(-3)!", false},
+		{"2.5!", false},
+		{"pi!", false},
+		{"9999!", false},
+	} {
+		t.Run(c.expr, func(t *testing.T) {
+			e, err := Parse(c.expr)
+			if (err != nil) != c.parse {
+				t.Fatalf("got parse error %v; want %v", err, c.parse)
+			}
+
+			if c.parse {
+				return
+			}
+
+			if _, err := e.Evaluate(); err == nil {
+				t.Fatal("expected an error")
+			}
+		})
+	}
+}
+
+func TestArithmetic(t *testing.T) {
+	for _, c := range []struct {
+		expr string
+		want bool
+	}{
+		{"2019", false},
+		{"pi", false},
+		{"(5)", false},
+		{"-5", true},
+		{"2+2", true},
+		{"sqrt 4", true},
+		{"15%", true},
+		{"1e6", false},
+		{"5!", true},
+	} {
+		t.Run(c.expr, func(t *testing.T) {
+			e, err := Parse(c.expr)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if got := e.Arithmetic(); got != c.want {
+				t.Fatalf("got %v; want %v", got, c.want)
+			}
+		})
+	}
+}
@@ -96,3 +96,14 @@ func (c *CamelCase) tests() []test {
 
 	return tests
 }
+
+func init() {
+	Register(Registration{
+		Name:     "camelcase",
+		Trigger:  `"camelcase" and some text`,
+		Priority: 40,
+		New: func(i *Instant) Answerer {
+			return &CamelCase{}
+		},
+	})
+}
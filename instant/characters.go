@@ -242,3 +242,14 @@ func (c *Characters) tests() []test {
 
 	return tests
 }
+
+func init() {
+	Register(Registration{
+		Name:     "characters",
+		Trigger:  `"number of characters" or "char count" and some text`,
+		Priority: 50,
+		New: func(i *Instant) Answerer {
+			return &Characters{}
+		},
+	})
+}
@@ -81,3 +81,14 @@ func (c *Coin) tests() []test {
 
 	return tests
 }
+
+func init() {
+	Register(Registration{
+		Name:     "coin",
+		Trigger:  `"flip a coin", "heads or tails" or "coin toss"`,
+		Priority: 60,
+		New: func(i *Instant) Answerer {
+			return &Coin{}
+		},
+	})
+}
@@ -0,0 +1,406 @@
+package instant
+
+import (
+	"fmt"
+	"math"
+	"net/http"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"golang.org/x/text/language"
+)
+
+// ColorType is an answer Type
+const ColorType Type = "color"
+
+// Color is an instant answer
+type Color struct {
+	Answer
+}
+
+// ColorResponse is a color in each of the formats we convert between
+type ColorResponse struct {
+	Hex  string `json:"hex"`
+	RGB  RGB    `json:"rgb"`
+	HSL  HSL    `json:"hsl"`
+	CMYK CMYK   `json:"cmyk"`
+	Name string `json:"name"`         // the nearest named CSS color
+	To   string `json:"to,omitempty"` // the format they asked to convert to, if any
+}
+
+// RGB is a color's red, green & blue, from 0 to 255
+type RGB struct {
+	R int `json:"r"`
+	G int `json:"g"`
+	B int `json:"b"`
+}
+
+// HSL is a color's hue in degrees and its saturation & lightness in percent
+type HSL struct {
+	H int `json:"h"`
+	S int `json:"s"`
+	L int `json:"l"`
+}
+
+// CMYK is a color's cyan, magenta, yellow & black in percent
+type CMYK struct {
+	C int `json:"c"`
+	M int `json:"m"`
+	Y int `json:"y"`
+	K int `json:"k"`
+}
+
+func (c RGB) String() string  { return fmt.Sprintf("rgb(%d, %d, %d)", c.R, c.G, c.B) }
+func (c HSL) String() string  { return fmt.Sprintf("hsl(%d, %d%%, %d%%)", c.H, c.S, c.L) }
+func (c CMYK) String() string { return fmt.Sprintf("cmyk(%d%%, %d%%, %d%%, %d%%)", c.C, c.M, c.Y, c.K) }
+
+var colorFormats = `hex|rgb|hsl|cmyk`
+
+func (c *Color) setQuery(r *http.Request, qv string) Answerer {
+	c.Answer.setQuery(r, qv)
+	return c
+}
+
+func (c *Color) setUserAgent(r *http.Request) Answerer {
+	return c
+}
+
+func (c *Color) setLanguage(lang language.Tag) Answerer {
+	c.language = lang
+	return c
+}
+
+func (c *Color) setType() Answerer {
+	c.Type = ColorType
+	return c
+}
+
+func (c *Color) setRegex() Answerer {
+	to := fmt.Sprintf(`(?: (?:to|in) (?P<to>%s))?`, colorFormats)
+	n := `\s*(\d{1,3})%?\s*`
+
+	// a bare hex code needs a "#" or some context, otherwise "deface" is a color
+	c.regex = append(c.regex, regexp.MustCompile(fmt.Sprintf(`^(?P<trigger>#)(?P<hex>[0-9a-f]{6}|[0-9a-f]{3})%s$`, to)))
+	c.regex = append(c.regex, regexp.MustCompile(fmt.Sprintf(`^(?P<hex>[0-9a-f]{6}|[0-9a-f]{3}) (?:to|in) (?P<to>%s)$`, colorFormats)))
+	c.regex = append(c.regex, regexp.MustCompile(`^(?:(?P<trigger>hex|color|colour) #?(?P<hex>[0-9a-f]{6}|[0-9a-f]{3})|#?(?P<hex2>[0-9a-f]{6}|[0-9a-f]{3}) (?P<trigger2>hex|color|colour))$`))
+	c.regex = append(c.regex, regexp.MustCompile(fmt.Sprintf(`^(?P<trigger>rgb|hsl)a?\s*\(?(?P<a>%[1]s),(?P<b>%[1]s),(?P<c>%[1]s)\)?%[2]s$`, n, to)))
+	c.regex = append(c.regex, regexp.MustCompile(fmt.Sprintf(`^(?P<trigger>rgb|hsl) (?P<a>\d{1,3}) (?P<b>\d{1,3}) (?P<c>\d{1,3})%s$`, to)))
+	c.regex = append(c.regex, regexp.MustCompile(fmt.Sprintf(`^(?P<trigger>cmyk)\s*\(?(?P<a>%[1]s),(?P<b>%[1]s),(?P<c>%[1]s),(?P<d>%[1]s)\)?%[2]s$`, n, to)))
+	c.regex = append(c.regex, regexp.MustCompile(fmt.Sprintf(`^(?P<name>[a-z]+) (?:color|colour) code%s$`, to)))
+	c.regex = append(c.regex, regexp.MustCompile(fmt.Sprintf(`^(?P<name>[a-z]+) (?:to|in) (?P<to>%s)$`, colorFormats)))
+	return c
+}
+
+func (c *Color) solve(r *http.Request) Answerer {
+	var rgb RGB
+	var err error
+
+	m := c.remainderM
+	if m["hex2"] != "" {
+		m["hex"] = m["hex2"]
+	}
+
+	switch {
+	case m["hex"] != "":
+		rgb, err = parseHex(m["hex"])
+	case m["name"] != "":
+		h, ok := colorNames[m["name"]]
+		if !ok {
+			err = fmt.Errorf("unknown color %q", m["name"])
+			break
+		}
+		rgb, err = parseHex(h)
+	case c.triggerWord == "cmyk":
+		var v []int
+		if v, err = colorInts(100, m["a"], m["b"], m["c"], m["d"]); err == nil {
+			rgb = cmykToRGB(CMYK{v[0], v[1], v[2], v[3]})
+		}
+	case c.triggerWord == "hsl":
+		var v []int
+		if v, err = colorInts(360, m["a"]); err == nil {
+			var sl []int
+			if sl, err = colorInts(100, m["b"], m["c"]); err == nil {
+				rgb = hslToRGB(HSL{v[0], sl[0], sl[1]})
+			}
+		}
+	case c.triggerWord == "rgb":
+		var v []int
+		if v, err = colorInts(255, m["a"], m["b"], m["c"]); err == nil {
+			rgb = RGB{v[0], v[1], v[2]}
+		}
+	default:
+		err = fmt.Errorf("not a color %q", c.query)
+	}
+
+	if err != nil {
+		c.Err = err
+		return c
+	}
+
+	c.Solution = &ColorResponse{
+		Hex:  fmt.Sprintf("#%02x%02x%02x", rgb.R, rgb.G, rgb.B),
+		RGB:  rgb,
+		HSL:  rgbToHSL(rgb),
+		CMYK: rgbToCMYK(rgb),
+		Name: nearestColor(rgb),
+		To:   m["to"],
+	}
+
+	return c
+}
+
+func parseHex(h string) (RGB, error) {
+	h = strings.TrimPrefix(h, "#")
+	if len(h) == 3 {
+		h = string([]byte{h[0], h[0], h[1], h[1], h[2], h[2]})
+	}
+
+	v, err := strconv.ParseUint(h, 16, 32)
+	if err != nil || len(h) != 6 {
+		return RGB{}, fmt.Errorf("invalid hex color %q", h)
+	}
+
+	return RGB{int(v >> 16), int(v >> 8 & 0xff), int(v & 0xff)}, nil
+}
+
+// colorInts parses each component, which must be no more than max
+func colorInts(max int, s ...string) ([]int, error) {
+	v := []int{}
+	for _, ss := range s {
+		i, err := strconv.Atoi(strings.TrimSpace(strings.TrimSuffix(strings.TrimSpace(ss), "%")))
+		if err != nil {
+			return nil, err
+		}
+
+		if i > max {
+			return nil, fmt.Errorf("%d is out of range", i)
+		}
+		v = append(v, i)
+	}
+	return v, nil
+}
+
+func rgbToHSL(c RGB) HSL {
+	r, g, b := float64(c.R)/255, float64(c.G)/255, float64(c.B)/255
+	max, min := math.Max(r, math.Max(g, b)), math.Min(r, math.Min(g, b))
+	l := (max + min) / 2
+
+	if max == min {
+		return HSL{0, 0, round(l * 100)}
+	}
+
+	d := max - min
+	s := d / (1 - math.Abs(2*l-1))
+
+	var h float64
+	switch max {
+	case r:
+		h = math.Mod((g-b)/d, 6)
+	case g:
+		h = (b-r)/d + 2
+	default:
+		h = (r-g)/d + 4
+	}
+
+	h *= 60
+	if h < 0 {
+		h += 360
+	}
+
+	return HSL{round(h) % 360, round(s * 100), round(l * 100)}
+}
+
+func hslToRGB(c HSL) RGB {
+	h, s, l := float64(c.H%360), float64(c.S)/100, float64(c.L)/100
+
+	ch := (1 - math.Abs(2*l-1)) * s
+	x := ch * (1 - math.Abs(math.Mod(h/60, 2)-1))
+	m := l - ch/2
+
+	var r, g, b float64
+	switch {
+	case h < 60:
+		r, g, b = ch, x, 0
+	case h < 120:
+		r, g, b = x, ch, 0
+	case h < 180:
+		r, g, b = 0, ch, x
+	case h < 240:
+		r, g, b = 0, x, ch
+	case h < 300:
+		r, g, b = x, 0, ch
+	default:
+		r, g, b = ch, 0, x
+	}
+
+	return RGB{round((r + m) * 255), round((g + m) * 255), round((b + m) * 255)}
+}
+
+func rgbToCMYK(c RGB) CMYK {
+	r, g, b := float64(c.R)/255, float64(c.G)/255, float64(c.B)/255
+	k := 1 - math.Max(r, math.Max(g, b))
+	if k == 1 {
+		return CMYK{0, 0, 0, 100}
+	}
+
+	return CMYK{
+		round((1 - r - k) / (1 - k) * 100),
+		round((1 - g - k) / (1 - k) * 100),
+		round((1 - b - k) / (1 - k) * 100),
+		round(k * 100),
+	}
+}
+
+func cmykToRGB(c CMYK) RGB {
+	k := 1 - float64(c.K)/100
+	return RGB{
+		round(255 * (1 - float64(c.C)/100) * k),
+		round(255 * (1 - float64(c.M)/100) * k),
+		round(255 * (1 - float64(c.Y)/100) * k),
+	}
+}
+
+func round(f float64) int {
+	return int(math.Floor(f + .5))
+}
+
+// nearestColor is the named color closest to c, using the "redmean" approximation of perceived distance
+func nearestColor(c RGB) string {
+	names := []string{}
+	for name := range colorNames {
+		names = append(names, name)
+	}
+	sort.Strings(names) // so ties (e.g. gray and grey) always go the same way
+
+	best, bestDist := "", math.MaxFloat64
+	for _, name := range names {
+		n, _ := parseHex(colorNames[name])
+
+		rm := float64(c.R+n.R) / 2
+		dr, dg, db := float64(c.R-n.R), float64(c.G-n.G), float64(c.B-n.B)
+		d := (2+rm/256)*dr*dr + 4*dg*dg + (2+(255-rm)/256)*db*db
+
+		if d < bestDist {
+			best, bestDist = name, d
+		}
+	}
+
+	return best
+}
+
+// colorNames are the CSS named colors
+var colorNames = map[string]string{
+	"aliceblue": "f0f8ff", "antiquewhite": "faebd7", "aqua": "00ffff", "aquamarine": "7fffd4",
+	"azure": "f0ffff", "beige": "f5f5dc", "bisque": "ffe4c4", "black": "000000",
+	"blanchedalmond": "ffebcd", "blue": "0000ff", "blueviolet": "8a2be2", "brown": "a52a2a",
+	"burlywood": "deb887", "cadetblue": "5f9ea0", "chartreuse": "7fff00", "chocolate": "d2691e",
+	"coral": "ff7f50", "cornflowerblue": "6495ed", "cornsilk": "fff8dc", "crimson": "dc143c",
+	"cyan": "00ffff", "darkblue": "00008b", "darkcyan": "008b8b", "darkgoldenrod": "b8860b",
+	"darkgray": "a9a9a9", "darkgreen": "006400", "darkgrey": "a9a9a9", "darkkhaki": "bdb76b",
+	"darkmagenta": "8b008b", "darkolivegreen": "556b2f", "darkorange": "ff8c00", "darkorchid": "9932cc",
+	"darkred": "8b0000", "darksalmon": "e9967a", "darkseagreen": "8fbc8f", "darkslateblue": "483d8b",
+	"darkslategray": "2f4f4f", "darkslategrey": "2f4f4f", "darkturquoise": "00ced1", "darkviolet": "9400d3",
+	"deeppink": "ff1493", "deepskyblue": "00bfff", "dimgray": "696969", "dimgrey": "696969",
+	"dodgerblue": "1e90ff", "firebrick": "b22222", "floralwhite": "fffaf0", "forestgreen": "228b22",
+	"fuchsia": "ff00ff", "gainsboro": "dcdcdc", "ghostwhite": "f8f8ff", "gold": "ffd700",
+	"goldenrod": "daa520", "gray": "808080", "green": "008000", "greenyellow": "adff2f",
+	"grey": "808080", "honeydew": "f0fff0", "hotpink": "ff69b4", "indianred": "cd5c5c",
+	"indigo": "4b0082", "ivory": "fffff0", "khaki": "f0e68c", "lavender": "e6e6fa",
+	"lavenderblush": "fff0f5", "lawngreen": "7cfc00", "lemonchiffon": "fffacd", "lightblue": "add8e6",
+	"lightcoral": "f08080", "lightcyan": "e0ffff", "lightgoldenrodyellow": "fafad2", "lightgray": "d3d3d3",
+	"lightgreen": "90ee90", "lightgrey": "d3d3d3", "lightpink": "ffb6c1", "lightsalmon": "ffa07a",
+	"lightseagreen": "20b2aa", "lightskyblue": "87cefa", "lightslategray": "778899", "lightslategrey": "778899",
+	"lightsteelblue": "b0c4de", "lightyellow": "ffffe0", "lime": "00ff00", "limegreen": "32cd32",
+	"linen": "faf0e6", "magenta": "ff00ff", "maroon": "800000", "mediumaquamarine": "66cdaa",
+	"mediumblue": "0000cd", "mediumorchid": "ba55d3", "mediumpurple": "9370db", "mediumseagreen": "3cb371",
+	"mediumslateblue": "7b68ee", "mediumspringgreen": "00fa9a", "mediumturquoise": "48d1cc", "mediumvioletred": "c71585",
+	"midnightblue": "191970", "mintcream": "f5fffa", "mistyrose": "ffe4e1", "moccasin": "ffe4b5",
+	"navajowhite": "ffdead", "navy": "000080", "oldlace": "fdf5e6", "olive": "808000",
+	"olivedrab": "6b8e23", "orange": "ffa500", "orangered": "ff4500", "orchid": "da70d6",
+	"palegoldenrod": "eee8aa", "palegreen": "98fb98", "paleturquoise": "afeeee", "palevioletred": "db7093",
+	"papayawhip": "ffefd5", "peachpuff": "ffdab9", "peru": "cd853f", "pink": "ffc0cb",
+	"plum": "dda0dd", "powderblue": "b0e0e6", "purple": "800080", "rebeccapurple": "663399",
+	"red": "ff0000", "rosybrown": "bc8f8f", "royalblue": "4169e1", "saddlebrown": "8b4513",
+	"salmon": "fa8072", "sandybrown": "f4a460", "seagreen": "2e8b57", "seashell": "fff5ee",
+	"sienna": "a0522d", "silver": "c0c0c0", "skyblue": "87ceeb", "slateblue": "6a5acd",
+	"slategray": "708090", "slategrey": "708090", "snow": "fffafa", "springgreen": "00ff7f",
+	"steelblue": "4682b4", "tan": "d2b48c", "teal": "008080", "thistle": "d8bfd8",
+	"tomato": "ff6347", "turquoise": "40e0d0", "violet": "ee82ee", "wheat": "f5deb3",
+	"white": "ffffff", "whitesmoke": "f5f5f5", "yellow": "ffff00", "yellowgreen": "9acd32",
+}
+
+func (c *Color) tests() []test {
+	orange := &ColorResponse{
+		Hex:  "#ff6600",
+		RGB:  RGB{255, 102, 0},
+		HSL:  HSL{24, 100, 50},
+		CMYK: CMYK{0, 60, 100, 0},
+		Name: "orangered",
+	}
+
+	to := func(f string) *ColorResponse {
+		o := *orange
+		o.To = f
+		return &o
+	}
+
+	tests := []test{}
+
+	for _, c := range []struct {
+		q    string
+		want *ColorResponse
+	}{
+		{"#ff6600", orange},
+		{"#F60", orange},
+		{"ff6600 to rgb", to("rgb")},
+		{"#ff6600 in cmyk", to("cmyk")},
+		{"hex ff6600", orange},
+		{"ff6600 color", orange},
+		{"rgb(255,102,0)", orange},
+		{"rgb(255, 102, 0) to hex", to("hex")},
+		{"rgb 255 102 0", orange},
+		{"hsl(24, 100%, 50%)", orange},
+		{"cmyk(0%, 60%, 100%, 0%)", orange},
+		{"rebeccapurple to hex", &ColorResponse{
+			Hex:  "#663399",
+			RGB:  RGB{102, 51, 153},
+			HSL:  HSL{270, 50, 40},
+			CMYK: CMYK{33, 67, 0, 40},
+			Name: "rebeccapurple",
+			To:   "hex",
+		}},
+		{"black color code", &ColorResponse{
+			Hex:  "#000000",
+			RGB:  RGB{0, 0, 0},
+			HSL:  HSL{0, 0, 0},
+			CMYK: CMYK{0, 0, 0, 100},
+			Name: "black",
+		}},
+	} {
+		tests = append(tests, test{
+			query: c.q,
+			expected: []Data{
+				{
+					Type:      ColorType,
+					Triggered: true,
+					Solution:  c.want,
+				},
+			},
+		})
+	}
+
+	return tests
+}
+
+func init() {
+	Register(Registration{
+		Name:     "color",
+		Trigger:  `a hex, rgb, hsl or cmyk color, e.g. "#ff6600" or "rgb(255,102,0) to hex"`,
+		Priority: 430,
+		New: func(i *Instant) Answerer {
+			return &Color{}
+		},
+	})
+}
@@ -7,6 +7,7 @@ import (
 	"strings"
 
 	"github.com/jivesearch/jivesearch/instant/congress"
+	"github.com/jivesearch/jivesearch/search/intent"
 	"golang.org/x/text/language"
 )
 
@@ -188,3 +189,16 @@ func (c *Congress) tests() []test {
 
 	return tests
 }
+
+func init() {
+	Register(Registration{
+		Name:     "congress",
+		Trigger:  `"senators" or "house members" and a US state`,
+		Priority: 70,
+		Intent:   intent.Informational,
+		External: true,
+		New: func(i *Instant) Answerer {
+			return &Congress{Fetcher: i.CongressFetcher}
+		},
+	})
+}
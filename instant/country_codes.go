@@ -156,3 +156,14 @@ func (c *CountryCode) tests() []test {
 
 	return tests
 }
+
+func init() {
+	Register(Registration{
+		Name:     "country_code",
+		Trigger:  `"country code" or "iso code" and a country`,
+		Priority: 80,
+		New: func(i *Instant) Answerer {
+			return &CountryCode{}
+		},
+	})
+}
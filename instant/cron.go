@@ -0,0 +1,515 @@
+package instant
+
+import (
+	"fmt"
+	"net/http"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/jivesearch/jivesearch/instant/location"
+	"golang.org/x/text/language"
+)
+
+// CronType is an answer Type
+const CronType Type = "cron"
+
+// Cron is an instant answer that explains a cron expression
+type Cron struct {
+	LocationFetcher location.Fetcher
+	Answer
+}
+
+// CronResponse is a cron expression in words and when it will next run
+type CronResponse struct {
+	Expression  string      `json:"expression"`
+	Description string      `json:"description"`
+	TimeZone    string      `json:"time_zone"`
+	Next        []time.Time `json:"next"`
+}
+
+// how many upcoming runs to show
+const cronRuns = 5
+
+var cronMacros = map[string]string{
+	"@yearly":   "0 0 1 1 *",
+	"@annually": "0 0 1 1 *",
+	"@monthly":  "0 0 1 * *",
+	"@weekly":   "0 0 * * 0",
+	"@daily":    "0 0 * * *",
+	"@midnight": "0 0 * * *",
+	"@hourly":   "0 * * * *",
+}
+
+func (c *Cron) setQuery(r *http.Request, qv string) Answerer {
+	c.Answer.setQuery(r, qv)
+	return c
+}
+
+func (c *Cron) setUserAgent(r *http.Request) Answerer {
+	return c
+}
+
+func (c *Cron) setLanguage(lang language.Tag) Answerer {
+	c.language = lang
+	return c
+}
+
+func (c *Cron) setType() Answerer {
+	c.Type = CronType
+	return c
+}
+
+func (c *Cron) setRegex() Answerer {
+	expr := `(?:[0-9a-z*,/-]+ ){4}[0-9a-z*,/-]+|@[a-z]+`
+	c.regex = append(c.regex, regexp.MustCompile(`^(?P<trigger>cron|crontab)(?: expression| job| schedule)? (?P<remainder>`+expr+`)$`))
+	c.regex = append(c.regex, regexp.MustCompile(`^(?P<remainder>`+expr+`) (?P<trigger>cron|crontab)(?: expression| job| schedule)?$`))
+	return c
+}
+
+func (c *Cron) solve(r *http.Request) Answerer {
+	s, err := parseCron(c.remainder)
+	if err != nil {
+		c.Err = err
+		return c
+	}
+
+	// the runs are in the user's time zone if we can find it
+	loc := time.UTC
+	if city, err := c.LocationFetcher.Fetch(IPAddress(r)); err == nil && city.Location.TimeZone != "" {
+		if l, err := time.LoadLocation(city.Location.TimeZone); err == nil {
+			loc = l
+		}
+	}
+
+	c.Solution = &CronResponse{
+		Expression:  c.remainder,
+		Description: s.describe(),
+		TimeZone:    loc.String(),
+		Next:        s.next(now().In(loc), cronRuns),
+	}
+
+	return c
+}
+
+// cronItem is one of the comma separated parts of a field, e.g. "1-5" or "*/15"
+type cronItem struct {
+	start, end, step int
+	every            bool // "*" or "*/n"
+}
+
+type cronField struct {
+	items []cronItem
+	bits  uint64
+	star  bool // starts with a "*", which matters for how the day fields combine
+	cronUnit
+}
+
+type cronUnit struct {
+	name     string
+	min, max int
+	names    []string // the names of the values, starting at min
+}
+
+var cronUnits = []cronUnit{
+	{name: "minute", min: 0, max: 59},
+	{name: "hour", min: 0, max: 23},
+	{name: "day-of-month", min: 1, max: 31},
+	{name: "month", min: 1, max: 12, names: []string{
+		"January", "February", "March", "April", "May", "June",
+		"July", "August", "September", "October", "November", "December",
+	}},
+	{name: "day-of-week", min: 0, max: 7, names: []string{ // 0 & 7 are both Sunday
+		"Sunday", "Monday", "Tuesday", "Wednesday", "Thursday", "Friday", "Saturday", "Sunday",
+	}},
+}
+
+type cronSchedule struct {
+	minute, hour, dom, month, dow *cronField
+}
+
+func parseCron(expr string) (*cronSchedule, error) {
+	if m, ok := cronMacros[expr]; ok {
+		expr = m
+	}
+
+	parts := strings.Fields(expr)
+	if len(parts) != len(cronUnits) {
+		return nil, fmt.Errorf("a cron expression has %d fields, not %d", len(cronUnits), len(parts))
+	}
+
+	fields := make([]*cronField, len(parts))
+	for i, p := range parts {
+		f, err := parseCronField(p, cronUnits[i])
+		if err != nil {
+			return nil, err
+		}
+		fields[i] = f
+	}
+
+	return &cronSchedule{fields[0], fields[1], fields[2], fields[3], fields[4]}, nil
+}
+
+func parseCronField(s string, u cronUnit) (*cronField, error) {
+	f := &cronField{star: strings.HasPrefix(s, "*"), cronUnit: u}
+
+	for _, part := range strings.Split(s, ",") {
+		item := cronItem{start: u.min, end: u.max, step: 1}
+
+		rng := part
+		if i := strings.Index(part, "/"); i > -1 {
+			step, err := strconv.Atoi(part[i+1:])
+			if err != nil || step < 1 {
+				return nil, fmt.Errorf("invalid %v step %q", u.name, part)
+			}
+			item.step, rng = step, part[:i]
+		}
+
+		switch {
+		case rng == "*":
+			item.every = true
+			if u.name == "day-of-week" {
+				item.end = 6
+			}
+		case strings.Contains(rng, "-"):
+			ends := strings.SplitN(rng, "-", 2)
+			start, err := u.value(ends[0])
+			if err != nil {
+				return nil, err
+			}
+			end, err := u.value(ends[1])
+			if err != nil {
+				return nil, err
+			}
+			if end < start {
+				return nil, fmt.Errorf("invalid %v range %q", u.name, rng)
+			}
+			item.start, item.end = start, end
+		default:
+			v, err := u.value(rng)
+			if err != nil {
+				return nil, err
+			}
+			item.start = v
+			if item.step == 1 { // "5/15" runs from 5 to the end
+				item.end = v
+			}
+		}
+
+		for v := item.start; v <= item.end; v += item.step {
+			if u.name == "day-of-week" && v == 7 {
+				v = 0
+			}
+			f.bits |= 1 << uint(v)
+		}
+
+		f.items = append(f.items, item)
+	}
+
+	return f, nil
+}
+
+// value is a number or a name, e.g. "jan" or "mon"
+func (u cronUnit) value(s string) (int, error) {
+	for i, name := range u.names {
+		if strings.HasPrefix(strings.ToLower(name), s) && len(s) == 3 {
+			return u.min + i, nil
+		}
+	}
+
+	v, err := strconv.Atoi(s)
+	if err != nil || v < u.min || v > u.max {
+		return 0, fmt.Errorf("invalid %v %q", u.name, s)
+	}
+
+	return v, nil
+}
+
+func (u cronUnit) label(v int) string {
+	if u.names != nil {
+		return u.names[v-u.min]
+	}
+	return strconv.Itoa(v)
+}
+
+func (f *cronField) has(v int) bool {
+	return f.bits&(1<<uint(v)) != 0
+}
+
+// all is true when the field matches every value, like "*"
+func (f *cronField) all() bool {
+	for v := f.min; v <= f.max; v++ {
+		if f.name == "day-of-week" && v == 7 {
+			break
+		}
+		if !f.has(v) {
+			return false
+		}
+	}
+	return true
+}
+
+// singles are the field's values when it is a plain list like "0,30"
+func (f *cronField) singles() []int {
+	vs := []int{}
+	for _, item := range f.items {
+		if item.every || item.start != item.end {
+			return nil
+		}
+		vs = append(vs, item.start)
+	}
+	sort.Ints(vs)
+	return vs
+}
+
+func (f *cronField) describe() string {
+	descs := []string{}
+	for _, item := range f.items {
+		var d string
+
+		switch {
+		case item.every && item.step == 1:
+			d = "every " + f.name
+		case item.every:
+			d = fmt.Sprintf("every %v %v", ordinal(item.step), f.name)
+		case item.start == item.end:
+			d = f.label(item.start)
+		case item.step == 1:
+			d = f.label(item.start) + " through " + f.label(item.end)
+		default:
+			d = fmt.Sprintf("every %v %v from %v through %v", ordinal(item.step), f.name, f.label(item.start), f.label(item.end))
+		}
+
+		descs = append(descs, d)
+	}
+
+	d := joinAnd(descs)
+
+	// "hour 5" but "every 2nd hour"
+	if f.names == nil && !strings.HasPrefix(d, "every") {
+		d = f.name + " " + d
+	}
+
+	return d
+}
+
+func (s *cronSchedule) describe() string {
+	parts := []string{s.describeTime()}
+
+	switch dom, dow := !s.dom.all(), !s.dow.all(); {
+	case dom && dow && !s.dom.star && !s.dow.star: // cron runs when either matches
+		parts = append(parts, "on "+s.dom.describe()+" or on "+s.dow.describe())
+	case dom:
+		parts = append(parts, "on "+s.dom.describe())
+		if dow {
+			parts = append(parts, "if it's "+s.dow.describe())
+		}
+	case dow:
+		parts = append(parts, "on "+s.dow.describe())
+	}
+
+	if !s.month.all() {
+		parts = append(parts, "in "+s.month.describe())
+	}
+
+	return strings.Join(parts, " ")
+}
+
+func (s *cronSchedule) describeTime() string {
+	mins, hours := s.minute.singles(), s.hour.singles()
+
+	// a handful of times of day read better as clock times, e.g. "At 09:00 and 17:00"
+	if len(mins) > 0 && len(hours) > 0 && len(mins)*len(hours) <= 6 {
+		times := []string{}
+		for _, h := range hours {
+			for _, m := range mins {
+				times = append(times, fmt.Sprintf("%02d:%02d", h, m))
+			}
+		}
+		return "At " + joinAnd(times)
+	}
+
+	var d string
+	switch {
+	case s.minute.all():
+		d = "At every minute"
+	default:
+		d = "At " + s.minute.describe()
+	}
+
+	if !s.hour.all() {
+		d += " past " + s.hour.describe()
+	}
+
+	return d
+}
+
+// next are the following n runs after t, giving up after a few years for schedules like February 30th
+func (s *cronSchedule) next(t time.Time, n int) []time.Time {
+	times := []time.Time{}
+
+	t = t.Truncate(time.Minute).Add(time.Minute)
+	limit := t.AddDate(5, 0, 0)
+
+	for len(times) < n && t.Before(limit) {
+		switch {
+		case !s.month.has(int(t.Month())):
+			t = time.Date(t.Year(), t.Month()+1, 1, 0, 0, 0, 0, t.Location())
+		case !s.dayMatches(t):
+			t = time.Date(t.Year(), t.Month(), t.Day()+1, 0, 0, 0, 0, t.Location())
+		case !s.hour.has(t.Hour()):
+			t = time.Date(t.Year(), t.Month(), t.Day(), t.Hour()+1, 0, 0, 0, t.Location())
+		case !s.minute.has(t.Minute()):
+			t = t.Add(time.Minute)
+		default:
+			times = append(times, t)
+			t = t.Add(time.Minute)
+		}
+	}
+
+	return times
+}
+
+// If both day fields are restricted cron runs when either of them matches
+func (s *cronSchedule) dayMatches(t time.Time) bool {
+	dom, dow := s.dom.has(t.Day()), s.dow.has(int(t.Weekday()))
+	if s.dom.star || s.dow.star {
+		return dom && dow
+	}
+	return dom || dow
+}
+
+func ordinal(n int) string {
+	suffix := "th"
+	switch n % 10 {
+	case 1:
+		suffix = "st"
+	case 2:
+		suffix = "nd"
+	case 3:
+		suffix = "rd"
+	}
+
+	if n%100 >= 11 && n%100 <= 13 {
+		suffix = "th"
+	}
+
+	return strconv.Itoa(n) + suffix
+}
+
+// joinAnd joins a list like "a, b and c"
+func joinAnd(s []string) string {
+	if len(s) < 2 {
+		return strings.Join(s, "")
+	}
+	return strings.Join(s[:len(s)-1], ", ") + " and " + s[len(s)-1]
+}
+
+func (c *Cron) tests() []test {
+	denver, err := time.LoadLocation("America/Denver")
+	if err != nil {
+		panic(err)
+	}
+
+	// now is Saturday, June 4th 2016 at 21:02 in Denver
+	tests := []test{}
+
+	for _, tt := range []struct {
+		query       string
+		expression  string
+		description string
+		next        []time.Time
+	}{
+		{
+			query:       "cron 0 5 * * 1",
+			expression:  "0 5 * * 1",
+			description: "At 05:00 on Monday",
+			next: []time.Time{
+				time.Date(2016, 6, 6, 5, 0, 0, 0, denver),
+				time.Date(2016, 6, 13, 5, 0, 0, 0, denver),
+				time.Date(2016, 6, 20, 5, 0, 0, 0, denver),
+				time.Date(2016, 6, 27, 5, 0, 0, 0, denver),
+				time.Date(2016, 7, 4, 5, 0, 0, 0, denver),
+			},
+		},
+		{
+			query:       "*/15 9-17 * * mon-fri crontab",
+			expression:  "*/15 9-17 * * mon-fri",
+			description: "At every 15th minute past hour 9 through 17 on Monday through Friday",
+			next: []time.Time{
+				time.Date(2016, 6, 6, 9, 0, 0, 0, denver),
+				time.Date(2016, 6, 6, 9, 15, 0, 0, denver),
+				time.Date(2016, 6, 6, 9, 30, 0, 0, denver),
+				time.Date(2016, 6, 6, 9, 45, 0, 0, denver),
+				time.Date(2016, 6, 6, 10, 0, 0, 0, denver),
+			},
+		},
+		{
+			query:       "cron expression 30 9,17 1,15 * 5",
+			expression:  "30 9,17 1,15 * 5",
+			description: "At 09:30 and 17:30 on day-of-month 1 and 15 or on Friday",
+			next: []time.Time{
+				time.Date(2016, 6, 10, 9, 30, 0, 0, denver),
+				time.Date(2016, 6, 10, 17, 30, 0, 0, denver),
+				time.Date(2016, 6, 15, 9, 30, 0, 0, denver),
+				time.Date(2016, 6, 15, 17, 30, 0, 0, denver),
+				time.Date(2016, 6, 17, 9, 30, 0, 0, denver),
+			},
+		},
+		{
+			query:       "cron @yearly",
+			expression:  "@yearly",
+			description: "At 00:00 on day-of-month 1 in January",
+			next: []time.Time{
+				time.Date(2017, 1, 1, 0, 0, 0, 0, denver),
+				time.Date(2018, 1, 1, 0, 0, 0, 0, denver),
+				time.Date(2019, 1, 1, 0, 0, 0, 0, denver),
+				time.Date(2020, 1, 1, 0, 0, 0, 0, denver),
+				time.Date(2021, 1, 1, 0, 0, 0, 0, denver),
+			},
+		},
+		{
+			query:       "cron 0 */6 * jan-mar *",
+			expression:  "0 */6 * jan-mar *",
+			description: "At minute 0 past every 6th hour in January through March",
+			next: []time.Time{
+				time.Date(2017, 1, 1, 0, 0, 0, 0, denver),
+				time.Date(2017, 1, 1, 6, 0, 0, 0, denver),
+				time.Date(2017, 1, 1, 12, 0, 0, 0, denver),
+				time.Date(2017, 1, 1, 18, 0, 0, 0, denver),
+				time.Date(2017, 1, 2, 0, 0, 0, 0, denver),
+			},
+		},
+	} {
+		tests = append(tests, test{
+			query: tt.query,
+			expected: []Data{
+				{
+					Type:      CronType,
+					Triggered: true,
+					Solution: &CronResponse{
+						Expression:  tt.expression,
+						Description: tt.description,
+						TimeZone:    "America/Denver",
+						Next:        tt.next,
+					},
+				},
+			},
+		})
+	}
+
+	return tests
+}
+
+func init() {
+	Register(Registration{
+		Name:     "cron",
+		Trigger:  `"cron" and a cron expression, e.g. "cron 0 5 * * 1"`,
+		Priority: 550,
+		New: func(i *Instant) Answerer {
+			return &Cron{LocationFetcher: i.LocationFetcher}
+		},
+	})
+}
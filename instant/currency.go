@@ -8,6 +8,7 @@ import (
 	"time"
 
 	curr "github.com/jivesearch/jivesearch/instant/currency"
+	"github.com/jivesearch/jivesearch/search/intent"
 	"golang.org/x/text/language"
 )
 
@@ -301,3 +302,19 @@ func (c *Currency) tests() []test {
 
 	return tests
 }
+
+func init() {
+	Register(Registration{
+		Name:     "currency",
+		Trigger:  `currencies or cryptocurrencies to convert, e.g. "convert 100 usd to eur"`,
+		Priority: 90,
+		Intent:   intent.Transactional,
+		External: true,
+		New: func(i *Instant) Answerer {
+			return &Currency{
+				CryptoFetcher: i.CryptoFetcher,
+				FXFetcher:     i.FXFetcher,
+			}
+		},
+	})
+}
@@ -61,6 +61,40 @@ func (r *Response) Sort() *Response {
 	return r
 }
 
+// Convert converts an amount between currencies at their latest rates.
+// ok is false if we don't have a rate for either of them.
+func (r *Response) Convert(amount float64, from, to string) (converted float64, ok bool) {
+	if r == nil {
+		return 0, false
+	}
+
+	f, ok := r.latest(from)
+	if !ok {
+		return 0, false
+	}
+
+	t, ok := r.latest(to)
+	if !ok {
+		return 0, false
+	}
+
+	return amount * f / t, true
+}
+
+// latest is the most recent rate of a currency in the base currency. History must be sorted.
+func (r *Response) latest(c string) (float64, bool) {
+	if c == r.Base.Short {
+		return 1, true
+	}
+
+	h := r.History[c]
+	if len(h) == 0 || h[len(h)-1].Rate == 0 {
+		return 0, false
+	}
+
+	return h[len(h)-1].Rate, true
+}
+
 // Currency is an FX currency
 type Currency struct {
 	Short string
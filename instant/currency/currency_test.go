@@ -1,6 +1,7 @@
 package currency
 
 import (
+	"math"
 	"reflect"
 	"testing"
 	"time"
@@ -104,3 +105,42 @@ func TestSort(t *testing.T) {
 		})
 	}
 }
+
+func TestConvert(t *testing.T) {
+	r := &Response{
+		Base: USD,
+		History: map[string][]*Rate{
+			EUR.Short: {
+				{DateTime: time.Date(2018, 1, 30, 0, 0, 0, 0, time.UTC), Rate: 1.1},
+				{DateTime: time.Date(2018, 1, 31, 0, 0, 0, 0, time.UTC), Rate: 1.25},
+			},
+			GBP.Short: {
+				{DateTime: time.Date(2018, 1, 31, 0, 0, 0, 0, time.UTC), Rate: 1.5},
+			},
+		},
+	}
+
+	for _, c := range []struct {
+		amount   float64
+		from, to string
+		want     float64
+		ok       bool
+	}{
+		{10, "EUR", "USD", 12.5, true},
+		{12.5, "USD", "EUR", 10, true},
+		{15, "GBP", "EUR", 18, true},
+		{10, "USD", "USD", 10, true},
+		{10, "JPY", "USD", 0, false},
+		{10, "USD", "JPY", 0, false},
+	} {
+		got, ok := r.Convert(c.amount, c.from, c.to)
+		if ok != c.ok || math.Abs(got-c.want) > 1e-9 {
+			t.Errorf("%v %v to %v: got %v, %v; want %v, %v", c.amount, c.from, c.to, got, ok, c.want, c.ok)
+		}
+	}
+
+	var nilResponse *Response
+	if _, ok := nilResponse.Convert(1, "USD", "EUR"); ok {
+		t.Error("expected a nil Response not to convert")
+	}
+}
@@ -0,0 +1,439 @@
+package instant
+
+import (
+	"fmt"
+	"math"
+	"net/http"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/dustin/go-humanize"
+	"golang.org/x/text/language"
+)
+
+// DateMathType is an answer Type
+const DateMathType Type = "date math"
+
+// DateMath is an instant answer for the days between dates, a date so many days from another and ages
+type DateMath struct {
+	Region language.Region // the user's region, for how dates are written
+	Answer
+}
+
+// DateMathResponse is the time from one date to another
+type DateMathResponse struct {
+	Question string        `json:"question"` // "between", "offset" or "age"
+	From     FormattedDate `json:"from"`
+	To       FormattedDate `json:"to"`
+	Days     int           `json:"days"` // negative if To is before From
+	Span     DateSpan      `json:"span"`
+	Unit     string        `json:"unit"`   // what was asked for, e.g. "weeks" or "month"
+	Amount   float64       `json:"amount"` // in Unit
+	YearOnly bool          `json:"year_only,omitempty"`
+	Summary  string        `json:"summary"` // e.g. "156 days" or "28 or 29 years old"
+}
+
+// FormattedDate is a date written the way the user's region writes them
+type FormattedDate struct {
+	Date      time.Time `json:"date"`
+	Formatted string    `json:"formatted"`
+}
+
+// DateSpan is the time between two dates in calendar years, months and days
+type DateSpan struct {
+	Years  int `json:"years"`
+	Months int `json:"months"`
+	Days   int `json:"days"`
+}
+
+const (
+	dateMathBetween = "between"
+	dateMathOffset  = "offset"
+	dateMathAge     = "age"
+)
+
+// e.g. the "th" in "march 4th"
+var ordinalSuffix = regexp.MustCompile(`(\d)(?:st|nd|rd|th)\b`)
+
+// regions that write the month before the day
+var monthFirstRegions = map[string]bool{"US": true, "PH": true, "FM": true, "MH": true, "PW": true}
+
+// regions that write the year first
+var yearFirstRegions = map[string]bool{
+	"CN": true, "HU": true, "JP": true, "KR": true, "LT": true, "MN": true, "TW": true,
+}
+
+func (d *DateMath) setQuery(r *http.Request, qv string) Answerer {
+	d.Answer.setQuery(r, qv)
+	return d
+}
+
+func (d *DateMath) setUserAgent(r *http.Request) Answerer {
+	return d
+}
+
+func (d *DateMath) setLanguage(lang language.Tag) Answerer {
+	d.language = lang
+	return d
+}
+
+func (d *DateMath) setType() Answerer {
+	d.Type = DateMathType
+	return d
+}
+
+func (d *DateMath) setRegex() Answerer {
+	units := `(?P<unit>days|weeks|months|years)`
+
+	d.regex = append(d.regex, regexp.MustCompile(`^(?:how many )?`+units+` (?:are there )?(?:between|from) (?P<from>.+?) (?:and|to|until|till) (?P<to>.+)$`))
+	d.regex = append(d.regex, regexp.MustCompile(`^(?:how many )?`+units+` (?:are there )?(?P<dir>until|till|since) (?P<date>.+)$`))
+	d.regex = append(d.regex, regexp.MustCompile(`^(?:what is the date |what's the date |what date is |date )?(?P<n>\d+) (?P<unit>days?|weeks?|months?|years?) (?P<dir>from|after|before|ago)(?: (?P<date>.+))?$`))
+	d.regex = append(d.regex, regexp.MustCompile(`^how old (?:is|am|are) (?:someone|somebody|a person|i|you)(?: if)?(?: i was| you were)? born (?:in|on) (?P<born>.+)$`))
+	d.regex = append(d.regex, regexp.MustCompile(`^age of (?:someone|somebody|a person) born (?:in|on) (?P<born>.+)$`))
+	return d
+}
+
+func (d *DateMath) solve(r *http.Request) Answerer {
+	today := now()
+	today = time.Date(today.Year(), today.Month(), today.Day(), 0, 0, 0, 0, time.UTC)
+
+	resp := &DateMathResponse{}
+	var from, to time.Time
+	var err error
+
+	switch {
+	case d.remainderM["born"] != "":
+		resp.Question, resp.Unit = dateMathAge, "years"
+
+		if y, e := strconv.Atoi(d.remainderM["born"]); e == nil && y > 999 && y < 10000 {
+			from, resp.YearOnly = time.Date(y, time.January, 1, 0, 0, 0, 0, time.UTC), true
+		} else if from, err = d.parseDate(d.remainderM["born"], today); err != nil {
+			d.Err = err
+			return d
+		}
+
+		to = today
+		if from.After(to) {
+			d.Err = fmt.Errorf("%v hasn't happened yet", d.remainderM["born"])
+			return d
+		}
+	case d.remainderM["n"] != "":
+		resp.Question = dateMathOffset
+		resp.Unit = strings.TrimSuffix(d.remainderM["unit"], "s") + "s"
+
+		n, err := strconv.Atoi(d.remainderM["n"])
+		if err != nil {
+			d.Err = err
+			return d
+		}
+		resp.Amount = float64(n)
+
+		from = today
+		switch dir := d.remainderM["dir"]; {
+		case dir == "ago" && d.remainderM["date"] != "":
+			d.Err = fmt.Errorf("%q can't have a date", d.query)
+			return d
+		case dir == "ago":
+			n = -n
+		case d.remainderM["date"] == "":
+			d.Err = fmt.Errorf("%q needs a date", d.query)
+			return d
+		default:
+			if from, err = d.parseDate(d.remainderM["date"], today); err != nil {
+				d.Err = err
+				return d
+			}
+			if dir == "before" {
+				n = -n
+			}
+		}
+
+		to = addDate(from, resp.Unit, n)
+	default:
+		resp.Question, resp.Unit = dateMathBetween, d.remainderM["unit"]
+
+		f, t := d.remainderM["from"], d.remainderM["to"]
+		switch d.remainderM["dir"] {
+		case "since":
+			f, t = d.remainderM["date"], "today"
+		case "until", "till":
+			f, t = "today", d.remainderM["date"]
+		}
+
+		if from, err = d.parseDate(f, today); err != nil {
+			d.Err = err
+			return d
+		}
+		if to, err = d.parseDate(t, today); err != nil {
+			d.Err = err
+			return d
+		}
+	}
+
+	resp.From = d.format(from)
+	resp.To = d.format(to)
+	resp.Days = int(math.Round(to.Sub(from).Hours() / 24))
+	resp.Span = dateSpan(from, to)
+
+	switch resp.Question {
+	case dateMathAge:
+		resp.Amount = float64(resp.Span.Years)
+		resp.Summary = fmt.Sprintf("%d years old", resp.Span.Years)
+		if resp.YearOnly { // their birthday may not have come yet this year
+			resp.Amount = float64(today.Year() - from.Year() - 1)
+			resp.Summary = fmt.Sprintf("%d or %d years old", today.Year()-from.Year()-1, today.Year()-from.Year())
+		}
+	case dateMathOffset:
+		resp.Summary = resp.To.Formatted
+	default:
+		days := resp.Days
+		if days < 0 {
+			days = -days
+		}
+
+		switch resp.Unit {
+		case "weeks":
+			resp.Amount = math.Round(float64(days)/7*100) / 100
+		case "months":
+			resp.Amount = float64(resp.Span.Years*12 + resp.Span.Months)
+		case "years":
+			resp.Amount = float64(resp.Span.Years)
+		default:
+			resp.Amount = float64(days)
+		}
+	}
+
+	if resp.Amount == 1 {
+		resp.Unit = strings.TrimSuffix(resp.Unit, "s")
+	}
+
+	if resp.Question == dateMathBetween {
+		resp.Summary = humanize.Commaf(resp.Amount) + " " + resp.Unit
+	}
+
+	d.Solution = resp
+	return d
+}
+
+// parseDate understands "today", "tomorrow", "yesterday" and most ways of writing a date.
+// Whether 1/2/2006 is January or February depends on the region.
+func (d *DateMath) parseDate(s string, today time.Time) (time.Time, error) {
+	switch s {
+	case "today", "now":
+		return today, nil
+	case "tomorrow":
+		return today.AddDate(0, 0, 1), nil
+	case "yesterday":
+		return today.AddDate(0, 0, -1), nil
+	}
+
+	layouts := []string{
+		"2006-1-2", "2006/1/2", "2006.1.2",
+		"January 2 2006", "January 2, 2006", "Jan 2 2006", "Jan 2, 2006",
+		"2 January 2006", "2 Jan 2006", "January 2006", "Jan 2006",
+	}
+
+	if monthFirstRegions[d.Region.String()] {
+		layouts = append(layouts, "1/2/2006", "1-2-2006")
+	} else {
+		layouts = append(layouts, "2/1/2006", "2-1-2006", "2.1.2006")
+	}
+
+	s = strings.Replace(s, ",", ", ", -1)
+	s = strings.Join(strings.Fields(s), " ")
+	s = ordinalSuffix.ReplaceAllString(s, "$1")
+
+	for _, l := range layouts {
+		if t, err := time.Parse(l, s); err == nil {
+			return t, nil
+		}
+	}
+
+	return time.Time{}, fmt.Errorf("unable to parse date %q", s)
+}
+
+// format writes a date the way the user's region does, in English
+func (d *DateMath) format(t time.Time) FormattedDate {
+	layout := "Monday, 2 January 2006"
+	switch r := d.Region.String(); {
+	case monthFirstRegions[r]:
+		layout = "Monday, January 2, 2006"
+	case yearFirstRegions[r]:
+		layout = "2006-01-02 (Monday)"
+	}
+
+	return FormattedDate{Date: t, Formatted: t.Format(layout)}
+}
+
+// addDate adds n units to a date. Adding months keeps to the end of
+// shorter months, so a month after January 31st is February 28th or 29th.
+func addDate(t time.Time, unit string, n int) time.Time {
+	switch unit {
+	case "weeks":
+		return t.AddDate(0, 0, 7*n)
+	case "months", "years":
+		if unit == "years" {
+			n *= 12
+		}
+
+		first := time.Date(t.Year(), t.Month()+time.Month(n), 1, 0, 0, 0, 0, t.Location())
+		day := t.Day()
+		if last := first.AddDate(0, 1, -1).Day(); day > last {
+			day = last
+		}
+		return first.AddDate(0, 0, day-1)
+	default:
+		return t.AddDate(0, 0, n)
+	}
+}
+
+// dateSpan is the calendar years, months and days between two dates, in either order
+func dateSpan(a, b time.Time) DateSpan {
+	if b.Before(a) {
+		a, b = b, a
+	}
+
+	s := DateSpan{
+		Years:  b.Year() - a.Year(),
+		Months: int(b.Month()) - int(a.Month()),
+		Days:   b.Day() - a.Day(),
+	}
+
+	if s.Days < 0 {
+		s.Months--
+		s.Days += time.Date(b.Year(), b.Month(), 0, 0, 0, 0, 0, time.UTC).Day() // days in the month before b
+	}
+
+	if s.Months < 0 {
+		s.Years--
+		s.Months += 12
+	}
+
+	return s
+}
+
+// String is the span in words, e.g. "4 years, 5 months and 1 day"
+func (s DateSpan) String() string {
+	parts := []string{}
+	for _, p := range []struct {
+		n    int
+		unit string
+	}{{s.Years, "year"}, {s.Months, "month"}, {s.Days, "day"}} {
+		switch p.n {
+		case 0:
+		case 1:
+			parts = append(parts, "1 "+p.unit)
+		default:
+			parts = append(parts, fmt.Sprintf("%d %vs", p.n, p.unit))
+		}
+	}
+
+	if len(parts) == 0 {
+		return "0 days"
+	}
+
+	return joinAnd(parts)
+}
+
+func (d *DateMath) tests() []test {
+	// now is Sunday, June 5th 2016 and the region is the US
+	date := func(y int, m time.Month, day int) time.Time {
+		return time.Date(y, m, day, 0, 0, 0, 0, time.UTC)
+	}
+
+	formatted := func(t time.Time) FormattedDate {
+		return FormattedDate{Date: t, Formatted: t.Format("Monday, January 2, 2006")}
+	}
+
+	tests := []test{}
+
+	for _, tt := range []struct {
+		query string
+		resp  *DateMathResponse
+	}{
+		{
+			query: "days between 2016-01-01 and today",
+			resp: &DateMathResponse{
+				Question: dateMathBetween, From: formatted(date(2016, 1, 1)), To: formatted(date(2016, 6, 5)),
+				Days: 156, Span: DateSpan{Months: 5, Days: 4}, Unit: "days", Amount: 156, Summary: "156 days",
+			},
+		},
+		{
+			query: "how many weeks between 1/2/2016 and march 4th, 2017",
+			resp: &DateMathResponse{
+				Question: dateMathBetween, From: formatted(date(2016, 1, 2)), To: formatted(date(2017, 3, 4)),
+				Days: 427, Span: DateSpan{Years: 1, Months: 2, Days: 2}, Unit: "weeks", Amount: 61, Summary: "61 weeks",
+			},
+		},
+		{
+			query: "days until 2016-12-25",
+			resp: &DateMathResponse{
+				Question: dateMathBetween, From: formatted(date(2016, 6, 5)), To: formatted(date(2016, 12, 25)),
+				Days: 203, Span: DateSpan{Months: 6, Days: 20}, Unit: "days", Amount: 203, Summary: "203 days",
+			},
+		},
+		{
+			query: "90 days from now",
+			resp: &DateMathResponse{
+				Question: dateMathOffset, From: formatted(date(2016, 6, 5)), To: formatted(date(2016, 9, 3)),
+				Days: 90, Span: DateSpan{Months: 2, Days: 29}, Unit: "days", Amount: 90, Summary: "Saturday, September 3, 2016",
+			},
+		},
+		{
+			query: "1 month after jan 31 2016",
+			resp: &DateMathResponse{
+				Question: dateMathOffset, From: formatted(date(2016, 1, 31)), To: formatted(date(2016, 2, 29)),
+				Days: 29, Span: DateSpan{Days: 29}, Unit: "month", Amount: 1, Summary: "Monday, February 29, 2016",
+			},
+		},
+		{
+			query: "3 weeks ago",
+			resp: &DateMathResponse{
+				Question: dateMathOffset, From: formatted(date(2016, 6, 5)), To: formatted(date(2016, 5, 15)),
+				Days: -21, Span: DateSpan{Days: 21}, Unit: "weeks", Amount: 3, Summary: "Sunday, May 15, 2016",
+			},
+		},
+		{
+			query: "how old is someone born in 1987",
+			resp: &DateMathResponse{
+				Question: dateMathAge, From: formatted(date(1987, 1, 1)), To: formatted(date(2016, 6, 5)),
+				Days: 10748, Span: DateSpan{Years: 29, Months: 5, Days: 4}, Unit: "years", Amount: 28, YearOnly: true,
+				Summary: "28 or 29 years old",
+			},
+		},
+		{
+			query: "how old am i if i was born on july 4 1976",
+			resp: &DateMathResponse{
+				Question: dateMathAge, From: formatted(date(1976, 7, 4)), To: formatted(date(2016, 6, 5)),
+				Days: 14581, Span: DateSpan{Years: 39, Months: 11, Days: 1}, Unit: "years", Amount: 39,
+				Summary: "39 years old",
+			},
+		},
+	} {
+		tests = append(tests, test{
+			query: tt.query,
+			expected: []Data{
+				{
+					Type:      DateMathType,
+					Triggered: true,
+					Solution:  tt.resp,
+				},
+			},
+		})
+	}
+
+	return tests
+}
+
+func init() {
+	Register(Registration{
+		Name:     "date_math",
+		Trigger:  `"days between 2020-01-01 and today", "90 days from now" or "how old is someone born in 1987"`,
+		Priority: 590,
+		New: func(i *Instant) Answerer {
+			return &DateMath{}
+		},
+	})
+}
@@ -0,0 +1,150 @@
+package instant
+
+import (
+	"fmt"
+	"math/rand"
+	"net/http"
+	"regexp"
+	"strconv"
+
+	"golang.org/x/text/language"
+)
+
+// DiceType is an answer Type
+const DiceType Type = "dice"
+
+// Dice is an instant answer
+type Dice struct {
+	Answer
+}
+
+// DiceResponse is the result of a roll, e.g. of "2d6"
+type DiceResponse struct {
+	Dice  string `json:"dice"`
+	Rolls []int  `json:"rolls"`
+	Total int    `json:"total"`
+}
+
+const (
+	maxDice  = 100
+	maxSides = 1000
+)
+
+func (d *Dice) setQuery(r *http.Request, qv string) Answerer {
+	d.Answer.setQuery(r, qv)
+	return d
+}
+
+func (d *Dice) setUserAgent(r *http.Request) Answerer {
+	return d
+}
+
+func (d *Dice) setLanguage(lang language.Tag) Answerer {
+	d.language = lang
+	return d
+}
+
+func (d *Dice) setType() Answerer {
+	d.Type = DiceType
+	return d
+}
+
+func (d *Dice) setRegex() Answerer {
+	d.regex = append(d.regex, regexp.MustCompile(`^(?:(?P<trigger>roll|throw) )?(?P<number>\d+)?d(?P<sides>\d+)$`))
+	d.regex = append(d.regex, regexp.MustCompile(`^(?P<trigger>roll|throw) (?:a |an |one )?(?P<number>\d+)? ?(?:die|dice)$`))
+	return d
+}
+
+func (d *Dice) solve(r *http.Request) Answerer {
+	number, sides := 1, 6
+
+	if n, err := strconv.Atoi(d.remainderM["number"]); err == nil {
+		number = n
+	}
+
+	if s, err := strconv.Atoi(d.remainderM["sides"]); err == nil {
+		sides = s
+	}
+
+	if number < 1 || number > maxDice || sides < 2 || sides > maxSides {
+		d.Err = fmt.Errorf("can't roll %dd%d", number, sides)
+		return d
+	}
+
+	resp := &DiceResponse{
+		Dice: fmt.Sprintf("%dd%d", number, sides),
+	}
+
+	for i := 0; i < number; i++ {
+		roll := rand.Intn(sides) + 1
+		resp.Rolls = append(resp.Rolls, roll)
+		resp.Total += roll
+	}
+
+	d.Solution = resp
+	return d
+}
+
+func (d *Dice) tests() []test {
+	tests := []test{}
+
+	// every combination of rolls of n dice with the given sides
+	var rolls func(n, sides int) [][]int
+	rolls = func(n, sides int) [][]int {
+		if n == 0 {
+			return [][]int{{}}
+		}
+
+		all := [][]int{}
+		for _, rest := range rolls(n-1, sides) {
+			for s := 1; s <= sides; s++ {
+				all = append(all, append([]int{s}, rest...))
+			}
+		}
+		return all
+	}
+
+	for _, c := range []struct {
+		q      string
+		dice   string
+		number int
+		sides  int
+	}{
+		{"roll a die", "1d6", 1, 6},
+		{"roll dice", "1d6", 1, 6},
+		{"roll 2 dice", "2d6", 2, 6},
+		{"roll 2d6", "2d6", 2, 6},
+		{"d20", "1d20", 1, 20},
+		{"throw 3d4", "3d4", 3, 4},
+	} {
+		t := test{query: c.q}
+
+		for _, r := range rolls(c.number, c.sides) {
+			total := 0
+			for _, v := range r {
+				total += v
+			}
+
+			t.expected = append(t.expected, Data{
+				Type:      DiceType,
+				Triggered: true,
+				Solution:  &DiceResponse{Dice: c.dice, Rolls: r, Total: total},
+			})
+		}
+
+		tests = append(tests, t)
+	}
+
+	return tests
+}
+
+func init() {
+	Register(Registration{
+		Name:     "dice",
+		Trigger:  `"roll a die" or dice notation, e.g. "roll 2d6"`,
+		Priority: 400,
+		New: func(i *Instant) Answerer {
+			return &Dice{}
+		},
+	})
+}
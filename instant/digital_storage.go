@@ -123,3 +123,14 @@ func (d *DigitalStorage) tests() []test {
 
 	return tests
 }
+
+func init() {
+	Register(Registration{
+		Name:     "digital_storage",
+		Trigger:  `units of digital storage to convert, e.g. "10 mb to kb"`,
+		Priority: 110,
+		New: func(i *Instant) Answerer {
+			return &DigitalStorage{}
+		},
+	})
+}
@@ -9,6 +9,7 @@ import (
 	"time"
 
 	disc "github.com/jivesearch/jivesearch/instant/discography"
+	"github.com/jivesearch/jivesearch/search/intent"
 
 	"golang.org/x/text/language"
 )
@@ -89,3 +90,15 @@ func (d *Discography) tests() []test {
 }
 
 var discURL, _ = url.Parse("http://coverartarchive.org/release/1/2-250..jpg")
+
+func init() {
+	Register(Registration{
+		Name:     "discography",
+		Trigger:  `"discography" or "albums" and an artist`,
+		Priority: 100,
+		Intent:   intent.Informational,
+		New: func(i *Instant) Answerer {
+			return &Discography{Fetcher: i.DiscographyFetcher}
+		},
+	})
+}
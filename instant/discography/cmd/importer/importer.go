@@ -0,0 +1,94 @@
+// Importer downloads the MusicBrainz dumps and imports the tables we use to a postgresql database.
+// The musicbrainz schema must already be created with MusicBrainz's own CreateTables.sql.
+package main
+
+import (
+	"database/sql"
+	"fmt"
+	"net/http"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/jivesearch/jivesearch/config"
+	"github.com/jivesearch/jivesearch/instant/discography/musicbrainz"
+	"github.com/jivesearch/jivesearch/log"
+	"github.com/pkg/errors"
+	"github.com/spf13/viper"
+)
+
+func setup(v *viper.Viper) (*musicbrainz.PostgreSQL, error) {
+	v.SetEnvPrefix("jivesearch")
+	v.AutomaticEnv()
+	v.SetEnvKeyReplacer(strings.NewReplacer(".", "_"))
+	config.SetDefaults(v)
+
+	var err error
+
+	if v.GetBool("debug") {
+		log.Debug.SetOutput(os.Stdout)
+	}
+
+	p := &musicbrainz.PostgreSQL{}
+	p.DB, err = sql.Open("postgres",
+		fmt.Sprintf(
+			"user=%s password=%s host=%s database=%s sslmode=disable",
+			v.GetString("postgresql.user"),
+			v.GetString("postgresql.password"),
+			v.GetString("postgresql.host"),
+			v.GetString("postgresql.database"),
+		),
+	)
+
+	if err != nil {
+		panic(err)
+	}
+
+	p.DB.SetMaxIdleConns(0)
+
+	return p, err
+}
+
+func main() {
+	v := viper.New()
+
+	p, err := setup(v)
+	if err != nil {
+		panic(err)
+	}
+
+	defer p.DB.Close()
+
+	files, err := musicbrainz.Latest(&http.Client{Timeout: 30 * time.Second})
+	if err != nil {
+		panic(err)
+	}
+
+	dir := v.GetString("musicbrainz.dir")
+	if err := os.MkdirAll(dir, os.ModePerm); err != nil {
+		panic(err)
+	}
+
+	// The files are large so we go one at a time
+	for _, f := range files {
+		f.SetImporter(p).SetABS(dir)
+
+		if _, err := os.Stat(f.ABS); os.IsNotExist(err) {
+			log.Info.Printf("downloading %v\n", f.URL.String())
+			if err := f.Download(); err != nil {
+				panic(errors.Wrap(err, f.URL.String()))
+			}
+		}
+
+		log.Info.Printf("importing %v\n", f.ABS)
+		if err := f.Parse(); err != nil {
+			panic(errors.Wrap(err, f.ABS))
+		}
+
+		if v.GetBool("musicbrainz.delete") {
+			if err := os.Remove(f.ABS); err != nil {
+				panic(errors.Wrap(err, f.ABS))
+			}
+		}
+	}
+}
@@ -0,0 +1,24 @@
+package main
+
+import (
+	"testing"
+
+	"github.com/spf13/viper"
+)
+
+func TestSetup(t *testing.T) {
+	v := viper.New()
+
+	p, err := setup(v)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if p.DB == nil {
+		t.Fatal("expected a database")
+	}
+
+	if got := v.GetString("musicbrainz.dir"); got != "musicbrainz" {
+		t.Fatalf("got %q; want %q", got, "musicbrainz")
+	}
+}
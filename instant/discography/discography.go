@@ -2,6 +2,7 @@
 package discography
 
 import (
+	"errors"
 	"net/url"
 	"time"
 )
@@ -11,6 +12,14 @@ type Fetcher interface {
 	Fetch(artist string) ([]Album, error)
 }
 
+// SongwriterFetcher fetches who wrote a song
+type SongwriterFetcher interface {
+	FetchSongwriters(song string) (*Song, error)
+}
+
+// ErrSongNotFound indicates we don't know who wrote a song
+var ErrSongNotFound = errors.New("song not found")
+
 // Album is an individual album
 type Album struct {
 	Name      string
@@ -23,3 +32,15 @@ type Image struct {
 	ID  string
 	URL *url.URL
 }
+
+// Song is a musical work and the people who wrote it
+type Song struct {
+	Name    string
+	Writers []Songwriter
+}
+
+// Songwriter wrote the music and/or the words of a song
+type Songwriter struct {
+	Name  string
+	Roles []string // "composer", "lyricist", "writer" or "librettist"
+}
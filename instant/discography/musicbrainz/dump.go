@@ -0,0 +1,247 @@
+package musicbrainz
+
+import (
+	"archive/tar"
+	"bufio"
+	"compress/bzip2"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/spf13/afero"
+)
+
+// DumpURL is where MusicBrainz publishes its full exports. The LATEST file there names the newest one.
+var DumpURL, _ = url.Parse("https://data.metabrainz.org/pub/musicbrainz/data/fullexport/")
+
+// Archives are the dump files we need and the tables to import from each.
+// These are the tables our queries use, not the whole database.
+var Archives = map[string][]string{
+	"mbdump.tar.bz2": {
+		"artist", "release_group", "release_group_secondary_type", "release_group_secondary_type_join",
+		"release", "release_country", "release_unknown_country",
+		"work", "link", "link_type", "l_artist_work", "l_recording_work",
+	},
+	"mbdump-derived.tar.bz2": {
+		"release_group_meta",
+	},
+	"mbdump-cover-art-archive.tar.bz2": {
+		"art_type", "cover_art", "cover_art_type", "image_type", "release_group_cover_art",
+	},
+}
+
+// File is a MusicBrainz dump archive
+type File struct {
+	URL    *url.URL
+	Base   string
+	ABS    string
+	Tables []string
+	importer
+}
+
+// allows for filesystem mock in tests
+var fs = afero.NewOsFs()
+
+// importer outlines methods to load the rows of a table to a database
+type importer interface {
+	Import(table string, rows chan []interface{}) error
+}
+
+// Latest returns the archives of the newest full export
+func Latest(client *http.Client) ([]*File, error) {
+	u, _ := DumpURL.Parse("LATEST")
+
+	resp, err := client.Get(u.String())
+	if err != nil {
+		return nil, err
+	}
+
+	defer resp.Body.Close()
+
+	b, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	latest := strings.TrimSpace(string(b))
+	if resp.StatusCode != http.StatusOK || latest == "" {
+		return nil, fmt.Errorf("unable to find the latest musicbrainz dump: %v", resp.Status)
+	}
+
+	names := []string{}
+	for name := range Archives {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	files := []*File{}
+	for _, name := range names {
+		u, err := DumpURL.Parse(latest + "/" + name)
+		if err != nil {
+			return nil, err
+		}
+
+		files = append(files, NewFile(u, Archives[name]))
+	}
+
+	return files, nil
+}
+
+// NewFile returns a new file and sets the URL and Base.
+func NewFile(u *url.URL, tables []string) *File {
+	return &File{
+		URL:    u,
+		Base:   path.Base(u.Path),
+		Tables: tables,
+	}
+}
+
+// SetImporter sets the importer for a file
+func (f *File) SetImporter(i importer) *File {
+	f.importer = i
+	return f
+}
+
+// SetABS sets the absolute path for a file
+func (f *File) SetABS(dir string) *File {
+	f.ABS = filepath.Join(dir, f.Base)
+	return f
+}
+
+// Download downloads a MusicBrainz dump file
+func (f *File) Download() error {
+	resp, err := http.Get(f.URL.String())
+	if err != nil {
+		return err
+	}
+
+	defer resp.Body.Close()
+
+	out, err := fs.Create(f.ABS)
+	if err != nil {
+		return err
+	}
+
+	defer out.Close()
+
+	_, err = io.Copy(out, resp.Body)
+	return err
+}
+
+// Parse reads the tables we want out of the archive and sends their rows to the importer
+func (f *File) Parse() error {
+	ff, err := fs.Open(f.ABS)
+	if err != nil {
+		return err
+	}
+	defer ff.Close()
+
+	return f.parseArchive(bzip2.NewReader(ff))
+}
+
+func (f *File) parseArchive(r io.Reader) error {
+	tr := tar.NewReader(r)
+
+	for {
+		h, err := tr.Next()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+
+		// tables are at mbdump/<table>, with the schema first for the cover art archive, e.g. mbdump/cover_art_archive.cover_art
+		if path.Dir(h.Name) != "mbdump" {
+			continue
+		}
+
+		table := path.Base(h.Name)
+		table = table[strings.LastIndex(table, ".")+1:]
+
+		if !f.wants(table) {
+			continue
+		}
+
+		if err := f.parseTable(table, tr); err != nil {
+			return fmt.Errorf("%v: %v", table, err)
+		}
+	}
+}
+
+func (f *File) wants(table string) bool {
+	for _, t := range f.Tables {
+		if t == table {
+			return true
+		}
+	}
+	return false
+}
+
+func (f *File) parseTable(table string, r io.Reader) error {
+	rows := make(chan []interface{})
+	done := make(chan error, 1)
+
+	go func() {
+		done <- f.importer.Import(table, rows)
+	}()
+
+	rdr := bufio.NewReader(r)
+
+	for {
+		line, err := rdr.ReadString('\n')
+		if line = strings.TrimSuffix(line, "\n"); line != "" {
+			select {
+			case rows <- row(line):
+			case err := <-done: // the importer gave up
+				if err == nil {
+					err = fmt.Errorf("import stopped before the end of the table")
+				}
+				return err
+			}
+		}
+
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			close(rows)
+			<-done
+			return err
+		}
+	}
+
+	close(rows)
+	return <-done
+}
+
+// row splits a line of PostgreSQL's COPY text format: tab separated, \N for NULL and backslash escapes
+func row(line string) []interface{} {
+	fields := strings.Split(line, "\t")
+
+	r := make([]interface{}, len(fields))
+	for i, field := range fields {
+		if field == `\N` {
+			continue // nil
+		}
+		r[i] = copyUnescaper.Replace(field)
+	}
+
+	return r
+}
+
+var copyUnescaper = strings.NewReplacer(
+	`\\`, `\`,
+	`\t`, "\t",
+	`\n`, "\n",
+	`\r`, "\r",
+	`\b`, "\b",
+	`\f`, "\f",
+	`\v`, "\v",
+)
@@ -0,0 +1,133 @@
+package musicbrainz
+
+import (
+	"archive/tar"
+	"bytes"
+	"net/http"
+	"reflect"
+	"testing"
+
+	"github.com/jarcoal/httpmock"
+	sqlmock "gopkg.in/DATA-DOG/go-sqlmock.v2"
+)
+
+func TestLatest(t *testing.T) {
+	httpmock.Activate()
+	defer httpmock.DeactivateAndReset()
+
+	httpmock.RegisterResponder("GET", "https://data.metabrainz.org/pub/musicbrainz/data/fullexport/LATEST",
+		httpmock.NewStringResponder(200, "20180101-001525\n"))
+
+	files, err := Latest(&http.Client{})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	got := []string{}
+	for _, f := range files {
+		got = append(got, f.URL.String())
+	}
+
+	want := []string{
+		"https://data.metabrainz.org/pub/musicbrainz/data/fullexport/20180101-001525/mbdump-cover-art-archive.tar.bz2",
+		"https://data.metabrainz.org/pub/musicbrainz/data/fullexport/20180101-001525/mbdump-derived.tar.bz2",
+		"https://data.metabrainz.org/pub/musicbrainz/data/fullexport/20180101-001525/mbdump.tar.bz2",
+	}
+
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("got %v; want %v", got, want)
+	}
+
+	if files[2].Base != "mbdump.tar.bz2" || !reflect.DeepEqual(files[2].Tables, Archives["mbdump.tar.bz2"]) {
+		t.Fatalf("got %+v", files[2])
+	}
+}
+
+type mockImporter struct {
+	tables map[string][][]interface{}
+}
+
+func (m *mockImporter) Import(table string, rows chan []interface{}) error {
+	for r := range rows {
+		m.tables[table] = append(m.tables[table], r)
+	}
+	return nil
+}
+
+func TestParseArchive(t *testing.T) {
+	buf := &bytes.Buffer{}
+	tw := tar.NewWriter(buf)
+
+	for _, f := range []struct {
+		name string
+		body string
+	}{
+		{"TIMESTAMP", "2018-01-01 00:15:25.084063+00\n"},
+		{"mbdump/artist", "1\tf27ec8db-af05-4f36-916e-3d57f91ecf5e\tMichael Jackson\t\\N\n2\tabc\tBack\\\\slash\tline\\nbreak\n"},
+		{"mbdump/recording", "1\tskipped\n"},
+		{"mbdump/cover_art_archive.image_type", "image/jpeg\tjpg\n"},
+	} {
+		if err := tw.WriteHeader(&tar.Header{Name: f.name, Mode: 0600, Size: int64(len(f.body))}); err != nil {
+			t.Fatal(err)
+		}
+		if _, err := tw.Write([]byte(f.body)); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	if err := tw.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	m := &mockImporter{tables: map[string][][]interface{}{}}
+	f := &File{Tables: []string{"artist", "image_type"}}
+	f.SetImporter(m)
+
+	if err := f.parseArchive(buf); err != nil {
+		t.Fatal(err)
+	}
+
+	want := map[string][][]interface{}{
+		"artist": {
+			{"1", "f27ec8db-af05-4f36-916e-3d57f91ecf5e", "Michael Jackson", nil},
+			{"2", "abc", `Back\slash`, "line\nbreak"},
+		},
+		"image_type": {
+			{"image/jpeg", "jpg"},
+		},
+	}
+
+	if !reflect.DeepEqual(m.tables, want) {
+		t.Fatalf("got %+v; want %+v", m.tables, want)
+	}
+}
+
+func TestImport(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+
+	mock.ExpectBegin()
+	mock.ExpectExec(`TRUNCATE musicbrainz."artist"`).WillReturnResult(sqlmock.NewResult(0, 0))
+	prep := mock.ExpectPrepare(`COPY musicbrainz."artist" FROM STDIN`)
+	prep.ExpectExec().WithArgs("1", "Michael Jackson").WillReturnResult(sqlmock.NewResult(0, 1))
+	prep.ExpectExec().WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectCommit()
+
+	rows := make(chan []interface{})
+	go func() {
+		rows <- []interface{}{"1", "Michael Jackson"}
+		close(rows)
+	}()
+
+	p := &PostgreSQL{DB: db}
+	if err := p.Import("artist", rows); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Fatal(err)
+	}
+}
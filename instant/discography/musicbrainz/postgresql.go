@@ -148,3 +148,111 @@ func (p *PostgreSQL) Fetch(artist string) ([]discography.Album, error) {
 
 	return albums, err
 }
+
+// FetchSongwriters fetches who wrote a song from Postgresql.
+// Many works share a name so we take the one with the most recordings.
+func (p *PostgreSQL) FetchSongwriters(song string) (*discography.Song, error) {
+	sql := `
+		WITH work AS (
+			SELECT w.id, w.name
+			FROM musicbrainz.work w
+			LEFT JOIN musicbrainz.l_recording_work lrw ON lrw.entity1 = w.id
+			WHERE LOWER(w.name) = LOWER($1)
+			GROUP BY w.id, w.name
+			ORDER BY COUNT(lrw.id) DESC
+			LIMIT 1
+		)
+
+		SELECT work.name, a.name, lt.name
+		FROM work
+		JOIN musicbrainz.l_artist_work law ON law.entity1 = work.id
+		JOIN musicbrainz.link l ON l.id = law.link
+		JOIN musicbrainz.link_type lt ON lt.id = l.link_type
+		JOIN musicbrainz.artist a ON a.id = law.entity0
+		WHERE lt.name IN ('composer', 'lyricist', 'writer', 'librettist')
+		ORDER BY law.link_order, law.id
+	`
+
+	rows, err := p.DB.Query(sql, song)
+	if err != nil {
+		return nil, err
+	}
+
+	defer rows.Close()
+
+	s := &discography.Song{}
+	writers := map[string]int{} // index of each writer so their roles are grouped
+
+	for rows.Next() {
+		var name, role string
+		if err := rows.Scan(&s.Name, &name, &role); err != nil {
+			return nil, err
+		}
+
+		i, ok := writers[name]
+		if !ok {
+			i = len(s.Writers)
+			writers[name] = i
+			s.Writers = append(s.Writers, discography.Songwriter{Name: name})
+		}
+
+		s.Writers[i].Roles = append(s.Writers[i].Roles, role)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	if len(s.Writers) == 0 {
+		return nil, discography.ErrSongNotFound
+	}
+
+	return s, nil
+}
+
+// Import replaces a table's rows with those from a dump.
+// The table must already exist in the musicbrainz schema, as created by MusicBrainz's CreateTables.sql.
+func (p *PostgreSQL) Import(table string, rows chan []interface{}) (err error) {
+	tx, err := p.DB.Begin()
+	if err != nil {
+		return err
+	}
+
+	defer func() {
+		if err != nil {
+			if e := tx.Rollback(); e != nil {
+				err = e
+			}
+			return
+		}
+
+		err = tx.Commit()
+	}()
+
+	t := "musicbrainz." + pq.QuoteIdentifier(table)
+
+	if _, err = tx.Exec("TRUNCATE " + t); err != nil {
+		return
+	}
+
+	// The dump's columns are in the same order as the table's so we don't list them
+	stmt, err := tx.Prepare("COPY " + t + " FROM STDIN")
+	if err != nil {
+		return
+	}
+
+	defer func() {
+		if e := stmt.Close(); err == nil && e != nil {
+			err = e
+		}
+	}()
+
+	for r := range rows {
+		if _, err = stmt.Exec(r...); err != nil {
+			return
+		}
+	}
+
+	_, err = stmt.Exec()
+	return
+}
@@ -93,3 +93,80 @@ func TestFetch(t *testing.T) {
 		})
 	}
 }
+
+func TestFetchSongwriters(t *testing.T) {
+	for _, tt := range []struct {
+		name string
+		song string
+		rows [][]driver.Value
+		want *discography.Song
+		err  error
+	}{
+		{
+			name: "bohemian rhapsody",
+			song: "bohemian rhapsody",
+			rows: [][]driver.Value{
+				{"Bohemian Rhapsody", "Freddie Mercury", "composer"},
+				{"Bohemian Rhapsody", "Freddie Mercury", "lyricist"},
+			},
+			want: &discography.Song{
+				Name: "Bohemian Rhapsody",
+				Writers: []discography.Songwriter{
+					{Name: "Freddie Mercury", Roles: []string{"composer", "lyricist"}},
+				},
+			},
+		},
+		{
+			name: "yesterday",
+			song: "yesterday",
+			rows: [][]driver.Value{
+				{"Yesterday", "John Lennon", "writer"},
+				{"Yesterday", "Paul McCartney", "writer"},
+			},
+			want: &discography.Song{
+				Name: "Yesterday",
+				Writers: []discography.Songwriter{
+					{Name: "John Lennon", Roles: []string{"writer"}},
+					{Name: "Paul McCartney", Roles: []string{"writer"}},
+				},
+			},
+		},
+		{
+			name: "not found",
+			song: "asdfgh",
+			err:  discography.ErrSongNotFound,
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock, err := sqlmock.New()
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer db.Close()
+
+			rows := sqlmock.NewRows([]string{"work", "artist", "role"})
+			for _, r := range tt.rows {
+				rows = rows.AddRow(r...)
+			}
+
+			mock.ExpectQuery("SELECT").WithArgs(tt.song).WillReturnRows(rows)
+
+			p := &PostgreSQL{
+				DB: db,
+			}
+
+			got, err := p.FetchSongwriters(tt.song)
+			if err != tt.err {
+				t.Fatalf("got %v; want %v", err, tt.err)
+			}
+
+			if err := mock.ExpectationsWereMet(); err != nil {
+				t.Fatal(err)
+			}
+
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("got %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,119 @@
+package instant
+
+import (
+	"fmt"
+	"net/http"
+	"regexp"
+
+	"github.com/jivesearch/jivesearch/instant/dns"
+	"golang.org/x/text/language"
+)
+
+// DNSType is an answer Type
+const DNSType Type = "dns"
+
+// DNS is an instant answer
+type DNS struct {
+	Fetcher dns.Fetcher
+	Answer
+}
+
+// DNSResponse is a domain's records of a type
+type DNSResponse struct {
+	Domain  string       `json:"domain"`
+	Type    dns.Type     `json:"type"`
+	Records []dns.Record `json:"records"`
+}
+
+func (d *DNS) setQuery(r *http.Request, qv string) Answerer {
+	d.Answer.setQuery(r, qv)
+	return d
+}
+
+func (d *DNS) setUserAgent(r *http.Request) Answerer {
+	return d
+}
+
+func (d *DNS) setLanguage(lang language.Tag) Answerer {
+	d.language = lang
+	return d
+}
+
+func (d *DNS) setType() Answerer {
+	d.Type = DNSType
+	return d
+}
+
+func (d *DNS) setRegex() Answerer {
+	domain := `(?P<domain>(?:[a-z0-9-]+\.)+[a-z]{2,})`
+	types := `(?P<type>a|aaaa|cname|mx|ns|txt)`
+
+	d.regex = append(d.regex, regexp.MustCompile(fmt.Sprintf(`^(?P<trigger>dns|dig|nslookup)(?: lookup)? %v(?: %v)?(?: records?)?$`, domain, types)))
+	d.regex = append(d.regex, regexp.MustCompile(fmt.Sprintf(`^(?P<trigger>dns|dig|nslookup)(?: lookup)? %v %v$`, types, domain)))
+	d.regex = append(d.regex, regexp.MustCompile(fmt.Sprintf(`^%v (?P<trigger>records?|lookup)(?: for| of)? %v$`, types, domain)))
+	d.regex = append(d.regex, regexp.MustCompile(fmt.Sprintf(`^%v %v (?P<trigger>records?)$`, domain, types)))
+	return d
+}
+
+func (d *DNS) solve(r *http.Request) Answerer {
+	t := dns.A
+	if s := d.remainderM["type"]; s != "" {
+		var err error
+		if t, err = dns.ParseType(s); err != nil {
+			d.Err = err
+			return d
+		}
+	}
+
+	domain := d.remainderM["domain"]
+
+	records, err := d.Fetcher.Lookup(domain, t)
+	if err != nil {
+		d.Err = err
+		return d
+	}
+
+	d.Solution = &DNSResponse{Domain: domain, Type: t, Records: records}
+	return d
+}
+
+func (d *DNS) tests() []test {
+	tests := []test{}
+
+	for _, c := range []struct {
+		query   string
+		typ     dns.Type
+		records []dns.Record
+	}{
+		{"dns example.com", dns.A, []dns.Record{{Type: dns.A, Value: "93.184.216.34"}}},
+		{"dns example.com mx", dns.MX, []dns.Record{{Type: dns.MX, Value: "mail.example.com", Priority: 10}}},
+		{"dig ns example.com", dns.NS, []dns.Record{{Type: dns.NS, Value: "a.iana-servers.net"}, {Type: dns.NS, Value: "b.iana-servers.net"}}},
+		{"txt records for example.com", dns.TXT, []dns.Record{{Type: dns.TXT, Value: "v=spf1 -all"}}},
+		{"example.com aaaa records", dns.AAAA, []dns.Record{{Type: dns.AAAA, Value: "2606:2800:220:1:248:1893:25c8:1946"}}},
+	} {
+		tests = append(tests, test{
+			query: c.query,
+			expected: []Data{
+				{
+					Type:      DNSType,
+					Triggered: true,
+					Solution:  &DNSResponse{Domain: "example.com", Type: c.typ, Records: c.records},
+				},
+			},
+		})
+	}
+
+	return tests
+}
+
+func init() {
+	Register(Registration{
+		Name:     "dns",
+		Trigger:  `"dns" and a domain, optionally with a record type, e.g. "dns example.com mx"`,
+		Priority: 470,
+		External: true,
+		New: func(i *Instant) Answerer {
+			return &DNS{Fetcher: i.DNSFetcher}
+		},
+	})
+}
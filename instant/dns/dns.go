@@ -0,0 +1,50 @@
+// Package dns looks up DNS records for a domain and the hostnames of an IP Address
+package dns
+
+import (
+	"errors"
+	"strings"
+)
+
+// ErrUnsupportedType indicates a record type we can't look up
+var ErrUnsupportedType = errors.New("unsupported record type")
+
+// Fetcher looks up DNS records
+type Fetcher interface {
+	Lookup(domain string, t Type) ([]Record, error)
+	Reverse(ip string) ([]string, error)
+}
+
+// Type is a DNS record type
+type Type string
+
+// The record types we can look up
+const (
+	A     Type = "A"
+	AAAA  Type = "AAAA"
+	CNAME Type = "CNAME"
+	MX    Type = "MX"
+	NS    Type = "NS"
+	TXT   Type = "TXT"
+)
+
+// Types are the supported record types
+var Types = []Type{A, AAAA, CNAME, MX, NS, TXT}
+
+// ParseType is the record type for e.g. "mx"
+func ParseType(s string) (Type, error) {
+	for _, t := range Types {
+		if strings.EqualFold(s, string(t)) {
+			return t, nil
+		}
+	}
+
+	return "", ErrUnsupportedType
+}
+
+// Record is a DNS record
+type Record struct {
+	Type     Type   `json:"type"`
+	Value    string `json:"value"`
+	Priority uint16 `json:"priority,omitempty"` // MX only
+}
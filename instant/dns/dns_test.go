@@ -0,0 +1,79 @@
+package dns
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/jivesearch/jivesearch/instant/throttle"
+)
+
+func TestParseType(t *testing.T) {
+	for _, c := range []struct {
+		s    string
+		want Type
+		err  error
+	}{
+		{"mx", MX, nil},
+		{"AAAA", AAAA, nil},
+		{"soa", "", ErrUnsupportedType},
+	} {
+		t.Run(c.s, func(t *testing.T) {
+			got, err := ParseType(c.s)
+			if got != c.want || err != c.err {
+				t.Fatalf("got %q, %v; want %q, %v", got, err, c.want, c.err)
+			}
+		})
+	}
+}
+
+type mockFetcher struct {
+	lookups int
+}
+
+func (m *mockFetcher) Lookup(domain string, t Type) ([]Record, error) {
+	m.lookups++
+	return []Record{{Type: t, Value: "93.184.216.34"}}, nil
+}
+
+func (m *mockFetcher) Reverse(ip string) ([]string, error) {
+	m.lookups++
+	return []string{"dns.google"}, nil
+}
+
+func TestLimited(t *testing.T) {
+	m := &mockFetcher{}
+	l := &Limited{
+		Fetcher:  m,
+		Throttle: &throttle.Throttle{Rate: 1, Burst: 2, TTL: time.Minute},
+	}
+
+	for i := 0; i < 2; i++ {
+		got, err := l.Lookup("example.com", A)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		want := []Record{{Type: A, Value: "93.184.216.34"}}
+		if !reflect.DeepEqual(got, want) {
+			t.Fatalf("got %+v; want %+v", got, want)
+		}
+
+		hosts, err := l.Reverse("8.8.8.8")
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if !reflect.DeepEqual(hosts, []string{"dns.google"}) {
+			t.Fatalf("got %+v", hosts)
+		}
+	}
+
+	if m.lookups != 2 {
+		t.Fatalf("got %d lookups; want 2", m.lookups)
+	}
+
+	if _, err := l.Lookup("example.org", MX); err != throttle.ErrLimited {
+		t.Fatalf("got %v; want %v", err, throttle.ErrLimited)
+	}
+}
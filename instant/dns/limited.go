@@ -0,0 +1,35 @@
+package dns
+
+import (
+	"github.com/jivesearch/jivesearch/instant/throttle"
+)
+
+// Limited caches and rate limits another Fetcher's lookups
+type Limited struct {
+	Fetcher
+	*throttle.Throttle
+}
+
+// Lookup gets a domain's records of a type
+func (l *Limited) Lookup(domain string, t Type) ([]Record, error) {
+	v, err := l.Do(string(t)+":"+domain, func() (interface{}, error) {
+		return l.Fetcher.Lookup(domain, t)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return v.([]Record), nil
+}
+
+// Reverse gets the hostnames of an IP Address
+func (l *Limited) Reverse(ip string) ([]string, error) {
+	v, err := l.Do("PTR:"+ip, func() (interface{}, error) {
+		return l.Fetcher.Reverse(ip)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return v.([]string), nil
+}
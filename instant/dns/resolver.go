@@ -0,0 +1,103 @@
+package dns
+
+import (
+	"context"
+	"net"
+	"strings"
+	"time"
+)
+
+// Resolver looks up records with the system's resolver
+type Resolver struct {
+	*net.Resolver
+	Timeout time.Duration
+}
+
+// Lookup gets a domain's records of a type
+func (r *Resolver) Lookup(domain string, t Type) ([]Record, error) {
+	ctx, cancel := r.context()
+	defer cancel()
+
+	records := []Record{}
+
+	switch t {
+	case A, AAAA:
+		network := "ip4"
+		if t == AAAA {
+			network = "ip6"
+		}
+
+		ips, err := r.LookupIP(ctx, network, domain)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, ip := range ips {
+			records = append(records, Record{Type: t, Value: ip.String()})
+		}
+	case CNAME:
+		cname, err := r.LookupCNAME(ctx, domain)
+		if err != nil {
+			return nil, err
+		}
+
+		records = append(records, Record{Type: t, Value: strings.TrimSuffix(cname, ".")})
+	case MX:
+		mxs, err := r.LookupMX(ctx, domain)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, mx := range mxs {
+			records = append(records, Record{Type: t, Value: strings.TrimSuffix(mx.Host, "."), Priority: mx.Pref})
+		}
+	case NS:
+		nss, err := r.LookupNS(ctx, domain)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, ns := range nss {
+			records = append(records, Record{Type: t, Value: strings.TrimSuffix(ns.Host, ".")})
+		}
+	case TXT:
+		txts, err := r.LookupTXT(ctx, domain)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, txt := range txts {
+			records = append(records, Record{Type: t, Value: txt})
+		}
+	default:
+		return nil, ErrUnsupportedType
+	}
+
+	return records, nil
+}
+
+// Reverse gets the hostnames of an IP Address
+func (r *Resolver) Reverse(ip string) ([]string, error) {
+	ctx, cancel := r.context()
+	defer cancel()
+
+	names, err := r.LookupAddr(ctx, ip)
+	if err != nil {
+		return nil, err
+	}
+
+	for i, n := range names {
+		names[i] = strings.TrimSuffix(n, ".")
+	}
+
+	return names, nil
+}
+
+func (r *Resolver) context() (context.Context, context.CancelFunc) {
+	timeout := r.Timeout
+	if timeout <= 0 {
+		timeout = 5 * time.Second
+	}
+
+	return context.WithTimeout(context.Background(), timeout)
+}
@@ -0,0 +1,313 @@
+package instant
+
+import (
+	"fmt"
+	"net/http"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/jivesearch/jivesearch/search/intent"
+	"golang.org/x/text/language"
+)
+
+// ElementType is an answer Type
+const ElementType Type = "element"
+
+// Element is an instant answer
+type Element struct {
+	Answer
+}
+
+// ChemicalElement is an element of the periodic table
+type ChemicalElement struct {
+	Number        int     `json:"number"`
+	Symbol        string  `json:"symbol"`
+	Name          string  `json:"name"`
+	Mass          float64 `json:"mass"`
+	MassNumber    bool    `json:"mass_number,omitempty"` // Mass is that of the most stable isotope as there is no standard atomic weight
+	Group         int     `json:"group,omitempty"`       // 0 for the lanthanides and actinides
+	Period        int     `json:"period"`
+	Category      string  `json:"category"`
+	Configuration string  `json:"configuration"` // electron configuration, e.g. [He] 2s2 2p2
+}
+
+// ElementResponse is an element and the property that was asked about, if any
+type ElementResponse struct {
+	ChemicalElement
+	Property string `json:"property,omitempty"` // "mass", "number", "symbol", "group", "period" or "configuration"
+}
+
+// elementProperties maps how people ask for a property to the property
+var elementProperties = map[string]string{
+	"atomic weight":          "mass",
+	"atomic mass":            "mass",
+	"molar mass":             "mass",
+	"mass":                   "mass",
+	"atomic number":          "number",
+	"symbol":                 "symbol",
+	"chemical symbol":        "symbol",
+	"group":                  "group",
+	"period":                 "period",
+	"electron configuration": "configuration",
+}
+
+// elementAliases are other spellings of element names
+var elementAliases = map[string]string{
+	"aluminum": "Aluminium",
+	"cesium":   "Caesium",
+	"sulphur":  "Sulfur",
+}
+
+func (e *Element) setQuery(r *http.Request, qv string) Answerer {
+	e.Answer.setQuery(r, qv)
+	return e
+}
+
+func (e *Element) setUserAgent(r *http.Request) Answerer {
+	return e
+}
+
+func (e *Element) setLanguage(lang language.Tag) Answerer {
+	e.language = lang
+	return e
+}
+
+func (e *Element) setType() Answerer {
+	e.Type = ElementType
+	return e
+}
+
+func (e *Element) setRegex() Answerer {
+	props := []string{}
+	for p := range elementProperties {
+		props = append(props, p)
+	}
+	sort.Strings(props)
+	p := strings.Join(props, "|")
+
+	e.regex = append(e.regex, regexp.MustCompile(`^(?P<trigger>element)(?: number)? (?P<remainder>\d{1,3}|[a-z]+)$`))
+	e.regex = append(e.regex, regexp.MustCompile(`^(?P<remainder>[a-z]+) (?P<trigger>element)$`))
+	e.regex = append(e.regex, regexp.MustCompile(fmt.Sprintf(`^(?:what is the )?(?P<property>%v) (?P<trigger>of|for) (?P<remainder>[a-z]+)$`, p)))
+	e.regex = append(e.regex, regexp.MustCompile(fmt.Sprintf(`^(?P<remainder>[a-z]+) (?P<trigger>%v)$`, p)))
+	return e
+}
+
+func (e *Element) solve(r *http.Request) Answerer {
+	el, ok := findElement(e.remainder)
+	if !ok {
+		e.Err = fmt.Errorf("unknown element %q", e.remainder)
+		return e
+	}
+
+	prop := e.remainderM["property"]
+	if prop == "" {
+		prop = e.triggerWord
+	}
+
+	e.Solution = &ElementResponse{ChemicalElement: el, Property: elementProperties[prop]}
+	return e
+}
+
+// findElement looks up an element by number, symbol or name
+func findElement(s string) (ChemicalElement, bool) {
+	if n, err := strconv.Atoi(s); err == nil {
+		if n < 1 || n > len(elements) {
+			return ChemicalElement{}, false
+		}
+		return element(n), true
+	}
+
+	if a, ok := elementAliases[s]; ok {
+		s = a
+	}
+
+	for i, el := range elements {
+		if strings.EqualFold(el.Symbol, s) || strings.EqualFold(el.Name, s) {
+			return element(i + 1), true
+		}
+	}
+
+	return ChemicalElement{}, false
+}
+
+// element fills in what the table leaves out
+func element(n int) ChemicalElement {
+	el := elements[n-1]
+	el.Number = n
+
+	// technetium, promethium and everything from polonium on have no stable isotopes
+	// but thorium, protactinium and uranium still have a standard atomic weight
+	el.MassNumber = (n == 43 || n == 61 || n >= 84) && (n < 90 || n > 92)
+	return el
+}
+
+func (e *Element) tests() []test {
+	carbon, _ := findElement("carbon")
+	gold, _ := findElement("79")
+	aluminium, _ := findElement("al")
+	technetium, _ := findElement("tc")
+
+	tests := []test{}
+
+	for _, c := range []struct {
+		query string
+		resp  *ElementResponse
+	}{
+		{"atomic weight of carbon", &ElementResponse{ChemicalElement: carbon, Property: "mass"}},
+		{"element 79", &ElementResponse{ChemicalElement: gold}},
+		{"gold element", &ElementResponse{ChemicalElement: gold}},
+		{"what is the electron configuration of au", &ElementResponse{ChemicalElement: gold, Property: "configuration"}},
+		{"aluminum atomic number", &ElementResponse{ChemicalElement: aluminium, Property: "number"}},
+		{"element technetium", &ElementResponse{ChemicalElement: technetium}},
+	} {
+		tests = append(tests, test{
+			query: c.query,
+			expected: []Data{
+				{
+					Type:      ElementType,
+					Triggered: true,
+					Solution:  c.resp,
+				},
+			},
+		})
+	}
+
+	return tests
+}
+
+func init() {
+	Register(Registration{
+		Name:     "element",
+		Trigger:  `"element" and a number, name or symbol, or a property like "atomic weight of carbon"`,
+		Priority: 510,
+		Intent:   intent.Informational,
+		New: func(i *Instant) Answerer {
+			return &Element{}
+		},
+	})
+}
+
+// elements is the periodic table in order of atomic number.
+// Masses are the IUPAC conventional atomic weights, or the mass number of the most stable isotope.
+var elements = []ChemicalElement{
+	{Symbol: "H", Name: "Hydrogen", Mass: 1.008, Group: 1, Period: 1, Category: "Nonmetal", Configuration: "1s1"},
+	{Symbol: "He", Name: "Helium", Mass: 4.0026, Group: 18, Period: 1, Category: "Noble gas", Configuration: "1s2"},
+	{Symbol: "Li", Name: "Lithium", Mass: 6.94, Group: 1, Period: 2, Category: "Alkali metal", Configuration: "[He] 2s1"},
+	{Symbol: "Be", Name: "Beryllium", Mass: 9.0122, Group: 2, Period: 2, Category: "Alkaline earth metal", Configuration: "[He] 2s2"},
+	{Symbol: "B", Name: "Boron", Mass: 10.81, Group: 13, Period: 2, Category: "Metalloid", Configuration: "[He] 2s2 2p1"},
+	{Symbol: "C", Name: "Carbon", Mass: 12.011, Group: 14, Period: 2, Category: "Nonmetal", Configuration: "[He] 2s2 2p2"},
+	{Symbol: "N", Name: "Nitrogen", Mass: 14.007, Group: 15, Period: 2, Category: "Nonmetal", Configuration: "[He] 2s2 2p3"},
+	{Symbol: "O", Name: "Oxygen", Mass: 15.999, Group: 16, Period: 2, Category: "Nonmetal", Configuration: "[He] 2s2 2p4"},
+	{Symbol: "F", Name: "Fluorine", Mass: 18.998, Group: 17, Period: 2, Category: "Halogen", Configuration: "[He] 2s2 2p5"},
+	{Symbol: "Ne", Name: "Neon", Mass: 20.18, Group: 18, Period: 2, Category: "Noble gas", Configuration: "[He] 2s2 2p6"},
+	{Symbol: "Na", Name: "Sodium", Mass: 22.99, Group: 1, Period: 3, Category: "Alkali metal", Configuration: "[Ne] 3s1"},
+	{Symbol: "Mg", Name: "Magnesium", Mass: 24.305, Group: 2, Period: 3, Category: "Alkaline earth metal", Configuration: "[Ne] 3s2"},
+	{Symbol: "Al", Name: "Aluminium", Mass: 26.982, Group: 13, Period: 3, Category: "Post-transition metal", Configuration: "[Ne] 3s2 3p1"},
+	{Symbol: "Si", Name: "Silicon", Mass: 28.085, Group: 14, Period: 3, Category: "Metalloid", Configuration: "[Ne] 3s2 3p2"},
+	{Symbol: "P", Name: "Phosphorus", Mass: 30.974, Group: 15, Period: 3, Category: "Nonmetal", Configuration: "[Ne] 3s2 3p3"},
+	{Symbol: "S", Name: "Sulfur", Mass: 32.06, Group: 16, Period: 3, Category: "Nonmetal", Configuration: "[Ne] 3s2 3p4"},
+	{Symbol: "Cl", Name: "Chlorine", Mass: 35.45, Group: 17, Period: 3, Category: "Halogen", Configuration: "[Ne] 3s2 3p5"},
+	{Symbol: "Ar", Name: "Argon", Mass: 39.95, Group: 18, Period: 3, Category: "Noble gas", Configuration: "[Ne] 3s2 3p6"},
+	{Symbol: "K", Name: "Potassium", Mass: 39.098, Group: 1, Period: 4, Category: "Alkali metal", Configuration: "[Ar] 4s1"},
+	{Symbol: "Ca", Name: "Calcium", Mass: 40.078, Group: 2, Period: 4, Category: "Alkaline earth metal", Configuration: "[Ar] 4s2"},
+	{Symbol: "Sc", Name: "Scandium", Mass: 44.956, Group: 3, Period: 4, Category: "Transition metal", Configuration: "[Ar] 3d1 4s2"},
+	{Symbol: "Ti", Name: "Titanium", Mass: 47.867, Group: 4, Period: 4, Category: "Transition metal", Configuration: "[Ar] 3d2 4s2"},
+	{Symbol: "V", Name: "Vanadium", Mass: 50.942, Group: 5, Period: 4, Category: "Transition metal", Configuration: "[Ar] 3d3 4s2"},
+	{Symbol: "Cr", Name: "Chromium", Mass: 51.996, Group: 6, Period: 4, Category: "Transition metal", Configuration: "[Ar] 3d5 4s1"},
+	{Symbol: "Mn", Name: "Manganese", Mass: 54.938, Group: 7, Period: 4, Category: "Transition metal", Configuration: "[Ar] 3d5 4s2"},
+	{Symbol: "Fe", Name: "Iron", Mass: 55.845, Group: 8, Period: 4, Category: "Transition metal", Configuration: "[Ar] 3d6 4s2"},
+	{Symbol: "Co", Name: "Cobalt", Mass: 58.933, Group: 9, Period: 4, Category: "Transition metal", Configuration: "[Ar] 3d7 4s2"},
+	{Symbol: "Ni", Name: "Nickel", Mass: 58.693, Group: 10, Period: 4, Category: "Transition metal", Configuration: "[Ar] 3d8 4s2"},
+	{Symbol: "Cu", Name: "Copper", Mass: 63.546, Group: 11, Period: 4, Category: "Transition metal", Configuration: "[Ar] 3d10 4s1"},
+	{Symbol: "Zn", Name: "Zinc", Mass: 65.38, Group: 12, Period: 4, Category: "Transition metal", Configuration: "[Ar] 3d10 4s2"},
+	{Symbol: "Ga", Name: "Gallium", Mass: 69.723, Group: 13, Period: 4, Category: "Post-transition metal", Configuration: "[Ar] 3d10 4s2 4p1"},
+	{Symbol: "Ge", Name: "Germanium", Mass: 72.63, Group: 14, Period: 4, Category: "Metalloid", Configuration: "[Ar] 3d10 4s2 4p2"},
+	{Symbol: "As", Name: "Arsenic", Mass: 74.922, Group: 15, Period: 4, Category: "Metalloid", Configuration: "[Ar] 3d10 4s2 4p3"},
+	{Symbol: "Se", Name: "Selenium", Mass: 78.971, Group: 16, Period: 4, Category: "Nonmetal", Configuration: "[Ar] 3d10 4s2 4p4"},
+	{Symbol: "Br", Name: "Bromine", Mass: 79.904, Group: 17, Period: 4, Category: "Halogen", Configuration: "[Ar] 3d10 4s2 4p5"},
+	{Symbol: "Kr", Name: "Krypton", Mass: 83.798, Group: 18, Period: 4, Category: "Noble gas", Configuration: "[Ar] 3d10 4s2 4p6"},
+	{Symbol: "Rb", Name: "Rubidium", Mass: 85.468, Group: 1, Period: 5, Category: "Alkali metal", Configuration: "[Kr] 5s1"},
+	{Symbol: "Sr", Name: "Strontium", Mass: 87.62, Group: 2, Period: 5, Category: "Alkaline earth metal", Configuration: "[Kr] 5s2"},
+	{Symbol: "Y", Name: "Yttrium", Mass: 88.906, Group: 3, Period: 5, Category: "Transition metal", Configuration: "[Kr] 4d1 5s2"},
+	{Symbol: "Zr", Name: "Zirconium", Mass: 91.224, Group: 4, Period: 5, Category: "Transition metal", Configuration: "[Kr] 4d2 5s2"},
+	{Symbol: "Nb", Name: "Niobium", Mass: 92.906, Group: 5, Period: 5, Category: "Transition metal", Configuration: "[Kr] 4d4 5s1"},
+	{Symbol: "Mo", Name: "Molybdenum", Mass: 95.95, Group: 6, Period: 5, Category: "Transition metal", Configuration: "[Kr] 4d5 5s1"},
+	{Symbol: "Tc", Name: "Technetium", Mass: 98, Group: 7, Period: 5, Category: "Transition metal", Configuration: "[Kr] 4d5 5s2"},
+	{Symbol: "Ru", Name: "Ruthenium", Mass: 101.07, Group: 8, Period: 5, Category: "Transition metal", Configuration: "[Kr] 4d7 5s1"},
+	{Symbol: "Rh", Name: "Rhodium", Mass: 102.91, Group: 9, Period: 5, Category: "Transition metal", Configuration: "[Kr] 4d8 5s1"},
+	{Symbol: "Pd", Name: "Palladium", Mass: 106.42, Group: 10, Period: 5, Category: "Transition metal", Configuration: "[Kr] 4d10"},
+	{Symbol: "Ag", Name: "Silver", Mass: 107.87, Group: 11, Period: 5, Category: "Transition metal", Configuration: "[Kr] 4d10 5s1"},
+	{Symbol: "Cd", Name: "Cadmium", Mass: 112.41, Group: 12, Period: 5, Category: "Transition metal", Configuration: "[Kr] 4d10 5s2"},
+	{Symbol: "In", Name: "Indium", Mass: 114.82, Group: 13, Period: 5, Category: "Post-transition metal", Configuration: "[Kr] 4d10 5s2 5p1"},
+	{Symbol: "Sn", Name: "Tin", Mass: 118.71, Group: 14, Period: 5, Category: "Post-transition metal", Configuration: "[Kr] 4d10 5s2 5p2"},
+	{Symbol: "Sb", Name: "Antimony", Mass: 121.76, Group: 15, Period: 5, Category: "Metalloid", Configuration: "[Kr] 4d10 5s2 5p3"},
+	{Symbol: "Te", Name: "Tellurium", Mass: 127.6, Group: 16, Period: 5, Category: "Metalloid", Configuration: "[Kr] 4d10 5s2 5p4"},
+	{Symbol: "I", Name: "Iodine", Mass: 126.9, Group: 17, Period: 5, Category: "Halogen", Configuration: "[Kr] 4d10 5s2 5p5"},
+	{Symbol: "Xe", Name: "Xenon", Mass: 131.29, Group: 18, Period: 5, Category: "Noble gas", Configuration: "[Kr] 4d10 5s2 5p6"},
+	{Symbol: "Cs", Name: "Caesium", Mass: 132.91, Group: 1, Period: 6, Category: "Alkali metal", Configuration: "[Xe] 6s1"},
+	{Symbol: "Ba", Name: "Barium", Mass: 137.33, Group: 2, Period: 6, Category: "Alkaline earth metal", Configuration: "[Xe] 6s2"},
+	{Symbol: "La", Name: "Lanthanum", Mass: 138.91, Period: 6, Category: "Lanthanide", Configuration: "[Xe] 5d1 6s2"},
+	{Symbol: "Ce", Name: "Cerium", Mass: 140.12, Period: 6, Category: "Lanthanide", Configuration: "[Xe] 4f1 5d1 6s2"},
+	{Symbol: "Pr", Name: "Praseodymium", Mass: 140.91, Period: 6, Category: "Lanthanide", Configuration: "[Xe] 4f3 6s2"},
+	{Symbol: "Nd", Name: "Neodymium", Mass: 144.24, Period: 6, Category: "Lanthanide", Configuration: "[Xe] 4f4 6s2"},
+	{Symbol: "Pm", Name: "Promethium", Mass: 145, Period: 6, Category: "Lanthanide", Configuration: "[Xe] 4f5 6s2"},
+	{Symbol: "Sm", Name: "Samarium", Mass: 150.36, Period: 6, Category: "Lanthanide", Configuration: "[Xe] 4f6 6s2"},
+	{Symbol: "Eu", Name: "Europium", Mass: 151.96, Period: 6, Category: "Lanthanide", Configuration: "[Xe] 4f7 6s2"},
+	{Symbol: "Gd", Name: "Gadolinium", Mass: 157.25, Period: 6, Category: "Lanthanide", Configuration: "[Xe] 4f7 5d1 6s2"},
+	{Symbol: "Tb", Name: "Terbium", Mass: 158.93, Period: 6, Category: "Lanthanide", Configuration: "[Xe] 4f9 6s2"},
+	{Symbol: "Dy", Name: "Dysprosium", Mass: 162.5, Period: 6, Category: "Lanthanide", Configuration: "[Xe] 4f10 6s2"},
+	{Symbol: "Ho", Name: "Holmium", Mass: 164.93, Period: 6, Category: "Lanthanide", Configuration: "[Xe] 4f11 6s2"},
+	{Symbol: "Er", Name: "Erbium", Mass: 167.26, Period: 6, Category: "Lanthanide", Configuration: "[Xe] 4f12 6s2"},
+	{Symbol: "Tm", Name: "Thulium", Mass: 168.93, Period: 6, Category: "Lanthanide", Configuration: "[Xe] 4f13 6s2"},
+	{Symbol: "Yb", Name: "Ytterbium", Mass: 173.05, Period: 6, Category: "Lanthanide", Configuration: "[Xe] 4f14 6s2"},
+	{Symbol: "Lu", Name: "Lutetium", Mass: 174.97, Period: 6, Category: "Lanthanide", Configuration: "[Xe] 4f14 5d1 6s2"},
+	{Symbol: "Hf", Name: "Hafnium", Mass: 178.49, Group: 4, Period: 6, Category: "Transition metal", Configuration: "[Xe] 4f14 5d2 6s2"},
+	{Symbol: "Ta", Name: "Tantalum", Mass: 180.95, Group: 5, Period: 6, Category: "Transition metal", Configuration: "[Xe] 4f14 5d3 6s2"},
+	{Symbol: "W", Name: "Tungsten", Mass: 183.84, Group: 6, Period: 6, Category: "Transition metal", Configuration: "[Xe] 4f14 5d4 6s2"},
+	{Symbol: "Re", Name: "Rhenium", Mass: 186.21, Group: 7, Period: 6, Category: "Transition metal", Configuration: "[Xe] 4f14 5d5 6s2"},
+	{Symbol: "Os", Name: "Osmium", Mass: 190.23, Group: 8, Period: 6, Category: "Transition metal", Configuration: "[Xe] 4f14 5d6 6s2"},
+	{Symbol: "Ir", Name: "Iridium", Mass: 192.22, Group: 9, Period: 6, Category: "Transition metal", Configuration: "[Xe] 4f14 5d7 6s2"},
+	{Symbol: "Pt", Name: "Platinum", Mass: 195.08, Group: 10, Period: 6, Category: "Transition metal", Configuration: "[Xe] 4f14 5d9 6s1"},
+	{Symbol: "Au", Name: "Gold", Mass: 196.97, Group: 11, Period: 6, Category: "Transition metal", Configuration: "[Xe] 4f14 5d10 6s1"},
+	{Symbol: "Hg", Name: "Mercury", Mass: 200.59, Group: 12, Period: 6, Category: "Transition metal", Configuration: "[Xe] 4f14 5d10 6s2"},
+	{Symbol: "Tl", Name: "Thallium", Mass: 204.38, Group: 13, Period: 6, Category: "Post-transition metal", Configuration: "[Xe] 4f14 5d10 6s2 6p1"},
+	{Symbol: "Pb", Name: "Lead", Mass: 207.2, Group: 14, Period: 6, Category: "Post-transition metal", Configuration: "[Xe] 4f14 5d10 6s2 6p2"},
+	{Symbol: "Bi", Name: "Bismuth", Mass: 208.98, Group: 15, Period: 6, Category: "Post-transition metal", Configuration: "[Xe] 4f14 5d10 6s2 6p3"},
+	{Symbol: "Po", Name: "Polonium", Mass: 209, Group: 16, Period: 6, Category: "Post-transition metal", Configuration: "[Xe] 4f14 5d10 6s2 6p4"},
+	{Symbol: "At", Name: "Astatine", Mass: 210, Group: 17, Period: 6, Category: "Halogen", Configuration: "[Xe] 4f14 5d10 6s2 6p5"},
+	{Symbol: "Rn", Name: "Radon", Mass: 222, Group: 18, Period: 6, Category: "Noble gas", Configuration: "[Xe] 4f14 5d10 6s2 6p6"},
+	{Symbol: "Fr", Name: "Francium", Mass: 223, Group: 1, Period: 7, Category: "Alkali metal", Configuration: "[Rn] 7s1"},
+	{Symbol: "Ra", Name: "Radium", Mass: 226, Group: 2, Period: 7, Category: "Alkaline earth metal", Configuration: "[Rn] 7s2"},
+	{Symbol: "Ac", Name: "Actinium", Mass: 227, Period: 7, Category: "Actinide", Configuration: "[Rn] 6d1 7s2"},
+	{Symbol: "Th", Name: "Thorium", Mass: 232.04, Period: 7, Category: "Actinide", Configuration: "[Rn] 6d2 7s2"},
+	{Symbol: "Pa", Name: "Protactinium", Mass: 231.04, Period: 7, Category: "Actinide", Configuration: "[Rn] 5f2 6d1 7s2"},
+	{Symbol: "U", Name: "Uranium", Mass: 238.03, Period: 7, Category: "Actinide", Configuration: "[Rn] 5f3 6d1 7s2"},
+	{Symbol: "Np", Name: "Neptunium", Mass: 237, Period: 7, Category: "Actinide", Configuration: "[Rn] 5f4 6d1 7s2"},
+	{Symbol: "Pu", Name: "Plutonium", Mass: 244, Period: 7, Category: "Actinide", Configuration: "[Rn] 5f6 7s2"},
+	{Symbol: "Am", Name: "Americium", Mass: 243, Period: 7, Category: "Actinide", Configuration: "[Rn] 5f7 7s2"},
+	{Symbol: "Cm", Name: "Curium", Mass: 247, Period: 7, Category: "Actinide", Configuration: "[Rn] 5f7 6d1 7s2"},
+	{Symbol: "Bk", Name: "Berkelium", Mass: 247, Period: 7, Category: "Actinide", Configuration: "[Rn] 5f9 7s2"},
+	{Symbol: "Cf", Name: "Californium", Mass: 251, Period: 7, Category: "Actinide", Configuration: "[Rn] 5f10 7s2"},
+	{Symbol: "Es", Name: "Einsteinium", Mass: 252, Period: 7, Category: "Actinide", Configuration: "[Rn] 5f11 7s2"},
+	{Symbol: "Fm", Name: "Fermium", Mass: 257, Period: 7, Category: "Actinide", Configuration: "[Rn] 5f12 7s2"},
+	{Symbol: "Md", Name: "Mendelevium", Mass: 258, Period: 7, Category: "Actinide", Configuration: "[Rn] 5f13 7s2"},
+	{Symbol: "No", Name: "Nobelium", Mass: 259, Period: 7, Category: "Actinide", Configuration: "[Rn] 5f14 7s2"},
+	{Symbol: "Lr", Name: "Lawrencium", Mass: 266, Period: 7, Category: "Actinide", Configuration: "[Rn] 5f14 7s2 7p1"},
+	{Symbol: "Rf", Name: "Rutherfordium", Mass: 267, Group: 4, Period: 7, Category: "Transition metal", Configuration: "[Rn] 5f14 6d2 7s2"},
+	{Symbol: "Db", Name: "Dubnium", Mass: 268, Group: 5, Period: 7, Category: "Transition metal", Configuration: "[Rn] 5f14 6d3 7s2"},
+	{Symbol: "Sg", Name: "Seaborgium", Mass: 269, Group: 6, Period: 7, Category: "Transition metal", Configuration: "[Rn] 5f14 6d4 7s2"},
+	{Symbol: "Bh", Name: "Bohrium", Mass: 270, Group: 7, Period: 7, Category: "Transition metal", Configuration: "[Rn] 5f14 6d5 7s2"},
+	{Symbol: "Hs", Name: "Hassium", Mass: 269, Group: 8, Period: 7, Category: "Transition metal", Configuration: "[Rn] 5f14 6d6 7s2"},
+	{Symbol: "Mt", Name: "Meitnerium", Mass: 278, Group: 9, Period: 7, Category: "Transition metal", Configuration: "[Rn] 5f14 6d7 7s2"},
+	{Symbol: "Ds", Name: "Darmstadtium", Mass: 281, Group: 10, Period: 7, Category: "Transition metal", Configuration: "[Rn] 5f14 6d8 7s2"},
+	{Symbol: "Rg", Name: "Roentgenium", Mass: 282, Group: 11, Period: 7, Category: "Transition metal", Configuration: "[Rn] 5f14 6d9 7s2"},
+	{Symbol: "Cn", Name: "Copernicium", Mass: 285, Group: 12, Period: 7, Category: "Transition metal", Configuration: "[Rn] 5f14 6d10 7s2"},
+	{Symbol: "Nh", Name: "Nihonium", Mass: 286, Group: 13, Period: 7, Category: "Unknown", Configuration: "[Rn] 5f14 6d10 7s2 7p1"},
+	{Symbol: "Fl", Name: "Flerovium", Mass: 289, Group: 14, Period: 7, Category: "Unknown", Configuration: "[Rn] 5f14 6d10 7s2 7p2"},
+	{Symbol: "Mc", Name: "Moscovium", Mass: 290, Group: 15, Period: 7, Category: "Unknown", Configuration: "[Rn] 5f14 6d10 7s2 7p3"},
+	{Symbol: "Lv", Name: "Livermorium", Mass: 293, Group: 16, Period: 7, Category: "Unknown", Configuration: "[Rn] 5f14 6d10 7s2 7p4"},
+	{Symbol: "Ts", Name: "Tennessine", Mass: 294, Group: 17, Period: 7, Category: "Unknown", Configuration: "[Rn] 5f14 6d10 7s2 7p5"},
+	{Symbol: "Og", Name: "Oganesson", Mass: 294, Group: 18, Period: 7, Category: "Unknown", Configuration: "[Rn] 5f14 6d10 7s2 7p6"},
+}
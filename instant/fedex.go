@@ -134,3 +134,15 @@ func (f *FedEx) tests() []test {
 
 	return tests
 }
+
+func init() {
+	Register(Registration{
+		Name:     "fedex",
+		Trigger:  `a FedEx tracking number`,
+		Priority: 120,
+		External: true,
+		New: func(i *Instant) Answerer {
+			return &FedEx{Fetcher: i.FedExFetcher}
+		},
+	})
+}
@@ -132,5 +132,14 @@ func (f *Frequency) tests() []test {
 }
 
 func init() {
+	Register(Registration{
+		Name:     "frequency",
+		Trigger:  `"frequency of" a letter or word in some text`,
+		Priority: 130,
+		New: func(i *Instant) Answerer {
+			return &Frequency{}
+		},
+	})
+
 	reFrequency = regexp.MustCompile(`^(.*?) in (.+)`)
 }
@@ -8,6 +8,7 @@ import (
 
 	"github.com/jivesearch/jivesearch/instant/econ"
 	ggdp "github.com/jivesearch/jivesearch/instant/econ/gdp"
+	"github.com/jivesearch/jivesearch/search/intent"
 	"github.com/pariz/gountries"
 	"golang.org/x/text/language"
 )
@@ -138,3 +139,16 @@ func (g *GDP) tests() []test {
 
 	return tests
 }
+
+func init() {
+	Register(Registration{
+		Name:     "gdp",
+		Trigger:  `"gdp" or "gross domestic product" and a country`,
+		Priority: 140,
+		Intent:   intent.Informational,
+		External: true,
+		New: func(i *Instant) Answerer {
+			return &GDP{GDPFetcher: i.GDPFetcher}
+		},
+	})
+}
@@ -202,3 +202,14 @@ func (h *Hash) tests() []test {
 
 	return tests
 }
+
+func init() {
+	Register(Registration{
+		Name:     "hash",
+		Trigger:  `"md5", "sha1", "sha256", etc. and some text`,
+		Priority: 150,
+		New: func(i *Instant) Answerer {
+			return &Hash{}
+		},
+	})
+}
@@ -0,0 +1,241 @@
+package instant
+
+import (
+	"fmt"
+	"net/http"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/jivesearch/jivesearch/instant/holiday"
+	"github.com/jivesearch/jivesearch/search/intent"
+	"github.com/pariz/gountries"
+	"golang.org/x/text/language"
+)
+
+// HolidayType is an answer Type for a single holiday, e.g. "when is thanksgiving"
+const HolidayType Type = "holiday"
+
+// HolidaysType is an answer Type for a country's holidays in a year
+const HolidaysType Type = "holidays"
+
+// Holiday is an instant answer
+type Holiday struct {
+	Region language.Region // the user's region, for when the query doesn't name a country
+	Answer
+}
+
+// HolidayResponse is the next occurrence of a holiday
+type HolidayResponse struct {
+	holiday.Holiday
+	Country string `json:"country,omitempty"` // only if named in the query
+	Days    int    `json:"days"`              // until the holiday
+}
+
+// HolidaysResponse is a country's public holidays in a year
+type HolidaysResponse struct {
+	Country  string            `json:"country"`
+	Year     int               `json:"year"`
+	Holidays []holiday.Holiday `json:"holidays"`
+}
+
+// countryAliases are common names gountries doesn't know
+var countryAliases = map[string]string{
+	"america":         "US",
+	"the us":          "US",
+	"the usa":         "US",
+	"usa":             "US",
+	"uk":              "GB",
+	"the uk":          "GB",
+	"britain":         "GB",
+	"great britain":   "GB",
+	"england":         "GB",
+	"holland":         "NL",
+	"the netherlands": "NL",
+}
+
+func (h *Holiday) setQuery(r *http.Request, qv string) Answerer {
+	h.Answer.setQuery(r, qv)
+	return h
+}
+
+func (h *Holiday) setUserAgent(r *http.Request) Answerer {
+	return h
+}
+
+func (h *Holiday) setLanguage(lang language.Tag) Answerer {
+	h.language = lang
+	return h
+}
+
+func (h *Holiday) setType() Answerer {
+	h.Type = HolidayType
+	return h
+}
+
+func (h *Holiday) setRegex() Answerer {
+	h.regex = append(h.regex, regexp.MustCompile(`^(?:public |bank )?(?P<trigger>holidays)(?: in| for)?(?: (?P<country>[\p{L} .]+?))?(?: (?P<year>\d{4}))?$`))
+	h.regex = append(h.regex, regexp.MustCompile(`^(?P<year>\d{4}) (?:public |bank )?(?P<trigger>holidays)(?: in| for)? (?P<country>[\p{L} .]+)$`))
+	h.regex = append(h.regex, regexp.MustCompile(`^(?P<country>[\p{L} .]+?) (?:public |bank )?(?P<trigger>holidays)(?: (?P<year>\d{4}))?$`))
+	h.regex = append(h.regex, regexp.MustCompile(`^(?P<trigger>when is|when's|what day is|what date is) (?P<holiday>.+?)(?: (?P<year>\d{4}))?(?: in (?P<country>[\p{L} .]+))?$`))
+	h.regex = append(h.regex, regexp.MustCompile(`^(?P<trigger>how many days|days|countdown) (?:until|till|til|to) (?P<holiday>.+?)(?: in (?P<country>[\p{L} .]+))?$`))
+	return h
+}
+
+func (h *Holiday) solve(r *http.Request) Answerer {
+	code := h.Region.String()
+	var name string
+
+	if c := h.remainderM["country"]; c != "" {
+		var err error
+		code, name, err = findCountry(c)
+		if err != nil {
+			h.Err = err
+			return h
+		}
+	}
+
+	today := now()
+
+	if h.triggerWord == "holidays" {
+		year := today.Year()
+		if y, err := strconv.Atoi(h.remainderM["year"]); err == nil {
+			year = y
+		}
+
+		if h.remainderM["country"] == "" && h.remainderM["year"] == "" {
+			h.Err = fmt.Errorf("%q needs a country or a year", h.query)
+			return h
+		}
+
+		holidays, ok := holiday.Holidays(code, year)
+		if !ok {
+			h.Err = fmt.Errorf("no holidays for %q", code)
+			return h
+		}
+
+		if name == "" {
+			if _, n, err := findCountry(code); err == nil {
+				name = n
+			}
+		}
+
+		h.Type = HolidaysType
+		h.Solution = &HolidaysResponse{Country: name, Year: year, Holidays: holidays}
+		return h
+	}
+
+	from := today
+	if y, err := strconv.Atoi(h.remainderM["year"]); err == nil {
+		from = time.Date(y, time.January, 1, 0, 0, 0, 0, time.UTC)
+	}
+
+	n := strings.TrimPrefix(h.remainderM["holiday"], "the ")
+	hol, ok := holiday.Find(n, code, from)
+	if !ok {
+		h.Err = fmt.Errorf("unknown holiday %q", n)
+		return h
+	}
+
+	start := time.Date(today.Year(), today.Month(), today.Day(), 0, 0, 0, 0, time.UTC)
+
+	h.Solution = &HolidayResponse{
+		Holiday: hol,
+		Country: name,
+		Days:    int(hol.Date.Sub(start).Hours() / 24),
+	}
+	return h
+}
+
+// findCountry is the ISO code and common name of a country, e.g. "france" or "uk"
+func findCountry(c string) (string, string, error) {
+	c = strings.TrimSpace(c)
+	if alpha, ok := countryAliases[c]; ok {
+		c = alpha
+	}
+
+	query := gountries.New()
+
+	country, err := query.FindCountryByName(c)
+	if err != nil {
+		country, err = query.FindCountryByAlpha(c)
+		if err != nil {
+			return "", "", err
+		}
+	}
+
+	return country.Alpha2, country.Name.Common, nil
+}
+
+func (h *Holiday) tests() []test {
+	date := func(month time.Month, day int) time.Time {
+		return time.Date(2016, month, day, 0, 0, 0, 0, time.UTC)
+	}
+
+	france := []holiday.Holiday{
+		{Name: "New Year's Day", Date: date(time.January, 1), Public: true},
+		{Name: "Easter Monday", Date: date(time.March, 28), Public: true},
+		{Name: "Labour Day", Date: date(time.May, 1), Public: true},
+		{Name: "Ascension Day", Date: date(time.May, 5), Public: true},
+		{Name: "Victory in Europe Day", Date: date(time.May, 8), Public: true},
+		{Name: "Whit Monday", Date: date(time.May, 16), Public: true},
+		{Name: "Bastille Day", Date: date(time.July, 14), Public: true},
+		{Name: "Assumption Day", Date: date(time.August, 15), Public: true},
+		{Name: "All Saints' Day", Date: date(time.November, 1), Public: true},
+		{Name: "Armistice Day", Date: date(time.November, 11), Public: true},
+		{Name: "Christmas Day", Date: date(time.December, 25), Public: true},
+	}
+
+	tests := []test{}
+
+	for _, q := range []string{"holidays in france 2016", "france holidays", "public holidays in France", "2016 holidays in france"} {
+		tests = append(tests, test{
+			query: q,
+			expected: []Data{
+				{
+					Type:      HolidaysType,
+					Triggered: true,
+					Solution:  &HolidaysResponse{Country: "France", Year: 2016, Holidays: france},
+				},
+			},
+		})
+	}
+
+	for _, c := range []struct {
+		q    string
+		resp *HolidayResponse
+	}{
+		{"when is thanksgiving", &HolidayResponse{Holiday: holiday.Holiday{Name: "Thanksgiving", Date: date(time.November, 24), Public: true}, Days: 172}},
+		{"when is thanksgiving in canada", &HolidayResponse{Holiday: holiday.Holiday{Name: "Thanksgiving", Date: date(time.October, 10), Public: true}, Country: "Canada", Days: 127}},
+		{"When is Easter 2017?", &HolidayResponse{Holiday: holiday.Holiday{Name: "Easter Sunday", Date: time.Date(2017, time.April, 16, 0, 0, 0, 0, time.UTC)}, Days: 315}},
+		{"days until christmas", &HolidayResponse{Holiday: holiday.Holiday{Name: "Christmas Day", Date: date(time.December, 25), Public: true}, Days: 203}},
+		{"how many days until halloween", &HolidayResponse{Holiday: holiday.Holiday{Name: "Halloween", Date: date(time.October, 31)}, Days: 148}},
+		{"days till the fourth of july", &HolidayResponse{Holiday: holiday.Holiday{Name: "Independence Day", Date: date(time.July, 4), Public: true}, Days: 29}},
+	} {
+		tests = append(tests, test{
+			query: c.q,
+			expected: []Data{
+				{
+					Type:      HolidayType,
+					Triggered: true,
+					Solution:  c.resp,
+				},
+			},
+		})
+	}
+
+	return tests
+}
+
+func init() {
+	Register(Registration{
+		Name:     "holiday",
+		Trigger:  `"holidays in france 2025", "when is thanksgiving" or "days until christmas"`,
+		Priority: 440,
+		Intent:   intent.Informational,
+		New: func(i *Instant) Answerer {
+			return &Holiday{}
+		},
+	})
+}
@@ -0,0 +1,179 @@
+package holiday
+
+import "time"
+
+// holidays shared by many countries
+var (
+	newYear       = Fixed("New Year's Day", time.January, 1).Also("new years", "new year")
+	epiphany      = Fixed("Epiphany", time.January, 6).Also("three kings day")
+	goodFriday    = Easter("Good Friday", -2)
+	easterMonday  = Easter("Easter Monday", 1)
+	labourDay     = Fixed("Labour Day", time.May, 1).Also("labor day", "may day")
+	ascension     = Easter("Ascension Day", 39).Also("ascension")
+	whitMonday    = Easter("Whit Monday", 50).Also("pentecost monday")
+	assumption    = Fixed("Assumption Day", time.August, 15).Also("assumption")
+	allSaints     = Fixed("All Saints' Day", time.November, 1)
+	immaculate    = Fixed("Immaculate Conception", time.December, 8)
+	christmas     = Fixed("Christmas Day", time.December, 25).Also("christmas", "xmas")
+	boxingDay     = Fixed("Boxing Day", time.December, 26)
+	stStephensDay = Fixed("St. Stephen's Day", time.December, 26).Also("saint stephens day")
+)
+
+// countries are the public holidays (and a few local observances) by ISO 3166 country code.
+// Regional holidays, like those of a single state, are left out.
+var countries = map[string][]Rule{
+	"AU": {
+		newYear,
+		Fixed("Australia Day", time.January, 26),
+		goodFriday,
+		easterMonday,
+		Fixed("Anzac Day", time.April, 25),
+		Nth("King's Birthday", time.June, time.Monday, 2).Also("queens birthday"),
+		christmas,
+		boxingDay,
+	},
+	"CA": {
+		newYear,
+		goodFriday,
+		Before("Victoria Day", time.May, 25, time.Monday),
+		Fixed("Canada Day", time.July, 1),
+		Nth("Labour Day", time.September, time.Monday, 1).Also("labor day"),
+		Fixed("National Day for Truth and Reconciliation", time.September, 30).Also("orange shirt day").Since(2021),
+		Nth("Thanksgiving", time.October, time.Monday, 2).Also("thanksgiving day"),
+		Fixed("Remembrance Day", time.November, 11),
+		christmas,
+		boxingDay,
+	},
+	"DE": {
+		newYear,
+		goodFriday,
+		easterMonday,
+		labourDay,
+		ascension,
+		whitMonday,
+		Fixed("German Unity Day", time.October, 3).Also("tag der deutschen einheit"),
+		christmas,
+		Fixed("Second Day of Christmas", time.December, 26).Also("zweiter weihnachtsfeiertag"),
+	},
+	"ES": {
+		newYear,
+		epiphany,
+		goodFriday,
+		labourDay,
+		assumption,
+		Fixed("National Day of Spain", time.October, 12).Also("fiesta nacional de españa", "hispanic day"),
+		allSaints,
+		Fixed("Constitution Day", time.December, 6),
+		immaculate,
+		christmas,
+	},
+	"FR": {
+		newYear.Also("jour de lan"),
+		easterMonday.Also("lundi de pâques"),
+		labourDay.Also("fête du travail"),
+		Fixed("Victory in Europe Day", time.May, 8).Also("ve day", "victoire 1945"),
+		ascension,
+		whitMonday,
+		Fixed("Bastille Day", time.July, 14).Also("fête nationale", "quatorze juillet"),
+		assumption,
+		allSaints.Also("toussaint"),
+		Fixed("Armistice Day", time.November, 11),
+		christmas.Also("noël"),
+	},
+	"GB": {
+		newYear,
+		goodFriday,
+		easterMonday,
+		Nth("Early May Bank Holiday", time.May, time.Monday, 1).Also("may day"),
+		Nth("Spring Bank Holiday", time.May, time.Monday, -1),
+		Nth("Summer Bank Holiday", time.August, time.Monday, -1),
+		christmas,
+		boxingDay,
+		Easter("Mothering Sunday", -21).Also("mothers day").Observance(),
+		Fixed("Bonfire Night", time.November, 5).Also("guy fawkes night").Observance(),
+	},
+	"IE": {
+		newYear,
+		{Name: "St. Brigid's Day", Public: true, date: stBrigidsDay},
+		Fixed("Saint Patrick's Day", time.March, 17).Also("st patricks day", "paddys day"),
+		easterMonday,
+		Nth("May Day", time.May, time.Monday, 1),
+		Nth("June Holiday", time.June, time.Monday, 1),
+		Nth("August Holiday", time.August, time.Monday, 1),
+		Nth("October Holiday", time.October, time.Monday, -1),
+		christmas,
+		stStephensDay,
+	},
+	"IT": {
+		newYear.Also("capodanno"),
+		epiphany,
+		easterMonday.Also("pasquetta"),
+		Fixed("Liberation Day", time.April, 25).Also("festa della liberazione"),
+		labourDay,
+		Fixed("Republic Day", time.June, 2).Also("festa della repubblica"),
+		assumption.Also("ferragosto"),
+		allSaints,
+		immaculate,
+		christmas.Also("natale"),
+		stStephensDay,
+	},
+	"NL": {
+		newYear,
+		easterMonday,
+		{Name: "King's Day", Aliases: []string{"koningsdag"}, Public: true, date: kingsDay},
+		Fixed("Liberation Day", time.May, 5).Also("bevrijdingsdag"),
+		ascension,
+		whitMonday,
+		christmas,
+		Fixed("Second Day of Christmas", time.December, 26),
+	},
+	"US": {
+		newYear,
+		Nth("Martin Luther King Jr. Day", time.January, time.Monday, 3).Also("mlk day", "martin luther king day"),
+		Nth("Presidents' Day", time.February, time.Monday, 3).Also("washingtons birthday"),
+		Nth("Memorial Day", time.May, time.Monday, -1),
+		Fixed("Juneteenth", time.June, 19).Since(2021),
+		Fixed("Independence Day", time.July, 4).Also("fourth of july", "4th of july", "july 4th", "july 4"),
+		Nth("Labor Day", time.September, time.Monday, 1).Also("labour day"),
+		Nth("Columbus Day", time.October, time.Monday, 2).Also("indigenous peoples day"),
+		Fixed("Veterans Day", time.November, 11),
+		Nth("Thanksgiving", time.November, time.Thursday, 4).Also("thanksgiving day"),
+		christmas,
+	},
+}
+
+// observances are well known days that are looked up if the country doesn't have its own
+var observances = []Rule{
+	Fixed("Valentine's Day", time.February, 14).Observance(),
+	Fixed("Saint Patrick's Day", time.March, 17).Also("st patricks day").Observance(),
+	Easter("Easter Sunday", 0).Also("easter").Observance(),
+	Nth("Mother's Day", time.May, time.Sunday, 2).Observance(),
+	Nth("Father's Day", time.June, time.Sunday, 3).Observance(),
+	Fixed("Halloween", time.October, 31).Observance(),
+	Fixed("Christmas Eve", time.December, 24).Observance(),
+	Fixed("New Year's Eve", time.December, 31).Observance(),
+	christmas,
+	newYear,
+}
+
+// stBrigidsDay is the first Monday in February, or February 1st if that is a Friday. From 2023.
+func stBrigidsDay(year int) time.Time {
+	if year < 2023 {
+		return time.Time{}
+	}
+
+	if feb1 := time.Date(year, time.February, 1, 0, 0, 0, 0, time.UTC); feb1.Weekday() == time.Friday {
+		return feb1
+	}
+
+	return Nth("", time.February, time.Monday, 1).Date(year)
+}
+
+// kingsDay is April 27th, or the 26th when the 27th is a Sunday
+func kingsDay(year int) time.Time {
+	d := time.Date(year, time.April, 27, 0, 0, 0, 0, time.UTC)
+	if d.Weekday() == time.Sunday {
+		return d.AddDate(0, 0, -1)
+	}
+	return d
+}
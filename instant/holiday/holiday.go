@@ -0,0 +1,201 @@
+// Package holiday computes public holidays and observances from rules, so no API is needed
+package holiday
+
+import (
+	"sort"
+	"strings"
+	"time"
+)
+
+// Holiday is a holiday on a particular date
+type Holiday struct {
+	Name   string    `json:"name"`
+	Date   time.Time `json:"date"`
+	Public bool      `json:"public"` // false for observances like Halloween that aren't days off
+}
+
+// Rule computes the date of a holiday in a given year
+type Rule struct {
+	Name    string
+	Aliases []string // other names for it, e.g. "xmas"
+	Public  bool
+	date    func(year int) time.Time
+}
+
+// Date is the holiday's date in a year
+func (r Rule) Date(year int) time.Time {
+	return r.date(year)
+}
+
+// Fixed is a holiday on the same day every year, e.g. Christmas
+func Fixed(name string, month time.Month, day int) Rule {
+	return Rule{Name: name, Public: true, date: func(year int) time.Time {
+		return time.Date(year, month, day, 0, 0, 0, 0, time.UTC)
+	}}
+}
+
+// Nth is a holiday on the nth weekday of a month, e.g. Thanksgiving is the 4th Thursday in November.
+// An n of -1 is the last weekday of the month.
+func Nth(name string, month time.Month, weekday time.Weekday, n int) Rule {
+	return Rule{Name: name, Public: true, date: func(year int) time.Time {
+		if n < 0 {
+			last := time.Date(year, month+1, 0, 0, 0, 0, 0, time.UTC)
+			return last.AddDate(0, 0, -int((last.Weekday()-weekday+7)%7))
+		}
+
+		first := time.Date(year, month, 1, 0, 0, 0, 0, time.UTC)
+		return first.AddDate(0, 0, int((weekday-first.Weekday()+7)%7)+7*(n-1))
+	}}
+}
+
+// Before is a holiday on the last weekday before a date, e.g. Victoria Day is the Monday before May 25
+func Before(name string, month time.Month, day int, weekday time.Weekday) Rule {
+	return Rule{Name: name, Public: true, date: func(year int) time.Time {
+		d := time.Date(year, month, day, 0, 0, 0, 0, time.UTC).AddDate(0, 0, -1)
+		return d.AddDate(0, 0, -int((d.Weekday()-weekday+7)%7))
+	}}
+}
+
+// Easter is a holiday a number of days after (or before, if negative) Western Easter Sunday
+func Easter(name string, days int) Rule {
+	return Rule{Name: name, Public: true, date: func(year int) time.Time {
+		return EasterSunday(year).AddDate(0, 0, days)
+	}}
+}
+
+// EasterSunday is the date of Western Easter, by the anonymous Gregorian algorithm
+func EasterSunday(year int) time.Time {
+	a := year % 19
+	b, c := year/100, year%100
+	d, e := b/4, b%4
+	f := (b + 8) / 25
+	g := (b - f + 1) / 3
+	h := (19*a + b - d - g + 15) % 30
+	i, k := c/4, c%4
+	l := (32 + 2*e + 2*i - h - k) % 7
+	m := (a + 11*h + 22*l) / 451
+	month := (h + l - 7*m + 114) / 31
+	day := (h+l-7*m+114)%31 + 1
+
+	return time.Date(year, time.Month(month), day, 0, 0, 0, 0, time.UTC)
+}
+
+// Also adds other names for a holiday
+func (r Rule) Also(aliases ...string) Rule {
+	r.Aliases = append(append([]string{}, r.Aliases...), aliases...) // don't share the backing array
+	return r
+}
+
+// Observance marks a holiday that isn't a day off, e.g. Valentine's Day
+func (r Rule) Observance() Rule {
+	r.Public = false
+	return r
+}
+
+// Since is a holiday that was first held in a year, e.g. Juneteenth in the US from 2021
+func (r Rule) Since(first int) Rule {
+	date := r.date
+	r.date = func(year int) time.Time {
+		if year < first {
+			return time.Time{}
+		}
+		return date(year)
+	}
+	return r
+}
+
+// Holidays are a country's public holidays in a year, in date order
+func Holidays(country string, year int) ([]Holiday, bool) {
+	rules, ok := countries[strings.ToUpper(country)]
+	if !ok {
+		return nil, false
+	}
+
+	holidays := []Holiday{}
+	for _, r := range rules {
+		if !r.Public {
+			continue
+		}
+
+		if d := r.Date(year); !d.IsZero() {
+			holidays = append(holidays, Holiday{Name: r.Name, Date: d, Public: true})
+		}
+	}
+
+	sort.SliceStable(holidays, func(i, j int) bool {
+		return holidays[i].Date.Before(holidays[j].Date)
+	})
+
+	return holidays, true
+}
+
+// Find looks up a holiday by name, preferring the country's own, e.g. Thanksgiving is in
+// October in Canada. It returns the first occurrence on or after from.
+func Find(name, country string, from time.Time) (Holiday, bool) {
+	name = normalize(name)
+	from = time.Date(from.Year(), from.Month(), from.Day(), 0, 0, 0, 0, time.UTC)
+
+	for _, rules := range [][]Rule{countries[strings.ToUpper(country)], observances, all()} {
+		for _, r := range rules {
+			if !r.matches(name) {
+				continue
+			}
+
+			for year := from.Year(); year <= from.Year()+1; year++ {
+				if d := r.Date(year); !d.IsZero() && !d.Before(from) {
+					return Holiday{Name: r.Name, Date: d, Public: r.Public}, true
+				}
+			}
+		}
+	}
+
+	return Holiday{}, false
+}
+
+// Supported is true if we have the holidays for a country
+func Supported(country string) bool {
+	_, ok := countries[strings.ToUpper(country)]
+	return ok
+}
+
+func (r Rule) matches(name string) bool {
+	if normalize(r.Name) == name {
+		return true
+	}
+
+	for _, a := range r.Aliases {
+		if normalize(a) == name {
+			return true
+		}
+	}
+
+	return false
+}
+
+// normalize makes "Valentine's Day" and "valentines day" the same
+func normalize(s string) string {
+	s = strings.ToLower(s)
+	s = strings.NewReplacer("'", "", "’", "", ".", "", "-", " ").Replace(s)
+	return strings.Join(strings.Fields(s), " ")
+}
+
+// all is every country's rules, in a fixed order with the US first
+// since it is what most people mean by e.g. "thanksgiving"
+func all() []Rule {
+	codes := []string{}
+	for c := range countries {
+		codes = append(codes, c)
+	}
+	sort.Slice(codes, func(i, j int) bool {
+		if codes[i] == "US" || codes[j] == "US" {
+			return codes[i] == "US"
+		}
+		return codes[i] < codes[j]
+	})
+
+	rules := []Rule{}
+	for _, c := range codes {
+		rules = append(rules, countries[c]...)
+	}
+	return rules
+}
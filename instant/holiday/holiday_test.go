@@ -0,0 +1,125 @@
+package holiday
+
+import (
+	"reflect"
+	"testing"
+	"time"
+)
+
+func date(year int, month time.Month, day int) time.Time {
+	return time.Date(year, month, day, 0, 0, 0, 0, time.UTC)
+}
+
+func TestEasterSunday(t *testing.T) {
+	for year, want := range map[int]time.Time{
+		2000: date(2000, time.April, 23),
+		2019: date(2019, time.April, 21),
+		2024: date(2024, time.March, 31),
+		2025: date(2025, time.April, 20),
+		2038: date(2038, time.April, 25),
+	} {
+		if got := EasterSunday(year); !got.Equal(want) {
+			t.Fatalf("%d: got %v; want %v", year, got, want)
+		}
+	}
+}
+
+func TestRules(t *testing.T) {
+	for _, c := range []struct {
+		name string
+		rule Rule
+		year int
+		want time.Time
+	}{
+		{"thanksgiving", Nth("", time.November, time.Thursday, 4), 2025, date(2025, time.November, 27)},
+		{"mlk day", Nth("", time.January, time.Monday, 3), 2024, date(2024, time.January, 15)},
+		{"memorial day", Nth("", time.May, time.Monday, -1), 2025, date(2025, time.May, 26)},
+		{"summer bank holiday", Nth("", time.August, time.Monday, -1), 2024, date(2024, time.August, 26)},
+		{"victoria day", Before("", time.May, 25, time.Monday), 2025, date(2025, time.May, 19)},
+		{"victoria day on the 25th", Before("", time.May, 25, time.Monday), 2026, date(2026, time.May, 18)},
+		{"good friday", Easter("", -2), 2025, date(2025, time.April, 18)},
+		{"juneteenth", Fixed("", time.June, 19).Since(2021), 2020, time.Time{}},
+		{"st brigids day", Rule{date: stBrigidsDay}, 2025, date(2025, time.February, 3)},
+		{"st brigids day on friday", Rule{date: stBrigidsDay}, 2030, date(2030, time.February, 1)},
+		{"kings day", Rule{date: kingsDay}, 2025, date(2025, time.April, 26)},
+	} {
+		t.Run(c.name, func(t *testing.T) {
+			if got := c.rule.Date(c.year); !got.Equal(c.want) {
+				t.Fatalf("got %v; want %v", got, c.want)
+			}
+		})
+	}
+}
+
+func TestHolidays(t *testing.T) {
+	got, ok := Holidays("fr", 2025)
+	if !ok {
+		t.Fatal("expected France to be supported")
+	}
+
+	want := []Holiday{
+		{Name: "New Year's Day", Date: date(2025, time.January, 1), Public: true},
+		{Name: "Easter Monday", Date: date(2025, time.April, 21), Public: true},
+		{Name: "Labour Day", Date: date(2025, time.May, 1), Public: true},
+		{Name: "Victory in Europe Day", Date: date(2025, time.May, 8), Public: true},
+		{Name: "Ascension Day", Date: date(2025, time.May, 29), Public: true},
+		{Name: "Whit Monday", Date: date(2025, time.June, 9), Public: true},
+		{Name: "Bastille Day", Date: date(2025, time.July, 14), Public: true},
+		{Name: "Assumption Day", Date: date(2025, time.August, 15), Public: true},
+		{Name: "All Saints' Day", Date: date(2025, time.November, 1), Public: true},
+		{Name: "Armistice Day", Date: date(2025, time.November, 11), Public: true},
+		{Name: "Christmas Day", Date: date(2025, time.December, 25), Public: true},
+	}
+
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("got %+v; want %+v", got, want)
+	}
+
+	// observances are left out
+	got, _ = Holidays("GB", 2025)
+	for _, h := range got {
+		if h.Name == "Mothering Sunday" {
+			t.Fatal("Mothering Sunday is not a public holiday")
+		}
+	}
+
+	if _, ok := Holidays("ZZ", 2025); ok {
+		t.Fatal("expected ZZ to be unsupported")
+	}
+}
+
+func TestFind(t *testing.T) {
+	from := date(2025, time.October, 1)
+
+	for _, c := range []struct {
+		name    string
+		country string
+		want    Holiday
+	}{
+		{"thanksgiving", "US", Holiday{Name: "Thanksgiving", Date: date(2025, time.November, 27), Public: true}},
+		{"thanksgiving", "ca", Holiday{Name: "Thanksgiving", Date: date(2025, time.October, 13), Public: true}},
+		{"thanksgiving", "", Holiday{Name: "Thanksgiving", Date: date(2025, time.November, 27), Public: true}},
+		{"xmas", "DE", Holiday{Name: "Christmas Day", Date: date(2025, time.December, 25), Public: true}},
+		{"Fourth of July", "US", Holiday{Name: "Independence Day", Date: date(2026, time.July, 4), Public: true}},
+		{"mothers day", "US", Holiday{Name: "Mother's Day", Date: date(2026, time.May, 10)}},
+		{"mothers day", "GB", Holiday{Name: "Mothering Sunday", Date: date(2026, time.March, 15)}},
+		{"halloween", "JP", Holiday{Name: "Halloween", Date: date(2025, time.October, 31)}},
+		{"bastille day", "US", Holiday{Name: "Bastille Day", Date: date(2026, time.July, 14), Public: true}},
+		{"new year's day", "US", Holiday{Name: "New Year's Day", Date: date(2026, time.January, 1), Public: true}},
+	} {
+		t.Run(c.name+" "+c.country, func(t *testing.T) {
+			got, ok := Find(c.name, c.country, from)
+			if !ok {
+				t.Fatal("not found")
+			}
+
+			if !reflect.DeepEqual(got, c.want) {
+				t.Fatalf("got %+v; want %+v", got, c.want)
+			}
+		})
+	}
+
+	if _, ok := Find("festivus", "US", from); ok {
+		t.Fatal("expected festivus to not be found")
+	}
+}
@@ -0,0 +1,105 @@
+package instant
+
+import (
+	"fmt"
+	"net/http"
+	"regexp"
+	"strconv"
+
+	"github.com/jivesearch/jivesearch/instant/reference"
+	"golang.org/x/text/language"
+)
+
+// HTTPStatusType is an answer Type
+const HTTPStatusType Type = "http status"
+
+// HTTPStatus is an instant answer
+type HTTPStatus struct {
+	Answer
+}
+
+func (h *HTTPStatus) setQuery(r *http.Request, qv string) Answerer {
+	h.Answer.setQuery(r, qv)
+	return h
+}
+
+func (h *HTTPStatus) setUserAgent(r *http.Request) Answerer {
+	return h
+}
+
+func (h *HTTPStatus) setLanguage(lang language.Tag) Answerer {
+	h.language = lang
+	return h
+}
+
+func (h *HTTPStatus) setType() Answerer {
+	h.Type = HTTPStatusType
+	return h
+}
+
+func (h *HTTPStatus) setRegex() Answerer {
+	triggers := `http|http status|http status code|http code|http error|status code`
+
+	h.regex = append(h.regex, regexp.MustCompile(fmt.Sprintf(`^(?P<trigger>%v) (?P<remainder>[1-5]\d\d)$`, triggers)))
+	h.regex = append(h.regex, regexp.MustCompile(fmt.Sprintf(`^(?P<remainder>[1-5]\d\d) (?P<trigger>%v)$`, triggers)))
+	return h
+}
+
+func (h *HTTPStatus) solve(r *http.Request) Answerer {
+	code, err := strconv.Atoi(h.remainder)
+	if err != nil {
+		h.Err = err
+		return h
+	}
+
+	s, ok := reference.HTTPStatus(code)
+	if !ok {
+		h.Err = fmt.Errorf("unknown http status code %d", code)
+		return h
+	}
+
+	h.Solution = &s
+	return h
+}
+
+func (h *HTTPStatus) tests() []test {
+	teapot, _ := reference.HTTPStatus(418)
+	notFound, _ := reference.HTTPStatus(404)
+	unavailable, _ := reference.HTTPStatus(503)
+
+	tests := []test{}
+
+	for _, c := range []struct {
+		query  string
+		status reference.Status
+	}{
+		{"http 418", teapot},
+		{"404 status code", notFound},
+		{"http status code 503", unavailable},
+	} {
+		s := c.status
+		tests = append(tests, test{
+			query: c.query,
+			expected: []Data{
+				{
+					Type:      HTTPStatusType,
+					Triggered: true,
+					Solution:  &s,
+				},
+			},
+		})
+	}
+
+	return tests
+}
+
+func init() {
+	Register(Registration{
+		Name:     "httpstatus",
+		Trigger:  `"http" and a status code, e.g. "http 418"`,
+		Priority: 480,
+		New: func(i *Instant) Answerer {
+			return &HTTPStatus{}
+		},
+	})
+}
@@ -0,0 +1,136 @@
+package instant
+
+import (
+	"fmt"
+	"net"
+	"net/http"
+	"regexp"
+
+	"github.com/jivesearch/jivesearch/instant/dns"
+	"github.com/jivesearch/jivesearch/instant/location"
+	"github.com/jivesearch/jivesearch/instant/throttle"
+	"golang.org/x/text/language"
+)
+
+// IPType is an answer Type
+const IPType Type = "ip"
+
+// IP is an instant answer
+type IP struct {
+	DNSFetcher dns.Fetcher
+	ASNFetcher location.ASNFetcher
+	Answer
+}
+
+// IPResponse is the reverse DNS and network of an IP Address
+type IPResponse struct {
+	IP        string        `json:"ip"`
+	Hostnames []string      `json:"hostnames,omitempty"`
+	ASN       *location.ASN `json:"asn,omitempty"`
+}
+
+func (i *IP) setQuery(r *http.Request, qv string) Answerer {
+	i.Answer.setQuery(r, qv)
+	return i
+}
+
+func (i *IP) setUserAgent(r *http.Request) Answerer {
+	return i
+}
+
+func (i *IP) setLanguage(lang language.Tag) Answerer {
+	i.language = lang
+	return i
+}
+
+func (i *IP) setType() Answerer {
+	i.Type = IPType
+	return i
+}
+
+func (i *IP) setRegex() Answerer {
+	i.regex = append(i.regex, regexp.MustCompile(`^(?P<trigger>ip|ip address|ip lookup|reverse dns|rdns|asn)(?: of| for)? (?P<remainder>[0-9a-f.:]+)$`))
+	i.regex = append(i.regex, regexp.MustCompile(`^(?P<remainder>[0-9a-f.:]+) (?P<trigger>ip lookup|reverse dns|rdns|asn)$`))
+	return i
+}
+
+func (i *IP) solve(r *http.Request) Answerer {
+	ip := net.ParseIP(i.remainder)
+	if ip == nil {
+		i.Err = fmt.Errorf("invalid ip address %q", i.remainder)
+		return i
+	}
+
+	resp := &IPResponse{IP: ip.String()}
+
+	// an IP without a PTR record is common so only give up if we were rate limited
+	if i.DNSFetcher != nil {
+		hostnames, err := i.DNSFetcher.Reverse(resp.IP)
+		if err == throttle.ErrLimited {
+			i.Err = err
+			return i
+		}
+		resp.Hostnames = hostnames
+	}
+
+	if i.ASNFetcher != nil {
+		if asn, err := i.ASNFetcher.FetchASN(ip); err == nil && asn.Number != 0 {
+			resp.ASN = asn
+		}
+	}
+
+	if len(resp.Hostnames) == 0 && resp.ASN == nil {
+		i.Err = fmt.Errorf("nothing found for %v", resp.IP)
+		return i
+	}
+
+	i.Solution = resp
+	return i
+}
+
+func (i *IP) tests() []test {
+	tests := []test{
+		{
+			query: "ip 8.8.8.8",
+			expected: []Data{
+				{
+					Type:      IPType,
+					Triggered: true,
+					Solution: &IPResponse{
+						IP:        "8.8.8.8",
+						Hostnames: []string{"dns.google"},
+						ASN:       &location.ASN{Number: 15169, Organization: "GOOGLE"},
+					},
+				},
+			},
+		},
+		{
+			query: "2001:4860:4860::8888 reverse dns",
+			expected: []Data{
+				{
+					Type:      IPType,
+					Triggered: true,
+					Solution: &IPResponse{
+						IP:        "2001:4860:4860::8888",
+						Hostnames: []string{"dns.google"},
+						ASN:       &location.ASN{Number: 15169, Organization: "GOOGLE"},
+					},
+				},
+			},
+		},
+	}
+
+	return tests
+}
+
+func init() {
+	Register(Registration{
+		Name:     "ip",
+		Trigger:  `"ip", "reverse dns" or "asn" and an ip address, e.g. "ip 8.8.8.8"`,
+		Priority: 460,
+		External: true,
+		New: func(i *Instant) Answerer {
+			return &IP{DNSFetcher: i.DNSFetcher, ASNFetcher: i.ASNFetcher}
+		},
+	})
+}
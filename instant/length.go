@@ -99,3 +99,14 @@ func (l *Length) tests() []test {
 
 	return tests
 }
+
+func init() {
+	Register(Registration{
+		Name:     "length",
+		Trigger:  `units of length to convert, e.g. "5 feet to meters"`,
+		Priority: 170,
+		New: func(i *Instant) Answerer {
+			return &Length{}
+		},
+	})
+}
@@ -17,6 +17,17 @@ type Fetcher interface {
 	Fetch(ip net.IP) (*City, error)
 }
 
+// ASNFetcher retrieves the network an IP Address belongs to
+type ASNFetcher interface {
+	FetchASN(ip net.IP) (*ASN, error)
+}
+
+// ASN is an autonomous system, e.g. 15169 for Google
+type ASN struct {
+	Number       uint   `json:"number" xml:"number"`
+	Organization string `json:"organization" xml:"organization"`
+}
+
 type xmlMap map[string]string
 type xmlMapEntry struct {
 	XMLName xml.Name
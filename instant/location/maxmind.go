@@ -9,7 +9,8 @@ import (
 // MaxMind is a location data provider
 // For install instructions: https://dev.maxmind.com/geoip/geoipupdate/
 type MaxMind struct {
-	DB string // location of MaxMind database
+	DB    string // location of MaxMind database
+	ASNDB string // location of the MaxMind ASN database, e.g. GeoLite2-ASN.mmdb
 }
 
 var open = func(loc string) (maxMinder, error) {
@@ -73,7 +74,25 @@ func (m *MaxMind) Fetch(ip net.IP) (*City, error) {
 	return city, err
 }
 
+// FetchASN gets the autonomous system of an IP Address
+func (m *MaxMind) FetchASN(ip net.IP) (*ASN, error) {
+	db, err := open(m.ASNDB)
+	if err != nil {
+		return nil, err
+	}
+
+	defer db.Close()
+
+	a, err := db.ASN(ip)
+	if err != nil {
+		return nil, err
+	}
+
+	return &ASN{Number: a.AutonomousSystemNumber, Organization: a.AutonomousSystemOrganization}, nil
+}
+
 type maxMinder interface {
 	Close() error
 	City(ipAddress net.IP) (*geoip2.City, error)
+	ASN(ipAddress net.IP) (*geoip2.ASN, error)
 }
@@ -57,6 +57,23 @@ func TestFetch(t *testing.T) {
 		})
 	}
 }
+func TestFetchASN(t *testing.T) {
+	open = func(loc string) (maxMinder, error) {
+		return &mockMinder{}, nil
+	}
+
+	mm := &MaxMind{}
+	got, err := mm.FetchASN(net.ParseIP("8.8.8.8"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := &ASN{Number: 15169, Organization: "GOOGLE"}
+
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("got %+v, want %+v", got, want)
+	}
+}
 
 type mockMinder struct{}
 
@@ -79,3 +96,14 @@ func (m *mockMinder) City(ipAddress net.IP) (*geoip2.City, error) {
 
 	return c, nil
 }
+
+func (m *mockMinder) ASN(ipAddress net.IP) (*geoip2.ASN, error) {
+	a := &geoip2.ASN{}
+
+	if ipAddress.String() == "8.8.8.8" {
+		a.AutonomousSystemNumber = 15169
+		a.AutonomousSystemOrganization = "GOOGLE"
+	}
+
+	return a, nil
+}
@@ -8,6 +8,7 @@ import (
 	"strings"
 
 	"github.com/jivesearch/jivesearch/instant/location"
+	"github.com/jivesearch/jivesearch/search/intent"
 	"golang.org/x/text/language"
 )
 
@@ -70,7 +71,7 @@ func (m *Maps) solve(r *http.Request) Answerer {
 	// The caller is expected to provide the solution when triggered, preferably in JavaScript
 	// Note: "Boston map" needs directions as we are unable to simply get the Lat/Long
 	// "Boston", on the other hand, gets the Lat/Long from Wikidata and is triggered there.
-	ip := getIPAddress(r)
+	ip := IPAddress(r)
 
 	city, err := m.LocationFetcher.Fetch(ip)
 	if err != nil {
@@ -206,3 +207,16 @@ func (m *Maps) tests() []test {
 
 	return tests
 }
+
+func init() {
+	Register(Registration{
+		Name:     "maps",
+		Trigger:  `"map" or "directions" and a place`,
+		Priority: 180,
+		Intent:   intent.Local,
+		Maps:     true,
+		New: func(i *Instant) Answerer {
+			return &Maps{LocationFetcher: i.LocationFetcher}
+		},
+	})
+}
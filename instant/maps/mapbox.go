@@ -0,0 +1,102 @@
+package maps
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+
+	"golang.org/x/text/language"
+)
+
+// MapBox is a geocoding and directions provider
+type MapBox struct {
+	HTTPClient *http.Client
+	Key        string
+}
+
+// MapBoxProvider is a maps data provider
+const MapBoxProvider provider = "MapBox"
+
+// Geocode finds the places matching a query
+func (m *MapBox) Geocode(q string, lang language.Tag) (*Geocoding, error) {
+	u, err := url.Parse(fmt.Sprintf("https://api.mapbox.com/geocoding/v5/mapbox.places/%v.json", url.PathEscape(q)))
+	if err != nil {
+		return nil, err
+	}
+
+	b, _ := lang.Base()
+
+	v := u.Query()
+	v.Set("access_token", m.Key)
+	v.Set("language", b.String())
+	v.Set("limit", "5")
+	u.RawQuery = v.Encode()
+
+	resp, err := m.HTTPClient.Get(u.String())
+	if err != nil {
+		return nil, err
+	}
+
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("unable to geocode %q: %v", q, resp.Status)
+	}
+
+	gr := &struct {
+		Features []struct {
+			PlaceName string    `json:"place_name"`
+			Center    []float64 `json:"center"`
+			BBox      []float64 `json:"bbox"`
+		} `json:"features"`
+	}{}
+
+	if err := json.NewDecoder(resp.Body).Decode(gr); err != nil {
+		return nil, err
+	}
+
+	g := &Geocoding{
+		Places:   []Place{},
+		Provider: MapBoxProvider,
+	}
+
+	for _, f := range gr.Features {
+		p := Place{
+			Name:       f.PlaceName,
+			Coordinate: lonLat(f.Center),
+		}
+
+		if len(f.BBox) == 4 { // minLon,minLat,maxLon,maxLat
+			p.BoundingBox = &BoundingBox{
+				SouthWest: lonLat(f.BBox[:2]),
+				NorthEast: lonLat(f.BBox[2:]),
+			}
+		}
+
+		g.Places = append(g.Places, p)
+	}
+
+	return g, nil
+}
+
+// Directions finds the routes between two places
+func (m *MapBox) Directions(profile Profile, from, to Coordinate) (*Directions, error) {
+	u, err := url.Parse(fmt.Sprintf("https://api.mapbox.com/directions/v5/mapbox/%v/%v", profile, coordinates(from, to)))
+	if err != nil {
+		return nil, err
+	}
+
+	v := routeParams()
+	v.Set("access_token", m.Key)
+	u.RawQuery = v.Encode()
+
+	d, err := fetchRoutes(m.HTTPClient, u)
+	if err != nil {
+		return nil, err
+	}
+
+	d.Profile = profile
+	d.Provider = MapBoxProvider
+	return d, nil
+}
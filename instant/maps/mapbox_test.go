@@ -0,0 +1,86 @@
+package maps
+
+import (
+	"net/http"
+	"reflect"
+	"testing"
+
+	"github.com/jarcoal/httpmock"
+	"golang.org/x/text/language"
+)
+
+func TestMapBoxGeocode(t *testing.T) {
+	httpmock.Activate()
+	defer httpmock.DeactivateAndReset()
+
+	raw := `{"type":"FeatureCollection","query":["eiffel","tower"],"features":[{"id":"poi.1","type":"Feature","text":"Eiffel Tower","place_name":"Eiffel Tower, 5 Avenue Anatole France, Paris, 75007, France","center":[2.294481,48.858370],"bbox":[2.29,48.85,2.30,48.86]},{"id":"place.2","type":"Feature","text":"Eiffel Tower","place_name":"Eiffel Tower, Las Vegas, Nevada, United States","center":[-115.1729,36.1125]}]}`
+
+	httpmock.RegisterResponder("GET", "https://api.mapbox.com/geocoding/v5/mapbox.places/eiffel%20tower.json?access_token=key&language=en&limit=5",
+		httpmock.NewStringResponder(200, raw))
+
+	m := &MapBox{HTTPClient: &http.Client{}, Key: "key"}
+
+	got, err := m.Geocode("eiffel tower", language.English)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := &Geocoding{
+		Places: []Place{
+			{
+				Name:       "Eiffel Tower, 5 Avenue Anatole France, Paris, 75007, France",
+				Coordinate: Coordinate{Latitude: 48.858370, Longitude: 2.294481},
+				BoundingBox: &BoundingBox{
+					SouthWest: Coordinate{Latitude: 48.85, Longitude: 2.29},
+					NorthEast: Coordinate{Latitude: 48.86, Longitude: 2.30},
+				},
+			},
+			{
+				Name:       "Eiffel Tower, Las Vegas, Nevada, United States",
+				Coordinate: Coordinate{Latitude: 36.1125, Longitude: -115.1729},
+			},
+		},
+		Provider: MapBoxProvider,
+	}
+
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("got %+v; want %+v", got, want)
+	}
+}
+
+func TestMapBoxDirections(t *testing.T) {
+	httpmock.Activate()
+	defer httpmock.DeactivateAndReset()
+
+	raw := `{"code":"Ok","routes":[{"distance":1520.3,"duration":301.5,"geometry":{"type":"LineString","coordinates":[[2.2945,48.8584],[2.3000,48.8600],[2.3376,48.8606]]},"legs":[{"steps":[{"distance":1000.1,"duration":200.2,"name":"Quai Branly","maneuver":{"instruction":"Head east on Quai Branly","type":"depart","location":[2.2945,48.8584]}},{"distance":520.2,"duration":101.3,"name":"","maneuver":{"instruction":"You have arrived at your destination","type":"arrive","location":[2.3376,48.8606]}}]}]}]}`
+
+	httpmock.RegisterResponder("GET", "https://api.mapbox.com/directions/v5/mapbox/walking/2.2945,48.8584;2.3376,48.8606?access_token=key&geometries=geojson&overview=full&steps=true",
+		httpmock.NewStringResponder(200, raw))
+
+	m := &MapBox{HTTPClient: &http.Client{}, Key: "key"}
+
+	got, err := m.Directions(Walking, Coordinate{48.8584, 2.2945}, Coordinate{48.8606, 2.3376})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := &Directions{
+		Profile: Walking,
+		Routes: []Route{
+			{
+				Distance: 1520.3,
+				Duration: 301.5,
+				Geometry: []Coordinate{{48.8584, 2.2945}, {48.8600, 2.3000}, {48.8606, 2.3376}},
+				Steps: []Step{
+					{Instruction: "Head east on Quai Branly", Distance: 1000.1, Duration: 200.2, Location: Coordinate{48.8584, 2.2945}},
+					{Instruction: "You have arrived at your destination", Distance: 520.2, Duration: 101.3, Location: Coordinate{48.8606, 2.3376}},
+				},
+			},
+		},
+		Provider: MapBoxProvider,
+	}
+
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("got %+v; want %+v", got, want)
+	}
+}
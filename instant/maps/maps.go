@@ -0,0 +1,117 @@
+// Package maps geocodes places and finds directions between them
+package maps
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"golang.org/x/text/language"
+)
+
+// Geocoder finds the places matching a query
+type Geocoder interface {
+	Geocode(q string, lang language.Tag) (*Geocoding, error)
+}
+
+// Router finds routes between two places
+type Router interface {
+	Directions(profile Profile, from, to Coordinate) (*Directions, error)
+}
+
+// Coordinate is the lat/long of a place
+type Coordinate struct {
+	Latitude  float64 `json:"latitude"`
+	Longitude float64 `json:"longitude"`
+}
+
+// ParseCoordinate parses a "lat,long" pair
+func ParseCoordinate(s string) (Coordinate, error) {
+	c := Coordinate{}
+
+	parts := strings.Split(s, ",")
+	if len(parts) != 2 {
+		return c, fmt.Errorf("invalid coordinate %q", s)
+	}
+
+	var err error
+	if c.Latitude, err = strconv.ParseFloat(strings.TrimSpace(parts[0]), 64); err != nil {
+		return c, err
+	}
+
+	if c.Longitude, err = strconv.ParseFloat(strings.TrimSpace(parts[1]), 64); err != nil {
+		return c, err
+	}
+
+	if c.Latitude < -90 || c.Latitude > 90 || c.Longitude < -180 || c.Longitude > 180 {
+		return c, fmt.Errorf("coordinate out of range %q", s)
+	}
+
+	return c, nil
+}
+
+// Geocoding holds the places matching a query, best match first
+type Geocoding struct {
+	Places   []Place  `json:"places"`
+	Provider provider `json:"provider"`
+}
+
+// Place is a geocoded place
+type Place struct {
+	Name string `json:"name"`
+	Coordinate
+	BoundingBox *BoundingBox `json:"bounding_box,omitempty"`
+}
+
+// BoundingBox is the southwest and northeast corners of a place
+type BoundingBox struct {
+	SouthWest Coordinate `json:"south_west"`
+	NorthEast Coordinate `json:"north_east"`
+}
+
+// Profile is a mode of transportation
+type Profile string
+
+// Profiles we support
+const (
+	Driving Profile = "driving"
+	Walking Profile = "walking"
+	Cycling Profile = "cycling"
+)
+
+// ParseProfile defaults to driving
+func ParseProfile(s string) (Profile, error) {
+	switch p := Profile(strings.ToLower(strings.TrimSpace(s))); p {
+	case "":
+		return Driving, nil
+	case Driving, Walking, Cycling:
+		return p, nil
+	}
+
+	return "", fmt.Errorf("unknown profile %q", s)
+}
+
+// Directions are the routes between two places
+type Directions struct {
+	Profile  Profile  `json:"profile"`
+	Routes   []Route  `json:"routes"`
+	Provider provider `json:"provider"`
+}
+
+// Route is a single way to get there
+type Route struct {
+	Distance float64      `json:"distance"` // meters
+	Duration float64      `json:"duration"` // seconds
+	Geometry []Coordinate `json:"geometry"`
+	Steps    []Step       `json:"steps"`
+}
+
+// Step is a single maneuver along a route
+type Step struct {
+	Instruction string     `json:"instruction"`
+	Distance    float64    `json:"distance"` // meters
+	Duration    float64    `json:"duration"` // seconds
+	Location    Coordinate `json:"location"`
+}
+
+type provider string
@@ -0,0 +1,52 @@
+package maps
+
+import "testing"
+
+func TestParseCoordinate(t *testing.T) {
+	for _, c := range []struct {
+		s    string
+		want Coordinate
+		err  bool
+	}{
+		{"48.8584,2.2945", Coordinate{48.8584, 2.2945}, false},
+		{" -33.8667 , 151.2 ", Coordinate{-33.8667, 151.2}, false},
+		{"paris", Coordinate{}, true},
+		{"1,2,3", Coordinate{}, true},
+		{"91,0", Coordinate{}, true},
+	} {
+		t.Run(c.s, func(t *testing.T) {
+			got, err := ParseCoordinate(c.s)
+			if (err != nil) != c.err {
+				t.Fatalf("got err %v; want err %v", err, c.err)
+			}
+
+			if !c.err && got != c.want {
+				t.Fatalf("got %+v; want %+v", got, c.want)
+			}
+		})
+	}
+}
+
+func TestParseProfile(t *testing.T) {
+	for _, c := range []struct {
+		s    string
+		want Profile
+		err  bool
+	}{
+		{"", Driving, false},
+		{"Walking", Walking, false},
+		{"cycling", Cycling, false},
+		{"flying", "", true},
+	} {
+		t.Run(c.s, func(t *testing.T) {
+			got, err := ParseProfile(c.s)
+			if (err != nil) != c.err {
+				t.Fatalf("got err %v; want err %v", err, c.err)
+			}
+
+			if got != c.want {
+				t.Fatalf("got %q; want %q", got, c.want)
+			}
+		})
+	}
+}
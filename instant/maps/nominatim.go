@@ -0,0 +1,97 @@
+package maps
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"golang.org/x/text/language"
+)
+
+// Nominatim geocodes with OpenStreetMap data.
+// The public server requires an identifying User-Agent and at most 1 request per second.
+type Nominatim struct {
+	HTTPClient *http.Client
+	URL        string // e.g. https://nominatim.openstreetmap.org
+	UserAgent  string
+}
+
+// NominatimProvider is a maps data provider
+const NominatimProvider provider = "Nominatim"
+
+// Geocode finds the places matching a query
+func (n *Nominatim) Geocode(q string, lang language.Tag) (*Geocoding, error) {
+	u, err := url.Parse(strings.TrimSuffix(n.URL, "/") + "/search")
+	if err != nil {
+		return nil, err
+	}
+
+	v := u.Query()
+	v.Set("q", q)
+	v.Set("format", "json")
+	v.Set("limit", "5")
+	v.Set("accept-language", lang.String())
+	u.RawQuery = v.Encode()
+
+	req, err := http.NewRequest("GET", u.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Set("User-Agent", n.UserAgent)
+
+	resp, err := n.HTTPClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("unable to geocode %q: %v", q, resp.Status)
+	}
+
+	results := []struct {
+		DisplayName string   `json:"display_name"`
+		Lat         string   `json:"lat"`
+		Lon         string   `json:"lon"`
+		BoundingBox []string `json:"boundingbox"` // minLat,maxLat,minLon,maxLon
+	}{}
+
+	if err := json.NewDecoder(resp.Body).Decode(&results); err != nil {
+		return nil, err
+	}
+
+	g := &Geocoding{
+		Places:   []Place{},
+		Provider: NominatimProvider,
+	}
+
+	for _, r := range results {
+		p := Place{
+			Name: r.DisplayName,
+		}
+
+		p.Latitude, _ = strconv.ParseFloat(r.Lat, 64)
+		p.Longitude, _ = strconv.ParseFloat(r.Lon, 64)
+
+		if len(r.BoundingBox) == 4 {
+			bb := make([]float64, 4)
+			for i, s := range r.BoundingBox {
+				bb[i], _ = strconv.ParseFloat(s, 64)
+			}
+
+			p.BoundingBox = &BoundingBox{
+				SouthWest: Coordinate{Latitude: bb[0], Longitude: bb[2]},
+				NorthEast: Coordinate{Latitude: bb[1], Longitude: bb[3]},
+			}
+		}
+
+		g.Places = append(g.Places, p)
+	}
+
+	return g, nil
+}
@@ -0,0 +1,45 @@
+package maps
+
+import (
+	"net/http"
+	"reflect"
+	"testing"
+
+	"github.com/jarcoal/httpmock"
+	"golang.org/x/text/language"
+)
+
+func TestNominatimGeocode(t *testing.T) {
+	httpmock.Activate()
+	defer httpmock.DeactivateAndReset()
+
+	raw := `[{"place_id":1,"lat":"48.8582599","lon":"2.2945006","display_name":"Tour Eiffel, Avenue Gustave Eiffel, Paris, France","boundingbox":["48.8574753","48.8590453","2.2933084","2.2956897"]}]`
+
+	httpmock.RegisterResponder("GET", "https://nominatim.openstreetmap.org/search?accept-language=fr&format=json&limit=5&q=tour+eiffel",
+		httpmock.NewStringResponder(200, raw))
+
+	n := &Nominatim{HTTPClient: &http.Client{}, URL: "https://nominatim.openstreetmap.org", UserAgent: "test"}
+
+	got, err := n.Geocode("tour eiffel", language.French)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := &Geocoding{
+		Places: []Place{
+			{
+				Name:       "Tour Eiffel, Avenue Gustave Eiffel, Paris, France",
+				Coordinate: Coordinate{Latitude: 48.8582599, Longitude: 2.2945006},
+				BoundingBox: &BoundingBox{
+					SouthWest: Coordinate{Latitude: 48.8574753, Longitude: 2.2933084},
+					NorthEast: Coordinate{Latitude: 48.8590453, Longitude: 2.2956897},
+				},
+			},
+		},
+		Provider: NominatimProvider,
+	}
+
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("got %+v; want %+v", got, want)
+	}
+}
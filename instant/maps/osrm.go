@@ -0,0 +1,147 @@
+package maps
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+// OSRM is the Open Source Routing Machine.
+// Their demo server only has the driving profile so you will likely want to run your own.
+type OSRM struct {
+	HTTPClient *http.Client
+	URL        string // e.g. https://router.project-osrm.org
+}
+
+// OSRMProvider is a maps data provider
+const OSRMProvider provider = "OSRM"
+
+// Directions finds the routes between two places
+func (o *OSRM) Directions(profile Profile, from, to Coordinate) (*Directions, error) {
+	u, err := url.Parse(fmt.Sprintf("%v/route/v1/%v/%v", strings.TrimSuffix(o.URL, "/"), profile, coordinates(from, to)))
+	if err != nil {
+		return nil, err
+	}
+
+	u.RawQuery = routeParams().Encode()
+
+	d, err := fetchRoutes(o.HTTPClient, u)
+	if err != nil {
+		return nil, err
+	}
+
+	d.Profile = profile
+	d.Provider = OSRMProvider
+	return d, nil
+}
+
+// routeResponse is the format of OSRM's route service, which MapBox shares
+type routeResponse struct {
+	Code    string `json:"code"`
+	Message string `json:"message"`
+	Routes  []struct {
+		Distance float64 `json:"distance"`
+		Duration float64 `json:"duration"`
+		Geometry struct {
+			Coordinates [][]float64 `json:"coordinates"`
+		} `json:"geometry"`
+		Legs []struct {
+			Steps []struct {
+				Distance float64 `json:"distance"`
+				Duration float64 `json:"duration"`
+				Name     string  `json:"name"`
+				Maneuver struct {
+					Instruction string    `json:"instruction"` // MapBox only
+					Type        string    `json:"type"`
+					Modifier    string    `json:"modifier"`
+					Location    []float64 `json:"location"`
+				} `json:"maneuver"`
+			} `json:"steps"`
+		} `json:"legs"`
+	} `json:"routes"`
+}
+
+func routeParams() url.Values {
+	q := url.Values{}
+	q.Set("geometries", "geojson")
+	q.Set("overview", "full")
+	q.Set("steps", "true")
+	return q
+}
+
+// coordinates are in long,lat order
+func coordinates(from, to Coordinate) string {
+	return fmt.Sprintf("%v,%v;%v,%v", from.Longitude, from.Latitude, to.Longitude, to.Latitude)
+}
+
+// lonLat converts a GeoJSON position
+func lonLat(p []float64) Coordinate {
+	if len(p) < 2 {
+		return Coordinate{}
+	}
+	return Coordinate{Latitude: p[1], Longitude: p[0]}
+}
+
+func fetchRoutes(client *http.Client, u *url.URL) (*Directions, error) {
+	resp, err := client.Get(u.String())
+	if err != nil {
+		return nil, err
+	}
+
+	defer resp.Body.Close()
+
+	rr := &routeResponse{}
+	if err := json.NewDecoder(resp.Body).Decode(rr); err != nil {
+		return nil, err
+	}
+
+	if rr.Code != "Ok" {
+		return nil, fmt.Errorf("unable to find directions: %v %v", rr.Code, rr.Message)
+	}
+
+	d := &Directions{
+		Routes: []Route{},
+	}
+
+	for _, r := range rr.Routes {
+		route := Route{
+			Distance: r.Distance,
+			Duration: r.Duration,
+			Geometry: []Coordinate{},
+			Steps:    []Step{},
+		}
+
+		for _, p := range r.Geometry.Coordinates {
+			route.Geometry = append(route.Geometry, lonLat(p))
+		}
+
+		for _, leg := range r.Legs {
+			for _, s := range leg.Steps {
+				instruction := s.Maneuver.Instruction
+				if instruction == "" {
+					instruction = strings.Join(strings.Fields(fmt.Sprintf("%v %v %v", s.Maneuver.Type, s.Maneuver.Modifier, onto(s.Name))), " ")
+				}
+
+				route.Steps = append(route.Steps, Step{
+					Instruction: instruction,
+					Distance:    s.Distance,
+					Duration:    s.Duration,
+					Location:    lonLat(s.Maneuver.Location),
+				})
+			}
+		}
+
+		d.Routes = append(d.Routes, route)
+	}
+
+	return d, nil
+}
+
+func onto(name string) string {
+	if name == "" {
+		return ""
+	}
+	return "onto " + name
+}
@@ -0,0 +1,53 @@
+package maps
+
+import (
+	"net/http"
+	"reflect"
+	"testing"
+
+	"github.com/jarcoal/httpmock"
+)
+
+func TestOSRMDirections(t *testing.T) {
+	httpmock.Activate()
+	defer httpmock.DeactivateAndReset()
+
+	raw := `{"code":"Ok","routes":[{"distance":1520.3,"duration":301.5,"geometry":{"type":"LineString","coordinates":[[2.2945,48.8584],[2.3376,48.8606]]},"legs":[{"steps":[{"distance":1000.1,"duration":200.2,"name":"Quai Branly","maneuver":{"type":"depart","location":[2.2945,48.8584]}},{"distance":520.2,"duration":101.3,"name":"Rue de Rivoli","maneuver":{"type":"turn","modifier":"left","location":[2.3000,48.8600]}},{"distance":0,"duration":0,"name":"","maneuver":{"type":"arrive","location":[2.3376,48.8606]}}]}]}]}`
+
+	httpmock.RegisterResponder("GET", "https://router.project-osrm.org/route/v1/driving/2.2945,48.8584;2.3376,48.8606?geometries=geojson&overview=full&steps=true",
+		httpmock.NewStringResponder(200, raw))
+	httpmock.RegisterResponder("GET", "https://router.project-osrm.org/route/v1/driving/0,0;2.3376,48.8606?geometries=geojson&overview=full&steps=true",
+		httpmock.NewStringResponder(400, `{"code":"NoRoute","message":"Impossible route between points"}`))
+
+	o := &OSRM{HTTPClient: &http.Client{}, URL: "https://router.project-osrm.org/"}
+
+	got, err := o.Directions(Driving, Coordinate{48.8584, 2.2945}, Coordinate{48.8606, 2.3376})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := &Directions{
+		Profile: Driving,
+		Routes: []Route{
+			{
+				Distance: 1520.3,
+				Duration: 301.5,
+				Geometry: []Coordinate{{48.8584, 2.2945}, {48.8606, 2.3376}},
+				Steps: []Step{
+					{Instruction: "depart onto Quai Branly", Distance: 1000.1, Duration: 200.2, Location: Coordinate{48.8584, 2.2945}},
+					{Instruction: "turn left onto Rue de Rivoli", Distance: 520.2, Duration: 101.3, Location: Coordinate{48.86, 2.3}},
+					{Instruction: "arrive", Location: Coordinate{48.8606, 2.3376}},
+				},
+			},
+		},
+		Provider: OSRMProvider,
+	}
+
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("got %+v; want %+v", got, want)
+	}
+
+	if _, err := o.Directions(Driving, Coordinate{}, Coordinate{48.8606, 2.3376}); err == nil {
+		t.Fatal("expected an error for an impossible route")
+	}
+}
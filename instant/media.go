@@ -0,0 +1,132 @@
+package instant
+
+import (
+	"fmt"
+	"net/http"
+	"regexp"
+	"strings"
+
+	"github.com/jivesearch/jivesearch/instant/media"
+	"github.com/jivesearch/jivesearch/search/intent"
+	"golang.org/x/text/language"
+)
+
+// MediaType is an answer Type
+const MediaType Type = "media"
+
+// Media is an instant answer for movies and TV shows
+type Media struct {
+	Fetcher media.Fetcher
+	Answer
+}
+
+func (m *Media) setQuery(r *http.Request, qv string) Answerer {
+	m.Answer.setQuery(r, qv)
+	return m
+}
+
+func (m *Media) setUserAgent(r *http.Request) Answerer {
+	return m
+}
+
+func (m *Media) setLanguage(lang language.Tag) Answerer {
+	m.language = lang
+	return m
+}
+
+func (m *Media) setType() Answerer {
+	m.Type = MediaType
+	return m
+}
+
+func (m *Media) setRegex() Answerer {
+	triggers := []string{
+		"cast", "actors", "seasons", "episodes", "runtime", "imdb rating",
+		"movie", "film", "tv show", "tv series",
+	}
+
+	t := strings.Join(triggers, "|")
+	m.regex = append(m.regex, regexp.MustCompile(fmt.Sprintf(`^(?P<remainder>.+?) (?P<trigger>%s)$`, t)))
+	m.regex = append(m.regex, regexp.MustCompile(`^(?P<trigger>cast of|who is in|who stars in|how many seasons of|how many seasons does) (?P<remainder>.+?)(?: have)?$`))
+	return m
+}
+
+func (m *Media) solve(r *http.Request) Answerer {
+	t, err := m.Fetcher.Fetch(m.remainder)
+	if err != nil {
+		m.Err = err
+		return m
+	}
+
+	m.Solution = t
+	return m
+}
+
+func (m *Media) tests() []test {
+	inception := &media.Title{
+		Name:     "Inception",
+		Kind:     media.Movie,
+		Year:     "2010",
+		Overview: "Cobb steals secrets from the subconscious.",
+		Poster:   "https://image.tmdb.org/t/p/w342/inception.jpg",
+		Genres:   []string{"Action", "Science Fiction"},
+		Rating:   8.4,
+		Votes:    35000,
+		Runtime:  148,
+		Cast:     []media.Role{{Actor: "Leonardo DiCaprio", Character: "Cobb"}},
+		Provider: media.TMDBProvider,
+	}
+
+	breakingBad := &media.Title{
+		Name:     "Breaking Bad",
+		Kind:     media.Series,
+		Year:     "2008–2013",
+		Overview: "A chemistry teacher turns to crime.",
+		Genres:   []string{"Drama"},
+		Rating:   8.9,
+		Votes:    12000,
+		Runtime:  45,
+		Seasons:  5,
+		Episodes: 62,
+		Cast:     []media.Role{{Actor: "Bryan Cranston", Character: "Walter White"}},
+		Provider: media.TMDBProvider,
+	}
+
+	tests := []test{}
+
+	for _, c := range []struct {
+		query string
+		title *media.Title
+	}{
+		{"inception cast", inception},
+		{"cast of inception", inception},
+		{"breaking bad seasons", breakingBad},
+		{"how many seasons does breaking bad have", breakingBad},
+	} {
+		tests = append(tests, test{
+			query: c.query,
+			expected: []Data{
+				{
+					Type:      MediaType,
+					Triggered: true,
+					Solution:  c.title,
+				},
+			},
+		})
+	}
+
+	return tests
+}
+
+func init() {
+	Register(Registration{
+		Name:     "media",
+		Trigger:  `a movie or TV show and "cast", "seasons" or "runtime", e.g. "inception cast"`,
+		Priority: 520,
+		Intent:   intent.Informational,
+		External: true,
+		New: func(i *Instant) Answerer {
+			return &Media{Fetcher: i.MediaFetcher}
+		},
+	})
+}
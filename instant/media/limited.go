@@ -0,0 +1,25 @@
+package media
+
+import (
+	"strings"
+
+	"github.com/jivesearch/jivesearch/instant/throttle"
+)
+
+// Limited caches and rate limits another Fetcher's lookups
+type Limited struct {
+	Fetcher
+	*throttle.Throttle
+}
+
+// Fetch looks up a movie or TV show by its title
+func (l *Limited) Fetch(title string) (*Title, error) {
+	v, err := l.Do(strings.ToLower(title), func() (interface{}, error) {
+		return l.Fetcher.Fetch(title)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return v.(*Title), nil
+}
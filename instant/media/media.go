@@ -0,0 +1,52 @@
+// Package media fetches movies and TV shows
+package media
+
+import (
+	"errors"
+)
+
+// Fetcher looks up a movie or TV show by its title
+type Fetcher interface {
+	Fetch(title string) (*Title, error)
+}
+
+// Provider is a source of movie and TV data
+type Provider string
+
+// Kind is whether a Title is a movie or a TV show
+type Kind string
+
+// Kinds of titles
+const (
+	Movie  Kind = "movie"
+	Series Kind = "series"
+)
+
+// ErrNotFound indicates there is no movie or show by that title
+var ErrNotFound = errors.New("title not found")
+
+// Title is a movie or TV show
+type Title struct {
+	Name     string   `json:"name"`
+	Kind     Kind     `json:"kind"`
+	Year     string   `json:"year"` // "2010", or "2008–2013" for a show that has ended
+	Overview string   `json:"overview"`
+	Poster   string   `json:"poster,omitempty"` // url of the poster at its original location, to go through our image proxy
+	Genres   []string `json:"genres"`
+	Rating   float64  `json:"rating"` // out of 10
+	Votes    int      `json:"votes"`
+	Runtime  int      `json:"runtime,omitempty"` // in minutes, per episode for a show
+	Seasons  int      `json:"seasons,omitempty"`
+	Episodes int      `json:"episodes,omitempty"`
+	Cast     []Role   `json:"cast"`
+	Provider Provider `json:"provider"`
+}
+
+// Role is an actor and who they play
+type Role struct {
+	Actor     string `json:"actor"`
+	Character string `json:"character,omitempty"`
+}
+
+// maxCast is how many of the top billed actors we keep
+const maxCast = 8
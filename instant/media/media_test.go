@@ -0,0 +1,44 @@
+package media
+
+import (
+	"testing"
+	"time"
+
+	"github.com/jivesearch/jivesearch/instant/throttle"
+)
+
+type mockFetcher struct {
+	fetches int
+}
+
+func (m *mockFetcher) Fetch(title string) (*Title, error) {
+	m.fetches++
+	return &Title{Name: title}, nil
+}
+
+func TestLimited(t *testing.T) {
+	m := &mockFetcher{}
+	l := &Limited{
+		Fetcher:  m,
+		Throttle: &throttle.Throttle{Rate: 1, Burst: 1, TTL: time.Minute},
+	}
+
+	for _, title := range []string{"Inception", "inception"} {
+		got, err := l.Fetch(title)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if got.Name != "Inception" {
+			t.Fatalf("got %q; want the cached Inception", got.Name)
+		}
+	}
+
+	if m.fetches != 1 {
+		t.Fatalf("got %d fetches; want 1", m.fetches)
+	}
+
+	if _, err := l.Fetch("breaking bad"); err != throttle.ErrLimited {
+		t.Fatalf("got %v; want %v", err, throttle.ErrLimited)
+	}
+}
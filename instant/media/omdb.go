@@ -0,0 +1,107 @@
+package media
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+)
+
+// OMDb retrieves titles from the Open Movie Database
+type OMDb struct {
+	HTTPClient *http.Client
+	Key        string
+}
+
+// OMDbProvider is a movie and TV provider
+var OMDbProvider Provider = "OMDb"
+
+type omdbResponse struct {
+	Title        string `json:"Title"`
+	Year         string `json:"Year"`
+	Runtime      string `json:"Runtime"`
+	Genre        string `json:"Genre"`
+	Actors       string `json:"Actors"`
+	Plot         string `json:"Plot"`
+	Poster       string `json:"Poster"`
+	IMDBRating   string `json:"imdbRating"`
+	IMDBVotes    string `json:"imdbVotes"`
+	Type         string `json:"Type"`
+	TotalSeasons string `json:"totalSeasons"`
+	Response     string `json:"Response"`
+	Error        string `json:"Error"`
+}
+
+// Fetch gets the title that best matches. OMDb only lists the top few actors and not who they play.
+func (o *OMDb) Fetch(title string) (*Title, error) {
+	u := fmt.Sprintf("https://www.omdbapi.com/?apikey=%v&t=%v", o.Key, url.QueryEscape(title))
+
+	resp, err := o.HTTPClient.Get(u)
+	if err != nil {
+		return nil, err
+	}
+
+	defer resp.Body.Close()
+
+	r := &omdbResponse{}
+	if err := json.NewDecoder(resp.Body).Decode(r); err != nil {
+		return nil, err
+	}
+
+	if r.Response != "True" {
+		if strings.HasSuffix(r.Error, "not found!") {
+			return nil, ErrNotFound
+		}
+		return nil, fmt.Errorf("omdb: %v", r.Error)
+	}
+
+	return r.title(), nil
+}
+
+func (r *omdbResponse) title() *Title {
+	t := &Title{
+		Name:     r.Title,
+		Kind:     Movie,
+		Year:     strings.TrimSuffix(r.Year, "–"), // shows still running end in a dash
+		Overview: na(r.Plot),
+		Genres:   split(r.Genre),
+		Cast:     []Role{},
+		Provider: OMDbProvider,
+	}
+
+	if r.Type == "series" {
+		t.Kind = Series
+		t.Seasons, _ = strconv.Atoi(r.TotalSeasons)
+	}
+
+	t.Poster = na(r.Poster)
+	t.Rating, _ = strconv.ParseFloat(r.IMDBRating, 64)
+	t.Votes, _ = strconv.Atoi(strings.Replace(r.IMDBVotes, ",", "", -1))
+	t.Runtime, _ = strconv.Atoi(strings.TrimSuffix(r.Runtime, " min"))
+
+	for _, a := range split(r.Actors) {
+		t.Cast = append(t.Cast, Role{Actor: a})
+	}
+
+	return t
+}
+
+// na blanks OMDb's placeholder for a missing value
+func na(s string) string {
+	if s == "N/A" {
+		return ""
+	}
+	return s
+}
+
+func split(s string) []string {
+	l := []string{}
+	for _, v := range strings.Split(na(s), ",") {
+		if v = strings.TrimSpace(v); v != "" {
+			l = append(l, v)
+		}
+	}
+	return l
+}
@@ -0,0 +1,80 @@
+package media
+
+import (
+	"net/http"
+	"reflect"
+	"testing"
+
+	"github.com/jarcoal/httpmock"
+)
+
+func TestOMDbFetch(t *testing.T) {
+	httpmock.Activate()
+	defer httpmock.DeactivateAndReset()
+
+	for _, tt := range []struct {
+		title string
+		u     string
+		resp  string
+		want  *Title
+		err   error
+	}{
+		{
+			title: "inception",
+			u:     "https://www.omdbapi.com/?apikey=key&t=inception",
+			resp:  `{"Title":"Inception","Year":"2010","Rated":"PG-13","Runtime":"148 min","Genre":"Action, Adventure, Sci-Fi","Actors":"Leonardo DiCaprio, Joseph Gordon-Levitt, Elliot Page","Plot":"A thief who steals corporate secrets.","Poster":"https://m.media-amazon.com/images/inception.jpg","imdbRating":"8.8","imdbVotes":"2,512,302","Type":"movie","Response":"True"}`,
+			want: &Title{
+				Name:     "Inception",
+				Kind:     Movie,
+				Year:     "2010",
+				Overview: "A thief who steals corporate secrets.",
+				Poster:   "https://m.media-amazon.com/images/inception.jpg",
+				Genres:   []string{"Action", "Adventure", "Sci-Fi"},
+				Rating:   8.8,
+				Votes:    2512302,
+				Runtime:  148,
+				Cast: []Role{
+					{Actor: "Leonardo DiCaprio"}, {Actor: "Joseph Gordon-Levitt"}, {Actor: "Elliot Page"},
+				},
+				Provider: OMDbProvider,
+			},
+		},
+		{
+			title: "breaking bad",
+			u:     "https://www.omdbapi.com/?apikey=key&t=breaking+bad",
+			resp:  `{"Title":"Breaking Bad","Year":"2008–2013","Runtime":"49 min","Genre":"Crime, Drama","Actors":"Bryan Cranston","Plot":"N/A","Poster":"N/A","imdbRating":"9.5","imdbVotes":"2,000,000","Type":"series","totalSeasons":"5","Response":"True"}`,
+			want: &Title{
+				Name:     "Breaking Bad",
+				Kind:     Series,
+				Year:     "2008–2013",
+				Genres:   []string{"Crime", "Drama"},
+				Rating:   9.5,
+				Votes:    2000000,
+				Runtime:  49,
+				Seasons:  5,
+				Cast:     []Role{{Actor: "Bryan Cranston"}},
+				Provider: OMDbProvider,
+			},
+		},
+		{
+			title: "asdfgh",
+			u:     "https://www.omdbapi.com/?apikey=key&t=asdfgh",
+			resp:  `{"Response":"False","Error":"Movie not found!"}`,
+			err:   ErrNotFound,
+		},
+	} {
+		t.Run(tt.title, func(t *testing.T) {
+			httpmock.RegisterResponder("GET", tt.u, httpmock.NewStringResponder(200, tt.resp))
+
+			o := &OMDb{HTTPClient: &http.Client{}, Key: "key"}
+			got, err := o.Fetch(tt.title)
+			if err != tt.err {
+				t.Fatalf("got %v; want %v", err, tt.err)
+			}
+
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Fatalf("got %+v; want %+v", got, tt.want)
+			}
+		})
+	}
+}
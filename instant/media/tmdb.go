@@ -0,0 +1,150 @@
+package media
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+// TMDB retrieves titles from The Movie Database
+type TMDB struct {
+	HTTPClient *http.Client
+	Key        string
+}
+
+// TMDBProvider is a movie and TV provider
+var TMDBProvider Provider = "The Movie Database"
+
+const tmdbAPI = "https://api.themoviedb.org/3"
+
+type tmdbSearch struct {
+	Results []struct {
+		ID        int    `json:"id"`
+		MediaType string `json:"media_type"`
+	} `json:"results"`
+}
+
+type tmdbDetails struct {
+	Title            string  `json:"title"` // movies
+	Name             string  `json:"name"`  // shows
+	ReleaseDate      string  `json:"release_date"`
+	FirstAirDate     string  `json:"first_air_date"`
+	LastAirDate      string  `json:"last_air_date"`
+	InProduction     bool    `json:"in_production"`
+	Overview         string  `json:"overview"`
+	PosterPath       string  `json:"poster_path"`
+	VoteAverage      float64 `json:"vote_average"`
+	VoteCount        int     `json:"vote_count"`
+	Runtime          int     `json:"runtime"`
+	EpisodeRunTime   []int   `json:"episode_run_time"`
+	NumberOfSeasons  int     `json:"number_of_seasons"`
+	NumberOfEpisodes int     `json:"number_of_episodes"`
+	Genres           []struct {
+		Name string `json:"name"`
+	} `json:"genres"`
+	Credits struct {
+		Cast []struct {
+			Name      string `json:"name"`
+			Character string `json:"character"`
+		} `json:"cast"`
+	} `json:"credits"`
+}
+
+// Fetch searches for a title and gets the details and cast of the best match
+func (t *TMDB) Fetch(title string) (*Title, error) {
+	s := &tmdbSearch{}
+	u := fmt.Sprintf("%v/search/multi?api_key=%v&query=%v", tmdbAPI, t.Key, url.QueryEscape(title))
+	if err := t.get(u, s); err != nil {
+		return nil, err
+	}
+
+	for _, r := range s.Results {
+		var kind Kind
+
+		switch r.MediaType {
+		case "movie":
+			kind = Movie
+		case "tv":
+			kind = Series
+		default: // people
+			continue
+		}
+
+		d := &tmdbDetails{}
+		u = fmt.Sprintf("%v/%v/%d?api_key=%v&append_to_response=credits", tmdbAPI, r.MediaType, r.ID, t.Key)
+		if err := t.get(u, d); err != nil {
+			return nil, err
+		}
+
+		return d.title(kind), nil
+	}
+
+	return nil, ErrNotFound
+}
+
+func (t *TMDB) get(u string, v interface{}) error {
+	resp, err := t.HTTPClient.Get(u)
+	if err != nil {
+		return err
+	}
+
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("tmdb returned %v", resp.Status)
+	}
+
+	return json.NewDecoder(resp.Body).Decode(v)
+}
+
+func (d *tmdbDetails) title(kind Kind) *Title {
+	t := &Title{
+		Name:     d.Title,
+		Kind:     kind,
+		Year:     year(d.ReleaseDate),
+		Overview: d.Overview,
+		Genres:   []string{},
+		Rating:   d.VoteAverage,
+		Votes:    d.VoteCount,
+		Runtime:  d.Runtime,
+		Cast:     []Role{},
+		Provider: TMDBProvider,
+	}
+
+	if kind == Series {
+		t.Name = d.Name
+		t.Year = year(d.FirstAirDate)
+		if !d.InProduction && year(d.LastAirDate) != t.Year {
+			t.Year += "–" + year(d.LastAirDate)
+		}
+		if len(d.EpisodeRunTime) > 0 {
+			t.Runtime = d.EpisodeRunTime[0]
+		}
+		t.Seasons = d.NumberOfSeasons
+		t.Episodes = d.NumberOfEpisodes
+	}
+
+	if d.PosterPath != "" {
+		t.Poster = "https://image.tmdb.org/t/p/w342" + d.PosterPath
+	}
+
+	for _, g := range d.Genres {
+		t.Genres = append(t.Genres, g.Name)
+	}
+
+	for i, c := range d.Credits.Cast {
+		if i == maxCast {
+			break
+		}
+		t.Cast = append(t.Cast, Role{Actor: c.Name, Character: c.Character})
+	}
+
+	return t
+}
+
+// year of a "2006-01-02" date
+func year(date string) string {
+	return strings.SplitN(date, "-", 2)[0]
+}
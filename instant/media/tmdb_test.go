@@ -0,0 +1,98 @@
+package media
+
+import (
+	"net/http"
+	"reflect"
+	"testing"
+
+	"github.com/jarcoal/httpmock"
+)
+
+func TestTMDBFetch(t *testing.T) {
+	httpmock.Activate()
+	defer httpmock.DeactivateAndReset()
+
+	for _, tt := range []struct {
+		title     string
+		searchURL string
+		search    string
+		detailURL string
+		detail    string
+		want      *Title
+	}{
+		{
+			title:     "inception",
+			searchURL: "https://api.themoviedb.org/3/search/multi?api_key=key&query=inception",
+			search:    `{"page":1,"results":[{"id":27205,"media_type":"movie","title":"Inception"},{"id":64956,"media_type":"tv","name":"Inception: The Cobol Job"}]}`,
+			detailURL: "https://api.themoviedb.org/3/movie/27205?api_key=key&append_to_response=credits",
+			detail:    `{"id":27205,"title":"Inception","release_date":"2010-07-15","overview":"Cobb steals secrets from the subconscious.","poster_path":"/inception.jpg","vote_average":8.4,"vote_count":35000,"runtime":148,"genres":[{"id":28,"name":"Action"},{"id":878,"name":"Science Fiction"}],"credits":{"cast":[{"name":"Leonardo DiCaprio","character":"Cobb"},{"name":"Joseph Gordon-Levitt","character":"Arthur"}]}}`,
+			want: &Title{
+				Name:     "Inception",
+				Kind:     Movie,
+				Year:     "2010",
+				Overview: "Cobb steals secrets from the subconscious.",
+				Poster:   "https://image.tmdb.org/t/p/w342/inception.jpg",
+				Genres:   []string{"Action", "Science Fiction"},
+				Rating:   8.4,
+				Votes:    35000,
+				Runtime:  148,
+				Cast: []Role{
+					{Actor: "Leonardo DiCaprio", Character: "Cobb"},
+					{Actor: "Joseph Gordon-Levitt", Character: "Arthur"},
+				},
+				Provider: TMDBProvider,
+			},
+		},
+		{
+			title:     "breaking bad",
+			searchURL: "https://api.themoviedb.org/3/search/multi?api_key=key&query=breaking+bad",
+			search:    `{"page":1,"results":[{"id":17419,"media_type":"person","name":"Bryan Cranston"},{"id":1396,"media_type":"tv","name":"Breaking Bad"}]}`,
+			detailURL: "https://api.themoviedb.org/3/tv/1396?api_key=key&append_to_response=credits",
+			detail:    `{"id":1396,"name":"Breaking Bad","first_air_date":"2008-01-20","last_air_date":"2013-09-29","in_production":false,"overview":"A chemistry teacher turns to crime.","poster_path":"","vote_average":8.9,"vote_count":12000,"episode_run_time":[45,47],"number_of_seasons":5,"number_of_episodes":62,"genres":[{"id":18,"name":"Drama"}],"credits":{"cast":[{"name":"Bryan Cranston","character":"Walter White"}]}}`,
+			want: &Title{
+				Name:     "Breaking Bad",
+				Kind:     Series,
+				Year:     "2008–2013",
+				Overview: "A chemistry teacher turns to crime.",
+				Genres:   []string{"Drama"},
+				Rating:   8.9,
+				Votes:    12000,
+				Runtime:  45,
+				Seasons:  5,
+				Episodes: 62,
+				Cast: []Role{
+					{Actor: "Bryan Cranston", Character: "Walter White"},
+				},
+				Provider: TMDBProvider,
+			},
+		},
+	} {
+		t.Run(tt.title, func(t *testing.T) {
+			httpmock.RegisterResponder("GET", tt.searchURL, httpmock.NewStringResponder(200, tt.search))
+			httpmock.RegisterResponder("GET", tt.detailURL, httpmock.NewStringResponder(200, tt.detail))
+
+			tmdb := &TMDB{HTTPClient: &http.Client{}, Key: "key"}
+			got, err := tmdb.Fetch(tt.title)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Fatalf("got %+v; want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestTMDBNotFound(t *testing.T) {
+	httpmock.Activate()
+	defer httpmock.DeactivateAndReset()
+
+	httpmock.RegisterResponder("GET", "https://api.themoviedb.org/3/search/multi?api_key=key&query=asdfgh",
+		httpmock.NewStringResponder(200, `{"page":1,"results":[]}`))
+
+	tmdb := &TMDB{HTTPClient: &http.Client{}, Key: "key"}
+	if _, err := tmdb.Fetch("asdfgh"); err != ErrNotFound {
+		t.Fatalf("got %v; want %v", err, ErrNotFound)
+	}
+}
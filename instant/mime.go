@@ -0,0 +1,98 @@
+package instant
+
+import (
+	"fmt"
+	"net/http"
+	"regexp"
+
+	"github.com/jivesearch/jivesearch/instant/reference"
+	"golang.org/x/text/language"
+)
+
+// MIMEType is an answer Type
+const MIMEType Type = "mime type"
+
+// MIME is an instant answer
+type MIME struct {
+	Answer
+}
+
+func (m *MIME) setQuery(r *http.Request, qv string) Answerer {
+	m.Answer.setQuery(r, qv)
+	return m
+}
+
+func (m *MIME) setUserAgent(r *http.Request) Answerer {
+	return m
+}
+
+func (m *MIME) setLanguage(lang language.Tag) Answerer {
+	m.language = lang
+	return m
+}
+
+func (m *MIME) setType() Answerer {
+	m.Type = MIMEType
+	return m
+}
+
+func (m *MIME) setRegex() Answerer {
+	triggers := `mime|mime type|mimetype|content type|media type`
+
+	m.regex = append(m.regex, regexp.MustCompile(fmt.Sprintf(`^(?P<trigger>%v)(?: of| for)? (?P<remainder>[a-z0-9.+/-]+)$`, triggers)))
+	m.regex = append(m.regex, regexp.MustCompile(fmt.Sprintf(`^(?P<remainder>[a-z0-9.+/-]+) (?P<trigger>%v)$`, triggers)))
+	return m
+}
+
+func (m *MIME) solve(r *http.Request) Answerer {
+	t, ok := reference.FindMIMEType(m.remainder)
+	if !ok {
+		m.Err = fmt.Errorf("unknown mime type %q", m.remainder)
+		return m
+	}
+
+	m.Solution = &t
+	return m
+}
+
+func (m *MIME) tests() []test {
+	json, _ := reference.FindMIMEType("json")
+	png, _ := reference.FindMIMEType("png")
+	pdf, _ := reference.FindMIMEType("pdf")
+
+	tests := []test{}
+
+	for _, c := range []struct {
+		query string
+		mime  reference.MIMEType
+	}{
+		{"mime type json", json},
+		{".png mime type", png},
+		{"content type for application/pdf", pdf},
+	} {
+		t := c.mime
+		tests = append(tests, test{
+			query: c.query,
+			expected: []Data{
+				{
+					Type:      MIMEType,
+					Triggered: true,
+					Solution:  &t,
+				},
+			},
+		})
+	}
+
+	return tests
+}
+
+func init() {
+	Register(Registration{
+		Name:     "mime",
+		Trigger:  `"mime type" and a file extension, e.g. "mime type json"`,
+		Priority: 500,
+		New: func(i *Instant) Answerer {
+			return &MIME{}
+		},
+	})
+}
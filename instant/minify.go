@@ -77,3 +77,14 @@ func (m *Minify) tests() []test {
 
 	return tests
 }
+
+func init() {
+	Register(Registration{
+		Name:     "minify",
+		Trigger:  `"minify" or "prettify"`,
+		Priority: 190,
+		New: func(i *Instant) Answerer {
+			return &Minify{}
+		},
+	})
+}
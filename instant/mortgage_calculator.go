@@ -2,10 +2,13 @@ package instant
 
 import (
 	"fmt"
+	"math"
 	"net/http"
 	"regexp"
+	"strconv"
 	"strings"
 
+	"github.com/jivesearch/jivesearch/search/intent"
 	"golang.org/x/text/language"
 )
 
@@ -17,6 +20,19 @@ type MortgageCalculator struct {
 	Answer
 }
 
+// MortgageResponse is the monthly payment on a loan and what it costs in all
+type MortgageResponse struct {
+	Amount        float64 `json:"amount"`
+	Rate          float64 `json:"rate"`
+	Years         int     `json:"years"`
+	Payment       float64 `json:"payment"`
+	TotalPaid     float64 `json:"total_paid"`
+	TotalInterest float64 `json:"total_interest"`
+}
+
+// the amount in the widget for a bare "mortgage calculator"
+const defaultMortgageAmount = 100000
+
 func (c *MortgageCalculator) setQuery(req *http.Request, q string) Answerer {
 	c.Answer.setQuery(req, q)
 	return c
@@ -39,26 +55,131 @@ func (c *MortgageCalculator) setType() Answerer {
 func (c *MortgageCalculator) setRegex() Answerer {
 	t := strings.Join([]string{"mortgage calculator", "calculate mortgage", "mortgage", "mortgage payments"}, "|")
 	c.regex = append(c.regex, regexp.MustCompile(fmt.Sprintf(`^(?P<trigger>%s)$`, t)))
+
+	// e.g. "mortgage 300000 at 6% 30 years" or "$300,000 mortgage at 6% over 30 years"
+	amt := `\$?(?P<amount>\d[\d,]*(?:\.\d+)?)`
+	terms := ` (?:at|@) (?P<rate>\d+(?:\.\d+)?) ?%(?: interest)?(?: for| over)? (?P<years>\d+) ?(?:years?|yrs?)$`
+	c.regex = append(c.regex, regexp.MustCompile(fmt.Sprintf(`^(?P<trigger>%s)(?: on| for)? %v%v`, t, amt, terms)))
+	c.regex = append(c.regex, regexp.MustCompile(fmt.Sprintf(`^%v (?P<trigger>mortgage)%v`, amt, terms)))
 	return c
 }
 
 func (c *MortgageCalculator) solve(r *http.Request) Answerer {
-	// The caller is expected to provide the solution when triggered, preferably in JavaScript
+	resp := &MortgageResponse{Amount: defaultMortgageAmount}
+
+	if a := c.remainderM["amount"]; a != "" {
+		var err error
+		if resp.Amount, err = strconv.ParseFloat(strings.Replace(a, ",", "", -1), 64); err != nil {
+			c.Err = err
+			return c
+		}
+		if resp.Rate, err = strconv.ParseFloat(c.remainderM["rate"], 64); err != nil {
+			c.Err = err
+			return c
+		}
+		if resp.Years, err = strconv.Atoi(c.remainderM["years"]); err != nil || resp.Years == 0 {
+			c.Err = fmt.Errorf("invalid mortgage period %q", c.remainderM["years"])
+			return c
+		}
+
+		payment := mortgagePayment(resp.Amount, resp.Rate, resp.Years)
+		resp.Payment = round2(payment)
+		resp.TotalPaid = round2(payment * float64(resp.Years*12))
+		resp.TotalInterest = round2(payment*float64(resp.Years*12) - resp.Amount)
+		c.Solution = resp
+	}
+
+	// a bare "mortgage calculator" only gets the widget
+	c.Widget = &Widget{
+		Formula: "mortgage",
+		Inputs: []WidgetField{
+			{Name: "amount", Label: "Mortgage Amount", Value: resp.Amount, Format: "currency"},
+			{Name: "rate", Label: "Interest Rate (%)", Value: resp.Rate},
+			{Name: "years", Label: "Period (years)", Value: float64(resp.Years)},
+		},
+		Outputs: []WidgetField{
+			{Name: "payment", Label: "Monthly Payment", Value: resp.Payment, Format: "currency"},
+			{Name: "total_paid", Label: "Total Paid", Value: resp.TotalPaid, Format: "currency"},
+			{Name: "total_interest", Label: "Total Interest", Value: resp.TotalInterest, Format: "currency"},
+		},
+	}
+
 	return c
 }
 
+// mortgagePayment is the monthly payment on a fixed rate loan
+func mortgagePayment(amount, rate float64, years int) float64 {
+	n := float64(years * 12)
+	r := rate / 1200
+	if r == 0 {
+		return amount / n
+	}
+
+	f := math.Pow(1+r, n)
+	return amount * r * f / (f - 1)
+}
+
 func (c *MortgageCalculator) tests() []test {
-	d := Data{
-		Type:      MortageCalculatorType,
-		Triggered: true,
+	widget := func(m *MortgageResponse) *Widget {
+		return &Widget{
+			Formula: "mortgage",
+			Inputs: []WidgetField{
+				{Name: "amount", Label: "Mortgage Amount", Value: m.Amount, Format: "currency"},
+				{Name: "rate", Label: "Interest Rate (%)", Value: m.Rate},
+				{Name: "years", Label: "Period (years)", Value: float64(m.Years)},
+			},
+			Outputs: []WidgetField{
+				{Name: "payment", Label: "Monthly Payment", Value: m.Payment, Format: "currency"},
+				{Name: "total_paid", Label: "Total Paid", Value: m.TotalPaid, Format: "currency"},
+				{Name: "total_interest", Label: "Total Interest", Value: m.TotalInterest, Format: "currency"},
+			},
+		}
 	}
 
 	tests := []test{
 		{
-			query:    "mortgage calculator",
-			expected: []Data{d},
+			query: "mortgage calculator",
+			expected: []Data{
+				{
+					Type:      MortageCalculatorType,
+					Triggered: true,
+					Widget:    widget(&MortgageResponse{Amount: defaultMortgageAmount}),
+				},
+			},
 		},
 	}
 
+	for _, tt := range []struct {
+		query string
+		resp  *MortgageResponse
+	}{
+		{"mortgage 300000 at 6% 30 years", &MortgageResponse{Amount: 300000, Rate: 6, Years: 30, Payment: 1798.65, TotalPaid: 647514.57, TotalInterest: 347514.57}},
+		{"$250,000 mortgage at 4.5% over 15 yrs", &MortgageResponse{Amount: 250000, Rate: 4.5, Years: 15, Payment: 1912.48, TotalPaid: 344246.98, TotalInterest: 94246.98}},
+	} {
+		tests = append(tests, test{
+			query: tt.query,
+			expected: []Data{
+				{
+					Type:      MortageCalculatorType,
+					Triggered: true,
+					Solution:  tt.resp,
+					Widget:    widget(tt.resp),
+				},
+			},
+		})
+	}
+
 	return tests
 }
+
+func init() {
+	Register(Registration{
+		Name:     "mortgage_calculator",
+		Trigger:  `"mortgage calculator" or a loan, e.g. "mortgage 300000 at 6% 30 years"`,
+		Priority: 200,
+		Intent:   intent.Transactional,
+		New: func(i *Instant) Answerer {
+			return &MortgageCalculator{}
+		},
+	})
+}
@@ -0,0 +1,102 @@
+package instant
+
+import (
+	"fmt"
+	"net"
+	"net/http"
+	"regexp"
+	"strings"
+
+	"golang.org/x/text/language"
+)
+
+// MyIPType is an answer Type
+const MyIPType Type = "my ip"
+
+// MyIP is an instant answer
+type MyIP struct {
+	Answer
+}
+
+func (m *MyIP) setQuery(r *http.Request, qv string) Answerer {
+	m.Answer.setQuery(r, qv)
+	return m
+}
+
+func (m *MyIP) setUserAgent(r *http.Request) Answerer {
+	return m
+}
+
+func (m *MyIP) setLanguage(lang language.Tag) Answerer {
+	m.language = lang
+	return m
+}
+
+func (m *MyIP) setType() Answerer {
+	m.Type = MyIPType
+	return m
+}
+
+func (m *MyIP) setRegex() Answerer {
+	triggers := []string{
+		"my ip", "my ip address",
+		"what's my ip", "what's my ip address",
+		"whats my ip", "whats my ip address",
+		"what is my ip", "what is my ip address",
+		"ip address", "my public ip",
+	}
+
+	t := strings.Join(triggers, "|")
+	m.regex = append(m.regex, regexp.MustCompile(fmt.Sprintf(`^(?P<trigger>%s)$`, t)))
+
+	return m
+}
+
+func (m *MyIP) solve(r *http.Request) Answerer {
+	ip := IPAddress(r)
+	if ip == nil {
+		m.Err = fmt.Errorf("no ip address for the request")
+		return m
+	}
+
+	m.Solution = ip.String()
+	return m
+}
+
+func (m *MyIP) tests() []test {
+	tests := []test{}
+
+	for _, c := range []struct {
+		query string
+		ip    string
+	}{
+		{"my ip", "8.8.8.8"},
+		{"what's my ip address?", "161.59.224.138"},
+		{"what is my ip", "2001:4860:4860::8888"},
+	} {
+		tests = append(tests, test{
+			query: c.query,
+			ip:    net.ParseIP(c.ip),
+			expected: []Data{
+				{
+					Type:      MyIPType,
+					Triggered: true,
+					Solution:  c.ip,
+				},
+			},
+		})
+	}
+
+	return tests
+}
+
+func init() {
+	Register(Registration{
+		Name:     "myip",
+		Trigger:  `"my ip" or "what is my ip address"`,
+		Priority: 450,
+		New: func(i *Instant) Answerer {
+			return &MyIP{}
+		},
+	})
+}
@@ -0,0 +1,86 @@
+package instant
+
+import (
+	"fmt"
+	"net/http"
+	"regexp"
+	"strings"
+
+	"github.com/jivesearch/jivesearch/search"
+	"golang.org/x/text/language"
+)
+
+// OperatorsType is an answer Type
+const OperatorsType Type = "operators"
+
+// Operators is an instant answer that lists our search operators
+type Operators struct {
+	Answer
+}
+
+func (o *Operators) setQuery(r *http.Request, qv string) Answerer {
+	o.Answer.setQuery(r, qv)
+	return o
+}
+
+func (o *Operators) setUserAgent(r *http.Request) Answerer {
+	return o
+}
+
+func (o *Operators) setLanguage(lang language.Tag) Answerer {
+	o.language = lang
+	return o
+}
+
+func (o *Operators) setType() Answerer {
+	o.Type = OperatorsType
+	return o
+}
+
+func (o *Operators) setRegex() Answerer {
+	triggers := []string{
+		"search operators", "search operator", "operators",
+		"search syntax", "query syntax",
+		"advanced search", "search help", "help",
+	}
+
+	t := strings.Join(triggers, "|")
+	o.regex = append(o.regex, regexp.MustCompile(fmt.Sprintf(`^(?P<trigger>%s)$`, t)))
+
+	return o
+}
+
+func (o *Operators) solve(r *http.Request) Answerer {
+	o.Solution = search.Operators
+	return o
+}
+
+func (o *Operators) tests() []test {
+	tests := []test{}
+
+	for _, q := range []string{"search operators", "Help", "advanced search?"} {
+		tests = append(tests, test{
+			query: q,
+			expected: []Data{
+				{
+					Type:      OperatorsType,
+					Triggered: true,
+					Solution:  search.Operators,
+				},
+			},
+		})
+	}
+
+	return tests
+}
+
+func init() {
+	Register(Registration{
+		Name:     "operators",
+		Trigger:  `"search operators" or "help"`,
+		Priority: 455,
+		New: func(i *Instant) Answerer {
+			return &Operators{}
+		},
+	})
+}
@@ -0,0 +1,194 @@
+package instant
+
+import (
+	"net/http"
+	"regexp"
+	"time"
+
+	"github.com/jivesearch/jivesearch/instant/packages"
+	"github.com/jivesearch/jivesearch/search/intent"
+	"golang.org/x/text/language"
+)
+
+// PackageType is an answer Type
+const PackageType Type = "package"
+
+// Package is an instant answer for the latest version of a library
+type Package struct {
+	packages.Fetcher
+	Answer
+}
+
+// packageRegistries are the words for each registry
+var packageRegistries = map[string]packages.Registry{
+	"pypi":   packages.PyPI,
+	"pip":    packages.PyPI,
+	"python": packages.PyPI,
+	"npm":    packages.NPM,
+	"node":   packages.NPM,
+	"crate":  packages.Crates,
+	"crates": packages.Crates,
+	"cargo":  packages.Crates,
+	"rust":   packages.Crates,
+	"go":     packages.Go,
+	"golang": packages.Go,
+}
+
+// goModule is a module path, which starts with a domain, e.g. github.com/jivesearch/jivesearch
+var goModule = regexp.MustCompile(`^[\w-]+(?:\.[\w-]+)+/[\w./-]+$`)
+
+func (p *Package) setQuery(r *http.Request, qv string) Answerer {
+	p.Answer.setQuery(r, qv)
+	return p
+}
+
+func (p *Package) setUserAgent(r *http.Request) Answerer {
+	return p
+}
+
+func (p *Package) setLanguage(lang language.Tag) Answerer {
+	p.language = lang
+	return p
+}
+
+func (p *Package) setType() Answerer {
+	p.Type = PackageType
+	return p
+}
+
+func (p *Package) setRegex() Answerer {
+	name := `[\w@][\w.@/-]*`
+	registry := `pypi|pip|python|npm|node|crates?|cargo|rust|golang|go`
+	latest := `(?:latest|current|newest)`
+	suffix := `(?: package| module| crate| library)?`
+
+	// "npm react version", "pypi django" and "go module github.com/spf13/viper"
+	p.regex = append(p.regex, regexp.MustCompile(`^(?:`+latest+` )?(?P<trigger>pypi|pip|npm|crates?|cargo) (?:package |crate )?(?P<remainder>`+name+`)(?: `+latest+`)?(?: version| release)?$`))
+	p.regex = append(p.regex, regexp.MustCompile(`^(?:`+latest+` )?(?P<trigger>golang|go) (?:module |package )?(?P<remainder>[\w.-]+\.[\w-]+/[\w./-]+)(?: `+latest+`)?(?: version| release)?$`))
+
+	// "react npm version" and "django python latest version"
+	p.regex = append(p.regex, regexp.MustCompile(`^(?:`+latest+` )?(?P<remainder>`+name+`) (?P<trigger>`+registry+`)`+suffix+`(?: `+latest+`)? (?:version|release)$`))
+
+	// "latest version of django" and "django latest version", where we have to find the registry
+	p.regex = append(p.regex, regexp.MustCompile(`^(?:what is )?(?:the )?(?P<trigger>`+latest+`) (?:version|release) of (?:the )?(?P<remainder>`+name+`)`+suffix+`(?: (?:in|on|for) (?P<registry>`+registry+`))?$`))
+	p.regex = append(p.regex, regexp.MustCompile(`^(?P<remainder>`+name+`)`+suffix+` (?P<trigger>`+latest+`) (?:version|release)$`))
+	return p
+}
+
+func (p *Package) solve(r *http.Request) Answerer {
+	reg, ok := packageRegistries[p.triggerWord]
+	if w := p.remainderM["registry"]; w != "" {
+		reg, ok = packageRegistries[w]
+	}
+
+	if !ok {
+		reg = packages.Any
+	}
+
+	name := p.remainder
+	switch {
+	case goModule.MatchString(name):
+		reg = packages.Go
+	case reg == packages.Go:
+		p.Err = packages.ErrNotFound // the proxy only knows full module paths
+		return p
+	case name == "latest" || name == "current" || name == "newest":
+		p.Err = packages.ErrNotFound
+		return p
+	}
+
+	pkg, err := p.Fetch(reg, name)
+	if err != nil {
+		p.Err = err
+		return p
+	}
+
+	p.Solution = pkg
+	return p
+}
+
+func (p *Package) tests() []test {
+	django := &packages.Package{
+		Name:        "Django",
+		Version:     "4.2.4",
+		Released:    time.Date(2023, 8, 1, 17, 30, 22, 0, time.UTC),
+		License:     "BSD-3-Clause",
+		Description: "A high-level Python web framework that encourages rapid development and clean, pragmatic design.",
+		URL:         "https://pypi.org/project/Django/",
+		Registry:    packages.PyPI,
+	}
+
+	react := &packages.Package{
+		Name:        "react",
+		Version:     "18.2.0",
+		Released:    time.Date(2022, 6, 14, 19, 46, 38, 0, time.UTC),
+		License:     "MIT",
+		Description: "React is a JavaScript library for building user interfaces.",
+		URL:         "https://www.npmjs.com/package/react",
+		Registry:    packages.NPM,
+	}
+
+	serde := &packages.Package{
+		Name:        "serde",
+		Version:     "1.0.183",
+		Released:    time.Date(2023, 8, 6, 16, 4, 31, 0, time.UTC),
+		License:     "MIT OR Apache-2.0",
+		Description: "A generic serialization/deserialization framework",
+		URL:         "https://crates.io/crates/serde",
+		Registry:    packages.Crates,
+	}
+
+	viper := &packages.Package{
+		Name:     "github.com/spf13/viper",
+		Version:  "v1.16.0",
+		Released: time.Date(2023, 6, 6, 11, 0, 0, 0, time.UTC),
+		URL:      "https://pkg.go.dev/github.com/spf13/viper",
+		Registry: packages.Go,
+	}
+
+	tests := []test{}
+
+	for _, c := range []struct {
+		query string
+		pkg   *packages.Package
+	}{
+		{"latest version of django", django},
+		{"what is the latest version of react?", react},
+		{"django latest version", django},
+		{"npm react version", react},
+		{"pypi django", django},
+		{"pip django latest version", django},
+		{"react npm version", react},
+		{"serde crate version", serde},
+		{"latest version of serde for rust", serde},
+		{"cargo serde", serde},
+		{"go module github.com/spf13/viper", viper},
+		{"latest version of github.com/spf13/viper", viper},
+	} {
+		tests = append(tests, test{
+			query: c.query,
+			expected: []Data{
+				{
+					Type:      PackageType,
+					Triggered: true,
+					Solution:  c.pkg,
+				},
+			},
+		})
+	}
+
+	return tests
+}
+
+func init() {
+	Register(Registration{
+		Name:     "package",
+		Trigger:  `the latest version of a PyPI, npm, crates.io or Go package, e.g. "latest version of django" or "npm react version"`,
+		Priority: 368, // before Stack Overflow, which also triggers on "... django"
+		Intent:   intent.Informational,
+		External: true,
+		New: func(i *Instant) Answerer {
+			return &Package{Fetcher: i.PackageFetcher}
+		},
+	})
+}
@@ -0,0 +1,25 @@
+package packages
+
+import (
+	"strings"
+
+	"github.com/jivesearch/jivesearch/instant/throttle"
+)
+
+// Limited caches and rate limits another Fetcher's lookups
+type Limited struct {
+	Fetcher
+	*throttle.Throttle
+}
+
+// Fetch gets the latest release of a package
+func (l *Limited) Fetch(registry Registry, name string) (*Package, error) {
+	v, err := l.Do(string(registry)+":"+strings.ToLower(name), func() (interface{}, error) {
+		return l.Fetcher.Fetch(registry, name)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return v.(*Package), nil
+}
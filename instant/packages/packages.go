@@ -0,0 +1,44 @@
+// Package packages fetches the latest release of a library from PyPI, npm, crates.io and the Go module proxy
+package packages
+
+import (
+	"errors"
+	"time"
+)
+
+// Fetcher fetches the latest release of a package from a registry
+type Fetcher interface {
+	Fetch(registry Registry, name string) (*Package, error)
+}
+
+// Registry is where a package is published
+type Registry string
+
+// Any looks for the package on each of PyPI, npm and crates.io
+const Any Registry = ""
+
+// PyPI is the Python Package Index
+const PyPI Registry = "PyPI"
+
+// NPM is the registry of JavaScript packages
+const NPM Registry = "npm"
+
+// Crates is the registry of Rust crates
+const Crates Registry = "crates.io"
+
+// Go is the Go module proxy
+const Go Registry = "Go"
+
+// ErrNotFound indicates the registry doesn't have the package
+var ErrNotFound = errors.New("package not found")
+
+// Package is the latest release of a package
+type Package struct {
+	Name        string    `json:"name"`
+	Version     string    `json:"version"`
+	Released    time.Time `json:"released"`
+	License     string    `json:"license,omitempty"`
+	Description string    `json:"description,omitempty"`
+	URL         string    `json:"url"`
+	Registry    Registry  `json:"registry"`
+}
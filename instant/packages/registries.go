@@ -0,0 +1,271 @@
+package packages
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+	"unicode"
+)
+
+// Registries fetches packages from the public registry APIs
+type Registries struct {
+	HTTPClient *http.Client
+	UserAgent  string // crates.io refuses requests without one
+}
+
+// Fetch gets the latest release of a package. For Any registry we ask each of them and,
+// as a name is often taken on more than one, go with the package released most recently.
+func (r *Registries) Fetch(registry Registry, name string) (*Package, error) {
+	switch registry {
+	case PyPI:
+		return r.pypi(name)
+	case NPM:
+		return r.npm(name)
+	case Crates:
+		return r.crates(name)
+	case Go:
+		return r.golang(name)
+	case Any:
+		return r.any(name)
+	}
+
+	return nil, fmt.Errorf("unknown registry %q", registry)
+}
+
+func (r *Registries) any(name string) (*Package, error) {
+	fetchers := []func(string) (*Package, error){r.pypi, r.npm, r.crates}
+	pkgs := make([]*Package, len(fetchers))
+	errs := make([]error, len(fetchers))
+
+	var wg sync.WaitGroup
+	for i, fn := range fetchers {
+		wg.Add(1)
+		go func(i int, fn func(string) (*Package, error)) {
+			defer wg.Done()
+			pkgs[i], errs[i] = fn(name)
+		}(i, fn)
+	}
+	wg.Wait()
+
+	var latest *Package
+	for i, p := range pkgs {
+		switch errs[i] {
+		case nil:
+			if latest == nil || p.Released.After(latest.Released) {
+				latest = p
+			}
+		case ErrNotFound:
+		default:
+			return nil, errs[i]
+		}
+	}
+
+	if latest == nil {
+		return nil, ErrNotFound
+	}
+
+	return latest, nil
+}
+
+type pypiResponse struct {
+	Info struct {
+		Name              string   `json:"name"`
+		Version           string   `json:"version"`
+		Summary           string   `json:"summary"`
+		License           string   `json:"license"`
+		LicenseExpression string   `json:"license_expression"`
+		Classifiers       []string `json:"classifiers"`
+	} `json:"info"`
+	URLs []struct {
+		UploadTime time.Time `json:"upload_time_iso_8601"`
+	} `json:"urls"` // the files of the latest version
+}
+
+func (r *Registries) pypi(name string) (*Package, error) {
+	resp := &pypiResponse{}
+	if err := r.get(fmt.Sprintf("https://pypi.org/pypi/%v/json", url.PathEscape(name)), resp); err != nil {
+		return nil, err
+	}
+
+	p := &Package{
+		Name:        resp.Info.Name,
+		Version:     resp.Info.Version,
+		Description: resp.Info.Summary,
+		URL:         fmt.Sprintf("https://pypi.org/project/%v/", resp.Info.Name),
+		Registry:    PyPI,
+	}
+
+	if len(resp.URLs) > 0 {
+		p.Released = resp.URLs[0].UploadTime
+	}
+
+	// the license field is sometimes the whole text of the license
+	switch {
+	case resp.Info.LicenseExpression != "":
+		p.License = resp.Info.LicenseExpression
+	case resp.Info.License != "" && len(resp.Info.License) < 40 && !strings.Contains(resp.Info.License, "\n"):
+		p.License = resp.Info.License
+	default:
+		for _, c := range resp.Info.Classifiers {
+			if strings.HasPrefix(c, "License :: ") {
+				parts := strings.Split(c, " :: ")
+				p.License = parts[len(parts)-1]
+				break
+			}
+		}
+	}
+
+	return p, nil
+}
+
+type npmResponse struct {
+	Name        string               `json:"name"`
+	Description string               `json:"description"`
+	DistTags    map[string]string    `json:"dist-tags"`
+	Time        map[string]time.Time `json:"time"`
+	License     json.RawMessage      `json:"license"` // usually "MIT" but older packages have {"type": "MIT"}
+}
+
+func (r *Registries) npm(name string) (*Package, error) {
+	resp := &npmResponse{}
+	// a scoped package's slash is escaped, e.g. @babel%2Fcore
+	if err := r.get("https://registry.npmjs.org/"+url.PathEscape(name), resp); err != nil {
+		return nil, err
+	}
+
+	latest := resp.DistTags["latest"]
+	if latest == "" {
+		return nil, ErrNotFound // unpublished
+	}
+
+	p := &Package{
+		Name:        resp.Name,
+		Version:     latest,
+		Released:    resp.Time[latest],
+		Description: resp.Description,
+		URL:         "https://www.npmjs.com/package/" + resp.Name,
+		Registry:    NPM,
+	}
+
+	if err := json.Unmarshal(resp.License, &p.License); err != nil {
+		l := struct {
+			Type string `json:"type"`
+		}{}
+		if err := json.Unmarshal(resp.License, &l); err == nil {
+			p.License = l.Type
+		}
+	}
+
+	return p, nil
+}
+
+type cratesResponse struct {
+	Crate struct {
+		Name             string `json:"name"`
+		Description      string `json:"description"`
+		MaxStableVersion string `json:"max_stable_version"`
+		NewestVersion    string `json:"newest_version"`
+	} `json:"crate"`
+	Versions []struct {
+		Num       string    `json:"num"`
+		CreatedAt time.Time `json:"created_at"`
+		License   string    `json:"license"`
+	} `json:"versions"`
+}
+
+func (r *Registries) crates(name string) (*Package, error) {
+	resp := &cratesResponse{}
+	if err := r.get(fmt.Sprintf("https://crates.io/api/v1/crates/%v", url.PathEscape(name)), resp); err != nil {
+		return nil, err
+	}
+
+	p := &Package{
+		Name:        resp.Crate.Name,
+		Version:     resp.Crate.MaxStableVersion,
+		Description: strings.TrimSpace(resp.Crate.Description),
+		URL:         fmt.Sprintf("https://crates.io/crates/%v", resp.Crate.Name),
+		Registry:    Crates,
+	}
+
+	if p.Version == "" { // only pre-releases
+		p.Version = resp.Crate.NewestVersion
+	}
+
+	for _, v := range resp.Versions {
+		if v.Num == p.Version {
+			p.Released, p.License = v.CreatedAt, v.License
+			break
+		}
+	}
+
+	return p, nil
+}
+
+type goResponse struct {
+	Version string    `json:"Version"`
+	Time    time.Time `json:"Time"`
+}
+
+// golang asks the module proxy as pkg.go.dev doesn't have an API. The proxy doesn't know the license.
+func (r *Registries) golang(module string) (*Package, error) {
+	resp := &goResponse{}
+	if err := r.get(fmt.Sprintf("https://proxy.golang.org/%v/@latest", escapeModule(module)), resp); err != nil {
+		return nil, err
+	}
+
+	return &Package{
+		Name:     module,
+		Version:  resp.Version,
+		Released: resp.Time,
+		URL:      "https://pkg.go.dev/" + module,
+		Registry: Go,
+	}, nil
+}
+
+// escapeModule is how the module proxy wants a path: each capital letter is a "!" and the letter lowercased
+// https://go.dev/ref/mod#goproxy-protocol
+func escapeModule(module string) string {
+	var b strings.Builder
+	for _, c := range module {
+		if unicode.IsUpper(c) {
+			b.WriteRune('!')
+			c = unicode.ToLower(c)
+		}
+		b.WriteRune(c)
+	}
+
+	return b.String()
+}
+
+func (r *Registries) get(u string, v interface{}) error {
+	req, err := http.NewRequest("GET", u, nil)
+	if err != nil {
+		return err
+	}
+
+	req.Header.Set("Accept", "application/json")
+	if r.UserAgent != "" {
+		req.Header.Set("User-Agent", r.UserAgent)
+	}
+
+	resp, err := r.HTTPClient.Do(req)
+	if err != nil {
+		return err
+	}
+
+	defer resp.Body.Close()
+
+	switch resp.StatusCode {
+	case http.StatusOK:
+	case http.StatusNotFound, http.StatusGone: // the module proxy says 410 for modules it can't find
+		return ErrNotFound
+	default:
+		return fmt.Errorf("%v returned %v", req.URL.Host, resp.Status)
+	}
+
+	return json.NewDecoder(resp.Body).Decode(v)
+}
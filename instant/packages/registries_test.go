@@ -0,0 +1,154 @@
+package packages
+
+import (
+	"net/http"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/jarcoal/httpmock"
+)
+
+func TestFetch(t *testing.T) {
+	httpmock.Activate()
+	defer httpmock.DeactivateAndReset()
+
+	httpmock.RegisterResponder("GET", "https://pypi.org/pypi/django/json",
+		httpmock.NewStringResponder(200, `{"info":{"name":"Django","version":"4.2.4","summary":"A high-level Python web framework that encourages rapid development and clean, pragmatic design.","license":"BSD-3-Clause","classifiers":["Framework :: Django","License :: OSI Approved :: BSD License"]},"urls":[{"upload_time_iso_8601":"2023-08-01T17:30:22.424871Z"}]}`))
+	httpmock.RegisterResponder("GET", "https://pypi.org/pypi/requests/json",
+		httpmock.NewStringResponder(200, `{"info":{"name":"requests","version":"2.31.0","summary":"Python HTTP for Humans.","license":"Copyright 2019 Kenneth Reitz\n\nLicensed under the Apache License, Version 2.0","classifiers":["License :: OSI Approved :: Apache Software License"]},"urls":[{"upload_time_iso_8601":"2023-05-22T15:12:42.313790Z"}]}`))
+	httpmock.RegisterResponder("GET", "https://registry.npmjs.org/react",
+		httpmock.NewStringResponder(200, `{"name":"react","description":"React is a JavaScript library for building user interfaces.","dist-tags":{"latest":"18.2.0","next":"18.3.0-next-1"},"time":{"18.1.0":"2022-04-26T16:11:03.465Z","18.2.0":"2022-06-14T19:46:38.369Z"},"license":"MIT"}`))
+	httpmock.RegisterResponder("GET", "https://registry.npmjs.org/@babel%2Fcore",
+		httpmock.NewStringResponder(200, `{"name":"@babel/core","description":"Babel compiler core.","dist-tags":{"latest":"7.22.10"},"time":{"7.22.10":"2023-08-07T14:10:02.123Z"},"license":{"type":"MIT"}}`))
+	httpmock.RegisterResponder("GET", "https://crates.io/api/v1/crates/serde",
+		httpmock.NewStringResponder(200, `{"crate":{"name":"serde","description":"A generic serialization/deserialization framework\n","max_stable_version":"1.0.183","newest_version":"1.0.183"},"versions":[{"num":"1.0.183","created_at":"2023-08-06T16:04:31.562412+00:00","license":"MIT OR Apache-2.0"},{"num":"1.0.182","created_at":"2023-08-05T22:33:10.123123+00:00","license":"MIT OR Apache-2.0"}]}`))
+	httpmock.RegisterResponder("GET", "https://proxy.golang.org/github.com/!burnt!sushi/toml/@latest",
+		httpmock.NewStringResponder(200, `{"Version":"v1.3.2","Time":"2023-06-08T06:08:20Z"}`))
+
+	for _, c := range []struct {
+		registry Registry
+		name     string
+		want     *Package
+	}{
+		{
+			PyPI, "django",
+			&Package{
+				Name:        "Django",
+				Version:     "4.2.4",
+				Released:    time.Date(2023, 8, 1, 17, 30, 22, 424871000, time.UTC),
+				License:     "BSD-3-Clause",
+				Description: "A high-level Python web framework that encourages rapid development and clean, pragmatic design.",
+				URL:         "https://pypi.org/project/Django/",
+				Registry:    PyPI,
+			},
+		},
+		{
+			PyPI, "requests",
+			&Package{
+				Name:        "requests",
+				Version:     "2.31.0",
+				Released:    time.Date(2023, 5, 22, 15, 12, 42, 313790000, time.UTC),
+				License:     "Apache Software License",
+				Description: "Python HTTP for Humans.",
+				URL:         "https://pypi.org/project/requests/",
+				Registry:    PyPI,
+			},
+		},
+		{
+			NPM, "react",
+			&Package{
+				Name:        "react",
+				Version:     "18.2.0",
+				Released:    time.Date(2022, 6, 14, 19, 46, 38, 369000000, time.UTC),
+				License:     "MIT",
+				Description: "React is a JavaScript library for building user interfaces.",
+				URL:         "https://www.npmjs.com/package/react",
+				Registry:    NPM,
+			},
+		},
+		{
+			NPM, "@babel/core",
+			&Package{
+				Name:        "@babel/core",
+				Version:     "7.22.10",
+				Released:    time.Date(2023, 8, 7, 14, 10, 2, 123000000, time.UTC),
+				License:     "MIT",
+				Description: "Babel compiler core.",
+				URL:         "https://www.npmjs.com/package/@babel/core",
+				Registry:    NPM,
+			},
+		},
+		{
+			Crates, "serde",
+			&Package{
+				Name:        "serde",
+				Version:     "1.0.183",
+				Released:    time.Date(2023, 8, 6, 16, 4, 31, 562412000, time.UTC),
+				License:     "MIT OR Apache-2.0",
+				Description: "A generic serialization/deserialization framework",
+				URL:         "https://crates.io/crates/serde",
+				Registry:    Crates,
+			},
+		},
+		{
+			Go, "github.com/BurntSushi/toml",
+			&Package{
+				Name:     "github.com/BurntSushi/toml",
+				Version:  "v1.3.2",
+				Released: time.Date(2023, 6, 8, 6, 8, 20, 0, time.UTC),
+				URL:      "https://pkg.go.dev/github.com/BurntSushi/toml",
+				Registry: Go,
+			},
+		},
+	} {
+		t.Run(c.name, func(t *testing.T) {
+			r := &Registries{HTTPClient: &http.Client{}}
+			got, err := r.Fetch(c.registry, c.name)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if !got.Released.Equal(c.want.Released) {
+				t.Fatalf("got released %v; want %v", got.Released, c.want.Released)
+			}
+			got.Released = c.want.Released
+
+			if !reflect.DeepEqual(got, c.want) {
+				t.Fatalf("got %+v; want %+v", got, c.want)
+			}
+		})
+	}
+}
+
+func TestFetchAny(t *testing.T) {
+	httpmock.Activate()
+	defer httpmock.DeactivateAndReset()
+
+	// a name that was squatted long ago on PyPI goes to the npm package that is still released
+	httpmock.RegisterResponder("GET", "https://pypi.org/pypi/react/json",
+		httpmock.NewStringResponder(200, `{"info":{"name":"react","version":"4.3.0","summary":""},"urls":[{"upload_time_iso_8601":"2013-02-09T09:12:07.000000Z"}]}`))
+	httpmock.RegisterResponder("GET", "https://registry.npmjs.org/react",
+		httpmock.NewStringResponder(200, `{"name":"react","dist-tags":{"latest":"18.2.0"},"time":{"18.2.0":"2022-06-14T19:46:38.369Z"},"license":"MIT"}`))
+	httpmock.RegisterResponder("GET", "https://crates.io/api/v1/crates/react",
+		httpmock.NewStringResponder(404, `{"errors":[{"detail":"Not Found"}]}`))
+
+	httpmock.RegisterResponder("GET", "https://pypi.org/pypi/asdfghjk/json", httpmock.NewStringResponder(404, `{"message":"Not Found"}`))
+	httpmock.RegisterResponder("GET", "https://registry.npmjs.org/asdfghjk", httpmock.NewStringResponder(404, `{"error":"Not found"}`))
+	httpmock.RegisterResponder("GET", "https://crates.io/api/v1/crates/asdfghjk", httpmock.NewStringResponder(404, `{"errors":[{"detail":"Not Found"}]}`))
+
+	r := &Registries{HTTPClient: &http.Client{}}
+
+	got, err := r.Fetch(Any, "react")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if got.Registry != NPM || got.Version != "18.2.0" {
+		t.Fatalf("got %+v; want react 18.2.0 from npm", got)
+	}
+
+	if _, err := r.Fetch(Any, "asdfghjk"); err != ErrNotFound {
+		t.Fatalf("got %v; want %v", err, ErrNotFound)
+	}
+}
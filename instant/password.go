@@ -0,0 +1,160 @@
+package instant
+
+import (
+	"crypto/rand"
+	"fmt"
+	"math/big"
+	"net/http"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"golang.org/x/text/language"
+)
+
+// PasswordType is an answer Type
+const PasswordType Type = "password"
+
+// Password is an instant answer
+type Password struct {
+	Answer
+}
+
+const (
+	defaultPasswordLength = 16
+	minPasswordLength     = 8
+	maxPasswordLength     = 128
+)
+
+// passwordClasses are the kinds of characters a password gets at least one of
+var passwordClasses = []string{
+	"abcdefghijklmnopqrstuvwxyz",
+	"ABCDEFGHIJKLMNOPQRSTUVWXYZ",
+	"0123456789",
+	"!@#$%^&*-_=+?",
+}
+
+func (p *Password) setQuery(r *http.Request, qv string) Answerer {
+	p.Answer.setQuery(r, qv)
+	return p
+}
+
+func (p *Password) setUserAgent(r *http.Request) Answerer {
+	return p
+}
+
+func (p *Password) setLanguage(lang language.Tag) Answerer {
+	p.language = lang
+	return p
+}
+
+func (p *Password) setType() Answerer {
+	p.Type = PasswordType
+	return p
+}
+
+func (p *Password) setRegex() Answerer {
+	triggers := []string{
+		"password generator", "generate password", "generate a password", "random password", "strong password",
+	}
+
+	t := strings.Join(triggers, "|")
+	length := `(?P<length>\d+)(?: ?(?:chars?|characters?))?(?: long)?`
+	p.regex = append(p.regex, regexp.MustCompile(fmt.Sprintf(`^(?P<trigger>%s)(?: %s)?$`, t, length)))
+	p.regex = append(p.regex, regexp.MustCompile(fmt.Sprintf(`^%s (?P<trigger>%s)$`, length, t)))
+	return p
+}
+
+// solve generates a password from a cryptographically secure source.
+// It has at least one lowercase letter, uppercase letter, digit and symbol.
+func (p *Password) solve(r *http.Request) Answerer {
+	length := defaultPasswordLength
+	if l, err := strconv.Atoi(p.remainderM["length"]); err == nil {
+		length = l
+	}
+
+	if length < minPasswordLength || length > maxPasswordLength {
+		p.Err = fmt.Errorf("password length must be between %d and %d, not %d", minPasswordLength, maxPasswordLength, length)
+		return p
+	}
+
+	all := strings.Join(passwordClasses, "")
+	pw := make([]byte, length)
+
+	for i := range pw {
+		chars := all
+		if i < len(passwordClasses) {
+			chars = passwordClasses[i]
+		}
+
+		j, err := secureIntn(len(chars))
+		if err != nil {
+			p.Err = err
+			return p
+		}
+		pw[i] = chars[j]
+	}
+
+	// Fisher-Yates, so the guaranteed characters aren't always up front
+	for i := len(pw) - 1; i > 0; i-- {
+		j, err := secureIntn(i + 1)
+		if err != nil {
+			p.Err = err
+			return p
+		}
+		pw[i], pw[j] = pw[j], pw[i]
+	}
+
+	p.Solution = string(pw)
+	return p
+}
+
+// secureIntn is a uniform random int in [0, n)
+func secureIntn(n int) (int, error) {
+	i, err := rand.Int(randReader, big.NewInt(int64(n)))
+	if err != nil {
+		return 0, err
+	}
+	return int(i.Int64()), nil
+}
+
+func (p *Password) tests() []test {
+	tests := []test{}
+
+	// randReader is mocked to all zeros, so each class gives its first character and the
+	// rest are "a"s. The shuffle then moves the first "a" to the end.
+	for _, c := range []struct {
+		q    string
+		want string
+	}{
+		{"password generator", "A0!aaaaaaaaaaaaa"},
+		{"password generator 16 chars", "A0!aaaaaaaaaaaaa"},
+		{"random password 8", "A0!aaaaa"},
+		{"generate a password 10 characters long", "A0!aaaaaaa"},
+		{"20 character strong password", "A0!aaaaaaaaaaaaaaaaa"},
+	} {
+		tests = append(tests, test{
+			query: c.q,
+			expected: []Data{
+				{
+					Type:      PasswordType,
+					Triggered: true,
+					Solution:  c.want,
+				},
+			},
+		})
+	}
+
+	return tests
+}
+
+func init() {
+	Register(Registration{
+		Name:     "password",
+		Trigger:  `"password generator" and optionally a length, e.g. "password generator 16 chars"`,
+		Priority: 420,
+		New: func(i *Instant) Answerer {
+			return &Password{}
+		},
+	})
+}
@@ -0,0 +1,225 @@
+package instant
+
+import (
+	"fmt"
+	"math"
+	"net/http"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"golang.org/x/text/language"
+)
+
+// PercentageType is an answer Type
+const PercentageType Type = "percentage"
+
+// Percentage is an instant answer for the percentage questions the calculator can't parse,
+// e.g. "15 is what percent of 60" or "percent change from 50 to 75"
+type Percentage struct {
+	Answer
+}
+
+// PercentageResponse is the answer in words and as a number
+type PercentageResponse struct {
+	Expression string  `json:"expression"`
+	Result     float64 `json:"result"`
+}
+
+func (p *Percentage) setQuery(r *http.Request, qv string) Answerer {
+	p.Answer.setQuery(r, qv)
+	return p
+}
+
+func (p *Percentage) setUserAgent(r *http.Request) Answerer {
+	return p
+}
+
+func (p *Percentage) setLanguage(lang language.Tag) Answerer {
+	p.language = lang
+	return p
+}
+
+func (p *Percentage) setType() Answerer {
+	p.Type = PercentageType
+	return p
+}
+
+func (p *Percentage) setRegex() Answerer {
+	n := `-?\d[\d,]*(?:\.\d+)?`
+	pct := `(?:percent|percentage|%)`
+
+	p.regex = append(p.regex, regexp.MustCompile(fmt.Sprintf(`^(?P<part>%v) is what %v of (?P<whole>%v)$`, n, pct, n)))
+	p.regex = append(p.regex, regexp.MustCompile(fmt.Sprintf(`^what %v of (?P<whole>%v) is (?P<part>%v)$`, pct, n, n)))
+	p.regex = append(p.regex, regexp.MustCompile(fmt.Sprintf(`^%v (?:change|increase|decrease|difference) (?:from|between) (?P<from>%v) (?:to|and) (?P<to>%v)$`, pct, n, n)))
+	p.regex = append(p.regex, regexp.MustCompile(fmt.Sprintf(`^(?P<part>%v) is (?P<percent>%v) ?%% of what(?: number)?$`, n, n)))
+	return p
+}
+
+func (p *Percentage) solve(r *http.Request) Answerer {
+	v := map[string]float64{}
+	for k, s := range p.remainderM {
+		f, err := strconv.ParseFloat(strings.Replace(s, ",", "", -1), 64)
+		if err != nil {
+			p.Err = err
+			return p
+		}
+		v[k] = f
+	}
+
+	resp := &PercentageResponse{}
+
+	switch {
+	case p.remainderM["from"] != "":
+		if v["from"] == 0 {
+			p.Err = fmt.Errorf("can't take a percent change from 0")
+			return p
+		}
+
+		resp.Result = round2((v["to"] - v["from"]) / math.Abs(v["from"]) * 100)
+		change := "increase"
+		if resp.Result < 0 {
+			change = "decrease"
+		}
+		resp.Expression = fmt.Sprintf("%v to %v is a %v%% %v", trimFloat(v["from"]), trimFloat(v["to"]), trimFloat(math.Abs(resp.Result)), change)
+
+		p.Widget = &Widget{
+			Formula: "percent-change",
+			Inputs: []WidgetField{
+				{Name: "from", Label: "From", Value: v["from"]},
+				{Name: "to", Label: "To", Value: v["to"]},
+			},
+			Outputs: []WidgetField{{Name: "result", Label: "Change", Value: resp.Result, Format: "percent"}},
+		}
+	case p.remainderM["percent"] != "":
+		if v["percent"] == 0 {
+			p.Err = fmt.Errorf("nothing is 0%% of a number")
+			return p
+		}
+
+		resp.Result = round2(v["part"] / (v["percent"] / 100))
+		resp.Expression = fmt.Sprintf("%v is %v%% of %v", trimFloat(v["part"]), trimFloat(v["percent"]), trimFloat(resp.Result))
+
+		p.Widget = &Widget{
+			Formula: "percent-whole",
+			Inputs: []WidgetField{
+				{Name: "part", Label: "Part", Value: v["part"]},
+				{Name: "percent", Label: "Percent (%)", Value: v["percent"]},
+			},
+			Outputs: []WidgetField{{Name: "result", Label: "Whole", Value: resp.Result}},
+		}
+	default:
+		if v["whole"] == 0 {
+			p.Err = fmt.Errorf("can't take a percent of 0")
+			return p
+		}
+
+		resp.Result = round2(v["part"] / v["whole"] * 100)
+		resp.Expression = fmt.Sprintf("%v is %v%% of %v", trimFloat(v["part"]), trimFloat(resp.Result), trimFloat(v["whole"]))
+
+		p.Widget = &Widget{
+			Formula: "percent-of",
+			Inputs: []WidgetField{
+				{Name: "part", Label: "Part", Value: v["part"]},
+				{Name: "whole", Label: "Whole", Value: v["whole"]},
+			},
+			Outputs: []WidgetField{{Name: "result", Label: "Percent", Value: resp.Result, Format: "percent"}},
+		}
+	}
+
+	p.Solution = resp
+	return p
+}
+
+// trimFloat formats a float without an exponent or trailing zeros
+func trimFloat(f float64) string {
+	return strconv.FormatFloat(f, 'f', -1, 64)
+}
+
+func (p *Percentage) tests() []test {
+	return []test{
+		{
+			query: "15 is what percent of 60",
+			expected: []Data{
+				{
+					Type:      PercentageType,
+					Triggered: true,
+					Solution:  &PercentageResponse{Expression: "15 is 25% of 60", Result: 25},
+					Widget: &Widget{
+						Formula: "percent-of",
+						Inputs: []WidgetField{
+							{Name: "part", Label: "Part", Value: 15},
+							{Name: "whole", Label: "Whole", Value: 60},
+						},
+						Outputs: []WidgetField{{Name: "result", Label: "Percent", Value: 25, Format: "percent"}},
+					},
+				},
+			},
+		},
+		{
+			query: "what percentage of 1,200 is 30",
+			expected: []Data{
+				{
+					Type:      PercentageType,
+					Triggered: true,
+					Solution:  &PercentageResponse{Expression: "30 is 2.5% of 1200", Result: 2.5},
+					Widget: &Widget{
+						Formula: "percent-of",
+						Inputs: []WidgetField{
+							{Name: "part", Label: "Part", Value: 30},
+							{Name: "whole", Label: "Whole", Value: 1200},
+						},
+						Outputs: []WidgetField{{Name: "result", Label: "Percent", Value: 2.5, Format: "percent"}},
+					},
+				},
+			},
+		},
+		{
+			query: "percent change from 80 to 60",
+			expected: []Data{
+				{
+					Type:      PercentageType,
+					Triggered: true,
+					Solution:  &PercentageResponse{Expression: "80 to 60 is a 25% decrease", Result: -25},
+					Widget: &Widget{
+						Formula: "percent-change",
+						Inputs: []WidgetField{
+							{Name: "from", Label: "From", Value: 80},
+							{Name: "to", Label: "To", Value: 60},
+						},
+						Outputs: []WidgetField{{Name: "result", Label: "Change", Value: -25, Format: "percent"}},
+					},
+				},
+			},
+		},
+		{
+			query: "15 is 20% of what",
+			expected: []Data{
+				{
+					Type:      PercentageType,
+					Triggered: true,
+					Solution:  &PercentageResponse{Expression: "15 is 20% of 75", Result: 75},
+					Widget: &Widget{
+						Formula: "percent-whole",
+						Inputs: []WidgetField{
+							{Name: "part", Label: "Part", Value: 15},
+							{Name: "percent", Label: "Percent (%)", Value: 20},
+						},
+						Outputs: []WidgetField{{Name: "result", Label: "Whole", Value: 75}},
+					},
+				},
+			},
+		},
+	}
+}
+
+func init() {
+	Register(Registration{
+		Name:     "percentage",
+		Trigger:  `a percentage question, e.g. "15 is what percent of 60" or "percent change from 50 to 75"`,
+		Priority: 580,
+		New: func(i *Instant) Answerer {
+			return &Percentage{}
+		},
+	})
+}
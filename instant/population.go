@@ -7,6 +7,7 @@ import (
 
 	"github.com/jivesearch/jivesearch/instant/econ"
 	pop "github.com/jivesearch/jivesearch/instant/econ/population"
+	"github.com/jivesearch/jivesearch/search/intent"
 	"github.com/pariz/gountries"
 	"golang.org/x/text/language"
 )
@@ -129,3 +130,16 @@ func (p *Population) tests() []test {
 
 	return tests
 }
+
+func init() {
+	Register(Registration{
+		Name:     "population",
+		Trigger:  `"population" and a country`,
+		Priority: 210,
+		Intent:   intent.Informational,
+		External: true,
+		New: func(i *Instant) Answerer {
+			return &Population{PopulationFetcher: i.PopulationFetcher}
+		},
+	})
+}
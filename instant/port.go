@@ -0,0 +1,114 @@
+package instant
+
+import (
+	"fmt"
+	"net/http"
+	"regexp"
+	"strconv"
+
+	"github.com/jivesearch/jivesearch/instant/reference"
+	"golang.org/x/text/language"
+)
+
+// PortType is an answer Type
+const PortType Type = "port"
+
+// Port is an instant answer
+type Port struct {
+	Answer
+}
+
+// PortResponse are the ports matching a number or a service
+type PortResponse struct {
+	Query string           `json:"query"`
+	Ports []reference.Port `json:"ports"`
+}
+
+func (p *Port) setQuery(r *http.Request, qv string) Answerer {
+	p.Answer.setQuery(r, qv)
+	return p
+}
+
+func (p *Port) setUserAgent(r *http.Request) Answerer {
+	return p
+}
+
+func (p *Port) setLanguage(lang language.Tag) Answerer {
+	p.language = lang
+	return p
+}
+
+func (p *Port) setType() Answerer {
+	p.Type = PortType
+	return p
+}
+
+func (p *Port) setRegex() Answerer {
+	p.regex = append(p.regex, regexp.MustCompile(`^(?:tcp |udp )?(?P<trigger>port)(?: number)? (?P<number>\d{1,5})$`))
+	p.regex = append(p.regex, regexp.MustCompile(`^(?P<number>\d{1,5}) (?P<trigger>port)$`))
+	p.regex = append(p.regex, regexp.MustCompile(`^(?:what |which )?(?P<trigger>port)(?: does| is)? (?P<service>[a-z0-9 -]+?)(?: use| on)?$`))
+	p.regex = append(p.regex, regexp.MustCompile(`^(?:default )?(?P<service>[a-z0-9 -]+?)(?: default)? (?P<trigger>port)(?: number)?$`))
+	return p
+}
+
+func (p *Port) solve(r *http.Request) Answerer {
+	resp := &PortResponse{}
+
+	if n, err := strconv.Atoi(p.remainderM["number"]); err == nil {
+		resp.Query = strconv.Itoa(n)
+		resp.Ports = reference.PortsByNumber(n)
+	} else {
+		resp.Query = p.remainderM["service"]
+		resp.Ports = reference.PortsByService(resp.Query)
+	}
+
+	if len(resp.Ports) == 0 {
+		p.Err = fmt.Errorf("no well known port for %q", resp.Query)
+		return p
+	}
+
+	p.Solution = resp
+	return p
+}
+
+func (p *Port) tests() []test {
+	postgres := reference.PortsByNumber(5432)
+	ssh := reference.PortsByNumber(22)
+
+	tests := []test{}
+
+	for _, c := range []struct {
+		query string
+		resp  *PortResponse
+	}{
+		{"port 5432", &PortResponse{Query: "5432", Ports: postgres}},
+		{"5432 port", &PortResponse{Query: "5432", Ports: postgres}},
+		{"postgres port", &PortResponse{Query: "postgres", Ports: postgres}},
+		{"what port does ssh use", &PortResponse{Query: "ssh", Ports: ssh}},
+		{"default ssh port", &PortResponse{Query: "ssh", Ports: ssh}},
+	} {
+		tests = append(tests, test{
+			query: c.query,
+			expected: []Data{
+				{
+					Type:      PortType,
+					Triggered: true,
+					Solution:  c.resp,
+				},
+			},
+		})
+	}
+
+	return tests
+}
+
+func init() {
+	Register(Registration{
+		Name:     "port",
+		Trigger:  `"port" and a number or a service, e.g. "port 5432" or "ssh port"`,
+		Priority: 490,
+		New: func(i *Instant) Answerer {
+			return &Port{}
+		},
+	})
+}
@@ -7,6 +7,7 @@ import (
 	"strconv"
 	"strings"
 
+	"github.com/jivesearch/jivesearch/search/intent"
 	"golang.org/x/text/language"
 )
 
@@ -658,3 +659,15 @@ func presidents(i int) (POTUS, bool) {
 	}
 	return p, found
 }
+
+func init() {
+	Register(Registration{
+		Name:     "potus",
+		Trigger:  `"potus" or "president of the united states"`,
+		Priority: 220,
+		Intent:   intent.Informational,
+		New: func(i *Instant) Answerer {
+			return &Potus{}
+		},
+	})
+}
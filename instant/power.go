@@ -82,3 +82,14 @@ func (p *Power) tests() []test {
 
 	return tests
 }
+
+func init() {
+	Register(Registration{
+		Name:     "power",
+		Trigger:  `units of power to convert, e.g. "100 hp to kw"`,
+		Priority: 230,
+		New: func(i *Instant) Answerer {
+			return &Power{}
+		},
+	})
+}
@@ -179,5 +179,14 @@ func (p *Prime) calculatePrimes(start, end int) []string {
 }
 
 func init() {
+	Register(Registration{
+		Name:     "prime",
+		Trigger:  `"prime numbers between" two numbers`,
+		Priority: 240,
+		New: func(i *Instant) Answerer {
+			return &Prime{}
+		},
+	})
+
 	rePrime = regexp.MustCompile(`^between (-?[0-9]+) and (-?[0-9]+)`)
 }
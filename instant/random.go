@@ -46,6 +46,7 @@ func (r *Random) setRegex() Answerer {
 	}
 
 	t := strings.Join(triggers, "|")
+	r.regex = append(r.regex, regexp.MustCompile(`^(?P<trigger>random number)$`))
 	r.regex = append(r.regex, regexp.MustCompile(fmt.Sprintf(`^(?P<trigger>%s) (?P<remainder>.*)$`, t)))
 	r.regex = append(r.regex, regexp.MustCompile(fmt.Sprintf(`^(?P<remainder>.*) (?P<trigger>%s)$`, t)))
 
@@ -96,10 +97,16 @@ func (r *Random) tests() []test {
 		return sol
 	}
 
+	numbers := []string{}
+	for i := 1; i <= 100; i++ {
+		numbers = append(numbers, strconv.Itoa(i))
+	}
+
 	for _, c := range []struct {
 		q   string
 		sol []string
 	}{
+		{"random number", numbers},
 		{"Random number between 1 and 3", []string{"1", "2", "3"}},
 		{"Random number between 5431 and 5434", []string{"5431", "5432", "5433", "5434"}},
 		{"Random number between -18 and -21", []string{"-18", "-19", "-20", "-21"}},
@@ -115,5 +122,14 @@ func (r *Random) tests() []test {
 }
 
 func init() {
+	Register(Registration{
+		Name:     "random",
+		Trigger:  `"random number", optionally between two numbers`,
+		Priority: 250,
+		New: func(i *Instant) Answerer {
+			return &Random{}
+		},
+	})
+
 	reRandom = regexp.MustCompile(`(?P<min>-?\d+).*?(?P<max>-?\d+)`)
 }
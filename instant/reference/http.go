@@ -0,0 +1,100 @@
+// Package reference holds lookup tables for developers: HTTP status codes, ports and MIME types
+package reference
+
+// Status is an HTTP status code
+type Status struct {
+	Code        int    `json:"code"`
+	Name        string `json:"name"`
+	Class       string `json:"class"`
+	Description string `json:"description"`
+	Spec        string `json:"spec"`
+}
+
+// HTTPStatus looks up a status code, e.g. 418
+func HTTPStatus(code int) (Status, bool) {
+	s, ok := statuses[code]
+	if !ok {
+		return Status{}, false
+	}
+
+	s.Code = code
+	s.Class = classes[code/100]
+	return s, true
+}
+
+var classes = map[int]string{
+	1: "Informational",
+	2: "Success",
+	3: "Redirection",
+	4: "Client Error",
+	5: "Server Error",
+}
+
+var statuses = map[int]Status{
+	100: {Name: "Continue", Spec: "RFC 9110", Description: "The server has received the request headers and the client should go on to send the body."},
+	101: {Name: "Switching Protocols", Spec: "RFC 9110", Description: "The server is switching to the protocol the client asked for in its Upgrade header, e.g. WebSocket."},
+	102: {Name: "Processing", Spec: "RFC 2518", Description: "The server has accepted the request but hasn't finished it yet (WebDAV)."},
+	103: {Name: "Early Hints", Spec: "RFC 8297", Description: "Headers the client can act on, like preloading resources, while the server prepares its response."},
+
+	200: {Name: "OK", Spec: "RFC 9110", Description: "The request succeeded."},
+	201: {Name: "Created", Spec: "RFC 9110", Description: "The request succeeded and a new resource was created."},
+	202: {Name: "Accepted", Spec: "RFC 9110", Description: "The request was accepted for processing but hasn't been acted on yet."},
+	203: {Name: "Non-Authoritative Information", Spec: "RFC 9110", Description: "The response was modified by a transforming proxy."},
+	204: {Name: "No Content", Spec: "RFC 9110", Description: "The request succeeded and there is no body to send."},
+	205: {Name: "Reset Content", Spec: "RFC 9110", Description: "The request succeeded and the client should reset the document view, e.g. clear a form."},
+	206: {Name: "Partial Content", Spec: "RFC 9110", Description: "Only the range of the resource the client asked for is being sent."},
+	207: {Name: "Multi-Status", Spec: "RFC 4918", Description: "The body holds the status of several independent operations (WebDAV)."},
+	208: {Name: "Already Reported", Spec: "RFC 5842", Description: "The members of a binding were already listed earlier in the response (WebDAV)."},
+	226: {Name: "IM Used", Spec: "RFC 3229", Description: "The response is the result of instance manipulations applied to the resource."},
+
+	300: {Name: "Multiple Choices", Spec: "RFC 9110", Description: "There is more than one representation of the resource to choose from."},
+	301: {Name: "Moved Permanently", Spec: "RFC 9110", Description: "The resource has moved for good to the URL in the Location header."},
+	302: {Name: "Found", Spec: "RFC 9110", Description: "The resource is temporarily at the URL in the Location header."},
+	303: {Name: "See Other", Spec: "RFC 9110", Description: "The client should GET the URL in the Location header, e.g. after a form POST."},
+	304: {Name: "Not Modified", Spec: "RFC 9110", Description: "The resource hasn't changed since the version the client has cached."},
+	305: {Name: "Use Proxy", Spec: "RFC 9110", Description: "Deprecated. The resource must be accessed through a proxy."},
+	307: {Name: "Temporary Redirect", Spec: "RFC 9110", Description: "The resource is temporarily at another URL and the method must not change."},
+	308: {Name: "Permanent Redirect", Spec: "RFC 9110", Description: "The resource has moved for good and the method must not change."},
+
+	400: {Name: "Bad Request", Spec: "RFC 9110", Description: "The server can't process the request because of a client error, like malformed syntax."},
+	401: {Name: "Unauthorized", Spec: "RFC 9110", Description: "The request needs authentication the client hasn't given."},
+	402: {Name: "Payment Required", Spec: "RFC 9110", Description: "Reserved for future use, though some APIs use it when a payment is due."},
+	403: {Name: "Forbidden", Spec: "RFC 9110", Description: "The server understood the request but refuses to authorize it."},
+	404: {Name: "Not Found", Spec: "RFC 9110", Description: "The server can't find the requested resource."},
+	405: {Name: "Method Not Allowed", Spec: "RFC 9110", Description: "The resource doesn't support the request's method."},
+	406: {Name: "Not Acceptable", Spec: "RFC 9110", Description: "No representation matches the request's Accept headers."},
+	407: {Name: "Proxy Authentication Required", Spec: "RFC 9110", Description: "The client must authenticate with the proxy."},
+	408: {Name: "Request Timeout", Spec: "RFC 9110", Description: "The server timed out waiting for the request."},
+	409: {Name: "Conflict", Spec: "RFC 9110", Description: "The request conflicts with the current state of the resource."},
+	410: {Name: "Gone", Spec: "RFC 9110", Description: "The resource was removed for good and has no forwarding address."},
+	411: {Name: "Length Required", Spec: "RFC 9110", Description: "The request needs a Content-Length header."},
+	412: {Name: "Precondition Failed", Spec: "RFC 9110", Description: "A precondition in the request headers, like If-Match, was false."},
+	413: {Name: "Content Too Large", Spec: "RFC 9110", Description: "The request body is larger than the server will process."},
+	414: {Name: "URI Too Long", Spec: "RFC 9110", Description: "The URI is longer than the server will interpret."},
+	415: {Name: "Unsupported Media Type", Spec: "RFC 9110", Description: "The server doesn't support the body's media type."},
+	416: {Name: "Range Not Satisfiable", Spec: "RFC 9110", Description: "The requested range can't be served, e.g. it is past the end of the file."},
+	417: {Name: "Expectation Failed", Spec: "RFC 9110", Description: "The server can't meet the request's Expect header."},
+	418: {Name: "I'm a teapot", Spec: "RFC 2324", Description: "The server refuses to brew coffee because it is, permanently, a teapot. An April Fools' joke from the Hyper Text Coffee Pot Control Protocol."},
+	421: {Name: "Misdirected Request", Spec: "RFC 9110", Description: "The request went to a server that can't produce a response for it."},
+	422: {Name: "Unprocessable Content", Spec: "RFC 9110", Description: "The request is well formed but has semantic errors."},
+	423: {Name: "Locked", Spec: "RFC 4918", Description: "The resource is locked (WebDAV)."},
+	424: {Name: "Failed Dependency", Spec: "RFC 4918", Description: "The request failed because a request it depended on failed (WebDAV)."},
+	425: {Name: "Too Early", Spec: "RFC 8470", Description: "The server won't risk processing a request that might be replayed."},
+	426: {Name: "Upgrade Required", Spec: "RFC 9110", Description: "The client must switch to another protocol, given in the Upgrade header."},
+	428: {Name: "Precondition Required", Spec: "RFC 6585", Description: "The server requires the request to be conditional."},
+	429: {Name: "Too Many Requests", Spec: "RFC 6585", Description: "The client has sent too many requests in a given time. See the Retry-After header."},
+	431: {Name: "Request Header Fields Too Large", Spec: "RFC 6585", Description: "The request's headers are too large."},
+	451: {Name: "Unavailable For Legal Reasons", Spec: "RFC 7725", Description: "The resource can't be served for legal reasons, like censorship."},
+
+	500: {Name: "Internal Server Error", Spec: "RFC 9110", Description: "The server hit an unexpected condition."},
+	501: {Name: "Not Implemented", Spec: "RFC 9110", Description: "The server doesn't support the functionality needed for the request."},
+	502: {Name: "Bad Gateway", Spec: "RFC 9110", Description: "A gateway or proxy got an invalid response from the upstream server."},
+	503: {Name: "Service Unavailable", Spec: "RFC 9110", Description: "The server is overloaded or down for maintenance."},
+	504: {Name: "Gateway Timeout", Spec: "RFC 9110", Description: "A gateway or proxy didn't get a response from the upstream server in time."},
+	505: {Name: "HTTP Version Not Supported", Spec: "RFC 9110", Description: "The server doesn't support the request's HTTP version."},
+	506: {Name: "Variant Also Negotiates", Spec: "RFC 2295", Description: "The server has a configuration error in transparent content negotiation."},
+	507: {Name: "Insufficient Storage", Spec: "RFC 4918", Description: "The server can't store what is needed to complete the request (WebDAV)."},
+	508: {Name: "Loop Detected", Spec: "RFC 5842", Description: "The server found an infinite loop while processing the request (WebDAV)."},
+	510: {Name: "Not Extended", Spec: "RFC 2774", Description: "The request needs further extensions for the server to fulfil it."},
+	511: {Name: "Network Authentication Required", Spec: "RFC 6585", Description: "The client must authenticate to get network access, e.g. a captive portal."},
+}
@@ -0,0 +1,110 @@
+package reference
+
+import (
+	"strings"
+)
+
+// MIMEType is a media type and the file extensions that use it
+type MIMEType struct {
+	Type       string   `json:"type"`
+	Name       string   `json:"name"`
+	Extensions []string `json:"extensions"`
+}
+
+// FindMIMEType looks up a media type by extension, e.g. "json" or ".json", or by the type itself
+func FindMIMEType(s string) (MIMEType, bool) {
+	s = strings.TrimPrefix(strings.ToLower(strings.TrimSpace(s)), ".")
+
+	for _, m := range mimeTypes {
+		if m.Type == s {
+			return m, true
+		}
+
+		for _, ext := range m.Extensions {
+			if ext == s {
+				return m, true
+			}
+		}
+	}
+
+	return MIMEType{}, false
+}
+
+var mimeTypes = []MIMEType{
+	// text
+	{"text/html", "HTML", []string{"html", "htm"}},
+	{"text/css", "CSS", []string{"css"}},
+	{"text/javascript", "JavaScript", []string{"js", "mjs", "javascript"}},
+	{"text/plain", "Plain text", []string{"txt", "text"}},
+	{"text/csv", "Comma-separated values", []string{"csv"}},
+	{"text/markdown", "Markdown", []string{"md", "markdown"}},
+	{"text/calendar", "iCalendar", []string{"ics"}},
+	{"text/vcard", "vCard", []string{"vcf", "vcard"}},
+
+	// application
+	{"application/json", "JSON", []string{"json"}},
+	{"application/ld+json", "JSON-LD", []string{"jsonld"}},
+	{"application/xml", "XML", []string{"xml"}},
+	{"application/pdf", "PDF", []string{"pdf"}},
+	{"application/zip", "ZIP archive", []string{"zip"}},
+	{"application/gzip", "Gzip archive", []string{"gz", "gzip"}},
+	{"application/x-tar", "Tar archive", []string{"tar"}},
+	{"application/x-7z-compressed", "7-Zip archive", []string{"7z"}},
+	{"application/vnd.rar", "RAR archive", []string{"rar"}},
+	{"application/octet-stream", "Binary data", []string{"bin", "exe", "dll"}},
+	{"application/wasm", "WebAssembly", []string{"wasm"}},
+	{"application/rtf", "Rich Text Format", []string{"rtf"}},
+	{"application/msword", "Microsoft Word", []string{"doc"}},
+	{"application/vnd.openxmlformats-officedocument.wordprocessingml.document", "Microsoft Word (OpenXML)", []string{"docx"}},
+	{"application/vnd.ms-excel", "Microsoft Excel", []string{"xls"}},
+	{"application/vnd.openxmlformats-officedocument.spreadsheetml.sheet", "Microsoft Excel (OpenXML)", []string{"xlsx"}},
+	{"application/vnd.ms-powerpoint", "Microsoft PowerPoint", []string{"ppt"}},
+	{"application/vnd.openxmlformats-officedocument.presentationml.presentation", "Microsoft PowerPoint (OpenXML)", []string{"pptx"}},
+	{"application/vnd.oasis.opendocument.text", "OpenDocument text", []string{"odt"}},
+	{"application/vnd.oasis.opendocument.spreadsheet", "OpenDocument spreadsheet", []string{"ods"}},
+	{"application/epub+zip", "EPUB", []string{"epub"}},
+	{"application/java-archive", "Java archive", []string{"jar"}},
+	{"application/x-sh", "Shell script", []string{"sh"}},
+	{"application/sql", "SQL", []string{"sql"}},
+	{"application/yaml", "YAML", []string{"yaml", "yml"}},
+	{"application/toml", "TOML", []string{"toml"}},
+	{"application/x-www-form-urlencoded", "URL encoded form", []string{"form"}},
+	{"application/rss+xml", "RSS", []string{"rss"}},
+	{"application/atom+xml", "Atom", []string{"atom"}},
+	{"application/manifest+json", "Web app manifest", []string{"webmanifest"}},
+
+	// images
+	{"image/png", "PNG image", []string{"png"}},
+	{"image/jpeg", "JPEG image", []string{"jpg", "jpeg", "jpe"}},
+	{"image/gif", "GIF image", []string{"gif"}},
+	{"image/webp", "WebP image", []string{"webp"}},
+	{"image/avif", "AVIF image", []string{"avif"}},
+	{"image/svg+xml", "SVG image", []string{"svg"}},
+	{"image/bmp", "Bitmap image", []string{"bmp"}},
+	{"image/tiff", "TIFF image", []string{"tif", "tiff"}},
+	{"image/x-icon", "Icon", []string{"ico"}},
+	{"image/heic", "HEIC image", []string{"heic"}},
+
+	// audio & video
+	{"audio/mpeg", "MP3 audio", []string{"mp3"}},
+	{"audio/ogg", "Ogg audio", []string{"ogg", "oga"}},
+	{"audio/wav", "WAV audio", []string{"wav"}},
+	{"audio/aac", "AAC audio", []string{"aac"}},
+	{"audio/flac", "FLAC audio", []string{"flac"}},
+	{"audio/webm", "WebM audio", []string{"weba"}},
+	{"video/mp4", "MP4 video", []string{"mp4", "m4v"}},
+	{"video/webm", "WebM video", []string{"webm"}},
+	{"video/ogg", "Ogg video", []string{"ogv"}},
+	{"video/quicktime", "QuickTime video", []string{"mov"}},
+	{"video/x-msvideo", "AVI video", []string{"avi"}},
+	{"video/x-matroska", "Matroska video", []string{"mkv"}},
+
+	// fonts
+	{"font/woff", "WOFF font", []string{"woff"}},
+	{"font/woff2", "WOFF2 font", []string{"woff2"}},
+	{"font/ttf", "TrueType font", []string{"ttf"}},
+	{"font/otf", "OpenType font", []string{"otf"}},
+
+	// multipart
+	{"multipart/form-data", "Form data", []string{"form-data"}},
+}
@@ -0,0 +1,118 @@
+package reference
+
+import (
+	"strings"
+)
+
+// Port is a well known port and the service that uses it
+type Port struct {
+	Number      int      `json:"number"`
+	Protocol    string   `json:"protocol"` // "TCP", "UDP" or "TCP/UDP"
+	Service     string   `json:"service"`
+	Description string   `json:"description"`
+	aliases     []string // other names for the service, e.g. "postgres"
+}
+
+// PortsByNumber are the services that use a port
+func PortsByNumber(n int) []Port {
+	found := []Port{}
+	for _, p := range ports {
+		if p.Number == n {
+			found = append(found, p)
+		}
+	}
+	return found
+}
+
+// PortsByService are the ports a service uses, e.g. "ssh" or "postgres"
+func PortsByService(name string) []Port {
+	name = strings.ToLower(strings.TrimSpace(name))
+
+	found := []Port{}
+	for _, p := range ports {
+		if strings.ToLower(p.Service) == name {
+			found = append(found, p)
+			continue
+		}
+
+		for _, a := range p.aliases {
+			if a == name {
+				found = append(found, p)
+				break
+			}
+		}
+	}
+	return found
+}
+
+var ports = []Port{
+	{20, "TCP", "FTP", "File Transfer Protocol data", []string{"ftp data"}},
+	{21, "TCP", "FTP", "File Transfer Protocol control", nil},
+	{22, "TCP", "SSH", "Secure Shell, also used by SCP and SFTP", []string{"scp", "sftp"}},
+	{23, "TCP", "Telnet", "Unencrypted remote login", nil},
+	{25, "TCP", "SMTP", "Simple Mail Transfer Protocol, between mail servers", nil},
+	{53, "TCP/UDP", "DNS", "Domain Name System", nil},
+	{67, "UDP", "DHCP", "Dynamic Host Configuration Protocol server", nil},
+	{68, "UDP", "DHCP", "Dynamic Host Configuration Protocol client", nil},
+	{69, "UDP", "TFTP", "Trivial File Transfer Protocol", nil},
+	{80, "TCP", "HTTP", "Hypertext Transfer Protocol", []string{"web"}},
+	{88, "TCP/UDP", "Kerberos", "Kerberos authentication", nil},
+	{110, "TCP", "POP3", "Post Office Protocol", []string{"pop"}},
+	{119, "TCP", "NNTP", "Network News Transfer Protocol, i.e. Usenet", []string{"usenet"}},
+	{123, "UDP", "NTP", "Network Time Protocol", nil},
+	{135, "TCP", "Microsoft RPC", "Microsoft Remote Procedure Call endpoint mapper", []string{"rpc", "msrpc"}},
+	{137, "UDP", "NetBIOS", "NetBIOS name service", nil},
+	{139, "TCP", "NetBIOS", "NetBIOS session service", nil},
+	{143, "TCP", "IMAP", "Internet Message Access Protocol", nil},
+	{161, "UDP", "SNMP", "Simple Network Management Protocol", nil},
+	{162, "UDP", "SNMP", "Simple Network Management Protocol traps", []string{"snmp trap"}},
+	{179, "TCP", "BGP", "Border Gateway Protocol", nil},
+	{194, "TCP", "IRC", "Internet Relay Chat", nil},
+	{389, "TCP/UDP", "LDAP", "Lightweight Directory Access Protocol", nil},
+	{443, "TCP/UDP", "HTTPS", "HTTP over TLS, and HTTP/3 over QUIC on UDP", []string{"ssl", "tls"}},
+	{445, "TCP", "SMB", "Server Message Block file sharing", []string{"samba", "cifs"}},
+	{465, "TCP", "SMTPS", "SMTP over TLS for mail submission", nil},
+	{500, "UDP", "IKE", "Internet Key Exchange for IPsec VPNs", []string{"ipsec"}},
+	{514, "UDP", "Syslog", "System logging", nil},
+	{587, "TCP", "SMTP", "Mail submission with STARTTLS", []string{"submission"}},
+	{636, "TCP", "LDAPS", "LDAP over TLS", nil},
+	{853, "TCP", "DNS over TLS", "Encrypted DNS", []string{"dot"}},
+	{873, "TCP", "rsync", "rsync file synchronization", nil},
+	{993, "TCP", "IMAPS", "IMAP over TLS", nil},
+	{995, "TCP", "POP3S", "POP3 over TLS", nil},
+	{1080, "TCP", "SOCKS", "SOCKS proxy", []string{"socks5"}},
+	{1194, "TCP/UDP", "OpenVPN", "OpenVPN", nil},
+	{1433, "TCP", "Microsoft SQL Server", "Microsoft SQL Server database", []string{"mssql", "sql server"}},
+	{1521, "TCP", "Oracle", "Oracle database listener", []string{"oracle database"}},
+	{1883, "TCP", "MQTT", "Message Queuing Telemetry Transport", nil},
+	{2049, "TCP/UDP", "NFS", "Network File System", nil},
+	{2181, "TCP", "ZooKeeper", "Apache ZooKeeper", nil},
+	{2375, "TCP", "Docker", "Docker daemon API, unencrypted", nil},
+	{2376, "TCP", "Docker", "Docker daemon API over TLS", nil},
+	{2379, "TCP", "etcd", "etcd client API", nil},
+	{3000, "TCP", "Development server", "Common default for Node.js, Rails and Grafana", []string{"grafana", "rails", "node"}},
+	{3306, "TCP", "MySQL", "MySQL and MariaDB database", []string{"mariadb"}},
+	{3389, "TCP/UDP", "RDP", "Remote Desktop Protocol", []string{"remote desktop"}},
+	{4222, "TCP", "NATS", "NATS messaging", nil},
+	{5060, "TCP/UDP", "SIP", "Session Initiation Protocol for VoIP", nil},
+	{5222, "TCP", "XMPP", "Extensible Messaging and Presence Protocol client", []string{"jabber"}},
+	{5353, "UDP", "mDNS", "Multicast DNS, e.g. Bonjour", []string{"bonjour"}},
+	{5432, "TCP", "PostgreSQL", "PostgreSQL database", []string{"postgres", "psql"}},
+	{5601, "TCP", "Kibana", "Kibana web interface", nil},
+	{5672, "TCP", "AMQP", "Advanced Message Queuing Protocol, e.g. RabbitMQ", []string{"rabbitmq"}},
+	{5900, "TCP", "VNC", "Virtual Network Computing remote desktop", nil},
+	{6379, "TCP", "Redis", "Redis key-value store", nil},
+	{6443, "TCP", "Kubernetes", "Kubernetes API server", []string{"k8s", "kubernetes api"}},
+	{6667, "TCP", "IRC", "Internet Relay Chat", nil},
+	{8080, "TCP", "HTTP alternate", "Common for proxies and application servers", []string{"http alt", "tomcat"}},
+	{8443, "TCP", "HTTPS alternate", "Common for application servers over TLS", []string{"https alt"}},
+	{9000, "TCP", "PHP-FPM", "PHP FastCGI Process Manager", []string{"php fpm"}},
+	{9090, "TCP", "Prometheus", "Prometheus server", nil},
+	{9092, "TCP", "Kafka", "Apache Kafka broker", nil},
+	{9200, "TCP", "Elasticsearch", "Elasticsearch REST API", nil},
+	{9300, "TCP", "Elasticsearch", "Elasticsearch node to node communication", nil},
+	{11211, "TCP/UDP", "Memcached", "Memcached", nil},
+	{25565, "TCP", "Minecraft", "Minecraft Java Edition server", nil},
+	{27017, "TCP", "MongoDB", "MongoDB database", []string{"mongo"}},
+	{51820, "UDP", "WireGuard", "WireGuard VPN", nil},
+}
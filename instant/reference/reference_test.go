@@ -0,0 +1,66 @@
+package reference
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestHTTPStatus(t *testing.T) {
+	got, ok := HTTPStatus(418)
+	if !ok {
+		t.Fatal("418 not found")
+	}
+
+	if got.Code != 418 || got.Name != "I'm a teapot" || got.Class != "Client Error" {
+		t.Fatalf("got %+v", got)
+	}
+
+	if _, ok := HTTPStatus(499); ok {
+		t.Fatal("499 isn't a registered status code")
+	}
+}
+
+func TestPorts(t *testing.T) {
+	for _, c := range []struct {
+		name string
+		got  []Port
+		want []int
+	}{
+		{"5432", PortsByNumber(5432), []int{5432}},
+		{"postgres", PortsByService("postgres"), []int{5432}},
+		{"SSH", PortsByService("SSH"), []int{22}},
+		{"ftp", PortsByService("ftp"), []int{20, 21}},
+		{"12345", PortsByNumber(12345), []int{}},
+	} {
+		t.Run(c.name, func(t *testing.T) {
+			got := []int{}
+			for _, p := range c.got {
+				got = append(got, p.Number)
+			}
+
+			if !reflect.DeepEqual(got, c.want) {
+				t.Fatalf("got %v; want %v", got, c.want)
+			}
+		})
+	}
+}
+
+func TestFindMIMEType(t *testing.T) {
+	for _, c := range []struct {
+		s    string
+		want string
+	}{
+		{"json", "application/json"},
+		{".PNG", "image/png"},
+		{"jpeg", "image/jpeg"},
+		{"application/pdf", "application/pdf"},
+		{"nope", ""},
+	} {
+		t.Run(c.s, func(t *testing.T) {
+			got, _ := FindMIMEType(c.s)
+			if got.Type != c.want {
+				t.Fatalf("got %q; want %q", got.Type, c.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,486 @@
+package instant
+
+import (
+	"fmt"
+	"net/http"
+	"regexp"
+	"regexp/syntax"
+	"strings"
+
+	"golang.org/x/text/language"
+)
+
+// RegexType is an answer Type
+const RegexType Type = "regex"
+
+// Regex is an instant answer that tests a regular expression
+type Regex struct {
+	raw string // patterns & their input are case sensitive so we keep the query as typed
+	Answer
+}
+
+// RegexResponse is a regular expression, what it matched and an explanation of it
+type RegexResponse struct {
+	Pattern     string       `json:"pattern"`
+	Flags       string       `json:"flags,omitempty"`
+	Input       string       `json:"input,omitempty"`
+	Tested      bool         `json:"tested"` // false when there was no input to test
+	Matches     []RegexMatch `json:"matches,omitempty"`
+	Explanation []RegexToken `json:"explanation,omitempty"`
+	Error       string       `json:"error,omitempty"`
+}
+
+// RegexMatch is a match and its capture groups
+type RegexMatch struct {
+	Text   string       `json:"text"`
+	Index  int          `json:"index"`
+	Groups []RegexGroup `json:"groups,omitempty"`
+}
+
+// RegexGroup is a capture group of a match
+type RegexGroup struct {
+	Number  int    `json:"number"`
+	Name    string `json:"name,omitempty"`
+	Text    string `json:"text"`
+	Matched bool   `json:"matched"`
+}
+
+// RegexToken is a piece of a pattern and what it does
+type RegexToken struct {
+	Pattern     string `json:"pattern"`
+	Description string `json:"description"`
+	Depth       int    `json:"depth"`
+}
+
+// regexQueries are case insensitive so they can be run against the raw query as well
+var regexQueries = []*regexp.Regexp{
+	regexp.MustCompile(`(?i)^(?:test\s+)?(?P<trigger>regexp?|regular expression)(?:\s+tester)?\s+/(?P<pattern>.+)/(?P<flags>[a-z]*)(?:\s+(?:on|against|with|in)\s+(?P<input>.+))?$`),
+	regexp.MustCompile(`(?i)^(?:test\s+)?(?P<trigger>regexp?|regular expression)\s+(?P<pattern>\S+)\s+(?:on|against)\s+(?P<input>.+)$`),
+}
+
+func (rx *Regex) setQuery(r *http.Request, qv string) Answerer {
+	rx.Answer.setQuery(r, qv)
+	rx.raw = strings.TrimSpace(r.FormValue(qv))
+	return rx
+}
+
+func (rx *Regex) setUserAgent(r *http.Request) Answerer {
+	return rx
+}
+
+func (rx *Regex) setLanguage(lang language.Tag) Answerer {
+	rx.language = lang
+	return rx
+}
+
+func (rx *Regex) setType() Answerer {
+	rx.Type = RegexType
+	return rx
+}
+
+func (rx *Regex) setRegex() Answerer {
+	rx.regex = append(rx.regex, regexQueries...)
+	return rx
+}
+
+func (rx *Regex) solve(r *http.Request) Answerer {
+	var m map[string]string
+	for _, re := range regexQueries {
+		if m = namedGroups(re, rx.raw); m != nil {
+			break
+		}
+	}
+
+	if m == nil {
+		rx.Err = fmt.Errorf("unable to parse a regular expression from %q", rx.raw)
+		return rx
+	}
+
+	resp := &RegexResponse{
+		Pattern: m["pattern"],
+		Flags:   m["flags"],
+		Input:   unquote(m["input"]),
+		Tested:  m["input"] != "",
+	}
+
+	rx.Solution = resp
+
+	var prefix string
+	for _, f := range resp.Flags {
+		switch f {
+		case 'i', 'm', 's', 'U':
+			prefix += string(f)
+		case 'g': // all matches, not just the first
+		default:
+			resp.Error = fmt.Sprintf("unknown flag %q. Try i, m, s, U or g.", f)
+			return rx
+		}
+	}
+
+	pattern := resp.Pattern
+	if prefix != "" {
+		pattern = "(?" + prefix + ")" + pattern
+	}
+
+	// this is Go's RE2 syntax so lookarounds & backreferences aren't supported
+	re, err := regexp.Compile(pattern)
+	if err != nil {
+		resp.Error = err.Error()
+		return rx
+	}
+
+	if tree, err := syntax.Parse(pattern, syntax.Perl); err == nil {
+		resp.Explanation = explainRegex(tree, 0)
+	}
+
+	if !resp.Tested {
+		return rx
+	}
+
+	n := 1
+	if strings.ContainsRune(resp.Flags, 'g') {
+		n = -1
+	}
+
+	names := re.SubexpNames()
+	for _, loc := range re.FindAllStringSubmatchIndex(resp.Input, n) {
+		match := RegexMatch{
+			Text:  resp.Input[loc[0]:loc[1]],
+			Index: loc[0],
+		}
+
+		for i := 1; i < len(names); i++ {
+			g := RegexGroup{Number: i, Name: names[i]}
+			if start, end := loc[2*i], loc[2*i+1]; start >= 0 {
+				g.Text, g.Matched = resp.Input[start:end], true
+			}
+			match.Groups = append(match.Groups, g)
+		}
+
+		resp.Matches = append(resp.Matches, match)
+	}
+
+	return rx
+}
+
+func namedGroups(re *regexp.Regexp, s string) map[string]string {
+	match := re.FindStringSubmatch(s)
+	if match == nil {
+		return nil
+	}
+
+	m := map[string]string{}
+	for i, name := range re.SubexpNames() {
+		if name != "" {
+			m[name] = match[i]
+		}
+	}
+
+	return m
+}
+
+func unquote(s string) string {
+	for _, q := range []string{`"`, `'`, "`"} {
+		if len(s) > 1 && strings.HasPrefix(s, q) && strings.HasSuffix(s, q) {
+			return s[1 : len(s)-1]
+		}
+	}
+	return s
+}
+
+// explainRegex describes each piece of a parsed pattern, nesting groups, alternatives & repeated pieces
+func explainRegex(re *syntax.Regexp, depth int) []RegexToken {
+	tokens := []RegexToken{}
+
+	switch re.Op {
+	case syntax.OpConcat:
+		for _, sub := range re.Sub {
+			tokens = append(tokens, explainRegex(sub, depth)...)
+		}
+	case syntax.OpCapture:
+		d := fmt.Sprintf("capture group #%d", re.Cap)
+		p := "(...)"
+		if re.Name != "" {
+			d = fmt.Sprintf("capture group #%d, named %q", re.Cap, re.Name)
+			p = fmt.Sprintf("(?P<%v>...)", re.Name)
+		}
+
+		tokens = append(tokens, RegexToken{Pattern: p, Description: d, Depth: depth})
+		tokens = append(tokens, explainRegex(re.Sub[0], depth+1)...)
+	case syntax.OpAlternate:
+		tokens = append(tokens, RegexToken{Pattern: "|", Description: "either", Depth: depth})
+		for i, sub := range re.Sub {
+			if i > 0 {
+				tokens = append(tokens, RegexToken{Pattern: "|", Description: "or", Depth: depth})
+			}
+			tokens = append(tokens, explainRegex(sub, depth+1)...)
+		}
+	case syntax.OpStar, syntax.OpPlus, syntax.OpQuest, syntax.OpRepeat:
+		q, d := quantifier(re)
+
+		// a repeated character or class reads better on one line
+		if sub := explainRegex(re.Sub[0], depth+1); len(sub) == 1 && re.Sub[0].Op != syntax.OpCapture {
+			tokens = append(tokens, RegexToken{Pattern: sub[0].Pattern + q, Description: sub[0].Description + ", " + d, Depth: depth})
+		} else {
+			tokens = append(tokens, RegexToken{Pattern: q, Description: "the following, " + d, Depth: depth})
+			tokens = append(tokens, sub...)
+		}
+	default:
+		p, d := regexLeaf(re)
+		tokens = append(tokens, RegexToken{Pattern: p, Description: d, Depth: depth})
+	}
+
+	return tokens
+}
+
+func quantifier(re *syntax.Regexp) (string, string) {
+	var q, d string
+
+	switch re.Op {
+	case syntax.OpStar:
+		q, d = "*", "zero or more times"
+	case syntax.OpPlus:
+		q, d = "+", "one or more times"
+	case syntax.OpQuest:
+		q, d = "?", "optionally"
+	case syntax.OpRepeat:
+		switch {
+		case re.Min == re.Max:
+			q, d = fmt.Sprintf("{%d}", re.Min), fmt.Sprintf("exactly %d times", re.Min)
+		case re.Max == -1:
+			q, d = fmt.Sprintf("{%d,}", re.Min), fmt.Sprintf("%d or more times", re.Min)
+		default:
+			q, d = fmt.Sprintf("{%d,%d}", re.Min, re.Max), fmt.Sprintf("between %d and %d times", re.Min, re.Max)
+		}
+	}
+
+	if re.Flags&syntax.NonGreedy != 0 {
+		q += "?"
+		d += ", as few as possible"
+	}
+
+	return q, d
+}
+
+// character classes that have a shorthand and a better name than their contents
+var namedClasses = map[string]struct{ short, description string }{
+	`[0-9]`:                  {`\d`, "a digit"},
+	`[^0-9]`:                 {`\D`, "anything but a digit"},
+	`[0-9A-Z_a-z]`:           {`\w`, "a word character"},
+	`[^0-9A-Z_a-z]`:          {`\W`, "anything but a word character"},
+	`[\t-\n\f-\r ]`:          {`\s`, "a whitespace character"},
+	`[^\t-\n\f-\r ]`:         {`\S`, "anything but a whitespace character"},
+	`[A-Za-z]`:               {`[A-Za-z]`, "a letter"},
+	`[0-9A-Za-z]`:            {`[0-9A-Za-z]`, "a letter or digit"},
+	`[\x00-\t\v-\x{10FFFF}]`: {`[^\n]`, "any character except a newline"},
+}
+
+func regexLeaf(re *syntax.Regexp) (string, string) {
+	var fold string
+	if re.Flags&syntax.FoldCase != 0 {
+		fold = ", ignoring case"
+	}
+
+	switch re.Op {
+	case syntax.OpLiteral:
+		s := string(re.Rune)
+		if fold != "" { // the parser upper cases them
+			s = strings.ToLower(s)
+		}
+
+		if len(re.Rune) == 1 {
+			return regexp.QuoteMeta(s), fmt.Sprintf("the character %q%v", s, fold)
+		}
+		return regexp.QuoteMeta(s), fmt.Sprintf("the text %q%v", s, fold)
+	case syntax.OpCharClass:
+		p := re.String()
+		if c, ok := namedClasses[p]; ok {
+			return c.short, c.description
+		}
+		if strings.HasPrefix(p, "[^") {
+			return p, "any character except those in " + p
+		}
+		return p, "one of the characters in " + p
+	case syntax.OpAnyCharNotNL:
+		return ".", "any character except a newline"
+	case syntax.OpAnyChar:
+		return ".", "any character"
+	case syntax.OpBeginLine:
+		return "^", "the start of a line"
+	case syntax.OpEndLine:
+		return "$", "the end of a line"
+	case syntax.OpBeginText:
+		return "^", "the start of the text"
+	case syntax.OpEndText:
+		if re.Flags&syntax.WasDollar != 0 {
+			return "$", "the end of the text"
+		}
+		return `\z`, "the end of the text"
+	case syntax.OpWordBoundary:
+		return `\b`, "a word boundary"
+	case syntax.OpNoWordBoundary:
+		return `\B`, "not a word boundary"
+	case syntax.OpEmptyMatch:
+		return "", "nothing"
+	default:
+		return re.String(), "a pattern"
+	}
+}
+
+func (rx *Regex) tests() []test {
+	tests := []test{
+		{
+			query: `regex /^a.+z$/ on "abcz"`,
+			expected: []Data{
+				{
+					Type:      RegexType,
+					Triggered: true,
+					Solution: &RegexResponse{
+						Pattern: "^a.+z$",
+						Input:   "abcz",
+						Tested:  true,
+						Matches: []RegexMatch{
+							{Text: "abcz", Index: 0},
+						},
+						Explanation: []RegexToken{
+							{Pattern: "^", Description: "the start of the text"},
+							{Pattern: "a", Description: `the character "a"`},
+							{Pattern: ".+", Description: "any character except a newline, one or more times"},
+							{Pattern: "z", Description: `the character "z"`},
+							{Pattern: "$", Description: "the end of the text"},
+						},
+					},
+				},
+			},
+		},
+		{
+			query: `regexp /(?P<year>\d{4})-(\d\d)?/g against "Released 2019-01 and 2020-"`,
+			expected: []Data{
+				{
+					Type:      RegexType,
+					Triggered: true,
+					Solution: &RegexResponse{
+						Pattern: `(?P<year>\d{4})-(\d\d)?`,
+						Flags:   "g",
+						Input:   "Released 2019-01 and 2020-",
+						Tested:  true,
+						Matches: []RegexMatch{
+							{
+								Text:  "2019-01",
+								Index: 9,
+								Groups: []RegexGroup{
+									{Number: 1, Name: "year", Text: "2019", Matched: true},
+									{Number: 2, Text: "01", Matched: true},
+								},
+							},
+							{
+								Text:  "2020-",
+								Index: 21,
+								Groups: []RegexGroup{
+									{Number: 1, Name: "year", Text: "2020", Matched: true},
+									{Number: 2}, // didn't take part in the match
+								},
+							},
+						},
+						Explanation: []RegexToken{
+							{Pattern: "(?P<year>...)", Description: `capture group #1, named "year"`},
+							{Pattern: `\d{4}`, Description: "a digit, exactly 4 times", Depth: 1},
+							{Pattern: "-", Description: `the character "-"`},
+							{Pattern: "?", Description: "the following, optionally"},
+							{Pattern: "(...)", Description: "capture group #2", Depth: 1},
+							{Pattern: `\d`, Description: "a digit", Depth: 2},
+							{Pattern: `\d`, Description: "a digit", Depth: 2},
+						},
+					},
+				},
+			},
+		},
+		{
+			query: `Regex /Hello|bye/i on "hello World"`,
+			expected: []Data{
+				{
+					Type:      RegexType,
+					Triggered: true,
+					Solution: &RegexResponse{
+						Pattern: "Hello|bye",
+						Flags:   "i",
+						Input:   "hello World",
+						Tested:  true,
+						Matches: []RegexMatch{
+							{Text: "hello", Index: 0},
+						},
+						Explanation: []RegexToken{
+							{Pattern: "|", Description: "either"},
+							{Pattern: "hello", Description: `the text "hello", ignoring case`, Depth: 1},
+							{Pattern: "|", Description: "or"},
+							{Pattern: "bye", Description: `the text "bye", ignoring case`, Depth: 1},
+						},
+					},
+				},
+			},
+		},
+		{
+			query: `regex [a-f]+ against xyz`,
+			expected: []Data{
+				{
+					Type:      RegexType,
+					Triggered: true,
+					Solution: &RegexResponse{
+						Pattern: "[a-f]+",
+						Input:   "xyz",
+						Tested:  true,
+						Explanation: []RegexToken{
+							{Pattern: "[a-f]+", Description: "one of the characters in [a-f], one or more times"},
+						},
+					},
+				},
+			},
+		},
+		{
+			query: `regex /\bgo\b/`,
+			expected: []Data{
+				{
+					Type:      RegexType,
+					Triggered: true,
+					Solution: &RegexResponse{
+						Pattern: `\bgo\b`,
+						Explanation: []RegexToken{
+							{Pattern: `\b`, Description: "a word boundary"},
+							{Pattern: "go", Description: `the text "go"`},
+							{Pattern: `\b`, Description: "a word boundary"},
+						},
+					},
+				},
+			},
+		},
+		{
+			query: `regex /a(?=b)/ on "ab"`,
+			expected: []Data{
+				{
+					Type:      RegexType,
+					Triggered: true,
+					Solution: &RegexResponse{
+						Pattern: "a(?=b)",
+						Input:   "ab",
+						Tested:  true,
+						Error:   "error parsing regexp: invalid or unsupported Perl syntax: `(?=`",
+					},
+				},
+			},
+		},
+	}
+
+	return tests
+}
+
+func init() {
+	Register(Registration{
+		Name:    "regex",
+		Trigger: `"regex", a /pattern/ and the text to test it on, e.g. regex /^a.+z$/ on "abcz"`,
+		// ahead of stackoverflow, which also triggers on "regex"
+		Priority: 365,
+		New: func(i *Instant) Answerer {
+			return &Regex{}
+		},
+	})
+}
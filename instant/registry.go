@@ -0,0 +1,145 @@
+package instant
+
+import (
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/jivesearch/jivesearch/search/intent"
+)
+
+// Registration describes an instant answer. Each answer registers itself in
+// init() so it can be listed and switched on or off without recompiling.
+type Registration struct {
+	Name       string                    `json:"name"`
+	Trigger    string                    `json:"trigger"`          // a description of the queries that trigger the answer
+	Priority   int                       `json:"priority"`         // lower priorities are tried first
+	Maps       bool                      `json:"maps"`             // also tried when the maps or images tab is selected
+	Confidence float64                   `json:"confidence"`       // how sure we are that a triggered answer is what the user wants. Defaults to 1.
+	Intent     intent.Intent             `json:"intent,omitempty"` // the query intent the answer serves, if any
+	External   bool                      `json:"external"`         // calls a third-party service when triggered
+	Enabled    bool                      `json:"enabled"`
+	TTL        time.Duration             `json:"ttl,omitempty"` // how long its solutions are cached. 0 isn't cached.
+	New        func(i *Instant) Answerer `json:"-"`
+}
+
+// lastPriority is for catch-all answers that should only trigger if nothing else does
+const lastPriority = 1000
+
+const defaultConfidence = 1.0
+
+var registry = struct {
+	sync.RWMutex
+	m map[string]*Registration
+}{
+	m: map[string]*Registration{},
+}
+
+// Register adds an instant answer to the registry, enabled.
+// It panics if an answer with the same name was already registered.
+func Register(r Registration) {
+	registry.Lock()
+	defer registry.Unlock()
+
+	if _, ok := registry.m[r.Name]; ok {
+		panic(fmt.Sprintf("instant answer %q registered twice", r.Name))
+	}
+
+	if r.Confidence == 0 {
+		r.Confidence = defaultConfidence
+	}
+
+	r.Enabled = true
+	registry.m[r.Name] = &r
+}
+
+// Registrations returns all registered instant answers in the order they are tried
+func Registrations() []Registration {
+	registry.RLock()
+	defer registry.RUnlock()
+
+	regs := []Registration{}
+	for _, r := range registry.m {
+		regs = append(regs, *r)
+	}
+
+	sort.Slice(regs, func(i, j int) bool {
+		if regs[i].Priority != regs[j].Priority {
+			return regs[i].Priority < regs[j].Priority
+		}
+		return regs[i].Name < regs[j].Name
+	})
+
+	return regs
+}
+
+// Enable turns on an instant answer
+func Enable(name string) error {
+	return setEnabled(name, true)
+}
+
+// Disable turns off an instant answer
+func Disable(name string) error {
+	return setEnabled(name, false)
+}
+
+// SetTTL sets how long the solutions of an instant answer are cached
+func SetTTL(name string, ttl time.Duration) error {
+	registry.Lock()
+	defer registry.Unlock()
+
+	r, ok := registry.m[name]
+	if !ok {
+		return fmt.Errorf("unknown instant answer %q", name)
+	}
+
+	r.TTL = ttl
+	return nil
+}
+
+func setEnabled(name string, enabled bool) error {
+	registry.Lock()
+	defer registry.Unlock()
+
+	r, ok := registry.m[name]
+	if !ok {
+		return fmt.Errorf("unknown instant answer %q", name)
+	}
+
+	r.Enabled = enabled
+	return nil
+}
+
+// Answerers creates the enabled instant answers in the order they should be tried.
+// If onlyMaps is true only those answers that apply to the maps and images tabs are returned.
+// Answers serving the query's top intent are tried first, so they win ties in confidence.
+// Catch-all answers stay last regardless.
+func (i *Instant) Answerers(onlyMaps bool, scores intent.Scores) []Answerer {
+	answers := []Answerer{}
+
+	regs := Registrations()
+	if scores != nil {
+		top := scores.Top()
+		sort.SliceStable(regs, func(a, b int) bool {
+			return regs[a].serves(top) && !regs[b].serves(top)
+		})
+	}
+
+	for _, r := range regs {
+		if !r.Enabled || (onlyMaps && !r.Maps) {
+			continue
+		}
+
+		a := r.New(i)
+		a.setConfidence(r.Confidence)
+		a.setTTL(r.Name, r.TTL)
+		answers = append(answers, a)
+	}
+
+	return answers
+}
+
+func (r Registration) serves(in intent.Intent) bool {
+	return r.Intent == in && r.Priority < lastPriority
+}
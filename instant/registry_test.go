@@ -0,0 +1,182 @@
+package instant
+
+import (
+	"fmt"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/jivesearch/jivesearch/search/intent"
+	"golang.org/x/text/language"
+)
+
+func TestAnswerers(t *testing.T) {
+	i := &Instant{}
+
+	types := func(answers []Answerer) map[string]int {
+		m := map[string]int{}
+		for j, a := range answers {
+			m[fmt.Sprintf("%T", a)] = j
+		}
+		return m
+	}
+
+	all := i.Answerers(false, nil)
+	got, want := types(all), types(answers(Instant{}))
+	if len(got) != len(want) {
+		t.Fatalf("got %d answers; want %d", len(got), len(want))
+	}
+
+	for k := range want {
+		if _, ok := got[k]; !ok {
+			t.Fatalf("%v is not registered", k)
+		}
+	}
+
+	if got["*instant.Speed"] > got["*instant.Length"] {
+		t.Fatalf("speed must be tried before length")
+	}
+
+	if _, ok := all[len(all)-1].(*Wikipedia); !ok {
+		t.Fatalf("got %T last; want *instant.Wikipedia", all[len(all)-1])
+	}
+
+	maps := []string{}
+	for _, a := range i.Answerers(true, nil) {
+		maps = append(maps, fmt.Sprintf("%T", a))
+	}
+
+	if want := []string{"*instant.Maps", "*instant.Wikipedia"}; !reflect.DeepEqual(maps, want) {
+		t.Fatalf("got %v; want %v", maps, want)
+	}
+}
+
+func TestAnswerersIntent(t *testing.T) {
+	i := &Instant{}
+
+	position := func(answers []Answerer, typ string) int {
+		for j, a := range answers {
+			if fmt.Sprintf("%T", a) == typ {
+				return j
+			}
+		}
+		t.Fatalf("%v is not registered", typ)
+		return -1
+	}
+
+	// congress is tried before maps by default
+	if position(i.Answerers(false, nil), "*instant.Congress") > position(i.Answerers(false, nil), "*instant.Maps") {
+		t.Fatal("congress must be tried before maps")
+	}
+
+	local := i.Answerers(false, intent.Scores{intent.Local: 1, intent.Informational: .5})
+	if position(local, "*instant.Maps") > position(local, "*instant.Congress") {
+		t.Fatal("maps must be tried before congress for a local query")
+	}
+
+	if position(local, "*instant.Maps") > position(local, "*instant.Weather") {
+		t.Fatal("answers serving the same intent must keep their priority")
+	}
+
+	if _, ok := local[len(local)-1].(*Wikipedia); !ok {
+		t.Fatalf("got %T last; want *instant.Wikipedia", local[len(local)-1])
+	}
+}
+
+func TestEnable(t *testing.T) {
+	enabled := func(name string) bool {
+		for _, r := range Registrations() {
+			if r.Name == name {
+				return r.Enabled
+			}
+		}
+		t.Fatalf("%q is not registered", name)
+		return false
+	}
+
+	if err := Disable("coin"); err != nil {
+		t.Fatal(err)
+	}
+
+	if enabled("coin") {
+		t.Fatal("coin should be disabled")
+	}
+
+	for _, a := range (&Instant{}).Answerers(false, nil) {
+		if _, ok := a.(*Coin); ok {
+			t.Fatal("got a disabled answer")
+		}
+	}
+
+	if err := Enable("coin"); err != nil {
+		t.Fatal(err)
+	}
+
+	if !enabled("coin") {
+		t.Fatal("coin should be enabled")
+	}
+
+	if err := Disable("not an answer"); err == nil {
+		t.Fatal("expected an error for an unknown answer")
+	}
+}
+
+func TestSetTTL(t *testing.T) {
+	if err := SetTTL("gdp", time.Hour); err != nil {
+		t.Fatal(err)
+	}
+	defer SetTTL("gdp", 0)
+
+	for _, a := range (&Instant{}).Answerers(false, nil) {
+		if _, ok := a.(*GDP); !ok {
+			continue
+		}
+
+		if key, ttl := a.cacheKey(language.MustParseRegion("US")); key != "gdp::und::US::" || ttl != time.Hour {
+			t.Fatalf("got %q, %v; want %q, %v", key, ttl, "gdp::und::US::", time.Hour)
+		}
+	}
+
+	if err := SetTTL("not an answer", time.Hour); err == nil {
+		t.Fatal("expected an error for an unknown answer")
+	}
+}
+
+func TestRank(t *testing.T) {
+	stock := Data{Type: StockQuoteType, Triggered: true, Confidence: .7}
+	wiki := Data{Type: WikipediaType, Triggered: true, Confidence: .6}
+	calc := Data{Type: CalculatorType, Triggered: true, Confidence: 1}
+	empty := Data{Type: WikipediaType, Triggered: true}
+
+	for _, c := range []struct {
+		name      string
+		solutions []Data
+		want      Data
+	}{
+		{"none", nil, Data{}},
+		{"one", []Data{wiki}, wiki},
+		{
+			"secondary",
+			[]Data{wiki, stock},
+			Data{Type: StockQuoteType, Triggered: true, Confidence: .7, Secondary: []Data{wiki}},
+		},
+		{
+			"ties go to the first tried",
+			[]Data{calc, {Type: HashType, Triggered: true, Confidence: 1}},
+			Data{Type: CalculatorType, Triggered: true, Confidence: 1, Secondary: []Data{{Type: HashType, Triggered: true, Confidence: 1}}},
+		},
+		{"no confidence", []Data{calc, empty}, calc},
+		{
+			"max secondary",
+			[]Data{calc, stock, wiki, stock, wiki},
+			Data{Type: CalculatorType, Triggered: true, Confidence: 1, Secondary: []Data{stock, stock, wiki}},
+		},
+	} {
+		t.Run(c.name, func(t *testing.T) {
+			got := Rank(c.solutions)
+			if !reflect.DeepEqual(got, c.want) {
+				t.Fatalf("got %+v; want %+v", got, c.want)
+			}
+		})
+	}
+}
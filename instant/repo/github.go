@@ -0,0 +1,105 @@
+package repo
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+)
+
+// GitHub retrieves repositories from GitHub's API
+type GitHub struct {
+	HTTPClient *http.Client
+	Token      string // optional, but unauthenticated requests are limited to 60 an hour
+}
+
+// GitHubProvider is a code hosting service
+var GitHubProvider Provider = "GitHub"
+
+type githubRepository struct {
+	FullName        string `json:"full_name"`
+	Description     string `json:"description"`
+	HTMLURL         string `json:"html_url"`
+	Language        string `json:"language"`
+	StargazersCount int    `json:"stargazers_count"`
+	ForksCount      int    `json:"forks_count"`
+	OpenIssuesCount int    `json:"open_issues_count"` // includes pull requests
+	License         *struct {
+		SPDXID string `json:"spdx_id"`
+	} `json:"license"`
+}
+
+type githubRelease struct {
+	Name        string    `json:"name"`
+	TagName     string    `json:"tag_name"`
+	HTMLURL     string    `json:"html_url"`
+	PublishedAt time.Time `json:"published_at"`
+}
+
+// Fetch gets a repository and its latest release
+func (g *GitHub) Fetch(path string) (*Repository, error) {
+	gr := &githubRepository{}
+	if err := g.get(fmt.Sprintf("https://api.github.com/repos/%v", path), gr); err != nil {
+		return nil, err
+	}
+
+	r := &Repository{
+		Path:        gr.FullName,
+		Description: gr.Description,
+		URL:         gr.HTMLURL,
+		Language:    gr.Language,
+		Stars:       gr.StargazersCount,
+		Forks:       gr.ForksCount,
+		OpenIssues:  gr.OpenIssuesCount,
+		Provider:    GitHubProvider,
+	}
+
+	if gr.License != nil && gr.License.SPDXID != "NOASSERTION" {
+		r.License = gr.License.SPDXID
+	}
+
+	rel := &githubRelease{}
+	switch err := g.get(fmt.Sprintf("https://api.github.com/repos/%v/releases/latest", path), rel); err {
+	case nil:
+		r.LatestRelease = &Release{
+			Name:      rel.Name,
+			Tag:       rel.TagName,
+			URL:       rel.HTMLURL,
+			Published: rel.PublishedAt,
+		}
+	case ErrNotFound: // plenty of repositories never publish a release
+	default:
+		return nil, err
+	}
+
+	return r, nil
+}
+
+func (g *GitHub) get(u string, v interface{}) error {
+	req, err := http.NewRequest("GET", u, nil)
+	if err != nil {
+		return err
+	}
+
+	req.Header.Set("Accept", "application/vnd.github+json")
+	if g.Token != "" {
+		req.Header.Set("Authorization", "token "+g.Token)
+	}
+
+	resp, err := g.HTTPClient.Do(req)
+	if err != nil {
+		return err
+	}
+
+	defer resp.Body.Close()
+
+	switch resp.StatusCode {
+	case http.StatusOK:
+	case http.StatusNotFound:
+		return ErrNotFound
+	default:
+		return fmt.Errorf("github returned %v", resp.Status)
+	}
+
+	return json.NewDecoder(resp.Body).Decode(v)
+}
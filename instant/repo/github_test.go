@@ -0,0 +1,91 @@
+package repo
+
+import (
+	"net/http"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/jarcoal/httpmock"
+)
+
+func TestGitHubFetch(t *testing.T) {
+	httpmock.Activate()
+	defer httpmock.DeactivateAndReset()
+
+	for _, tt := range []struct {
+		path    string
+		repo    string
+		release int
+		latest  string
+		want    *Repository
+	}{
+		{
+			path:    "golang/go",
+			repo:    `{"full_name":"golang/go","description":"The Go programming language","html_url":"https://github.com/golang/go","language":"Go","stargazers_count":118000,"forks_count":17000,"open_issues_count":9000,"license":{"key":"bsd-3-clause","spdx_id":"BSD-3-Clause"}}`,
+			release: 200,
+			latest:  `{"name":"go1.21.0","tag_name":"go1.21.0","html_url":"https://github.com/golang/go/releases/tag/go1.21.0","published_at":"2023-08-08T15:00:00Z"}`,
+			want: &Repository{
+				Path:        "golang/go",
+				Description: "The Go programming language",
+				URL:         "https://github.com/golang/go",
+				Language:    "Go",
+				Stars:       118000,
+				Forks:       17000,
+				OpenIssues:  9000,
+				License:     "BSD-3-Clause",
+				LatestRelease: &Release{
+					Name:      "go1.21.0",
+					Tag:       "go1.21.0",
+					URL:       "https://github.com/golang/go/releases/tag/go1.21.0",
+					Published: time.Date(2023, 8, 8, 15, 0, 0, 0, time.UTC),
+				},
+				Provider: GitHubProvider,
+			},
+		},
+		{
+			path:    "jivesearch/jivesearch",
+			repo:    `{"full_name":"jivesearch/jivesearch","description":"A search engine that doesn't track you.","html_url":"https://github.com/jivesearch/jivesearch","language":"Go","stargazers_count":1300,"forks_count":100,"open_issues_count":20,"license":{"key":"other","spdx_id":"NOASSERTION"}}`,
+			release: 404,
+			latest:  `{"message":"Not Found"}`,
+			want: &Repository{
+				Path:        "jivesearch/jivesearch",
+				Description: "A search engine that doesn't track you.",
+				URL:         "https://github.com/jivesearch/jivesearch",
+				Language:    "Go",
+				Stars:       1300,
+				Forks:       100,
+				OpenIssues:  20,
+				Provider:    GitHubProvider,
+			},
+		},
+	} {
+		t.Run(tt.path, func(t *testing.T) {
+			httpmock.RegisterResponder("GET", "https://api.github.com/repos/"+tt.path, httpmock.NewStringResponder(200, tt.repo))
+			httpmock.RegisterResponder("GET", "https://api.github.com/repos/"+tt.path+"/releases/latest", httpmock.NewStringResponder(tt.release, tt.latest))
+
+			g := &GitHub{HTTPClient: &http.Client{}}
+			got, err := g.Fetch(tt.path)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Fatalf("got %+v; want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestGitHubNotFound(t *testing.T) {
+	httpmock.Activate()
+	defer httpmock.DeactivateAndReset()
+
+	httpmock.RegisterResponder("GET", "https://api.github.com/repos/asdf/ghjk",
+		httpmock.NewStringResponder(404, `{"message":"Not Found"}`))
+
+	g := &GitHub{HTTPClient: &http.Client{}}
+	if _, err := g.Fetch("asdf/ghjk"); err != ErrNotFound {
+		t.Fatalf("got %v; want %v", err, ErrNotFound)
+	}
+}
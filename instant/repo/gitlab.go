@@ -0,0 +1,126 @@
+package repo
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"time"
+)
+
+// GitLab retrieves repositories from GitLab's API
+type GitLab struct {
+	HTTPClient *http.Client
+	Token      string // optional
+}
+
+// GitLabProvider is a code hosting service
+var GitLabProvider Provider = "GitLab"
+
+type gitlabProject struct {
+	PathWithNamespace string `json:"path_with_namespace"`
+	Description       string `json:"description"`
+	WebURL            string `json:"web_url"`
+	StarCount         int    `json:"star_count"`
+	ForksCount        int    `json:"forks_count"`
+	OpenIssuesCount   int    `json:"open_issues_count"`
+	License           *struct {
+		Nickname string `json:"nickname"`
+		Key      string `json:"key"`
+	} `json:"license"`
+}
+
+type gitlabRelease struct {
+	Name       string    `json:"name"`
+	TagName    string    `json:"tag_name"`
+	ReleasedAt time.Time `json:"released_at"`
+	Links      struct {
+		Self string `json:"self"`
+	} `json:"_links"`
+}
+
+// Fetch gets a project, its main language and latest release.
+// Projects can be nested in subgroups so the path may have more than two parts.
+func (g *GitLab) Fetch(path string) (*Repository, error) {
+	base := "https://gitlab.com/api/v4/projects/" + url.PathEscape(path)
+
+	p := &gitlabProject{}
+	if err := g.get(base+"?license=true", p); err != nil {
+		return nil, err
+	}
+
+	r := &Repository{
+		Path:        p.PathWithNamespace,
+		Description: p.Description,
+		URL:         p.WebURL,
+		Stars:       p.StarCount,
+		Forks:       p.ForksCount,
+		OpenIssues:  p.OpenIssuesCount,
+		Provider:    GitLabProvider,
+	}
+
+	if p.License != nil {
+		r.License = p.License.Nickname
+		if r.License == "" {
+			r.License = p.License.Key
+		}
+	}
+
+	// percentages of the code in each language
+	languages := map[string]float64{}
+	if err := g.get(base+"/languages", &languages); err != nil {
+		return nil, err
+	}
+
+	var most float64
+	for l, pct := range languages {
+		if pct > most || (pct == most && l < r.Language) {
+			r.Language, most = l, pct
+		}
+	}
+
+	// newest first
+	releases := []gitlabRelease{}
+	if err := g.get(base+"/releases?per_page=1", &releases); err != nil {
+		return nil, err
+	}
+
+	if len(releases) > 0 {
+		r.LatestRelease = &Release{
+			Name:      releases[0].Name,
+			Tag:       releases[0].TagName,
+			URL:       releases[0].Links.Self,
+			Published: releases[0].ReleasedAt,
+		}
+	}
+
+	return r, nil
+}
+
+func (g *GitLab) get(u string, v interface{}) error {
+	req, err := http.NewRequest("GET", u, nil)
+	if err != nil {
+		return err
+	}
+
+	if g.Token != "" {
+		req.Header.Set("PRIVATE-TOKEN", g.Token)
+	}
+
+	resp, err := g.HTTPClient.Do(req)
+	if err != nil {
+		return err
+	}
+
+	defer resp.Body.Close()
+
+	switch resp.StatusCode {
+	case http.StatusOK:
+	case http.StatusNotFound:
+		return ErrNotFound
+	default:
+		return fmt.Errorf("gitlab returned %v", resp.Status)
+	}
+
+	return json.NewDecoder(resp.Body).Decode(v)
+}
@@ -0,0 +1,65 @@
+package repo
+
+import (
+	"net/http"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/jarcoal/httpmock"
+)
+
+func TestGitLabFetch(t *testing.T) {
+	httpmock.Activate()
+	defer httpmock.DeactivateAndReset()
+
+	base := "https://gitlab.com/api/v4/projects/gitlab-org%2Fgitlab-runner"
+
+	httpmock.RegisterResponder("GET", base+"?license=true", httpmock.NewStringResponder(200,
+		`{"path_with_namespace":"gitlab-org/gitlab-runner","description":"GitLab Runner","web_url":"https://gitlab.com/gitlab-org/gitlab-runner","star_count":2400,"forks_count":4300,"open_issues_count":3200,"license":{"key":"mit","name":"MIT License","nickname":""}}`))
+	httpmock.RegisterResponder("GET", base+"/languages", httpmock.NewStringResponder(200,
+		`{"Go":95.5,"Shell":2.5,"PowerShell":2.0}`))
+	httpmock.RegisterResponder("GET", base+"/releases?per_page=1", httpmock.NewStringResponder(200,
+		`[{"name":"v16.2.0","tag_name":"v16.2.0","released_at":"2023-07-20T10:00:00Z","_links":{"self":"https://gitlab.com/gitlab-org/gitlab-runner/-/releases/v16.2.0"}}]`))
+
+	want := &Repository{
+		Path:        "gitlab-org/gitlab-runner",
+		Description: "GitLab Runner",
+		URL:         "https://gitlab.com/gitlab-org/gitlab-runner",
+		Language:    "Go",
+		Stars:       2400,
+		Forks:       4300,
+		OpenIssues:  3200,
+		License:     "mit",
+		LatestRelease: &Release{
+			Name:      "v16.2.0",
+			Tag:       "v16.2.0",
+			URL:       "https://gitlab.com/gitlab-org/gitlab-runner/-/releases/v16.2.0",
+			Published: time.Date(2023, 7, 20, 10, 0, 0, 0, time.UTC),
+		},
+		Provider: GitLabProvider,
+	}
+
+	g := &GitLab{HTTPClient: &http.Client{}}
+	got, err := g.Fetch("gitlab-org/gitlab-runner")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("got %+v; want %+v", got, want)
+	}
+}
+
+func TestGitLabNotFound(t *testing.T) {
+	httpmock.Activate()
+	defer httpmock.DeactivateAndReset()
+
+	httpmock.RegisterResponder("GET", "https://gitlab.com/api/v4/projects/asdf%2Fghjk?license=true",
+		httpmock.NewStringResponder(404, `{"message":"404 Project Not Found"}`))
+
+	g := &GitLab{HTTPClient: &http.Client{}}
+	if _, err := g.Fetch("asdf/ghjk"); err != ErrNotFound {
+		t.Fatalf("got %v; want %v", err, ErrNotFound)
+	}
+}
@@ -0,0 +1,25 @@
+package repo
+
+import (
+	"strings"
+
+	"github.com/jivesearch/jivesearch/instant/throttle"
+)
+
+// Limited caches and rate limits another Fetcher's lookups
+type Limited struct {
+	Fetcher
+	*throttle.Throttle
+}
+
+// Fetch gets a repository by its path
+func (l *Limited) Fetch(path string) (*Repository, error) {
+	v, err := l.Do(strings.ToLower(path), func() (interface{}, error) {
+		return l.Fetcher.Fetch(path)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return v.(*Repository), nil
+}
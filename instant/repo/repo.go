@@ -0,0 +1,40 @@
+// Package repo fetches code repositories from GitHub and GitLab
+package repo
+
+import (
+	"errors"
+	"time"
+)
+
+// Fetcher fetches a repository by its path, e.g. "golang/go"
+type Fetcher interface {
+	Fetch(path string) (*Repository, error)
+}
+
+// Provider is a code hosting service
+type Provider string
+
+// ErrNotFound indicates there is no public repository at that path
+var ErrNotFound = errors.New("repository not found")
+
+// Repository is a code repository
+type Repository struct {
+	Path          string   `json:"path"`
+	Description   string   `json:"description"`
+	URL           string   `json:"url"`
+	Language      string   `json:"language"`
+	Stars         int      `json:"stars"`
+	Forks         int      `json:"forks"`
+	OpenIssues    int      `json:"open_issues"`
+	License       string   `json:"license,omitempty"`
+	LatestRelease *Release `json:"latest_release,omitempty"`
+	Provider      Provider `json:"provider"`
+}
+
+// Release is a published version of a repository
+type Release struct {
+	Name      string    `json:"name"`
+	Tag       string    `json:"tag"`
+	URL       string    `json:"url"`
+	Published time.Time `json:"published"`
+}
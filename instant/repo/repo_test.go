@@ -0,0 +1,44 @@
+package repo
+
+import (
+	"testing"
+	"time"
+
+	"github.com/jivesearch/jivesearch/instant/throttle"
+)
+
+type mockFetcher struct {
+	fetches int
+}
+
+func (m *mockFetcher) Fetch(path string) (*Repository, error) {
+	m.fetches++
+	return &Repository{Path: path}, nil
+}
+
+func TestLimited(t *testing.T) {
+	m := &mockFetcher{}
+	l := &Limited{
+		Fetcher:  m,
+		Throttle: &throttle.Throttle{Rate: 1, Burst: 1, TTL: time.Minute},
+	}
+
+	for _, path := range []string{"golang/go", "Golang/Go"} {
+		got, err := l.Fetch(path)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if got.Path != "golang/go" {
+			t.Fatalf("got %q; want the cached golang/go", got.Path)
+		}
+	}
+
+	if m.fetches != 1 {
+		t.Fatalf("got %d fetches; want 1", m.fetches)
+	}
+
+	if _, err := l.Fetch("rust-lang/rust"); err != throttle.ErrLimited {
+		t.Fatalf("got %v; want %v", err, throttle.ErrLimited)
+	}
+}
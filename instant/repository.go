@@ -0,0 +1,146 @@
+package instant
+
+import (
+	"net/http"
+	"regexp"
+	"strings"
+
+	"github.com/jivesearch/jivesearch/instant/repo"
+	"github.com/jivesearch/jivesearch/search/intent"
+	"golang.org/x/text/language"
+)
+
+// RepositoryType is an answer Type
+const RepositoryType Type = "repository"
+
+// Repository is an instant answer for GitHub and GitLab repositories
+type Repository struct {
+	GitHubFetcher repo.Fetcher
+	GitLabFetcher repo.Fetcher
+	Answer
+}
+
+func (r *Repository) setQuery(req *http.Request, qv string) Answerer {
+	r.Answer.setQuery(req, qv)
+	return r
+}
+
+func (r *Repository) setUserAgent(req *http.Request) Answerer {
+	return r
+}
+
+func (r *Repository) setLanguage(lang language.Tag) Answerer {
+	r.language = lang
+	return r
+}
+
+func (r *Repository) setType() Answerer {
+	r.Type = RepositoryType
+	return r
+}
+
+func (r *Repository) setRegex() Answerer {
+	path := `[\w.-]+(?:/[\w.-]+)+`
+	r.regex = append(r.regex, regexp.MustCompile(`^(?:https?://)?(?:www\.)?(?P<trigger>github|gitlab)\.com/(?P<remainder>`+path+`(?:/-/.*)?)/?$`))
+	r.regex = append(r.regex, regexp.MustCompile(`^(?P<remainder>`+path+`) (?P<trigger>github|gitlab)(?: repo| repository)?$`))
+	r.regex = append(r.regex, regexp.MustCompile(`^(?P<trigger>github|gitlab)(?: repo| repository)? (?P<remainder>`+path+`)$`))
+	return r
+}
+
+func (r *Repository) solve(req *http.Request) Answerer {
+	p := strings.TrimSuffix(r.remainder, ".git")
+	f := r.GitHubFetcher
+
+	switch r.triggerWord {
+	case "github":
+		// github repositories are always owner/name. The rest is a file, branch, issue, etc.
+		if parts := strings.SplitN(p, "/", 3); len(parts) > 2 {
+			p = strings.Join(parts[:2], "/")
+		}
+	case "gitlab":
+		// gitlab projects can be nested in subgroups so everything before the /-/ is the project
+		p = strings.SplitN(p, "/-/", 2)[0]
+		f = r.GitLabFetcher
+	}
+
+	rp, err := f.Fetch(p)
+	if err != nil {
+		r.Err = err
+		return r
+	}
+
+	r.Solution = rp
+	return r
+}
+
+func (r *Repository) tests() []test {
+	golang := &repo.Repository{
+		Path:        "golang/go",
+		Description: "The Go programming language",
+		URL:         "https://github.com/golang/go",
+		Language:    "Go",
+		Stars:       118000,
+		Forks:       17000,
+		OpenIssues:  9000,
+		License:     "BSD-3-Clause",
+		LatestRelease: &repo.Release{
+			Name: "go1.21.0",
+			Tag:  "go1.21.0",
+			URL:  "https://github.com/golang/go/releases/tag/go1.21.0",
+		},
+		Provider: repo.GitHubProvider,
+	}
+
+	runner := &repo.Repository{
+		Path:        "gitlab-org/gitlab-runner",
+		Description: "GitLab Runner",
+		URL:         "https://gitlab.com/gitlab-org/gitlab-runner",
+		Language:    "Go",
+		Stars:       2400,
+		Forks:       4300,
+		OpenIssues:  3200,
+		License:     "mit",
+		Provider:    repo.GitLabProvider,
+	}
+
+	tests := []test{}
+
+	for _, c := range []struct {
+		query string
+		repo  *repo.Repository
+	}{
+		{"golang/go github", golang},
+		{"github golang/go", golang},
+		{"https://github.com/golang/go", golang},
+		{"github.com/golang/go.git", golang},
+		{"https://github.com/golang/go/tree/master/src/net/http", golang},
+		{"gitlab-org/gitlab-runner gitlab repo", runner},
+		{"https://gitlab.com/gitlab-org/gitlab-runner/-/issues/123", runner},
+	} {
+		tests = append(tests, test{
+			query: c.query,
+			expected: []Data{
+				{
+					Type:      RepositoryType,
+					Triggered: true,
+					Solution:  c.repo,
+				},
+			},
+		})
+	}
+
+	return tests
+}
+
+func init() {
+	Register(Registration{
+		Name:     "repository",
+		Trigger:  `a GitHub or GitLab repository, e.g. "golang/go github" or its URL`,
+		Priority: 540,
+		Intent:   intent.Navigational,
+		External: true,
+		New: func(i *Instant) Answerer {
+			return &Repository{GitHubFetcher: i.GitHubFetcher, GitLabFetcher: i.GitLabFetcher}
+		},
+	})
+}
@@ -108,3 +108,14 @@ func (r *Reverse) tests() []test {
 
 	return tests
 }
+
+func init() {
+	Register(Registration{
+		Name:     "reverse",
+		Trigger:  `"reverse" and some text`,
+		Priority: 260,
+		New: func(i *Instant) Answerer {
+			return &Reverse{}
+		},
+	})
+}
@@ -95,3 +95,15 @@ func (s *Shortener) tests() []test {
 
 	return tests
 }
+
+func init() {
+	Register(Registration{
+		Name:     "shortener",
+		Trigger:  `"shorten" or "url shortener" and a url`,
+		Priority: 270,
+		External: true,
+		New: func(i *Instant) Answerer {
+			return &Shortener{Service: i.LinkShortener}
+		},
+	})
+}
@@ -0,0 +1,99 @@
+package instant
+
+import (
+	"net/http"
+	"regexp"
+
+	disc "github.com/jivesearch/jivesearch/instant/discography"
+	"github.com/jivesearch/jivesearch/search/intent"
+	"golang.org/x/text/language"
+)
+
+// SongwriterType is an answer Type
+const SongwriterType Type = "songwriter"
+
+// Songwriter is an instant answer
+type Songwriter struct {
+	Fetcher disc.SongwriterFetcher
+	Answer
+}
+
+func (s *Songwriter) setQuery(r *http.Request, qv string) Answerer {
+	s.Answer.setQuery(r, qv)
+	return s
+}
+
+func (s *Songwriter) setUserAgent(r *http.Request) Answerer {
+	return s
+}
+
+func (s *Songwriter) setLanguage(lang language.Tag) Answerer {
+	s.language = lang
+	return s
+}
+
+func (s *Songwriter) setType() Answerer {
+	s.Type = SongwriterType
+	return s
+}
+
+func (s *Songwriter) setRegex() Answerer {
+	s.regex = append(s.regex, regexp.MustCompile(`^who (?P<trigger>wrote|composed|penned)(?: the song)? (?P<remainder>.+)$`))
+	s.regex = append(s.regex, regexp.MustCompile(`^(?P<trigger>songwriters?|composer|writers?) (?:of|for) (?:the song )?(?P<remainder>.+)$`))
+	s.regex = append(s.regex, regexp.MustCompile(`^(?P<remainder>.+) (?P<trigger>songwriters?)$`))
+	return s
+}
+
+func (s *Songwriter) solve(r *http.Request) Answerer {
+	song, err := s.Fetcher.FetchSongwriters(s.remainder)
+	if err != nil {
+		s.Err = err
+		return s
+	}
+
+	s.Solution = song
+	return s
+}
+
+func (s *Songwriter) tests() []test {
+	bohemian := &disc.Song{
+		Name: "Bohemian Rhapsody",
+		Writers: []disc.Songwriter{
+			{Name: "Freddie Mercury", Roles: []string{"composer", "lyricist"}},
+		},
+	}
+
+	tests := []test{}
+
+	for _, q := range []string{
+		"who wrote bohemian rhapsody",
+		"who wrote the song bohemian rhapsody",
+		"songwriter of bohemian rhapsody",
+		"bohemian rhapsody songwriters",
+	} {
+		tests = append(tests, test{
+			query: q,
+			expected: []Data{
+				{
+					Type:      SongwriterType,
+					Triggered: true,
+					Solution:  bohemian,
+				},
+			},
+		})
+	}
+
+	return tests
+}
+
+func init() {
+	Register(Registration{
+		Name:     "songwriter",
+		Trigger:  `"who wrote" and a song, e.g. "who wrote bohemian rhapsody"`,
+		Priority: 530,
+		Intent:   intent.Informational,
+		New: func(i *Instant) Answerer {
+			return &Songwriter{Fetcher: i.SongwriterFetcher}
+		},
+	})
+}
@@ -93,3 +93,14 @@ func (s *Speed) tests() []test {
 
 	return tests
 }
+
+func init() {
+	Register(Registration{
+		Name:     "speed",
+		Trigger:  `units of speed to convert, e.g. "60 mph to kmh"`,
+		Priority: 160,
+		New: func(i *Instant) Answerer {
+			return &Speed{}
+		},
+	})
+}
@@ -2,11 +2,13 @@ package instant
 
 import (
 	"fmt"
+	"html"
 	"net/http"
 	"regexp"
 	"strings"
 
 	so "github.com/jivesearch/jivesearch/instant/stackoverflow"
+	"github.com/jivesearch/jivesearch/search/intent"
 	"golang.org/x/text/language"
 )
 
@@ -24,13 +26,17 @@ type StackOverflow struct {
 type StackOverflowAnswer struct {
 	Question string
 	Link     string
+	Language string // the tag used to highlight the snippet
 	Answer   SOAnswer
 }
 
 // SOAnswer is the answer portion of a SO question
 type SOAnswer struct {
-	User string
-	Text string
+	User     string
+	UserLink string
+	Accepted bool
+	Text     string
+	Snippet  string // the first code block of the answer, unescaped
 }
 
 func (s *StackOverflow) setQuery(r *http.Request, qv string) Answerer {
@@ -81,6 +87,18 @@ func (s *StackOverflow) setRegex() Answerer {
 	t := strings.Join(triggers, "|")
 	s.regex = append(s.regex, regexp.MustCompile(fmt.Sprintf(`^(?P<trigger>%s) (?P<remainder>.*)$`, t)))
 	s.regex = append(s.regex, regexp.MustCompile(fmt.Sprintf(`^(?P<remainder>.*) (?P<trigger>%s)$`, t)))
+	s.regex = append(s.regex, regexp.MustCompile(fmt.Sprintf(`^(?P<remainder>how (?:to|do i|do you|can i) .+) (?:in|with|using) (?P<trigger>%s) .+$`, t)))
+
+	// error messages are searched as is, without a tag
+	errs := []string{
+		`(?:type|syntax|reference|range|name|value|key|index|attribute|import|modulenotfound|filenotfound|zerodivision|unboundlocal|recursion|runtime|assertion|unicodedecode|unicodeencode)error`,
+		`[a-z.]+exception`,
+		"segmentation fault", "undefined reference to", "cannot find symbol", "command not found",
+		"is not a function", "is not defined", "cannot read propert(?:y|ies) of", "unexpected token",
+		"object is not subscriptable", "object has no attribute", "nil pointer dereference", "panic:",
+	}
+
+	s.regex = append(s.regex, regexp.MustCompile(fmt.Sprintf(`^(?P<remainder>.*\b(?:%s).*)$`, strings.Join(errs, "|"))))
 
 	return s
 }
@@ -107,35 +125,66 @@ func tagger(txt string) string {
 func (s *StackOverflow) solve(r *http.Request) Answerer {
 	a := &StackOverflowAnswer{}
 
-	resp, err := s.Fetch(s.remainder, []string{tagger(s.triggerWord)})
+	var tags []string
+	if s.triggerWord != "" {
+		tags = []string{tagger(s.triggerWord)}
+	}
+
+	resp, err := s.Fetch(s.remainder, tags)
 	if err != nil {
 		s.Err = err
 		return s
 	}
 
-	// Find the answer with the most votes
-	// Is there a way to return just this answer in the API?
+	// Take the accepted answer, or the one with the most votes if none was accepted
 	var score int
 	for _, item := range resp.Items {
 		a.Question = item.Title
 		a.Link = item.Link
 
+		a.Language = tagger(s.triggerWord)
+		if a.Language == "" && len(item.Tags) > 0 {
+			a.Language = item.Tags[0]
+		}
+
 		for _, answer := range item.Answers {
-			if answer.Score >= score {
-				score = answer.Score
-				a.Answer = SOAnswer{
-					User: answer.Owner.DisplayName,
-					Text: answer.Body,
-				}
+			if a.Answer.Accepted || (!answer.IsAccepted && answer.Score < score) {
+				continue
+			}
+
+			score = answer.Score
+			a.Answer = SOAnswer{
+				User:     answer.Owner.DisplayName,
+				UserLink: answer.Owner.Link,
+				Accepted: answer.IsAccepted,
+				Text:     answer.Body,
+				Snippet:  snippet(answer.Body),
 			}
 		}
 	}
 
+	if a.Answer.Text == "" {
+		s.Err = fmt.Errorf("no stack overflow answer for %q", s.remainder)
+		return s
+	}
+
 	s.Data.Solution = a
 
 	return s
 }
 
+var codeBlock = regexp.MustCompile(`(?s)<pre[^>]*>\s*<code[^>]*>(.*?)</code>\s*</pre>`)
+
+// snippet is the first code block of an answer's html
+func snippet(body string) string {
+	m := codeBlock.FindStringSubmatch(body)
+	if len(m) < 2 {
+		return ""
+	}
+
+	return strings.TrimSpace(html.UnescapeString(m[1]))
+}
+
 func (s *StackOverflow) tests() []test {
 	tests := []test{
 		{
@@ -147,9 +196,13 @@ func (s *StackOverflow) tests() []test {
 					Solution: &StackOverflowAnswer{
 						Question: "How does PHP &#39;foreach&#39; actually work?",
 						Link:     "https://stackoverflow.com/questions/10057671/how-does-php-foreach-actually-work",
+						Language: "php",
 						Answer: SOAnswer{
-							User: "NikiC",
-							Text: "an answer",
+							User:     "NikiC",
+							UserLink: "https://stackoverflow.com/users/385378/nikic",
+							Accepted: true,
+							Text:     "<p>an answer</p><pre><code>foreach ($array as &amp;$v) {}\n</code></pre>",
+							Snippet:  "foreach ($array as &$v) {}",
 						},
 					},
 				},
@@ -164,6 +217,7 @@ func (s *StackOverflow) tests() []test {
 					Solution: &StackOverflowAnswer{
 						Question: "Some made-up question",
 						Link:     "https://stackoverflow.com/questions/90210/c++-loop",
+						Language: "c++",
 						Answer: SOAnswer{
 							User: "JamesT",
 							Text: "a very good answer",
@@ -181,6 +235,7 @@ func (s *StackOverflow) tests() []test {
 					Solution: &StackOverflowAnswer{
 						Question: "Some made-up question",
 						Link:     "https://stackoverflow.com/questions/90210/go-loop",
+						Language: "go",
 						Answer: SOAnswer{
 							User: "Danny Zuko",
 							Text: "a superbly good answer",
@@ -198,6 +253,7 @@ func (s *StackOverflow) tests() []test {
 					Solution: &StackOverflowAnswer{
 						Question: "Some made-up question",
 						Link:     "https://stackoverflow.com/questions/90210/macos-loop",
+						Language: "macos",
 						Answer: SOAnswer{
 							User: "Danny Zuko",
 							Text: "a superbly good answer",
@@ -215,6 +271,7 @@ func (s *StackOverflow) tests() []test {
 					Solution: &StackOverflowAnswer{
 						Question: "Some made-up question",
 						Link:     "https://stackoverflow.com/questions/90210/regex-loop",
+						Language: "regex",
 						Answer: SOAnswer{
 							User: "Danny Zuko",
 							Text: "a superbly good answer",
@@ -223,7 +280,59 @@ func (s *StackOverflow) tests() []test {
 				},
 			},
 		},
+		{
+			query: "how to sort a map in go by value",
+			expected: []Data{
+				{
+					Type:      StackOverflowType,
+					Triggered: true,
+					Solution: &StackOverflowAnswer{
+						Question: "How can I sort a Map[string]int by its values?",
+						Link:     "https://stackoverflow.com/questions/18695346/how-can-i-sort-a-mapstringint-by-its-values",
+						Language: "go",
+						Answer: SOAnswer{
+							User:     "samol",
+							Accepted: true,
+							Text:     "<pre class=\"lang-go\"><code>sort.Slice(ss, func(i, j int) bool {\n\treturn ss[i].Value &gt; ss[j].Value\n})</code></pre>",
+							Snippet:  "sort.Slice(ss, func(i, j int) bool {\n\treturn ss[i].Value > ss[j].Value\n})",
+						},
+					},
+				},
+			},
+		},
+		{
+			query: "TypeError: undefined is not a function",
+			expected: []Data{
+				{
+					Type:      StackOverflowType,
+					Triggered: true,
+					Solution: &StackOverflowAnswer{
+						Question: "What does &quot;undefined is not a function&quot; mean?",
+						Link:     "https://stackoverflow.com/questions/90210/undefined-is-not-a-function",
+						Language: "javascript",
+						Answer: SOAnswer{
+							User:     "Danny Zuko",
+							Accepted: true,
+							Text:     "<p>you called something that isn't a function</p>",
+						},
+					},
+				},
+			},
+		},
 	}
 
 	return tests
 }
+
+func init() {
+	Register(Registration{
+		Name:     "stackoverflow",
+		Trigger:  `a programming language or tool and a question, e.g. "php loop", or an error message`,
+		Priority: 370,
+		Intent:   intent.Informational,
+		External: true,
+		New: func(i *Instant) Answerer {
+			return &StackOverflow{Fetcher: i.StackOverflowFetcher}
+		},
+	})
+}
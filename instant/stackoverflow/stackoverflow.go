@@ -3,6 +3,7 @@ package stackoverflow
 
 import (
 	"encoding/json"
+	"fmt"
 	"net/http"
 	"net/url"
 	"strings"
@@ -28,55 +29,74 @@ type Response struct {
 
 // Item is a question with answers, a link, and a title
 type Item struct {
-	Answers []Answer `json:"answers"`
-	Link    string   `json:"link"`
-	Title   string   `json:"title"`
+	Answers          []Answer `json:"answers"`
+	AcceptedAnswerID int      `json:"accepted_answer_id"`
+	Link             string   `json:"link"`
+	Tags             []string `json:"tags"`
+	Title            string   `json:"title"`
 }
 
 // Answer is a single answer
 type Answer struct {
-	Owner `json:"owner"`
-	Score int    `json:"score"`
-	Body  string `json:"body"`
+	Owner      `json:"owner"`
+	Score      int    `json:"score"`
+	IsAccepted bool   `json:"is_accepted"`
+	Body       string `json:"body"`
 }
 
 // Owner is the person who answered the question
-// TODO: I wasn't able to get both the User's display name and link to their profile or id.
-// Can select one or the other but not both in their filter.
 type Owner struct {
 	DisplayName string `json:"display_name"`
+	Link        string `json:"link"`
 }
 
+const api = "https://api.stackexchange.com/2.2/"
+
 func (a *API) buildURL(query string, tags []string) (string, error) {
 	// find the question
-	// To edit fields returned, etc...
-	// https://api.stackexchange.com/docs/advanced-search#page=1&pagesize=1&order=desc&sort=relevance&q=sum%20variables&answers=1&tagged=php&filter=!OfZYd4zGqhN8IapZI6RQ6uaya_ZCewDWcGt5p6k_N2q&site=stackoverflow&run=true
-	u, err := url.Parse("https://api.stackexchange.com/2.2/search/advanced")
+	// https://api.stackexchange.com/docs/advanced-search#page=1&pagesize=1&order=desc&sort=relevance&accepted=True&q=sum%20variables&tagged=php&site=stackoverflow&run=true
+	u, err := url.Parse(api + "search/advanced")
 	if err != nil {
 		return "", err
 	}
 
-	// We search questions (ranked by relevancy) with at least 1 answer.
-	// We then take the highest upvoted answer, whether or not it is the accepted answer.
-	// e.g. a search for "php loop": https://stackoverflow.com/search?q=%5Bphp%5D+loop
+	// We search questions (ranked by relevancy) that have an accepted answer.
+	// The default filter includes the accepted answer's id but not the answers themselves.
+	// e.g. a search for "php loop": https://stackoverflow.com/search?q=%5Bphp%5D+loop+hasaccepted%3Ayes
 	// TODO: make params more configurable
 	q := u.Query()
 	q.Set("key", a.Key)
 	q.Set("q", query)
-	q.Set("tagged", strings.Join(tags, ","))
+	if len(tags) > 0 { // error messages are searched without a tag
+		q.Set("tagged", strings.Join(tags, ";"))
+	}
 	q.Set("page", "1")
 	q.Set("pagesize", "1")
 	q.Set("order", "desc")
 	q.Set("sort", "relevance")
-	q.Set("answers", "1") // has at least 1 answer
+	q.Set("accepted", "True")
+	q.Set("site", "stackoverflow")
+	u.RawQuery = q.Encode()
+
+	return u.String(), nil
+}
+
+func (a *API) answerURL(id int) (string, error) {
+	u, err := url.Parse(fmt.Sprintf("%vanswers/%d", api, id))
+	if err != nil {
+		return "", err
+	}
+
+	q := u.Query()
+	q.Set("key", a.Key)
 	q.Set("site", "stackoverflow")
-	q.Set("filter", "!OfZYd4zGqhN8IapZI6RQ6uaya_ZBeR7bHr1c)NV5Cu9")
+	q.Set("filter", "withbody") // a built-in filter that adds the body to the default fields
 	u.RawQuery = q.Encode()
 
 	return u.String(), nil
 }
 
-// Fetch retrieves a Stack Overflow response
+// Fetch retrieves the most relevant question and its accepted answer
 func (a *API) Fetch(query string, tags []string) (Response, error) {
 	r := Response{}
 
@@ -85,13 +105,44 @@ func (a *API) Fetch(query string, tags []string) (Response, error) {
 		return r, err
 	}
 
+	if err := a.get(u, &r); err != nil {
+		return r, err
+	}
+
+	for i, item := range r.Items {
+		if item.AcceptedAnswerID == 0 {
+			continue
+		}
+
+		u, err := a.answerURL(item.AcceptedAnswerID)
+		if err != nil {
+			return r, err
+		}
+
+		answers := struct {
+			Items          []Answer `json:"items"`
+			QuotaMax       int      `json:"quota_max"`
+			QuotaRemaining int      `json:"quota_remaining"`
+		}{}
+
+		if err := a.get(u, &answers); err != nil {
+			return r, err
+		}
+
+		r.Items[i].Answers = answers.Items
+		r.QuotaMax, r.QuotaRemaining = answers.QuotaMax, answers.QuotaRemaining
+	}
+
+	return r, nil
+}
+
+func (a *API) get(u string, v interface{}) error {
 	resp, err := a.HTTPClient.Get(u)
 	if err != nil {
-		return r, err
+		return err
 	}
 
 	defer resp.Body.Close()
 
-	err = json.NewDecoder(resp.Body).Decode(&r)
-	return r, err
+	return json.NewDecoder(resp.Body).Decode(v)
 }
@@ -20,31 +20,43 @@ func TestAPIFetch(t *testing.T) {
 	for _, tt := range []struct {
 		name string
 		args
-		u    string
-		resp string
-		want Response
+		u       string
+		resp    string
+		answerU string
+		answer  string
+		want    Response
 	}{
 		{
 			name: "php loop",
 			args: args{"loop", []string{"php"}},
-			u:    `https://api.stackexchange.com/2.2/search/advanced?answers=1&filter=%21OfZYd4zGqhN8IapZI6RQ6uaya_ZBeR7bHr1c%29NV5Cu9&key=&order=desc&page=1&pagesize=1&q=loop&site=stackoverflow&sort=relevance&tagged=php`,
+			u:    `https://api.stackexchange.com/2.2/search/advanced?accepted=True&key=&order=desc&page=1&pagesize=1&q=loop&site=stackoverflow&sort=relevance&tagged=php`,
 			resp: `{
 				"items": [
 					{
-						"answers": [
-							{
-								"owner": {
-								"display_name": "NikiC"
-								},
-								"score": 1273,
-								"body": "an answer"					  
-							}
-						],
+						"tags": ["php", "loops", "foreach"],
+						"accepted_answer_id": 14854568,
 						"link": "https:\/\/stackoverflow.com\/questions\/10057671\/how-does-php-foreach-actually-work",
 						"title": "How does PHP &#39;foreach&#39; actually work?"
 					}
 				],
 				"quota_max": 300,
+				"quota_remaining": 198
+			}`,
+			answerU: `https://api.stackexchange.com/2.2/answers/14854568?filter=withbody&key=&site=stackoverflow`,
+			answer: `{
+				"items": [
+					{
+						"owner": {
+							"display_name": "NikiC",
+							"link": "https:\/\/stackoverflow.com\/users\/385378\/nikic"
+						},
+						"is_accepted": true,
+						"score": 1273,
+						"answer_id": 14854568,
+						"body": "an answer"
+					}
+				],
+				"quota_max": 300,
 				"quota_remaining": 197
 			}`,
 			want: Response{
@@ -54,23 +66,44 @@ func TestAPIFetch(t *testing.T) {
 							{
 								Owner: Owner{
 									DisplayName: "NikiC",
+									Link:        "https://stackoverflow.com/users/385378/nikic",
 								},
-								Score: 1273,
-								Body:  "an answer",
+								Score:      1273,
+								IsAccepted: true,
+								Body:       "an answer",
 							},
 						},
-						Link:  "https://stackoverflow.com/questions/10057671/how-does-php-foreach-actually-work",
-						Title: "How does PHP &#39;foreach&#39; actually work?",
+						AcceptedAnswerID: 14854568,
+						Link:             "https://stackoverflow.com/questions/10057671/how-does-php-foreach-actually-work",
+						Tags:             []string{"php", "loops", "foreach"},
+						Title:            "How does PHP &#39;foreach&#39; actually work?",
 					},
 				},
 				QuotaMax:       300,
 				QuotaRemaining: 197,
 			},
 		},
+		{
+			name: "error message",
+			args: args{"typeerror: undefined is not a function", nil},
+			u:    `https://api.stackexchange.com/2.2/search/advanced?accepted=True&key=&order=desc&page=1&pagesize=1&q=typeerror%3A+undefined+is+not+a+function&site=stackoverflow&sort=relevance`,
+			resp: `{
+				"items": [],
+				"quota_max": 300,
+				"quota_remaining": 196
+			}`,
+			want: Response{
+				Items:          []Item{},
+				QuotaMax:       300,
+				QuotaRemaining: 196,
+			},
+		},
 	} {
 		t.Run(tt.name, func(t *testing.T) {
-			responder := httpmock.NewStringResponder(200, tt.resp)
-			httpmock.RegisterResponder("GET", tt.u, responder)
+			httpmock.RegisterResponder("GET", tt.u, httpmock.NewStringResponder(200, tt.resp))
+			if tt.answerU != "" {
+				httpmock.RegisterResponder("GET", tt.answerU, httpmock.NewStringResponder(200, tt.answer))
+			}
 
 			a := &API{
 				Key:        "",
@@ -175,5 +175,14 @@ func sum(numbers []float64) float64 {
 }
 
 func init() {
+	Register(Registration{
+		Name:     "stats",
+		Trigger:  `"average", "median", "sum", etc. and some numbers`,
+		Priority: 280,
+		New: func(i *Instant) Answerer {
+			return &Stats{}
+		},
+	})
+
 	reStats = regexp.MustCompile(`[-+]?[0-9]*\.?[0-9]+([eE][-+]?[0-9]+)?`)
 }
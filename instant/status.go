@@ -105,3 +105,15 @@ func (s *Status) tests() []test {
 
 	return tests
 }
+
+func init() {
+	Register(Registration{
+		Name:     "status",
+		Trigger:  `"is it down" or "status of" and a domain`,
+		Priority: 290,
+		External: true,
+		New: func(i *Instant) Answerer {
+			return &Status{Fetcher: i.StatusFetcher}
+		},
+	})
+}
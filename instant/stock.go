@@ -8,6 +8,7 @@ import (
 	"time"
 
 	"github.com/jivesearch/jivesearch/instant/stock"
+	"github.com/jivesearch/jivesearch/search/intent"
 	"golang.org/x/text/language"
 )
 
@@ -76,6 +77,11 @@ func (s *StockQuote) solve(r *http.Request) Answerer {
 
 	resp = resp.SortHistorical()
 
+	// a bare ticker might just as well be a word, e.g. "apple"
+	if s.triggerWord == "" {
+		s.Confidence *= .7
+	}
+
 	s.Data.Solution = resp
 	return s
 }
@@ -138,3 +144,16 @@ func (s *StockQuote) tests() []test {
 
 	return tests
 }
+
+func init() {
+	Register(Registration{
+		Name:     "stock_quote",
+		Trigger:  `a ticker symbol, optionally with "stock quote", e.g. "aapl quote"`,
+		Priority: 300,
+		Intent:   intent.Transactional,
+		External: true,
+		New: func(i *Instant) Answerer {
+			return &StockQuote{Fetcher: i.StockQuoteFetcher}
+		},
+	})
+}
@@ -97,3 +97,14 @@ func (t *Temperature) tests() []test {
 
 	return tests
 }
+
+func init() {
+	Register(Registration{
+		Name:     "temperature",
+		Trigger:  `units of temperature to convert, e.g. "32 f to c"`,
+		Priority: 310,
+		New: func(i *Instant) Answerer {
+			return &Temperature{}
+		},
+	})
+}
@@ -0,0 +1,93 @@
+// Package throttle caches and rate limits calls to outside services
+package throttle
+
+import (
+	"errors"
+	"math"
+	"sync"
+	"time"
+)
+
+// ErrLimited indicates we've made too many calls recently
+var ErrLimited = errors.New("too many lookups, try again later")
+
+// Throttle caches results and limits how many calls go out.
+// The limit is a token bucket shared by every user: Burst calls at once, refilled at Rate per second.
+// A zero Rate or Burst means no limit.
+type Throttle struct {
+	Rate  float64
+	Burst int
+	TTL   time.Duration // how long to cache a result
+
+	mu     sync.Mutex
+	cache  map[string]result
+	tokens float64
+	last   time.Time
+}
+
+type result struct {
+	value   interface{}
+	err     error
+	expires time.Time
+}
+
+var now = func() time.Time { return time.Now().UTC() }
+
+// Do returns the cached result for key or, if we have a token to spare, calls fn.
+// Errors are cached too so a bad query can't be used to drain the bucket.
+func (t *Throttle) Do(key string, fn func() (interface{}, error)) (interface{}, error) {
+	t.mu.Lock()
+
+	n := now()
+
+	if r, ok := t.cache[key]; ok && n.Before(r.expires) {
+		t.mu.Unlock()
+		return r.value, r.err
+	}
+
+	if !t.take(n) {
+		t.mu.Unlock()
+		return nil, ErrLimited
+	}
+
+	t.mu.Unlock()
+
+	v, err := fn()
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if t.cache == nil {
+		t.cache = map[string]result{}
+	}
+
+	// drop what has expired so the cache doesn't grow forever
+	for k, r := range t.cache {
+		if !n.Before(r.expires) {
+			delete(t.cache, k)
+		}
+	}
+
+	t.cache[key] = result{value: v, err: err, expires: n.Add(t.TTL)}
+	return v, err
+}
+
+func (t *Throttle) take(n time.Time) bool {
+	if t.Rate <= 0 || t.Burst <= 0 {
+		return true
+	}
+
+	if t.last.IsZero() {
+		t.tokens = float64(t.Burst)
+	} else {
+		t.tokens = math.Min(float64(t.Burst), t.tokens+n.Sub(t.last).Seconds()*t.Rate)
+	}
+	t.last = n
+
+	if t.tokens < 1 {
+		return false
+	}
+
+	t.tokens--
+	return true
+}
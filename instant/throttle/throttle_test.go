@@ -0,0 +1,84 @@
+package throttle
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestDo(t *testing.T) {
+	start := time.Date(2018, 1, 1, 0, 0, 0, 0, time.UTC)
+	now = func() time.Time { return start }
+
+	calls := 0
+	fn := func(v string, err error) func() (interface{}, error) {
+		return func() (interface{}, error) {
+			calls++
+			return v, err
+		}
+	}
+
+	th := &Throttle{Rate: 1, Burst: 2, TTL: time.Minute}
+
+	for i := 0; i < 3; i++ { // only the first call goes out
+		got, err := th.Do("a", fn("93.184.216.34", nil))
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if got != "93.184.216.34" {
+			t.Fatalf("got %v", got)
+		}
+	}
+
+	notFound := errors.New("not found")
+
+	if _, err := th.Do("b", fn("", notFound)); err != notFound {
+		t.Fatalf("got %v; want %v", err, notFound)
+	}
+
+	if _, err := th.Do("b", fn("", notFound)); err != notFound { // cached
+		t.Fatalf("got %v; want %v", err, notFound)
+	}
+
+	if calls != 2 {
+		t.Fatalf("got %d calls; want 2", calls)
+	}
+
+	// the bucket is empty
+	if _, err := th.Do("c", fn("c", nil)); err != ErrLimited {
+		t.Fatalf("got %v; want %v", err, ErrLimited)
+	}
+
+	// a second later there's another token and the cache still holds
+	now = func() time.Time { return start.Add(time.Second) }
+
+	for _, k := range []string{"c", "a"} {
+		if _, err := th.Do(k, fn(k, nil)); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	if calls != 3 {
+		t.Fatalf("got %d calls; want 3", calls)
+	}
+
+	// after the TTL we call it again
+	now = func() time.Time { return start.Add(2 * time.Minute) }
+
+	if _, err := th.Do("a", fn("a", nil)); err != nil {
+		t.Fatal(err)
+	}
+
+	if calls != 4 {
+		t.Fatalf("got %d calls; want 4", calls)
+	}
+
+	// no limit
+	th = &Throttle{}
+	for i := 0; i < 10; i++ {
+		if _, err := th.Do("a", fn("a", nil)); err != nil {
+			t.Fatal(err)
+		}
+	}
+}
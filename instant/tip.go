@@ -0,0 +1,158 @@
+package instant
+
+import (
+	"net/http"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"golang.org/x/text/language"
+)
+
+// TipType is an answer Type
+const TipType Type = "tip"
+
+// Tip is an instant answer that calculates the tip on a bill
+type Tip struct {
+	Answer
+}
+
+// TipResponse is the tip and what each person pays
+type TipResponse struct {
+	Bill      float64 `json:"bill"`
+	Percent   float64 `json:"percent"`
+	Tip       float64 `json:"tip"`
+	Total     float64 `json:"total"`
+	People    int     `json:"people"`
+	PerPerson float64 `json:"per_person"`
+}
+
+// the tip when the query doesn't say
+const defaultTipPercent = 15
+
+func (t *Tip) setQuery(r *http.Request, qv string) Answerer {
+	t.Answer.setQuery(r, qv)
+	return t
+}
+
+func (t *Tip) setUserAgent(r *http.Request) Answerer {
+	return t
+}
+
+func (t *Tip) setLanguage(lang language.Tag) Answerer {
+	t.language = lang
+	return t
+}
+
+func (t *Tip) setType() Answerer {
+	t.Type = TipType
+	return t
+}
+
+func (t *Tip) setRegex() Answerer {
+	bill := `(?: (?:on|for|of))? \$?(?P<bill>\d[\d,]*(?:\.\d+)?)`
+	split := `(?: (?:split|between|for) (?P<people>\d+)(?: ways| people)?)?`
+	t.regex = append(t.regex, regexp.MustCompile(`^(?:(?P<percent>\d+(?:\.\d+)?) ?% )?(?P<trigger>tip)`+bill+split+`$`))
+	t.regex = append(t.regex, regexp.MustCompile(`^(?P<trigger>tip) (?P<percent>\d+(?:\.\d+)?) ?%`+bill+split+`$`))
+	return t
+}
+
+func (t *Tip) solve(r *http.Request) Answerer {
+	bill, err := strconv.ParseFloat(strings.Replace(t.remainderM["bill"], ",", "", -1), 64)
+	if err != nil {
+		t.Err = err
+		return t
+	}
+
+	pct := float64(defaultTipPercent)
+	if p := t.remainderM["percent"]; p != "" {
+		if pct, err = strconv.ParseFloat(p, 64); err != nil {
+			t.Err = err
+			return t
+		}
+	}
+
+	people := 1
+	if p := t.remainderM["people"]; p != "" {
+		if people, err = strconv.Atoi(p); err != nil || people < 1 {
+			people = 1
+		}
+	}
+
+	tip := bill * pct / 100
+	resp := &TipResponse{
+		Bill:      bill,
+		Percent:   pct,
+		Tip:       round2(tip),
+		Total:     round2(bill + tip),
+		People:    people,
+		PerPerson: round2((bill + tip) / float64(people)),
+	}
+
+	t.Solution = resp
+	t.Widget = &Widget{
+		Formula: "tip",
+		Inputs: []WidgetField{
+			{Name: "bill", Label: "Bill", Value: resp.Bill, Format: "currency"},
+			{Name: "percent", Label: "Tip (%)", Value: resp.Percent},
+			{Name: "people", Label: "People", Value: float64(resp.People)},
+		},
+		Outputs: []WidgetField{
+			{Name: "tip", Label: "Tip", Value: resp.Tip, Format: "currency"},
+			{Name: "total", Label: "Total", Value: resp.Total, Format: "currency"},
+			{Name: "per_person", Label: "Per Person", Value: resp.PerPerson, Format: "currency"},
+		},
+	}
+
+	return t
+}
+
+func (t *Tip) tests() []test {
+	tests := []test{}
+
+	for _, tt := range []struct {
+		query string
+		resp  *TipResponse
+	}{
+		{"tip on 84.50", &TipResponse{Bill: 84.5, Percent: 15, Tip: 12.68, Total: 97.18, People: 1, PerPerson: 97.18}},
+		{"20% tip on $120 split 4 ways", &TipResponse{Bill: 120, Percent: 20, Tip: 24, Total: 144, People: 4, PerPerson: 36}},
+		{"tip 18% for 1,250.40", &TipResponse{Bill: 1250.4, Percent: 18, Tip: 225.07, Total: 1475.47, People: 1, PerPerson: 1475.47}},
+	} {
+		tests = append(tests, test{
+			query: tt.query,
+			expected: []Data{
+				{
+					Type:      TipType,
+					Triggered: true,
+					Solution:  tt.resp,
+					Widget: &Widget{
+						Formula: "tip",
+						Inputs: []WidgetField{
+							{Name: "bill", Label: "Bill", Value: tt.resp.Bill, Format: "currency"},
+							{Name: "percent", Label: "Tip (%)", Value: tt.resp.Percent},
+							{Name: "people", Label: "People", Value: float64(tt.resp.People)},
+						},
+						Outputs: []WidgetField{
+							{Name: "tip", Label: "Tip", Value: tt.resp.Tip, Format: "currency"},
+							{Name: "total", Label: "Total", Value: tt.resp.Total, Format: "currency"},
+							{Name: "per_person", Label: "Per Person", Value: tt.resp.PerPerson, Format: "currency"},
+						},
+					},
+				},
+			},
+		})
+	}
+
+	return tests
+}
+
+func init() {
+	Register(Registration{
+		Name:     "tip",
+		Trigger:  `"tip" and the bill, e.g. "20% tip on 84.50 split 3 ways"`,
+		Priority: 560,
+		New: func(i *Instant) Answerer {
+			return &Tip{}
+		},
+	})
+}
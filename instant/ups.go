@@ -136,3 +136,15 @@ func (u *UPS) tests() []test {
 
 	return tests
 }
+
+func init() {
+	Register(Registration{
+		Name:     "ups",
+		Trigger:  `a UPS tracking number`,
+		Priority: 330,
+		External: true,
+		New: func(i *Instant) Answerer {
+			return &UPS{Fetcher: i.UPSFetcher}
+		},
+	})
+}
@@ -71,3 +71,14 @@ func (u *URLDecode) tests() []test {
 
 	return tests
 }
+
+func init() {
+	Register(Registration{
+		Name:     "urldecode",
+		Trigger:  `"urldecode" and some text`,
+		Priority: 340,
+		New: func(i *Instant) Answerer {
+			return &URLDecode{}
+		},
+	})
+}
@@ -71,3 +71,14 @@ func (u *URLEncode) tests() []test {
 
 	return tests
 }
+
+func init() {
+	Register(Registration{
+		Name:     "urlencode",
+		Trigger:  `"urlencode" and some text`,
+		Priority: 350,
+		New: func(i *Instant) Answerer {
+			return &URLEncode{}
+		},
+	})
+}
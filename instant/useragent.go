@@ -121,3 +121,14 @@ func (u *UserAgent) tests() []test {
 
 	return tests
 }
+
+func init() {
+	Register(Registration{
+		Name:     "useragent",
+		Trigger:  `"user agent" or "what is my user agent"`,
+		Priority: 360,
+		New: func(i *Instant) Answerer {
+			return &UserAgent{}
+		},
+	})
+}
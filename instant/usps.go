@@ -116,3 +116,15 @@ func (u *USPS) tests() []test {
 
 	return tests
 }
+
+func init() {
+	Register(Registration{
+		Name:     "usps",
+		Trigger:  `a USPS tracking number`,
+		Priority: 320,
+		External: true,
+		New: func(i *Instant) Answerer {
+			return &USPS{Fetcher: i.USPSFetcher}
+		},
+	})
+}
@@ -0,0 +1,94 @@
+package instant
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"regexp"
+	"strings"
+
+	"golang.org/x/text/language"
+)
+
+// UUIDType is an answer Type
+const UUIDType Type = "uuid"
+
+// UUID is an instant answer
+type UUID struct {
+	Answer
+}
+
+func (u *UUID) setQuery(r *http.Request, qv string) Answerer {
+	u.Answer.setQuery(r, qv)
+	return u
+}
+
+func (u *UUID) setUserAgent(r *http.Request) Answerer {
+	return u
+}
+
+func (u *UUID) setLanguage(lang language.Tag) Answerer {
+	u.language = lang
+	return u
+}
+
+func (u *UUID) setType() Answerer {
+	u.Type = UUIDType
+	return u
+}
+
+func (u *UUID) setRegex() Answerer {
+	triggers := []string{
+		"uuid", "guid", "random uuid", "random guid", "generate uuid", "generate guid",
+		"uuid generator", "guid generator", "uuid v4", "uuid4",
+	}
+
+	t := strings.Join(triggers, "|")
+	u.regex = append(u.regex, regexp.MustCompile(fmt.Sprintf(`^(?P<trigger>%s)$`, t)))
+	return u
+}
+
+// solve generates a random (version 4) UUID
+func (u *UUID) solve(r *http.Request) Answerer {
+	b := make([]byte, 16)
+	if _, err := io.ReadFull(randReader, b); err != nil {
+		u.Err = err
+		return u
+	}
+
+	b[6] = b[6]&0x0f | 0x40 // version 4
+	b[8] = b[8]&0x3f | 0x80 // RFC 4122 variant
+
+	u.Solution = fmt.Sprintf("%x-%x-%x-%x-%x", b[0:4], b[4:6], b[6:8], b[8:10], b[10:])
+	return u
+}
+
+func (u *UUID) tests() []test {
+	tests := []test{}
+
+	for _, q := range []string{"random uuid", "uuid v4", "GUID generator"} {
+		tests = append(tests, test{
+			query: q,
+			expected: []Data{
+				{
+					Type:      UUIDType,
+					Triggered: true,
+					Solution:  "00000000-0000-4000-8000-000000000000", // randReader is mocked
+				},
+			},
+		})
+	}
+
+	return tests
+}
+
+func init() {
+	Register(Registration{
+		Name:     "uuid",
+		Trigger:  `"uuid", "guid" or "random uuid"`,
+		Priority: 410,
+		New: func(i *Instant) Answerer {
+			return &UUID{}
+		},
+	})
+}
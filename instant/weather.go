@@ -11,6 +11,7 @@ import (
 
 	"github.com/jivesearch/jivesearch/instant/location"
 	"github.com/jivesearch/jivesearch/instant/weather"
+	"github.com/jivesearch/jivesearch/search/intent"
 	"golang.org/x/text/language"
 )
 
@@ -98,7 +99,7 @@ func (w *Weather) city(r *http.Request) *Weather {
 func (w *Weather) local(r *http.Request) *Weather {
 	// fetch by lat/long. On localhost this will likely give you weather for "Earth"
 	w.Type = "local weather"
-	ip := getIPAddress(r)
+	ip := IPAddress(r)
 
 	city, err := w.LocationFetcher.Fetch(ip)
 	if err != nil {
@@ -267,3 +268,16 @@ func (w *Weather) tests() []test {
 
 	return tests
 }
+
+func init() {
+	Register(Registration{
+		Name:     "weather",
+		Trigger:  `"weather" and optionally a place or zip code`,
+		Priority: 380,
+		Intent:   intent.Local,
+		External: true,
+		New: func(i *Instant) Answerer {
+			return &Weather{Fetcher: i.WeatherFetcher, LocationFetcher: i.LocationFetcher}
+		},
+	})
+}
@@ -85,3 +85,15 @@ func (w *WHOIS) tests() []test {
 
 	return tests
 }
+
+func init() {
+	Register(Registration{
+		Name:     "whois",
+		Trigger:  `"whois" and a domain`,
+		Priority: 390,
+		External: true,
+		New: func(i *Instant) Answerer {
+			return &WHOIS{Fetcher: i.WHOISFetcher}
+		},
+	})
+}
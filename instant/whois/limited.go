@@ -0,0 +1,23 @@
+package whois
+
+import (
+	"github.com/jivesearch/jivesearch/instant/throttle"
+)
+
+// Limited caches and rate limits another Fetcher's lookups
+type Limited struct {
+	Fetcher
+	*throttle.Throttle
+}
+
+// Fetch retrieves WHOIS information for a domain
+func (l *Limited) Fetch(domain string) (*Response, error) {
+	v, err := l.Do(domain, func() (interface{}, error) {
+		return l.Fetcher.Fetch(domain)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return v.(*Response), nil
+}
@@ -1 +1,45 @@
 package whois
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/jivesearch/jivesearch/instant/throttle"
+)
+
+type mockFetcher struct {
+	lookups int
+}
+
+func (m *mockFetcher) Fetch(domain string) (*Response, error) {
+	m.lookups++
+	return &Response{Domain: domain}, nil
+}
+
+func TestLimited(t *testing.T) {
+	m := &mockFetcher{}
+	l := &Limited{
+		Fetcher:  m,
+		Throttle: &throttle.Throttle{Rate: 1, Burst: 1, TTL: time.Minute},
+	}
+
+	for i := 0; i < 2; i++ {
+		got, err := l.Fetch("example.com")
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if want := (&Response{Domain: "example.com"}); !reflect.DeepEqual(got, want) {
+			t.Fatalf("got %+v; want %+v", got, want)
+		}
+	}
+
+	if m.lookups != 1 {
+		t.Fatalf("got %d lookups; want 1", m.lookups)
+	}
+
+	if _, err := l.Fetch("example.org"); err != throttle.ErrLimited {
+		t.Fatalf("got %v; want %v", err, throttle.ErrLimited)
+	}
+}
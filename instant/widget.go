@@ -0,0 +1,25 @@
+package instant
+
+import "math"
+
+// Widget is an interactive calculator shown with an answer.
+// The frontend reruns Formula over the Inputs whenever one of them changes.
+type Widget struct {
+	Formula string        `json:"formula"`
+	Inputs  []WidgetField `json:"inputs"`
+	Outputs []WidgetField `json:"outputs"`
+}
+
+// WidgetField is one of a widget's inputs or outputs
+type WidgetField struct {
+	Name   string  `json:"name"`
+	Label  string  `json:"label"`
+	Value  float64 `json:"value"`
+	Text   string  `json:"text,omitempty"`   // for outputs that aren't numbers, e.g. a BMI category
+	Format string  `json:"format,omitempty"` // "currency" or "percent"
+}
+
+// round2 rounds to the cent
+func round2(f float64) float64 {
+	return math.Round(f*100) / 100
+}
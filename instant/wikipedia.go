@@ -36,6 +36,7 @@ type Wikipedia struct {
 	NutritionFetcher nutrition.Fetcher
 	TimeZoneFetcher  timezone.Fetcher
 	wikipedia.Fetcher
+	Fallback []language.Tag // the user's other preferred languages, in order
 	Answer
 }
 
@@ -333,7 +334,7 @@ var contains = func(x string, sl []string) bool {
 // TODO: Return the Title (and perhaps Image???) as
 // confirmation that we fetched the right asset.
 func (w *Wikipedia) solve(r *http.Request) Answerer {
-	items, err := w.Fetch(w.remainder, w.language)
+	items, err := w.fetch()
 	if err != nil {
 		w.Err = err
 		return w
@@ -463,7 +464,7 @@ func (w *Wikipedia) solve(r *http.Request) Answerer {
 			}
 		}
 
-		if contains(w.triggerWord, allNutrientTriggers) {
+		if contains(w.triggerWord, allNutrientTriggers) && w.NutritionFetcher != nil {
 			// Wikipedia seems more reliable ndbno id's for items like "egg"
 			// but doesn't have things like "Whopper" or "Big Mac without sauce"
 			var ndbnos = []string{}
@@ -539,7 +540,7 @@ func (w *Wikipedia) solve(r *http.Request) Answerer {
 
 		switch w.remainder {
 		case clock, currentTime, timeIn, wTime: // if "clock", "current time", etc. then they want the current time for their current location
-			ip := getIPAddress(r)
+			ip := IPAddress(r)
 			c, err := w.LocationFetcher.Fetch(ip)
 			if err != nil {
 				w.Err = err
@@ -570,6 +571,14 @@ func (w *Wikipedia) solve(r *http.Request) Answerer {
 		default:
 			w.Type = WikipediaType
 			w.Data.Solution = items
+
+			// the box is a catch-all so give way to answers that triggered on purpose
+			switch hasArticle(items) {
+			case true:
+				w.Confidence *= .6
+			default:
+				w.Confidence = 0
+			}
 		}
 
 	}
@@ -577,6 +586,45 @@ func (w *Wikipedia) solve(r *http.Request) Answerer {
 	return w
 }
 
+// fetch falls back through the user's preferred languages and finally English
+// when the matched language's Wikipedia doesn't have an article for the query.
+func (w *Wikipedia) fetch() ([]*wikipedia.Item, error) {
+	items, err := w.Fetch(w.remainder, w.language)
+	if err == nil && hasArticle(items) {
+		return items, err
+	}
+
+	tried := map[language.Tag]bool{w.language: true}
+
+	for _, lang := range append(w.Fallback, language.English) {
+		if tried[lang] {
+			continue
+		}
+		tried[lang] = true
+
+		itms, e := w.Fetch(w.remainder, lang)
+		if e != nil || !hasArticle(itms) {
+			continue
+		}
+
+		w.Data.Language = lang.String()
+		return itms, nil
+	}
+
+	return items, err
+}
+
+func hasArticle(items []*wikipedia.Item) bool {
+	for _, item := range items {
+		if item.Wikipedia.Title != "" || (item.Wikidata != nil && item.Wikidata.ID != "") ||
+			len(item.Wikiquote.Quotes) > 0 || len(item.Wiktionary.Definitions) > 0 {
+			return true
+		}
+	}
+
+	return false
+}
+
 func (w *Wikipedia) getTime(lat, lon float64) (time.Time, error) {
 	t := time.Time{}
 
@@ -868,3 +916,20 @@ func (w *Wikipedia) tests() []test {
 
 	return tests
 }
+
+func init() {
+	Register(Registration{
+		Name:     "wikipedia",
+		Trigger:  `any query matching the title of a Wikipedia article`,
+		Priority: lastPriority, // the Wikipedia box triggers only if nothing else does
+		Maps:     true,
+		New: func(i *Instant) Answerer {
+			return &Wikipedia{
+				LocationFetcher:  i.LocationFetcher,
+				NutritionFetcher: i.NutritionFetcher,
+				TimeZoneFetcher:  i.TimeZoneFetcher,
+				Fetcher:          i.WikipediaFetcher,
+			}
+		},
+	})
+}
@@ -0,0 +1,88 @@
+// Updater applies Wikipedia's recent changes to our postgresql database
+// so that we don't have to wait for the next dump.
+package main
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"net/http"
+	"os"
+	"os/signal"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/jivesearch/jivesearch/config"
+	"github.com/jivesearch/jivesearch/instant/wikipedia"
+	"github.com/jivesearch/jivesearch/log"
+	"github.com/spf13/viper"
+	"golang.org/x/text/language"
+)
+
+func setup(v *viper.Viper) *wikipedia.Updater {
+	v.SetEnvPrefix("jivesearch")
+	v.AutomaticEnv()
+	v.SetEnvKeyReplacer(strings.NewReplacer(".", "_"))
+	config.SetDefaults(v)
+
+	if v.GetBool("debug") {
+		log.Debug.SetOutput(os.Stdout)
+	}
+
+	p := &wikipedia.PostgreSQL{}
+
+	var err error
+	p.DB, err = sql.Open("postgres",
+		fmt.Sprintf(
+			"user=%s password=%s host=%s database=%s sslmode=disable",
+			v.GetString("postgresql.user"),
+			v.GetString("postgresql.password"),
+			v.GetString("postgresql.host"),
+			v.GetString("postgresql.database"),
+		),
+	)
+	if err != nil {
+		panic(err)
+	}
+
+	supported := []language.Tag{}
+	for _, l := range v.GetStringSlice("languages") {
+		supported = append(supported, language.MustParse(l))
+	}
+
+	supported, unsupported := wikipedia.Languages(supported)
+	for _, lang := range unsupported {
+		log.Info.Printf("wikipedia does not support langugage %q\n", lang)
+	}
+
+	return &wikipedia.Updater{
+		Client:    &http.Client{}, // no timeout as the stream stays open
+		Stream:    wikipedia.EventStreamsURL,
+		Languages: supported,
+		Store:     p,
+		Checkpoint: &wikipedia.FileCheckpoint{
+			Path: v.GetString("wikipedia.checkpoint"),
+		},
+		Truncate: v.GetInt("wikipedia.truncate"),
+		Retry:    10 * time.Second,
+		Interval: 30 * time.Second,
+	}
+}
+
+func main() {
+	u := setup(viper.New())
+
+	ctx, cancel := context.WithCancel(context.Background())
+
+	sig := make(chan os.Signal, 1)
+	signal.Notify(sig, os.Interrupt, syscall.SIGTERM)
+	go func() {
+		<-sig
+		cancel()
+	}()
+
+	if err := u.Run(ctx); err != nil && err != context.Canceled {
+		log.Info.Fatalln(err)
+	}
+}
@@ -0,0 +1,182 @@
+package wikipedia
+
+import (
+	"strings"
+	"time"
+
+	"golang.org/x/text/language"
+)
+
+// Panel is a knowledge panel (infobox) for a person, place or thing.
+// Unlike an Item it is already condensed for display so it can be
+// rendered beside the organic results or returned as-is by the API.
+type Panel struct {
+	ID          string   `json:"id"`
+	Title       string   `json:"title"`
+	Description string   `json:"description,omitempty"`
+	Text        string   `json:"text,omitempty"`
+	Language    string   `json:"language,omitempty"`
+	Image       string   `json:"image,omitempty"`
+	Facts       []Fact   `json:"facts,omitempty"`
+	Related     []Entity `json:"related,omitempty"`
+}
+
+// Fact is a single labeled attribute of an entity
+type Fact struct {
+	Label string `json:"label"`
+	Value string `json:"value"`
+}
+
+// Entity is a related Wikidata item
+type Entity struct {
+	ID       string `json:"id"`
+	Label    string `json:"label"`
+	Relation string `json:"relation"`
+}
+
+// maxRelated caps the number of related entities in a panel
+const maxRelated = 8
+
+// NewPanel condenses an Item into a knowledge panel.
+// Nil is returned for items without Wikidata as there wouldn't be anything to show.
+func NewPanel(item *Item, lang language.Tag) *Panel {
+	if item == nil || item.Wikidata == nil {
+		return nil
+	}
+
+	p := &Panel{
+		ID:          item.Wikidata.ID,
+		Title:       item.Wikipedia.Title,
+		Description: text(item.Descriptions, lang),
+		Text:        item.Wikipedia.Text,
+		Language:    item.Wikipedia.Language,
+	}
+
+	if p.Title == "" {
+		p.Title = text(item.Labels, lang)
+	}
+
+	c := item.Claims
+	if c == nil {
+		return p
+	}
+
+	if len(c.Image) > 0 {
+		p.Image = c.Image[0]
+	}
+
+	p.addFact("Born", date(c.Birthday))
+	p.addFact("Died", date(c.Death))
+	p.addFact("Founded", date(c.Start))
+	p.addFact("Occupation", join(c.Occupation, lang))
+
+	for _, cc := range c.Country {
+		p.addFact("Country", join(cc.Item, lang))
+		break
+	}
+
+	for _, cp := range c.Capital {
+		if len(cp.End) == 0 { // the current capital
+			p.addFact("Capital", join(cp.Item, lang))
+		}
+	}
+
+	for _, pop := range c.Population {
+		for _, v := range pop.Value {
+			p.addFact("Population", strings.TrimPrefix(v.Amount, "+"))
+		}
+		break
+	}
+
+	p.addFact("Genre", join(c.Genre, lang))
+
+	if len(c.Website) > 0 {
+		p.addFact("Website", c.Website[0])
+	}
+
+	for _, s := range c.Spouse {
+		p.addRelated("Spouse", s.Item, lang)
+	}
+
+	for _, m := range c.Members {
+		p.addRelated("Member", m.Item, lang)
+	}
+
+	p.addRelated("Father", c.Father, lang)
+	p.addRelated("Mother", c.Mother, lang)
+	p.addRelated("Sibling", c.Siblings, lang)
+	p.addRelated("Influence", c.Influences, lang)
+
+	return p
+}
+
+// Matches returns true if the panel is the subject of the query rather than
+// an item that merely mentions it.
+func (p *Panel) Matches(q string) bool {
+	q = strings.ToLower(strings.TrimSpace(q))
+	return q != "" && strings.ToLower(p.Title) == q
+}
+
+func (p *Panel) addFact(label, value string) {
+	if value == "" {
+		return
+	}
+
+	p.Facts = append(p.Facts, Fact{Label: label, Value: value})
+}
+
+func (p *Panel) addRelated(relation string, items []Wikidata, lang language.Tag) {
+	for _, item := range items {
+		if len(p.Related) >= maxRelated {
+			return
+		}
+
+		l := text(item.Labels, lang)
+		if l == "" {
+			continue
+		}
+
+		p.Related = append(p.Related, Entity{ID: item.ID, Label: l, Relation: relation})
+	}
+}
+
+// text returns the value for the closest language, falling back to English
+func text(m map[string]Text, lang language.Tag) string {
+	base, _ := lang.Base()
+
+	for _, k := range []string{lang.String(), base.String(), "en"} {
+		if t, ok := m[k]; ok {
+			return t.Text
+		}
+	}
+
+	return ""
+}
+
+func join(items []Wikidata, lang language.Tag) string {
+	sl := []string{}
+	for _, item := range items {
+		if l := text(item.Labels, lang); l != "" {
+			sl = append(sl, l)
+		}
+	}
+
+	return strings.Join(sl, ", ")
+}
+
+// date formats the first DateTime. Some only have year precision.
+func date(dts []DateTime) string {
+	for _, dt := range dts {
+		if t, err := time.Parse(time.RFC3339Nano, dt.Value); err == nil {
+			return t.Format("January 2, 2006")
+		}
+
+		if len(dt.Value) >= 4 {
+			if t, err := time.Parse("2006", dt.Value[:4]); err == nil {
+				return t.Format("2006")
+			}
+		}
+	}
+
+	return ""
+}
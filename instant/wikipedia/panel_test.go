@@ -0,0 +1,125 @@
+package wikipedia
+
+import (
+	"reflect"
+	"testing"
+
+	"golang.org/x/text/language"
+)
+
+func TestNewPanel(t *testing.T) {
+	curie := &Item{
+		Wikipedia: Wikipedia{
+			ID:       "Q7186",
+			Language: "en",
+			Title:    "Marie Curie",
+			Text:     "Marie Skłodowska Curie was a Polish and naturalized-French physicist and chemist.",
+		},
+		Wikidata: &Wikidata{
+			ID: "Q7186",
+			Labels: Labels{
+				"en": {Text: "Marie Curie", Language: "en"},
+				"fr": {Text: "Marie Curie", Language: "fr"},
+			},
+			Descriptions: Descriptions{
+				"en": {Text: "Polish-French physicist and chemist", Language: "en"},
+				"fr": {Text: "physicienne et chimiste", Language: "fr"},
+			},
+			Claims: &Claims{
+				Image: []string{"https://upload.wikimedia.org/wikipedia/commons/c/c8/Marie_Curie_c1920.jpg"},
+				Birthday: []DateTime{
+					{Value: "1867-11-07T00:00:00Z", Calendar: Wikidata{ID: "Q1985727"}},
+				},
+				Death: []DateTime{
+					{Value: "1934-07-04T00:00:00Z", Calendar: Wikidata{ID: "Q1985727"}},
+				},
+				Occupation: []Wikidata{
+					{ID: "Q169470", Labels: Labels{"en": {Text: "physicist", Language: "en"}}},
+					{ID: "Q593644", Labels: Labels{"en": {Text: "chemist", Language: "en"}}},
+				},
+				Spouse: []Spouse{
+					{Item: []Wikidata{{ID: "Q37463", Labels: Labels{"en": {Text: "Pierre Curie", Language: "en"}}}}},
+				},
+				Siblings: []Wikidata{
+					{ID: "Q123", Labels: Labels{"en": {Text: "Bronisława Dłuska", Language: "en"}}},
+					{ID: "Q456"}, // no label
+				},
+			},
+		},
+	}
+
+	for _, c := range []struct {
+		name string
+		item *Item
+		lang language.Tag
+		want *Panel
+	}{
+		{"nil", nil, language.English, nil},
+		{"no wikidata", &Item{Wikipedia: Wikipedia{Title: "Something"}}, language.English, nil},
+		{
+			"marie curie", curie, language.English,
+			&Panel{
+				ID:          "Q7186",
+				Title:       "Marie Curie",
+				Description: "Polish-French physicist and chemist",
+				Text:        "Marie Skłodowska Curie was a Polish and naturalized-French physicist and chemist.",
+				Language:    "en",
+				Image:       "https://upload.wikimedia.org/wikipedia/commons/c/c8/Marie_Curie_c1920.jpg",
+				Facts: []Fact{
+					{Label: "Born", Value: "November 7, 1867"},
+					{Label: "Died", Value: "July 4, 1934"},
+					{Label: "Occupation", Value: "physicist, chemist"},
+				},
+				Related: []Entity{
+					{ID: "Q37463", Label: "Pierre Curie", Relation: "Spouse"},
+					{ID: "Q123", Label: "Bronisława Dłuska", Relation: "Sibling"},
+				},
+			},
+		},
+		{
+			"french description", &Item{Wikidata: curie.Wikidata}, language.MustParse("fr-CA"),
+			&Panel{
+				ID:          "Q7186",
+				Title:       "Marie Curie",
+				Description: "physicienne et chimiste",
+				Image:       "https://upload.wikimedia.org/wikipedia/commons/c/c8/Marie_Curie_c1920.jpg",
+				Facts: []Fact{
+					{Label: "Born", Value: "November 7, 1867"},
+					{Label: "Died", Value: "July 4, 1934"},
+					{Label: "Occupation", Value: "physicist, chemist"},
+				},
+				Related: []Entity{
+					{ID: "Q37463", Label: "Pierre Curie", Relation: "Spouse"},
+					{ID: "Q123", Label: "Bronisława Dłuska", Relation: "Sibling"},
+				},
+			},
+		},
+	} {
+		t.Run(c.name, func(t *testing.T) {
+			got := NewPanel(c.item, c.lang)
+			if !reflect.DeepEqual(got, c.want) {
+				t.Fatalf("got %+v; want %+v", got, c.want)
+			}
+		})
+	}
+}
+
+func TestPanel_Matches(t *testing.T) {
+	p := &Panel{Title: "Eiffel Tower"}
+
+	for _, c := range []struct {
+		q    string
+		want bool
+	}{
+		{"eiffel tower", true},
+		{" Eiffel Tower ", true},
+		{"eiffel tower height", false},
+		{"", false},
+	} {
+		t.Run(c.q, func(t *testing.T) {
+			if got := p.Matches(c.q); got != c.want {
+				t.Fatalf("got %v; want %v", got, c.want)
+			}
+		})
+	}
+}
@@ -5,6 +5,7 @@ import (
 	"encoding/json"
 	"fmt"
 	"reflect"
+	"strconv"
 	"strings"
 
 	"github.com/jivesearch/jivesearch/log"
@@ -192,7 +193,7 @@ func (p *PostgreSQL) Fetch(query string, lang language.Tag) ([]*Item, error) {
 			SELECT *
 			FROM (
 				SELECT 
-				w."id", w."title", w."text", w."outgoing_link", w."popularity_score",
+				w."id", w."title", w."text", w."outgoing_link", w."heading", w."popularity_score",
 				wq."quotes", wd."labels", wd."descriptions", wd."claims" 
 				FROM %vwikipedia w
 				LEFT JOIN %vwikiquote wq ON w.id = wq.id
@@ -224,7 +225,7 @@ func (p *PostgreSQL) Fetch(query string, lang language.Tag) ([]*Item, error) {
 		%v
 		SELECT
 			coalesce(item."id", ''), coalesce(item."title", ''), coalesce(item."text", ''), coalesce(item."outgoing_link", '{}'),
-			coalesce(item."quotes", '{}'), coalesce(item."wktitle", ''), coalesce(item."definitions", '[]'),
+			coalesce(item."heading", '{}'), coalesce(item."quotes", '{}'), coalesce(item."wktitle", ''), coalesce(item."definitions", '[]'),
 			coalesce(item."labels", '{}'::jsonb), coalesce(item."descriptions", '{}'::jsonb), %v "claims"
 		FROM item, %v
 	`, item.Wikipedia.Language, item.Wiktionary.Language, item.Wikipedia.Language, item.Wiktionary.Language,
@@ -235,7 +236,7 @@ func (p *PostgreSQL) Fetch(query string, lang language.Tag) ([]*Item, error) {
 
 	err := p.DB.QueryRow(sql, query, query).Scan(
 		&item.Wikidata.ID, &item.Wikipedia.Title, &item.Wikipedia.Text, pq.Array(&item.Wikipedia.OutgoingLink),
-		pq.Array(&item.Wikiquote.Quotes), &item.Wiktionary.Title, &definitions,
+		pq.Array(&item.Wikipedia.Headings), pq.Array(&item.Wikiquote.Quotes), &item.Wiktionary.Title, &definitions,
 		&item.Labels, &item.Descriptions, &item.Claims,
 	)
 
@@ -354,8 +355,7 @@ func (p *PostgreSQL) Dump(ft FileType, lang language.Tag, rows chan interface{})
 		}
 	case WikipediaFT:
 		t.Type = wikipediaTable
-		n := strings.Replace(lang.String(), "-", "_", -1)
-		t.name = fmt.Sprintf("%v%v", strings.ToLower(n), wikipediaTable) // enwikipedia, cebwikipedia, etc...
+		t.name = wikipediaTableName(lang)
 	case WikiquoteFT:
 		t.Type = wikiquoteTable
 		n := strings.Replace(lang.String(), "-", "_", -1)
@@ -438,6 +438,47 @@ func (p *PostgreSQL) Dump(ft FileType, lang language.Tag, rows chan interface{})
 	return nil
 }
 
+// Upsert inserts or replaces a single page in the language's Wikipedia table
+func (p *PostgreSQL) Upsert(lang language.Tag, w *Wikipedia) error {
+	return p.executeTransaction(func(tx *sql.Tx) error {
+		tbl := wikipediaTableName(lang)
+
+		res, err := tx.Exec(
+			fmt.Sprintf(`UPDATE %v SET id = $1, title = $2, text = $3 WHERE LOWER(title) = LOWER($2)`, tbl),
+			w.ID, w.Title, w.Text,
+		)
+		if err != nil {
+			return err
+		}
+
+		n, err := res.RowsAffected()
+		if err != nil || n > 0 {
+			return err
+		}
+
+		_, err = tx.Exec(
+			fmt.Sprintf(`INSERT INTO %v (id, title, text, popularity_score) VALUES ($1, $2, $3, $4)`, tbl),
+			w.ID, w.Title, w.Text, strconv.FormatFloat(w.Popularity, 'f', -1, 64),
+		)
+		return err
+	})
+}
+
+// Delete removes a single page from the language's Wikipedia table
+func (p *PostgreSQL) Delete(lang language.Tag, title string) error {
+	_, err := p.DB.Exec(
+		fmt.Sprintf(`DELETE FROM %v WHERE LOWER(title) = LOWER($1)`, wikipediaTableName(lang)),
+		title,
+	)
+	return err
+}
+
+// wikipediaTableName is enwikipedia, cebwikipedia, etc...
+func wikipediaTableName(lang language.Tag) string {
+	n := strings.Replace(lang.String(), "-", "_", -1)
+	return fmt.Sprintf("%v%v", strings.ToLower(n), wikipediaTable)
+}
+
 func (t *table) setColumns() error {
 	var err error
 
@@ -462,6 +503,7 @@ func (t *table) setColumns() error {
 			{"title", "text", true},
 			{"text", "text", false},
 			{"outgoing_link", "text[]", false},
+			{"heading", "text[]", false},
 			{"popularity_score", "numeric", true},
 		}
 	case wikiquoteTable:
@@ -487,7 +529,7 @@ func (t *table) createTable() string {
 	cols := []string{}
 	for _, col := range t.columns {
 		switch col.name {
-		case "outgoing_link":
+		case "outgoing_link", "heading":
 			cols = append(cols, fmt.Sprintf("%v %v", col.name, col.t))
 		default:
 			cols = append(cols, fmt.Sprintf("%v %v NOT NULL", col.name, col.t))
@@ -521,7 +563,7 @@ func (t *table) insert(tx *sql.Tx) (err error) {
 
 		switch row := row.(type) {
 		case *Wikipedia:
-			r = []interface{}{row.ID, row.Title, row.Text, pq.Array(row.OutgoingLink), row.Popularity}
+			r = []interface{}{row.ID, row.Title, row.Text, pq.Array(row.OutgoingLink), pq.Array(row.Headings), row.Popularity}
 		case *Wikidata:
 			r = []interface{}{row.ID}
 
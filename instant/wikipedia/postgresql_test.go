@@ -64,7 +64,7 @@ func TestPostgreSQL_Fetch(t *testing.T) {
 				"Shaquille O'Neal", language.MustParse("en"),
 				[]driver.Value{
 					"Q169452", "Shaquille O'Neal", "Shaquille O'Neal is a basketball player", "{}",
-					"{}", "Shaquille O'Neal", shaqWiktionaryJSON,
+					"{Early life,Career}", "{}", "Shaquille O'Neal", shaqWiktionaryJSON,
 					[]byte(shaqRawLabels), []byte(shaqRawDescriptions), shaqClaimsJSON,
 				},
 			},
@@ -73,6 +73,7 @@ func TestPostgreSQL_Fetch(t *testing.T) {
 					Wikipedia: Wikipedia{
 						Language:     "en",
 						OutgoingLink: []string{},
+						Headings:     []string{"Early life", "Career"},
 						Title:        "Shaquille O'Neal",
 						Text:         "Shaquille O'Neal is a basketball player",
 					},
@@ -101,7 +102,7 @@ func TestPostgreSQL_Fetch(t *testing.T) {
 			defer db.Close()
 
 			rows := sqlmock.NewRows(
-				[]string{"id", "title", "text", "outgoing_link", "quotes", "wktitle", "definitions",
+				[]string{"id", "title", "text", "outgoing_link", "heading", "quotes", "wktitle", "definitions",
 					"labels", "descriptions", "claims",
 				},
 			)
@@ -195,7 +196,7 @@ func TestPostgreSQL_Dump(t *testing.T) {
 				w := tt.row.(*Wikipedia)
 
 				mock.ExpectPrepare("COPY").ExpectExec().
-					WithArgs(w.ID, w.Title, w.Text, pq.Array(w.OutgoingLink), w.Popularity).
+					WithArgs(w.ID, w.Title, w.Text, pq.Array(w.OutgoingLink), pq.Array(w.Headings), w.Popularity).
 					WillReturnResult(sqlmock.NewResult(1, 1))
 			case WikidataFT:
 				wd := tt.row.(*Wikidata)
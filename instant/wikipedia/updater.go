@@ -0,0 +1,329 @@
+package wikipedia
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/jivesearch/jivesearch/log"
+	"golang.org/x/text/language"
+)
+
+// EventStreamsURL is Wikimedia's feed of recent changes to all wikis
+var EventStreamsURL, _ = url.Parse("https://stream.wikimedia.org/v2/stream/recentchange")
+
+// maxBackfill is how far back EventStreams keeps history.
+// Anything older than that requires a fresh dump.
+const maxBackfill = 7 * 24 * time.Hour
+
+// PageStore persists individual Wikipedia pages as they change
+type PageStore interface {
+	Upsert(lang language.Tag, w *Wikipedia) error
+	Delete(lang language.Tag, title string) error
+}
+
+// Checkpointer remembers the timestamp of the last change we applied
+// so that we can resume (and backfill) after a restart or disconnect.
+type Checkpointer interface {
+	Load() (time.Time, error)
+	Save(t time.Time) error
+}
+
+// Updater keeps our Wikipedia tables current by consuming the
+// recent changes stream instead of reimporting the full dumps.
+type Updater struct {
+	Client     *http.Client
+	Stream     *url.URL
+	Languages  []language.Tag
+	Store      PageStore
+	Checkpoint Checkpointer
+	Truncate   int
+	Retry      time.Duration // wait between reconnects
+	Interval   time.Duration // how often to save the checkpoint
+	summary    string        // REST API for the updated page. Overridden in tests.
+}
+
+// Change is an event from the recent changes stream
+// https://github.com/wikimedia/mediawiki-event-schemas/tree/master/jsonschema/mediawiki/recentchange
+type Change struct {
+	Meta struct {
+		ID string    `json:"id"`
+		DT time.Time `json:"dt"`
+	} `json:"meta"`
+	Type       string          `json:"type"` // edit, new, log, categorize
+	Namespace  int             `json:"namespace"`
+	Title      string          `json:"title"`
+	Wiki       string          `json:"wiki"` // enwiki, dewiki, etc...
+	ServerName string          `json:"server_name"`
+	LogType    string          `json:"log_type,omitempty"`
+	LogAction  string          `json:"log_action,omitempty"`
+	LogParams  json.RawMessage `json:"log_params,omitempty"`
+}
+
+// FileCheckpoint saves the checkpoint to a local file
+type FileCheckpoint struct {
+	Path string
+}
+
+// Load returns the zero time if we've never saved a checkpoint
+func (f *FileCheckpoint) Load() (time.Time, error) {
+	b, err := ioutil.ReadFile(f.Path)
+	if os.IsNotExist(err) {
+		return time.Time{}, nil
+	} else if err != nil {
+		return time.Time{}, err
+	}
+
+	return time.Parse(time.RFC3339Nano, strings.TrimSpace(string(b)))
+}
+
+// Save writes to a temporary file first so a crash can't leave a partial checkpoint
+func (f *FileCheckpoint) Save(t time.Time) error {
+	tmp := f.Path + ".tmp"
+	if err := ioutil.WriteFile(tmp, []byte(t.UTC().Format(time.RFC3339Nano)), 0644); err != nil {
+		return err
+	}
+
+	return os.Rename(tmp, f.Path)
+}
+
+// Run consumes the stream until the context is canceled, reconnecting on errors.
+// Each connection resumes from the last checkpoint so any gap is backfilled.
+func (u *Updater) Run(ctx context.Context) error {
+	for {
+		since, err := u.Checkpoint.Load()
+		if err != nil {
+			return err
+		}
+
+		err = u.consume(ctx, since)
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+
+		log.Info.Printf("wikipedia updater disconnected: %v\n", err)
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(u.Retry):
+		}
+	}
+}
+
+func (u *Updater) consume(ctx context.Context, since time.Time) error {
+	s := *u.Stream
+
+	if !since.IsZero() {
+		if time.Since(since) > maxBackfill {
+			log.Info.Printf("checkpoint %v is older than the stream's history. Reimport the dumps to fill the gap.\n", since)
+			since = time.Now().Add(-maxBackfill)
+		}
+
+		q := s.Query()
+		q.Set("since", since.UTC().Format(time.RFC3339))
+		s.RawQuery = q.Encode()
+	}
+
+	req, err := http.NewRequest("GET", s.String(), nil)
+	if err != nil {
+		return err
+	}
+
+	req = req.WithContext(ctx)
+	req.Header.Set("Accept", "text/event-stream")
+
+	resp, err := u.Client.Do(req)
+	if err != nil {
+		return err
+	}
+
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("unexpected status code %v from %v", resp.StatusCode, s.String())
+	}
+
+	var last, saved time.Time
+
+	// save our progress on the way out, too
+	defer func() {
+		if last.After(saved) {
+			if err := u.Checkpoint.Save(last); err != nil {
+				log.Info.Println(err)
+			}
+		}
+	}()
+
+	scanner := bufio.NewScanner(resp.Body)
+	scanner.Buffer(make([]byte, 64*1024), 1024*1024) // some events are quite large
+
+	var data []string
+
+	for scanner.Scan() {
+		line := scanner.Text()
+
+		switch {
+		case strings.HasPrefix(line, "data:"):
+			data = append(data, strings.TrimSpace(strings.TrimPrefix(line, "data:")))
+			continue
+		case line != "": // id, event and comment lines
+			continue
+		case len(data) == 0:
+			continue
+		}
+
+		// a blank line dispatches the event
+		c := &Change{}
+		err := json.Unmarshal([]byte(strings.Join(data, "\n")), c)
+		data = nil
+		if err != nil {
+			log.Debug.Println(err)
+			continue
+		}
+
+		if err := u.apply(c); err != nil {
+			return err // without advancing the checkpoint so this change is retried
+		}
+
+		last = c.Meta.DT
+
+		if last.Sub(saved) >= u.Interval {
+			if err := u.Checkpoint.Save(last); err != nil {
+				return err
+			}
+			saved = last
+		}
+	}
+
+	if err := scanner.Err(); err != nil {
+		return err
+	}
+
+	return fmt.Errorf("stream closed")
+}
+
+// apply an individual change to the store.
+// We only care about articles on the Wikipedias we support.
+func (u *Updater) apply(c *Change) error {
+	if c.Namespace != 0 || !strings.HasSuffix(c.ServerName, ".wikipedia.org") || !strings.HasSuffix(c.Wiki, "wiki") {
+		return nil
+	}
+
+	w := strings.TrimSuffix(c.Wiki, "wiki")
+	if _, err := language.Parse(w); err != nil {
+		return nil
+	}
+
+	lang, ok := isSupported(w, u.Languages)
+	if !ok {
+		return nil
+	}
+
+	switch c.Type {
+	case "edit", "new":
+		return u.update(lang, c.ServerName, c.Title)
+	case "log":
+		switch c.LogType {
+		case "delete":
+			if c.LogAction == "restore" {
+				return u.update(lang, c.ServerName, c.Title)
+			}
+			log.Debug.Printf("deleting %v %q\n", lang, c.Title)
+			return u.Store.Delete(lang, c.Title)
+		case "move":
+			p := struct {
+				Target string `json:"target"`
+			}{}
+
+			if err := json.Unmarshal(c.LogParams, &p); err != nil || p.Target == "" {
+				return nil
+			}
+
+			if err := u.Store.Delete(lang, c.Title); err != nil {
+				return err
+			}
+
+			return u.update(lang, c.ServerName, p.Target)
+		}
+	}
+
+	return nil
+}
+
+// update fetches the latest version of a page and saves it
+func (u *Updater) update(lang language.Tag, server, title string) error {
+	w, err := u.page(server, title)
+	if err != nil {
+		return err
+	}
+
+	if w == nil { // deleted or a redirect
+		return nil
+	}
+
+	log.Debug.Printf("updating %v %q\n", lang, w.Title)
+	return u.Store.Upsert(lang, w)
+}
+
+type summary struct {
+	Type         string `json:"type"`
+	Title        string `json:"title"`
+	Extract      string `json:"extract"`
+	WikibaseItem string `json:"wikibase_item"`
+	Lang         string `json:"lang"`
+}
+
+func (u *Updater) page(server, title string) (*Wikipedia, error) {
+	base := u.summary
+	if base == "" {
+		base = "https://" + server + "/api/rest_v1/page/summary/"
+	}
+
+	resp, err := u.Client.Get(base + url.PathEscape(strings.Replace(title, " ", "_", -1)))
+	if err != nil {
+		return nil, err
+	}
+
+	defer resp.Body.Close()
+
+	switch resp.StatusCode {
+	case http.StatusOK:
+	case http.StatusNotFound:
+		return nil, nil
+	default:
+		return nil, fmt.Errorf("unexpected status code %v for %q", resp.StatusCode, title)
+	}
+
+	s := &summary{}
+	if err := json.NewDecoder(resp.Body).Decode(s); err != nil {
+		return nil, err
+	}
+
+	// the summary is only helpful for actual articles
+	if s.Type != "standard" || s.WikibaseItem == "" {
+		return nil, nil
+	}
+
+	w := &Wikipedia{
+		ID:       s.WikibaseItem,
+		Language: s.Lang,
+		Title:    s.Title,
+		Text:     s.Extract,
+		truncate: u.Truncate,
+	}
+
+	if w.truncate <= 0 {
+		w.truncate = -1
+	}
+
+	w.clean()
+	return w, nil
+}
@@ -0,0 +1,212 @@
+package wikipedia
+
+import (
+	"context"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+	"time"
+
+	"golang.org/x/text/language"
+	sqlmock "gopkg.in/DATA-DOG/go-sqlmock.v2"
+)
+
+const recentChanges = `:ok
+
+event: message
+id: [{"topic":"eqiad.mediawiki.recentchange","partition":0,"timestamp":1}]
+data: {"meta":{"id":"1","dt":"2018-09-01T00:00:01Z"},"type":"edit","namespace":0,"title":"Marie Curie","wiki":"enwiki","server_name":"en.wikipedia.org"}
+
+event: message
+data: {"meta":{"id":"2","dt":"2018-09-01T00:00:02Z"},"type":"edit","namespace":1,"title":"Talk:Marie Curie","wiki":"enwiki","server_name":"en.wikipedia.org"}
+
+event: message
+data: {"meta":{"id":"3","dt":"2018-09-01T00:00:03Z"},"type":"edit","namespace":0,"title":"Q42","wiki":"wikidatawiki","server_name":"www.wikidata.org"}
+
+event: message
+data: {"meta":{"id":"4","dt":"2018-09-01T00:00:04Z"},"type":"edit","namespace":0,"title":"Marie Curie","wiki":"dewiki","server_name":"de.wikipedia.org"}
+
+event: message
+data: {"meta":{"id":"5","dt":"2018-09-01T00:00:05Z"},"type":"log","namespace":0,"title":"Some Vandalism","wiki":"enwiki","server_name":"en.wikipedia.org","log_type":"delete","log_action":"delete"}
+
+event: message
+data: {"meta":{"id":"6","dt":"2018-09-01T00:00:06Z"},"type":"log","namespace":0,"title":"Eifel Tower","wiki":"enwiki","server_name":"en.wikipedia.org","log_type":"move","log_action":"move","log_params":{"target":"Eiffel Tower","noredir":"0"}}
+
+`
+
+func TestUpdater_Run(t *testing.T) {
+	summaries := map[string]string{
+		"/Marie_Curie":  `{"type":"standard","title":"Marie Curie","extract":"Marie Skłodowska Curie (born Maria Salomea Skłodowska; 7 November 1867 – 4 July 1934) was a physicist.","wikibase_item":"Q7186","lang":"en"}`,
+		"/Eiffel_Tower": `{"type":"standard","title":"Eiffel Tower","extract":"The Eiffel Tower is a wrought-iron lattice tower.","wikibase_item":"Q243","lang":"en"}`,
+	}
+
+	var sinces []string
+
+	ctx, cancel := context.WithCancel(context.Background())
+
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/stream" {
+			sinces = append(sinces, r.URL.Query().Get("since"))
+			if len(sinces) > 1 { // the reconnect is all we need to see
+				cancel()
+				return
+			}
+			fmt.Fprint(w, recentChanges)
+			return
+		}
+
+		s, ok := summaries[r.URL.Path]
+		if !ok {
+			http.NotFound(w, r)
+			return
+		}
+		fmt.Fprint(w, s)
+	}))
+	defer ts.Close()
+
+	dir, err := ioutil.TempDir("", "updater")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	cp := &FileCheckpoint{Path: filepath.Join(dir, "checkpoint")}
+	start := time.Now().Add(-time.Hour).UTC().Truncate(time.Second)
+	if err := cp.Save(start); err != nil {
+		t.Fatal(err)
+	}
+
+	stream, _ := url.Parse(ts.URL + "/stream")
+	store := &mockPageStore{}
+
+	u := &Updater{
+		Client:     ts.Client(),
+		Stream:     stream,
+		Languages:  []language.Tag{language.English},
+		Store:      store,
+		Checkpoint: cp,
+		Retry:      time.Millisecond,
+		Interval:   time.Hour,
+		summary:    ts.URL + "/",
+	}
+
+	if err := u.Run(ctx); err != context.Canceled {
+		t.Fatalf("got %v; want %v", err, context.Canceled)
+	}
+
+	// the reconnect resumes from the last change but that is too old to backfill
+	backfill := time.Now().Add(-maxBackfill).UTC().Format(time.RFC3339)[:13]
+	if len(sinces) != 2 || sinces[0] != start.Format(time.RFC3339) || sinces[1][:13] != backfill {
+		t.Fatalf("got since %q; want [%q %q...]", sinces, start.Format(time.RFC3339), backfill)
+	}
+
+	want := []string{
+		"upsert en Q7186 Marie Curie: Marie Skłodowska Curie was a physicist.",
+		"delete en Some Vandalism",
+		"delete en Eifel Tower",
+		"upsert en Q243 Eiffel Tower: The Eiffel Tower is a wrought-iron lattice tower.",
+	}
+
+	if !reflect.DeepEqual(store.calls, want) {
+		t.Fatalf("got %q; want %q", store.calls, want)
+	}
+
+	got, err := cp.Load()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if wantCP := time.Date(2018, 9, 1, 0, 0, 6, 0, time.UTC); !got.Equal(wantCP) {
+		t.Fatalf("got checkpoint %v; want %v", got, wantCP)
+	}
+}
+
+func TestFileCheckpoint(t *testing.T) {
+	dir, err := ioutil.TempDir("", "checkpoint")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	cp := &FileCheckpoint{Path: filepath.Join(dir, "checkpoint")}
+
+	got, err := cp.Load()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !got.IsZero() {
+		t.Fatalf("got %v; want zero time", got)
+	}
+
+	want := time.Date(2018, 9, 1, 12, 30, 0, 500, time.UTC)
+	if err := cp.Save(want); err != nil {
+		t.Fatal(err)
+	}
+
+	got, err = cp.Load()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !got.Equal(want) {
+		t.Fatalf("got %v; want %v", got, want)
+	}
+}
+
+func TestPostgreSQL_Upsert(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+
+	w := &Wikipedia{ID: "Q243", Title: "Eiffel Tower", Text: "The Eiffel Tower is a wrought-iron lattice tower."}
+
+	mock.ExpectBegin()
+	mock.ExpectExec("UPDATE enwikipedia SET").
+		WithArgs(w.ID, w.Title, w.Text).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectExec("INSERT INTO enwikipedia").
+		WithArgs(w.ID, w.Title, w.Text, "0").
+		WillReturnResult(sqlmock.NewResult(1, 1))
+	mock.ExpectCommit()
+
+	mock.ExpectExec("DELETE FROM enwikipedia").
+		WithArgs("Eifel Tower").
+		WillReturnResult(sqlmock.NewResult(0, 1))
+
+	p := &PostgreSQL{DB: db}
+
+	if err := p.Upsert(language.English, w); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := p.Delete(language.English, "Eifel Tower"); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Fatal(err)
+	}
+}
+
+type mockPageStore struct {
+	calls []string
+}
+
+func (m *mockPageStore) Upsert(lang language.Tag, w *Wikipedia) error {
+	m.calls = append(m.calls, fmt.Sprintf("upsert %v %v %v: %v", lang, w.ID, w.Title, w.Text))
+	return nil
+}
+
+func (m *mockPageStore) Delete(lang language.Tag, title string) error {
+	m.calls = append(m.calls, fmt.Sprintf("delete %v %v", lang, title))
+	return nil
+}
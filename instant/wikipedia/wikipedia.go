@@ -31,6 +31,7 @@ type Wikipedia struct {
 	Popularity   float64  `json:"popularity_score,omitempty"`
 	Title        string   `json:"title"`
 	Text         string   `json:"text"`
+	Headings     []string `json:"heading,omitempty"` // section headings
 	truncate     int
 }
 
@@ -51,6 +52,12 @@ func (w *Wikipedia) UnmarshalJSON(data []byte) error {
 		return err
 	}
 
+	w.clean()
+	return nil
+}
+
+// clean strips parenthesis from the text and truncates it
+func (w *Wikipedia) clean() {
 	w.Text = reParen.ReplaceAllString(w.Text, "")
 	w.Text = strings.Replace(w.Text, "\u00a0", "", -1) // otherwise causes a panic below
 
@@ -61,8 +68,6 @@ func (w *Wikipedia) UnmarshalJSON(data []byte) error {
 			w.Text = w.Text + " ..."
 		}
 	}
-
-	return nil
 }
 
 // Languages verifies languages based on Wikipedia's supported languages.
@@ -94,8 +99,10 @@ func Languages(supported []language.Tag) ([]language.Tag, []language.Tag) {
 // Available is a map of all languages that Wikipedia supports.
 // https://en.wikipedia.org/wiki/List_of_Wikipedias
 // There is also a separate entry for Wiktionary and Wikiquote:
-//	 https://en.wiktionary.org/wiki/Wiktionary:List_of_languages
-//	 https://en.wikiquote.org/wiki/Wikiquote:Other_language_Wikiquotes
+//
+//	https://en.wiktionary.org/wiki/Wiktionary:List_of_languages
+//	https://en.wikiquote.org/wiki/Wikiquote:Other_language_Wikiquotes
+//
 // We sort their table by # of Articles descending.
 var Available = map[language.Tag]struct{}{
 	language.MustParse("en"):         {}, // english is fallback
@@ -0,0 +1,151 @@
+package instant
+
+import (
+	"fmt"
+	"net/http"
+	"regexp"
+	"strings"
+
+	"github.com/jivesearch/jivesearch/instant/words"
+	"golang.org/x/text/language"
+)
+
+// WordsType is an answer Type
+const WordsType Type = "words"
+
+// Words is an instant answer for anagrams, words that start or end a certain way and scrabble scores
+type Words struct {
+	Lists words.Lists
+	Answer
+}
+
+// WordsResponse is the words found or a word's scrabble score
+type WordsResponse struct {
+	Tool  string   `json:"tool"` // "anagrams", "starting", "ending", "containing" or "scrabble"
+	Word  string   `json:"word"`
+	Words []string `json:"words,omitempty"` // at most maxWords of them
+	Total int      `json:"total"`
+	Score int      `json:"score,omitempty"`
+	Valid bool     `json:"valid"` // Word is in the word list
+}
+
+// the most words we'll list
+const maxWords = 100
+
+func (w *Words) setQuery(r *http.Request, qv string) Answerer {
+	w.Answer.setQuery(r, qv)
+	return w
+}
+
+func (w *Words) setUserAgent(r *http.Request) Answerer {
+	return w
+}
+
+func (w *Words) setLanguage(lang language.Tag) Answerer {
+	w.language = lang
+	return w
+}
+
+func (w *Words) setType() Answerer {
+	w.Type = WordsType
+	return w
+}
+
+func (w *Words) setRegex() Answerer {
+	w.regex = append(w.regex, regexp.MustCompile(`^(?:what are )?(?:the )?(?:anagrams?|unscramble|unjumble)(?: of| for)? (?P<anagrams>\p{L}+)$`))
+	w.regex = append(w.regex, regexp.MustCompile(`^(?P<anagrams>\p{L}+) anagrams?$`))
+	w.regex = append(w.regex, regexp.MustCompile(`^(?:list of )?words (?:that )?(?P<position>end(?:ing|s)?|start(?:ing|s)?|begin(?:ning|s)?|contain(?:ing|s)?)(?: in| with)? -?(?P<affix>\p{L}+)-?$`))
+	w.regex = append(w.regex, regexp.MustCompile(`^scrabble(?: score| points| value)?(?: of| for)? (?P<scrabble>\p{L}+)$`))
+	w.regex = append(w.regex, regexp.MustCompile(`^(?P<scrabble>\p{L}+) scrabble(?: score| points| value)?$`))
+	return w
+}
+
+func (w *Words) solve(r *http.Request) Answerer {
+	l, ok := w.Lists.Get(w.language)
+	if !ok {
+		w.Err = fmt.Errorf("no word list for %v", w.language)
+		return w
+	}
+
+	resp := &WordsResponse{}
+
+	switch {
+	case w.remainderM["scrabble"] != "":
+		resp.Tool, resp.Word = "scrabble", w.remainderM["scrabble"]
+
+		score, err := words.Score(l.Language, resp.Word)
+		if err != nil {
+			w.Err = err
+			return w
+		}
+		resp.Score = score
+	case w.remainderM["anagrams"] != "":
+		resp.Tool, resp.Word = "anagrams", w.remainderM["anagrams"]
+		resp.Words = l.Anagrams(resp.Word)
+	default:
+		resp.Word = w.remainderM["affix"]
+
+		switch p := w.remainderM["position"]; {
+		case strings.HasPrefix(p, "end"):
+			resp.Tool, resp.Words = "ending", l.EndingIn(resp.Word)
+		case strings.HasPrefix(p, "contain"):
+			resp.Tool, resp.Words = "containing", l.Containing(resp.Word)
+		default:
+			resp.Tool, resp.Words = "starting", l.StartingWith(resp.Word)
+		}
+
+		if len(resp.Words) == 0 {
+			w.Err = fmt.Errorf("no words %v %q", resp.Tool, resp.Word)
+			return w
+		}
+	}
+
+	resp.Valid = l.Contains(resp.Word)
+	resp.Total = len(resp.Words)
+	if resp.Total > maxWords {
+		resp.Words = resp.Words[:maxWords]
+	}
+
+	w.Solution = resp
+	return w
+}
+
+func (w *Words) tests() []test {
+	tests := []test{}
+
+	for _, tt := range []struct {
+		query string
+		resp  *WordsResponse
+	}{
+		{"anagram of listen", &WordsResponse{Tool: "anagrams", Word: "listen", Words: []string{"enlist", "inlets", "silent", "tinsel"}, Total: 4, Valid: true}},
+		{"unscramble tinsel", &WordsResponse{Tool: "anagrams", Word: "tinsel", Words: []string{"enlist", "inlets", "listen", "silent"}, Total: 4, Valid: true}},
+		{"words ending in -ology", &WordsResponse{Tool: "ending", Word: "ology", Words: []string{"biology", "geology"}, Total: 2}},
+		{"words that start with geo", &WordsResponse{Tool: "starting", Word: "geo", Words: []string{"geology"}, Total: 1}},
+		{"scrabble score of quixotic", &WordsResponse{Tool: "scrabble", Word: "quixotic", Score: 26, Valid: true}},
+		{"zzxq scrabble points", &WordsResponse{Tool: "scrabble", Word: "zzxq", Score: 38}},
+	} {
+		tests = append(tests, test{
+			query: tt.query,
+			expected: []Data{
+				{
+					Type:      WordsType,
+					Triggered: true,
+					Solution:  tt.resp,
+				},
+			},
+		})
+	}
+
+	return tests
+}
+
+func init() {
+	Register(Registration{
+		Name:     "words",
+		Trigger:  `"anagram of listen", "words ending in -ology" or "scrabble score of quixotic"`,
+		Priority: 600,
+		New: func(i *Instant) Answerer {
+			return &Words{Lists: i.WordLists}
+		},
+	})
+}
@@ -0,0 +1,51 @@
+package words
+
+import (
+	"fmt"
+	"strings"
+
+	"golang.org/x/text/language"
+)
+
+// tiles are the points for each letter in a language's edition of Scrabble
+var tiles = map[string]map[rune]int{
+	"en": letters(map[int]string{
+		1: "aeilnorstu", 2: "dg", 3: "bcmp", 4: "fhvwy", 5: "k", 8: "jx", 10: "qz",
+	}),
+	"fr": letters(map[int]string{
+		1: "aeilnorstu", 2: "dgm", 3: "bcp", 4: "fhv", 8: "jq", 10: "kwxyz",
+	}),
+	"de": letters(map[int]string{
+		1: "adeinrstu", 2: "ghlo", 3: "bmwz", 4: "cfkp", 6: "äjüv", 8: "öx", 10: "qy",
+	}),
+}
+
+func letters(points map[int]string) map[rune]int {
+	m := map[rune]int{}
+	for p, s := range points {
+		for _, r := range s {
+			m[r] = p
+		}
+	}
+	return m
+}
+
+// Score is a word's Scrabble score, without any premium squares
+func Score(lang language.Tag, w string) (int, error) {
+	base, _ := lang.Base()
+	t, ok := tiles[base.String()]
+	if !ok {
+		t = tiles["en"]
+	}
+
+	score := 0
+	for _, r := range strings.ToLower(w) {
+		p, ok := t[r]
+		if !ok {
+			return 0, fmt.Errorf("%q isn't a %v scrabble tile", r, base)
+		}
+		score += p
+	}
+
+	return score, nil
+}
@@ -0,0 +1,166 @@
+// Package words answers questions about the words in a language: anagrams, words by how they start or end and scrabble scores
+package words
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/spf13/afero"
+	"golang.org/x/text/language"
+)
+
+// allows for filesystem mock in tests
+var fs = afero.NewOsFs()
+
+// List is the words of a language
+type List struct {
+	Language language.Tag
+	words    []string            // sorted
+	anagrams map[string][]string // keyed by the word's letters in order
+}
+
+// Lists are word lists by base language, e.g. "en"
+type Lists map[string]*List
+
+// New reads a list of words, one per line. Lines starting with "#" are comments.
+func New(lang language.Tag, r io.Reader) (*List, error) {
+	l := &List{
+		Language: lang,
+		anagrams: map[string][]string{},
+	}
+
+	seen := map[string]bool{}
+
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		w := strings.ToLower(strings.TrimSpace(scanner.Text()))
+		if w == "" || strings.HasPrefix(w, "#") || seen[w] {
+			continue
+		}
+		seen[w] = true
+
+		l.words = append(l.words, w)
+		k := key(w)
+		l.anagrams[k] = append(l.anagrams[k], w)
+	}
+
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+
+	if len(l.words) == 0 {
+		return nil, fmt.Errorf("no words for %v", lang)
+	}
+
+	sort.Strings(l.words)
+	for _, a := range l.anagrams {
+		sort.Strings(a)
+	}
+
+	return l, nil
+}
+
+// Load reads the list for each language from dir, e.g. dir/en.txt.
+// Languages without a list are left out.
+func Load(dir string, langs []language.Tag) (Lists, error) {
+	lists := Lists{}
+
+	for _, lang := range langs {
+		base, _ := lang.Base()
+		if _, ok := lists[base.String()]; ok {
+			continue
+		}
+
+		f, err := fs.Open(filepath.Join(dir, base.String()+".txt"))
+		if err != nil {
+			continue
+		}
+
+		l, err := New(language.Make(base.String()), f)
+		f.Close()
+		if err != nil {
+			return nil, err
+		}
+
+		lists[base.String()] = l
+	}
+
+	return lists, nil
+}
+
+// Get returns the list for a language, falling back to English
+func (ls Lists) Get(lang language.Tag) (*List, bool) {
+	base, _ := lang.Base()
+	if l, ok := ls[base.String()]; ok {
+		return l, true
+	}
+
+	l, ok := ls["en"]
+	return l, ok
+}
+
+// Contains reports whether a word is in the list
+func (l *List) Contains(w string) bool {
+	w = strings.ToLower(w)
+	i := sort.SearchStrings(l.words, w)
+	return i < len(l.words) && l.words[i] == w
+}
+
+// Anagrams are the other words with the same letters
+func (l *List) Anagrams(w string) []string {
+	w = strings.ToLower(w)
+
+	found := []string{}
+	for _, a := range l.anagrams[key(w)] {
+		if a != w {
+			found = append(found, a)
+		}
+	}
+
+	return found
+}
+
+// StartingWith are the words that start with a prefix, other than the prefix itself
+func (l *List) StartingWith(prefix string) []string {
+	prefix = strings.ToLower(prefix)
+
+	found := []string{}
+	for i := sort.SearchStrings(l.words, prefix); i < len(l.words) && strings.HasPrefix(l.words[i], prefix); i++ {
+		if l.words[i] != prefix {
+			found = append(found, l.words[i])
+		}
+	}
+
+	return found
+}
+
+// EndingIn are the words that end with a suffix, other than the suffix itself
+func (l *List) EndingIn(suffix string) []string {
+	return l.filter(strings.ToLower(suffix), strings.HasSuffix)
+}
+
+// Containing are the words that have a string in them, other than the string itself
+func (l *List) Containing(s string) []string {
+	return l.filter(strings.ToLower(s), strings.Contains)
+}
+
+func (l *List) filter(s string, fn func(w, s string) bool) []string {
+	found := []string{}
+	for _, w := range l.words {
+		if w != s && fn(w, s) {
+			found = append(found, w)
+		}
+	}
+	return found
+}
+
+// key is a word's letters in order, which is the same for all its anagrams
+func key(w string) string {
+	r := []rune(w)
+	sort.Slice(r, func(i, j int) bool { return r[i] < r[j] })
+	return string(r)
+}
@@ -0,0 +1,140 @@
+package words
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/spf13/afero"
+	"golang.org/x/text/language"
+)
+
+const list = `# a comment
+listen
+silent
+enlist
+tinsel
+inlets
+biology
+geology
+ology
+bio
+biome
+Quixotic
+quixotic
+`
+
+func TestList(t *testing.T) {
+	l, err := New(language.English, strings.NewReader(list))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, c := range []struct {
+		name string
+		got  []string
+		want []string
+	}{
+		{"anagrams", l.Anagrams("Listen"), []string{"enlist", "inlets", "silent", "tinsel"}},
+		{"no anagrams", l.Anagrams("quixotic"), []string{}},
+		{"starting with", l.StartingWith("bio"), []string{"biology", "biome"}},
+		{"ending in", l.EndingIn("ology"), []string{"biology", "geology"}},
+		{"containing", l.Containing("ist"), []string{"enlist", "listen"}},
+	} {
+		t.Run(c.name, func(t *testing.T) {
+			if !reflect.DeepEqual(c.got, c.want) {
+				t.Fatalf("got %+v; want %+v", c.got, c.want)
+			}
+		})
+	}
+
+	if !l.Contains("Quixotic") || l.Contains("quixotical") {
+		t.Fatal("Contains is wrong")
+	}
+}
+
+func TestNewEmpty(t *testing.T) {
+	if _, err := New(language.English, strings.NewReader("# nothing here\n")); err == nil {
+		t.Fatal("expected an error for an empty list")
+	}
+}
+
+func TestLoad(t *testing.T) {
+	fs = afero.NewMemMapFs()
+	defer func() { fs = afero.NewOsFs() }()
+
+	if err := afero.WriteFile(fs, "/words/fr.txt", []byte("chien\nniche\nchine\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	lists, err := Load("/words", []language.Tag{language.French, language.MustParse("fr-CA"), language.German})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(lists) != 1 {
+		t.Fatalf("got %d lists; want 1", len(lists))
+	}
+
+	l, ok := lists.Get(language.MustParse("fr-CA"))
+	if !ok {
+		t.Fatal("no list for fr-CA")
+	}
+
+	if got, want := l.Anagrams("chien"), []string{"chine", "niche"}; !reflect.DeepEqual(got, want) {
+		t.Fatalf("got %+v; want %+v", got, want)
+	}
+
+	// German has no list and there isn't an English one to fall back to
+	if _, ok := lists.Get(language.German); ok {
+		t.Fatal("expected no list for German")
+	}
+}
+
+// the bundled list should load and know the common words
+func TestBundled(t *testing.T) {
+	lists, err := Load(".", []language.Tag{language.AmericanEnglish})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	l, ok := lists.Get(language.Spanish) // falls back to English
+	if !ok {
+		t.Fatal("no English word list")
+	}
+
+	for _, w := range []string{"listen", "silent", "quixotic", "biology"} {
+		if !l.Contains(w) {
+			t.Errorf("%q is missing from the English word list", w)
+		}
+	}
+}
+
+func TestScore(t *testing.T) {
+	for _, c := range []struct {
+		lang language.Tag
+		word string
+		want int
+	}{
+		{language.English, "quixotic", 26},
+		{language.English, "Cabbage", 14},
+		{language.French, "kiwi", 22},
+		{language.German, "Würze", 14},
+		{language.Japanese, "zoo", 12}, // English tiles
+	} {
+		t.Run(c.word, func(t *testing.T) {
+			got, err := Score(c.lang, c.word)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if got != c.want {
+				t.Fatalf("got %d; want %d", got, c.want)
+			}
+		})
+	}
+
+	if _, err := Score(language.English, "ice-cream"); err == nil {
+		t.Fatal("expected an error for a hyphen")
+	}
+}
@@ -0,0 +1,242 @@
+package log
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"log"
+	"os"
+	"regexp"
+	"runtime"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Fields are the key/value pairs of a structured log line
+type Fields map[string]interface{}
+
+// Format is how our log lines are written
+type Format string
+
+// Text is the standard library's format with key=value pairs appended
+const Text Format = "text"
+
+// JSON writes one object per line for log collectors
+const JSON Format = "json"
+
+// Level is the lowest level we write. Only the Debug logger can be turned off.
+type Level string
+
+// DebugLevel writes both Info and Debug
+const DebugLevel Level = "debug"
+
+// InfoLevel writes Info and discards Debug
+const InfoLevel Level = "info"
+
+var (
+	mu     sync.Mutex // guards format and level
+	format = Text
+	level  = InfoLevel
+	outMu  sync.Mutex // a json line is written in one piece
+	out    io.Writer  = os.Stdout
+)
+
+var now = func() time.Time { return time.Now().UTC() }
+
+// ParseFormat returns the Format for s, e.g. "json"
+func ParseFormat(s string) (Format, error) {
+	switch f := Format(strings.ToLower(s)); f {
+	case Text, JSON:
+		return f, nil
+	}
+	return Text, fmt.Errorf("unknown log format %q", s)
+}
+
+// ParseLevel returns the Level for s, e.g. "debug"
+func ParseLevel(s string) (Level, error) {
+	switch l := Level(strings.ToLower(s)); l {
+	case DebugLevel, InfoLevel:
+		return l, nil
+	}
+	return InfoLevel, fmt.Errorf("unknown log level %q", s)
+}
+
+// SetFormat switches Info, Debug and the structured logs to a format.
+// It is safe to call while we are logging.
+func SetFormat(f Format) {
+	mu.Lock()
+	defer mu.Unlock()
+	format = f
+	configure()
+}
+
+// SetLevel turns the Debug logger on or off. It is safe to call while we are logging.
+func SetLevel(l Level) {
+	mu.Lock()
+	defer mu.Unlock()
+	level = l
+	configure()
+}
+
+// configure points our loggers at the right writers. mu must be held.
+// We change the existing loggers rather than replace them as they
+// may be in use by another goroutine.
+func configure() {
+	var info, debug io.Writer = out, out
+	if format == JSON {
+		info, debug = &jsonWriter{level: InfoLevel}, &jsonWriter{level: DebugLevel}
+	}
+
+	if level != DebugLevel {
+		debug = ioutil.Discard
+	}
+
+	switch format {
+	case JSON:
+		Info.SetPrefix("")
+		Info.SetFlags(log.Lshortfile)
+		Debug.SetPrefix("")
+		Debug.SetFlags(log.Llongfile)
+	default:
+		Info.SetPrefix("INFO ")
+		Info.SetFlags(log.Ldate | log.Ltime | log.Lshortfile)
+		Debug.SetPrefix("DEBUG ")
+		Debug.SetFlags(log.Ldate | log.Ltime | log.Llongfile)
+	}
+
+	Info.SetOutput(info)
+	Debug.SetOutput(debug)
+}
+
+// the file:line the standard library puts before a message
+var caller = regexp.MustCompile(`(?s)^(\S+?:\d+): (.*)$`)
+
+// jsonWriter turns the lines of a standard library logger into json
+type jsonWriter struct {
+	level Level
+}
+
+func (j *jsonWriter) Write(p []byte) (int, error) {
+	msg := strings.TrimSuffix(string(p), "\n")
+
+	e := Fields{}
+	if m := caller.FindStringSubmatch(msg); m != nil {
+		e["file"], msg = m[1], m[2]
+	}
+	e["msg"] = msg
+
+	if err := write(j.level, e); err != nil {
+		return 0, err
+	}
+	return len(p), nil
+}
+
+// write writes a json line with durations in milliseconds.
+// The time and level can't be overwritten by the fields.
+func write(l Level, fields Fields) error {
+	e := make(Fields, len(fields)+2)
+	for k, v := range fields {
+		switch t := v.(type) {
+		case error:
+			v = t.Error()
+		case time.Duration:
+			v = float64(t) / float64(time.Millisecond)
+		}
+		e[k] = v
+	}
+	e["time"] = now().Format(time.RFC3339Nano)
+	e["level"] = l
+
+	b, err := json.Marshal(e)
+	if err != nil {
+		return err
+	}
+
+	outMu.Lock()
+	defer outMu.Unlock()
+	_, err = out.Write(append(b, '\n'))
+	return err
+}
+
+type contextKey struct{}
+
+// NewContext returns a copy of ctx that carries a request id
+func NewContext(ctx context.Context, requestID string) context.Context {
+	return context.WithValue(ctx, contextKey{}, requestID)
+}
+
+// RequestID is the request id carried by ctx, if any
+func RequestID(ctx context.Context) string {
+	id, _ := ctx.Value(contextKey{}).(string)
+	return id
+}
+
+// Infow logs msg along with the fields and the request id in ctx
+func Infow(ctx context.Context, msg string, fields Fields) {
+	output(ctx, InfoLevel, msg, fields)
+}
+
+// Debugw is Infow for the Debug logger
+func Debugw(ctx context.Context, msg string, fields Fields) {
+	output(ctx, DebugLevel, msg, fields)
+}
+
+func output(ctx context.Context, l Level, msg string, fields Fields) {
+	mu.Lock()
+	f, lvl := format, level
+	mu.Unlock()
+
+	if l == DebugLevel && lvl != DebugLevel {
+		return
+	}
+
+	e := Fields{}
+	for k, v := range fields {
+		e[k] = v
+	}
+	if id := RequestID(ctx); id != "" {
+		e["request_id"] = id
+	}
+
+	if f == JSON {
+		if _, file, line, ok := runtime.Caller(2); ok {
+			e["file"] = fmt.Sprintf("%v:%d", shortFile(file, l), line)
+		}
+		e["msg"] = msg
+		write(l, e)
+		return
+	}
+
+	keys := make([]string, 0, len(e))
+	for k := range e {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	var b strings.Builder
+	b.WriteString(msg)
+	for _, k := range keys {
+		fmt.Fprintf(&b, " %v=%v", k, e[k])
+	}
+
+	lg := Info
+	if l == DebugLevel {
+		lg = Debug
+	}
+	lg.Output(3, b.String())
+}
+
+// shortFile matches the file names of the standard library loggers
+func shortFile(file string, l Level) string {
+	if l == DebugLevel {
+		return file
+	}
+	if i := strings.LastIndex(file, "/"); i > -1 {
+		return file[i+1:]
+	}
+	return file
+}
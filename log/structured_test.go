@@ -0,0 +1,134 @@
+package log
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestStructured(t *testing.T) {
+	now = func() time.Time {
+		return time.Date(2018, 10, 16, 12, 0, 0, 0, time.UTC)
+	}
+
+	type want struct {
+		lines []string // json lines or the suffix of text lines
+	}
+
+	for _, c := range []struct {
+		name   string
+		format Format
+		level  Level
+		log    func()
+		want
+	}{
+		{
+			name:   "json info",
+			format: JSON,
+			level:  InfoLevel,
+			log: func() {
+				Info.Println("hello")
+				Debug.Println("discarded")
+				Debugw(context.Background(), "discarded", nil)
+			},
+			want: want{
+				[]string{`{"file":"structured_test.go:36","level":"info","msg":"hello","time":"2018-10-16T12:00:00Z"}`},
+			},
+		},
+		{
+			name:   "json request id",
+			format: JSON,
+			level:  DebugLevel,
+			log: func() {
+				ctx := NewContext(context.Background(), "abc123")
+				Infow(ctx, "search", Fields{"search": 15 * time.Millisecond, "error": fmt.Errorf("oops")})
+			},
+			want: want{
+				[]string{`{"error":"oops","file":"structured_test.go:50","level":"info","msg":"search","request_id":"abc123","search":15,"time":"2018-10-16T12:00:00Z"}`},
+			},
+		},
+		{
+			name:   "text",
+			format: Text,
+			level:  DebugLevel,
+			log: func() {
+				ctx := NewContext(context.Background(), "abc123")
+				Infow(ctx, "search", Fields{"search": 15 * time.Millisecond, "hits": 10})
+				Debugw(ctx, "cache get", Fields{"hit": true})
+			},
+			want: want{
+				[]string{
+					"structured_test.go:62: search hits=10 request_id=abc123 search=15ms",
+					"log/structured_test.go:63: cache get hit=true request_id=abc123",
+				},
+			},
+		},
+	} {
+		t.Run(c.name, func(t *testing.T) {
+			buf := &bytes.Buffer{}
+			out = buf
+			defer func() {
+				out = os.Stdout
+				SetFormat(Text)
+				SetLevel(InfoLevel)
+			}()
+
+			SetFormat(c.format)
+			SetLevel(c.level)
+			c.log()
+
+			got := strings.Split(strings.TrimSpace(buf.String()), "\n")
+			if len(got) != len(c.want.lines) {
+				t.Fatalf("got %q; want %q", got, c.want.lines)
+			}
+
+			for i, line := range got {
+				if c.format == Text {
+					if !strings.HasSuffix(line, c.want.lines[i]) {
+						t.Fatalf("got %q; want suffix %q", line, c.want.lines[i])
+					}
+					continue
+				}
+
+				var g, w map[string]interface{}
+				if err := json.Unmarshal([]byte(line), &g); err != nil {
+					t.Fatal(err)
+				}
+				if err := json.Unmarshal([]byte(c.want.lines[i]), &w); err != nil {
+					t.Fatal(err)
+				}
+
+				if !reflect.DeepEqual(g, w) {
+					t.Fatalf("got %v; want %v", line, c.want.lines[i])
+				}
+			}
+		})
+	}
+}
+
+func TestParse(t *testing.T) {
+	for _, c := range []struct {
+		format string
+		level  string
+		err    bool
+	}{
+		{"json", "debug", false},
+		{"TEXT", "Info", false},
+		{"xml", "info", true},
+		{"text", "trace", true},
+	} {
+		t.Run(c.format+"/"+c.level, func(t *testing.T) {
+			_, ferr := ParseFormat(c.format)
+			_, lerr := ParseLevel(c.level)
+			if got := ferr != nil || lerr != nil; got != c.err {
+				t.Fatalf("got errors %v, %v; want an error %v", ferr, lerr, c.err)
+			}
+		})
+	}
+}
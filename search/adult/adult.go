@@ -0,0 +1,152 @@
+// Package adult scores how likely a web page is adult content so SafeSearch can hide it
+package adult
+
+import (
+	"bufio"
+	"io"
+	"net"
+	"strings"
+	"unicode"
+
+	"github.com/jivesearch/jivesearch/search/document"
+)
+
+// Threshold is the score at which we treat a page as adult content
+const Threshold = 0.5
+
+// how much an adult word counts in each part of a page. Two or more words count fully.
+const (
+	titleWeight = 0.4
+	urlWeight   = 0.4
+	textWeight  = 0.2 // each of the keywords and the description
+)
+
+// Classifier scores pages by their domain and their text
+type Classifier struct {
+	domains map[string]struct{}
+	words   map[string]struct{}
+	phrases []string
+}
+
+// New creates an empty Classifier
+func New() *Classifier {
+	return &Classifier{
+		domains: map[string]struct{}{},
+		words:   map[string]struct{}{},
+	}
+}
+
+// AddDomains adds a blocklist of adult domains, one per line or in the hosts file format
+func (c *Classifier) AddDomains(r io.Reader) error {
+	return lines(r, func(l string) {
+		f := strings.Fields(l)
+		c.domains[strings.Trim(strings.ToLower(f[len(f)-1]), ".")] = struct{}{}
+	})
+}
+
+// AddWords adds the words and phrases that suggest a page is adult content, one per line
+func (c *Classifier) AddWords(r io.Reader) error {
+	return lines(r, func(l string) {
+		w := strings.Join(tokenize(l), " ")
+		switch {
+		case w == "":
+		case strings.Contains(w, " "):
+			c.phrases = append(c.phrases, w)
+		default:
+			c.words[w] = struct{}{}
+		}
+	})
+}
+
+// lines calls fn with each line that isn't blank or a # comment
+func lines(r io.Reader, fn func(l string)) error {
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		l := strings.TrimSpace(scanner.Text())
+		if l == "" || strings.HasPrefix(l, "#") {
+			continue
+		}
+		fn(l)
+	}
+
+	return scanner.Err()
+}
+
+// Score is how likely the page is adult content, from 0 to 1.
+// A page on a blocklisted domain, or a subdomain of one, scores 1.
+func (c *Classifier) Score(doc *document.Document) float64 {
+	if c.Blocked(doc.Host) || c.Blocked(doc.Domain) {
+		return 1
+	}
+
+	score := titleWeight*c.text(doc.Title) +
+		urlWeight*c.text(doc.Host+" "+doc.PathParts) +
+		textWeight*c.text(doc.Keywords) +
+		textWeight*c.text(doc.Description)
+
+	if score > 1 {
+		score = 1
+	}
+
+	return score
+}
+
+// Blocked is true if the host or one of its parent domains is on our blocklist
+func (c *Classifier) Blocked(host string) bool {
+	if h, _, err := net.SplitHostPort(host); err == nil {
+		host = h
+	}
+	host = strings.Trim(strings.ToLower(host), ".")
+
+	for host != "" {
+		if _, ok := c.domains[host]; ok {
+			return true
+		}
+
+		i := strings.Index(host, ".")
+		if i == -1 {
+			break
+		}
+		host = host[i+1:]
+	}
+
+	return false
+}
+
+// text is 0 without adult words, 0.5 with one and 1 with two or more
+func (c *Classifier) text(s string) float64 {
+	tokens := tokenize(s)
+	if len(tokens) == 0 {
+		return 0
+	}
+
+	found := map[string]bool{}
+	for _, t := range tokens {
+		if _, ok := c.words[t]; ok {
+			found[t] = true
+		}
+	}
+
+	joined := " " + strings.Join(tokens, " ") + " "
+	for _, p := range c.phrases {
+		if strings.Contains(joined, " "+p+" ") {
+			found[p] = true
+		}
+	}
+
+	switch len(found) {
+	case 0:
+		return 0
+	case 1:
+		return 0.5
+	default:
+		return 1
+	}
+}
+
+// tokenize splits lowercased text into words, so "Free-Porn.com" is "free porn com"
+func tokenize(s string) []string {
+	return strings.FieldsFunc(strings.ToLower(s), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsNumber(r)
+	})
+}
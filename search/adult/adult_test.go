@@ -0,0 +1,111 @@
+package adult
+
+import (
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/jivesearch/jivesearch/search/document"
+)
+
+func classifier(t *testing.T) *Classifier {
+	c := New()
+
+	for name, add := range map[string]func(f *os.File) error{
+		"domains.txt": func(f *os.File) error { return c.AddDomains(f) },
+		"words.txt":   func(f *os.File) error { return c.AddWords(f) },
+	} {
+		f, err := os.Open(name)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if err := add(f); err != nil {
+			t.Fatal(err)
+		}
+		f.Close()
+	}
+
+	return c
+}
+
+func TestScore(t *testing.T) {
+	c := classifier(t)
+
+	if err := c.AddDomains(strings.NewReader("# a hosts file\n0.0.0.0 adult.example.org\n")); err != nil {
+		t.Fatal(err)
+	}
+
+	for _, c2 := range []struct {
+		name string
+		doc  *document.Document
+		want float64
+	}{
+		{
+			"blocklisted", &document.Document{Host: "www.pornhub.com", Domain: "pornhub.com"}, 1,
+		},
+		{
+			"hosts file", &document.Document{Host: "adult.example.org:8080", Domain: "example.org"}, 1,
+		},
+		{
+			"parent of a blocklisted domain", &document.Document{Host: "www.example.org", Domain: "example.org"}, 0,
+		},
+		{
+			"clean", &document.Document{
+				Host:   "golang.org",
+				Domain: "golang.org",
+				Content: document.Content{
+					Title:       "The Go Programming Language",
+					Description: "Go is an open source programming language.",
+				},
+			}, 0,
+		},
+		{
+			"sex education", &document.Document{
+				Host:   "www.example.com",
+				Domain: "example.com",
+				Content: document.Content{
+					Title:       "Sex education in schools",
+					Description: "What children learn about sex, consent and relationships.",
+				},
+			}, 0,
+		},
+		{
+			"adult text", &document.Document{
+				Host:      "free-porn.example.com",
+				Domain:    "example.com",
+				PathParts: "videos",
+				Content: document.Content{
+					Title:       "Free Porn Videos - XXX",
+					Description: "The hottest porn.",
+				},
+			}, 0.7,
+		},
+		{
+			"one word", &document.Document{
+				Host:   "www.example.com",
+				Domain: "example.com",
+				Content: document.Content{
+					Title: "NSFW reactions to the finale",
+				},
+			}, 0.2,
+		},
+		{
+			"phrase", &document.Document{
+				Host:   "www.example.com",
+				Domain: "example.com",
+				Content: document.Content{
+					Title:    "Live sex cams",
+					Keywords: "sex cams, camgirls",
+				},
+			}, 0.4,
+		},
+	} {
+		t.Run(c2.name, func(t *testing.T) {
+			got := c.Score(c2.doc)
+			if got < c2.want-0.001 || got > c2.want+0.001 {
+				t.Fatalf("got %v; want %v", got, c2.want)
+			}
+		})
+	}
+}
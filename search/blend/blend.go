@@ -0,0 +1,60 @@
+// Package blend decides which other verticals to mix into the web results
+package blend
+
+import (
+	"sort"
+
+	"github.com/jivesearch/jivesearch/search/intent"
+)
+
+// Vertical is a type of results that can be blended into the web results
+type Vertical string
+
+// Verticals we can blend
+const (
+	Images Vertical = "images"
+	Videos Vertical = "videos"
+	News   Vertical = "news"
+)
+
+// Blender blends a vertical when the query's intent for it, times its weight, clears the threshold
+type Blender struct {
+	Weights   map[Vertical]float64 // a weight of 0 disables a vertical
+	Threshold float64
+}
+
+// intents are the query intents that call for each vertical
+var intents = map[Vertical]intent.Intent{
+	Images: intent.Images,
+	Videos: intent.Videos,
+	News:   intent.News,
+}
+
+// Verticals are the verticals to blend for a query's intent, strongest first
+func (b *Blender) Verticals(scores intent.Scores) []Vertical {
+	type scored struct {
+		Vertical
+		score float64
+	}
+
+	s := []scored{}
+	for v, in := range intents {
+		if score := scores[in] * b.Weights[v]; score > 0 && score >= b.Threshold {
+			s = append(s, scored{v, score})
+		}
+	}
+
+	sort.Slice(s, func(i, j int) bool {
+		if s[i].score == s[j].score {
+			return s[i].Vertical < s[j].Vertical
+		}
+		return s[i].score > s[j].score
+	})
+
+	verticals := []Vertical{}
+	for _, v := range s {
+		verticals = append(verticals, v.Vertical)
+	}
+
+	return verticals
+}
@@ -0,0 +1,61 @@
+package blend
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/jivesearch/jivesearch/search/intent"
+)
+
+func TestVerticals(t *testing.T) {
+	b := &Blender{
+		Weights:   map[Vertical]float64{Images: 1, Videos: 1, News: 1},
+		Threshold: .5,
+	}
+
+	for _, c := range []struct {
+		name   string
+		scores intent.Scores
+		want   []Vertical
+	}{
+		{"none", nil, []Vertical{}},
+		{"images", intent.Scores{intent.Images: 1}, []Vertical{Images}},
+		{"not a vertical", intent.Scores{intent.Local: 1, intent.Navigational: 1}, []Vertical{}},
+		{"videos", intent.Scores{intent.Informational: .7, intent.Videos: .6}, []Vertical{Videos}},
+		{"below threshold", intent.Scores{intent.News: .4}, []Vertical{}},
+		{"tie", intent.Scores{intent.News: 1, intent.Videos: 1}, []Vertical{News, Videos}},
+		{"strongest first", intent.Scores{intent.Images: 1, intent.Videos: .6}, []Vertical{Images, Videos}},
+	} {
+		t.Run(c.name, func(t *testing.T) {
+			got := b.Verticals(c.scores)
+			if !reflect.DeepEqual(got, c.want) {
+				t.Fatalf("got %v; want %v", got, c.want)
+			}
+		})
+	}
+}
+
+func TestWeights(t *testing.T) {
+	b := &Blender{
+		Weights:   map[Vertical]float64{Images: .5, Videos: 2},
+		Threshold: .5,
+	}
+
+	for _, c := range []struct {
+		name   string
+		scores intent.Scores
+		want   []Vertical
+	}{
+		{"cat pictures", intent.Scores{intent.Images: 1}, []Vertical{Images}},
+		{"cat logo", intent.Scores{intent.Images: .8}, []Vertical{}},
+		{"cat tutorial", intent.Scores{intent.Videos: .6}, []Vertical{Videos}},
+		{"cat news", intent.Scores{intent.News: 1}, []Vertical{}}, // disabled
+	} {
+		t.Run(c.name, func(t *testing.T) {
+			got := b.Verticals(c.scores)
+			if !reflect.DeepEqual(got, c.want) {
+				t.Fatalf("got %v; want %v", got, c.want)
+			}
+		})
+	}
+}
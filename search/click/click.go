@@ -0,0 +1,69 @@
+// Package click learns from which results people click to improve our ranking.
+// Only a salted hash of the query, the result and its position are kept: there is
+// no user, IP address or time so a click can't be tied back to whoever made it.
+package click
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"math"
+	"strings"
+)
+
+// Store persists the click-through statistics, aggregated by query and URL
+type Store interface {
+	Setup() error
+	// Impression counts the query's first page of results being shown
+	Impression(query string) error
+	// Click counts a click on the result at position (starting at 1)
+	Click(query, url string, position int) error
+	Stats(query string) (*Stats, error)
+}
+
+// Stats are the click-through statistics of a query
+type Stats struct {
+	Impressions int64
+	URLs        map[string]*Stat
+}
+
+// Stat are the clicks on one of a query's results
+type Stat struct {
+	Clicks   int64
+	Weighted float64 // clicks corrected for their position, see weight
+}
+
+// CTR is a result's position-corrected click-through rate
+func (s *Stats) CTR(url string) float64 {
+	st, ok := s.URLs[url]
+	if !ok || s.Impressions == 0 {
+		return 0
+	}
+
+	return math.Min(st.Weighted/float64(s.Impressions), 1)
+}
+
+// MaxPosition is the lowest position we count clicks for
+const MaxPosition = 100
+
+// weight corrects for people being more likely to click a result near the top
+// simply because they look there first. Clicks are weighted by the inverse of
+// the chance that the position is looked at, which we assume falls off as 1/sqrt(position).
+func weight(position int) float64 {
+	return math.Sqrt(float64(position))
+}
+
+// Hasher turns a query into the key we store its statistics under
+type Hasher struct {
+	Salt string
+}
+
+// hashLen is the number of bytes of the hash we keep
+const hashLen = 16
+
+// Hash is the salted hash of a query. Queries that differ only by case or spacing have the same hash.
+func (h Hasher) Hash(q string) string {
+	m := hmac.New(sha256.New, []byte(h.Salt))
+	m.Write([]byte(strings.Join(strings.Fields(strings.ToLower(q)), " ")))
+	return hex.EncodeToString(m.Sum(nil)[:hashLen])
+}
@@ -0,0 +1,47 @@
+package click
+
+import (
+	"testing"
+)
+
+func TestHash(t *testing.T) {
+	h := Hasher{Salt: "my_salt"}
+
+	got := h.Hash("Jive  Search")
+	if got != h.Hash("jive search") {
+		t.Fatal("queries that differ only by case and spacing should have the same hash")
+	}
+
+	if got == h.Hash("jive") || got == (Hasher{Salt: "other"}).Hash("jive search") {
+		t.Fatal("expected a different hash for a different query or salt")
+	}
+
+	if len(got) != 2*hashLen {
+		t.Fatalf("got hash %q; want %d hex characters", got, 2*hashLen)
+	}
+}
+
+func TestCTR(t *testing.T) {
+	st := &Stats{
+		Impressions: 10,
+		URLs: map[string]*Stat{
+			"https://a.example.com": {Clicks: 4, Weighted: 4},
+			"https://b.example.com": {Clicks: 6, Weighted: 6 * weight(4)},
+		},
+	}
+
+	for _, c := range []struct {
+		url  string
+		want float64
+	}{
+		{"https://a.example.com", .4},
+		{"https://b.example.com", 1}, // capped
+		{"https://c.example.com", 0},
+	} {
+		t.Run(c.url, func(t *testing.T) {
+			if got := st.CTR(c.url); got != c.want {
+				t.Fatalf("got %v; want %v", got, c.want)
+			}
+		})
+	}
+}
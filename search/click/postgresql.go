@@ -0,0 +1,88 @@
+package click
+
+import (
+	"database/sql"
+)
+
+// PostgreSQL stores our click-through statistics in PostgreSQL
+type PostgreSQL struct {
+	*sql.DB
+}
+
+const (
+	queriesTable = "click_queries"
+	clicksTable  = "clicks"
+)
+
+// Setup creates our tables if they don't exist
+func (p *PostgreSQL) Setup() error {
+	_, err := p.DB.Exec(`
+		CREATE TABLE IF NOT EXISTS ` + queriesTable + ` (
+			query text PRIMARY KEY,
+			impressions bigint NOT NULL DEFAULT 0
+		);
+		CREATE TABLE IF NOT EXISTS ` + clicksTable + ` (
+			query text NOT NULL,
+			url text NOT NULL,
+			clicks bigint NOT NULL DEFAULT 0,
+			weighted double precision NOT NULL DEFAULT 0,
+			PRIMARY KEY (query, url)
+		);
+	`)
+
+	return err
+}
+
+// Impression counts the query's first page of results being shown
+func (p *PostgreSQL) Impression(query string) error {
+	_, err := p.DB.Exec(
+		`INSERT INTO `+queriesTable+` (query, impressions) VALUES ($1, 1)
+		ON CONFLICT (query) DO UPDATE SET impressions = `+queriesTable+`.impressions + 1`,
+		query,
+	)
+
+	return err
+}
+
+// Click counts a click on the result at position
+func (p *PostgreSQL) Click(query, url string, position int) error {
+	_, err := p.DB.Exec(
+		`INSERT INTO `+clicksTable+` (query, url, clicks, weighted) VALUES ($1, $2, 1, $3)
+		ON CONFLICT (query, url) DO UPDATE SET clicks = `+clicksTable+`.clicks + 1, weighted = `+clicksTable+`.weighted + EXCLUDED.weighted`,
+		query, url, weight(position),
+	)
+
+	return err
+}
+
+// Stats returns the query's statistics
+func (p *PostgreSQL) Stats(query string) (*Stats, error) {
+	st := &Stats{URLs: map[string]*Stat{}}
+
+	err := p.DB.QueryRow(`SELECT impressions FROM `+queriesTable+` WHERE query = $1`, query).Scan(&st.Impressions)
+	switch err {
+	case nil:
+	case sql.ErrNoRows:
+		return st, nil
+	default:
+		return nil, err
+	}
+
+	rows, err := p.DB.Query(`SELECT url, clicks, weighted FROM `+clicksTable+` WHERE query = $1`, query)
+	if err != nil {
+		return nil, err
+	}
+
+	defer rows.Close()
+
+	for rows.Next() {
+		var u string
+		s := &Stat{}
+		if err := rows.Scan(&u, &s.Clicks, &s.Weighted); err != nil {
+			return nil, err
+		}
+		st.URLs[u] = s
+	}
+
+	return st, rows.Err()
+}
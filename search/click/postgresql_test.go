@@ -0,0 +1,74 @@
+package click
+
+import (
+	"reflect"
+	"testing"
+
+	sqlmock "gopkg.in/DATA-DOG/go-sqlmock.v2"
+)
+
+func TestPostgreSQL(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+
+	p := &PostgreSQL{DB: db}
+
+	mock.ExpectExec("INSERT INTO click_queries (.+) ON CONFLICT").WithArgs("abc").
+		WillReturnResult(sqlmock.NewResult(0, 1))
+
+	if err := p.Impression("abc"); err != nil {
+		t.Fatal(err)
+	}
+
+	mock.ExpectExec("INSERT INTO clicks (.+) ON CONFLICT").WithArgs("abc", "https://b.example.com", weight(4)).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+
+	if err := p.Click("abc", "https://b.example.com", 4); err != nil {
+		t.Fatal(err)
+	}
+
+	mock.ExpectQuery("SELECT impressions FROM click_queries").WithArgs("abc").
+		WillReturnRows(sqlmock.NewRows([]string{"impressions"}).AddRow(20))
+	mock.ExpectQuery("SELECT url, clicks, weighted FROM clicks").WithArgs("abc").
+		WillReturnRows(sqlmock.NewRows([]string{"url", "clicks", "weighted"}).
+			AddRow("https://a.example.com", 5, 5.0).
+			AddRow("https://b.example.com", 1, 2.0),
+		)
+
+	got, err := p.Stats("abc")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := &Stats{
+		Impressions: 20,
+		URLs: map[string]*Stat{
+			"https://a.example.com": {Clicks: 5, Weighted: 5},
+			"https://b.example.com": {Clicks: 1, Weighted: 2},
+		},
+	}
+
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("got %+v; want %+v", got, want)
+	}
+
+	// a query we haven't seen
+	mock.ExpectQuery("SELECT impressions FROM click_queries").WithArgs("xyz").
+		WillReturnRows(sqlmock.NewRows([]string{"impressions"}))
+
+	got, err = p.Stats("xyz")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if want := (&Stats{URLs: map[string]*Stat{}}); !reflect.DeepEqual(got, want) {
+		t.Fatalf("got %+v; want %+v", got, want)
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Fatal(err)
+	}
+}
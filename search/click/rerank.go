@@ -0,0 +1,75 @@
+package click
+
+import (
+	"math"
+	"sort"
+
+	"github.com/jivesearch/jivesearch/log"
+	"github.com/jivesearch/jivesearch/search"
+	"golang.org/x/text/language"
+)
+
+// Reranker blends a query's historical click-through rates into the order of its results
+type Reranker struct {
+	search.Fetcher
+	Store
+	Hasher
+	Weight         float64 // from 0 (original order) to 1 (click-through rate only)
+	MinImpressions int64   // queries shown fewer times than this aren't reranked
+}
+
+// Fetch returns the results reordered by how often each one is clicked.
+// A result keeps (1 - Weight) of a score that falls off with its original rank,
+// the same discount as DCG, and gains Weight times its click-through rate.
+// Results only move within the page they were fetched for.
+func (rr *Reranker) Fetch(q string, s search.Filter, lang language.Tag, region language.Region, number int, offset int) (*search.Results, error) {
+	res, err := rr.Fetcher.Fetch(q, s, lang, region, number, offset)
+	if err != nil || rr.Weight <= 0 || res == nil || len(res.Documents) < 2 {
+		return res, err
+	}
+
+	st, err := rr.Store.Stats(rr.Hash(q))
+	if err != nil {
+		log.Info.Println(err)
+		return res, nil
+	}
+
+	if st.Impressions == 0 || st.Impressions < rr.MinImpressions {
+		return res, nil
+	}
+
+	res = res.Detrack() // so the URLs match the ones people click on
+
+	scores := make(map[string]float64, len(res.Documents))
+	for i, doc := range res.Documents {
+		rel := 1 / math.Log2(float64(offset+i+2))
+		scores[doc.ID] = (1-rr.Weight)*rel + rr.Weight*st.CTR(doc.ID)
+	}
+
+	sort.SliceStable(res.Documents, func(i, j int) bool {
+		return scores[res.Documents[i].ID] > scores[res.Documents[j].ID]
+	})
+
+	return res, nil
+}
+
+// FetchAfter returns the results that follow the cursor. Pages that deep aren't reranked.
+func (rr *Reranker) FetchAfter(q string, s search.Filter, lang language.Tag, region language.Region, number int, cursor string) (*search.Results, error) {
+	af, ok := rr.Fetcher.(search.AfterFetcher)
+	if !ok {
+		return &search.Results{}, search.ErrInvalidCursor
+	}
+
+	return af.FetchAfter(q, s, lang, region, number, cursor)
+}
+
+// FetchFiles returns the files matching a query. Their facets would no longer add up
+// if we reordered them so they aren't reranked.
+func (rr *Reranker) FetchFiles(q string, s search.Filter, f search.FileFilter, lang language.Tag, region language.Region, number int, offset int) (*search.Results, error) {
+	ff, ok := rr.Fetcher.(search.FileFetcher)
+	if !ok {
+		return &search.Results{}, search.ErrNoFiles
+	}
+
+	return ff.FetchFiles(q, s, f, lang, region, number, offset)
+}
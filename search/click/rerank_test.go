@@ -0,0 +1,79 @@
+package click
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/jivesearch/jivesearch/search"
+	"github.com/jivesearch/jivesearch/search/document"
+	"golang.org/x/text/language"
+)
+
+type mockFetcher struct{}
+
+func (m *mockFetcher) Fetch(q string, s search.Filter, lang language.Tag, region language.Region, number int, offset int) (*search.Results, error) {
+	res := &search.Results{Count: 3}
+	for _, u := range []string{"https://a.example.com", "https://b.example.com", "https://c.example.com"} {
+		res.Documents = append(res.Documents, &document.Document{ID: u})
+	}
+	return res, nil
+}
+
+func TestReranker(t *testing.T) {
+	h := Hasher{Salt: "my_salt"}
+	s := &Simple{}
+	if err := s.Setup(); err != nil {
+		t.Fatal(err)
+	}
+
+	// people skip past the top result to the third
+	for i := 0; i < 10; i++ {
+		if err := s.Impression(h.Hash("jive search")); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	for i := 0; i < 6; i++ {
+		if err := s.Click(h.Hash("jive search"), "https://c.example.com", 3); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	for _, c := range []struct {
+		name           string
+		query          string
+		weight         float64
+		minImpressions int64
+		want           []string
+	}{
+		{"reranked", "Jive Search", .5, 5, []string{"https://c.example.com", "https://a.example.com", "https://b.example.com"}},
+		{"light weight", "jive search", .1, 5, []string{"https://a.example.com", "https://b.example.com", "https://c.example.com"}},
+		{"too few impressions", "jive search", .5, 20, []string{"https://a.example.com", "https://b.example.com", "https://c.example.com"}},
+		{"no clicks", "jive", .5, 0, []string{"https://a.example.com", "https://b.example.com", "https://c.example.com"}},
+		{"off", "jive search", 0, 0, []string{"https://a.example.com", "https://b.example.com", "https://c.example.com"}},
+	} {
+		t.Run(c.name, func(t *testing.T) {
+			rr := &Reranker{
+				Fetcher:        &mockFetcher{},
+				Store:          s,
+				Hasher:         h,
+				Weight:         c.weight,
+				MinImpressions: c.minImpressions,
+			}
+
+			res, err := rr.Fetch(c.query, search.Moderate, language.English, language.MustParseRegion("US"), 10, 0)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			got := []string{}
+			for _, doc := range res.Documents {
+				got = append(got, doc.ID)
+			}
+
+			if !reflect.DeepEqual(got, c.want) {
+				t.Fatalf("got %+v; want %+v", got, c.want)
+			}
+		})
+	}
+}
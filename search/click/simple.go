@@ -0,0 +1,72 @@
+package click
+
+import (
+	"sync"
+)
+
+// Simple is an in-memory Store. Statistics are lost on restart so it is only suitable for testing.
+type Simple struct {
+	mu    sync.Mutex
+	stats map[string]*Stats
+}
+
+// Setup initializes the store
+func (s *Simple) Setup() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.stats = map[string]*Stats{}
+	return nil
+}
+
+func (s *Simple) get(query string) *Stats {
+	st, ok := s.stats[query]
+	if !ok {
+		st = &Stats{URLs: map[string]*Stat{}}
+		s.stats[query] = st
+	}
+	return st
+}
+
+// Impression counts the query's first page of results being shown
+func (s *Simple) Impression(query string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.get(query).Impressions++
+	return nil
+}
+
+// Click counts a click on the result at position
+func (s *Simple) Click(query, url string, position int) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	st := s.get(query)
+	u, ok := st.URLs[url]
+	if !ok {
+		u = &Stat{}
+		st.URLs[url] = u
+	}
+
+	u.Clicks++
+	u.Weighted += weight(position)
+	return nil
+}
+
+// Stats returns a copy of the query's statistics
+func (s *Simple) Stats(query string) (*Stats, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	st := &Stats{URLs: map[string]*Stat{}}
+	if o, ok := s.stats[query]; ok {
+		st.Impressions = o.Impressions
+		for u, stat := range o.URLs {
+			ss := *stat
+			st.URLs[u] = &ss
+		}
+	}
+
+	return st, nil
+}
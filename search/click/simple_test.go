@@ -0,0 +1,58 @@
+package click
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestSimple(t *testing.T) {
+	s := &Simple{}
+	if err := s.Setup(); err != nil {
+		t.Fatal(err)
+	}
+
+	for i := 0; i < 3; i++ {
+		if err := s.Impression("abc"); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	for _, c := range []struct {
+		url      string
+		position int
+	}{
+		{"https://a.example.com", 1},
+		{"https://b.example.com", 4},
+		{"https://a.example.com", 1},
+	} {
+		if err := s.Click("abc", c.url, c.position); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	got, err := s.Stats("abc")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := &Stats{
+		Impressions: 3,
+		URLs: map[string]*Stat{
+			"https://a.example.com": {Clicks: 2, Weighted: 2},
+			"https://b.example.com": {Clicks: 1, Weighted: 2},
+		},
+	}
+
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("got %+v; want %+v", got, want)
+	}
+
+	got, err = s.Stats("unknown")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if want := (&Stats{URLs: map[string]*Stat{}}); !reflect.DeepEqual(got, want) {
+		t.Fatalf("got %+v; want %+v", got, want)
+	}
+}
@@ -0,0 +1,117 @@
+package search
+
+import (
+	"fmt"
+	"net/url"
+	"strings"
+)
+
+// PerHost is the number of results we show from a host before collapsing the rest
+const PerHost = 2
+
+// More are the results from a host that were collapsed
+type More struct {
+	Host   string `json:"host"`
+	After  string `json:"after"` // the ID of the host's last result we do show
+	Hidden int    `json:"hidden"`
+	Query  string `json:"query"` // the query for all of the host's results
+}
+
+// Collapse keeps the first perHost results from each host so one site doesn't take over the page.
+// The hosts that had results collapsed are listed in More along with a site: query to see them all.
+// Queries that are already limited to a site aren't collapsed.
+func (r *Results) Collapse(q string, perHost int) *Results {
+	if _, sites := SplitSites(q); perHost < 1 || len(sites) > 0 {
+		return r
+	}
+
+	shown := map[string]int{}
+	last := map[string]string{} // the ID of the last result shown from each host
+	more := map[string]*More{}
+	docs := r.Documents[:0]
+	r.More = nil
+
+	for _, doc := range r.Documents {
+		host := Host(doc.ID)
+		if host == "" || shown[host] < perHost {
+			shown[host]++
+			last[host] = doc.ID
+			docs = append(docs, doc)
+			continue
+		}
+
+		m, ok := more[host]
+		if !ok {
+			m = &More{
+				Host:  host,
+				After: last[host],
+				Query: fmt.Sprintf("site:%v %v", host, q),
+			}
+			more[host] = m
+			r.More = append(r.More, m)
+		}
+		m.Hidden++
+	}
+
+	r.Documents = docs
+	return r
+}
+
+// MoreFrom returns the collapsed results to link to after a result, if any
+func (r *Results) MoreFrom(id string) *More {
+	for _, m := range r.More {
+		if m.After == id {
+			return m
+		}
+	}
+
+	return nil
+}
+
+// SplitSites separates the site: operators from the rest of a query
+func SplitSites(q string) (string, []string) {
+	return splitOperator(q, "site:")
+}
+
+// SplitFileTypes separates the filetype: operators from the rest of a query, e.g. "filetype:pdf"
+func SplitFileTypes(q string) (string, []string) {
+	return splitOperator(q, "filetype:")
+}
+
+// splitOperator separates an operator's lowercased values from the rest of a query.
+// An operator without a value is left as a term.
+func splitOperator(q, op string) (string, []string) {
+	terms, values := []string{}, []string{}
+
+	for _, f := range strings.Fields(q) {
+		if strings.HasPrefix(strings.ToLower(f), op) {
+			if v := strings.ToLower(f[len(op):]); v != "" {
+				values = append(values, v)
+				continue
+			}
+		}
+		terms = append(terms, f)
+	}
+
+	return strings.Join(terms, " "), values
+}
+
+// Site limits a query to a single host. Any site: operators in the query are replaced.
+func Site(q, site string) string {
+	if site == "" {
+		return q
+	}
+
+	q, _ = SplitSites(q)
+	return strings.TrimSpace(q + " site:" + site)
+}
+
+// Host is the lowercased host of a result
+func Host(id string) string {
+	u, err := url.Parse(id)
+	if err != nil {
+		return ""
+	}
+
+	return strings.ToLower(u.Hostname())
+}
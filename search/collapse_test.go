@@ -0,0 +1,118 @@
+package search
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/jivesearch/jivesearch/search/document"
+)
+
+func TestCollapse(t *testing.T) {
+	ids := []string{
+		"https://docs.example.com/a",
+		"https://docs.example.com/b",
+		"https://other.org/",
+		"https://docs.example.com/c",
+		"https://Docs.Example.com/d",
+		"https://www.example.com/",
+	}
+
+	for _, c := range []struct {
+		name  string
+		query string
+		want  []string
+		more  []*More
+	}{
+		{
+			name:  "collapsed",
+			query: "jive docs",
+			want:  []string{"https://docs.example.com/a", "https://docs.example.com/b", "https://other.org/", "https://www.example.com/"},
+			more: []*More{
+				{Host: "docs.example.com", After: "https://docs.example.com/b", Hidden: 2, Query: "site:docs.example.com jive docs"},
+			},
+		},
+		{
+			name:  "site",
+			query: "jive site:docs.example.com",
+			want:  ids,
+		},
+	} {
+		t.Run(c.name, func(t *testing.T) {
+			r := &Results{}
+			for _, id := range ids {
+				r.Documents = append(r.Documents, &document.Document{ID: id})
+			}
+
+			r = r.Collapse(c.query, PerHost)
+
+			got := []string{}
+			for _, doc := range r.Documents {
+				got = append(got, doc.ID)
+			}
+
+			if !reflect.DeepEqual(got, c.want) {
+				t.Fatalf("got %+v; want %+v", got, c.want)
+			}
+
+			if !reflect.DeepEqual(r.More, c.more) {
+				t.Fatalf("got %+v; want %+v", r.More, c.more)
+			}
+
+			if len(c.more) > 0 && !reflect.DeepEqual(r.MoreFrom("https://docs.example.com/b"), c.more[0]) {
+				t.Fatal("expected the link to the rest of the results after the host's last result")
+			}
+		})
+	}
+}
+
+func TestSplitSites(t *testing.T) {
+	q, sites := SplitSites("jive site:Example.com search site: site:example.org")
+	if q != "jive search site:" {
+		t.Fatalf("got query %q; want %q", q, "jive search site:")
+	}
+
+	if want := []string{"example.com", "example.org"}; !reflect.DeepEqual(sites, want) {
+		t.Fatalf("got sites %+v; want %+v", sites, want)
+	}
+}
+
+func TestSplitFileTypes(t *testing.T) {
+	q, types := SplitFileTypes("annual report filetype:PDF site:example.com filetype:docx")
+	if q != "annual report site:example.com" {
+		t.Fatalf("got query %q; want %q", q, "annual report site:example.com")
+	}
+
+	if want := []string{"pdf", "docx"}; !reflect.DeepEqual(types, want) {
+		t.Fatalf("got file types %+v; want %+v", types, want)
+	}
+}
+
+func TestSite(t *testing.T) {
+	for _, c := range []struct {
+		q, site, want string
+	}{
+		{"jive", "", "jive"},
+		{"jive", "example.com", "jive site:example.com"},
+		{"", "example.com", "site:example.com"},
+		{"jive site:Example.com", "example.com", "jive site:example.com"},
+		{"jive site:example.org search", "example.com", "jive search site:example.com"},
+	} {
+		t.Run(c.q+" "+c.site, func(t *testing.T) {
+			if got := Site(c.q, c.site); got != c.want {
+				t.Fatalf("got %q; want %q", got, c.want)
+			}
+		})
+	}
+}
+
+func TestHost(t *testing.T) {
+	for id, want := range map[string]string{
+		"https://Docs.Example.com:8080/a?b=c": "docs.example.com",
+		"not a url":                           "",
+		"%":                                   "",
+	} {
+		if got := Host(id); got != want {
+			t.Fatalf("got %q for %q; want %q", got, id, want)
+		}
+	}
+}
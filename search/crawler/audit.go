@@ -0,0 +1,238 @@
+package crawler
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/jivesearch/jivesearch/log"
+	"github.com/jivesearch/jivesearch/search/document"
+	img "github.com/jivesearch/jivesearch/search/image"
+	"github.com/pkg/errors"
+	"github.com/temoto/robotstxt"
+)
+
+// Indexer lists the documents in our index and removes them
+type Indexer interface {
+	Indexed(fn func(id string) error) error
+	Delete(id string) error
+}
+
+// Auditor checks the documents in our index against the
+// current robots.txt and noindex rules of their sites
+type Auditor struct {
+	HTTPClient *http.Client
+	UserAgent
+	Indexer
+	MaxBytes int64         // max number of bytes of a page to download...-1 for no limit
+	Delay    time.Duration // min time between requests to the same host
+	Noindex  bool          // refetch each page to check its X-Robots-Tag header and robots meta tag
+	Purge    bool          // remove the documents we aren't allowed to index
+	groups   map[string]*robotstxt.Group
+	last     map[string]time.Time
+}
+
+// Reason is why a document isn't allowed in our index
+type Reason string
+
+// Disallowed means the site's robots.txt doesn't let us crawl the page
+const Disallowed Reason = "robots.txt"
+
+// NoIndex means the page asks not to be indexed
+const NoIndex Reason = "noindex"
+
+// Violation is a document that shouldn't be in our index
+type Violation struct {
+	ID     string
+	Reason Reason
+}
+
+// Audit summarizes an audit of our index
+type Audit struct {
+	Checked    int64
+	Violations []Violation
+	Purged     int64
+	Errors     int64 // documents we couldn't check
+}
+
+// Count is the number of violations for a reason
+func (a *Audit) Count(r Reason) int64 {
+	var cnt int64
+	for _, v := range a.Violations {
+		if v.Reason == r {
+			cnt++
+		}
+	}
+	return cnt
+}
+
+func (a *Audit) String() string {
+	return fmt.Sprintf("checked: %d, disallowed by robots.txt: %d, noindex: %d, purged: %d, errors: %d",
+		a.Checked, a.Count(Disallowed), a.Count(NoIndex), a.Purged, a.Errors)
+}
+
+var sleep = time.Sleep
+
+// Run audits every indexed document. A robots.txt that can't be fetched
+// or that returns a 5xx isn't treated as a violation as it is likely temporary.
+func (au *Auditor) Run() (*Audit, error) {
+	au.groups = map[string]*robotstxt.Group{}
+	au.last = map[string]time.Time{}
+	audit := &Audit{}
+
+	err := au.Indexed(func(id string) error {
+		audit.Checked++
+
+		reason, err := au.check(id)
+		if err != nil {
+			log.Debug.Println(err)
+			audit.Errors++
+			return nil
+		}
+
+		if reason == "" {
+			return nil
+		}
+
+		audit.Violations = append(audit.Violations, Violation{ID: id, Reason: reason})
+		log.Info.Printf("%v %v\n", reason, id)
+
+		if !au.Purge {
+			return nil
+		}
+
+		if err := au.Delete(id); err != nil {
+			return errors.Wrapf(err, "unable to purge %v", id)
+		}
+		audit.Purged++
+		return nil
+	})
+
+	return audit, err
+}
+
+// check returns the reason we aren't allowed to index a document, if any
+func (au *Auditor) check(id string) (Reason, error) {
+	doc, err := document.New(id)
+	if err != nil {
+		return "", errors.Wrapf(err, "invalid document id %v", id)
+	}
+
+	group, err := au.group(doc)
+	if err != nil {
+		return "", err
+	}
+
+	if !group.Test(doc.URL.Path) {
+		return Disallowed, nil
+	}
+
+	if !au.Noindex {
+		return "", nil
+	}
+
+	au.wait(doc.Host, group.CrawlDelay)
+
+	resp, err := au.get(doc.ID)
+	if err != nil {
+		return "", err
+	}
+	defer resp.Body.Close()
+
+	// a missing page will be removed the next time it is crawled
+	if resp.StatusCode != http.StatusOK {
+		return "", nil
+	}
+
+	var b io.Reader = resp.Body
+	if au.MaxBytes > -1 {
+		b = io.LimitReader(b, au.MaxBytes)
+	}
+
+	if err := doc.SetHeader(resp.Header).SetPolicyFromHeader(au.UserAgent.Short).SetTokenizer(b); err != nil {
+		return "", errors.Wrapf(err, "unable to parse %v", id)
+	}
+
+	// we only want the robots meta tag so we throw away any links and images
+	links, images := make(chan string), make(chan *img.Image)
+	go func() {
+		for range links {
+		}
+	}()
+	go func() {
+		for range images {
+		}
+	}()
+
+	err = doc.SetContent(au.UserAgent.Short, 0, links, images, 0, 0, 0)
+	close(links)
+	close(images)
+
+	if err != nil {
+		return "", errors.Wrapf(err, "unable to parse %v", id)
+	}
+
+	if !doc.Index {
+		return NoIndex, nil
+	}
+
+	return "", nil
+}
+
+// group fetches the robots.txt rules for our useragent once per host
+func (au *Auditor) group(doc *document.Document) (*robotstxt.Group, error) {
+	sh := doc.SchemeHost()
+	if g, ok := au.groups[sh]; ok {
+		if g == nil {
+			return nil, fmt.Errorf("robots.txt unavailable for %v", sh)
+		}
+		return g, nil
+	}
+
+	au.groups[sh] = nil
+
+	au.wait(doc.Host, 0)
+	resp, err := au.get(doc.URL.ResolveReference(RobotsPath).String())
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= 500 {
+		return nil, fmt.Errorf("robots.txt for %v returned %d", sh, resp.StatusCode)
+	}
+
+	rbts, err := robotstxt.FromResponse(resp)
+	if err != nil {
+		return nil, errors.Wrapf(err, "unable to parse robots.txt for %v", sh)
+	}
+
+	au.groups[sh] = rbts.FindGroup(au.UserAgent.Full)
+	return au.groups[sh], nil
+}
+
+// wait spaces out our requests to a host by the greater of Delay and its crawl-delay
+func (au *Auditor) wait(host string, delay time.Duration) {
+	if delay < au.Delay {
+		delay = au.Delay
+	}
+
+	if l, ok := au.last[host]; ok {
+		if d := delay - now().Sub(l); d > 0 {
+			sleep(d)
+		}
+	}
+
+	au.last[host] = now()
+}
+
+func (au *Auditor) get(u string) (*http.Response, error) {
+	req, err := http.NewRequest("GET", u, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Set("User-Agent", au.UserAgent.Full)
+	return au.HTTPClient.Do(req)
+}
@@ -0,0 +1,136 @@
+package crawler
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+	"time"
+)
+
+type mockIndexer struct {
+	ids     []string
+	deleted []string
+}
+
+func (m *mockIndexer) Indexed(fn func(id string) error) error {
+	for _, id := range m.ids {
+		if err := fn(id); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (m *mockIndexer) Delete(id string) error {
+	m.deleted = append(m.deleted, id)
+	return nil
+}
+
+func TestAudit(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/robots.txt", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, "User-agent: *\nDisallow: /private\n")
+	})
+	mux.HandleFunc("/public", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `<html><head><title>public</title></head><body><a href="/elsewhere">link</a></body></html>`)
+	})
+	mux.HandleFunc("/meta", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `<html><head><meta name="robots" content="noindex"></head><body><img src="/a.png"></body></html>`)
+	})
+	mux.HandleFunc("/header", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("X-Robots-Tag", "noindex")
+		fmt.Fprint(w, `<html><head><title>header</title></head></html>`)
+	})
+	mux.HandleFunc("/gone", http.NotFound)
+
+	ts := httptest.NewServer(mux)
+	defer ts.Close()
+
+	down := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer down.Close()
+
+	ids := []string{
+		ts.URL + "/public", ts.URL + "/private/page", ts.URL + "/meta",
+		ts.URL + "/header", ts.URL + "/gone", down.URL + "/page",
+	}
+
+	sleep = func(time.Duration) {}
+	defer func() { sleep = time.Sleep }()
+
+	for _, c := range []struct {
+		name    string
+		noindex bool
+		purge   bool
+		want    *Audit
+		deleted []string
+	}{
+		{
+			name: "robots.txt",
+			want: &Audit{
+				Checked:    6,
+				Violations: []Violation{{ID: ts.URL + "/private/page", Reason: Disallowed}},
+				Errors:     1,
+			},
+		},
+		{
+			name:    "noindex and purge",
+			noindex: true,
+			purge:   true,
+			want: &Audit{
+				Checked: 6,
+				Violations: []Violation{
+					{ID: ts.URL + "/private/page", Reason: Disallowed},
+					{ID: ts.URL + "/meta", Reason: NoIndex},
+					{ID: ts.URL + "/header", Reason: NoIndex},
+				},
+				Purged: 3,
+				Errors: 1,
+			},
+			deleted: []string{ts.URL + "/private/page", ts.URL + "/meta", ts.URL + "/header"},
+		},
+	} {
+		t.Run(c.name, func(t *testing.T) {
+			idx := &mockIndexer{ids: ids}
+			au := &Auditor{
+				HTTPClient: http.DefaultClient,
+				UserAgent:  UserAgent{Full: "test-bot-full", Short: "test-bot-short"},
+				Indexer:    idx,
+				MaxBytes:   -1,
+				Delay:      time.Second,
+				Noindex:    c.noindex,
+				Purge:      c.purge,
+			}
+
+			got, err := au.Run()
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if !reflect.DeepEqual(got, c.want) {
+				t.Fatalf("got %+v; want %+v", got, c.want)
+			}
+
+			if !reflect.DeepEqual(idx.deleted, c.deleted) {
+				t.Fatalf("got %+v; want %+v", idx.deleted, c.deleted)
+			}
+		})
+	}
+}
+
+func TestAuditString(t *testing.T) {
+	a := &Audit{
+		Checked:    10,
+		Violations: []Violation{{"a", Disallowed}, {"b", NoIndex}, {"c", Disallowed}},
+		Purged:     3,
+		Errors:     1,
+	}
+
+	want := "checked: 10, disallowed by robots.txt: 2, noindex: 1, purged: 3, errors: 1"
+	if got := a.String(); got != want {
+		t.Fatalf("got %q; want %q", got, want)
+	}
+}
@@ -0,0 +1,64 @@
+// Command audit checks our index against the current robots.txt and noindex rules
+// of each site, optionally purges the documents we may no longer index and reports a summary
+package main
+
+import (
+	"net/http"
+	"os"
+	"strings"
+
+	"github.com/jivesearch/jivesearch/config"
+	"github.com/jivesearch/jivesearch/log"
+	"github.com/jivesearch/jivesearch/search/crawler"
+	"github.com/jivesearch/jivesearch/search/document"
+	"github.com/olivere/elastic"
+	"github.com/spf13/viper"
+)
+
+func setup(v *viper.Viper) {
+	v.SetEnvPrefix("jivesearch")
+	v.AutomaticEnv()
+	v.SetEnvKeyReplacer(strings.NewReplacer(".", "_"))
+	config.SetDefaults(v)
+
+	if v.GetBool("debug") {
+		log.Debug.SetOutput(os.Stdout)
+	}
+}
+
+func main() {
+	v := viper.New()
+	setup(v)
+
+	client, err := elastic.NewClient(elastic.SetURL(v.GetString("elasticsearch.url")), elastic.SetSniff(false))
+	if err != nil {
+		panic(err)
+	}
+
+	au := &crawler.Auditor{
+		HTTPClient: &http.Client{
+			Timeout: v.GetDuration("crawler.timeout"),
+		},
+		UserAgent: crawler.UserAgent{
+			Full:  v.GetString("crawler.useragent.full"),
+			Short: v.GetString("crawler.useragent.short"),
+		},
+		Indexer: &crawler.ElasticSearch{
+			ElasticSearch: &document.ElasticSearch{
+				Client: client,
+				Index:  v.GetString("elasticsearch.search.index"),
+				Type:   v.GetString("elasticsearch.search.type"),
+			},
+		},
+		MaxBytes: int64(v.GetInt("crawler.max.bytes")),
+		Delay:    v.GetDuration("crawler.audit.delay"),
+		Noindex:  v.GetBool("crawler.audit.noindex"),
+		Purge:    v.GetBool("crawler.audit.purge"),
+	}
+
+	audit, err := au.Run()
+	log.Info.Println(audit)
+	if err != nil {
+		log.Info.Fatalf("%+v", err)
+	}
+}
@@ -0,0 +1,16 @@
+package main
+
+import (
+	"testing"
+
+	"github.com/spf13/viper"
+)
+
+func TestSetup(t *testing.T) {
+	v := viper.New()
+	setup(v)
+
+	if d := v.GetDuration("crawler.audit.delay"); d == 0 {
+		t.Fatalf("expected non zero delay. got %v", d)
+	}
+}
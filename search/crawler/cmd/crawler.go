@@ -5,8 +5,10 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"io"
 	"net/http"
 	"os"
+	"path"
 	"strings"
 	"time"
 
@@ -14,11 +16,14 @@ import (
 	"github.com/garyburd/redigo/redis"
 	"github.com/jivesearch/jivesearch/config"
 	"github.com/jivesearch/jivesearch/log"
+	"github.com/jivesearch/jivesearch/search"
+	"github.com/jivesearch/jivesearch/search/adult"
 	"github.com/jivesearch/jivesearch/search/crawler"
 	"github.com/jivesearch/jivesearch/search/crawler/queue"
 	"github.com/jivesearch/jivesearch/search/crawler/robots"
 	"github.com/jivesearch/jivesearch/search/document"
 	img "github.com/jivesearch/jivesearch/search/image"
+	"github.com/jivesearch/jivesearch/search/langid"
 	"github.com/olivere/elastic"
 	"github.com/spf13/viper"
 )
@@ -38,6 +43,17 @@ func afterFn(executionID int64, requests []elastic.BulkableRequest, resp *elasti
 	}
 }
 
+// addList reads a list of adult domains or words into our classifier
+func addList(f string, add func(r io.Reader) error) error {
+	file, err := os.Open(f)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	return add(file)
+}
+
 var (
 	c        *crawler.Crawler
 	duration time.Duration
@@ -104,12 +120,26 @@ func main() {
 
 	defer bulk.Close()
 
+	// new indices use the BM25 parameters from the ranking config
+	vr := viper.New()
+	vr.SetConfigType("toml")
+	vr.AddConfigPath("../..")
+	vr.SetConfigName("ranking")
+
+	ranking, err := search.ReadRanking(vr)
+	if err != nil {
+		panic(err)
+	}
+
 	// setup our search index
 	c.Backend = &crawler.ElasticSearch{
 		ElasticSearch: &document.ElasticSearch{
-			Client: client,
-			Index:  v.GetString("elasticsearch.search.index"),
-			Type:   v.GetString("elasticsearch.search.type"),
+			Client:    client,
+			Index:     v.GetString("elasticsearch.search.index"),
+			Type:      v.GetString("elasticsearch.search.type"),
+			BM25:      ranking.BM25,
+			Plugins:   v.GetStringSlice("elasticsearch.search.plugins"),
+			Compounds: v.GetString("elasticsearch.search.compounds"),
 		},
 		Bulk: bulk,
 	}
@@ -149,6 +179,29 @@ func main() {
 		}
 	}
 
+	// score pages for SafeSearch with our bundled lists and any extra blocklists
+	cwd, err := os.Getwd()
+	if err != nil {
+		panic(err)
+	}
+
+	c.Adult = adult.New()
+	for _, f := range append([]string{path.Join(cwd, "../../adult/domains.txt")}, v.GetStringSlice("crawler.adult.domains")...) {
+		if err := addList(f, c.Adult.AddDomains); err != nil {
+			panic(err)
+		}
+	}
+
+	if err := addList(path.Join(cwd, "../../adult/words.txt"), c.Adult.AddWords); err != nil {
+		panic(err)
+	}
+
+	// pages are indexed in the language of their text
+	c.Detector = langid.New()
+	if err := c.Detector.Load(path.Join(cwd, "../../langid/corpus")); err != nil {
+		panic(err)
+	}
+
 	// Setup our queue
 	rds := &queue.Redis{
 		RedisPool: &redis.Pool{
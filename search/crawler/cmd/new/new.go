@@ -15,6 +15,7 @@ import (
 	"github.com/gocolly/redisstorage"
 	"github.com/jivesearch/jivesearch/config"
 	"github.com/jivesearch/jivesearch/log"
+	"github.com/jivesearch/jivesearch/search"
 	"github.com/jivesearch/jivesearch/search/crawler"
 	"github.com/jivesearch/jivesearch/search/document"
 	img "github.com/jivesearch/jivesearch/search/image"
@@ -71,12 +72,26 @@ func main() {
 
 	defer bulk.Close()
 
+	// new indices use the BM25 parameters from the ranking config
+	vr := viper.New()
+	vr.SetConfigType("toml")
+	vr.AddConfigPath("../../..")
+	vr.SetConfigName("ranking")
+
+	ranking, err := search.ReadRanking(vr)
+	if err != nil {
+		panic(err)
+	}
+
 	// setup our search index
 	backend := &crawler.ElasticSearch{
 		ElasticSearch: &document.ElasticSearch{
-			Client: client,
-			Index:  v.GetString("elasticsearch.search.index"),
-			Type:   v.GetString("elasticsearch.search.type"),
+			Client:    client,
+			Index:     v.GetString("elasticsearch.search.index"),
+			Type:      v.GetString("elasticsearch.search.type"),
+			BM25:      ranking.BM25,
+			Plugins:   v.GetStringSlice("elasticsearch.search.plugins"),
+			Compounds: v.GetString("elasticsearch.search.compounds"),
 		},
 		Bulk: bulk,
 	}
@@ -8,8 +8,10 @@ import (
 	"net/url"
 	"strconv"
 
+	"github.com/jivesearch/jivesearch/search/adult"
 	"github.com/jivesearch/jivesearch/search/document"
 	img "github.com/jivesearch/jivesearch/search/image"
+	"github.com/jivesearch/jivesearch/search/langid"
 
 	"github.com/jivesearch/jivesearch/config"
 	"github.com/jivesearch/jivesearch/log"
@@ -41,6 +43,8 @@ type Crawler struct {
 	stats *Stats
 	Backend
 	ImageBackend
+	Adult    *adult.Classifier // optional
+	Detector *langid.Detector  // optional. Otherwise pages are indexed in the language they declare.
 }
 
 type channels struct {
@@ -286,27 +290,38 @@ func (c *Crawler) work(lnk string) {
 			return
 		}
 
-		// TODO: image (& video?) search and extract some of the text of pdf files.
-		// Note: some html is mismarked as text/xml
-		if doc.MIME != "text/plain" && doc.MIME != "text/html" && doc.MIME != "text/xml" {
-			return
-		}
+		// TODO: image (& video?) search.
+		switch {
+		case document.FileTypes[doc.MIME] != "": // files have no links to follow
+			if err := doc.SetFile(c.truncate.title, c.truncate.description); err != nil {
+				log.Debug.Printf("file extraction error: %v\n%v", doc.ID, err)
+				return
+			}
+		case doc.MIME == "text/plain" || doc.MIME == "text/html" || doc.MIME == "text/xml": // some html is mismarked as text/xml
+			queueCnt, err := c.Queue.CountLinks()
+			if err != nil {
+				log.Debug.Printf("unable to count links in queue: %v\n%v", doc.ID, err)
+				return
+			}
 
-		queueCnt, err := c.Queue.CountLinks()
-		if err != nil {
-			log.Debug.Printf("unable to count links in queue: %v\n%v", doc.ID, err)
-			return
-		}
+			maxLinks := c.maxLinks
+			if queueCnt > c.maxQueueLinks {
+				maxLinks = 0
+			}
 
-		maxLinks := c.maxLinks
-		if queueCnt > c.maxQueueLinks {
-			maxLinks = 0
+			if err := doc.SetContent(c.UserAgent.Short, maxLinks, c.links, c.images,
+				c.truncate.title, c.truncate.keywords, c.truncate.description); err != nil {
+				log.Debug.Printf("document parsing error: %v\n%v", doc.ID, err)
+			}
+		default:
+			return
 		}
 
-		if err := doc.SetContent(c.UserAgent.Short, maxLinks, c.links, c.images,
-			c.truncate.title, c.truncate.keywords, c.truncate.description); err != nil {
-			log.Debug.Printf("document parsing error: %v\n%v", doc.ID, err)
+		// before the content is dropped below so the page stays in the same language index
+		if c.Detector != nil {
+			doc.Language = c.Detector.Language(doc)
 		}
+		doc.SetLang()
 
 		// don't index content if not wanted or if not canonical
 		if doc.SetCanonical(c.links); !doc.Canonical || !doc.Index {
@@ -316,9 +331,14 @@ func (c *Crawler) work(lnk string) {
 				Content: document.Content{
 					StatusCode: doc.StatusCode,
 					Language:   doc.Language,
+					Lang:       doc.Lang,
 				},
 			}
 		}
+
+		if doc.Index && c.Adult != nil {
+			doc.Adult = c.Adult.Score(doc)
+		}
 	}
 
 	if err := c.Backend.Upsert(doc); err != nil {
@@ -4,6 +4,7 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"io"
 	"sync"
 	"time"
 
@@ -111,3 +112,40 @@ func (e *ElasticSearch) CrawledAndCount(u, domain string) (time.Time, int, error
 
 	return crawled, cnt, err
 }
+
+// Indexed calls fn with the ID of each document we index
+func (e *ElasticSearch) Indexed(fn func(id string) error) error {
+	svc := e.Client.Scroll(e.Index + "-*").
+		Type(e.Type).
+		Query(elastic.NewTermQuery("index", true)).
+		FetchSource(false).
+		Size(500)
+
+	defer svc.Clear(context.TODO())
+
+	for {
+		res, err := svc.Do(context.TODO())
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+
+		for _, h := range res.Hits.Hits {
+			if err := fn(h.Id); err != nil {
+				return err
+			}
+		}
+	}
+}
+
+// Delete removes a document from whichever language index it is in
+func (e *ElasticSearch) Delete(id string) error {
+	_, err := e.Client.DeleteByQuery(e.Index + "-*").
+		Type(e.Type).
+		Query(elastic.NewIdsQuery(e.Type).Ids(id)).
+		Do(context.TODO())
+
+	return err
+}
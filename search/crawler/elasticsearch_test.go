@@ -4,6 +4,7 @@ import (
 	"context"
 	"net/http"
 	"net/http/httptest"
+	"reflect"
 	"testing"
 	"time"
 
@@ -320,3 +321,65 @@ func MockService(url string) (*ElasticSearch, error) {
 		Bulk: bulk,
 	}, nil
 }
+
+func TestIndexed(t *testing.T) {
+	var scrolled bool
+
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		resp := `{"_scroll_id": "abc", "hits": {"total": 2, "hits": []}}`
+		if r.Method == "POST" && !scrolled {
+			scrolled = true
+			resp = `{"_scroll_id": "abc", "hits": {"total": 2, "hits": [
+				{"_index": "search-english", "_type": "document", "_id": "https://www.example.com/"},
+				{"_index": "search-french", "_type": "document", "_id": "https://www.example.fr/"}
+			]}}`
+		}
+		if _, err := w.Write([]byte(resp)); err != nil {
+			t.Fatal(err)
+		}
+	}))
+	defer ts.Close()
+
+	e, err := MockService(ts.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	got := []string{}
+	if err := e.Indexed(func(id string) error {
+		got = append(got, id)
+		return nil
+	}); err != nil {
+		t.Fatal(err)
+	}
+
+	want := []string{"https://www.example.com/", "https://www.example.fr/"}
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("got %+v; want %+v", got, want)
+	}
+}
+
+func TestDelete(t *testing.T) {
+	var path string
+
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		path = r.URL.Path
+		if _, err := w.Write([]byte(`{"took": 1, "deleted": 1, "total": 1}`)); err != nil {
+			t.Fatal(err)
+		}
+	}))
+	defer ts.Close()
+
+	e, err := MockService(ts.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err := e.Delete("https://www.example.com/"); err != nil {
+		t.Fatal(err)
+	}
+
+	if want := "/search-*/document/_delete_by_query"; path != want {
+		t.Fatalf("got %q; want %q", path, want)
+	}
+}
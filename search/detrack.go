@@ -0,0 +1,160 @@
+package search
+
+import (
+	"net/url"
+	"strings"
+
+	"github.com/jivesearch/jivesearch/search/document"
+)
+
+// trackingParams are removed from result urls. Anything starting with "utm_" is also removed.
+var trackingParams = map[string]bool{
+	"dclid":   true,
+	"fbclid":  true,
+	"gclid":   true,
+	"igshid":  true,
+	"mc_cid":  true,
+	"mc_eid":  true,
+	"msclkid": true,
+	"yclid":   true,
+	"_hsenc":  true,
+	"_hsmi":   true,
+}
+
+// redirectors wrap the real url in a query param.
+// Keys are either host+path or just the host if any path will do.
+var redirectors = map[string]string{
+	"l.facebook.com/l.php":          "u",
+	"lm.facebook.com/l.php":         "u",
+	"l.instagram.com":               "u",
+	"out.reddit.com":                "url",
+	"www.google.com/url":            "q",
+	"www.youtube.com/redirect":      "q",
+	"t.umblr.com/redirect":          "z",
+	"away.vk.com/away.php":          "to",
+	"slack-redir.net/link":          "url",
+	"steamcommunity.com/linkfilter": "url",
+}
+
+// hstsPreload is a subset of the HSTS preload list (https://hstspreload.org).
+// The list requires includeSubDomains so subdomains of these are safe to upgrade too.
+var hstsPreload = map[string]bool{
+	// entire TLDs
+	"app":       true,
+	"bank":      true,
+	"dev":       true,
+	"foo":       true,
+	"insurance": true,
+	"page":      true,
+	// domains
+	"dropbox.com":    true,
+	"github.com":     true,
+	"paypal.com":     true,
+	"stripe.com":     true,
+	"torproject.org": true,
+	"twitter.com":    true,
+}
+
+// maxUnwrap guards against redirectors that point to each other
+const maxUnwrap = 3
+
+// Detrack cleans the url of each result. Tracking params are removed,
+// known redirectors are unwrapped and http is upgraded to https where it's safe.
+func (r *Results) Detrack() *Results {
+	for _, doc := range r.Documents {
+		u, err := url.Parse(doc.ID)
+		if err != nil {
+			continue
+		}
+
+		cleaned := Detrack(u).String()
+		if cleaned == doc.ID {
+			continue
+		}
+
+		d, err := document.New(cleaned)
+		if err != nil {
+			continue
+		}
+
+		doc.ID, doc.URL = d.ID, d.URL
+		doc.Scheme, doc.Host = d.Scheme, d.Host
+		doc.Domain, doc.TLD, doc.PathParts = d.Domain, d.TLD, d.PathParts
+	}
+
+	return r
+}
+
+// Detrack returns a cleaned copy of a url
+func Detrack(u *url.URL) *url.URL {
+	u = unwrap(u)
+	u = stripTracking(u)
+	return upgrade(u)
+}
+
+func unwrap(u *url.URL) *url.URL {
+	for i := 0; i < maxUnwrap; i++ {
+		h := strings.ToLower(u.Hostname())
+
+		param, ok := redirectors[h+strings.TrimSuffix(u.Path, "/")]
+		if !ok {
+			if param, ok = redirectors[h]; !ok {
+				return u
+			}
+		}
+
+		target, err := url.Parse(u.Query().Get(param))
+		if err != nil || target.Host == "" || (target.Scheme != "http" && target.Scheme != "https") {
+			return u
+		}
+
+		u = target
+	}
+
+	return u
+}
+
+func stripTracking(u *url.URL) *url.URL {
+	if u.RawQuery == "" {
+		return u
+	}
+
+	q := u.Query()
+	removed := false
+	for k := range q {
+		if trackingParams[strings.ToLower(k)] || strings.HasPrefix(strings.ToLower(k), "utm_") {
+			q.Del(k)
+			removed = true
+		}
+	}
+
+	if !removed { // don't reorder params if we didn't have to
+		return u
+	}
+
+	c := *u
+	c.RawQuery = q.Encode()
+	return &c
+}
+
+func upgrade(u *url.URL) *url.URL {
+	if u.Scheme != "http" || (u.Port() != "" && u.Port() != "80") {
+		return u
+	}
+
+	h := strings.TrimSuffix(strings.ToLower(u.Hostname()), ".")
+	for {
+		if hstsPreload[h] {
+			c := *u
+			c.Scheme = "https"
+			c.Host = u.Hostname()
+			return &c
+		}
+
+		i := strings.Index(h, ".")
+		if i == -1 {
+			return u
+		}
+		h = h[i+1:]
+	}
+}
@@ -0,0 +1,81 @@
+package search
+
+import (
+	"net/url"
+	"reflect"
+	"testing"
+
+	"github.com/jivesearch/jivesearch/search/document"
+)
+
+func TestDetrack(t *testing.T) {
+	for _, c := range []struct {
+		raw  string
+		want string
+	}{
+		{"https://www.example.com/path?id=1", "https://www.example.com/path?id=1"},
+		{"https://www.example.com/path?b=2&a=1", "https://www.example.com/path?b=2&a=1"},
+		{"https://www.example.com/?utm_source=news&utm_Medium=email&id=1", "https://www.example.com/?id=1"},
+		{"https://www.example.com/?fbclid=abc&gclid=def", "https://www.example.com/"},
+		{"https://l.facebook.com/l.php?u=https%3A%2F%2Fwww.example.com%2F%3Ffbclid%3Dabc&h=xyz", "https://www.example.com/"},
+		{"https://out.reddit.com/t3_abc?url=https%3A%2F%2Fwww.example.com%2Fpage&token=123", "https://www.example.com/page"},
+		{"https://www.google.com/url?q=https%3A%2F%2Fl.facebook.com%2Fl.php%3Fu%3Dhttps%253A%252F%252Fwww.example.com%252F", "https://www.example.com/"},
+		{"https://www.google.com/search?q=https%3A%2F%2Fwww.example.com%2F", "https://www.google.com/search?q=https%3A%2F%2Fwww.example.com%2F"},
+		{"https://out.reddit.com/t3_abc?url=javascript%3Aalert(1)", "https://out.reddit.com/t3_abc?url=javascript%3Aalert(1)"},
+		{"http://github.com/jivesearch/jivesearch", "https://github.com/jivesearch/jivesearch"},
+		{"http://gist.github.com:80/", "https://gist.github.com/"},
+		{"http://github.com:8080/", "http://github.com:8080/"},
+		{"http://get.dev/", "https://get.dev/"},
+		{"http://www.example.com/", "http://www.example.com/"},
+		{"http://notgithub.com/", "http://notgithub.com/"},
+	} {
+		t.Run(c.raw, func(t *testing.T) {
+			u, err := url.Parse(c.raw)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			got := Detrack(u).String()
+			if got != c.want {
+				t.Fatalf("got %q; want %q", got, c.want)
+			}
+		})
+	}
+}
+
+func TestResultsDetrack(t *testing.T) {
+	clean := &document.Document{ID: "https://www.example.com/", Domain: "example.com"}
+	tracked := &document.Document{
+		ID:      "https://l.facebook.com/l.php?u=http%3A%2F%2Fwww.github.com%2Fjivesearch%3Futm_source%3Dfb",
+		Scheme:  "https",
+		Host:    "l.facebook.com",
+		Domain:  "facebook.com",
+		TLD:     "com",
+		Content: document.Content{Title: "Jive Search"},
+	}
+
+	res := &Results{Documents: []*document.Document{clean, tracked}}
+	res = res.Detrack()
+
+	u, _ := url.Parse("https://www.github.com/jivesearch")
+	want := []*document.Document{
+		{ID: "https://www.example.com/", Domain: "example.com"},
+		{
+			ID:        "https://www.github.com/jivesearch",
+			URL:       u,
+			Scheme:    "https",
+			Host:      "www.github.com",
+			Domain:    "github.com",
+			TLD:       "com",
+			PathParts: "jivesearch",
+			Content:   document.Content{Title: "Jive Search"},
+		},
+	}
+
+	if !reflect.DeepEqual(res.Documents, want) {
+		for i := range want {
+			t.Logf("got %+v; want %+v", res.Documents[i], want[i])
+		}
+		t.Fatal("documents not detracked")
+	}
+}
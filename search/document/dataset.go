@@ -0,0 +1,41 @@
+package document
+
+import (
+	"bytes"
+	"encoding/csv"
+	"io"
+	"strings"
+)
+
+// extractCSV makes a dataset searchable by its header and first rows. Datasets
+// rarely have a title of their own so SetFile falls back to the file name.
+func extractCSV(b []byte, comma rune) (*file, error) {
+	r := csv.NewReader(bytes.NewReader(bytes.TrimPrefix(b, []byte("\xef\xbb\xbf"))))
+	r.Comma = comma
+	r.FieldsPerRecord = -1 // ragged rows are common
+	r.LazyQuotes = true
+
+	var sb strings.Builder
+	for rows := 0; sb.Len() < maxFileText; rows++ {
+		rec, err := r.Read()
+		if err == io.EOF {
+			break
+		}
+
+		if err != nil {
+			if rows == 0 {
+				return nil, err
+			}
+			break // keep the rows before a malformed one
+		}
+
+		sb.WriteString(strings.Join(rec, " "))
+		sb.WriteString(" ")
+	}
+
+	if sb.Len() == 0 {
+		return nil, errFileType
+	}
+
+	return &file{text: sb.String()}, nil
+}
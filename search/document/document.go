@@ -8,6 +8,7 @@ import (
 	"net/http"
 	"net/url"
 	"regexp"
+	"strconv"
 	"strings"
 	"time"
 
@@ -38,7 +39,9 @@ type Document struct {
 	Crawled   string   `json:"crawled,omitempty"`
 	header    http.Header
 	MIME      string `json:"mime,omitempty"`
+	Threat    string `json:"threat,omitempty"` // malware or phishing, flagged as results are served rather than indexed
 	tokenizer *html.Tokenizer
+	file      io.Reader // the body of a file in FileTypes
 	Content
 }
 
@@ -48,10 +51,16 @@ type Content struct {
 	canonical   string
 	Canonical   bool         `json:"canonical,omitempty"`
 	Language    language.Tag `json:"-"`
+	Lang        string       `json:"lang,omitempty"` // the base of Language, e.g. "pt" for pt-BR, so results can be filtered by it
 	Date        string       `json:"date,omitempty"`
 	Title       string       `json:"title,omitempty"`
 	Keywords    string       `json:"keywords,omitempty"`
 	Description string       `json:"description,omitempty"`
+	Adult       float64      `json:"adult,omitempty"`    // how likely the page is adult content, from 0 to 1
+	FileType    string       `json:"filetype,omitempty"` // pdf, docx, pptx, epub, csv or tsv. Empty for webpages.
+	Size        int64        `json:"size,omitempty"`     // bytes, of files only
+	Author      string       `json:"author,omitempty"`
+	Pages       int          `json:"pages,omitempty"` // or slides
 	Policy
 }
 
@@ -142,6 +151,15 @@ func (d *Document) SetCrawled(t time.Time) *Document {
 	return d
 }
 
+// SetLang sets Lang from the Document's Language
+func (d *Document) SetLang() *Document {
+	if d.Language != language.Und {
+		b, _ := d.Language.Base()
+		d.Lang = b.String()
+	}
+	return d
+}
+
 // SetHeader sets the Document's header to the response header.
 func (d *Document) SetHeader(h http.Header) *Document {
 	d.header = h
@@ -194,6 +212,7 @@ func (d *Document) setPolicy(bot, pol string) {
 }
 
 // SetTokenizer sets the html tokenizer and MIME Type from the response's body (utf-8 encoded).
+// The body of a file in FileTypes is instead held for SetFile.
 // It is the caller's responsibility to close the response body.
 func (d *Document) SetTokenizer(b io.Reader) error {
 	bdy := bufio.NewReader(b)
@@ -203,6 +222,10 @@ func (d *Document) SetTokenizer(b io.Reader) error {
 	}
 
 	d.MIME = strings.Split(http.DetectContentType(peek), ";")[0]
+	if d.MIME = fileMIME(d.MIME, d.header.Get("Content-Type"), d.URL); FileTypes[d.MIME] != "" {
+		d.file = bdy
+		return nil
+	}
 
 	// html tokenizer requires utf-8
 	utf, err := charset.NewReader(bdy, d.MIME)
@@ -223,16 +246,28 @@ func (d *Document) SetContent(bot string, maxLinks int, links chan string, image
 	var tt html.TokenType
 	var title bool
 
+	// the images of a <figure> wait for its <figcaption>, which may come after them
+	var figure []*img.Image
+	var inFigure, inCaption bool
+	var caption strings.Builder
+
 	for {
 		tt = d.tokenizer.Next()
 
 		switch tt {
 		case html.ErrorToken:
+			for _, im := range figure {
+				images <- im
+			}
 			return nil
 		case html.TextToken:
 			if title {
 				d.Title = d.extractText(string(d.tokenizer.Text()), truncateTitle)
 			}
+			if inCaption {
+				caption.Write(d.tokenizer.Text())
+				caption.WriteString(" ")
+			}
 		case html.StartTagToken, html.SelfClosingTagToken:
 			t := d.tokenizer.Token()
 
@@ -299,19 +334,22 @@ func (d *Document) SetContent(bot string, maxLinks int, links chan string, image
 					}
 				}
 			case atom.Img:
-				src, _ := getAttribute(t, "src")
-				u, err := d.handleLink(src)
+				im, err := d.image(t, truncateDescription)
 				if err != nil {
 					continue
 				}
 
-				img, err := img.New(u)
-				if err != nil {
+				if inFigure {
+					figure = append(figure, im)
 					continue
 				}
 
-				img.Alt, _ = getAttribute(t, "alt")
-				images <- img
+				images <- im
+			case atom.Figure:
+				inFigure = true
+			case atom.Figcaption:
+				inCaption = true
+				caption.Reset()
 			case atom.Time:
 				// There are a few ways to get the creation date (or modified) date of the document:
 
@@ -339,6 +377,19 @@ func (d *Document) SetContent(bot string, maxLinks int, links chan string, image
 			switch t.DataAtom {
 			case atom.Title:
 				title = false
+			case atom.Figcaption:
+				inCaption = false
+			case atom.Figure:
+				c := d.extractText(caption.String(), truncateDescription)
+				for _, im := range figure {
+					if c != "" {
+						im.Caption = c
+					}
+					images <- im
+				}
+
+				figure, inFigure = nil, false
+				caption.Reset()
 			}
 		}
 	}
@@ -418,6 +469,38 @@ func (d *Document) handleLink(href string) (string, error) {
 	return "", err
 }
 
+// image is the image of an <img> tag with what the page tells us about it.
+// Lazy loaded images often have a placeholder src and the real one in data-src.
+// The width & height are what it is displayed at until we crawl the image itself.
+func (d *Document) image(t html.Token, truncateCaption int) (*img.Image, error) {
+	var u string
+	for _, attr := range []string{"src", "data-src"} {
+		src, _ := getAttribute(t, attr)
+		if u, _ = d.handleLink(src); u != "" {
+			break
+		}
+	}
+
+	im, err := img.New(u)
+	if err != nil {
+		return nil, err
+	}
+
+	alt, _ := getAttribute(t, "alt")
+	im.Alt = d.extractText(alt, truncateCaption)
+
+	title, _ := getAttribute(t, "title")
+	im.Caption = d.extractText(title, truncateCaption)
+	im.Page = d.ID
+
+	w, _ := getAttribute(t, "width")
+	h, _ := getAttribute(t, "height")
+	im.Width, _ = strconv.Atoi(strings.TrimSuffix(w, "px"))
+	im.Height, _ = strconv.Atoi(strings.TrimSuffix(h, "px"))
+
+	return im, nil
+}
+
 func getAttribute(t html.Token, key string) (string, bool) {
 	for _, a := range t.Attr {
 		if a.Key == key {
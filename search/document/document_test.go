@@ -161,6 +161,25 @@ func TestSetCrawled(t *testing.T) {
 	}
 }
 
+func TestSetLang(t *testing.T) {
+	for _, c := range []struct {
+		lang language.Tag
+		want string
+	}{
+		{language.BrazilianPortuguese, "pt"},
+		{language.German, "de"},
+		{language.Und, ""},
+	} {
+		t.Run(c.lang.String(), func(t *testing.T) {
+			d := &Document{}
+			d.Language = c.lang
+			if d.SetLang(); d.Lang != c.want {
+				t.Fatalf("got %q; want %q", d.Lang, c.want)
+			}
+		})
+	}
+}
+
 func TestSetHeader(t *testing.T) {
 	for _, c := range []struct {
 		name string
@@ -461,6 +480,68 @@ func TestSetContent(t *testing.T) {
 	}
 }
 
+func TestSetContentImages(t *testing.T) {
+	body := `<html>
+		<body>
+			<img src="/logo.png" alt="Example logo" width="120" height="40px">
+			<figure>
+				<img src="data:image/gif;base64,R0lGODlhAQABAAAAACw=" data-src="https://cdn.example.com/lake.jpg" alt="A lake" title="Tooltip">
+				<figcaption>Lake Tahoe <em>at dawn</em></figcaption>
+			</figure>
+			<img src="https://cdn.example.com/hills.jpg" title="Rolling   hills" width="100%">
+			<img alt="no source">
+			<figure><img src="/unclosed.gif">
+		</body>
+	</html>`
+
+	want := []*img.Image{
+		{ID: "https://www.example.com/logo.png", Domain: "example.com", Alt: "Example logo", Page: "https://www.example.com/gallery", Width: 120, Height: 40},
+		{ID: "https://cdn.example.com/lake.jpg", Domain: "example.com", Alt: "A lake", Caption: "Lake Tahoe at dawn", Page: "https://www.example.com/gallery"},
+		{ID: "https://cdn.example.com/hills.jpg", Domain: "example.com", Caption: "Rolling hills", Page: "https://www.example.com/gallery"},
+		{ID: "https://www.example.com/unclosed.gif", Domain: "example.com", Page: "https://www.example.com/gallery"},
+	}
+
+	d, err := New("https://www.example.com/gallery")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err := d.SetTokenizer(strings.NewReader(body)); err != nil {
+		t.Fatal(err)
+	}
+
+	links := make(chan string)
+	images := make(chan *img.Image)
+	collected := make(chan []*img.Image)
+
+	go func() {
+		for range links {
+		}
+	}()
+
+	go func() {
+		got := []*img.Image{}
+		for im := range images {
+			got = append(got, im)
+		}
+		collected <- got
+	}()
+
+	if err := d.SetContent("", 10, links, images, 100, 5, 100); err != nil {
+		t.Fatal(err)
+	}
+
+	close(links)
+	close(images)
+
+	if got := <-collected; !reflect.DeepEqual(got, want) {
+		for _, im := range got {
+			t.Logf("%+v", im)
+		}
+		t.Fatalf("got %d images; want %+v", len(got), want)
+	}
+}
+
 func TestLanguages(t *testing.T) {
 	for _, c := range []struct {
 		name string
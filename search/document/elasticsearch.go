@@ -2,6 +2,7 @@ package document
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 
 	"github.com/jivesearch/jivesearch/log"
@@ -14,8 +15,26 @@ type ElasticSearch struct {
 	Client *elastic.Client
 	Index  string
 	Type   string
+	BM25   BM25 // similarity of new indices. Zero values are Elasticsearch's defaults.
+	// Plugins are the analysis plugins installed on our nodes, e.g. "analysis-kuromoji".
+	// Japanese, Korean and Chinese text is split into bigrams without them.
+	Plugins []string
+	// Compounds is a word list on our nodes, relative to their config directory, that splits
+	// German compounds into the words they're made of, e.g. "analysis/de_compounds.txt". Optional.
+	Compounds string
 }
 
+// BM25 are the parameters of the BM25 similarity used to score text fields.
+// K1 controls term frequency saturation and B how much field length normalizes scores.
+// https://www.elastic.co/guide/en/elasticsearch/reference/current/index-modules-similarity.html
+type BM25 struct {
+	K1 float64 `mapstructure:"k1"`
+	B  float64 `mapstructure:"b"`
+}
+
+// DefaultBM25 are Elasticsearch's own BM25 parameters
+var DefaultBM25 = BM25{K1: 1.2, B: 0.75}
+
 var langAnalyzer = make(map[language.Tag]string)
 
 // IndexName returns the language-specific index
@@ -40,17 +59,69 @@ func (e *ElasticSearch) Analyzer(lang language.Tag) (string, error) {
 	return "", fmt.Errorf("analyzer should not be blank. Lang: %s", lang)
 }
 
+// BulkUpsert indexes the documents in one bulk request, replacing any with the same url.
+// errs[i] is why docs[i] wasn't indexed. err is why none were, e.g. ctx is done.
+func (e *ElasticSearch) BulkUpsert(ctx context.Context, docs []*Document) (errs []error, err error) {
+	errs = make([]error, len(docs))
+	bulk := e.Client.Bulk()
+	sent := []int{} // the docs in our request, which Elasticsearch answers in order
+
+	for i, doc := range docs {
+		a, err := e.Analyzer(doc.Language)
+		if err != nil {
+			errs[i] = err
+			continue
+		}
+
+		bulk.Add(elastic.NewBulkUpdateRequest().
+			Index(e.IndexName(a)).
+			Type(e.Type).
+			Id(doc.ID).
+			DocAsUpsert(true).
+			Doc(doc))
+		sent = append(sent, i)
+	}
+
+	if len(sent) == 0 {
+		return errs, nil
+	}
+
+	resp, err := bulk.Do(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(resp.Items) != len(sent) {
+		return nil, fmt.Errorf("sent %d documents and got %d results", len(sent), len(resp.Items))
+	}
+
+	for j, item := range resp.Items {
+		for _, res := range item {
+			if res.Status >= 200 && res.Status <= 299 {
+				continue
+			}
+
+			errs[sent[j]] = fmt.Errorf("status %d", res.Status)
+			if res.Error != nil {
+				errs[sent[j]] = fmt.Errorf("%v: %v", res.Error.Type, res.Error.Reason)
+			}
+		}
+	}
+
+	return errs, nil
+}
+
 // Setup will create our main search index
 // and language-specific indices for the content
 func (e *ElasticSearch) Setup() error {
 	// We create one index per analyzer: search-english, search-spanish, etc...
 	// This is a list of all elasticsearch analyzers
 	analyzers := []string{"arabic", "armenian", "basque", "brazilian",
-		"bulgarian", "catalan", "cjk", "czech", "danish", "dutch",
+		"bulgarian", "catalan", "chinese", "cjk", "czech", "danish", "dutch",
 		"english", "finnish", "french", "galician", "german", "greek",
-		"hindi", "hungarian", "indonesian", "irish", "italian", "latvian",
-		"lithuanian", "norwegian", "persian", "portuguese", "romanian",
-		"russian", "sorani", "spanish", "swedish", "turkish", "thai",
+		"hindi", "hungarian", "indonesian", "irish", "italian", "japanese",
+		"korean", "latvian", "lithuanian", "norwegian", "persian", "portuguese",
+		"romanian", "russian", "sorani", "spanish", "swedish", "turkish", "thai",
 	}
 
 	for _, a := range analyzers {
@@ -71,14 +142,112 @@ func (e *ElasticSearch) Setup() error {
 	return nil
 }
 
+// hasPlugin is true if the analysis plugin is installed on our nodes
+func (e *ElasticSearch) hasPlugin(plugin string) bool {
+	for _, p := range e.Plugins {
+		if p == plugin {
+			return true
+		}
+	}
+	return false
+}
+
+// textAnalysis is the analyzer of the "lang" fields of an index along with the custom analyzers and
+// token filters it needs. Most languages use Elasticsearch's built-in analyzer of the same name.
+// Indices keep the analysis they were created with, so an index has to be rebuilt to pick up a change.
+// https://www.elastic.co/guide/en/elasticsearch/plugins/current/analysis.html
+func (e *ElasticSearch) textAnalysis(a string) (string, map[string]interface{}, map[string]interface{}) {
+	type custom struct {
+		Type      string   `json:"type"`
+		Tokenizer string   `json:"tokenizer"`
+		Filter    []string `json:"filter"`
+	}
+
+	name := a + "_text"
+	analyzers := map[string]interface{}{}
+	filters := map[string]interface{}{}
+
+	// without a plugin we index overlapping pairs of characters like the cjk analyzer
+	bigrams := custom{"custom", "standard", []string{"cjk_width", "lowercase", "cjk_bigram"}}
+
+	switch a {
+	case "japanese":
+		analyzers[name] = bigrams
+		if e.hasPlugin("analysis-kuromoji") {
+			analyzers[name] = custom{"custom", "kuromoji_tokenizer", []string{
+				"kuromoji_baseform", "kuromoji_part_of_speech", "cjk_width", "ja_stop", "kuromoji_stemmer", "lowercase",
+			}}
+		}
+	case "korean":
+		analyzers[name] = bigrams
+		if e.hasPlugin("analysis-nori") {
+			analyzers[name] = custom{"custom", "nori_tokenizer", []string{"nori_part_of_speech", "nori_readingform", "lowercase"}}
+		}
+	case "chinese":
+		analyzers[name] = bigrams
+		if e.hasPlugin("analysis-smartcn") {
+			analyzers[name] = custom{"custom", "smartcn_tokenizer", []string{"lowercase"}}
+		}
+	case "german":
+		// the built-in german analyzer with compounds split before the stemming
+		f := []string{"lowercase"}
+		if e.Compounds != "" {
+			filters["german_compounds"] = map[string]interface{}{
+				"type":               "dictionary_decompounder",
+				"word_list_path":     e.Compounds,
+				"only_longest_match": true,
+			}
+			f = append(f, "german_compounds")
+		}
+
+		filters["german_stop"] = map[string]interface{}{
+			"type":      "stop",
+			"stopwords": "_german_",
+		}
+
+		analyzers[name] = custom{"custom", "standard", append(f, "german_stop", "german_normalization", "german_light_stem")}
+	default:
+		return a, analyzers, filters
+	}
+
+	return name, analyzers, filters
+}
+
 // mapping is the mapping of our main search Index.
 // https://www.elastic.co/guide/en/elasticsearch/guide/current/one-lang-docs.html
 func (e *ElasticSearch) mapping(a string) string {
+	analyzer, analyzers, filters := e.textAnalysis(a)
+
+	// the custom analyzers and filters are added to those below
+	extra := func(m map[string]interface{}) string {
+		if len(m) == 0 {
+			return ""
+		}
+
+		b, _ := json.Marshal(m) // can't fail for our maps
+		return "," + string(b[1:len(b)-1])
+	}
+
+	bm25 := e.BM25
+	if bm25.K1 == 0 {
+		bm25.K1 = DefaultBM25.K1
+	}
+	if bm25.B == 0 {
+		bm25.B = DefaultBM25.B
+	}
+
 	// Notes: Does the domain_name_analyzer and path_analyzer deal with rtl text (arabic, hebrew, etc)???
 	// Also, needs better analyzer for domain...
 	// e.g. search for "jimi hendrix" should return jimihendrix.com
 	m := fmt.Sprintf(`{
 		"settings": {
+			"similarity": {
+				"default": {
+					"type": "BM25",
+					"k1":   %[2]v,
+					"b":    %[3]v
+				}
+			},
 			"analysis": {
 				"filter": {
 					"my_shingle_filter": {
@@ -86,7 +255,7 @@ func (e *ElasticSearch) mapping(a string) string {
 						"min_shingle_size": 2, 
 						"max_shingle_size": 2, 
 						"output_unigrams":  false   
-					}
+					}%[4]v
 				},
 				"analyzer": {
 					"my_shingle_analyzer": {
@@ -102,7 +271,7 @@ func (e *ElasticSearch) mapping(a string) string {
 					},
 					"path_analyzer": {
 						"tokenizer": "path_tokenizer"
-					}
+					}%[5]v
 				},
 				"tokenizer": {
 					"domain_name_tokenizer": {
@@ -129,7 +298,7 @@ func (e *ElasticSearch) mapping(a string) string {
 						"fields": {
 							"lang": {
 								"type":     "text",
-								"analyzer": "%v" 
+								"analyzer": "%[1]v" 
 							},
 							"shingles": {
 								"type": 	"text",
@@ -142,7 +311,7 @@ func (e *ElasticSearch) mapping(a string) string {
 						"fields": {
 							"lang": {
 								"type":     "text",
-								"analyzer": "%v" 
+								"analyzer": "%[1]v" 
 							},
 							"shingles": {
 								"type": 	"text",
@@ -150,6 +319,21 @@ func (e *ElasticSearch) mapping(a string) string {
 							}
 						}
 					},
+					"anchors": {
+						"type": "text",
+						"fields": {
+							"lang": {
+								"type":     "text",
+								"analyzer": "%[1]v" 
+							}
+						}
+					},
+					"authority": {
+						"type": "float"
+					},
+					"adult": {
+						"type": "float"
+					},
 					"id": {
 						"type": "keyword"
 					},
@@ -193,11 +377,26 @@ func (e *ElasticSearch) mapping(a string) string {
 					},
 					"mime": {
 						"type": "keyword"
+					},
+					"filetype": {
+						"type": "keyword"
+					},
+					"lang": {
+						"type": "keyword"
+					},
+					"author": {
+						"type": "text"
+					},
+					"pages": {
+						"type": "integer"
+					},
+					"size": {
+						"type": "long"
 					}
 				}
 			}
 		}
-	}`, a, a)
+	}`, analyzer, bm25.K1, bm25.B, extra(filters), extra(analyzers))
 
 	return m
 }
@@ -239,13 +438,13 @@ func init() {
 	langAnalyzer[language.Armenian] = "armenian"     //  hy
 	langAnalyzer[language.Indonesian] = "indonesian" //  id
 	//langAnalyzer[language.Icelandic] = ""                   //  is
-	langAnalyzer[language.Italian] = "italian" //  it
-	langAnalyzer[language.Japanese] = "cjk"    //  ja
+	langAnalyzer[language.Italian] = "italian"   //  it
+	langAnalyzer[language.Japanese] = "japanese" //  ja
 	//langAnalyzer[language.Georgian] = ""                    //  ka
 	//langAnalyzer[language.Kazakh] = ""                    //  kk
 	//langAnalyzer[language.Khmer] = ""                    //  km
 	//langAnalyzer[language.Kannada] = ""                      //  kn
-	langAnalyzer[language.Korean] = "cjk" //  ko
+	langAnalyzer[language.Korean] = "korean" //  ko
 	//langAnalyzer[language.Kirghiz] = ""                      //  ky
 	//langAnalyzer[language.Lao] = ""                          //  lo
 	langAnalyzer[language.Lithuanian] = "lithuanian" //  lt
@@ -281,9 +480,9 @@ func init() {
 	//langAnalyzer[language.Ukrainian] = ""                   //  uk
 	//langAnalyzer[language.Urdu] = ""                        //  ur
 	//langAnalyzer[language.Uzbek] = ""                      //  uz
-	langAnalyzer[language.Vietnamese] = "cjk"         //  vi
-	langAnalyzer[language.Chinese] = "cjk"            //  zh
-	langAnalyzer[language.SimplifiedChinese] = "cjk"  //  zh-Hans
-	langAnalyzer[language.TraditionalChinese] = "cjk" //  zh-Hant
+	langAnalyzer[language.Vietnamese] = "cjk"             //  vi
+	langAnalyzer[language.Chinese] = "chinese"            //  zh
+	langAnalyzer[language.SimplifiedChinese] = "chinese"  //  zh-Hans
+	langAnalyzer[language.TraditionalChinese] = "chinese" //  zh-Hant
 	//langAnalyzer[language.Zulu] = ""                       //  zu
 }
@@ -1,8 +1,13 @@
 package document
 
 import (
+	"context"
+	"encoding/json"
+	"io/ioutil"
 	"net/http"
 	"net/http/httptest"
+	"reflect"
+	"strings"
 	"testing"
 
 	"github.com/olivere/elastic"
@@ -33,6 +38,10 @@ func TestAnalyzer(t *testing.T) {
 		{"Portuguese", language.Portuguese, "portuguese"},
 		{"European Portuguese", language.EuropeanPortuguese, "portuguese"},
 		{"Brazilian Portuguese", language.BrazilianPortuguese, "brazilian"},
+		{"Japanese", language.Japanese, "japanese"},
+		{"Korean", language.Korean, "korean"},
+		{"Chinese", language.Chinese, "chinese"},
+		{"Traditional Chinese", language.TraditionalChinese, "chinese"},
 	} {
 		t.Run(c.name, func(t *testing.T) {
 			handler := http.NotFound
@@ -98,6 +107,70 @@ func TestSetup(t *testing.T) {
 	}
 }
 
+func TestBulkUpsert(t *testing.T) {
+	var body string
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		b, err := ioutil.ReadAll(r.Body)
+		if err != nil {
+			t.Fatal(err)
+		}
+		body = string(b)
+
+		w.Header().Set("Content-Type", "application/json")
+		if _, err := w.Write([]byte(`{"took":3,"errors":true,"items":[
+			{"update":{"_index":"search-english","_type":"document","_id":"https://example.com/","status":201}},
+			{"update":{"_index":"search-german","_type":"document","_id":"https://example.de/","status":400,
+				"error":{"type":"mapper_parsing_exception","reason":"failed to parse"}}}
+		]}`)); err != nil {
+			t.Fatal(err)
+		}
+	}))
+	defer ts.Close()
+
+	e, err := MockService(ts.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	docs := []*Document{}
+	for _, d := range []struct {
+		u    string
+		lang language.Tag
+	}{
+		{"https://example.com/", language.English},
+		{"https://example.de/", language.German},
+	} {
+		doc, err := New(d.u)
+		if err != nil {
+			t.Fatal(err)
+		}
+		doc.Language = d.lang
+		docs = append(docs, doc)
+	}
+
+	errs, err := e.BulkUpsert(context.Background(), docs)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if errs[0] != nil || errs[1] == nil || errs[1].Error() != "mapper_parsing_exception: failed to parse" {
+		t.Fatalf("got %v; want the second document rejected", errs)
+	}
+
+	for _, want := range []string{`"_index":"search-english"`, `"_index":"search-german"`, `"doc_as_upsert":true`} {
+		if !strings.Contains(body, want) {
+			t.Fatalf("got %q; want %q in our request", body, want)
+		}
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	if _, err := e.BulkUpsert(ctx, docs); err == nil {
+		t.Fatal("got no error once ctx is done")
+	}
+}
+
 func MockService(url string) (*ElasticSearch, error) {
 	client, err := elastic.NewSimpleClient(elastic.SetURL(url))
 	if err != nil {
@@ -108,3 +181,128 @@ func MockService(url string) (*ElasticSearch, error) {
 		Client: client, Index: "search", Type: "document",
 	}, nil
 }
+
+func TestMappingBM25(t *testing.T) {
+	for _, c := range []struct {
+		name  string
+		bm25  BM25
+		k1, b float64
+	}{
+		{"default", BM25{}, 1.2, 0.75},
+		{"tuned", BM25{K1: 1.6, B: 0.3}, 1.6, 0.3},
+	} {
+		t.Run(c.name, func(t *testing.T) {
+			e := &ElasticSearch{BM25: c.bm25}
+
+			m := struct {
+				Settings struct {
+					Similarity struct {
+						Default struct {
+							Type string
+							K1   float64
+							B    float64
+						}
+					}
+				}
+			}{}
+
+			if err := json.Unmarshal([]byte(e.mapping("english")), &m); err != nil {
+				t.Fatal(err)
+			}
+
+			sim := m.Settings.Similarity.Default
+			if sim.Type != "BM25" || sim.K1 != c.k1 || sim.B != c.b {
+				t.Fatalf("got %+v; want k1 %v, b %v", sim, c.k1, c.b)
+			}
+		})
+	}
+}
+
+func TestMappingAnalysis(t *testing.T) {
+	type analyzer struct {
+		Tokenizer string
+		Filter    []string
+	}
+
+	for _, c := range []struct {
+		name      string
+		analyzer  string
+		plugins   []string
+		compounds string
+		lang      string // the analyzer of the "lang" fields
+		want      *analyzer
+		filters   []string
+	}{
+		{"built in", "english", nil, "", "english", nil, nil},
+		{
+			"japanese bigrams", "japanese", nil, "", "japanese_text",
+			&analyzer{"standard", []string{"cjk_width", "lowercase", "cjk_bigram"}}, nil,
+		},
+		{
+			"kuromoji", "japanese", []string{"analysis-icu", "analysis-kuromoji"}, "", "japanese_text",
+			&analyzer{"kuromoji_tokenizer", []string{"kuromoji_baseform", "kuromoji_part_of_speech", "cjk_width", "ja_stop", "kuromoji_stemmer", "lowercase"}}, nil,
+		},
+		{
+			"nori", "korean", []string{"analysis-nori"}, "", "korean_text",
+			&analyzer{"nori_tokenizer", []string{"nori_part_of_speech", "nori_readingform", "lowercase"}}, nil,
+		},
+		{
+			"smartcn", "chinese", []string{"analysis-smartcn"}, "", "chinese_text",
+			&analyzer{"smartcn_tokenizer", []string{"lowercase"}}, nil,
+		},
+		{
+			"german", "german", nil, "", "german_text",
+			&analyzer{"standard", []string{"lowercase", "german_stop", "german_normalization", "german_light_stem"}},
+			[]string{"german_stop"},
+		},
+		{
+			"german compounds", "german", nil, "analysis/de_compounds.txt", "german_text",
+			&analyzer{"standard", []string{"lowercase", "german_compounds", "german_stop", "german_normalization", "german_light_stem"}},
+			[]string{"german_compounds", "german_stop"},
+		},
+	} {
+		t.Run(c.name, func(t *testing.T) {
+			e := &ElasticSearch{Plugins: c.plugins, Compounds: c.compounds}
+
+			m := struct {
+				Settings struct {
+					Analysis struct {
+						Filter   map[string]json.RawMessage
+						Analyzer map[string]*analyzer
+					}
+				}
+				Mappings struct {
+					Document struct {
+						Properties struct {
+							Title struct {
+								Fields struct {
+									Lang struct {
+										Analyzer string
+									}
+								}
+							}
+						}
+					}
+				}
+			}{}
+
+			if err := json.Unmarshal([]byte(e.mapping(c.analyzer)), &m); err != nil {
+				t.Fatal(err)
+			}
+
+			if got := m.Mappings.Document.Properties.Title.Fields.Lang.Analyzer; got != c.lang {
+				t.Fatalf("got analyzer %q; want %q", got, c.lang)
+			}
+
+			if got := m.Settings.Analysis.Analyzer[c.lang]; !reflect.DeepEqual(got, c.want) {
+				t.Fatalf("got %+v; want %+v", got, c.want)
+			}
+
+			for _, f := range c.filters {
+				if _, ok := m.Settings.Analysis.Filter[f]; !ok {
+					t.Fatalf("no %q filter", f)
+				}
+			}
+		})
+	}
+}
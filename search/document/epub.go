@@ -0,0 +1,189 @@
+package document
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/xml"
+	"io"
+	"net/url"
+	pathpkg "path"
+	"strings"
+)
+
+// epubPackage is the part of an ebook's package document we need: its metadata
+// and the chapters in their reading order (the spine).
+// https://www.w3.org/publishing/epub3/epub-packages.html
+type epubPackage struct {
+	Title    []string `xml:"metadata>title"`
+	Creator  []string `xml:"metadata>creator"`
+	Language []string `xml:"metadata>language"`
+	Date     []string `xml:"metadata>date"`
+	Manifest []struct {
+		ID   string `xml:"id,attr"`
+		Href string `xml:"href,attr"`
+	} `xml:"manifest>item"`
+	Spine []struct {
+		IDRef string `xml:"idref,attr"`
+	} `xml:"spine>itemref"`
+}
+
+// extractEPUB pulls the text out of an ebook, a zip archive of xhtml chapters.
+// META-INF/container.xml points to the package document that lists them.
+func extractEPUB(b []byte) (*file, error) {
+	zr, err := zip.NewReader(bytes.NewReader(b), int64(len(b)))
+	if err != nil {
+		return nil, err
+	}
+
+	parts := map[string]*zip.File{}
+	for _, zf := range zr.File {
+		parts[zf.Name] = zf
+	}
+
+	container, ok := parts["META-INF/container.xml"]
+	if !ok {
+		return nil, errFileType
+	}
+
+	var root string
+	err = officeTokens(container, func(dec *xml.Decoder, t xml.Token) error {
+		if se, ok := t.(xml.StartElement); ok && se.Name.Local == "rootfile" && root == "" {
+			for _, a := range se.Attr {
+				if a.Name.Local == "full-path" {
+					root = a.Value
+				}
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	opf, ok := parts[root]
+	if !ok {
+		return nil, errFileType
+	}
+
+	pkg := &epubPackage{}
+	if err := decodePart(opf, pkg); err != nil {
+		return nil, err
+	}
+
+	f := &file{
+		title:    first(pkg.Title),
+		author:   strings.Join(pkg.Creator, ", "),
+		language: first(pkg.Language),
+		date:     first(pkg.Date),
+	}
+
+	hrefs := map[string]string{}
+	for _, item := range pkg.Manifest {
+		hrefs[item.ID] = item.Href
+	}
+
+	// chapter hrefs are relative to the package document
+	dir := pathpkg.Dir(root)
+
+	text := []string{}
+	for _, ref := range pkg.Spine {
+		href, err := url.PathUnescape(hrefs[ref.IDRef])
+		if err != nil {
+			continue
+		}
+
+		zf, ok := parts[pathpkg.Join(dir, href)]
+		if !ok {
+			continue
+		}
+
+		t, err := epubText(zf)
+		if err != nil {
+			return nil, err
+		}
+		text = append(text, t)
+
+		if len(strings.Join(text, " ")) >= maxFileText {
+			break
+		}
+	}
+
+	if len(text) == 0 {
+		return nil, errFileType
+	}
+
+	f.text = strings.Join(text, " ")
+	return f, nil
+}
+
+func decodePart(zf *zip.File, v interface{}) error {
+	rc, err := zf.Open()
+	if err != nil {
+		return err
+	}
+	defer rc.Close()
+
+	return xml.NewDecoder(io.LimitReader(rc, maxInflate)).Decode(v)
+}
+
+// epubText is the text of the body of a chapter. Chapters are xhtml but many use html's
+// entities, like &nbsp;, so we don't decode them strictly.
+func epubText(zf *zip.File) (string, error) {
+	rc, err := zf.Open()
+	if err != nil {
+		return "", err
+	}
+	defer rc.Close()
+
+	dec := xml.NewDecoder(io.LimitReader(rc, maxInflate))
+	dec.Strict = false
+	dec.AutoClose = xml.HTMLAutoClose
+	dec.Entity = xml.HTMLEntity
+
+	var sb strings.Builder
+	var inBody bool
+	var skip int // we are in a <script> or <style>
+
+	for {
+		t, err := dec.Token()
+		if err == io.EOF {
+			return sb.String(), nil
+		}
+		if err != nil {
+			return "", err
+		}
+
+		switch tt := t.(type) {
+		case xml.StartElement:
+			switch tt.Name.Local {
+			case "body":
+				inBody = true
+			case "script", "style":
+				skip++
+			case "br":
+				sb.WriteString(" ")
+			}
+		case xml.EndElement:
+			switch tt.Name.Local {
+			case "body":
+				inBody = false
+			case "script", "style":
+				skip--
+			case "p", "div", "li", "h1", "h2", "h3", "h4", "h5", "h6", "td", "th":
+				sb.WriteString(" ")
+			}
+		case xml.CharData:
+			if inBody && skip == 0 && sb.Len() < maxFileText {
+				sb.Write(tt)
+			}
+		}
+	}
+}
+
+// first is the first of the values, e.g. the main title when an ebook has a subtitle too
+func first(values []string) string {
+	if len(values) == 0 {
+		return ""
+	}
+	return strings.TrimSpace(values[0])
+}
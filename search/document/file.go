@@ -0,0 +1,142 @@
+package document
+
+import (
+	"fmt"
+	"io/ioutil"
+	"mime"
+	"net/url"
+	"strings"
+	"time"
+
+	"golang.org/x/text/language"
+)
+
+// FileTypes are the MIME types of the files, other than webpages, we extract the text of
+var FileTypes = map[string]string{
+	"application/epub+zip": "epub",
+	"application/pdf":      "pdf",
+	"application/vnd.openxmlformats-officedocument.wordprocessingml.document":   "docx",
+	"application/vnd.openxmlformats-officedocument.presentationml.presentation": "pptx",
+	"text/csv":                  "csv",
+	"text/tab-separated-values": "tsv",
+}
+
+var errFileType = fmt.Errorf("not a pdf, docx, pptx, epub, csv or tsv file")
+
+// file is the text & metadata extracted from a file
+type file struct {
+	title    string
+	author   string
+	language string
+	date     string // when it was written, e.g. "2018-02-06"
+	pages    int    // or slides
+	text     string
+}
+
+// ambiguous are what we sniff office files, ebooks and datasets as. Office files and ebooks
+// are zip archives and are often served as application/octet-stream and datasets are plain text.
+var ambiguous = map[string]bool{
+	"application/octet-stream": true,
+	"application/zip":          true,
+	"text/plain":               true,
+}
+
+// fileMIME is the MIME type of a file we extract the text of. For the
+// ambiguous MIME types we also check the header and extension.
+func fileMIME(sniffed, header string, u *url.URL) string {
+	if _, ok := FileTypes[sniffed]; ok {
+		return sniffed
+	}
+
+	if !ambiguous[sniffed] {
+		return sniffed
+	}
+
+	if m, _, err := mime.ParseMediaType(header); err == nil {
+		if _, ok := FileTypes[m]; ok {
+			return m
+		}
+	}
+
+	if u != nil {
+		name := strings.ToLower(fileName(u))
+		for m, ft := range FileTypes {
+			if strings.HasSuffix(name, "."+ft) {
+				return m
+			}
+		}
+	}
+
+	return sniffed
+}
+
+// SetFile extracts the title, author, date, page count and text of a file from
+// the response body held by SetTokenizer. The text is kept as the description.
+func (d *Document) SetFile(truncateTitle, truncateDescription int) error {
+	ft, ok := FileTypes[d.MIME]
+	if !ok || d.file == nil {
+		return errFileType
+	}
+
+	b, err := ioutil.ReadAll(d.file)
+	if err != nil {
+		return err
+	}
+
+	var f *file
+	switch ft {
+	case "pdf":
+		f, err = extractPDF(b)
+	case "epub":
+		f, err = extractEPUB(b)
+	case "csv":
+		f, err = extractCSV(b, ',')
+	case "tsv":
+		f, err = extractCSV(b, '\t')
+	default:
+		f, err = extractOffice(b, ft)
+	}
+
+	if err != nil {
+		return err
+	}
+
+	d.FileType = ft
+	d.Size = int64(len(b))
+	d.Author = d.extractText(f.author, truncateTitle)
+	d.Pages = f.pages
+	d.Date = fileDate(f.date)
+
+	d.Title = d.extractText(f.title, truncateTitle)
+	if d.Title == "" && d.URL != nil { // e.g. "annual-report-2018.pdf"
+		d.Title = d.extractText(fileName(d.URL), truncateTitle)
+	}
+
+	d.Description = d.extractText(f.text, truncateDescription)
+
+	var tag language.Tag
+	if f.language != "" {
+		tag = language.Make(strings.ToLower(f.language))
+	}
+	d.Language, _, _ = Matcher.Match(tag)
+
+	return nil
+}
+
+// fileName is the last segment of a url's path
+func fileName(u *url.URL) string {
+	return u.Path[strings.LastIndex(u.Path, "/")+1:]
+}
+
+// fileDate is the day of a date in a file's metadata, which can be a full timestamp
+// or as little as a year. It is empty if we can't tell what the date is.
+func fileDate(s string) string {
+	s = strings.TrimSpace(s)
+	for _, layout := range []string{time.RFC3339, "2006-01-02T15:04:05", "2006-01-02", "2006-01", "2006"} {
+		if t, err := time.Parse(layout, s); err == nil {
+			return t.Format("2006-01-02")
+		}
+	}
+
+	return ""
+}
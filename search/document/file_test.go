@@ -0,0 +1,227 @@
+package document
+
+import (
+	"archive/zip"
+	"bytes"
+	"compress/zlib"
+	"fmt"
+	"net/url"
+	"testing"
+
+	"golang.org/x/text/language"
+)
+
+// mockPDF has an uncompressed page with plain and kerned text and a compressed page
+func mockPDF(t *testing.T) []byte {
+	var z bytes.Buffer
+	zw := zlib.NewWriter(&z)
+	if _, err := zw.Write([]byte("BT /F1 12 Tf 72 700 Td (Second page) Tj ET")); err != nil {
+		t.Fatal(err)
+	}
+	if err := zw.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	content := "BT /F1 12 Tf 72 712 Td [(Annual)-300(Rep)15(ort)] TJ 0 -14 Td (Revenue grew \\(a lot\\)) Tj ET"
+
+	var b bytes.Buffer
+	b.WriteString("%PDF-1.4\n")
+	b.WriteString("1 0 obj\n<< /Type /Catalog /Pages 2 0 R >>\nendobj\n")
+	b.WriteString("2 0 obj\n<< /Type /Pages /Kids [3 0 R 4 0 R] /Count 2 >>\nendobj\n")
+	b.WriteString("3 0 obj\n<< /Type /Page /Parent 2 0 R /Contents 5 0 R >>\nendobj\n")
+	b.WriteString("4 0 obj\n<< /Type /Page /Parent 2 0 R /Contents 6 0 R >>\nendobj\n")
+	fmt.Fprintf(&b, "5 0 obj\n<< /Length %d >>\nstream\n%s\nendstream\nendobj\n", len(content), content)
+	fmt.Fprintf(&b, "6 0 obj\n<< /Length %d /Filter /FlateDecode >>\nstream\n", z.Len())
+	b.Write(z.Bytes())
+	b.WriteString("\nendstream\nendobj\n")
+	b.WriteString("7 0 obj\n<< /Title <FEFF004A0069007600650020005200650070006F00720074> /Author (Jane Doe) /CreationDate (D:20180206153000+01'00') >>\nendobj\n")
+	b.WriteString("trailer\n<< /Root 1 0 R /Info 7 0 R >>\n%%EOF\n")
+
+	return b.Bytes()
+}
+
+// mockOffice zips the parts of a docx or pptx
+func mockOffice(t *testing.T, parts map[string]string) []byte {
+	var b bytes.Buffer
+	zw := zip.NewWriter(&b)
+	for name, content := range parts {
+		w, err := zw.Create(name)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if _, err := w.Write([]byte(content)); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	if err := zw.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	return b.Bytes()
+}
+
+const mockOPF = `<?xml version="1.0" encoding="UTF-8"?>
+<package xmlns="http://www.idpf.org/2007/opf" version="3.0">
+<metadata xmlns:dc="http://purl.org/dc/elements/1.1/">
+<dc:title>Moby Dick</dc:title><dc:title>or, The Whale</dc:title><dc:creator>Herman Melville</dc:creator><dc:language>en</dc:language><dc:date>1851-10-18</dc:date>
+</metadata>
+<manifest>
+<item id="ch2" href="Text/ch2.xhtml" media-type="application/xhtml+xml"/>
+<item id="ch1" href="Text/ch%201.xhtml" media-type="application/xhtml+xml"/>
+</manifest>
+<spine><itemref idref="ch1"/><itemref idref="ch2"/></spine>
+</package>`
+
+const mockCore = `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<cp:coreProperties xmlns:cp="http://schemas.openxmlformats.org/package/2006/metadata/core-properties" xmlns:dc="http://purl.org/dc/elements/1.1/">
+<dc:title>Quarterly Plan</dc:title><dc:creator>John Smith</dc:creator><dc:language>fr-FR</dc:language><dcterms:created xmlns:dcterms="http://purl.org/dc/terms/">2019-03-04T10:00:00Z</dcterms:created>
+</cp:coreProperties>`
+
+func TestSetFile(t *testing.T) {
+	docx := mockOffice(t, map[string]string{
+		"docProps/core.xml": mockCore,
+		"docProps/app.xml":  `<Properties><Pages>3</Pages></Properties>`,
+		"word/document.xml": `<w:document xmlns:w="w"><w:body><w:p><w:r><w:t>Bonjour</w:t></w:r><w:r><w:t xml:space="preserve"> le </w:t></w:r><w:r><w:t>monde</w:t></w:r></w:p><w:p><w:r><w:t>Fin</w:t></w:r></w:p></w:body></w:document>`,
+	})
+
+	pptx := mockOffice(t, map[string]string{
+		"ppt/slides/slide1.xml":  `<p:sld xmlns:p="p" xmlns:a="a"><a:p><a:r><a:t>First</a:t></a:r></a:p></p:sld>`,
+		"ppt/slides/slide2.xml":  `<p:sld xmlns:p="p" xmlns:a="a"><a:p><a:r><a:t>Second</a:t></a:r></a:p></p:sld>`,
+		"ppt/slides/slide10.xml": `<p:sld xmlns:p="p" xmlns:a="a"><a:p><a:r><a:t>Tenth</a:t></a:r></a:p></p:sld>`,
+	})
+
+	epub := mockOffice(t, map[string]string{
+		"mimetype":                "application/epub+zip",
+		"META-INF/container.xml":  `<container xmlns="urn:oasis:names:tc:opendocument:xmlns:container"><rootfiles><rootfile full-path="OEBPS/content.opf" media-type="application/oebps-package+xml"/></rootfiles></container>`,
+		"OEBPS/content.opf":       mockOPF,
+		"OEBPS/Text/ch%201.xhtml": "",
+		"OEBPS/Text/ch 1.xhtml":   `<html xmlns="http://www.w3.org/1999/xhtml"><head><title>One</title><style>p {}</style></head><body><h1>Chapter&nbsp;One</h1><p>Call me Ishmael.</p></body></html>`,
+		"OEBPS/Text/ch2.xhtml":    `<html xmlns="http://www.w3.org/1999/xhtml"><body><p>Chapter Two<br/>ends</p></body></html>`,
+	})
+
+	dataset := []byte("\xef\xbb\xbfcity,country,population\nTokyo,Japan,37400068\n\"Delhi, NCT\",India,28514000\n")
+
+	type want struct {
+		mime        string
+		filetype    string
+		title       string
+		author      string
+		pages       int
+		description string
+		lang        language.Tag
+		date        string
+	}
+
+	for _, c := range []struct {
+		name        string
+		url         string
+		contentType string
+		body        []byte
+		want
+	}{
+		{
+			"pdf", "https://example.com/files/report.pdf", "application/pdf", mockPDF(t),
+			want{"application/pdf", "pdf", "Jive Report", "Jane Doe", 2, "Annual Report Revenue grew (a lot) Second page", language.English, "2018-02-06"},
+		},
+		{
+			"docx", "https://example.com/plan.docx", "application/vnd.openxmlformats-officedocument.wordprocessingml.document", docx,
+			want{"application/vnd.openxmlformats-officedocument.wordprocessingml.document", "docx", "Quarterly Plan", "John Smith", 3, "Bonjour le monde Fin", language.French, "2019-03-04"},
+		},
+		{
+			"pptx served as octet-stream", "https://example.com/decks/Kick%20Off.pptx", "application/octet-stream", pptx,
+			want{"application/vnd.openxmlformats-officedocument.presentationml.presentation", "pptx", "Kick Off.pptx", "", 3, "First Second Tenth", language.English, ""},
+		},
+		{
+			"epub served as zip", "https://example.com/books/moby-dick.epub", "application/zip", epub,
+			want{"application/epub+zip", "epub", "Moby Dick", "Herman Melville", 0, "Chapter One Call me Ishmael. Chapter Two ends", language.English, "1851-10-18"},
+		},
+		{
+			"csv", "https://example.com/data/cities.csv", "text/csv; charset=utf-8", dataset,
+			want{"text/csv", "csv", "cities.csv", "", 0, "city country population Tokyo Japan 37400068 Delhi, NCT India 28514000", language.English, ""},
+		},
+	} {
+		t.Run(c.name, func(t *testing.T) {
+			d, err := New(c.url)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			d.SetHeader(map[string][]string{"Content-Type": {c.contentType}})
+			if err := d.SetTokenizer(bytes.NewReader(c.body)); err != nil {
+				t.Fatal(err)
+			}
+
+			if d.MIME != c.want.mime {
+				t.Fatalf("got MIME %q; want %q", d.MIME, c.want.mime)
+			}
+
+			if err := d.SetFile(100, 200); err != nil {
+				t.Fatal(err)
+			}
+
+			got := want{d.MIME, d.FileType, d.Title, d.Author, d.Pages, d.Description, d.Language, d.Date}
+			if got != c.want {
+				t.Fatalf("got %+v; want %+v", got, c.want)
+			}
+
+			if d.Size != int64(len(c.body)) {
+				t.Fatalf("got size %d; want %d", d.Size, len(c.body))
+			}
+		})
+	}
+}
+
+func TestSetFileErrors(t *testing.T) {
+	for _, c := range []struct {
+		name string
+		mime string
+		body []byte
+		want error
+	}{
+		{"html", "text/html", []byte("<html></html>"), errFileType},
+		{"encrypted", "application/pdf", []byte("%PDF-1.4\ntrailer\n<< /Encrypt 9 0 R >>"), errEncrypted},
+		{"docx without a document", "application/vnd.openxmlformats-officedocument.wordprocessingml.document",
+			mockOffice(t, map[string]string{"docProps/core.xml": mockCore}), errFileType},
+		{"epub without a container", "application/epub+zip", mockOffice(t, map[string]string{"mimetype": "application/epub+zip"}), errFileType},
+	} {
+		t.Run(c.name, func(t *testing.T) {
+			d := &Document{file: bytes.NewReader(c.body)}
+			d.MIME = c.mime
+
+			if err := d.SetFile(100, 200); err != c.want {
+				t.Fatalf("got %v; want %v", err, c.want)
+			}
+		})
+	}
+}
+
+func TestFileMIME(t *testing.T) {
+	for _, c := range []struct {
+		sniffed string
+		header  string
+		url     string
+		want    string
+	}{
+		{"application/pdf", "", "https://example.com/download", "application/pdf"},
+		{"application/zip", "application/vnd.openxmlformats-officedocument.presentationml.presentation; charset=binary", "https://example.com/download", "application/vnd.openxmlformats-officedocument.presentationml.presentation"},
+		{"application/zip", "application/zip", "https://example.com/Plan.DOCX", "application/vnd.openxmlformats-officedocument.wordprocessingml.document"},
+		{"application/zip", "application/zip", "https://example.com/archive.zip", "application/zip"},
+		{"text/html", "application/pdf", "https://example.com/report.pdf", "text/html"},
+		{"application/zip", "application/octet-stream", "https://example.com/book.epub", "application/epub+zip"},
+		{"text/plain", "text/csv", "https://example.com/download", "text/csv"},
+		{"text/plain", "text/plain", "https://example.com/data.tsv", "text/tab-separated-values"},
+		{"text/plain", "text/plain", "https://example.com/robots.txt", "text/plain"},
+	} {
+		t.Run(c.url, func(t *testing.T) {
+			u, err := url.Parse(c.url)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if got := fileMIME(c.sniffed, c.header, u); got != c.want {
+				t.Fatalf("got %q; want %q", got, c.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,175 @@
+package document
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/xml"
+	"io"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+var slideName = regexp.MustCompile(`^ppt/slides/slide(\d+)\.xml$`)
+
+// extractOffice pulls the text out of a docx or pptx file, which are zip archives of xml.
+// https://www.ecma-international.org/publications/standards/Ecma-376.htm
+func extractOffice(b []byte, ft string) (*file, error) {
+	zr, err := zip.NewReader(bytes.NewReader(b), int64(len(b)))
+	if err != nil {
+		return nil, err
+	}
+
+	parts := map[string]*zip.File{}
+	for _, zf := range zr.File {
+		parts[zf.Name] = zf
+	}
+
+	f := &file{}
+
+	if zf, ok := parts["docProps/core.xml"]; ok {
+		core, err := officeXML(zf, "title", "creator", "language", "created")
+		if err != nil {
+			return nil, err
+		}
+
+		f.title, f.author, f.language, f.date = core["title"], core["creator"], core["language"], core["created"]
+	}
+
+	if zf, ok := parts["docProps/app.xml"]; ok {
+		app, err := officeXML(zf, "Pages", "Slides")
+		if err != nil {
+			return nil, err
+		}
+
+		f.pages, _ = strconv.Atoi(app["Pages"] + app["Slides"])
+	}
+
+	switch ft {
+	case "docx":
+		zf, ok := parts["word/document.xml"]
+		if !ok {
+			return nil, errFileType
+		}
+
+		if f.text, err = officeText(zf); err != nil {
+			return nil, err
+		}
+	case "pptx":
+		slides := []int{}
+		for name := range parts {
+			if m := slideName.FindStringSubmatch(name); m != nil {
+				n, _ := strconv.Atoi(m[1])
+				slides = append(slides, n)
+			}
+		}
+
+		if len(slides) == 0 {
+			return nil, errFileType
+		}
+
+		sort.Ints(slides) // slide10.xml comes after slide9.xml
+
+		text := []string{}
+		for _, n := range slides {
+			t, err := officeText(parts["ppt/slides/slide"+strconv.Itoa(n)+".xml"])
+			if err != nil {
+				return nil, err
+			}
+			text = append(text, t)
+		}
+
+		f.text = strings.Join(text, " ")
+		if f.pages == 0 {
+			f.pages = len(slides)
+		}
+	default:
+		return nil, errFileType
+	}
+
+	return f, nil
+}
+
+// officeXML is the text of the elements of a part with those local names, e.g. "title" for <dc:title>
+func officeXML(zf *zip.File, names ...string) (map[string]string, error) {
+	values := map[string]string{}
+
+	err := officeTokens(zf, func(dec *xml.Decoder, t xml.Token) error {
+		se, ok := t.(xml.StartElement)
+		if !ok {
+			return nil
+		}
+
+		for _, n := range names {
+			if se.Name.Local == n {
+				var s string
+				if err := dec.DecodeElement(&s, &se); err != nil {
+					return err
+				}
+				values[n] = strings.TrimSpace(s)
+			}
+		}
+
+		return nil
+	})
+
+	return values, err
+}
+
+// officeText is the text of the runs of a document or slide. Both wordprocessing (<w:t>)
+// and drawing (<a:t>) runs are named "t", and both name their paragraphs "p".
+func officeText(zf *zip.File) (string, error) {
+	var sb strings.Builder
+	inText := false
+
+	err := officeTokens(zf, func(dec *xml.Decoder, t xml.Token) error {
+		switch tt := t.(type) {
+		case xml.StartElement:
+			switch tt.Name.Local {
+			case "t":
+				inText = true
+			case "tab", "br":
+				sb.WriteString(" ")
+			}
+		case xml.EndElement:
+			switch tt.Name.Local {
+			case "t":
+				inText = false
+			case "p":
+				sb.WriteString(" ")
+			}
+		case xml.CharData:
+			if inText && sb.Len() < maxFileText {
+				sb.Write(tt)
+			}
+		}
+
+		return nil
+	})
+
+	return sb.String(), err
+}
+
+func officeTokens(zf *zip.File, fn func(dec *xml.Decoder, t xml.Token) error) error {
+	rc, err := zf.Open()
+	if err != nil {
+		return err
+	}
+	defer rc.Close()
+
+	dec := xml.NewDecoder(io.LimitReader(rc, maxInflate))
+	for {
+		t, err := dec.Token()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+
+		if err := fn(dec, t); err != nil {
+			return err
+		}
+	}
+}
@@ -0,0 +1,406 @@
+package document
+
+import (
+	"bytes"
+	"compress/zlib"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"regexp"
+	"strconv"
+	"strings"
+	"unicode"
+	"unicode/utf16"
+)
+
+const (
+	maxInflate  = 16 << 20 // per stream, so a small file can't inflate to gigabytes
+	maxFileText = 1 << 20
+)
+
+var (
+	errEncrypted = fmt.Errorf("encrypted pdf")
+	errNotPDF    = fmt.Errorf("not a pdf")
+)
+
+var (
+	pdfInfo   = regexp.MustCompile(`/Info\s+(\d+)\s+(\d+)\s+R`)
+	pdfPage   = regexp.MustCompile(`/Type\s*/Page\b`)
+	pdfDateRe = regexp.MustCompile(`^(?:D:)?(\d{4})(\d{2})(\d{2})`)
+)
+
+// extractPDF pulls the text out of the content streams of a pdf. We don't map the glyphs of
+// fonts with their own encodings back to text (mostly CJK), so those pdfs have little text.
+func extractPDF(b []byte) (*file, error) {
+	head := b
+	if len(head) > 1024 { // the header can follow some junk
+		head = head[:1024]
+	}
+
+	if !bytes.Contains(head, []byte("%PDF-")) {
+		return nil, errNotPDF
+	}
+
+	if bytes.Contains(b, []byte("/Encrypt")) {
+		return nil, errEncrypted
+	}
+
+	f := &file{}
+	f.title, f.author, f.date = pdfMetadata(b)
+
+	var text strings.Builder
+	streams := pdfStreams(b)
+	for _, s := range streams {
+		f.pages += len(pdfPage.FindAll(s, -1)) // pages are often in compressed object streams
+
+		if text.Len() < maxFileText && bytes.Contains(s, []byte("BT")) {
+			text.WriteString(pdfText(s))
+			text.WriteString(" ")
+		}
+	}
+
+	f.pages += len(pdfPage.FindAll(b, -1))
+	f.text = text.String()
+	return f, nil
+}
+
+// pdfStreams are the decoded streams of a pdf, skipping images, fonts and other filters we can't decode
+func pdfStreams(b []byte) [][]byte {
+	streams := [][]byte{}
+
+	for pos := 0; ; {
+		i := bytes.Index(b[pos:], []byte("stream"))
+		if i == -1 {
+			return streams
+		}
+
+		start := pos + i
+		pos = start + len("stream")
+
+		// the keyword, not the end of one or e.g. "upstream" in the text of an uncompressed stream
+		if start == 0 || pos == len(b) || (b[start-1] != '>' && !pdfSpace(b[start-1])) || (b[pos] != '\r' && b[pos] != '\n') {
+			continue
+		}
+
+		dict := b[:start]
+		if o := bytes.LastIndex(dict, []byte("obj")); o > -1 {
+			dict = dict[o:]
+		}
+
+		// the data starts after the end of line that follows "stream"
+		if bytes.HasPrefix(b[pos:], []byte("\r\n")) {
+			pos += 2
+		} else if bytes.HasPrefix(b[pos:], []byte("\n")) {
+			pos++
+		}
+
+		end := bytes.Index(b[pos:], []byte("endstream"))
+		if end == -1 {
+			return streams
+		}
+
+		data := b[pos : pos+end]
+		pos += end + len("endstream")
+
+		if bytes.Contains(dict, []byte("/Image")) || bytes.Contains(dict, []byte("/Length1")) {
+			continue
+		}
+
+		switch {
+		case bytes.Contains(dict, []byte("/FlateDecode")):
+			zr, err := zlib.NewReader(bytes.NewReader(data))
+			if err != nil {
+				continue
+			}
+
+			// a truncated stream still has the text up to where it ends
+			inflated, _ := ioutil.ReadAll(io.LimitReader(zr, maxInflate))
+			streams = append(streams, inflated)
+		case bytes.Contains(dict, []byte("/Filter")):
+		default:
+			streams = append(streams, data)
+		}
+	}
+}
+
+// pdfMetadata is the title, author and creation date in the Info dictionary the trailer points to
+func pdfMetadata(b []byte) (string, string, string) {
+	m := pdfInfo.FindAllSubmatch(b, -1)
+	if len(m) == 0 {
+		return "", "", ""
+	}
+
+	ref := m[len(m)-1] // the last trailer is the most recent
+	obj := regexp.MustCompile(`(?:^|\s)` + string(ref[1]) + `\s+` + string(ref[2]) + `\s+obj\b`)
+	loc := obj.FindIndex(b)
+	if loc == nil {
+		return "", "", ""
+	}
+
+	dict := b[loc[1]:]
+	if end := bytes.Index(dict, []byte("endobj")); end > -1 {
+		dict = dict[:end]
+	}
+
+	value := func(key string) string {
+		i := bytes.Index(dict, []byte(key))
+		if i == -1 {
+			return ""
+		}
+
+		rest := bytes.TrimLeft(dict[i+len(key):], " \t\r\n")
+		if len(rest) == 0 {
+			return ""
+		}
+
+		switch rest[0] {
+		case '(':
+			s, _ := pdfLiteral(rest)
+			return pdfString(s)
+		case '<':
+			s, _ := pdfHex(rest)
+			return pdfString(s)
+		}
+
+		return ""
+	}
+
+	return value("/Title"), value("/Author"), pdfDate(value("/CreationDate"))
+}
+
+// pdfDate turns a pdf's date, e.g. "D:20180206153000+01'00'", into one fileDate understands
+func pdfDate(s string) string {
+	m := pdfDateRe.FindStringSubmatch(s)
+	if m == nil {
+		return ""
+	}
+
+	return m[1] + "-" + m[2] + "-" + m[3]
+}
+
+// pdfText is the text shown by a content stream.
+// https://www.adobe.com/content/dam/acom/en/devnet/pdf/pdfs/PDF32000_2008.pdf (9.4 Text Objects)
+func pdfText(content []byte) string {
+	var sb strings.Builder
+	space := func() {
+		if s := sb.String(); s != "" && !strings.HasSuffix(s, " ") {
+			sb.WriteString(" ")
+		}
+	}
+
+	var operands [][]byte
+	var array []interface{} // the strings & kerning of a TJ array
+	inArray := false
+
+	for i := 0; i < len(content); {
+		c := content[i]
+		switch {
+		case pdfSpace(c):
+			i++
+		case c == '%':
+			for i < len(content) && content[i] != '\n' && content[i] != '\r' {
+				i++
+			}
+		case c == '(':
+			s, n := pdfLiteral(content[i:])
+			i += n
+			if inArray {
+				array = append(array, s)
+				continue
+			}
+			operands = append(operands, s)
+		case c == '<' && i+1 < len(content) && content[i+1] == '<', c == '>' && i+1 < len(content) && content[i+1] == '>':
+			i += 2
+		case c == '<':
+			s, n := pdfHex(content[i:])
+			i += n
+			if inArray {
+				array = append(array, s)
+				continue
+			}
+			operands = append(operands, s)
+		case c == '[':
+			inArray, array = true, nil
+			i++
+		case c == ']':
+			inArray = false
+			i++
+		case c == '/':
+			i++
+			for i < len(content) && !pdfDelimiter(content[i]) {
+				i++
+			}
+		case c == '-' || c == '+' || c == '.' || (c >= '0' && c <= '9'):
+			j := i + 1
+			for j < len(content) && (content[j] == '.' || (content[j] >= '0' && content[j] <= '9')) {
+				j++
+			}
+			if inArray {
+				if n, err := strconv.ParseFloat(string(content[i:j]), 64); err == nil {
+					array = append(array, n)
+				}
+			}
+			i = j
+		default:
+			j := i + 1
+			for j < len(content) && !pdfDelimiter(content[j]) {
+				j++
+			}
+			op := string(content[i:j])
+			i = j
+
+			switch op {
+			case "Tj", "'", `"`:
+				if op != "Tj" {
+					space()
+				}
+				if len(operands) > 0 {
+					sb.WriteString(pdfString(operands[len(operands)-1]))
+				}
+			case "TJ":
+				for _, a := range array {
+					switch v := a.(type) {
+					case []byte:
+						sb.WriteString(pdfString(v))
+					case float64:
+						if v < -250 { // a gap wider than a quarter of the font size is a space
+							space()
+						}
+					}
+				}
+				array = nil
+			case "Td", "TD", "T*", "Tm", "ET":
+				space()
+			case "BI": // skip the data of inline images
+				if end := bytes.Index(content[i:], []byte("EI")); end > -1 {
+					i += end + 2
+				}
+			}
+			operands = operands[:0]
+		}
+	}
+
+	return sb.String()
+}
+
+func pdfSpace(c byte) bool {
+	return c == ' ' || c == '\t' || c == '\r' || c == '\n' || c == '\f' || c == 0
+}
+
+func pdfDelimiter(c byte) bool {
+	return strings.IndexByte(" \t\r\n\f\x00()<>[]{}/%", c) > -1
+}
+
+// pdfLiteral is a (string) with its escapes and balanced parentheses and how many bytes it took
+func pdfLiteral(b []byte) ([]byte, int) {
+	s := []byte{}
+	depth := 0
+
+	for i := 0; i < len(b); i++ {
+		c := b[i]
+		switch c {
+		case '(':
+			if depth > 0 {
+				s = append(s, c)
+			}
+			depth++
+		case ')':
+			depth--
+			if depth == 0 {
+				return s, i + 1
+			}
+			s = append(s, c)
+		case '\\':
+			i++
+			if i == len(b) {
+				return s, i
+			}
+
+			switch e := b[i]; e {
+			case 'n':
+				s = append(s, '\n')
+			case 'r':
+				s = append(s, '\r')
+			case 't':
+				s = append(s, '\t')
+			case 'b', 'f':
+			case '\r', '\n': // a line continuation
+				if e == '\r' && i+1 < len(b) && b[i+1] == '\n' {
+					i++
+				}
+			default:
+				if e >= '0' && e <= '7' { // up to 3 octal digits
+					n := 0
+					for j := 0; j < 3 && i < len(b) && b[i] >= '0' && b[i] <= '7'; j++ {
+						n = n*8 + int(b[i]-'0')
+						i++
+					}
+					i--
+					s = append(s, byte(n))
+					continue
+				}
+				s = append(s, e)
+			}
+		default:
+			s = append(s, c)
+		}
+	}
+
+	return s, len(b)
+}
+
+// pdfHex is a <hex string> and how many bytes it took
+func pdfHex(b []byte) ([]byte, int) {
+	end := bytes.IndexByte(b, '>')
+	if end == -1 {
+		end = len(b) - 1
+	}
+
+	digits := []byte{}
+	for _, c := range b[1:end] {
+		if (c >= '0' && c <= '9') || (c >= 'a' && c <= 'f') || (c >= 'A' && c <= 'F') {
+			digits = append(digits, c)
+		}
+	}
+
+	if len(digits)%2 == 1 {
+		digits = append(digits, '0')
+	}
+
+	s := make([]byte, len(digits)/2)
+	for i := range s {
+		n, _ := strconv.ParseUint(string(digits[2*i:2*i+2]), 16, 8)
+		s[i] = byte(n)
+	}
+
+	return s, end + 1
+}
+
+// pdfString decodes a string that's UTF-16 if it has a byte order mark and otherwise
+// close enough to Latin-1 for our purposes. Unprintable characters are dropped.
+func pdfString(b []byte) string {
+	var runes []rune
+	if len(b) >= 2 && b[0] == 0xfe && b[1] == 0xff {
+		u := make([]uint16, 0, len(b)/2)
+		for i := 2; i+1 < len(b); i += 2 {
+			u = append(u, uint16(b[i])<<8|uint16(b[i+1]))
+		}
+		runes = utf16.Decode(u)
+	} else {
+		for _, c := range b {
+			runes = append(runes, rune(c))
+		}
+	}
+
+	var sb strings.Builder
+	for _, r := range runes {
+		switch {
+		case unicode.IsSpace(r):
+			sb.WriteRune(' ')
+		case unicode.IsPrint(r):
+			sb.WriteRune(r)
+		}
+	}
+
+	return sb.String()
+}
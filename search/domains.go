@@ -0,0 +1,109 @@
+package search
+
+import (
+	"fmt"
+	"net/url"
+	"sort"
+	"strings"
+)
+
+// Preference is what we do with a domain's results
+type Preference string
+
+// Ban removes a domain's results
+const Ban Preference = "ban"
+
+// Sink moves a domain's results below the others
+const Sink Preference = "sink"
+
+// Pin moves a domain's results above the others
+const Pin Preference = "pin"
+
+// Preferences are the domains with a Preference. A domain includes its subdomains.
+type Preferences map[string]Preference
+
+// ParsePreference validates a preference
+func ParsePreference(s string) (Preference, error) {
+	switch p := Preference(strings.ToLower(strings.TrimSpace(s))); p {
+	case Ban, Sink, Pin:
+		return p, nil
+	}
+
+	return "", fmt.Errorf("invalid domain preference %q", s)
+}
+
+// NormalizeDomain lowercases a domain and strips a leading "*." or "."
+func NormalizeDomain(d string) string {
+	d = strings.ToLower(strings.TrimSpace(d))
+	d = strings.TrimPrefix(d, "*")
+	return strings.TrimPrefix(d, ".")
+}
+
+// Add sets the preference for each comma-separated domain in s
+func (p Preferences) Add(s string, pref Preference) Preferences {
+	for _, d := range strings.Split(s, ",") {
+		if d = NormalizeDomain(d); d != "" {
+			p[d] = pref
+		}
+	}
+
+	return p
+}
+
+// Lookup finds the preference for a host. The most specific domain listed wins.
+func (p Preferences) Lookup(host string) (Preference, bool) {
+	host = strings.ToLower(host)
+	for {
+		if pref, ok := p[host]; ok {
+			return pref, true
+		}
+
+		i := strings.Index(host, ".")
+		if i < 0 {
+			return "", false
+		}
+		host = host[i+1:]
+	}
+}
+
+// Prefer applies the instance's and the user's domain preferences.
+// Banned results are removed, pinned results move to the top and sunk results to the
+// bottom. Otherwise results keep their order. The user's preferences take precedence
+// over the instance's except that a domain the instance bans is never shown.
+func (r *Results) Prefer(instance, user Preferences) *Results {
+	if len(instance) == 0 && len(user) == 0 {
+		return r
+	}
+
+	rank := map[Preference]int{Pin: -1, Sink: 1}
+	docs := r.Documents[:0]
+	order := map[string]int{}
+
+	for _, doc := range r.Documents {
+		var host string
+		if u, err := url.Parse(doc.ID); err == nil {
+			host = u.Hostname()
+		}
+
+		pref, _ := instance.Lookup(host)
+		if pref != Ban {
+			if p, ok := user.Lookup(host); ok {
+				pref = p
+			}
+		}
+
+		if pref == Ban {
+			continue
+		}
+
+		order[doc.ID] = rank[pref]
+		docs = append(docs, doc)
+	}
+
+	sort.SliceStable(docs, func(i, j int) bool {
+		return order[docs[i].ID] < order[docs[j].ID]
+	})
+
+	r.Documents = docs
+	return r
+}
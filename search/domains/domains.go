@@ -0,0 +1,15 @@
+// Package domains persists the instance's domain preferences: the domains whose
+// results are banned, sunk or pinned for everyone
+package domains
+
+import (
+	"github.com/jivesearch/jivesearch/search"
+)
+
+// Store outlines the methods to persist our domain preferences
+type Store interface {
+	Setup() error
+	List() (search.Preferences, error)
+	Set(domain string, p search.Preference) error
+	Delete(domain string) error
+}
@@ -0,0 +1,64 @@
+package domains
+
+import (
+	"database/sql"
+
+	"github.com/jivesearch/jivesearch/search"
+)
+
+// PostgreSQL stores our domain preferences in PostgreSQL
+type PostgreSQL struct {
+	*sql.DB
+}
+
+const domainsTable = "domain_preferences"
+
+// Setup creates our table if it doesn't exist
+func (p *PostgreSQL) Setup() error {
+	_, err := p.DB.Exec(`
+		CREATE TABLE IF NOT EXISTS ` + domainsTable + ` (
+			domain text PRIMARY KEY,
+			preference text NOT NULL
+		);
+	`)
+
+	return err
+}
+
+// List returns the preferences
+func (p *PostgreSQL) List() (search.Preferences, error) {
+	rows, err := p.DB.Query(`SELECT domain, preference FROM ` + domainsTable)
+	if err != nil {
+		return nil, err
+	}
+
+	defer rows.Close()
+
+	prefs := search.Preferences{}
+	for rows.Next() {
+		var d, pref string
+		if err := rows.Scan(&d, &pref); err != nil {
+			return nil, err
+		}
+		prefs[d] = search.Preference(pref)
+	}
+
+	return prefs, rows.Err()
+}
+
+// Set adds or changes a domain's preference
+func (p *PostgreSQL) Set(domain string, pref search.Preference) error {
+	_, err := p.DB.Exec(
+		`INSERT INTO `+domainsTable+` (domain, preference) VALUES ($1, $2)
+		ON CONFLICT (domain) DO UPDATE SET preference = EXCLUDED.preference`,
+		domain, string(pref),
+	)
+
+	return err
+}
+
+// Delete removes a domain's preference
+func (p *PostgreSQL) Delete(domain string) error {
+	_, err := p.DB.Exec(`DELETE FROM `+domainsTable+` WHERE domain = $1`, domain)
+	return err
+}
@@ -0,0 +1,53 @@
+package domains
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/jivesearch/jivesearch/search"
+	sqlmock "gopkg.in/DATA-DOG/go-sqlmock.v2"
+)
+
+func TestPostgreSQL(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+
+	p := &PostgreSQL{DB: db}
+
+	mock.ExpectExec("INSERT INTO domain_preferences (.+) ON CONFLICT").WithArgs("example.com", "ban").
+		WillReturnResult(sqlmock.NewResult(0, 1))
+
+	if err := p.Set("example.com", search.Ban); err != nil {
+		t.Fatal(err)
+	}
+
+	mock.ExpectExec("DELETE FROM domain_preferences").WithArgs("example.org").
+		WillReturnResult(sqlmock.NewResult(0, 1))
+
+	if err := p.Delete("example.org"); err != nil {
+		t.Fatal(err)
+	}
+
+	mock.ExpectQuery("SELECT domain, preference FROM domain_preferences").
+		WillReturnRows(sqlmock.NewRows([]string{"domain", "preference"}).
+			AddRow("example.com", "ban").
+			AddRow("example.net", "pin"),
+		)
+
+	got, err := p.List()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := search.Preferences{"example.com": search.Ban, "example.net": search.Pin}
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("got %+v; want %+v", got, want)
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Fatal(err)
+	}
+}
@@ -0,0 +1,53 @@
+package domains
+
+import (
+	"sync"
+
+	"github.com/jivesearch/jivesearch/search"
+)
+
+// Simple is an in-memory Store. Preferences are lost on restart so it is only suitable for testing.
+type Simple struct {
+	mu    sync.Mutex
+	prefs search.Preferences
+}
+
+// Setup initializes the store
+func (s *Simple) Setup() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.prefs = search.Preferences{}
+	return nil
+}
+
+// List returns a copy of the preferences
+func (s *Simple) List() (search.Preferences, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	prefs := search.Preferences{}
+	for d, p := range s.prefs {
+		prefs[d] = p
+	}
+
+	return prefs, nil
+}
+
+// Set adds or changes a domain's preference
+func (s *Simple) Set(domain string, p search.Preference) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.prefs[domain] = p
+	return nil
+}
+
+// Delete removes a domain's preference
+func (s *Simple) Delete(domain string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	delete(s.prefs, domain)
+	return nil
+}
@@ -0,0 +1,43 @@
+package domains
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/jivesearch/jivesearch/search"
+)
+
+func TestSimple(t *testing.T) {
+	s := &Simple{}
+	if err := s.Setup(); err != nil {
+		t.Fatal(err)
+	}
+
+	for d, p := range map[string]search.Preference{
+		"example.com":     search.Ban,
+		"example.org":     search.Pin,
+		"spam.example.io": search.Sink,
+	} {
+		if err := s.Set(d, p); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	if err := s.Set("example.com", search.Sink); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := s.Delete("example.org"); err != nil {
+		t.Fatal(err)
+	}
+
+	got, err := s.List()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := search.Preferences{"example.com": search.Sink, "spam.example.io": search.Sink}
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("got %+v; want %+v", got, want)
+	}
+}
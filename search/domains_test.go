@@ -0,0 +1,63 @@
+package search
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/jivesearch/jivesearch/search/document"
+)
+
+func TestPrefer(t *testing.T) {
+	instance := Preferences{
+		"spam.com":    Ban,
+		"content.com": Sink,
+		"docs.org":    Pin,
+	}
+
+	for _, c := range []struct {
+		name string
+		user Preferences
+		want []string
+	}{
+		{
+			name: "instance",
+			want: []string{"https://docs.org/b", "https://a.com/", "https://blog.a.com/", "https://c.net/", "https://www.content.com/"},
+		},
+		{
+			name: "user",
+			user: Preferences{}.Add("*.A.com, c.net ", Sink).Add("content.com", Pin).Add("spam.com", Pin),
+			want: []string{"https://www.content.com/", "https://docs.org/b", "https://a.com/", "https://blog.a.com/", "https://c.net/"},
+		},
+		{
+			name: "most specific domain",
+			user: Preferences{"a.com": Ban, "blog.a.com": Pin},
+			want: []string{"https://docs.org/b", "https://blog.a.com/", "https://c.net/", "https://www.content.com/"},
+		},
+	} {
+		t.Run(c.name, func(t *testing.T) {
+			r := &Results{}
+			for _, u := range []string{"https://a.com/", "https://www.content.com/", "https://spam.com/x", "https://docs.org/b", "https://blog.a.com/", "https://c.net/"} {
+				r.Documents = append(r.Documents, &document.Document{ID: u})
+			}
+
+			got := []string{}
+			for _, doc := range r.Prefer(instance, c.user).Documents {
+				got = append(got, doc.ID)
+			}
+
+			if !reflect.DeepEqual(got, c.want) {
+				t.Fatalf("got %+v; want %+v", got, c.want)
+			}
+		})
+	}
+}
+
+func TestParsePreference(t *testing.T) {
+	if p, err := ParsePreference(" Ban "); err != nil || p != Ban {
+		t.Fatalf("got %q, %v; want %q", p, err, Ban)
+	}
+
+	if _, err := ParsePreference("hide"); err == nil {
+		t.Fatal("expected an error")
+	}
+}
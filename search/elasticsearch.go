@@ -3,9 +3,12 @@ package search
 import (
 	"context"
 	"encoding/json"
+	"fmt"
 	"strings"
 
+	"github.com/jivesearch/jivesearch/search/adult"
 	"github.com/jivesearch/jivesearch/search/document"
+	"github.com/jivesearch/jivesearch/search/paging"
 	"github.com/olivere/elastic"
 	"golang.org/x/text/language"
 )
@@ -13,39 +16,126 @@ import (
 // ElasticSearch embeds our main Elasticsearch instance
 type ElasticSearch struct {
 	*document.ElasticSearch
+	Ranker *Ranker // nil uses the DefaultRanking
 }
 
+// moderateBoost is how much of their score adult pages keep with a Moderate SafeSearch
+const moderateBoost = 0.2
+
 // Fetch returns search results for a search query
 // https://www.elastic.co/guide/en/elasticsearch/guide/current/one-lang-docs.html
 // https://www.elastic.co/guide/en/elasticsearch/guide/current/_single_query_string.html#know-your-data
 // The idea here is to first filter out docs that do not want to be indexed.
 // We then search multiple fields for the search query, giving more weight to certain fields.
 // We also are searching the standard analyzer and the language-specific analyzer.
-// We weight the domain > path, path > title, title > description.
-// We also give extra weight for bigram matches (need trigram????):
+// The weight of each field (by default domain > path > title, anchors > description),
+// how much fresh pages and domain authority count come from the Ranker.
+// We also give extra weight for bigram matches (need trigram????) and for the terms
+// as a phrase, or near each other, as set by the Ranker's Proximity:
 // https://www.elastic.co/guide/en/elasticsearch/guide/current/shingles.html
+// https://www.elastic.co/guide/en/elasticsearch/guide/current/proximity-relevance.html
 // Note: "It is not useful to mix not_analyzed fields with analyzed fields in multi_match queries."
+// A Strict SafeSearch filters out the pages the crawler scored as adult content and Moderate demotes them.
+// Offsets past MaxOffset are refused as Elasticsearch has to collect and discard every result before them.
 // TODO: A better domain name method...we could use regex ('.*hendrix'), prefix query, etc.
 func (e *ElasticSearch) Fetch(q string, filter Filter, lang language.Tag, region language.Region, number int, offset int) (*Results, error) {
+	if offset > MaxOffset {
+		return &Results{}, ErrDeepOffset
+	}
+
+	return e.fetch(q, filter, nil, lang, region, number, func(s *elastic.SearchService) *elastic.SearchService {
+		return s.From(offset)
+	})
+}
+
+// FetchFiles returns the files matching a query, with the facets narrowing them down.
+// The facets are counted before the user's filter is applied (a post_filter) so the
+// other values of a facet stay visible once one is chosen.
+func (e *ElasticSearch) FetchFiles(q string, filter Filter, files FileFilter, lang language.Tag, region language.Region, number int, offset int) (*Results, error) {
+	if offset > MaxOffset {
+		return &Results{}, ErrDeepOffset
+	}
+
+	return e.fetch(q, filter, &files, lang, region, number, func(s *elastic.SearchService) *elastic.SearchService {
+		return s.From(offset)
+	})
+}
+
+// FetchAfter returns the search results that follow the cursor.
+// The cursor holds the sort values of the last hit (search_after).
+func (e *ElasticSearch) FetchAfter(q string, filter Filter, lang language.Tag, region language.Region, number int, cursor string) (*Results, error) {
+	after := []interface{}{}
+	if err := paging.DecodeCursor(cursor, &after); err != nil || len(after) == 0 {
+		return &Results{}, ErrInvalidCursor
+	}
+
+	return e.fetch(q, filter, nil, lang, region, number, func(s *elastic.SearchService) *elastic.SearchService {
+		return s.SearchAfter(after...)
+	})
+}
+
+// fetch runs the search query. position sets either the "from" or the "search_after" of the request.
+// A FileFilter restricts the query to files and counts their facets.
+func (e *ElasticSearch) fetch(q string, filter Filter, files *FileFilter, lang language.Tag, region language.Region, number int, position func(*elastic.SearchService) *elastic.SearchService) (*Results, error) {
 	res := &Results{}
 
+	// "site:example.com" limits the results to a host
+	q, sites := SplitSites(q)
+
+	// "filetype:pdf" limits them to the files we extracted the text of
+	q, types := SplitFileTypes(q)
+
+	// "a OR b" matches docs with any of the terms rather than most of them
+	match := "-25%"
+	if strings.Contains(q, " OR ") {
+		q = strings.Replace(q, " OR ", " ", -1)
+		match = "1"
+	}
+
+	rk := e.Ranker.Ranking()
+
+	mm := elastic.NewMultiMatchQuery(q).Type("cross_fields").MinimumShouldMatch(match)
+	for _, f := range rk.fields() {
+		mm = mm.FieldWithBoost(f.name, f.boost)
+	}
+
+	var must elastic.Query = mm
+	if q == "" { // just a site: or filetype: query
+		must = elastic.NewMatchAllQuery()
+	}
+
 	qu := elastic.NewBoolQuery().
 		Filter(elastic.NewTermQuery("index", true)).
-		Must(
-			elastic.NewMultiMatchQuery(
-				q,
-				"domain^3", "path^2",
-				"title^1.5", "title.lang^1.5",
-				"description", "description.lang",
-			).Type("cross_fields").MinimumShouldMatch("-25%"),
-		).
-		Should(
-			elastic.NewMultiMatchQuery(
-				q,
-				"title.shingles",
-				"description.shingles",
-			).Type("cross_fields"),
+		Must(must).
+		Should(rk.proximity(q)...)
+
+	if len(sites) > 0 {
+		sq := elastic.NewBoolQuery().MinimumNumberShouldMatch(1)
+		for _, site := range sites {
+			sq = sq.Should(elastic.NewTermQuery("host", site))
+		}
+		qu = qu.Filter(sq)
+	}
+
+	// several languages can share an analyzer and its index. Pages from before we stored their language are kept.
+	if base, conf := lang.Base(); conf != language.No {
+		qu = qu.Filter(elastic.NewBoolQuery().
+			Should(elastic.NewTermQuery("lang", base.String())).
+			Should(elastic.NewBoolQuery().MustNot(elastic.NewExistsQuery("lang"))),
 		)
+	}
+
+	if len(types) > 0 {
+		ft := []interface{}{}
+		for _, t := range types {
+			ft = append(ft, strings.TrimPrefix(t, "."))
+		}
+		qu = qu.Filter(elastic.NewTermsQuery("filetype", ft...))
+	}
+
+	if files != nil { // only html pages lack a filetype
+		qu = qu.Filter(elastic.NewExistsQuery("filetype"))
+	}
 
 	// Boost results for regional queries (except for .me, .tv, etc. that are used for other purposes sometimes)
 	// https://support.google.com/webmasters/answer/182192#1
@@ -56,6 +146,17 @@ func (e *ElasticSearch) Fetch(q string, filter Filter, lang language.Tag, region
 		}
 	}
 
+	// SafeSearch hides pages the crawler scored as adult content or pushes them down
+	adultQuery := elastic.NewRangeQuery("adult").Gte(adult.Threshold)
+	if filter == Strict {
+		qu = qu.MustNot(adultQuery)
+	}
+
+	var query elastic.Query = rk.score(qu)
+	if filter == Moderate {
+		query = elastic.NewBoostingQuery().Positive(query).Negative(adultQuery).NegativeBoost(moderateBoost)
+	}
+
 	a, err := e.Analyzer(lang)
 	if err != nil {
 		return res, err
@@ -63,7 +164,14 @@ func (e *ElasticSearch) Fetch(q string, filter Filter, lang language.Tag, region
 
 	idx := e.IndexName(a)
 
-	out, err := e.Client.Search().Index(idx).Type(e.Type).Query(qu).From(offset).Size(number).Do(context.TODO())
+	// the id breaks ties in score so search_after doesn't skip or repeat results
+	svc := e.Client.Search().Index(idx).Type(e.Type).Query(query).Sort("_score", false).Sort("id", true).Size(number)
+
+	if files != nil {
+		svc = facet(svc, *files)
+	}
+
+	out, err := position(svc).Do(context.TODO())
 	if err != nil {
 		return res, err
 	}
@@ -91,5 +199,138 @@ func (e *ElasticSearch) Fetch(q string, filter Filter, lang language.Tag, region
 		res.Documents = append(res.Documents, doc)
 	}
 
+	if files != nil {
+		res.Facets = facets(out.Aggregations)
+		return res, nil // files page with an offset only
+	}
+
+	// a full page means there may be more
+	if l := len(out.Hits.Hits); l > 0 && l == number {
+		res.Cursor, err = paging.EncodeCursor(out.Hits.Hits[l-1].Sort)
+	}
+
 	return res, err
 }
+
+// facet counts the files by type, size and date and filters the hits by the user's choices
+func facet(svc *elastic.SearchService, f FileFilter) *elastic.SearchService {
+	sizes := elastic.NewRangeAggregation().Field("size")
+	for _, s := range FileSizes {
+		switch {
+		case s.From == 0:
+			sizes = sizes.AddUnboundedFromWithKey(s.Value, s.To)
+		case s.To == 0:
+			sizes = sizes.AddUnboundedToWithKey(s.Value, s.From)
+		default:
+			sizes = sizes.AddRangeWithKey(s.Value, s.From, s.To)
+		}
+	}
+
+	dates := elastic.NewDateRangeAggregation().Field("date")
+	for _, d := range FileDates {
+		dates = dates.AddUnboundedToWithKey(d.Value, d.From)
+	}
+
+	svc = svc.Aggregation("type", elastic.NewTermsAggregation().Field("filetype")).
+		Aggregation("size", sizes).
+		Aggregation("date", dates)
+
+	post := []elastic.Query{}
+	if f.Type != "" {
+		post = append(post, elastic.NewTermQuery("filetype", f.Type))
+	}
+
+	for _, s := range FileSizes {
+		if s.Value != f.Size {
+			continue
+		}
+		rq := elastic.NewRangeQuery("size")
+		if s.From > 0 {
+			rq = rq.Gte(s.From)
+		}
+		if s.To > 0 {
+			rq = rq.Lt(s.To)
+		}
+		post = append(post, rq)
+	}
+
+	for _, d := range FileDates {
+		if d.Value == f.Date {
+			post = append(post, elastic.NewRangeQuery("date").Gte(d.From))
+		}
+	}
+
+	if len(post) > 0 {
+		svc = svc.PostFilter(elastic.NewBoolQuery().Filter(post...))
+	}
+
+	return svc
+}
+
+// facets reads the counts of the aggregations from facet. Empty buckets are left out.
+func facets(aggs elastic.Aggregations) *Facets {
+	f := &Facets{
+		Type: []Facet{},
+		Size: []Facet{},
+		Date: []Facet{},
+	}
+
+	if t, ok := aggs.Terms("type"); ok {
+		for _, b := range t.Buckets {
+			if b.DocCount > 0 {
+				f.Type = append(f.Type, Facet{fmt.Sprint(b.Key), b.DocCount})
+			}
+		}
+	}
+
+	for name, facet := range map[string]*[]Facet{"size": &f.Size, "date": &f.Date} {
+		r, ok := aggs.Range(name)
+		if !ok {
+			continue
+		}
+		for _, b := range r.Buckets {
+			if b.DocCount > 0 {
+				*facet = append(*facet, Facet{b.Key, b.DocCount})
+			}
+		}
+	}
+
+	return f
+}
+
+// Correct uses a phrase suggester on the titles of our documents for "Did you mean?"
+func (e *ElasticSearch) Correct(q string, lang language.Tag) (string, error) {
+	a, err := e.Analyzer(lang)
+	if err != nil {
+		return "", err
+	}
+
+	sug := elastic.NewPhraseSuggester("spelling").Text(q).Field("title").Size(1)
+
+	out, err := e.Client.Search().Index(e.IndexName(a)).Type(e.Type).Suggester(sug).Size(0).Do(context.TODO())
+	if err != nil {
+		return "", err
+	}
+
+	for _, s := range out.Suggest["spelling"] {
+		for _, o := range s.Options {
+			return o.Text, nil
+		}
+	}
+
+	return "", nil
+}
+
+// Ping checks that Elasticsearch is reachable and that we have at least one index
+func (e *ElasticSearch) Ping() error {
+	ok, err := e.Client.IndexExists(e.Index + "-*").Do(context.TODO())
+	if err != nil {
+		return err
+	}
+
+	if !ok {
+		return fmt.Errorf("no %v-* indices", e.Index)
+	}
+
+	return nil
+}
@@ -1,9 +1,11 @@
 package search
 
 import (
+	"io/ioutil"
 	"net/http"
 	"net/http/httptest"
 	"reflect"
+	"strings"
 	"testing"
 
 	"github.com/jivesearch/jivesearch/search/document"
@@ -213,3 +215,274 @@ func MockService(url string) (*ElasticSearch, error) {
 
 	return e, nil
 }
+
+func TestCorrect(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		resp := `{
+		  "took": 5,
+		  "timed_out": false,
+		  "hits": {"total": 0, "max_score": 0, "hits": []},
+		  "suggest": {
+		    "spelling": [
+		      {
+		        "text": "jimmy hendrix",
+		        "offset": 0,
+		        "length": 13,
+		        "options": [
+		          {"text": "jimi hendrix", "score": 0.0264}
+		        ]
+		      }
+		    ]
+		  }
+		}`
+
+		if _, err := w.Write([]byte(resp)); err != nil {
+			t.Fatal(err)
+		}
+	}))
+	defer ts.Close()
+
+	e, err := MockService(ts.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	got, err := e.Correct("jimmy hendrix", language.English)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if got != "jimi hendrix" {
+		t.Fatalf("got %q; want %q", got, "jimi hendrix")
+	}
+}
+
+func TestFetchSite(t *testing.T) {
+	var body string
+
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		b, err := ioutil.ReadAll(r.Body)
+		if err != nil {
+			t.Fatal(err)
+		}
+		body = string(b)
+
+		if _, err := w.Write([]byte(`{"hits": {"total": 0, "hits": []}}`)); err != nil {
+			t.Fatal(err)
+		}
+	}))
+	defer ts.Close()
+
+	e, err := MockService(ts.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := e.Fetch("site:Docs.Example.com jive", Moderate, language.English, language.MustParseRegion("US"), 10, 0); err != nil {
+		t.Fatal(err)
+	}
+
+	for _, want := range []string{`{"term":{"host":"docs.example.com"}}`, `"query":"jive"`} {
+		if !strings.Contains(body, want) {
+			t.Fatalf("got %s; want it to contain %s", body, want)
+		}
+	}
+
+	if _, err := e.Fetch("annual report filetype:pdf", Moderate, language.English, language.MustParseRegion("US"), 10, 0); err != nil {
+		t.Fatal(err)
+	}
+
+	for _, want := range []string{`{"terms":{"filetype":["pdf"]}}`, `"query":"annual report"`, `{"term":{"lang":"en"}}`} {
+		if !strings.Contains(body, want) {
+			t.Fatalf("got %s; want it to contain %s", body, want)
+		}
+	}
+}
+
+func TestFetchAfter(t *testing.T) {
+	var body string
+
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		b, err := ioutil.ReadAll(r.Body)
+		if err != nil {
+			t.Fatal(err)
+		}
+		body = string(b)
+
+		resp := `{"hits": {"total": 7, "hits": [
+			{"_id": "https://a.example.com", "_score": 2.5, "_source": {}, "sort": [2.5, "https://a.example.com"]},
+			{"_id": "https://b.example.com", "_score": 1.5, "_source": {}, "sort": [1.5, "https://b.example.com"]}
+		]}}`
+
+		if _, err := w.Write([]byte(resp)); err != nil {
+			t.Fatal(err)
+		}
+	}))
+	defer ts.Close()
+
+	e, err := MockService(ts.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	res, err := e.Fetch("jive", Moderate, language.English, language.MustParseRegion("US"), 2, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if res.Cursor == "" {
+		t.Fatal("a full page should have a cursor")
+	}
+
+	if _, err := e.FetchAfter("jive", Moderate, language.English, language.MustParseRegion("US"), 2, res.Cursor); err != nil {
+		t.Fatal(err)
+	}
+
+	if want := `"search_after":[1.5,"https://b.example.com"]`; !strings.Contains(body, want) {
+		t.Fatalf("got %s; want it to contain %s", body, want)
+	}
+
+	if _, err := e.FetchAfter("jive", Moderate, language.English, language.MustParseRegion("US"), 2, "bad"); err != ErrInvalidCursor {
+		t.Fatalf("got err %v; want %v", err, ErrInvalidCursor)
+	}
+
+	if _, err := e.Fetch("jive", Moderate, language.English, language.MustParseRegion("US"), 25, MaxOffset+25); err != ErrDeepOffset {
+		t.Fatalf("got err %v; want %v", err, ErrDeepOffset)
+	}
+}
+
+func TestFetchSafeSearch(t *testing.T) {
+	for _, c := range []struct {
+		filter Filter
+		want   []string
+		not    []string
+	}{
+		{Strict, []string{`"must_not":{"range":{"adult":{"from":0.5,"include_lower":true,"include_upper":true,"to":null}}}`}, []string{`"boosting"`}},
+		{Moderate, []string{`"boosting":{"negative":{"range":{"adult":{"from":0.5,"include_lower":true,"include_upper":true,"to":null}}},"negative_boost":0.2`}, []string{`"must_not":{"range"`}},
+		{Off, nil, []string{`"adult"`}},
+	} {
+		t.Run(string(c.filter), func(t *testing.T) {
+			var body string
+
+			ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				b, err := ioutil.ReadAll(r.Body)
+				if err != nil {
+					t.Fatal(err)
+				}
+				body = string(b)
+
+				if _, err := w.Write([]byte(`{"hits": {"total": 0, "hits": []}}`)); err != nil {
+					t.Fatal(err)
+				}
+			}))
+			defer ts.Close()
+
+			e, err := MockService(ts.URL)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if _, err := e.Fetch("jive", c.filter, language.English, language.MustParseRegion("US"), 10, 0); err != nil {
+				t.Fatal(err)
+			}
+
+			for _, want := range c.want {
+				if !strings.Contains(body, want) {
+					t.Fatalf("got %s; want it to contain %s", body, want)
+				}
+			}
+
+			for _, not := range c.not {
+				if strings.Contains(body, not) {
+					t.Fatalf("got %s; want it without %s", body, not)
+				}
+			}
+		})
+	}
+}
+
+func TestFetchFiles(t *testing.T) {
+	var body string
+
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		b, err := ioutil.ReadAll(r.Body)
+		if err != nil {
+			t.Fatal(err)
+		}
+		body = string(b)
+
+		resp := `{"hits": {"total": 1, "hits": [
+			{"_id": "https://example.com/report.pdf", "_score": 2.5, "_source": {"filetype": "pdf", "size": 2048}, "sort": [2.5, "https://example.com/report.pdf"]}
+		]},
+		"aggregations": {
+			"type": {"buckets": [{"key": "pdf", "doc_count": 4}, {"key": "csv", "doc_count": 2}]},
+			"size": {"buckets": [{"key": "small", "to": 1048576, "doc_count": 5}, {"key": "medium", "from": 1048576, "to": 10485760, "doc_count": 1}, {"key": "large", "from": 10485760, "doc_count": 0}]},
+			"date": {"buckets": [{"key": "day", "doc_count": 0}, {"key": "week", "doc_count": 1}, {"key": "month", "doc_count": 3}, {"key": "year", "doc_count": 6}]}
+		}}`
+
+		if _, err := w.Write([]byte(resp)); err != nil {
+			t.Fatal(err)
+		}
+	}))
+	defer ts.Close()
+
+	e, err := MockService(ts.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	files := NewFileFilter("PDF", "small", "month")
+	res, err := e.FetchFiles("annual report", Moderate, files, language.English, language.MustParseRegion("US"), 1, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, want := range []string{
+		`{"exists":{"field":"filetype"}}`,
+		`"aggregations":{"date":{"date_range":{"field":"date","ranges":[{"from":"now-1d","key":"day"}`,
+		`"post_filter":{"bool":{"filter":[{"term":{"filetype":"pdf"}},{"range":{"size":{"from":null,"include_lower":true,"include_upper":false,"to":1048576}}},{"range":{"date":{"from":"now-1M","include_lower":true,"include_upper":true,"to":null}}}]}}`,
+	} {
+		if !strings.Contains(body, want) {
+			t.Fatalf("got %s; want it to contain %s", body, want)
+		}
+	}
+
+	want := &Facets{
+		Type: []Facet{{"pdf", 4}, {"csv", 2}},
+		Size: []Facet{{"small", 5}, {"medium", 1}},
+		Date: []Facet{{"week", 1}, {"month", 3}, {"year", 6}},
+	}
+
+	if !reflect.DeepEqual(res.Facets, want) {
+		t.Fatalf("got %+v; want %+v", res.Facets, want)
+	}
+
+	if res.Cursor != "" {
+		t.Fatalf("got cursor %q; files don't page with a cursor", res.Cursor)
+	}
+
+	if _, err := e.Fetch("annual report", Moderate, language.English, language.MustParseRegion("US"), 1, 0); err != nil {
+		t.Fatal(err)
+	}
+
+	for _, not := range []string{`"aggregations"`, `"post_filter"`, `{"exists":{"field":"filetype"}}`} {
+		if strings.Contains(body, not) {
+			t.Fatalf("got %s; want it without %s", body, not)
+		}
+	}
+}
+
+func TestNewFileFilter(t *testing.T) {
+	for _, c := range []struct {
+		typ, size, date string
+		want            FileFilter
+	}{
+		{"csv", "large", "week", FileFilter{"csv", "large", "week"}},
+		{".exe", "huge", "decade", FileFilter{}},
+		{"", "", "", FileFilter{}},
+	} {
+		if got := NewFileFilter(c.typ, c.size, c.date); got != c.want {
+			t.Fatalf("got %+v; want %+v", got, c.want)
+		}
+	}
+}
@@ -0,0 +1,88 @@
+package search
+
+import (
+	"errors"
+	"strings"
+
+	"golang.org/x/text/language"
+)
+
+// FileFetcher searches only the files we extracted the text of, e.g. pdfs and datasets,
+// and counts them by type, size and date for the facets of the files vertical.
+type FileFetcher interface {
+	FetchFiles(q string, s Filter, f FileFilter, lang language.Tag, region language.Region, number int, offset int) (*Results, error)
+}
+
+// ErrNoFiles indicates a searcher that doesn't index files
+var ErrNoFiles = errors.New("files aren't searchable")
+
+// FileFilter narrows the files to a type, size and age. Empty fields don't narrow.
+type FileFilter struct {
+	Type string `json:"type,omitempty"`
+	Size string `json:"size,omitempty"`
+	Date string `json:"date,omitempty"`
+}
+
+// FileSize is a bucket of file sizes
+type FileSize struct {
+	Value string
+	From  int64 // bytes. 0 is unbounded.
+	To    int64
+}
+
+// FileSizes are the size facets
+var FileSizes = []FileSize{
+	{"small", 0, 1 << 20},
+	{"medium", 1 << 20, 10 << 20},
+	{"large", 10 << 20, 0},
+}
+
+// FileDate is how recent a file is
+type FileDate struct {
+	Value string
+	From  string // date math, e.g. "now-1w"
+}
+
+// FileDates are the date facets
+var FileDates = []FileDate{
+	{"day", "now-1d"},
+	{"week", "now-1w"},
+	{"month", "now-1M"},
+	{"year", "now-1y"},
+}
+
+// NewFileFilter drops the values that aren't one of our facets
+func NewFileFilter(typ, size, date string) FileFilter {
+	f := FileFilter{}
+
+	if typ = strings.ToLower(typ); fileTypes[typ] {
+		f.Type = typ
+	}
+
+	for _, s := range FileSizes {
+		if s.Value == size {
+			f.Size = size
+		}
+	}
+
+	for _, d := range FileDates {
+		if d.Value == date {
+			f.Date = date
+		}
+	}
+
+	return f
+}
+
+// Facets count the files matching a query by type, size and date
+type Facets struct {
+	Type []Facet `json:"type"`
+	Size []Facet `json:"size"`
+	Date []Facet `json:"date"`
+}
+
+// Facet is the number of files with a value
+type Facet struct {
+	Value string `json:"value"`
+	Count int64  `json:"count"`
+}
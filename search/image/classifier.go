@@ -0,0 +1,138 @@
+package image
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+// Classifier scores how likely an image is to be NSFW, from 0 to 1, so
+// SafeSearch can filter our own images without a provider's scores.
+type Classifier interface {
+	Classify(i *Image) error
+}
+
+// OpenNSFW is our classifier.py server, which combines Yahoo's open_nsfw
+// with an imagenet model. Along with the score it describes what the image
+// is of and reads its metadata.
+type OpenNSFW struct {
+	HTTPClient *http.Client
+	Host       string
+}
+
+// Classify scores an image and sets its classification, metadata & attributes
+func (o *OpenNSFW) Classify(i *Image) error {
+	resp, err := o.HTTPClient.Get(o.Host + "?image=" + url.QueryEscape(i.ID))
+	if err != nil {
+		return err
+	}
+
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("open_nsfw status: %d", resp.StatusCode)
+	}
+
+	im := &struct {
+		Image
+		DominantColor string `json:"dominant_color"`
+		Transparent   bool   `json:"transparent"`
+	}{}
+
+	if err := json.NewDecoder(resp.Body).Decode(&im); err != nil {
+		return err
+	}
+
+	i.NSFW = roundScore(im.NSFW)
+	i.Width = im.Width
+	i.Height = im.Height
+	i.MIME = im.MIME
+	i.EXIF = im.EXIF
+	i.Classification = separateKeys(im.Classification)
+	i.SetAttributes(im.DominantColor, im.Transparent)
+
+	return nil
+}
+
+// Sightengine scores images with Sightengine's nudity model
+// https://sightengine.com/docs/nudity-detection
+type Sightengine struct {
+	HTTPClient *http.Client
+	User       string
+	Secret     string
+}
+
+// SightengineResponse is the response from Sightengine's check endpoint
+type SightengineResponse struct {
+	Status string `json:"status"`
+	Nudity struct {
+		Raw     float64 `json:"raw"`
+		Partial float64 `json:"partial"`
+		Safe    float64 `json:"safe"`
+	} `json:"nudity"`
+	Error struct {
+		Message string `json:"message"`
+	} `json:"error"`
+}
+
+// Classify sets the NSFW score of an image as the chance that it isn't safe
+func (s *Sightengine) Classify(i *Image) error {
+	u, err := url.Parse("https://api.sightengine.com/1.0/check.json")
+	if err != nil {
+		return err
+	}
+
+	q := u.Query()
+	q.Set("models", "nudity")
+	q.Set("url", i.ID)
+	q.Set("api_user", s.User)
+	q.Set("api_secret", s.Secret)
+	u.RawQuery = q.Encode()
+
+	resp, err := s.HTTPClient.Get(u.String())
+	if err != nil {
+		return err
+	}
+
+	defer resp.Body.Close()
+
+	sr := &SightengineResponse{}
+	if err := json.NewDecoder(resp.Body).Decode(sr); err != nil {
+		return err
+	}
+
+	if sr.Status != "success" {
+		return fmt.Errorf("Sightengine status: %d %q", resp.StatusCode, sr.Error.Message)
+	}
+
+	i.NSFW = roundScore(1 - sr.Nudity.Safe)
+	return nil
+}
+
+// roundScore keeps 4 decimal places, which is plenty for a threshold
+func roundScore(f float64) float64 {
+	return math.Round(f/.0001) / 10000
+}
+
+// separateKeys turns "punching bag, punch bag" to 2 items
+// In case of duplicate keys we take that with highest value
+func separateKeys(c map[string]float64) map[string]float64 {
+	m := map[string]float64{}
+	for key, val := range c {
+		rounded := math.Round(val/.001) / 1000
+		for _, s := range strings.Split(key, ",") {
+			s = strings.TrimSpace(s)
+			if v, ok := m[s]; ok {
+				if v >= rounded {
+					continue
+				}
+			}
+			m[s] = rounded
+		}
+	}
+
+	return m
+}
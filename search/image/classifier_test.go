@@ -0,0 +1,101 @@
+package image
+
+import (
+	"net/http"
+	"reflect"
+	"testing"
+
+	"github.com/jarcoal/httpmock"
+)
+
+func TestOpenNSFWClassify(t *testing.T) {
+	httpmock.Activate()
+	defer httpmock.DeactivateAndReset()
+
+	u := "http://127.0.0.1:8080?image=https%3A%2F%2Fexample.com%2Fpunch.jpg%3Fs%3D1%26w%3D2"
+	resp := `{"nsfw_score":0.0123456,"width":800,"height":600,"mime":"image/jpeg","copyright":"CC BY 4.0",
+		"classification":{"punching bag, punch bag":0.8123,"punch bag":0.9,"gym":0.0555},
+		"dominant_color":"#ff2010","transparent":false}`
+	httpmock.RegisterResponder("GET", u, httpmock.NewStringResponder(200, resp))
+
+	o := &OpenNSFW{
+		HTTPClient: &http.Client{},
+		Host:       "http://127.0.0.1:8080",
+	}
+
+	got := &Image{ID: "https://example.com/punch.jpg?s=1&w=2"}
+	if err := o.Classify(got); err != nil {
+		t.Fatal(err)
+	}
+
+	want := &Image{
+		ID:     "https://example.com/punch.jpg?s=1&w=2",
+		NSFW:   0.0123,
+		Width:  800,
+		Height: 600,
+		EXIF:   EXIF{Copyright: "CC BY 4.0"},
+		Classification: map[string]float64{
+			"punching bag": 0.812,
+			"punch bag":    0.9,
+			"gym":          0.056,
+		},
+		MIME:    "image/jpeg",
+		Size:    Medium,
+		Aspect:  Wide,
+		Color:   Red,
+		Kind:    Photo,
+		License: CreativeCommons,
+	}
+
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("got %+v; want %+v", got, want)
+	}
+}
+
+func TestSightengineClassify(t *testing.T) {
+	httpmock.Activate()
+	defer httpmock.DeactivateAndReset()
+
+	u := "https://api.sightengine.com/1.0/check.json?api_secret=secret&api_user=user&models=nudity&url=https%3A%2F%2Fexample.com%2Fbeach.jpg"
+
+	for _, c := range []struct {
+		name   string
+		status int
+		resp   string
+		want   float64
+		err    bool
+	}{
+		{
+			name:   "success",
+			status: 200,
+			resp:   `{"status":"success","request":{"id":"req_1"},"nudity":{"raw":0.0512,"partial":0.20018,"safe":0.74862},"media":{"id":"med_1","uri":"https://example.com/beach.jpg"}}`,
+			want:   0.2514,
+		},
+		{
+			name:   "failure",
+			status: 401,
+			resp:   `{"status":"failure","request":{"id":"req_2"},"error":{"type":"authentication_error","code":1,"message":"Incorrect API user or API secret"}}`,
+			err:    true,
+		},
+	} {
+		t.Run(c.name, func(t *testing.T) {
+			httpmock.RegisterResponder("GET", u, httpmock.NewStringResponder(c.status, c.resp))
+
+			s := &Sightengine{
+				HTTPClient: &http.Client{},
+				User:       "user",
+				Secret:     "secret",
+			}
+
+			got := &Image{ID: "https://example.com/beach.jpg"}
+			err := s.Classify(got)
+			if (err != nil) != c.err {
+				t.Fatalf("got error %v; want error: %v", err, c.err)
+			}
+
+			if got.NSFW != c.want {
+				t.Fatalf("got %v; want %v", got.NSFW, c.want)
+			}
+		})
+	}
+}
@@ -70,6 +70,8 @@ def nsfw_score(im, caffe_transformer=None, caffe_net=None, output_layers=None):
 def metadata(im):
   d = {}
   d["width"], d["height"] = im.size
+  d["transparent"] = im.mode in ("RGBA", "LA") or "transparency" in im.info
+  d["dominant_color"] = dominant_color(im)
 
   if hasattr(im, "_getexif"):
     info = im._getexif()
@@ -83,6 +85,19 @@ def metadata(im):
   
   return d
 
+def dominant_color(im):
+  """Returns the most common color as hex after shrinking and quantizing the image"""
+  try:
+    small = im.convert('RGB').resize((64, 64))
+    q = small.quantize(colors=8)
+    palette = q.getpalette()
+    count, idx = max(q.getcolors())
+    r, g, b = palette[idx*3:idx*3+3]
+    return '#%02x%02x%02x' % (r, g, b)
+  except Exception, e:
+    print(e)
+    return ''
+
 class NodeLookup(object):
   """Converts integer node ID's to human readable labels."""
   def __init__(self, label_lookup_path=None, uid_lookup_path=None):
@@ -2,15 +2,12 @@ package main
 
 import (
 	"context"
-	"io/ioutil"
-	"math"
+	"fmt"
 	"net/http"
 	"os"
 	"strings"
-	"time"
-
-	"encoding/json"
 	"sync"
+	"time"
 
 	"github.com/jivesearch/jivesearch/config"
 	"github.com/jivesearch/jivesearch/log"
@@ -20,12 +17,12 @@ import (
 )
 
 type conf struct {
-	e       *img.ElasticSearch
-	workers int
-	client  *http.Client
-	host    string
-	since   time.Time
-	ch      chan *img.Image
+	e          *img.ElasticSearch
+	workers    int
+	client     *http.Client
+	classifier img.Classifier
+	since      time.Time
+	ch         chan *img.Image
 }
 
 var c *conf
@@ -60,10 +57,26 @@ func setup(v *viper.Viper) {
 		client: &http.Client{
 			Timeout: 25 * time.Second,
 		},
-		host:  v.GetString("nsfw.host"),
 		since: v.GetTime("nsfw.since"),
 		ch:    make(chan *img.Image),
 	}
+
+	// The following is a pure Go attempt to cut out the Python server altogether
+	// but doesn't seem to work very well:
+	// https://gist.github.com/brentadamson/e601d8a2704e10dcd2d3ea5c31301994
+	switch v.GetString("nsfw.provider") {
+	case "open_nsfw":
+		c.classifier = &img.OpenNSFW{
+			HTTPClient: c.client,
+			Host:       v.GetString("nsfw.host"),
+		}
+	case "sightengine":
+		c.classifier = &img.Sightengine{
+			HTTPClient: c.client,
+			User:       v.GetString("sightengine.user"),
+			Secret:     v.GetString("sightengine.secret"),
+		}
+	}
 }
 
 func main() {
@@ -153,56 +166,37 @@ func main() {
 func (c *conf) fetchImage(i *img.Image) (*img.Image, error) {
 	i.Crawled = time.Now().Format("20060102")
 
-	// The following is a pure Go attempt to cut out the Python server altogether
-	// but doesn't seem to work very well:
-	// https://gist.github.com/brentadamson/e601d8a2704e10dcd2d3ea5c31301994
-	u := c.host + "?image=" + i.ID
-	resp, err := c.client.Get(u)
-	if err != nil {
-		return i, err
-	}
-
-	defer resp.Body.Close()
-
-	if resp.StatusCode == 200 {
-		bdy, err := ioutil.ReadAll(resp.Body)
-		if err != nil {
+	// open_nsfw reads the metadata as it scores the image. Otherwise we read it ourselves.
+	if _, ok := c.classifier.(*img.OpenNSFW); !ok {
+		if err := c.fetchMetadata(i); err != nil {
 			return i, err
 		}
+	}
 
-		im := &img.Image{}
+	if c.classifier == nil {
+		return i, nil
+	}
 
-		if err := json.Unmarshal(bdy, &im); err != nil {
-			return i, err
-		}
+	return i, c.classifier.Classify(i)
+}
 
-		i.NSFW = math.Round(im.NSFW/.0001) / 10000
-		i.Width = im.Width
-		i.Height = im.Height
-		i.MIME = im.MIME
-		i.EXIF = im.EXIF
-		i.Classification = separateKeys(im.Classification)
+// fetchMetadata downloads the image itself for its dimensions, MIME type and EXIF
+func (c *conf) fetchMetadata(i *img.Image) error {
+	resp, err := c.client.Get(i.ID)
+	if err != nil {
+		return err
 	}
 
-	return i, err
-}
+	defer resp.Body.Close()
 
-// separateKeys turns "punching bag, punch bag" to 2 items
-// In case of duplicate keys we take that with highest value
-func separateKeys(c map[string]float64) map[string]float64 {
-	m := map[string]float64{}
-	for key, val := range c {
-		rounded := math.Round(val/.001) / 1000
-		for _, s := range strings.Split(key, ",") {
-			s = strings.TrimSpace(s)
-			if v, ok := m[s]; ok {
-				if v >= rounded {
-					continue
-				}
-			}
-			m[s] = rounded
-		}
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("unable to fetch %v: %v", i.ID, resp.Status)
+	}
+
+	if err := i.SetMetadata(resp.Body); err != nil {
+		return err
 	}
 
-	return m
+	i.SetAttributes("", false)
+	return nil
 }
@@ -4,9 +4,11 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"strings"
 	"time"
 
 	"github.com/jivesearch/jivesearch/log"
+	"github.com/jivesearch/jivesearch/search/paging"
 	"github.com/olivere/elastic"
 )
 
@@ -20,7 +22,29 @@ type ElasticSearch struct {
 }
 
 // Fetch returns image results for a search query
-func (e *ElasticSearch) Fetch(q string, safe bool, number int, offset int) (*Results, error) {
+func (e *ElasticSearch) Fetch(q string, safe bool, f Filter, number int, offset int) (*Results, error) {
+	return e.fetch(q, safe, f, number, fmt.Sprintf(`"from": %d`, offset))
+}
+
+// FetchAfter returns the image results that follow the cursor.
+// The cursor holds the sort values of the last hit (search_after) so
+// Elasticsearch doesn't have to collect and discard every prior page.
+func (e *ElasticSearch) FetchAfter(q string, safe bool, f Filter, number int, cursor string) (*Results, error) {
+	after := []interface{}{}
+	if err := paging.DecodeCursor(cursor, &after); err != nil || len(after) == 0 {
+		return &Results{}, ErrInvalidCursor
+	}
+
+	b, err := json.Marshal(after)
+	if err != nil {
+		return &Results{}, err
+	}
+
+	return e.fetch(q, safe, f, number, fmt.Sprintf(`"search_after": %s`, b))
+}
+
+// fetch runs the image query. position is either a "from" or "search_after" clause.
+func (e *ElasticSearch) fetch(q string, safe bool, f Filter, number int, position string) (*Results, error) {
 	res := &Results{}
 
 	var safeQuery string
@@ -42,7 +66,7 @@ func (e *ElasticSearch) Fetch(q string, safe bool, number int, offset int) (*Res
 								"multi_match": {
 									"query": "%v",
 									"fields": [
-										"alt"
+										"alt", "caption"
 									]
 								}
 							}
@@ -55,7 +79,8 @@ func (e *ElasticSearch) Fetch(q string, safe bool, number int, offset int) (*Res
 									}
 								}
 							}
-						]
+						],
+						"filter": [%v]
 					}
 				},
 				"field_value_factor": {
@@ -67,9 +92,13 @@ func (e *ElasticSearch) Fetch(q string, safe bool, number int, offset int) (*Res
 				"boost_mode": "sum"
 			}
 		},
-		"from": %d,
+		"sort": [
+			{"_score": "desc"},
+			{"_id": "asc"}
+		],
+		%v,
 		"size": %d
-	}`, q, safeQuery, q, offset, number)
+	}`, q, safeQuery, filterQuery(f), q, position, number)
 
 	out, err := e.Client.Search(e.Index).Source(qu).Do(context.TODO())
 	if err != nil {
@@ -90,9 +119,38 @@ func (e *ElasticSearch) Fetch(q string, safe bool, number int, offset int) (*Res
 		res.Images = append(res.Images, img)
 	}
 
+	// a full page means there may be more
+	if l := len(out.Hits.Hits); l > 0 && l == number {
+		res.Cursor, err = paging.EncodeCursor(out.Hits.Hits[l-1].Sort)
+	}
+
 	return res, err
 }
 
+// filterQuery turns the image filters into term queries
+func filterQuery(f Filter) string {
+	terms := []string{}
+
+	for _, t := range []struct {
+		field string
+		value string
+	}{
+		{"size", string(f.Size)},
+		{"aspect", string(f.Aspect)},
+		{"color", string(f.Color)},
+		{"kind", string(f.Kind)},
+		{"license", string(f.License)},
+	} {
+		if t.value == "" {
+			continue
+		}
+
+		terms = append(terms, fmt.Sprintf(`{"term": {%q: %q}}`, t.field, t.value))
+	}
+
+	return strings.Join(terms, ",")
+}
+
 // Upsert updates an image link or inserts it if it doesn't exist
 // NOTE: Elasticsearch has a 512-byte limit on an insert operation.
 // Upsert does not have that limit.
@@ -240,9 +298,31 @@ func (e *ElasticSearch) mapping() string {
 					"alt": {
 						"type": "text"
 					},
+					"caption": {
+						"type": "text"
+					},
+					"page": {
+						"type": "keyword"
+					},
 					"copyright": {
 						"type": "text"
 					},
+					"artist": {
+						"type": "text"
+					},
+					"make": {
+						"type": "keyword"
+					},
+					"model": {
+						"type": "keyword"
+					},
+					"taken": {
+						"type": "date",
+						"format": "basic_date"
+					},
+					"orientation": {
+						"type": "integer"
+					},
 					"mime": {
 						"type": "keyword"
 					},
@@ -252,6 +332,21 @@ func (e *ElasticSearch) mapping() string {
 					"height": {
 						"type": "integer"
 					},
+					"size": {
+						"type": "keyword"
+					},
+					"aspect": {
+						"type": "keyword"
+					},
+					"color": {
+						"type": "keyword"
+					},
+					"kind": {
+						"type": "keyword"
+					},
+					"license": {
+						"type": "keyword"
+					},
 					"nsfw_score": {
 						"type": "scaled_float",
 						"scaling_factor": 100
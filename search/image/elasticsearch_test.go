@@ -2,12 +2,15 @@ package image
 
 import (
 	"context"
+	"io/ioutil"
 	"net/http"
 	"net/http/httptest"
 	"reflect"
+	"strings"
 	"testing"
 	"time"
 
+	"github.com/jivesearch/jivesearch/search/paging"
 	"github.com/olivere/elastic"
 )
 
@@ -21,6 +24,7 @@ func TestFetch(t *testing.T) {
 		name   string
 		query  string
 		safe   bool
+		filter Filter
 		number int
 		offset int
 		status int
@@ -31,6 +35,7 @@ func TestFetch(t *testing.T) {
 			name:   "basic",
 			query:  "Bob Dylan",
 			safe:   true,
+			filter: Filter{Size: Large, Color: Blue},
 			number: 25,
 			offset: 1,
 			status: http.StatusOK,
@@ -129,7 +134,7 @@ func TestFetch(t *testing.T) {
 				t.Fatal(err)
 			}
 
-			got, err := e.Fetch(c.query, c.safe, c.number, c.offset)
+			got, err := e.Fetch(c.query, c.safe, c.filter, c.number, c.offset)
 			if err != c.want.err {
 				t.Fatalf("got err %q; want %q", err, c.want.err)
 			}
@@ -141,6 +146,96 @@ func TestFetch(t *testing.T) {
 	}
 }
 
+func TestFetchAfter(t *testing.T) {
+	cursor, err := paging.EncodeCursor([]interface{}{1.5, "https://example.com/a.jpg"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, c := range []struct {
+		name   string
+		cursor string
+		number int
+		resp   string
+		want   *Results
+		err    error
+	}{
+		{
+			name:   "invalid cursor",
+			cursor: "not a cursor",
+			number: 1,
+			want:   &Results{},
+			err:    ErrInvalidCursor,
+		},
+		{
+			name:   "next page",
+			cursor: cursor,
+			number: 1,
+			resp: `{
+				"took": 2,
+				"timed_out": false,
+				"hits": {
+				  "total": 16077,
+				  "max_score": null,
+				  "hits": [
+					{
+					  "_index": "test-images",
+					  "_type": "image",
+					  "_id": "https://example.com/b.jpg",
+					  "_score": 1.25,
+					  "_source": {
+						"id": "https://example.com/b.jpg",
+						"domain": "example.com"
+					  },
+					  "sort": [1.25, "https://example.com/b.jpg"]
+					}
+				  ]
+				}
+			}`,
+			want: &Results{
+				Count:  16077,
+				Cursor: "WzEuMjUsImh0dHBzOi8vZXhhbXBsZS5jb20vYi5qcGciXQ",
+				Images: []*Image{
+					{
+						ID:     "https://example.com/b.jpg",
+						Domain: "example.com",
+					},
+				},
+			},
+		},
+	} {
+		t.Run(c.name, func(t *testing.T) {
+			var body string
+			ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				b, _ := ioutil.ReadAll(r.Body)
+				body = string(b)
+				if _, err := w.Write([]byte(c.resp)); err != nil {
+					t.Fatal(err)
+				}
+			}))
+			defer ts.Close()
+
+			e, err := MockService(ts.URL)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			got, err := e.FetchAfter("example", true, Filter{}, c.number, c.cursor)
+			if err != c.err {
+				t.Fatalf("got err %q; want %q", err, c.err)
+			}
+
+			if !reflect.DeepEqual(got, c.want) {
+				t.Fatalf("got %+v; want %+v", got, c.want)
+			}
+
+			if c.err == nil && !strings.Contains(body, `"search_after": [1.5,"https://example.com/a.jpg"]`) {
+				t.Fatalf("search_after missing from request %v", body)
+			}
+		})
+	}
+}
+
 func TestUpsert(t *testing.T) {
 	for _, c := range []struct {
 		name   string
@@ -0,0 +1,265 @@
+package image
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+)
+
+// Filter narrows image results by their attributes.
+// An empty value for any field means "any".
+type Filter struct {
+	Size    Size
+	Aspect  Aspect
+	Color   Color
+	Kind    Kind
+	License License
+}
+
+// Size is a bucket of an image's dimensions
+type Size string
+
+// Aspect is a bucket of an image's width to height ratio
+type Aspect string
+
+// Color is the dominant color of an image
+type Color string
+
+// Kind describes what sort of image it is
+type Kind string
+
+// License is the detected license of an image
+type License string
+
+// Image size buckets
+const (
+	Icon   Size = "icon"
+	Small  Size = "small"
+	Medium Size = "medium"
+	Large  Size = "large"
+)
+
+// Image aspect buckets
+const (
+	Tall      Aspect = "tall"
+	Square    Aspect = "square"
+	Wide      Aspect = "wide"
+	Panoramic Aspect = "panoramic"
+)
+
+// Image colors
+const (
+	Red    Color = "red"
+	Orange Color = "orange"
+	Yellow Color = "yellow"
+	Green  Color = "green"
+	Teal   Color = "teal"
+	Blue   Color = "blue"
+	Purple Color = "purple"
+	Pink   Color = "pink"
+	White  Color = "white"
+	Gray   Color = "gray"
+	Black  Color = "black"
+	Brown  Color = "brown"
+)
+
+// Image kinds
+const (
+	Photo       Kind = "photo"
+	ClipArt     Kind = "clipart"
+	GIF         Kind = "gif"
+	Transparent Kind = "transparent"
+)
+
+// Image licenses
+const (
+	PublicDomain      License = "public"
+	CreativeCommons   License = "creativecommons"
+	AllRightsReserved License = "reserved"
+)
+
+// Sizes are the valid image size filters
+var Sizes = []Size{Icon, Small, Medium, Large}
+
+// Aspects are the valid image aspect filters
+var Aspects = []Aspect{Tall, Square, Wide, Panoramic}
+
+// Colors are the valid image color filters
+var Colors = []Color{Red, Orange, Yellow, Green, Teal, Blue, Purple, Pink, White, Gray, Black, Brown}
+
+// Kinds are the valid image kind filters
+var Kinds = []Kind{Photo, ClipArt, GIF, Transparent}
+
+// Licenses are the valid image license filters
+var Licenses = []License{PublicDomain, CreativeCommons, AllRightsReserved}
+
+// NewFilter returns a Filter from raw values, ignoring any that are invalid.
+func NewFilter(size, aspect, color, kind, license string) Filter {
+	f := Filter{}
+
+	for _, s := range Sizes {
+		if string(s) == strings.ToLower(size) {
+			f.Size = s
+		}
+	}
+
+	for _, a := range Aspects {
+		if string(a) == strings.ToLower(aspect) {
+			f.Aspect = a
+		}
+	}
+
+	for _, c := range Colors {
+		if string(c) == strings.ToLower(color) {
+			f.Color = c
+		}
+	}
+
+	for _, k := range Kinds {
+		if string(k) == strings.ToLower(kind) {
+			f.Kind = k
+		}
+	}
+
+	for _, l := range Licenses {
+		if string(l) == strings.ToLower(license) {
+			f.License = l
+		}
+	}
+
+	return f
+}
+
+// SetAttributes derives the filterable attributes of an image from its metadata.
+// The dominant color (as hex, e.g. "#ff6600") and transparency come from the classifier.
+func (i *Image) SetAttributes(dominant string, transparent bool) *Image {
+	i.Size = sizeBucket(i.Width, i.Height)
+	i.Aspect = aspectBucket(i.Width, i.Height)
+	i.Kind = kind(i.MIME, transparent)
+	i.License = license(i.EXIF.Copyright)
+
+	if c, err := nearestColor(dominant); err == nil {
+		i.Color = c
+	}
+
+	return i
+}
+
+func sizeBucket(width, height int) Size {
+	mx := width
+	if height > mx {
+		mx = height
+	}
+
+	switch {
+	case mx == 0:
+		return ""
+	case mx <= 64:
+		return Icon
+	case mx < 400:
+		return Small
+	case mx < 1024:
+		return Medium
+	default:
+		return Large
+	}
+}
+
+func aspectBucket(width, height int) Aspect {
+	if width == 0 || height == 0 {
+		return ""
+	}
+
+	ratio := float64(width) / float64(height)
+
+	switch {
+	case ratio < .85:
+		return Tall
+	case ratio <= 1.15:
+		return Square
+	case ratio < 2:
+		return Wide
+	default:
+		return Panoramic
+	}
+}
+
+func kind(mime string, transparent bool) Kind {
+	mime = strings.ToLower(mime)
+
+	switch {
+	case strings.HasSuffix(mime, "gif"):
+		return GIF
+	case transparent:
+		return Transparent
+	case strings.HasSuffix(mime, "jpeg"), strings.HasSuffix(mime, "jpg"):
+		return Photo
+	case strings.HasSuffix(mime, "png"), strings.Contains(mime, "svg"):
+		return ClipArt
+	default:
+		return ""
+	}
+}
+
+// license does a best-effort detection from the copyright notice.
+// No notice at all tells us nothing so we leave it blank.
+func license(copyright string) License {
+	c := strings.ToLower(copyright)
+
+	switch {
+	case c == "":
+		return ""
+	case strings.Contains(c, "public domain"), strings.Contains(c, "cc0"):
+		return PublicDomain
+	case strings.Contains(c, "creative commons"), strings.Contains(c, "cc by"), strings.Contains(c, "cc-by"):
+		return CreativeCommons
+	default:
+		return AllRightsReserved
+	}
+}
+
+var palette = map[Color][3]float64{
+	Red:    {220, 30, 30},
+	Orange: {245, 140, 20},
+	Yellow: {245, 225, 40},
+	Green:  {50, 160, 50},
+	Teal:   {20, 150, 150},
+	Blue:   {30, 80, 220},
+	Purple: {130, 50, 180},
+	Pink:   {240, 130, 190},
+	White:  {250, 250, 250},
+	Gray:   {128, 128, 128},
+	Black:  {10, 10, 10},
+	Brown:  {120, 75, 35},
+}
+
+var errInvalidColor = fmt.Errorf("invalid color")
+
+// nearestColor maps a hex color to the closest color in our palette
+func nearestColor(hex string) (Color, error) {
+	hex = strings.TrimPrefix(strings.TrimSpace(hex), "#")
+	if len(hex) != 6 {
+		return "", errInvalidColor
+	}
+
+	v, err := strconv.ParseUint(hex, 16, 32)
+	if err != nil {
+		return "", errInvalidColor
+	}
+
+	r, g, b := float64(v>>16&0xff), float64(v>>8&0xff), float64(v&0xff)
+
+	var nearest Color
+	min := math.MaxFloat64
+
+	for _, c := range Colors { // ranging over Colors keeps ties deterministic
+		p := palette[c]
+		d := math.Pow(r-p[0], 2) + math.Pow(g-p[1], 2) + math.Pow(b-p[2], 2)
+		if d < min {
+			min, nearest = d, c
+		}
+	}
+
+	return nearest, nil
+}
@@ -0,0 +1,94 @@
+package image
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestNewFilter(t *testing.T) {
+	type args struct {
+		size, aspect, color, kind, license string
+	}
+
+	for _, c := range []struct {
+		name string
+		args
+		want Filter
+	}{
+		{
+			name: "empty",
+			want: Filter{},
+		},
+		{
+			name: "all",
+			args: args{"large", "Wide", "BLUE", "photo", "creativecommons"},
+			want: Filter{Size: Large, Aspect: Wide, Color: Blue, Kind: Photo, License: CreativeCommons},
+		},
+		{
+			name: "invalid",
+			args: args{"huge", "square", "chartreuse", "", "mine"},
+			want: Filter{Aspect: Square},
+		},
+	} {
+		t.Run(c.name, func(t *testing.T) {
+			got := NewFilter(c.size, c.aspect, c.color, c.kind, c.license)
+			if !reflect.DeepEqual(got, c.want) {
+				t.Fatalf("got %+v; want %+v", got, c.want)
+			}
+		})
+	}
+}
+
+func TestSetAttributes(t *testing.T) {
+	type args struct {
+		dominant    string
+		transparent bool
+	}
+
+	for _, c := range []struct {
+		name string
+		img  *Image
+		args
+		want *Image
+	}{
+		{
+			name: "photo",
+			img:  &Image{Width: 1920, Height: 1080, MIME: "image/jpeg", EXIF: EXIF{Copyright: "CC BY-SA 4.0"}},
+			args: args{"#ff6600", false},
+			want: &Image{
+				Width: 1920, Height: 1080, MIME: "image/jpeg", EXIF: EXIF{Copyright: "CC BY-SA 4.0"},
+				Size: Large, Aspect: Wide, Color: Orange, Kind: Photo, License: CreativeCommons,
+			},
+		},
+		{
+			name: "transparent icon",
+			img:  &Image{Width: 32, Height: 32, MIME: "image/png"},
+			args: args{"#0a0a0a", true},
+			want: &Image{
+				Width: 32, Height: 32, MIME: "image/png",
+				Size: Icon, Aspect: Square, Color: Black, Kind: Transparent,
+			},
+		},
+		{
+			name: "gif",
+			img:  &Image{Width: 300, Height: 900, MIME: "gif", EXIF: EXIF{Copyright: "Acme Inc."}},
+			args: args{"bad color", false},
+			want: &Image{
+				Width: 300, Height: 900, MIME: "gif", EXIF: EXIF{Copyright: "Acme Inc."},
+				Size: Medium, Aspect: Tall, Kind: GIF, License: AllRightsReserved,
+			},
+		},
+		{
+			name: "no metadata",
+			img:  &Image{},
+			want: &Image{},
+		},
+	} {
+		t.Run(c.name, func(t *testing.T) {
+			got := c.img.SetAttributes(c.dominant, c.transparent)
+			if !reflect.DeepEqual(got, c.want) {
+				t.Fatalf("got %+v; want %+v", got, c.want)
+			}
+		})
+	}
+}
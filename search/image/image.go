@@ -5,33 +5,49 @@ import (
 	"net/url"
 	"strings"
 
+	"github.com/jivesearch/jivesearch/search/paging"
 	"golang.org/x/net/publicsuffix"
 )
 
 // Image is a link to an image
 type Image struct {
-	ID     string  `json:"id"`
-	Domain string  `json:"domain"`
-	Alt    string  `json:"alt,omitempty"`
-	NSFW   float64 `json:"nsfw_score,omitempty"`
-	Width  int     `json:"width,omitempty"`
-	Height int     `json:"height,omitempty"`
+	ID      string  `json:"id"`
+	Domain  string  `json:"domain"`
+	Alt     string  `json:"alt,omitempty"`
+	Caption string  `json:"caption,omitempty"` // its <figcaption> or title
+	Page    string  `json:"page,omitempty"`    // where we found it
+	NSFW    float64 `json:"nsfw_score,omitempty"`
+	Width   int     `json:"width,omitempty"`
+	Height  int     `json:"height,omitempty"`
 	EXIF
 	Classification map[string]float64 `json:"classification,omitempty"`
 	MIME           string             `json:"mime,omitempty"`
+	Size           Size               `json:"size,omitempty"`
+	Aspect         Aspect             `json:"aspect,omitempty"`
+	Color          Color              `json:"color,omitempty"`
+	Kind           Kind               `json:"kind,omitempty"`
+	License        License            `json:"license,omitempty"`
 	Crawled        string             `json:"crawled,omitempty"`
+	Thumbnail      string             `json:"thumbnail,omitempty"`
 	Base64         string             `json:"base64,omitempty"`
 }
 
 // EXIF is the metadata of an image
-// What other fields do we need???
 type EXIF struct {
-	Copyright string `json:"copyright,omitempty"`
+	Copyright   string `json:"copyright,omitempty"`
+	Artist      string `json:"artist,omitempty"`
+	Make        string `json:"make,omitempty"`
+	Model       string `json:"model,omitempty"`
+	Taken       string `json:"taken,omitempty"`
+	Orientation int    `json:"orientation,omitempty"`
 }
 
-// Fetcher outlines the methods used to retrieve the image results
+// Fetcher outlines the methods used to retrieve the image results.
+// FetchAfter continues from the Cursor of a previous Results, which
+// avoids deep offsets when the user keeps scrolling.
 type Fetcher interface {
-	Fetch(q string, safe bool, number int, offset int) (*Results, error)
+	Fetch(q string, safe bool, f Filter, number int, offset int) (*Results, error)
+	FetchAfter(q string, safe bool, f Filter, number int, cursor string) (*Results, error)
 }
 
 // Provider is an image source
@@ -46,9 +62,22 @@ type Results struct {
 	Next       string   `json:"next"`
 	Last       string   `json:"last"`
 	Pagination []string `json:"pagination"`
+	Cursor     string   `json:"cursor,omitempty"`
 	Images     []*Image `json:"images"`
 }
 
+// Copy is a copy of the results whose images can be changed without changing ours
+func (r *Results) Copy() *Results {
+	c := *r
+	c.Images = make([]*Image, len(r.Images))
+	for i, im := range r.Images {
+		m := *im
+		c.Images[i] = &m
+	}
+
+	return &c
+}
+
 var errInvalidURL = fmt.Errorf("invalid url")
 
 // New creates a new *Image and validates the url
@@ -80,3 +109,6 @@ func (i *Image) SimplifyMIME() *Image {
 
 	return i
 }
+
+// ErrInvalidCursor indicates a cursor that we didn't issue
+var ErrInvalidCursor = paging.ErrInvalidCursor
@@ -0,0 +1,80 @@
+package image
+
+import (
+	"bytes"
+	"image"
+	"io"
+	"io/ioutil"
+	"strings"
+
+	// register the formats image.DecodeConfig understands
+	_ "image/gif"
+	_ "image/jpeg"
+	_ "image/png"
+
+	"github.com/rwcarlsen/goexif/exif"
+	_ "golang.org/x/image/webp"
+)
+
+// maxImage is as much of an image as we read. Larger ones are rarely worth indexing.
+const maxImage = 20 << 20
+
+// SetMetadata sets the dimensions, MIME type and EXIF of an image from its content.
+// This doesn't need the classifier so works for images it can't reach.
+func (i *Image) SetMetadata(r io.Reader) error {
+	b, err := ioutil.ReadAll(io.LimitReader(r, maxImage))
+	if err != nil {
+		return err
+	}
+
+	cfg, format, err := image.DecodeConfig(bytes.NewReader(b))
+	if err != nil {
+		return err
+	}
+
+	i.Width, i.Height = cfg.Width, cfg.Height
+	i.MIME = "image/" + format
+
+	if format == "jpeg" {
+		i.EXIF = decodeEXIF(b)
+	}
+
+	return nil
+}
+
+// decodeEXIF reads the fields we index from the EXIF of a jpeg. Most images
+// don't have any, or have only some, so missing fields are left empty.
+func decodeEXIF(b []byte) EXIF {
+	e := EXIF{}
+
+	// a broken sub-IFD, e.g. the GPS, still leaves us the rest
+	x, err := exif.Decode(bytes.NewReader(b))
+	if err != nil && exif.IsCriticalError(err) {
+		return e
+	}
+
+	for _, f := range []struct {
+		name exif.FieldName
+		val  *string
+	}{
+		{exif.Copyright, &e.Copyright},
+		{exif.Artist, &e.Artist},
+		{exif.Make, &e.Make},
+		{exif.Model, &e.Model},
+	} {
+		if tag, err := x.Get(f.name); err == nil {
+			s, _ := tag.StringVal()
+			*f.val = strings.TrimSpace(strings.TrimRight(s, "\x00"))
+		}
+	}
+
+	if tag, err := x.Get(exif.Orientation); err == nil {
+		e.Orientation, _ = tag.Int(0)
+	}
+
+	if t, err := x.DateTime(); err == nil {
+		e.Taken = t.Format("20060102")
+	}
+
+	return e
+}
@@ -0,0 +1,147 @@
+package image
+
+import (
+	"bytes"
+	"encoding/binary"
+	"image"
+	"image/jpeg"
+	"image/png"
+	"reflect"
+	"testing"
+)
+
+type ifdEntry struct {
+	tag   uint16
+	typ   uint16 // 2 ascii, 3 short, 4 long
+	value interface{}
+}
+
+// mockEXIF is a little-endian tiff with an IFD0 and an Exif sub-IFD for the DateTimeOriginal
+func mockEXIF() []byte {
+	ifd0 := []ifdEntry{
+		{0x010F, 2, "Canon"},
+		{0x0110, 2, "Canon EOS 5D"},
+		{0x0112, 3, uint16(6)},
+		{0x013B, 2, "Jane Doe"},
+		{0x8298, 2, "Copyright 2018 Jane Doe"},
+		{0x8769, 4, uint32(0)}, // Exif sub-IFD pointer, set below
+	}
+	sub := []ifdEntry{
+		{0x9003, 2, "2018:06:01 12:30:00"},
+	}
+
+	size := func(entries []ifdEntry) int { return 2 + 12*len(entries) + 4 }
+	subOffset := 8 + size(ifd0)
+	ifd0[len(ifd0)-1].value = uint32(subOffset)
+	dataOffset := subOffset + size(sub)
+
+	var data bytes.Buffer
+	write := func(b *bytes.Buffer, entries []ifdEntry) {
+		binary.Write(b, binary.LittleEndian, uint16(len(entries)))
+		for _, e := range entries {
+			binary.Write(b, binary.LittleEndian, e.tag)
+			binary.Write(b, binary.LittleEndian, e.typ)
+			switch v := e.value.(type) {
+			case string:
+				s := append([]byte(v), 0)
+				binary.Write(b, binary.LittleEndian, uint32(len(s)))
+				binary.Write(b, binary.LittleEndian, uint32(dataOffset+data.Len()))
+				data.Write(s)
+			case uint16:
+				binary.Write(b, binary.LittleEndian, uint32(1))
+				binary.Write(b, binary.LittleEndian, v)
+				binary.Write(b, binary.LittleEndian, uint16(0))
+			case uint32:
+				binary.Write(b, binary.LittleEndian, uint32(1))
+				binary.Write(b, binary.LittleEndian, v)
+			}
+		}
+		binary.Write(b, binary.LittleEndian, uint32(0)) // no next IFD
+	}
+
+	var t bytes.Buffer
+	t.WriteString("II*\x00")
+	binary.Write(&t, binary.LittleEndian, uint32(8))
+	write(&t, ifd0)
+	write(&t, sub)
+	t.Write(data.Bytes())
+
+	return t.Bytes()
+}
+
+// withEXIF inserts an APP1 segment after the SOI marker of a jpeg
+func withEXIF(jpg, tiff []byte) []byte {
+	payload := append([]byte("Exif\x00\x00"), tiff...)
+
+	var b bytes.Buffer
+	b.Write(jpg[:2])
+	b.Write([]byte{0xFF, 0xE1})
+	binary.Write(&b, binary.BigEndian, uint16(len(payload)+2))
+	b.Write(payload)
+	b.Write(jpg[2:])
+
+	return b.Bytes()
+}
+
+func TestSetMetadata(t *testing.T) {
+	m := image.NewRGBA(image.Rect(0, 0, 64, 48))
+
+	var jpg bytes.Buffer
+	if err := jpeg.Encode(&jpg, m, nil); err != nil {
+		t.Fatal(err)
+	}
+
+	var pn bytes.Buffer
+	if err := png.Encode(&pn, m); err != nil {
+		t.Fatal(err)
+	}
+
+	for _, c := range []struct {
+		name string
+		body []byte
+		want *Image
+	}{
+		{
+			name: "jpeg with exif",
+			body: withEXIF(jpg.Bytes(), mockEXIF()),
+			want: &Image{
+				Width:  64,
+				Height: 48,
+				MIME:   "image/jpeg",
+				EXIF: EXIF{
+					Copyright:   "Copyright 2018 Jane Doe",
+					Artist:      "Jane Doe",
+					Make:        "Canon",
+					Model:       "Canon EOS 5D",
+					Taken:       "20180601",
+					Orientation: 6,
+				},
+			},
+		},
+		{
+			name: "jpeg without exif",
+			body: jpg.Bytes(),
+			want: &Image{Width: 64, Height: 48, MIME: "image/jpeg"},
+		},
+		{
+			name: "png",
+			body: pn.Bytes(),
+			want: &Image{Width: 64, Height: 48, MIME: "image/png"},
+		},
+	} {
+		t.Run(c.name, func(t *testing.T) {
+			got := &Image{}
+			if err := got.SetMetadata(bytes.NewReader(c.body)); err != nil {
+				t.Fatal(err)
+			}
+
+			if !reflect.DeepEqual(got, c.want) {
+				t.Fatalf("got %+v; want %+v", got, c.want)
+			}
+		})
+	}
+
+	if err := (&Image{}).SetMetadata(bytes.NewReader([]byte("<html></html>"))); err != image.ErrFormat {
+		t.Fatalf("got %v; want %v", err, image.ErrFormat)
+	}
+}
@@ -7,6 +7,8 @@ import (
 	"net/http"
 	"net/url"
 	"strconv"
+
+	"github.com/jivesearch/jivesearch/search/paging"
 )
 
 // Pixabay holds settings for the Pixabay image search API
@@ -19,7 +21,7 @@ type Pixabay struct {
 const PixabayProvider Provider = "Pixabay"
 
 // Fetch returns image results for a search query
-func (p *Pixabay) Fetch(query string, safe bool, number int, offset int) (*Results, error) {
+func (p *Pixabay) Fetch(query string, safe bool, f Filter, number int, offset int) (*Results, error) {
 	u, err := url.Parse("https://pixabay.com/api/")
 	if err != nil {
 		return nil, err
@@ -36,6 +38,7 @@ func (p *Pixabay) Fetch(query string, safe bool, number int, offset int) (*Resul
 	q.Set("per_page", strconv.Itoa(number))
 	q.Set("page", strconv.Itoa((offset+number)/number))
 	q.Set("safesearch", safeSearch)
+	pixabayFilters(q, f)
 	u.RawQuery = q.Encode()
 
 	resp, err := p.HTTPClient.Get(u.String())
@@ -66,6 +69,13 @@ func (p *Pixabay) Fetch(query string, safe bool, number int, offset int) (*Resul
 		Count:    pr.Total,
 	}
 
+	// Pixabay only pages so our cursor is simply the next page's offset
+	if next := offset + number; len(pr.Hits) == number && int64(next) < pr.TotalHits {
+		if res.Cursor, err = paging.EncodeCursor(next); err != nil {
+			return nil, err
+		}
+	}
+
 	for _, h := range pr.Hits {
 		img := &Image{
 			ID: h.WebformatURL,
@@ -76,6 +86,53 @@ func (p *Pixabay) Fetch(query string, safe bool, number int, offset int) (*Resul
 	return res, err
 }
 
+// FetchAfter returns the image results that follow the cursor
+func (p *Pixabay) FetchAfter(query string, safe bool, f Filter, number int, cursor string) (*Results, error) {
+	var offset int
+	if err := paging.DecodeCursor(cursor, &offset); err != nil || offset < 0 {
+		return &Results{}, ErrInvalidCursor
+	}
+
+	return p.Fetch(query, safe, f, number, offset)
+}
+
+// pixabayFilters maps our image filters to Pixabay's closest equivalents.
+// Pixabay has no license filter as all of its images are free to use.
+func pixabayFilters(q url.Values, f Filter) {
+	switch f.Size {
+	case Medium:
+		q.Set("min_width", "400")
+	case Large:
+		q.Set("min_width", "1024")
+	}
+
+	switch f.Aspect {
+	case Tall:
+		q.Set("orientation", "vertical")
+	case Wide, Panoramic:
+		q.Set("orientation", "horizontal")
+	}
+
+	switch f.Color {
+	case "":
+	case Teal:
+		q.Set("colors", "turquoise")
+	case Purple:
+		q.Set("colors", "lilac")
+	default:
+		q.Set("colors", string(f.Color))
+	}
+
+	switch f.Kind {
+	case Photo:
+		q.Set("image_type", "photo")
+	case ClipArt:
+		q.Set("image_type", "illustration")
+	case Transparent:
+		q.Set("colors", "transparent")
+	}
+}
+
 // PixabayResponse is the raw API response from Pixabay
 type PixabayResponse struct {
 	TotalHits int64 `json:"totalHits"`
@@ -15,6 +15,7 @@ func TestPixabayFetch(t *testing.T) {
 	type args struct {
 		query  string
 		safe   bool
+		filter Filter
 		number int
 		offset int
 	}
@@ -29,7 +30,7 @@ func TestPixabayFetch(t *testing.T) {
 	}{
 		{
 			name:   "cat",
-			args:   args{"cat", true, 100, 100},
+			args:   args{"cat", true, Filter{}, 100, 100},
 			u:      `https://pixabay.com/api/?key=test&page=2&per_page=100&q=cat&safesearch=true`,
 			status: 200,
 			resp: `{"totalHits":500,"hits":[
@@ -51,6 +52,33 @@ func TestPixabayFetch(t *testing.T) {
 				},
 			},
 		},
+		{
+			name:   "more pages",
+			args:   args{"cat", true, Filter{}, 1, 0},
+			u:      `https://pixabay.com/api/?key=test&page=1&per_page=1&q=cat&safesearch=true`,
+			status: 200,
+			resp:   `{"totalHits":500,"hits":[{"webformatURL":"https://pixabay.com/get/cat_640.jpg"}],"total":4156}`,
+			want: &Results{
+				Provider: PixabayProvider,
+				Count:    4156,
+				Cursor:   "MQ",
+				Images: []*Image{
+					{
+						ID: "https://pixabay.com/get/cat_640.jpg",
+					},
+				},
+			},
+		},
+		{
+			name:   "filtered",
+			args:   args{"dog", false, Filter{Size: Large, Aspect: Wide, Color: Teal, Kind: Photo}, 100, 0},
+			u:      `https://pixabay.com/api/?colors=turquoise&image_type=photo&key=test&min_width=1024&orientation=horizontal&page=1&per_page=100&q=dog&safesearch=false`,
+			status: 200,
+			resp:   `{"totalHits":0,"hits":[],"total":0}`,
+			want: &Results{
+				Provider: PixabayProvider,
+			},
+		},
 	} {
 		t.Run(tt.name, func(t *testing.T) {
 			responder := httpmock.NewStringResponder(tt.status, tt.resp)
@@ -60,7 +88,7 @@ func TestPixabayFetch(t *testing.T) {
 				Key:        "test",
 				HTTPClient: &http.Client{},
 			}
-			got, err := p.Fetch(tt.args.query, tt.args.safe, tt.args.number, tt.args.offset)
+			got, err := p.Fetch(tt.args.query, tt.args.safe, tt.args.filter, tt.args.number, tt.args.offset)
 			if err != nil {
 				t.Fatal(err)
 			}
@@ -0,0 +1,161 @@
+// Package intent guesses what a user is after from their query
+package intent
+
+import (
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
+)
+
+// Intent is a kind of need behind a query
+type Intent string
+
+// Intents we classify
+const (
+	Navigational  Intent = "navigational"  // they want a particular site, e.g. "facebook login"
+	Informational Intent = "informational" // they want to learn something, e.g. "who was jimi hendrix"
+	Transactional Intent = "transactional" // they want to do or buy something, e.g. "cheap flights"
+	Local         Intent = "local"         // they want something nearby, e.g. "pizza near me"
+	Images        Intent = "images"
+	Videos        Intent = "videos"
+	News          Intent = "news"
+)
+
+// Scores is how strongly a query signals each intent, from 0 to 1
+type Scores map[Intent]float64
+
+// Top is the strongest intent. Queries without a clear intent are informational.
+func (s Scores) Top() Intent {
+	intents := []Intent{}
+	for in, score := range s {
+		if score > 0 {
+			intents = append(intents, in)
+		}
+	}
+
+	if len(intents) == 0 {
+		return Informational
+	}
+
+	sort.Slice(intents, func(i, j int) bool {
+		if s[intents[i]] == s[intents[j]] {
+			return intents[i] < intents[j]
+		}
+		return s[intents[i]] > s[intents[j]]
+	})
+
+	return intents[0]
+}
+
+// Classifier scores queries with heuristics, and with a trained model if it has one
+type Classifier struct {
+	Model *Model // optional
+}
+
+// Classify scores a query. Scores is nil if the query shows no intent at all.
+func (c *Classifier) Classify(q string) Scores {
+	words := tokenize(q)
+	if len(words) == 0 {
+		return nil
+	}
+
+	scores := heuristics(q, words)
+
+	if c.Model != nil {
+		for in, score := range c.Model.Predict(words) {
+			if score > scores[in] {
+				scores[in] = score
+			}
+		}
+	}
+
+	for in, score := range scores {
+		if score == 0 {
+			delete(scores, in)
+		}
+	}
+
+	if len(scores) == 0 {
+		return nil
+	}
+
+	return scores
+}
+
+// triggers are words that signal an intent, with how strongly they do so
+var triggers = map[Intent]map[string]float64{
+	Navigational: {
+		"login": 1, "log in": 1, "sign in": 1, "signin": 1, "homepage": .9, "website": .8,
+		"official site": .9, "www": .8, "account": .5,
+	},
+	Informational: {
+		"what": .8, "who": .8, "why": .8, "when": .7, "where": .6, "how": .7, "define": 1,
+		"definition": 1, "meaning": .9, "wiki": .9, "history of": .9, "facts": .7,
+	},
+	Transactional: {
+		"buy": 1, "price": .9, "prices": .9, "cheap": .9, "deal": .8, "deals": .8, "coupon": 1,
+		"coupons": 1, "discount": .9, "order": .7, "for sale": 1, "download": .8, "tickets": .8,
+		"book": .5, "rent": .7, "subscribe": .7,
+	},
+	Local: {
+		"near me": 1, "nearby": 1, "near": .8, "directions": .9, "open now": 1, "restaurants": .6,
+		"delivery": .6, "map": .7, "closest": .8,
+	},
+	Images: {
+		"image": 1, "images": 1, "picture": 1, "pictures": 1, "photo": 1, "photos": 1, "pics": 1,
+		"wallpaper": 1, "wallpapers": 1, "logo": .8, "clipart": .8, "drawing": .6, "diagram": .6,
+	},
+	Videos: {
+		"video": 1, "videos": 1, "trailer": 1, "trailers": 1, "youtube": 1, "clip": .8, "clips": .8,
+		"livestream": .8, "tutorial": .6, "highlights": .6, "how to": .6,
+	},
+	News: {
+		"news": 1, "headlines": 1, "breaking": .8, "latest": .6, "election": .6, "today": .5, "scores": .5,
+	},
+}
+
+// domainRe matches queries that are a bare domain, e.g. "example.com"
+var domainRe = regexp.MustCompile(`^(?:https?://)?(?:www\.)?[a-z0-9-]+(?:\.[a-z0-9-]+)*\.[a-z]{2,}/?$`)
+
+func heuristics(q string, words []string) Scores {
+	padded := " " + strings.Join(words, " ") + " " // so triggers only match whole words
+
+	scores := Scores{}
+	for in, trg := range triggers {
+		for t, score := range trg {
+			if score > scores[in] && strings.Contains(padded, " "+t+" ") {
+				scores[in] = score
+			}
+		}
+	}
+
+	if domainRe.MatchString(strings.ToLower(strings.TrimSpace(q))) {
+		scores[Navigational] = 1
+	}
+
+	// a question mark is a question, whatever the words
+	if strings.HasSuffix(strings.TrimSpace(q), "?") && scores[Informational] < .8 {
+		scores[Informational] = .8
+	}
+
+	// a recent year hints at news
+	yr := now().Year()
+	for _, y := range []int{yr, yr - 1} {
+		if strings.Contains(padded, " "+strconv.Itoa(y)+" ") && scores[News] < .4 {
+			scores[News] = .4
+		}
+	}
+
+	return scores
+}
+
+func tokenize(q string) []string {
+	return strings.FieldsFunc(strings.ToLower(q), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+}
+
+var now = func() time.Time { return time.Now().UTC() }
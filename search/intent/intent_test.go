@@ -0,0 +1,62 @@
+package intent
+
+import (
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestClassify(t *testing.T) {
+	now = func() time.Time {
+		return time.Date(2018, 02, 06, 11, 0, 0, 0, time.UTC)
+	}
+
+	c := &Classifier{}
+
+	for _, tc := range []struct {
+		q    string
+		want Scores
+	}{
+		{"", nil},
+		{"jimi hendrix", nil},
+		{"Jimi Hendrix Photos!", Scores{Images: 1}},
+		{"photosynthesis", nil},
+		{"facebook login", Scores{Navigational: 1}},
+		{"example.com", Scores{Navigational: 1}},
+		{"www.example.com", Scores{Navigational: 1}},
+		{"who was jimi hendrix", Scores{Informational: .8}},
+		{"jimi hendrix guitar?", Scores{Informational: .8}},
+		{"how to tie a tie", Scores{Informational: .7, Videos: .6}},
+		{"cheap flights", Scores{Transactional: .9}},
+		{"pizza near me", Scores{Local: 1}},
+		{"election 2018", Scores{News: .6}},
+		{"world cup 2018", Scores{News: .4}},
+		{"latest news video", Scores{News: 1, Videos: 1}},
+	} {
+		t.Run(tc.q, func(t *testing.T) {
+			got := c.Classify(tc.q)
+			if !reflect.DeepEqual(got, tc.want) {
+				t.Fatalf("got %v; want %v", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestTop(t *testing.T) {
+	for _, c := range []struct {
+		name   string
+		scores Scores
+		want   Intent
+	}{
+		{"none", nil, Informational},
+		{"zero", Scores{Local: 0}, Informational},
+		{"strongest", Scores{Local: .6, Transactional: .9}, Transactional},
+		{"tie", Scores{Videos: 1, News: 1}, News},
+	} {
+		t.Run(c.name, func(t *testing.T) {
+			if got := c.scores.Top(); got != c.want {
+				t.Fatalf("got %v; want %v", got, c.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,45 @@
+package intent
+
+import (
+	"encoding/json"
+	"io"
+	"math"
+)
+
+// Model is a logistic regression per intent over the words and word pairs of a query.
+// It is trained offline on labeled queries and loaded from JSON, e.g.
+//
+//	{"bias": {"local": -2.1}, "weights": {"local": {"pizza": 1.3, "near me": 3.2}}}
+type Model struct {
+	Bias    map[Intent]float64            `json:"bias"`
+	Weights map[Intent]map[string]float64 `json:"weights"`
+}
+
+// LoadModel decodes a trained model
+func LoadModel(r io.Reader) (*Model, error) {
+	m := &Model{}
+	if err := json.NewDecoder(r).Decode(m); err != nil {
+		return nil, err
+	}
+
+	return m, nil
+}
+
+// Predict is the probability of each intent the model knows about
+func (m *Model) Predict(words []string) Scores {
+	features := append([]string{}, words...)
+	for i := 1; i < len(words); i++ {
+		features = append(features, words[i-1]+" "+words[i])
+	}
+
+	scores := Scores{}
+	for in, weights := range m.Weights {
+		z := m.Bias[in]
+		for _, f := range features {
+			z += weights[f]
+		}
+		scores[in] = 1 / (1 + math.Exp(-z))
+	}
+
+	return scores
+}
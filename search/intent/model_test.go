@@ -0,0 +1,40 @@
+package intent
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestModel(t *testing.T) {
+	m, err := LoadModel(strings.NewReader(`{
+		"bias": {"local": -2, "transactional": -3},
+		"weights": {
+			"local": {"pizza": 1.5, "late night": 2},
+			"transactional": {"pizza": 0.5}
+		}
+	}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	c := &Classifier{Model: m}
+
+	got := c.Classify("late night pizza")
+	if got.Top() != Local {
+		t.Fatalf("got %v; want %v", got.Top(), Local)
+	}
+
+	// sigmoid(-2 + 2 + 1.5)
+	if got[Local] < .81 || got[Local] > .82 {
+		t.Fatalf("got %v for local; want about .818", got[Local])
+	}
+
+	// the heuristics win when they are surer than the model
+	if got := c.Classify("pizza near me"); got[Local] != 1 {
+		t.Fatalf("got %v for local; want 1", got[Local])
+	}
+
+	if _, err := LoadModel(strings.NewReader(`not json`)); err == nil {
+		t.Fatal("expected an error for a bad model")
+	}
+}
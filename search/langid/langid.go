@@ -0,0 +1,272 @@
+// Package langid identifies the language of a web page's text so it is indexed,
+// and found, in that language rather than whichever one the page declares
+package langid
+
+import (
+	"bufio"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"unicode"
+
+	"github.com/jivesearch/jivesearch/search/document"
+	"golang.org/x/text/language"
+)
+
+const (
+	profileSize = 300 // the most frequent trigrams of a language that we compare
+	minLetters  = 20  // shorter text is too little to go on
+	// how much closer the text must be to the nearest language than to the next. Otherwise
+	// it is likely in a language we don't know, e.g. Polish is about as near to Lithuanian as to Czech.
+	minMargin = 0.02
+)
+
+// the languages that are the only ones we detect for their script
+var scripts = []struct {
+	*unicode.RangeTable
+	language.Tag
+}{
+	{unicode.Hangul, language.Korean},
+	{unicode.Thai, language.Thai},
+	{unicode.Armenian, language.Armenian},
+	{unicode.Greek, language.Greek},
+	{unicode.Devanagari, language.Hindi},
+}
+
+// the scripts that several of our languages share, told apart by their trigrams
+var shared = []*unicode.RangeTable{unicode.Latin, unicode.Cyrillic, unicode.Arabic}
+
+// Detector identifies languages with a trigram model, comparing how often each
+// sequence of 3 letters appears in a text with how often it does in each language.
+// http://citeseerx.ist.psu.edu/viewdoc/summary?doi=10.1.1.53.9367
+type Detector struct {
+	profiles []profile
+}
+
+type profile struct {
+	language.Tag
+	script *unicode.RangeTable
+	ranks  map[string]int
+}
+
+// New creates a Detector without any languages but those identified by their script
+func New() *Detector {
+	return &Detector{}
+}
+
+// Load trains the Detector with the sample text of each language in a directory, named by its tag, e.g. "de.txt"
+func (d *Detector) Load(dir string) error {
+	files, err := filepath.Glob(filepath.Join(dir, "*.txt"))
+	if err != nil {
+		return err
+	}
+
+	for _, f := range files {
+		tag, err := language.Parse(strings.TrimSuffix(filepath.Base(f), ".txt"))
+		if err != nil {
+			return err
+		}
+
+		r, err := os.Open(f)
+		if err != nil {
+			return err
+		}
+
+		err = d.Train(tag, r)
+		r.Close()
+
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// Train adds a language from a sample of its text. A few hundred words are enough.
+func (d *Detector) Train(tag language.Tag, r io.Reader) error {
+	var sb strings.Builder
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		sb.WriteString(scanner.Text())
+		sb.WriteString(" ")
+	}
+
+	if err := scanner.Err(); err != nil {
+		return err
+	}
+
+	text := sb.String()
+	d.profiles = append(d.profiles, profile{
+		Tag:    tag,
+		script: script(text),
+		ranks:  ranks(text),
+	})
+
+	return nil
+}
+
+// Detect is the language of a text, if there's enough of it to tell
+func (d *Detector) Detect(text string) (language.Tag, bool) {
+	letters, cjk, kana := 0, 0, 0
+	counts := map[*unicode.RangeTable]int{}
+
+	for _, r := range text {
+		if !unicode.IsLetter(r) {
+			continue
+		}
+		letters++
+
+		switch {
+		case unicode.In(r, unicode.Hiragana, unicode.Katakana):
+			kana++
+		case unicode.Is(unicode.Han, r):
+			cjk++
+		}
+
+		for _, s := range scripts {
+			if unicode.Is(s.RangeTable, r) {
+				counts[s.RangeTable]++
+			}
+		}
+	}
+
+	// Chinese and Japanese have few letters per word
+	switch {
+	case kana > 0 && (kana+cjk)*2 > letters:
+		return language.Japanese, true
+	case cjk*2 > letters:
+		return language.Chinese, true
+	case letters < minLetters:
+		return language.Und, false
+	}
+
+	for _, s := range scripts {
+		if counts[s.RangeTable]*2 > letters {
+			return s.Tag, true
+		}
+	}
+
+	sc := script(text)
+	if sc == nil {
+		return language.Und, false
+	}
+
+	tr := ranks(text)
+	best, distance, next := language.Und, -1, -1
+	for _, p := range d.profiles {
+		if p.script != sc {
+			continue
+		}
+
+		switch dist := p.distance(tr); {
+		case distance == -1 || dist < distance:
+			distance, next = dist, distance
+			best = p.Tag
+		case next == -1 || dist < next:
+			next = dist
+		}
+	}
+
+	if best == language.Und || (next > 0 && float64(next-distance)/float64(next) < minMargin) {
+		return language.Und, false
+	}
+
+	return best, true
+}
+
+// Language is the language a document is indexed in. What we detect wins over what the page
+// declares, which is often the default of its template, unless only their regions differ.
+func (d *Detector) Language(doc *document.Document) language.Tag {
+	detected, ok := d.Detect(strings.Join([]string{doc.Title, doc.Description, doc.Keywords}, " "))
+	if !ok {
+		return doc.Language
+	}
+
+	db, _ := detected.Base()
+	if b, _ := doc.Language.Base(); b == db {
+		return doc.Language
+	}
+
+	return detected
+}
+
+// distance is the "out-of-place" measure of how far the ranks of a text's trigrams are from ours
+func (p profile) distance(ranks map[string]int) int {
+	dist := 0
+	for t, r := range ranks {
+		pr, ok := p.ranks[t]
+		switch {
+		case !ok:
+			dist += profileSize
+		case pr > r:
+			dist += pr - r
+		default:
+			dist += r - pr
+		}
+	}
+
+	return dist
+}
+
+// script is the shared script most of the letters of a text are in
+func script(text string) *unicode.RangeTable {
+	counts := map[*unicode.RangeTable]int{}
+	for _, r := range text {
+		for _, s := range shared {
+			if unicode.Is(s, r) {
+				counts[s]++
+			}
+		}
+	}
+
+	var best *unicode.RangeTable
+	for _, s := range shared {
+		if counts[s] > 0 && (best == nil || counts[s] > counts[best]) {
+			best = s
+		}
+	}
+
+	return best
+}
+
+// ranks orders the most frequent trigrams of a text's words, padded with a space on each end
+func ranks(text string) map[string]int {
+	counts := map[string]int{}
+
+	words := strings.FieldsFunc(strings.ToLower(text), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.Is(unicode.Mn, r)
+	})
+
+	for _, w := range words {
+		runes := []rune(" " + w + " ")
+		for i := 0; i+3 <= len(runes); i++ {
+			counts[string(runes[i:i+3])]++
+		}
+	}
+
+	trigrams := make([]string, 0, len(counts))
+	for t := range counts {
+		trigrams = append(trigrams, t)
+	}
+
+	sort.Slice(trigrams, func(i, j int) bool {
+		if counts[trigrams[i]] != counts[trigrams[j]] {
+			return counts[trigrams[i]] > counts[trigrams[j]]
+		}
+		return trigrams[i] < trigrams[j]
+	})
+
+	if len(trigrams) > profileSize {
+		trigrams = trigrams[:profileSize]
+	}
+
+	r := make(map[string]int, len(trigrams))
+	for i, t := range trigrams {
+		r[t] = i
+	}
+
+	return r
+}
@@ -0,0 +1,77 @@
+package langid
+
+import (
+	"testing"
+
+	"github.com/jivesearch/jivesearch/search/document"
+	"golang.org/x/text/language"
+)
+
+func TestDetect(t *testing.T) {
+	d := New()
+	if err := d.Load("corpus"); err != nil {
+		t.Fatal(err)
+	}
+
+	for _, c := range []struct {
+		text string
+		want language.Tag
+		ok   bool
+	}{
+		{"Welcome to our store. We sell the best shoes and clothing for the whole family at low prices.", language.English, true},
+		{"Bienvenido a nuestra tienda. Vendemos los mejores zapatos y ropa para toda la familia a precios bajos.", language.Spanish, true},
+		{"Bienvenue dans notre magasin. Nous vendons les meilleures chaussures et vêtements pour toute la famille.", language.French, true},
+		{"Willkommen in unserem Geschäft. Wir verkaufen die besten Schuhe und Kleidung für die ganze Familie.", language.German, true},
+		{"Benvenuti nel nostro negozio. Vendiamo le migliori scarpe e abbigliamento per tutta la famiglia.", language.Italian, true},
+		{"Bem-vindo à nossa loja. Vendemos os melhores sapatos e roupas para toda a família a preços baixos.", language.Portuguese, true},
+		{"Welkom in onze winkel. Wij verkopen de beste schoenen en kleding voor het hele gezin.", language.Dutch, true},
+		{"Välkommen till vår butik. Vi säljer de bästa skorna och kläderna för hela familjen.", language.Swedish, true},
+		{"Tervetuloa kauppaamme. Myymme parhaat kengät ja vaatteet koko perheelle edullisesti.", language.Finnish, true},
+		{"Witamy w naszym sklepie. Sprzedajemy najlepsze buty i ubrania dla całej rodziny.", language.Und, false}, // Polish
+		{"Home Page", language.Und, false}, // not enough to go on
+		{"Добро пожаловать в наш магазин. Мы продаем лучшую обувь и одежду для всей семьи.", language.Russian, true},
+		{"Добре дошли в нашия магазин. Продаваме най-добрите обувки и дрехи за цялото семейство.", language.Bulgarian, true},
+		{"مرحبا بكم في متجرنا. نبيع أفضل الأحذية والملابس لجميع أفراد الأسرة.", language.Arabic, true},
+		{"به فروشگاه ما خوش آمدید. ما بهترین کفش و لباس را برای همه خانواده می‌فروشیم.", language.Persian, true},
+		{"ようこそ私たちの店へ。家族みんなのための最高の靴と服を販売しています。", language.Japanese, true},
+		{"欢迎来到我们的商店。我们为全家人出售最好的鞋子和衣服。", language.Chinese, true},
+		{"저희 가게에 오신 것을 환영합니다. 온 가족을 위한 최고의 신발과 옷을 판매합니다.", language.Korean, true},
+		{"Καλώς ήρθατε στο κατάστημά μας. Πουλάμε τα καλύτερα παπούτσια για όλη την οικογένεια.", language.Greek, true},
+		{"12345 67890 !!!", language.Und, false},
+	} {
+		t.Run(c.want.String(), func(t *testing.T) {
+			got, ok := d.Detect(c.text)
+			if got != c.want || ok != c.ok {
+				t.Fatalf("got %v, %v; want %v, %v", got, ok, c.want, c.ok)
+			}
+		})
+	}
+}
+
+func TestLanguage(t *testing.T) {
+	d := New()
+	if err := d.Load("corpus"); err != nil {
+		t.Fatal(err)
+	}
+
+	for _, c := range []struct {
+		name     string
+		declared language.Tag
+		title    string
+		want     language.Tag
+	}{
+		{"template default", language.English, "Die besten Rezepte für den Sommer und das ganze Jahr", language.German},
+		{"same language keeps the region", language.BrazilianPortuguese, "As melhores receitas para o verão e para o ano inteiro", language.BrazilianPortuguese},
+		{"too short to tell", language.French, "Accueil", language.French},
+	} {
+		t.Run(c.name, func(t *testing.T) {
+			doc := &document.Document{}
+			doc.Language = c.declared
+			doc.Title = c.title
+
+			if got := d.Language(doc); got != c.want {
+				t.Fatalf("got %v; want %v", got, c.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,105 @@
+// Package local finds businesses and other points of interest near a place
+package local
+
+import (
+	"math"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/jivesearch/jivesearch/instant/maps"
+	"golang.org/x/text/language"
+)
+
+// Fetcher outlines the methods used to retrieve places near a coordinate
+type Fetcher interface {
+	Fetch(q string, near maps.Coordinate, lang language.Tag, number int) (*Results, error)
+}
+
+// Provider is a source of local results
+type Provider string
+
+// Results are the places matching a query, nearest first
+type Results struct {
+	Provider Provider        `json:"provider"`
+	Location string          `json:"location,omitempty"` // e.g. "boston" for "pizza near boston"
+	Near     maps.Coordinate `json:"near"`
+	Places   []*Place        `json:"places"`
+}
+
+// Place is a business or point of interest
+type Place struct {
+	Marker   string `json:"marker"` // label of the map marker, e.g. "A"
+	Name     string `json:"name"`
+	Category string `json:"category,omitempty"`
+	Address  string `json:"address,omitempty"`
+	Hours    string `json:"hours,omitempty"`
+	Phone    string `json:"phone,omitempty"`
+	Website  string `json:"website,omitempty"`
+	maps.Coordinate
+	Distance float64 `json:"distance"` // meters from Near
+}
+
+var nearRe = regexp.MustCompile(`^(?P<what>.+?)\s+(?:near|in|around)\s+(?P<where>.+)$`)
+
+// ParseQuery splits "pizza near boston" into what they want and where.
+// Where is empty if they didn't give a location or asked for "near me".
+func ParseQuery(q string) (what, where string) {
+	q = strings.Join(strings.Fields(strings.ToLower(q)), " ")
+
+	m := nearRe.FindStringSubmatch(q)
+	if m == nil {
+		return strings.TrimSuffix(q, " nearby"), ""
+	}
+
+	what, where = m[1], m[2]
+	if where == "me" {
+		where = ""
+	}
+
+	return what, where
+}
+
+// sortAndLabel orders the places by distance from near, keeps the
+// nearest and labels their markers "A", "B", etc.
+func (r *Results) sortAndLabel(number int) *Results {
+	for _, p := range r.Places {
+		p.Distance = math.Round(distance(r.Near, p.Coordinate))
+	}
+
+	sort.SliceStable(r.Places, func(i, j int) bool {
+		return r.Places[i].Distance < r.Places[j].Distance
+	})
+
+	if len(r.Places) > number {
+		r.Places = r.Places[:number]
+	}
+
+	for i, p := range r.Places {
+		p.Marker = marker(i)
+	}
+
+	return r
+}
+
+func marker(i int) string {
+	if i < 26 {
+		return string(rune('A' + i))
+	}
+	return marker(i/26-1) + marker(i%26)
+}
+
+// distance is the haversine distance in meters
+func distance(a, b maps.Coordinate) float64 {
+	const earthRadius = 6371000
+
+	rad := func(d float64) float64 { return d * math.Pi / 180 }
+
+	dLat := rad(b.Latitude - a.Latitude)
+	dLon := rad(b.Longitude - a.Longitude)
+
+	h := math.Sin(dLat/2)*math.Sin(dLat/2) +
+		math.Cos(rad(a.Latitude))*math.Cos(rad(b.Latitude))*math.Sin(dLon/2)*math.Sin(dLon/2)
+
+	return 2 * earthRadius * math.Asin(math.Sqrt(h))
+}
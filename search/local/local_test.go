@@ -0,0 +1,65 @@
+package local
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/jivesearch/jivesearch/instant/maps"
+)
+
+func TestParseQuery(t *testing.T) {
+	for _, c := range []struct {
+		q     string
+		what  string
+		where string
+	}{
+		{"pizza", "pizza", ""},
+		{"Pizza  near Boston", "pizza", "boston"},
+		{"coffee shops in san francisco, ca", "coffee shops", "san francisco, ca"},
+		{"gas stations near me", "gas stations", ""},
+		{"sushi nearby", "sushi", ""},
+	} {
+		t.Run(c.q, func(t *testing.T) {
+			what, where := ParseQuery(c.q)
+			if what != c.what || where != c.where {
+				t.Fatalf("got %q, %q; want %q, %q", what, where, c.what, c.where)
+			}
+		})
+	}
+}
+
+func TestSortAndLabel(t *testing.T) {
+	r := &Results{
+		Near: maps.Coordinate{Latitude: 42.3601, Longitude: -71.0589},
+		Places: []*Place{
+			{Name: "far", Coordinate: maps.Coordinate{Latitude: 42.4, Longitude: -71.0589}},
+			{Name: "near", Coordinate: maps.Coordinate{Latitude: 42.3611, Longitude: -71.0589}},
+			{Name: "middle", Coordinate: maps.Coordinate{Latitude: 42.37, Longitude: -71.0589}},
+		},
+	}
+
+	got := r.sortAndLabel(2)
+
+	want := &Results{
+		Near: r.Near,
+		Places: []*Place{
+			{Marker: "A", Name: "near", Coordinate: maps.Coordinate{Latitude: 42.3611, Longitude: -71.0589}, Distance: 111},
+			{Marker: "B", Name: "middle", Coordinate: maps.Coordinate{Latitude: 42.37, Longitude: -71.0589}, Distance: 1101},
+		},
+	}
+
+	if !reflect.DeepEqual(got, want) {
+		for _, p := range got.Places {
+			t.Logf("%+v", p)
+		}
+		t.Fatalf("got %+v; want %+v", got, want)
+	}
+}
+
+func TestMarker(t *testing.T) {
+	for i, want := range map[int]string{0: "A", 25: "Z", 26: "AA", 27: "AB"} {
+		if got := marker(i); got != want {
+			t.Fatalf("marker(%d) got %q; want %q", i, got, want)
+		}
+	}
+}
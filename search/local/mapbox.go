@@ -0,0 +1,94 @@
+package local
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"github.com/jivesearch/jivesearch/instant/maps"
+	"golang.org/x/text/language"
+)
+
+// MapBox searches MapBox's points of interest
+type MapBox struct {
+	HTTPClient *http.Client
+	Key        string
+}
+
+// MapBoxProvider is a local results provider
+const MapBoxProvider Provider = "MapBox"
+
+// Fetch returns the places nearest to near matching the query
+func (m *MapBox) Fetch(q string, near maps.Coordinate, lang language.Tag, number int) (*Results, error) {
+	u, err := url.Parse(fmt.Sprintf("https://api.mapbox.com/geocoding/v5/mapbox.places/%v.json", url.PathEscape(q)))
+	if err != nil {
+		return nil, err
+	}
+
+	if number > 10 { // MapBox's max
+		number = 10
+	}
+
+	b, _ := lang.Base()
+
+	v := u.Query()
+	v.Set("access_token", m.Key)
+	v.Set("language", b.String())
+	v.Set("limit", strconv.Itoa(number))
+	v.Set("proximity", fmt.Sprintf("%v,%v", near.Longitude, near.Latitude))
+	v.Set("types", "poi")
+	u.RawQuery = v.Encode()
+
+	resp, err := m.HTTPClient.Get(u.String())
+	if err != nil {
+		return nil, err
+	}
+
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("MapBox status: %v", resp.Status)
+	}
+
+	mr := &struct {
+		Features []struct {
+			Text       string `json:"text"`
+			PlaceName  string `json:"place_name"`
+			Properties struct {
+				Address  string `json:"address"`
+				Category string `json:"category"` // e.g. "pizza, restaurant, food"
+			} `json:"properties"`
+			Center []float64 `json:"center"`
+		} `json:"features"`
+	}{}
+
+	if err := json.NewDecoder(resp.Body).Decode(mr); err != nil {
+		return nil, err
+	}
+
+	res := &Results{
+		Provider: MapBoxProvider,
+		Near:     near,
+		Places:   []*Place{},
+	}
+
+	for _, f := range mr.Features {
+		if len(f.Center) < 2 {
+			continue
+		}
+
+		p := &Place{
+			Name:       f.Text,
+			Category:   strings.TrimSpace(strings.Split(f.Properties.Category, ",")[0]),
+			Address:    strings.TrimPrefix(f.PlaceName, f.Text+", "),
+			Coordinate: maps.Coordinate{Latitude: f.Center[1], Longitude: f.Center[0]},
+		}
+
+		res.Places = append(res.Places, p)
+	}
+
+	return res.sortAndLabel(number), nil
+}
@@ -0,0 +1,48 @@
+package local
+
+import (
+	"net/http"
+	"reflect"
+	"testing"
+
+	"github.com/jarcoal/httpmock"
+	"github.com/jivesearch/jivesearch/instant/maps"
+	"golang.org/x/text/language"
+)
+
+func TestMapBoxFetch(t *testing.T) {
+	httpmock.Activate()
+	defer httpmock.DeactivateAndReset()
+
+	raw := `{"type":"FeatureCollection","query":["pizza"],"features":[{"id":"poi.1","type":"Feature","place_type":["poi"],"text":"Regina Pizzeria","place_name":"Regina Pizzeria, 11 1/2 Thacher St, Boston, Massachusetts 02113, United States","properties":{"address":"11 1/2 Thacher St","category":"pizza, restaurant, food"},"center":[-71.0589,42.3611]}]}`
+
+	httpmock.RegisterResponder("GET", "https://api.mapbox.com/geocoding/v5/mapbox.places/pizza.json?access_token=key&language=en&limit=10&proximity=-71.0589%2C42.3601&types=poi",
+		httpmock.NewStringResponder(200, raw))
+
+	m := &MapBox{HTTPClient: &http.Client{}, Key: "key"}
+	near := maps.Coordinate{Latitude: 42.3601, Longitude: -71.0589}
+
+	got, err := m.Fetch("pizza", near, language.English, 25)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := &Results{
+		Provider: MapBoxProvider,
+		Near:     near,
+		Places: []*Place{
+			{
+				Marker:     "A",
+				Name:       "Regina Pizzeria",
+				Category:   "pizza",
+				Address:    "11 1/2 Thacher St, Boston, Massachusetts 02113, United States",
+				Coordinate: maps.Coordinate{Latitude: 42.3611, Longitude: -71.0589},
+				Distance:   111,
+			},
+		},
+	}
+
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("got %+v; want %+v", got, want)
+	}
+}
@@ -0,0 +1,145 @@
+package local
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"regexp"
+	"strings"
+
+	"github.com/jivesearch/jivesearch/instant/maps"
+	"golang.org/x/text/language"
+)
+
+// Overpass searches OpenStreetMap data with the Overpass API.
+// The public servers are rate limited so you will likely want to run your own.
+type Overpass struct {
+	HTTPClient *http.Client
+	URL        string // e.g. https://overpass-api.de/api/interpreter
+	UserAgent  string
+	Radius     int // meters
+}
+
+// OverpassProvider is a local results provider
+const OverpassProvider Provider = "OpenStreetMap"
+
+// the tags we match the query against
+var overpassKeys = []string{"name", "amenity", "shop", "cuisine", "tourism", "leisure"}
+
+// Fetch returns the places nearest to near matching the query
+func (o *Overpass) Fetch(q string, near maps.Coordinate, lang language.Tag, number int) (*Results, error) {
+	radius := o.Radius
+	if radius <= 0 {
+		radius = 10000
+	}
+
+	// "pizza" should also match cuisine=pizza and "coffee shop" matches "coffee_shop"
+	re := strings.Replace(regexp.QuoteMeta(q), " ", "[ _]", -1)
+
+	// fetch extra since Overpass doesn't sort by distance
+	query := fmt.Sprintf(`[out:json][timeout:10];nwr[~"^(%v)$"~"%v",i](around:%d,%v,%v);out center %d;`,
+		strings.Join(overpassKeys, "|"), overpassEscape(re), radius, near.Latitude, near.Longitude, number*4,
+	)
+
+	u, err := url.Parse(o.URL)
+	if err != nil {
+		return nil, err
+	}
+
+	v := u.Query()
+	v.Set("data", query)
+	u.RawQuery = v.Encode()
+
+	req, err := http.NewRequest("GET", u.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Set("User-Agent", o.UserAgent)
+
+	resp, err := o.HTTPClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("Overpass status: %v", resp.Status)
+	}
+
+	or := &struct {
+		Elements []struct {
+			Lat    float64 `json:"lat"`
+			Lon    float64 `json:"lon"`
+			Center *struct {
+				Lat float64 `json:"lat"`
+				Lon float64 `json:"lon"`
+			} `json:"center"` // ways and relations
+			Tags map[string]string `json:"tags"`
+		} `json:"elements"`
+	}{}
+
+	if err := json.NewDecoder(resp.Body).Decode(or); err != nil {
+		return nil, err
+	}
+
+	res := &Results{
+		Provider: OverpassProvider,
+		Near:     near,
+		Places:   []*Place{},
+	}
+
+	for _, e := range or.Elements {
+		name := e.Tags["name"]
+		if name == "" {
+			continue
+		}
+
+		p := &Place{
+			Name:       name,
+			Category:   overpassCategory(e.Tags),
+			Address:    overpassAddress(e.Tags),
+			Hours:      e.Tags["opening_hours"],
+			Phone:      e.Tags["phone"],
+			Website:    e.Tags["website"],
+			Coordinate: maps.Coordinate{Latitude: e.Lat, Longitude: e.Lon},
+		}
+
+		if e.Center != nil {
+			p.Coordinate = maps.Coordinate{Latitude: e.Center.Lat, Longitude: e.Center.Lon}
+		}
+
+		res.Places = append(res.Places, p)
+	}
+
+	return res.sortAndLabel(number), nil
+}
+
+// overpassEscape escapes a string for use in Overpass QL's double quotes
+func overpassEscape(s string) string {
+	return strings.NewReplacer(`\`, `\\`, `"`, `\"`).Replace(s)
+}
+
+func overpassCategory(tags map[string]string) string {
+	for _, k := range overpassKeys[1:] {
+		if v, ok := tags[k]; ok {
+			return strings.Replace(v, "_", " ", -1)
+		}
+	}
+	return ""
+}
+
+func overpassAddress(tags map[string]string) string {
+	street := strings.TrimSpace(tags["addr:housenumber"] + " " + tags["addr:street"])
+
+	parts := []string{}
+	for _, s := range []string{street, tags["addr:city"], tags["addr:postcode"]} {
+		if s != "" {
+			parts = append(parts, s)
+		}
+	}
+
+	return strings.Join(parts, ", ")
+}
@@ -0,0 +1,68 @@
+package local
+
+import (
+	"net/http"
+	"net/url"
+	"reflect"
+	"testing"
+
+	"github.com/jarcoal/httpmock"
+	"github.com/jivesearch/jivesearch/instant/maps"
+	"golang.org/x/text/language"
+)
+
+func TestOverpassFetch(t *testing.T) {
+	httpmock.Activate()
+	defer httpmock.DeactivateAndReset()
+
+	raw := `{"version":0.6,"elements":[{"type":"way","id":2,"center":{"lat":42.3700,"lon":-71.0589},"tags":{"amenity":"fast_food","cuisine":"pizza","name":"Slice Shop","website":"https://example.com"}},{"type":"node","id":1,"lat":42.3611,"lon":-71.0589,"tags":{"addr:city":"Boston","addr:housenumber":"11","addr:street":"North Square","amenity":"restaurant","cuisine":"pizza","name":"Regina Pizzeria","opening_hours":"Mo-Su 11:00-23:00","phone":"+1 617-227-0765"}},{"type":"node","id":3,"lat":42.3612,"lon":-71.0590,"tags":{"cuisine":"pizza"}}]}`
+
+	u := "https://overpass-api.de/api/interpreter?" + url.Values{
+		"data": {`[out:json][timeout:10];nwr[~"^(name|amenity|shop|cuisine|tourism|leisure)$"~"pizza",i](around:10000,42.3601,-71.0589);out center 40;`},
+	}.Encode()
+
+	httpmock.RegisterResponder("GET", u, httpmock.NewStringResponder(200, raw))
+
+	o := &Overpass{HTTPClient: &http.Client{}, URL: "https://overpass-api.de/api/interpreter", UserAgent: "test"}
+	near := maps.Coordinate{Latitude: 42.3601, Longitude: -71.0589}
+
+	got, err := o.Fetch("pizza", near, language.English, 10)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := &Results{
+		Provider: OverpassProvider,
+		Near:     near,
+		Places: []*Place{
+			{
+				Marker:     "A",
+				Name:       "Regina Pizzeria",
+				Category:   "restaurant",
+				Address:    "11 North Square, Boston",
+				Hours:      "Mo-Su 11:00-23:00",
+				Phone:      "+1 617-227-0765",
+				Coordinate: maps.Coordinate{Latitude: 42.3611, Longitude: -71.0589},
+				Distance:   111,
+			},
+			{
+				Marker:     "B",
+				Name:       "Slice Shop",
+				Category:   "fast food",
+				Website:    "https://example.com",
+				Coordinate: maps.Coordinate{Latitude: 42.3700, Longitude: -71.0589},
+				Distance:   1101,
+			},
+		},
+	}
+
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("got %+v; want %+v", got, want)
+	}
+}
+
+func TestOverpassEscape(t *testing.T) {
+	if got, want := overpassEscape(`joe's "pizza" \o/`), `joe's \"pizza\" \\o/`; got != want {
+		t.Fatalf("got %q; want %q", got, want)
+	}
+}
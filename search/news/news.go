@@ -0,0 +1,32 @@
+// Package news searches for recent news articles
+package news
+
+import (
+	"time"
+
+	"golang.org/x/text/language"
+)
+
+// Fetcher outlines the methods used to retrieve news results
+type Fetcher interface {
+	Fetch(q string, lang language.Tag, number int) (*Results, error)
+}
+
+// Provider is a news source
+type Provider string
+
+// Results are the articles matching a query, newest first
+type Results struct {
+	Provider Provider   `json:"provider"`
+	Articles []*Article `json:"articles"`
+}
+
+// Article is a single news story
+type Article struct {
+	URL         string    `json:"url"`
+	Title       string    `json:"title"`
+	Description string    `json:"description"`
+	Source      string    `json:"source"`
+	Image       string    `json:"image,omitempty"`
+	Published   time.Time `json:"published"`
+}
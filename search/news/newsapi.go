@@ -0,0 +1,87 @@
+package news
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"time"
+
+	"golang.org/x/text/language"
+)
+
+// NewsAPI holds settings for the newsapi.org search API
+type NewsAPI struct {
+	Key        string
+	HTTPClient *http.Client
+}
+
+// NewsAPIProvider is a news provider
+const NewsAPIProvider Provider = "NewsAPI"
+
+// Fetch returns the latest articles for a search query
+func (n *NewsAPI) Fetch(query string, lang language.Tag, number int) (*Results, error) {
+	u, err := url.Parse("https://newsapi.org/v2/everything")
+	if err != nil {
+		return nil, err
+	}
+
+	b, _ := lang.Base()
+
+	q := u.Query()
+	q.Set("apiKey", n.Key)
+	q.Set("q", query)
+	q.Set("language", b.String())
+	q.Set("pageSize", strconv.Itoa(number))
+	q.Set("sortBy", "publishedAt")
+	u.RawQuery = q.Encode()
+
+	resp, err := n.HTTPClient.Get(u.String())
+	if err != nil {
+		return nil, err
+	}
+
+	defer resp.Body.Close()
+
+	nr := &struct {
+		Status   string `json:"status"`
+		Message  string `json:"message"`
+		Articles []struct {
+			Source struct {
+				Name string `json:"name"`
+			} `json:"source"`
+			Title       string    `json:"title"`
+			Description string    `json:"description"`
+			URL         string    `json:"url"`
+			URLToImage  string    `json:"urlToImage"`
+			PublishedAt time.Time `json:"publishedAt"`
+		} `json:"articles"`
+	}{}
+
+	if err := json.NewDecoder(resp.Body).Decode(nr); err != nil {
+		return nil, err
+	}
+
+	if nr.Status != "ok" {
+		return nil, fmt.Errorf("NewsAPI status: %d %q", resp.StatusCode, nr.Message)
+	}
+
+	res := &Results{
+		Provider: NewsAPIProvider,
+		Articles: []*Article{},
+	}
+
+	for _, a := range nr.Articles {
+		res.Articles = append(res.Articles, &Article{
+			URL:         a.URL,
+			Title:       a.Title,
+			Description: a.Description,
+			Source:      a.Source.Name,
+			Image:       a.URLToImage,
+			Published:   a.PublishedAt,
+		})
+	}
+
+	return res, nil
+}
@@ -0,0 +1,98 @@
+package news
+
+import (
+	"net/http"
+	"net/url"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/jarcoal/httpmock"
+	"golang.org/x/text/language"
+)
+
+func TestNewsAPIFetch(t *testing.T) {
+	httpmock.Activate()
+	defer httpmock.DeactivateAndReset()
+
+	for _, c := range []struct {
+		name   string
+		q      string
+		status int
+		resp   string
+		want   *Results
+		err    bool
+	}{
+		{
+			name:   "election",
+			q:      "election",
+			status: 200,
+			resp: `{
+				"status": "ok",
+				"totalResults": 2,
+				"articles": [
+					{
+						"source": {"id": null, "name": "Example News"},
+						"author": "Jane Doe",
+						"title": "Polls close in tight election",
+						"description": "Votes are being counted.",
+						"url": "https://news.example.com/polls-close",
+						"urlToImage": "https://news.example.com/polls.jpg",
+						"publishedAt": "2018-11-06T23:00:00Z"
+					},
+					{
+						"source": {"id": "wire", "name": "The Wire"},
+						"title": "Turnout at record high",
+						"description": "",
+						"url": "https://wire.example.com/turnout",
+						"urlToImage": null,
+						"publishedAt": "2018-11-06T20:30:00Z"
+					}
+				]
+			}`,
+			want: &Results{
+				Provider: NewsAPIProvider,
+				Articles: []*Article{
+					{
+						URL:         "https://news.example.com/polls-close",
+						Title:       "Polls close in tight election",
+						Description: "Votes are being counted.",
+						Source:      "Example News",
+						Image:       "https://news.example.com/polls.jpg",
+						Published:   time.Date(2018, 11, 6, 23, 0, 0, 0, time.UTC),
+					},
+					{
+						URL:       "https://wire.example.com/turnout",
+						Title:     "Turnout at record high",
+						Source:    "The Wire",
+						Published: time.Date(2018, 11, 6, 20, 30, 0, 0, time.UTC),
+					},
+				},
+			},
+		},
+		{
+			name:   "bad key",
+			q:      "bad key",
+			status: 401,
+			resp:   `{"status": "error", "code": "apiKeyInvalid", "message": "Your API key is invalid or incorrect."}`,
+			err:    true,
+		},
+	} {
+		t.Run(c.name, func(t *testing.T) {
+			u := "https://newsapi.org/v2/everything?apiKey=key&language=en&pageSize=2&q=" + url.QueryEscape(c.q) + "&sortBy=publishedAt"
+			httpmock.RegisterResponder("GET", u, httpmock.NewStringResponder(c.status, c.resp))
+
+			n := &NewsAPI{Key: "key", HTTPClient: &http.Client{}}
+			got, err := n.Fetch(c.q, language.English, 2)
+			if (err != nil) != c.err {
+				t.Fatalf("got err %v; want err %v", err, c.err)
+			}
+
+			if !reflect.DeepEqual(got, c.want) {
+				t.Fatalf("got %+v; want %+v", got, c.want)
+			}
+		})
+	}
+
+	httpmock.Reset()
+}
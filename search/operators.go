@@ -0,0 +1,87 @@
+package search
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// Operator is part of our query syntax
+type Operator struct {
+	Syntax      string `json:"syntax"`
+	Example     string `json:"example"`
+	Description string `json:"description"`
+}
+
+// Operators are the operators we support, for the "search operators" instant answer
+var Operators = []Operator{
+	{`site:`, `jimi hendrix site:wikipedia.org`, "Only results from a site. Use it more than once for any of several sites."},
+	{`filetype:`, `guitar tabs filetype:pdf`, "Only documents of a type: pdf, docx, pptx, epub, csv or tsv."},
+	{`OR`, `hendrix OR clapton`, "Results with any of the words rather than most of them. It must be in capitals."},
+	{`"..."`, `"purple haze"`, "Results with the words together in that order come first."},
+	{`!bang`, `!w jimi hendrix`, "Search another site, e.g. !w for Wikipedia or !g for Google."},
+	{`!!`, `!! jimi hendrix`, "Go straight to the first result."},
+}
+
+// fileTypes are the documents we extract the text of
+var fileTypes = map[string]bool{"pdf": true, "docx": true, "pptx": true, "epub": true, "csv": true, "tsv": true}
+
+// QueryError is a malformed operator in a query. We still search but tell the user
+// why their results may not be what they expected.
+type QueryError struct {
+	Term string `json:"term"`
+	Hint string `json:"hint"`
+}
+
+func (e QueryError) Error() string {
+	return fmt.Sprintf("%v: %v", e.Term, e.Hint)
+}
+
+// prefix is what looks like an operator, e.g. "intitle:hendrix". Anything with a
+// digit or symbol before the colon, like a time or "c++:", is left alone.
+var prefix = regexp.MustCompile(`^([a-zA-Z]{2,}):(.*)$`)
+
+// schemes are urls rather than operators
+var schemes = map[string]bool{"http": true, "https": true, "ftp": true, "mailto": true}
+
+// Validate lists the malformed operators in a query: unbalanced quotes, operators
+// without a value and prefixes like "intitle:" that aren't operators of ours.
+func Validate(q string) []QueryError {
+	errs := []QueryError{}
+
+	if strings.Count(q, `"`)%2 != 0 {
+		errs = append(errs, QueryError{Term: `"`, Hint: "A quote isn't closed so the words were searched for separately"})
+	}
+
+	terms := strings.Fields(q)
+	for i, t := range terms {
+		if t == "OR" && (i == 0 || i == len(terms)-1) {
+			errs = append(errs, QueryError{Term: t, Hint: "OR needs a word on each side"})
+			continue
+		}
+
+		m := prefix.FindStringSubmatch(t)
+		if m == nil {
+			continue
+		}
+
+		op, v := strings.ToLower(m[1]), strings.ToLower(m[2])
+
+		switch {
+		case schemes[op]:
+		case op == "site" && v == "":
+			errs = append(errs, QueryError{Term: t, Hint: "site: needs a site, e.g. site:wikipedia.org"})
+		case op == "filetype" && !fileTypes[strings.TrimPrefix(v, ".")]:
+			errs = append(errs, QueryError{Term: t, Hint: "filetype: can be pdf, docx, pptx, epub, csv or tsv"})
+		case op == "site", op == "filetype":
+		default:
+			errs = append(errs, QueryError{Term: t, Hint: fmt.Sprintf("%v: isn't an operator we support so it was searched for as a word", op)})
+		}
+	}
+
+	if len(errs) == 0 {
+		return nil
+	}
+
+	return errs
+}
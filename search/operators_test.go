@@ -0,0 +1,38 @@
+package search
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestValidate(t *testing.T) {
+	for _, c := range []struct {
+		q    string
+		want []QueryError
+	}{
+		{`jimi hendrix`, nil},
+		{`"purple haze" hendrix OR clapton site:wikipedia.org filetype:PDF`, nil},
+		{`https://example.com 10:30 c++: re`, nil},
+		{`"purple haze`, []QueryError{{`"`, "A quote isn't closed so the words were searched for separately"}}},
+		{`OR hendrix`, []QueryError{{"OR", "OR needs a word on each side"}}},
+		{`hendrix OR`, []QueryError{{"OR", "OR needs a word on each side"}}},
+		{`hendrix site:`, []QueryError{{"site:", "site: needs a site, e.g. site:wikipedia.org"}}},
+		{`tabs filetype:exe`, []QueryError{{"filetype:exe", "filetype: can be pdf, docx, pptx, epub, csv or tsv"}}},
+		{`tabs filetype:.docx`, nil},
+		{
+			`intitle:hendrix "woodstock inurl:live`,
+			[]QueryError{
+				{`"`, "A quote isn't closed so the words were searched for separately"},
+				{"intitle:hendrix", "intitle: isn't an operator we support so it was searched for as a word"},
+				{"inurl:live", "inurl: isn't an operator we support so it was searched for as a word"},
+			},
+		},
+	} {
+		t.Run(c.q, func(t *testing.T) {
+			got := Validate(c.q)
+			if !reflect.DeepEqual(got, c.want) {
+				t.Fatalf("got %+v; want %+v", got, c.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,36 @@
+// Package paging makes the opaque cursors our providers page with.
+// A cursor is the provider's position, e.g. the sort values of the last hit, as url-safe json.
+package paging
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+)
+
+// ErrInvalidCursor indicates a cursor that we didn't issue
+var ErrInvalidCursor = errors.New("invalid cursor")
+
+// EncodeCursor makes an opaque, url-safe cursor from the provider's position
+func EncodeCursor(v interface{}) (string, error) {
+	b, err := json.Marshal(v)
+	if err != nil {
+		return "", err
+	}
+
+	return base64.RawURLEncoding.EncodeToString(b), nil
+}
+
+// DecodeCursor reverses EncodeCursor
+func DecodeCursor(cursor string, v interface{}) error {
+	b, err := base64.RawURLEncoding.DecodeString(cursor)
+	if err != nil {
+		return ErrInvalidCursor
+	}
+
+	if err := json.Unmarshal(b, v); err != nil {
+		return ErrInvalidCursor
+	}
+
+	return nil
+}
@@ -0,0 +1,28 @@
+package paging
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestCursor(t *testing.T) {
+	c, err := EncodeCursor([]interface{}{5.68, "https://example.com"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	got := []interface{}{}
+	if err := DecodeCursor(c, &got); err != nil {
+		t.Fatal(err)
+	}
+
+	if want := []interface{}{5.68, "https://example.com"}; !reflect.DeepEqual(got, want) {
+		t.Fatalf("got %+v; want %+v", got, want)
+	}
+
+	for _, bad := range []string{"not a cursor!", "bm90IGpzb24"} { // the second is "not json"
+		if err := DecodeCursor(bad, &got); err != ErrInvalidCursor {
+			t.Fatalf("got err %v for %q; want %v", err, bad, ErrInvalidCursor)
+		}
+	}
+}
@@ -0,0 +1,232 @@
+package search
+
+import (
+	"strings"
+	"sync"
+
+	"github.com/fsnotify/fsnotify"
+	"github.com/jivesearch/jivesearch/log"
+	"github.com/jivesearch/jivesearch/search/document"
+	"github.com/olivere/elastic"
+	"github.com/spf13/viper"
+)
+
+// Ranking tunes how documents in our own index are scored
+type Ranking struct {
+	Boosts    Boosts        `mapstructure:"boosts"`
+	BM25      document.BM25 `mapstructure:"bm25"` // only applied when an index is created
+	Freshness Freshness     `mapstructure:"freshness"`
+	Authority Authority     `mapstructure:"authority"`
+	Proximity Proximity     `mapstructure:"proximity"`
+}
+
+// Boosts weight a match in one field against a match in another
+type Boosts struct {
+	Title   float64 `mapstructure:"title"`
+	Anchors float64 `mapstructure:"anchors"` // the text of links pointing to the page
+	Body    float64 `mapstructure:"body"`
+	Domain  float64 `mapstructure:"domain"`
+	Path    float64 `mapstructure:"path"`
+}
+
+// Freshness favors recently crawled pages with a gaussian decay.
+// A page Offset old scores fully and one Offset+Scale old scores Decay.
+type Freshness struct {
+	Field  string  `mapstructure:"field"`
+	Scale  string  `mapstructure:"scale"`
+	Offset string  `mapstructure:"offset"`
+	Decay  float64 `mapstructure:"decay"`
+	Weight float64 `mapstructure:"weight"` // 0 turns it off
+}
+
+// Authority blends a page's domain authority into its score
+type Authority struct {
+	Field    string  `mapstructure:"field"`
+	Factor   float64 `mapstructure:"factor"`
+	Modifier string  `mapstructure:"modifier"` // e.g. "log1p", "sqrt"
+	Missing  float64 `mapstructure:"missing"`
+	Weight   float64 `mapstructure:"weight"` // 0 turns it off
+}
+
+// Proximity favors documents with a query's terms near each other over those that merely have them.
+// It only applies to queries of more than one term. A boost of 0 turns that match off.
+type Proximity struct {
+	Shingles float64 `mapstructure:"shingles"` // pairs of the query's adjacent terms
+	Phrase   float64 `mapstructure:"phrase"`   // all the terms together in the query's order
+	Near     float64 `mapstructure:"near"`     // all the terms within Slop moves of the query's order
+	Slop     int     `mapstructure:"slop"`
+}
+
+// DefaultRanking is used when there isn't a ranking config
+var DefaultRanking = Ranking{
+	Boosts: Boosts{
+		Title:   1.5,
+		Anchors: 1.5,
+		Body:    1,
+		Domain:  3,
+		Path:    2,
+	},
+	BM25: document.DefaultBM25,
+	Freshness: Freshness{
+		Field:  "crawled",
+		Scale:  "365d",
+		Offset: "30d",
+		Decay:  0.5,
+	},
+	Authority: Authority{
+		Field:    "authority",
+		Factor:   1,
+		Modifier: "log1p",
+		Missing:  0,
+	},
+	Proximity: Proximity{
+		Shingles: 1,
+		Phrase:   2,
+		Near:     1,
+		Slop:     3,
+	},
+}
+
+// Ranker holds the current Ranking, which is reloaded whenever its config file changes
+type Ranker struct {
+	sync.RWMutex
+	ranking Ranking
+}
+
+// NewRanker reads the ranking config and watches it for changes
+func NewRanker(v *viper.Viper) (*Ranker, error) {
+	r := &Ranker{}
+	if err := r.load(v); err != nil {
+		return nil, err
+	}
+
+	v.OnConfigChange(func(e fsnotify.Event) {
+		if err := r.load(v); err != nil {
+			log.Info.Printf("keeping the old ranking, %v has an error: %v\n", e.Name, err)
+			return
+		}
+		log.Info.Println("reloaded ranking from", e.Name)
+	})
+	v.WatchConfig()
+
+	return r, nil
+}
+
+func (r *Ranker) load(v *viper.Viper) error {
+	rk, err := ReadRanking(v)
+	if err != nil {
+		return err
+	}
+
+	r.Lock()
+	r.ranking = rk
+	r.Unlock()
+	return nil
+}
+
+// ReadRanking reads a ranking config. Anything left out is the DefaultRanking.
+func ReadRanking(v *viper.Viper) (Ranking, error) {
+	rk := DefaultRanking
+
+	if err := v.ReadInConfig(); err != nil {
+		return rk, err
+	}
+
+	err := v.Unmarshal(&rk)
+	return rk, err
+}
+
+// Ranking is the current Ranking
+func (r *Ranker) Ranking() Ranking {
+	if r == nil {
+		return DefaultRanking
+	}
+
+	r.RLock()
+	defer r.RUnlock()
+	return r.ranking
+}
+
+type field struct {
+	name  string
+	boost float64
+}
+
+// fields are the fields searched with their boosts.
+// We search both the standard analyzer and the language-specific one.
+func (rk Ranking) fields() []field {
+	return []field{
+		{"domain", rk.Boosts.Domain},
+		{"path_parts", rk.Boosts.Path},
+		{"title", rk.Boosts.Title},
+		{"title.lang", rk.Boosts.Title},
+		{"anchors", rk.Boosts.Anchors},
+		{"anchors.lang", rk.Boosts.Anchors},
+		{"description", rk.Boosts.Body},
+		{"description.lang", rk.Boosts.Body},
+	}
+}
+
+// proximity are the optional matches that score a query's terms higher the nearer they are to each other.
+// The phrases are matched in the language-specific fields, which ignore stopwords.
+func (rk Ranking) proximity(q string) []elastic.Query {
+	queries := []elastic.Query{}
+	if len(strings.Fields(q)) < 2 {
+		return queries
+	}
+
+	p := rk.Proximity
+	if p.Shingles != 0 {
+		queries = append(queries,
+			elastic.NewMultiMatchQuery(q, "title.shingles", "description.shingles").Type("cross_fields").Boost(p.Shingles),
+		)
+	}
+
+	phrase := func(slop int, boost float64) elastic.Query {
+		return elastic.NewMultiMatchQuery(q).
+			FieldWithBoost("title.lang", rk.Boosts.Title).
+			FieldWithBoost("anchors.lang", rk.Boosts.Anchors).
+			FieldWithBoost("description.lang", rk.Boosts.Body).
+			Type("phrase").Slop(slop).Boost(boost)
+	}
+
+	if p.Phrase != 0 {
+		queries = append(queries, phrase(0, p.Phrase))
+	}
+
+	if p.Near != 0 && p.Slop > 0 {
+		queries = append(queries, phrase(p.Slop, p.Near))
+	}
+
+	return queries
+}
+
+// score blends freshness and domain authority into the relevance of a query:
+// relevance * (1 + freshness weight * decay + authority weight * authority)
+func (rk Ranking) score(qu elastic.Query) elastic.Query {
+	if rk.Freshness.Weight == 0 && rk.Authority.Weight == 0 {
+		return qu
+	}
+
+	fs := elastic.NewFunctionScoreQuery().Query(qu).
+		AddScoreFunc(elastic.NewWeightFactorFunction(1)).
+		ScoreMode("sum").
+		BoostMode("multiply")
+
+	if f := rk.Freshness; f.Weight != 0 {
+		fn := elastic.NewGaussDecayFunction().FieldName(f.Field).Scale(f.Scale).Decay(f.Decay).Weight(f.Weight)
+		if f.Offset != "" {
+			fn = fn.Offset(f.Offset)
+		}
+		fs = fs.AddScoreFunc(fn)
+	}
+
+	if a := rk.Authority; a.Weight != 0 {
+		fs = fs.AddScoreFunc(
+			elastic.NewFieldValueFactorFunction().Field(a.Field).Factor(a.Factor).
+				Modifier(a.Modifier).Missing(a.Missing).Weight(a.Weight),
+		)
+	}
+
+	return fs
+}
@@ -0,0 +1,198 @@
+package search
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/olivere/elastic"
+	"github.com/spf13/afero"
+	"github.com/spf13/viper"
+)
+
+func TestReadRanking(t *testing.T) {
+	fs := afero.NewMemMapFs()
+	cfg := `
+[boosts]
+	title = 4
+	body = 0.5
+
+[bm25]
+	k1 = 1.6
+
+[freshness]
+	scale = "90d"
+	weight = 2
+`
+	if err := afero.WriteFile(fs, "/config/ranking.toml", []byte(cfg), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	v := viper.New()
+	v.SetFs(fs)
+	v.SetConfigType("toml")
+	v.AddConfigPath("/config")
+	v.SetConfigName("ranking")
+
+	got, err := ReadRanking(v)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := DefaultRanking
+	want.Boosts.Title = 4
+	want.Boosts.Body = 0.5
+	want.BM25.K1 = 1.6
+	want.Freshness.Scale = "90d"
+	want.Freshness.Weight = 2
+
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("got %+v; want %+v", got, want)
+	}
+}
+
+func TestRankingScore(t *testing.T) {
+	qu := elastic.NewTermQuery("index", true)
+
+	for _, c := range []struct {
+		name    string
+		ranking func(rk *Ranking)
+		want    string
+	}{
+		{
+			name:    "relevance only",
+			ranking: func(rk *Ranking) {},
+			want:    `{"term":{"index":true}}`,
+		},
+		{
+			name: "freshness",
+			ranking: func(rk *Ranking) {
+				rk.Freshness.Weight = 0.5
+			},
+			want: `{"function_score":{"boost_mode":"multiply","functions":[{"weight":1},{"gauss":{"crawled":{"decay":0.5,"offset":"30d","scale":"365d"}},"weight":0.5}],"query":{"term":{"index":true}},"score_mode":"sum"}}`,
+		},
+		{
+			name: "authority",
+			ranking: func(rk *Ranking) {
+				rk.Authority.Weight = 0.25
+			},
+			want: `{"function_score":{"boost_mode":"multiply","functions":[{"weight":1},{"field_value_factor":{"factor":1,"field":"authority","missing":0,"modifier":"log1p"},"weight":0.25}],"query":{"term":{"index":true}},"score_mode":"sum"}}`,
+		},
+	} {
+		t.Run(c.name, func(t *testing.T) {
+			rk := DefaultRanking
+			c.ranking(&rk)
+
+			src, err := rk.score(qu).Source()
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			got, err := json.Marshal(src)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if string(got) != c.want {
+				t.Fatalf("got %s; want %s", got, c.want)
+			}
+		})
+	}
+}
+
+func TestRankingProximity(t *testing.T) {
+	for _, c := range []struct {
+		name    string
+		q       string
+		ranking func(rk *Ranking)
+		want    string
+	}{
+		{
+			name:    "one term",
+			q:       "golang",
+			ranking: func(rk *Ranking) {},
+			want:    `[]`,
+		},
+		{
+			name:    "default",
+			q:       "golang http client timeout",
+			ranking: func(rk *Ranking) {},
+			want: `[{"multi_match":{"boost":1,"fields":["title.shingles","description.shingles"],"query":"golang http client timeout","tie_breaker":0,"type":"cross_fields"}},` +
+				`{"multi_match":{"boost":2,"fields":["title.lang^1.500000","anchors.lang^1.500000","description.lang^1.000000"],"query":"golang http client timeout","slop":0,"tie_breaker":0,"type":"phrase"}},` +
+				`{"multi_match":{"boost":1,"fields":["title.lang^1.500000","anchors.lang^1.500000","description.lang^1.000000"],"query":"golang http client timeout","slop":3,"tie_breaker":0,"type":"phrase"}}]`,
+		},
+		{
+			name: "phrase only",
+			q:    "golang http client timeout",
+			ranking: func(rk *Ranking) {
+				rk.Proximity = Proximity{Phrase: 3}
+			},
+			want: `[{"multi_match":{"boost":3,"fields":["title.lang^1.500000","anchors.lang^1.500000","description.lang^1.000000"],"query":"golang http client timeout","slop":0,"tie_breaker":0,"type":"phrase"}}]`,
+		},
+	} {
+		t.Run(c.name, func(t *testing.T) {
+			rk := DefaultRanking
+			c.ranking(&rk)
+
+			srcs := []interface{}{}
+			for _, qu := range rk.proximity(c.q) {
+				src, err := qu.Source()
+				if err != nil {
+					t.Fatal(err)
+				}
+				srcs = append(srcs, src)
+			}
+
+			got, err := json.Marshal(srcs)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if string(got) != c.want {
+				t.Fatalf("got %s; want %s", got, c.want)
+			}
+		})
+	}
+}
+
+func TestRankerReload(t *testing.T) {
+	dir, err := ioutil.TempDir("", "ranking")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	f := filepath.Join(dir, "ranking.toml")
+	if err := ioutil.WriteFile(f, []byte("[boosts]\n\ttitle = 2\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	v := viper.New()
+	v.SetConfigType("toml")
+	v.AddConfigPath(dir)
+	v.SetConfigName("ranking")
+
+	r, err := NewRanker(v)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if got := r.Ranking().Boosts.Title; got != 2 {
+		t.Fatalf("got title boost %v; want 2", got)
+	}
+
+	if err := ioutil.WriteFile(f, []byte("[boosts]\n\ttitle = 5\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	for deadline := time.Now().Add(5 * time.Second); r.Ranking().Boosts.Title != 5; {
+		if time.Now().After(deadline) {
+			t.Fatalf("got title boost %v; want 5", r.Ranking().Boosts.Title)
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+}
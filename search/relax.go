@@ -0,0 +1,111 @@
+package search
+
+import (
+	"strings"
+
+	"github.com/jivesearch/jivesearch/log"
+	"golang.org/x/text/language"
+)
+
+// Speller corrects the spelling of a query
+type Speller interface {
+	Correct(q string, lang language.Tag) (string, error)
+}
+
+// Relaxer retries a query that has no results with looser matching so users don't hit a dead end.
+// In order, we drop the quotes, correct the spelling and then match any of the terms.
+// The query that found the results is set in Results.Relaxed.
+type Relaxer struct {
+	Fetcher
+	Speller Speller // optional
+}
+
+// Fetch returns the results of the first query that isn't empty
+func (r *Relaxer) Fetch(q string, s Filter, lang language.Tag, region language.Region, number int, offset int) (*Results, error) {
+	return r.fetch(q, lang, func(q string) (*Results, error) {
+		return r.Fetcher.Fetch(q, s, lang, region, number, offset)
+	})
+}
+
+// FetchAfter is Fetch from a cursor, if our Fetcher takes them
+func (r *Relaxer) FetchAfter(q string, s Filter, lang language.Tag, region language.Region, number int, cursor string) (*Results, error) {
+	af, ok := r.Fetcher.(AfterFetcher)
+	if !ok {
+		return &Results{}, ErrInvalidCursor
+	}
+
+	return r.fetch(q, lang, func(q string) (*Results, error) {
+		return af.FetchAfter(q, s, lang, region, number, cursor)
+	})
+}
+
+// FetchFiles is Fetch restricted to files, if our Fetcher indexes them
+func (r *Relaxer) FetchFiles(q string, s Filter, f FileFilter, lang language.Tag, region language.Region, number int, offset int) (*Results, error) {
+	ff, ok := r.Fetcher.(FileFetcher)
+	if !ok {
+		return &Results{}, ErrNoFiles
+	}
+
+	return r.fetch(q, lang, func(q string) (*Results, error) {
+		return ff.FetchFiles(q, s, f, lang, region, number, offset)
+	})
+}
+
+func (r *Relaxer) fetch(q string, lang language.Tag, fetch func(q string) (*Results, error)) (*Results, error) {
+	res, err := fetch(q)
+	if err != nil || !res.empty() {
+		return res, err
+	}
+
+	for _, relaxed := range r.relax(q, lang) {
+		rr, err := fetch(relaxed)
+		if err != nil {
+			log.Info.Println(err)
+			continue
+		}
+
+		if !rr.empty() {
+			rr.Relaxed = relaxed
+			return rr, nil
+		}
+	}
+
+	return res, nil
+}
+
+// relax lists the looser versions of a query, skipping those that don't change it
+func (r *Relaxer) relax(q string, lang language.Tag) []string {
+	queries := []string{}
+	seen := map[string]bool{q: true}
+
+	add := func(s string) {
+		if s = strings.TrimSpace(s); s != "" && !seen[s] {
+			seen[s] = true
+			queries = append(queries, s)
+		}
+	}
+
+	q = strings.Join(strings.Fields(strings.Replace(q, `"`, " ", -1)), " ")
+	add(q)
+
+	if r.Speller != nil {
+		c, err := r.Speller.Correct(q, lang)
+		switch {
+		case err != nil:
+			log.Info.Println(err)
+		case c != "":
+			q = c
+			add(q)
+		}
+	}
+
+	if terms := strings.Fields(q); len(terms) > 1 {
+		add(strings.Join(terms, " OR "))
+	}
+
+	return queries
+}
+
+func (r *Results) empty() bool {
+	return r == nil || (r.Count == 0 && len(r.Documents) == 0)
+}
@@ -0,0 +1,158 @@
+package search
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/jivesearch/jivesearch/search/document"
+	"golang.org/x/text/language"
+)
+
+func TestRelaxer(t *testing.T) {
+	for _, c := range []struct {
+		name    string
+		q       string
+		speller Speller
+		found   string // the only query with results
+		tried   []string
+		relaxed string
+	}{
+		{
+			name:  "has results",
+			q:     "jimi hendrix",
+			found: "jimi hendrix",
+			tried: []string{"jimi hendrix"},
+		},
+		{
+			name:    "quotes",
+			q:       `"jimi hendrix"`,
+			found:   "jimi hendrix",
+			tried:   []string{`"jimi hendrix"`, "jimi hendrix"},
+			relaxed: "jimi hendrix",
+		},
+		{
+			name:    "spelling",
+			q:       `"jimmy hendrix"`,
+			speller: &mockSpeller{},
+			found:   "jimi hendrix",
+			tried:   []string{`"jimmy hendrix"`, "jimmy hendrix", "jimi hendrix"},
+			relaxed: "jimi hendrix",
+		},
+		{
+			name:    "or",
+			q:       "jimi hendrix guitar",
+			found:   "jimi OR hendrix OR guitar",
+			tried:   []string{"jimi hendrix guitar", "jimi OR hendrix OR guitar"},
+			relaxed: "jimi OR hendrix OR guitar",
+		},
+		{
+			name:  "dead end",
+			q:     "qwertyuiop",
+			found: "something else",
+			tried: []string{"qwertyuiop"},
+		},
+	} {
+		t.Run(c.name, func(t *testing.T) {
+			m := &mockFetcher{found: c.found}
+			r := &Relaxer{Fetcher: m, Speller: c.speller}
+
+			res, err := r.Fetch(c.q, Moderate, language.English, language.MustParseRegion("US"), 25, 0)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if !reflect.DeepEqual(m.tried, c.tried) {
+				t.Fatalf("tried %q; want %q", m.tried, c.tried)
+			}
+
+			if res.Relaxed != c.relaxed {
+				t.Fatalf("got relaxed %q; want %q", res.Relaxed, c.relaxed)
+			}
+		})
+	}
+}
+
+type mockFetcher struct {
+	found string
+	tried []string
+}
+
+func (m *mockFetcher) Fetch(q string, s Filter, lang language.Tag, region language.Region, number int, offset int) (*Results, error) {
+	m.tried = append(m.tried, q)
+
+	if q != m.found {
+		return &Results{}, nil
+	}
+
+	return &Results{
+		Count:     1,
+		Documents: []*document.Document{{ID: "https://www.example.com"}},
+	}, nil
+}
+
+func TestRelaxerFetchAfter(t *testing.T) {
+	r := &Relaxer{Fetcher: &mockFetcher{found: "jimi hendrix"}}
+	if _, err := r.FetchAfter(`"jimi hendrix"`, Moderate, language.English, language.MustParseRegion("US"), 25, "cursor"); err != ErrInvalidCursor {
+		t.Fatalf("got err %v; want %v", err, ErrInvalidCursor)
+	}
+
+	m := &mockAfterFetcher{mockFetcher: &mockFetcher{found: "jimi hendrix"}}
+	r = &Relaxer{Fetcher: m}
+
+	res, err := r.FetchAfter(`"jimi hendrix"`, Moderate, language.English, language.MustParseRegion("US"), 25, "cursor")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if want := []string{`"jimi hendrix"`, "jimi hendrix"}; !reflect.DeepEqual(m.tried, want) {
+		t.Fatalf("tried %q; want %q", m.tried, want)
+	}
+
+	if res.Relaxed != "jimi hendrix" {
+		t.Fatalf("got relaxed %q; want %q", res.Relaxed, "jimi hendrix")
+	}
+}
+
+func TestRelaxerFetchFiles(t *testing.T) {
+	r := &Relaxer{Fetcher: &mockFetcher{found: "jimi hendrix"}}
+	if _, err := r.FetchFiles(`"jimi hendrix"`, Moderate, FileFilter{Type: "pdf"}, language.English, language.MustParseRegion("US"), 25, 0); err != ErrNoFiles {
+		t.Fatalf("got err %v; want %v", err, ErrNoFiles)
+	}
+
+	m := &mockFileFetcher{mockFetcher: &mockFetcher{found: "jimi hendrix"}}
+	r = &Relaxer{Fetcher: m}
+
+	res, err := r.FetchFiles(`"jimi hendrix"`, Moderate, FileFilter{Type: "pdf"}, language.English, language.MustParseRegion("US"), 25, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if res.Relaxed != "jimi hendrix" {
+		t.Fatalf("got relaxed %q; want %q", res.Relaxed, "jimi hendrix")
+	}
+}
+
+type mockFileFetcher struct {
+	*mockFetcher
+}
+
+func (m *mockFileFetcher) FetchFiles(q string, s Filter, f FileFilter, lang language.Tag, region language.Region, number int, offset int) (*Results, error) {
+	return m.Fetch(q, s, lang, region, number, offset)
+}
+
+type mockAfterFetcher struct {
+	*mockFetcher
+}
+
+func (m *mockAfterFetcher) FetchAfter(q string, s Filter, lang language.Tag, region language.Region, number int, cursor string) (*Results, error) {
+	return m.Fetch(q, s, lang, region, number, 25)
+}
+
+type mockSpeller struct{}
+
+func (m *mockSpeller) Correct(q string, lang language.Tag) (string, error) {
+	if q == "jimmy hendrix" {
+		return "jimi hendrix", nil
+	}
+	return "", nil
+}
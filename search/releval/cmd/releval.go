@@ -0,0 +1,180 @@
+// Command releval scores our ranking on a set of judged queries with NDCG, MRR and recall.
+// Given a second ranking file it compares the two and lists the queries whose NDCG changed.
+//
+//	releval ranking.toml [other.toml]
+package main
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/jivesearch/jivesearch/config"
+	"github.com/jivesearch/jivesearch/log"
+	"github.com/jivesearch/jivesearch/search"
+	"github.com/jivesearch/jivesearch/search/document"
+	"github.com/jivesearch/jivesearch/search/releval"
+	"github.com/olivere/elastic"
+	"github.com/spf13/viper"
+	"golang.org/x/text/language"
+)
+
+const usage = "usage: releval ranking.toml [other.toml]"
+
+func setup(v *viper.Viper) {
+	v.SetEnvPrefix("jivesearch")
+	v.AutomaticEnv()
+	v.SetEnvKeyReplacer(strings.NewReplacer(".", "_"))
+	config.SetDefaults(v)
+
+	if v.GetBool("debug") {
+		log.Debug.SetOutput(os.Stdout)
+	}
+}
+
+// run evaluates each ranking file with the searcher newSearcher returns for it
+func run(v *viper.Viper, args []string, w io.Writer, newSearcher func(file string) (releval.Searcher, error)) error {
+	if len(args) == 0 || len(args) > 2 {
+		return fmt.Errorf(usage)
+	}
+
+	queries, qrels, err := judgments(v)
+	if err != nil {
+		return err
+	}
+
+	depth := v.GetInt("releval.depth")
+
+	evals := []*releval.Evaluation{}
+	for _, file := range args {
+		s, err := newSearcher(file)
+		if err != nil {
+			return fmt.Errorf("%v: %v", file, err)
+		}
+
+		e, err := releval.Evaluate(s, queries, qrels, depth)
+		if err != nil {
+			return fmt.Errorf("%v: %v", file, err)
+		}
+
+		evals = append(evals, e)
+	}
+
+	tw := tabwriter.NewWriter(w, 0, 4, 2, ' ', 0)
+	fmt.Fprintf(tw, "ranking\tndcg@%d\tmrr\trecall@%d\tqueries\n", depth, depth)
+	for i, e := range evals {
+		fmt.Fprintf(tw, "%v\t%.4f\t%.4f\t%.4f\t%d\n", args[i], e.Mean.NDCG, e.Mean.MRR, e.Mean.Recall, len(e.Queries))
+	}
+	tw.Flush()
+
+	if skipped := evals[0].Skipped; len(skipped) > 0 {
+		fmt.Fprintf(w, "skipped %d queries without a relevant document: %v\n", len(skipped), strings.Join(skipped, ", "))
+	}
+
+	if len(evals) < 2 {
+		return nil
+	}
+
+	changes := releval.Diff(evals[0], evals[1], queries)
+
+	var wins int
+	for _, c := range changes {
+		if c.Delta() > 0 {
+			wins++
+		}
+	}
+
+	fmt.Fprintf(w, "\n%v vs %v: %d better, %d worse, %d unchanged\n",
+		args[1], args[0], wins, len(changes)-wins, len(evals[0].Queries)-len(changes))
+
+	if len(changes) == 0 {
+		return nil
+	}
+
+	tw = tabwriter.NewWriter(w, 0, 4, 2, ' ', 0)
+	fmt.Fprintln(tw, "\nquery\tbefore\tafter\tchange")
+	for _, c := range changes {
+		fmt.Fprintf(tw, "%v\t%.4f\t%.4f\t%+.4f\n", c.Query.Text, c.Before.NDCG, c.After.NDCG, c.Delta())
+	}
+
+	return tw.Flush()
+}
+
+// judgments reads our queries and their relevance judgments
+func judgments(v *viper.Viper) ([]releval.Query, releval.Qrels, error) {
+	f, err := os.Open(v.GetString("releval.queries"))
+	if err != nil {
+		return nil, nil, err
+	}
+
+	defer f.Close()
+
+	queries, err := releval.ReadQueries(f)
+	if err != nil {
+		return nil, nil, fmt.Errorf("%v: %v", f.Name(), err)
+	}
+
+	g, err := os.Open(v.GetString("releval.qrels"))
+	if err != nil {
+		return nil, nil, err
+	}
+
+	defer g.Close()
+
+	qrels, err := releval.ReadQrels(g)
+	if err != nil {
+		return nil, nil, fmt.Errorf("%v: %v", g.Name(), err)
+	}
+
+	return queries, qrels, nil
+}
+
+func main() {
+	v := viper.New()
+	setup(v)
+
+	client, err := elastic.NewClient(elastic.SetURL(v.GetString("elasticsearch.url")), elastic.SetSniff(false))
+	if err != nil {
+		panic(err)
+	}
+
+	lang, err := language.Parse(v.GetString("releval.lang"))
+	if err != nil {
+		panic(err)
+	}
+
+	region, err := language.ParseRegion(v.GetString("releval.region"))
+	if err != nil {
+		panic(err)
+	}
+
+	newSearcher := func(file string) (releval.Searcher, error) {
+		vr := viper.New()
+		vr.SetConfigFile(file)
+
+		ranker, err := search.NewRanker(vr)
+		if err != nil {
+			return nil, err
+		}
+
+		return &releval.Fetcher{
+			Fetcher: &search.ElasticSearch{
+				ElasticSearch: &document.ElasticSearch{
+					Client: client,
+					Index:  v.GetString("elasticsearch.search.index"),
+					Type:   v.GetString("elasticsearch.search.type"),
+				},
+				Ranker: ranker,
+			},
+			Filter: search.Filter(v.GetString("releval.safe")),
+			Lang:   lang,
+			Region: region,
+		}, nil
+	}
+
+	if err := run(v, os.Args[1:], os.Stdout, newSearcher); err != nil {
+		log.Info.Fatalln(err)
+	}
+}
@@ -0,0 +1,90 @@
+package main
+
+import (
+	"bytes"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/jivesearch/jivesearch/search/releval"
+	"github.com/spf13/viper"
+)
+
+func TestSetup(t *testing.T) {
+	v := viper.New()
+	setup(v)
+
+	if got := v.GetInt("releval.depth"); got != 10 {
+		t.Fatalf("got depth %v; want 10", got)
+	}
+}
+
+type mockSearcher map[string][]string
+
+func (m mockSearcher) Search(q string, n int) ([]string, error) {
+	return m[q], nil
+}
+
+func TestRun(t *testing.T) {
+	dir, err := ioutil.TempDir("", "releval")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	queries := "1\tjimi hendrix\n2\tbob dylan\n3\tunjudged\n"
+	qrels := "1 0 https://www.jimihendrix.com/ 1\n2 0 https://www.bobdylan.com/ 1\n"
+
+	for name, s := range map[string]string{"queries.tsv": queries, "qrels.txt": qrels} {
+		if err := ioutil.WriteFile(filepath.Join(dir, name), []byte(s), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	v := viper.New()
+	setup(v)
+	v.Set("releval.queries", filepath.Join(dir, "queries.tsv"))
+	v.Set("releval.qrels", filepath.Join(dir, "qrels.txt"))
+
+	searchers := map[string]releval.Searcher{
+		"old.toml": mockSearcher{
+			"jimi hendrix": {"https://www.jimihendrix.com/"},
+			"bob dylan":    {"https://example.com/", "https://www.bobdylan.com/"},
+		},
+		"new.toml": mockSearcher{
+			"jimi hendrix": {"https://www.jimihendrix.com/"},
+			"bob dylan":    {"https://www.bobdylan.com/"},
+		},
+	}
+
+	newSearcher := func(file string) (releval.Searcher, error) {
+		return searchers[file], nil
+	}
+
+	for _, args := range [][]string{{}, {"a.toml", "b.toml", "c.toml"}} {
+		if err := run(v, args, &bytes.Buffer{}, newSearcher); err == nil || err.Error() != usage {
+			t.Fatalf("%v: got %v; want %v", args, err, usage)
+		}
+	}
+
+	w := &bytes.Buffer{}
+	if err := run(v, []string{"old.toml", "new.toml"}, w, newSearcher); err != nil {
+		t.Fatal(err)
+	}
+
+	want := `ranking   ndcg@10  mrr     recall@10  queries
+old.toml  0.8155   0.7500  1.0000     2
+new.toml  1.0000   1.0000  1.0000     2
+skipped 1 queries without a relevant document: 3
+
+new.toml vs old.toml: 1 better, 0 worse, 1 unchanged
+
+query      before  after   change
+bob dylan  0.6309  1.0000  +0.3691
+`
+
+	if got := w.String(); got != want {
+		t.Fatalf("got\n%v\nwant\n%v", got, want)
+	}
+}
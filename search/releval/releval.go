@@ -0,0 +1,283 @@
+// Package releval measures how relevant our search results are for a set of queries with
+// relevance judgments, so a change to our ranking can be compared with the one it replaces.
+package releval
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/jivesearch/jivesearch/search"
+	"golang.org/x/text/language"
+)
+
+// Query is one of the queries we evaluate, e.g. a TREC topic
+type Query struct {
+	ID   string
+	Text string
+}
+
+// Qrels are the graded relevance judgments of documents (urls) by query ID.
+// 0 is not relevant and documents that weren't judged count as 0.
+type Qrels map[string]map[string]int
+
+// Searcher returns the urls of the top n results of a query
+type Searcher interface {
+	Search(q string, n int) ([]string, error)
+}
+
+// Fetcher searches with one of our search.Fetchers, e.g. our index with a Ranker
+type Fetcher struct {
+	search.Fetcher
+	Filter search.Filter
+	Lang   language.Tag
+	Region language.Region
+}
+
+// Search returns the urls of the top n results of a query
+func (f *Fetcher) Search(q string, n int) ([]string, error) {
+	res, err := f.Fetch(q, f.Filter, f.Lang, f.Region, n, 0)
+	if err != nil {
+		return nil, err
+	}
+
+	urls := []string{}
+	for _, d := range res.Documents {
+		urls = append(urls, d.ID)
+	}
+
+	return urls, nil
+}
+
+// Scores are how relevant the results of a query are, or the mean of several queries'
+type Scores struct {
+	NDCG   float64 `json:"ndcg"`
+	MRR    float64 `json:"mrr"`
+	Recall float64 `json:"recall"`
+}
+
+// Evaluation is the Scores of each query and their mean
+type Evaluation struct {
+	Depth   int               `json:"depth"` // how many results were scored
+	Queries map[string]Scores `json:"queries"`
+	Mean    Scores            `json:"mean"`
+	Skipped []string          `json:"skipped,omitempty"` // queries without a relevant document
+}
+
+// ReadQueries reads a query per line as its ID and text separated by a tab.
+// Blank lines and those starting with "#" are skipped.
+func ReadQueries(r io.Reader) ([]Query, error) {
+	queries := []Query{}
+
+	err := lines(r, func(n int, line string) error {
+		f := strings.SplitN(line, "\t", 2)
+		if len(f) != 2 || strings.TrimSpace(f[1]) == "" {
+			return fmt.Errorf("line %d: want \"id<tab>query\", got %q", n, line)
+		}
+
+		queries = append(queries, Query{ID: strings.TrimSpace(f[0]), Text: strings.TrimSpace(f[1])})
+		return nil
+	})
+
+	return queries, err
+}
+
+// ReadQrels reads TREC qrels, "query iteration document relevance" per line.
+// The iteration is ignored.
+func ReadQrels(r io.Reader) (Qrels, error) {
+	qrels := Qrels{}
+
+	err := lines(r, func(n int, line string) error {
+		f := strings.Fields(line)
+		if len(f) != 4 {
+			return fmt.Errorf("line %d: want \"query iteration document relevance\", got %q", n, line)
+		}
+
+		rel, err := strconv.Atoi(f[3])
+		if err != nil {
+			return fmt.Errorf("line %d: %v", n, err)
+		}
+
+		if _, ok := qrels[f[0]]; !ok {
+			qrels[f[0]] = map[string]int{}
+		}
+
+		qrels[f[0]][f[2]] = rel
+		return nil
+	})
+
+	return qrels, err
+}
+
+func lines(r io.Reader, fn func(n int, line string) error) error {
+	scanner := bufio.NewScanner(r)
+	for n := 1; scanner.Scan(); n++ {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		if err := fn(n, line); err != nil {
+			return err
+		}
+	}
+
+	return scanner.Err()
+}
+
+// Evaluate searches each query and scores its top depth results against the judgments.
+// As with trec_eval, queries without any relevant documents are skipped.
+func Evaluate(s Searcher, queries []Query, qrels Qrels, depth int) (*Evaluation, error) {
+	e := &Evaluation{
+		Depth:   depth,
+		Queries: map[string]Scores{},
+	}
+
+	for _, q := range queries {
+		judged := qrels[q.ID]
+		if relevant(judged) == 0 {
+			e.Skipped = append(e.Skipped, q.ID)
+			continue
+		}
+
+		ranked, err := s.Search(q.Text, depth)
+		if err != nil {
+			return nil, fmt.Errorf("query %v: %v", q.ID, err)
+		}
+
+		sc := Scores{
+			NDCG:   NDCG(ranked, judged, depth),
+			MRR:    ReciprocalRank(ranked, judged),
+			Recall: Recall(ranked, judged, depth),
+		}
+
+		e.Queries[q.ID] = sc
+		e.Mean.NDCG += sc.NDCG
+		e.Mean.MRR += sc.MRR
+		e.Mean.Recall += sc.Recall
+	}
+
+	if n := float64(len(e.Queries)); n > 0 {
+		e.Mean.NDCG /= n
+		e.Mean.MRR /= n
+		e.Mean.Recall /= n
+	}
+
+	return e, nil
+}
+
+// NDCG is the normalized discounted cumulative gain of the top k results, with a gain
+// of 2^relevance - 1 discounted by the log of the rank. A perfect ranking scores 1.
+func NDCG(ranked []string, judged map[string]int, k int) float64 {
+	gains := []int{}
+	for _, rel := range judged {
+		if rel > 0 {
+			gains = append(gains, rel)
+		}
+	}
+	sort.Sort(sort.Reverse(sort.IntSlice(gains)))
+
+	ideal := dcg(gains, k)
+	if ideal == 0 {
+		return 0
+	}
+
+	got := []int{}
+	for _, u := range ranked {
+		got = append(got, judged[u])
+	}
+
+	return dcg(got, k) / ideal
+}
+
+func dcg(rels []int, k int) float64 {
+	var sum float64
+	for i, rel := range rels {
+		if i == k {
+			break
+		}
+		sum += (math.Pow(2, float64(rel)) - 1) / math.Log2(float64(i+2))
+	}
+
+	return sum
+}
+
+// ReciprocalRank is 1 over the rank of the first relevant result, or 0 if there isn't one
+func ReciprocalRank(ranked []string, judged map[string]int) float64 {
+	for i, u := range ranked {
+		if judged[u] > 0 {
+			return 1 / float64(i+1)
+		}
+	}
+
+	return 0
+}
+
+// Recall is the share of the relevant documents in the top k results
+func Recall(ranked []string, judged map[string]int, k int) float64 {
+	total := relevant(judged)
+	if total == 0 {
+		return 0
+	}
+
+	found := 0
+	for i, u := range ranked {
+		if i == k {
+			break
+		}
+		if judged[u] > 0 {
+			found++
+		}
+	}
+
+	return float64(found) / float64(total)
+}
+
+func relevant(judged map[string]int) int {
+	n := 0
+	for _, rel := range judged {
+		if rel > 0 {
+			n++
+		}
+	}
+
+	return n
+}
+
+// Change is how the Scores of a query changed from one Evaluation to another
+type Change struct {
+	Query  Query
+	Before Scores
+	After  Scores
+}
+
+// Diff is the queries whose NDCG changed between two Evaluations, the largest change first
+func Diff(before, after *Evaluation, queries []Query) []Change {
+	changes := []Change{}
+	for _, q := range queries {
+		b, ok := before.Queries[q.ID]
+		if !ok {
+			continue
+		}
+
+		a := after.Queries[q.ID]
+		if a.NDCG != b.NDCG {
+			changes = append(changes, Change{Query: q, Before: b, After: a})
+		}
+	}
+
+	sort.SliceStable(changes, func(i, j int) bool {
+		return math.Abs(changes[i].Delta()) > math.Abs(changes[j].Delta())
+	})
+
+	return changes
+}
+
+// Delta is how much the NDCG of the query went up, or down if it is negative
+func (c Change) Delta() float64 {
+	return c.After.NDCG - c.Before.NDCG
+}
@@ -0,0 +1,196 @@
+package releval
+
+import (
+	"fmt"
+	"math"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestReadQueries(t *testing.T) {
+	for _, c := range []struct {
+		name string
+		in   string
+		want []Query
+		err  bool
+	}{
+		{
+			name: "queries",
+			in:   "# topics\n1\tjimi hendrix\n\n2\t  bob dylan albums \n",
+			want: []Query{{"1", "jimi hendrix"}, {"2", "bob dylan albums"}},
+		},
+		{
+			name: "no tab",
+			in:   "1 jimi hendrix\n",
+			err:  true,
+		},
+	} {
+		t.Run(c.name, func(t *testing.T) {
+			got, err := ReadQueries(strings.NewReader(c.in))
+			if (err != nil) != c.err {
+				t.Fatalf("got error %v; want error: %v", err, c.err)
+			}
+
+			if !c.err && !reflect.DeepEqual(got, c.want) {
+				t.Fatalf("got %+v; want %+v", got, c.want)
+			}
+		})
+	}
+}
+
+func TestReadQrels(t *testing.T) {
+	for _, c := range []struct {
+		name string
+		in   string
+		want Qrels
+		err  bool
+	}{
+		{
+			name: "qrels",
+			in:   "1 0 https://www.jimihendrix.com/ 2\n1 0 https://en.wikipedia.org/wiki/Jimi_Hendrix 1\n2 0 https://www.bobdylan.com/ 0\n",
+			want: Qrels{
+				"1": {"https://www.jimihendrix.com/": 2, "https://en.wikipedia.org/wiki/Jimi_Hendrix": 1},
+				"2": {"https://www.bobdylan.com/": 0},
+			},
+		},
+		{
+			name: "missing relevance",
+			in:   "1 0 https://www.jimihendrix.com/\n",
+			err:  true,
+		},
+		{
+			name: "bad relevance",
+			in:   "1 0 https://www.jimihendrix.com/ high\n",
+			err:  true,
+		},
+	} {
+		t.Run(c.name, func(t *testing.T) {
+			got, err := ReadQrels(strings.NewReader(c.in))
+			if (err != nil) != c.err {
+				t.Fatalf("got error %v; want error: %v", err, c.err)
+			}
+
+			if !c.err && !reflect.DeepEqual(got, c.want) {
+				t.Fatalf("got %+v; want %+v", got, c.want)
+			}
+		})
+	}
+}
+
+func TestMetrics(t *testing.T) {
+	judged := map[string]int{"a": 3, "b": 2, "c": 0, "d": 1}
+
+	for _, c := range []struct {
+		name   string
+		ranked []string
+		k      int
+		ndcg   float64
+		rr     float64
+		recall float64
+	}{
+		{"perfect", []string{"a", "b", "d"}, 3, 1, 1, 1},
+		{"nothing relevant", []string{"c", "e"}, 3, 0, 0, 0},
+		{"no results", []string{}, 3, 0, 0, 0},
+		{
+			name:   "reversed",
+			ranked: []string{"c", "d", "b", "a"},
+			k:      3,
+			ndcg:   (1/math.Log2(3) + 3/math.Log2(4)) / (7 + 3/math.Log2(3) + 1/math.Log2(4)),
+			rr:     .5,
+			recall: 2. / 3,
+		},
+	} {
+		t.Run(c.name, func(t *testing.T) {
+			if got := NDCG(c.ranked, judged, c.k); math.Abs(got-c.ndcg) > 1e-9 {
+				t.Fatalf("ndcg: got %v; want %v", got, c.ndcg)
+			}
+			if got := ReciprocalRank(c.ranked, judged); got != c.rr {
+				t.Fatalf("reciprocal rank: got %v; want %v", got, c.rr)
+			}
+			if got := Recall(c.ranked, judged, c.k); math.Abs(got-c.recall) > 1e-9 {
+				t.Fatalf("recall: got %v; want %v", got, c.recall)
+			}
+		})
+	}
+}
+
+type mockSearcher map[string][]string
+
+func (m mockSearcher) Search(q string, n int) ([]string, error) {
+	res, ok := m[q]
+	if !ok {
+		return nil, fmt.Errorf("unknown query %q", q)
+	}
+	if len(res) > n {
+		res = res[:n]
+	}
+	return res, nil
+}
+
+func TestEvaluate(t *testing.T) {
+	queries := []Query{{"1", "jimi hendrix"}, {"2", "bob dylan"}, {"3", "unjudged"}}
+	qrels := Qrels{
+		"1": {"https://www.jimihendrix.com/": 1},
+		"2": {"https://www.bobdylan.com/": 1, "https://en.wikipedia.org/wiki/Bob_Dylan": 1},
+		"3": {"https://example.com/": 0},
+	}
+
+	s := mockSearcher{
+		"jimi hendrix": {"https://www.jimihendrix.com/", "https://example.com/"},
+		"bob dylan":    {"https://example.com/", "https://www.bobdylan.com/"},
+	}
+
+	got, err := Evaluate(s, queries, qrels, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := &Evaluation{
+		Depth: 1,
+		Queries: map[string]Scores{
+			"1": {NDCG: 1, MRR: 1, Recall: 1},
+			"2": {NDCG: 0, MRR: 0, Recall: 0}, // only the top result is searched
+		},
+		Mean:    Scores{NDCG: .5, MRR: .5, Recall: .5},
+		Skipped: []string{"3"},
+	}
+
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("got %+v; want %+v", got, want)
+	}
+
+	if _, err := Evaluate(mockSearcher{}, queries, qrels, 1); err == nil {
+		t.Fatal("expected an error when a search fails")
+	}
+}
+
+func TestDiff(t *testing.T) {
+	queries := []Query{{"1", "a"}, {"2", "b"}, {"3", "c"}, {"4", "d"}}
+
+	before := &Evaluation{Queries: map[string]Scores{
+		"1": {NDCG: .5},
+		"2": {NDCG: .9},
+		"3": {NDCG: .2},
+	}}
+	after := &Evaluation{Queries: map[string]Scores{
+		"1": {NDCG: .6},
+		"2": {NDCG: .9},
+		"3": {NDCG: .7},
+		"4": {NDCG: 1},
+	}}
+
+	want := []Change{
+		{Query: Query{"3", "c"}, Before: Scores{NDCG: .2}, After: Scores{NDCG: .7}},
+		{Query: Query{"1", "a"}, Before: Scores{NDCG: .5}, After: Scores{NDCG: .6}},
+	}
+
+	got := Diff(before, after, queries)
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("got %+v; want %+v", got, want)
+	}
+
+	if d := got[0].Delta(); math.Abs(d-.5) > 1e-9 {
+		t.Fatalf("got delta %v; want .5", d)
+	}
+}
@@ -0,0 +1,102 @@
+package scholar
+
+import (
+	"encoding/xml"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// ArXiv searches the preprints on arXiv. It doesn't count citations.
+type ArXiv struct {
+	HTTPClient *http.Client
+	UserAgent  string
+}
+
+// ArXivProvider is a scholar provider
+const ArXivProvider Provider = "arXiv"
+
+// Fetch returns the preprints most relevant to a query
+func (a *ArXiv) Fetch(q string, number int) (*Results, error) {
+	u, err := url.Parse("https://export.arxiv.org/api/query")
+	if err != nil {
+		return nil, err
+	}
+
+	if number > 2000 { // arXiv's max
+		number = 2000
+	}
+
+	v := u.Query()
+	v.Set("search_query", "all:"+q)
+	v.Set("max_results", strconv.Itoa(number))
+	u.RawQuery = v.Encode()
+
+	req, err := http.NewRequest("GET", u.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Set("User-Agent", a.UserAgent)
+
+	resp, err := a.HTTPClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("arXiv status: %v", resp.Status)
+	}
+
+	feed := &struct {
+		Entries []struct {
+			ID        string    `xml:"id"`
+			Title     string    `xml:"title"`
+			Published time.Time `xml:"published"`
+			Authors   []struct {
+				Name string `xml:"name"`
+			} `xml:"author"`
+			DOI     string `xml:"http://arxiv.org/schemas/atom doi"`
+			Journal string `xml:"http://arxiv.org/schemas/atom journal_ref"`
+		} `xml:"entry"`
+	}{}
+
+	if err := xml.NewDecoder(resp.Body).Decode(feed); err != nil {
+		return nil, err
+	}
+
+	res := &Results{
+		Provider: ArXivProvider,
+		Papers:   []*Paper{},
+	}
+
+	for _, e := range feed.Entries {
+		p := &Paper{
+			ID:    e.ID,
+			Title: strings.Join(strings.Fields(e.Title), " "), // titles are wrapped over several lines
+			Venue: e.Journal,
+			DOI:   e.DOI,
+		}
+
+		if p.ID == "" || p.Title == "" {
+			continue
+		}
+
+		if !e.Published.IsZero() {
+			p.Year = e.Published.Year()
+		}
+
+		for _, au := range e.Authors {
+			p.Authors = append(p.Authors, au.Name)
+		}
+
+		res.Papers = append(res.Papers, p)
+	}
+
+	return res, nil
+}
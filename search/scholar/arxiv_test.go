@@ -0,0 +1,57 @@
+package scholar
+
+import (
+	"net/http"
+	"reflect"
+	"testing"
+
+	"github.com/jarcoal/httpmock"
+)
+
+func TestArXivFetch(t *testing.T) {
+	httpmock.Activate()
+	defer httpmock.DeactivateAndReset()
+
+	raw := `<?xml version="1.0" encoding="UTF-8"?>
+<feed xmlns="http://www.w3.org/2005/Atom">
+  <title type="html">ArXiv Query: search_query=all:attention&amp;id_list=&amp;start=0&amp;max_results=10</title>
+  <entry>
+    <id>http://arxiv.org/abs/1706.03762v7</id>
+    <published>2017-06-12T17:57:34Z</published>
+    <title>Attention Is All
+  You Need</title>
+    <author><name>Ashish Vaswani</name></author>
+    <author><name>Noam Shazeer</name></author>
+    <arxiv:doi xmlns:arxiv="http://arxiv.org/schemas/atom">10.48550/arXiv.1706.03762</arxiv:doi>
+    <arxiv:journal_ref xmlns:arxiv="http://arxiv.org/schemas/atom">NeurIPS 2017</arxiv:journal_ref>
+  </entry>
+</feed>`
+
+	httpmock.RegisterResponder("GET", "https://export.arxiv.org/api/query?max_results=10&search_query=all%3Aattention",
+		httpmock.NewStringResponder(200, raw))
+
+	a := &ArXiv{HTTPClient: &http.Client{}}
+
+	got, err := a.Fetch("attention", 10)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := &Results{
+		Provider: ArXivProvider,
+		Papers: []*Paper{
+			{
+				ID:      "http://arxiv.org/abs/1706.03762v7",
+				Title:   "Attention Is All You Need",
+				Authors: []string{"Ashish Vaswani", "Noam Shazeer"},
+				Venue:   "NeurIPS 2017",
+				Year:    2017,
+				DOI:     "10.48550/arXiv.1706.03762",
+			},
+		},
+	}
+
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("got %+v; want %+v", got, want)
+	}
+}
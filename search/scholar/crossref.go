@@ -0,0 +1,120 @@
+package scholar
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+)
+
+// Crossref searches the metadata Crossref has of the works registered with a doi
+type Crossref struct {
+	HTTPClient *http.Client
+	Mailto     string // optional. Crossref serves requests with a contact from a faster pool.
+	UserAgent  string
+}
+
+// CrossrefProvider is a scholar provider
+const CrossrefProvider Provider = "Crossref"
+
+// Fetch returns the works most relevant to a query
+func (c *Crossref) Fetch(q string, number int) (*Results, error) {
+	u, err := url.Parse("https://api.crossref.org/works")
+	if err != nil {
+		return nil, err
+	}
+
+	if number > 1000 { // Crossref's max
+		number = 1000
+	}
+
+	v := u.Query()
+	v.Set("query.bibliographic", q)
+	v.Set("rows", strconv.Itoa(number))
+	v.Set("select", "DOI,title,author,container-title,issued,is-referenced-by-count")
+	if c.Mailto != "" {
+		v.Set("mailto", c.Mailto)
+	}
+	u.RawQuery = v.Encode()
+
+	req, err := http.NewRequest("GET", u.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Set("User-Agent", c.UserAgent)
+
+	resp, err := c.HTTPClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("Crossref status: %v", resp.Status)
+	}
+
+	cr := &struct {
+		Message struct {
+			Items []struct {
+				DOI    string   `json:"DOI"`
+				Title  []string `json:"title"`
+				Author []struct {
+					Given  string `json:"given"`
+					Family string `json:"family"`
+					Name   string `json:"name"` // organizations
+				} `json:"author"`
+				ContainerTitle []string `json:"container-title"`
+				Issued         struct {
+					DateParts [][]int `json:"date-parts"`
+				} `json:"issued"`
+				Citations int `json:"is-referenced-by-count"`
+			} `json:"items"`
+		} `json:"message"`
+	}{}
+
+	if err := json.NewDecoder(resp.Body).Decode(cr); err != nil {
+		return nil, err
+	}
+
+	res := &Results{
+		Provider: CrossrefProvider,
+		Papers:   []*Paper{},
+	}
+
+	for _, item := range cr.Message.Items {
+		if len(item.Title) == 0 || item.DOI == "" {
+			continue
+		}
+
+		p := &Paper{
+			ID:        doiURL(item.DOI),
+			Title:     item.Title[0],
+			Citations: item.Citations,
+			DOI:       item.DOI,
+		}
+
+		for _, a := range item.Author {
+			name := strings.TrimSpace(a.Given + " " + a.Family)
+			if name == "" {
+				name = a.Name
+			}
+			p.Authors = append(p.Authors, name)
+		}
+
+		if len(item.ContainerTitle) > 0 {
+			p.Venue = item.ContainerTitle[0]
+		}
+
+		if len(item.Issued.DateParts) > 0 && len(item.Issued.DateParts[0]) > 0 {
+			p.Year = item.Issued.DateParts[0][0]
+		}
+
+		res.Papers = append(res.Papers, p)
+	}
+
+	return res, nil
+}
@@ -0,0 +1,45 @@
+package scholar
+
+import (
+	"net/http"
+	"reflect"
+	"testing"
+
+	"github.com/jarcoal/httpmock"
+)
+
+func TestCrossrefFetch(t *testing.T) {
+	httpmock.Activate()
+	defer httpmock.DeactivateAndReset()
+
+	raw := `{"status":"ok","message-type":"work-list","message":{"total-results":2,"items":[{"DOI":"10.1038/nature14539","title":["Deep learning"],"author":[{"given":"Yann","family":"LeCun","sequence":"first"},{"name":"Google Brain"}],"container-title":["Nature"],"issued":{"date-parts":[[2015,5,27]]},"is-referenced-by-count":50000},{"DOI":"10.1000/untitled","title":[]}]}}`
+
+	httpmock.RegisterResponder("GET", "https://api.crossref.org/works?mailto=admin%40example.com&query.bibliographic=deep+learning&rows=10&select=DOI%2Ctitle%2Cauthor%2Ccontainer-title%2Cissued%2Cis-referenced-by-count",
+		httpmock.NewStringResponder(200, raw))
+
+	c := &Crossref{HTTPClient: &http.Client{}, Mailto: "admin@example.com"}
+
+	got, err := c.Fetch("deep learning", 10)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := &Results{
+		Provider: CrossrefProvider,
+		Papers: []*Paper{
+			{
+				ID:        "https://doi.org/10.1038/nature14539",
+				Title:     "Deep learning",
+				Authors:   []string{"Yann LeCun", "Google Brain"},
+				Venue:     "Nature",
+				Year:      2015,
+				Citations: 50000,
+				DOI:       "10.1038/nature14539",
+			},
+		},
+	}
+
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("got %+v; want %+v", got, want)
+	}
+}
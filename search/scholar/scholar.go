@@ -0,0 +1,110 @@
+// Package scholar searches for academic papers
+package scholar
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+	"unicode"
+)
+
+// Fetcher outlines the methods used to retrieve papers
+type Fetcher interface {
+	Fetch(q string, number int) (*Results, error)
+}
+
+// Provider is a source of papers
+type Provider string
+
+// Results are the papers matching a query
+type Results struct {
+	Provider Provider `json:"provider"`
+	Papers   []*Paper `json:"papers"`
+}
+
+// Paper is a scholarly article. Each provider normalizes their records to this.
+type Paper struct {
+	ID        string   `json:"id"` // a link to the paper, the doi's if it has one
+	Title     string   `json:"title"`
+	Authors   []string `json:"authors,omitempty"`
+	Venue     string   `json:"venue,omitempty"` // the journal or conference
+	Year      int      `json:"year,omitempty"`
+	Citations int      `json:"citations"`
+	DOI       string   `json:"doi,omitempty"`
+	BibTeX    string   `json:"bibtex,omitempty"`
+}
+
+// doiURL is where a doi resolves
+func doiURL(doi string) string {
+	return "https://doi.org/" + doi
+}
+
+var nonAlphanumeric = regexp.MustCompile(`[^a-z0-9]`)
+
+// Cite returns the paper as a BibTeX entry
+func (p *Paper) Cite() string {
+	typ := "misc"
+	if p.Venue != "" {
+		typ = "article"
+	}
+
+	fields := [][2]string{
+		{"title", p.Title},
+		{"author", strings.Join(p.Authors, " and ")},
+		{"journal", p.Venue},
+		{"year", ""},
+		{"doi", p.DOI},
+		{"url", p.ID},
+	}
+
+	if p.Year > 0 {
+		fields[3][1] = fmt.Sprint(p.Year)
+	}
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "@%v{%v", typ, p.key())
+	for _, f := range fields {
+		if f[1] == "" {
+			continue
+		}
+		fmt.Fprintf(&b, ",\n  %v = {%v}", f[0], escape(f[1]))
+	}
+	b.WriteString("\n}")
+
+	return b.String()
+}
+
+// key is the citation key, e.g. "hendrix1967experience" for the
+// first author's surname, the year and the first long word of the title
+func (p *Paper) key() string {
+	k := ""
+	if len(p.Authors) > 0 {
+		names := strings.Fields(p.Authors[0])
+		if len(names) > 0 {
+			k = names[len(names)-1]
+		}
+	}
+
+	if p.Year > 0 {
+		k += fmt.Sprint(p.Year)
+	}
+
+	for _, w := range strings.FieldsFunc(p.Title, func(r rune) bool { return !unicode.IsLetter(r) && !unicode.IsDigit(r) }) {
+		if len(w) > 3 {
+			k += w
+			break
+		}
+	}
+
+	k = nonAlphanumeric.ReplaceAllString(strings.ToLower(k), "")
+	if k == "" {
+		k = "paper"
+	}
+
+	return k
+}
+
+// escape keeps a value from closing its braces early
+func escape(s string) string {
+	return strings.NewReplacer(`{`, `\{`, `}`, `\}`).Replace(s)
+}
@@ -0,0 +1,53 @@
+package scholar
+
+import "testing"
+
+func TestCite(t *testing.T) {
+	for _, c := range []struct {
+		name  string
+		paper *Paper
+		want  string
+	}{
+		{
+			"article",
+			&Paper{
+				ID:      "https://doi.org/10.1038/nature14539",
+				Title:   "Deep learning",
+				Authors: []string{"Yann LeCun", "Yoshua Bengio", "Geoffrey Hinton"},
+				Venue:   "Nature",
+				Year:    2015,
+				DOI:     "10.1038/nature14539",
+			},
+			"@article{lecun2015deep,\n" +
+				"  title = {Deep learning},\n" +
+				"  author = {Yann LeCun and Yoshua Bengio and Geoffrey Hinton},\n" +
+				"  journal = {Nature},\n" +
+				"  year = {2015},\n" +
+				"  doi = {10.1038/nature14539},\n" +
+				"  url = {https://doi.org/10.1038/nature14539}\n" +
+				"}",
+		},
+		{
+			"preprint",
+			&Paper{
+				ID:    "http://arxiv.org/abs/1706.03762v7",
+				Title: "Attention {Is} All You Need",
+			},
+			"@misc{attention,\n" +
+				"  title = {Attention \\{Is\\} All You Need},\n" +
+				"  url = {http://arxiv.org/abs/1706.03762v7}\n" +
+				"}",
+		},
+		{
+			"no key",
+			&Paper{ID: "https://example.com", Title: "A"},
+			"@misc{paper,\n  title = {A},\n  url = {https://example.com}\n}",
+		},
+	} {
+		t.Run(c.name, func(t *testing.T) {
+			if got := c.paper.Cite(); got != c.want {
+				t.Fatalf("got\n%v\nwant\n%v", got, c.want)
+			}
+		})
+	}
+}
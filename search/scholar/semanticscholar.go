@@ -0,0 +1,108 @@
+package scholar
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+)
+
+// SemanticScholar searches the Semantic Scholar Academic Graph
+type SemanticScholar struct {
+	HTTPClient *http.Client
+	Key        string // optional. Requests with a key are rate limited less.
+}
+
+// SemanticScholarProvider is a scholar provider
+const SemanticScholarProvider Provider = "Semantic Scholar"
+
+// Fetch returns the papers most relevant to a query
+func (s *SemanticScholar) Fetch(q string, number int) (*Results, error) {
+	u, err := url.Parse("https://api.semanticscholar.org/graph/v1/paper/search")
+	if err != nil {
+		return nil, err
+	}
+
+	if number > 100 { // Semantic Scholar's max
+		number = 100
+	}
+
+	v := u.Query()
+	v.Set("query", q)
+	v.Set("limit", strconv.Itoa(number))
+	v.Set("fields", "title,authors,venue,year,citationCount,externalIds,url")
+	u.RawQuery = v.Encode()
+
+	req, err := http.NewRequest("GET", u.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	if s.Key != "" {
+		req.Header.Set("x-api-key", s.Key)
+	}
+
+	resp, err := s.HTTPClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("Semantic Scholar status: %v", resp.Status)
+	}
+
+	sr := &struct {
+		Data []struct {
+			URL     string `json:"url"`
+			Title   string `json:"title"`
+			Authors []struct {
+				Name string `json:"name"`
+			} `json:"authors"`
+			Venue       string `json:"venue"`
+			Year        int    `json:"year"`
+			Citations   int    `json:"citationCount"`
+			ExternalIDs struct {
+				DOI string `json:"DOI"`
+			} `json:"externalIds"`
+		} `json:"data"`
+	}{}
+
+	if err := json.NewDecoder(resp.Body).Decode(sr); err != nil {
+		return nil, err
+	}
+
+	res := &Results{
+		Provider: SemanticScholarProvider,
+		Papers:   []*Paper{},
+	}
+
+	for _, d := range sr.Data {
+		p := &Paper{
+			ID:        d.URL,
+			Title:     d.Title,
+			Venue:     d.Venue,
+			Year:      d.Year,
+			Citations: d.Citations,
+			DOI:       d.ExternalIDs.DOI,
+		}
+
+		if p.DOI != "" {
+			p.ID = doiURL(p.DOI)
+		}
+
+		if p.ID == "" || p.Title == "" {
+			continue
+		}
+
+		for _, a := range d.Authors {
+			p.Authors = append(p.Authors, a.Name)
+		}
+
+		res.Papers = append(res.Papers, p)
+	}
+
+	return res, nil
+}
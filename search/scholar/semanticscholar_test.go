@@ -0,0 +1,58 @@
+package scholar
+
+import (
+	"net/http"
+	"reflect"
+	"testing"
+
+	"github.com/jarcoal/httpmock"
+)
+
+func TestSemanticScholarFetch(t *testing.T) {
+	httpmock.Activate()
+	defer httpmock.DeactivateAndReset()
+
+	raw := `{"total":2,"offset":0,"data":[{"paperId":"204e3073870fae3d05bcbc2f6a8e263d9b72e776","url":"https://www.semanticscholar.org/paper/204e3073870fae3d05bcbc2f6a8e263d9b72e776","title":"Attention is All you Need","venue":"Neural Information Processing Systems","year":2017,"citationCount":100000,"externalIds":{"ArXiv":"1706.03762"},"authors":[{"authorId":"40348417","name":"Ashish Vaswani"}]},{"paperId":"abc","url":"https://www.semanticscholar.org/paper/abc","title":"Deep learning","venue":"Nature","year":2015,"citationCount":50000,"externalIds":{"DOI":"10.1038/nature14539"},"authors":[]}]}`
+
+	httpmock.RegisterResponder("GET", "https://api.semanticscholar.org/graph/v1/paper/search?fields=title%2Cauthors%2Cvenue%2Cyear%2CcitationCount%2CexternalIds%2Curl&limit=100&query=attention",
+		func(req *http.Request) (*http.Response, error) {
+			if got := req.Header.Get("x-api-key"); got != "key" {
+				t.Fatalf("got key %q; want key", got)
+			}
+			return httpmock.NewStringResponse(200, raw), nil
+		},
+	)
+
+	s := &SemanticScholar{HTTPClient: &http.Client{}, Key: "key"}
+
+	got, err := s.Fetch("attention", 250)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := &Results{
+		Provider: SemanticScholarProvider,
+		Papers: []*Paper{
+			{
+				ID:        "https://www.semanticscholar.org/paper/204e3073870fae3d05bcbc2f6a8e263d9b72e776",
+				Title:     "Attention is All you Need",
+				Authors:   []string{"Ashish Vaswani"},
+				Venue:     "Neural Information Processing Systems",
+				Year:      2017,
+				Citations: 100000,
+			},
+			{
+				ID:        "https://doi.org/10.1038/nature14539",
+				Title:     "Deep learning",
+				Venue:     "Nature",
+				Year:      2015,
+				Citations: 50000,
+				DOI:       "10.1038/nature14539",
+			},
+		},
+	}
+
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("got %+v; want %+v", got, want)
+	}
+}
@@ -2,10 +2,12 @@
 package search
 
 import (
+	"errors"
 	"math"
 	"strconv"
 
 	"github.com/jivesearch/jivesearch/search/document"
+	"github.com/jivesearch/jivesearch/search/paging"
 	"golang.org/x/text/language"
 )
 
@@ -14,6 +16,21 @@ type Fetcher interface {
 	Fetch(q string, s Filter, lang language.Tag, region language.Region, number int, offset int) (*Results, error)
 }
 
+// AfterFetcher continues from the Cursor of a previous Results, which
+// avoids deep offsets when the user keeps paging.
+type AfterFetcher interface {
+	FetchAfter(q string, s Filter, lang language.Tag, region language.Region, number int, cursor string) (*Results, error)
+}
+
+// MaxOffset is as deep as we page with an offset. Deeper pages follow the Cursor of the page before them.
+const MaxOffset = 250
+
+// ErrDeepOffset indicates an offset past MaxOffset
+var ErrDeepOffset = errors.New("offset is too deep, use a cursor")
+
+// ErrInvalidCursor indicates a cursor that we didn't issue
+var ErrInvalidCursor = paging.ErrInvalidCursor
+
 // Provider is a search provider
 type Provider string
 
@@ -39,6 +56,12 @@ type Results struct {
 	Last       string               `json:"-"`
 	Pagination []string             `json:"-"`
 	Documents  []*document.Document `json:"documents"`
+	Related    []string             `json:"related,omitempty"`
+	Relaxed    string               `json:"relaxed,omitempty"` // the looser query used when the original had no results
+	More       []*More              `json:"more,omitempty"`    // hosts with results collapsed
+	Cursor     string               `json:"cursor,omitempty"`  // fetches the next page with FetchAfter
+	Syntax     []QueryError         `json:"syntax,omitempty"`  // malformed operators in the query
+	Facets     *Facets              `json:"facets,omitempty"`  // counts of the files vertical
 	Err        error
 }
 
@@ -77,3 +100,38 @@ func (r *Results) AddPagination(number, page int) *Results {
 
 	return r
 }
+
+// Shallow keeps the pages we can reach with an offset, along with the current page.
+// The pages past MaxOffset can only be reached with the Cursor of the page before them.
+func (r *Results) Shallow(number, page int) *Results {
+	deep := func(p int) bool {
+		return (p-1)*number > MaxOffset
+	}
+
+	pages := []string{}
+	for _, p := range r.Pagination {
+		if n, err := strconv.Atoi(p); err == nil && (n == page || !deep(n)) {
+			pages = append(pages, p)
+		}
+	}
+	r.Pagination = pages
+
+	if n, err := strconv.Atoi(r.Previous); err == nil && deep(n) {
+		r.Previous = ""
+	}
+
+	return r
+}
+
+// Copy is a copy of the results whose documents can be changed without changing ours,
+// e.g. when the results of one fetch are shared by several requests.
+func (r *Results) Copy() *Results {
+	c := *r
+	c.Documents = make([]*document.Document, len(r.Documents))
+	for i, d := range r.Documents {
+		doc := *d
+		c.Documents[i] = &doc
+	}
+
+	return &c
+}
@@ -3,6 +3,8 @@ package search
 import (
 	"reflect"
 	"testing"
+
+	"github.com/jivesearch/jivesearch/search/document"
 )
 
 func TestAddPagination(t *testing.T) {
@@ -62,3 +64,80 @@ func TestAddPagination(t *testing.T) {
 		})
 	}
 }
+
+func TestShallow(t *testing.T) {
+	for _, c := range []struct {
+		name       string
+		number     int
+		page       int
+		pagination []string
+		previous   string
+		want       []string
+		wantPrev   string
+	}{
+		{
+			name:       "shallow",
+			number:     25,
+			page:       2,
+			pagination: []string{"1", "2", "3", "4"},
+			previous:   "1",
+			want:       []string{"1", "2", "3", "4"},
+			wantPrev:   "1",
+		},
+		{
+			name:       "past the offsets",
+			number:     25,
+			page:       9,
+			pagination: []string{"4", "5", "6", "7", "8", "9", "10", "11", "12", "13"},
+			previous:   "8",
+			want:       []string{"4", "5", "6", "7", "8", "9", "10", "11"},
+			wantPrev:   "8",
+		},
+		{
+			name:       "deep",
+			number:     25,
+			page:       14,
+			pagination: []string{"9", "10", "11", "12", "13", "14", "15"},
+			previous:   "13",
+			want:       []string{"9", "10", "11", "14"},
+			wantPrev:   "",
+		},
+	} {
+		t.Run(c.name, func(t *testing.T) {
+			res := &Results{
+				Pagination: c.pagination,
+				Previous:   c.previous,
+			}
+
+			got := res.Shallow(c.number, c.page)
+
+			if !reflect.DeepEqual(got.Pagination, c.want) {
+				t.Fatalf("got %+v; want %+v", got.Pagination, c.want)
+			}
+
+			if got.Previous != c.wantPrev {
+				t.Fatalf("got previous %q; want %q", got.Previous, c.wantPrev)
+			}
+		})
+	}
+}
+
+func TestCopy(t *testing.T) {
+	r := &Results{
+		Count:      2,
+		Pagination: []string{"1"},
+		Documents:  []*document.Document{{ID: "https://example.com"}, {ID: "https://example.org"}},
+	}
+
+	c := r.Copy()
+	if !reflect.DeepEqual(c, r) {
+		t.Fatalf("got %+v; want %+v", c, r)
+	}
+
+	c.Documents[0].Title = "changed"
+	c.Documents = c.Documents[1:]
+
+	if r.Documents[0].Title != "" || len(r.Documents) != 2 {
+		t.Fatalf("changing the copy changed the results: %+v", r.Documents)
+	}
+}
@@ -0,0 +1,207 @@
+package shopping
+
+import (
+	"bytes"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"golang.org/x/text/language"
+)
+
+// Amazon searches Amazon with their Product Advertising API (PA-API 5)
+type Amazon struct {
+	HTTPClient *http.Client
+	AccessKey  string
+	SecretKey  string
+	PartnerTag string // the associate tag the api is tied to
+}
+
+// AmazonProvider is a shopping provider
+const AmazonProvider Provider = "Amazon"
+
+// amazonMarketplace is an Amazon site and the api host and AWS region that serve it
+type amazonMarketplace struct {
+	site, host, region string
+}
+
+// amazonMarketplaces are Amazon's sites by region. Anywhere else gets amazon.com.
+var amazonMarketplaces = map[string]amazonMarketplace{
+	"AU": {"www.amazon.com.au", "webservices.amazon.com.au", "us-west-2"},
+	"BR": {"www.amazon.com.br", "webservices.amazon.com.br", "us-east-1"},
+	"CA": {"www.amazon.ca", "webservices.amazon.ca", "us-east-1"},
+	"DE": {"www.amazon.de", "webservices.amazon.de", "eu-west-1"},
+	"ES": {"www.amazon.es", "webservices.amazon.es", "eu-west-1"},
+	"FR": {"www.amazon.fr", "webservices.amazon.fr", "eu-west-1"},
+	"GB": {"www.amazon.co.uk", "webservices.amazon.co.uk", "eu-west-1"},
+	"IN": {"www.amazon.in", "webservices.amazon.in", "eu-west-1"},
+	"IT": {"www.amazon.it", "webservices.amazon.it", "eu-west-1"},
+	"JP": {"www.amazon.co.jp", "webservices.amazon.co.jp", "us-west-2"},
+	"MX": {"www.amazon.com.mx", "webservices.amazon.com.mx", "us-east-1"},
+	"US": {"www.amazon.com", "webservices.amazon.com", "us-east-1"},
+}
+
+const amazonTarget = "com.amazon.paapi5.v1.ProductAdvertisingAPIv1.SearchItems"
+
+// Fetch returns Amazon's products for a query on their site for the region
+func (a *Amazon) Fetch(q string, region language.Region, number int) (*Results, error) {
+	m, ok := amazonMarketplaces[region.String()]
+	if !ok {
+		m = amazonMarketplaces["US"]
+	}
+
+	if number > 10 { // Amazon's max
+		number = 10
+	}
+
+	body, err := json.Marshal(map[string]interface{}{
+		"Keywords":    q,
+		"ItemCount":   number,
+		"Marketplace": m.site,
+		"PartnerTag":  a.PartnerTag,
+		"PartnerType": "Associates",
+		"Resources": []string{
+			"Images.Primary.Medium",
+			"ItemInfo.Title",
+			"Offers.Listings.MerchantInfo",
+			"Offers.Listings.Price",
+		},
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("POST", fmt.Sprintf("https://%v/paapi5/searchitems", m.host), bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Set("Content-Encoding", "amz-1.0")
+	req.Header.Set("Content-Type", "application/json; charset=utf-8")
+	req.Header.Set("X-Amz-Target", amazonTarget)
+	a.sign(req, body, m.region, time.Now().UTC())
+
+	resp, err := a.HTTPClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("Amazon status: %v", resp.Status)
+	}
+
+	ar := &struct {
+		SearchResult struct {
+			Items []struct {
+				DetailPageURL string `json:"DetailPageURL"`
+				Images        struct {
+					Primary struct {
+						Medium struct {
+							URL string `json:"URL"`
+						} `json:"Medium"`
+					} `json:"Primary"`
+				} `json:"Images"`
+				ItemInfo struct {
+					Title struct {
+						DisplayValue string `json:"DisplayValue"`
+					} `json:"Title"`
+				} `json:"ItemInfo"`
+				Offers struct {
+					Listings []struct {
+						MerchantInfo struct {
+							Name string `json:"Name"`
+						} `json:"MerchantInfo"`
+						Price struct {
+							Amount   float64 `json:"Amount"`
+							Currency string  `json:"Currency"`
+						} `json:"Price"`
+					} `json:"Listings"`
+				} `json:"Offers"`
+			} `json:"Items"`
+		} `json:"SearchResult"`
+	}{}
+
+	if err := json.NewDecoder(resp.Body).Decode(ar); err != nil {
+		return nil, err
+	}
+
+	res := &Results{
+		Provider: AmazonProvider,
+		Products: []*Product{},
+	}
+
+	for _, item := range ar.SearchResult.Items {
+		if len(item.Offers.Listings) == 0 || item.DetailPageURL == "" { // nothing for sale
+			continue
+		}
+
+		l := item.Offers.Listings[0]
+
+		res.Products = append(res.Products, &Product{
+			ID:       item.DetailPageURL,
+			Title:    item.ItemInfo.Title.DisplayValue,
+			Image:    item.Images.Primary.Medium.URL,
+			Merchant: l.MerchantInfo.Name,
+			Price:    Price{Amount: l.Price.Amount, Currency: l.Price.Currency},
+		})
+	}
+
+	return res, nil
+}
+
+// sign adds AWS's Signature Version 4 to the request
+func (a *Amazon) sign(req *http.Request, body []byte, region string, t time.Time) {
+	const service = "ProductAdvertisingAPI"
+
+	amzDate := t.Format("20060102T150405Z")
+	date := t.Format("20060102")
+
+	req.Header.Set("Host", req.URL.Host)
+	req.Header.Set("X-Amz-Date", amzDate)
+
+	signed := []string{"content-encoding", "content-type", "host", "x-amz-date", "x-amz-target"}
+
+	headers := ""
+	for _, h := range signed {
+		headers += h + ":" + strings.TrimSpace(req.Header.Get(h)) + "\n"
+	}
+
+	canonical := strings.Join([]string{
+		req.Method,
+		req.URL.EscapedPath(),
+		req.URL.RawQuery,
+		headers,
+		strings.Join(signed, ";"),
+		hexHash(body),
+	}, "\n")
+
+	scope := strings.Join([]string{date, region, service, "aws4_request"}, "/")
+	toSign := strings.Join([]string{"AWS4-HMAC-SHA256", amzDate, scope, hexHash([]byte(canonical))}, "\n")
+
+	key := []byte("AWS4" + a.SecretKey)
+	for _, s := range []string{date, region, service, "aws4_request"} {
+		key = hmacSHA256(key, s)
+	}
+
+	req.Header.Set("Authorization", fmt.Sprintf("AWS4-HMAC-SHA256 Credential=%v/%v, SignedHeaders=%v, Signature=%v",
+		a.AccessKey, scope, strings.Join(signed, ";"), hex.EncodeToString(hmacSHA256(key, toSign)),
+	))
+}
+
+func hmacSHA256(key []byte, s string) []byte {
+	h := hmac.New(sha256.New, key)
+	h.Write([]byte(s))
+	return h.Sum(nil)
+}
+
+func hexHash(b []byte) string {
+	h := sha256.Sum256(b)
+	return hex.EncodeToString(h[:])
+}
@@ -0,0 +1,77 @@
+package shopping
+
+import (
+	"bytes"
+	"net/http"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/jarcoal/httpmock"
+	"golang.org/x/text/language"
+)
+
+func TestAmazonFetch(t *testing.T) {
+	httpmock.Activate()
+	defer httpmock.DeactivateAndReset()
+
+	raw := `{"SearchResult":{"TotalResultCount":2,"Items":[{"ASIN":"B000","DetailPageURL":"https://www.amazon.de/dp/B000?tag=tag-21","Images":{"Primary":{"Medium":{"URL":"https://m.media-amazon.com/images/I/strat.jpg","Height":160,"Width":160}}},"ItemInfo":{"Title":{"DisplayValue":"Fender Player Stratocaster","Label":"Title"}},"Offers":{"Listings":[{"MerchantInfo":{"Name":"Musikhaus"},"Price":{"Amount":649.5,"Currency":"EUR","DisplayAmount":"649,50 €"}}]}},{"ASIN":"B001","DetailPageURL":"https://www.amazon.de/dp/B001?tag=tag-21","ItemInfo":{"Title":{"DisplayValue":"Out of stock"}}}]}}`
+
+	httpmock.RegisterResponder("POST", "https://webservices.amazon.de/paapi5/searchitems",
+		func(req *http.Request) (*http.Response, error) {
+			if got := req.Header.Get("Authorization"); !strings.HasPrefix(got, "AWS4-HMAC-SHA256 Credential=access/") || !strings.Contains(got, "/eu-west-1/ProductAdvertisingAPI/aws4_request") {
+				t.Fatalf("got Authorization %q", got)
+			}
+			return httpmock.NewStringResponse(200, raw), nil
+		},
+	)
+
+	a := &Amazon{HTTPClient: &http.Client{}, AccessKey: "access", SecretKey: "secret", PartnerTag: "tag-21"}
+
+	got, err := a.Fetch("stratocaster", language.MustParseRegion("DE"), 25)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := &Results{
+		Provider: AmazonProvider,
+		Products: []*Product{
+			{
+				ID:       "https://www.amazon.de/dp/B000?tag=tag-21",
+				Title:    "Fender Player Stratocaster",
+				Image:    "https://m.media-amazon.com/images/I/strat.jpg",
+				Merchant: "Musikhaus",
+				Price:    Price{Amount: 649.5, Currency: "EUR"},
+			},
+		},
+	}
+
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("got %+v; want %+v", got, want)
+	}
+}
+
+func TestAmazonSign(t *testing.T) {
+	body := []byte(`{"Keywords":"stratocaster"}`)
+
+	req, err := http.NewRequest("POST", "https://webservices.amazon.com/paapi5/searchitems", bytes.NewReader(body))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	req.Header.Set("Content-Encoding", "amz-1.0")
+	req.Header.Set("Content-Type", "application/json; charset=utf-8")
+	req.Header.Set("X-Amz-Target", amazonTarget)
+
+	a := &Amazon{AccessKey: "access", SecretKey: "secret"}
+	a.sign(req, body, "us-east-1", time.Date(2020, 1, 2, 3, 4, 5, 0, time.UTC))
+
+	want := "AWS4-HMAC-SHA256 Credential=access/20200102/us-east-1/ProductAdvertisingAPI/aws4_request, " +
+		"SignedHeaders=content-encoding;content-type;host;x-amz-date;x-amz-target, " +
+		"Signature=8a23f44bfa3148960fa51fc41b160c00fc8e9de9b89729b0d6df5ec183cf1bda"
+
+	if got := req.Header.Get("Authorization"); got != want {
+		t.Fatalf("got %q; want %q", got, want)
+	}
+}
@@ -0,0 +1,123 @@
+package shopping
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+
+	"golang.org/x/text/language"
+)
+
+// EBay searches eBay's listings with their Browse API
+type EBay struct {
+	HTTPClient *http.Client
+	Token      string // an application access token
+}
+
+// EBayProvider is a shopping provider
+const EBayProvider Provider = "eBay"
+
+// marketplaces are eBay's sites by region. Anywhere else gets ebay.com.
+var marketplaces = map[string]string{
+	"AT": "EBAY_AT",
+	"AU": "EBAY_AU",
+	"BE": "EBAY_BE",
+	"CA": "EBAY_CA",
+	"CH": "EBAY_CH",
+	"DE": "EBAY_DE",
+	"ES": "EBAY_ES",
+	"FR": "EBAY_FR",
+	"GB": "EBAY_GB",
+	"HK": "EBAY_HK",
+	"IE": "EBAY_IE",
+	"IT": "EBAY_IT",
+	"NL": "EBAY_NL",
+	"PL": "EBAY_PL",
+	"SG": "EBAY_SG",
+}
+
+// Fetch returns eBay's listings for a query on their site for the region
+func (e *EBay) Fetch(q string, region language.Region, number int) (*Results, error) {
+	u, err := url.Parse("https://api.ebay.com/buy/browse/v1/item_summary/search")
+	if err != nil {
+		return nil, err
+	}
+
+	if number > 200 { // eBay's max
+		number = 200
+	}
+
+	v := u.Query()
+	v.Set("q", q)
+	v.Set("limit", strconv.Itoa(number))
+	u.RawQuery = v.Encode()
+
+	req, err := http.NewRequest("GET", u.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	marketplace, ok := marketplaces[region.String()]
+	if !ok {
+		marketplace = "EBAY_US"
+	}
+
+	req.Header.Set("Authorization", "Bearer "+e.Token)
+	req.Header.Set("X-EBAY-C-MARKETPLACE-ID", marketplace)
+
+	resp, err := e.HTTPClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("eBay status: %v", resp.Status)
+	}
+
+	er := &struct {
+		ItemSummaries []struct {
+			Title  string `json:"title"`
+			WebURL string `json:"itemWebUrl"`
+			Image  struct {
+				URL string `json:"imageUrl"`
+			} `json:"image"`
+			Price struct {
+				Value    string `json:"value"`
+				Currency string `json:"currency"`
+			} `json:"price"`
+			Seller struct {
+				Username string `json:"username"`
+			} `json:"seller"`
+		} `json:"itemSummaries"`
+	}{}
+
+	if err := json.NewDecoder(resp.Body).Decode(er); err != nil {
+		return nil, err
+	}
+
+	res := &Results{
+		Provider: EBayProvider,
+		Products: []*Product{},
+	}
+
+	for _, item := range er.ItemSummaries {
+		price, ok := ParsePrice(item.Price.Value + " " + item.Price.Currency)
+		if !ok || item.WebURL == "" {
+			continue
+		}
+
+		res.Products = append(res.Products, &Product{
+			ID:       item.WebURL,
+			Title:    item.Title,
+			Image:    item.Image.URL,
+			Merchant: item.Seller.Username,
+			Price:    price,
+		})
+	}
+
+	return res, nil
+}
@@ -0,0 +1,53 @@
+package shopping
+
+import (
+	"net/http"
+	"reflect"
+	"testing"
+
+	"github.com/jarcoal/httpmock"
+	"golang.org/x/text/language"
+)
+
+func TestEBayFetch(t *testing.T) {
+	httpmock.Activate()
+	defer httpmock.DeactivateAndReset()
+
+	raw := `{"href":"https://api.ebay.com/buy/browse/v1/item_summary/search?q=stratocaster&limit=25","total":2,"itemSummaries":[{"itemId":"v1|1|0","title":"Fender Stratocaster 1965","image":{"imageUrl":"https://i.ebayimg.com/images/g/strat.jpg"},"price":{"value":"15000.00","currency":"GBP"},"seller":{"username":"vintageguitars","feedbackPercentage":"100.0"},"itemWebUrl":"https://www.ebay.co.uk/itm/1"},{"itemId":"v1|2|0","title":"Stratocaster pickguard","price":{"value":"","currency":"GBP"},"itemWebUrl":"https://www.ebay.co.uk/itm/2"}]}`
+
+	httpmock.RegisterResponder("GET", "https://api.ebay.com/buy/browse/v1/item_summary/search?limit=25&q=stratocaster",
+		func(req *http.Request) (*http.Response, error) {
+			if got := req.Header.Get("Authorization"); got != "Bearer token" {
+				t.Fatalf("got Authorization %q", got)
+			}
+			if got := req.Header.Get("X-EBAY-C-MARKETPLACE-ID"); got != "EBAY_GB" {
+				t.Fatalf("got marketplace %q; want EBAY_GB", got)
+			}
+			return httpmock.NewStringResponse(200, raw), nil
+		},
+	)
+
+	e := &EBay{HTTPClient: &http.Client{}, Token: "token"}
+
+	got, err := e.Fetch("stratocaster", language.MustParseRegion("GB"), 25)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := &Results{
+		Provider: EBayProvider,
+		Products: []*Product{
+			{
+				ID:       "https://www.ebay.co.uk/itm/1",
+				Title:    "Fender Stratocaster 1965",
+				Image:    "https://i.ebayimg.com/images/g/strat.jpg",
+				Merchant: "vintageguitars",
+				Price:    Price{Amount: 15000, Currency: "GBP"},
+			},
+		},
+	}
+
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("got %+v; want %+v", got, want)
+	}
+}
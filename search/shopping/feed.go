@@ -0,0 +1,126 @@
+package shopping
+
+import (
+	"encoding/xml"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"golang.org/x/text/language"
+)
+
+// Feed searches merchants' open product feeds, the RSS feeds in the Google merchant
+// format that many shops already publish. Each feed is fetched for every query so
+// this suits a handful of small feeds. Region is ignored.
+type Feed struct {
+	HTTPClient *http.Client
+	URLs       []string
+	UserAgent  string
+}
+
+// FeedProvider is a shopping provider
+const FeedProvider Provider = "Product Feeds"
+
+type rss struct {
+	Channel struct {
+		Title string `xml:"title"`
+		Items []struct {
+			// a field without a namespace matches the g: elements too, so those come first
+			GTitle    string `xml:"http://base.google.com/ns/1.0 title"`
+			GLink     string `xml:"http://base.google.com/ns/1.0 link"`
+			Title     string `xml:"title"`
+			Link      string `xml:"link"`
+			Image     string `xml:"http://base.google.com/ns/1.0 image_link"`
+			Price     string `xml:"http://base.google.com/ns/1.0 price"`
+			SalePrice string `xml:"http://base.google.com/ns/1.0 sale_price"`
+		} `xml:"item"`
+	} `xml:"channel"`
+}
+
+// Fetch returns the products in our feeds with all the words of the query in their title
+func (f *Feed) Fetch(q string, region language.Region, number int) (*Results, error) {
+	res := &Results{
+		Provider: FeedProvider,
+		Products: []*Product{},
+	}
+
+	words := strings.Fields(strings.ToLower(q))
+
+	for _, u := range f.URLs {
+		feed, err := f.fetch(u)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, item := range feed.Channel.Items {
+			title := first(item.GTitle, item.Title)
+			link := first(item.GLink, item.Link)
+
+			price, ok := ParsePrice(first(item.SalePrice, item.Price))
+			if !ok || link == "" || !matches(title, words) {
+				continue
+			}
+
+			res.Products = append(res.Products, &Product{
+				ID:       link,
+				Title:    title,
+				Image:    item.Image,
+				Merchant: feed.Channel.Title,
+				Price:    price,
+			})
+
+			if len(res.Products) == number {
+				return res, nil
+			}
+		}
+	}
+
+	return res, nil
+}
+
+func (f *Feed) fetch(u string) (*rss, error) {
+	req, err := http.NewRequest("GET", u, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Set("User-Agent", f.UserAgent)
+
+	resp, err := f.HTTPClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("product feed %v status: %v", u, resp.Status)
+	}
+
+	feed := &rss{}
+	if err := xml.NewDecoder(resp.Body).Decode(feed); err != nil {
+		return nil, err
+	}
+
+	return feed, nil
+}
+
+// first is the first of the values that isn't empty
+func first(values ...string) string {
+	for _, v := range values {
+		if v = strings.TrimSpace(v); v != "" {
+			return v
+		}
+	}
+	return ""
+}
+
+func matches(title string, words []string) bool {
+	title = strings.ToLower(title)
+	for _, w := range words {
+		if !strings.Contains(title, w) {
+			return false
+		}
+	}
+	return len(words) > 0
+}
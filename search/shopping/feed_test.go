@@ -0,0 +1,87 @@
+package shopping
+
+import (
+	"net/http"
+	"reflect"
+	"testing"
+
+	"github.com/jarcoal/httpmock"
+	"golang.org/x/text/language"
+)
+
+func TestFeedFetch(t *testing.T) {
+	httpmock.Activate()
+	defer httpmock.DeactivateAndReset()
+
+	raw := `<?xml version="1.0"?>
+<rss xmlns:g="http://base.google.com/ns/1.0" version="2.0">
+  <channel>
+    <title>Guitar Shop</title>
+    <link>https://guitars.example.com</link>
+    <item>
+      <g:id>1</g:id>
+      <g:title>Fender Stratocaster</g:title>
+      <g:link>https://guitars.example.com/strat</g:link>
+      <g:image_link>https://guitars.example.com/strat.jpg</g:image_link>
+      <g:price>1499.00 USD</g:price>
+      <g:sale_price>1299.00 USD</g:sale_price>
+    </item>
+    <item>
+      <title>Gibson Les Paul</title>
+      <link>https://guitars.example.com/lespaul</link>
+      <g:price>2499.00 USD</g:price>
+    </item>
+    <item>
+      <title>Stratocaster strap</title>
+      <link>https://guitars.example.com/strap</link>
+      <g:price>20 USD</g:price>
+    </item>
+  </channel>
+</rss>`
+
+	httpmock.RegisterResponder("GET", "https://guitars.example.com/feed.xml", httpmock.NewStringResponder(200, raw))
+
+	f := &Feed{HTTPClient: &http.Client{}, URLs: []string{"https://guitars.example.com/feed.xml"}}
+
+	for _, c := range []struct {
+		q    string
+		want []*Product
+	}{
+		{
+			"fender stratocaster",
+			[]*Product{
+				{
+					ID:       "https://guitars.example.com/strat",
+					Title:    "Fender Stratocaster",
+					Image:    "https://guitars.example.com/strat.jpg",
+					Merchant: "Guitar Shop",
+					Price:    Price{Amount: 1299, Currency: "USD"},
+				},
+			},
+		},
+		{
+			"les paul",
+			[]*Product{
+				{
+					ID:       "https://guitars.example.com/lespaul",
+					Title:    "Gibson Les Paul",
+					Merchant: "Guitar Shop",
+					Price:    Price{Amount: 2499, Currency: "USD"},
+				},
+			},
+		},
+		{"telecaster", []*Product{}},
+	} {
+		t.Run(c.q, func(t *testing.T) {
+			got, err := f.Fetch(c.q, language.MustParseRegion("US"), 10)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			want := &Results{Provider: FeedProvider, Products: c.want}
+			if !reflect.DeepEqual(got, want) {
+				t.Fatalf("got %+v; want %+v", got, want)
+			}
+		})
+	}
+}
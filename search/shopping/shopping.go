@@ -0,0 +1,175 @@
+// Package shopping searches for products for sale
+package shopping
+
+import (
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/jivesearch/jivesearch/instant/currency"
+	"golang.org/x/text/language"
+)
+
+// Fetcher outlines the methods used to retrieve products
+type Fetcher interface {
+	Fetch(q string, region language.Region, number int) (*Results, error)
+}
+
+// Provider is a source of products
+type Provider string
+
+// Results are the products matching a query
+type Results struct {
+	Provider Provider   `json:"provider"`
+	Products []*Product `json:"products"`
+}
+
+// Product is an item for sale. Each provider normalizes their listings to this.
+type Product struct {
+	ID        string `json:"id"` // the url of the listing
+	Title     string `json:"title"`
+	Image     string `json:"image,omitempty"`
+	Thumbnail string `json:"thumbnail,omitempty"` // the image through our proxy
+	Merchant  string `json:"merchant,omitempty"`
+	Price     Price  `json:"price"`
+	Original  *Price `json:"original,omitempty"` // the price before we converted it to the user's currency
+}
+
+// Price is an amount in a currency, e.g. 15.00 USD
+type Price struct {
+	Amount   float64 `json:"amount"`
+	Currency string  `json:"currency"`
+}
+
+// ParsePrice parses prices like "15.00 USD" and "USD 15.00"
+func ParsePrice(s string) (Price, bool) {
+	fields := strings.Fields(s)
+	if len(fields) != 2 {
+		return Price{}, false
+	}
+
+	amount, cur := strings.Replace(fields[0], ",", "", -1), fields[1]
+	if _, err := strconv.ParseFloat(amount, 64); err != nil {
+		amount, cur = strings.Replace(cur, ",", "", -1), fields[0]
+	}
+
+	a, err := strconv.ParseFloat(amount, 64)
+	if err != nil || a < 0 {
+		return Price{}, false
+	}
+
+	return Price{Amount: a, Currency: strings.ToUpper(cur)}, true
+}
+
+// Currencies are what users can convert prices to. We have rates for each from the ECB.
+var Currencies = []string{"AUD", "BRL", "CAD", "CHF", "CNY", "EUR", "GBP", "INR", "JPY", "KRW", "MXN", "SEK", "USD"}
+
+// Sort orders the products
+type Sort string
+
+// sorts
+const (
+	SortRelevance Sort = ""
+	SortPriceAsc  Sort = "price_asc"
+	SortPriceDesc Sort = "price_desc"
+)
+
+// Filter is how the user wants their products sorted, priced and in which currency
+type Filter struct {
+	Sort     Sort
+	Min      float64 // 0 for no minimum
+	Max      float64 // 0 for no maximum
+	Currency string  // empty to show each product in its own currency
+}
+
+// NewFilter returns a Filter from the url params. Anything invalid is ignored.
+func NewFilter(srt, min, max, cur string) Filter {
+	f := Filter{}
+
+	switch s := Sort(srt); s {
+	case SortPriceAsc, SortPriceDesc:
+		f.Sort = s
+	}
+
+	if m, err := strconv.ParseFloat(min, 64); err == nil && m > 0 {
+		f.Min = m
+	}
+
+	if m, err := strconv.ParseFloat(max, 64); err == nil && m > 0 {
+		f.Max = m
+	}
+
+	if f.Max > 0 && f.Max < f.Min {
+		f.Min, f.Max = f.Max, f.Min
+	}
+
+	if ok, c := currency.Valid(cur); ok {
+		f.Currency = c.Short
+	}
+
+	return f
+}
+
+// Apply converts the prices to the Filter's currency and filters and sorts the products.
+// A product we can't convert is kept in its own currency, unless we are filtering by price,
+// and sorted after the others.
+func (r *Results) Apply(f Filter, fx *currency.Response) *Results {
+	products := []*Product{}
+
+	for _, p := range r.Products {
+		converted := p.convert(f.Currency, fx)
+
+		if f.Min > 0 || f.Max > 0 {
+			if !converted {
+				continue
+			}
+
+			if p.Price.Amount < f.Min || (f.Max > 0 && p.Price.Amount > f.Max) {
+				continue
+			}
+		}
+
+		products = append(products, p)
+	}
+
+	if f.Sort != SortRelevance {
+		comparable := func(p *Product) bool {
+			return f.Currency == "" || p.Price.Currency == f.Currency
+		}
+
+		sort.SliceStable(products, func(i, j int) bool {
+			a, b := products[i], products[j]
+			if comparable(a) != comparable(b) {
+				return comparable(a)
+			}
+
+			if f.Sort == SortPriceDesc {
+				return a.Price.Amount > b.Price.Amount
+			}
+			return a.Price.Amount < b.Price.Amount
+		})
+	}
+
+	r.Products = products
+	return r
+}
+
+// convert changes the price to the currency, if we have the rates for it. With
+// no currency each product keeps its own so there is nothing to convert.
+func (p *Product) convert(to string, fx *currency.Response) bool {
+	if to == "" || p.Price.Currency == to {
+		return true
+	}
+
+	amount, ok := fx.Convert(p.Price.Amount, p.Price.Currency, to)
+	if !ok {
+		return false
+	}
+
+	original := p.Price
+	p.Original = &original
+	p.Price = Price{Amount: math.Round(amount*100) / 100, Currency: to}
+
+	return true
+}
@@ -0,0 +1,100 @@
+package shopping
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/jivesearch/jivesearch/instant/currency"
+)
+
+func TestParsePrice(t *testing.T) {
+	for _, c := range []struct {
+		s    string
+		want Price
+		ok   bool
+	}{
+		{"15.00 USD", Price{15, "USD"}, true},
+		{"EUR 9.5", Price{9.5, "EUR"}, true},
+		{"1,299.99 usd", Price{1299.99, "USD"}, true},
+		{"15.00", Price{}, false},
+		{"free USD", Price{}, false},
+		{"-1 USD", Price{}, false},
+	} {
+		got, ok := ParsePrice(c.s)
+		if got != c.want || ok != c.ok {
+			t.Errorf("%q: got %+v, %v; want %+v, %v", c.s, got, ok, c.want, c.ok)
+		}
+	}
+}
+
+func TestNewFilter(t *testing.T) {
+	for _, c := range []struct {
+		sort, min, max, currency string
+		want                     Filter
+	}{
+		{"", "", "", "", Filter{}},
+		{"price_asc", "10", "50", "eur", Filter{SortPriceAsc, 10, 50, "EUR"}},
+		{"price_desc", "50", "10", "", Filter{SortPriceDesc, 10, 50, ""}},
+		{"cheapest", "-5", "abc", "XYZ", Filter{}},
+	} {
+		got := NewFilter(c.sort, c.min, c.max, c.currency)
+		if got != c.want {
+			t.Errorf("got %+v; want %+v", got, c.want)
+		}
+	}
+}
+
+func TestApply(t *testing.T) {
+	fx := &currency.Response{
+		Base: currency.USD,
+		History: map[string][]*currency.Rate{
+			currency.EUR.Short: {{DateTime: time.Date(2018, 1, 31, 0, 0, 0, 0, time.UTC), Rate: 1.25}},
+		},
+	}
+
+	products := func() *Results {
+		return &Results{
+			Provider: EBayProvider,
+			Products: []*Product{
+				{ID: "a", Price: Price{20, "USD"}},
+				{ID: "b", Price: Price{10, "EUR"}},
+				{ID: "c", Price: Price{1000, "JPY"}},
+				{ID: "d", Price: Price{5, "USD"}},
+			},
+		}
+	}
+
+	ids := func(r *Results) []string {
+		s := []string{}
+		for _, p := range r.Products {
+			s = append(s, p.ID)
+		}
+		return s
+	}
+
+	for _, c := range []struct {
+		name   string
+		filter Filter
+		want   []string
+	}{
+		{"relevance", Filter{}, []string{"a", "b", "c", "d"}},
+		{"cheapest in usd", Filter{Sort: SortPriceAsc, Currency: "USD"}, []string{"d", "b", "a", "c"}},
+		{"dearest in usd", Filter{Sort: SortPriceDesc, Currency: "USD"}, []string{"a", "b", "d", "c"}},
+		{"between 10 and 15 usd", Filter{Min: 10, Max: 15, Currency: "USD"}, []string{"b"}},
+		{"over 15 usd", Filter{Min: 15, Currency: "USD"}, []string{"a"}},
+	} {
+		t.Run(c.name, func(t *testing.T) {
+			got := ids(products().Apply(c.filter, fx))
+			if !reflect.DeepEqual(got, c.want) {
+				t.Fatalf("got %v; want %v", got, c.want)
+			}
+		})
+	}
+
+	r := products().Apply(Filter{Currency: "USD"}, fx)
+	want := &Product{ID: "b", Price: Price{12.5, "USD"}, Original: &Price{10, "EUR"}}
+	if !reflect.DeepEqual(r.Products[1], want) {
+		t.Fatalf("got %+v; want %+v", r.Products[1], want)
+	}
+}
@@ -0,0 +1,26 @@
+package search
+
+import (
+	"github.com/jivesearch/jivesearch/search/threat"
+)
+
+// Warn flags the results on the feed's malware and phishing blocklists, or removes them if filter is true
+func (r *Results) Warn(feed *threat.Feed, filter bool) *Results {
+	if feed == nil {
+		return r
+	}
+
+	docs := r.Documents[:0]
+	for _, doc := range r.Documents {
+		kind, ok := feed.Lookup(doc.ID)
+		if ok && filter {
+			continue
+		}
+
+		doc.Threat = string(kind)
+		docs = append(docs, doc)
+	}
+
+	r.Documents = docs
+	return r
+}
@@ -0,0 +1,64 @@
+package threat
+
+import (
+	"hash/fnv"
+	"math"
+)
+
+// Bloom is a bloom filter. It never misses a domain that was added but
+// may, at its false positive rate, report one that wasn't.
+type Bloom struct {
+	bits []uint64
+	k    uint64
+}
+
+// NewBloom sizes a bloom filter for n domains with a false positive rate of p
+func NewBloom(n int, p float64) *Bloom {
+	if n < 1 {
+		n = 1
+	}
+
+	m := math.Ceil(-float64(n) * math.Log(p) / (math.Ln2 * math.Ln2))
+	k := math.Max(1, math.Round(m/float64(n)*math.Ln2))
+
+	return &Bloom{
+		bits: make([]uint64, (uint64(m)+63)/64),
+		k:    uint64(k),
+	}
+}
+
+// Add adds a domain to the filter
+func (b *Bloom) Add(s string) {
+	h1, h2 := hashes(s)
+	m := uint64(len(b.bits)) * 64
+	for i := uint64(0); i < b.k; i++ {
+		j := (h1 + i*h2) % m
+		b.bits[j/64] |= 1 << (j % 64)
+	}
+}
+
+// Has is true if the domain was probably added
+func (b *Bloom) Has(s string) bool {
+	h1, h2 := hashes(s)
+	m := uint64(len(b.bits)) * 64
+	for i := uint64(0); i < b.k; i++ {
+		j := (h1 + i*h2) % m
+		if b.bits[j/64]&(1<<(j%64)) == 0 {
+			return false
+		}
+	}
+
+	return true
+}
+
+// hashes are the two hashes we combine for each of our k positions
+// https://www.eecs.harvard.edu/~michaelm/postscripts/rsa2008.pdf
+func hashes(s string) (uint64, uint64) {
+	a := fnv.New64a()
+	a.Write([]byte(s))
+
+	b := fnv.New64()
+	b.Write([]byte(s))
+
+	return a.Sum64(), b.Sum64() | 1
+}
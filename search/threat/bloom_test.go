@@ -0,0 +1,30 @@
+package threat
+
+import (
+	"fmt"
+	"testing"
+)
+
+func TestBloom(t *testing.T) {
+	b := NewBloom(1000, 0.01)
+	for i := 0; i < 1000; i++ {
+		b.Add(fmt.Sprintf("bad%d.example.com", i))
+	}
+
+	for i := 0; i < 1000; i++ {
+		if d := fmt.Sprintf("bad%d.example.com", i); !b.Has(d) {
+			t.Fatalf("missed %v", d)
+		}
+	}
+
+	var fp int
+	for i := 0; i < 10000; i++ {
+		if b.Has(fmt.Sprintf("good%d.example.com", i)) {
+			fp++
+		}
+	}
+
+	if fp > 300 {
+		t.Fatalf("got %d false positives in 10000; want about 100", fp)
+	}
+}
@@ -0,0 +1,103 @@
+// Package threat flags urls on locally mirrored malware and phishing blocklists
+package threat
+
+import (
+	"bufio"
+	"io"
+	"net/url"
+	"strings"
+)
+
+// Kind is the kind of threat a blocklist is for
+type Kind string
+
+// Malware is a site that serves malicious software
+const Malware Kind = "malware"
+
+// Phishing is a site that tricks users into giving up their credentials
+const Phishing Kind = "phishing"
+
+// FalsePositives is how often a domain that isn't listed is flagged
+const FalsePositives = 1e-6
+
+// Feed holds a bloom filter of the domains on each kind of blocklist
+type Feed struct {
+	kinds   []Kind
+	filters map[Kind]*Bloom
+}
+
+// New creates an empty Feed
+func New() *Feed {
+	return &Feed{
+		filters: map[Kind]*Bloom{},
+	}
+}
+
+// Load replaces the kind's blocklist with the domains in the readers. Each line has a domain, a url or
+// is in the hosts file format. Blank lines and # comments are skipped.
+func (f *Feed) Load(kind Kind, rs ...io.Reader) error {
+	domains := []string{}
+	for _, r := range rs {
+		scanner := bufio.NewScanner(r)
+		for scanner.Scan() {
+			l := strings.TrimSpace(scanner.Text())
+			if l == "" || strings.HasPrefix(l, "#") {
+				continue
+			}
+
+			fields := strings.Fields(l)
+			if d := domain(fields[len(fields)-1]); d != "" {
+				domains = append(domains, d)
+			}
+		}
+
+		if err := scanner.Err(); err != nil {
+			return err
+		}
+	}
+
+	b := NewBloom(len(domains), FalsePositives)
+	for _, d := range domains {
+		b.Add(d)
+	}
+
+	if _, ok := f.filters[kind]; !ok {
+		f.kinds = append(f.kinds, kind)
+	}
+
+	f.filters[kind] = b
+	return nil
+}
+
+// Lookup is the kind of threat listed for the url's host or one of its parent domains
+func (f *Feed) Lookup(u string) (Kind, bool) {
+	host := domain(u)
+	for host != "" {
+		for _, k := range f.kinds {
+			if f.filters[k].Has(host) {
+				return k, true
+			}
+		}
+
+		i := strings.Index(host, ".")
+		if i == -1 {
+			break
+		}
+		host = host[i+1:]
+	}
+
+	return "", false
+}
+
+// domain is the lowercased host of a domain or url
+func domain(s string) string {
+	if strings.Contains(s, "://") {
+		u, err := url.Parse(s)
+		if err != nil {
+			return ""
+		}
+		s = u.Hostname()
+	}
+
+	return strings.Trim(strings.ToLower(s), ".")
+}
@@ -0,0 +1,50 @@
+package threat
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestLookup(t *testing.T) {
+	f := New()
+
+	malware := "# a hosts file\n0.0.0.0 malware.example.com\n\n127.0.0.1 Bad.Example.NET\n"
+	if err := f.Load(Malware, strings.NewReader(malware)); err != nil {
+		t.Fatal(err)
+	}
+
+	phishing := []string{"https://login.phish.example.org/account/verify\n", "bank-example.com\n"}
+	if err := f.Load(Phishing, strings.NewReader(phishing[0]), strings.NewReader(phishing[1])); err != nil {
+		t.Fatal(err)
+	}
+
+	for _, c := range []struct {
+		u    string
+		want Kind
+		ok   bool
+	}{
+		{"https://malware.example.com/download.exe", Malware, true},
+		{"http://cdn.bad.example.net", Malware, true},
+		{"https://login.phish.example.org/", Phishing, true},
+		{"https://www.bank-example.com/login", Phishing, true},
+		{"https://phish.example.org/", "", false},
+		{"https://example.com/", "", false},
+		{"https://www.wikipedia.org/", "", false},
+	} {
+		t.Run(c.u, func(t *testing.T) {
+			got, ok := f.Lookup(c.u)
+			if got != c.want || ok != c.ok {
+				t.Fatalf("got %q, %v; want %q, %v", got, ok, c.want, c.ok)
+			}
+		})
+	}
+
+	// a reload replaces the list
+	if err := f.Load(Malware, strings.NewReader("other.example.com")); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, ok := f.Lookup("https://malware.example.com"); ok {
+		t.Fatal("got malware.example.com after it was dropped from the list")
+	}
+}
@@ -0,0 +1,49 @@
+package search
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/jivesearch/jivesearch/search/document"
+	"github.com/jivesearch/jivesearch/search/threat"
+)
+
+func TestWarn(t *testing.T) {
+	feed := threat.New()
+	if err := feed.Load(threat.Malware, strings.NewReader("malware.com")); err != nil {
+		t.Fatal(err)
+	}
+	if err := feed.Load(threat.Phishing, strings.NewReader("https://login.phish.net/verify")); err != nil {
+		t.Fatal(err)
+	}
+
+	for _, c := range []struct {
+		name   string
+		filter bool
+		want   []string
+	}{
+		{"warn", false, []string{"https://a.com/", "https://www.malware.com/x:malware", "https://login.phish.net/:phishing", "https://c.net/"}},
+		{"filter", true, []string{"https://a.com/", "https://c.net/"}},
+	} {
+		t.Run(c.name, func(t *testing.T) {
+			r := &Results{}
+			for _, u := range []string{"https://a.com/", "https://www.malware.com/x", "https://login.phish.net/", "https://c.net/"} {
+				r.Documents = append(r.Documents, &document.Document{ID: u})
+			}
+
+			got := []string{}
+			for _, doc := range r.Warn(feed, c.filter).Documents {
+				if doc.Threat != "" {
+					got = append(got, doc.ID+":"+doc.Threat)
+					continue
+				}
+				got = append(got, doc.ID)
+			}
+
+			if !reflect.DeepEqual(got, c.want) {
+				t.Fatalf("got %+v; want %+v", got, c.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,32 @@
+// Package video searches for videos
+package video
+
+import (
+	"time"
+
+	"golang.org/x/text/language"
+)
+
+// Fetcher outlines the methods used to retrieve video results
+type Fetcher interface {
+	Fetch(q string, safe bool, lang language.Tag, number int) (*Results, error)
+}
+
+// Provider is a video source
+type Provider string
+
+// Results are the videos matching a query
+type Results struct {
+	Provider Provider `json:"provider"`
+	Videos   []*Video `json:"videos"`
+}
+
+// Video is a single video
+type Video struct {
+	ID        string    `json:"id"`
+	URL       string    `json:"url"`
+	Title     string    `json:"title"`
+	Channel   string    `json:"channel"`
+	Thumbnail string    `json:"thumbnail"`
+	Published time.Time `json:"published"`
+}
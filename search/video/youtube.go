@@ -0,0 +1,104 @@
+package video
+
+import (
+	"encoding/json"
+	"fmt"
+	"html"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"strconv"
+	"time"
+
+	"golang.org/x/text/language"
+)
+
+// YouTube holds settings for the YouTube Data API
+type YouTube struct {
+	Key        string
+	HTTPClient *http.Client
+}
+
+// YouTubeProvider is a video provider
+const YouTubeProvider Provider = "YouTube"
+
+// Fetch returns videos for a search query
+func (y *YouTube) Fetch(query string, safe bool, lang language.Tag, number int) (*Results, error) {
+	u, err := url.Parse("https://www.googleapis.com/youtube/v3/search")
+	if err != nil {
+		return nil, err
+	}
+
+	safeSearch := "moderate"
+	if !safe {
+		safeSearch = "none"
+	}
+
+	b, _ := lang.Base()
+
+	q := u.Query()
+	q.Set("key", y.Key)
+	q.Set("part", "snippet")
+	q.Set("type", "video")
+	q.Set("q", query)
+	q.Set("maxResults", strconv.Itoa(number))
+	q.Set("safeSearch", safeSearch)
+	q.Set("relevanceLanguage", b.String())
+	u.RawQuery = q.Encode()
+
+	resp, err := y.HTTPClient.Get(u.String())
+	if err != nil {
+		return nil, err
+	}
+
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		bdy, err := ioutil.ReadAll(resp.Body)
+		if err != nil {
+			return nil, err
+		}
+
+		return nil, fmt.Errorf("YouTube status: %d %q", resp.StatusCode, string(bdy))
+	}
+
+	yr := &struct {
+		Items []struct {
+			ID struct {
+				VideoID string `json:"videoId"`
+			} `json:"id"`
+			Snippet struct {
+				PublishedAt  time.Time `json:"publishedAt"`
+				Title        string    `json:"title"`
+				ChannelTitle string    `json:"channelTitle"`
+				Thumbnails   struct {
+					Medium struct {
+						URL string `json:"url"`
+					} `json:"medium"`
+				} `json:"thumbnails"`
+			} `json:"snippet"`
+		} `json:"items"`
+	}{}
+
+	if err := json.NewDecoder(resp.Body).Decode(yr); err != nil {
+		return nil, err
+	}
+
+	res := &Results{
+		Provider: YouTubeProvider,
+		Videos:   []*Video{},
+	}
+
+	for _, item := range yr.Items {
+		res.Videos = append(res.Videos, &Video{
+			ID:        item.ID.VideoID,
+			URL:       "https://www.youtube.com/watch?v=" + item.ID.VideoID,
+			Title:     html.UnescapeString(item.Snippet.Title), // titles come back with entities
+			Channel:   item.Snippet.ChannelTitle,
+			Thumbnail: item.Snippet.Thumbnails.Medium.URL,
+			Published: item.Snippet.PublishedAt,
+		})
+	}
+
+	return res, nil
+}
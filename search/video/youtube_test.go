@@ -0,0 +1,94 @@
+package video
+
+import (
+	"net/http"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/jarcoal/httpmock"
+	"golang.org/x/text/language"
+)
+
+func TestYouTubeFetch(t *testing.T) {
+	httpmock.Activate()
+	defer httpmock.DeactivateAndReset()
+
+	for _, c := range []struct {
+		name string
+		q    string
+		safe bool
+		u    string
+		resp string
+		want *Results
+	}{
+		{
+			name: "jimi hendrix",
+			q:    "jimi hendrix",
+			safe: true,
+			u:    "https://www.googleapis.com/youtube/v3/search?key=key&maxResults=2&part=snippet&q=jimi+hendrix&relevanceLanguage=en&safeSearch=moderate&type=video",
+			resp: `{
+				"kind": "youtube#searchListResponse",
+				"items": [
+					{
+						"kind": "youtube#searchResult",
+						"id": {"kind": "youtube#video", "videoId": "TLV4_xaYynY"},
+						"snippet": {
+							"publishedAt": "2013-03-08T15:00:01Z",
+							"title": "Jimi Hendrix - All Along The Watchtower (Official Audio)",
+							"channelTitle": "JimiHendrixVEVO",
+							"thumbnails": {"medium": {"url": "https://i.ytimg.com/vi/TLV4_xaYynY/mqdefault.jpg", "width": 320, "height": 180}}
+						}
+					},
+					{
+						"kind": "youtube#searchResult",
+						"id": {"kind": "youtube#video", "videoId": "cJunCsrhJjg"},
+						"snippet": {
+							"publishedAt": "2010-03-04T22:19:49Z",
+							"title": "Jimi Hendrix &quot;Purple Haze&quot;",
+							"channelTitle": "JimiHendrixVEVO",
+							"thumbnails": {"medium": {"url": "https://i.ytimg.com/vi/cJunCsrhJjg/mqdefault.jpg", "width": 320, "height": 180}}
+						}
+					}
+				]
+			}`,
+			want: &Results{
+				Provider: YouTubeProvider,
+				Videos: []*Video{
+					{
+						ID:        "TLV4_xaYynY",
+						URL:       "https://www.youtube.com/watch?v=TLV4_xaYynY",
+						Title:     "Jimi Hendrix - All Along The Watchtower (Official Audio)",
+						Channel:   "JimiHendrixVEVO",
+						Thumbnail: "https://i.ytimg.com/vi/TLV4_xaYynY/mqdefault.jpg",
+						Published: time.Date(2013, 3, 8, 15, 0, 1, 0, time.UTC),
+					},
+					{
+						ID:        "cJunCsrhJjg",
+						URL:       "https://www.youtube.com/watch?v=cJunCsrhJjg",
+						Title:     `Jimi Hendrix "Purple Haze"`,
+						Channel:   "JimiHendrixVEVO",
+						Thumbnail: "https://i.ytimg.com/vi/cJunCsrhJjg/mqdefault.jpg",
+						Published: time.Date(2010, 3, 4, 22, 19, 49, 0, time.UTC),
+					},
+				},
+			},
+		},
+	} {
+		t.Run(c.name, func(t *testing.T) {
+			httpmock.RegisterResponder("GET", c.u, httpmock.NewStringResponder(200, c.resp))
+
+			y := &YouTube{Key: "key", HTTPClient: &http.Client{}}
+			got, err := y.Fetch(c.q, c.safe, language.English, 2)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if !reflect.DeepEqual(got, c.want) {
+				t.Fatalf("got %+v; want %+v", got, c.want)
+			}
+		})
+	}
+
+	httpmock.Reset()
+}
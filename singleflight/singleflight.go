@@ -0,0 +1,71 @@
+// Package singleflight lets concurrent callers asking for the same thing share one call,
+// e.g. the many users searching for a popular query before its results are cached.
+package singleflight
+
+import (
+	"errors"
+	"sync"
+)
+
+// ErrPanicked is what the callers waiting on a call get if it panics
+var ErrPanicked = errors.New("singleflight: the shared call panicked")
+
+// Group coalesces calls by key. The zero value is ready to use and a nil Group doesn't coalesce.
+type Group struct {
+	mu sync.Mutex
+	m  map[string]*call
+}
+
+type call struct {
+	wg   sync.WaitGroup
+	val  interface{}
+	err  error
+	dups int
+}
+
+// Do calls fn unless a call for the key is already in flight, in which case it waits
+// for that call and returns its result. shared is true if more than one caller got it,
+// so they know to copy anything they intend to change.
+func (g *Group) Do(key string, fn func() (interface{}, error)) (v interface{}, err error, shared bool) {
+	if g == nil {
+		v, err = fn()
+		return v, err, false
+	}
+
+	g.mu.Lock()
+	if g.m == nil {
+		g.m = map[string]*call{}
+	}
+
+	if c, ok := g.m[key]; ok {
+		c.dups++
+		g.mu.Unlock()
+		c.wg.Wait()
+		return c.val, c.err, true
+	}
+
+	c := &call{err: ErrPanicked}
+	c.wg.Add(1)
+	g.m[key] = c
+	g.mu.Unlock()
+
+	g.do(key, c, fn)
+
+	g.mu.Lock()
+	shared = c.dups > 0
+	g.mu.Unlock()
+
+	return c.val, c.err, shared
+}
+
+// do makes the call. It is forgotten once done, even if fn panics, so the next caller starts afresh.
+func (g *Group) do(key string, c *call, fn func() (interface{}, error)) {
+	defer func() {
+		g.mu.Lock()
+		delete(g.m, key)
+		g.mu.Unlock()
+		c.wg.Done()
+	}()
+
+	c.val, c.err = fn()
+}
@@ -0,0 +1,135 @@
+package singleflight
+
+import (
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+)
+
+// waiting is how many callers are waiting on the call for key
+func (g *Group) waiting(key string) int {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	if c, ok := g.m[key]; ok {
+		return c.dups
+	}
+	return -1
+}
+
+func (g *Group) waitFor(t *testing.T, key string, n int) {
+	for i := 0; g.waiting(key) != n; i++ {
+		if i == 1000 {
+			t.Fatalf("got %d callers waiting; want %d", g.waiting(key), n)
+		}
+		time.Sleep(time.Millisecond)
+	}
+}
+
+func TestDo(t *testing.T) {
+	g := &Group{}
+
+	var calls int
+	release := make(chan struct{})
+	fn := func() (interface{}, error) {
+		calls++
+		<-release
+		return "jimi hendrix", nil
+	}
+
+	type result struct {
+		v      interface{}
+		err    error
+		shared bool
+	}
+
+	n := 5
+	results := make(chan result, n)
+
+	var wg sync.WaitGroup
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		v, err, shared := g.Do("key", fn)
+		results <- result{v, err, shared}
+	}()
+
+	g.waitFor(t, "key", 0) // the first caller is in flight
+
+	for i := 1; i < n; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			v, err, shared := g.Do("key", fn)
+			results <- result{v, err, shared}
+		}()
+	}
+
+	g.waitFor(t, "key", n-1)
+	close(release)
+	wg.Wait()
+	close(results)
+
+	if calls != 1 {
+		t.Fatalf("got %d calls; want 1", calls)
+	}
+
+	for r := range results {
+		if r.v != "jimi hendrix" || r.err != nil || !r.shared {
+			t.Fatalf("got %+v; want the shared result", r)
+		}
+	}
+
+	// the call is forgotten once done
+	v, err, shared := g.Do("key", func() (interface{}, error) { return "bob dylan", nil })
+	if v != "bob dylan" || err != nil || shared {
+		t.Fatalf("got %v, %v, %v; want a fresh call", v, err, shared)
+	}
+}
+
+func TestDoNil(t *testing.T) {
+	var g *Group
+
+	want := fmt.Errorf("an error")
+	v, err, shared := g.Do("key", func() (interface{}, error) { return 1, want })
+	if v != 1 || err != want || shared {
+		t.Fatalf("got %v, %v, %v; want 1, %v, false", v, err, shared, want)
+	}
+}
+
+func TestDoPanic(t *testing.T) {
+	g := &Group{}
+
+	release := make(chan struct{})
+	done := make(chan struct{})
+
+	go func() {
+		defer close(done)
+		defer func() {
+			if recover() == nil {
+				t.Error("expected the caller to panic")
+			}
+		}()
+		g.Do("key", func() (interface{}, error) {
+			<-release
+			panic("boom")
+		})
+	}()
+
+	g.waitFor(t, "key", 0)
+
+	errs := make(chan error, 1)
+	go func() {
+		_, err, _ := g.Do("key", func() (interface{}, error) { return nil, nil })
+		errs <- err
+	}()
+
+	g.waitFor(t, "key", 1)
+	close(release)
+	<-done
+
+	if err := <-errs; err != ErrPanicked {
+		t.Fatalf("got %v; want %v", err, ErrPanicked)
+	}
+}
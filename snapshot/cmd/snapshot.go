@@ -0,0 +1,156 @@
+// Command snapshot backs up and restores our search, image and query suggestion indices
+// and our wikipedia tables.
+//
+//	snapshot create [name]
+//	snapshot restore name
+//	snapshot list
+package main
+
+import (
+	"database/sql"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/jivesearch/jivesearch/config"
+	"github.com/jivesearch/jivesearch/log"
+	"github.com/jivesearch/jivesearch/snapshot"
+	"github.com/olivere/elastic"
+	"github.com/spf13/afero"
+	"github.com/spf13/viper"
+)
+
+const usage = "usage: snapshot create [name] | restore name | list"
+
+func setup(v *viper.Viper) {
+	v.SetEnvPrefix("jivesearch")
+	v.AutomaticEnv()
+	v.SetEnvKeyReplacer(strings.NewReplacer(".", "_"))
+	config.SetDefaults(v)
+
+	if v.GetBool("debug") {
+		log.Debug.SetOutput(os.Stdout)
+	}
+}
+
+// indices are the Elasticsearch indices we snapshot
+func indices(v *viper.Viper) []string {
+	return []string{
+		v.GetString("elasticsearch.search.index") + "-*", // one per language
+		v.GetString("elasticsearch.image.index"),
+		v.GetString("elasticsearch.query.index"),
+	}
+}
+
+func run(s *snapshot.Snapshot, v *viper.Viper, args []string, w io.Writer) error {
+	if len(args) == 0 {
+		return fmt.Errorf(usage)
+	}
+
+	switch args[0] {
+	case "create":
+		name := snapshot.Name()
+		if len(args) > 1 {
+			name = args[1]
+		}
+
+		if err := s.Elasticsearch.Setup(); err != nil {
+			return err
+		}
+
+		m, err := s.Create(name, indices(v), v.GetStringSlice("snapshot.tables"))
+		if err != nil {
+			return err
+		}
+		summarize(w, m)
+	case "restore":
+		if len(args) < 2 {
+			return fmt.Errorf(usage)
+		}
+
+		if err := s.Elasticsearch.Setup(); err != nil {
+			return err
+		}
+
+		m, err := s.Restore(args[1])
+		if err != nil {
+			return err
+		}
+		summarize(w, m)
+	case "list":
+		manifests, err := s.List()
+		if err != nil {
+			return err
+		}
+
+		for _, m := range manifests {
+			summarize(w, m)
+		}
+	default:
+		return fmt.Errorf(usage)
+	}
+
+	return nil
+}
+
+func summarize(w io.Writer, m *snapshot.Manifest) {
+	var idx, es string
+	if m.Elasticsearch != nil {
+		idx, es = strings.Join(m.Elasticsearch.Indices, ","), m.Elasticsearch.Version
+	}
+
+	tables := []string{}
+	for _, t := range m.Tables {
+		tables = append(tables, fmt.Sprintf("%v (%d rows)", t.Name, t.Rows))
+	}
+
+	fmt.Fprintf(w, "%v\tcreated: %v\tversion: %d\telasticsearch %v: %v\ttables: %v\n",
+		m.Name, m.Created.Format("2006-01-02 15:04:05"), m.Version, es, idx, strings.Join(tables, ", "))
+}
+
+func main() {
+	v := viper.New()
+	setup(v)
+
+	client, err := elastic.NewClient(elastic.SetURL(v.GetString("elasticsearch.url")), elastic.SetSniff(false))
+	if err != nil {
+		panic(err)
+	}
+
+	settings := map[string]interface{}{"location": v.GetString("snapshot.location")}
+	if v.GetString("snapshot.type") == "s3" {
+		settings = map[string]interface{}{"bucket": v.GetString("snapshot.location")}
+	}
+
+	db, err := sql.Open("postgres",
+		fmt.Sprintf(
+			"user=%s password=%s host=%s database=%s sslmode=disable",
+			v.GetString("postgresql.user"),
+			v.GetString("postgresql.password"),
+			v.GetString("postgresql.host"),
+			v.GetString("postgresql.database"),
+		),
+	)
+	if err != nil {
+		panic(err)
+	}
+
+	defer db.Close()
+
+	s := &snapshot.Snapshot{
+		Elasticsearch: &snapshot.ElasticSearch{
+			Client:     client,
+			Repository: v.GetString("snapshot.repository"),
+			Type:       v.GetString("snapshot.type"),
+			Settings:   settings,
+		},
+		PostgreSQL: &snapshot.PostgreSQL{DB: db},
+		Fs:         afero.NewOsFs(),
+		Dir:        v.GetString("snapshot.dir"),
+	}
+
+	if err := run(s, v, os.Args[1:], os.Stdout); err != nil {
+		log.Info.Fatalln(err)
+	}
+}
@@ -0,0 +1,43 @@
+package main
+
+import (
+	"bytes"
+	"reflect"
+	"testing"
+
+	"github.com/jivesearch/jivesearch/snapshot"
+	"github.com/spf13/afero"
+	"github.com/spf13/viper"
+)
+
+func TestSetup(t *testing.T) {
+	v := viper.New()
+	setup(v)
+
+	want := []string{"test-search-*", "test-images", "test-queries"}
+	if got := indices(v); !reflect.DeepEqual(got, want) {
+		t.Fatalf("got %+v; want %+v", got, want)
+	}
+}
+
+func TestRun(t *testing.T) {
+	v := viper.New()
+	setup(v)
+
+	s := &snapshot.Snapshot{Fs: afero.NewMemMapFs(), Dir: "snapshots"}
+
+	for _, args := range [][]string{{}, {"restore"}, {"unknown"}} {
+		if err := run(s, v, args, &bytes.Buffer{}); err == nil || err.Error() != usage {
+			t.Fatalf("%v: got %v; want %v", args, err, usage)
+		}
+	}
+
+	w := &bytes.Buffer{}
+	if err := run(s, v, []string{"list"}, w); err != nil {
+		t.Fatal(err)
+	}
+
+	if w.Len() != 0 {
+		t.Fatalf("got %q; want no snapshots", w.String())
+	}
+}
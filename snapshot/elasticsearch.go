@@ -0,0 +1,96 @@
+package snapshot
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"strings"
+
+	"github.com/olivere/elastic"
+)
+
+// ElasticSearch snapshots our indices to an Elasticsearch snapshot repository.
+// An "fs" repository's location must be listed in path.repo of each node and
+// an "s3" repository requires the repository-s3 plugin.
+type ElasticSearch struct {
+	Client     *elastic.Client
+	Repository string
+	Type       string                 // fs or s3
+	Settings   map[string]interface{} // e.g. {"location": "/mnt/backups"} or {"bucket": "jivesearch"}
+}
+
+// Indices are the indices in a snapshot along with the
+// Elasticsearch version that took it and their mappings
+type Indices struct {
+	Repository string                 `json:"repository"`
+	Snapshot   string                 `json:"snapshot"`
+	Version    string                 `json:"version"`
+	Indices    []string               `json:"indices"`
+	Mappings   map[string]interface{} `json:"mappings"`
+}
+
+// Setup registers our snapshot repository
+func (e *ElasticSearch) Setup() error {
+	_, err := e.Client.SnapshotCreateRepository(e.Repository).
+		Type(e.Type).
+		Settings(e.Settings).
+		Do(context.TODO())
+
+	return err
+}
+
+// Create snapshots the indices. Wildcards like "search-*" are allowed.
+func (e *ElasticSearch) Create(name string, indices []string) (*Indices, error) {
+	mappings, err := e.Client.GetMapping().Index(indices...).Do(context.TODO())
+	if err != nil {
+		return nil, err
+	}
+
+	res, err := e.Client.SnapshotCreate(e.Repository, name).
+		WaitForCompletion(true).
+		BodyJson(map[string]interface{}{
+			"indices":              strings.Join(indices, ","),
+			"ignore_unavailable":   true,
+			"include_global_state": false,
+		}).
+		Do(context.TODO())
+
+	if err != nil {
+		return nil, err
+	}
+
+	if res.Snapshot == nil || res.Snapshot.State != "SUCCESS" {
+		return nil, fmt.Errorf("elasticsearch snapshot %q failed: %+v", name, res.Snapshot)
+	}
+
+	return &Indices{
+		Repository: e.Repository,
+		Snapshot:   name,
+		Version:    res.Snapshot.Version,
+		Indices:    res.Snapshot.Indices,
+		Mappings:   mappings,
+	}, nil
+}
+
+// Restore deletes our copies of the indices and restores them from the snapshot.
+// Elasticsearch refuses snapshots taken by a newer major version.
+func (e *ElasticSearch) Restore(idx *Indices) error {
+	for _, index := range idx.Indices {
+		if _, err := e.Client.DeleteIndex(index).Do(context.TODO()); err != nil && !elastic.IsNotFound(err) {
+			return err
+		}
+	}
+
+	_, err := e.Client.PerformRequest(context.TODO(), elastic.PerformRequestOptions{
+		Method: "POST",
+		Path: fmt.Sprintf("/_snapshot/%v/%v/_restore",
+			url.PathEscape(idx.Repository), url.PathEscape(idx.Snapshot)),
+		Params: url.Values{"wait_for_completion": []string{"true"}},
+		Body: map[string]interface{}{
+			"indices":              strings.Join(idx.Indices, ","),
+			"include_global_state": false,
+		},
+	})
+
+	return err
+}
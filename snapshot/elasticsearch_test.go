@@ -0,0 +1,106 @@
+package snapshot
+
+import (
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/olivere/elastic"
+)
+
+// mockElasticsearch records the requests it gets and answers them like Elasticsearch would
+func mockElasticsearch(t *testing.T, requests *[]string) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		b, err := ioutil.ReadAll(r.Body)
+		if err != nil {
+			t.Fatal(err)
+		}
+		*requests = append(*requests, r.Method+" "+r.URL.Path+" "+string(b))
+
+		var resp string
+		switch {
+		case r.Method == "GET" && strings.Contains(r.URL.Path, "/_mapping"):
+			resp = `{"search-english": {"mappings": {"document": {}}}, "images": {"mappings": {"image": {}}}}`
+		case r.Method == "PUT" && r.URL.Path == "/_snapshot/backups/nightly":
+			resp = `{"snapshot": {"snapshot": "nightly", "version": "6.2.4", "indices": ["search-english", "images"], "state": "SUCCESS"}}`
+		case r.Method == "DELETE" && r.URL.Path == "/images":
+			w.WriteHeader(http.StatusNotFound)
+			resp = `{"error": {"type": "index_not_found_exception"}, "status": 404}`
+		default:
+			resp = `{"acknowledged": true}`
+		}
+
+		if _, err := w.Write([]byte(resp)); err != nil {
+			t.Fatal(err)
+		}
+	}))
+}
+
+func mockElasticSearch(url string) (*ElasticSearch, error) {
+	client, err := elastic.NewSimpleClient(elastic.SetURL(url))
+	if err != nil {
+		return nil, err
+	}
+
+	return &ElasticSearch{
+		Client:     client,
+		Repository: "backups",
+		Type:       "fs",
+		Settings:   map[string]interface{}{"location": "/mnt/backups"},
+	}, nil
+}
+
+var nightly = &Indices{
+	Repository: "backups",
+	Snapshot:   "nightly",
+	Version:    "6.2.4",
+	Indices:    []string{"search-english", "images"},
+	Mappings: map[string]interface{}{
+		"search-english": map[string]interface{}{"mappings": map[string]interface{}{"document": map[string]interface{}{}}},
+		"images":         map[string]interface{}{"mappings": map[string]interface{}{"image": map[string]interface{}{}}},
+	},
+}
+
+func TestElasticSearch(t *testing.T) {
+	requests := []string{}
+	ts := mockElasticsearch(t, &requests)
+	defer ts.Close()
+
+	e, err := mockElasticSearch(ts.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err := e.Setup(); err != nil {
+		t.Fatal(err)
+	}
+
+	got, err := e.Create("nightly", []string{"search-*", "images"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !reflect.DeepEqual(got, nightly) {
+		t.Fatalf("got %+v; want %+v", got, nightly)
+	}
+
+	if err := e.Restore(got); err != nil {
+		t.Fatal(err)
+	}
+
+	want := []string{
+		`PUT /_snapshot/backups {"settings":{"location":"/mnt/backups"},"type":"fs"}`,
+		`GET /search-*,images/_mapping/_all `,
+		`PUT /_snapshot/backups/nightly {"ignore_unavailable":true,"include_global_state":false,"indices":"search-*,images"}`,
+		`DELETE /search-english `,
+		`DELETE /images `,
+		`POST /_snapshot/backups/nightly/_restore {"include_global_state":false,"indices":"search-english,images"}`,
+	}
+
+	if !reflect.DeepEqual(requests, want) {
+		t.Fatalf("got %q; want %q", requests, want)
+	}
+}
@@ -0,0 +1,311 @@
+package snapshot
+
+import (
+	"compress/gzip"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/lib/pq"
+)
+
+// PostgreSQL dumps tables as gzipped json lines, one array of column values per row
+type PostgreSQL struct {
+	*sql.DB
+}
+
+// Table is the schema of a table in a snapshot
+type Table struct {
+	Name       string   `json:"name"`
+	Columns    []Column `json:"columns"`
+	PrimaryKey string   `json:"primary_key,omitempty"` // e.g. PRIMARY KEY (pk)
+	Indexes    []string `json:"indexes,omitempty"`     // CREATE INDEX statements
+	Rows       int64    `json:"rows"`
+	File       string   `json:"file"`
+}
+
+// Column is a column of a table
+type Column struct {
+	Name    string `json:"name"`
+	Type    string `json:"type"` // e.g. text[] or jsonb
+	NotNull bool   `json:"not_null,omitempty"`
+	Serial  bool   `json:"serial,omitempty"`
+}
+
+// Tables returns the schema of the tables that match any of the LIKE patterns
+func (p *PostgreSQL) Tables(patterns []string) ([]*Table, error) {
+	tables := []*Table{}
+
+	rows, err := p.DB.Query(`SELECT tablename FROM pg_tables
+		WHERE schemaname = 'public' AND tablename LIKE ANY($1)
+		ORDER BY tablename`, pq.Array(patterns))
+	if err != nil {
+		return tables, err
+	}
+
+	defer rows.Close()
+
+	for rows.Next() {
+		t := &Table{}
+		if err := rows.Scan(&t.Name); err != nil {
+			return tables, err
+		}
+		tables = append(tables, t)
+	}
+
+	if err := rows.Err(); err != nil {
+		return tables, err
+	}
+
+	for _, t := range tables {
+		if err := p.schema(t); err != nil {
+			return tables, err
+		}
+	}
+
+	return tables, nil
+}
+
+func (p *PostgreSQL) schema(t *Table) error {
+	rows, err := p.DB.Query(`SELECT a.attname, format_type(a.atttypid, a.atttypmod), a.attnotnull,
+			COALESCE(pg_get_expr(d.adbin, d.adrelid), '') LIKE 'nextval(%'
+		FROM pg_attribute a
+		LEFT JOIN pg_attrdef d ON d.adrelid = a.attrelid AND d.adnum = a.attnum
+		WHERE a.attrelid = $1::regclass AND a.attnum > 0 AND NOT a.attisdropped
+		ORDER BY a.attnum`, t.Name)
+	if err != nil {
+		return err
+	}
+
+	defer rows.Close()
+
+	for rows.Next() {
+		c := Column{}
+		if err := rows.Scan(&c.Name, &c.Type, &c.NotNull, &c.Serial); err != nil {
+			return err
+		}
+		t.Columns = append(t.Columns, c)
+	}
+
+	if err := rows.Err(); err != nil {
+		return err
+	}
+
+	err = p.DB.QueryRow(`SELECT COALESCE(MAX(pg_get_constraintdef(oid)), '') FROM pg_constraint
+		WHERE conrelid = $1::regclass AND contype = 'p'`, t.Name).Scan(&t.PrimaryKey)
+	if err != nil {
+		return err
+	}
+
+	idx, err := p.DB.Query(`SELECT pg_get_indexdef(indexrelid) FROM pg_index
+		WHERE indrelid = $1::regclass AND NOT indisprimary
+		ORDER BY indexrelid`, t.Name)
+	if err != nil {
+		return err
+	}
+
+	defer idx.Close()
+
+	for idx.Next() {
+		var def string
+		if err := idx.Scan(&def); err != nil {
+			return err
+		}
+		t.Indexes = append(t.Indexes, def)
+	}
+
+	return idx.Err()
+}
+
+func (t *Table) columnNames() []string {
+	names := []string{}
+	for _, c := range t.Columns {
+		names = append(names, c.Name)
+	}
+	return names
+}
+
+// Dump writes the rows of a table. Every value is kept in its text representation.
+func (p *PostgreSQL) Dump(t *Table, w io.Writer) error {
+	cols := []string{}
+	for _, c := range t.columnNames() {
+		cols = append(cols, pq.QuoteIdentifier(c))
+	}
+
+	rows, err := p.DB.Query(fmt.Sprintf(`SELECT %v FROM %v`,
+		strings.Join(cols, ", "), pq.QuoteIdentifier(t.Name)))
+	if err != nil {
+		return err
+	}
+
+	defer rows.Close()
+
+	gz := gzip.NewWriter(w)
+	enc := json.NewEncoder(gz)
+
+	vals := make([]sql.NullString, len(cols))
+	dest := make([]interface{}, len(cols))
+	for i := range vals {
+		dest[i] = &vals[i]
+	}
+
+	t.Rows = 0
+	for rows.Next() {
+		if err := rows.Scan(dest...); err != nil {
+			return err
+		}
+
+		row := make([]*string, len(vals))
+		for i, v := range vals {
+			if v.Valid {
+				s := v.String
+				row[i] = &s
+			}
+		}
+
+		if err := enc.Encode(row); err != nil {
+			return err
+		}
+		t.Rows++
+	}
+
+	if err := rows.Err(); err != nil {
+		return err
+	}
+
+	return gz.Close()
+}
+
+// Restore recreates a table from its schema and dumped rows, replacing our copy
+func (p *PostgreSQL) Restore(t *Table, r io.Reader) (err error) {
+	gz, err := gzip.NewReader(r)
+	if err != nil {
+		return err
+	}
+
+	tx, err := p.DB.Begin()
+	if err != nil {
+		return err
+	}
+
+	defer func() {
+		if err != nil {
+			tx.Rollback()
+			return
+		}
+		err = tx.Commit()
+	}()
+
+	name := pq.QuoteIdentifier(t.Name)
+
+	if _, err = tx.Exec(fmt.Sprintf(`DROP TABLE IF EXISTS %v`, name)); err != nil {
+		return err
+	}
+
+	if _, err = tx.Exec(t.createTable()); err != nil {
+		return err
+	}
+
+	stmt, err := tx.Prepare(pq.CopyIn(t.Name, t.columnNames()...))
+	if err != nil {
+		return err
+	}
+
+	var n int64
+	dec := json.NewDecoder(gz)
+	for {
+		row := []*string{}
+		if err = dec.Decode(&row); err == io.EOF {
+			break
+		}
+		if err != nil {
+			stmt.Close()
+			return err
+		}
+
+		if len(row) != len(t.Columns) {
+			stmt.Close()
+			return fmt.Errorf("%v row %d has %d columns; want %d", t.Name, n+1, len(row), len(t.Columns))
+		}
+
+		vals := make([]interface{}, len(row))
+		for i, v := range row {
+			if v != nil {
+				vals[i] = *v
+			}
+		}
+
+		if _, err = stmt.Exec(vals...); err != nil {
+			stmt.Close()
+			return err
+		}
+		n++
+	}
+
+	if _, err = stmt.Exec(); err != nil {
+		stmt.Close()
+		return err
+	}
+
+	if err = stmt.Close(); err != nil {
+		return err
+	}
+
+	if n != t.Rows {
+		return fmt.Errorf("restored %d rows to %v; want %d", n, t.Name, t.Rows)
+	}
+
+	if t.PrimaryKey != "" {
+		if _, err = tx.Exec(fmt.Sprintf(`ALTER TABLE %v ADD %v`, name, t.PrimaryKey)); err != nil {
+			return err
+		}
+	}
+
+	for _, idx := range t.Indexes {
+		if _, err = tx.Exec(idx); err != nil {
+			return err
+		}
+	}
+
+	// continue serial columns after the restored values
+	for _, c := range t.Columns {
+		if !c.Serial {
+			continue
+		}
+
+		_, err = tx.Exec(fmt.Sprintf(`SELECT setval(pg_get_serial_sequence($1, $2), COALESCE(MAX(%v), 0) + 1, false) FROM %v`,
+			pq.QuoteIdentifier(c.Name), name), t.Name, c.Name)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (t *Table) createTable() string {
+	cols := []string{}
+	for _, c := range t.Columns {
+		typ := c.Type
+		if c.Serial {
+			switch typ {
+			case "bigint":
+				typ = "bigserial"
+			case "smallint":
+				typ = "smallserial"
+			default:
+				typ = "serial"
+			}
+		}
+
+		col := fmt.Sprintf("%v %v", pq.QuoteIdentifier(c.Name), typ)
+		if c.NotNull {
+			col += " NOT NULL"
+		}
+		cols = append(cols, col)
+	}
+
+	return fmt.Sprintf(`CREATE TABLE %v (%v)`, pq.QuoteIdentifier(t.Name), strings.Join(cols, ", "))
+}
@@ -0,0 +1,156 @@
+package snapshot
+
+import (
+	"bytes"
+	"database/sql/driver"
+	"reflect"
+	"testing"
+
+	sqlmock "gopkg.in/DATA-DOG/go-sqlmock.v2"
+)
+
+var enwikipedia = &Table{
+	Name: "enwikipedia",
+	Columns: []Column{
+		{Name: "pk", Type: "integer", NotNull: true, Serial: true},
+		{Name: "title", Type: "text", NotNull: true},
+		{Name: "heading", Type: "text[]"},
+	},
+	PrimaryKey: "PRIMARY KEY (pk)",
+	Indexes:    []string{"CREATE INDEX enwikipedia_title ON public.enwikipedia USING btree (lower(title))"},
+}
+
+func mockTables(mock sqlmock.Sqlmock) {
+	mock.ExpectQuery(`SELECT tablename FROM pg_tables`).
+		WithArgs(`{"%wikipedia"}`).
+		WillReturnRows(sqlmock.NewRows([]string{"tablename"}).AddRow("enwikipedia"))
+
+	mock.ExpectQuery(`SELECT a.attname`).WithArgs("enwikipedia").
+		WillReturnRows(sqlmock.NewRows([]string{"attname", "format_type", "attnotnull", "serial"}).
+			AddRow("pk", "integer", true, true).
+			AddRow("title", "text", true, false).
+			AddRow("heading", "text[]", false, false),
+		)
+
+	mock.ExpectQuery(`SELECT COALESCE\(MAX\(pg_get_constraintdef`).WithArgs("enwikipedia").
+		WillReturnRows(sqlmock.NewRows([]string{"def"}).AddRow("PRIMARY KEY (pk)"))
+
+	mock.ExpectQuery(`SELECT pg_get_indexdef`).WithArgs("enwikipedia").
+		WillReturnRows(sqlmock.NewRows([]string{"def"}).AddRow(enwikipedia.Indexes[0]))
+}
+
+func mockDump(mock sqlmock.Sqlmock) {
+	mock.ExpectQuery(`SELECT "pk", "title", "heading" FROM "enwikipedia"`).
+		WillReturnRows(sqlmock.NewRows([]string{"pk", "title", "heading"}).
+			AddRow(int64(1), "Jimi Hendrix", []byte("{Early life,Career}")).
+			AddRow(int64(2), "Bob Dylan", nil),
+		)
+}
+
+func mockRestore(mock sqlmock.Sqlmock) {
+	mock.ExpectBegin()
+	mock.ExpectExec(`DROP TABLE IF EXISTS "enwikipedia"`).WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectExec(`CREATE TABLE "enwikipedia" \("pk" serial NOT NULL, "title" text NOT NULL, "heading" text\[\]\)`).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+
+	prep := mock.ExpectPrepare(`COPY "enwikipedia" \("pk", "title", "heading"\) FROM STDIN`)
+	prep.ExpectExec().WithArgs("1", "Jimi Hendrix", "{Early life,Career}").WillReturnResult(sqlmock.NewResult(0, 1))
+	prep.ExpectExec().WithArgs("2", "Bob Dylan", nil).WillReturnResult(sqlmock.NewResult(0, 1))
+	prep.ExpectExec().WillReturnResult(sqlmock.NewResult(0, 0))
+
+	mock.ExpectExec(`ALTER TABLE "enwikipedia" ADD PRIMARY KEY \(pk\)`).WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectExec(`CREATE INDEX enwikipedia_title`).WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectExec(`SELECT setval`).WithArgs("enwikipedia", "pk").WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectCommit()
+}
+
+func TestTables(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+
+	mockTables(mock)
+
+	p := &PostgreSQL{DB: db}
+	got, err := p.Tables([]string{"%wikipedia"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if want := []*Table{enwikipedia}; !reflect.DeepEqual(got, want) {
+		t.Fatalf("got %+v; want %+v", got[0], want[0])
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestDumpAndRestore(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+
+	mockDump(mock)
+	mockRestore(mock)
+
+	p := &PostgreSQL{DB: db}
+	tbl := *enwikipedia
+	buf := &bytes.Buffer{}
+
+	if err := p.Dump(&tbl, buf); err != nil {
+		t.Fatal(err)
+	}
+
+	if tbl.Rows != 2 {
+		t.Fatalf("got %d rows; want 2", tbl.Rows)
+	}
+
+	if err := p.Restore(&tbl, buf); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestRestoreRowCount(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+
+	mockDump(mock)
+
+	p := &PostgreSQL{DB: db}
+	tbl := *enwikipedia
+	buf := &bytes.Buffer{}
+
+	if err := p.Dump(&tbl, buf); err != nil {
+		t.Fatal(err)
+	}
+
+	mock.ExpectBegin()
+	mock.ExpectExec(`DROP TABLE`).WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectExec(`CREATE TABLE`).WillReturnResult(sqlmock.NewResult(0, 0))
+	prep := mock.ExpectPrepare(`COPY`)
+	for i := 0; i < 3; i++ {
+		prep.ExpectExec().WillReturnResult(driver.ResultNoRows)
+	}
+	mock.ExpectRollback()
+
+	tbl.Rows = 3
+	if err := p.Restore(&tbl, buf); err == nil {
+		t.Fatal("expected an error for a missing row")
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Fatal(err)
+	}
+}
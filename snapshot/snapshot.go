@@ -0,0 +1,206 @@
+// Package snapshot backs up and restores our Elasticsearch indices and PostgreSQL tables
+package snapshot
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"time"
+
+	"github.com/spf13/afero"
+)
+
+// Version is the format of our snapshots.
+// It changes whenever an older version can no longer restore a snapshot.
+const Version = 1
+
+const manifestFile = "manifest.json"
+
+// Manifest describes what a snapshot holds so an instance can be rebuilt or migrated from it
+type Manifest struct {
+	Version       int       `json:"version"`
+	Name          string    `json:"name"`
+	Created       time.Time `json:"created"`
+	Elasticsearch *Indices  `json:"elasticsearch,omitempty"`
+	Tables        []*Table  `json:"tables,omitempty"`
+}
+
+// Snapshot takes and restores snapshots. Elasticsearch writes the indices to its own
+// snapshot repository (a shared filesystem or S3) while the PostgreSQL tables
+// and the manifest are written to a directory of their own in Dir.
+type Snapshot struct {
+	Elasticsearch *ElasticSearch
+	PostgreSQL    *PostgreSQL
+	Fs            afero.Fs
+	Dir           string
+}
+
+var now = func() time.Time { return time.Now().UTC() }
+
+// Elasticsearch only allows lowercase snapshot names
+var validName = regexp.MustCompile(`^[a-z0-9][a-z0-9_.-]*$`)
+
+// Name is the default name of a snapshot taken now
+func Name() string {
+	return now().Format("20060102150405")
+}
+
+// Create takes a snapshot of the indices and of the tables
+// that match the patterns, e.g. "%wikipedia" for all languages.
+func (s *Snapshot) Create(name string, indices, tables []string) (*Manifest, error) {
+	if !validName.MatchString(name) {
+		return nil, fmt.Errorf("invalid snapshot name %q", name)
+	}
+
+	dir := filepath.Join(s.Dir, name)
+	if ok, err := afero.DirExists(s.Fs, dir); err != nil || ok {
+		if err == nil {
+			err = fmt.Errorf("snapshot %q already exists", name)
+		}
+		return nil, err
+	}
+
+	if err := s.Fs.MkdirAll(dir, os.ModePerm); err != nil {
+		return nil, err
+	}
+
+	m := &Manifest{
+		Version: Version,
+		Name:    name,
+		Created: now(),
+	}
+
+	var err error
+
+	if s.Elasticsearch != nil && len(indices) > 0 {
+		if m.Elasticsearch, err = s.Elasticsearch.Create(name, indices); err != nil {
+			return nil, err
+		}
+	}
+
+	if s.PostgreSQL != nil && len(tables) > 0 {
+		if m.Tables, err = s.PostgreSQL.Tables(tables); err != nil {
+			return nil, err
+		}
+
+		for _, t := range m.Tables {
+			t.File = t.Name + ".json.gz"
+			if err := s.dump(t, filepath.Join(dir, t.File)); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	b, err := json.MarshalIndent(m, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+
+	return m, afero.WriteFile(s.Fs, filepath.Join(dir, manifestFile), b, 0644)
+}
+
+func (s *Snapshot) dump(t *Table, pth string) (err error) {
+	f, err := s.Fs.Create(pth)
+	if err != nil {
+		return err
+	}
+
+	defer func() {
+		if e := f.Close(); err == nil {
+			err = e
+		}
+	}()
+
+	return s.PostgreSQL.Dump(t, f)
+}
+
+// Restore replaces our indices and tables with the ones in a snapshot
+func (s *Snapshot) Restore(name string) (*Manifest, error) {
+	m, err := s.Manifest(name)
+	if err != nil {
+		return nil, err
+	}
+
+	if m.Version > Version {
+		return m, fmt.Errorf("snapshot %q is version %d but we can only restore up to version %d", name, m.Version, Version)
+	}
+
+	if m.Elasticsearch != nil {
+		if s.Elasticsearch == nil {
+			return m, fmt.Errorf("snapshot %q has Elasticsearch indices but Elasticsearch isn't setup", name)
+		}
+
+		if err := s.Elasticsearch.Restore(m.Elasticsearch); err != nil {
+			return m, err
+		}
+	}
+
+	if len(m.Tables) > 0 && s.PostgreSQL == nil {
+		return m, fmt.Errorf("snapshot %q has PostgreSQL tables but PostgreSQL isn't setup", name)
+	}
+
+	for _, t := range m.Tables {
+		f, err := s.Fs.Open(filepath.Join(s.Dir, name, t.File))
+		if err != nil {
+			return m, err
+		}
+
+		err = s.PostgreSQL.Restore(t, f)
+		f.Close()
+
+		if err != nil {
+			return m, err
+		}
+	}
+
+	return m, nil
+}
+
+// Manifest reads the manifest of a snapshot
+func (s *Snapshot) Manifest(name string) (*Manifest, error) {
+	b, err := afero.ReadFile(s.Fs, filepath.Join(s.Dir, name, manifestFile))
+	if err != nil {
+		return nil, err
+	}
+
+	m := &Manifest{}
+	return m, json.Unmarshal(b, m)
+}
+
+// List returns the manifests of our snapshots, oldest first
+func (s *Snapshot) List() ([]*Manifest, error) {
+	manifests := []*Manifest{}
+
+	dirs, err := afero.ReadDir(s.Fs, s.Dir)
+	if err != nil {
+		if os.IsNotExist(err) {
+			err = nil
+		}
+		return manifests, err
+	}
+
+	for _, d := range dirs {
+		if !d.IsDir() {
+			continue
+		}
+
+		m, err := s.Manifest(d.Name())
+		if err != nil {
+			if os.IsNotExist(err) { // not a snapshot or one that didn't finish
+				continue
+			}
+			return manifests, err
+		}
+
+		manifests = append(manifests, m)
+	}
+
+	sort.Slice(manifests, func(i, j int) bool {
+		return manifests[i].Created.Before(manifests[j].Created)
+	})
+
+	return manifests, nil
+}
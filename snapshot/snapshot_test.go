@@ -0,0 +1,99 @@
+package snapshot
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/spf13/afero"
+	sqlmock "gopkg.in/DATA-DOG/go-sqlmock.v2"
+)
+
+func TestSnapshot(t *testing.T) {
+	now = func() time.Time { return time.Date(2018, 2, 6, 20, 34, 12, 0, time.UTC) }
+
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+
+	requests := []string{}
+	ts := mockElasticsearch(t, &requests)
+	defer ts.Close()
+
+	e, err := mockElasticSearch(ts.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	s := &Snapshot{
+		Elasticsearch: e,
+		PostgreSQL:    &PostgreSQL{DB: db},
+		Fs:            afero.NewMemMapFs(),
+		Dir:           "snapshots",
+	}
+
+	if _, err := s.Create("Nightly Backup", nil, nil); err == nil {
+		t.Fatal("expected an error for an invalid name")
+	}
+
+	mockTables(mock)
+	mockDump(mock)
+
+	got, err := s.Create("nightly", []string{"search-*", "images"}, []string{"%wikipedia"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tbl := *enwikipedia
+	tbl.Rows, tbl.File = 2, "enwikipedia.json.gz"
+
+	want := &Manifest{
+		Version:       Version,
+		Name:          "nightly",
+		Created:       now(),
+		Elasticsearch: nightly,
+		Tables:        []*Table{&tbl},
+	}
+
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("got %+v; want %+v", got, want)
+	}
+
+	if _, err := s.Create("nightly", nil, nil); err == nil {
+		t.Fatal("expected an error for an existing snapshot")
+	}
+
+	list, err := s.List()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(list) != 1 || list[0].Name != "nightly" || list[0].Tables[0].Rows != 2 {
+		t.Fatalf("got %+v; want the nightly snapshot", list)
+	}
+
+	mockRestore(mock)
+
+	if _, err := s.Restore("nightly"); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestRestoreNewerVersion(t *testing.T) {
+	s := &Snapshot{Fs: afero.NewMemMapFs(), Dir: "snapshots"}
+
+	m := `{"version": 99, "name": "future"}`
+	if err := afero.WriteFile(s.Fs, "snapshots/future/manifest.json", []byte(m), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := s.Restore("future"); err == nil {
+		t.Fatal("expected an error for a newer snapshot version")
+	}
+}
@@ -3,6 +3,7 @@ package suggest
 import (
 	"context"
 	"fmt"
+	"io"
 
 	"github.com/olivere/elastic"
 )
@@ -89,6 +90,46 @@ func (e *ElasticSearch) Increment(term string) error {
 	return err
 }
 
+// Scrub removes the terms we no longer want and returns how many were removed.
+// A term is the ID of its document.
+func (e *ElasticSearch) Scrub(remove func(term string) bool) (int, error) {
+	svc := e.Client.Scroll(e.Index).
+		Type(e.Type).
+		FetchSource(false).
+		Size(500)
+
+	defer svc.Clear(context.TODO())
+
+	n := 0
+	for {
+		res, err := svc.Do(context.TODO())
+		if err == io.EOF {
+			return n, nil
+		}
+		if err != nil {
+			return n, err
+		}
+
+		bulk := e.Client.Bulk()
+		for _, h := range res.Hits.Hits {
+			if remove(h.Id) {
+				bulk.Add(elastic.NewBulkDeleteRequest().Index(e.Index).Type(e.Type).Id(h.Id))
+			}
+		}
+
+		if bulk.NumberOfActions() == 0 {
+			continue
+		}
+
+		resp, err := bulk.Do(context.TODO())
+		if err != nil {
+			return n, err
+		}
+
+		n += len(resp.Succeeded())
+	}
+}
+
 func (e *ElasticSearch) mapping() string {
 	return fmt.Sprintf(`{
 		"mappings": {
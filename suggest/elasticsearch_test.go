@@ -1,6 +1,7 @@
 package suggest
 
 import (
+	"io/ioutil"
 	"net/http"
 	"net/http/httptest"
 	"reflect"
@@ -464,6 +465,56 @@ func TestSetup(t *testing.T) {
 	}
 }
 
+func TestScrub(t *testing.T) {
+	var scrolled bool
+	var deleted string
+
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		resp := `{"_scroll_id": "abc", "hits": {"total": 3, "hits": []}}`
+		switch {
+		case r.URL.Path == "/_bulk":
+			b, err := ioutil.ReadAll(r.Body)
+			if err != nil {
+				t.Fatal(err)
+			}
+			deleted = string(b)
+			resp = `{"took": 1, "errors": false, "items": [
+				{"delete": {"_index": "test-queries", "_type": "query", "_id": "bob@example.com", "status": 200}}
+			]}`
+		case r.Method == "POST" && !scrolled:
+			scrolled = true
+			resp = `{"_scroll_id": "abc", "hits": {"total": 3, "hits": [
+				{"_index": "test-queries", "_type": "query", "_id": "bob"},
+				{"_index": "test-queries", "_type": "query", "_id": "bob@example.com"},
+				{"_index": "test-queries", "_type": "query", "_id": "brad pitt"}
+			]}}`
+		}
+		if _, err := w.Write([]byte(resp)); err != nil {
+			t.Fatal(err)
+		}
+	}))
+	defer ts.Close()
+
+	e, err := MockService(ts.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	n, err := e.Scrub(PII)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if n != 1 {
+		t.Fatalf("got %d scrubbed; want 1", n)
+	}
+
+	want := `{"delete":{"_index":"test-queries","_type":"query","_id":"bob@example.com"}}` + "\n"
+	if deleted != want {
+		t.Fatalf("got %q; want %q", deleted, want)
+	}
+}
+
 func MockService(url string) (*ElasticSearch, error) {
 	client, err := elastic.NewSimpleClient(elastic.SetURL(url))
 	if err != nil {
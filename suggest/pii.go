@@ -0,0 +1,59 @@
+package suggest
+
+import (
+	"regexp"
+	"time"
+
+	"github.com/jivesearch/jivesearch/log"
+)
+
+// pii are patterns of personal information people type into the search box that
+// we never want to suggest to someone else, e.g. "john.doe@example.com password"
+var pii = []*regexp.Regexp{
+	// emails
+	regexp.MustCompile(`[\w.+-]+@[\w-]+(?:\.[\w-]+)+`),
+	// US social security numbers
+	regexp.MustCompile(`\b\d{3}[- .]\d{2}[- .]\d{4}\b`),
+	// phone numbers, e.g. (555) 867-5309 and +44 20 7946 0958
+	regexp.MustCompile(`(?:^|[^\w])(?:\+?\d{1,3}[- .]?)?\(?\d{3}\)?[- .]?\d{3}[- .]\d{4}\b`),
+	regexp.MustCompile(`\+\d[\d -]{7,}\d`),
+	// account, card, tracking & id numbers
+	regexp.MustCompile(`\d(?:[- ]?\d){8,}`),
+	// street addresses
+	regexp.MustCompile(`(?i)\b\d{1,6} (?:[a-z]+ ){1,3}(?:st|street|ave|avenue|rd|road|blvd|boulevard|dr|drive|ln|lane|ct|court|way|pl|place|ter|terrace|pkwy|parkway|cir|circle)\b`),
+}
+
+// PII indicates if a query looks like it holds personal information,
+// such as an email address, phone number or street address
+func PII(q string) bool {
+	for _, re := range pii {
+		if re.MatchString(q) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// Scrubber removes queries with personal information that made it into our store,
+// e.g. from before we filtered them or as our patterns improve
+type Scrubber struct {
+	Suggester
+	Interval time.Duration
+}
+
+// Run scrubs our store every Interval. It doesn't return.
+func (s *Scrubber) Run() {
+	for {
+		n, err := s.Scrub(PII)
+		if err != nil {
+			log.Info.Printf("unable to scrub autocomplete: %v\n", err)
+		}
+
+		if n > 0 {
+			log.Info.Printf("scrubbed %d queries with personal information from autocomplete\n", n)
+		}
+
+		time.Sleep(s.Interval)
+	}
+}
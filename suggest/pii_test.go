@@ -0,0 +1,36 @@
+package suggest
+
+import (
+	"testing"
+)
+
+func TestPII(t *testing.T) {
+	for _, c := range []struct {
+		q    string
+		want bool
+	}{
+		{"john.doe@example.com", true},
+		{"reset password for jane+news@mail.co.uk", true},
+		{"123-45-6789", true},
+		{"call (555) 867-5309", true},
+		{"555.867.5309 who called me", true},
+		{"+44 20 7946 0958", true},
+		{"4111 1111 1111 1111", true},
+		{"tracking 1Z999AA10123456784", true},
+		{"1600 Pennsylvania Ave", true},
+		{"42 wallaby way sydney", true},
+		{"brad pitt", false},
+		{"iphone 11 pro max", false},
+		{"2019 world series", false},
+		{"catch-22", false},
+		{"10 downing", false},
+		{"what is 25 * 4", false},
+		{"@jivesearch", false},
+	} {
+		t.Run(c.q, func(t *testing.T) {
+			if got := PII(c.q); got != c.want {
+				t.Fatalf("got %t; want %t", got, c.want)
+			}
+		})
+	}
+}
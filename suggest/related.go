@@ -0,0 +1,119 @@
+package suggest
+
+import (
+	"sort"
+	"strings"
+)
+
+// stopWords are too common to say anything about how queries are related
+var stopWords = map[string]struct{}{
+	"a": {}, "an": {}, "and": {}, "are": {}, "as": {}, "at": {}, "be": {}, "by": {}, "for": {}, "from": {},
+	"how": {}, "in": {}, "is": {}, "it": {}, "of": {}, "on": {}, "or": {}, "the": {}, "to": {}, "was": {},
+	"what": {}, "when": {}, "where": {}, "who": {}, "why": {}, "with": {},
+}
+
+// Related finds queries in the query log related to q.
+// Queries that expand on q ("marie curie" -> "marie curie death") rank first,
+// followed by queries that co-occur with the most of q's terms.
+func Related(s Suggester, q string, size int) ([]string, error) {
+	q = strings.ToLower(strings.Join(strings.Fields(q), " "))
+	terms := strings.Fields(q)
+
+	type candidate struct {
+		query string
+		score int
+		order int
+	}
+
+	candidates := map[string]*candidate{}
+
+	add := func(query string, score int) {
+		query = strings.ToLower(strings.TrimSpace(query))
+		if query == "" || query == q || Naughty(query) {
+			return
+		}
+
+		if c, ok := candidates[query]; ok {
+			if score > c.score {
+				c.score = score
+			}
+			return
+		}
+
+		candidates[query] = &candidate{query, score, len(candidates)}
+	}
+
+	// term expansion
+	res, err := s.Completion(q+" ", size)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, sug := range res.Suggestions {
+		add(sug, len(terms)+1)
+	}
+
+	// co-occurrence
+	if len(terms) > 1 {
+		for _, t := range terms {
+			if _, ok := stopWords[t]; ok {
+				continue
+			}
+
+			res, err := s.Completion(t, size)
+			if err != nil {
+				return nil, err
+			}
+
+			for _, sug := range res.Suggestions {
+				if n := shared(terms, sug); n > 0 {
+					add(sug, n)
+				}
+			}
+		}
+	}
+
+	sorted := []*candidate{}
+	for _, c := range candidates {
+		sorted = append(sorted, c)
+	}
+
+	sort.Slice(sorted, func(i, j int) bool {
+		if sorted[i].score != sorted[j].score {
+			return sorted[i].score > sorted[j].score
+		}
+		return sorted[i].order < sorted[j].order
+	})
+
+	related := []string{}
+	for _, c := range sorted {
+		if len(related) == size {
+			break
+		}
+		related = append(related, c.query)
+	}
+
+	return related, nil
+}
+
+// shared counts the terms that appear in query, ignoring stop words
+func shared(terms []string, query string) int {
+	var n int
+
+	words := map[string]struct{}{}
+	for _, w := range strings.Fields(strings.ToLower(query)) {
+		words[w] = struct{}{}
+	}
+
+	for _, t := range terms {
+		if _, ok := stopWords[t]; ok {
+			continue
+		}
+
+		if _, ok := words[t]; ok {
+			n++
+		}
+	}
+
+	return n
+}
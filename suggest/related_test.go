@@ -0,0 +1,56 @@
+package suggest
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestRelated(t *testing.T) {
+	if err := createMockFile("naughty.txt"); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := NewNaughty("naughty.txt"); err != nil {
+		t.Fatal(err)
+	}
+
+	for _, c := range []struct {
+		q    string
+		want []string
+	}{
+		{"Marie  Curie", []string{
+			"marie curie death", "marie curie quotes", "marie curie institute", "pierre and marie curie", "marie antoinette",
+		}},
+		{"nothing here", []string{}},
+	} {
+		t.Run(c.q, func(t *testing.T) {
+			got, err := Related(&mockRelatedSuggester{}, c.q, 5)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if !reflect.DeepEqual(got, c.want) {
+				t.Fatalf("got %q; want %q", got, c.want)
+			}
+		})
+	}
+}
+
+type mockRelatedSuggester struct {
+	Simple
+}
+
+func (m *mockRelatedSuggester) Completion(q string, size int) (Results, error) {
+	res := Results{}
+
+	switch q {
+	case "marie curie ":
+		res.Suggestions = []string{"marie curie death", "marie curie quotes", "marie curie naughty pics"}
+	case "marie":
+		res.Suggestions = []string{"marie antoinette", "marie curie", "marie curie institute", "marie kondo"}
+	case "curie":
+		res.Suggestions = []string{"curie temperature", "pierre and marie curie", "marie curie death"}
+	}
+
+	return res, nil
+}
@@ -41,6 +41,35 @@ func (s *Simple) Increment(term string) error {
 	return nil
 }
 
+// Scrub removes the terms we no longer want and returns how many were removed
+func (s *Simple) Scrub(remove func(term string) bool) (int, error) {
+	keep := []string{}
+	for _, w := range s.all {
+		if !remove(w) {
+			keep = append(keep, w)
+		}
+	}
+
+	n := len(s.all) - len(keep)
+	if n == 0 {
+		return 0, nil
+	}
+
+	// our index can't delete so we rebuild it
+	if err := s.Setup(); err != nil {
+		return 0, err
+	}
+
+	s.all = nil
+	for _, w := range keep {
+		if err := s.Insert(w); err != nil {
+			return 0, err
+		}
+	}
+
+	return n, nil
+}
+
 // Setup creates a completion index
 func (s *Simple) Setup() error {
 	s.db = ferret.New([]string{}, []string{}, []interface{}{}, func(s string) []byte { return []byte(s) })
@@ -71,3 +71,35 @@ func TestSimpleCompletion(t *testing.T) {
 		})
 	}
 }
+
+func TestSimpleScrub(t *testing.T) {
+	ms := &Simple{}
+	if err := ms.Setup(); err != nil {
+		t.Fatal(err)
+	}
+
+	for _, term := range []string{"bob", "bob@example.com", "brad pitt", "call bob 555-867-5309"} {
+		if err := ms.Insert(term); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	n, err := ms.Scrub(PII)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if n != 2 {
+		t.Fatalf("got %d scrubbed; want 2", n)
+	}
+
+	got, err := ms.Completion("bob", 10)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := Results{Suggestions: []string{"bob"}}
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("got %+v; want %+v", got, want)
+	}
+}
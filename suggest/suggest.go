@@ -18,6 +18,7 @@ type Suggester interface {
 	Insert(q string) error
 	Increment(q string) error
 	Completion(q string, size int) (Results, error)
+	Scrub(remove func(q string) bool) (int, error)
 	//phrase(q string) Results //  TODO: "Did you mean?"
 }
 